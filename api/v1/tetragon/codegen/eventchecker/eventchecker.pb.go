@@ -11,6 +11,7 @@ import (
 	fmt "fmt"
 	tetragon "github.com/cilium/tetragon/api/v1/tetragon"
 	bytesmatcher "github.com/cilium/tetragon/pkg/matchers/bytesmatcher"
+	durationmatcher "github.com/cilium/tetragon/pkg/matchers/durationmatcher"
 	listmatcher "github.com/cilium/tetragon/pkg/matchers/listmatcher"
 	stringmatcher "github.com/cilium/tetragon/pkg/matchers/stringmatcher"
 	timestampmatcher "github.com/cilium/tetragon/pkg/matchers/timestampmatcher"
@@ -270,16 +271,38 @@ func CheckerFromEvent(event Event) (EventChecker, error) {
 		return NewProcessExitChecker("").FromProcessExit(ev), nil
 	case *tetragon.ProcessKprobe:
 		return NewProcessKprobeChecker("").FromProcessKprobe(ev), nil
+	case *tetragon.ProcessKprobeCount:
+		return NewProcessKprobeCountChecker("").FromProcessKprobeCount(ev), nil
+	case *tetragon.MiningSuspected:
+		return NewMiningSuspectedChecker("").FromMiningSuspected(ev), nil
+	case *tetragon.SshConnection:
+		return NewSshConnectionChecker("").FromSshConnection(ev), nil
 	case *tetragon.ProcessTracepoint:
 		return NewProcessTracepointChecker("").FromProcessTracepoint(ev), nil
 	case *tetragon.ProcessUprobe:
 		return NewProcessUprobeChecker("").FromProcessUprobe(ev), nil
+	case *tetragon.ProcessLsm:
+		return NewProcessLsmChecker("").FromProcessLsm(ev), nil
+	case *tetragon.ProcessPerfEvent:
+		return NewProcessPerfEventChecker("").FromProcessPerfEvent(ev), nil
 	case *tetragon.Test:
 		return NewTestChecker("").FromTest(ev), nil
 	case *tetragon.ProcessLoader:
 		return NewProcessLoaderChecker("").FromProcessLoader(ev), nil
 	case *tetragon.RateLimitInfo:
 		return NewRateLimitInfoChecker("").FromRateLimitInfo(ev), nil
+	case *tetragon.ExportSinkHealth:
+		return NewExportSinkHealthChecker("").FromExportSinkHealth(ev), nil
+	case *tetragon.RingBufferDrops:
+		return NewRingBufferDropsChecker("").FromRingBufferDrops(ev), nil
+	case *tetragon.LostEvent:
+		return NewLostEventChecker("").FromLostEvent(ev), nil
+	case *tetragon.MapFill:
+		return NewMapFillChecker("").FromMapFill(ev), nil
+	case *tetragon.ConfigSnapshot:
+		return NewConfigSnapshotChecker("").FromConfigSnapshot(ev), nil
+	case *tetragon.EventAnnotation:
+		return NewEventAnnotationChecker("").FromEventAnnotation(ev), nil
 
 	default:
 		return nil, fmt.Errorf("Unhandled event type %T", event)
@@ -330,16 +353,38 @@ func EventFromResponse(response *tetragon.GetEventsResponse) (Event, error) {
 		return ev.ProcessExit, nil
 	case *tetragon.GetEventsResponse_ProcessKprobe:
 		return ev.ProcessKprobe, nil
+	case *tetragon.GetEventsResponse_ProcessKprobeCount:
+		return ev.ProcessKprobeCount, nil
+	case *tetragon.GetEventsResponse_MiningSuspected:
+		return ev.MiningSuspected, nil
+	case *tetragon.GetEventsResponse_SshConnection:
+		return ev.SshConnection, nil
 	case *tetragon.GetEventsResponse_ProcessTracepoint:
 		return ev.ProcessTracepoint, nil
 	case *tetragon.GetEventsResponse_ProcessUprobe:
 		return ev.ProcessUprobe, nil
+	case *tetragon.GetEventsResponse_ProcessLsm:
+		return ev.ProcessLsm, nil
+	case *tetragon.GetEventsResponse_ProcessPerfEvent:
+		return ev.ProcessPerfEvent, nil
 	case *tetragon.GetEventsResponse_Test:
 		return ev.Test, nil
 	case *tetragon.GetEventsResponse_ProcessLoader:
 		return ev.ProcessLoader, nil
 	case *tetragon.GetEventsResponse_RateLimitInfo:
 		return ev.RateLimitInfo, nil
+	case *tetragon.GetEventsResponse_ExportSinkHealth:
+		return ev.ExportSinkHealth, nil
+	case *tetragon.GetEventsResponse_RingBufferDrops:
+		return ev.RingBufferDrops, nil
+	case *tetragon.GetEventsResponse_LostEvent:
+		return ev.LostEvent, nil
+	case *tetragon.GetEventsResponse_MapFill:
+		return ev.MapFill, nil
+	case *tetragon.GetEventsResponse_ConfigSnapshot:
+		return ev.ConfigSnapshot, nil
+	case *tetragon.GetEventsResponse_EventAnnotation:
+		return ev.EventAnnotation, nil
 
 	default:
 		return nil, fmt.Errorf("Unknown event type %T", response.Event)
@@ -696,15 +741,21 @@ func (checker *ProcessExitChecker) FromProcessExit(event *tetragon.ProcessExit)
 
 // ProcessKprobeChecker implements a checker struct to check a ProcessKprobe event
 type ProcessKprobeChecker struct {
-	CheckerName  string                       `json:"checkerName"`
-	Process      *ProcessChecker              `json:"process,omitempty"`
-	Parent       *ProcessChecker              `json:"parent,omitempty"`
-	FunctionName *stringmatcher.StringMatcher `json:"functionName,omitempty"`
-	Args         *KprobeArgumentListMatcher   `json:"args,omitempty"`
-	Return       *KprobeArgumentChecker       `json:"return,omitempty"`
-	Action       *KprobeActionChecker         `json:"action,omitempty"`
-	StackTrace   *StackTraceEntryListMatcher  `json:"stackTrace,omitempty"`
-	PolicyName   *stringmatcher.StringMatcher `json:"policyName,omitempty"`
+	CheckerName    string                           `json:"checkerName"`
+	Process        *ProcessChecker                  `json:"process,omitempty"`
+	Parent         *ProcessChecker                  `json:"parent,omitempty"`
+	FunctionName   *stringmatcher.StringMatcher     `json:"functionName,omitempty"`
+	Args           *KprobeArgumentListMatcher       `json:"args,omitempty"`
+	Return         *KprobeArgumentChecker           `json:"return,omitempty"`
+	Action         *KprobeActionChecker             `json:"action,omitempty"`
+	StackTrace     *StackTraceEntryListMatcher      `json:"stackTrace,omitempty"`
+	PolicyName     *stringmatcher.StringMatcher     `json:"policyName,omitempty"`
+	UserStackTrace *StackTraceEntryListMatcher      `json:"userStackTrace,omitempty"`
+	Compat         *bool                            `json:"compat,omitempty"`
+	Latency        *durationmatcher.DurationMatcher `json:"latency,omitempty"`
+	ActionLatency  *durationmatcher.DurationMatcher `json:"actionLatency,omitempty"`
+	AuditAction    *AuditActionChecker              `json:"auditAction,omitempty"`
+	PackageManager *stringmatcher.StringMatcher     `json:"packageManager,omitempty"`
 }
 
 // CheckEvent checks a single event and implements the EventChecker interface
@@ -786,6 +837,36 @@ func (checker *ProcessKprobeChecker) Check(event *tetragon.ProcessKprobe) error
 				return fmt.Errorf("PolicyName check failed: %w", err)
 			}
 		}
+		if checker.UserStackTrace != nil {
+			if err := checker.UserStackTrace.Check(event.UserStackTrace); err != nil {
+				return fmt.Errorf("UserStackTrace check failed: %w", err)
+			}
+		}
+		if checker.Compat != nil {
+			if *checker.Compat != event.Compat {
+				return fmt.Errorf("Compat has value %t which does not match expected value %t", event.Compat, *checker.Compat)
+			}
+		}
+		if checker.Latency != nil {
+			if err := checker.Latency.Match(event.Latency); err != nil {
+				return fmt.Errorf("Latency check failed: %w", err)
+			}
+		}
+		if checker.ActionLatency != nil {
+			if err := checker.ActionLatency.Match(event.ActionLatency); err != nil {
+				return fmt.Errorf("ActionLatency check failed: %w", err)
+			}
+		}
+		if checker.AuditAction != nil {
+			if err := checker.AuditAction.Check(event.AuditAction); err != nil {
+				return fmt.Errorf("AuditAction check failed: %w", err)
+			}
+		}
+		if checker.PackageManager != nil {
+			if err := checker.PackageManager.Match(event.PackageManager); err != nil {
+				return fmt.Errorf("PackageManager check failed: %w", err)
+			}
+		}
 		return nil
 	}
 	if err := fieldChecks(); err != nil {
@@ -843,6 +924,42 @@ func (checker *ProcessKprobeChecker) WithPolicyName(check *stringmatcher.StringM
 	return checker
 }
 
+// WithUserStackTrace adds a UserStackTrace check to the ProcessKprobeChecker
+func (checker *ProcessKprobeChecker) WithUserStackTrace(check *StackTraceEntryListMatcher) *ProcessKprobeChecker {
+	checker.UserStackTrace = check
+	return checker
+}
+
+// WithCompat adds a Compat check to the ProcessKprobeChecker
+func (checker *ProcessKprobeChecker) WithCompat(check bool) *ProcessKprobeChecker {
+	checker.Compat = &check
+	return checker
+}
+
+// WithLatency adds a Latency check to the ProcessKprobeChecker
+func (checker *ProcessKprobeChecker) WithLatency(check *durationmatcher.DurationMatcher) *ProcessKprobeChecker {
+	checker.Latency = check
+	return checker
+}
+
+// WithActionLatency adds a ActionLatency check to the ProcessKprobeChecker
+func (checker *ProcessKprobeChecker) WithActionLatency(check *durationmatcher.DurationMatcher) *ProcessKprobeChecker {
+	checker.ActionLatency = check
+	return checker
+}
+
+// WithAuditAction adds a AuditAction check to the ProcessKprobeChecker
+func (checker *ProcessKprobeChecker) WithAuditAction(check *AuditActionChecker) *ProcessKprobeChecker {
+	checker.AuditAction = check
+	return checker
+}
+
+// WithPackageManager adds a PackageManager check to the ProcessKprobeChecker
+func (checker *ProcessKprobeChecker) WithPackageManager(check *stringmatcher.StringMatcher) *ProcessKprobeChecker {
+	checker.PackageManager = check
+	return checker
+}
+
 //FromProcessKprobe populates the ProcessKprobeChecker using data from a ProcessKprobe event
 func (checker *ProcessKprobeChecker) FromProcessKprobe(event *tetragon.ProcessKprobe) *ProcessKprobeChecker {
 	if event == nil {
@@ -886,6 +1003,31 @@ func (checker *ProcessKprobeChecker) FromProcessKprobe(event *tetragon.ProcessKp
 		checker.StackTrace = lm
 	}
 	checker.PolicyName = stringmatcher.Full(event.PolicyName)
+	{
+		var checks []*StackTraceEntryChecker
+		for _, check := range event.UserStackTrace {
+			var convertedCheck *StackTraceEntryChecker
+			if check != nil {
+				convertedCheck = NewStackTraceEntryChecker().FromStackTraceEntry(check)
+			}
+			checks = append(checks, convertedCheck)
+		}
+		lm := NewStackTraceEntryListMatcher().WithOperator(listmatcher.Ordered).
+			WithValues(checks...)
+		checker.UserStackTrace = lm
+	}
+	{
+		val := event.Compat
+		checker.Compat = &val
+	}
+	// NB: We don't want to match durations for now
+	checker.Latency = nil
+	// NB: We don't want to match durations for now
+	checker.ActionLatency = nil
+	if event.AuditAction != nil {
+		checker.AuditAction = NewAuditActionChecker().FromAuditAction(event.AuditAction)
+	}
+	checker.PackageManager = stringmatcher.Full(event.PackageManager)
 	return checker
 }
 
@@ -1089,28 +1231,27 @@ nextCheck:
 	return nil
 }
 
-// ProcessTracepointChecker implements a checker struct to check a ProcessTracepoint event
-type ProcessTracepointChecker struct {
-	CheckerName string                       `json:"checkerName"`
-	Process     *ProcessChecker              `json:"process,omitempty"`
-	Parent      *ProcessChecker              `json:"parent,omitempty"`
-	Subsys      *stringmatcher.StringMatcher `json:"subsys,omitempty"`
-	Event       *stringmatcher.StringMatcher `json:"event,omitempty"`
-	Args        *KprobeArgumentListMatcher   `json:"args,omitempty"`
-	PolicyName  *stringmatcher.StringMatcher `json:"policyName,omitempty"`
-	Action      *KprobeActionChecker         `json:"action,omitempty"`
+// ProcessKprobeCountChecker implements a checker struct to check a ProcessKprobeCount event
+type ProcessKprobeCountChecker struct {
+	CheckerName  string                           `json:"checkerName"`
+	Process      *ProcessChecker                  `json:"process,omitempty"`
+	Parent       *ProcessChecker                  `json:"parent,omitempty"`
+	FunctionName *stringmatcher.StringMatcher     `json:"functionName,omitempty"`
+	PolicyName   *stringmatcher.StringMatcher     `json:"policyName,omitempty"`
+	Count        *uint64                          `json:"count,omitempty"`
+	Window       *durationmatcher.DurationMatcher `json:"window,omitempty"`
 }
 
 // CheckEvent checks a single event and implements the EventChecker interface
-func (checker *ProcessTracepointChecker) CheckEvent(event Event) error {
-	if ev, ok := event.(*tetragon.ProcessTracepoint); ok {
+func (checker *ProcessKprobeCountChecker) CheckEvent(event Event) error {
+	if ev, ok := event.(*tetragon.ProcessKprobeCount); ok {
 		return checker.Check(ev)
 	}
-	return fmt.Errorf("%s: %T is not a ProcessTracepoint event", CheckerLogPrefix(checker), event)
+	return fmt.Errorf("%s: %T is not a ProcessKprobeCount event", CheckerLogPrefix(checker), event)
 }
 
 // CheckResponse checks a single gRPC response and implements the EventChecker interface
-func (checker *ProcessTracepointChecker) CheckResponse(response *tetragon.GetEventsResponse) error {
+func (checker *ProcessKprobeCountChecker) CheckResponse(response *tetragon.GetEventsResponse) error {
 	event, err := EventFromResponse(response)
 	if err != nil {
 		return err
@@ -1118,25 +1259,25 @@ func (checker *ProcessTracepointChecker) CheckResponse(response *tetragon.GetEve
 	return checker.CheckEvent(event)
 }
 
-// NewProcessTracepointChecker creates a new ProcessTracepointChecker
-func NewProcessTracepointChecker(name string) *ProcessTracepointChecker {
-	return &ProcessTracepointChecker{CheckerName: name}
+// NewProcessKprobeCountChecker creates a new ProcessKprobeCountChecker
+func NewProcessKprobeCountChecker(name string) *ProcessKprobeCountChecker {
+	return &ProcessKprobeCountChecker{CheckerName: name}
 }
 
 // Get the name associated with the checker
-func (checker *ProcessTracepointChecker) GetCheckerName() string {
+func (checker *ProcessKprobeCountChecker) GetCheckerName() string {
 	return checker.CheckerName
 }
 
 // Get the type of the checker as a string
-func (checker *ProcessTracepointChecker) GetCheckerType() string {
-	return "ProcessTracepointChecker"
+func (checker *ProcessKprobeCountChecker) GetCheckerType() string {
+	return "ProcessKprobeCountChecker"
 }
 
-// Check checks a ProcessTracepoint event
-func (checker *ProcessTracepointChecker) Check(event *tetragon.ProcessTracepoint) error {
+// Check checks a ProcessKprobeCount event
+func (checker *ProcessKprobeCountChecker) Check(event *tetragon.ProcessKprobeCount) error {
 	if event == nil {
-		return fmt.Errorf("%s: ProcessTracepoint event is nil", CheckerLogPrefix(checker))
+		return fmt.Errorf("%s: ProcessKprobeCount event is nil", CheckerLogPrefix(checker))
 	}
 
 	fieldChecks := func() error {
@@ -1150,19 +1291,9 @@ func (checker *ProcessTracepointChecker) Check(event *tetragon.ProcessTracepoint
 				return fmt.Errorf("Parent check failed: %w", err)
 			}
 		}
-		if checker.Subsys != nil {
-			if err := checker.Subsys.Match(event.Subsys); err != nil {
-				return fmt.Errorf("Subsys check failed: %w", err)
-			}
-		}
-		if checker.Event != nil {
-			if err := checker.Event.Match(event.Event); err != nil {
-				return fmt.Errorf("Event check failed: %w", err)
-			}
-		}
-		if checker.Args != nil {
-			if err := checker.Args.Check(event.Args); err != nil {
-				return fmt.Errorf("Args check failed: %w", err)
+		if checker.FunctionName != nil {
+			if err := checker.FunctionName.Match(event.FunctionName); err != nil {
+				return fmt.Errorf("FunctionName check failed: %w", err)
 			}
 		}
 		if checker.PolicyName != nil {
@@ -1170,9 +1301,14 @@ func (checker *ProcessTracepointChecker) Check(event *tetragon.ProcessTracepoint
 				return fmt.Errorf("PolicyName check failed: %w", err)
 			}
 		}
-		if checker.Action != nil {
-			if err := checker.Action.Check(&event.Action); err != nil {
-				return fmt.Errorf("Action check failed: %w", err)
+		if checker.Count != nil {
+			if *checker.Count != event.Count {
+				return fmt.Errorf("Count has value %d which does not match expected value %d", event.Count, *checker.Count)
+			}
+		}
+		if checker.Window != nil {
+			if err := checker.Window.Match(event.Window); err != nil {
+				return fmt.Errorf("Window check failed: %w", err)
 			}
 		}
 		return nil
@@ -1183,51 +1319,44 @@ func (checker *ProcessTracepointChecker) Check(event *tetragon.ProcessTracepoint
 	return nil
 }
 
-// WithProcess adds a Process check to the ProcessTracepointChecker
-func (checker *ProcessTracepointChecker) WithProcess(check *ProcessChecker) *ProcessTracepointChecker {
+// WithProcess adds a Process check to the ProcessKprobeCountChecker
+func (checker *ProcessKprobeCountChecker) WithProcess(check *ProcessChecker) *ProcessKprobeCountChecker {
 	checker.Process = check
 	return checker
 }
 
-// WithParent adds a Parent check to the ProcessTracepointChecker
-func (checker *ProcessTracepointChecker) WithParent(check *ProcessChecker) *ProcessTracepointChecker {
+// WithParent adds a Parent check to the ProcessKprobeCountChecker
+func (checker *ProcessKprobeCountChecker) WithParent(check *ProcessChecker) *ProcessKprobeCountChecker {
 	checker.Parent = check
 	return checker
 }
 
-// WithSubsys adds a Subsys check to the ProcessTracepointChecker
-func (checker *ProcessTracepointChecker) WithSubsys(check *stringmatcher.StringMatcher) *ProcessTracepointChecker {
-	checker.Subsys = check
-	return checker
-}
-
-// WithEvent adds a Event check to the ProcessTracepointChecker
-func (checker *ProcessTracepointChecker) WithEvent(check *stringmatcher.StringMatcher) *ProcessTracepointChecker {
-	checker.Event = check
+// WithFunctionName adds a FunctionName check to the ProcessKprobeCountChecker
+func (checker *ProcessKprobeCountChecker) WithFunctionName(check *stringmatcher.StringMatcher) *ProcessKprobeCountChecker {
+	checker.FunctionName = check
 	return checker
 }
 
-// WithArgs adds a Args check to the ProcessTracepointChecker
-func (checker *ProcessTracepointChecker) WithArgs(check *KprobeArgumentListMatcher) *ProcessTracepointChecker {
-	checker.Args = check
+// WithPolicyName adds a PolicyName check to the ProcessKprobeCountChecker
+func (checker *ProcessKprobeCountChecker) WithPolicyName(check *stringmatcher.StringMatcher) *ProcessKprobeCountChecker {
+	checker.PolicyName = check
 	return checker
 }
 
-// WithPolicyName adds a PolicyName check to the ProcessTracepointChecker
-func (checker *ProcessTracepointChecker) WithPolicyName(check *stringmatcher.StringMatcher) *ProcessTracepointChecker {
-	checker.PolicyName = check
+// WithCount adds a Count check to the ProcessKprobeCountChecker
+func (checker *ProcessKprobeCountChecker) WithCount(check uint64) *ProcessKprobeCountChecker {
+	checker.Count = &check
 	return checker
 }
 
-// WithAction adds a Action check to the ProcessTracepointChecker
-func (checker *ProcessTracepointChecker) WithAction(check tetragon.KprobeAction) *ProcessTracepointChecker {
-	wrappedCheck := KprobeActionChecker(check)
-	checker.Action = &wrappedCheck
+// WithWindow adds a Window check to the ProcessKprobeCountChecker
+func (checker *ProcessKprobeCountChecker) WithWindow(check *durationmatcher.DurationMatcher) *ProcessKprobeCountChecker {
+	checker.Window = check
 	return checker
 }
 
-//FromProcessTracepoint populates the ProcessTracepointChecker using data from a ProcessTracepoint event
-func (checker *ProcessTracepointChecker) FromProcessTracepoint(event *tetragon.ProcessTracepoint) *ProcessTracepointChecker {
+//FromProcessKprobeCount populates the ProcessKprobeCountChecker using data from a ProcessKprobeCount event
+func (checker *ProcessKprobeCountChecker) FromProcessKprobeCount(event *tetragon.ProcessKprobeCount) *ProcessKprobeCountChecker {
 	if event == nil {
 		return checker
 	}
@@ -1237,46 +1366,36 @@ func (checker *ProcessTracepointChecker) FromProcessTracepoint(event *tetragon.P
 	if event.Parent != nil {
 		checker.Parent = NewProcessChecker().FromProcess(event.Parent)
 	}
-	checker.Subsys = stringmatcher.Full(event.Subsys)
-	checker.Event = stringmatcher.Full(event.Event)
+	checker.FunctionName = stringmatcher.Full(event.FunctionName)
+	checker.PolicyName = stringmatcher.Full(event.PolicyName)
 	{
-		var checks []*KprobeArgumentChecker
-		for _, check := range event.Args {
-			var convertedCheck *KprobeArgumentChecker
-			if check != nil {
-				convertedCheck = NewKprobeArgumentChecker().FromKprobeArgument(check)
-			}
-			checks = append(checks, convertedCheck)
-		}
-		lm := NewKprobeArgumentListMatcher().WithOperator(listmatcher.Ordered).
-			WithValues(checks...)
-		checker.Args = lm
+		val := event.Count
+		checker.Count = &val
 	}
-	checker.PolicyName = stringmatcher.Full(event.PolicyName)
-	checker.Action = NewKprobeActionChecker(event.Action)
+	// NB: We don't want to match durations for now
+	checker.Window = nil
 	return checker
 }
 
-// ProcessUprobeChecker implements a checker struct to check a ProcessUprobe event
-type ProcessUprobeChecker struct {
-	CheckerName string                       `json:"checkerName"`
-	Process     *ProcessChecker              `json:"process,omitempty"`
-	Parent      *ProcessChecker              `json:"parent,omitempty"`
-	Path        *stringmatcher.StringMatcher `json:"path,omitempty"`
-	Symbol      *stringmatcher.StringMatcher `json:"symbol,omitempty"`
-	PolicyName  *stringmatcher.StringMatcher `json:"policyName,omitempty"`
+// MiningSuspectedChecker implements a checker struct to check a MiningSuspected event
+type MiningSuspectedChecker struct {
+	CheckerName string                   `json:"checkerName"`
+	Process     *ProcessChecker          `json:"process,omitempty"`
+	Parent      *ProcessChecker          `json:"parent,omitempty"`
+	Confidence  *float64                 `json:"confidence,omitempty"`
+	Signals     *MiningSignalListMatcher `json:"signals,omitempty"`
 }
 
 // CheckEvent checks a single event and implements the EventChecker interface
-func (checker *ProcessUprobeChecker) CheckEvent(event Event) error {
-	if ev, ok := event.(*tetragon.ProcessUprobe); ok {
+func (checker *MiningSuspectedChecker) CheckEvent(event Event) error {
+	if ev, ok := event.(*tetragon.MiningSuspected); ok {
 		return checker.Check(ev)
 	}
-	return fmt.Errorf("%s: %T is not a ProcessUprobe event", CheckerLogPrefix(checker), event)
+	return fmt.Errorf("%s: %T is not a MiningSuspected event", CheckerLogPrefix(checker), event)
 }
 
 // CheckResponse checks a single gRPC response and implements the EventChecker interface
-func (checker *ProcessUprobeChecker) CheckResponse(response *tetragon.GetEventsResponse) error {
+func (checker *MiningSuspectedChecker) CheckResponse(response *tetragon.GetEventsResponse) error {
 	event, err := EventFromResponse(response)
 	if err != nil {
 		return err
@@ -1284,25 +1403,25 @@ func (checker *ProcessUprobeChecker) CheckResponse(response *tetragon.GetEventsR
 	return checker.CheckEvent(event)
 }
 
-// NewProcessUprobeChecker creates a new ProcessUprobeChecker
-func NewProcessUprobeChecker(name string) *ProcessUprobeChecker {
-	return &ProcessUprobeChecker{CheckerName: name}
+// NewMiningSuspectedChecker creates a new MiningSuspectedChecker
+func NewMiningSuspectedChecker(name string) *MiningSuspectedChecker {
+	return &MiningSuspectedChecker{CheckerName: name}
 }
 
 // Get the name associated with the checker
-func (checker *ProcessUprobeChecker) GetCheckerName() string {
+func (checker *MiningSuspectedChecker) GetCheckerName() string {
 	return checker.CheckerName
 }
 
 // Get the type of the checker as a string
-func (checker *ProcessUprobeChecker) GetCheckerType() string {
-	return "ProcessUprobeChecker"
+func (checker *MiningSuspectedChecker) GetCheckerType() string {
+	return "MiningSuspectedChecker"
 }
 
-// Check checks a ProcessUprobe event
-func (checker *ProcessUprobeChecker) Check(event *tetragon.ProcessUprobe) error {
+// Check checks a MiningSuspected event
+func (checker *MiningSuspectedChecker) Check(event *tetragon.MiningSuspected) error {
 	if event == nil {
-		return fmt.Errorf("%s: ProcessUprobe event is nil", CheckerLogPrefix(checker))
+		return fmt.Errorf("%s: MiningSuspected event is nil", CheckerLogPrefix(checker))
 	}
 
 	fieldChecks := func() error {
@@ -1316,19 +1435,14 @@ func (checker *ProcessUprobeChecker) Check(event *tetragon.ProcessUprobe) error
 				return fmt.Errorf("Parent check failed: %w", err)
 			}
 		}
-		if checker.Path != nil {
-			if err := checker.Path.Match(event.Path); err != nil {
-				return fmt.Errorf("Path check failed: %w", err)
-			}
-		}
-		if checker.Symbol != nil {
-			if err := checker.Symbol.Match(event.Symbol); err != nil {
-				return fmt.Errorf("Symbol check failed: %w", err)
+		if checker.Confidence != nil {
+			if *checker.Confidence != event.Confidence {
+				return fmt.Errorf("Confidence has value %f which does not match expected value %f", event.Confidence, *checker.Confidence)
 			}
 		}
-		if checker.PolicyName != nil {
-			if err := checker.PolicyName.Match(event.PolicyName); err != nil {
-				return fmt.Errorf("PolicyName check failed: %w", err)
+		if checker.Signals != nil {
+			if err := checker.Signals.Check(event.Signals); err != nil {
+				return fmt.Errorf("Signals check failed: %w", err)
 			}
 		}
 		return nil
@@ -1339,38 +1453,32 @@ func (checker *ProcessUprobeChecker) Check(event *tetragon.ProcessUprobe) error
 	return nil
 }
 
-// WithProcess adds a Process check to the ProcessUprobeChecker
-func (checker *ProcessUprobeChecker) WithProcess(check *ProcessChecker) *ProcessUprobeChecker {
+// WithProcess adds a Process check to the MiningSuspectedChecker
+func (checker *MiningSuspectedChecker) WithProcess(check *ProcessChecker) *MiningSuspectedChecker {
 	checker.Process = check
 	return checker
 }
 
-// WithParent adds a Parent check to the ProcessUprobeChecker
-func (checker *ProcessUprobeChecker) WithParent(check *ProcessChecker) *ProcessUprobeChecker {
+// WithParent adds a Parent check to the MiningSuspectedChecker
+func (checker *MiningSuspectedChecker) WithParent(check *ProcessChecker) *MiningSuspectedChecker {
 	checker.Parent = check
 	return checker
 }
 
-// WithPath adds a Path check to the ProcessUprobeChecker
-func (checker *ProcessUprobeChecker) WithPath(check *stringmatcher.StringMatcher) *ProcessUprobeChecker {
-	checker.Path = check
-	return checker
-}
-
-// WithSymbol adds a Symbol check to the ProcessUprobeChecker
-func (checker *ProcessUprobeChecker) WithSymbol(check *stringmatcher.StringMatcher) *ProcessUprobeChecker {
-	checker.Symbol = check
+// WithConfidence adds a Confidence check to the MiningSuspectedChecker
+func (checker *MiningSuspectedChecker) WithConfidence(check float64) *MiningSuspectedChecker {
+	checker.Confidence = &check
 	return checker
 }
 
-// WithPolicyName adds a PolicyName check to the ProcessUprobeChecker
-func (checker *ProcessUprobeChecker) WithPolicyName(check *stringmatcher.StringMatcher) *ProcessUprobeChecker {
-	checker.PolicyName = check
+// WithSignals adds a Signals check to the MiningSuspectedChecker
+func (checker *MiningSuspectedChecker) WithSignals(check *MiningSignalListMatcher) *MiningSuspectedChecker {
+	checker.Signals = check
 	return checker
 }
 
-//FromProcessUprobe populates the ProcessUprobeChecker using data from a ProcessUprobe event
-func (checker *ProcessUprobeChecker) FromProcessUprobe(event *tetragon.ProcessUprobe) *ProcessUprobeChecker {
+//FromMiningSuspected populates the MiningSuspectedChecker using data from a MiningSuspected event
+func (checker *MiningSuspectedChecker) FromMiningSuspected(event *tetragon.MiningSuspected) *MiningSuspectedChecker {
 	if event == nil {
 		return checker
 	}
@@ -1380,78 +1488,2099 @@ func (checker *ProcessUprobeChecker) FromProcessUprobe(event *tetragon.ProcessUp
 	if event.Parent != nil {
 		checker.Parent = NewProcessChecker().FromProcess(event.Parent)
 	}
-	checker.Path = stringmatcher.Full(event.Path)
-	checker.Symbol = stringmatcher.Full(event.Symbol)
-	checker.PolicyName = stringmatcher.Full(event.PolicyName)
+	{
+		val := event.Confidence
+		checker.Confidence = &val
+	}
+	{
+		var checks []*MiningSignalChecker
+		for _, check := range event.Signals {
+			var convertedCheck *MiningSignalChecker
+			if check != nil {
+				convertedCheck = NewMiningSignalChecker().FromMiningSignal(check)
+			}
+			checks = append(checks, convertedCheck)
+		}
+		lm := NewMiningSignalListMatcher().WithOperator(listmatcher.Ordered).
+			WithValues(checks...)
+		checker.Signals = lm
+	}
 	return checker
 }
 
-// TestChecker implements a checker struct to check a Test event
-type TestChecker struct {
-	CheckerName string  `json:"checkerName"`
-	Arg0        *uint64 `json:"arg0,omitempty"`
-	Arg1        *uint64 `json:"arg1,omitempty"`
-	Arg2        *uint64 `json:"arg2,omitempty"`
-	Arg3        *uint64 `json:"arg3,omitempty"`
+// MiningSignalListMatcher checks a list of *tetragon.MiningSignal fields
+type MiningSignalListMatcher struct {
+	Operator listmatcher.Operator   `json:"operator"`
+	Values   []*MiningSignalChecker `json:"values"`
 }
 
-// CheckEvent checks a single event and implements the EventChecker interface
-func (checker *TestChecker) CheckEvent(event Event) error {
-	if ev, ok := event.(*tetragon.Test); ok {
-		return checker.Check(ev)
+// NewMiningSignalListMatcher creates a new MiningSignalListMatcher. The checker defaults to a subset checker unless otherwise specified using WithOperator()
+func NewMiningSignalListMatcher() *MiningSignalListMatcher {
+	return &MiningSignalListMatcher{
+		Operator: listmatcher.Subset,
 	}
-	return fmt.Errorf("%s: %T is not a Test event", CheckerLogPrefix(checker), event)
 }
 
-// CheckResponse checks a single gRPC response and implements the EventChecker interface
-func (checker *TestChecker) CheckResponse(response *tetragon.GetEventsResponse) error {
-	event, err := EventFromResponse(response)
-	if err != nil {
-		return err
-	}
-	return checker.CheckEvent(event)
+// WithOperator sets the match kind for the MiningSignalListMatcher
+func (checker *MiningSignalListMatcher) WithOperator(operator listmatcher.Operator) *MiningSignalListMatcher {
+	checker.Operator = operator
+	return checker
 }
 
-// NewTestChecker creates a new TestChecker
-func NewTestChecker(name string) *TestChecker {
-	return &TestChecker{CheckerName: name}
+// WithValues sets the checkers that the MiningSignalListMatcher should use
+func (checker *MiningSignalListMatcher) WithValues(values ...*MiningSignalChecker) *MiningSignalListMatcher {
+	checker.Values = values
+	return checker
 }
 
-// Get the name associated with the checker
-func (checker *TestChecker) GetCheckerName() string {
-	return checker.CheckerName
+// Check checks a list of *tetragon.MiningSignal fields
+func (checker *MiningSignalListMatcher) Check(values []*tetragon.MiningSignal) error {
+	switch checker.Operator {
+	case listmatcher.Ordered:
+		return checker.orderedCheck(values)
+	case listmatcher.Unordered:
+		return checker.unorderedCheck(values)
+	case listmatcher.Subset:
+		return checker.subsetCheck(values)
+	default:
+		return fmt.Errorf("Unhandled ListMatcher operator %s", checker.Operator)
+	}
 }
 
-// Get the type of the checker as a string
-func (checker *TestChecker) GetCheckerType() string {
-	return "TestChecker"
-}
+// orderedCheck checks a list of ordered *tetragon.MiningSignal fields
+func (checker *MiningSignalListMatcher) orderedCheck(values []*tetragon.MiningSignal) error {
+	innerCheck := func(check *MiningSignalChecker, value *tetragon.MiningSignal) error {
+		if err := check.Check(value); err != nil {
+			return fmt.Errorf("Signals check failed: %w", err)
+		}
+		return nil
+	}
 
-// Check checks a Test event
-func (checker *TestChecker) Check(event *tetragon.Test) error {
-	if event == nil {
-		return fmt.Errorf("%s: Test event is nil", CheckerLogPrefix(checker))
+	if len(checker.Values) != len(values) {
+		return fmt.Errorf("MiningSignalListMatcher: Wanted %d elements, got %d", len(checker.Values), len(values))
 	}
 
-	fieldChecks := func() error {
-		if checker.Arg0 != nil {
-			if *checker.Arg0 != event.Arg0 {
-				return fmt.Errorf("Arg0 has value %d which does not match expected value %d", event.Arg0, *checker.Arg0)
-			}
-		}
-		if checker.Arg1 != nil {
+	for i, check := range checker.Values {
+		value := values[i]
+		if err := innerCheck(check, value); err != nil {
+			return fmt.Errorf("MiningSignalListMatcher: Check failed on element %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// unorderedCheck checks a list of unordered *tetragon.MiningSignal fields
+func (checker *MiningSignalListMatcher) unorderedCheck(values []*tetragon.MiningSignal) error {
+	if len(checker.Values) != len(values) {
+		return fmt.Errorf("MiningSignalListMatcher: Wanted %d elements, got %d", len(checker.Values), len(values))
+	}
+
+	return checker.subsetCheck(values)
+}
+
+// subsetCheck checks a subset of *tetragon.MiningSignal fields
+func (checker *MiningSignalListMatcher) subsetCheck(values []*tetragon.MiningSignal) error {
+	innerCheck := func(check *MiningSignalChecker, value *tetragon.MiningSignal) error {
+		if err := check.Check(value); err != nil {
+			return fmt.Errorf("Signals check failed: %w", err)
+		}
+		return nil
+	}
+
+	numDesired := len(checker.Values)
+	numMatched := 0
+
+nextCheck:
+	for _, check := range checker.Values {
+		for _, value := range values {
+			if err := innerCheck(check, value); err == nil {
+				numMatched += 1
+				continue nextCheck
+			}
+		}
+	}
+
+	if numMatched < numDesired {
+		return fmt.Errorf("MiningSignalListMatcher: Check failed, only matched %d elements but wanted %d", numMatched, numDesired)
+	}
+
+	return nil
+}
+
+// SshConnectionChecker implements a checker struct to check a SshConnection event
+type SshConnectionChecker struct {
+	CheckerName        string                       `json:"checkerName"`
+	Process            *ProcessChecker              `json:"process,omitempty"`
+	Parent             *ProcessChecker              `json:"parent,omitempty"`
+	Client             *stringmatcher.StringMatcher `json:"client,omitempty"`
+	DestinationHost    *stringmatcher.StringMatcher `json:"destinationHost,omitempty"`
+	DestinationAddress *stringmatcher.StringMatcher `json:"destinationAddress,omitempty"`
+	DestinationPort    *uint32                      `json:"destinationPort,omitempty"`
+	User               *stringmatcher.StringMatcher `json:"user,omitempty"`
+}
+
+// CheckEvent checks a single event and implements the EventChecker interface
+func (checker *SshConnectionChecker) CheckEvent(event Event) error {
+	if ev, ok := event.(*tetragon.SshConnection); ok {
+		return checker.Check(ev)
+	}
+	return fmt.Errorf("%s: %T is not a SshConnection event", CheckerLogPrefix(checker), event)
+}
+
+// CheckResponse checks a single gRPC response and implements the EventChecker interface
+func (checker *SshConnectionChecker) CheckResponse(response *tetragon.GetEventsResponse) error {
+	event, err := EventFromResponse(response)
+	if err != nil {
+		return err
+	}
+	return checker.CheckEvent(event)
+}
+
+// NewSshConnectionChecker creates a new SshConnectionChecker
+func NewSshConnectionChecker(name string) *SshConnectionChecker {
+	return &SshConnectionChecker{CheckerName: name}
+}
+
+// Get the name associated with the checker
+func (checker *SshConnectionChecker) GetCheckerName() string {
+	return checker.CheckerName
+}
+
+// Get the type of the checker as a string
+func (checker *SshConnectionChecker) GetCheckerType() string {
+	return "SshConnectionChecker"
+}
+
+// Check checks a SshConnection event
+func (checker *SshConnectionChecker) Check(event *tetragon.SshConnection) error {
+	if event == nil {
+		return fmt.Errorf("%s: SshConnection event is nil", CheckerLogPrefix(checker))
+	}
+
+	fieldChecks := func() error {
+		if checker.Process != nil {
+			if err := checker.Process.Check(event.Process); err != nil {
+				return fmt.Errorf("Process check failed: %w", err)
+			}
+		}
+		if checker.Parent != nil {
+			if err := checker.Parent.Check(event.Parent); err != nil {
+				return fmt.Errorf("Parent check failed: %w", err)
+			}
+		}
+		if checker.Client != nil {
+			if err := checker.Client.Match(event.Client); err != nil {
+				return fmt.Errorf("Client check failed: %w", err)
+			}
+		}
+		if checker.DestinationHost != nil {
+			if err := checker.DestinationHost.Match(event.DestinationHost); err != nil {
+				return fmt.Errorf("DestinationHost check failed: %w", err)
+			}
+		}
+		if checker.DestinationAddress != nil {
+			if err := checker.DestinationAddress.Match(event.DestinationAddress); err != nil {
+				return fmt.Errorf("DestinationAddress check failed: %w", err)
+			}
+		}
+		if checker.DestinationPort != nil {
+			if *checker.DestinationPort != event.DestinationPort {
+				return fmt.Errorf("DestinationPort has value %d which does not match expected value %d", event.DestinationPort, *checker.DestinationPort)
+			}
+		}
+		if checker.User != nil {
+			if err := checker.User.Match(event.User); err != nil {
+				return fmt.Errorf("User check failed: %w", err)
+			}
+		}
+		return nil
+	}
+	if err := fieldChecks(); err != nil {
+		return fmt.Errorf("%s: %w", CheckerLogPrefix(checker), err)
+	}
+	return nil
+}
+
+// WithProcess adds a Process check to the SshConnectionChecker
+func (checker *SshConnectionChecker) WithProcess(check *ProcessChecker) *SshConnectionChecker {
+	checker.Process = check
+	return checker
+}
+
+// WithParent adds a Parent check to the SshConnectionChecker
+func (checker *SshConnectionChecker) WithParent(check *ProcessChecker) *SshConnectionChecker {
+	checker.Parent = check
+	return checker
+}
+
+// WithClient adds a Client check to the SshConnectionChecker
+func (checker *SshConnectionChecker) WithClient(check *stringmatcher.StringMatcher) *SshConnectionChecker {
+	checker.Client = check
+	return checker
+}
+
+// WithDestinationHost adds a DestinationHost check to the SshConnectionChecker
+func (checker *SshConnectionChecker) WithDestinationHost(check *stringmatcher.StringMatcher) *SshConnectionChecker {
+	checker.DestinationHost = check
+	return checker
+}
+
+// WithDestinationAddress adds a DestinationAddress check to the SshConnectionChecker
+func (checker *SshConnectionChecker) WithDestinationAddress(check *stringmatcher.StringMatcher) *SshConnectionChecker {
+	checker.DestinationAddress = check
+	return checker
+}
+
+// WithDestinationPort adds a DestinationPort check to the SshConnectionChecker
+func (checker *SshConnectionChecker) WithDestinationPort(check uint32) *SshConnectionChecker {
+	checker.DestinationPort = &check
+	return checker
+}
+
+// WithUser adds a User check to the SshConnectionChecker
+func (checker *SshConnectionChecker) WithUser(check *stringmatcher.StringMatcher) *SshConnectionChecker {
+	checker.User = check
+	return checker
+}
+
+//FromSshConnection populates the SshConnectionChecker using data from a SshConnection event
+func (checker *SshConnectionChecker) FromSshConnection(event *tetragon.SshConnection) *SshConnectionChecker {
+	if event == nil {
+		return checker
+	}
+	if event.Process != nil {
+		checker.Process = NewProcessChecker().FromProcess(event.Process)
+	}
+	if event.Parent != nil {
+		checker.Parent = NewProcessChecker().FromProcess(event.Parent)
+	}
+	checker.Client = stringmatcher.Full(event.Client)
+	checker.DestinationHost = stringmatcher.Full(event.DestinationHost)
+	checker.DestinationAddress = stringmatcher.Full(event.DestinationAddress)
+	{
+		val := event.DestinationPort
+		checker.DestinationPort = &val
+	}
+	checker.User = stringmatcher.Full(event.User)
+	return checker
+}
+
+// ProcessTracepointChecker implements a checker struct to check a ProcessTracepoint event
+type ProcessTracepointChecker struct {
+	CheckerName   string                           `json:"checkerName"`
+	Process       *ProcessChecker                  `json:"process,omitempty"`
+	Parent        *ProcessChecker                  `json:"parent,omitempty"`
+	Subsys        *stringmatcher.StringMatcher     `json:"subsys,omitempty"`
+	Event         *stringmatcher.StringMatcher     `json:"event,omitempty"`
+	Args          *KprobeArgumentListMatcher       `json:"args,omitempty"`
+	PolicyName    *stringmatcher.StringMatcher     `json:"policyName,omitempty"`
+	Action        *KprobeActionChecker             `json:"action,omitempty"`
+	Compat        *bool                            `json:"compat,omitempty"`
+	Return        *KprobeArgumentChecker           `json:"return,omitempty"`
+	ActionLatency *durationmatcher.DurationMatcher `json:"actionLatency,omitempty"`
+	AuditAction   *AuditActionChecker              `json:"auditAction,omitempty"`
+}
+
+// CheckEvent checks a single event and implements the EventChecker interface
+func (checker *ProcessTracepointChecker) CheckEvent(event Event) error {
+	if ev, ok := event.(*tetragon.ProcessTracepoint); ok {
+		return checker.Check(ev)
+	}
+	return fmt.Errorf("%s: %T is not a ProcessTracepoint event", CheckerLogPrefix(checker), event)
+}
+
+// CheckResponse checks a single gRPC response and implements the EventChecker interface
+func (checker *ProcessTracepointChecker) CheckResponse(response *tetragon.GetEventsResponse) error {
+	event, err := EventFromResponse(response)
+	if err != nil {
+		return err
+	}
+	return checker.CheckEvent(event)
+}
+
+// NewProcessTracepointChecker creates a new ProcessTracepointChecker
+func NewProcessTracepointChecker(name string) *ProcessTracepointChecker {
+	return &ProcessTracepointChecker{CheckerName: name}
+}
+
+// Get the name associated with the checker
+func (checker *ProcessTracepointChecker) GetCheckerName() string {
+	return checker.CheckerName
+}
+
+// Get the type of the checker as a string
+func (checker *ProcessTracepointChecker) GetCheckerType() string {
+	return "ProcessTracepointChecker"
+}
+
+// Check checks a ProcessTracepoint event
+func (checker *ProcessTracepointChecker) Check(event *tetragon.ProcessTracepoint) error {
+	if event == nil {
+		return fmt.Errorf("%s: ProcessTracepoint event is nil", CheckerLogPrefix(checker))
+	}
+
+	fieldChecks := func() error {
+		if checker.Process != nil {
+			if err := checker.Process.Check(event.Process); err != nil {
+				return fmt.Errorf("Process check failed: %w", err)
+			}
+		}
+		if checker.Parent != nil {
+			if err := checker.Parent.Check(event.Parent); err != nil {
+				return fmt.Errorf("Parent check failed: %w", err)
+			}
+		}
+		if checker.Subsys != nil {
+			if err := checker.Subsys.Match(event.Subsys); err != nil {
+				return fmt.Errorf("Subsys check failed: %w", err)
+			}
+		}
+		if checker.Event != nil {
+			if err := checker.Event.Match(event.Event); err != nil {
+				return fmt.Errorf("Event check failed: %w", err)
+			}
+		}
+		if checker.Args != nil {
+			if err := checker.Args.Check(event.Args); err != nil {
+				return fmt.Errorf("Args check failed: %w", err)
+			}
+		}
+		if checker.PolicyName != nil {
+			if err := checker.PolicyName.Match(event.PolicyName); err != nil {
+				return fmt.Errorf("PolicyName check failed: %w", err)
+			}
+		}
+		if checker.Action != nil {
+			if err := checker.Action.Check(&event.Action); err != nil {
+				return fmt.Errorf("Action check failed: %w", err)
+			}
+		}
+		if checker.Compat != nil {
+			if *checker.Compat != event.Compat {
+				return fmt.Errorf("Compat has value %t which does not match expected value %t", event.Compat, *checker.Compat)
+			}
+		}
+		if checker.Return != nil {
+			if err := checker.Return.Check(event.Return); err != nil {
+				return fmt.Errorf("Return check failed: %w", err)
+			}
+		}
+		if checker.ActionLatency != nil {
+			if err := checker.ActionLatency.Match(event.ActionLatency); err != nil {
+				return fmt.Errorf("ActionLatency check failed: %w", err)
+			}
+		}
+		if checker.AuditAction != nil {
+			if err := checker.AuditAction.Check(event.AuditAction); err != nil {
+				return fmt.Errorf("AuditAction check failed: %w", err)
+			}
+		}
+		return nil
+	}
+	if err := fieldChecks(); err != nil {
+		return fmt.Errorf("%s: %w", CheckerLogPrefix(checker), err)
+	}
+	return nil
+}
+
+// WithProcess adds a Process check to the ProcessTracepointChecker
+func (checker *ProcessTracepointChecker) WithProcess(check *ProcessChecker) *ProcessTracepointChecker {
+	checker.Process = check
+	return checker
+}
+
+// WithParent adds a Parent check to the ProcessTracepointChecker
+func (checker *ProcessTracepointChecker) WithParent(check *ProcessChecker) *ProcessTracepointChecker {
+	checker.Parent = check
+	return checker
+}
+
+// WithSubsys adds a Subsys check to the ProcessTracepointChecker
+func (checker *ProcessTracepointChecker) WithSubsys(check *stringmatcher.StringMatcher) *ProcessTracepointChecker {
+	checker.Subsys = check
+	return checker
+}
+
+// WithEvent adds a Event check to the ProcessTracepointChecker
+func (checker *ProcessTracepointChecker) WithEvent(check *stringmatcher.StringMatcher) *ProcessTracepointChecker {
+	checker.Event = check
+	return checker
+}
+
+// WithArgs adds a Args check to the ProcessTracepointChecker
+func (checker *ProcessTracepointChecker) WithArgs(check *KprobeArgumentListMatcher) *ProcessTracepointChecker {
+	checker.Args = check
+	return checker
+}
+
+// WithPolicyName adds a PolicyName check to the ProcessTracepointChecker
+func (checker *ProcessTracepointChecker) WithPolicyName(check *stringmatcher.StringMatcher) *ProcessTracepointChecker {
+	checker.PolicyName = check
+	return checker
+}
+
+// WithAction adds a Action check to the ProcessTracepointChecker
+func (checker *ProcessTracepointChecker) WithAction(check tetragon.KprobeAction) *ProcessTracepointChecker {
+	wrappedCheck := KprobeActionChecker(check)
+	checker.Action = &wrappedCheck
+	return checker
+}
+
+// WithCompat adds a Compat check to the ProcessTracepointChecker
+func (checker *ProcessTracepointChecker) WithCompat(check bool) *ProcessTracepointChecker {
+	checker.Compat = &check
+	return checker
+}
+
+// WithReturn adds a Return check to the ProcessTracepointChecker
+func (checker *ProcessTracepointChecker) WithReturn(check *KprobeArgumentChecker) *ProcessTracepointChecker {
+	checker.Return = check
+	return checker
+}
+
+// WithActionLatency adds a ActionLatency check to the ProcessTracepointChecker
+func (checker *ProcessTracepointChecker) WithActionLatency(check *durationmatcher.DurationMatcher) *ProcessTracepointChecker {
+	checker.ActionLatency = check
+	return checker
+}
+
+// WithAuditAction adds a AuditAction check to the ProcessTracepointChecker
+func (checker *ProcessTracepointChecker) WithAuditAction(check *AuditActionChecker) *ProcessTracepointChecker {
+	checker.AuditAction = check
+	return checker
+}
+
+//FromProcessTracepoint populates the ProcessTracepointChecker using data from a ProcessTracepoint event
+func (checker *ProcessTracepointChecker) FromProcessTracepoint(event *tetragon.ProcessTracepoint) *ProcessTracepointChecker {
+	if event == nil {
+		return checker
+	}
+	if event.Process != nil {
+		checker.Process = NewProcessChecker().FromProcess(event.Process)
+	}
+	if event.Parent != nil {
+		checker.Parent = NewProcessChecker().FromProcess(event.Parent)
+	}
+	checker.Subsys = stringmatcher.Full(event.Subsys)
+	checker.Event = stringmatcher.Full(event.Event)
+	{
+		var checks []*KprobeArgumentChecker
+		for _, check := range event.Args {
+			var convertedCheck *KprobeArgumentChecker
+			if check != nil {
+				convertedCheck = NewKprobeArgumentChecker().FromKprobeArgument(check)
+			}
+			checks = append(checks, convertedCheck)
+		}
+		lm := NewKprobeArgumentListMatcher().WithOperator(listmatcher.Ordered).
+			WithValues(checks...)
+		checker.Args = lm
+	}
+	checker.PolicyName = stringmatcher.Full(event.PolicyName)
+	checker.Action = NewKprobeActionChecker(event.Action)
+	{
+		val := event.Compat
+		checker.Compat = &val
+	}
+	if event.Return != nil {
+		checker.Return = NewKprobeArgumentChecker().FromKprobeArgument(event.Return)
+	}
+	// NB: We don't want to match durations for now
+	checker.ActionLatency = nil
+	if event.AuditAction != nil {
+		checker.AuditAction = NewAuditActionChecker().FromAuditAction(event.AuditAction)
+	}
+	return checker
+}
+
+// ProcessUprobeChecker implements a checker struct to check a ProcessUprobe event
+type ProcessUprobeChecker struct {
+	CheckerName string                       `json:"checkerName"`
+	Process     *ProcessChecker              `json:"process,omitempty"`
+	Parent      *ProcessChecker              `json:"parent,omitempty"`
+	Path        *stringmatcher.StringMatcher `json:"path,omitempty"`
+	Symbol      *stringmatcher.StringMatcher `json:"symbol,omitempty"`
+	PolicyName  *stringmatcher.StringMatcher `json:"policyName,omitempty"`
+	Offset      *uint64                      `json:"offset,omitempty"`
+	Args        *KprobeArgumentListMatcher   `json:"args,omitempty"`
+	AuditAction *AuditActionChecker          `json:"auditAction,omitempty"`
+}
+
+// CheckEvent checks a single event and implements the EventChecker interface
+func (checker *ProcessUprobeChecker) CheckEvent(event Event) error {
+	if ev, ok := event.(*tetragon.ProcessUprobe); ok {
+		return checker.Check(ev)
+	}
+	return fmt.Errorf("%s: %T is not a ProcessUprobe event", CheckerLogPrefix(checker), event)
+}
+
+// CheckResponse checks a single gRPC response and implements the EventChecker interface
+func (checker *ProcessUprobeChecker) CheckResponse(response *tetragon.GetEventsResponse) error {
+	event, err := EventFromResponse(response)
+	if err != nil {
+		return err
+	}
+	return checker.CheckEvent(event)
+}
+
+// NewProcessUprobeChecker creates a new ProcessUprobeChecker
+func NewProcessUprobeChecker(name string) *ProcessUprobeChecker {
+	return &ProcessUprobeChecker{CheckerName: name}
+}
+
+// Get the name associated with the checker
+func (checker *ProcessUprobeChecker) GetCheckerName() string {
+	return checker.CheckerName
+}
+
+// Get the type of the checker as a string
+func (checker *ProcessUprobeChecker) GetCheckerType() string {
+	return "ProcessUprobeChecker"
+}
+
+// Check checks a ProcessUprobe event
+func (checker *ProcessUprobeChecker) Check(event *tetragon.ProcessUprobe) error {
+	if event == nil {
+		return fmt.Errorf("%s: ProcessUprobe event is nil", CheckerLogPrefix(checker))
+	}
+
+	fieldChecks := func() error {
+		if checker.Process != nil {
+			if err := checker.Process.Check(event.Process); err != nil {
+				return fmt.Errorf("Process check failed: %w", err)
+			}
+		}
+		if checker.Parent != nil {
+			if err := checker.Parent.Check(event.Parent); err != nil {
+				return fmt.Errorf("Parent check failed: %w", err)
+			}
+		}
+		if checker.Path != nil {
+			if err := checker.Path.Match(event.Path); err != nil {
+				return fmt.Errorf("Path check failed: %w", err)
+			}
+		}
+		if checker.Symbol != nil {
+			if err := checker.Symbol.Match(event.Symbol); err != nil {
+				return fmt.Errorf("Symbol check failed: %w", err)
+			}
+		}
+		if checker.PolicyName != nil {
+			if err := checker.PolicyName.Match(event.PolicyName); err != nil {
+				return fmt.Errorf("PolicyName check failed: %w", err)
+			}
+		}
+		if checker.Offset != nil {
+			if *checker.Offset != event.Offset {
+				return fmt.Errorf("Offset has value %d which does not match expected value %d", event.Offset, *checker.Offset)
+			}
+		}
+		if checker.Args != nil {
+			if err := checker.Args.Check(event.Args); err != nil {
+				return fmt.Errorf("Args check failed: %w", err)
+			}
+		}
+		if checker.AuditAction != nil {
+			if err := checker.AuditAction.Check(event.AuditAction); err != nil {
+				return fmt.Errorf("AuditAction check failed: %w", err)
+			}
+		}
+		return nil
+	}
+	if err := fieldChecks(); err != nil {
+		return fmt.Errorf("%s: %w", CheckerLogPrefix(checker), err)
+	}
+	return nil
+}
+
+// WithProcess adds a Process check to the ProcessUprobeChecker
+func (checker *ProcessUprobeChecker) WithProcess(check *ProcessChecker) *ProcessUprobeChecker {
+	checker.Process = check
+	return checker
+}
+
+// WithParent adds a Parent check to the ProcessUprobeChecker
+func (checker *ProcessUprobeChecker) WithParent(check *ProcessChecker) *ProcessUprobeChecker {
+	checker.Parent = check
+	return checker
+}
+
+// WithPath adds a Path check to the ProcessUprobeChecker
+func (checker *ProcessUprobeChecker) WithPath(check *stringmatcher.StringMatcher) *ProcessUprobeChecker {
+	checker.Path = check
+	return checker
+}
+
+// WithSymbol adds a Symbol check to the ProcessUprobeChecker
+func (checker *ProcessUprobeChecker) WithSymbol(check *stringmatcher.StringMatcher) *ProcessUprobeChecker {
+	checker.Symbol = check
+	return checker
+}
+
+// WithPolicyName adds a PolicyName check to the ProcessUprobeChecker
+func (checker *ProcessUprobeChecker) WithPolicyName(check *stringmatcher.StringMatcher) *ProcessUprobeChecker {
+	checker.PolicyName = check
+	return checker
+}
+
+// WithOffset adds a Offset check to the ProcessUprobeChecker
+func (checker *ProcessUprobeChecker) WithOffset(check uint64) *ProcessUprobeChecker {
+	checker.Offset = &check
+	return checker
+}
+
+// WithArgs adds a Args check to the ProcessUprobeChecker
+func (checker *ProcessUprobeChecker) WithArgs(check *KprobeArgumentListMatcher) *ProcessUprobeChecker {
+	checker.Args = check
+	return checker
+}
+
+// WithAuditAction adds a AuditAction check to the ProcessUprobeChecker
+func (checker *ProcessUprobeChecker) WithAuditAction(check *AuditActionChecker) *ProcessUprobeChecker {
+	checker.AuditAction = check
+	return checker
+}
+
+//FromProcessUprobe populates the ProcessUprobeChecker using data from a ProcessUprobe event
+func (checker *ProcessUprobeChecker) FromProcessUprobe(event *tetragon.ProcessUprobe) *ProcessUprobeChecker {
+	if event == nil {
+		return checker
+	}
+	if event.Process != nil {
+		checker.Process = NewProcessChecker().FromProcess(event.Process)
+	}
+	if event.Parent != nil {
+		checker.Parent = NewProcessChecker().FromProcess(event.Parent)
+	}
+	checker.Path = stringmatcher.Full(event.Path)
+	checker.Symbol = stringmatcher.Full(event.Symbol)
+	checker.PolicyName = stringmatcher.Full(event.PolicyName)
+	{
+		val := event.Offset
+		checker.Offset = &val
+	}
+	{
+		var checks []*KprobeArgumentChecker
+		for _, check := range event.Args {
+			var convertedCheck *KprobeArgumentChecker
+			if check != nil {
+				convertedCheck = NewKprobeArgumentChecker().FromKprobeArgument(check)
+			}
+			checks = append(checks, convertedCheck)
+		}
+		lm := NewKprobeArgumentListMatcher().WithOperator(listmatcher.Ordered).
+			WithValues(checks...)
+		checker.Args = lm
+	}
+	if event.AuditAction != nil {
+		checker.AuditAction = NewAuditActionChecker().FromAuditAction(event.AuditAction)
+	}
+	return checker
+}
+
+// ProcessLsmChecker implements a checker struct to check a ProcessLsm event
+type ProcessLsmChecker struct {
+	CheckerName    string                           `json:"checkerName"`
+	Process        *ProcessChecker                  `json:"process,omitempty"`
+	Parent         *ProcessChecker                  `json:"parent,omitempty"`
+	FunctionName   *stringmatcher.StringMatcher     `json:"functionName,omitempty"`
+	Args           *KprobeArgumentListMatcher       `json:"args,omitempty"`
+	Action         *KprobeActionChecker             `json:"action,omitempty"`
+	PolicyName     *stringmatcher.StringMatcher     `json:"policyName,omitempty"`
+	ActionLatency  *durationmatcher.DurationMatcher `json:"actionLatency,omitempty"`
+	AuditAction    *AuditActionChecker              `json:"auditAction,omitempty"`
+	PackageManager *stringmatcher.StringMatcher     `json:"packageManager,omitempty"`
+}
+
+// CheckEvent checks a single event and implements the EventChecker interface
+func (checker *ProcessLsmChecker) CheckEvent(event Event) error {
+	if ev, ok := event.(*tetragon.ProcessLsm); ok {
+		return checker.Check(ev)
+	}
+	return fmt.Errorf("%s: %T is not a ProcessLsm event", CheckerLogPrefix(checker), event)
+}
+
+// CheckResponse checks a single gRPC response and implements the EventChecker interface
+func (checker *ProcessLsmChecker) CheckResponse(response *tetragon.GetEventsResponse) error {
+	event, err := EventFromResponse(response)
+	if err != nil {
+		return err
+	}
+	return checker.CheckEvent(event)
+}
+
+// NewProcessLsmChecker creates a new ProcessLsmChecker
+func NewProcessLsmChecker(name string) *ProcessLsmChecker {
+	return &ProcessLsmChecker{CheckerName: name}
+}
+
+// Get the name associated with the checker
+func (checker *ProcessLsmChecker) GetCheckerName() string {
+	return checker.CheckerName
+}
+
+// Get the type of the checker as a string
+func (checker *ProcessLsmChecker) GetCheckerType() string {
+	return "ProcessLsmChecker"
+}
+
+// Check checks a ProcessLsm event
+func (checker *ProcessLsmChecker) Check(event *tetragon.ProcessLsm) error {
+	if event == nil {
+		return fmt.Errorf("%s: ProcessLsm event is nil", CheckerLogPrefix(checker))
+	}
+
+	fieldChecks := func() error {
+		if checker.Process != nil {
+			if err := checker.Process.Check(event.Process); err != nil {
+				return fmt.Errorf("Process check failed: %w", err)
+			}
+		}
+		if checker.Parent != nil {
+			if err := checker.Parent.Check(event.Parent); err != nil {
+				return fmt.Errorf("Parent check failed: %w", err)
+			}
+		}
+		if checker.FunctionName != nil {
+			if err := checker.FunctionName.Match(event.FunctionName); err != nil {
+				return fmt.Errorf("FunctionName check failed: %w", err)
+			}
+		}
+		if checker.Args != nil {
+			if err := checker.Args.Check(event.Args); err != nil {
+				return fmt.Errorf("Args check failed: %w", err)
+			}
+		}
+		if checker.Action != nil {
+			if err := checker.Action.Check(&event.Action); err != nil {
+				return fmt.Errorf("Action check failed: %w", err)
+			}
+		}
+		if checker.PolicyName != nil {
+			if err := checker.PolicyName.Match(event.PolicyName); err != nil {
+				return fmt.Errorf("PolicyName check failed: %w", err)
+			}
+		}
+		if checker.ActionLatency != nil {
+			if err := checker.ActionLatency.Match(event.ActionLatency); err != nil {
+				return fmt.Errorf("ActionLatency check failed: %w", err)
+			}
+		}
+		if checker.AuditAction != nil {
+			if err := checker.AuditAction.Check(event.AuditAction); err != nil {
+				return fmt.Errorf("AuditAction check failed: %w", err)
+			}
+		}
+		if checker.PackageManager != nil {
+			if err := checker.PackageManager.Match(event.PackageManager); err != nil {
+				return fmt.Errorf("PackageManager check failed: %w", err)
+			}
+		}
+		return nil
+	}
+	if err := fieldChecks(); err != nil {
+		return fmt.Errorf("%s: %w", CheckerLogPrefix(checker), err)
+	}
+	return nil
+}
+
+// WithProcess adds a Process check to the ProcessLsmChecker
+func (checker *ProcessLsmChecker) WithProcess(check *ProcessChecker) *ProcessLsmChecker {
+	checker.Process = check
+	return checker
+}
+
+// WithParent adds a Parent check to the ProcessLsmChecker
+func (checker *ProcessLsmChecker) WithParent(check *ProcessChecker) *ProcessLsmChecker {
+	checker.Parent = check
+	return checker
+}
+
+// WithFunctionName adds a FunctionName check to the ProcessLsmChecker
+func (checker *ProcessLsmChecker) WithFunctionName(check *stringmatcher.StringMatcher) *ProcessLsmChecker {
+	checker.FunctionName = check
+	return checker
+}
+
+// WithArgs adds a Args check to the ProcessLsmChecker
+func (checker *ProcessLsmChecker) WithArgs(check *KprobeArgumentListMatcher) *ProcessLsmChecker {
+	checker.Args = check
+	return checker
+}
+
+// WithAction adds a Action check to the ProcessLsmChecker
+func (checker *ProcessLsmChecker) WithAction(check tetragon.KprobeAction) *ProcessLsmChecker {
+	wrappedCheck := KprobeActionChecker(check)
+	checker.Action = &wrappedCheck
+	return checker
+}
+
+// WithPolicyName adds a PolicyName check to the ProcessLsmChecker
+func (checker *ProcessLsmChecker) WithPolicyName(check *stringmatcher.StringMatcher) *ProcessLsmChecker {
+	checker.PolicyName = check
+	return checker
+}
+
+// WithActionLatency adds a ActionLatency check to the ProcessLsmChecker
+func (checker *ProcessLsmChecker) WithActionLatency(check *durationmatcher.DurationMatcher) *ProcessLsmChecker {
+	checker.ActionLatency = check
+	return checker
+}
+
+// WithAuditAction adds a AuditAction check to the ProcessLsmChecker
+func (checker *ProcessLsmChecker) WithAuditAction(check *AuditActionChecker) *ProcessLsmChecker {
+	checker.AuditAction = check
+	return checker
+}
+
+// WithPackageManager adds a PackageManager check to the ProcessLsmChecker
+func (checker *ProcessLsmChecker) WithPackageManager(check *stringmatcher.StringMatcher) *ProcessLsmChecker {
+	checker.PackageManager = check
+	return checker
+}
+
+//FromProcessLsm populates the ProcessLsmChecker using data from a ProcessLsm event
+func (checker *ProcessLsmChecker) FromProcessLsm(event *tetragon.ProcessLsm) *ProcessLsmChecker {
+	if event == nil {
+		return checker
+	}
+	if event.Process != nil {
+		checker.Process = NewProcessChecker().FromProcess(event.Process)
+	}
+	if event.Parent != nil {
+		checker.Parent = NewProcessChecker().FromProcess(event.Parent)
+	}
+	checker.FunctionName = stringmatcher.Full(event.FunctionName)
+	{
+		var checks []*KprobeArgumentChecker
+		for _, check := range event.Args {
+			var convertedCheck *KprobeArgumentChecker
+			if check != nil {
+				convertedCheck = NewKprobeArgumentChecker().FromKprobeArgument(check)
+			}
+			checks = append(checks, convertedCheck)
+		}
+		lm := NewKprobeArgumentListMatcher().WithOperator(listmatcher.Ordered).
+			WithValues(checks...)
+		checker.Args = lm
+	}
+	checker.Action = NewKprobeActionChecker(event.Action)
+	checker.PolicyName = stringmatcher.Full(event.PolicyName)
+	// NB: We don't want to match durations for now
+	checker.ActionLatency = nil
+	if event.AuditAction != nil {
+		checker.AuditAction = NewAuditActionChecker().FromAuditAction(event.AuditAction)
+	}
+	checker.PackageManager = stringmatcher.Full(event.PackageManager)
+	return checker
+}
+
+// ProcessPerfEventChecker implements a checker struct to check a ProcessPerfEvent event
+type ProcessPerfEventChecker struct {
+	CheckerName  string                       `json:"checkerName"`
+	Process      *ProcessChecker              `json:"process,omitempty"`
+	Parent       *ProcessChecker              `json:"parent,omitempty"`
+	Type         *stringmatcher.StringMatcher `json:"type,omitempty"`
+	Event        *stringmatcher.StringMatcher `json:"event,omitempty"`
+	Samples      *uint64                      `json:"samples,omitempty"`
+	SamplePeriod *uint64                      `json:"samplePeriod,omitempty"`
+	Action       *KprobeActionChecker         `json:"action,omitempty"`
+	PolicyName   *stringmatcher.StringMatcher `json:"policyName,omitempty"`
+	AuditAction  *AuditActionChecker          `json:"auditAction,omitempty"`
+}
+
+// CheckEvent checks a single event and implements the EventChecker interface
+func (checker *ProcessPerfEventChecker) CheckEvent(event Event) error {
+	if ev, ok := event.(*tetragon.ProcessPerfEvent); ok {
+		return checker.Check(ev)
+	}
+	return fmt.Errorf("%s: %T is not a ProcessPerfEvent event", CheckerLogPrefix(checker), event)
+}
+
+// CheckResponse checks a single gRPC response and implements the EventChecker interface
+func (checker *ProcessPerfEventChecker) CheckResponse(response *tetragon.GetEventsResponse) error {
+	event, err := EventFromResponse(response)
+	if err != nil {
+		return err
+	}
+	return checker.CheckEvent(event)
+}
+
+// NewProcessPerfEventChecker creates a new ProcessPerfEventChecker
+func NewProcessPerfEventChecker(name string) *ProcessPerfEventChecker {
+	return &ProcessPerfEventChecker{CheckerName: name}
+}
+
+// Get the name associated with the checker
+func (checker *ProcessPerfEventChecker) GetCheckerName() string {
+	return checker.CheckerName
+}
+
+// Get the type of the checker as a string
+func (checker *ProcessPerfEventChecker) GetCheckerType() string {
+	return "ProcessPerfEventChecker"
+}
+
+// Check checks a ProcessPerfEvent event
+func (checker *ProcessPerfEventChecker) Check(event *tetragon.ProcessPerfEvent) error {
+	if event == nil {
+		return fmt.Errorf("%s: ProcessPerfEvent event is nil", CheckerLogPrefix(checker))
+	}
+
+	fieldChecks := func() error {
+		if checker.Process != nil {
+			if err := checker.Process.Check(event.Process); err != nil {
+				return fmt.Errorf("Process check failed: %w", err)
+			}
+		}
+		if checker.Parent != nil {
+			if err := checker.Parent.Check(event.Parent); err != nil {
+				return fmt.Errorf("Parent check failed: %w", err)
+			}
+		}
+		if checker.Type != nil {
+			if err := checker.Type.Match(event.Type); err != nil {
+				return fmt.Errorf("Type check failed: %w", err)
+			}
+		}
+		if checker.Event != nil {
+			if err := checker.Event.Match(event.Event); err != nil {
+				return fmt.Errorf("Event check failed: %w", err)
+			}
+		}
+		if checker.Samples != nil {
+			if *checker.Samples != event.Samples {
+				return fmt.Errorf("Samples has value %d which does not match expected value %d", event.Samples, *checker.Samples)
+			}
+		}
+		if checker.SamplePeriod != nil {
+			if *checker.SamplePeriod != event.SamplePeriod {
+				return fmt.Errorf("SamplePeriod has value %d which does not match expected value %d", event.SamplePeriod, *checker.SamplePeriod)
+			}
+		}
+		if checker.Action != nil {
+			if err := checker.Action.Check(&event.Action); err != nil {
+				return fmt.Errorf("Action check failed: %w", err)
+			}
+		}
+		if checker.PolicyName != nil {
+			if err := checker.PolicyName.Match(event.PolicyName); err != nil {
+				return fmt.Errorf("PolicyName check failed: %w", err)
+			}
+		}
+		if checker.AuditAction != nil {
+			if err := checker.AuditAction.Check(event.AuditAction); err != nil {
+				return fmt.Errorf("AuditAction check failed: %w", err)
+			}
+		}
+		return nil
+	}
+	if err := fieldChecks(); err != nil {
+		return fmt.Errorf("%s: %w", CheckerLogPrefix(checker), err)
+	}
+	return nil
+}
+
+// WithProcess adds a Process check to the ProcessPerfEventChecker
+func (checker *ProcessPerfEventChecker) WithProcess(check *ProcessChecker) *ProcessPerfEventChecker {
+	checker.Process = check
+	return checker
+}
+
+// WithParent adds a Parent check to the ProcessPerfEventChecker
+func (checker *ProcessPerfEventChecker) WithParent(check *ProcessChecker) *ProcessPerfEventChecker {
+	checker.Parent = check
+	return checker
+}
+
+// WithType adds a Type check to the ProcessPerfEventChecker
+func (checker *ProcessPerfEventChecker) WithType(check *stringmatcher.StringMatcher) *ProcessPerfEventChecker {
+	checker.Type = check
+	return checker
+}
+
+// WithEvent adds a Event check to the ProcessPerfEventChecker
+func (checker *ProcessPerfEventChecker) WithEvent(check *stringmatcher.StringMatcher) *ProcessPerfEventChecker {
+	checker.Event = check
+	return checker
+}
+
+// WithSamples adds a Samples check to the ProcessPerfEventChecker
+func (checker *ProcessPerfEventChecker) WithSamples(check uint64) *ProcessPerfEventChecker {
+	checker.Samples = &check
+	return checker
+}
+
+// WithSamplePeriod adds a SamplePeriod check to the ProcessPerfEventChecker
+func (checker *ProcessPerfEventChecker) WithSamplePeriod(check uint64) *ProcessPerfEventChecker {
+	checker.SamplePeriod = &check
+	return checker
+}
+
+// WithAction adds a Action check to the ProcessPerfEventChecker
+func (checker *ProcessPerfEventChecker) WithAction(check tetragon.KprobeAction) *ProcessPerfEventChecker {
+	wrappedCheck := KprobeActionChecker(check)
+	checker.Action = &wrappedCheck
+	return checker
+}
+
+// WithPolicyName adds a PolicyName check to the ProcessPerfEventChecker
+func (checker *ProcessPerfEventChecker) WithPolicyName(check *stringmatcher.StringMatcher) *ProcessPerfEventChecker {
+	checker.PolicyName = check
+	return checker
+}
+
+// WithAuditAction adds a AuditAction check to the ProcessPerfEventChecker
+func (checker *ProcessPerfEventChecker) WithAuditAction(check *AuditActionChecker) *ProcessPerfEventChecker {
+	checker.AuditAction = check
+	return checker
+}
+
+//FromProcessPerfEvent populates the ProcessPerfEventChecker using data from a ProcessPerfEvent event
+func (checker *ProcessPerfEventChecker) FromProcessPerfEvent(event *tetragon.ProcessPerfEvent) *ProcessPerfEventChecker {
+	if event == nil {
+		return checker
+	}
+	if event.Process != nil {
+		checker.Process = NewProcessChecker().FromProcess(event.Process)
+	}
+	if event.Parent != nil {
+		checker.Parent = NewProcessChecker().FromProcess(event.Parent)
+	}
+	checker.Type = stringmatcher.Full(event.Type)
+	checker.Event = stringmatcher.Full(event.Event)
+	{
+		val := event.Samples
+		checker.Samples = &val
+	}
+	{
+		val := event.SamplePeriod
+		checker.SamplePeriod = &val
+	}
+	checker.Action = NewKprobeActionChecker(event.Action)
+	checker.PolicyName = stringmatcher.Full(event.PolicyName)
+	if event.AuditAction != nil {
+		checker.AuditAction = NewAuditActionChecker().FromAuditAction(event.AuditAction)
+	}
+	return checker
+}
+
+// TestChecker implements a checker struct to check a Test event
+type TestChecker struct {
+	CheckerName string  `json:"checkerName"`
+	Arg0        *uint64 `json:"arg0,omitempty"`
+	Arg1        *uint64 `json:"arg1,omitempty"`
+	Arg2        *uint64 `json:"arg2,omitempty"`
+	Arg3        *uint64 `json:"arg3,omitempty"`
+}
+
+// CheckEvent checks a single event and implements the EventChecker interface
+func (checker *TestChecker) CheckEvent(event Event) error {
+	if ev, ok := event.(*tetragon.Test); ok {
+		return checker.Check(ev)
+	}
+	return fmt.Errorf("%s: %T is not a Test event", CheckerLogPrefix(checker), event)
+}
+
+// CheckResponse checks a single gRPC response and implements the EventChecker interface
+func (checker *TestChecker) CheckResponse(response *tetragon.GetEventsResponse) error {
+	event, err := EventFromResponse(response)
+	if err != nil {
+		return err
+	}
+	return checker.CheckEvent(event)
+}
+
+// NewTestChecker creates a new TestChecker
+func NewTestChecker(name string) *TestChecker {
+	return &TestChecker{CheckerName: name}
+}
+
+// Get the name associated with the checker
+func (checker *TestChecker) GetCheckerName() string {
+	return checker.CheckerName
+}
+
+// Get the type of the checker as a string
+func (checker *TestChecker) GetCheckerType() string {
+	return "TestChecker"
+}
+
+// Check checks a Test event
+func (checker *TestChecker) Check(event *tetragon.Test) error {
+	if event == nil {
+		return fmt.Errorf("%s: Test event is nil", CheckerLogPrefix(checker))
+	}
+
+	fieldChecks := func() error {
+		if checker.Arg0 != nil {
+			if *checker.Arg0 != event.Arg0 {
+				return fmt.Errorf("Arg0 has value %d which does not match expected value %d", event.Arg0, *checker.Arg0)
+			}
+		}
+		if checker.Arg1 != nil {
 			if *checker.Arg1 != event.Arg1 {
 				return fmt.Errorf("Arg1 has value %d which does not match expected value %d", event.Arg1, *checker.Arg1)
 			}
 		}
-		if checker.Arg2 != nil {
-			if *checker.Arg2 != event.Arg2 {
-				return fmt.Errorf("Arg2 has value %d which does not match expected value %d", event.Arg2, *checker.Arg2)
+		if checker.Arg2 != nil {
+			if *checker.Arg2 != event.Arg2 {
+				return fmt.Errorf("Arg2 has value %d which does not match expected value %d", event.Arg2, *checker.Arg2)
+			}
+		}
+		if checker.Arg3 != nil {
+			if *checker.Arg3 != event.Arg3 {
+				return fmt.Errorf("Arg3 has value %d which does not match expected value %d", event.Arg3, *checker.Arg3)
+			}
+		}
+		return nil
+	}
+	if err := fieldChecks(); err != nil {
+		return fmt.Errorf("%s: %w", CheckerLogPrefix(checker), err)
+	}
+	return nil
+}
+
+// WithArg0 adds a Arg0 check to the TestChecker
+func (checker *TestChecker) WithArg0(check uint64) *TestChecker {
+	checker.Arg0 = &check
+	return checker
+}
+
+// WithArg1 adds a Arg1 check to the TestChecker
+func (checker *TestChecker) WithArg1(check uint64) *TestChecker {
+	checker.Arg1 = &check
+	return checker
+}
+
+// WithArg2 adds a Arg2 check to the TestChecker
+func (checker *TestChecker) WithArg2(check uint64) *TestChecker {
+	checker.Arg2 = &check
+	return checker
+}
+
+// WithArg3 adds a Arg3 check to the TestChecker
+func (checker *TestChecker) WithArg3(check uint64) *TestChecker {
+	checker.Arg3 = &check
+	return checker
+}
+
+//FromTest populates the TestChecker using data from a Test event
+func (checker *TestChecker) FromTest(event *tetragon.Test) *TestChecker {
+	if event == nil {
+		return checker
+	}
+	{
+		val := event.Arg0
+		checker.Arg0 = &val
+	}
+	{
+		val := event.Arg1
+		checker.Arg1 = &val
+	}
+	{
+		val := event.Arg2
+		checker.Arg2 = &val
+	}
+	{
+		val := event.Arg3
+		checker.Arg3 = &val
+	}
+	return checker
+}
+
+// ProcessLoaderChecker implements a checker struct to check a ProcessLoader event
+type ProcessLoaderChecker struct {
+	CheckerName        string                       `json:"checkerName"`
+	Process            *ProcessChecker              `json:"process,omitempty"`
+	Path               *stringmatcher.StringMatcher `json:"path,omitempty"`
+	Buildid            *bytesmatcher.BytesMatcher   `json:"buildid,omitempty"`
+	AllowlistViolation *bool                        `json:"allowlistViolation,omitempty"`
+	AllowlistPolicies  *StringListMatcher           `json:"allowlistPolicies,omitempty"`
+}
+
+// CheckEvent checks a single event and implements the EventChecker interface
+func (checker *ProcessLoaderChecker) CheckEvent(event Event) error {
+	if ev, ok := event.(*tetragon.ProcessLoader); ok {
+		return checker.Check(ev)
+	}
+	return fmt.Errorf("%s: %T is not a ProcessLoader event", CheckerLogPrefix(checker), event)
+}
+
+// CheckResponse checks a single gRPC response and implements the EventChecker interface
+func (checker *ProcessLoaderChecker) CheckResponse(response *tetragon.GetEventsResponse) error {
+	event, err := EventFromResponse(response)
+	if err != nil {
+		return err
+	}
+	return checker.CheckEvent(event)
+}
+
+// NewProcessLoaderChecker creates a new ProcessLoaderChecker
+func NewProcessLoaderChecker(name string) *ProcessLoaderChecker {
+	return &ProcessLoaderChecker{CheckerName: name}
+}
+
+// Get the name associated with the checker
+func (checker *ProcessLoaderChecker) GetCheckerName() string {
+	return checker.CheckerName
+}
+
+// Get the type of the checker as a string
+func (checker *ProcessLoaderChecker) GetCheckerType() string {
+	return "ProcessLoaderChecker"
+}
+
+// Check checks a ProcessLoader event
+func (checker *ProcessLoaderChecker) Check(event *tetragon.ProcessLoader) error {
+	if event == nil {
+		return fmt.Errorf("%s: ProcessLoader event is nil", CheckerLogPrefix(checker))
+	}
+
+	fieldChecks := func() error {
+		if checker.Process != nil {
+			if err := checker.Process.Check(event.Process); err != nil {
+				return fmt.Errorf("Process check failed: %w", err)
+			}
+		}
+		if checker.Path != nil {
+			if err := checker.Path.Match(event.Path); err != nil {
+				return fmt.Errorf("Path check failed: %w", err)
+			}
+		}
+		if checker.Buildid != nil {
+			if err := checker.Buildid.Match(event.Buildid); err != nil {
+				return fmt.Errorf("Buildid check failed: %w", err)
+			}
+		}
+		if checker.AllowlistViolation != nil {
+			if *checker.AllowlistViolation != event.AllowlistViolation {
+				return fmt.Errorf("AllowlistViolation has value %t which does not match expected value %t", event.AllowlistViolation, *checker.AllowlistViolation)
+			}
+		}
+		if checker.AllowlistPolicies != nil {
+			if err := checker.AllowlistPolicies.Check(event.AllowlistPolicies); err != nil {
+				return fmt.Errorf("AllowlistPolicies check failed: %w", err)
+			}
+		}
+		return nil
+	}
+	if err := fieldChecks(); err != nil {
+		return fmt.Errorf("%s: %w", CheckerLogPrefix(checker), err)
+	}
+	return nil
+}
+
+// WithProcess adds a Process check to the ProcessLoaderChecker
+func (checker *ProcessLoaderChecker) WithProcess(check *ProcessChecker) *ProcessLoaderChecker {
+	checker.Process = check
+	return checker
+}
+
+// WithPath adds a Path check to the ProcessLoaderChecker
+func (checker *ProcessLoaderChecker) WithPath(check *stringmatcher.StringMatcher) *ProcessLoaderChecker {
+	checker.Path = check
+	return checker
+}
+
+// WithBuildid adds a Buildid check to the ProcessLoaderChecker
+func (checker *ProcessLoaderChecker) WithBuildid(check *bytesmatcher.BytesMatcher) *ProcessLoaderChecker {
+	checker.Buildid = check
+	return checker
+}
+
+// WithAllowlistViolation adds a AllowlistViolation check to the ProcessLoaderChecker
+func (checker *ProcessLoaderChecker) WithAllowlistViolation(check bool) *ProcessLoaderChecker {
+	checker.AllowlistViolation = &check
+	return checker
+}
+
+// WithAllowlistPolicies adds a AllowlistPolicies check to the ProcessLoaderChecker
+func (checker *ProcessLoaderChecker) WithAllowlistPolicies(check *StringListMatcher) *ProcessLoaderChecker {
+	checker.AllowlistPolicies = check
+	return checker
+}
+
+//FromProcessLoader populates the ProcessLoaderChecker using data from a ProcessLoader event
+func (checker *ProcessLoaderChecker) FromProcessLoader(event *tetragon.ProcessLoader) *ProcessLoaderChecker {
+	if event == nil {
+		return checker
+	}
+	if event.Process != nil {
+		checker.Process = NewProcessChecker().FromProcess(event.Process)
+	}
+	checker.Path = stringmatcher.Full(event.Path)
+	checker.Buildid = bytesmatcher.Full(event.Buildid)
+	{
+		val := event.AllowlistViolation
+		checker.AllowlistViolation = &val
+	}
+	{
+		var checks []*stringmatcher.StringMatcher
+		for _, check := range event.AllowlistPolicies {
+			var convertedCheck *stringmatcher.StringMatcher
+			convertedCheck = stringmatcher.Full(check)
+			checks = append(checks, convertedCheck)
+		}
+		lm := NewStringListMatcher().WithOperator(listmatcher.Ordered).
+			WithValues(checks...)
+		checker.AllowlistPolicies = lm
+	}
+	return checker
+}
+
+// StringListMatcher checks a list of string fields
+type StringListMatcher struct {
+	Operator listmatcher.Operator           `json:"operator"`
+	Values   []*stringmatcher.StringMatcher `json:"values"`
+}
+
+// NewStringListMatcher creates a new StringListMatcher. The checker defaults to a subset checker unless otherwise specified using WithOperator()
+func NewStringListMatcher() *StringListMatcher {
+	return &StringListMatcher{
+		Operator: listmatcher.Subset,
+	}
+}
+
+// WithOperator sets the match kind for the StringListMatcher
+func (checker *StringListMatcher) WithOperator(operator listmatcher.Operator) *StringListMatcher {
+	checker.Operator = operator
+	return checker
+}
+
+// WithValues sets the checkers that the StringListMatcher should use
+func (checker *StringListMatcher) WithValues(values ...*stringmatcher.StringMatcher) *StringListMatcher {
+	checker.Values = values
+	return checker
+}
+
+// Check checks a list of string fields
+func (checker *StringListMatcher) Check(values []string) error {
+	switch checker.Operator {
+	case listmatcher.Ordered:
+		return checker.orderedCheck(values)
+	case listmatcher.Unordered:
+		return checker.unorderedCheck(values)
+	case listmatcher.Subset:
+		return checker.subsetCheck(values)
+	default:
+		return fmt.Errorf("Unhandled ListMatcher operator %s", checker.Operator)
+	}
+}
+
+// orderedCheck checks a list of ordered string fields
+func (checker *StringListMatcher) orderedCheck(values []string) error {
+	innerCheck := func(check *stringmatcher.StringMatcher, value string) error {
+		if err := check.Match(value); err != nil {
+			return fmt.Errorf("AllowlistPolicies check failed: %w", err)
+		}
+		return nil
+	}
+
+	if len(checker.Values) != len(values) {
+		return fmt.Errorf("StringListMatcher: Wanted %d elements, got %d", len(checker.Values), len(values))
+	}
+
+	for i, check := range checker.Values {
+		value := values[i]
+		if err := innerCheck(check, value); err != nil {
+			return fmt.Errorf("StringListMatcher: Check failed on element %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// unorderedCheck checks a list of unordered string fields
+func (checker *StringListMatcher) unorderedCheck(values []string) error {
+	if len(checker.Values) != len(values) {
+		return fmt.Errorf("StringListMatcher: Wanted %d elements, got %d", len(checker.Values), len(values))
+	}
+
+	return checker.subsetCheck(values)
+}
+
+// subsetCheck checks a subset of string fields
+func (checker *StringListMatcher) subsetCheck(values []string) error {
+	innerCheck := func(check *stringmatcher.StringMatcher, value string) error {
+		if err := check.Match(value); err != nil {
+			return fmt.Errorf("AllowlistPolicies check failed: %w", err)
+		}
+		return nil
+	}
+
+	numDesired := len(checker.Values)
+	numMatched := 0
+
+nextCheck:
+	for _, check := range checker.Values {
+		for _, value := range values {
+			if err := innerCheck(check, value); err == nil {
+				numMatched += 1
+				continue nextCheck
+			}
+		}
+	}
+
+	if numMatched < numDesired {
+		return fmt.Errorf("StringListMatcher: Check failed, only matched %d elements but wanted %d", numMatched, numDesired)
+	}
+
+	return nil
+}
+
+// RateLimitInfoChecker implements a checker struct to check a RateLimitInfo event
+type RateLimitInfoChecker struct {
+	CheckerName                  string  `json:"checkerName"`
+	NumberOfDroppedProcessEvents *uint64 `json:"numberOfDroppedProcessEvents,omitempty"`
+}
+
+// CheckEvent checks a single event and implements the EventChecker interface
+func (checker *RateLimitInfoChecker) CheckEvent(event Event) error {
+	if ev, ok := event.(*tetragon.RateLimitInfo); ok {
+		return checker.Check(ev)
+	}
+	return fmt.Errorf("%s: %T is not a RateLimitInfo event", CheckerLogPrefix(checker), event)
+}
+
+// CheckResponse checks a single gRPC response and implements the EventChecker interface
+func (checker *RateLimitInfoChecker) CheckResponse(response *tetragon.GetEventsResponse) error {
+	event, err := EventFromResponse(response)
+	if err != nil {
+		return err
+	}
+	return checker.CheckEvent(event)
+}
+
+// NewRateLimitInfoChecker creates a new RateLimitInfoChecker
+func NewRateLimitInfoChecker(name string) *RateLimitInfoChecker {
+	return &RateLimitInfoChecker{CheckerName: name}
+}
+
+// Get the name associated with the checker
+func (checker *RateLimitInfoChecker) GetCheckerName() string {
+	return checker.CheckerName
+}
+
+// Get the type of the checker as a string
+func (checker *RateLimitInfoChecker) GetCheckerType() string {
+	return "RateLimitInfoChecker"
+}
+
+// Check checks a RateLimitInfo event
+func (checker *RateLimitInfoChecker) Check(event *tetragon.RateLimitInfo) error {
+	if event == nil {
+		return fmt.Errorf("%s: RateLimitInfo event is nil", CheckerLogPrefix(checker))
+	}
+
+	fieldChecks := func() error {
+		if checker.NumberOfDroppedProcessEvents != nil {
+			if *checker.NumberOfDroppedProcessEvents != event.NumberOfDroppedProcessEvents {
+				return fmt.Errorf("NumberOfDroppedProcessEvents has value %d which does not match expected value %d", event.NumberOfDroppedProcessEvents, *checker.NumberOfDroppedProcessEvents)
+			}
+		}
+		return nil
+	}
+	if err := fieldChecks(); err != nil {
+		return fmt.Errorf("%s: %w", CheckerLogPrefix(checker), err)
+	}
+	return nil
+}
+
+// WithNumberOfDroppedProcessEvents adds a NumberOfDroppedProcessEvents check to the RateLimitInfoChecker
+func (checker *RateLimitInfoChecker) WithNumberOfDroppedProcessEvents(check uint64) *RateLimitInfoChecker {
+	checker.NumberOfDroppedProcessEvents = &check
+	return checker
+}
+
+//FromRateLimitInfo populates the RateLimitInfoChecker using data from a RateLimitInfo event
+func (checker *RateLimitInfoChecker) FromRateLimitInfo(event *tetragon.RateLimitInfo) *RateLimitInfoChecker {
+	if event == nil {
+		return checker
+	}
+	{
+		val := event.NumberOfDroppedProcessEvents
+		checker.NumberOfDroppedProcessEvents = &val
+	}
+	return checker
+}
+
+// ExportSinkHealthChecker implements a checker struct to check a ExportSinkHealth event
+type ExportSinkHealthChecker struct {
+	CheckerName    string                             `json:"checkerName"`
+	Sink           *stringmatcher.StringMatcher       `json:"sink,omitempty"`
+	FailoverSink   *stringmatcher.StringMatcher       `json:"failoverSink,omitempty"`
+	Healthy        *bool                              `json:"healthy,omitempty"`
+	FirstEventTime *timestampmatcher.TimestampMatcher `json:"firstEventTime,omitempty"`
+	LastEventTime  *timestampmatcher.TimestampMatcher `json:"lastEventTime,omitempty"`
+	Error          *stringmatcher.StringMatcher       `json:"error,omitempty"`
+}
+
+// CheckEvent checks a single event and implements the EventChecker interface
+func (checker *ExportSinkHealthChecker) CheckEvent(event Event) error {
+	if ev, ok := event.(*tetragon.ExportSinkHealth); ok {
+		return checker.Check(ev)
+	}
+	return fmt.Errorf("%s: %T is not a ExportSinkHealth event", CheckerLogPrefix(checker), event)
+}
+
+// CheckResponse checks a single gRPC response and implements the EventChecker interface
+func (checker *ExportSinkHealthChecker) CheckResponse(response *tetragon.GetEventsResponse) error {
+	event, err := EventFromResponse(response)
+	if err != nil {
+		return err
+	}
+	return checker.CheckEvent(event)
+}
+
+// NewExportSinkHealthChecker creates a new ExportSinkHealthChecker
+func NewExportSinkHealthChecker(name string) *ExportSinkHealthChecker {
+	return &ExportSinkHealthChecker{CheckerName: name}
+}
+
+// Get the name associated with the checker
+func (checker *ExportSinkHealthChecker) GetCheckerName() string {
+	return checker.CheckerName
+}
+
+// Get the type of the checker as a string
+func (checker *ExportSinkHealthChecker) GetCheckerType() string {
+	return "ExportSinkHealthChecker"
+}
+
+// Check checks a ExportSinkHealth event
+func (checker *ExportSinkHealthChecker) Check(event *tetragon.ExportSinkHealth) error {
+	if event == nil {
+		return fmt.Errorf("%s: ExportSinkHealth event is nil", CheckerLogPrefix(checker))
+	}
+
+	fieldChecks := func() error {
+		if checker.Sink != nil {
+			if err := checker.Sink.Match(event.Sink); err != nil {
+				return fmt.Errorf("Sink check failed: %w", err)
+			}
+		}
+		if checker.FailoverSink != nil {
+			if err := checker.FailoverSink.Match(event.FailoverSink); err != nil {
+				return fmt.Errorf("FailoverSink check failed: %w", err)
+			}
+		}
+		if checker.Healthy != nil {
+			if *checker.Healthy != event.Healthy {
+				return fmt.Errorf("Healthy has value %t which does not match expected value %t", event.Healthy, *checker.Healthy)
+			}
+		}
+		if checker.FirstEventTime != nil {
+			if err := checker.FirstEventTime.Match(event.FirstEventTime); err != nil {
+				return fmt.Errorf("FirstEventTime check failed: %w", err)
+			}
+		}
+		if checker.LastEventTime != nil {
+			if err := checker.LastEventTime.Match(event.LastEventTime); err != nil {
+				return fmt.Errorf("LastEventTime check failed: %w", err)
+			}
+		}
+		if checker.Error != nil {
+			if err := checker.Error.Match(event.Error); err != nil {
+				return fmt.Errorf("Error check failed: %w", err)
+			}
+		}
+		return nil
+	}
+	if err := fieldChecks(); err != nil {
+		return fmt.Errorf("%s: %w", CheckerLogPrefix(checker), err)
+	}
+	return nil
+}
+
+// WithSink adds a Sink check to the ExportSinkHealthChecker
+func (checker *ExportSinkHealthChecker) WithSink(check *stringmatcher.StringMatcher) *ExportSinkHealthChecker {
+	checker.Sink = check
+	return checker
+}
+
+// WithFailoverSink adds a FailoverSink check to the ExportSinkHealthChecker
+func (checker *ExportSinkHealthChecker) WithFailoverSink(check *stringmatcher.StringMatcher) *ExportSinkHealthChecker {
+	checker.FailoverSink = check
+	return checker
+}
+
+// WithHealthy adds a Healthy check to the ExportSinkHealthChecker
+func (checker *ExportSinkHealthChecker) WithHealthy(check bool) *ExportSinkHealthChecker {
+	checker.Healthy = &check
+	return checker
+}
+
+// WithFirstEventTime adds a FirstEventTime check to the ExportSinkHealthChecker
+func (checker *ExportSinkHealthChecker) WithFirstEventTime(check *timestampmatcher.TimestampMatcher) *ExportSinkHealthChecker {
+	checker.FirstEventTime = check
+	return checker
+}
+
+// WithLastEventTime adds a LastEventTime check to the ExportSinkHealthChecker
+func (checker *ExportSinkHealthChecker) WithLastEventTime(check *timestampmatcher.TimestampMatcher) *ExportSinkHealthChecker {
+	checker.LastEventTime = check
+	return checker
+}
+
+// WithError adds a Error check to the ExportSinkHealthChecker
+func (checker *ExportSinkHealthChecker) WithError(check *stringmatcher.StringMatcher) *ExportSinkHealthChecker {
+	checker.Error = check
+	return checker
+}
+
+//FromExportSinkHealth populates the ExportSinkHealthChecker using data from a ExportSinkHealth event
+func (checker *ExportSinkHealthChecker) FromExportSinkHealth(event *tetragon.ExportSinkHealth) *ExportSinkHealthChecker {
+	if event == nil {
+		return checker
+	}
+	checker.Sink = stringmatcher.Full(event.Sink)
+	checker.FailoverSink = stringmatcher.Full(event.FailoverSink)
+	{
+		val := event.Healthy
+		checker.Healthy = &val
+	}
+	// NB: We don't want to match timestamps for now
+	checker.FirstEventTime = nil
+	// NB: We don't want to match timestamps for now
+	checker.LastEventTime = nil
+	checker.Error = stringmatcher.Full(event.Error)
+	return checker
+}
+
+// RingBufferDropsChecker implements a checker struct to check a RingBufferDrops event
+type RingBufferDropsChecker struct {
+	CheckerName string                           `json:"checkerName"`
+	Lost        *uint64                          `json:"lost,omitempty"`
+	Window      *durationmatcher.DurationMatcher `json:"window,omitempty"`
+	Cgroups     *CgroupEventRateListMatcher      `json:"cgroups,omitempty"`
+}
+
+// CheckEvent checks a single event and implements the EventChecker interface
+func (checker *RingBufferDropsChecker) CheckEvent(event Event) error {
+	if ev, ok := event.(*tetragon.RingBufferDrops); ok {
+		return checker.Check(ev)
+	}
+	return fmt.Errorf("%s: %T is not a RingBufferDrops event", CheckerLogPrefix(checker), event)
+}
+
+// CheckResponse checks a single gRPC response and implements the EventChecker interface
+func (checker *RingBufferDropsChecker) CheckResponse(response *tetragon.GetEventsResponse) error {
+	event, err := EventFromResponse(response)
+	if err != nil {
+		return err
+	}
+	return checker.CheckEvent(event)
+}
+
+// NewRingBufferDropsChecker creates a new RingBufferDropsChecker
+func NewRingBufferDropsChecker(name string) *RingBufferDropsChecker {
+	return &RingBufferDropsChecker{CheckerName: name}
+}
+
+// Get the name associated with the checker
+func (checker *RingBufferDropsChecker) GetCheckerName() string {
+	return checker.CheckerName
+}
+
+// Get the type of the checker as a string
+func (checker *RingBufferDropsChecker) GetCheckerType() string {
+	return "RingBufferDropsChecker"
+}
+
+// Check checks a RingBufferDrops event
+func (checker *RingBufferDropsChecker) Check(event *tetragon.RingBufferDrops) error {
+	if event == nil {
+		return fmt.Errorf("%s: RingBufferDrops event is nil", CheckerLogPrefix(checker))
+	}
+
+	fieldChecks := func() error {
+		if checker.Lost != nil {
+			if *checker.Lost != event.Lost {
+				return fmt.Errorf("Lost has value %d which does not match expected value %d", event.Lost, *checker.Lost)
+			}
+		}
+		if checker.Window != nil {
+			if err := checker.Window.Match(event.Window); err != nil {
+				return fmt.Errorf("Window check failed: %w", err)
+			}
+		}
+		if checker.Cgroups != nil {
+			if err := checker.Cgroups.Check(event.Cgroups); err != nil {
+				return fmt.Errorf("Cgroups check failed: %w", err)
+			}
+		}
+		return nil
+	}
+	if err := fieldChecks(); err != nil {
+		return fmt.Errorf("%s: %w", CheckerLogPrefix(checker), err)
+	}
+	return nil
+}
+
+// WithLost adds a Lost check to the RingBufferDropsChecker
+func (checker *RingBufferDropsChecker) WithLost(check uint64) *RingBufferDropsChecker {
+	checker.Lost = &check
+	return checker
+}
+
+// WithWindow adds a Window check to the RingBufferDropsChecker
+func (checker *RingBufferDropsChecker) WithWindow(check *durationmatcher.DurationMatcher) *RingBufferDropsChecker {
+	checker.Window = check
+	return checker
+}
+
+// WithCgroups adds a Cgroups check to the RingBufferDropsChecker
+func (checker *RingBufferDropsChecker) WithCgroups(check *CgroupEventRateListMatcher) *RingBufferDropsChecker {
+	checker.Cgroups = check
+	return checker
+}
+
+//FromRingBufferDrops populates the RingBufferDropsChecker using data from a RingBufferDrops event
+func (checker *RingBufferDropsChecker) FromRingBufferDrops(event *tetragon.RingBufferDrops) *RingBufferDropsChecker {
+	if event == nil {
+		return checker
+	}
+	{
+		val := event.Lost
+		checker.Lost = &val
+	}
+	// NB: We don't want to match durations for now
+	checker.Window = nil
+	{
+		var checks []*CgroupEventRateChecker
+		for _, check := range event.Cgroups {
+			var convertedCheck *CgroupEventRateChecker
+			if check != nil {
+				convertedCheck = NewCgroupEventRateChecker().FromCgroupEventRate(check)
+			}
+			checks = append(checks, convertedCheck)
+		}
+		lm := NewCgroupEventRateListMatcher().WithOperator(listmatcher.Ordered).
+			WithValues(checks...)
+		checker.Cgroups = lm
+	}
+	return checker
+}
+
+// CgroupEventRateListMatcher checks a list of *tetragon.CgroupEventRate fields
+type CgroupEventRateListMatcher struct {
+	Operator listmatcher.Operator      `json:"operator"`
+	Values   []*CgroupEventRateChecker `json:"values"`
+}
+
+// NewCgroupEventRateListMatcher creates a new CgroupEventRateListMatcher. The checker defaults to a subset checker unless otherwise specified using WithOperator()
+func NewCgroupEventRateListMatcher() *CgroupEventRateListMatcher {
+	return &CgroupEventRateListMatcher{
+		Operator: listmatcher.Subset,
+	}
+}
+
+// WithOperator sets the match kind for the CgroupEventRateListMatcher
+func (checker *CgroupEventRateListMatcher) WithOperator(operator listmatcher.Operator) *CgroupEventRateListMatcher {
+	checker.Operator = operator
+	return checker
+}
+
+// WithValues sets the checkers that the CgroupEventRateListMatcher should use
+func (checker *CgroupEventRateListMatcher) WithValues(values ...*CgroupEventRateChecker) *CgroupEventRateListMatcher {
+	checker.Values = values
+	return checker
+}
+
+// Check checks a list of *tetragon.CgroupEventRate fields
+func (checker *CgroupEventRateListMatcher) Check(values []*tetragon.CgroupEventRate) error {
+	switch checker.Operator {
+	case listmatcher.Ordered:
+		return checker.orderedCheck(values)
+	case listmatcher.Unordered:
+		return checker.unorderedCheck(values)
+	case listmatcher.Subset:
+		return checker.subsetCheck(values)
+	default:
+		return fmt.Errorf("Unhandled ListMatcher operator %s", checker.Operator)
+	}
+}
+
+// orderedCheck checks a list of ordered *tetragon.CgroupEventRate fields
+func (checker *CgroupEventRateListMatcher) orderedCheck(values []*tetragon.CgroupEventRate) error {
+	innerCheck := func(check *CgroupEventRateChecker, value *tetragon.CgroupEventRate) error {
+		if err := check.Check(value); err != nil {
+			return fmt.Errorf("Cgroups check failed: %w", err)
+		}
+		return nil
+	}
+
+	if len(checker.Values) != len(values) {
+		return fmt.Errorf("CgroupEventRateListMatcher: Wanted %d elements, got %d", len(checker.Values), len(values))
+	}
+
+	for i, check := range checker.Values {
+		value := values[i]
+		if err := innerCheck(check, value); err != nil {
+			return fmt.Errorf("CgroupEventRateListMatcher: Check failed on element %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// unorderedCheck checks a list of unordered *tetragon.CgroupEventRate fields
+func (checker *CgroupEventRateListMatcher) unorderedCheck(values []*tetragon.CgroupEventRate) error {
+	if len(checker.Values) != len(values) {
+		return fmt.Errorf("CgroupEventRateListMatcher: Wanted %d elements, got %d", len(checker.Values), len(values))
+	}
+
+	return checker.subsetCheck(values)
+}
+
+// subsetCheck checks a subset of *tetragon.CgroupEventRate fields
+func (checker *CgroupEventRateListMatcher) subsetCheck(values []*tetragon.CgroupEventRate) error {
+	innerCheck := func(check *CgroupEventRateChecker, value *tetragon.CgroupEventRate) error {
+		if err := check.Check(value); err != nil {
+			return fmt.Errorf("Cgroups check failed: %w", err)
+		}
+		return nil
+	}
+
+	numDesired := len(checker.Values)
+	numMatched := 0
+
+nextCheck:
+	for _, check := range checker.Values {
+		for _, value := range values {
+			if err := innerCheck(check, value); err == nil {
+				numMatched += 1
+				continue nextCheck
+			}
+		}
+	}
+
+	if numMatched < numDesired {
+		return fmt.Errorf("CgroupEventRateListMatcher: Check failed, only matched %d elements but wanted %d", numMatched, numDesired)
+	}
+
+	return nil
+}
+
+// LostEventChecker implements a checker struct to check a LostEvent event
+type LostEventChecker struct {
+	CheckerName string                             `json:"checkerName"`
+	Count       *uint64                            `json:"count,omitempty"`
+	Cpu         *uint32                            `json:"cpu,omitempty"`
+	WindowStart *timestampmatcher.TimestampMatcher `json:"windowStart,omitempty"`
+	WindowEnd   *timestampmatcher.TimestampMatcher `json:"windowEnd,omitempty"`
+	Transport   *stringmatcher.StringMatcher       `json:"transport,omitempty"`
+}
+
+// CheckEvent checks a single event and implements the EventChecker interface
+func (checker *LostEventChecker) CheckEvent(event Event) error {
+	if ev, ok := event.(*tetragon.LostEvent); ok {
+		return checker.Check(ev)
+	}
+	return fmt.Errorf("%s: %T is not a LostEvent event", CheckerLogPrefix(checker), event)
+}
+
+// CheckResponse checks a single gRPC response and implements the EventChecker interface
+func (checker *LostEventChecker) CheckResponse(response *tetragon.GetEventsResponse) error {
+	event, err := EventFromResponse(response)
+	if err != nil {
+		return err
+	}
+	return checker.CheckEvent(event)
+}
+
+// NewLostEventChecker creates a new LostEventChecker
+func NewLostEventChecker(name string) *LostEventChecker {
+	return &LostEventChecker{CheckerName: name}
+}
+
+// Get the name associated with the checker
+func (checker *LostEventChecker) GetCheckerName() string {
+	return checker.CheckerName
+}
+
+// Get the type of the checker as a string
+func (checker *LostEventChecker) GetCheckerType() string {
+	return "LostEventChecker"
+}
+
+// Check checks a LostEvent event
+func (checker *LostEventChecker) Check(event *tetragon.LostEvent) error {
+	if event == nil {
+		return fmt.Errorf("%s: LostEvent event is nil", CheckerLogPrefix(checker))
+	}
+
+	fieldChecks := func() error {
+		if checker.Count != nil {
+			if *checker.Count != event.Count {
+				return fmt.Errorf("Count has value %d which does not match expected value %d", event.Count, *checker.Count)
+			}
+		}
+		if checker.Cpu != nil {
+			if *checker.Cpu != event.Cpu {
+				return fmt.Errorf("Cpu has value %d which does not match expected value %d", event.Cpu, *checker.Cpu)
+			}
+		}
+		if checker.WindowStart != nil {
+			if err := checker.WindowStart.Match(event.WindowStart); err != nil {
+				return fmt.Errorf("WindowStart check failed: %w", err)
+			}
+		}
+		if checker.WindowEnd != nil {
+			if err := checker.WindowEnd.Match(event.WindowEnd); err != nil {
+				return fmt.Errorf("WindowEnd check failed: %w", err)
+			}
+		}
+		if checker.Transport != nil {
+			if err := checker.Transport.Match(event.Transport); err != nil {
+				return fmt.Errorf("Transport check failed: %w", err)
+			}
+		}
+		return nil
+	}
+	if err := fieldChecks(); err != nil {
+		return fmt.Errorf("%s: %w", CheckerLogPrefix(checker), err)
+	}
+	return nil
+}
+
+// WithCount adds a Count check to the LostEventChecker
+func (checker *LostEventChecker) WithCount(check uint64) *LostEventChecker {
+	checker.Count = &check
+	return checker
+}
+
+// WithCpu adds a Cpu check to the LostEventChecker
+func (checker *LostEventChecker) WithCpu(check uint32) *LostEventChecker {
+	checker.Cpu = &check
+	return checker
+}
+
+// WithWindowStart adds a WindowStart check to the LostEventChecker
+func (checker *LostEventChecker) WithWindowStart(check *timestampmatcher.TimestampMatcher) *LostEventChecker {
+	checker.WindowStart = check
+	return checker
+}
+
+// WithWindowEnd adds a WindowEnd check to the LostEventChecker
+func (checker *LostEventChecker) WithWindowEnd(check *timestampmatcher.TimestampMatcher) *LostEventChecker {
+	checker.WindowEnd = check
+	return checker
+}
+
+// WithTransport adds a Transport check to the LostEventChecker
+func (checker *LostEventChecker) WithTransport(check *stringmatcher.StringMatcher) *LostEventChecker {
+	checker.Transport = check
+	return checker
+}
+
+//FromLostEvent populates the LostEventChecker using data from a LostEvent event
+func (checker *LostEventChecker) FromLostEvent(event *tetragon.LostEvent) *LostEventChecker {
+	if event == nil {
+		return checker
+	}
+	{
+		val := event.Count
+		checker.Count = &val
+	}
+	{
+		val := event.Cpu
+		checker.Cpu = &val
+	}
+	// NB: We don't want to match timestamps for now
+	checker.WindowStart = nil
+	// NB: We don't want to match timestamps for now
+	checker.WindowEnd = nil
+	checker.Transport = stringmatcher.Full(event.Transport)
+	return checker
+}
+
+// MapFillChecker implements a checker struct to check a MapFill event
+type MapFillChecker struct {
+	CheckerName    string                       `json:"checkerName"`
+	Policy         *stringmatcher.StringMatcher `json:"policy,omitempty"`
+	Map            *stringmatcher.StringMatcher `json:"map,omitempty"`
+	Pin            *stringmatcher.StringMatcher `json:"pin,omitempty"`
+	Entries        *uint32                      `json:"entries,omitempty"`
+	MaxEntries     *uint32                      `json:"maxEntries,omitempty"`
+	AboveThreshold *bool                        `json:"aboveThreshold,omitempty"`
+	NextMaxEntries *uint32                      `json:"nextMaxEntries,omitempty"`
+}
+
+// CheckEvent checks a single event and implements the EventChecker interface
+func (checker *MapFillChecker) CheckEvent(event Event) error {
+	if ev, ok := event.(*tetragon.MapFill); ok {
+		return checker.Check(ev)
+	}
+	return fmt.Errorf("%s: %T is not a MapFill event", CheckerLogPrefix(checker), event)
+}
+
+// CheckResponse checks a single gRPC response and implements the EventChecker interface
+func (checker *MapFillChecker) CheckResponse(response *tetragon.GetEventsResponse) error {
+	event, err := EventFromResponse(response)
+	if err != nil {
+		return err
+	}
+	return checker.CheckEvent(event)
+}
+
+// NewMapFillChecker creates a new MapFillChecker
+func NewMapFillChecker(name string) *MapFillChecker {
+	return &MapFillChecker{CheckerName: name}
+}
+
+// Get the name associated with the checker
+func (checker *MapFillChecker) GetCheckerName() string {
+	return checker.CheckerName
+}
+
+// Get the type of the checker as a string
+func (checker *MapFillChecker) GetCheckerType() string {
+	return "MapFillChecker"
+}
+
+// Check checks a MapFill event
+func (checker *MapFillChecker) Check(event *tetragon.MapFill) error {
+	if event == nil {
+		return fmt.Errorf("%s: MapFill event is nil", CheckerLogPrefix(checker))
+	}
+
+	fieldChecks := func() error {
+		if checker.Policy != nil {
+			if err := checker.Policy.Match(event.Policy); err != nil {
+				return fmt.Errorf("Policy check failed: %w", err)
+			}
+		}
+		if checker.Map != nil {
+			if err := checker.Map.Match(event.Map); err != nil {
+				return fmt.Errorf("Map check failed: %w", err)
 			}
 		}
-		if checker.Arg3 != nil {
-			if *checker.Arg3 != event.Arg3 {
-				return fmt.Errorf("Arg3 has value %d which does not match expected value %d", event.Arg3, *checker.Arg3)
+		if checker.Pin != nil {
+			if err := checker.Pin.Match(event.Pin); err != nil {
+				return fmt.Errorf("Pin check failed: %w", err)
+			}
+		}
+		if checker.Entries != nil {
+			if *checker.Entries != event.Entries {
+				return fmt.Errorf("Entries has value %d which does not match expected value %d", event.Entries, *checker.Entries)
+			}
+		}
+		if checker.MaxEntries != nil {
+			if *checker.MaxEntries != event.MaxEntries {
+				return fmt.Errorf("MaxEntries has value %d which does not match expected value %d", event.MaxEntries, *checker.MaxEntries)
+			}
+		}
+		if checker.AboveThreshold != nil {
+			if *checker.AboveThreshold != event.AboveThreshold {
+				return fmt.Errorf("AboveThreshold has value %t which does not match expected value %t", event.AboveThreshold, *checker.AboveThreshold)
+			}
+		}
+		if checker.NextMaxEntries != nil {
+			if *checker.NextMaxEntries != event.NextMaxEntries {
+				return fmt.Errorf("NextMaxEntries has value %d which does not match expected value %d", event.NextMaxEntries, *checker.NextMaxEntries)
 			}
 		}
 		return nil
@@ -1462,72 +3591,98 @@ func (checker *TestChecker) Check(event *tetragon.Test) error {
 	return nil
 }
 
-// WithArg0 adds a Arg0 check to the TestChecker
-func (checker *TestChecker) WithArg0(check uint64) *TestChecker {
-	checker.Arg0 = &check
+// WithPolicy adds a Policy check to the MapFillChecker
+func (checker *MapFillChecker) WithPolicy(check *stringmatcher.StringMatcher) *MapFillChecker {
+	checker.Policy = check
 	return checker
 }
 
-// WithArg1 adds a Arg1 check to the TestChecker
-func (checker *TestChecker) WithArg1(check uint64) *TestChecker {
-	checker.Arg1 = &check
+// WithMap adds a Map check to the MapFillChecker
+func (checker *MapFillChecker) WithMap(check *stringmatcher.StringMatcher) *MapFillChecker {
+	checker.Map = check
 	return checker
 }
 
-// WithArg2 adds a Arg2 check to the TestChecker
-func (checker *TestChecker) WithArg2(check uint64) *TestChecker {
-	checker.Arg2 = &check
+// WithPin adds a Pin check to the MapFillChecker
+func (checker *MapFillChecker) WithPin(check *stringmatcher.StringMatcher) *MapFillChecker {
+	checker.Pin = check
 	return checker
 }
 
-// WithArg3 adds a Arg3 check to the TestChecker
-func (checker *TestChecker) WithArg3(check uint64) *TestChecker {
-	checker.Arg3 = &check
+// WithEntries adds a Entries check to the MapFillChecker
+func (checker *MapFillChecker) WithEntries(check uint32) *MapFillChecker {
+	checker.Entries = &check
 	return checker
 }
 
-//FromTest populates the TestChecker using data from a Test event
-func (checker *TestChecker) FromTest(event *tetragon.Test) *TestChecker {
+// WithMaxEntries adds a MaxEntries check to the MapFillChecker
+func (checker *MapFillChecker) WithMaxEntries(check uint32) *MapFillChecker {
+	checker.MaxEntries = &check
+	return checker
+}
+
+// WithAboveThreshold adds a AboveThreshold check to the MapFillChecker
+func (checker *MapFillChecker) WithAboveThreshold(check bool) *MapFillChecker {
+	checker.AboveThreshold = &check
+	return checker
+}
+
+// WithNextMaxEntries adds a NextMaxEntries check to the MapFillChecker
+func (checker *MapFillChecker) WithNextMaxEntries(check uint32) *MapFillChecker {
+	checker.NextMaxEntries = &check
+	return checker
+}
+
+//FromMapFill populates the MapFillChecker using data from a MapFill event
+func (checker *MapFillChecker) FromMapFill(event *tetragon.MapFill) *MapFillChecker {
 	if event == nil {
 		return checker
 	}
+	checker.Policy = stringmatcher.Full(event.Policy)
+	checker.Map = stringmatcher.Full(event.Map)
+	checker.Pin = stringmatcher.Full(event.Pin)
 	{
-		val := event.Arg0
-		checker.Arg0 = &val
+		val := event.Entries
+		checker.Entries = &val
 	}
 	{
-		val := event.Arg1
-		checker.Arg1 = &val
+		val := event.MaxEntries
+		checker.MaxEntries = &val
 	}
 	{
-		val := event.Arg2
-		checker.Arg2 = &val
+		val := event.AboveThreshold
+		checker.AboveThreshold = &val
 	}
 	{
-		val := event.Arg3
-		checker.Arg3 = &val
+		val := event.NextMaxEntries
+		checker.NextMaxEntries = &val
 	}
 	return checker
 }
 
-// ProcessLoaderChecker implements a checker struct to check a ProcessLoader event
-type ProcessLoaderChecker struct {
-	CheckerName string                       `json:"checkerName"`
-	Process     *ProcessChecker              `json:"process,omitempty"`
-	Path        *stringmatcher.StringMatcher `json:"path,omitempty"`
-	Buildid     *bytesmatcher.BytesMatcher   `json:"buildid,omitempty"`
+// ConfigSnapshotChecker implements a checker struct to check a ConfigSnapshot event
+type ConfigSnapshotChecker struct {
+	CheckerName   string                                 `json:"checkerName"`
+	Version       *stringmatcher.StringMatcher           `json:"version,omitempty"`
+	Flags         map[string]stringmatcher.StringMatcher `json:"flags,omitempty"`
+	KernelRelease *stringmatcher.StringMatcher           `json:"kernelRelease,omitempty"`
+	KernelVersion *stringmatcher.StringMatcher           `json:"kernelVersion,omitempty"`
+	Machine       *stringmatcher.StringMatcher           `json:"machine,omitempty"`
+	Policies      *ConfigSnapshotPolicyListMatcher       `json:"policies,omitempty"`
+	Hash          *stringmatcher.StringMatcher           `json:"hash,omitempty"`
+	Changed       *bool                                  `json:"changed,omitempty"`
 }
 
 // CheckEvent checks a single event and implements the EventChecker interface
-func (checker *ProcessLoaderChecker) CheckEvent(event Event) error {
-	if ev, ok := event.(*tetragon.ProcessLoader); ok {
+func (checker *ConfigSnapshotChecker) CheckEvent(event Event) error {
+	if ev, ok := event.(*tetragon.ConfigSnapshot); ok {
 		return checker.Check(ev)
 	}
-	return fmt.Errorf("%s: %T is not a ProcessLoader event", CheckerLogPrefix(checker), event)
+	return fmt.Errorf("%s: %T is not a ConfigSnapshot event", CheckerLogPrefix(checker), event)
 }
 
 // CheckResponse checks a single gRPC response and implements the EventChecker interface
-func (checker *ProcessLoaderChecker) CheckResponse(response *tetragon.GetEventsResponse) error {
+func (checker *ConfigSnapshotChecker) CheckResponse(response *tetragon.GetEventsResponse) error {
 	event, err := EventFromResponse(response)
 	if err != nil {
 		return err
@@ -1535,41 +3690,86 @@ func (checker *ProcessLoaderChecker) CheckResponse(response *tetragon.GetEventsR
 	return checker.CheckEvent(event)
 }
 
-// NewProcessLoaderChecker creates a new ProcessLoaderChecker
-func NewProcessLoaderChecker(name string) *ProcessLoaderChecker {
-	return &ProcessLoaderChecker{CheckerName: name}
+// NewConfigSnapshotChecker creates a new ConfigSnapshotChecker
+func NewConfigSnapshotChecker(name string) *ConfigSnapshotChecker {
+	return &ConfigSnapshotChecker{CheckerName: name}
 }
 
 // Get the name associated with the checker
-func (checker *ProcessLoaderChecker) GetCheckerName() string {
+func (checker *ConfigSnapshotChecker) GetCheckerName() string {
 	return checker.CheckerName
 }
 
 // Get the type of the checker as a string
-func (checker *ProcessLoaderChecker) GetCheckerType() string {
-	return "ProcessLoaderChecker"
+func (checker *ConfigSnapshotChecker) GetCheckerType() string {
+	return "ConfigSnapshotChecker"
 }
 
-// Check checks a ProcessLoader event
-func (checker *ProcessLoaderChecker) Check(event *tetragon.ProcessLoader) error {
+// Check checks a ConfigSnapshot event
+func (checker *ConfigSnapshotChecker) Check(event *tetragon.ConfigSnapshot) error {
 	if event == nil {
-		return fmt.Errorf("%s: ProcessLoader event is nil", CheckerLogPrefix(checker))
+		return fmt.Errorf("%s: ConfigSnapshot event is nil", CheckerLogPrefix(checker))
 	}
 
 	fieldChecks := func() error {
-		if checker.Process != nil {
-			if err := checker.Process.Check(event.Process); err != nil {
-				return fmt.Errorf("Process check failed: %w", err)
+		if checker.Version != nil {
+			if err := checker.Version.Match(event.Version); err != nil {
+				return fmt.Errorf("Version check failed: %w", err)
 			}
 		}
-		if checker.Path != nil {
-			if err := checker.Path.Match(event.Path); err != nil {
-				return fmt.Errorf("Path check failed: %w", err)
+		{
+			var unmatched []string
+			matched := make(map[string]struct{})
+			for key, value := range event.Flags {
+				if len(checker.Flags) > 0 {
+					// Attempt to grab the matcher for this key
+					if matcher, ok := checker.Flags[key]; ok {
+						if err := matcher.Match(value); err != nil {
+							return fmt.Errorf("Flags[%s] (%s=%s) check failed: %w", key, key, value, err)
+						}
+						matched[key] = struct{}{}
+					}
+				}
+			}
+
+			// See if we have any unmatched values that we wanted to match
+			if len(matched) != len(checker.Flags) {
+				for k := range checker.Flags {
+					if _, ok := matched[k]; !ok {
+						unmatched = append(unmatched, k)
+					}
+				}
+				return fmt.Errorf("Flags unmatched: %v", unmatched)
 			}
 		}
-		if checker.Buildid != nil {
-			if err := checker.Buildid.Match(event.Buildid); err != nil {
-				return fmt.Errorf("Buildid check failed: %w", err)
+		if checker.KernelRelease != nil {
+			if err := checker.KernelRelease.Match(event.KernelRelease); err != nil {
+				return fmt.Errorf("KernelRelease check failed: %w", err)
+			}
+		}
+		if checker.KernelVersion != nil {
+			if err := checker.KernelVersion.Match(event.KernelVersion); err != nil {
+				return fmt.Errorf("KernelVersion check failed: %w", err)
+			}
+		}
+		if checker.Machine != nil {
+			if err := checker.Machine.Match(event.Machine); err != nil {
+				return fmt.Errorf("Machine check failed: %w", err)
+			}
+		}
+		if checker.Policies != nil {
+			if err := checker.Policies.Check(event.Policies); err != nil {
+				return fmt.Errorf("Policies check failed: %w", err)
+			}
+		}
+		if checker.Hash != nil {
+			if err := checker.Hash.Match(event.Hash); err != nil {
+				return fmt.Errorf("Hash check failed: %w", err)
+			}
+		}
+		if checker.Changed != nil {
+			if *checker.Changed != event.Changed {
+				return fmt.Errorf("Changed has value %t which does not match expected value %t", event.Changed, *checker.Changed)
 			}
 		}
 		return nil
@@ -1580,53 +3780,205 @@ func (checker *ProcessLoaderChecker) Check(event *tetragon.ProcessLoader) error
 	return nil
 }
 
-// WithProcess adds a Process check to the ProcessLoaderChecker
-func (checker *ProcessLoaderChecker) WithProcess(check *ProcessChecker) *ProcessLoaderChecker {
-	checker.Process = check
+// WithVersion adds a Version check to the ConfigSnapshotChecker
+func (checker *ConfigSnapshotChecker) WithVersion(check *stringmatcher.StringMatcher) *ConfigSnapshotChecker {
+	checker.Version = check
 	return checker
 }
 
-// WithPath adds a Path check to the ProcessLoaderChecker
-func (checker *ProcessLoaderChecker) WithPath(check *stringmatcher.StringMatcher) *ProcessLoaderChecker {
-	checker.Path = check
+// WithFlags adds a Flags check to the ConfigSnapshotChecker
+func (checker *ConfigSnapshotChecker) WithFlags(check map[string]stringmatcher.StringMatcher) *ConfigSnapshotChecker {
+	checker.Flags = check
 	return checker
 }
 
-// WithBuildid adds a Buildid check to the ProcessLoaderChecker
-func (checker *ProcessLoaderChecker) WithBuildid(check *bytesmatcher.BytesMatcher) *ProcessLoaderChecker {
-	checker.Buildid = check
+// WithKernelRelease adds a KernelRelease check to the ConfigSnapshotChecker
+func (checker *ConfigSnapshotChecker) WithKernelRelease(check *stringmatcher.StringMatcher) *ConfigSnapshotChecker {
+	checker.KernelRelease = check
 	return checker
 }
 
-//FromProcessLoader populates the ProcessLoaderChecker using data from a ProcessLoader event
-func (checker *ProcessLoaderChecker) FromProcessLoader(event *tetragon.ProcessLoader) *ProcessLoaderChecker {
+// WithKernelVersion adds a KernelVersion check to the ConfigSnapshotChecker
+func (checker *ConfigSnapshotChecker) WithKernelVersion(check *stringmatcher.StringMatcher) *ConfigSnapshotChecker {
+	checker.KernelVersion = check
+	return checker
+}
+
+// WithMachine adds a Machine check to the ConfigSnapshotChecker
+func (checker *ConfigSnapshotChecker) WithMachine(check *stringmatcher.StringMatcher) *ConfigSnapshotChecker {
+	checker.Machine = check
+	return checker
+}
+
+// WithPolicies adds a Policies check to the ConfigSnapshotChecker
+func (checker *ConfigSnapshotChecker) WithPolicies(check *ConfigSnapshotPolicyListMatcher) *ConfigSnapshotChecker {
+	checker.Policies = check
+	return checker
+}
+
+// WithHash adds a Hash check to the ConfigSnapshotChecker
+func (checker *ConfigSnapshotChecker) WithHash(check *stringmatcher.StringMatcher) *ConfigSnapshotChecker {
+	checker.Hash = check
+	return checker
+}
+
+// WithChanged adds a Changed check to the ConfigSnapshotChecker
+func (checker *ConfigSnapshotChecker) WithChanged(check bool) *ConfigSnapshotChecker {
+	checker.Changed = &check
+	return checker
+}
+
+//FromConfigSnapshot populates the ConfigSnapshotChecker using data from a ConfigSnapshot event
+func (checker *ConfigSnapshotChecker) FromConfigSnapshot(event *tetragon.ConfigSnapshot) *ConfigSnapshotChecker {
 	if event == nil {
 		return checker
 	}
-	if event.Process != nil {
-		checker.Process = NewProcessChecker().FromProcess(event.Process)
+	checker.Version = stringmatcher.Full(event.Version)
+	// TODO: implement fromMap
+	checker.KernelRelease = stringmatcher.Full(event.KernelRelease)
+	checker.KernelVersion = stringmatcher.Full(event.KernelVersion)
+	checker.Machine = stringmatcher.Full(event.Machine)
+	{
+		var checks []*ConfigSnapshotPolicyChecker
+		for _, check := range event.Policies {
+			var convertedCheck *ConfigSnapshotPolicyChecker
+			if check != nil {
+				convertedCheck = NewConfigSnapshotPolicyChecker().FromConfigSnapshotPolicy(check)
+			}
+			checks = append(checks, convertedCheck)
+		}
+		lm := NewConfigSnapshotPolicyListMatcher().WithOperator(listmatcher.Ordered).
+			WithValues(checks...)
+		checker.Policies = lm
+	}
+	checker.Hash = stringmatcher.Full(event.Hash)
+	{
+		val := event.Changed
+		checker.Changed = &val
 	}
-	checker.Path = stringmatcher.Full(event.Path)
-	checker.Buildid = bytesmatcher.Full(event.Buildid)
 	return checker
 }
 
-// RateLimitInfoChecker implements a checker struct to check a RateLimitInfo event
-type RateLimitInfoChecker struct {
-	CheckerName                  string  `json:"checkerName"`
-	NumberOfDroppedProcessEvents *uint64 `json:"numberOfDroppedProcessEvents,omitempty"`
+// ConfigSnapshotPolicyListMatcher checks a list of *tetragon.ConfigSnapshotPolicy fields
+type ConfigSnapshotPolicyListMatcher struct {
+	Operator listmatcher.Operator           `json:"operator"`
+	Values   []*ConfigSnapshotPolicyChecker `json:"values"`
+}
+
+// NewConfigSnapshotPolicyListMatcher creates a new ConfigSnapshotPolicyListMatcher. The checker defaults to a subset checker unless otherwise specified using WithOperator()
+func NewConfigSnapshotPolicyListMatcher() *ConfigSnapshotPolicyListMatcher {
+	return &ConfigSnapshotPolicyListMatcher{
+		Operator: listmatcher.Subset,
+	}
+}
+
+// WithOperator sets the match kind for the ConfigSnapshotPolicyListMatcher
+func (checker *ConfigSnapshotPolicyListMatcher) WithOperator(operator listmatcher.Operator) *ConfigSnapshotPolicyListMatcher {
+	checker.Operator = operator
+	return checker
+}
+
+// WithValues sets the checkers that the ConfigSnapshotPolicyListMatcher should use
+func (checker *ConfigSnapshotPolicyListMatcher) WithValues(values ...*ConfigSnapshotPolicyChecker) *ConfigSnapshotPolicyListMatcher {
+	checker.Values = values
+	return checker
+}
+
+// Check checks a list of *tetragon.ConfigSnapshotPolicy fields
+func (checker *ConfigSnapshotPolicyListMatcher) Check(values []*tetragon.ConfigSnapshotPolicy) error {
+	switch checker.Operator {
+	case listmatcher.Ordered:
+		return checker.orderedCheck(values)
+	case listmatcher.Unordered:
+		return checker.unorderedCheck(values)
+	case listmatcher.Subset:
+		return checker.subsetCheck(values)
+	default:
+		return fmt.Errorf("Unhandled ListMatcher operator %s", checker.Operator)
+	}
+}
+
+// orderedCheck checks a list of ordered *tetragon.ConfigSnapshotPolicy fields
+func (checker *ConfigSnapshotPolicyListMatcher) orderedCheck(values []*tetragon.ConfigSnapshotPolicy) error {
+	innerCheck := func(check *ConfigSnapshotPolicyChecker, value *tetragon.ConfigSnapshotPolicy) error {
+		if err := check.Check(value); err != nil {
+			return fmt.Errorf("Policies check failed: %w", err)
+		}
+		return nil
+	}
+
+	if len(checker.Values) != len(values) {
+		return fmt.Errorf("ConfigSnapshotPolicyListMatcher: Wanted %d elements, got %d", len(checker.Values), len(values))
+	}
+
+	for i, check := range checker.Values {
+		value := values[i]
+		if err := innerCheck(check, value); err != nil {
+			return fmt.Errorf("ConfigSnapshotPolicyListMatcher: Check failed on element %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// unorderedCheck checks a list of unordered *tetragon.ConfigSnapshotPolicy fields
+func (checker *ConfigSnapshotPolicyListMatcher) unorderedCheck(values []*tetragon.ConfigSnapshotPolicy) error {
+	if len(checker.Values) != len(values) {
+		return fmt.Errorf("ConfigSnapshotPolicyListMatcher: Wanted %d elements, got %d", len(checker.Values), len(values))
+	}
+
+	return checker.subsetCheck(values)
+}
+
+// subsetCheck checks a subset of *tetragon.ConfigSnapshotPolicy fields
+func (checker *ConfigSnapshotPolicyListMatcher) subsetCheck(values []*tetragon.ConfigSnapshotPolicy) error {
+	innerCheck := func(check *ConfigSnapshotPolicyChecker, value *tetragon.ConfigSnapshotPolicy) error {
+		if err := check.Check(value); err != nil {
+			return fmt.Errorf("Policies check failed: %w", err)
+		}
+		return nil
+	}
+
+	numDesired := len(checker.Values)
+	numMatched := 0
+
+nextCheck:
+	for _, check := range checker.Values {
+		for _, value := range values {
+			if err := innerCheck(check, value); err == nil {
+				numMatched += 1
+				continue nextCheck
+			}
+		}
+	}
+
+	if numMatched < numDesired {
+		return fmt.Errorf("ConfigSnapshotPolicyListMatcher: Check failed, only matched %d elements but wanted %d", numMatched, numDesired)
+	}
+
+	return nil
+}
+
+// EventAnnotationChecker implements a checker struct to check a EventAnnotation event
+type EventAnnotationChecker struct {
+	CheckerName string                             `json:"checkerName"`
+	ExecId      *stringmatcher.StringMatcher       `json:"execId,omitempty"`
+	Verdict     *EventVerdictChecker               `json:"verdict,omitempty"`
+	Annotation  *stringmatcher.StringMatcher       `json:"annotation,omitempty"`
+	Author      *stringmatcher.StringMatcher       `json:"author,omitempty"`
+	Time        *timestampmatcher.TimestampMatcher `json:"time,omitempty"`
 }
 
 // CheckEvent checks a single event and implements the EventChecker interface
-func (checker *RateLimitInfoChecker) CheckEvent(event Event) error {
-	if ev, ok := event.(*tetragon.RateLimitInfo); ok {
+func (checker *EventAnnotationChecker) CheckEvent(event Event) error {
+	if ev, ok := event.(*tetragon.EventAnnotation); ok {
 		return checker.Check(ev)
 	}
-	return fmt.Errorf("%s: %T is not a RateLimitInfo event", CheckerLogPrefix(checker), event)
+	return fmt.Errorf("%s: %T is not a EventAnnotation event", CheckerLogPrefix(checker), event)
 }
 
 // CheckResponse checks a single gRPC response and implements the EventChecker interface
-func (checker *RateLimitInfoChecker) CheckResponse(response *tetragon.GetEventsResponse) error {
+func (checker *EventAnnotationChecker) CheckResponse(response *tetragon.GetEventsResponse) error {
 	event, err := EventFromResponse(response)
 	if err != nil {
 		return err
@@ -1634,31 +3986,51 @@ func (checker *RateLimitInfoChecker) CheckResponse(response *tetragon.GetEventsR
 	return checker.CheckEvent(event)
 }
 
-// NewRateLimitInfoChecker creates a new RateLimitInfoChecker
-func NewRateLimitInfoChecker(name string) *RateLimitInfoChecker {
-	return &RateLimitInfoChecker{CheckerName: name}
+// NewEventAnnotationChecker creates a new EventAnnotationChecker
+func NewEventAnnotationChecker(name string) *EventAnnotationChecker {
+	return &EventAnnotationChecker{CheckerName: name}
 }
 
 // Get the name associated with the checker
-func (checker *RateLimitInfoChecker) GetCheckerName() string {
+func (checker *EventAnnotationChecker) GetCheckerName() string {
 	return checker.CheckerName
 }
 
 // Get the type of the checker as a string
-func (checker *RateLimitInfoChecker) GetCheckerType() string {
-	return "RateLimitInfoChecker"
+func (checker *EventAnnotationChecker) GetCheckerType() string {
+	return "EventAnnotationChecker"
 }
 
-// Check checks a RateLimitInfo event
-func (checker *RateLimitInfoChecker) Check(event *tetragon.RateLimitInfo) error {
+// Check checks a EventAnnotation event
+func (checker *EventAnnotationChecker) Check(event *tetragon.EventAnnotation) error {
 	if event == nil {
-		return fmt.Errorf("%s: RateLimitInfo event is nil", CheckerLogPrefix(checker))
+		return fmt.Errorf("%s: EventAnnotation event is nil", CheckerLogPrefix(checker))
 	}
 
 	fieldChecks := func() error {
-		if checker.NumberOfDroppedProcessEvents != nil {
-			if *checker.NumberOfDroppedProcessEvents != event.NumberOfDroppedProcessEvents {
-				return fmt.Errorf("NumberOfDroppedProcessEvents has value %d which does not match expected value %d", event.NumberOfDroppedProcessEvents, *checker.NumberOfDroppedProcessEvents)
+		if checker.ExecId != nil {
+			if err := checker.ExecId.Match(event.ExecId); err != nil {
+				return fmt.Errorf("ExecId check failed: %w", err)
+			}
+		}
+		if checker.Verdict != nil {
+			if err := checker.Verdict.Check(&event.Verdict); err != nil {
+				return fmt.Errorf("Verdict check failed: %w", err)
+			}
+		}
+		if checker.Annotation != nil {
+			if err := checker.Annotation.Match(event.Annotation); err != nil {
+				return fmt.Errorf("Annotation check failed: %w", err)
+			}
+		}
+		if checker.Author != nil {
+			if err := checker.Author.Match(event.Author); err != nil {
+				return fmt.Errorf("Author check failed: %w", err)
+			}
+		}
+		if checker.Time != nil {
+			if err := checker.Time.Match(event.Time); err != nil {
+				return fmt.Errorf("Time check failed: %w", err)
 			}
 		}
 		return nil
@@ -1669,21 +4041,48 @@ func (checker *RateLimitInfoChecker) Check(event *tetragon.RateLimitInfo) error
 	return nil
 }
 
-// WithNumberOfDroppedProcessEvents adds a NumberOfDroppedProcessEvents check to the RateLimitInfoChecker
-func (checker *RateLimitInfoChecker) WithNumberOfDroppedProcessEvents(check uint64) *RateLimitInfoChecker {
-	checker.NumberOfDroppedProcessEvents = &check
+// WithExecId adds a ExecId check to the EventAnnotationChecker
+func (checker *EventAnnotationChecker) WithExecId(check *stringmatcher.StringMatcher) *EventAnnotationChecker {
+	checker.ExecId = check
 	return checker
 }
 
-//FromRateLimitInfo populates the RateLimitInfoChecker using data from a RateLimitInfo event
-func (checker *RateLimitInfoChecker) FromRateLimitInfo(event *tetragon.RateLimitInfo) *RateLimitInfoChecker {
+// WithVerdict adds a Verdict check to the EventAnnotationChecker
+func (checker *EventAnnotationChecker) WithVerdict(check tetragon.EventVerdict) *EventAnnotationChecker {
+	wrappedCheck := EventVerdictChecker(check)
+	checker.Verdict = &wrappedCheck
+	return checker
+}
+
+// WithAnnotation adds a Annotation check to the EventAnnotationChecker
+func (checker *EventAnnotationChecker) WithAnnotation(check *stringmatcher.StringMatcher) *EventAnnotationChecker {
+	checker.Annotation = check
+	return checker
+}
+
+// WithAuthor adds a Author check to the EventAnnotationChecker
+func (checker *EventAnnotationChecker) WithAuthor(check *stringmatcher.StringMatcher) *EventAnnotationChecker {
+	checker.Author = check
+	return checker
+}
+
+// WithTime adds a Time check to the EventAnnotationChecker
+func (checker *EventAnnotationChecker) WithTime(check *timestampmatcher.TimestampMatcher) *EventAnnotationChecker {
+	checker.Time = check
+	return checker
+}
+
+//FromEventAnnotation populates the EventAnnotationChecker using data from a EventAnnotation event
+func (checker *EventAnnotationChecker) FromEventAnnotation(event *tetragon.EventAnnotation) *EventAnnotationChecker {
 	if event == nil {
 		return checker
 	}
-	{
-		val := event.NumberOfDroppedProcessEvents
-		checker.NumberOfDroppedProcessEvents = &val
-	}
+	checker.ExecId = stringmatcher.Full(event.ExecId)
+	checker.Verdict = NewEventVerdictChecker(event.Verdict)
+	checker.Annotation = stringmatcher.Full(event.Annotation)
+	checker.Author = stringmatcher.Full(event.Author)
+	// NB: We don't want to match timestamps for now
+	checker.Time = nil
 	return checker
 }
 
@@ -2631,6 +5030,213 @@ func (checker *UserNamespaceChecker) FromUserNamespace(event *tetragon.UserNames
 	return checker
 }
 
+// UserRecordChecker implements a checker struct to check a UserRecord field
+type UserRecordChecker struct {
+	Name  *stringmatcher.StringMatcher `json:"name,omitempty"`
+	Group *stringmatcher.StringMatcher `json:"group,omitempty"`
+}
+
+// NewUserRecordChecker creates a new UserRecordChecker
+func NewUserRecordChecker() *UserRecordChecker {
+	return &UserRecordChecker{}
+}
+
+// Get the type of the checker as a string
+func (checker *UserRecordChecker) GetCheckerType() string {
+	return "UserRecordChecker"
+}
+
+// Check checks a UserRecord field
+func (checker *UserRecordChecker) Check(event *tetragon.UserRecord) error {
+	if event == nil {
+		return fmt.Errorf("%s: UserRecord field is nil", CheckerLogPrefix(checker))
+	}
+
+	fieldChecks := func() error {
+		if checker.Name != nil {
+			if err := checker.Name.Match(event.Name); err != nil {
+				return fmt.Errorf("Name check failed: %w", err)
+			}
+		}
+		if checker.Group != nil {
+			if err := checker.Group.Match(event.Group); err != nil {
+				return fmt.Errorf("Group check failed: %w", err)
+			}
+		}
+		return nil
+	}
+	if err := fieldChecks(); err != nil {
+		return fmt.Errorf("%s: %w", CheckerLogPrefix(checker), err)
+	}
+	return nil
+}
+
+// WithName adds a Name check to the UserRecordChecker
+func (checker *UserRecordChecker) WithName(check *stringmatcher.StringMatcher) *UserRecordChecker {
+	checker.Name = check
+	return checker
+}
+
+// WithGroup adds a Group check to the UserRecordChecker
+func (checker *UserRecordChecker) WithGroup(check *stringmatcher.StringMatcher) *UserRecordChecker {
+	checker.Group = check
+	return checker
+}
+
+//FromUserRecord populates the UserRecordChecker using data from a UserRecord field
+func (checker *UserRecordChecker) FromUserRecord(event *tetragon.UserRecord) *UserRecordChecker {
+	if event == nil {
+		return checker
+	}
+	checker.Name = stringmatcher.Full(event.Name)
+	checker.Group = stringmatcher.Full(event.Group)
+	return checker
+}
+
+// GoBuildInfoChecker implements a checker struct to check a GoBuildInfo field
+type GoBuildInfoChecker struct {
+	GoVersion     *stringmatcher.StringMatcher `json:"goVersion,omitempty"`
+	Path          *stringmatcher.StringMatcher `json:"path,omitempty"`
+	ModulePath    *stringmatcher.StringMatcher `json:"modulePath,omitempty"`
+	ModuleVersion *stringmatcher.StringMatcher `json:"moduleVersion,omitempty"`
+	ModuleSum     *stringmatcher.StringMatcher `json:"moduleSum,omitempty"`
+	VcsRevision   *stringmatcher.StringMatcher `json:"vcsRevision,omitempty"`
+	VcsTime       *stringmatcher.StringMatcher `json:"vcsTime,omitempty"`
+	VcsModified   *bool                        `json:"vcsModified,omitempty"`
+}
+
+// NewGoBuildInfoChecker creates a new GoBuildInfoChecker
+func NewGoBuildInfoChecker() *GoBuildInfoChecker {
+	return &GoBuildInfoChecker{}
+}
+
+// Get the type of the checker as a string
+func (checker *GoBuildInfoChecker) GetCheckerType() string {
+	return "GoBuildInfoChecker"
+}
+
+// Check checks a GoBuildInfo field
+func (checker *GoBuildInfoChecker) Check(event *tetragon.GoBuildInfo) error {
+	if event == nil {
+		return fmt.Errorf("%s: GoBuildInfo field is nil", CheckerLogPrefix(checker))
+	}
+
+	fieldChecks := func() error {
+		if checker.GoVersion != nil {
+			if err := checker.GoVersion.Match(event.GoVersion); err != nil {
+				return fmt.Errorf("GoVersion check failed: %w", err)
+			}
+		}
+		if checker.Path != nil {
+			if err := checker.Path.Match(event.Path); err != nil {
+				return fmt.Errorf("Path check failed: %w", err)
+			}
+		}
+		if checker.ModulePath != nil {
+			if err := checker.ModulePath.Match(event.ModulePath); err != nil {
+				return fmt.Errorf("ModulePath check failed: %w", err)
+			}
+		}
+		if checker.ModuleVersion != nil {
+			if err := checker.ModuleVersion.Match(event.ModuleVersion); err != nil {
+				return fmt.Errorf("ModuleVersion check failed: %w", err)
+			}
+		}
+		if checker.ModuleSum != nil {
+			if err := checker.ModuleSum.Match(event.ModuleSum); err != nil {
+				return fmt.Errorf("ModuleSum check failed: %w", err)
+			}
+		}
+		if checker.VcsRevision != nil {
+			if err := checker.VcsRevision.Match(event.VcsRevision); err != nil {
+				return fmt.Errorf("VcsRevision check failed: %w", err)
+			}
+		}
+		if checker.VcsTime != nil {
+			if err := checker.VcsTime.Match(event.VcsTime); err != nil {
+				return fmt.Errorf("VcsTime check failed: %w", err)
+			}
+		}
+		if checker.VcsModified != nil {
+			if *checker.VcsModified != event.VcsModified {
+				return fmt.Errorf("VcsModified has value %t which does not match expected value %t", event.VcsModified, *checker.VcsModified)
+			}
+		}
+		return nil
+	}
+	if err := fieldChecks(); err != nil {
+		return fmt.Errorf("%s: %w", CheckerLogPrefix(checker), err)
+	}
+	return nil
+}
+
+// WithGoVersion adds a GoVersion check to the GoBuildInfoChecker
+func (checker *GoBuildInfoChecker) WithGoVersion(check *stringmatcher.StringMatcher) *GoBuildInfoChecker {
+	checker.GoVersion = check
+	return checker
+}
+
+// WithPath adds a Path check to the GoBuildInfoChecker
+func (checker *GoBuildInfoChecker) WithPath(check *stringmatcher.StringMatcher) *GoBuildInfoChecker {
+	checker.Path = check
+	return checker
+}
+
+// WithModulePath adds a ModulePath check to the GoBuildInfoChecker
+func (checker *GoBuildInfoChecker) WithModulePath(check *stringmatcher.StringMatcher) *GoBuildInfoChecker {
+	checker.ModulePath = check
+	return checker
+}
+
+// WithModuleVersion adds a ModuleVersion check to the GoBuildInfoChecker
+func (checker *GoBuildInfoChecker) WithModuleVersion(check *stringmatcher.StringMatcher) *GoBuildInfoChecker {
+	checker.ModuleVersion = check
+	return checker
+}
+
+// WithModuleSum adds a ModuleSum check to the GoBuildInfoChecker
+func (checker *GoBuildInfoChecker) WithModuleSum(check *stringmatcher.StringMatcher) *GoBuildInfoChecker {
+	checker.ModuleSum = check
+	return checker
+}
+
+// WithVcsRevision adds a VcsRevision check to the GoBuildInfoChecker
+func (checker *GoBuildInfoChecker) WithVcsRevision(check *stringmatcher.StringMatcher) *GoBuildInfoChecker {
+	checker.VcsRevision = check
+	return checker
+}
+
+// WithVcsTime adds a VcsTime check to the GoBuildInfoChecker
+func (checker *GoBuildInfoChecker) WithVcsTime(check *stringmatcher.StringMatcher) *GoBuildInfoChecker {
+	checker.VcsTime = check
+	return checker
+}
+
+// WithVcsModified adds a VcsModified check to the GoBuildInfoChecker
+func (checker *GoBuildInfoChecker) WithVcsModified(check bool) *GoBuildInfoChecker {
+	checker.VcsModified = &check
+	return checker
+}
+
+//FromGoBuildInfo populates the GoBuildInfoChecker using data from a GoBuildInfo field
+func (checker *GoBuildInfoChecker) FromGoBuildInfo(event *tetragon.GoBuildInfo) *GoBuildInfoChecker {
+	if event == nil {
+		return checker
+	}
+	checker.GoVersion = stringmatcher.Full(event.GoVersion)
+	checker.Path = stringmatcher.Full(event.Path)
+	checker.ModulePath = stringmatcher.Full(event.ModulePath)
+	checker.ModuleVersion = stringmatcher.Full(event.ModuleVersion)
+	checker.ModuleSum = stringmatcher.Full(event.ModuleSum)
+	checker.VcsRevision = stringmatcher.Full(event.VcsRevision)
+	checker.VcsTime = stringmatcher.Full(event.VcsTime)
+	{
+		val := event.VcsModified
+		checker.VcsModified = &val
+	}
+	return checker
+}
+
 // ProcessCredentialsChecker implements a checker struct to check a ProcessCredentials field
 type ProcessCredentialsChecker struct {
 	Uid        *uint32                    `json:"uid,omitempty"`
@@ -3068,6 +5674,8 @@ type ProcessChecker struct {
 	Tid                *uint32                            `json:"tid,omitempty"`
 	ProcessCredentials *ProcessCredentialsChecker         `json:"processCredentials,omitempty"`
 	BinaryProperties   *BinaryPropertiesChecker           `json:"binaryProperties,omitempty"`
+	User               *UserRecordChecker                 `json:"user,omitempty"`
+	GoBuildInfo        *GoBuildInfoChecker                `json:"goBuildInfo,omitempty"`
 }
 
 // NewProcessChecker creates a new ProcessChecker
@@ -3189,6 +5797,16 @@ func (checker *ProcessChecker) Check(event *tetragon.Process) error {
 				return fmt.Errorf("BinaryProperties check failed: %w", err)
 			}
 		}
+		if checker.User != nil {
+			if err := checker.User.Check(event.User); err != nil {
+				return fmt.Errorf("User check failed: %w", err)
+			}
+		}
+		if checker.GoBuildInfo != nil {
+			if err := checker.GoBuildInfo.Check(event.GoBuildInfo); err != nil {
+				return fmt.Errorf("GoBuildInfo check failed: %w", err)
+			}
+		}
 		return nil
 	}
 	if err := fieldChecks(); err != nil {
@@ -3305,54 +5923,151 @@ func (checker *ProcessChecker) WithBinaryProperties(check *BinaryPropertiesCheck
 	return checker
 }
 
-//FromProcess populates the ProcessChecker using data from a Process field
-func (checker *ProcessChecker) FromProcess(event *tetragon.Process) *ProcessChecker {
+// WithUser adds a User check to the ProcessChecker
+func (checker *ProcessChecker) WithUser(check *UserRecordChecker) *ProcessChecker {
+	checker.User = check
+	return checker
+}
+
+// WithGoBuildInfo adds a GoBuildInfo check to the ProcessChecker
+func (checker *ProcessChecker) WithGoBuildInfo(check *GoBuildInfoChecker) *ProcessChecker {
+	checker.GoBuildInfo = check
+	return checker
+}
+
+//FromProcess populates the ProcessChecker using data from a Process field
+func (checker *ProcessChecker) FromProcess(event *tetragon.Process) *ProcessChecker {
+	if event == nil {
+		return checker
+	}
+	checker.ExecId = stringmatcher.Full(event.ExecId)
+	if event.Pid != nil {
+		val := event.Pid.Value
+		checker.Pid = &val
+	}
+	if event.Uid != nil {
+		val := event.Uid.Value
+		checker.Uid = &val
+	}
+	checker.Cwd = stringmatcher.Full(event.Cwd)
+	checker.Binary = stringmatcher.Full(event.Binary)
+	checker.Arguments = stringmatcher.Full(event.Arguments)
+	checker.Flags = stringmatcher.Full(event.Flags)
+	// NB: We don't want to match timestamps for now
+	checker.StartTime = nil
+	if event.Auid != nil {
+		val := event.Auid.Value
+		checker.Auid = &val
+	}
+	if event.Pod != nil {
+		checker.Pod = NewPodChecker().FromPod(event.Pod)
+	}
+	checker.Docker = stringmatcher.Full(event.Docker)
+	checker.ParentExecId = stringmatcher.Full(event.ParentExecId)
+	{
+		val := event.Refcnt
+		checker.Refcnt = &val
+	}
+	if event.Cap != nil {
+		checker.Cap = NewCapabilitiesChecker().FromCapabilities(event.Cap)
+	}
+	if event.Ns != nil {
+		checker.Ns = NewNamespacesChecker().FromNamespaces(event.Ns)
+	}
+	if event.Tid != nil {
+		val := event.Tid.Value
+		checker.Tid = &val
+	}
+	if event.ProcessCredentials != nil {
+		checker.ProcessCredentials = NewProcessCredentialsChecker().FromProcessCredentials(event.ProcessCredentials)
+	}
+	if event.BinaryProperties != nil {
+		checker.BinaryProperties = NewBinaryPropertiesChecker().FromBinaryProperties(event.BinaryProperties)
+	}
+	if event.User != nil {
+		checker.User = NewUserRecordChecker().FromUserRecord(event.User)
+	}
+	if event.GoBuildInfo != nil {
+		checker.GoBuildInfo = NewGoBuildInfoChecker().FromGoBuildInfo(event.GoBuildInfo)
+	}
+	return checker
+}
+
+// KprobeSockaddrChecker implements a checker struct to check a KprobeSockaddr field
+type KprobeSockaddrChecker struct {
+	Family *stringmatcher.StringMatcher `json:"family,omitempty"`
+	Addr   *stringmatcher.StringMatcher `json:"addr,omitempty"`
+	Port   *uint32                      `json:"port,omitempty"`
+}
+
+// NewKprobeSockaddrChecker creates a new KprobeSockaddrChecker
+func NewKprobeSockaddrChecker() *KprobeSockaddrChecker {
+	return &KprobeSockaddrChecker{}
+}
+
+// Get the type of the checker as a string
+func (checker *KprobeSockaddrChecker) GetCheckerType() string {
+	return "KprobeSockaddrChecker"
+}
+
+// Check checks a KprobeSockaddr field
+func (checker *KprobeSockaddrChecker) Check(event *tetragon.KprobeSockaddr) error {
+	if event == nil {
+		return fmt.Errorf("%s: KprobeSockaddr field is nil", CheckerLogPrefix(checker))
+	}
+
+	fieldChecks := func() error {
+		if checker.Family != nil {
+			if err := checker.Family.Match(event.Family); err != nil {
+				return fmt.Errorf("Family check failed: %w", err)
+			}
+		}
+		if checker.Addr != nil {
+			if err := checker.Addr.Match(event.Addr); err != nil {
+				return fmt.Errorf("Addr check failed: %w", err)
+			}
+		}
+		if checker.Port != nil {
+			if *checker.Port != event.Port {
+				return fmt.Errorf("Port has value %d which does not match expected value %d", event.Port, *checker.Port)
+			}
+		}
+		return nil
+	}
+	if err := fieldChecks(); err != nil {
+		return fmt.Errorf("%s: %w", CheckerLogPrefix(checker), err)
+	}
+	return nil
+}
+
+// WithFamily adds a Family check to the KprobeSockaddrChecker
+func (checker *KprobeSockaddrChecker) WithFamily(check *stringmatcher.StringMatcher) *KprobeSockaddrChecker {
+	checker.Family = check
+	return checker
+}
+
+// WithAddr adds a Addr check to the KprobeSockaddrChecker
+func (checker *KprobeSockaddrChecker) WithAddr(check *stringmatcher.StringMatcher) *KprobeSockaddrChecker {
+	checker.Addr = check
+	return checker
+}
+
+// WithPort adds a Port check to the KprobeSockaddrChecker
+func (checker *KprobeSockaddrChecker) WithPort(check uint32) *KprobeSockaddrChecker {
+	checker.Port = &check
+	return checker
+}
+
+//FromKprobeSockaddr populates the KprobeSockaddrChecker using data from a KprobeSockaddr field
+func (checker *KprobeSockaddrChecker) FromKprobeSockaddr(event *tetragon.KprobeSockaddr) *KprobeSockaddrChecker {
 	if event == nil {
 		return checker
 	}
-	checker.ExecId = stringmatcher.Full(event.ExecId)
-	if event.Pid != nil {
-		val := event.Pid.Value
-		checker.Pid = &val
-	}
-	if event.Uid != nil {
-		val := event.Uid.Value
-		checker.Uid = &val
-	}
-	checker.Cwd = stringmatcher.Full(event.Cwd)
-	checker.Binary = stringmatcher.Full(event.Binary)
-	checker.Arguments = stringmatcher.Full(event.Arguments)
-	checker.Flags = stringmatcher.Full(event.Flags)
-	// NB: We don't want to match timestamps for now
-	checker.StartTime = nil
-	if event.Auid != nil {
-		val := event.Auid.Value
-		checker.Auid = &val
-	}
-	if event.Pod != nil {
-		checker.Pod = NewPodChecker().FromPod(event.Pod)
-	}
-	checker.Docker = stringmatcher.Full(event.Docker)
-	checker.ParentExecId = stringmatcher.Full(event.ParentExecId)
+	checker.Family = stringmatcher.Full(event.Family)
+	checker.Addr = stringmatcher.Full(event.Addr)
 	{
-		val := event.Refcnt
-		checker.Refcnt = &val
-	}
-	if event.Cap != nil {
-		checker.Cap = NewCapabilitiesChecker().FromCapabilities(event.Cap)
-	}
-	if event.Ns != nil {
-		checker.Ns = NewNamespacesChecker().FromNamespaces(event.Ns)
-	}
-	if event.Tid != nil {
-		val := event.Tid.Value
-		checker.Tid = &val
-	}
-	if event.ProcessCredentials != nil {
-		checker.ProcessCredentials = NewProcessCredentialsChecker().FromProcessCredentials(event.ProcessCredentials)
-	}
-	if event.BinaryProperties != nil {
-		checker.BinaryProperties = NewBinaryPropertiesChecker().FromBinaryProperties(event.BinaryProperties)
+		val := event.Port
+		checker.Port = &val
 	}
 	return checker
 }
@@ -3567,6 +6282,8 @@ type KprobeSkbChecker struct {
 	SecPathOlen *uint32                      `json:"secPathOlen,omitempty"`
 	Protocol    *stringmatcher.StringMatcher `json:"protocol,omitempty"`
 	Family      *stringmatcher.StringMatcher `json:"family,omitempty"`
+	IcmpType    *uint32                      `json:"icmpType,omitempty"`
+	IcmpCode    *uint32                      `json:"icmpCode,omitempty"`
 }
 
 // NewKprobeSkbChecker creates a new KprobeSkbChecker
@@ -3651,6 +6368,16 @@ func (checker *KprobeSkbChecker) Check(event *tetragon.KprobeSkb) error {
 				return fmt.Errorf("Family check failed: %w", err)
 			}
 		}
+		if checker.IcmpType != nil {
+			if *checker.IcmpType != event.IcmpType {
+				return fmt.Errorf("IcmpType has value %d which does not match expected value %d", event.IcmpType, *checker.IcmpType)
+			}
+		}
+		if checker.IcmpCode != nil {
+			if *checker.IcmpCode != event.IcmpCode {
+				return fmt.Errorf("IcmpCode has value %d which does not match expected value %d", event.IcmpCode, *checker.IcmpCode)
+			}
+		}
 		return nil
 	}
 	if err := fieldChecks(); err != nil {
@@ -3737,6 +6464,18 @@ func (checker *KprobeSkbChecker) WithFamily(check *stringmatcher.StringMatcher)
 	return checker
 }
 
+// WithIcmpType adds a IcmpType check to the KprobeSkbChecker
+func (checker *KprobeSkbChecker) WithIcmpType(check uint32) *KprobeSkbChecker {
+	checker.IcmpType = &check
+	return checker
+}
+
+// WithIcmpCode adds a IcmpCode check to the KprobeSkbChecker
+func (checker *KprobeSkbChecker) WithIcmpCode(check uint32) *KprobeSkbChecker {
+	checker.IcmpCode = &check
+	return checker
+}
+
 //FromKprobeSkb populates the KprobeSkbChecker using data from a KprobeSkb field
 func (checker *KprobeSkbChecker) FromKprobeSkb(event *tetragon.KprobeSkb) *KprobeSkbChecker {
 	if event == nil {
@@ -3782,6 +6521,14 @@ func (checker *KprobeSkbChecker) FromKprobeSkb(event *tetragon.KprobeSkb) *Kprob
 	}
 	checker.Protocol = stringmatcher.Full(event.Protocol)
 	checker.Family = stringmatcher.Full(event.Family)
+	{
+		val := event.IcmpType
+		checker.IcmpType = &val
+	}
+	{
+		val := event.IcmpCode
+		checker.IcmpCode = &val
+	}
 	return checker
 }
 
@@ -3937,6 +6684,145 @@ func (checker *KprobeFileChecker) FromKprobeFile(event *tetragon.KprobeFile) *Kp
 	return checker
 }
 
+// KprobeLinuxBinprmChecker implements a checker struct to check a KprobeLinuxBinprm field
+type KprobeLinuxBinprmChecker struct {
+	Path  *stringmatcher.StringMatcher `json:"path,omitempty"`
+	Flags *stringmatcher.StringMatcher `json:"flags,omitempty"`
+}
+
+// NewKprobeLinuxBinprmChecker creates a new KprobeLinuxBinprmChecker
+func NewKprobeLinuxBinprmChecker() *KprobeLinuxBinprmChecker {
+	return &KprobeLinuxBinprmChecker{}
+}
+
+// Get the type of the checker as a string
+func (checker *KprobeLinuxBinprmChecker) GetCheckerType() string {
+	return "KprobeLinuxBinprmChecker"
+}
+
+// Check checks a KprobeLinuxBinprm field
+func (checker *KprobeLinuxBinprmChecker) Check(event *tetragon.KprobeLinuxBinprm) error {
+	if event == nil {
+		return fmt.Errorf("%s: KprobeLinuxBinprm field is nil", CheckerLogPrefix(checker))
+	}
+
+	fieldChecks := func() error {
+		if checker.Path != nil {
+			if err := checker.Path.Match(event.Path); err != nil {
+				return fmt.Errorf("Path check failed: %w", err)
+			}
+		}
+		if checker.Flags != nil {
+			if err := checker.Flags.Match(event.Flags); err != nil {
+				return fmt.Errorf("Flags check failed: %w", err)
+			}
+		}
+		return nil
+	}
+	if err := fieldChecks(); err != nil {
+		return fmt.Errorf("%s: %w", CheckerLogPrefix(checker), err)
+	}
+	return nil
+}
+
+// WithPath adds a Path check to the KprobeLinuxBinprmChecker
+func (checker *KprobeLinuxBinprmChecker) WithPath(check *stringmatcher.StringMatcher) *KprobeLinuxBinprmChecker {
+	checker.Path = check
+	return checker
+}
+
+// WithFlags adds a Flags check to the KprobeLinuxBinprmChecker
+func (checker *KprobeLinuxBinprmChecker) WithFlags(check *stringmatcher.StringMatcher) *KprobeLinuxBinprmChecker {
+	checker.Flags = check
+	return checker
+}
+
+//FromKprobeLinuxBinprm populates the KprobeLinuxBinprmChecker using data from a KprobeLinuxBinprm field
+func (checker *KprobeLinuxBinprmChecker) FromKprobeLinuxBinprm(event *tetragon.KprobeLinuxBinprm) *KprobeLinuxBinprmChecker {
+	if event == nil {
+		return checker
+	}
+	checker.Path = stringmatcher.Full(event.Path)
+	checker.Flags = stringmatcher.Full(event.Flags)
+	return checker
+}
+
+// KprobeStringArrayChecker implements a checker struct to check a KprobeStringArray field
+type KprobeStringArrayChecker struct {
+	Values    *StringListMatcher `json:"values,omitempty"`
+	Truncated *bool              `json:"truncated,omitempty"`
+}
+
+// NewKprobeStringArrayChecker creates a new KprobeStringArrayChecker
+func NewKprobeStringArrayChecker() *KprobeStringArrayChecker {
+	return &KprobeStringArrayChecker{}
+}
+
+// Get the type of the checker as a string
+func (checker *KprobeStringArrayChecker) GetCheckerType() string {
+	return "KprobeStringArrayChecker"
+}
+
+// Check checks a KprobeStringArray field
+func (checker *KprobeStringArrayChecker) Check(event *tetragon.KprobeStringArray) error {
+	if event == nil {
+		return fmt.Errorf("%s: KprobeStringArray field is nil", CheckerLogPrefix(checker))
+	}
+
+	fieldChecks := func() error {
+		if checker.Values != nil {
+			if err := checker.Values.Check(event.Values); err != nil {
+				return fmt.Errorf("Values check failed: %w", err)
+			}
+		}
+		if checker.Truncated != nil {
+			if *checker.Truncated != event.Truncated {
+				return fmt.Errorf("Truncated has value %t which does not match expected value %t", event.Truncated, *checker.Truncated)
+			}
+		}
+		return nil
+	}
+	if err := fieldChecks(); err != nil {
+		return fmt.Errorf("%s: %w", CheckerLogPrefix(checker), err)
+	}
+	return nil
+}
+
+// WithValues adds a Values check to the KprobeStringArrayChecker
+func (checker *KprobeStringArrayChecker) WithValues(check *StringListMatcher) *KprobeStringArrayChecker {
+	checker.Values = check
+	return checker
+}
+
+// WithTruncated adds a Truncated check to the KprobeStringArrayChecker
+func (checker *KprobeStringArrayChecker) WithTruncated(check bool) *KprobeStringArrayChecker {
+	checker.Truncated = &check
+	return checker
+}
+
+//FromKprobeStringArray populates the KprobeStringArrayChecker using data from a KprobeStringArray field
+func (checker *KprobeStringArrayChecker) FromKprobeStringArray(event *tetragon.KprobeStringArray) *KprobeStringArrayChecker {
+	if event == nil {
+		return checker
+	}
+	{
+		var checks []*stringmatcher.StringMatcher
+		for _, check := range event.Values {
+			var convertedCheck *stringmatcher.StringMatcher
+			convertedCheck = stringmatcher.Full(check)
+			checks = append(checks, convertedCheck)
+		}
+		lm := NewStringListMatcher().WithOperator(listmatcher.Ordered).
+			WithValues(checks...)
+		checker.Values = lm
+	}
+	{
+		val := event.Truncated
+		checker.Truncated = &val
+	}
+	return checker
+}
+
 // KprobeTruncatedBytesChecker implements a checker struct to check a KprobeTruncatedBytes field
 type KprobeTruncatedBytesChecker struct {
 	BytesArg *bytesmatcher.BytesMatcher `json:"bytesArg,omitempty"`
@@ -4594,6 +7480,9 @@ type KprobeArgumentChecker struct {
 	ProcessCredentialsArg *ProcessCredentialsChecker   `json:"processCredentialsArg,omitempty"`
 	UserNsArg             *UserNamespaceChecker        `json:"userNsArg,omitempty"`
 	ModuleArg             *KernelModuleChecker         `json:"moduleArg,omitempty"`
+	SockaddrArg           *KprobeSockaddrChecker       `json:"sockaddrArg,omitempty"`
+	LinuxBinprmArg        *KprobeLinuxBinprmChecker    `json:"linuxBinprmArg,omitempty"`
+	StringArrayArg        *KprobeStringArrayChecker    `json:"stringArrayArg,omitempty"`
 	Label                 *stringmatcher.StringMatcher `json:"label,omitempty"`
 }
 
@@ -4814,6 +7703,36 @@ func (checker *KprobeArgumentChecker) Check(event *tetragon.KprobeArgument) erro
 				return fmt.Errorf("KprobeArgumentChecker: ModuleArg check failed: %T is not a ModuleArg", event)
 			}
 		}
+		if checker.SockaddrArg != nil {
+			switch event := event.Arg.(type) {
+			case *tetragon.KprobeArgument_SockaddrArg:
+				if err := checker.SockaddrArg.Check(event.SockaddrArg); err != nil {
+					return fmt.Errorf("SockaddrArg check failed: %w", err)
+				}
+			default:
+				return fmt.Errorf("KprobeArgumentChecker: SockaddrArg check failed: %T is not a SockaddrArg", event)
+			}
+		}
+		if checker.LinuxBinprmArg != nil {
+			switch event := event.Arg.(type) {
+			case *tetragon.KprobeArgument_LinuxBinprmArg:
+				if err := checker.LinuxBinprmArg.Check(event.LinuxBinprmArg); err != nil {
+					return fmt.Errorf("LinuxBinprmArg check failed: %w", err)
+				}
+			default:
+				return fmt.Errorf("KprobeArgumentChecker: LinuxBinprmArg check failed: %T is not a LinuxBinprmArg", event)
+			}
+		}
+		if checker.StringArrayArg != nil {
+			switch event := event.Arg.(type) {
+			case *tetragon.KprobeArgument_StringArrayArg:
+				if err := checker.StringArrayArg.Check(event.StringArrayArg); err != nil {
+					return fmt.Errorf("StringArrayArg check failed: %w", err)
+				}
+			default:
+				return fmt.Errorf("KprobeArgumentChecker: StringArrayArg check failed: %T is not a StringArrayArg", event)
+			}
+		}
 		if checker.Label != nil {
 			if err := checker.Label.Match(event.Label); err != nil {
 				return fmt.Errorf("Label check failed: %w", err)
@@ -4947,6 +7866,24 @@ func (checker *KprobeArgumentChecker) WithModuleArg(check *KernelModuleChecker)
 	return checker
 }
 
+// WithSockaddrArg adds a SockaddrArg check to the KprobeArgumentChecker
+func (checker *KprobeArgumentChecker) WithSockaddrArg(check *KprobeSockaddrChecker) *KprobeArgumentChecker {
+	checker.SockaddrArg = check
+	return checker
+}
+
+// WithLinuxBinprmArg adds a LinuxBinprmArg check to the KprobeArgumentChecker
+func (checker *KprobeArgumentChecker) WithLinuxBinprmArg(check *KprobeLinuxBinprmChecker) *KprobeArgumentChecker {
+	checker.LinuxBinprmArg = check
+	return checker
+}
+
+// WithStringArrayArg adds a StringArrayArg check to the KprobeArgumentChecker
+func (checker *KprobeArgumentChecker) WithStringArrayArg(check *KprobeStringArrayChecker) *KprobeArgumentChecker {
+	checker.StringArrayArg = check
+	return checker
+}
+
 // WithLabel adds a Label check to the KprobeArgumentChecker
 func (checker *KprobeArgumentChecker) WithLabel(check *stringmatcher.StringMatcher) *KprobeArgumentChecker {
 	checker.Label = check
@@ -5048,37 +7985,200 @@ func (checker *KprobeArgumentChecker) FromKprobeArgument(event *tetragon.KprobeA
 			checker.UintArg = &val
 		}
 	}
-	switch event := event.Arg.(type) {
-	case *tetragon.KprobeArgument_UserNamespaceArg:
-		if event.UserNamespaceArg != nil {
-			checker.UserNamespaceArg = NewKprobeUserNamespaceChecker().FromKprobeUserNamespace(event.UserNamespaceArg)
-		}
+	switch event := event.Arg.(type) {
+	case *tetragon.KprobeArgument_UserNamespaceArg:
+		if event.UserNamespaceArg != nil {
+			checker.UserNamespaceArg = NewKprobeUserNamespaceChecker().FromKprobeUserNamespace(event.UserNamespaceArg)
+		}
+	}
+	switch event := event.Arg.(type) {
+	case *tetragon.KprobeArgument_CapabilityArg:
+		if event.CapabilityArg != nil {
+			checker.CapabilityArg = NewKprobeCapabilityChecker().FromKprobeCapability(event.CapabilityArg)
+		}
+	}
+	switch event := event.Arg.(type) {
+	case *tetragon.KprobeArgument_ProcessCredentialsArg:
+		if event.ProcessCredentialsArg != nil {
+			checker.ProcessCredentialsArg = NewProcessCredentialsChecker().FromProcessCredentials(event.ProcessCredentialsArg)
+		}
+	}
+	switch event := event.Arg.(type) {
+	case *tetragon.KprobeArgument_UserNsArg:
+		if event.UserNsArg != nil {
+			checker.UserNsArg = NewUserNamespaceChecker().FromUserNamespace(event.UserNsArg)
+		}
+	}
+	switch event := event.Arg.(type) {
+	case *tetragon.KprobeArgument_ModuleArg:
+		if event.ModuleArg != nil {
+			checker.ModuleArg = NewKernelModuleChecker().FromKernelModule(event.ModuleArg)
+		}
+	}
+	switch event := event.Arg.(type) {
+	case *tetragon.KprobeArgument_SockaddrArg:
+		if event.SockaddrArg != nil {
+			checker.SockaddrArg = NewKprobeSockaddrChecker().FromKprobeSockaddr(event.SockaddrArg)
+		}
+	}
+	switch event := event.Arg.(type) {
+	case *tetragon.KprobeArgument_LinuxBinprmArg:
+		if event.LinuxBinprmArg != nil {
+			checker.LinuxBinprmArg = NewKprobeLinuxBinprmChecker().FromKprobeLinuxBinprm(event.LinuxBinprmArg)
+		}
+	}
+	switch event := event.Arg.(type) {
+	case *tetragon.KprobeArgument_StringArrayArg:
+		if event.StringArrayArg != nil {
+			checker.StringArrayArg = NewKprobeStringArrayChecker().FromKprobeStringArray(event.StringArrayArg)
+		}
+	}
+	checker.Label = stringmatcher.Full(event.Label)
+	return checker
+}
+
+// AuditActionChecker implements a checker struct to check a AuditAction field
+type AuditActionChecker struct {
+	Action   *KprobeActionChecker `json:"action,omitempty"`
+	Selector *uint32              `json:"selector,omitempty"`
+}
+
+// NewAuditActionChecker creates a new AuditActionChecker
+func NewAuditActionChecker() *AuditActionChecker {
+	return &AuditActionChecker{}
+}
+
+// Get the type of the checker as a string
+func (checker *AuditActionChecker) GetCheckerType() string {
+	return "AuditActionChecker"
+}
+
+// Check checks a AuditAction field
+func (checker *AuditActionChecker) Check(event *tetragon.AuditAction) error {
+	if event == nil {
+		return fmt.Errorf("%s: AuditAction field is nil", CheckerLogPrefix(checker))
+	}
+
+	fieldChecks := func() error {
+		if checker.Action != nil {
+			if err := checker.Action.Check(&event.Action); err != nil {
+				return fmt.Errorf("Action check failed: %w", err)
+			}
+		}
+		if checker.Selector != nil {
+			if *checker.Selector != event.Selector {
+				return fmt.Errorf("Selector has value %d which does not match expected value %d", event.Selector, *checker.Selector)
+			}
+		}
+		return nil
+	}
+	if err := fieldChecks(); err != nil {
+		return fmt.Errorf("%s: %w", CheckerLogPrefix(checker), err)
+	}
+	return nil
+}
+
+// WithAction adds a Action check to the AuditActionChecker
+func (checker *AuditActionChecker) WithAction(check tetragon.KprobeAction) *AuditActionChecker {
+	wrappedCheck := KprobeActionChecker(check)
+	checker.Action = &wrappedCheck
+	return checker
+}
+
+// WithSelector adds a Selector check to the AuditActionChecker
+func (checker *AuditActionChecker) WithSelector(check uint32) *AuditActionChecker {
+	checker.Selector = &check
+	return checker
+}
+
+//FromAuditAction populates the AuditActionChecker using data from a AuditAction field
+func (checker *AuditActionChecker) FromAuditAction(event *tetragon.AuditAction) *AuditActionChecker {
+	if event == nil {
+		return checker
+	}
+	checker.Action = NewKprobeActionChecker(event.Action)
+	{
+		val := event.Selector
+		checker.Selector = &val
+	}
+	return checker
+}
+
+// MiningSignalChecker implements a checker struct to check a MiningSignal field
+type MiningSignalChecker struct {
+	Type   *MiningSignalTypeChecker           `json:"type,omitempty"`
+	Detail *stringmatcher.StringMatcher       `json:"detail,omitempty"`
+	Time   *timestampmatcher.TimestampMatcher `json:"time,omitempty"`
+}
+
+// NewMiningSignalChecker creates a new MiningSignalChecker
+func NewMiningSignalChecker() *MiningSignalChecker {
+	return &MiningSignalChecker{}
+}
+
+// Get the type of the checker as a string
+func (checker *MiningSignalChecker) GetCheckerType() string {
+	return "MiningSignalChecker"
+}
+
+// Check checks a MiningSignal field
+func (checker *MiningSignalChecker) Check(event *tetragon.MiningSignal) error {
+	if event == nil {
+		return fmt.Errorf("%s: MiningSignal field is nil", CheckerLogPrefix(checker))
 	}
-	switch event := event.Arg.(type) {
-	case *tetragon.KprobeArgument_CapabilityArg:
-		if event.CapabilityArg != nil {
-			checker.CapabilityArg = NewKprobeCapabilityChecker().FromKprobeCapability(event.CapabilityArg)
+
+	fieldChecks := func() error {
+		if checker.Type != nil {
+			if err := checker.Type.Check(&event.Type); err != nil {
+				return fmt.Errorf("Type check failed: %w", err)
+			}
 		}
-	}
-	switch event := event.Arg.(type) {
-	case *tetragon.KprobeArgument_ProcessCredentialsArg:
-		if event.ProcessCredentialsArg != nil {
-			checker.ProcessCredentialsArg = NewProcessCredentialsChecker().FromProcessCredentials(event.ProcessCredentialsArg)
+		if checker.Detail != nil {
+			if err := checker.Detail.Match(event.Detail); err != nil {
+				return fmt.Errorf("Detail check failed: %w", err)
+			}
 		}
-	}
-	switch event := event.Arg.(type) {
-	case *tetragon.KprobeArgument_UserNsArg:
-		if event.UserNsArg != nil {
-			checker.UserNsArg = NewUserNamespaceChecker().FromUserNamespace(event.UserNsArg)
+		if checker.Time != nil {
+			if err := checker.Time.Match(event.Time); err != nil {
+				return fmt.Errorf("Time check failed: %w", err)
+			}
 		}
+		return nil
 	}
-	switch event := event.Arg.(type) {
-	case *tetragon.KprobeArgument_ModuleArg:
-		if event.ModuleArg != nil {
-			checker.ModuleArg = NewKernelModuleChecker().FromKernelModule(event.ModuleArg)
-		}
+	if err := fieldChecks(); err != nil {
+		return fmt.Errorf("%s: %w", CheckerLogPrefix(checker), err)
 	}
-	checker.Label = stringmatcher.Full(event.Label)
+	return nil
+}
+
+// WithType adds a Type check to the MiningSignalChecker
+func (checker *MiningSignalChecker) WithType(check tetragon.MiningSignalType) *MiningSignalChecker {
+	wrappedCheck := MiningSignalTypeChecker(check)
+	checker.Type = &wrappedCheck
+	return checker
+}
+
+// WithDetail adds a Detail check to the MiningSignalChecker
+func (checker *MiningSignalChecker) WithDetail(check *stringmatcher.StringMatcher) *MiningSignalChecker {
+	checker.Detail = check
+	return checker
+}
+
+// WithTime adds a Time check to the MiningSignalChecker
+func (checker *MiningSignalChecker) WithTime(check *timestampmatcher.TimestampMatcher) *MiningSignalChecker {
+	checker.Time = check
+	return checker
+}
+
+//FromMiningSignal populates the MiningSignalChecker using data from a MiningSignal field
+func (checker *MiningSignalChecker) FromMiningSignal(event *tetragon.MiningSignal) *MiningSignalChecker {
+	if event == nil {
+		return checker
+	}
+	checker.Type = NewMiningSignalTypeChecker(event.Type)
+	checker.Detail = stringmatcher.Full(event.Detail)
+	// NB: We don't want to match timestamps for now
+	checker.Time = nil
 	return checker
 }
 
@@ -5279,6 +8379,7 @@ type StackTraceEntryChecker struct {
 	Address *uint64                      `json:"address,omitempty"`
 	Offset  *uint64                      `json:"offset,omitempty"`
 	Symbol  *stringmatcher.StringMatcher `json:"symbol,omitempty"`
+	Module  *stringmatcher.StringMatcher `json:"module,omitempty"`
 }
 
 // NewStackTraceEntryChecker creates a new StackTraceEntryChecker
@@ -5313,6 +8414,11 @@ func (checker *StackTraceEntryChecker) Check(event *tetragon.StackTraceEntry) er
 				return fmt.Errorf("Symbol check failed: %w", err)
 			}
 		}
+		if checker.Module != nil {
+			if err := checker.Module.Match(event.Module); err != nil {
+				return fmt.Errorf("Module check failed: %w", err)
+			}
+		}
 		return nil
 	}
 	if err := fieldChecks(); err != nil {
@@ -5339,6 +8445,12 @@ func (checker *StackTraceEntryChecker) WithSymbol(check *stringmatcher.StringMat
 	return checker
 }
 
+// WithModule adds a Module check to the StackTraceEntryChecker
+func (checker *StackTraceEntryChecker) WithModule(check *stringmatcher.StringMatcher) *StackTraceEntryChecker {
+	checker.Module = check
+	return checker
+}
+
 //FromStackTraceEntry populates the StackTraceEntryChecker using data from a StackTraceEntry field
 func (checker *StackTraceEntryChecker) FromStackTraceEntry(event *tetragon.StackTraceEntry) *StackTraceEntryChecker {
 	if event == nil {
@@ -5353,6 +8465,212 @@ func (checker *StackTraceEntryChecker) FromStackTraceEntry(event *tetragon.Stack
 		checker.Offset = &val
 	}
 	checker.Symbol = stringmatcher.Full(event.Symbol)
+	checker.Module = stringmatcher.Full(event.Module)
+	return checker
+}
+
+// CgroupEventRateChecker implements a checker struct to check a CgroupEventRate field
+type CgroupEventRateChecker struct {
+	CgroupId   *uint64                      `json:"cgroupId,omitempty"`
+	CgroupPath *stringmatcher.StringMatcher `json:"cgroupPath,omitempty"`
+	Pod        *PodChecker                  `json:"pod,omitempty"`
+	Events     *uint64                      `json:"events,omitempty"`
+	Bytes      *uint64                      `json:"bytes,omitempty"`
+}
+
+// NewCgroupEventRateChecker creates a new CgroupEventRateChecker
+func NewCgroupEventRateChecker() *CgroupEventRateChecker {
+	return &CgroupEventRateChecker{}
+}
+
+// Get the type of the checker as a string
+func (checker *CgroupEventRateChecker) GetCheckerType() string {
+	return "CgroupEventRateChecker"
+}
+
+// Check checks a CgroupEventRate field
+func (checker *CgroupEventRateChecker) Check(event *tetragon.CgroupEventRate) error {
+	if event == nil {
+		return fmt.Errorf("%s: CgroupEventRate field is nil", CheckerLogPrefix(checker))
+	}
+
+	fieldChecks := func() error {
+		if checker.CgroupId != nil {
+			if *checker.CgroupId != event.CgroupId {
+				return fmt.Errorf("CgroupId has value %d which does not match expected value %d", event.CgroupId, *checker.CgroupId)
+			}
+		}
+		if checker.CgroupPath != nil {
+			if err := checker.CgroupPath.Match(event.CgroupPath); err != nil {
+				return fmt.Errorf("CgroupPath check failed: %w", err)
+			}
+		}
+		if checker.Pod != nil {
+			if err := checker.Pod.Check(event.Pod); err != nil {
+				return fmt.Errorf("Pod check failed: %w", err)
+			}
+		}
+		if checker.Events != nil {
+			if *checker.Events != event.Events {
+				return fmt.Errorf("Events has value %d which does not match expected value %d", event.Events, *checker.Events)
+			}
+		}
+		if checker.Bytes != nil {
+			if *checker.Bytes != event.Bytes {
+				return fmt.Errorf("Bytes has value %d which does not match expected value %d", event.Bytes, *checker.Bytes)
+			}
+		}
+		return nil
+	}
+	if err := fieldChecks(); err != nil {
+		return fmt.Errorf("%s: %w", CheckerLogPrefix(checker), err)
+	}
+	return nil
+}
+
+// WithCgroupId adds a CgroupId check to the CgroupEventRateChecker
+func (checker *CgroupEventRateChecker) WithCgroupId(check uint64) *CgroupEventRateChecker {
+	checker.CgroupId = &check
+	return checker
+}
+
+// WithCgroupPath adds a CgroupPath check to the CgroupEventRateChecker
+func (checker *CgroupEventRateChecker) WithCgroupPath(check *stringmatcher.StringMatcher) *CgroupEventRateChecker {
+	checker.CgroupPath = check
+	return checker
+}
+
+// WithPod adds a Pod check to the CgroupEventRateChecker
+func (checker *CgroupEventRateChecker) WithPod(check *PodChecker) *CgroupEventRateChecker {
+	checker.Pod = check
+	return checker
+}
+
+// WithEvents adds a Events check to the CgroupEventRateChecker
+func (checker *CgroupEventRateChecker) WithEvents(check uint64) *CgroupEventRateChecker {
+	checker.Events = &check
+	return checker
+}
+
+// WithBytes adds a Bytes check to the CgroupEventRateChecker
+func (checker *CgroupEventRateChecker) WithBytes(check uint64) *CgroupEventRateChecker {
+	checker.Bytes = &check
+	return checker
+}
+
+//FromCgroupEventRate populates the CgroupEventRateChecker using data from a CgroupEventRate field
+func (checker *CgroupEventRateChecker) FromCgroupEventRate(event *tetragon.CgroupEventRate) *CgroupEventRateChecker {
+	if event == nil {
+		return checker
+	}
+	{
+		val := event.CgroupId
+		checker.CgroupId = &val
+	}
+	checker.CgroupPath = stringmatcher.Full(event.CgroupPath)
+	if event.Pod != nil {
+		checker.Pod = NewPodChecker().FromPod(event.Pod)
+	}
+	{
+		val := event.Events
+		checker.Events = &val
+	}
+	{
+		val := event.Bytes
+		checker.Bytes = &val
+	}
+	return checker
+}
+
+// ConfigSnapshotPolicyChecker implements a checker struct to check a ConfigSnapshotPolicy field
+type ConfigSnapshotPolicyChecker struct {
+	Name      *stringmatcher.StringMatcher `json:"name,omitempty"`
+	Namespace *stringmatcher.StringMatcher `json:"namespace,omitempty"`
+	Enabled   *bool                        `json:"enabled,omitempty"`
+	SpecYaml  *stringmatcher.StringMatcher `json:"specYaml,omitempty"`
+}
+
+// NewConfigSnapshotPolicyChecker creates a new ConfigSnapshotPolicyChecker
+func NewConfigSnapshotPolicyChecker() *ConfigSnapshotPolicyChecker {
+	return &ConfigSnapshotPolicyChecker{}
+}
+
+// Get the type of the checker as a string
+func (checker *ConfigSnapshotPolicyChecker) GetCheckerType() string {
+	return "ConfigSnapshotPolicyChecker"
+}
+
+// Check checks a ConfigSnapshotPolicy field
+func (checker *ConfigSnapshotPolicyChecker) Check(event *tetragon.ConfigSnapshotPolicy) error {
+	if event == nil {
+		return fmt.Errorf("%s: ConfigSnapshotPolicy field is nil", CheckerLogPrefix(checker))
+	}
+
+	fieldChecks := func() error {
+		if checker.Name != nil {
+			if err := checker.Name.Match(event.Name); err != nil {
+				return fmt.Errorf("Name check failed: %w", err)
+			}
+		}
+		if checker.Namespace != nil {
+			if err := checker.Namespace.Match(event.Namespace); err != nil {
+				return fmt.Errorf("Namespace check failed: %w", err)
+			}
+		}
+		if checker.Enabled != nil {
+			if *checker.Enabled != event.Enabled {
+				return fmt.Errorf("Enabled has value %t which does not match expected value %t", event.Enabled, *checker.Enabled)
+			}
+		}
+		if checker.SpecYaml != nil {
+			if err := checker.SpecYaml.Match(event.SpecYaml); err != nil {
+				return fmt.Errorf("SpecYaml check failed: %w", err)
+			}
+		}
+		return nil
+	}
+	if err := fieldChecks(); err != nil {
+		return fmt.Errorf("%s: %w", CheckerLogPrefix(checker), err)
+	}
+	return nil
+}
+
+// WithName adds a Name check to the ConfigSnapshotPolicyChecker
+func (checker *ConfigSnapshotPolicyChecker) WithName(check *stringmatcher.StringMatcher) *ConfigSnapshotPolicyChecker {
+	checker.Name = check
+	return checker
+}
+
+// WithNamespace adds a Namespace check to the ConfigSnapshotPolicyChecker
+func (checker *ConfigSnapshotPolicyChecker) WithNamespace(check *stringmatcher.StringMatcher) *ConfigSnapshotPolicyChecker {
+	checker.Namespace = check
+	return checker
+}
+
+// WithEnabled adds a Enabled check to the ConfigSnapshotPolicyChecker
+func (checker *ConfigSnapshotPolicyChecker) WithEnabled(check bool) *ConfigSnapshotPolicyChecker {
+	checker.Enabled = &check
+	return checker
+}
+
+// WithSpecYaml adds a SpecYaml check to the ConfigSnapshotPolicyChecker
+func (checker *ConfigSnapshotPolicyChecker) WithSpecYaml(check *stringmatcher.StringMatcher) *ConfigSnapshotPolicyChecker {
+	checker.SpecYaml = check
+	return checker
+}
+
+//FromConfigSnapshotPolicy populates the ConfigSnapshotPolicyChecker using data from a ConfigSnapshotPolicy field
+func (checker *ConfigSnapshotPolicyChecker) FromConfigSnapshotPolicy(event *tetragon.ConfigSnapshotPolicy) *ConfigSnapshotPolicyChecker {
+	if event == nil {
+		return checker
+	}
+	checker.Name = stringmatcher.Full(event.Name)
+	checker.Namespace = stringmatcher.Full(event.Namespace)
+	{
+		val := event.Enabled
+		checker.Enabled = &val
+	}
+	checker.SpecYaml = stringmatcher.Full(event.SpecYaml)
 	return checker
 }
 
@@ -5512,6 +8830,58 @@ func (enum *KprobeActionChecker) Check(val *tetragon.KprobeAction) error {
 	return nil
 }
 
+// MiningSignalTypeChecker checks a tetragon.MiningSignalType
+type MiningSignalTypeChecker tetragon.MiningSignalType
+
+// MarshalJSON implements json.Marshaler interface
+func (enum MiningSignalTypeChecker) MarshalJSON() ([]byte, error) {
+	if name, ok := tetragon.MiningSignalType_name[int32(enum)]; ok {
+		name = strings.TrimPrefix(name, "MINING_SIGNAL_")
+		return json.Marshal(name)
+	}
+
+	return nil, fmt.Errorf("Unknown MiningSignalType %d", enum)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (enum *MiningSignalTypeChecker) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := yaml.UnmarshalStrict(b, &str); err != nil {
+		return err
+	}
+
+	// Convert to uppercase if not already
+	str = strings.ToUpper(str)
+
+	// Look up the value from the enum values map
+	if n, ok := tetragon.MiningSignalType_value[str]; ok {
+		*enum = MiningSignalTypeChecker(n)
+	} else if n, ok := tetragon.MiningSignalType_value["MINING_SIGNAL_"+str]; ok {
+		*enum = MiningSignalTypeChecker(n)
+	} else {
+		return fmt.Errorf("Unknown MiningSignalType %s", str)
+	}
+
+	return nil
+}
+
+// NewMiningSignalTypeChecker creates a new MiningSignalTypeChecker
+func NewMiningSignalTypeChecker(val tetragon.MiningSignalType) *MiningSignalTypeChecker {
+	enum := MiningSignalTypeChecker(val)
+	return &enum
+}
+
+// Check checks a MiningSignalType against the checker
+func (enum *MiningSignalTypeChecker) Check(val *tetragon.MiningSignalType) error {
+	if val == nil {
+		return fmt.Errorf("MiningSignalTypeChecker: MiningSignalType is nil and does not match expected value %s", tetragon.MiningSignalType(*enum))
+	}
+	if *enum != MiningSignalTypeChecker(*val) {
+		return fmt.Errorf("MiningSignalTypeChecker: MiningSignalType has value %s which does not match expected value %s", (*val), tetragon.MiningSignalType(*enum))
+	}
+	return nil
+}
+
 // TaintedBitsTypeChecker checks a tetragon.TaintedBitsType
 type TaintedBitsTypeChecker tetragon.TaintedBitsType
 
@@ -5563,3 +8933,55 @@ func (enum *TaintedBitsTypeChecker) Check(val *tetragon.TaintedBitsType) error {
 	}
 	return nil
 }
+
+// EventVerdictChecker checks a tetragon.EventVerdict
+type EventVerdictChecker tetragon.EventVerdict
+
+// MarshalJSON implements json.Marshaler interface
+func (enum EventVerdictChecker) MarshalJSON() ([]byte, error) {
+	if name, ok := tetragon.EventVerdict_name[int32(enum)]; ok {
+		name = strings.TrimPrefix(name, "EVENT_VERDICT_")
+		return json.Marshal(name)
+	}
+
+	return nil, fmt.Errorf("Unknown EventVerdict %d", enum)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (enum *EventVerdictChecker) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := yaml.UnmarshalStrict(b, &str); err != nil {
+		return err
+	}
+
+	// Convert to uppercase if not already
+	str = strings.ToUpper(str)
+
+	// Look up the value from the enum values map
+	if n, ok := tetragon.EventVerdict_value[str]; ok {
+		*enum = EventVerdictChecker(n)
+	} else if n, ok := tetragon.EventVerdict_value["EVENT_VERDICT_"+str]; ok {
+		*enum = EventVerdictChecker(n)
+	} else {
+		return fmt.Errorf("Unknown EventVerdict %s", str)
+	}
+
+	return nil
+}
+
+// NewEventVerdictChecker creates a new EventVerdictChecker
+func NewEventVerdictChecker(val tetragon.EventVerdict) *EventVerdictChecker {
+	enum := EventVerdictChecker(val)
+	return &enum
+}
+
+// Check checks a EventVerdict against the checker
+func (enum *EventVerdictChecker) Check(val *tetragon.EventVerdict) error {
+	if val == nil {
+		return fmt.Errorf("EventVerdictChecker: EventVerdict is nil and does not match expected value %s", tetragon.EventVerdict(*enum))
+	}
+	if *enum != EventVerdictChecker(*val) {
+		return fmt.Errorf("EventVerdictChecker: EventVerdict has value %s which does not match expected value %s", (*val), tetragon.EventVerdict(*enum))
+	}
+	return nil
+}
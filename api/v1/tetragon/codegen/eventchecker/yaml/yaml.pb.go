@@ -140,14 +140,25 @@ func (conf *EventCheckerConf) WriteYamlFile(file string) error {
 }
 
 type eventCheckerHelper struct {
-	ProcessExec       *eventchecker.ProcessExecChecker       `json:"exec,omitempty"`
-	ProcessExit       *eventchecker.ProcessExitChecker       `json:"exit,omitempty"`
-	ProcessKprobe     *eventchecker.ProcessKprobeChecker     `json:"kprobe,omitempty"`
-	ProcessTracepoint *eventchecker.ProcessTracepointChecker `json:"tracepoint,omitempty"`
-	ProcessUprobe     *eventchecker.ProcessUprobeChecker     `json:"uprobe,omitempty"`
-	Test              *eventchecker.TestChecker              `json:"test,omitempty"`
-	ProcessLoader     *eventchecker.ProcessLoaderChecker     `json:"loader,omitempty"`
-	RateLimitInfo     *eventchecker.RateLimitInfoChecker     `json:"rateLimitInfo,omitempty"`
+	ProcessExec        *eventchecker.ProcessExecChecker        `json:"exec,omitempty"`
+	ProcessExit        *eventchecker.ProcessExitChecker        `json:"exit,omitempty"`
+	ProcessKprobe      *eventchecker.ProcessKprobeChecker      `json:"kprobe,omitempty"`
+	ProcessKprobeCount *eventchecker.ProcessKprobeCountChecker `json:"kprobeCount,omitempty"`
+	MiningSuspected    *eventchecker.MiningSuspectedChecker    `json:"miningSuspected,omitempty"`
+	SshConnection      *eventchecker.SshConnectionChecker      `json:"sshConnection,omitempty"`
+	ProcessTracepoint  *eventchecker.ProcessTracepointChecker  `json:"tracepoint,omitempty"`
+	ProcessUprobe      *eventchecker.ProcessUprobeChecker      `json:"uprobe,omitempty"`
+	ProcessLsm         *eventchecker.ProcessLsmChecker         `json:"lsm,omitempty"`
+	ProcessPerfEvent   *eventchecker.ProcessPerfEventChecker   `json:"perfEvent,omitempty"`
+	Test               *eventchecker.TestChecker               `json:"test,omitempty"`
+	ProcessLoader      *eventchecker.ProcessLoaderChecker      `json:"loader,omitempty"`
+	RateLimitInfo      *eventchecker.RateLimitInfoChecker      `json:"rateLimitInfo,omitempty"`
+	ExportSinkHealth   *eventchecker.ExportSinkHealthChecker   `json:"exportSinkHealth,omitempty"`
+	RingBufferDrops    *eventchecker.RingBufferDropsChecker    `json:"ringBufferDrops,omitempty"`
+	LostEvent          *eventchecker.LostEventChecker          `json:"lostEvent,omitempty"`
+	MapFill            *eventchecker.MapFillChecker            `json:"mapFill,omitempty"`
+	ConfigSnapshot     *eventchecker.ConfigSnapshotChecker     `json:"configSnapshot,omitempty"`
+	EventAnnotation    *eventchecker.EventAnnotationChecker    `json:"eventAnnotation,omitempty"`
 }
 
 // EventChecker is a wrapper around the EventChecker interface to help unmarshaling
@@ -180,6 +191,24 @@ func (checker *EventChecker) UnmarshalJSON(b []byte) error {
 		}
 		eventChecker = helper.ProcessKprobe
 	}
+	if helper.ProcessKprobeCount != nil {
+		if eventChecker != nil {
+			return fmt.Errorf("EventChecker: cannot define more than one checker, got %T but already had %T", helper.ProcessKprobeCount, eventChecker)
+		}
+		eventChecker = helper.ProcessKprobeCount
+	}
+	if helper.MiningSuspected != nil {
+		if eventChecker != nil {
+			return fmt.Errorf("EventChecker: cannot define more than one checker, got %T but already had %T", helper.MiningSuspected, eventChecker)
+		}
+		eventChecker = helper.MiningSuspected
+	}
+	if helper.SshConnection != nil {
+		if eventChecker != nil {
+			return fmt.Errorf("EventChecker: cannot define more than one checker, got %T but already had %T", helper.SshConnection, eventChecker)
+		}
+		eventChecker = helper.SshConnection
+	}
 	if helper.ProcessTracepoint != nil {
 		if eventChecker != nil {
 			return fmt.Errorf("EventChecker: cannot define more than one checker, got %T but already had %T", helper.ProcessTracepoint, eventChecker)
@@ -192,6 +221,18 @@ func (checker *EventChecker) UnmarshalJSON(b []byte) error {
 		}
 		eventChecker = helper.ProcessUprobe
 	}
+	if helper.ProcessLsm != nil {
+		if eventChecker != nil {
+			return fmt.Errorf("EventChecker: cannot define more than one checker, got %T but already had %T", helper.ProcessLsm, eventChecker)
+		}
+		eventChecker = helper.ProcessLsm
+	}
+	if helper.ProcessPerfEvent != nil {
+		if eventChecker != nil {
+			return fmt.Errorf("EventChecker: cannot define more than one checker, got %T but already had %T", helper.ProcessPerfEvent, eventChecker)
+		}
+		eventChecker = helper.ProcessPerfEvent
+	}
 	if helper.Test != nil {
 		if eventChecker != nil {
 			return fmt.Errorf("EventChecker: cannot define more than one checker, got %T but already had %T", helper.Test, eventChecker)
@@ -210,6 +251,42 @@ func (checker *EventChecker) UnmarshalJSON(b []byte) error {
 		}
 		eventChecker = helper.RateLimitInfo
 	}
+	if helper.ExportSinkHealth != nil {
+		if eventChecker != nil {
+			return fmt.Errorf("EventChecker: cannot define more than one checker, got %T but already had %T", helper.ExportSinkHealth, eventChecker)
+		}
+		eventChecker = helper.ExportSinkHealth
+	}
+	if helper.RingBufferDrops != nil {
+		if eventChecker != nil {
+			return fmt.Errorf("EventChecker: cannot define more than one checker, got %T but already had %T", helper.RingBufferDrops, eventChecker)
+		}
+		eventChecker = helper.RingBufferDrops
+	}
+	if helper.LostEvent != nil {
+		if eventChecker != nil {
+			return fmt.Errorf("EventChecker: cannot define more than one checker, got %T but already had %T", helper.LostEvent, eventChecker)
+		}
+		eventChecker = helper.LostEvent
+	}
+	if helper.MapFill != nil {
+		if eventChecker != nil {
+			return fmt.Errorf("EventChecker: cannot define more than one checker, got %T but already had %T", helper.MapFill, eventChecker)
+		}
+		eventChecker = helper.MapFill
+	}
+	if helper.ConfigSnapshot != nil {
+		if eventChecker != nil {
+			return fmt.Errorf("EventChecker: cannot define more than one checker, got %T but already had %T", helper.ConfigSnapshot, eventChecker)
+		}
+		eventChecker = helper.ConfigSnapshot
+	}
+	if helper.EventAnnotation != nil {
+		if eventChecker != nil {
+			return fmt.Errorf("EventChecker: cannot define more than one checker, got %T but already had %T", helper.EventAnnotation, eventChecker)
+		}
+		eventChecker = helper.EventAnnotation
+	}
 	checker.EventChecker = eventChecker
 	return nil
 }
@@ -224,16 +301,38 @@ func (checker EventChecker) MarshalJSON() ([]byte, error) {
 		helper.ProcessExit = c
 	case *eventchecker.ProcessKprobeChecker:
 		helper.ProcessKprobe = c
+	case *eventchecker.ProcessKprobeCountChecker:
+		helper.ProcessKprobeCount = c
+	case *eventchecker.MiningSuspectedChecker:
+		helper.MiningSuspected = c
+	case *eventchecker.SshConnectionChecker:
+		helper.SshConnection = c
 	case *eventchecker.ProcessTracepointChecker:
 		helper.ProcessTracepoint = c
 	case *eventchecker.ProcessUprobeChecker:
 		helper.ProcessUprobe = c
+	case *eventchecker.ProcessLsmChecker:
+		helper.ProcessLsm = c
+	case *eventchecker.ProcessPerfEventChecker:
+		helper.ProcessPerfEvent = c
 	case *eventchecker.TestChecker:
 		helper.Test = c
 	case *eventchecker.ProcessLoaderChecker:
 		helper.ProcessLoader = c
 	case *eventchecker.RateLimitInfoChecker:
 		helper.RateLimitInfo = c
+	case *eventchecker.ExportSinkHealthChecker:
+		helper.ExportSinkHealth = c
+	case *eventchecker.RingBufferDropsChecker:
+		helper.RingBufferDrops = c
+	case *eventchecker.LostEventChecker:
+		helper.LostEvent = c
+	case *eventchecker.MapFillChecker:
+		helper.MapFill = c
+	case *eventchecker.ConfigSnapshotChecker:
+		helper.ConfigSnapshot = c
+	case *eventchecker.EventAnnotationChecker:
+		helper.EventAnnotation = c
 	default:
 		return nil, fmt.Errorf("EventChecker: unknown checker type %T", c)
 	}
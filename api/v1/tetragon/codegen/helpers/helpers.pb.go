@@ -34,10 +34,32 @@ func ResponseTypeString(response *tetragon.GetEventsResponse) (string, error) {
 		return tetragon.EventType_PROCESS_LOADER.String(), nil
 	case *tetragon.GetEventsResponse_ProcessUprobe:
 		return tetragon.EventType_PROCESS_UPROBE.String(), nil
+	case *tetragon.GetEventsResponse_ProcessLsm:
+		return tetragon.EventType_PROCESS_LSM.String(), nil
+	case *tetragon.GetEventsResponse_ProcessKprobeCount:
+		return tetragon.EventType_PROCESS_KPROBE_COUNT.String(), nil
+	case *tetragon.GetEventsResponse_MiningSuspected:
+		return tetragon.EventType_MINING_SUSPECTED.String(), nil
+	case *tetragon.GetEventsResponse_SshConnection:
+		return tetragon.EventType_SSH_CONNECTION.String(), nil
+	case *tetragon.GetEventsResponse_ProcessPerfEvent:
+		return tetragon.EventType_PROCESS_PERF_EVENT.String(), nil
 	case *tetragon.GetEventsResponse_Test:
 		return tetragon.EventType_TEST.String(), nil
 	case *tetragon.GetEventsResponse_RateLimitInfo:
 		return tetragon.EventType_RATE_LIMIT_INFO.String(), nil
+	case *tetragon.GetEventsResponse_ExportSinkHealth:
+		return tetragon.EventType_EXPORT_SINK_HEALTH.String(), nil
+	case *tetragon.GetEventsResponse_EventAnnotation:
+		return tetragon.EventType_EVENT_ANNOTATION.String(), nil
+	case *tetragon.GetEventsResponse_RingBufferDrops:
+		return tetragon.EventType_RING_BUFFER_DROPS.String(), nil
+	case *tetragon.GetEventsResponse_MapFill:
+		return tetragon.EventType_MAP_FILL.String(), nil
+	case *tetragon.GetEventsResponse_ConfigSnapshot:
+		return tetragon.EventType_CONFIG_SNAPSHOT.String(), nil
+	case *tetragon.GetEventsResponse_LostEvent:
+		return tetragon.EventType_LOST_EVENT.String(), nil
 
 	}
 	return "", fmt.Errorf("Unhandled response type %T", event)
@@ -66,10 +88,20 @@ func ResponseInnerGetProcess(event tetragon.IsGetEventsResponse_Event) *tetragon
 		return ev.ProcessExit.Process
 	case *tetragon.GetEventsResponse_ProcessKprobe:
 		return ev.ProcessKprobe.Process
+	case *tetragon.GetEventsResponse_ProcessKprobeCount:
+		return ev.ProcessKprobeCount.Process
+	case *tetragon.GetEventsResponse_MiningSuspected:
+		return ev.MiningSuspected.Process
+	case *tetragon.GetEventsResponse_SshConnection:
+		return ev.SshConnection.Process
 	case *tetragon.GetEventsResponse_ProcessTracepoint:
 		return ev.ProcessTracepoint.Process
 	case *tetragon.GetEventsResponse_ProcessUprobe:
 		return ev.ProcessUprobe.Process
+	case *tetragon.GetEventsResponse_ProcessLsm:
+		return ev.ProcessLsm.Process
+	case *tetragon.GetEventsResponse_ProcessPerfEvent:
+		return ev.ProcessPerfEvent.Process
 	case *tetragon.GetEventsResponse_ProcessLoader:
 		return ev.ProcessLoader.Process
 
@@ -100,10 +132,20 @@ func ResponseInnerGetParent(event tetragon.IsGetEventsResponse_Event) *tetragon.
 		return ev.ProcessExit.Parent
 	case *tetragon.GetEventsResponse_ProcessKprobe:
 		return ev.ProcessKprobe.Parent
+	case *tetragon.GetEventsResponse_ProcessKprobeCount:
+		return ev.ProcessKprobeCount.Parent
+	case *tetragon.GetEventsResponse_MiningSuspected:
+		return ev.MiningSuspected.Parent
+	case *tetragon.GetEventsResponse_SshConnection:
+		return ev.SshConnection.Parent
 	case *tetragon.GetEventsResponse_ProcessTracepoint:
 		return ev.ProcessTracepoint.Parent
 	case *tetragon.GetEventsResponse_ProcessUprobe:
 		return ev.ProcessUprobe.Parent
+	case *tetragon.GetEventsResponse_ProcessLsm:
+		return ev.ProcessLsm.Parent
+	case *tetragon.GetEventsResponse_ProcessPerfEvent:
+		return ev.ProcessPerfEvent.Parent
 
 	}
 	return nil
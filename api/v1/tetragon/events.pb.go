@@ -34,15 +34,26 @@ const (
 type EventType int32
 
 const (
-	EventType_UNDEF              EventType = 0
-	EventType_PROCESS_EXEC       EventType = 1
-	EventType_PROCESS_EXIT       EventType = 5
-	EventType_PROCESS_KPROBE     EventType = 9
-	EventType_PROCESS_TRACEPOINT EventType = 10
-	EventType_PROCESS_LOADER     EventType = 11
-	EventType_PROCESS_UPROBE     EventType = 12
-	EventType_TEST               EventType = 40000
-	EventType_RATE_LIMIT_INFO    EventType = 40001
+	EventType_UNDEF                EventType = 0
+	EventType_PROCESS_EXEC         EventType = 1
+	EventType_PROCESS_EXIT         EventType = 5
+	EventType_PROCESS_KPROBE       EventType = 9
+	EventType_PROCESS_TRACEPOINT   EventType = 10
+	EventType_PROCESS_LOADER       EventType = 11
+	EventType_PROCESS_UPROBE       EventType = 12
+	EventType_PROCESS_LSM          EventType = 13
+	EventType_PROCESS_KPROBE_COUNT EventType = 14
+	EventType_MINING_SUSPECTED     EventType = 15
+	EventType_SSH_CONNECTION       EventType = 16
+	EventType_PROCESS_PERF_EVENT   EventType = 17
+	EventType_TEST                 EventType = 40000
+	EventType_RATE_LIMIT_INFO      EventType = 40001
+	EventType_EXPORT_SINK_HEALTH   EventType = 40002
+	EventType_EVENT_ANNOTATION     EventType = 40003
+	EventType_RING_BUFFER_DROPS    EventType = 40004
+	EventType_MAP_FILL             EventType = 40005
+	EventType_CONFIG_SNAPSHOT      EventType = 40006
+	EventType_LOST_EVENT           EventType = 40007
 )
 
 // Enum value maps for EventType.
@@ -55,19 +66,41 @@ var (
 		10:    "PROCESS_TRACEPOINT",
 		11:    "PROCESS_LOADER",
 		12:    "PROCESS_UPROBE",
+		13:    "PROCESS_LSM",
+		14:    "PROCESS_KPROBE_COUNT",
+		15:    "MINING_SUSPECTED",
+		16:    "SSH_CONNECTION",
+		17:    "PROCESS_PERF_EVENT",
 		40000: "TEST",
 		40001: "RATE_LIMIT_INFO",
+		40002: "EXPORT_SINK_HEALTH",
+		40003: "EVENT_ANNOTATION",
+		40004: "RING_BUFFER_DROPS",
+		40005: "MAP_FILL",
+		40006: "CONFIG_SNAPSHOT",
+		40007: "LOST_EVENT",
 	}
 	EventType_value = map[string]int32{
-		"UNDEF":              0,
-		"PROCESS_EXEC":       1,
-		"PROCESS_EXIT":       5,
-		"PROCESS_KPROBE":     9,
-		"PROCESS_TRACEPOINT": 10,
-		"PROCESS_LOADER":     11,
-		"PROCESS_UPROBE":     12,
-		"TEST":               40000,
-		"RATE_LIMIT_INFO":    40001,
+		"UNDEF":                0,
+		"PROCESS_EXEC":         1,
+		"PROCESS_EXIT":         5,
+		"PROCESS_KPROBE":       9,
+		"PROCESS_TRACEPOINT":   10,
+		"PROCESS_LOADER":       11,
+		"PROCESS_UPROBE":       12,
+		"PROCESS_LSM":          13,
+		"PROCESS_KPROBE_COUNT": 14,
+		"MINING_SUSPECTED":     15,
+		"SSH_CONNECTION":       16,
+		"PROCESS_PERF_EVENT":   17,
+		"TEST":                 40000,
+		"RATE_LIMIT_INFO":      40001,
+		"EXPORT_SINK_HEALTH":   40002,
+		"EVENT_ANNOTATION":     40003,
+		"RING_BUFFER_DROPS":    40004,
+		"MAP_FILL":             40005,
+		"CONFIG_SNAPSHOT":      40006,
+		"LOST_EVENT":           40007,
 	}
 )
 
@@ -145,6 +178,58 @@ func (FieldFilterAction) EnumDescriptor() ([]byte, []int) {
 	return file_tetragon_events_proto_rawDescGZIP(), []int{1}
 }
 
+type EventVerdict int32
+
+const (
+	EventVerdict_EVENT_VERDICT_UNSPECIFIED EventVerdict = 0
+	EventVerdict_EVENT_VERDICT_BENIGN      EventVerdict = 1
+	EventVerdict_EVENT_VERDICT_SUSPICIOUS  EventVerdict = 2
+	EventVerdict_EVENT_VERDICT_MALICIOUS   EventVerdict = 3
+)
+
+// Enum value maps for EventVerdict.
+var (
+	EventVerdict_name = map[int32]string{
+		0: "EVENT_VERDICT_UNSPECIFIED",
+		1: "EVENT_VERDICT_BENIGN",
+		2: "EVENT_VERDICT_SUSPICIOUS",
+		3: "EVENT_VERDICT_MALICIOUS",
+	}
+	EventVerdict_value = map[string]int32{
+		"EVENT_VERDICT_UNSPECIFIED": 0,
+		"EVENT_VERDICT_BENIGN":      1,
+		"EVENT_VERDICT_SUSPICIOUS":  2,
+		"EVENT_VERDICT_MALICIOUS":   3,
+	}
+)
+
+func (x EventVerdict) Enum() *EventVerdict {
+	p := new(EventVerdict)
+	*p = x
+	return p
+}
+
+func (x EventVerdict) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventVerdict) Descriptor() protoreflect.EnumDescriptor {
+	return file_tetragon_events_proto_enumTypes[2].Descriptor()
+}
+
+func (EventVerdict) Type() protoreflect.EnumType {
+	return &file_tetragon_events_proto_enumTypes[2]
+}
+
+func (x EventVerdict) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventVerdict.Descriptor instead.
+func (EventVerdict) EnumDescriptor() ([]byte, []int) {
+	return file_tetragon_events_proto_rawDescGZIP(), []int{2}
+}
+
 type Filter struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -171,6 +256,26 @@ type Filter struct {
 	// Note that this filter never matches events without the pod field (i.e.
 	// host process events).
 	Labels []string `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty"`
+	// Filter events matching all of these filters, in addition to the other
+	// fields of this filter.
+	And []*Filter `protobuf:"bytes,10,rep,name=and,proto3" json:"and,omitempty"`
+	// Filter events matching at least one of these filters, in addition to the
+	// other fields of this filter.
+	Or []*Filter `protobuf:"bytes,11,rep,name=or,proto3" json:"or,omitempty"`
+	// Filter events not matching this filter, in addition to the other fields
+	// of this filter. For example, {"namespace":["prod"],"not":{"binary_regex":["^/bin/sh$"]}}
+	// matches the events of the prod namespace, except the ones of /bin/sh.
+	Not *Filter `protobuf:"bytes,12,opt,name=not,proto3" json:"not,omitempty"`
+	// Filter by the policy_name field of the process_kprobe, process_tracepoint,
+	// process_uprobe, process_lsm and process_perf_event events. Note that
+	// this filter never matches the other events.
+	PolicyNames []string `protobuf:"bytes,13,rep,name=policy_names,json=policyNames,proto3" json:"policy_names,omitempty"`
+	// Filter events matching at least one of these CEL expressions. The
+	// expressions refer to the event through a variable named after its type,
+	// for example process_kprobe.args[0].file_arg.path.startsWith("/etc").
+	// Expressions referring to another type than the type of the event do not
+	// match. See https://github.com/google/cel-spec for the syntax.
+	CelExpression []string `protobuf:"bytes,14,rep,name=cel_expression,json=celExpression,proto3" json:"cel_expression,omitempty"`
 }
 
 func (x *Filter) Reset() {
@@ -268,6 +373,41 @@ func (x *Filter) GetLabels() []string {
 	return nil
 }
 
+func (x *Filter) GetAnd() []*Filter {
+	if x != nil {
+		return x.And
+	}
+	return nil
+}
+
+func (x *Filter) GetOr() []*Filter {
+	if x != nil {
+		return x.Or
+	}
+	return nil
+}
+
+func (x *Filter) GetNot() *Filter {
+	if x != nil {
+		return x.Not
+	}
+	return nil
+}
+
+func (x *Filter) GetPolicyNames() []string {
+	if x != nil {
+		return x.PolicyNames
+	}
+	return nil
+}
+
+func (x *Filter) GetCelExpression() []string {
+	if x != nil {
+		return x.CelExpression
+	}
+	return nil
+}
+
 type FieldFilter struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -343,6 +483,80 @@ func (x *FieldFilter) GetInvertEventSet() *wrapperspb.BoolValue {
 	return nil
 }
 
+// RedactionFilter redacts the values of string and bytes fields of the events
+// that match regular expressions, e.g. secrets in the arguments of the
+// processes, before the events are exported or sent to the gRPC clients.
+type RedactionFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Event types to redact or undefined to redact all event types.
+	EventSet []EventType `protobuf:"varint,1,rep,packed,name=event_set,json=eventSet,proto3,enum=tetragon.EventType" json:"event_set,omitempty"`
+	// Fields to redact, relative to the event, e.g. process.arguments or
+	// args.string_arg. The fields of the repeated messages on the path,
+	// like the arguments of kprobes, are redacted in all the messages.
+	Fields *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=fields,proto3" json:"fields,omitempty"`
+	// RE2 regular expressions of the values to redact. The parts of a value
+	// matching the capture groups of an expression are redacted, or the
+	// whole match if the expression has no capture group. The whole values
+	// of the fields are redacted if no expression is set.
+	Redact []string `protobuf:"bytes,3,rep,name=redact,proto3" json:"redact,omitempty"`
+}
+
+func (x *RedactionFilter) Reset() {
+	*x = RedactionFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tetragon_events_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RedactionFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedactionFilter) ProtoMessage() {}
+
+func (x *RedactionFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_tetragon_events_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedactionFilter.ProtoReflect.Descriptor instead.
+func (*RedactionFilter) Descriptor() ([]byte, []int) {
+	return file_tetragon_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RedactionFilter) GetEventSet() []EventType {
+	if x != nil {
+		return x.EventSet
+	}
+	return nil
+}
+
+func (x *RedactionFilter) GetFields() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *RedactionFilter) GetRedact() []string {
+	if x != nil {
+		return x.Redact
+	}
+	return nil
+}
+
 type GetEventsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -372,7 +586,7 @@ type GetEventsRequest struct {
 func (x *GetEventsRequest) Reset() {
 	*x = GetEventsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tetragon_events_proto_msgTypes[2]
+		mi := &file_tetragon_events_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -385,7 +599,7 @@ func (x *GetEventsRequest) String() string {
 func (*GetEventsRequest) ProtoMessage() {}
 
 func (x *GetEventsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tetragon_events_proto_msgTypes[2]
+	mi := &file_tetragon_events_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -398,7 +612,7 @@ func (x *GetEventsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetEventsRequest.ProtoReflect.Descriptor instead.
 func (*GetEventsRequest) Descriptor() ([]byte, []int) {
-	return file_tetragon_events_proto_rawDescGZIP(), []int{2}
+	return file_tetragon_events_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GetEventsRequest) GetAllowList() []*Filter {
@@ -446,7 +660,7 @@ type AggregationOptions struct {
 func (x *AggregationOptions) Reset() {
 	*x = AggregationOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tetragon_events_proto_msgTypes[3]
+		mi := &file_tetragon_events_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -459,7 +673,7 @@ func (x *AggregationOptions) String() string {
 func (*AggregationOptions) ProtoMessage() {}
 
 func (x *AggregationOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_tetragon_events_proto_msgTypes[3]
+	mi := &file_tetragon_events_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -472,7 +686,7 @@ func (x *AggregationOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AggregationOptions.ProtoReflect.Descriptor instead.
 func (*AggregationOptions) Descriptor() ([]byte, []int) {
-	return file_tetragon_events_proto_rawDescGZIP(), []int{3}
+	return file_tetragon_events_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *AggregationOptions) GetWindowSize() *durationpb.Duration {
@@ -502,7 +716,7 @@ type AggregationInfo struct {
 func (x *AggregationInfo) Reset() {
 	*x = AggregationInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tetragon_events_proto_msgTypes[4]
+		mi := &file_tetragon_events_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -515,7 +729,7 @@ func (x *AggregationInfo) String() string {
 func (*AggregationInfo) ProtoMessage() {}
 
 func (x *AggregationInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_tetragon_events_proto_msgTypes[4]
+	mi := &file_tetragon_events_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -528,7 +742,7 @@ func (x *AggregationInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AggregationInfo.ProtoReflect.Descriptor instead.
 func (*AggregationInfo) Descriptor() ([]byte, []int) {
-	return file_tetragon_events_proto_rawDescGZIP(), []int{4}
+	return file_tetragon_events_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *AggregationInfo) GetCount() uint64 {
@@ -549,7 +763,7 @@ type RateLimitInfo struct {
 func (x *RateLimitInfo) Reset() {
 	*x = RateLimitInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tetragon_events_proto_msgTypes[5]
+		mi := &file_tetragon_events_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -562,7 +776,7 @@ func (x *RateLimitInfo) String() string {
 func (*RateLimitInfo) ProtoMessage() {}
 
 func (x *RateLimitInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_tetragon_events_proto_msgTypes[5]
+	mi := &file_tetragon_events_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -575,7 +789,7 @@ func (x *RateLimitInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RateLimitInfo.ProtoReflect.Descriptor instead.
 func (*RateLimitInfo) Descriptor() ([]byte, []int) {
-	return file_tetragon_events_proto_rawDescGZIP(), []int{5}
+	return file_tetragon_events_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *RateLimitInfo) GetNumberOfDroppedProcessEvents() uint64 {
@@ -585,54 +799,48 @@ func (x *RateLimitInfo) GetNumberOfDroppedProcessEvents() uint64 {
 	return 0
 }
 
-type GetEventsResponse struct {
+// ExportSinkHealth records a delivery health change of an export sink. It is
+// emitted when events switch over to the failover sink and when they switch
+// back to the primary sink.
+type ExportSinkHealth struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The type-specific fields of an event.
-	//
-	// NOTE: Numbers must stay in sync with enum EventType.
-	//
-	// Types that are assignable to Event:
-	//
-	//	*GetEventsResponse_ProcessExec
-	//	*GetEventsResponse_ProcessExit
-	//	*GetEventsResponse_ProcessKprobe
-	//	*GetEventsResponse_ProcessTracepoint
-	//	*GetEventsResponse_ProcessLoader
-	//	*GetEventsResponse_ProcessUprobe
-	//	*GetEventsResponse_Test
-	//	*GetEventsResponse_RateLimitInfo
-	Event isGetEventsResponse_Event `protobuf_oneof:"event"`
-	// Name of the node where this event was observed.
-	NodeName string `protobuf:"bytes,1000,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
-	// Timestamp at which this event was observed.
-	// For an aggregated response, this field to set to the timestamp at which
-	// the event was observed for the first time in a given aggregation time window.
-	Time *timestamppb.Timestamp `protobuf:"bytes,1001,opt,name=time,proto3" json:"time,omitempty"`
-	// aggregation_info contains information about aggregation results. This field
-	// is set only for aggregated responses.
-	AggregationInfo *AggregationInfo `protobuf:"bytes,1002,opt,name=aggregation_info,json=aggregationInfo,proto3" json:"aggregation_info,omitempty"`
-}
-
-func (x *GetEventsResponse) Reset() {
-	*x = GetEventsResponse{}
+	// Name of the primary sink.
+	Sink string `protobuf:"bytes,1,opt,name=sink,proto3" json:"sink,omitempty"`
+	// Name of the failover sink.
+	FailoverSink string `protobuf:"bytes,2,opt,name=failover_sink,json=failoverSink,proto3" json:"failover_sink,omitempty"`
+	// Whether the primary sink is delivering events.
+	Healthy bool `protobuf:"varint,3,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	// Time of the first event that was delivered to the failover sink, from
+	// the time field of the event.
+	FirstEventTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=first_event_time,json=firstEventTime,proto3" json:"first_event_time,omitempty"`
+	// Time of the last event that was delivered to the failover sink, from
+	// the time field of the event. Only set once the primary sink is healthy
+	// again.
+	LastEventTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_event_time,json=lastEventTime,proto3" json:"last_event_time,omitempty"`
+	// Error reported by the primary sink when it became unhealthy.
+	Error string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *ExportSinkHealth) Reset() {
+	*x = ExportSinkHealth{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tetragon_events_proto_msgTypes[6]
+		mi := &file_tetragon_events_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetEventsResponse) String() string {
+func (x *ExportSinkHealth) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetEventsResponse) ProtoMessage() {}
+func (*ExportSinkHealth) ProtoMessage() {}
 
-func (x *GetEventsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tetragon_events_proto_msgTypes[6]
+func (x *ExportSinkHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_tetragon_events_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -643,111 +851,938 @@ func (x *GetEventsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetEventsResponse.ProtoReflect.Descriptor instead.
-func (*GetEventsResponse) Descriptor() ([]byte, []int) {
-	return file_tetragon_events_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ExportSinkHealth.ProtoReflect.Descriptor instead.
+func (*ExportSinkHealth) Descriptor() ([]byte, []int) {
+	return file_tetragon_events_proto_rawDescGZIP(), []int{7}
 }
 
-func (m *GetEventsResponse) GetEvent() isGetEventsResponse_Event {
-	if m != nil {
-		return m.Event
+func (x *ExportSinkHealth) GetSink() string {
+	if x != nil {
+		return x.Sink
 	}
-	return nil
+	return ""
 }
 
-func (x *GetEventsResponse) GetProcessExec() *ProcessExec {
-	if x, ok := x.GetEvent().(*GetEventsResponse_ProcessExec); ok {
-		return x.ProcessExec
+func (x *ExportSinkHealth) GetFailoverSink() string {
+	if x != nil {
+		return x.FailoverSink
 	}
-	return nil
+	return ""
 }
 
-func (x *GetEventsResponse) GetProcessExit() *ProcessExit {
-	if x, ok := x.GetEvent().(*GetEventsResponse_ProcessExit); ok {
-		return x.ProcessExit
+func (x *ExportSinkHealth) GetHealthy() bool {
+	if x != nil {
+		return x.Healthy
 	}
-	return nil
+	return false
 }
 
-func (x *GetEventsResponse) GetProcessKprobe() *ProcessKprobe {
-	if x, ok := x.GetEvent().(*GetEventsResponse_ProcessKprobe); ok {
-		return x.ProcessKprobe
+func (x *ExportSinkHealth) GetFirstEventTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstEventTime
 	}
 	return nil
 }
 
-func (x *GetEventsResponse) GetProcessTracepoint() *ProcessTracepoint {
-	if x, ok := x.GetEvent().(*GetEventsResponse_ProcessTracepoint); ok {
-		return x.ProcessTracepoint
+func (x *ExportSinkHealth) GetLastEventTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastEventTime
 	}
 	return nil
 }
 
-func (x *GetEventsResponse) GetProcessLoader() *ProcessLoader {
-	if x, ok := x.GetEvent().(*GetEventsResponse_ProcessLoader); ok {
-		return x.ProcessLoader
+func (x *ExportSinkHealth) GetError() string {
+	if x != nil {
+		return x.Error
 	}
-	return nil
+	return ""
 }
 
-func (x *GetEventsResponse) GetProcessUprobe() *ProcessUprobe {
-	if x, ok := x.GetEvent().(*GetEventsResponse_ProcessUprobe); ok {
-		return x.ProcessUprobe
+// CgroupEventRate is the number of events that the tasks of a cgroup wrote to
+// the BPF ring buffer during a report window.
+type CgroupEventRate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Cgroup ID, in the cgroup v2 hierarchy.
+	CgroupId uint64 `protobuf:"varint,1,opt,name=cgroup_id,json=cgroupId,proto3" json:"cgroup_id,omitempty"`
+	// Path of the cgroup, relative to the root of the cgroup filesystem.
+	// Empty if the cgroup was not found.
+	CgroupPath string `protobuf:"bytes,2,opt,name=cgroup_path,json=cgroupPath,proto3" json:"cgroup_path,omitempty"`
+	// Kubernetes pod of the cgroup, if any.
+	Pod *Pod `protobuf:"bytes,3,opt,name=pod,proto3" json:"pod,omitempty"`
+	// Number of events written to the ring buffer.
+	Events uint64 `protobuf:"varint,4,opt,name=events,proto3" json:"events,omitempty"`
+	// Size of the events written to the ring buffer, in bytes.
+	Bytes uint64 `protobuf:"varint,5,opt,name=bytes,proto3" json:"bytes,omitempty"`
+}
+
+func (x *CgroupEventRate) Reset() {
+	*x = CgroupEventRate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tetragon_events_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *GetEventsResponse) GetTest() *Test {
-	if x, ok := x.GetEvent().(*GetEventsResponse_Test); ok {
-		return x.Test
+func (x *CgroupEventRate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CgroupEventRate) ProtoMessage() {}
+
+func (x *CgroupEventRate) ProtoReflect() protoreflect.Message {
+	mi := &file_tetragon_events_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *GetEventsResponse) GetRateLimitInfo() *RateLimitInfo {
-	if x, ok := x.GetEvent().(*GetEventsResponse_RateLimitInfo); ok {
-		return x.RateLimitInfo
+// Deprecated: Use CgroupEventRate.ProtoReflect.Descriptor instead.
+func (*CgroupEventRate) Descriptor() ([]byte, []int) {
+	return file_tetragon_events_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CgroupEventRate) GetCgroupId() uint64 {
+	if x != nil {
+		return x.CgroupId
 	}
-	return nil
+	return 0
 }
 
-func (x *GetEventsResponse) GetNodeName() string {
+func (x *CgroupEventRate) GetCgroupPath() string {
 	if x != nil {
-		return x.NodeName
+		return x.CgroupPath
 	}
 	return ""
 }
 
-func (x *GetEventsResponse) GetTime() *timestamppb.Timestamp {
+func (x *CgroupEventRate) GetPod() *Pod {
 	if x != nil {
-		return x.Time
+		return x.Pod
 	}
 	return nil
 }
 
-func (x *GetEventsResponse) GetAggregationInfo() *AggregationInfo {
+func (x *CgroupEventRate) GetEvents() uint64 {
 	if x != nil {
-		return x.AggregationInfo
+		return x.Events
 	}
-	return nil
+	return 0
 }
 
-type isGetEventsResponse_Event interface {
-	isGetEventsResponse_Event()
+func (x *CgroupEventRate) GetBytes() uint64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
 }
 
-type GetEventsResponse_ProcessExec struct {
-	// ProcessExec event includes information about the execution of
-	// binaries and other related process metadata.
-	ProcessExec *ProcessExec `protobuf:"bytes,1,opt,name=process_exec,json=processExec,proto3,oneof"`
+// RingBufferDrops reports events that were lost because the BPF ring buffer
+// was full, and attributes the events written to the ring buffer during the
+// same report window to their cgroups, so that the workloads that filled the
+// ring buffer can be identified.
+type RingBufferDrops struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of events lost during the report window.
+	Lost uint64 `protobuf:"varint,1,opt,name=lost,proto3" json:"lost,omitempty"`
+	// Duration of the report window.
+	Window *durationpb.Duration `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`
+	// Cgroups that wrote the most events to the ring buffer during the
+	// report window, in decreasing order of events.
+	Cgroups []*CgroupEventRate `protobuf:"bytes,3,rep,name=cgroups,proto3" json:"cgroups,omitempty"`
 }
 
-type GetEventsResponse_ProcessExit struct {
-	// ProcessExit event indicates how and when a process terminates.
-	ProcessExit *ProcessExit `protobuf:"bytes,5,opt,name=process_exit,json=processExit,proto3,oneof"`
+func (x *RingBufferDrops) Reset() {
+	*x = RingBufferDrops{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tetragon_events_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-type GetEventsResponse_ProcessKprobe struct {
+func (x *RingBufferDrops) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RingBufferDrops) ProtoMessage() {}
+
+func (x *RingBufferDrops) ProtoReflect() protoreflect.Message {
+	mi := &file_tetragon_events_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RingBufferDrops.ProtoReflect.Descriptor instead.
+func (*RingBufferDrops) Descriptor() ([]byte, []int) {
+	return file_tetragon_events_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RingBufferDrops) GetLost() uint64 {
+	if x != nil {
+		return x.Lost
+	}
+	return 0
+}
+
+func (x *RingBufferDrops) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *RingBufferDrops) GetCgroups() []*CgroupEventRate {
+	if x != nil {
+		return x.Cgroups
+	}
+	return nil
+}
+
+// LostEvent reports events that were lost on a CPU during a window, because
+// the transport of the events was full, so that the consumers of the events
+// know that their view of the window is incomplete.
+type LostEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of events lost during the window.
+	Count uint64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	// CPU that lost the events.
+	Cpu uint32 `protobuf:"varint,2,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	// Start of the window.
+	WindowStart *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=window_start,json=windowStart,proto3" json:"window_start,omitempty"`
+	// End of the window.
+	WindowEnd *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=window_end,json=windowEnd,proto3" json:"window_end,omitempty"`
+	// Transport of the events: perf or ringbuf.
+	Transport string `protobuf:"bytes,5,opt,name=transport,proto3" json:"transport,omitempty"`
+}
+
+func (x *LostEvent) Reset() {
+	*x = LostEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tetragon_events_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LostEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LostEvent) ProtoMessage() {}
+
+func (x *LostEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_tetragon_events_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LostEvent.ProtoReflect.Descriptor instead.
+func (*LostEvent) Descriptor() ([]byte, []int) {
+	return file_tetragon_events_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *LostEvent) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *LostEvent) GetCpu() uint32 {
+	if x != nil {
+		return x.Cpu
+	}
+	return 0
+}
+
+func (x *LostEvent) GetWindowStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WindowStart
+	}
+	return nil
+}
+
+func (x *LostEvent) GetWindowEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WindowEnd
+	}
+	return nil
+}
+
+func (x *LostEvent) GetTransport() string {
+	if x != nil {
+		return x.Transport
+	}
+	return ""
+}
+
+// MapFill reports that a BPF map of a tracing policy crossed the fill level
+// threshold. Some maps, once full, silently change the behavior of the policy,
+// e.g., file descriptors are no longer tracked or selector values no longer
+// match.
+type MapFill struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the tracing policy that uses the map.
+	Policy string `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	// Name of the map.
+	Map string `protobuf:"bytes,2,opt,name=map,proto3" json:"map,omitempty"`
+	// Pin path of the map, relative to the BPF filesystem directory of
+	// Tetragon.
+	Pin string `protobuf:"bytes,3,opt,name=pin,proto3" json:"pin,omitempty"`
+	// Number of entries in the map.
+	Entries uint32 `protobuf:"varint,4,opt,name=entries,proto3" json:"entries,omitempty"`
+	// Maximum number of entries of the map.
+	MaxEntries uint32 `protobuf:"varint,5,opt,name=max_entries,json=maxEntries,proto3" json:"max_entries,omitempty"`
+	// True if the fill level rose above the threshold, false if it went
+	// back below it.
+	AboveThreshold bool `protobuf:"varint,6,opt,name=above_threshold,json=aboveThreshold,proto3" json:"above_threshold,omitempty"`
+	// Maximum number of entries that the map will have the next time the
+	// policy is enabled, if the map was resized. Zero otherwise.
+	NextMaxEntries uint32 `protobuf:"varint,7,opt,name=next_max_entries,json=nextMaxEntries,proto3" json:"next_max_entries,omitempty"`
+}
+
+func (x *MapFill) Reset() {
+	*x = MapFill{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tetragon_events_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MapFill) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapFill) ProtoMessage() {}
+
+func (x *MapFill) ProtoReflect() protoreflect.Message {
+	mi := &file_tetragon_events_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapFill.ProtoReflect.Descriptor instead.
+func (*MapFill) Descriptor() ([]byte, []int) {
+	return file_tetragon_events_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *MapFill) GetPolicy() string {
+	if x != nil {
+		return x.Policy
+	}
+	return ""
+}
+
+func (x *MapFill) GetMap() string {
+	if x != nil {
+		return x.Map
+	}
+	return ""
+}
+
+func (x *MapFill) GetPin() string {
+	if x != nil {
+		return x.Pin
+	}
+	return ""
+}
+
+func (x *MapFill) GetEntries() uint32 {
+	if x != nil {
+		return x.Entries
+	}
+	return 0
+}
+
+func (x *MapFill) GetMaxEntries() uint32 {
+	if x != nil {
+		return x.MaxEntries
+	}
+	return 0
+}
+
+func (x *MapFill) GetAboveThreshold() bool {
+	if x != nil {
+		return x.AboveThreshold
+	}
+	return false
+}
+
+func (x *MapFill) GetNextMaxEntries() uint32 {
+	if x != nil {
+		return x.NextMaxEntries
+	}
+	return 0
+}
+
+// ConfigSnapshotPolicy is a tracing policy of a configuration snapshot.
+type ConfigSnapshotPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the policy.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Namespace of the policy, empty if the policy is not namespaced.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// True if the policy is enabled.
+	Enabled bool `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Spec of the policy, encoded in YAML.
+	SpecYaml string `protobuf:"bytes,4,opt,name=spec_yaml,json=specYaml,proto3" json:"spec_yaml,omitempty"`
+}
+
+func (x *ConfigSnapshotPolicy) Reset() {
+	*x = ConfigSnapshotPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tetragon_events_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigSnapshotPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigSnapshotPolicy) ProtoMessage() {}
+
+func (x *ConfigSnapshotPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_tetragon_events_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigSnapshotPolicy.ProtoReflect.Descriptor instead.
+func (*ConfigSnapshotPolicy) Descriptor() ([]byte, []int) {
+	return file_tetragon_events_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ConfigSnapshotPolicy) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ConfigSnapshotPolicy) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ConfigSnapshotPolicy) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *ConfigSnapshotPolicy) GetSpecYaml() string {
+	if x != nil {
+		return x.SpecYaml
+	}
+	return ""
+}
+
+// ConfigSnapshot records the configuration of the agent: its version, flags,
+// the running kernel and the loaded tracing policies. It is sent periodically
+// and when the configuration changes, so that the events can be interpreted
+// against the configuration that was active when they were observed.
+type ConfigSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Version of Tetragon.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Flags of the agent, by name.
+	Flags map[string]string `protobuf:"bytes,2,rep,name=flags,proto3" json:"flags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Release of the running kernel, e.g. "6.1.0-13-amd64".
+	KernelRelease string `protobuf:"bytes,3,opt,name=kernel_release,json=kernelRelease,proto3" json:"kernel_release,omitempty"`
+	// Version of the running kernel, as reported by uname.
+	KernelVersion string `protobuf:"bytes,4,opt,name=kernel_version,json=kernelVersion,proto3" json:"kernel_version,omitempty"`
+	// Machine hardware name, e.g. "x86_64".
+	Machine string `protobuf:"bytes,5,opt,name=machine,proto3" json:"machine,omitempty"`
+	// Tracing policies loaded in the agent.
+	Policies []*ConfigSnapshotPolicy `protobuf:"bytes,6,rep,name=policies,proto3" json:"policies,omitempty"`
+	// Hash of the configuration. Snapshots of the same configuration have
+	// the same hash.
+	Hash string `protobuf:"bytes,7,opt,name=hash,proto3" json:"hash,omitempty"`
+	// True if the configuration changed since the previous snapshot, or if
+	// this is the first snapshot of the agent.
+	Changed bool `protobuf:"varint,8,opt,name=changed,proto3" json:"changed,omitempty"`
+}
+
+func (x *ConfigSnapshot) Reset() {
+	*x = ConfigSnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tetragon_events_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigSnapshot) ProtoMessage() {}
+
+func (x *ConfigSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_tetragon_events_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigSnapshot.ProtoReflect.Descriptor instead.
+func (*ConfigSnapshot) Descriptor() ([]byte, []int) {
+	return file_tetragon_events_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ConfigSnapshot) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ConfigSnapshot) GetFlags() map[string]string {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+func (x *ConfigSnapshot) GetKernelRelease() string {
+	if x != nil {
+		return x.KernelRelease
+	}
+	return ""
+}
+
+func (x *ConfigSnapshot) GetKernelVersion() string {
+	if x != nil {
+		return x.KernelVersion
+	}
+	return ""
+}
+
+func (x *ConfigSnapshot) GetMachine() string {
+	if x != nil {
+		return x.Machine
+	}
+	return ""
+}
+
+func (x *ConfigSnapshot) GetPolicies() []*ConfigSnapshotPolicy {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+func (x *ConfigSnapshot) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *ConfigSnapshot) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+// EventAnnotation is a verdict or annotation that a client attached to an
+// event, e.g., the response decision of a SOAR tool. The annotated event is
+// identified by the exec_id of its process and by its time.
+type EventAnnotation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// exec_id of the process of the annotated event.
+	ExecId string `protobuf:"bytes,1,opt,name=exec_id,json=execId,proto3" json:"exec_id,omitempty"`
+	// Verdict for the annotated event.
+	Verdict EventVerdict `protobuf:"varint,2,opt,name=verdict,proto3,enum=tetragon.EventVerdict" json:"verdict,omitempty"`
+	// Free-form annotation.
+	Annotation string `protobuf:"bytes,3,opt,name=annotation,proto3" json:"annotation,omitempty"`
+	// Name of the client that posted the annotation.
+	Author string `protobuf:"bytes,4,opt,name=author,proto3" json:"author,omitempty"`
+	// Time of the annotated event, from its time field.
+	Time *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *EventAnnotation) Reset() {
+	*x = EventAnnotation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tetragon_events_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventAnnotation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventAnnotation) ProtoMessage() {}
+
+func (x *EventAnnotation) ProtoReflect() protoreflect.Message {
+	mi := &file_tetragon_events_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventAnnotation.ProtoReflect.Descriptor instead.
+func (*EventAnnotation) Descriptor() ([]byte, []int) {
+	return file_tetragon_events_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *EventAnnotation) GetExecId() string {
+	if x != nil {
+		return x.ExecId
+	}
+	return ""
+}
+
+func (x *EventAnnotation) GetVerdict() EventVerdict {
+	if x != nil {
+		return x.Verdict
+	}
+	return EventVerdict_EVENT_VERDICT_UNSPECIFIED
+}
+
+func (x *EventAnnotation) GetAnnotation() string {
+	if x != nil {
+		return x.Annotation
+	}
+	return ""
+}
+
+func (x *EventAnnotation) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *EventAnnotation) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+type GetEventsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The type-specific fields of an event.
+	//
+	// NOTE: Numbers must stay in sync with enum EventType.
+	//
+	// Types that are assignable to Event:
+	//
+	//	*GetEventsResponse_ProcessExec
+	//	*GetEventsResponse_ProcessExit
+	//	*GetEventsResponse_ProcessKprobe
+	//	*GetEventsResponse_ProcessTracepoint
+	//	*GetEventsResponse_ProcessLoader
+	//	*GetEventsResponse_ProcessUprobe
+	//	*GetEventsResponse_ProcessLsm
+	//	*GetEventsResponse_ProcessKprobeCount
+	//	*GetEventsResponse_MiningSuspected
+	//	*GetEventsResponse_SshConnection
+	//	*GetEventsResponse_ProcessPerfEvent
+	//	*GetEventsResponse_Test
+	//	*GetEventsResponse_RateLimitInfo
+	//	*GetEventsResponse_ExportSinkHealth
+	//	*GetEventsResponse_EventAnnotation
+	//	*GetEventsResponse_RingBufferDrops
+	//	*GetEventsResponse_MapFill
+	//	*GetEventsResponse_ConfigSnapshot
+	//	*GetEventsResponse_LostEvent
+	Event isGetEventsResponse_Event `protobuf_oneof:"event"`
+	// Name of the node where this event was observed.
+	NodeName string `protobuf:"bytes,1000,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	// Timestamp at which this event was observed.
+	// For an aggregated response, this field to set to the timestamp at which
+	// the event was observed for the first time in a given aggregation time window.
+	Time *timestamppb.Timestamp `protobuf:"bytes,1001,opt,name=time,proto3" json:"time,omitempty"`
+	// aggregation_info contains information about aggregation results. This field
+	// is set only for aggregated responses.
+	AggregationInfo *AggregationInfo `protobuf:"bytes,1002,opt,name=aggregation_info,json=aggregationInfo,proto3" json:"aggregation_info,omitempty"`
+}
+
+func (x *GetEventsResponse) Reset() {
+	*x = GetEventsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tetragon_events_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventsResponse) ProtoMessage() {}
+
+func (x *GetEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tetragon_events_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventsResponse.ProtoReflect.Descriptor instead.
+func (*GetEventsResponse) Descriptor() ([]byte, []int) {
+	return file_tetragon_events_proto_rawDescGZIP(), []int{15}
+}
+
+func (m *GetEventsResponse) GetEvent() isGetEventsResponse_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *GetEventsResponse) GetProcessExec() *ProcessExec {
+	if x, ok := x.GetEvent().(*GetEventsResponse_ProcessExec); ok {
+		return x.ProcessExec
+	}
+	return nil
+}
+
+func (x *GetEventsResponse) GetProcessExit() *ProcessExit {
+	if x, ok := x.GetEvent().(*GetEventsResponse_ProcessExit); ok {
+		return x.ProcessExit
+	}
+	return nil
+}
+
+func (x *GetEventsResponse) GetProcessKprobe() *ProcessKprobe {
+	if x, ok := x.GetEvent().(*GetEventsResponse_ProcessKprobe); ok {
+		return x.ProcessKprobe
+	}
+	return nil
+}
+
+func (x *GetEventsResponse) GetProcessTracepoint() *ProcessTracepoint {
+	if x, ok := x.GetEvent().(*GetEventsResponse_ProcessTracepoint); ok {
+		return x.ProcessTracepoint
+	}
+	return nil
+}
+
+func (x *GetEventsResponse) GetProcessLoader() *ProcessLoader {
+	if x, ok := x.GetEvent().(*GetEventsResponse_ProcessLoader); ok {
+		return x.ProcessLoader
+	}
+	return nil
+}
+
+func (x *GetEventsResponse) GetProcessUprobe() *ProcessUprobe {
+	if x, ok := x.GetEvent().(*GetEventsResponse_ProcessUprobe); ok {
+		return x.ProcessUprobe
+	}
+	return nil
+}
+
+func (x *GetEventsResponse) GetProcessLsm() *ProcessLsm {
+	if x, ok := x.GetEvent().(*GetEventsResponse_ProcessLsm); ok {
+		return x.ProcessLsm
+	}
+	return nil
+}
+
+func (x *GetEventsResponse) GetProcessKprobeCount() *ProcessKprobeCount {
+	if x, ok := x.GetEvent().(*GetEventsResponse_ProcessKprobeCount); ok {
+		return x.ProcessKprobeCount
+	}
+	return nil
+}
+
+func (x *GetEventsResponse) GetMiningSuspected() *MiningSuspected {
+	if x, ok := x.GetEvent().(*GetEventsResponse_MiningSuspected); ok {
+		return x.MiningSuspected
+	}
+	return nil
+}
+
+func (x *GetEventsResponse) GetSshConnection() *SshConnection {
+	if x, ok := x.GetEvent().(*GetEventsResponse_SshConnection); ok {
+		return x.SshConnection
+	}
+	return nil
+}
+
+func (x *GetEventsResponse) GetProcessPerfEvent() *ProcessPerfEvent {
+	if x, ok := x.GetEvent().(*GetEventsResponse_ProcessPerfEvent); ok {
+		return x.ProcessPerfEvent
+	}
+	return nil
+}
+
+func (x *GetEventsResponse) GetTest() *Test {
+	if x, ok := x.GetEvent().(*GetEventsResponse_Test); ok {
+		return x.Test
+	}
+	return nil
+}
+
+func (x *GetEventsResponse) GetRateLimitInfo() *RateLimitInfo {
+	if x, ok := x.GetEvent().(*GetEventsResponse_RateLimitInfo); ok {
+		return x.RateLimitInfo
+	}
+	return nil
+}
+
+func (x *GetEventsResponse) GetExportSinkHealth() *ExportSinkHealth {
+	if x, ok := x.GetEvent().(*GetEventsResponse_ExportSinkHealth); ok {
+		return x.ExportSinkHealth
+	}
+	return nil
+}
+
+func (x *GetEventsResponse) GetEventAnnotation() *EventAnnotation {
+	if x, ok := x.GetEvent().(*GetEventsResponse_EventAnnotation); ok {
+		return x.EventAnnotation
+	}
+	return nil
+}
+
+func (x *GetEventsResponse) GetRingBufferDrops() *RingBufferDrops {
+	if x, ok := x.GetEvent().(*GetEventsResponse_RingBufferDrops); ok {
+		return x.RingBufferDrops
+	}
+	return nil
+}
+
+func (x *GetEventsResponse) GetMapFill() *MapFill {
+	if x, ok := x.GetEvent().(*GetEventsResponse_MapFill); ok {
+		return x.MapFill
+	}
+	return nil
+}
+
+func (x *GetEventsResponse) GetConfigSnapshot() *ConfigSnapshot {
+	if x, ok := x.GetEvent().(*GetEventsResponse_ConfigSnapshot); ok {
+		return x.ConfigSnapshot
+	}
+	return nil
+}
+
+func (x *GetEventsResponse) GetLostEvent() *LostEvent {
+	if x, ok := x.GetEvent().(*GetEventsResponse_LostEvent); ok {
+		return x.LostEvent
+	}
+	return nil
+}
+
+func (x *GetEventsResponse) GetNodeName() string {
+	if x != nil {
+		return x.NodeName
+	}
+	return ""
+}
+
+func (x *GetEventsResponse) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *GetEventsResponse) GetAggregationInfo() *AggregationInfo {
+	if x != nil {
+		return x.AggregationInfo
+	}
+	return nil
+}
+
+type isGetEventsResponse_Event interface {
+	isGetEventsResponse_Event()
+}
+
+type GetEventsResponse_ProcessExec struct {
+	// ProcessExec event includes information about the execution of
+	// binaries and other related process metadata.
+	ProcessExec *ProcessExec `protobuf:"bytes,1,opt,name=process_exec,json=processExec,proto3,oneof"`
+}
+
+type GetEventsResponse_ProcessExit struct {
+	// ProcessExit event indicates how and when a process terminates.
+	ProcessExit *ProcessExit `protobuf:"bytes,5,opt,name=process_exit,json=processExit,proto3,oneof"`
+}
+
+type GetEventsResponse_ProcessKprobe struct {
 	// ProcessKprobe event contains information about the pre-defined
 	// functions and the process that invoked them.
 	ProcessKprobe *ProcessKprobe `protobuf:"bytes,9,opt,name=process_kprobe,json=processKprobe,proto3,oneof"`
@@ -767,6 +1802,34 @@ type GetEventsResponse_ProcessUprobe struct {
 	ProcessUprobe *ProcessUprobe `protobuf:"bytes,12,opt,name=process_uprobe,json=processUprobe,proto3,oneof"`
 }
 
+type GetEventsResponse_ProcessLsm struct {
+	// ProcessLsm contains information about the LSM hook that was
+	// called and the process that called it.
+	ProcessLsm *ProcessLsm `protobuf:"bytes,13,opt,name=process_lsm,json=processLsm,proto3,oneof"`
+}
+
+type GetEventsResponse_ProcessKprobeCount struct {
+	// ProcessKprobeCount reports the calls of a kprobe that a process
+	// made, counted in the kernel with the Count action.
+	ProcessKprobeCount *ProcessKprobeCount `protobuf:"bytes,14,opt,name=process_kprobe_count,json=processKprobeCount,proto3,oneof"`
+}
+
+type GetEventsResponse_MiningSuspected struct {
+	// MiningSuspected reports a process suspected of crypto-mining.
+	MiningSuspected *MiningSuspected `protobuf:"bytes,15,opt,name=mining_suspected,json=miningSuspected,proto3,oneof"`
+}
+
+type GetEventsResponse_SshConnection struct {
+	// SshConnection reports a connection of an SSH client.
+	SshConnection *SshConnection `protobuf:"bytes,16,opt,name=ssh_connection,json=sshConnection,proto3,oneof"`
+}
+
+type GetEventsResponse_ProcessPerfEvent struct {
+	// ProcessPerfEvent reports a sample of a perf event and the process
+	// that was running.
+	ProcessPerfEvent *ProcessPerfEvent `protobuf:"bytes,17,opt,name=process_perf_event,json=processPerfEvent,proto3,oneof"`
+}
+
 type GetEventsResponse_Test struct {
 	Test *Test `protobuf:"bytes,40000,opt,name=test,proto3,oneof"`
 }
@@ -775,6 +1838,30 @@ type GetEventsResponse_RateLimitInfo struct {
 	RateLimitInfo *RateLimitInfo `protobuf:"bytes,40001,opt,name=rate_limit_info,json=rateLimitInfo,proto3,oneof"`
 }
 
+type GetEventsResponse_ExportSinkHealth struct {
+	ExportSinkHealth *ExportSinkHealth `protobuf:"bytes,40002,opt,name=export_sink_health,json=exportSinkHealth,proto3,oneof"`
+}
+
+type GetEventsResponse_EventAnnotation struct {
+	EventAnnotation *EventAnnotation `protobuf:"bytes,40003,opt,name=event_annotation,json=eventAnnotation,proto3,oneof"`
+}
+
+type GetEventsResponse_RingBufferDrops struct {
+	RingBufferDrops *RingBufferDrops `protobuf:"bytes,40004,opt,name=ring_buffer_drops,json=ringBufferDrops,proto3,oneof"`
+}
+
+type GetEventsResponse_MapFill struct {
+	MapFill *MapFill `protobuf:"bytes,40005,opt,name=map_fill,json=mapFill,proto3,oneof"`
+}
+
+type GetEventsResponse_ConfigSnapshot struct {
+	ConfigSnapshot *ConfigSnapshot `protobuf:"bytes,40006,opt,name=config_snapshot,json=configSnapshot,proto3,oneof"`
+}
+
+type GetEventsResponse_LostEvent struct {
+	LostEvent *LostEvent `protobuf:"bytes,40007,opt,name=lost_event,json=lostEvent,proto3,oneof"`
+}
+
 func (*GetEventsResponse_ProcessExec) isGetEventsResponse_Event() {}
 
 func (*GetEventsResponse_ProcessExit) isGetEventsResponse_Event() {}
@@ -787,10 +1874,32 @@ func (*GetEventsResponse_ProcessLoader) isGetEventsResponse_Event() {}
 
 func (*GetEventsResponse_ProcessUprobe) isGetEventsResponse_Event() {}
 
+func (*GetEventsResponse_ProcessLsm) isGetEventsResponse_Event() {}
+
+func (*GetEventsResponse_ProcessKprobeCount) isGetEventsResponse_Event() {}
+
+func (*GetEventsResponse_MiningSuspected) isGetEventsResponse_Event() {}
+
+func (*GetEventsResponse_SshConnection) isGetEventsResponse_Event() {}
+
+func (*GetEventsResponse_ProcessPerfEvent) isGetEventsResponse_Event() {}
+
 func (*GetEventsResponse_Test) isGetEventsResponse_Event() {}
 
 func (*GetEventsResponse_RateLimitInfo) isGetEventsResponse_Event() {}
 
+func (*GetEventsResponse_ExportSinkHealth) isGetEventsResponse_Event() {}
+
+func (*GetEventsResponse_EventAnnotation) isGetEventsResponse_Event() {}
+
+func (*GetEventsResponse_RingBufferDrops) isGetEventsResponse_Event() {}
+
+func (*GetEventsResponse_MapFill) isGetEventsResponse_Event() {}
+
+func (*GetEventsResponse_ConfigSnapshot) isGetEventsResponse_Event() {}
+
+func (*GetEventsResponse_LostEvent) isGetEventsResponse_Event() {}
+
 var File_tetragon_events_proto protoreflect.FileDescriptor
 
 var file_tetragon_events_proto_rawDesc = []byte{
@@ -805,7 +1914,7 @@ var file_tetragon_events_proto_rawDesc = []byte{
 	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65,
 	0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20, 0x67, 0x6f, 0x6f,
 	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x66, 0x69, 0x65,
-	0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc3, 0x02,
+	0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf7, 0x03,
 	0x0a, 0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x69, 0x6e, 0x61,
 	0x72, 0x79, 0x5f, 0x72, 0x65, 0x67, 0x65, 0x78, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b,
 	0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x67, 0x65, 0x78, 0x12, 0x1c, 0x0a, 0x09, 0x6e,
@@ -826,112 +1935,308 @@ var file_tetragon_events_proto_rawDesc = []byte{
 	0x72, 0x65, 0x67, 0x65, 0x78, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x72, 0x67,
 	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x67, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x6c,
 	0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62,
-	0x65, 0x6c, 0x73, 0x22, 0xee, 0x01, 0x0a, 0x0b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x46, 0x69, 0x6c,
-	0x74, 0x65, 0x72, 0x12, 0x30, 0x0a, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x74,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f,
-	0x6e, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x65, 0x76, 0x65,
-	0x6e, 0x74, 0x53, 0x65, 0x74, 0x12, 0x32, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73,
-	0x6b, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x33, 0x0a, 0x06, 0x61, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x74, 0x65, 0x74, 0x72,
-	0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
-	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x44,
-	0x0a, 0x10, 0x69, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x73,
-	0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x52, 0x0e, 0x69, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x53, 0x65, 0x74, 0x22, 0xfd, 0x01, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x0a, 0x61, 0x6c, 0x6c,
-	0x6f, 0x77, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e,
-	0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52,
-	0x09, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x09, 0x64, 0x65,
-	0x6e, 0x79, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e,
-	0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52,
-	0x08, 0x64, 0x65, 0x6e, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x4d, 0x0a, 0x13, 0x61, 0x67, 0x67,
-	0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f,
-	0x6e, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x12, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3a, 0x0a, 0x0d, 0x66, 0x69, 0x65, 0x6c,
-	0x64, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x15, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64,
-	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0c, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x46, 0x69, 0x6c,
-	0x74, 0x65, 0x72, 0x73, 0x22, 0x80, 0x01, 0x0a, 0x12, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3a, 0x0a, 0x0b, 0x77,
-	0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x65, 0x6c, 0x73, 0x12, 0x22, 0x0a, 0x03, 0x61, 0x6e, 0x64, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x52, 0x03, 0x61, 0x6e, 0x64, 0x12, 0x20, 0x0a, 0x02, 0x6f, 0x72, 0x18, 0x0b, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x02, 0x6f, 0x72, 0x12, 0x22, 0x0a, 0x03, 0x6e, 0x6f, 0x74,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f,
+	0x6e, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x03, 0x6e, 0x6f, 0x74, 0x12, 0x21, 0x0a,
+	0x0c, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x0d, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x73,
+	0x12, 0x25, 0x0a, 0x0e, 0x63, 0x65, 0x6c, 0x5f, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x65, 0x6c, 0x45, 0x78, 0x70,
+	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xee, 0x01, 0x0a, 0x0b, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x30, 0x0a, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x5f, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x74, 0x65, 0x74,
+	0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x74, 0x12, 0x32, 0x0a, 0x06, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x33, 0x0a,
+	0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e,
+	0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x44, 0x0a, 0x10, 0x69, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x5f, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42,
+	0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0e, 0x69, 0x6e, 0x76, 0x65, 0x72, 0x74,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x74, 0x22, 0x8f, 0x01, 0x0a, 0x0f, 0x52, 0x65, 0x64,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x30, 0x0a, 0x09,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32,
+	0x13, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x74, 0x12, 0x32,
+	0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x64, 0x61, 0x63, 0x74, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x64, 0x61, 0x63, 0x74, 0x22, 0xfd, 0x01, 0x0a, 0x10, 0x47,
+	0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x2f, 0x0a, 0x0a, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x09, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x4c, 0x69, 0x73, 0x74,
+	0x12, 0x2d, 0x0a, 0x09, 0x64, 0x65, 0x6e, 0x79, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x08, 0x64, 0x65, 0x6e, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x12,
+	0x4d, 0x0a, 0x13, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x74,
+	0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x12, 0x61, 0x67, 0x67, 0x72,
+	0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3a,
+	0x0a, 0x0d, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e,
+	0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0c, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x22, 0x80, 0x01, 0x0a, 0x12, 0x41,
+	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x3a, 0x0a, 0x0b, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0a, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2e, 0x0a,
+	0x13, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x63, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x27, 0x0a,
+	0x0f, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x57, 0x0a, 0x0d, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x46, 0x0a, 0x20, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x5f, 0x6f, 0x66, 0x5f, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x1c, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66, 0x44, 0x72, 0x6f, 0x70, 0x70,
+	0x65, 0x64, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x22,
+	0x85, 0x02, 0x0a, 0x10, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x69, 0x6e, 0x6b, 0x48, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x73, 0x69, 0x6e, 0x6b, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x61, 0x69, 0x6c,
+	0x6f, 0x76, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x66, 0x61, 0x69, 0x6c, 0x6f, 0x76, 0x65, 0x72, 0x53, 0x69, 0x6e, 0x6b, 0x12, 0x18, 0x0a,
+	0x07, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x12, 0x44, 0x0a, 0x10, 0x66, 0x69, 0x72, 0x73, 0x74,
+	0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0e, 0x66,
+	0x69, 0x72, 0x73, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x42, 0x0a,
+	0x0f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x9e, 0x01, 0x0a, 0x0f, 0x43, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x63,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08,
+	0x63, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1f, 0x0a, 0x03, 0x70, 0x6f, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f,
+	0x6e, 0x2e, 0x50, 0x6f, 0x64, 0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x22, 0x8d, 0x01, 0x0a, 0x0f, 0x52, 0x69, 0x6e,
+	0x67, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x44, 0x72, 0x6f, 0x70, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x6c, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x6c, 0x6f, 0x73, 0x74,
+	0x12, 0x31, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x77, 0x69, 0x6e,
-	0x64, 0x6f, 0x77, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x63, 0x68, 0x61, 0x6e, 0x6e,
-	0x65, 0x6c, 0x5f, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x42, 0x75, 0x66,
-	0x66, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x27, 0x0a, 0x0f, 0x41, 0x67, 0x67, 0x72, 0x65,
-	0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x22, 0x57, 0x0a, 0x0d, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x49, 0x6e, 0x66,
-	0x6f, 0x12, 0x46, 0x0a, 0x20, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x6f, 0x66, 0x5f, 0x64,
-	0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x65,
-	0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x1c, 0x6e, 0x75, 0x6d,
-	0x62, 0x65, 0x72, 0x4f, 0x66, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x50, 0x72, 0x6f, 0x63,
-	0x65, 0x73, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xab, 0x05, 0x0a, 0x11, 0x47, 0x65,
-	0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x3a, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x65, 0x78, 0x65, 0x63, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e,
-	0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x45, 0x78, 0x65, 0x63, 0x48, 0x00, 0x52, 0x0b,
-	0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x45, 0x78, 0x65, 0x63, 0x12, 0x3a, 0x0a, 0x0c, 0x70,
-	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x65, 0x78, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x15, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x6f,
-	0x63, 0x65, 0x73, 0x73, 0x45, 0x78, 0x69, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x63,
-	0x65, 0x73, 0x73, 0x45, 0x78, 0x69, 0x74, 0x12, 0x40, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x63, 0x65,
-	0x73, 0x73, 0x5f, 0x6b, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x17, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65,
-	0x73, 0x73, 0x4b, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x48, 0x00, 0x52, 0x0d, 0x70, 0x72, 0x6f, 0x63,
-	0x65, 0x73, 0x73, 0x4b, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x4c, 0x0a, 0x12, 0x70, 0x72, 0x6f,
-	0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18,
-	0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e,
-	0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69,
-	0x6e, 0x74, 0x48, 0x00, 0x52, 0x11, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x54, 0x72, 0x61,
-	0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x40, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x63, 0x65,
-	0x73, 0x73, 0x5f, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x17, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65,
-	0x73, 0x73, 0x4c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x48, 0x00, 0x52, 0x0d, 0x70, 0x72, 0x6f, 0x63,
-	0x65, 0x73, 0x73, 0x4c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x12, 0x40, 0x0a, 0x0e, 0x70, 0x72, 0x6f,
-	0x63, 0x65, 0x73, 0x73, 0x5f, 0x75, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x17, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x6f,
-	0x63, 0x65, 0x73, 0x73, 0x55, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x48, 0x00, 0x52, 0x0d, 0x70, 0x72,
-	0x6f, 0x63, 0x65, 0x73, 0x73, 0x55, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x26, 0x0a, 0x04, 0x74,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x77, 0x69, 0x6e,
+	0x64, 0x6f, 0x77, 0x12, 0x33, 0x0a, 0x07, 0x63, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e,
+	0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x61, 0x74, 0x65, 0x52,
+	0x07, 0x63, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x22, 0xcb, 0x01, 0x0a, 0x09, 0x4c, 0x6f, 0x73,
+	0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x63, 0x70, 0x75, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x63, 0x70, 0x75, 0x12, 0x3d,
+	0x0a, 0x0c, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x0b, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x39, 0x0a,
+	0x0a, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x77,
+	0x69, 0x6e, 0x64, 0x6f, 0x77, 0x45, 0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x22, 0xd3, 0x01, 0x0a, 0x07, 0x4d, 0x61, 0x70, 0x46, 0x69,
+	0x6c, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61,
+	0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x61, 0x70, 0x12, 0x10, 0x0a, 0x03,
+	0x70, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x69, 0x6e, 0x12, 0x18,
+	0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f,
+	0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6d,
+	0x61, 0x78, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x61, 0x62, 0x6f,
+	0x76, 0x65, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0e, 0x61, 0x62, 0x6f, 0x76, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f,
+	0x6c, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x65,
+	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x6e, 0x65,
+	0x78, 0x74, 0x4d, 0x61, 0x78, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x7f, 0x0a, 0x14,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x12, 0x1b, 0x0a, 0x09, 0x73, 0x70, 0x65, 0x63, 0x5f, 0x79, 0x61, 0x6d, 0x6c, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x70, 0x65, 0x63, 0x59, 0x61, 0x6d, 0x6c, 0x22, 0xf1, 0x02,
+	0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x05, 0x66, 0x6c,
+	0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x74, 0x65, 0x74, 0x72,
+	0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x2e, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05,
+	0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x5f,
+	0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6b,
+	0x65, 0x72, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e,
+	0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x12, 0x3a, 0x0a,
+	0x08, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1e, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52,
+	0x08, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73,
+	0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x18, 0x0a,
+	0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x1a, 0x38, 0x0a, 0x0a, 0x46, 0x6c, 0x61, 0x67, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0xc4, 0x01, 0x0a, 0x0f, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x41, 0x6e, 0x6e, 0x6f, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x65, 0x78, 0x65, 0x63, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x78, 0x65, 0x63, 0x49, 0x64, 0x12, 0x30,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x64, 0x69, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x16, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x56, 0x65, 0x72, 0x64, 0x69, 0x63, 0x74, 0x52, 0x07, 0x76, 0x65, 0x72, 0x64, 0x69, 0x63, 0x74,
+	0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x16, 0x0a, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0xa0, 0x0b, 0x0a, 0x11, 0x47, 0x65, 0x74,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a,
+	0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x65, 0x78, 0x65, 0x63, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e,
+	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x45, 0x78, 0x65, 0x63, 0x48, 0x00, 0x52, 0x0b, 0x70,
+	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x45, 0x78, 0x65, 0x63, 0x12, 0x3a, 0x0a, 0x0c, 0x70, 0x72,
+	0x6f, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x65, 0x78, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x6f, 0x63,
+	0x65, 0x73, 0x73, 0x45, 0x78, 0x69, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x63, 0x65,
+	0x73, 0x73, 0x45, 0x78, 0x69, 0x74, 0x12, 0x40, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73,
+	0x73, 0x5f, 0x6b, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73,
+	0x73, 0x4b, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x48, 0x00, 0x52, 0x0d, 0x70, 0x72, 0x6f, 0x63, 0x65,
+	0x73, 0x73, 0x4b, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x4c, 0x0a, 0x12, 0x70, 0x72, 0x6f, 0x63,
+	0x65, 0x73, 0x73, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e,
+	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x54, 0x72, 0x61, 0x63, 0x65, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x48, 0x00, 0x52, 0x11, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x54, 0x72, 0x61, 0x63,
+	0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x40, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73,
+	0x73, 0x5f, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73,
+	0x73, 0x4c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x48, 0x00, 0x52, 0x0d, 0x70, 0x72, 0x6f, 0x63, 0x65,
+	0x73, 0x73, 0x4c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x12, 0x40, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x63,
+	0x65, 0x73, 0x73, 0x5f, 0x75, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x6f, 0x63,
+	0x65, 0x73, 0x73, 0x55, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x48, 0x00, 0x52, 0x0d, 0x70, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x55, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x37, 0x0a, 0x0b, 0x70, 0x72,
+	0x6f, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x6c, 0x73, 0x6d, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65,
+	0x73, 0x73, 0x4c, 0x73, 0x6d, 0x48, 0x00, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
+	0x4c, 0x73, 0x6d, 0x12, 0x50, 0x0a, 0x14, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x6b,
+	0x70, 0x72, 0x6f, 0x62, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x4b, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x48,
+	0x00, 0x52, 0x12, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x4b, 0x70, 0x72, 0x6f, 0x62, 0x65,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x46, 0x0a, 0x10, 0x6d, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x5f,
+	0x73, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x4d, 0x69, 0x6e, 0x69, 0x6e,
+	0x67, 0x53, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0f, 0x6d, 0x69,
+	0x6e, 0x69, 0x6e, 0x67, 0x53, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x40, 0x0a,
+	0x0e, 0x73, 0x73, 0x68, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e,
+	0x2e, 0x53, 0x73, 0x68, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00,
+	0x52, 0x0d, 0x73, 0x73, 0x68, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x4a, 0x0a, 0x12, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x66, 0x5f,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74, 0x65,
+	0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x50, 0x65,
+	0x72, 0x66, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x10, 0x70, 0x72, 0x6f, 0x63, 0x65,
+	0x73, 0x73, 0x50, 0x65, 0x72, 0x66, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x04, 0x74,
 	0x65, 0x73, 0x74, 0x18, 0xc0, 0xb8, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x74, 0x65,
 	0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x04, 0x74,
 	0x65, 0x73, 0x74, 0x12, 0x43, 0x0a, 0x0f, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69,
 	0x74, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0xc1, 0xb8, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
 	0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69,
 	0x6d, 0x69, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x48, 0x00, 0x52, 0x0d, 0x72, 0x61, 0x74, 0x65, 0x4c,
-	0x69, 0x6d, 0x69, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x6f, 0x64, 0x65,
-	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0xe8, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x6f,
-	0x64, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0xe9,
-	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x45, 0x0a, 0x10, 0x61, 0x67, 0x67, 0x72, 0x65,
-	0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0xea, 0x07, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x41, 0x67,
-	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0f, 0x61,
-	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x42, 0x07,
-	0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2a, 0xb1, 0x01, 0x0a, 0x09, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x55, 0x4e, 0x44, 0x45, 0x46, 0x10, 0x00,
-	0x12, 0x10, 0x0a, 0x0c, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x45, 0x58, 0x45, 0x43,
-	0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x45, 0x58,
-	0x49, 0x54, 0x10, 0x05, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f,
-	0x4b, 0x50, 0x52, 0x4f, 0x42, 0x45, 0x10, 0x09, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x52, 0x4f, 0x43,
-	0x45, 0x53, 0x53, 0x5f, 0x54, 0x52, 0x41, 0x43, 0x45, 0x50, 0x4f, 0x49, 0x4e, 0x54, 0x10, 0x0a,
-	0x12, 0x12, 0x0a, 0x0e, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x4c, 0x4f, 0x41, 0x44,
-	0x45, 0x52, 0x10, 0x0b, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f,
-	0x55, 0x50, 0x52, 0x4f, 0x42, 0x45, 0x10, 0x0c, 0x12, 0x0a, 0x0a, 0x04, 0x54, 0x45, 0x53, 0x54,
-	0x10, 0xc0, 0xb8, 0x02, 0x12, 0x15, 0x0a, 0x0f, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x49, 0x4d,
-	0x49, 0x54, 0x5f, 0x49, 0x4e, 0x46, 0x4f, 0x10, 0xc1, 0xb8, 0x02, 0x2a, 0x2d, 0x0a, 0x11, 0x46,
-	0x69, 0x65, 0x6c, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x43, 0x4c, 0x55, 0x44, 0x45, 0x10, 0x00, 0x12, 0x0b, 0x0a,
-	0x07, 0x45, 0x58, 0x43, 0x4c, 0x55, 0x44, 0x45, 0x10, 0x01, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x69, 0x6d, 0x69, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x4c, 0x0a, 0x12, 0x65, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x5f, 0x73, 0x69, 0x6e, 0x6b, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x18, 0xc2,
+	0xb8, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f,
+	0x6e, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x69, 0x6e, 0x6b, 0x48, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x48, 0x00, 0x52, 0x10, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x69, 0x6e, 0x6b,
+	0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x48, 0x0a, 0x10, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f,
+	0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0xc3, 0xb8, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52,
+	0x0f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x49, 0x0a, 0x11, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x5f,
+	0x64, 0x72, 0x6f, 0x70, 0x73, 0x18, 0xc4, 0xb8, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x52, 0x69, 0x6e, 0x67, 0x42, 0x75, 0x66,
+	0x66, 0x65, 0x72, 0x44, 0x72, 0x6f, 0x70, 0x73, 0x48, 0x00, 0x52, 0x0f, 0x72, 0x69, 0x6e, 0x67,
+	0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x44, 0x72, 0x6f, 0x70, 0x73, 0x12, 0x30, 0x0a, 0x08, 0x6d,
+	0x61, 0x70, 0x5f, 0x66, 0x69, 0x6c, 0x6c, 0x18, 0xc5, 0xb8, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x4d, 0x61, 0x70, 0x46, 0x69,
+	0x6c, 0x6c, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x61, 0x70, 0x46, 0x69, 0x6c, 0x6c, 0x12, 0x45, 0x0a,
+	0x0f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x18, 0xc6, 0xb8, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61,
+	0x67, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x48, 0x00, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x12, 0x36, 0x0a, 0x0a, 0x6c, 0x6f, 0x73, 0x74, 0x5f, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x18, 0xc7, 0xb8, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x65, 0x74,
+	0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x4c, 0x6f, 0x73, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48,
+	0x00, 0x52, 0x09, 0x6c, 0x6f, 0x73, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0xe8, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x6e, 0x6f, 0x64, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0xe9, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x45, 0x0a, 0x10, 0x61,
+	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18,
+	0xea, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f,
+	0x6e, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x0f, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e,
+	0x66, 0x6f, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2a, 0xa2, 0x03, 0x0a, 0x09,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x55, 0x4e, 0x44,
+	0x45, 0x46, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f,
+	0x45, 0x58, 0x45, 0x43, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53,
+	0x53, 0x5f, 0x45, 0x58, 0x49, 0x54, 0x10, 0x05, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x52, 0x4f, 0x43,
+	0x45, 0x53, 0x53, 0x5f, 0x4b, 0x50, 0x52, 0x4f, 0x42, 0x45, 0x10, 0x09, 0x12, 0x16, 0x0a, 0x12,
+	0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x54, 0x52, 0x41, 0x43, 0x45, 0x50, 0x4f, 0x49,
+	0x4e, 0x54, 0x10, 0x0a, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f,
+	0x4c, 0x4f, 0x41, 0x44, 0x45, 0x52, 0x10, 0x0b, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x52, 0x4f, 0x43,
+	0x45, 0x53, 0x53, 0x5f, 0x55, 0x50, 0x52, 0x4f, 0x42, 0x45, 0x10, 0x0c, 0x12, 0x0f, 0x0a, 0x0b,
+	0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x4c, 0x53, 0x4d, 0x10, 0x0d, 0x12, 0x18, 0x0a,
+	0x14, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x4b, 0x50, 0x52, 0x4f, 0x42, 0x45, 0x5f,
+	0x43, 0x4f, 0x55, 0x4e, 0x54, 0x10, 0x0e, 0x12, 0x14, 0x0a, 0x10, 0x4d, 0x49, 0x4e, 0x49, 0x4e,
+	0x47, 0x5f, 0x53, 0x55, 0x53, 0x50, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x0f, 0x12, 0x12, 0x0a,
+	0x0e, 0x53, 0x53, 0x48, 0x5f, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x10,
+	0x10, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x50, 0x45, 0x52,
+	0x46, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x10, 0x11, 0x12, 0x0a, 0x0a, 0x04, 0x54, 0x45, 0x53,
+	0x54, 0x10, 0xc0, 0xb8, 0x02, 0x12, 0x15, 0x0a, 0x0f, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x49,
+	0x4d, 0x49, 0x54, 0x5f, 0x49, 0x4e, 0x46, 0x4f, 0x10, 0xc1, 0xb8, 0x02, 0x12, 0x18, 0x0a, 0x12,
+	0x45, 0x58, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x53, 0x49, 0x4e, 0x4b, 0x5f, 0x48, 0x45, 0x41, 0x4c,
+	0x54, 0x48, 0x10, 0xc2, 0xb8, 0x02, 0x12, 0x16, 0x0a, 0x10, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f,
+	0x41, 0x4e, 0x4e, 0x4f, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0xc3, 0xb8, 0x02, 0x12, 0x17,
+	0x0a, 0x11, 0x52, 0x49, 0x4e, 0x47, 0x5f, 0x42, 0x55, 0x46, 0x46, 0x45, 0x52, 0x5f, 0x44, 0x52,
+	0x4f, 0x50, 0x53, 0x10, 0xc4, 0xb8, 0x02, 0x12, 0x0e, 0x0a, 0x08, 0x4d, 0x41, 0x50, 0x5f, 0x46,
+	0x49, 0x4c, 0x4c, 0x10, 0xc5, 0xb8, 0x02, 0x12, 0x15, 0x0a, 0x0f, 0x43, 0x4f, 0x4e, 0x46, 0x49,
+	0x47, 0x5f, 0x53, 0x4e, 0x41, 0x50, 0x53, 0x48, 0x4f, 0x54, 0x10, 0xc6, 0xb8, 0x02, 0x12, 0x10,
+	0x0a, 0x0a, 0x4c, 0x4f, 0x53, 0x54, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x10, 0xc7, 0xb8, 0x02,
+	0x2a, 0x2d, 0x0a, 0x11, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x43, 0x4c, 0x55, 0x44, 0x45,
+	0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x45, 0x58, 0x43, 0x4c, 0x55, 0x44, 0x45, 0x10, 0x01, 0x2a,
+	0x82, 0x01, 0x0a, 0x0c, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x56, 0x65, 0x72, 0x64, 0x69, 0x63, 0x74,
+	0x12, 0x1d, 0x0a, 0x19, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x56, 0x45, 0x52, 0x44, 0x49, 0x43,
+	0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x18, 0x0a, 0x14, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x56, 0x45, 0x52, 0x44, 0x49, 0x43, 0x54,
+	0x5f, 0x42, 0x45, 0x4e, 0x49, 0x47, 0x4e, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x56, 0x45,
+	0x4e, 0x54, 0x5f, 0x56, 0x45, 0x52, 0x44, 0x49, 0x43, 0x54, 0x5f, 0x53, 0x55, 0x53, 0x50, 0x49,
+	0x43, 0x49, 0x4f, 0x55, 0x53, 0x10, 0x02, 0x12, 0x1b, 0x0a, 0x17, 0x45, 0x56, 0x45, 0x4e, 0x54,
+	0x5f, 0x56, 0x45, 0x52, 0x44, 0x49, 0x43, 0x54, 0x5f, 0x4d, 0x41, 0x4c, 0x49, 0x43, 0x49, 0x4f,
+	0x55, 0x53, 0x10, 0x03, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -946,57 +2251,101 @@ func file_tetragon_events_proto_rawDescGZIP() []byte {
 	return file_tetragon_events_proto_rawDescData
 }
 
-var file_tetragon_events_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_tetragon_events_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_tetragon_events_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_tetragon_events_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_tetragon_events_proto_goTypes = []interface{}{
 	(EventType)(0),                // 0: tetragon.EventType
 	(FieldFilterAction)(0),        // 1: tetragon.FieldFilterAction
-	(*Filter)(nil),                // 2: tetragon.Filter
-	(*FieldFilter)(nil),           // 3: tetragon.FieldFilter
-	(*GetEventsRequest)(nil),      // 4: tetragon.GetEventsRequest
-	(*AggregationOptions)(nil),    // 5: tetragon.AggregationOptions
-	(*AggregationInfo)(nil),       // 6: tetragon.AggregationInfo
-	(*RateLimitInfo)(nil),         // 7: tetragon.RateLimitInfo
-	(*GetEventsResponse)(nil),     // 8: tetragon.GetEventsResponse
-	(*wrapperspb.BoolValue)(nil),  // 9: google.protobuf.BoolValue
-	(*fieldmaskpb.FieldMask)(nil), // 10: google.protobuf.FieldMask
-	(*durationpb.Duration)(nil),   // 11: google.protobuf.Duration
-	(*ProcessExec)(nil),           // 12: tetragon.ProcessExec
-	(*ProcessExit)(nil),           // 13: tetragon.ProcessExit
-	(*ProcessKprobe)(nil),         // 14: tetragon.ProcessKprobe
-	(*ProcessTracepoint)(nil),     // 15: tetragon.ProcessTracepoint
-	(*ProcessLoader)(nil),         // 16: tetragon.ProcessLoader
-	(*ProcessUprobe)(nil),         // 17: tetragon.ProcessUprobe
-	(*Test)(nil),                  // 18: tetragon.Test
-	(*timestamppb.Timestamp)(nil), // 19: google.protobuf.Timestamp
+	(EventVerdict)(0),             // 2: tetragon.EventVerdict
+	(*Filter)(nil),                // 3: tetragon.Filter
+	(*FieldFilter)(nil),           // 4: tetragon.FieldFilter
+	(*RedactionFilter)(nil),       // 5: tetragon.RedactionFilter
+	(*GetEventsRequest)(nil),      // 6: tetragon.GetEventsRequest
+	(*AggregationOptions)(nil),    // 7: tetragon.AggregationOptions
+	(*AggregationInfo)(nil),       // 8: tetragon.AggregationInfo
+	(*RateLimitInfo)(nil),         // 9: tetragon.RateLimitInfo
+	(*ExportSinkHealth)(nil),      // 10: tetragon.ExportSinkHealth
+	(*CgroupEventRate)(nil),       // 11: tetragon.CgroupEventRate
+	(*RingBufferDrops)(nil),       // 12: tetragon.RingBufferDrops
+	(*LostEvent)(nil),             // 13: tetragon.LostEvent
+	(*MapFill)(nil),               // 14: tetragon.MapFill
+	(*ConfigSnapshotPolicy)(nil),  // 15: tetragon.ConfigSnapshotPolicy
+	(*ConfigSnapshot)(nil),        // 16: tetragon.ConfigSnapshot
+	(*EventAnnotation)(nil),       // 17: tetragon.EventAnnotation
+	(*GetEventsResponse)(nil),     // 18: tetragon.GetEventsResponse
+	nil,                           // 19: tetragon.ConfigSnapshot.FlagsEntry
+	(*wrapperspb.BoolValue)(nil),  // 20: google.protobuf.BoolValue
+	(*fieldmaskpb.FieldMask)(nil), // 21: google.protobuf.FieldMask
+	(*durationpb.Duration)(nil),   // 22: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil), // 23: google.protobuf.Timestamp
+	(*Pod)(nil),                   // 24: tetragon.Pod
+	(*ProcessExec)(nil),           // 25: tetragon.ProcessExec
+	(*ProcessExit)(nil),           // 26: tetragon.ProcessExit
+	(*ProcessKprobe)(nil),         // 27: tetragon.ProcessKprobe
+	(*ProcessTracepoint)(nil),     // 28: tetragon.ProcessTracepoint
+	(*ProcessLoader)(nil),         // 29: tetragon.ProcessLoader
+	(*ProcessUprobe)(nil),         // 30: tetragon.ProcessUprobe
+	(*ProcessLsm)(nil),            // 31: tetragon.ProcessLsm
+	(*ProcessKprobeCount)(nil),    // 32: tetragon.ProcessKprobeCount
+	(*MiningSuspected)(nil),       // 33: tetragon.MiningSuspected
+	(*SshConnection)(nil),         // 34: tetragon.SshConnection
+	(*ProcessPerfEvent)(nil),      // 35: tetragon.ProcessPerfEvent
+	(*Test)(nil),                  // 36: tetragon.Test
 }
 var file_tetragon_events_proto_depIdxs = []int32{
-	9,  // 0: tetragon.Filter.health_check:type_name -> google.protobuf.BoolValue
+	20, // 0: tetragon.Filter.health_check:type_name -> google.protobuf.BoolValue
 	0,  // 1: tetragon.Filter.event_set:type_name -> tetragon.EventType
-	0,  // 2: tetragon.FieldFilter.event_set:type_name -> tetragon.EventType
-	10, // 3: tetragon.FieldFilter.fields:type_name -> google.protobuf.FieldMask
-	1,  // 4: tetragon.FieldFilter.action:type_name -> tetragon.FieldFilterAction
-	9,  // 5: tetragon.FieldFilter.invert_event_set:type_name -> google.protobuf.BoolValue
-	2,  // 6: tetragon.GetEventsRequest.allow_list:type_name -> tetragon.Filter
-	2,  // 7: tetragon.GetEventsRequest.deny_list:type_name -> tetragon.Filter
-	5,  // 8: tetragon.GetEventsRequest.aggregation_options:type_name -> tetragon.AggregationOptions
-	3,  // 9: tetragon.GetEventsRequest.field_filters:type_name -> tetragon.FieldFilter
-	11, // 10: tetragon.AggregationOptions.window_size:type_name -> google.protobuf.Duration
-	12, // 11: tetragon.GetEventsResponse.process_exec:type_name -> tetragon.ProcessExec
-	13, // 12: tetragon.GetEventsResponse.process_exit:type_name -> tetragon.ProcessExit
-	14, // 13: tetragon.GetEventsResponse.process_kprobe:type_name -> tetragon.ProcessKprobe
-	15, // 14: tetragon.GetEventsResponse.process_tracepoint:type_name -> tetragon.ProcessTracepoint
-	16, // 15: tetragon.GetEventsResponse.process_loader:type_name -> tetragon.ProcessLoader
-	17, // 16: tetragon.GetEventsResponse.process_uprobe:type_name -> tetragon.ProcessUprobe
-	18, // 17: tetragon.GetEventsResponse.test:type_name -> tetragon.Test
-	7,  // 18: tetragon.GetEventsResponse.rate_limit_info:type_name -> tetragon.RateLimitInfo
-	19, // 19: tetragon.GetEventsResponse.time:type_name -> google.protobuf.Timestamp
-	6,  // 20: tetragon.GetEventsResponse.aggregation_info:type_name -> tetragon.AggregationInfo
-	21, // [21:21] is the sub-list for method output_type
-	21, // [21:21] is the sub-list for method input_type
-	21, // [21:21] is the sub-list for extension type_name
-	21, // [21:21] is the sub-list for extension extendee
-	0,  // [0:21] is the sub-list for field type_name
+	3,  // 2: tetragon.Filter.and:type_name -> tetragon.Filter
+	3,  // 3: tetragon.Filter.or:type_name -> tetragon.Filter
+	3,  // 4: tetragon.Filter.not:type_name -> tetragon.Filter
+	0,  // 5: tetragon.FieldFilter.event_set:type_name -> tetragon.EventType
+	21, // 6: tetragon.FieldFilter.fields:type_name -> google.protobuf.FieldMask
+	1,  // 7: tetragon.FieldFilter.action:type_name -> tetragon.FieldFilterAction
+	20, // 8: tetragon.FieldFilter.invert_event_set:type_name -> google.protobuf.BoolValue
+	0,  // 9: tetragon.RedactionFilter.event_set:type_name -> tetragon.EventType
+	21, // 10: tetragon.RedactionFilter.fields:type_name -> google.protobuf.FieldMask
+	3,  // 11: tetragon.GetEventsRequest.allow_list:type_name -> tetragon.Filter
+	3,  // 12: tetragon.GetEventsRequest.deny_list:type_name -> tetragon.Filter
+	7,  // 13: tetragon.GetEventsRequest.aggregation_options:type_name -> tetragon.AggregationOptions
+	4,  // 14: tetragon.GetEventsRequest.field_filters:type_name -> tetragon.FieldFilter
+	22, // 15: tetragon.AggregationOptions.window_size:type_name -> google.protobuf.Duration
+	23, // 16: tetragon.ExportSinkHealth.first_event_time:type_name -> google.protobuf.Timestamp
+	23, // 17: tetragon.ExportSinkHealth.last_event_time:type_name -> google.protobuf.Timestamp
+	24, // 18: tetragon.CgroupEventRate.pod:type_name -> tetragon.Pod
+	22, // 19: tetragon.RingBufferDrops.window:type_name -> google.protobuf.Duration
+	11, // 20: tetragon.RingBufferDrops.cgroups:type_name -> tetragon.CgroupEventRate
+	23, // 21: tetragon.LostEvent.window_start:type_name -> google.protobuf.Timestamp
+	23, // 22: tetragon.LostEvent.window_end:type_name -> google.protobuf.Timestamp
+	19, // 23: tetragon.ConfigSnapshot.flags:type_name -> tetragon.ConfigSnapshot.FlagsEntry
+	15, // 24: tetragon.ConfigSnapshot.policies:type_name -> tetragon.ConfigSnapshotPolicy
+	2,  // 25: tetragon.EventAnnotation.verdict:type_name -> tetragon.EventVerdict
+	23, // 26: tetragon.EventAnnotation.time:type_name -> google.protobuf.Timestamp
+	25, // 27: tetragon.GetEventsResponse.process_exec:type_name -> tetragon.ProcessExec
+	26, // 28: tetragon.GetEventsResponse.process_exit:type_name -> tetragon.ProcessExit
+	27, // 29: tetragon.GetEventsResponse.process_kprobe:type_name -> tetragon.ProcessKprobe
+	28, // 30: tetragon.GetEventsResponse.process_tracepoint:type_name -> tetragon.ProcessTracepoint
+	29, // 31: tetragon.GetEventsResponse.process_loader:type_name -> tetragon.ProcessLoader
+	30, // 32: tetragon.GetEventsResponse.process_uprobe:type_name -> tetragon.ProcessUprobe
+	31, // 33: tetragon.GetEventsResponse.process_lsm:type_name -> tetragon.ProcessLsm
+	32, // 34: tetragon.GetEventsResponse.process_kprobe_count:type_name -> tetragon.ProcessKprobeCount
+	33, // 35: tetragon.GetEventsResponse.mining_suspected:type_name -> tetragon.MiningSuspected
+	34, // 36: tetragon.GetEventsResponse.ssh_connection:type_name -> tetragon.SshConnection
+	35, // 37: tetragon.GetEventsResponse.process_perf_event:type_name -> tetragon.ProcessPerfEvent
+	36, // 38: tetragon.GetEventsResponse.test:type_name -> tetragon.Test
+	9,  // 39: tetragon.GetEventsResponse.rate_limit_info:type_name -> tetragon.RateLimitInfo
+	10, // 40: tetragon.GetEventsResponse.export_sink_health:type_name -> tetragon.ExportSinkHealth
+	17, // 41: tetragon.GetEventsResponse.event_annotation:type_name -> tetragon.EventAnnotation
+	12, // 42: tetragon.GetEventsResponse.ring_buffer_drops:type_name -> tetragon.RingBufferDrops
+	14, // 43: tetragon.GetEventsResponse.map_fill:type_name -> tetragon.MapFill
+	16, // 44: tetragon.GetEventsResponse.config_snapshot:type_name -> tetragon.ConfigSnapshot
+	13, // 45: tetragon.GetEventsResponse.lost_event:type_name -> tetragon.LostEvent
+	23, // 46: tetragon.GetEventsResponse.time:type_name -> google.protobuf.Timestamp
+	8,  // 47: tetragon.GetEventsResponse.aggregation_info:type_name -> tetragon.AggregationInfo
+	48, // [48:48] is the sub-list for method output_type
+	48, // [48:48] is the sub-list for method input_type
+	48, // [48:48] is the sub-list for extension type_name
+	48, // [48:48] is the sub-list for extension extendee
+	0,  // [0:48] is the sub-list for field type_name
 }
 
 func init() { file_tetragon_events_proto_init() }
@@ -1031,7 +2380,7 @@ func file_tetragon_events_proto_init() {
 			}
 		}
 		file_tetragon_events_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetEventsRequest); i {
+			switch v := v.(*RedactionFilter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1043,7 +2392,7 @@ func file_tetragon_events_proto_init() {
 			}
 		}
 		file_tetragon_events_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AggregationOptions); i {
+			switch v := v.(*GetEventsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1055,7 +2404,7 @@ func file_tetragon_events_proto_init() {
 			}
 		}
 		file_tetragon_events_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AggregationInfo); i {
+			switch v := v.(*AggregationOptions); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1067,7 +2416,7 @@ func file_tetragon_events_proto_init() {
 			}
 		}
 		file_tetragon_events_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RateLimitInfo); i {
+			switch v := v.(*AggregationInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1079,6 +2428,114 @@ func file_tetragon_events_proto_init() {
 			}
 		}
 		file_tetragon_events_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RateLimitInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tetragon_events_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportSinkHealth); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tetragon_events_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CgroupEventRate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tetragon_events_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RingBufferDrops); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tetragon_events_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LostEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tetragon_events_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MapFill); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tetragon_events_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigSnapshotPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tetragon_events_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigSnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tetragon_events_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EventAnnotation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tetragon_events_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetEventsResponse); i {
 			case 0:
 				return &v.state
@@ -1091,23 +2548,34 @@ func file_tetragon_events_proto_init() {
 			}
 		}
 	}
-	file_tetragon_events_proto_msgTypes[6].OneofWrappers = []interface{}{
+	file_tetragon_events_proto_msgTypes[15].OneofWrappers = []interface{}{
 		(*GetEventsResponse_ProcessExec)(nil),
 		(*GetEventsResponse_ProcessExit)(nil),
 		(*GetEventsResponse_ProcessKprobe)(nil),
 		(*GetEventsResponse_ProcessTracepoint)(nil),
 		(*GetEventsResponse_ProcessLoader)(nil),
 		(*GetEventsResponse_ProcessUprobe)(nil),
+		(*GetEventsResponse_ProcessLsm)(nil),
+		(*GetEventsResponse_ProcessKprobeCount)(nil),
+		(*GetEventsResponse_MiningSuspected)(nil),
+		(*GetEventsResponse_SshConnection)(nil),
+		(*GetEventsResponse_ProcessPerfEvent)(nil),
 		(*GetEventsResponse_Test)(nil),
 		(*GetEventsResponse_RateLimitInfo)(nil),
+		(*GetEventsResponse_ExportSinkHealth)(nil),
+		(*GetEventsResponse_EventAnnotation)(nil),
+		(*GetEventsResponse_RingBufferDrops)(nil),
+		(*GetEventsResponse_MapFill)(nil),
+		(*GetEventsResponse_ConfigSnapshot)(nil),
+		(*GetEventsResponse_LostEvent)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_tetragon_events_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   7,
+			NumEnums:      3,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
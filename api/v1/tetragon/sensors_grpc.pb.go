@@ -22,20 +22,27 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	FineGuidanceSensors_GetEvents_FullMethodName            = "/tetragon.FineGuidanceSensors/GetEvents"
-	FineGuidanceSensors_GetHealth_FullMethodName            = "/tetragon.FineGuidanceSensors/GetHealth"
-	FineGuidanceSensors_AddTracingPolicy_FullMethodName     = "/tetragon.FineGuidanceSensors/AddTracingPolicy"
-	FineGuidanceSensors_DeleteTracingPolicy_FullMethodName  = "/tetragon.FineGuidanceSensors/DeleteTracingPolicy"
-	FineGuidanceSensors_RemoveSensor_FullMethodName         = "/tetragon.FineGuidanceSensors/RemoveSensor"
-	FineGuidanceSensors_ListTracingPolicies_FullMethodName  = "/tetragon.FineGuidanceSensors/ListTracingPolicies"
-	FineGuidanceSensors_EnableTracingPolicy_FullMethodName  = "/tetragon.FineGuidanceSensors/EnableTracingPolicy"
-	FineGuidanceSensors_DisableTracingPolicy_FullMethodName = "/tetragon.FineGuidanceSensors/DisableTracingPolicy"
-	FineGuidanceSensors_ListSensors_FullMethodName          = "/tetragon.FineGuidanceSensors/ListSensors"
-	FineGuidanceSensors_EnableSensor_FullMethodName         = "/tetragon.FineGuidanceSensors/EnableSensor"
-	FineGuidanceSensors_DisableSensor_FullMethodName        = "/tetragon.FineGuidanceSensors/DisableSensor"
-	FineGuidanceSensors_GetStackTraceTree_FullMethodName    = "/tetragon.FineGuidanceSensors/GetStackTraceTree"
-	FineGuidanceSensors_GetVersion_FullMethodName           = "/tetragon.FineGuidanceSensors/GetVersion"
-	FineGuidanceSensors_RuntimeHook_FullMethodName          = "/tetragon.FineGuidanceSensors/RuntimeHook"
+	FineGuidanceSensors_GetEvents_FullMethodName                      = "/tetragon.FineGuidanceSensors/GetEvents"
+	FineGuidanceSensors_GetHealth_FullMethodName                      = "/tetragon.FineGuidanceSensors/GetHealth"
+	FineGuidanceSensors_AddTracingPolicy_FullMethodName               = "/tetragon.FineGuidanceSensors/AddTracingPolicy"
+	FineGuidanceSensors_DeleteTracingPolicy_FullMethodName            = "/tetragon.FineGuidanceSensors/DeleteTracingPolicy"
+	FineGuidanceSensors_RemoveSensor_FullMethodName                   = "/tetragon.FineGuidanceSensors/RemoveSensor"
+	FineGuidanceSensors_ListTracingPolicies_FullMethodName            = "/tetragon.FineGuidanceSensors/ListTracingPolicies"
+	FineGuidanceSensors_EnableTracingPolicy_FullMethodName            = "/tetragon.FineGuidanceSensors/EnableTracingPolicy"
+	FineGuidanceSensors_DisableTracingPolicy_FullMethodName           = "/tetragon.FineGuidanceSensors/DisableTracingPolicy"
+	FineGuidanceSensors_UpdateTracingPolicySelectors_FullMethodName   = "/tetragon.FineGuidanceSensors/UpdateTracingPolicySelectors"
+	FineGuidanceSensors_AddTracingPolicySelectorValues_FullMethodName = "/tetragon.FineGuidanceSensors/AddTracingPolicySelectorValues"
+	FineGuidanceSensors_ListSensors_FullMethodName                    = "/tetragon.FineGuidanceSensors/ListSensors"
+	FineGuidanceSensors_EnableSensor_FullMethodName                   = "/tetragon.FineGuidanceSensors/EnableSensor"
+	FineGuidanceSensors_DisableSensor_FullMethodName                  = "/tetragon.FineGuidanceSensors/DisableSensor"
+	FineGuidanceSensors_GetStackTraceTree_FullMethodName              = "/tetragon.FineGuidanceSensors/GetStackTraceTree"
+	FineGuidanceSensors_GetVersion_FullMethodName                     = "/tetragon.FineGuidanceSensors/GetVersion"
+	FineGuidanceSensors_RuntimeHook_FullMethodName                    = "/tetragon.FineGuidanceSensors/RuntimeHook"
+	FineGuidanceSensors_GetProcess_FullMethodName                     = "/tetragon.FineGuidanceSensors/GetProcess"
+	FineGuidanceSensors_GetProcessByExecId_FullMethodName             = "/tetragon.FineGuidanceSensors/GetProcessByExecId"
+	FineGuidanceSensors_AnnotateEvent_FullMethodName                  = "/tetragon.FineGuidanceSensors/AnnotateEvent"
+	FineGuidanceSensors_GetCapabilityReport_FullMethodName            = "/tetragon.FineGuidanceSensors/GetCapabilityReport"
+	FineGuidanceSensors_GetKernelFeatures_FullMethodName              = "/tetragon.FineGuidanceSensors/GetKernelFeatures"
 )
 
 // FineGuidanceSensorsClient is the client API for FineGuidanceSensors service.
@@ -50,12 +57,27 @@ type FineGuidanceSensorsClient interface {
 	ListTracingPolicies(ctx context.Context, in *ListTracingPoliciesRequest, opts ...grpc.CallOption) (*ListTracingPoliciesResponse, error)
 	EnableTracingPolicy(ctx context.Context, in *EnableTracingPolicyRequest, opts ...grpc.CallOption) (*EnableTracingPolicyResponse, error)
 	DisableTracingPolicy(ctx context.Context, in *DisableTracingPolicyRequest, opts ...grpc.CallOption) (*DisableTracingPolicyResponse, error)
+	// UpdateTracingPolicySelectors updates the selectors of an enabled
+	// tracing policy in place, without reloading its BPF programs.
+	UpdateTracingPolicySelectors(ctx context.Context, in *UpdateTracingPolicySelectorsRequest, opts ...grpc.CallOption) (*UpdateTracingPolicySelectorsResponse, error)
+	// AddTracingPolicySelectorValues adds values to a matchArgs filter of an
+	// enabled tracing policy in place, optionally for a limited time.
+	AddTracingPolicySelectorValues(ctx context.Context, in *AddTracingPolicySelectorValuesRequest, opts ...grpc.CallOption) (*AddTracingPolicySelectorValuesResponse, error)
 	ListSensors(ctx context.Context, in *ListSensorsRequest, opts ...grpc.CallOption) (*ListSensorsResponse, error)
 	EnableSensor(ctx context.Context, in *EnableSensorRequest, opts ...grpc.CallOption) (*EnableSensorResponse, error)
 	DisableSensor(ctx context.Context, in *DisableSensorRequest, opts ...grpc.CallOption) (*DisableSensorResponse, error)
 	GetStackTraceTree(ctx context.Context, in *GetStackTraceTreeRequest, opts ...grpc.CallOption) (*GetStackTraceTreeResponse, error)
 	GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error)
 	RuntimeHook(ctx context.Context, in *RuntimeHookRequest, opts ...grpc.CallOption) (*RuntimeHookResponse, error)
+	GetProcess(ctx context.Context, in *GetProcessRequest, opts ...grpc.CallOption) (*GetProcessResponse, error)
+	GetProcessByExecId(ctx context.Context, in *GetProcessByExecIdRequest, opts ...grpc.CallOption) (*GetProcessResponse, error)
+	AnnotateEvent(ctx context.Context, in *AnnotateEventRequest, opts ...grpc.CallOption) (*AnnotateEventResponse, error)
+	// GetCapabilityReport reports the capabilities that workloads used, as
+	// observed by the capability-use policy.
+	GetCapabilityReport(ctx context.Context, in *GetCapabilityReportRequest, opts ...grpc.CallOption) (*GetCapabilityReportResponse, error)
+	// GetKernelFeatures reports the BPF features of the running kernel that
+	// tracing policies depend on.
+	GetKernelFeatures(ctx context.Context, in *GetKernelFeaturesRequest, opts ...grpc.CallOption) (*GetKernelFeaturesResponse, error)
 }
 
 type fineGuidanceSensorsClient struct {
@@ -161,6 +183,24 @@ func (c *fineGuidanceSensorsClient) DisableTracingPolicy(ctx context.Context, in
 	return out, nil
 }
 
+func (c *fineGuidanceSensorsClient) UpdateTracingPolicySelectors(ctx context.Context, in *UpdateTracingPolicySelectorsRequest, opts ...grpc.CallOption) (*UpdateTracingPolicySelectorsResponse, error) {
+	out := new(UpdateTracingPolicySelectorsResponse)
+	err := c.cc.Invoke(ctx, FineGuidanceSensors_UpdateTracingPolicySelectors_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fineGuidanceSensorsClient) AddTracingPolicySelectorValues(ctx context.Context, in *AddTracingPolicySelectorValuesRequest, opts ...grpc.CallOption) (*AddTracingPolicySelectorValuesResponse, error) {
+	out := new(AddTracingPolicySelectorValuesResponse)
+	err := c.cc.Invoke(ctx, FineGuidanceSensors_AddTracingPolicySelectorValues_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *fineGuidanceSensorsClient) ListSensors(ctx context.Context, in *ListSensorsRequest, opts ...grpc.CallOption) (*ListSensorsResponse, error) {
 	out := new(ListSensorsResponse)
 	err := c.cc.Invoke(ctx, FineGuidanceSensors_ListSensors_FullMethodName, in, out, opts...)
@@ -215,6 +255,51 @@ func (c *fineGuidanceSensorsClient) RuntimeHook(ctx context.Context, in *Runtime
 	return out, nil
 }
 
+func (c *fineGuidanceSensorsClient) GetProcess(ctx context.Context, in *GetProcessRequest, opts ...grpc.CallOption) (*GetProcessResponse, error) {
+	out := new(GetProcessResponse)
+	err := c.cc.Invoke(ctx, FineGuidanceSensors_GetProcess_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fineGuidanceSensorsClient) GetProcessByExecId(ctx context.Context, in *GetProcessByExecIdRequest, opts ...grpc.CallOption) (*GetProcessResponse, error) {
+	out := new(GetProcessResponse)
+	err := c.cc.Invoke(ctx, FineGuidanceSensors_GetProcessByExecId_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fineGuidanceSensorsClient) AnnotateEvent(ctx context.Context, in *AnnotateEventRequest, opts ...grpc.CallOption) (*AnnotateEventResponse, error) {
+	out := new(AnnotateEventResponse)
+	err := c.cc.Invoke(ctx, FineGuidanceSensors_AnnotateEvent_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fineGuidanceSensorsClient) GetCapabilityReport(ctx context.Context, in *GetCapabilityReportRequest, opts ...grpc.CallOption) (*GetCapabilityReportResponse, error) {
+	out := new(GetCapabilityReportResponse)
+	err := c.cc.Invoke(ctx, FineGuidanceSensors_GetCapabilityReport_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fineGuidanceSensorsClient) GetKernelFeatures(ctx context.Context, in *GetKernelFeaturesRequest, opts ...grpc.CallOption) (*GetKernelFeaturesResponse, error) {
+	out := new(GetKernelFeaturesResponse)
+	err := c.cc.Invoke(ctx, FineGuidanceSensors_GetKernelFeatures_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FineGuidanceSensorsServer is the server API for FineGuidanceSensors service.
 // All implementations should embed UnimplementedFineGuidanceSensorsServer
 // for forward compatibility
@@ -227,12 +312,27 @@ type FineGuidanceSensorsServer interface {
 	ListTracingPolicies(context.Context, *ListTracingPoliciesRequest) (*ListTracingPoliciesResponse, error)
 	EnableTracingPolicy(context.Context, *EnableTracingPolicyRequest) (*EnableTracingPolicyResponse, error)
 	DisableTracingPolicy(context.Context, *DisableTracingPolicyRequest) (*DisableTracingPolicyResponse, error)
+	// UpdateTracingPolicySelectors updates the selectors of an enabled
+	// tracing policy in place, without reloading its BPF programs.
+	UpdateTracingPolicySelectors(context.Context, *UpdateTracingPolicySelectorsRequest) (*UpdateTracingPolicySelectorsResponse, error)
+	// AddTracingPolicySelectorValues adds values to a matchArgs filter of an
+	// enabled tracing policy in place, optionally for a limited time.
+	AddTracingPolicySelectorValues(context.Context, *AddTracingPolicySelectorValuesRequest) (*AddTracingPolicySelectorValuesResponse, error)
 	ListSensors(context.Context, *ListSensorsRequest) (*ListSensorsResponse, error)
 	EnableSensor(context.Context, *EnableSensorRequest) (*EnableSensorResponse, error)
 	DisableSensor(context.Context, *DisableSensorRequest) (*DisableSensorResponse, error)
 	GetStackTraceTree(context.Context, *GetStackTraceTreeRequest) (*GetStackTraceTreeResponse, error)
 	GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error)
 	RuntimeHook(context.Context, *RuntimeHookRequest) (*RuntimeHookResponse, error)
+	GetProcess(context.Context, *GetProcessRequest) (*GetProcessResponse, error)
+	GetProcessByExecId(context.Context, *GetProcessByExecIdRequest) (*GetProcessResponse, error)
+	AnnotateEvent(context.Context, *AnnotateEventRequest) (*AnnotateEventResponse, error)
+	// GetCapabilityReport reports the capabilities that workloads used, as
+	// observed by the capability-use policy.
+	GetCapabilityReport(context.Context, *GetCapabilityReportRequest) (*GetCapabilityReportResponse, error)
+	// GetKernelFeatures reports the BPF features of the running kernel that
+	// tracing policies depend on.
+	GetKernelFeatures(context.Context, *GetKernelFeaturesRequest) (*GetKernelFeaturesResponse, error)
 }
 
 // UnimplementedFineGuidanceSensorsServer should be embedded to have forward compatible implementations.
@@ -263,6 +363,12 @@ func (UnimplementedFineGuidanceSensorsServer) EnableTracingPolicy(context.Contex
 func (UnimplementedFineGuidanceSensorsServer) DisableTracingPolicy(context.Context, *DisableTracingPolicyRequest) (*DisableTracingPolicyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DisableTracingPolicy not implemented")
 }
+func (UnimplementedFineGuidanceSensorsServer) UpdateTracingPolicySelectors(context.Context, *UpdateTracingPolicySelectorsRequest) (*UpdateTracingPolicySelectorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTracingPolicySelectors not implemented")
+}
+func (UnimplementedFineGuidanceSensorsServer) AddTracingPolicySelectorValues(context.Context, *AddTracingPolicySelectorValuesRequest) (*AddTracingPolicySelectorValuesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTracingPolicySelectorValues not implemented")
+}
 func (UnimplementedFineGuidanceSensorsServer) ListSensors(context.Context, *ListSensorsRequest) (*ListSensorsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListSensors not implemented")
 }
@@ -281,6 +387,21 @@ func (UnimplementedFineGuidanceSensorsServer) GetVersion(context.Context, *GetVe
 func (UnimplementedFineGuidanceSensorsServer) RuntimeHook(context.Context, *RuntimeHookRequest) (*RuntimeHookResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RuntimeHook not implemented")
 }
+func (UnimplementedFineGuidanceSensorsServer) GetProcess(context.Context, *GetProcessRequest) (*GetProcessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProcess not implemented")
+}
+func (UnimplementedFineGuidanceSensorsServer) GetProcessByExecId(context.Context, *GetProcessByExecIdRequest) (*GetProcessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProcessByExecId not implemented")
+}
+func (UnimplementedFineGuidanceSensorsServer) AnnotateEvent(context.Context, *AnnotateEventRequest) (*AnnotateEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnnotateEvent not implemented")
+}
+func (UnimplementedFineGuidanceSensorsServer) GetCapabilityReport(context.Context, *GetCapabilityReportRequest) (*GetCapabilityReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapabilityReport not implemented")
+}
+func (UnimplementedFineGuidanceSensorsServer) GetKernelFeatures(context.Context, *GetKernelFeaturesRequest) (*GetKernelFeaturesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKernelFeatures not implemented")
+}
 
 // UnsafeFineGuidanceSensorsServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to FineGuidanceSensorsServer will
@@ -440,6 +561,42 @@ func _FineGuidanceSensors_DisableTracingPolicy_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FineGuidanceSensors_UpdateTracingPolicySelectors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTracingPolicySelectorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FineGuidanceSensorsServer).UpdateTracingPolicySelectors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FineGuidanceSensors_UpdateTracingPolicySelectors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FineGuidanceSensorsServer).UpdateTracingPolicySelectors(ctx, req.(*UpdateTracingPolicySelectorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FineGuidanceSensors_AddTracingPolicySelectorValues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTracingPolicySelectorValuesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FineGuidanceSensorsServer).AddTracingPolicySelectorValues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FineGuidanceSensors_AddTracingPolicySelectorValues_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FineGuidanceSensorsServer).AddTracingPolicySelectorValues(ctx, req.(*AddTracingPolicySelectorValuesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FineGuidanceSensors_ListSensors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListSensorsRequest)
 	if err := dec(in); err != nil {
@@ -548,6 +705,96 @@ func _FineGuidanceSensors_RuntimeHook_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FineGuidanceSensors_GetProcess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProcessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FineGuidanceSensorsServer).GetProcess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FineGuidanceSensors_GetProcess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FineGuidanceSensorsServer).GetProcess(ctx, req.(*GetProcessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FineGuidanceSensors_GetProcessByExecId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProcessByExecIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FineGuidanceSensorsServer).GetProcessByExecId(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FineGuidanceSensors_GetProcessByExecId_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FineGuidanceSensorsServer).GetProcessByExecId(ctx, req.(*GetProcessByExecIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FineGuidanceSensors_AnnotateEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnnotateEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FineGuidanceSensorsServer).AnnotateEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FineGuidanceSensors_AnnotateEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FineGuidanceSensorsServer).AnnotateEvent(ctx, req.(*AnnotateEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FineGuidanceSensors_GetCapabilityReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCapabilityReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FineGuidanceSensorsServer).GetCapabilityReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FineGuidanceSensors_GetCapabilityReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FineGuidanceSensorsServer).GetCapabilityReport(ctx, req.(*GetCapabilityReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FineGuidanceSensors_GetKernelFeatures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKernelFeaturesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FineGuidanceSensorsServer).GetKernelFeatures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FineGuidanceSensors_GetKernelFeatures_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FineGuidanceSensorsServer).GetKernelFeatures(ctx, req.(*GetKernelFeaturesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FineGuidanceSensors_ServiceDesc is the grpc.ServiceDesc for FineGuidanceSensors service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -583,6 +830,14 @@ var FineGuidanceSensors_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DisableTracingPolicy",
 			Handler:    _FineGuidanceSensors_DisableTracingPolicy_Handler,
 		},
+		{
+			MethodName: "UpdateTracingPolicySelectors",
+			Handler:    _FineGuidanceSensors_UpdateTracingPolicySelectors_Handler,
+		},
+		{
+			MethodName: "AddTracingPolicySelectorValues",
+			Handler:    _FineGuidanceSensors_AddTracingPolicySelectorValues_Handler,
+		},
 		{
 			MethodName: "ListSensors",
 			Handler:    _FineGuidanceSensors_ListSensors_Handler,
@@ -607,6 +862,26 @@ var FineGuidanceSensors_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RuntimeHook",
 			Handler:    _FineGuidanceSensors_RuntimeHook_Handler,
 		},
+		{
+			MethodName: "GetProcess",
+			Handler:    _FineGuidanceSensors_GetProcess_Handler,
+		},
+		{
+			MethodName: "GetProcessByExecId",
+			Handler:    _FineGuidanceSensors_GetProcessByExecId_Handler,
+		},
+		{
+			MethodName: "AnnotateEvent",
+			Handler:    _FineGuidanceSensors_AnnotateEvent_Handler,
+		},
+		{
+			MethodName: "GetCapabilityReport",
+			Handler:    _FineGuidanceSensors_GetCapabilityReport_Handler,
+		},
+		{
+			MethodName: "GetKernelFeatures",
+			Handler:    _FineGuidanceSensors_GetKernelFeatures_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
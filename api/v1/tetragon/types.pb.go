@@ -89,6 +89,66 @@ func (event *ProcessKprobe) SetParent(p *Process) {
 	event.Parent = p
 }
 
+// Encapsulate implements the Event interface.
+// Returns the event wrapped by its GetEventsResponse_* type.
+func (event *ProcessKprobeCount) Encapsulate() IsGetEventsResponse_Event {
+	return &GetEventsResponse_ProcessKprobeCount{
+		ProcessKprobeCount: event,
+	}
+}
+
+// SetProcess implements the ProcessEvent interface.
+// Sets the Process field of an event.
+func (event *ProcessKprobeCount) SetProcess(p *Process) {
+	event.Process = p
+}
+
+// SetParent implements the ParentEvent interface.
+// Sets the Parent field of an event.
+func (event *ProcessKprobeCount) SetParent(p *Process) {
+	event.Parent = p
+}
+
+// Encapsulate implements the Event interface.
+// Returns the event wrapped by its GetEventsResponse_* type.
+func (event *MiningSuspected) Encapsulate() IsGetEventsResponse_Event {
+	return &GetEventsResponse_MiningSuspected{
+		MiningSuspected: event,
+	}
+}
+
+// SetProcess implements the ProcessEvent interface.
+// Sets the Process field of an event.
+func (event *MiningSuspected) SetProcess(p *Process) {
+	event.Process = p
+}
+
+// SetParent implements the ParentEvent interface.
+// Sets the Parent field of an event.
+func (event *MiningSuspected) SetParent(p *Process) {
+	event.Parent = p
+}
+
+// Encapsulate implements the Event interface.
+// Returns the event wrapped by its GetEventsResponse_* type.
+func (event *SshConnection) Encapsulate() IsGetEventsResponse_Event {
+	return &GetEventsResponse_SshConnection{
+		SshConnection: event,
+	}
+}
+
+// SetProcess implements the ProcessEvent interface.
+// Sets the Process field of an event.
+func (event *SshConnection) SetProcess(p *Process) {
+	event.Process = p
+}
+
+// SetParent implements the ParentEvent interface.
+// Sets the Parent field of an event.
+func (event *SshConnection) SetParent(p *Process) {
+	event.Parent = p
+}
+
 // Encapsulate implements the Event interface.
 // Returns the event wrapped by its GetEventsResponse_* type.
 func (event *ProcessTracepoint) Encapsulate() IsGetEventsResponse_Event {
@@ -129,6 +189,46 @@ func (event *ProcessUprobe) SetParent(p *Process) {
 	event.Parent = p
 }
 
+// Encapsulate implements the Event interface.
+// Returns the event wrapped by its GetEventsResponse_* type.
+func (event *ProcessLsm) Encapsulate() IsGetEventsResponse_Event {
+	return &GetEventsResponse_ProcessLsm{
+		ProcessLsm: event,
+	}
+}
+
+// SetProcess implements the ProcessEvent interface.
+// Sets the Process field of an event.
+func (event *ProcessLsm) SetProcess(p *Process) {
+	event.Process = p
+}
+
+// SetParent implements the ParentEvent interface.
+// Sets the Parent field of an event.
+func (event *ProcessLsm) SetParent(p *Process) {
+	event.Parent = p
+}
+
+// Encapsulate implements the Event interface.
+// Returns the event wrapped by its GetEventsResponse_* type.
+func (event *ProcessPerfEvent) Encapsulate() IsGetEventsResponse_Event {
+	return &GetEventsResponse_ProcessPerfEvent{
+		ProcessPerfEvent: event,
+	}
+}
+
+// SetProcess implements the ProcessEvent interface.
+// Sets the Process field of an event.
+func (event *ProcessPerfEvent) SetProcess(p *Process) {
+	event.Process = p
+}
+
+// SetParent implements the ParentEvent interface.
+// Sets the Parent field of an event.
+func (event *ProcessPerfEvent) SetParent(p *Process) {
+	event.Parent = p
+}
+
 // Encapsulate implements the Event interface.
 // Returns the event wrapped by its GetEventsResponse_* type.
 func (event *Test) Encapsulate() IsGetEventsResponse_Event {
@@ -159,6 +259,54 @@ func (event *RateLimitInfo) Encapsulate() IsGetEventsResponse_Event {
 	}
 }
 
+// Encapsulate implements the Event interface.
+// Returns the event wrapped by its GetEventsResponse_* type.
+func (event *ExportSinkHealth) Encapsulate() IsGetEventsResponse_Event {
+	return &GetEventsResponse_ExportSinkHealth{
+		ExportSinkHealth: event,
+	}
+}
+
+// Encapsulate implements the Event interface.
+// Returns the event wrapped by its GetEventsResponse_* type.
+func (event *RingBufferDrops) Encapsulate() IsGetEventsResponse_Event {
+	return &GetEventsResponse_RingBufferDrops{
+		RingBufferDrops: event,
+	}
+}
+
+// Encapsulate implements the Event interface.
+// Returns the event wrapped by its GetEventsResponse_* type.
+func (event *LostEvent) Encapsulate() IsGetEventsResponse_Event {
+	return &GetEventsResponse_LostEvent{
+		LostEvent: event,
+	}
+}
+
+// Encapsulate implements the Event interface.
+// Returns the event wrapped by its GetEventsResponse_* type.
+func (event *MapFill) Encapsulate() IsGetEventsResponse_Event {
+	return &GetEventsResponse_MapFill{
+		MapFill: event,
+	}
+}
+
+// Encapsulate implements the Event interface.
+// Returns the event wrapped by its GetEventsResponse_* type.
+func (event *ConfigSnapshot) Encapsulate() IsGetEventsResponse_Event {
+	return &GetEventsResponse_ConfigSnapshot{
+		ConfigSnapshot: event,
+	}
+}
+
+// Encapsulate implements the Event interface.
+// Returns the event wrapped by its GetEventsResponse_* type.
+func (event *EventAnnotation) Encapsulate() IsGetEventsResponse_Event {
+	return &GetEventsResponse_EventAnnotation{
+		EventAnnotation: event,
+	}
+}
+
 // UnwrapGetEventsResponse gets the inner event type from a GetEventsResponse
 func UnwrapGetEventsResponse(response *GetEventsResponse) interface{} {
 	event := response.GetEvent()
@@ -172,16 +320,38 @@ func UnwrapGetEventsResponse(response *GetEventsResponse) interface{} {
 		return ev.ProcessExit
 	case *GetEventsResponse_ProcessKprobe:
 		return ev.ProcessKprobe
+	case *GetEventsResponse_ProcessKprobeCount:
+		return ev.ProcessKprobeCount
+	case *GetEventsResponse_MiningSuspected:
+		return ev.MiningSuspected
+	case *GetEventsResponse_SshConnection:
+		return ev.SshConnection
 	case *GetEventsResponse_ProcessTracepoint:
 		return ev.ProcessTracepoint
 	case *GetEventsResponse_ProcessUprobe:
 		return ev.ProcessUprobe
+	case *GetEventsResponse_ProcessLsm:
+		return ev.ProcessLsm
+	case *GetEventsResponse_ProcessPerfEvent:
+		return ev.ProcessPerfEvent
 	case *GetEventsResponse_Test:
 		return ev.Test
 	case *GetEventsResponse_ProcessLoader:
 		return ev.ProcessLoader
 	case *GetEventsResponse_RateLimitInfo:
 		return ev.RateLimitInfo
+	case *GetEventsResponse_ExportSinkHealth:
+		return ev.ExportSinkHealth
+	case *GetEventsResponse_RingBufferDrops:
+		return ev.RingBufferDrops
+	case *GetEventsResponse_LostEvent:
+		return ev.LostEvent
+	case *GetEventsResponse_MapFill:
+		return ev.MapFill
+	case *GetEventsResponse_ConfigSnapshot:
+		return ev.ConfigSnapshot
+	case *GetEventsResponse_EventAnnotation:
+		return ev.EventAnnotation
 	}
 	return nil
 }
@@ -1469,6 +1469,82 @@ struct pt_regs {
 	u64 exit_rcu;
 };
 
+#elif defined(__TARGET_ARCH_riscv)
+
+struct user_regs_struct {
+	long unsigned int pc;
+	long unsigned int ra;
+	long unsigned int sp;
+	long unsigned int gp;
+	long unsigned int tp;
+	long unsigned int t0;
+	long unsigned int t1;
+	long unsigned int t2;
+	long unsigned int s0;
+	long unsigned int s1;
+	long unsigned int a0;
+	long unsigned int a1;
+	long unsigned int a2;
+	long unsigned int a3;
+	long unsigned int a4;
+	long unsigned int a5;
+	long unsigned int a6;
+	long unsigned int a7;
+	long unsigned int s2;
+	long unsigned int s3;
+	long unsigned int s4;
+	long unsigned int s5;
+	long unsigned int s6;
+	long unsigned int s7;
+	long unsigned int s8;
+	long unsigned int s9;
+	long unsigned int s10;
+	long unsigned int s11;
+	long unsigned int t3;
+	long unsigned int t4;
+	long unsigned int t5;
+	long unsigned int t6;
+};
+
+struct pt_regs {
+	long unsigned int epc;
+	long unsigned int ra;
+	long unsigned int sp;
+	long unsigned int gp;
+	long unsigned int tp;
+	long unsigned int t0;
+	long unsigned int t1;
+	long unsigned int t2;
+	long unsigned int s0;
+	long unsigned int s1;
+	long unsigned int a0;
+	long unsigned int a1;
+	long unsigned int a2;
+	long unsigned int a3;
+	long unsigned int a4;
+	long unsigned int a5;
+	long unsigned int a6;
+	long unsigned int a7;
+	long unsigned int s2;
+	long unsigned int s3;
+	long unsigned int s4;
+	long unsigned int s5;
+	long unsigned int s6;
+	long unsigned int s7;
+	long unsigned int s8;
+	long unsigned int s9;
+	long unsigned int s10;
+	long unsigned int s11;
+	long unsigned int t3;
+	long unsigned int t4;
+	long unsigned int t5;
+	long unsigned int t6;
+	long unsigned int status;
+	long unsigned int badaddr;
+	long unsigned int cause;
+	long unsigned int orig_a0;
+};
+
 #endif
 
 struct desc_ptr {
@@ -21499,6 +21575,7 @@ struct user_i387_struct {
 	__u32 padding[24];
 };
 
+#if !defined(__TARGET_ARCH_riscv)
 struct user_regs_struct {
 	long unsigned int r15;
 	long unsigned int r14;
@@ -21528,6 +21605,7 @@ struct user_regs_struct {
 	long unsigned int fs;
 	long unsigned int gs;
 };
+#endif
 
 struct user {
 	struct user_regs_struct regs;
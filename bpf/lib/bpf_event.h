@@ -16,4 +16,88 @@ struct {
 	__type(value, struct event);
 } tcpmon_map SEC(".maps");
 
+/* Number of events, and their size in bytes, that the tasks of a cgroup wrote
+ * to tcpmon_map. The agent reads them when events are lost to attribute the
+ * losses to the cgroups that filled the ring.
+ */
+struct event_cgroup_stats {
+	__u64 events;
+	__u64 bytes;
+};
+
+struct {
+	__uint(type, BPF_MAP_TYPE_LRU_PERCPU_HASH);
+	__uint(max_entries, 4096);
+	__type(key, __u64); /* cgroup id */
+	__type(value, struct event_cgroup_stats);
+} event_cgroup_stats_map SEC(".maps");
+
+#ifdef __V61_BPF_PROG
+/* BPF ring buffer of the events, shared by all CPUs, that the agent can use
+ * instead of tcpmon_map. Its size is set by the agent when it is used.
+ */
+struct {
+	__uint(type, BPF_MAP_TYPE_RINGBUF);
+	__uint(max_entries, 1 << 18);
+} tcpmon_ringbuf SEC(".maps");
+
+/* Transport of the events, set by the agent: 0 for tcpmon_map and 1 for
+ * tcpmon_ringbuf.
+ */
+struct {
+	__uint(type, BPF_MAP_TYPE_ARRAY);
+	__uint(max_entries, 1);
+	__type(key, __u32);
+	__type(value, __u32);
+} event_transport_map SEC(".maps");
+
+/* Number of events lost because tcpmon_ringbuf was full. Unlike perf ring
+ * buffers, BPF ring buffers do not report their losses to the reader.
+ */
+struct {
+	__uint(type, BPF_MAP_TYPE_PERCPU_ARRAY);
+	__uint(max_entries, 1);
+	__type(key, __u32);
+	__type(value, __u64);
+} ringbuf_lost_map SEC(".maps");
+#endif
+
+/* event_output() writes an event to tcpmon_map, or tcpmon_ringbuf if the agent
+ * selected it, and accounts it to the cgroup (of the v2 hierarchy) of the
+ * current task.
+ */
+static inline __attribute__((always_inline)) void
+event_output(void *ctx, void *data, __u64 size)
+{
+	struct event_cgroup_stats *stats, zero = {};
+	__u64 cgrpid = get_current_cgroup_id();
+#ifdef __V61_BPF_PROG
+	__u32 key = 0;
+	__u32 *ringbuf;
+	__u64 *lost;
+#endif
+
+	stats = map_lookup_elem(&event_cgroup_stats_map, &cgrpid);
+	if (!stats) {
+		map_update_elem(&event_cgroup_stats_map, &cgrpid, &zero, BPF_NOEXIST);
+		stats = map_lookup_elem(&event_cgroup_stats_map, &cgrpid);
+	}
+	if (stats) {
+		stats->events++;
+		stats->bytes += size;
+	}
+#ifdef __V61_BPF_PROG
+	ringbuf = map_lookup_elem(&event_transport_map, &key);
+	if (ringbuf && *ringbuf) {
+		if (ringbuf_output(&tcpmon_ringbuf, data, size, 0) < 0) {
+			lost = map_lookup_elem(&ringbuf_lost_map, &key);
+			if (lost)
+				(*lost)++;
+		}
+		return;
+	}
+#endif
+	perf_event_output(ctx, &tcpmon_map, BPF_F_CURRENT_CPU, data, size);
+}
+
 #endif // __BPF_EVENT_H
@@ -18,6 +18,39 @@
 #define PROBE_CWD_READ_ITERATIONS 11
 #endif
 
+/* x86 thread_info status flag of tasks in a compat (ia32) syscall */
+#define TS_COMPAT 0x0002
+/* arm64 and riscv thread_info flag of 32-bit tasks */
+#if defined(__TARGET_ARCH_arm64)
+#define TIF_32BIT 22
+#elif defined(__TARGET_ARCH_riscv)
+#define TIF_32BIT 11
+#endif
+
+/* Returns true if the current task is in a compat (32-bit) syscall. Like in
+ * the kernel, on x86 this is set per syscall, so 64-bit tasks using the ia32
+ * syscall table (int 0x80) are in compat syscalls.
+ */
+static inline __attribute__((always_inline)) bool
+in_compat_syscall(void)
+{
+	struct task_struct *task = (struct task_struct *)get_current_task();
+
+#if defined(__TARGET_ARCH_x86)
+	__u32 status = 0;
+
+	probe_read(&status, sizeof(status), _(&task->thread_info.status));
+	return status & TS_COMPAT;
+#elif defined(TIF_32BIT)
+	unsigned long flags = 0;
+
+	probe_read(&flags, sizeof(flags), _(&task->thread_info.flags));
+	return flags & (1UL << TIF_32BIT);
+#else
+	return false;
+#endif
+}
+
 static inline __attribute__((always_inline)) struct task_struct *
 get_parent(struct task_struct *t)
 {
@@ -4,8 +4,10 @@
 #define _MSG_COMMON__
 
 /* msg_common internal flags */
-#define MSG_COMMON_FLAG_RETURN	   BIT(0)
-#define MSG_COMMON_FLAG_STACKTRACE BIT(1)
+#define MSG_COMMON_FLAG_RETURN		BIT(0)
+#define MSG_COMMON_FLAG_STACKTRACE	BIT(1)
+#define MSG_COMMON_FLAG_USER_STACKTRACE BIT(2)
+#define MSG_COMMON_FLAG_COMPAT		BIT(3)
 
 /* Msg Layout */
 struct msg_common {
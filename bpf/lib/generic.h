@@ -8,10 +8,14 @@
 #include "msg_types.h"
 #include "process.h"
 
-/* The namespace and capability changes filters require later kernels */
+/* The namespace and capability changes, the credentials and the tags
+ * filters require later kernels
+ */
 #ifdef __LARGE_BPF_PROG
 #define __NS_CHANGES_FILTER
 #define __CAP_CHANGES_FILTER
+#define __CREDS_FILTER
+#define __TAGS_FILTER
 #endif
 
 #define FILTER_SIZE 4096
@@ -44,6 +48,10 @@ struct msg_generic_kprobe {
 	__u32 action_arg_id; // only one URL or FQDN action can be fired per match
 	__u32 tid; // Thread ID that triggered the event
 	__u64 stack_id; // Stack trace ID on u32 and potential error, see flag in msg_common.flags
+	__u64 user_stack_id; // User stack trace ID, same as stack_id
+	__u64 action_ktime; // ktime at which the enforcement action was applied, 0 if none
+	__u32 audit_action; // enforcement action not taken in audit mode, 0 if none
+	__u32 audit_selector; // index of the selector of audit_action
 	/* anything above is shared with the userspace so it should match structs MsgGenericKprobe and MsgGenericTracepoint in Go */
 	char args[24000];
 	unsigned long a0, a1, a2, a3, a4;
@@ -51,6 +59,7 @@ struct msg_generic_kprobe {
 	struct msg_selector_data sel;
 	__u32 idx;
 	int pass;
+	bool audit;
 };
 
 static inline __attribute__((always_inline)) size_t generic_kprobe_common_size()
@@ -81,5 +90,37 @@ struct {
 	__type(value, __u32);
 } names_map SEC(".maps");
 
+struct names_prefix_map_key {
+	__u32 prefixlen;
+	char path[256];
+};
+
+/* names_prefix_map is used by matchBinaries Prefix/NotPrefix operators. At
+ * exec time we do a longest prefix match of the binary path and store the
+ * id of the matching prefix in execve_map->binary_prefix.
+ */
+struct {
+	__uint(type, BPF_MAP_TYPE_LPM_TRIE);
+	__uint(max_entries, 256); /* maximum number of binary prefixes for all matchBinary selectors */
+	__type(key, __u8[sizeof(struct names_prefix_map_key)]);
+	__type(value, __u32);
+	__uint(map_flags, BPF_F_NO_PREALLOC);
+} names_prefix_map SEC(".maps");
+
+/* names_prefix_parent_map maps the id of a binary prefix to the id of the
+ * longest shorter prefix of names_prefix_map that covers it. Since
+ * execve_map->binary_prefix only holds the id of the longest matching prefix,
+ * matchBinaries walks up to NAMES_PREFIX_MAX_DEPTH parents to match the
+ * shorter prefixes of its selector.
+ */
+#define NAMES_PREFIX_MAX_DEPTH 8
+
+struct {
+	__uint(type, BPF_MAP_TYPE_HASH);
+	__uint(max_entries, 1024);
+	__type(key, __u32);
+	__type(value, __u32);
+} names_prefix_parent_map SEC(".maps");
+
 #endif // ALIGNCHECKER
 #endif // _GENERIC__
@@ -29,6 +29,12 @@ enum msg_ops {
 
 	MSG_OP_CGROUP = 25,
 
+	/* MSG_OP_LOADER = 26, defined in bpf_loader.c */
+
+	MSG_OP_GENERIC_LSM = 27,
+
+	MSG_OP_GENERIC_PERF_EVENT = 28,
+
 	MSG_OP_MAX,
 };
 
@@ -139,6 +139,8 @@
 #define EVENT_ERROR_PATH_COMPONENTS   0x400000
 #define EVENT_DATA_FILENAME	      0x800000
 #define EVENT_DATA_ARGS		      0x1000000
+#define EVENT_PROCFS_POLL	      0x2000000
+#define EVENT_PROC_CONNECTOR	      0x4000000
 
 #define EVENT_COMMON_FLAG_CLONE 0x01
 
@@ -290,20 +292,25 @@ struct msg_execve_event {
 	 * heap for execve programs
 	 */
 	__u32 binary;
+	__u32 binary_prefix;
 }; // All fields aligned so no 'packed' attribute.
 
 // The execve_map_value is tracked by the TGID of the thread group
 // the msg_execve_key.pid. The thread IDs are recorded on the
 // fly and sent with every corresponding event.
+/* The number of tags of a process, the bits of execve_map_value tags */
+#define MAX_TAGS 64
+
 struct execve_map_value {
 	struct msg_execve_key key;
 	struct msg_execve_key pkey;
 	__u32 flags;
 	__u32 nspid;
 	__u32 binary;
-	__u32 pad;
+	__u32 binary_prefix;
 	struct msg_ns ns;
 	struct msg_capabilities caps;
+	__u64 tags; /* bitmask of the tags set by the SetTag action */
 } __attribute__((packed)) __attribute__((aligned(8)));
 
 struct {
@@ -345,6 +352,10 @@ struct execve_heap {
 		char maxpath[4096];
 	};
 	struct execve_info info;
+	struct {
+		__u32 prefixlen;
+		char path[PATHNAME_SIZE];
+	} prefix_key;
 };
 
 struct {
@@ -203,8 +203,9 @@ struct pt_regs___arm64 {
 #define __PT_RC_REG a5
 #define __PT_SP_REG sp
 #define __PT_IP_REG pc
-/* riscv does not select ARCH_HAS_SYSCALL_WRAPPER. */
-#define PT_REGS_SYSCALL_REGS(ctx) ctx
+/* riscv selects ARCH_HAS_SYSCALL_WRAPPER since 6.6, the syscall entry points
+ * (__riscv_sys_*) get the user pt_regs as their first argument.
+ */
 
 #elif defined(bpf_target_arc)
 
@@ -130,26 +130,40 @@ read_cwd(void *ctx, struct msg_process *p)
 	return getcwd(p, p->size, p->pid);
 }
 
-static inline __attribute__((always_inline)) __u32
+static inline __attribute__((always_inline)) void
 binary_filter(void *ctx, struct msg_execve_event *event, void *filename)
 {
 	struct msg_process *p = &event->process;
 	struct execve_heap *heap;
 	uint32_t *value;
 	__u32 zero = 0;
+	long size;
+
+	event->binary = 0;
+	event->binary_prefix = 0;
 
 	// skip binaries check for long (> 255) filenames for now
 	if (p->flags & EVENT_DATA_FILENAME)
-		return 0;
+		return;
 
 	heap = map_lookup_elem(&execve_heap, &zero);
 	if (!heap)
-		return 0;
+		return;
 
 	memset(heap->pathname, 0, PATHNAME_SIZE);
-	probe_read_str(heap->pathname, PATHNAME_SIZE, filename);
+	size = probe_read_str(heap->pathname, PATHNAME_SIZE, filename);
 	value = map_lookup_elem(&names_map, heap->pathname);
-	return value ? *value : 0;
+	if (value)
+		event->binary = *value;
+
+	if (size <= 1 || size > PATHNAME_SIZE)
+		return;
+
+	memcpy(heap->prefix_key.path, heap->pathname, PATHNAME_SIZE);
+	heap->prefix_key.prefixlen = (size - 1) * 8; // prefix is in bits
+	value = map_lookup_elem(&names_prefix_map, &heap->prefix_key);
+	if (value)
+		event->binary_prefix = *value;
 }
 
 static inline __attribute__((always_inline)) __u32
@@ -214,7 +228,7 @@ event_execve(struct sched_execve_args *ctx)
 	event->common.ktime = p->ktime;
 	event->common.size = offsetof(struct msg_execve_event, process) + p->size;
 
-	event->binary = binary_filter(ctx, event, filename);
+	binary_filter(ctx, event, filename);
 
 	BPF_CORE_READ_INTO(&event->kube.net_ns, task, nsproxy, net_ns, ns.inum);
 
@@ -275,6 +289,7 @@ execve_send(struct sched_execve_args *ctx)
 		}
 		curr->flags = 0;
 		curr->binary = event->binary;
+		curr->binary_prefix = event->binary_prefix;
 #ifdef __NS_CHANGES_FILTER
 		if (init_curr)
 			memcpy(&(curr->ns), &(event->ns),
@@ -296,6 +311,6 @@ execve_send(struct sched_execve_args *ctx)
 		sizeof(struct msg_capabilities) +
 		sizeof(struct msg_cred_minimal) + sizeof(struct msg_ns) +
 		sizeof(struct msg_execve_key) + p->size);
-	perf_event_output(ctx, &tcpmon_map, BPF_F_CURRENT_CPU, event, size);
+	event_output(ctx, event, size);
 	return 0;
 }
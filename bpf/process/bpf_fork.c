@@ -46,6 +46,7 @@ BPF_KPROBE(event_wake_up_new_task, struct task_struct *task)
 		curr->nspid = get_task_pid_vnr();
 		curr->binary = parent->binary;
 		curr->pkey = parent->key;
+		curr->tags = parent->tags;
 
 		u64 size = sizeof(struct msg_clone_event);
 		struct msg_clone_event msg = {
@@ -69,8 +70,7 @@ BPF_KPROBE(event_wake_up_new_task, struct task_struct *task)
 		/* Last: set any encountered error when setting cgroup info */
 		msg.flags |= error_flags;
 
-		perf_event_output(ctx, &tcpmon_map, BPF_F_CURRENT_CPU, &msg,
-				  size);
+		event_output(ctx, &msg, size);
 	}
 	return 0;
 }
@@ -0,0 +1,254 @@
+// SPDX-License-Identifier: GPL-2.0
+/* Copyright Authors of Cilium */
+
+#include "vmlinux.h"
+#include "api.h"
+
+#define GENERIC_LSM
+
+#include "bpf_event.h"
+#include "bpf_task.h"
+#include "retprobe_map.h"
+#include "types/operations.h"
+#include "types/basic.h"
+#include "generic_calls.h"
+#include "pfilter.h"
+
+char _license[] __attribute__((section("license"), used)) = "GPL";
+
+struct {
+	__uint(type, BPF_MAP_TYPE_PERCPU_ARRAY);
+	__uint(max_entries, 1);
+	__type(key, __u32);
+	__type(value, struct msg_generic_kprobe);
+} process_call_heap SEC(".maps");
+
+struct {
+	__uint(type, BPF_MAP_TYPE_PROG_ARRAY);
+	__uint(max_entries, 13);
+	__uint(key_size, sizeof(__u32));
+	__uint(value_size, sizeof(__u32));
+} lsm_calls SEC(".maps");
+
+struct {
+	__uint(type, BPF_MAP_TYPE_HASH);
+	__uint(max_entries, 32768);
+	__type(key, __u64);
+	__type(value, __s32);
+} override_tasks SEC(".maps");
+
+struct filter_map_value {
+	unsigned char buf[FILTER_SIZE];
+};
+
+/* Arrays of size 1 will be rewritten to direct loads in verifier */
+struct {
+	__uint(type, BPF_MAP_TYPE_ARRAY);
+	__uint(max_entries, 1);
+	__type(key, int);
+	__type(value, struct filter_map_value);
+} filter_map SEC(".maps");
+
+struct {
+	__uint(type, BPF_MAP_TYPE_ARRAY);
+	__uint(max_entries, 1);
+	__type(key, __u32);
+	__type(value, struct event_config);
+} config_map SEC(".maps");
+
+static inline __attribute__((always_inline)) int
+generic_lsm_start_process_filter(void *ctx)
+{
+	struct msg_generic_kprobe *msg;
+	struct event_config *config;
+	struct task_struct *task;
+	int i, zero = 0;
+
+	msg = map_lookup_elem(&process_call_heap, &zero);
+	if (!msg)
+		return 0;
+	/* Initialize selector index to 0 */
+	msg->sel.curr = 0;
+#pragma unroll
+	for (i = 0; i < MAX_CONFIGURED_SELECTORS; i++)
+		msg->sel.active[i] = 0;
+	/* Initialize accept field to reject */
+	msg->sel.pass = 0;
+	task = (struct task_struct *)get_current_task();
+	/* Initialize namespaces to apply filters on them */
+	get_namespaces(&msg->ns, task);
+	/* Initialize capabilities to apply filters on them */
+	get_current_subj_caps(&msg->caps, task);
+#ifdef __NS_CHANGES_FILTER
+	msg->sel.match_ns = 0;
+#endif
+#ifdef __CAP_CHANGES_FILTER
+	msg->sel.match_cap = 0;
+#endif
+	// setup index and function id
+	config = map_lookup_elem(&config_map, &msg->idx);
+	if (!config)
+		return 0;
+	msg->idx = 0;
+	msg->func_id = config->func_id;
+	msg->retprobe_id = 0;
+	if (!generic_process_filter_binary(config))
+		return 0;
+	/* Tail call into filters. */
+	tail_call(ctx, &lsm_calls, 5);
+	return 0;
+}
+
+/* The sections of the LSM programs do not name the attached hook, the
+ * programs are attached to the hook of the policy by the loader.
+ */
+__attribute__((section("lsm/generic_lsm_core"), used)) int
+generic_lsm_event(void *ctx)
+{
+	return generic_lsm_start_process_filter(ctx);
+}
+
+__attribute__((section("lsm/0"), used)) int
+generic_lsm_process_event0(void *ctx)
+{
+	return generic_process_event_and_setup(
+		ctx, (struct bpf_map_def *)&process_call_heap,
+		(struct bpf_map_def *)&lsm_calls,
+		(struct bpf_map_def *)&config_map, 0);
+}
+
+__attribute__((section("lsm/1"), used)) int
+generic_lsm_process_event1(void *ctx)
+{
+	return generic_process_event(ctx, 1,
+				     (struct bpf_map_def *)&process_call_heap,
+				     (struct bpf_map_def *)&lsm_calls,
+				     (struct bpf_map_def *)&config_map, 0);
+}
+
+__attribute__((section("lsm/2"), used)) int
+generic_lsm_process_event2(void *ctx)
+{
+	return generic_process_event(ctx, 2,
+				     (struct bpf_map_def *)&process_call_heap,
+				     (struct bpf_map_def *)&lsm_calls,
+				     (struct bpf_map_def *)&config_map, 0);
+}
+
+__attribute__((section("lsm/3"), used)) int
+generic_lsm_process_event3(void *ctx)
+{
+	return generic_process_event(ctx, 3,
+				     (struct bpf_map_def *)&process_call_heap,
+				     (struct bpf_map_def *)&lsm_calls,
+				     (struct bpf_map_def *)&config_map, 0);
+}
+
+__attribute__((section("lsm/4"), used)) int
+generic_lsm_process_event4(void *ctx)
+{
+	return generic_process_event(ctx, 4,
+				     (struct bpf_map_def *)&process_call_heap,
+				     (struct bpf_map_def *)&lsm_calls,
+				     (struct bpf_map_def *)&config_map, 0);
+}
+
+__attribute__((section("lsm/5"), used)) int
+generic_lsm_process_filter(void *ctx)
+{
+	struct msg_generic_kprobe *msg;
+	int ret, zero = 0;
+
+	msg = map_lookup_elem(&process_call_heap, &zero);
+	if (!msg)
+		return 0;
+
+	ret = generic_process_filter(&msg->sel, &msg->current, &msg->ns,
+				     &msg->caps, &filter_map, msg->idx);
+	if (ret == PFILTER_CONTINUE)
+		tail_call(ctx, &lsm_calls, 5);
+	else if (ret == PFILTER_ACCEPT)
+		tail_call(ctx, &lsm_calls, 0);
+	/* If filter does not accept drop it. Ideally we would
+	 * log error codes for later review, TBD.
+	 */
+	return PFILTER_REJECT;
+}
+
+__attribute__((section("lsm/6"), used)) int
+generic_lsm_filter_arg1(void *ctx)
+{
+	return filter_read_arg(ctx, 0, (struct bpf_map_def *)&process_call_heap,
+			       (struct bpf_map_def *)&filter_map,
+			       (struct bpf_map_def *)&lsm_calls,
+			       (struct bpf_map_def *)&config_map);
+}
+
+__attribute__((section("lsm/7"), used)) int
+generic_lsm_filter_arg2(void *ctx)
+{
+	return filter_read_arg(ctx, 1, (struct bpf_map_def *)&process_call_heap,
+			       (struct bpf_map_def *)&filter_map,
+			       (struct bpf_map_def *)&lsm_calls,
+			       (struct bpf_map_def *)&config_map);
+}
+
+__attribute__((section("lsm/8"), used)) int
+generic_lsm_filter_arg3(void *ctx)
+{
+	return filter_read_arg(ctx, 2, (struct bpf_map_def *)&process_call_heap,
+			       (struct bpf_map_def *)&filter_map,
+			       (struct bpf_map_def *)&lsm_calls,
+			       (struct bpf_map_def *)&config_map);
+}
+
+__attribute__((section("lsm/9"), used)) int
+generic_lsm_filter_arg4(void *ctx)
+{
+	return filter_read_arg(ctx, 3, (struct bpf_map_def *)&process_call_heap,
+			       (struct bpf_map_def *)&filter_map,
+			       (struct bpf_map_def *)&lsm_calls,
+			       (struct bpf_map_def *)&config_map);
+}
+
+__attribute__((section("lsm/10"), used)) int
+generic_lsm_filter_arg5(void *ctx)
+{
+	return filter_read_arg(ctx, 4, (struct bpf_map_def *)&process_call_heap,
+			       (struct bpf_map_def *)&filter_map,
+			       (struct bpf_map_def *)&lsm_calls,
+			       (struct bpf_map_def *)&config_map);
+}
+
+__attribute__((section("lsm/11"), used)) int
+generic_lsm_actions(void *ctx)
+{
+	return generic_actions(ctx, (struct bpf_map_def *)&process_call_heap,
+			       (struct bpf_map_def *)&filter_map,
+			       (struct bpf_map_def *)&lsm_calls,
+			       (struct bpf_map_def *)&override_tasks);
+}
+
+__attribute__((section("lsm/12"), used)) int
+generic_lsm_output(void *ctx)
+{
+	return generic_output(ctx, (struct bpf_map_def *)&process_call_heap);
+}
+
+/* The override program runs after generic_lsm_event on the same hook and
+ * returns the error set by the Override action, denying the operation.
+ */
+__attribute__((section("lsm/generic_lsm_override"), used)) int
+generic_lsm_override(void *ctx)
+{
+	__u64 id = get_current_pid_tgid();
+	__s32 *error, ret;
+
+	error = map_lookup_elem(&override_tasks, &id);
+	if (!error)
+		return 0;
+
+	ret = *error;
+	map_delete_elem(&override_tasks, &id);
+	return ret;
+}
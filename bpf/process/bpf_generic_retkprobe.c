@@ -40,14 +40,75 @@ struct {
 #define data_heap_ptr 0
 #endif
 
+/* Latencies are counted in log2 slots of nanoseconds, the slot
+ * LATENCY_HIST_SUM holds the sum of the latencies.
+ */
+#define LATENCY_HIST_SLOTS 64
+#define LATENCY_HIST_SUM   LATENCY_HIST_SLOTS
+
+struct latency_hist_key {
+	__u32 func_id;
+	__u32 slot;
+};
+
+/* user space sets max_entries to (LATENCY_HIST_SLOTS + 1) * number of probes */
+struct {
+	__uint(type, BPF_MAP_TYPE_PERCPU_HASH);
+	__uint(max_entries, LATENCY_HIST_SLOTS + 1);
+	__type(key, struct latency_hist_key);
+	__type(value, __u64);
+} latency_hist_map SEC(".maps");
+
+static inline __attribute__((always_inline)) __u32
+latency_slot(__u64 v)
+{
+	__u32 r = 0, shift;
+
+	shift = (v > 0xffffffff) << 5;
+	v >>= shift;
+	r |= shift;
+	shift = (v > 0xffff) << 4;
+	v >>= shift;
+	r |= shift;
+	shift = (v > 0xff) << 3;
+	v >>= shift;
+	r |= shift;
+	shift = (v > 0xf) << 2;
+	v >>= shift;
+	r |= shift;
+	shift = (v > 0x3) << 1;
+	v >>= shift;
+	r |= shift;
+	r |= (v >> 1);
+	return r;
+}
+
+static inline __attribute__((always_inline)) void
+latency_hist_add(__u32 func_id, __u32 slot, __u64 v)
+{
+	struct latency_hist_key key = {
+		.func_id = func_id,
+		.slot = slot,
+	};
+	__u64 *count;
+
+	count = map_lookup_elem(&latency_hist_map, &key);
+	if (count)
+		*count += v;
+	else
+		map_update_elem(&latency_hist_map, &key, &v, BPF_ANY);
+}
+
 #ifdef __MULTI_KPROBE
 #define MAIN "kprobe.multi/generic_retkprobe"
+#elif defined(__TRACEPOINT)
+#define MAIN "tracepoint/generic_rettracepoint"
 #else
 #define MAIN "kprobe/generic_retkprobe"
 #endif
 
-__attribute__((section((MAIN)), used)) int
-BPF_KRETPROBE(generic_retkprobe_event, unsigned long ret)
+static inline __attribute__((always_inline)) int
+generic_retkprobe(void *ctx, unsigned long ret)
 {
 	struct execve_map_value *enter;
 	struct msg_generic_kprobe *e;
@@ -82,6 +143,19 @@ BPF_KRETPROBE(generic_retkprobe_event, unsigned long ret)
 	*(unsigned long *)e->args = info.ktime_enter;
 	size += sizeof(info.ktime_enter);
 
+	if (config->flags & (FLAGS_LATENCY | FLAGS_LATENCY_HIST)) {
+		__u64 latency = ktime_get_ns() - info.ktime_enter;
+
+		if (config->flags & FLAGS_LATENCY) {
+			*(__u64 *)&e->args[size] = latency;
+			size += sizeof(latency);
+		}
+		if (config->flags & FLAGS_LATENCY_HIST) {
+			latency_hist_add(e->func_id, latency_slot(latency), 1);
+			latency_hist_add(e->func_id, LATENCY_HIST_SUM, latency);
+		}
+	}
+
 	ty_arg = config->argreturn;
 	do_copy = config->argreturncopy;
 	if (ty_arg) {
@@ -111,10 +185,18 @@ BPF_KRETPROBE(generic_retkprobe_event, unsigned long ret)
 
 	switch (do_copy) {
 	case char_buf:
-		size += __copy_char_buf(ctx, size, info.ptr, ret, false, e, (struct bpf_map_def *)data_heap_ptr);
+		size += __copy_char_buf(ctx, size, info.ptr, ret, false,
+					config->argreturncopy_max_size, e,
+					(struct bpf_map_def *)data_heap_ptr);
 		break;
-	case char_iovec:
-		size += __copy_char_iovec(size, info.ptr, info.cnt, ret, e);
+	case char_iovec: {
+		unsigned long max = ret;
+
+		if (config->argreturncopy_max_size && max > config->argreturncopy_max_size)
+			max = config->argreturncopy_max_size;
+		size += __copy_char_iovec(size, info.ptr, info.cnt, max, ret, e);
+		break;
+	}
 	default:
 		break;
 	}
@@ -122,8 +204,14 @@ BPF_KRETPROBE(generic_retkprobe_event, unsigned long ret)
 	/* Complete message header and send */
 	enter = event_find_curr(&ppid, &walker);
 
+#ifdef __TRACEPOINT
+	e->common.op = MSG_OP_GENERIC_TRACEPOINT;
+#else
 	e->common.op = MSG_OP_GENERIC_KPROBE;
+#endif
 	e->common.flags |= MSG_COMMON_FLAG_RETURN;
+	if (in_compat_syscall())
+		e->common.flags |= MSG_COMMON_FLAG_COMPAT;
 	e->common.pad[0] = 0;
 	e->common.pad[1] = 0;
 	e->common.size = size;
@@ -150,6 +238,50 @@ BPF_KRETPROBE(generic_retkprobe_event, unsigned long ret)
 		     : [total] "+r"(total)
 		     :);
 	e->common.size = total;
-	perf_event_output(ctx, &tcpmon_map, BPF_F_CURRENT_CPU, e, total);
+	event_output(ctx, e, total);
 	return 0;
 }
+
+#ifdef __FENTRY
+__attribute__((section((MAIN)), used)) int
+generic_retkprobe_event(void *ctx)
+{
+	struct event_config *config;
+	unsigned long ret = 0;
+	int zero = 0;
+
+	config = map_lookup_elem(&config_map, &zero);
+	if (!config)
+		return 0;
+
+	/* fexit programs get the return value after the function arguments,
+	 * user space sets the number of arguments in t_arg0_ctx_off.
+	 */
+	probe_read(&ret, sizeof(ret), (__u64 *)ctx + (config->t_arg0_ctx_off & 0xf));
+	return generic_retkprobe(ctx, ret);
+}
+#elif defined(__TRACEPOINT)
+__attribute__((section((MAIN)), used)) int
+generic_rettracepoint_event(void *ctx)
+{
+	struct event_config *config;
+	long ret = 0;
+	int zero = 0;
+
+	config = map_lookup_elem(&config_map, &zero);
+	if (!config)
+		return 0;
+
+	/* sys_exit tracepoints get the return value in their ret field, user
+	 * space sets its offset in t_arg0_ctx_off.
+	 */
+	probe_read(&ret, sizeof(ret), (char *)ctx + (config->t_arg0_ctx_off & 0xff));
+	return generic_retkprobe(ctx, ret);
+}
+#else
+__attribute__((section((MAIN)), used)) int
+BPF_KRETPROBE(generic_retkprobe_event, unsigned long ret)
+{
+	return generic_retkprobe(ctx, ret);
+}
+#endif
@@ -124,6 +124,9 @@ generic_tracepoint_event(struct generic_tracepoint_event_arg *ctx)
 	if (!config)
 		return 0;
 
+	if ((config->flags & FLAGS_SKIP_COMPAT) && in_compat_syscall())
+		return 0;
+
 	if (!generic_process_filter_binary(config))
 		return 0;
 
@@ -134,7 +137,6 @@ generic_tracepoint_event(struct generic_tracepoint_event_arg *ctx)
 	/* Tail call into filters. */
 	msg->idx = 0;
 	msg->func_id = config->func_id;
-	msg->retprobe_id = 0;
 
 	msg->a0 = ({
 		unsigned long ctx_off = config->t_arg0_ctx_off;
@@ -178,6 +180,13 @@ generic_tracepoint_event(struct generic_tracepoint_event_arg *ctx)
 
 	generic_process_init(msg, MSG_OP_GENERIC_TRACEPOINT, config);
 
+	/* If the syscall return value is needed, mark the retprobe for the
+	 * program of the sys_exit tracepoint.
+	 */
+	msg->retprobe_id = get_current_pid_tgid();
+	if (config->argreturn > 0)
+		retprobe_map_set(msg->func_id, msg->retprobe_id, msg->common.ktime, 1);
+
 	msg->common.op = MSG_OP_GENERIC_TRACEPOINT;
 	msg->sel.curr = 0;
 #pragma unroll
@@ -14,6 +14,9 @@ struct msg_loader {
 	__u32 pid;
 	__u32 buildid_size;
 	__u32 path_size;
+	__u32 flags;
+	__u32 violations;
+	__u32 unknown;
 	char buildid[20];
 	char path[4096];
 	void *pe;
@@ -35,6 +38,53 @@ struct {
 	__type(value, __u64);
 } ids_map SEC(".maps");
 
+#define LOADER_MAX_POLICIES 8
+#define LOADER_MAX_BINARIES 8
+
+#define LOADER_POLICY_F_SIGKILL 1
+
+/* Allowlist of a loader policy, in the slot of the policy. */
+struct loader_policy {
+	__u32 active;
+	__u32 flags;
+	/* names_map ids of the binaries that the allowlist applies to, all
+	 * the binaries if none is set
+	 */
+	__u32 binaries[LOADER_MAX_BINARIES];
+};
+
+struct {
+	__uint(type, BPF_MAP_TYPE_ARRAY);
+	__uint(max_entries, LOADER_MAX_POLICIES);
+	__type(key, __u32);
+	__type(value, struct loader_policy);
+} loader_policies SEC(".maps");
+
+struct loader_config {
+	/* number of loader policies without an allowlist */
+	__u32 report_all;
+};
+
+struct {
+	__uint(type, BPF_MAP_TYPE_ARRAY);
+	__uint(max_entries, 1);
+	__type(key, __u32);
+	__type(value, struct loader_config);
+} loader_config_map SEC(".maps");
+
+struct loader_allowlist_key {
+	__u32 policy;
+	char buildid[20];
+};
+
+/* Build ids of the libraries that the policies allow to be loaded. */
+struct {
+	__uint(type, BPF_MAP_TYPE_HASH);
+	__uint(max_entries, 32768);
+	__type(key, struct loader_allowlist_key);
+	__type(value, __u8);
+} loader_allowlist SEC(".maps");
+
 struct __perf_event_attr {
 	__u32 type;
 	__u32 size;
@@ -58,6 +108,41 @@ struct {
 #define VM_EXEC	      0x00000004
 #define MSG_OP_LOADER 26
 
+#define LOADER_F_ALLOWLIST_VIOLATION 1
+#define LOADER_F_REPORT_ALL	     2
+
+#ifndef SIGKILL
+#define SIGKILL 9
+#endif
+
+enum {
+	LOADER_BINARY_NO_MATCH,
+	LOADER_BINARY_MATCH,
+	LOADER_BINARY_UNKNOWN,
+};
+
+/* Processes started before the binaries of a policy were added to the
+ * names_map have no binary id, userspace resolves them from the process
+ * cache.
+ */
+static inline __attribute__((always_inline)) int
+loader_match_binary(struct loader_policy *policy, __u32 binary)
+{
+	int i, any = 1;
+
+#pragma unroll
+	for (i = 0; i < LOADER_MAX_BINARIES; i++) {
+		if (!policy->binaries[i])
+			continue;
+		if (policy->binaries[i] == binary)
+			return LOADER_BINARY_MATCH;
+		any = 0;
+	}
+	if (any)
+		return LOADER_BINARY_MATCH;
+	return binary ? LOADER_BINARY_NO_MATCH : LOADER_BINARY_UNKNOWN;
+}
+
 #define ATTR_BIT_MMAP	 BIT_ULL(8)
 #define ATTR_BIT_MMAP2	 BIT_ULL(23)
 #define ATTR_BIT_BUILDID BIT_ULL(34)
@@ -66,7 +151,10 @@ __attribute__((section(("kprobe/perf_event_mmap_output")), used)) int
 loader_kprobe(struct pt_regs *ctx)
 {
 	struct perf_mmap_event *mmap_event;
+	struct loader_allowlist_key key = {};
 	struct execve_map_value *curr;
+	struct loader_config *config;
+	struct loader_policy *policy;
 	struct task_struct *current;
 	struct vm_area_struct *vma;
 	struct msg_loader *msg;
@@ -74,7 +162,9 @@ loader_kprobe(struct pt_regs *ctx)
 	struct perf_event *pe;
 	__u64 *id_map, id_pe;
 	const char *path;
+	bool kill = false;
 	size_t total;
+	__u32 i;
 	int tgid;
 	long len;
 
@@ -122,6 +212,48 @@ loader_kprobe(struct pt_regs *ctx)
 		   _(&mmap_event->build_id[0]));
 	msg->buildid_size = BPF_CORE_READ(mmap_event, build_id_size);
 
+	/* Report the libraries outside of the allowlist of a policy, and
+	 * all of them if a policy has no allowlist.
+	 */
+	msg->flags = 0;
+	msg->violations = 0;
+	msg->unknown = 0;
+	config = map_lookup_elem(&loader_config_map, &(__u32){ 0 });
+	if (config && config->report_all)
+		msg->flags |= LOADER_F_REPORT_ALL;
+
+	memcpy(&key.buildid[0], &msg->buildid[0], sizeof(key.buildid));
+#pragma unroll
+	for (i = 0; i < LOADER_MAX_POLICIES; i++) {
+		policy = map_lookup_elem(&loader_policies, &i);
+		if (!policy || !policy->active)
+			continue;
+		key.policy = i;
+		if (map_lookup_elem(&loader_allowlist, &key))
+			continue;
+		switch (loader_match_binary(policy, curr->binary)) {
+		case LOADER_BINARY_MATCH:
+			msg->violations |= 1 << i;
+			if (policy->flags & LOADER_POLICY_F_SIGKILL)
+				kill = true;
+			break;
+		case LOADER_BINARY_UNKNOWN:
+			msg->unknown |= 1 << i;
+			break;
+		}
+	}
+
+	if (!msg->flags && !msg->violations && !msg->unknown)
+		return 0;
+	if (msg->violations)
+		msg->flags |= LOADER_F_ALLOWLIST_VIOLATION;
+
+	/* The signal is delivered before the process returns to user space,
+	 * so no code of the library runs.
+	 */
+	if (kill)
+		send_signal(SIGKILL);
+
 	path = BPF_CORE_READ(mmap_event, file_name);
 	len = probe_read_str(&msg->path, sizeof(msg->path), path);
 	msg->path_size = (__u32)len;
@@ -134,6 +266,6 @@ loader_kprobe(struct pt_regs *ctx)
 	msg->common.op = MSG_OP_LOADER;
 	msg->common.flags = 0;
 
-	perf_event_output(ctx, &tcpmon_map, BPF_F_CURRENT_CPU, msg, total);
+	event_output(ctx, msg, total);
 	return 0;
 }
@@ -365,6 +365,92 @@ d_path_local(const struct path *path, int *buflen, int *error)
 	return buffer;
 }
 
+struct dentry_read_data {
+	char *bf;
+	struct dentry *dentry;
+	char *bptr;
+	int blen;
+	bool resolved;
+};
+
+static inline __attribute__((always_inline)) long
+dentry_read(struct dentry_read_data *data)
+{
+	struct qstr d_name;
+	struct dentry *parent;
+	struct dentry *dentry = data->dentry;
+	int error;
+
+	if (!dentry || IS_ROOT(dentry)) {
+		// reached the root of the filesystem of the dentry
+		data->resolved = true;
+		return 1;
+	}
+	probe_read(&parent, sizeof(parent), _(&dentry->d_parent));
+	probe_read(&d_name, sizeof(d_name), _(&dentry->d_name));
+	error = prepend_name(data->bf, &data->bptr, &data->blen,
+			     (const char *)d_name.name, d_name.len);
+	// The dentry name does not fit in the buffer, see cwd_read.
+	if (error)
+		return 1;
+
+	data->dentry = parent;
+	return 0;
+}
+
+#ifdef __V61_BPF_PROG
+static long dentry_read_v61(__u32 index, void *data)
+{
+	return dentry_read(data);
+}
+#endif
+
+/*
+ * Returns the path of a dentry from the root of its filesystem, like
+ * d_path_local does for a path. A dentry does not tell on which mount it
+ * is, so the mount points are not crossed: the path of a dentry of a file
+ * on a mount is relative to the mount point.
+ */
+static inline __attribute__((always_inline)) char *
+dentry_path_local(struct dentry *dentry, int *buflen, int *error)
+{
+	struct dentry_read_data data = {
+		.dentry = dentry,
+	};
+	int zero = 0;
+	char *buffer = 0;
+
+	buffer = map_lookup_elem(&buffer_heap_map, &zero);
+	if (!buffer)
+		return 0;
+
+	data.bf = buffer;
+	data.bptr = buffer + 256;
+	data.blen = 256;
+	if (dentry && d_unlinked(dentry))
+		prepend(&data.bptr, &data.blen, " (deleted)", 10);
+	buffer = data.bptr;
+
+#ifndef __V61_BPF_PROG
+#pragma unroll
+	for (int i = 0; i < PROBE_CWD_READ_ITERATIONS; ++i) {
+		if (dentry_read(&data))
+			break;
+	}
+#else
+	loop(PROBE_CWD_READ_ITERATIONS, dentry_read_v61, (void *)&data, 0);
+#endif /* __V61_BPF_PROG */
+
+	*error = data.resolved ? 0 : UNRESOLVED_PATH_COMPONENTS;
+	if (data.bptr == buffer) {
+		// root of the filesystem, like d_path_local
+		*buflen = 0;
+		return buffer;
+	}
+	*buflen = 256 - data.blen;
+	return data.bptr;
+}
+
 static inline __attribute__((always_inline)) __u32
 getcwd(struct msg_process *curr, __u32 offset, __u32 proc_pid)
 {
@@ -8,6 +8,31 @@
 
 #define MAX_TOTAL 9000
 
+#ifdef GENERIC_UPROBE
+/* USDT argument location, encoded by pkg/usdt: the offset of the register
+ * in pt_regs in the low bits, and for memory and constant arguments a
+ * signed 16 bit displacement or value.
+ */
+#define USDT_ARG_REG_MASK  0x1ff
+#define USDT_ARG_VAL_SHIFT 12
+#define USDT_ARG_MEMORY	   BIT(29)
+#define USDT_ARG_CONSTANT  BIT(30)
+
+static inline __attribute__((always_inline)) unsigned long
+read_usdt_arg(void *ctx, __u32 loc)
+{
+	__s16 val = (loc >> USDT_ARG_VAL_SHIFT) & 0xffff;
+	unsigned long ret = 0;
+
+	if (loc & USDT_ARG_CONSTANT)
+		return (long)val;
+	probe_read(&ret, sizeof(ret), (char *)ctx + (loc & USDT_ARG_REG_MASK));
+	if (loc & USDT_ARG_MEMORY)
+		probe_read(&ret, sizeof(ret), (void *)(ret + val));
+	return ret;
+}
+#endif
+
 static inline __attribute__((always_inline)) int
 generic_process_event(void *ctx, int index, struct bpf_map_def *heap_map,
 		      struct bpf_map_def *tailcals, struct bpf_map_def *config_map,
@@ -67,6 +92,8 @@ generic_process_init(struct msg_generic_kprobe *e, u8 op, struct event_config *c
 	e->common.op = op;
 
 	e->common.flags = 0;
+	if (in_compat_syscall())
+		e->common.flags |= MSG_COMMON_FLAG_COMPAT;
 	e->common.pad[0] = 0;
 	e->common.pad[1] = 0;
 	e->common.size = 0;
@@ -78,6 +105,10 @@ generic_process_init(struct msg_generic_kprobe *e, u8 op, struct event_config *c
 	e->current.pad[3] = 0;
 
 	e->action = 0;
+	e->action_ktime = 0;
+	e->audit_action = 0;
+	e->audit_selector = 0;
+	e->audit = config->flags & FLAGS_AUDIT;
 
 	/**
 	 * Per thread tracking rules TID is the calling thread:
@@ -86,6 +117,34 @@ generic_process_init(struct msg_generic_kprobe *e, u8 op, struct event_config *c
 	e->tid = (__u32)get_current_pid_tgid();
 }
 
+/* values of the syscall field of the event config */
+#define SYSCALL_NATIVE 1
+#define SYSCALL_COMPAT 2
+
+/* Reads the arguments of a syscall from the user pt_regs. On x86_64, the
+ * arguments of the compat (32-bit) syscalls are passed in the registers of
+ * the i386 ABI.
+ */
+static inline __attribute__((always_inline)) void
+generic_syscall_args(struct msg_generic_kprobe *e, struct pt_regs *regs, __u32 syscall)
+{
+#if defined(__TARGET_ARCH_x86)
+	if (syscall == SYSCALL_COMPAT) {
+		e->a0 = BPF_CORE_READ(regs, bx);
+		e->a1 = BPF_CORE_READ(regs, cx);
+		e->a2 = BPF_CORE_READ(regs, dx);
+		e->a3 = BPF_CORE_READ(regs, si);
+		e->a4 = BPF_CORE_READ(regs, di);
+		return;
+	}
+#endif
+	e->a0 = PT_REGS_PARM1_CORE_SYSCALL(regs);
+	e->a1 = PT_REGS_PARM2_CORE_SYSCALL(regs);
+	e->a2 = PT_REGS_PARM3_CORE_SYSCALL(regs);
+	e->a3 = PT_REGS_PARM4_CORE_SYSCALL(regs);
+	e->a4 = PT_REGS_PARM5_CORE_SYSCALL(regs);
+}
+
 static inline __attribute__((always_inline)) int
 generic_process_event_and_setup(struct pt_regs *ctx,
 				struct bpf_map_def *heap_map,
@@ -108,16 +167,31 @@ generic_process_event_and_setup(struct pt_regs *ctx,
 		return 0;
 
 #ifdef GENERIC_KPROBE
+#ifdef __FENTRY
+	/* fentry programs get the function arguments as an array of u64
+	 * values, syscall functions get a pointer to the user pt_regs.
+	 */
+	if (config->syscall) {
+		struct pt_regs *_ctx = 0;
+
+		probe_read(&_ctx, sizeof(_ctx), (__u64 *)ctx + 0);
+		if (!_ctx)
+			return 0;
+		generic_syscall_args(e, _ctx, config->syscall);
+	} else {
+		probe_read(&e->a0, sizeof(e->a0), (__u64 *)ctx + 0);
+		probe_read(&e->a1, sizeof(e->a1), (__u64 *)ctx + 1);
+		probe_read(&e->a2, sizeof(e->a2), (__u64 *)ctx + 2);
+		probe_read(&e->a3, sizeof(e->a3), (__u64 *)ctx + 3);
+		probe_read(&e->a4, sizeof(e->a4), (__u64 *)ctx + 4);
+	}
+#else
 	if (config->syscall) {
 		struct pt_regs *_ctx;
 		_ctx = PT_REGS_SYSCALL_REGS(ctx);
 		if (!_ctx)
 			return 0;
-		e->a0 = PT_REGS_PARM1_CORE_SYSCALL(_ctx);
-		e->a1 = PT_REGS_PARM2_CORE_SYSCALL(_ctx);
-		e->a2 = PT_REGS_PARM3_CORE_SYSCALL(_ctx);
-		e->a3 = PT_REGS_PARM4_CORE_SYSCALL(_ctx);
-		e->a4 = PT_REGS_PARM5_CORE_SYSCALL(_ctx);
+		generic_syscall_args(e, _ctx, config->syscall);
 	} else {
 		e->a0 = PT_REGS_PARM1_CORE(ctx);
 		e->a1 = PT_REGS_PARM2_CORE(ctx);
@@ -125,6 +199,7 @@ generic_process_event_and_setup(struct pt_regs *ctx,
 		e->a3 = PT_REGS_PARM4_CORE(ctx);
 		e->a4 = PT_REGS_PARM5_CORE(ctx);
 	}
+#endif
 
 	generic_process_init(e, MSG_OP_GENERIC_KPROBE, config);
 
@@ -137,15 +212,46 @@ generic_process_event_and_setup(struct pt_regs *ctx,
 #endif
 
 #ifdef GENERIC_UPROBE
-	/* no arguments for uprobes for now */
-	e->a0 = 0;
-	e->a1 = 0;
-	e->a2 = 0;
-	e->a3 = 0;
-	e->a4 = 0;
+	if (config->flags & FLAGS_USDT) {
+		/* USDT argument locations are resolved from the probe notes
+		 * by user space, see read_usdt_arg.
+		 */
+		e->a0 = read_usdt_arg(ctx, config->t_arg0_ctx_off);
+		e->a1 = read_usdt_arg(ctx, config->t_arg1_ctx_off);
+		e->a2 = read_usdt_arg(ctx, config->t_arg2_ctx_off);
+		e->a3 = read_usdt_arg(ctx, config->t_arg3_ctx_off);
+		e->a4 = read_usdt_arg(ctx, config->t_arg4_ctx_off);
+	} else {
+		/* uprobes see the user space registers of the probed function */
+		e->a0 = PT_REGS_PARM1_CORE(ctx);
+		e->a1 = PT_REGS_PARM2_CORE(ctx);
+		e->a2 = PT_REGS_PARM3_CORE(ctx);
+		e->a3 = PT_REGS_PARM4_CORE(ctx);
+		e->a4 = PT_REGS_PARM5_CORE(ctx);
+	}
 	generic_process_init(e, MSG_OP_GENERIC_UPROBE, config);
 #endif
 
+#ifdef GENERIC_LSM
+	/* LSM programs get the hook arguments as an array of u64 values, read
+	 * them with probe_read as the hooks have different numbers of arguments.
+	 */
+	probe_read(&e->a0, sizeof(e->a0), (__u64 *)ctx + 0);
+	probe_read(&e->a1, sizeof(e->a1), (__u64 *)ctx + 1);
+	probe_read(&e->a2, sizeof(e->a2), (__u64 *)ctx + 2);
+	probe_read(&e->a3, sizeof(e->a3), (__u64 *)ctx + 3);
+	probe_read(&e->a4, sizeof(e->a4), (__u64 *)ctx + 4);
+	generic_process_init(e, MSG_OP_GENERIC_LSM, config);
+#endif
+
+#ifdef GENERIC_PERF_EVENT
+	/* The perf event programs have no arguments, the filter program sets
+	 * a0 to the number of samples of the process and a1 to the sample
+	 * period, they are reported as the u64 arguments of the event.
+	 */
+	generic_process_init(e, MSG_OP_GENERIC_PERF_EVENT, config);
+#endif
+
 	return generic_process_event(ctx, 0, heap_map, tailcals, config_map, data_heap);
 }
 
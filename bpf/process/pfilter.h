@@ -297,6 +297,81 @@ process_filter_capability_change(__u32 ty, __u32 op, __u32 ns, __u64 val,
 }
 #endif
 
+#ifdef __TAGS_FILTER
+/* process_filter_tags: In matches processes with any of the tags of the mask,
+ * NotIn processes with none of them.
+ */
+static inline __attribute__((always_inline)) int
+process_filter_tags(__u32 op, __u32 mask_lo, __u32 mask_hi,
+		    struct execve_map_value *enter)
+{
+	__u64 mask = ((__u64)mask_hi << 32) | mask_lo;
+	bool match = (enter->tags & mask) != 0;
+
+	if (op == op_filter_notin)
+		match = !match;
+	return match ? PFILTER_ACCEPT : PFILTER_REJECT;
+}
+#endif
+
+#ifdef __CREDS_FILTER
+#define CRED_FILTER_UID	  0
+#define CRED_FILTER_EUID  1
+#define CRED_FILTER_GID	  2
+#define CRED_FILTER_EGID  3
+#define CRED_FILTER_FSUID 4
+
+static inline __attribute__((always_inline)) int
+process_filter_cred(__u32 i, __u32 off, __u32 *f, __u64 ty, __u64 credty,
+		    struct execve_map_value *enter, struct msg_ns *n,
+		    struct msg_capabilities *c)
+{
+	struct task_struct *task;
+	const struct cred *cred;
+	__u32 sel, id = 0;
+
+	if (off > 1000)
+		sel = 0;
+	else {
+		__u64 o = (__u64)off;
+		o = o / 4;
+		asm volatile("%[o] &= 0x3ff;\n" ::[o] "+r"(o)
+			     :);
+		sel = f[o];
+	}
+
+	task = (struct task_struct *)get_current_task();
+	/* Get the task's subjective creds */
+	probe_read(&cred, sizeof(cred), _(&task->cred));
+
+	switch (credty) {
+	case CRED_FILTER_UID:
+		probe_read(&id, sizeof(id), _(&cred->uid));
+		break;
+	case CRED_FILTER_EUID:
+		probe_read(&id, sizeof(id), _(&cred->euid));
+		break;
+	case CRED_FILTER_GID:
+		probe_read(&id, sizeof(id), _(&cred->gid));
+		break;
+	case CRED_FILTER_EGID:
+		probe_read(&id, sizeof(id), _(&cred->egid));
+		break;
+	case CRED_FILTER_FSUID:
+		probe_read(&id, sizeof(id), _(&cred->fsuid));
+		break;
+	default: /* We should not reach that. Userspace checks that. */
+		return PFILTER_REJECT;
+	}
+
+	if (ty == op_filter_in && sel != id)
+		return PFILTER_REJECT;
+	else if (ty == op_filter_notin && sel == id)
+		return PFILTER_REJECT;
+	return PFILTER_ACCEPT;
+}
+#endif
+
 #define MAX_SELECTOR_VALUES 4
 
 static inline __attribute__((always_inline)) int
@@ -374,6 +449,29 @@ struct nc_filter {
 	u32 value; /* contains all namespaces to monitor (i.e. bit 0 is for ns_uts, bit 1 for ns_ipc etc.) */
 } __attribute__((packed));
 
+struct cred_filter {
+	u32 ty; /* credential (i.e. CRED_FILTER_UID, CRED_FILTER_EUID, ...) */
+	u32 op; /* op (i.e. op_filter_in or op_filter_notin) */
+	u32 len; /* number of values */
+	u32 val[]; /* values */
+} __attribute__((packed));
+
+struct tag_filter {
+	u32 op; /* op (i.e. op_filter_in or op_filter_notin) */
+	u32 mask_lo; /* mask of the tags, low 32 bits */
+	u32 mask_hi; /* mask of the tags, high 32 bits */
+} __attribute__((packed));
+
+/* If you update the value of NUM_TAG_FILTERS below you should
+ * also update ParseMatchTags() in kernel.go
+ */
+#define NUM_TAG_FILTERS 2
+
+/* If you update the value of NUM_CRED_FILTERS below you should
+ * also update ParseMatchCredentials() in kernel.go
+ */
+#define NUM_CRED_FILTERS 5
+
 #define VALUES_MASK 0x1f /* max 4 values with 4 bytes each | 0x1f == 31 */
 
 /* If you update the value of NUM_NS_FILTERS_SMALL below you should
@@ -391,6 +489,12 @@ selector_process_filter(__u32 *f, __u32 index, struct execve_map_value *enter,
 	struct ns_filter *ns;
 #ifdef __NS_CHANGES_FILTER
 	struct nc_filter *nc;
+#endif
+#ifdef __CREDS_FILTER
+	struct cred_filter *cr;
+#endif
+#ifdef __TAGS_FILTER
+	struct tag_filter *tg;
 #endif
 	struct caps_filter *caps;
 	__u32 len;
@@ -465,10 +569,10 @@ selector_process_filter(__u32 *f, __u32 index, struct execve_map_value *enter,
 
 	if (len > 0) {
 		caps = (struct caps_filter *)((u64)f + (index & INDEX_MASK));
-		index += sizeof(struct caps_filter); /* 20: ty, op, ns, val */
 		res = process_filter_capabilities(caps->ty, caps->op, caps->ns,
 						  caps->val, n, c);
 	}
+	index += (len & INDEX_MASK); /* now index points at the end of capabilities filter */
 	if (res == PFILTER_REJECT)
 		return res;
 
@@ -501,14 +605,64 @@ selector_process_filter(__u32 *f, __u32 index, struct execve_map_value *enter,
 
 	if (len > 0) {
 		caps = (struct caps_filter *)((u64)f + (index & INDEX_MASK));
-		index += sizeof(struct caps_filter); /* 20: ty, op, ns, val */
 		res = process_filter_capability_change(
 			caps->ty, caps->op, caps->ns, caps->val, n, c, sel);
 	}
+	index += (len & INDEX_MASK); /* now index points at the end of capability changes filter */
 	if (res == PFILTER_REJECT)
 		return res;
 #endif
 
+#ifdef __CREDS_FILTER
+	/* matchCredentials */
+	len = *(__u32 *)((__u64)f +
+			 (index &
+			  INDEX_MASK)); /* (sizeof(cr1) + sizeof(cr2) + ... + 4) */
+	index += 4; /* 4: creds header */
+	len -= 4;
+
+	for (i = 0; i < NUM_CRED_FILTERS; i++) {
+		if (len > 0) {
+			cr = (struct cred_filter *)((u64)f +
+						    (index & INDEX_MASK));
+			index += sizeof(
+				struct cred_filter); /* 12: type, op, length */
+			res = selector_match(f, index, cr->op, cr->ty, cr->len,
+					     enter, n, c, &process_filter_cred);
+			index +=
+				((cr->len * sizeof(cr->val[0])) &
+				 VALUES_MASK); /* now index points at the end of credentials filter */
+			len -= (sizeof(struct cred_filter) +
+				(cr->len * sizeof(cr->val[0])));
+		}
+		if (res == PFILTER_REJECT)
+			return res;
+	}
+#endif
+
+#ifdef __TAGS_FILTER
+	/* matchTags */
+	len = *(__u32 *)((__u64)f +
+			 (index &
+			  INDEX_MASK)); /* (sizeof(tag1) + sizeof(tag2) + ... + 4) */
+	index += 4; /* 4: tags header */
+	len -= 4;
+
+#pragma unroll
+	for (i = 0; i < NUM_TAG_FILTERS; i++) {
+		if (len > 0) {
+			tg = (struct tag_filter *)((u64)f +
+						   (index & INDEX_MASK));
+			index += sizeof(struct tag_filter); /* 12: op, mask */
+			res = process_filter_tags(tg->op, tg->mask_lo,
+						  tg->mask_hi, enter);
+			len -= sizeof(struct tag_filter);
+		}
+		if (res == PFILTER_REJECT)
+			return res;
+	}
+#endif
+
 	return res;
 }
 
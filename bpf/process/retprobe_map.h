@@ -120,11 +120,16 @@ retprobe_map_set_iovec(__u64 id, __u64 tid, __u64 ktime, unsigned long ptr,
 static inline __attribute__((always_inline)) __u64
 retprobe_map_get_key(struct pt_regs *ctx)
 {
+#if defined(__FENTRY) || defined(__TRACEPOINT)
+	/* ctx is not a pt_regs for fentry/fexit and tracepoint programs */
+	return get_current_pid_tgid();
+#else
 	__u64 ret = get_current_pid_tgid();
 	if (ret == (__u64)-22) { // -EINVAL -- current == NULL
 		ret = PT_REGS_FP_CORE(ctx);
 	}
 	return ret;
+#endif
 }
 
 #endif /* __RETPROBE_MAP_H__ */
@@ -9,6 +9,7 @@
 #include "bpf_cred.h"
 #include "skb.h"
 #include "sock.h"
+#include "sockaddr.h"
 #include "../bpf_process_event.h"
 #include "bpfattr.h"
 #include "perfevent.h"
@@ -61,6 +62,12 @@ enum {
 	load_module_type = 26,
 	kernel_module_type = 27,
 
+	socket_type = 28,
+	sockaddr_type = 29,
+	linux_binprm_type = 30,
+	dentry_type = 31,
+	string_array_type = 32,
+
 	nop_s64_ty = -10,
 	nop_u64_ty = -11,
 	nop_u32_ty = -12,
@@ -89,6 +96,8 @@ enum {
 	ACTION_TRACKSOCK = 10,
 	ACTION_UNTRACKSOCK = 11,
 	ACTION_NOTIFY_KILLER = 12,
+	ACTION_COUNT = 13,
+	ACTION_SET_TAG = 14,
 };
 
 enum {
@@ -114,6 +123,15 @@ struct selector_arg_filters {
 } __attribute__((packed));
 
 #define FLAGS_EARLY_FILTER BIT(0)
+#define FLAGS_USDT	   BIT(1)
+/* events of compat syscalls are dropped */
+#define FLAGS_SKIP_COMPAT  BIT(2)
+/* return events carry the latency of the call after the enter ktime */
+#define FLAGS_LATENCY	   BIT(3)
+/* latencies of the call are counted in latency_hist_map */
+#define FLAGS_LATENCY_HIST BIT(4)
+/* enforcement actions are reported in the events instead of being taken */
+#define FLAGS_AUDIT	   BIT(5)
 
 struct event_config {
 	__u32 func_id;
@@ -145,6 +163,10 @@ struct event_config {
 	 */
 	__u32 policy_id;
 	__u32 flags;
+	/* maximum number of bytes copied for the argument of argreturncopy,
+	 * 0 means no limit other than the size of the event.
+	 */
+	__u32 argreturncopy_max_size;
 } __attribute__((packed));
 
 #define MAX_ARGS_SIZE	 80
@@ -174,6 +196,21 @@ struct event_config {
  */
 #define MAX_STRING 1024
 
+/* Bounds of the NULL-terminated arrays of strings (string_array_type): the
+ * number of strings that are copied and the size of each string, including
+ * the nul character.
+ */
+#ifdef __LARGE_BPF_PROG
+#define MAX_STRING_ARRAY_ENTRIES 16
+#else
+#define MAX_STRING_ARRAY_ENTRIES 4
+#endif
+#define MAX_STRING_ARRAY_ELEM	 256
+#define STRING_ARRAY_SIZE	 (8 + MAX_STRING_ARRAY_ENTRIES * (4 + MAX_STRING_ARRAY_ELEM))
+
+/* The array has more entries than the copied ones */
+#define STRING_ARRAY_TRUNCATED 1
+
 #ifdef __MULTI_KPROBE
 static inline __attribute__((always_inline)) __u32 get_index(void *ctx)
 {
@@ -239,7 +276,7 @@ return_stack_error(char *args, int orig, int err)
 
 static inline __attribute__((always_inline)) int
 parse_iovec_array(long off, unsigned long arg, int i, unsigned long max,
-		  struct msg_generic_kprobe *e)
+		  __u64 *total, struct msg_generic_kprobe *e)
 {
 	struct iovec
 		iov; // limit is 1024 using a hack now. For 5.4 kernel we should loop over 1024
@@ -251,6 +288,7 @@ parse_iovec_array(long off, unsigned long arg, int i, unsigned long max,
 	if (err < 0)
 		return char_buf_pagefault;
 	size = iov.iov_len;
+	*total += size;
 	if (max && size > max)
 		size = max;
 	if (size > 4094)
@@ -270,7 +308,7 @@ parse_iovec_array(long off, unsigned long arg, int i, unsigned long max,
 		/* embedding this in the loop counter breaks verifier */ \
 		if (i >= cnt)                                            \
 			goto char_iovec_done;                            \
-		c = parse_iovec_array(off, arg, i, max, e);              \
+		c = parse_iovec_array(off, arg, i, max, &total, e);      \
 		if (c < 0) {                                             \
 			char *args = args_off(e, off_orig);              \
 			return return_stack_error(args, 0, c);           \
@@ -402,17 +440,11 @@ static inline __attribute__((always_inline)) int cmpbytes(char *s1, char *s2,
 }
 
 static inline __attribute__((always_inline)) long
-copy_path(char *args, const struct path *arg)
+__copy_path(char *args, char *buffer, int size, int flags)
 {
 	int *s = (int *)args;
-	int size = 0, flags = 0;
-	char *buffer;
 	void *curr = &args[4];
 
-	buffer = d_path_local(arg, &size, &flags);
-	if (!buffer)
-		return 0;
-
 	asm volatile("%[size] &= 0xff;\n" ::[size] "+r"(size)
 		     :);
 	probe_read(curr, size, buffer);
@@ -445,6 +477,32 @@ a:
 	return size;
 }
 
+static inline __attribute__((always_inline)) long
+copy_path(char *args, const struct path *arg)
+{
+	int size = 0, flags = 0;
+	char *buffer;
+
+	buffer = d_path_local(arg, &size, &flags);
+	if (!buffer)
+		return 0;
+	return __copy_path(args, buffer, size, flags);
+}
+
+/* copy_dentry copies the path of a dentry, in the format of copy_path. */
+static inline __attribute__((always_inline)) long
+copy_dentry(char *args, unsigned long arg)
+{
+	struct dentry *dentry = (struct dentry *)arg;
+	int size = 0, flags = 0;
+	char *buffer;
+
+	buffer = dentry_path_local(dentry, &size, &flags);
+	if (!buffer)
+		return 0;
+	return __copy_path(args, buffer, size, flags);
+}
+
 static inline __attribute__((always_inline)) long
 copy_strings(char *args, unsigned long arg)
 {
@@ -462,6 +520,45 @@ copy_strings(char *args, unsigned long arg)
 	return size + 4;
 }
 
+/* copy_string_array copies a NULL-terminated array of pointers to strings,
+ * like the argv and envp arguments of execve, in the form:
+ * *---------*---------*---------*---------*-----
+ * | 4 bytes | 4 bytes | 4 bytes | N bytes | ...
+ * |  count  |  flags  |  size   | string  | ...
+ * *---------*---------*---------*---------*-----
+ * Strings longer than MAX_STRING_ARRAY_ELEM - 1 are truncated.
+ */
+static inline __attribute__((always_inline)) long
+copy_string_array(char *args, unsigned long arg)
+{
+	__u32 *hdr = (__u32 *)args;
+	char **arr = (char **)arg;
+	long off = 8, size;
+	char *str;
+	int i;
+
+	hdr[0] = 0;
+	hdr[1] = 0;
+#pragma unroll
+	for (i = 0; i < MAX_STRING_ARRAY_ENTRIES; i++) {
+		if (probe_read(&str, sizeof(str), &arr[i]) < 0 || !str)
+			return off;
+		// help the verifier, off is below STRING_ARRAY_SIZE
+		off &= 0x1fff;
+		size = probe_read_str(&args[off + 4], MAX_STRING_ARRAY_ELEM, str);
+		if (size < 1)
+			size = 1;
+		// Remove the nul character from end.
+		size--;
+		*(__u32 *)&args[off] = size;
+		off += size + 4;
+		hdr[0]++;
+	}
+	if (probe_read(&str, sizeof(str), &arr[i]) == 0 && str)
+		hdr[1] |= STRING_ARRAY_TRUNCATED;
+	return off;
+}
+
 static inline __attribute__((always_inline)) long copy_skb(char *args,
 							   unsigned long arg)
 {
@@ -491,6 +588,27 @@ static inline __attribute__((always_inline)) long copy_sock(char *args,
 	return sizeof(struct sk_type);
 }
 
+static inline __attribute__((always_inline)) long copy_socket(char *args,
+							      unsigned long arg)
+{
+	struct socket *sock = (struct socket *)arg;
+	struct sock *sk;
+
+	probe_read(&sk, sizeof(sk), _(&sock->sk));
+	return copy_sock(args, (unsigned long)sk);
+}
+
+static inline __attribute__((always_inline)) long
+copy_sockaddr(char *args, unsigned long arg)
+{
+	struct sockaddr *address = (struct sockaddr *)arg;
+	struct sockaddr_in_type *event = (struct sockaddr_in_type *)args;
+
+	set_event_from_sockaddr(event, address);
+
+	return sizeof(struct sockaddr_in_type);
+}
+
 static inline __attribute__((always_inline)) long
 copy_user_ns(char *args, unsigned long arg)
 {
@@ -595,6 +713,8 @@ copy_kernel_module(char *args, unsigned long arg)
 #define ARGM_INDEX_MASK	 0xf
 #define ARGM_RETURN_COPY BIT(4)
 #define ARGM_MAX_DATA	 BIT(5)
+/* the bits above hold the maximum number of bytes to copy (maxDataSize) */
+#define ARGM_MAX_SIZE_SHIFT 8
 
 static inline __attribute__((always_inline)) bool
 hasReturnCopy(unsigned long argm)
@@ -608,6 +728,12 @@ has_max_data(unsigned long argm)
 	return (argm & ARGM_MAX_DATA) != 0;
 }
 
+static inline __attribute__((always_inline)) unsigned long
+get_max_size(unsigned long argm)
+{
+	return (argm & 0xffffffff) >> ARGM_MAX_SIZE_SHIFT;
+}
+
 static inline __attribute__((always_inline)) unsigned long
 get_arg_meta(int meta, struct msg_generic_kprobe *e)
 {
@@ -628,24 +754,36 @@ get_arg_meta(int meta, struct msg_generic_kprobe *e)
 
 static inline __attribute__((always_inline)) long
 __copy_char_buf(void *ctx, long off, unsigned long arg, unsigned long bytes,
-		bool max_data, struct msg_generic_kprobe *e,
-		struct bpf_map_def *data_heap)
+		bool max_data, unsigned long max_size,
+		struct msg_generic_kprobe *e, struct bpf_map_def *data_heap)
 {
 	int *s = (int *)args_off(e, off);
-	size_t rd_bytes, extra = 8;
+	size_t rd_bytes, cp_bytes = bytes, extra = 8;
 	int err;
 
+	/* Copy at most max_size bytes, the original size is still reported so
+	 * that the argument is marked as truncated.
+	 */
+	if (max_size && cp_bytes > max_size)
+		cp_bytes = max_size;
+
 #ifdef __LARGE_BPF_PROG
 	if (max_data && data_heap) {
 		/* The max_data flag is enabled, the first int value indicates
 		 * if we use (1) data events or not (0).
 		 */
-		if (bytes >= 0x1000) {
+		if (cp_bytes >= 0x1000) {
+			struct data_event_desc *desc = (struct data_event_desc *)&s[1];
+			size_t ret;
+
 			s[0] = 1;
-			return data_event_bytes(ctx,
-						(struct data_event_desc *)&s[1],
-						arg, bytes, data_heap) +
-			       4;
+			ret = data_event_bytes(ctx, desc, arg, cp_bytes, data_heap);
+			/* account the bytes beyond max_size as not sent */
+			if (!desc->error) {
+				desc->size += bytes - cp_bytes;
+				desc->leftover += bytes - cp_bytes;
+			}
+			return ret + 4;
 		}
 		s[0] = 0;
 		s = (int *)args_off(e, off + 4);
@@ -654,7 +792,7 @@ __copy_char_buf(void *ctx, long off, unsigned long arg, unsigned long bytes,
 #endif // __LARGE_BPF_PROG
 
 	/* Bound bytes <4095 to ensure bytes does not read past end of buffer */
-	rd_bytes = bytes < 0x1000 ? bytes : 0xfff;
+	rd_bytes = cp_bytes < 0x1000 ? cp_bytes : 0xfff;
 	asm volatile("%[rd_bytes] &= 0xfff;\n" ::[rd_bytes] "+r"(rd_bytes)
 		     :);
 	err = probe_read(&s[2], rd_bytes, (char *)arg);
@@ -682,7 +820,8 @@ copy_char_buf(void *ctx, long off, unsigned long arg, int argm,
 	}
 	meta = get_arg_meta(argm, e);
 	probe_read(&bytes, sizeof(bytes), &meta);
-	return __copy_char_buf(ctx, off, arg, bytes, has_max_data(argm), e, data_heap);
+	return __copy_char_buf(ctx, off, arg, bytes, has_max_data(argm),
+			       get_max_size(argm), e, data_heap);
 }
 
 static inline __attribute__((always_inline)) long
@@ -931,14 +1070,13 @@ filter_addr_map(struct selector_arg_filter *filter, __u64 *addr, __u16 family)
 	__u32 map_idx;
 	struct addr4_lpm_trie arg4;
 	struct addr6_lpm_trie arg6;
+	__u8 *pass = 0;
 	void *arg;
 
 	switch (family) {
 	case AF_INET:
 		map_idx = map_idxs[0];
 		addrmap = map_lookup_elem(&addr4lpm_maps, &map_idx);
-		if (!addrmap)
-			return 0;
 		arg4.prefix = 32;
 		arg4.addr = addr[0];
 		arg = &arg4;
@@ -946,8 +1084,6 @@ filter_addr_map(struct selector_arg_filter *filter, __u64 *addr, __u16 family)
 	case AF_INET6:
 		map_idx = map_idxs[1];
 		addrmap = map_lookup_elem(&addr6lpm_maps, &map_idx);
-		if (!addrmap)
-			return 0;
 		arg6.prefix = 128;
 		// write the address in as 4 u32s due to alignment
 		write_ipv6_addr32(arg6.addr, (__u32 *)addr);
@@ -957,7 +1093,10 @@ filter_addr_map(struct selector_arg_filter *filter, __u64 *addr, __u16 family)
 		return 0;
 	}
 
-	__u8 *pass = map_lookup_elem(addrmap, arg);
+	// If there is no map for the family, none of the selector addresses
+	// belongs to it, so the address cannot match.
+	if (addrmap)
+		pass = map_lookup_elem(addrmap, arg);
 
 	switch (filter->op) {
 	case op_filter_saddr:
@@ -970,6 +1109,72 @@ filter_addr_map(struct selector_arg_filter *filter, __u64 *addr, __u16 family)
 	return 0;
 }
 
+// use the selector value to determine a LPM Trie map of ports, and do a lookup to determine
+// whether the port is in the defined set. Ports are stored as 32-bit values in network byte
+// order in the IPv4 LPM Tries, so that ranges of ports can be stored as prefixes.
+static inline __attribute__((always_inline)) long
+filter_port_map(struct selector_arg_filter *filter, __u32 port)
+{
+	void *portmap;
+	__u32 map_idx = filter->value;
+	struct addr4_lpm_trie arg;
+	__u8 *pass = 0;
+
+	portmap = map_lookup_elem(&addr4lpm_maps, &map_idx);
+	if (portmap) {
+		arg.prefix = 32;
+		arg.addr = bpf_htonl(port);
+		pass = map_lookup_elem(portmap, &arg);
+	}
+
+	switch (filter->op) {
+	case op_filter_sport:
+	case op_filter_dport:
+		return !!pass;
+	case op_filter_notsport:
+	case op_filter_notdport:
+		return !pass;
+	}
+	return 0;
+}
+
+/* filter_sockaddr: runs a comparison between the family, the IPv4/6 address
+ * and the port of the sockaddr in the args aginst the filter parameters. The
+ * sockaddr has a single address, matched by both the source and destination
+ * operators.
+ */
+static inline __attribute__((always_inline)) long
+filter_sockaddr(struct selector_arg_filter *filter, char *args)
+{
+	struct sockaddr_in_type *address = (struct sockaddr_in_type *)args;
+	__u64 addr[2] = { 0, 0 };
+	__u32 value;
+
+	switch (filter->op) {
+	case op_filter_saddr:
+	case op_filter_notsaddr:
+	case op_filter_daddr:
+	case op_filter_notdaddr:
+		write_ipv6_addr(addr, address->sin_addr);
+		return filter_addr_map(filter, addr, address->sin_family);
+	case op_filter_sport:
+	case op_filter_notsport:
+	case op_filter_dport:
+	case op_filter_notdport:
+		return filter_port_map(filter, address->sin_port);
+	case op_filter_sportpriv:
+	case op_filter_dportpriv:
+		return address->sin_port < 1024;
+	case op_filter_notsportpriv:
+	case op_filter_notdportpriv:
+		return address->sin_port >= 1024;
+	case op_filter_family:
+		value = address->sin_family;
+		return filter_32ty_map(filter, (char *)&value);
+	}
+	return 0;
+}
+
 /* filter_inet: runs a comparison between the IPv4/6 addresses and ports in
  * the sock or skb in the args aginst the filter parameters.
  */
@@ -985,6 +1190,7 @@ filter_inet(struct selector_arg_filter *filter, char *args)
 
 	switch (filter->type) {
 	case sock_type:
+	case socket_type:
 		sk = (struct sk_type *)args;
 		tuple = &sk->tuple;
 		break;
@@ -1024,9 +1230,13 @@ filter_inet(struct selector_arg_filter *filter, char *args)
 		value = tuple->family;
 		break;
 	case op_filter_state:
-		if (filter->type == sock_type)
+		if (sk)
 			value = sk->state;
 		break;
+	case op_filter_socktype:
+		if (sk)
+			value = sk->type;
+		break;
 	default:
 		return 0;
 	}
@@ -1036,7 +1246,7 @@ filter_inet(struct selector_arg_filter *filter, char *args)
 	case op_filter_dport:
 	case op_filter_notsport:
 	case op_filter_notdport:
-		return filter_32ty_map(filter, (char *)&port);
+		return filter_port_map(filter, port);
 	case op_filter_sportpriv:
 	case op_filter_dportpriv:
 		return port < 1024;
@@ -1052,7 +1262,8 @@ filter_inet(struct selector_arg_filter *filter, char *args)
 	case op_filter_family:
 		return filter_32ty_map(filter, (char *)&value);
 	case op_filter_state:
-		if (filter->type == sock_type)
+	case op_filter_socktype:
+		if (sk)
 			return filter_32ty_map(filter, (char *)&value);
 	}
 	return 0;
@@ -1060,10 +1271,12 @@ filter_inet(struct selector_arg_filter *filter, char *args)
 
 static inline __attribute__((always_inline)) long
 __copy_char_iovec(long off, unsigned long arg, unsigned long cnt,
-		  unsigned long max, struct msg_generic_kprobe *e)
+		  unsigned long max, unsigned long orig,
+		  struct msg_generic_kprobe *e)
 {
 	long size, off_orig = off;
 	unsigned long i = 0;
+	__u64 total = 0;
 	int *s;
 
 	size = 0;
@@ -1073,7 +1286,10 @@ __copy_char_iovec(long off, unsigned long arg, unsigned long cnt,
 		char_iovec_done :
 
 	    s = (int *)args_off(e, off_orig);
-	s[0] = size;
+	/* Without the original size, report the size of the parsed entries,
+	 * which is larger than the copied size when it was limited.
+	 */
+	s[0] = orig ? orig : total;
 	s[1] = size;
 	return size + 8;
 }
@@ -1093,7 +1309,7 @@ copy_char_iovec(void *ctx, long off, unsigned long arg, int argm,
 		retprobe_map_set_iovec(e->func_id, retid, e->common.ktime, arg, meta);
 		return return_error(s, char_buf_saved_for_retprobe);
 	}
-	return __copy_char_iovec(off, arg, meta, 0, e);
+	return __copy_char_iovec(off, arg, meta, get_max_size(argm), 0, e);
 }
 
 static inline __attribute__((always_inline)) long
@@ -1191,7 +1407,8 @@ copy_iov_iter(void *ctx, long off, unsigned long arg, int argm, struct msg_gener
 		probe_read(&count, sizeof(count), tmp);
 
 		return __copy_char_buf(ctx, off, (unsigned long)buf, count,
-				       has_max_data(argm), e, data_heap);
+				       has_max_data(argm), get_max_size(argm),
+				       e, data_heap);
 	}
 
 #ifdef __V61_BPF_PROG
@@ -1203,7 +1420,8 @@ copy_iov_iter(void *ctx, long off, unsigned long arg, int argm, struct msg_gener
 		probe_read(&count, sizeof(count), tmp);
 
 		return __copy_char_buf(ctx, off, (unsigned long)buf, count,
-				       has_max_data(argm), e, data_heap);
+				       has_max_data(argm), get_max_size(argm),
+				       e, data_heap);
 	}
 #endif
 
@@ -1344,15 +1562,12 @@ filter_32ty_map(struct selector_arg_filter *filter, char *args)
 
 	switch (filter->op) {
 	case op_filter_inmap:
-	case op_filter_sport:
-	case op_filter_dport:
 	case op_filter_protocol:
 	case op_filter_family:
 	case op_filter_state:
+	case op_filter_socktype:
 		return !!pass;
 	case op_filter_notinmap:
-	case op_filter_notsport:
-	case op_filter_notdport:
 		return !pass;
 	}
 	return 0;
@@ -1381,6 +1596,8 @@ static inline __attribute__((always_inline)) size_t type_to_min_size(int type,
 	case fd_ty:
 	case file_ty:
 	case path_ty:
+	case linux_binprm_type:
+	case dentry_type:
 	case string_type:
 		return MAX_STRING;
 	case int_type:
@@ -1390,9 +1607,14 @@ static inline __attribute__((always_inline)) size_t type_to_min_size(int type,
 	case skb_type:
 		return sizeof(struct skb_type);
 	case sock_type:
+	case socket_type:
 		return sizeof(struct sk_type);
+	case sockaddr_type:
+		return sizeof(struct sockaddr_in_type);
 	case cred_type:
 		return sizeof(struct msg_cred);
+	case string_array_type:
+		return STRING_ARRAY_SIZE;
 	case size_type:
 	case s64_ty:
 	case u64_ty:
@@ -1438,6 +1660,12 @@ static inline __attribute__((always_inline)) size_t type_to_min_size(int type,
  *
  * When we check the selectors, use ->binary to index sel_names_map and decide
  * whether the selector matches or not.
+ *
+ * Prefix/NotPrefix operators work the same way, but use names_prefix_map (an
+ * LPM trie) at exec time and ->binary_prefix for the sel_names_map lookup.
+ * A selector only contains the ids of its own prefixes, the ids of the shorter
+ * prefixes are found with names_prefix_parent_map. Ids are unique across
+ * names_map and names_prefix_map.
  */
 struct {
 	__uint(type, BPF_MAP_TYPE_HASH_OF_MAPS);
@@ -1457,8 +1685,9 @@ static inline __attribute__((always_inline)) int match_binaries(void *sel_names,
 	void *binaries_map;
 	struct execve_map_value *execve;
 	__u32 *op, max = 0xffffffff; // UINT32_MAX
-	__u32 ppid, bin_key, *bin_val;
+	__u32 ppid, bin_key, *bin_val, *parent;
 	bool walker = 0;
+	int i;
 
 	// if binaries_map is NULL for the specific selidx, this
 	// means that the specific selector does not contain any
@@ -1474,6 +1703,21 @@ static inline __attribute__((always_inline)) int match_binaries(void *sel_names,
 			bin_key = execve->binary;
 			bin_val = map_lookup_elem(binaries_map, &bin_key);
 
+			/* walk from the longest matching prefix to the shorter ones */
+			bin_key = execve->binary_prefix;
+#pragma unroll
+			for (i = 0; i < NAMES_PREFIX_MAX_DEPTH; i++) {
+				if (bin_val || !bin_key)
+					break;
+				bin_val = map_lookup_elem(binaries_map, &bin_key);
+				if (bin_val)
+					break;
+				parent = map_lookup_elem(&names_prefix_parent_map, &bin_key);
+				if (!parent)
+					break;
+				bin_key = *parent;
+			}
+
 			/*
 			 * The following things may happen:
 			 * binary is not part of names_map, execve_map->binary will be `0` and `bin_val` will always be `0`
@@ -1497,7 +1741,7 @@ static inline __attribute__((always_inline)) int match_binaries(void *sel_names,
 static inline __attribute__((always_inline)) int
 generic_process_filter_binary(struct event_config *config)
 {
-	/* single flag bit at the moment (FLAGS_EARLY_FILTER) */
+	/* binaries are matched before the selectors with FLAGS_EARLY_FILTER */
 	if (config->flags & FLAGS_EARLY_FILTER)
 		return match_binaries(&sel_names_map, 0);
 	return 1;
@@ -1531,6 +1775,10 @@ selector_arg_offset(__u8 *f, struct msg_generic_kprobe *e, __u32 selidx,
 	seloff += *(__u32 *)((__u64)f + (seloff & INDEX_MASK));
 	/* skip the matchCapabilityChanges by reading its length */
 	seloff += *(__u32 *)((__u64)f + (seloff & INDEX_MASK));
+	/* skip the matchCredentials by reading its length */
+	seloff += *(__u32 *)((__u64)f + (seloff & INDEX_MASK));
+	/* skip the matchTags by reading its length */
+	seloff += *(__u32 *)((__u64)f + (seloff & INDEX_MASK));
 
 	// check for match binary actions
 	if (!early_binary_filter && !match_binaries(&sel_names_map, selidx))
@@ -1574,6 +1822,8 @@ selector_arg_offset(__u8 *f, struct msg_generic_kprobe *e, __u32 selidx,
 			args += 4;
 		case file_ty:
 		case path_ty:
+		case linux_binprm_type:
+		case dentry_type:
 			pass &= filter_file_buf(filter, (struct string_buf *)args);
 			break;
 		case string_type:
@@ -1594,11 +1844,17 @@ selector_arg_offset(__u8 *f, struct msg_generic_kprobe *e, __u32 selidx,
 		case int_type:
 		case s32_ty:
 		case u32_ty:
+		case capability_type:
 			pass &= filter_32ty(filter, args);
 			break;
 		case skb_type:
 		case sock_type:
+		case socket_type:
 			pass &= filter_inet(filter, args);
+			break;
+		case sockaddr_type:
+			pass &= filter_sockaddr(filter, args);
+			break;
 		default:
 			break;
 		}
@@ -1757,14 +2013,26 @@ copyfd(struct msg_generic_kprobe *e, int oldfd, int newfd)
 
 #ifdef __LARGE_BPF_PROG
 static inline __attribute__((always_inline)) void
-do_action_signal(int signal)
+do_action_signal(struct msg_generic_kprobe *e, int signal)
 {
 	send_signal(signal);
+	e->action_ktime = ktime_get_ns();
 }
 #else
-#define do_action_signal(signal)
+#define do_action_signal(e, signal)
 #endif /* __LARGE_BPF_PROG */
 
+/* In audit mode, the enforcement actions are not taken, but reported in the
+ * event with the index of the selector that matched.
+ */
+static inline __attribute__((always_inline)) __u32
+do_action_audit(struct msg_generic_kprobe *e, int action, __u32 i)
+{
+	e->audit_action = action;
+	e->audit_selector = e->sel.curr;
+	return ++i;
+}
+
 /* The number of bytes per argument to include in the key
  * that we use to check for repeating data.
  * 40 is good for IPv6 data.
@@ -1781,6 +2049,7 @@ struct ratelimit_key {
 
 struct ratelimit_value {
 	__u64 ktime;
+	__u64 count;
 };
 
 struct {
@@ -1808,12 +2077,18 @@ struct {
 	__type(value, __u8[sizeof(struct ratelimit_key) + 128]);
 } ratelimit_ro_heap SEC(".maps");
 
+#define RATELIMIT_SCOPE_THREAD	0
+#define RATELIMIT_SCOPE_PROCESS 1
+#define RATELIMIT_SCOPE_GLOBAL	2
+
 #ifdef __LARGE_BPF_PROG
 static inline __attribute__((always_inline)) bool
-rate_limit(__u64 ratelimit_interval, struct msg_generic_kprobe *e)
+rate_limit(__u64 ratelimit_interval, __u64 ratelimit_count, __u64 ratelimit_scope,
+	   struct msg_generic_kprobe *e)
 {
 	__u64 curr_time = ktime_get_ns();
-	__u64 *last_repeat_entry;
+	struct ratelimit_value *last_repeat_entry;
+	struct ratelimit_value value;
 	struct ratelimit_key *key;
 	void *ro_heap;
 	__u32 zero = 0;
@@ -1834,7 +2109,19 @@ rate_limit(__u64 ratelimit_interval, struct msg_generic_kprobe *e)
 	key->func_id = e->func_id;
 	key->retprobe_id = e->retprobe_id;
 	key->action = e->action;
-	key->tid = e->tid;
+	switch (ratelimit_scope) {
+	case RATELIMIT_SCOPE_THREAD:
+		key->tid = e->tid;
+		break;
+	case RATELIMIT_SCOPE_PROCESS:
+		key->tid = get_current_pid_tgid() >> 32;
+		break;
+	case RATELIMIT_SCOPE_GLOBAL:
+		key->tid = 0;
+		break;
+	default:
+		return false;
+	}
 
 	// Clean the heap
 	probe_read(key->data, MAX_POSSIBLE_ARGS * KEY_BYTES_PER_ARG, ro_heap);
@@ -1862,13 +2149,18 @@ rate_limit(__u64 ratelimit_interval, struct msg_generic_kprobe *e)
 	last_repeat_entry = map_lookup_elem(&ratelimit_map, key);
 	if (last_repeat_entry) {
 		/* ratelimit_interval is in milliseconds. */
-		if (*last_repeat_entry > curr_time - (ratelimit_interval * 1000000)) {
-			/* This event is too soon after the last matching event. */
-			return true;
+		if (last_repeat_entry->ktime > curr_time - (ratelimit_interval * 1000000)) {
+			/* We already posted ratelimit_count events in this period. */
+			if (last_repeat_entry->count >= ratelimit_count)
+				return true;
+			__sync_fetch_and_add(&last_repeat_entry->count, 1);
+			return false;
 		}
 	}
-	/* As we're acting on this event, update the map with the current time. */
-	map_update_elem(&ratelimit_map, key, &curr_time, 0);
+	/* As we're acting on this event, start a new period at the current time. */
+	value.ktime = curr_time;
+	value.count = 1;
+	map_update_elem(&ratelimit_map, key, &value, 0);
 	return false;
 }
 #endif
@@ -1980,6 +2272,107 @@ do_action_notify_killer(int error, int signal)
 #define do_action_notify_killer(error, signal)
 #endif
 
+#ifdef __LARGE_BPF_PROG
+struct sample_key {
+	__u32 func_id;
+	__u32 pass;
+	__u32 action;
+	__u32 pad;
+};
+
+/* Number of matches of the post actions with a sample rate, per hook and
+ * selector: the offset of the selector in the filter map (pass) and of the
+ * action in the selector identify the action of the hook.
+ */
+struct {
+	__uint(type, BPF_MAP_TYPE_LRU_HASH);
+	__uint(max_entries, 32768);
+	__type(key, struct sample_key);
+	__type(value, __u64);
+} sample_map SEC(".maps");
+
+/* sample_skip returns true if the event is not part of the sample, i.e. it
+ * is not the first of every rate matches of the action.
+ */
+static inline __attribute__((always_inline)) bool
+sample_skip(struct msg_generic_kprobe *e, __u32 action, __u64 rate)
+{
+	struct sample_key key = {
+		.func_id = e->func_id,
+		.pass = e->pass,
+		.action = action,
+	};
+	__u64 *count, zero = 0;
+
+	if (rate <= 1)
+		return false;
+
+	count = map_lookup_elem(&sample_map, &key);
+	if (!count) {
+		map_update_elem(&sample_map, &key, &zero, BPF_NOEXIST);
+		count = map_lookup_elem(&sample_map, &key);
+		if (!count)
+			return false;
+	}
+	return __sync_fetch_and_add(count, 1) % rate != 0;
+}
+#endif /* __LARGE_BPF_PROG */
+
+#ifdef GENERIC_KPROBE
+struct count_key {
+	__u32 func_id;
+	__u32 pid;
+	__u64 ktime;
+};
+
+/* Number of calls of the selectors with the Count action, per kprobe and
+ * process. The values are read by the user space, which reports the counts
+ * periodically.
+ */
+struct {
+	__uint(type, BPF_MAP_TYPE_LRU_PERCPU_HASH);
+	__uint(max_entries, 32768);
+	__type(key, struct count_key);
+	__type(value, __u64);
+} count_map SEC(".maps");
+
+static inline __attribute__((always_inline)) void
+do_action_count(struct msg_generic_kprobe *e)
+{
+	struct count_key key = {
+		.func_id = e->func_id,
+		.pid = e->current.pid,
+		.ktime = e->current.ktime,
+	};
+	__u64 *count, one = 1;
+
+	count = map_lookup_elem(&count_map, &key);
+	if (count)
+		(*count)++;
+	else
+		map_update_elem(&count_map, &key, &one, BPF_ANY);
+
+	/* counted calls are not posted, including their return */
+	retprobe_map_clear(e->func_id, get_current_pid_tgid());
+}
+#else
+#define do_action_count(e)
+#endif
+
+/* do_action_set_tag: sets a tag on the current process, inherited by its
+ * children, for the matchTags selectors.
+ */
+static inline __attribute__((always_inline)) void
+do_action_set_tag(__u32 tag)
+{
+	struct execve_map_value *curr;
+	__u32 pid = get_current_pid_tgid() >> 32;
+
+	curr = execve_map_get_noinit(pid);
+	if (curr)
+		curr->tags |= 1ULL << (tag & (MAX_TAGS - 1));
+}
+
 static inline __attribute__((always_inline)) __u32
 do_action(void *ctx, __u32 i, struct msg_generic_kprobe *e,
 	  struct selector_action *actions, struct bpf_map_def *override_tasks, bool *post)
@@ -1998,9 +2391,12 @@ do_action(void *ctx, __u32 i, struct msg_generic_kprobe *e,
 		*post = false;
 		break;
 	case ACTION_POST: {
+		__u32 post_action __maybe_unused = i;
 		__u64 ratelimit_interval __maybe_unused = actions->act[++i];
+		__u64 ratelimit_count __maybe_unused = actions->act[++i];
+		__u64 ratelimit_scope __maybe_unused = actions->act[++i];
 #ifdef __LARGE_BPF_PROG
-		if (rate_limit(ratelimit_interval, e))
+		if (rate_limit(ratelimit_interval, ratelimit_count, ratelimit_scope, e))
 			*post = false;
 #endif /* __LARGE_BPF_PROG */
 		__u32 stack_trace = actions->act[++i];
@@ -2016,6 +2412,19 @@ do_action(void *ctx, __u32 i, struct msg_generic_kprobe *e,
 			// Here we just signal that there was a collision returning -EEXIST.
 			e->stack_id = get_stackid(ctx, &stack_trace_map, 0);
 		}
+
+		__u32 user_stack_trace = actions->act[++i];
+
+		if (user_stack_trace) {
+			e->common.flags |= MSG_COMMON_FLAG_USER_STACKTRACE;
+			e->user_stack_id = get_stackid(ctx, &stack_trace_map, BPF_F_USER_STACK);
+		}
+
+		__u64 sample __maybe_unused = actions->act[++i];
+#ifdef __LARGE_BPF_PROG
+		if (*post && sample_skip(e, post_action, sample))
+			*post = false;
+#endif /* __LARGE_BPF_PROG */
 		break;
 	}
 
@@ -2033,10 +2442,14 @@ do_action(void *ctx, __u32 i, struct msg_generic_kprobe *e,
 	case ACTION_SIGNAL:
 		signal = actions->act[++i];
 	case ACTION_SIGKILL:
-		do_action_signal(signal);
+		if (e->audit)
+			return do_action_audit(e, action, i);
+		do_action_signal(e, signal);
 		break;
 	case ACTION_OVERRIDE:
 		error = actions->act[++i];
+		if (e->audit)
+			return do_action_audit(e, action, i);
 		id = get_current_pid_tgid();
 
 		if (!override_tasks)
@@ -2051,6 +2464,8 @@ do_action(void *ctx, __u32 i, struct msg_generic_kprobe *e,
 			*error_p = error;
 		else
 			map_update_elem(override_tasks, &id, &error, BPF_ANY);
+		/* the override program of the hook then skips the function */
+		e->action_ktime = ktime_get_ns();
 		break;
 	case ACTION_GETURL:
 	case ACTION_DNSLOOKUP:
@@ -2065,8 +2480,17 @@ do_action(void *ctx, __u32 i, struct msg_generic_kprobe *e,
 	case ACTION_NOTIFY_KILLER:
 		error = actions->act[++i];
 		signal = actions->act[++i];
+		if (e->audit)
+			return do_action_audit(e, action, i);
 		do_action_notify_killer(error, signal);
 		break;
+	case ACTION_COUNT:
+		*post = false;
+		do_action_count(e);
+		break;
+	case ACTION_SET_TAG:
+		do_action_set_tag(actions->act[++i]);
+		break;
 	default:
 		break;
 	}
@@ -2133,6 +2557,7 @@ filter_read_arg(void *ctx, int index, struct bpf_map_def *heap,
 	// otherwise pass==1 indicates using default action.
 	if (pass > 1) {
 		e->pass = pass;
+		e->sel.curr = index;
 		tail_call(ctx, tailcalls, 11);
 	}
 
@@ -2226,7 +2651,7 @@ generic_output(void *ctx, struct bpf_map_def *heap)
 		     :
 		     : [total] "+r"(total)
 		     :);
-	perf_event_output(ctx, &tcpmon_map, BPF_F_CURRENT_CPU, e, total);
+	event_output(ctx, e, total);
 	return 1;
 }
 
@@ -2286,6 +2711,17 @@ read_call_arg(void *ctx, struct msg_generic_kprobe *e, int index, int type,
 		size = copy_path(args, path_arg);
 		break;
 	}
+	case linux_binprm_type: {
+		struct linux_binprm *bprm = (struct linux_binprm *)arg;
+		struct file *file;
+
+		probe_read(&file, sizeof(file), _(&bprm->file));
+		size = copy_path(args, _(&file->f_path));
+		break;
+	}
+	case dentry_type:
+		size = copy_dentry(args, arg);
+		break;
 	case fd_ty: {
 		struct fdinstall_key key = { 0 };
 		struct fdinstall_value *val;
@@ -2327,6 +2763,9 @@ read_call_arg(void *ctx, struct msg_generic_kprobe *e, int index, int type,
 	case string_type:
 		size = copy_strings(args, arg);
 		break;
+	case string_array_type:
+		size = copy_string_array(args, arg);
+		break;
 	case size_type:
 	case s64_ty:
 	case u64_ty:
@@ -2348,6 +2787,12 @@ read_call_arg(void *ctx, struct msg_generic_kprobe *e, int index, int type,
 		// Look up socket in our sock->pid_tgid map
 		update_pid_tid_from_sock(e, arg);
 		break;
+	case socket_type:
+		size = copy_socket(args, arg);
+		break;
+	case sockaddr_type:
+		size = copy_sockaddr(args, arg);
+		break;
 	case cred_type:
 		size = copy_cred(args, arg);
 		break;
@@ -41,6 +41,7 @@ enum {
 	// more socket ops
 	op_filter_family = 28,
 	op_filter_state = 29,
+	op_filter_socktype = 30,
 };
 
 #endif // __OPERATIONS_H__
@@ -14,8 +14,15 @@ struct skb_type {
 	__u32 mark;
 	__u32 secpath_len;
 	__u32 secpath_olen;
+	__u8 icmp_type;
+	__u8 icmp_code;
+	__u8 pad[6];
 };
 
+#ifndef IPPROTO_ICMPV6
+#define IPPROTO_ICMPV6 58
+#endif
+
 /* The IPv6 specification states that the following headers are valid
  * after the fixed header (up to 1 of each, except Destination Options,
  * which is up to 2):
@@ -116,8 +123,8 @@ get_ip6_protocol(u16 *payload_off, struct ipv6hdr *ip, u16 network_header_off,
 
 /* set_event_from_skb(skb)
  *
- * Populate the event args with the SKB 5-tuple when supported. Currently,
- * only supports IPv4 with TCP/UDP.
+ * Populate the event args with the SKB 5-tuple when supported. Ports are set
+ * for TCP/UDP, and the ICMP type and code for ICMP/ICMPv6.
  */
 static inline __attribute__((unused)) int
 set_event_from_skb(struct skb_type *event, struct sk_buff *skb)
@@ -135,6 +142,9 @@ set_event_from_skb(struct skb_type *event, struct sk_buff *skb)
 	probe_read(&iphdr_byte0, 1, _(ip));
 
 	u8 ip_ver = iphdr_byte0 >> 4;
+
+	event->icmp_type = 0;
+	event->icmp_code = 0;
 	if (ip_ver == 4) { // IPv4
 		probe_read(&protocol, 1, _(&ip->protocol));
 		event->tuple.protocol = protocol;
@@ -173,6 +183,16 @@ set_event_from_skb(struct skb_type *event, struct sk_buff *skb)
 			   _(&udp->source));
 		probe_read(&event->tuple.dport, sizeof(event->tuple.dport),
 			   _(&udp->dest));
+	} else if (protocol == IPPROTO_ICMP || protocol == IPPROTO_ICMPV6) {
+		// ICMP and ICMPv6 headers both start with type and code.
+		struct icmphdr *icmp =
+			(struct icmphdr *)(skb_head + l4_off);
+		probe_read(&event->icmp_type, sizeof(event->icmp_type),
+			   _(&icmp->type));
+		probe_read(&event->icmp_code, sizeof(event->icmp_code),
+			   _(&icmp->code));
+		event->tuple.sport = 0;
+		event->tuple.dport = 0;
 	} else {
 		event->tuple.sport = 0;
 		event->tuple.dport = 0;
@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: GPL-2.0
+/* Copyright Authors of Cilium */
+
+#ifndef __SOCKADDR_H__
+#define __SOCKADDR_H__
+
+#include "tuple.h"
+
+struct sockaddr_in_type {
+	__u16 sin_family;
+	__u16 sin_port;
+	__u32 pad;
+	__u64 sin_addr[2];
+};
+
+/* set_event_from_sockaddr(address)
+ *
+ * Populate the event args with the family, the address and the port of an
+ * IPv4 or IPv6 sockaddr. The address and the port are zero for the other
+ * families.
+ */
+static inline __attribute__((unused)) void
+set_event_from_sockaddr(struct sockaddr_in_type *event, struct sockaddr *address)
+{
+	struct sockaddr_in *ipv4 = (struct sockaddr_in *)address;
+	struct sockaddr_in6 *ipv6 = (struct sockaddr_in6 *)address;
+
+	event->sin_family = 0;
+	event->sin_port = 0;
+	event->pad = 0;
+	event->sin_addr[0] = 0;
+	event->sin_addr[1] = 0;
+	probe_read(&event->sin_family, sizeof(event->sin_family),
+		   _(&address->sa_family));
+	switch (event->sin_family) {
+	case AF_INET:
+		probe_read(&event->sin_port, sizeof(event->sin_port), _(&ipv4->sin_port));
+		probe_read(&event->sin_addr, IPV4LEN, _(&ipv4->sin_addr.s_addr));
+		break;
+	case AF_INET6:
+		probe_read(&event->sin_port, sizeof(event->sin_port), _(&ipv6->sin6_port));
+		probe_read(&event->sin_addr, IPV6LEN, _(&ipv6->sin6_addr));
+		break;
+	}
+	event->sin_port = bpf_ntohs(event->sin_port);
+}
+#endif // __SOCKADDR_H__
@@ -789,11 +789,15 @@ func (field *Field) typeName(g *protogen.GeneratedFile) (string, error) {
 		protoreflect.Uint32Kind,
 		protoreflect.Int64Kind,
 		protoreflect.Sint64Kind,
-		protoreflect.Uint64Kind,
-		protoreflect.FloatKind,
-		protoreflect.DoubleKind:
+		protoreflect.Uint64Kind:
 		type_ = kind.String()
 
+	case protoreflect.FloatKind:
+		type_ = "float32"
+
+	case protoreflect.DoubleKind:
+		type_ = "float64"
+
 	case protoreflect.BytesKind:
 		bmatcher := common.BytesMatcherIdent(g, "BytesMatcher")
 		type_ = bmatcher
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package annotate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/cmd/tetra/common"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const examples = `  # Mark an event as malicious, from the exec_id of its process and its time
+  tetra annotate --token-file /etc/tetragon/annotation-token --verdict malicious \
+    --author soar --annotation "host isolated" \
+    a2luZC1jb250cm9sLXBsYW5lOjEzMjY3NzY4ODI3NjQ4OjQ1MjU= 2024-01-02T15:04:05.123456789Z`
+
+var (
+	tokenFile  string
+	verdict    string
+	author     string
+	annotation string
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "annotate <exec-id> <time>",
+		Short:   "Attach a verdict or annotation to an event",
+		Long:    "Attach a verdict or annotation to the event identified by the exec_id of its process and by its time, in RFC 3339 format.",
+		Example: examples,
+		Args:    cobra.ExactArgs(2),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if _, ok := tetragon.EventVerdict_value[verdictEnumName(verdict)]; !ok {
+				return fmt.Errorf("unknown verdict %q", verdict)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			eventTime, err := time.Parse(time.RFC3339Nano, args[1])
+			if err != nil {
+				return fmt.Errorf("invalid event time: %w", err)
+			}
+			token, err := os.ReadFile(tokenFile)
+			if err != nil {
+				return fmt.Errorf("failed to read token file: %w", err)
+			}
+			req := &tetragon.AnnotateEventRequest{
+				Annotation: &tetragon.EventAnnotation{
+					ExecId:     args[0],
+					Time:       timestamppb.New(eventTime),
+					Verdict:    tetragon.EventVerdict(tetragon.EventVerdict_value[verdictEnumName(verdict)]),
+					Annotation: annotation,
+					Author:     author,
+				},
+			}
+			common.CliRun(func(ctx context.Context, cli tetragon.FineGuidanceSensorsClient) {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+strings.TrimSpace(string(token)))
+				if _, err := cli.AnnotateEvent(ctx, req); err != nil {
+					fmt.Printf("failed to annotate event: %s\n", err)
+				}
+			})
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&tokenFile, "token-file", "", "File containing the event annotation token")
+	flags.StringVar(&verdict, "verdict", "unspecified", "Verdict for the event (unspecified, benign, suspicious, malicious)")
+	flags.StringVar(&author, "author", "", "Name of the client posting the annotation")
+	flags.StringVar(&annotation, "annotation", "", "Free-form annotation")
+	cmd.MarkFlagRequired("token-file")
+	return cmd
+}
+
+func verdictEnumName(v string) string {
+	return "EVENT_VERDICT_" + strings.ToUpper(v)
+}
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package capabilities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/cmd/tetra/common"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const examples = `  # Report the capabilities that the workloads used during the agent window
+  tetra capabilities report
+
+  # Report the capabilities that the workloads of a namespace used during the last hour
+  tetra capabilities report --namespace default --window 1h
+
+  # Print the securityContext of the containers of the workloads
+  tetra capabilities report -o securitycontext`
+
+// capabilityName returns the name of a capability as used in the
+// securityContext of Kubernetes containers, i.e., without the CAP_ prefix.
+func capabilityName(c tetragon.CapabilitiesType) string {
+	return strings.TrimPrefix(c.String(), "CAP_")
+}
+
+func workloadName(r *tetragon.CapabilityReport) string {
+	if r.Namespace == "" {
+		return r.Binary
+	}
+	name := r.Namespace + "/" + r.Workload
+	if r.WorkloadKind != "" {
+		name = r.Namespace + "/" + strings.ToLower(r.WorkloadKind) + "/" + r.Workload
+	}
+	if r.Container != "" {
+		name += " container " + r.Container
+	}
+	return name
+}
+
+// securityContext returns the securityContext of the container of a report,
+// that drops all the capabilities except the ones that it used.
+func securityContext(r *tetragon.CapabilityReport) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n", workloadName(r))
+	sb.WriteString("securityContext:\n  capabilities:\n    drop:\n    - ALL\n")
+	if len(r.Capabilities) > 0 {
+		sb.WriteString("    add:\n")
+		for _, c := range r.Capabilities {
+			fmt.Fprintf(&sb, "    - %s\n", capabilityName(c))
+		}
+	}
+	return sb.String()
+}
+
+func New() *cobra.Command {
+	capsCmd := &cobra.Command{
+		Use:   "capabilities",
+		Short: "Report the capabilities that workloads use",
+	}
+
+	var (
+		window     time.Duration
+		namespace  string
+		outputFlag string
+	)
+	reportCmd := &cobra.Command{
+		Use:   "report",
+		Short: "Report the minimal capabilities of each workload, from the capabilities that it used",
+		Long: `Report the capabilities that each workload used, as observed by the
+capability-use policy of the agent (see the --enable-capability-use flag).
+The capabilities of the processes that are not in pods are reported by binary.`,
+		Example: examples,
+		Args:    cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if outputFlag != "json" && outputFlag != "text" && outputFlag != "securitycontext" {
+				return fmt.Errorf("invalid value for %q flag: %s", common.KeyOutput, outputFlag)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := common.NewConnectedClient()
+			defer c.Close()
+
+			req := &tetragon.GetCapabilityReportRequest{Namespace: namespace}
+			if window > 0 {
+				req.Window = durationpb.New(window)
+			}
+			res, err := c.Client.GetCapabilityReport(c.Ctx, req)
+			if err != nil || res == nil {
+				return fmt.Errorf("failed to get capability report: %w", err)
+			}
+
+			switch outputFlag {
+			case "json":
+				b, err := res.MarshalJSON()
+				if err != nil {
+					return fmt.Errorf("failed to generate json: %w", err)
+				}
+				cmd.Println(string(b))
+			case "securitycontext":
+				first := true
+				for _, r := range res.Reports {
+					// only the containers of pods have a securityContext
+					if r.Namespace == "" {
+						continue
+					}
+					if !first {
+						cmd.Println("---")
+					}
+					first = false
+					cmd.Print(securityContext(r))
+				}
+			default:
+				cmd.Printf("capabilities used during the last %s:\n", res.Window.AsDuration())
+				for _, r := range res.Reports {
+					caps := make([]string, 0, len(r.Capabilities))
+					for _, c := range r.Capabilities {
+						caps = append(caps, c.String())
+					}
+					cmd.Printf("%s: %s (last used %s)\n", workloadName(r), strings.Join(caps, ","),
+						r.LastUsed.AsTime().Format(time.RFC3339))
+				}
+			}
+			return nil
+		},
+	}
+	flags := reportCmd.Flags()
+	flags.DurationVar(&window, "window", 0, "Report the capabilities used during the window, up to the window of the agent (default: the window of the agent)")
+	flags.StringVar(&namespace, "namespace", "", "Report only the workloads of a Kubernetes namespace")
+	flags.StringVarP(&outputFlag, common.KeyOutput, "o", "text", "Output format. text, json or securitycontext")
+	capsCmd.AddCommand(reportCmd)
+
+	return capsCmd
+}
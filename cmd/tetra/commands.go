@@ -4,7 +4,12 @@
 package main
 
 import (
+	"github.com/cilium/tetragon/cmd/tetra/annotate"
+	"github.com/cilium/tetragon/cmd/tetra/capabilities"
+	"github.com/cilium/tetragon/cmd/tetra/features"
 	"github.com/cilium/tetragon/cmd/tetra/getevents"
+	"github.com/cilium/tetragon/cmd/tetra/process"
+	"github.com/cilium/tetragon/cmd/tetra/query"
 	"github.com/cilium/tetragon/cmd/tetra/rthooks"
 	"github.com/cilium/tetragon/cmd/tetra/sensors"
 	"github.com/cilium/tetragon/cmd/tetra/stacktracetree"
@@ -14,7 +19,8 @@ import (
 )
 
 // addBaseCommands adds commands that build and make sense on all platform:
-// getevents, version, sensors, stacktracetree, status, rthooks
+// getevents, version, sensors, stacktracetree, status, rthooks, process,
+// annotate, query, capabilities, features
 func addBaseCommands(rootCmd *cobra.Command) {
 	rootCmd.AddCommand(getevents.New())
 	rootCmd.AddCommand(version.New())
@@ -22,6 +28,11 @@ func addBaseCommands(rootCmd *cobra.Command) {
 	rootCmd.AddCommand(stacktracetree.New())
 	rootCmd.AddCommand(status.New())
 	rootCmd.AddCommand(rthooks.New())
+	rootCmd.AddCommand(process.New())
+	rootCmd.AddCommand(annotate.New())
+	rootCmd.AddCommand(query.New())
+	rootCmd.AddCommand(capabilities.New())
+	rootCmd.AddCommand(features.New())
 
 	// bugtool technically builds on darwin and windows but makes no sense since
 	// it's supposed to be run on the machine running Tetragon, using
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package features
+
+import (
+	"fmt"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/cmd/tetra/common"
+	"github.com/spf13/cobra"
+)
+
+const examples = `  # Print the BPF features of the kernel of the agent
+  tetra features
+
+  # Print the features in JSON
+  tetra features -o json`
+
+func New() *cobra.Command {
+	var outputFlag string
+	cmd := &cobra.Command{
+		Use:   "features",
+		Short: "Print the BPF features of the kernel that tracing policies depend on",
+		Long: `Print the BPF features of the kernel that the agent runs on, as probed by
+the agent at startup. Tracing policies that need a feature that the kernel
+does not support fail to load, for example policies with the Override action
+need bpf_override_return.`,
+		Example: examples,
+		Args:    cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if outputFlag != "json" && outputFlag != "text" {
+				return fmt.Errorf("invalid value for %q flag: %s", common.KeyOutput, outputFlag)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := common.NewConnectedClient()
+			defer c.Close()
+
+			res, err := c.Client.GetKernelFeatures(c.Ctx, &tetragon.GetKernelFeaturesRequest{})
+			if err != nil || res == nil {
+				return fmt.Errorf("failed to get kernel features: %w", err)
+			}
+
+			switch outputFlag {
+			case "json":
+				b, err := res.MarshalJSON()
+				if err != nil {
+					return fmt.Errorf("failed to generate json: %w", err)
+				}
+				cmd.Println(string(b))
+			default:
+				cmd.Printf("kernel: %s\n", res.KernelRelease)
+				for _, f := range res.Features {
+					cmd.Printf("%s: %t (%s)\n", f.Name, f.Supported, f.Description)
+					if !f.Supported {
+						cmd.Printf("\trequires: %s\n", f.Requirement)
+					}
+				}
+			}
+			return nil
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&outputFlag, common.KeyOutput, "o", "text", "Output format. text or json")
+	return cmd
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/cilium/tetragon/api/v1/tetragon"
 	"github.com/cilium/tetragon/cmd/tetra/common"
 	"github.com/cilium/tetragon/pkg/encoder"
+	"github.com/cilium/tetragon/pkg/fileutils"
 	"github.com/cilium/tetragon/pkg/logger"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -29,9 +30,18 @@ redirection of events to the stdin. Examples:
   # Connect, print and filter by process events
   %[1]s getevents --process netserver
 
+  # Connect, print and filter by the events of the file-monitoring policy
+  %[1]s getevents --policy-names file-monitoring
+
+  # Connect, print and filter by a CEL expression
+  %[1]s getevents --cel-expression 'process_exec.process.binary == "/usr/bin/curl"'
+
   # Redirect events and filter by namespace from stdin
   cat events.json | %[1]s getevents -o compact --namespace default
 
+  # Print the events of a rotated export file, gzip or zstd compressed
+  cat tetragon-2024-05-01T10-00-00.000.log.gz | %[1]s getevents -o compact
+
   # Exclude parent field
   %[1]s getevents -F parent
 
@@ -50,6 +60,8 @@ type Opts struct {
 	Process       []string // deprecated: use Processes
 	Pods          []string
 	Pod           []string // deprecated: use Pods
+	PolicyNames   []string
+	CelExpression []string
 	Host          bool
 	Timestamps    bool
 	TTYEncode     string
@@ -89,6 +101,12 @@ var GetFilter = func() *tetragon.Filter {
 	if len(Options.Pods) > 0 {
 		filter.PodRegex = Options.Pods
 	}
+	if len(Options.PolicyNames) > 0 {
+		filter.PolicyNames = Options.PolicyNames
+	}
+	if len(Options.CelExpression) > 0 {
+		filter.CelExpression = Options.CelExpression
+	}
 	// Is used to filter on the event types i.e. PROCESS_EXEC, PROCESS_EXIT etc.
 	if len(Options.EventTypes) > 0 {
 		var eventType tetragon.EventType
@@ -183,8 +201,14 @@ func New() *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			fi, _ := os.Stdin.Stat()
 			if fi.Mode()&os.ModeNamedPipe != 0 {
-				// read events from stdin
-				getEvents(context.Background(), newIOReaderClient(os.Stdin, viper.GetBool("debug")))
+				// read events from stdin, which can be a compressed
+				// export file
+				r, err := fileutils.NewDecompressReader(os.Stdin)
+				if err != nil {
+					logger.GetLogger().WithError(err).Fatal("Failed to read events from stdin")
+				}
+				defer r.Close()
+				getEvents(context.Background(), newIOReaderClient(r, viper.GetBool("debug")))
 				return
 			}
 			// connect to server
@@ -211,6 +235,9 @@ func New() *cobra.Command {
 	flags.StringSliceVar(&Options.Pod, "pod", nil, "Get events by pod name regex")
 	flags.MarkDeprecated("pod", "please use --pods instead")
 
+	flags.StringSliceVar(&Options.PolicyNames, "policy-names", nil, "Get events by tracing policy names")
+	flags.StringArrayVar(&Options.CelExpression, "cel-expression", nil, "Get events matching CEL expressions")
+
 	flags.BoolVar(&Options.Host, "host", false, "Get host events")
 	flags.BoolVar(&Options.Timestamps, "timestamps", false, "Include timestamps in compact output")
 	flags.StringVarP(&Options.TTYEncode, "tty-encode", "t", "", "Encode terminal data by file path (all other events will be ignored)")
@@ -92,6 +92,26 @@ func Test_GetEvents_Processes(t *testing.T) {
 	})
 }
 
+func Test_GetEvents_PolicyNames(t *testing.T) {
+	t.Run("FilterAll", func(t *testing.T) {
+		testutils.MockPipedFile(t, testutils.RepoRootPath("testdata/events.json"))
+		cmd := New()
+		cmd.SetArgs([]string{"--policy-names", "file-monitoring"})
+		output := testutils.RedirectStdoutExecuteCmd(t, cmd)
+		assert.Equal(t, 0, bytes.Count(output, []byte("\n")))
+	})
+}
+
+func Test_GetEvents_CelExpression(t *testing.T) {
+	t.Run("FilterNetserver", func(t *testing.T) {
+		testutils.MockPipedFile(t, testutils.RepoRootPath("testdata/events.json"))
+		cmd := New()
+		cmd.SetArgs([]string{"--cel-expression", `process_exec.process.binary.endsWith("netserver")`})
+		output := testutils.RedirectStdoutExecuteCmd(t, cmd)
+		assert.Equal(t, 1, bytes.Count(output, []byte("\n")))
+	})
+}
+
 func Test_GetEvents_FilterFields(t *testing.T) {
 	t.Run("ExcludeParent", func(t *testing.T) {
 		testutils.MockPipedFile(t, testutils.RepoRootPath("testdata/events.json"))
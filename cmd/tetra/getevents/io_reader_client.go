@@ -11,6 +11,7 @@ import (
 	"os"
 
 	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/encoder"
 	"github.com/cilium/tetragon/pkg/filters"
 	hubbleV1 "github.com/cilium/tetragon/pkg/oldhubble/api/v1"
 	hubbleFilters "github.com/cilium/tetragon/pkg/oldhubble/filters"
@@ -71,6 +72,14 @@ func (i *ioReaderClient) DisableTracingPolicy(_ context.Context, _ *tetragon.Dis
 	panic("stub")
 }
 
+func (i *ioReaderClient) UpdateTracingPolicySelectors(_ context.Context, _ *tetragon.UpdateTracingPolicySelectorsRequest, _ ...grpc.CallOption) (*tetragon.UpdateTracingPolicySelectorsResponse, error) {
+	panic("stub")
+}
+
+func (i *ioReaderClient) AddTracingPolicySelectorValues(_ context.Context, _ *tetragon.AddTracingPolicySelectorValuesRequest, _ ...grpc.CallOption) (*tetragon.AddTracingPolicySelectorValuesResponse, error) {
+	panic("stub")
+}
+
 func (i *ioReaderClient) ListTracingPolicies(_ context.Context, _ *tetragon.ListTracingPoliciesRequest, _ ...grpc.CallOption) (*tetragon.ListTracingPoliciesResponse, error) {
 	panic("stub")
 }
@@ -102,7 +111,7 @@ func (i *ioReaderClient) GetVersion(_ context.Context, _ *tetragon.GetVersionReq
 func (i *ioReaderClient) Recv() (*tetragon.GetEventsResponse, error) {
 	for i.scanner.Scan() {
 		var res tetragon.GetEventsResponse
-		line := i.scanner.Bytes()
+		line := encoder.UnwrapCloudEvent(i.scanner.Bytes())
 		err := i.unmarshaller.Unmarshal(line, &res)
 		if err != nil && i.debug {
 			fmt.Fprintf(os.Stderr, "DEBUG: failed unmarshal: %s: %s\n", line, err)
@@ -133,3 +142,23 @@ func (i *ioReaderClient) Recv() (*tetragon.GetEventsResponse, error) {
 func (i *ioReaderClient) RuntimeHook(_ context.Context, _ *tetragon.RuntimeHookRequest, _ ...grpc.CallOption) (*tetragon.RuntimeHookResponse, error) {
 	panic("stub")
 }
+
+func (i *ioReaderClient) GetProcess(_ context.Context, _ *tetragon.GetProcessRequest, _ ...grpc.CallOption) (*tetragon.GetProcessResponse, error) {
+	panic("stub")
+}
+
+func (i *ioReaderClient) GetProcessByExecId(_ context.Context, _ *tetragon.GetProcessByExecIdRequest, _ ...grpc.CallOption) (*tetragon.GetProcessResponse, error) {
+	panic("stub")
+}
+
+func (i *ioReaderClient) AnnotateEvent(_ context.Context, _ *tetragon.AnnotateEventRequest, _ ...grpc.CallOption) (*tetragon.AnnotateEventResponse, error) {
+	panic("stub")
+}
+
+func (i *ioReaderClient) GetCapabilityReport(_ context.Context, _ *tetragon.GetCapabilityReportRequest, _ ...grpc.CallOption) (*tetragon.GetCapabilityReportResponse, error) {
+	panic("stub")
+}
+
+func (i *ioReaderClient) GetKernelFeatures(_ context.Context, _ *tetragon.GetKernelFeaturesRequest, _ ...grpc.CallOption) (*tetragon.GetKernelFeaturesResponse, error) {
+	panic("stub")
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package process
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/cmd/tetra/common"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const examples = `  # Retrieve a process from the agent process cache by exec_id
+  tetra process get --exec-id OjEwMzQ4NDAwMDAwOjE=
+
+  # Retrieve a process by pid and kernel start time
+  tetra process get --pid 1 --start-time 10348400000`
+
+var (
+	execID    string
+	pid       uint32
+	startTime uint64
+)
+
+func getProcess(ctx context.Context, client tetragon.FineGuidanceSensorsClient) {
+	var res *tetragon.GetProcessResponse
+	var err error
+	if execID != "" {
+		res, err = client.GetProcessByExecId(ctx, &tetragon.GetProcessByExecIdRequest{ExecId: execID})
+	} else {
+		res, err = client.GetProcess(ctx, &tetragon.GetProcessRequest{Pid: pid, StartTime: startTime})
+	}
+	if err != nil {
+		fmt.Printf("failed to get process: %s\n", err)
+		return
+	}
+	b, err := protojson.MarshalOptions{Multiline: true}.Marshal(res)
+	if err != nil {
+		fmt.Printf("failed to marshal process: %s\n", err)
+		return
+	}
+	fmt.Println(string(b))
+}
+
+func New() *cobra.Command {
+	processCmd := &cobra.Command{
+		Use:   "process",
+		Short: "Query the agent process cache",
+	}
+
+	getCmd := &cobra.Command{
+		Use:     "get",
+		Short:   "Print a live or recently exited process from the process cache",
+		Example: examples,
+		Args:    cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if execID == "" && !cmd.Flags().Changed("pid") {
+				return fmt.Errorf("one of --exec-id or --pid must be specified")
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			common.CliRun(getProcess)
+		},
+	}
+	flags := getCmd.Flags()
+	flags.StringVar(&execID, "exec-id", "", "exec_id of the process")
+	flags.Uint32Var(&pid, "pid", 0, "pid of the process")
+	flags.Uint64Var(&startTime, "start-time", 0, "kernel start time of the process (nanoseconds since boot)")
+	getCmd.MarkFlagsMutuallyExclusive("exec-id", "pid")
+	processCmd.AddCommand(getCmd)
+
+	return processCmd
+}
@@ -0,0 +1,516 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/api/v1/tetragon/codegen/helpers"
+)
+
+// The expression language of the queries:
+//
+//	expr       := andExpr ("or" andExpr)*
+//	andExpr    := notExpr ("and" notExpr)*
+//	notExpr    := "not" notExpr | "(" expr ")" | comparison
+//	comparison := field op value
+//	op         := "==" | "!=" | "<" | "<=" | ">" | ">=" | "=~" | "!~"
+//
+// Values are either quoted strings or bare words. Times are RFC 3339 times or
+// durations, meaning that long ago.
+
+type valueKind int
+
+const (
+	kindString valueKind = iota
+	kindNumber
+	kindTime
+)
+
+type value struct {
+	s string
+	n uint64
+	t time.Time
+}
+
+// field is a field of the events that can be queried.
+type field struct {
+	kind valueKind
+	get  func(res *tetragon.GetEventsResponse) (value, bool)
+}
+
+func processField(parent bool, kind valueKind, get func(p *tetragon.Process) (value, bool)) field {
+	return field{
+		kind: kind,
+		get: func(res *tetragon.GetEventsResponse) (value, bool) {
+			var p *tetragon.Process
+			if parent {
+				p = helpers.ResponseGetParent(res)
+			} else {
+				p = helpers.ResponseGetProcess(res)
+			}
+			if p == nil {
+				return value{}, false
+			}
+			return get(p)
+		},
+	}
+}
+
+func stringValue(s string) (value, bool) {
+	return value{s: s}, true
+}
+
+func podField(get func(pod *tetragon.Pod) string) field {
+	return processField(false, kindString, func(p *tetragon.Process) (value, bool) {
+		if p.Pod == nil {
+			return value{}, false
+		}
+		return stringValue(get(p.Pod))
+	})
+}
+
+var fields = map[string]field{
+	"time": {kind: kindTime, get: func(res *tetragon.GetEventsResponse) (value, bool) {
+		if res.Time == nil {
+			return value{}, false
+		}
+		return value{t: res.Time.AsTime()}, true
+	}},
+	"type": {kind: kindString, get: func(res *tetragon.GetEventsResponse) (value, bool) {
+		ty, err := helpers.ResponseTypeString(res)
+		if err != nil {
+			return value{}, false
+		}
+		return stringValue(ty)
+	}},
+	"node": {kind: kindString, get: func(res *tetragon.GetEventsResponse) (value, bool) {
+		return stringValue(res.NodeName)
+	}},
+	"policy": {kind: kindString, get: func(res *tetragon.GetEventsResponse) (value, bool) {
+		switch ev := res.Event.(type) {
+		case *tetragon.GetEventsResponse_ProcessKprobe:
+			return stringValue(ev.ProcessKprobe.PolicyName)
+		case *tetragon.GetEventsResponse_ProcessTracepoint:
+			return stringValue(ev.ProcessTracepoint.PolicyName)
+		case *tetragon.GetEventsResponse_ProcessUprobe:
+			return stringValue(ev.ProcessUprobe.PolicyName)
+		case *tetragon.GetEventsResponse_ProcessLsm:
+			return stringValue(ev.ProcessLsm.PolicyName)
+		case *tetragon.GetEventsResponse_ProcessKprobeCount:
+			return stringValue(ev.ProcessKprobeCount.PolicyName)
+		case *tetragon.GetEventsResponse_ProcessPerfEvent:
+			return stringValue(ev.ProcessPerfEvent.PolicyName)
+		}
+		return value{}, false
+	}},
+	"function": {kind: kindString, get: func(res *tetragon.GetEventsResponse) (value, bool) {
+		switch ev := res.Event.(type) {
+		case *tetragon.GetEventsResponse_ProcessKprobe:
+			return stringValue(ev.ProcessKprobe.FunctionName)
+		case *tetragon.GetEventsResponse_ProcessUprobe:
+			return stringValue(ev.ProcessUprobe.Symbol)
+		case *tetragon.GetEventsResponse_ProcessLsm:
+			return stringValue(ev.ProcessLsm.FunctionName)
+		case *tetragon.GetEventsResponse_ProcessKprobeCount:
+			return stringValue(ev.ProcessKprobeCount.FunctionName)
+		}
+		return value{}, false
+	}},
+	"pid": processField(false, kindNumber, func(p *tetragon.Process) (value, bool) {
+		return value{n: uint64(p.Pid.GetValue())}, p.Pid != nil
+	}),
+	"uid": processField(false, kindNumber, func(p *tetragon.Process) (value, bool) {
+		return value{n: uint64(p.Uid.GetValue())}, p.Uid != nil
+	}),
+	"binary": processField(false, kindString, func(p *tetragon.Process) (value, bool) {
+		return stringValue(p.Binary)
+	}),
+	"arguments": processField(false, kindString, func(p *tetragon.Process) (value, bool) {
+		return stringValue(p.Arguments)
+	}),
+	"cwd": processField(false, kindString, func(p *tetragon.Process) (value, bool) {
+		return stringValue(p.Cwd)
+	}),
+	"exec_id": processField(false, kindString, func(p *tetragon.Process) (value, bool) {
+		return stringValue(p.ExecId)
+	}),
+	"parent.pid": processField(true, kindNumber, func(p *tetragon.Process) (value, bool) {
+		return value{n: uint64(p.Pid.GetValue())}, p.Pid != nil
+	}),
+	"parent.binary": processField(true, kindString, func(p *tetragon.Process) (value, bool) {
+		return stringValue(p.Binary)
+	}),
+	"pod": podField(func(pod *tetragon.Pod) string {
+		return pod.Name
+	}),
+	"namespace": podField(func(pod *tetragon.Pod) string {
+		return pod.Namespace
+	}),
+	"workload": podField(func(pod *tetragon.Pod) string {
+		return pod.Workload
+	}),
+	"container": podField(func(pod *tetragon.Pod) string {
+		return pod.GetContainer().GetName()
+	}),
+	"image": podField(func(pod *tetragon.Pod) string {
+		return pod.GetContainer().GetImage().GetName()
+	}),
+}
+
+// FieldNames returns the names of the fields that can be queried.
+func FieldNames() []string {
+	ret := make([]string, 0, len(fields))
+	for name := range fields {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+type node interface {
+	match(res *tetragon.GetEventsResponse) bool
+}
+
+type andNode struct {
+	left, right node
+}
+
+func (n *andNode) match(res *tetragon.GetEventsResponse) bool {
+	return n.left.match(res) && n.right.match(res)
+}
+
+type orNode struct {
+	left, right node
+}
+
+func (n *orNode) match(res *tetragon.GetEventsResponse) bool {
+	return n.left.match(res) || n.right.match(res)
+}
+
+type notNode struct {
+	expr node
+}
+
+func (n *notNode) match(res *tetragon.GetEventsResponse) bool {
+	return !n.expr.match(res)
+}
+
+type cmpNode struct {
+	name  string
+	field field
+	op    string
+	val   value
+	re    *regexp.Regexp
+}
+
+func (n *cmpNode) match(res *tetragon.GetEventsResponse) bool {
+	v, ok := n.field.get(res)
+	if !ok {
+		// events without the field never match
+		return false
+	}
+
+	switch n.op {
+	case "=~":
+		return n.re.MatchString(v.s)
+	case "!~":
+		return !n.re.MatchString(v.s)
+	}
+
+	var c int
+	switch n.field.kind {
+	case kindString:
+		c = strings.Compare(v.s, n.val.s)
+	case kindNumber:
+		switch {
+		case v.n < n.val.n:
+			c = -1
+		case v.n > n.val.n:
+			c = 1
+		}
+	case kindTime:
+		c = v.t.Compare(n.val.t)
+	}
+
+	switch n.op {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return false
+}
+
+// Query is a parsed query expression.
+type Query struct {
+	root node
+}
+
+// Match returns true if the event matches the query.
+func (q *Query) Match(res *tetragon.GetEventsResponse) bool {
+	if q.root == nil {
+		return true
+	}
+	return q.root.match(res)
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+var operators = []string{"==", "!=", "<=", ">=", "=~", "!~", "<", ">"}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_.-:/+*", r)
+}
+
+func tokenize(s string) ([]token, error) {
+	var ret []token
+	for i := 0; i < len(s); {
+		r := rune(s[i])
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			ret = append(ret, token{kind: tokLParen, text: "(", pos: i})
+			i++
+		case r == ')':
+			ret = append(ret, token{kind: tokRParen, text: ")", pos: i})
+			i++
+		case r == '"':
+			end := i + 1
+			for ; end < len(s) && s[end] != '"'; end++ {
+				if s[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(s) {
+				return nil, fmt.Errorf("unterminated string at %d", i)
+			}
+			str, err := strconv.Unquote(s[i : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at %d: %w", i, err)
+			}
+			ret = append(ret, token{kind: tokString, text: str, pos: i})
+			i = end + 1
+		case strings.ContainsRune("=!<>", r):
+			op := ""
+			for _, o := range operators {
+				if strings.HasPrefix(s[i:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("invalid operator at %d", i)
+			}
+			ret = append(ret, token{kind: tokOp, text: op, pos: i})
+			i += len(op)
+		case isWordRune(r):
+			end := i
+			for end < len(s) && isWordRune(rune(s[end])) {
+				end++
+			}
+			ret = append(ret, token{kind: tokWord, text: s[i:end], pos: i})
+			i = end
+		default:
+			return nil, fmt.Errorf("unexpected character %q at %d", r, i)
+		}
+	}
+	return append(ret, token{kind: tokEOF, pos: len(s)}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	now    time.Time
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isKeyword(kw string) bool {
+	t := p.peek()
+	return t.kind == tokWord && strings.EqualFold(t.text, kw)
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (node, error) {
+	if p.isKeyword("not") {
+		p.next()
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{expr: expr}, nil
+	}
+	if p.peek().kind == tokLParen {
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != tokRParen {
+			return nil, fmt.Errorf("expected ')' at %d", t.pos)
+		}
+		return expr, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	t := p.next()
+	if t.kind != tokWord {
+		return nil, fmt.Errorf("expected a field at %d", t.pos)
+	}
+	name := strings.ToLower(t.text)
+	f, ok := fields[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown field %q at %d, should be one of %s", t.text, t.pos, strings.Join(FieldNames(), ", "))
+	}
+
+	op := p.next()
+	if op.kind != tokOp {
+		return nil, fmt.Errorf("expected an operator after %s at %d", name, op.pos)
+	}
+
+	t = p.next()
+	if t.kind != tokWord && t.kind != tokString {
+		return nil, fmt.Errorf("expected a value after %s %s at %d", name, op.text, t.pos)
+	}
+
+	n := &cmpNode{name: name, field: f, op: op.text}
+	if op.text == "=~" || op.text == "!~" {
+		if f.kind != kindString {
+			return nil, fmt.Errorf("operator %s cannot be used with field %s", op.text, name)
+		}
+		re, err := regexp.Compile(t.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression for %s: %w", name, err)
+		}
+		n.re = re
+		return n, nil
+	}
+
+	switch f.kind {
+	case kindString:
+		n.val.s = t.text
+	case kindNumber:
+		v, err := strconv.ParseUint(t.text, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q for %s", t.text, name)
+		}
+		n.val.n = v
+	case kindTime:
+		v, err := parseTime(t.text, p.now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time %q for %s: %w", t.text, name, err)
+		}
+		n.val.t = v
+	}
+	return n, nil
+}
+
+// parseTime parses an RFC 3339 time, or a duration meaning that long before
+// now.
+func parseTime(s string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(strings.TrimPrefix(s, "-"))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("should be an RFC 3339 time or a duration")
+	}
+	return now.Add(-d), nil
+}
+
+// Parse parses a query expression. An empty expression matches all the
+// events. Durations are relative to now.
+func Parse(s string, now time.Time) (*Query, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, now: now}
+	if p.peek().kind == tokEOF {
+		return &Query{}, nil
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at %d", t.text, t.pos)
+	}
+	return &Query{root: root}, nil
+}
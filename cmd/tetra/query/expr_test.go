@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var testNow = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func execEvent(pid uint32, binary string, pod *tetragon.Pod, t time.Time) *tetragon.GetEventsResponse {
+	return &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessExec{ProcessExec: &tetragon.ProcessExec{
+			Process: &tetragon.Process{
+				Pid:    wrapperspb.UInt32(pid),
+				Binary: binary,
+				Pod:    pod,
+			},
+		}},
+		NodeName: "node1",
+		Time:     timestamppb.New(t),
+	}
+}
+
+func TestQueryMatch(t *testing.T) {
+	pod := &tetragon.Pod{Namespace: "default", Name: "xwing"}
+	curl := execEvent(1234, "/usr/bin/curl", pod, testNow.Add(-10*time.Minute))
+	bash := execEvent(42, "/bin/bash", nil, testNow.Add(-2*time.Hour))
+	kprobe := &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessKprobe{ProcessKprobe: &tetragon.ProcessKprobe{
+			Process:      &tetragon.Process{Pid: wrapperspb.UInt32(1234), Binary: "/usr/bin/curl"},
+			FunctionName: "tcp_connect",
+			PolicyName:   "connect",
+		}},
+	}
+
+	tests := []struct {
+		expr    string
+		matches []*tetragon.GetEventsResponse
+	}{
+		{"", []*tetragon.GetEventsResponse{curl, bash, kprobe}},
+		{"pid == 1234", []*tetragon.GetEventsResponse{curl, kprobe}},
+		{"pid > 100 and type == PROCESS_EXEC", []*tetragon.GetEventsResponse{curl}},
+		{"pid==42 or policy == connect", []*tetragon.GetEventsResponse{bash, kprobe}},
+		{"not pid == 42", []*tetragon.GetEventsResponse{curl, kprobe}},
+		{`binary =~ "curl$" and not (function == tcp_connect)`, []*tetragon.GetEventsResponse{curl}},
+		{"binary !~ curl", []*tetragon.GetEventsResponse{bash}},
+		{"pod == xwing and namespace == default", []*tetragon.GetEventsResponse{curl}},
+		// events without the field do not match
+		{"pod != xwing", nil},
+		{"time > 1h", []*tetragon.GetEventsResponse{curl}},
+		{"time < -1h", []*tetragon.GetEventsResponse{bash}},
+		{`time >= "2024-05-01T11:50:00Z" AND node == node1`, []*tetragon.GetEventsResponse{curl}},
+	}
+
+	for _, test := range tests {
+		q, err := Parse(test.expr, testNow)
+		require.NoError(t, err, test.expr)
+		var matches []*tetragon.GetEventsResponse
+		for _, ev := range []*tetragon.GetEventsResponse{curl, bash, kprobe} {
+			if q.Match(ev) {
+				matches = append(matches, ev)
+			}
+		}
+		assert.Equal(t, test.matches, matches, test.expr)
+	}
+}
+
+func TestQueryParseErrors(t *testing.T) {
+	for _, expr := range []string{
+		"pid",
+		"pid ==",
+		"pid == abc",
+		"foo == bar",
+		"pid =~ 12",
+		`binary =~ "("`,
+		"time > yesterday",
+		"(pid == 1",
+		"pid == 1 pid == 2",
+		`binary == "curl`,
+		"pid = 1",
+		"pid == 1 and",
+	} {
+		_, err := Parse(expr, testNow)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestQueryHints(t *testing.T) {
+	q, err := Parse(`time > 1h and time > 2h and time <= 10m and pid == 1234 and pod == "xwing" and (pid == 1 or pod == tie)`, testNow)
+	require.NoError(t, err)
+	h := q.hints()
+	assert.Equal(t, testNow.Add(-time.Hour), h.since)
+	assert.Equal(t, testNow.Add(-10*time.Minute), h.until)
+	assert.Equal(t, [][]byte{[]byte("1234"), []byte(`"xwing"`)}, h.needles)
+
+	// disjunctions give no hints
+	q, err = Parse("time > 1h or pid == 1234", testNow)
+	require.NoError(t, err)
+	assert.Equal(t, hints{}, q.hints())
+}
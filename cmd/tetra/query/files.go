@@ -0,0 +1,342 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package query
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/encoder"
+	"github.com/cilium/tetragon/pkg/exportindex"
+	"github.com/cilium/tetragon/pkg/fileutils"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	formatAuto  = "auto"
+	formatJSON  = "json"
+	formatProto = "proto"
+
+	// maxEventSize is the maximum size of an encoded event
+	maxEventSize = 16 * 1024 * 1024
+	// fileTimeSlack is the time between the events and their export, e.g.
+	// when the events wait in the event cache, for which the time ranges of
+	// the files are extended
+	fileTimeSlack = time.Minute
+)
+
+// exportFile is an export file, or one of its rotated backups.
+type exportFile struct {
+	path    string
+	modTime time.Time
+}
+
+// exportFiles returns the export file and its rotated backups, which are
+// named like name-<time>.ext and may be gzip or zstd compressed, oldest first.
+func exportFiles(name string) ([]exportFile, error) {
+	ext := filepath.Ext(name)
+	prefix := strings.TrimSuffix(name, ext)
+
+	var paths []string
+	patterns := []string{prefix + "-*" + ext}
+	for _, cext := range fileutils.CompressedExts {
+		patterns = append(patterns, prefix+"-*"+ext+cext)
+	}
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, matches...)
+	}
+	if _, err := os.Stat(name); err == nil {
+		paths = append(paths, name)
+	}
+
+	var ret []exportFile
+	for _, p := range paths {
+		fi, err := os.Stat(p)
+		if err != nil || !fi.Mode().IsRegular() {
+			continue
+		}
+		ret = append(ret, exportFile{path: p, modTime: fi.ModTime()})
+	}
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("no export files found for %s", name)
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].modTime.Before(ret[j].modTime)
+	})
+	return ret, nil
+}
+
+// hints are conditions that all the events matching a query satisfy. They are
+// used to skip files, blocks of the index, and undecoded events.
+type hints struct {
+	since, until time.Time
+	// needles must all appear in the JSON encoding of the events
+	needles [][]byte
+	// execIDs and pods are the exec IDs and pod names of the events
+	execIDs, pods []string
+}
+
+// hints returns the hints of the comparisons that the whole query depends on,
+// i.e., the ones of its top-level conjunction.
+func (q *Query) hints() hints {
+	var h hints
+	var walk func(n node)
+	walk = func(n node) {
+		switch n := n.(type) {
+		case *andNode:
+			walk(n.left)
+			walk(n.right)
+		case *cmpNode:
+			switch {
+			case n.name == "time" && (n.op == ">" || n.op == ">="):
+				if n.val.t.After(h.since) {
+					h.since = n.val.t
+				}
+			case n.name == "time" && (n.op == "<" || n.op == "<="):
+				if h.until.IsZero() || n.val.t.Before(h.until) {
+					h.until = n.val.t
+				}
+			case n.name == "pid" && n.op == "==":
+				h.needles = append(h.needles, []byte(strconv.FormatUint(n.val.n, 10)))
+			case (n.name == "pod" || n.name == "exec_id") && n.op == "==":
+				// the encoding of the value, with the quotes
+				if b, err := json.Marshal(n.val.s); err == nil {
+					h.needles = append(h.needles, b)
+				}
+				if n.name == "pod" {
+					h.pods = append(h.pods, n.val.s)
+				} else {
+					h.execIDs = append(h.execIDs, n.val.s)
+				}
+			}
+		}
+	}
+	walk(q.root)
+	return h
+}
+
+// filesInRange returns the files that can contain events in the time range of
+// the hints. A file contains the events written after the previous file was
+// rotated, until it was rotated itself.
+func (h *hints) filesInRange(files []exportFile) []exportFile {
+	var ret []exportFile
+	var start time.Time
+	for _, f := range files {
+		end := f.modTime
+		if !h.until.IsZero() && start.Add(-fileTimeSlack).After(h.until) {
+			break
+		}
+		if h.since.IsZero() || !end.Add(fileTimeSlack).Before(h.since) {
+			ret = append(ret, f)
+		}
+		start = end
+	}
+	return ret
+}
+
+func (h *hints) filter() *exportindex.Filter {
+	return &exportindex.Filter{
+		Since:   h.since,
+		Until:   h.until,
+		ExecIDs: h.execIDs,
+		Pods:    h.pods,
+	}
+}
+
+func (h *hints) matchRaw(line []byte) bool {
+	for _, n := range h.needles {
+		if !bytes.Contains(line, n) {
+			return false
+		}
+	}
+	return true
+}
+
+// scanner scans the events of export files.
+type scanner struct {
+	query  *Query
+	hints  hints
+	format string
+	debug  bool
+	// blocks are the blocks of the index of the files, if any
+	blocks []exportindex.Block
+	// fn is called for each matching event, scanning stops if it returns
+	// an error
+	fn func(res *tetragon.GetEventsResponse) error
+}
+
+// openFile opens an export file, decompressing it if needed.
+func openFile(path string) (io.ReadCloser, error) {
+	return fileutils.OpenDecompress(path)
+}
+
+// skipRanges returns the ranges of the file that the index allows to skip.
+func (s *scanner) skipRanges(path string) []exportindex.Range {
+	if len(s.blocks) == 0 {
+		return nil
+	}
+	f, err := openFile(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	key, err := exportindex.FileKey(f)
+	if err != nil {
+		return nil
+	}
+	return exportindex.SkipRanges(s.blocks, key, s.hints.filter())
+}
+
+func (s *scanner) scanFile(path string) error {
+	skip := s.skipRanges(path)
+	f, err := openFile(path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if len(skip) > 0 {
+		r = &skipReader{r: f, skip: skip}
+	}
+	if err := s.scan(r); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// skipReader reads a file without the bytes of the skipped ranges. The ranges
+// are blocks of whole events, so the remaining events are not cut.
+type skipReader struct {
+	r    io.Reader
+	pos  int64
+	skip []exportindex.Range
+}
+
+func (sr *skipReader) Read(p []byte) (int, error) {
+	for len(sr.skip) > 0 && sr.pos >= sr.skip[0].Start {
+		if end := sr.skip[0].End; sr.pos < end {
+			var err error
+			if seeker, ok := sr.r.(io.Seeker); ok {
+				_, err = seeker.Seek(end, io.SeekStart)
+			} else {
+				_, err = io.CopyN(io.Discard, sr.r, end-sr.pos)
+			}
+			if err != nil {
+				return 0, err
+			}
+			sr.pos = end
+		}
+		sr.skip = sr.skip[1:]
+	}
+	if len(sr.skip) > 0 && int64(len(p)) > sr.skip[0].Start-sr.pos {
+		p = p[:sr.skip[0].Start-sr.pos]
+	}
+	n, err := sr.r.Read(p)
+	sr.pos += int64(n)
+	return n, err
+}
+
+// scan scans the events of r, either JSON events, one per line, or
+// length-delimited protobuf events.
+func (s *scanner) scan(r io.Reader) error {
+	br := bufio.NewReader(r)
+	format := s.format
+	if format == formatAuto {
+		b, err := br.Peek(1)
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		format = formatProto
+		if b[0] == '{' {
+			format = formatJSON
+		}
+	}
+
+	if format == formatJSON {
+		return s.scanJSON(br)
+	}
+	return s.scanProto(br)
+}
+
+func (s *scanner) scanJSON(r io.Reader) error {
+	lines := bufio.NewScanner(r)
+	lines.Buffer(make([]byte, 64*1024), maxEventSize)
+	unmarshaller := protojson.UnmarshalOptions{DiscardUnknown: true}
+	for lines.Scan() {
+		line := lines.Bytes()
+		if !s.hints.matchRaw(line) {
+			continue
+		}
+		var res tetragon.GetEventsResponse
+		if err := unmarshaller.Unmarshal(encoder.UnwrapCloudEvent(line), &res); err != nil {
+			if s.debug {
+				fmt.Fprintf(os.Stderr, "DEBUG: failed unmarshal: %s: %s\n", line, err)
+			}
+			continue
+		}
+		if err := s.match(&res); err != nil {
+			return err
+		}
+	}
+	return lines.Err()
+}
+
+func (s *scanner) scanProto(r *bufio.Reader) error {
+	buf := make([]byte, 0, 64*1024)
+	for {
+		size, err := binary.ReadUvarint(r)
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if size > maxEventSize {
+			return fmt.Errorf("invalid event size %d", size)
+		}
+		if uint64(cap(buf)) < size {
+			buf = make([]byte, size)
+		}
+		buf = buf[:size]
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return err
+		}
+		var res tetragon.GetEventsResponse
+		if err := proto.Unmarshal(buf, &res); err != nil {
+			if s.debug {
+				fmt.Fprintf(os.Stderr, "DEBUG: failed unmarshal: %s\n", err)
+			}
+			continue
+		}
+		if err := s.match(&res); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *scanner) match(res *tetragon.GetEventsResponse) error {
+	if !s.query.Match(res) {
+		return nil
+	}
+	return s.fn(res)
+}
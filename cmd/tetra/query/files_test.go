@@ -0,0 +1,217 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package query
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/encoder"
+	"github.com/cilium/tetragon/pkg/exportindex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func encodeJSON(t *testing.T, events ...*tetragon.GetEventsResponse) []byte {
+	var buf bytes.Buffer
+	enc := encoder.NewProtojsonEncoder(&buf)
+	for _, ev := range events {
+		require.NoError(t, enc.Encode(ev))
+	}
+	return buf.Bytes()
+}
+
+func encodeProto(t *testing.T, events ...*tetragon.GetEventsResponse) []byte {
+	var buf bytes.Buffer
+	for _, ev := range events {
+		b, err := proto.Marshal(ev)
+		require.NoError(t, err)
+		buf.Write(binary.AppendUvarint(nil, uint64(len(b))))
+		buf.Write(b)
+	}
+	return buf.Bytes()
+}
+
+func writeFile(t *testing.T, path string, data []byte, modTime time.Time) {
+	if filepath.Ext(path) == ".gz" {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		_, err := gz.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, gz.Close())
+		data = buf.Bytes()
+	}
+	if filepath.Ext(path) == ".zst" {
+		cmd := exec.Command("zstd", "-c", "-q")
+		cmd.Stdin = bytes.NewReader(data)
+		var err error
+		data, err = cmd.Output()
+		require.NoError(t, err)
+	}
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func TestQueryFiles(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "tetragon.log")
+
+	t0 := testNow.Add(-3 * time.Hour)
+	t1 := testNow.Add(-2 * time.Hour)
+	t2 := testNow.Add(-1 * time.Hour)
+	ev0 := execEvent(1, "/bin/a", nil, t0.Add(-time.Minute))
+	ev1 := execEvent(2, "/bin/b", nil, t1.Add(-20*time.Minute))
+	ev2 := execEvent(3, "/bin/c", nil, t2.Add(-time.Minute))
+	ev3 := execEvent(12, "/bin/d", nil, testNow.Add(-time.Minute))
+
+	writeFile(t, filepath.Join(dir, "tetragon-2024-05-01T09-00-00.000.log.gz"), encodeJSON(t, ev0), t0)
+	writeFile(t, filepath.Join(dir, "tetragon-2024-05-01T10-00-00.000.log"), encodeProto(t, ev1), t1)
+	writeFile(t, filepath.Join(dir, "tetragon-2024-05-01T11-00-00.000.log"), encodeJSON(t, ev2), t2)
+	writeFile(t, name, encodeJSON(t, ev3), testNow)
+	// not an export file
+	writeFile(t, filepath.Join(dir, "other.log"), encodeJSON(t, ev0), testNow)
+
+	files, err := exportFiles(name)
+	require.NoError(t, err)
+	require.Len(t, files, 4)
+	assert.Equal(t, name, files[3].path)
+
+	run := func(expr string) ([]*tetragon.GetEventsResponse, []exportFile) {
+		q, err := Parse(expr, testNow)
+		require.NoError(t, err)
+		var ret []*tetragon.GetEventsResponse
+		s := &scanner{
+			query:  q,
+			hints:  q.hints(),
+			format: formatAuto,
+			fn: func(res *tetragon.GetEventsResponse) error {
+				ret = append(ret, res)
+				return nil
+			},
+		}
+		scanned := s.hints.filesInRange(files)
+		for _, f := range scanned {
+			require.NoError(t, s.scanFile(f.path))
+		}
+		return ret, scanned
+	}
+
+	assertEvents := func(expected, actual []*tetragon.GetEventsResponse) {
+		require.Len(t, actual, len(expected))
+		for i := range expected {
+			assert.True(t, proto.Equal(expected[i], actual[i]), "expected %v, got %v", expected[i], actual[i])
+		}
+	}
+
+	events, scanned := run("")
+	assertEvents([]*tetragon.GetEventsResponse{ev0, ev1, ev2, ev3}, events)
+	assert.Len(t, scanned, 4)
+
+	// the files rotated before the time range are skipped
+	events, scanned = run("time > 90m")
+	assertEvents([]*tetragon.GetEventsResponse{ev2, ev3}, events)
+	assert.Len(t, scanned, 2)
+
+	// and the files started after it
+	events, scanned = run("time > 150m and time < 130m")
+	assertEvents([]*tetragon.GetEventsResponse{ev1}, events)
+	assert.Len(t, scanned, 1)
+
+	// needles skip lines, and do not miss events of protobuf files
+	events, _ = run("pid == 2 or pid == 12")
+	assertEvents([]*tetragon.GetEventsResponse{ev1, ev3}, events)
+	events, _ = run("pid == 2")
+	assertEvents([]*tetragon.GetEventsResponse{ev1}, events)
+
+	_, err = exportFiles(filepath.Join(dir, "missing.log"))
+	assert.Error(t, err)
+}
+
+func TestQueryFilesZstd(t *testing.T) {
+	if _, err := exec.LookPath("zstd"); err != nil {
+		t.Skip("zstd command not found")
+	}
+	dir := t.TempDir()
+	name := filepath.Join(dir, "tetragon.log")
+	t0 := testNow.Add(-time.Hour)
+	ev0 := execEvent(1, "/bin/a", nil, t0.Add(-time.Minute))
+	ev1 := execEvent(2, "/bin/b", nil, testNow.Add(-time.Minute))
+	writeFile(t, filepath.Join(dir, "tetragon-2024-05-01T10-00-00.000.log.zst"), encodeJSON(t, ev0), t0)
+	writeFile(t, name, encodeJSON(t, ev1), testNow)
+
+	files, err := exportFiles(name)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	q, err := Parse("pid == 1", testNow)
+	require.NoError(t, err)
+	var events []*tetragon.GetEventsResponse
+	s := &scanner{
+		query:  q,
+		hints:  q.hints(),
+		format: formatAuto,
+		fn: func(res *tetragon.GetEventsResponse) error {
+			events = append(events, res)
+			return nil
+		},
+	}
+	for _, f := range files {
+		require.NoError(t, s.scanFile(f.path))
+	}
+	require.Len(t, events, 1)
+	assert.True(t, proto.Equal(ev0, events[0]))
+}
+
+func TestQueryIndex(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "tetragon.log")
+	xwing := &tetragon.Pod{Namespace: "default", Name: "xwing"}
+	ev0 := execEvent(1, "/bin/a", xwing, testNow.Add(-3*time.Hour))
+	ev1 := execEvent(2, "/bin/b", xwing, testNow.Add(-2*time.Hour))
+	ev2 := execEvent(3, "/bin/c", xwing, testNow.Add(-time.Hour))
+	data0, data1, data2 := encodeJSON(t, ev0), encodeJSON(t, ev1), encodeJSON(t, ev2)
+	writeFile(t, name+".gz", append(append(append([]byte{}, data0...), data1...), data2...), testNow)
+
+	key, err := exportindex.FileKey(bytes.NewReader(data0))
+	require.NoError(t, err)
+	size0, size1 := int64(len(data0)), int64(len(data1))
+	// the index is not accurate, to check which blocks are skipped: the
+	// second event is not recorded to be in the xwing pod, and the third
+	// event is not covered by the index
+	blocks := []exportindex.Block{
+		{File: key, Offset: 0, Size: size0, Start: ev0.Time.AsTime(), End: ev0.Time.AsTime(), Pods: []string{"xwing"}},
+		{File: key, Offset: size0, Size: size1, Start: ev1.Time.AsTime(), End: ev1.Time.AsTime()},
+	}
+
+	run := func(expr string) []*tetragon.GetEventsResponse {
+		q, err := Parse(expr, testNow)
+		require.NoError(t, err)
+		var ret []*tetragon.GetEventsResponse
+		s := &scanner{
+			query:  q,
+			hints:  q.hints(),
+			format: formatAuto,
+			blocks: blocks,
+			fn: func(res *tetragon.GetEventsResponse) error {
+				ret = append(ret, res)
+				return nil
+			},
+		}
+		require.NoError(t, s.scanFile(name+".gz"))
+		return ret
+	}
+
+	assert.Len(t, run(""), 3)
+	assert.Len(t, run("pod == xwing"), 2)
+	events := run("pod == xwing and time > 150m")
+	require.Len(t, events, 1)
+	assert.True(t, proto.Equal(ev2, events[0]))
+}
@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package query
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/cmd/tetra/common"
+	"github.com/cilium/tetragon/pkg/encoder"
+	"github.com/cilium/tetragon/pkg/exportindex"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// DocLong documents the command with some examples
+const DocLong = `This command prints the events of the export files of the node that match an
+expression. It scans the export file and its rotated backups, gzip or zstd
+compressed or not, and JSON or protobuf encoded. Decompressing zstd requires
+the zstd command. Examples:
+
+  # Print the events of a process of the last hour
+  %[1]s query 'pid == 1234 and time > 1h'
+
+  # Print the exec events of curl in the default namespace
+  %[1]s query -o compact 'type == PROCESS_EXEC and namespace == default and binary =~ "curl$"'
+
+  # Print the events of a time range from a custom export file
+  %[1]s query --file /var/log/tetragon.log 'time >= "2024-05-01T10:00:00Z" and time < "2024-05-01T11:00:00Z"'
+
+Expressions combine comparisons of fields with and, or, not and parentheses.
+The operators are ==, !=, <, <=, >, >=, and the regular expression operators
+=~ and !~. Times are RFC 3339 times, or durations meaning that long ago.
+The fields are: %[2]s.
+
+The conditions on time, pid, pod and exec_id that the whole expression depends
+on are used to skip files and events without decoding them. When the export
+file has an index, written by the agent with --export-index, it is used to skip
+the parts of the files without matching events.`
+
+type Opts struct {
+	File       string
+	Format     string
+	Output     string
+	Color      string
+	Timestamps bool
+	Limit      int
+	Index      bool
+}
+
+var Options Opts
+
+var errLimit = errors.New("limit reached")
+
+func query(expr string) error {
+	q, err := Parse(expr, time.Now())
+	if err != nil {
+		return fmt.Errorf("invalid expression: %w", err)
+	}
+	files, err := exportFiles(Options.File)
+	if err != nil {
+		return err
+	}
+
+	var eventEncoder encoder.EventEncoder
+	if Options.Output == "compact" {
+		eventEncoder = encoder.NewCompactEncoder(os.Stdout, encoder.ColorMode(Options.Color), Options.Timestamps, true)
+	} else {
+		eventEncoder = encoder.NewProtojsonEncoder(os.Stdout)
+	}
+
+	count := 0
+	s := &scanner{
+		query:  q,
+		hints:  q.hints(),
+		format: Options.Format,
+		debug:  viper.GetBool(common.KeyDebug),
+		fn: func(res *tetragon.GetEventsResponse) error {
+			if err := eventEncoder.Encode(res); err != nil {
+				logger.GetLogger().WithError(err).WithField("event", res).Debug("Failed to encode event")
+				return nil
+			}
+			count++
+			if Options.Limit > 0 && count >= Options.Limit {
+				return errLimit
+			}
+			return nil
+		},
+	}
+	if Options.Index {
+		blocks, err := exportindex.Read(exportindex.Path(Options.File))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			logger.GetLogger().WithError(err).Warn("Failed to read the index, scanning the export files")
+		}
+		s.blocks = blocks
+	}
+	for _, f := range s.hints.filesInRange(files) {
+		if err := s.scanFile(f.path); err != nil {
+			if errors.Is(err, errLimit) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func New() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "query [expression]",
+		Short: "Query the events of the export files",
+		Long:  fmt.Sprintf(DocLong, "tetra", strings.Join(FieldNames(), ", ")),
+		Args:  cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if Options.Output != "json" && Options.Output != "compact" {
+				return fmt.Errorf("invalid value for %q flag: %s", common.KeyOutput, Options.Output)
+			}
+			if Options.Color != "auto" && Options.Color != "always" && Options.Color != "never" {
+				return fmt.Errorf("invalid value for %q flag: %s", common.KeyColor, Options.Color)
+			}
+			if Options.Format != formatAuto && Options.Format != formatJSON && Options.Format != formatProto {
+				return fmt.Errorf("invalid value for %q flag: %s", "format", Options.Format)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			expr := ""
+			if len(args) > 0 {
+				expr = args[0]
+			}
+			return query(expr)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&Options.File, "file", "/var/run/cilium/tetragon/tetragon.log", "Export file, its rotated backups are also scanned")
+	flags.StringVar(&Options.Format, "format", formatAuto, "Encoding of the export files. auto, json, or proto")
+	flags.StringVarP(&Options.Output, common.KeyOutput, "o", "json", "Output format. json or compact")
+	flags.StringVar(&Options.Color, common.KeyColor, "auto", "Colorize compact output. auto, always, or never")
+	flags.BoolVar(&Options.Timestamps, "timestamps", false, "Include timestamps in compact output")
+	flags.IntVar(&Options.Limit, "limit", 0, "Maximum number of events to print, 0 for no limit")
+	flags.BoolVar(&Options.Index, "index", true, "Use the index of the export files, if it exists")
+	return &cmd
+}
@@ -6,6 +6,8 @@ package sensors
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/cilium/tetragon/api/v1/tetragon"
 	"github.com/cilium/tetragon/cmd/tetra/common"
@@ -88,10 +90,36 @@ func listSensors(ctx context.Context, client tetragon.FineGuidanceSensorsClient)
 		} else {
 			enabled = "(not enabled)"
 		}
-		fmt.Printf("%s %s %s\n", sensor.Name, enabled, sensor.Collection)
+		fmt.Printf("%s %s %s%s%s\n", sensor.Name, enabled, sensor.Collection, attachModes(sensor.AttachModes), deferredHooks(sensor.DeferredHooks))
 	}
 }
 
+// attachModes returns the attach modes of a sensor as " [fn=mode ...]", or
+// an empty string if the sensor has none.
+func attachModes(modes map[string]string) string {
+	if len(modes) == 0 {
+		return ""
+	}
+	fns := make([]string, 0, len(modes))
+	for fn := range modes {
+		fns = append(fns, fn)
+	}
+	sort.Strings(fns)
+	for i, fn := range fns {
+		fns[i] = fn + "=" + modes[fn]
+	}
+	return " [" + strings.Join(fns, " ") + "]"
+}
+
+// deferredHooks returns the deferred hooks of a sensor as
+// " deferred:[fn=state ...]", or an empty string if the sensor has none.
+func deferredHooks(hooks map[string]string) string {
+	if len(hooks) == 0 {
+		return ""
+	}
+	return " deferred:" + strings.TrimPrefix(attachModes(hooks), " ")
+}
+
 func removeSensor(ctx context.Context, client tetragon.FineGuidanceSensorsClient, sensor string) {
 	_, err := client.RemoveSensor(ctx, &tetragon.RemoveSensorRequest{
 		Name: sensor,
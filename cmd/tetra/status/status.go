@@ -18,7 +18,13 @@ func getStatus(ctx context.Context, client tetragon.FineGuidanceSensorsClient) {
 		fmt.Printf("status error: %s\n", err)
 		return
 	}
-	fmt.Printf("Health Status: %s\n", response.GetHealthStatus()[0].Details)
+	for i, hs := range response.GetHealthStatus() {
+		if i == 0 {
+			fmt.Printf("Health Status: %s\n", hs.Details)
+			continue
+		}
+		fmt.Printf("%s: %s (%s)\n", hs.Event, hs.Status, hs.Details)
+	}
 }
 
 func New() *cobra.Command {
@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package tracingpolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	ec "github.com/cilium/tetragon/api/v1/tetragon/codegen/eventchecker"
+	"github.com/cilium/tetragon/api/v1/tetragon/codegen/helpers"
+	"github.com/cilium/tetragon/cmd/tetra/common"
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"github.com/cilium/tetragon/pkg/reader/namespace"
+	"github.com/cilium/tetragon/pkg/tracingpolicy"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// policyTestStreamDelay is the time given to the agent to start
+	// streaming the events before the trigger command is run.
+	policyTestStreamDelay = time.Second
+	// policyTestDefaultTimeout is the timeout of the tests that do not set
+	// one.
+	policyTestDefaultTimeout = 10 * time.Second
+)
+
+func newTestCommand() *cobra.Command {
+	var testName string
+
+	cmd := &cobra.Command{
+		Use:   "test <yaml_file>",
+		Short: "run the tests of a tracing policy",
+		Long: `Run the tests of a tracing policy.
+
+The policy is validated and added to the agent. Then, for each test of the
+tests block of the policy, the trigger command is run and the events of the
+trigger command and of its children are checked against the expected events
+of the test. The policy is deleted once the tests are done. The command fails
+if a test fails.
+
+The events are filtered by pid, so the command must run in the pid namespace
+of the agent, e.g. on the host of the agent.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read yaml file %s: %w", args[0], err)
+			}
+			tp, report := tracingpolicy.Validate(args[0], data)
+			if tp == nil {
+				printValidationReport(cmd, report)
+				return errors.New("invalid tracing policy")
+			}
+
+			var tests []v1alpha1.PolicyTestSpec
+			for _, test := range tp.TpSpec().Tests {
+				if testName == "" || test.Name == testName {
+					tests = append(tests, test)
+				}
+			}
+			if len(tests) == 0 {
+				return fmt.Errorf("tracing policy %q has no tests to run", tp.TpName())
+			}
+
+			c := common.NewConnectedClient()
+			defer c.Close()
+
+			_, err = c.Client.AddTracingPolicy(c.Ctx, &tetragon.AddTracingPolicyRequest{
+				Yaml: string(data),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to add tracing policy: %w", err)
+			}
+			defer func() {
+				_, err := c.Client.DeleteTracingPolicy(context.Background(), &tetragon.DeleteTracingPolicyRequest{
+					Name: tp.TpName(),
+				})
+				if err != nil {
+					cmd.PrintErrf("failed to delete tracing policy %q: %s\n", tp.TpName(), err)
+				}
+			}()
+
+			failed := 0
+			for i := range tests {
+				if err := runPolicyTest(c.Ctx, c.Client, &tests[i]); err != nil {
+					cmd.Printf("FAIL %s: %s\n", tests[i].Name, err)
+					failed++
+					continue
+				}
+				cmd.Printf("PASS %s\n", tests[i].Name)
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d/%d tests failed", failed, len(tests))
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&testName, "run", "", "Only run the test with this name")
+	return cmd
+}
+
+// runPolicyTest runs the trigger command of a test and checks the events of
+// the agent against the expected events of the test.
+func runPolicyTest(ctx context.Context, client tetragon.FineGuidanceSensorsClient, test *v1alpha1.PolicyTestSpec) error {
+	checker, err := tracingpolicy.PolicyTestChecker(test)
+	if err != nil {
+		return err
+	}
+
+	timeout := time.Duration(test.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = policyTestDefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, policyTestStreamDelay+timeout)
+	defer cancel()
+
+	// Only the events of the trigger command, and of its children, are
+	// checked. The trigger is a child of this process, so the pid_set
+	// filter of the agent allows the events of this process and of its
+	// children, and the events of this process are dropped below.
+	pid := namespace.GetMyPidG()
+	stream, err := client.GetEvents(ctx, &tetragon.GetEventsRequest{
+		AllowList: []*tetragon.Filter{{PidSet: []uint32{pid}}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get events: %w", err)
+	}
+	time.Sleep(policyTestStreamDelay)
+
+	// The exit status of the trigger command is ignored, the policy may
+	// for example kill it. The command is killed when the test ends.
+	trigger := exec.CommandContext(ctx, test.Trigger[0], test.Trigger[1:]...)
+	if err := trigger.Start(); err != nil {
+		return fmt.Errorf("failed to start trigger command: %w", err)
+	}
+	go trigger.Wait()
+
+	log := logrus.New()
+	log.SetLevel(logrus.WarnLevel)
+	for {
+		res, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return checker.FinalCheck(log)
+			}
+			return fmt.Errorf("failed to receive events: %w", err)
+		}
+		if helpers.ResponseGetProcess(res).GetPid().GetValue() == pid {
+			continue
+		}
+		if done, err := ec.NextResponseCheck(checker, res, log); done {
+			return err
+		}
+	}
+}
@@ -4,14 +4,26 @@
 package tracingpolicy
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/cilium/tetragon/api/v1/tetragon"
 	"github.com/cilium/tetragon/cmd/tetra/common"
 	"github.com/cilium/tetragon/cmd/tetra/tracingpolicy/generate"
+	"github.com/cilium/tetragon/pkg/defaults"
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"github.com/cilium/tetragon/pkg/option"
+	"github.com/cilium/tetragon/pkg/tracingpolicy"
+	"github.com/cilium/tetragon/pkg/tracingpolicy/dryrun"
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"sigs.k8s.io/yaml"
 )
 
 func New() *cobra.Command {
@@ -110,6 +122,76 @@ func New() *cobra.Command {
 		},
 	}
 
+	tpUpdateSelectorsCmd := &cobra.Command{
+		Use:   "update-selectors <yaml_file>",
+		Short: "update the selectors of a tracing policy",
+		Long: `Update the selectors of an enabled tracing policy in place, without reloading its BPF programs, so that no event is lost.
+The policy of the file must only differ from the loaded policy of the same name by the selectors of its kprobes and tracepoints.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := common.NewConnectedClient()
+			defer c.Close()
+
+			yamlb, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read yaml file %s: %w", args[0], err)
+			}
+
+			_, err = c.Client.UpdateTracingPolicySelectors(c.Ctx, &tetragon.UpdateTracingPolicySelectorsRequest{
+				Yaml: string(yamlb),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update the selectors of tracing policy: %w", err)
+			}
+			cmd.Printf("selectors of tracing policy %q updated\n", args[0])
+
+			return nil
+		},
+	}
+
+	var tpAddValuesKprobeFlag, tpAddValuesTracepointFlag, tpAddValuesSelectorFlag int
+	var tpAddValuesArgFlag uint32
+	var tpAddValuesTTLFlag time.Duration
+	tpAddValuesCmd := &cobra.Command{
+		Use:   "add-values <name> <value>...",
+		Short: "add values to a selector of a tracing policy",
+		Long: `Add values to a matchArgs filter of a selector of an enabled tracing policy in place, without reloading its BPF programs.
+The values are removed once their TTL expires, or when the policy is disabled or deleted.`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := common.NewConnectedClient()
+			defer c.Close()
+
+			req := &tetragon.AddTracingPolicySelectorValuesRequest{
+				Name:     args[0],
+				Kprobe:   uint32(tpAddValuesKprobeFlag),
+				Selector: uint32(tpAddValuesSelectorFlag),
+				Arg:      tpAddValuesArgFlag,
+				Values:   args[1:],
+			}
+			if cmd.Flags().Changed("tracepoint") {
+				req.Tracepoint = true
+				req.Kprobe = uint32(tpAddValuesTracepointFlag)
+			}
+			if tpAddValuesTTLFlag > 0 {
+				req.Ttl = durationpb.New(tpAddValuesTTLFlag)
+			}
+			if _, err := c.Client.AddTracingPolicySelectorValues(c.Ctx, req); err != nil {
+				return fmt.Errorf("failed to add values to tracing policy: %w", err)
+			}
+			cmd.Printf("values added to tracing policy %q\n", args[0])
+
+			return nil
+		},
+	}
+	tpAddValuesFlags := tpAddValuesCmd.Flags()
+	tpAddValuesFlags.IntVar(&tpAddValuesKprobeFlag, "kprobe", 0, "Index of the kprobe in the kprobes of the policy")
+	tpAddValuesFlags.IntVar(&tpAddValuesTracepointFlag, "tracepoint", 0, "Index of the tracepoint in the tracepoints of the policy, to add the values to a tracepoint instead of a kprobe")
+	tpAddValuesFlags.IntVar(&tpAddValuesSelectorFlag, "selector", 0, "Index of the selector in the selectors of the kprobe or tracepoint")
+	tpAddValuesFlags.Uint32Var(&tpAddValuesArgFlag, "arg", 0, "Argument index of the matchArgs filter of the selector")
+	tpAddValuesFlags.DurationVar(&tpAddValuesTTLFlag, "ttl", 0, "Time after which the values are removed. Kept until the policy is disabled if not set")
+	tpAddValuesCmd.MarkFlagsMutuallyExclusive("kprobe", "tracepoint")
+
 	var tpListOutputFlag string
 	tpListCmd := &cobra.Command{
 		Use:   "list",
@@ -167,13 +249,26 @@ func New() *cobra.Command {
 						}
 					}
 
-					cmd.Printf("[%d] %s enabled:%t filterID:%d namespace:%s sensors:%s\n", pol.Id, pol.Name, pol.Enabled, pol.FilterId, namespace, sensors)
+					cmd.Printf("[%d] %s enabled:%t filterID:%d namespace:%s sensors:%s state:%s events:%d\n",
+						pol.Id, pol.Name, pol.Enabled, pol.FilterId, namespace, sensors, policyState(pol.State), pol.Events)
 					if pol.Info != "" {
 						cmd.Printf("\tinfo: %s\n", pol.Info)
 					}
 					if pol.Error != "" && pol.Error != "<nil>" {
 						cmd.Printf("\terror: %s\n", pol.Error)
 					}
+					hooks := make([]string, 0, len(pol.FailedHooks))
+					for hook := range pol.FailedHooks {
+						hooks = append(hooks, hook)
+					}
+					sort.Strings(hooks)
+					for _, hook := range hooks {
+						cmd.Printf("\tfailed hook %s: %s\n", hook, pol.FailedHooks[hook])
+					}
+					for _, s := range pol.SensorStatus {
+						cmd.Printf("\tsensor %s: attach:%s programs:%d maps:%d\n",
+							s.Name, attachModes(s.AttachModes), len(s.Programs), len(s.Maps))
+					}
 				}
 			}
 
@@ -183,14 +278,195 @@ func New() *cobra.Command {
 	tpListFlags := tpListCmd.Flags()
 	tpListFlags.StringVarP(&tpListOutputFlag, common.KeyOutput, "o", "text", "Output format. text or json")
 
+	tpDiffCmd := &cobra.Command{
+		Use:   "diff <yaml_file>",
+		Short: "show the changes of a tracing policy compared to the loaded one",
+		Long: `Compare a tracing policy file with the version of the policy loaded in the agent.
+
+The hooks added or removed and the changes of arguments, selectors and actions
+of the hooks are shown, so that they can be reviewed before applying the policy.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			newTp, err := tracingpolicy.FromFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to parse tracing policy %s: %w", args[0], err)
+			}
+			var namespace string
+			if tpNs, ok := newTp.(tracingpolicy.TracingPolicyNamespaced); ok {
+				namespace = tpNs.TpNamespace()
+			}
+
+			c := common.NewConnectedClient()
+			defer c.Close()
+
+			res, err := c.Client.ListTracingPolicies(c.Ctx, &tetragon.ListTracingPoliciesRequest{})
+			if err != nil || res == nil {
+				return fmt.Errorf("failed to list tracing policies: %w", err)
+			}
+
+			oldSpec := &v1alpha1.TracingPolicySpec{}
+			var loaded bool
+			for _, pol := range res.Policies {
+				if pol.Name != newTp.TpName() || pol.Namespace != namespace {
+					continue
+				}
+				if pol.SpecYaml == "" {
+					return fmt.Errorf("the agent does not report the spec of tracing policy %q", pol.Name)
+				}
+				if err := yaml.UnmarshalStrict([]byte(pol.SpecYaml), oldSpec); err != nil {
+					return fmt.Errorf("failed to parse the spec of loaded tracing policy %q: %w", pol.Name, err)
+				}
+				loaded = true
+				break
+			}
+
+			if !loaded {
+				cmd.Printf("tracing policy %q is not loaded\n", newTp.TpName())
+			}
+			changes := tracingpolicy.DiffSpecs(oldSpec, newTp.TpSpec())
+			if len(changes) == 0 {
+				cmd.Printf("tracing policy %q has no changes\n", newTp.TpName())
+				return nil
+			}
+			for _, change := range changes {
+				cmd.Println(change.String())
+			}
+			return nil
+		},
+	}
+
+	var tpValidateOutputFlag string
+	var tpValidateKernelFlag bool
+	var tpValidateBTFFlag string
+	var tpValidateBPFLibFlag string
+	tpValidateCmd := &cobra.Command{
+		Use:   "validate <yaml_file>...",
+		Short: "validate tracing policies without loading them",
+		Long: `Validate tracing policies without loading them.
+
+The errors and warnings of the policies are reported along with a description
+of their hooks. With -o json, the result is a stable JSON document that can be
+used by automation. The command fails if a policy is invalid.
+
+With --kernel, the policies are also compiled against the running kernel, as
+the agent does with --tracing-policy-dry-run: their selectors are compiled,
+their argument types are checked against BTF, and their hooks are resolved
+against the kernel symbols, without loading or attaching anything. Run it as
+root on the target node for the kernel feature checks to be accurate.`,
+		Args: cobra.MinimumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if tpValidateOutputFlag != "json" && tpValidateOutputFlag != "text" {
+				return fmt.Errorf("invalid value for %q flag: %s", common.KeyOutput, tpValidateOutputFlag)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			validate := tracingpolicy.ValidateFile
+			if tpValidateKernelFlag {
+				if option.Config.ProcFS == "" {
+					option.Config.ProcFS = "/proc/"
+				}
+				option.Config.HubbleLib = tpValidateBPFLibFlag
+				option.Config.BTF = tpValidateBTFFlag
+				compiler, err := dryrun.NewCompiler(tpValidateBPFLibFlag, tpValidateBTFFlag)
+				if err != nil {
+					return fmt.Errorf("failed to initialize the policy compiler: %w", err)
+				}
+				validate = compiler.ValidateFile
+			}
+
+			result := tracingpolicy.NewValidationResult()
+			for _, file := range args {
+				_, report := validate(file)
+				result.Add(report)
+			}
+
+			if tpValidateOutputFlag == "json" {
+				b, err := json.MarshalIndent(result, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to generate json: %w", err)
+				}
+				cmd.Println(string(b))
+			} else {
+				for _, report := range result.Policies {
+					printValidationReport(cmd, report)
+				}
+			}
+
+			if !result.Valid {
+				return errors.New("invalid tracing policies")
+			}
+			return nil
+		},
+	}
+	tpValidateFlags := tpValidateCmd.Flags()
+	tpValidateFlags.StringVarP(&tpValidateOutputFlag, common.KeyOutput, "o", "text", "Output format. text or json")
+	tpValidateFlags.BoolVar(&tpValidateKernelFlag, "kernel", false, "Compile the policies against the running kernel")
+	tpValidateFlags.StringVar(&tpValidateBTFFlag, "btf", "", "Location of the BTF of the kernel, with --kernel. Discovered if not set")
+	tpValidateFlags.StringVar(&tpValidateBPFLibFlag, "bpf-lib", defaults.DefaultTetragonLib, "Location of the Tetragon libs (BPF objects and BTF files), with --kernel")
+
 	tpCmd.AddCommand(
 		tpAddCmd,
 		tpDelCmd,
 		tpEnableCmd,
 		tpDisableCmd,
+		tpUpdateSelectorsCmd,
+		tpAddValuesCmd,
 		tpListCmd,
+		tpDiffCmd,
+		tpValidateCmd,
+		newTestCommand(),
 		generate.New(),
 	)
 
 	return tpCmd
 }
+
+func printValidationReport(cmd *cobra.Command, report *tracingpolicy.ValidationReport) {
+	status := "valid"
+	if !report.Valid {
+		status = "invalid"
+	}
+	cmd.Printf("%s: %s %s\n", report.File, report.Policy, status)
+	msg := func(kind string, m tracingpolicy.ValidationMessage) {
+		if m.Hook != "" {
+			cmd.Printf("\t%s: %s: %s\n", kind, m.Hook, m.Message)
+		} else {
+			cmd.Printf("\t%s: %s\n", kind, m.Message)
+		}
+	}
+	for _, m := range report.Errors {
+		msg("error", m)
+	}
+	for _, m := range report.Warnings {
+		msg("warning", m)
+	}
+	for _, h := range report.Hooks {
+		if h.Target != "" {
+			cmd.Printf("\thook: %s target:%s resolved:%t args:%d selectors:%d enforcing:%t\n", h.Hook, h.Target, h.Resolved, h.Args, h.Selectors, h.Enforcing)
+		} else {
+			cmd.Printf("\thook: %s args:%d selectors:%d enforcing:%t\n", h.Hook, h.Args, h.Selectors, h.Enforcing)
+		}
+	}
+}
+
+// policyState returns the state of a policy without the enum prefix, e.g.,
+// enabled for TP_STATE_ENABLED.
+func policyState(state tetragon.TracingPolicyState) string {
+	return strings.ToLower(strings.TrimPrefix(state.String(), "TP_STATE_"))
+}
+
+// attachModes returns the distinct attach modes of the hooks of a sensor.
+func attachModes(modes map[string]string) string {
+	var ret []string
+	for _, m := range modes {
+		if !slices.Contains(ret, m) {
+			ret = append(ret, m)
+		}
+	}
+	if len(ret) == 0 {
+		return "-"
+	}
+	sort.Strings(ret)
+	return strings.Join(ret, ",")
+}
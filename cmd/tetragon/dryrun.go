@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"path/filepath"
+
+	"github.com/cilium/tetragon/pkg/option"
+	"github.com/cilium/tetragon/pkg/tracingpolicy"
+	"github.com/cilium/tetragon/pkg/tracingpolicy/dryrun"
+)
+
+// dryRunTracingPolicies validates the tracing policies of the
+// --tracing-policy and --tracing-policy-dir flags without loading them, and
+// writes the result as JSON to w. On top of the validation of the policy
+// specs, the sensors of the policies are built and their hooks are resolved
+// against the running kernel.
+func dryRunTracingPolicies(w io.Writer) error {
+	compiler, err := dryrun.NewCompiler(option.Config.HubbleLib, option.Config.BTF)
+	if err != nil {
+		return err
+	}
+
+	files, err := tpFilesFromDir(option.Config.TracingPolicyDir)
+	if err != nil {
+		return err
+	}
+	if option.Config.TracingPolicy != "" {
+		file, err := filepath.Abs(filepath.Clean(option.Config.TracingPolicy))
+		if err != nil {
+			return err
+		}
+		files = append(files, file)
+	}
+
+	result := tracingpolicy.NewValidationResult()
+	for _, file := range files {
+		_, report := compiler.ValidateFile(file)
+		result.Add(report)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(result); err != nil {
+		return err
+	}
+	if !result.Valid {
+		return errors.New("invalid tracing policies")
+	}
+	return nil
+}
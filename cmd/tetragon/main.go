@@ -4,9 +4,10 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
-	"io/fs"
-	"net"
+	"io"
 	"net/http"
 	pprofhttp "net/http/pprof"
 	"os"
@@ -20,31 +21,49 @@ import (
 	"time"
 
 	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/authfiles"
 	"github.com/cilium/tetragon/pkg/bpf"
 	"github.com/cilium/tetragon/pkg/btf"
 	"github.com/cilium/tetragon/pkg/bugtool"
+	"github.com/cilium/tetragon/pkg/capabilityuse"
 	"github.com/cilium/tetragon/pkg/checkprocfs"
 	"github.com/cilium/tetragon/pkg/cilium"
+	"github.com/cilium/tetragon/pkg/configsnapshot"
 	"github.com/cilium/tetragon/pkg/defaults"
 	"github.com/cilium/tetragon/pkg/encoder"
+	"github.com/cilium/tetragon/pkg/eventforward"
 	"github.com/cilium/tetragon/pkg/exporter"
+	"github.com/cilium/tetragon/pkg/exporter/fluent"
+	"github.com/cilium/tetragon/pkg/exporter/otlp"
+	"github.com/cilium/tetragon/pkg/exportindex"
+	"github.com/cilium/tetragon/pkg/features"
 	"github.com/cilium/tetragon/pkg/fileutils"
 	"github.com/cilium/tetragon/pkg/filters"
 	tetragonGrpc "github.com/cilium/tetragon/pkg/grpc"
 	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/lostevents"
+	"github.com/cilium/tetragon/pkg/mapfill"
+	"github.com/cilium/tetragon/pkg/memlimit"
 	"github.com/cilium/tetragon/pkg/metrics"
 	"github.com/cilium/tetragon/pkg/metrics/metricsconfig"
+	"github.com/cilium/tetragon/pkg/miningdetect"
 	"github.com/cilium/tetragon/pkg/observer"
 	"github.com/cilium/tetragon/pkg/option"
 	"github.com/cilium/tetragon/pkg/process"
 	"github.com/cilium/tetragon/pkg/ratelimit"
+	"github.com/cilium/tetragon/pkg/reader/node"
+	"github.com/cilium/tetragon/pkg/ringdrops"
 	"github.com/cilium/tetragon/pkg/rthooks"
 	"github.com/cilium/tetragon/pkg/sensors/base"
+	"github.com/cilium/tetragon/pkg/sensors/exec/procevents"
 	"github.com/cilium/tetragon/pkg/sensors/program"
+	"github.com/cilium/tetragon/pkg/sensors/tracing"
 	"github.com/cilium/tetragon/pkg/server"
+	"github.com/cilium/tetragon/pkg/sshconnect"
+	"github.com/cilium/tetragon/pkg/stalepins"
 	"github.com/cilium/tetragon/pkg/tgsyscall"
 	"github.com/cilium/tetragon/pkg/tracingpolicy"
-	"github.com/cilium/tetragon/pkg/unixlisten"
+	"github.com/cilium/tetragon/pkg/tracingpolicy/dirwatch"
 	"github.com/cilium/tetragon/pkg/version"
 	"github.com/cilium/tetragon/pkg/watcher"
 	k8sconf "github.com/cilium/tetragon/pkg/watcher/conf"
@@ -64,6 +83,7 @@ import (
 	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	apiextensionsinformer "k8s.io/apiextensions-apiserver/pkg/client/informers/externalversions/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 )
@@ -95,6 +115,20 @@ func getFieldFilters() ([]*tetragon.FieldFilter, error) {
 	return filters, nil
 }
 
+// getExportTime returns the format and the time zone of the timestamps of the
+// exported events.
+func getExportTime() (encoder.TimeFormat, *time.Location, error) {
+	timeFormat, err := encoder.ParseTimeFormat(option.Config.ExportTimeFormat)
+	if err != nil {
+		return "", nil, err
+	}
+	timeLocation, err := time.LoadLocation(option.Config.ExportTimeZone)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid export time zone '%s': %w", option.Config.ExportTimeZone, err)
+	}
+	return timeFormat, timeLocation, nil
+}
+
 // Save daemon information so it is used by client cli but
 // also by bugtool
 func saveInitInfo() error {
@@ -160,7 +194,7 @@ func tetragonExecute() error {
 	log.WithField("version", version.Version).Info("Starting tetragon")
 	log.WithField("config", viper.AllSettings()).Info("config settings")
 
-	log.Info("BPF detected features: ", bpf.LogFeatures())
+	log.Info("BPF detected features: ", features.Log())
 
 	if option.Config.ForceLargeProgs && option.Config.ForceSmallProgs {
 		log.Fatalf("Can't specify --force-small-progs and --force-large-progs together")
@@ -174,6 +208,10 @@ func tetragonExecute() error {
 		log.Info("Force loading smallprograms")
 	}
 
+	if option.Config.TracingPolicyDryRun {
+		return dryRunTracingPolicies(os.Stdout)
+	}
+
 	if viper.IsSet(option.KeyNetnsDir) {
 		defaults.NetnsDir = viper.GetString(option.KeyNetnsDir)
 	}
@@ -221,14 +259,21 @@ func tetragonExecute() error {
 	option.Config.BpfDir = observerDir
 	option.Config.MapDir = observerDir
 
-	// Check if option to remove old BPF and maps is enabled.
-	if option.Config.ReleasePinned {
-		err := os.RemoveAll(observerDir)
+	// Clean up the BPF programs and maps left by the previous runs. If the
+	// option to release them is enabled, they are all removed.
+	stalePinsMode := stalepins.ModeRemove
+	if !option.Config.ReleasePinned {
+		mode, err := stalepins.ParseMode(option.Config.StalePinnedBPF)
 		if err != nil {
-			log.WithField("bpf-dir", observerDir).WithError(err).Warn("BPF: failed to release pinned BPF programs and maps, Consider removing it manually")
-		} else {
-			log.WithField("bpf-dir", observerDir).Info("BPF: successfully released pinned BPF programs and maps")
+			return err
 		}
+		stalePinsMode = mode
+	}
+	if report, err := stalepins.Clean(observerDir, stalePinsMode, base.IsPin); err != nil {
+		log.WithField("bpf-dir", observerDir).WithError(err).Warn("BPF: failed to clean up pinned BPF programs and maps, Consider removing them manually")
+	} else {
+		logStalePins(observerDir, report)
+		defer stalepins.Release(observerDir)
 	}
 
 	// Get observer from configFile
@@ -366,6 +411,14 @@ func tetragonExecute() error {
 		wg.Wait()
 		close(stop)
 		k8sWatcher = watcher.NewK8sWatcher(k8sClient, 60*time.Second)
+
+		// the node selectors of the tracing policies match the labels
+		// of the node
+		if k8sNode, err := k8sClient.CoreV1().Nodes().Get(ctx, os.Getenv("NODE_NAME"), metav1.GetOptions{}); err != nil {
+			log.WithError(err).Warn("Failed to get the labels of the node, node selectors of tracing policies will only match the labels set by the agent")
+		} else {
+			node.SetNodeLabels(k8sNode.Labels)
+		}
 	} else {
 		log.Info("Disabling Kubernetes API")
 		k8sWatcher = watcher.NewFakeK8sWatcher(nil)
@@ -379,6 +432,17 @@ func tetragonExecute() error {
 		return err
 	}
 
+	// shrink the caches and queues before reaching the memory cgroup limit
+	if option.Config.MemoryThrottleThreshold > 0 {
+		if monitor, err := memlimit.NewMonitor(option.Config.MemoryThrottleThreshold); err != nil {
+			log.WithError(err).Warn("Memory usage will not be throttled")
+		} else if monitor != nil {
+			go monitor.Run(ctx)
+		} else {
+			log.Info("No memory cgroup limit, memory usage will not be throttled")
+		}
+	}
+
 	// cleanupWg is needed to ensure that gRPC code cleanly finishes before we exit (e.g,
 	// due to a signal). This is needed, for example, so that the exported writes full
 	// (uncorrupted) to the file. See: 4b7c8d1c427a46b864763e910e8f3511e1c4eb00.
@@ -404,7 +468,7 @@ func tetragonExecute() error {
 	if err != nil {
 		return err
 	}
-	if err = Serve(ctx, option.Config.ServerAddress, pm.Server); err != nil {
+	if err = Serve(ctx, option.Config.ServerAddress, option.Config.ServerListeners, pm.Server); err != nil {
 		return err
 	}
 	if option.Config.ExportFilename != "" {
@@ -412,6 +476,30 @@ func tetragonExecute() error {
 			return err
 		}
 	}
+	if option.Config.ExportOTLPEndpoint != "" {
+		if err = startOTLPExporter(ctx, pm.Server); err != nil {
+			return err
+		}
+	}
+	if option.Config.ExportSyslogAddress != "" {
+		if err = startSyslogExporter(ctx, pm.Server); err != nil {
+			return err
+		}
+	}
+	if option.Config.ExportFluentAddress != "" {
+		if err = startFluentExporter(ctx, pm.Server); err != nil {
+			return err
+		}
+	}
+	if option.Config.EventForwardVsockPort != 0 {
+		forwarder := eventforward.NewVsockForwarder(option.Config.EventForwardVsockPort)
+		exporter.NewExporter(ctx, &tetragon.GetEventsRequest{}, pm.Server, forwarder, forwarder, nil).Start()
+	}
+	if option.Config.EventReceiveVsockPort != 0 {
+		if err = eventforward.NewReceiver(pm.Server).ServeVsock(ctx, option.Config.EventReceiveVsockPort); err != nil {
+			return err
+		}
+	}
 
 	log.WithField("enabled", option.Config.ExportFilename != "").WithField("fileName", option.Config.ExportFilename).Info("Exporter configuration")
 	obs.AddListener(pm)
@@ -422,23 +510,95 @@ func tetragonExecute() error {
 
 	obs.LogPinnedBpf(observerDir)
 
-	// load base sensor
-	base := base.GetInitialSensor()
-	if err := base.Load(observerDir, observerDir); err != nil {
+	// the BPF ring buffer is sized when the base sensor is loaded
+	transport, err := observer.EventTransport()
+	if err != nil {
 		return err
 	}
-	defer func() {
-		base.Unload()
-	}()
+	if transport == observer.TransportRingBuf {
+		base.SetRingBufSize(observer.RingBufSize())
+	} else if option.Config.RBTransport == observer.TransportRingBuf {
+		log.Warn("The BPF ring buffer transport needs kernel 6.1 or later, falling back to perf ring buffers")
+	}
+
+	// load base sensor, unless the process events come from another source
+	base := base.GetInitialSensor()
+	source := option.Config.ProcessEventsSource
+	if !procevents.ValidSource(source) {
+		return fmt.Errorf("invalid value for --%s: %q, expected one of %s", option.KeyProcessEventsSource,
+			source, strings.Join(procevents.Sources, ", "))
+	}
+	bpfEvents := false
+	if source == procevents.SourceAuto || source == procevents.SourceBPF {
+		if err := base.Load(observerDir, observerDir); err == nil {
+			bpfEvents = true
+			defer func() {
+				base.Unload()
+			}()
+		} else if source == procevents.SourceBPF {
+			return err
+		} else {
+			log.WithError(err).Warn("Failed to load the base sensor")
+		}
+	}
+	if !bpfEvents {
+		// kernels where the BPF programs cannot be loaded still get basic
+		// process telemetry
+		if err := startProcessEventsSource(ctx, source); err != nil {
+			return err
+		}
+	}
+
+	// the base sensor pinned the map of the event statistics per cgroup
+	if reporter, err := ringdrops.NewReporter(obs, observerDir); err != nil {
+		log.WithError(err).Warn("Lost events will not be attributed to cgroups")
+	} else {
+		go reporter.Run(ctx)
+	}
+
+	// report the events lost by each CPU
+	go lostevents.NewReporter(obs, transport).Run(ctx)
+
+	// report the calls counted by the count action of the kprobes
+	go tracing.RunCountReporter(ctx)
 
 	// now that the base sensor was loaded, we can start the sensor manager
 	close(sensorMgWait)
 	sensorMgWait = nil
 	observer.GetSensorManager().LogSensorsAndProbes(ctx)
 
-	err = loadTpFromDir(ctx, option.Config.TracingPolicyDir)
-	if err != nil {
-		return err
+	if option.Config.MapFillCheckInterval > 0 {
+		go mapfill.NewMonitor(observer.GetSensorManager(), option.Config.MapFillCheckInterval,
+			option.Config.MapFillThreshold, option.Config.MapFillAutoResize).Run(ctx)
+	}
+
+	// remove the runtime selector values once their TTL expires
+	go observer.GetSensorManager().RunSelectorValuesGC(ctx)
+
+	if option.Config.TracingPolicyDirWatch {
+		tpWatcher := dirwatch.New(option.Config.TracingPolicyDir, observer.GetSensorManager())
+		// watch before listing and loading the policies, so that the
+		// changes made meanwhile are not missed
+		if err := tpWatcher.Watch(); err != nil {
+			return err
+		}
+		files, err := tpFilesFromDir(option.Config.TracingPolicyDir)
+		if err != nil {
+			return err
+		}
+		if err := tpWatcher.Load(ctx, files); err != nil {
+			return err
+		}
+		go func() {
+			if err := tpWatcher.Run(ctx); err != nil {
+				log.WithError(err).Warn("Failed to watch the TracingPolicy directory")
+			}
+		}()
+	} else {
+		err = loadTpFromDir(ctx, option.Config.TracingPolicyDir)
+		if err != nil {
+			return err
+		}
 	}
 
 	// load sensor from tracing policy file
@@ -449,53 +609,132 @@ func tetragonExecute() error {
 		}
 	}
 
-	// k8s should have metrics, so periodically log only in a non k8s
-	if option.Config.EnableK8s == false {
-		go logStatus(ctx, obs)
-	}
+	if option.Config.EnableCapabilityUse {
+		// aggregate the events of the policy for GetCapabilityReport
+		recorder := capabilityuse.NewRecorder(option.Config.CapabilityUseReportWindow)
+		capabilityuse.SetRecorder(recorder)
+		pm.AddListener(recorder)
 
-	return obs.Start(ctx)
-}
+		tp, err := capabilityuse.Policy()
+		if err != nil {
+			return err
+		}
+		if err := observer.GetSensorManager().AddTracingPolicy(ctx, tp); err != nil {
+			return fmt.Errorf("failed to add the capability-use policy: %w", err)
+		}
+	}
 
-func loadTpFromDir(ctx context.Context, dir string) error {
-	tpMaxDepth := 1
-	tpFS := os.DirFS(dir)
+	if option.Config.EnableMiningDetection {
+		detector := miningdetect.NewDetector(option.Config.MiningDetectionThreshold)
+		pm.AddListener(detector)
+		go detector.Run(ctx)
 
-	if dir == defaults.DefaultTpDir {
-		// If the default directory does not exist then do not fail
-		// Probably tetragon not fully installed, developers testing, etc
-		if _, err := os.Stat(dir); os.IsNotExist(err) {
-			log.WithField("tracing-policy-dir", dir).Info("Loading Tracing Policies from directory ignored, directory does not exist")
-			return nil
+		tp, err := miningdetect.Policy(option.Config.MiningDetectionPoolPorts)
+		if err != nil {
+			return err
+		}
+		if err := observer.GetSensorManager().AddTracingPolicy(ctx, tp); err != nil {
+			return fmt.Errorf("failed to add the crypto-mining policy: %w", err)
 		}
 	}
 
-	err := fs.WalkDir(tpFS, ".", func(path string, d fs.DirEntry, err error) error {
+	if option.Config.EnableSshDetection {
+		correlator := sshconnect.NewCorrelator()
+		pm.AddListener(correlator)
+		go correlator.Run(ctx)
+
+		tp, err := sshconnect.Policy(option.Config.SshDetectionBinaries)
 		if err != nil {
 			return err
 		}
-
-		if d.IsDir() {
-			if strings.Count(path, string(os.PathSeparator)) >= tpMaxDepth {
-				return fs.SkipDir
-			}
-			return nil
+		if err := observer.GetSensorManager().AddTracingPolicy(ctx, tp); err != nil {
+			return fmt.Errorf("failed to add the ssh-lateral-movement policy: %w", err)
 		}
+	}
 
-		file := filepath.Join(dir, path)
-		st, err := os.Stat(file)
+	if option.Config.EnableAuthFilePolicy {
+		tp, err := authfiles.Policy(option.Config.AuthFilePolicyExcludeBinaries)
 		if err != nil {
 			return err
 		}
+		if err := observer.GetSensorManager().AddTracingPolicy(ctx, tp); err != nil {
+			return fmt.Errorf("failed to add the auth-file-access policy: %w", err)
+		}
+	}
+
+	// the policies loaded at startup are in the first snapshot
+	if option.Config.ConfigSnapshotInterval > 0 {
+		go configsnapshot.NewSnapshotter(observer.GetSensorManager(), option.Config.ConfigSnapshotInterval).Run(ctx)
+	}
+
+	// k8s should have metrics, so periodically log only in a non k8s
+	if option.Config.EnableK8s == false {
+		go logStatus(ctx, obs)
+	}
+
+	if !bpfEvents {
+		// there are no BPF events to read, the process events come from
+		// another source
+		<-ctx.Done()
+		return nil
+	}
+
+	return obs.Start(ctx)
+}
 
-		if st.Mode().IsRegular() == false {
+// startProcessEventsSource starts the source of the process events when the base sensor is not
+// loaded: the proc connector, or the procfs poller. In auto mode, the proc connector is preferred
+// and the poller is used if it is unavailable.
+func startProcessEventsSource(ctx context.Context, source string) error {
+	if source == procevents.SourceAuto || source == procevents.SourceProcConnector {
+		conn, err := procevents.NewProcConnector()
+		if err == nil {
+			go func() {
+				if err := conn.Run(ctx); err != nil {
+					log.WithError(err).Warn("Failed to read process events from the proc connector")
+				}
+			}()
 			return nil
 		}
+		if source == procevents.SourceProcConnector {
+			return err
+		}
+		log.WithError(err).Warn("Proc connector unavailable")
+	}
 
-		return addTracingPolicy(ctx, file)
-	})
+	if option.Config.ProcFSFallbackInterval <= 0 {
+		return fmt.Errorf("no source of process events available: polling procfs is disabled by --%s",
+			option.KeyProcFSFallbackInterval)
+	}
+	go procevents.NewPoller(option.Config.ProcFSFallbackInterval).Run(ctx)
+	return nil
+}
 
-	return err
+func loadTpFromDir(ctx context.Context, dir string) error {
+	files, err := tpFilesFromDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := addTracingPolicy(ctx, file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tpFilesFromDir returns the tracing policy files of a directory
+func tpFilesFromDir(dir string) ([]string, error) {
+	if dir == defaults.DefaultTpDir {
+		// If the default directory does not exist then do not fail
+		// Probably tetragon not fully installed, developers testing, etc
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			log.WithField("tracing-policy-dir", dir).Info("Loading Tracing Policies from directory ignored, directory does not exist")
+			return nil, nil
+		}
+	}
+
+	return dirwatch.Files(dir)
 }
 
 func addTracingPolicy(ctx context.Context, file string) error {
@@ -567,6 +806,36 @@ func getObserverDir() string {
 	return bpf.MapPrefixPath()
 }
 
+// logStalePins logs what was found in the observer directory on startup.
+func logStalePins(observerDir string, report *stalepins.Report) {
+	fields := logrus.Fields{
+		"bpf-dir":    observerDir,
+		"generation": report.Generation,
+	}
+	if report.Crashed() {
+		log.WithFields(fields).WithField("previous-generation", report.PrevGeneration).
+			Warn("BPF: previous run did not exit cleanly")
+	}
+	if report.Found() == 0 {
+		return
+	}
+	log.WithFields(fields).WithFields(logrus.Fields{
+		"adopted": len(report.Adopted),
+		"removed": len(report.Removed),
+		"kept":    len(report.Kept),
+		"failed":  len(report.Failed),
+	}).Info("BPF: cleaned up pinned BPF programs and maps of previous runs")
+	log.WithFields(fields).WithFields(logrus.Fields{
+		"adopted": report.Adopted,
+		"removed": report.Removed,
+		"kept":    report.Kept,
+	}).Debug("BPF: pinned BPF programs and maps of previous runs")
+	if len(report.Failed) > 0 {
+		log.WithFields(fields).WithField("pinned-bpf", report.Failed).
+			Warn("BPF: failed to release pinned BPF programs and maps, Consider removing them manually")
+	}
+}
+
 func startExporter(ctx context.Context, server *server.Server) error {
 	allowList, denyList, err := getExportFilters()
 	if err != nil {
@@ -576,10 +845,30 @@ func startExporter(ctx context.Context, server *server.Server) error {
 	if err != nil {
 		return err
 	}
+	timeFormat, timeLocation, err := getExportTime()
+	if err != nil {
+		return err
+	}
+	var flattener *encoder.Flattener
+	if option.Config.ExportFlatten {
+		arrays, err := encoder.ParseArrayMode(option.Config.ExportFlattenArrays)
+		if err != nil {
+			return err
+		}
+		if option.Config.ExportFlattenDepth < 0 {
+			return fmt.Errorf("export flatten depth '%d' is negative", option.Config.ExportFlattenDepth)
+		}
+		flattener = &encoder.Flattener{MaxDepth: option.Config.ExportFlattenDepth, Arrays: arrays}
+	}
+	var cloudEventer *encoder.CloudEventer
+	if option.Config.ExportCloudEvents {
+		cloudEventer = &encoder.CloudEventer{Cluster: option.Config.ClusterName}
+	}
 	writer := &lumberjack.Logger{
 		Filename:   option.Config.ExportFilename,
 		MaxSize:    option.Config.ExportFileMaxSizeMB,
 		MaxBackups: option.Config.ExportFileMaxBackups,
+		MaxAge:     option.Config.ExportFileMaxAgeDays,
 		Compress:   option.Config.ExportFileCompress,
 	}
 
@@ -590,6 +879,21 @@ func startExporter(ctx context.Context, server *server.Server) error {
 	}
 	writer.FileMode = perms
 
+	// the writer of the index tracks the writes and the rotations of the
+	// export file
+	var fileWriter io.Writer = writer
+	rotate := writer.Rotate
+	var indexWriter *exportindex.FileWriter
+	if option.Config.ExportIndex {
+		if option.Config.ExportIndexMaxSizeMB <= 0 {
+			return fmt.Errorf("export index size '%d' is not positive", option.Config.ExportIndexMaxSizeMB)
+		}
+		indexWriter = exportindex.NewFileWriter(writer)
+		fileWriter = indexWriter
+		rotate = indexWriter.Rotate
+	}
+	fileWriter = exporter.NewMeteredWriter(fileWriter, option.Config.ExportFilename)
+
 	finfo, err := os.Stat(filepath.Clean(option.Config.ExportFilename))
 	if err == nil && finfo.IsDir() {
 		// Error if exportFilename points to a directory
@@ -623,7 +927,7 @@ func startExporter(ctx context.Context, server *server.Server) error {
 						"file":      logFile,
 						"directory": logsDir,
 					}).Info("Rotating JSON logs export")
-					if rotationErr := writer.Rotate(); rotationErr != nil {
+					if rotationErr := rotate(); rotationErr != nil {
 						log.WithError(rotationErr).
 							WithField("file", option.Config.ExportFilename).
 							Warn("Failed to rotate JSON export file")
@@ -633,10 +937,42 @@ func startExporter(ctx context.Context, server *server.Server) error {
 		}()
 	}
 
-	encoder := encoder.NewProtojsonEncoder(writer)
+	var eventEncoder exporter.ExportEncoder = encoder.NewProtojsonEncoderWithTime(fileWriter, timeFormat, timeLocation).WithFlattener(flattener).WithCloudEvents(cloudEventer)
+	var closer io.Closer = writer
+	if indexWriter != nil {
+		indexPath := exportindex.Path(option.Config.ExportFilename)
+		indexEncoder, err := exportindex.NewEncoder(eventEncoder, indexWriter, indexPath, int64(option.Config.ExportIndexMaxSizeMB)*1024*1024)
+		if err != nil {
+			return err
+		}
+		eventEncoder = indexEncoder
+		// write the last block to the index before closing the file
+		closer = multiCloser{indexEncoder, writer}
+		log.WithField("index", indexPath).Info("Indexing JSON export files")
+	}
+	if option.Config.ExportFailoverFilename != "" {
+		failoverWriter := &lumberjack.Logger{
+			Filename:   option.Config.ExportFailoverFilename,
+			MaxSize:    option.Config.ExportFileMaxSizeMB,
+			MaxBackups: option.Config.ExportFileMaxBackups,
+			MaxAge:     option.Config.ExportFileMaxAgeDays,
+			Compress:   option.Config.ExportFileCompress,
+			FileMode:   perms,
+		}
+		eventEncoder = exporter.NewFailoverEncoder(
+			exporter.Sink{Name: option.Config.ExportFilename, Encoder: eventEncoder},
+			exporter.Sink{Name: option.Config.ExportFailoverFilename, Encoder: encoder.NewProtojsonEncoderWithTime(exporter.NewMeteredWriter(failoverWriter, option.Config.ExportFailoverFilename), timeFormat, timeLocation).WithFlattener(flattener).WithCloudEvents(cloudEventer)},
+			option.Config.ExportFailoverRetryInterval,
+		)
+		closer = multiCloser{closer, failoverWriter}
+		log.WithFields(logrus.Fields{
+			"failoverFileName": option.Config.ExportFailoverFilename,
+			"retryInterval":    option.Config.ExportFailoverRetryInterval.String(),
+		}).Info("Configured JSON export failover")
+	}
 	var rateLimiter *ratelimit.RateLimiter
 	if option.Config.ExportRateLimit >= 0 {
-		rateLimiter = ratelimit.NewRateLimiter(ctx, 1*time.Minute, option.Config.ExportRateLimit, encoder)
+		rateLimiter = ratelimit.NewRateLimiter(ctx, 1*time.Minute, option.Config.ExportRateLimit, eventEncoder)
 	}
 	var aggregationOptions *tetragon.AggregationOptions
 	if option.Config.EnableExportAggregation {
@@ -648,43 +984,194 @@ func startExporter(ctx context.Context, server *server.Server) error {
 	req := tetragon.GetEventsRequest{AllowList: allowList, DenyList: denyList, AggregationOptions: aggregationOptions, FieldFilters: fieldFilters}
 	log.WithFields(logrus.Fields{"fieldFilters": fieldFilters}).Debug("Configured field filters")
 	log.WithFields(logrus.Fields{"logger": writer, "request": &req}).Info("Starting JSON exporter")
-	exporter := exporter.NewExporter(ctx, &req, server, encoder, writer, rateLimiter)
+	exporter := exporter.NewExporter(ctx, &req, server, eventEncoder, closer, rateLimiter)
 	exporter.Start()
 	return nil
 }
 
-func Serve(ctx context.Context, listenAddr string, srv *server.Server) error {
-	grpcServer := grpc.NewServer()
-	tetragon.RegisterFineGuidanceSensorsServer(grpcServer, srv)
-	proto, addr, err := server.SplitListenAddr(listenAddr)
+func startOTLPExporter(ctx context.Context, server *server.Server) error {
+	allowList, denyList, err := getExportFilters()
 	if err != nil {
-		return fmt.Errorf("failed to parse listen address: %w", err)
+		return err
 	}
-	go func(proto, addr string) {
-		var listener net.Listener
-		var err error
-		if proto == "unix" {
-			listener, err = unixlisten.ListenWithRename(addr, 0660)
-		} else {
-			listener, err = net.Listen(proto, addr)
-		}
+	fieldFilters, err := getFieldFilters()
+	if err != nil {
+		return err
+	}
+	timeFormat, timeLocation, err := getExportTime()
+	if err != nil {
+		return err
+	}
+	otlpExporter, err := otlp.NewExporter(otlp.Config{
+		Endpoint:      option.Config.ExportOTLPEndpoint,
+		Insecure:      option.Config.ExportOTLPInsecure,
+		Headers:       option.Config.ExportOTLPHeaders,
+		QueueSize:     option.Config.ExportOTLPQueueSize,
+		BatchSize:     option.Config.ExportOTLPBatchSize,
+		FlushInterval: option.Config.ExportOTLPFlushInterval,
+		Cluster:       option.Config.ClusterName,
+		TimeFormat:    timeFormat,
+		TimeLocation:  timeLocation,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+	var rateLimiter *ratelimit.RateLimiter
+	if option.Config.ExportRateLimit >= 0 {
+		rateLimiter = ratelimit.NewRateLimiter(ctx, 1*time.Minute, option.Config.ExportRateLimit, otlpExporter)
+	}
+	req := tetragon.GetEventsRequest{AllowList: allowList, DenyList: denyList, FieldFilters: fieldFilters}
+	log.WithFields(logrus.Fields{"endpoint": option.Config.ExportOTLPEndpoint, "request": &req}).Info("Starting OTLP exporter")
+	exporter.NewExporter(ctx, &req, server, otlpExporter, otlpExporter, rateLimiter).Start()
+	return nil
+}
+
+func startSyslogExporter(ctx context.Context, server *server.Server) error {
+	allowList, denyList, err := getExportFilters()
+	if err != nil {
+		return err
+	}
+	fieldFilters, err := getFieldFilters()
+	if err != nil {
+		return err
+	}
+	format, err := encoder.ParseSyslogFormat(option.Config.ExportSyslogFormat)
+	if err != nil {
+		return err
+	}
+	var tlsConfig *tls.Config
+	if option.Config.ExportSyslogTLSCAFile != "" {
+		pem, err := os.ReadFile(option.Config.ExportSyslogTLSCAFile)
 		if err != nil {
-			log.WithError(err).WithField("protocol", proto).WithField("address", addr).Fatal("Failed to start gRPC server")
+			return fmt.Errorf("failed to read syslog CA file: %w", err)
 		}
-		log.WithField("address", addr).WithField("protocol", proto).Info("Starting gRPC server")
-		if err = grpcServer.Serve(listener); err != nil {
-			log.WithError(err).Error("Failed to close gRPC server")
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in syslog CA file '%s'", option.Config.ExportSyslogTLSCAFile)
 		}
-	}(proto, addr)
-	go func(proto, addr string) {
-		<-ctx.Done()
-		grpcServer.Stop()
-		// if proto is unix, ListenWithRename() creates the socket
-		// then renames it, so explicitly clean it up.
-		if proto == "unix" {
-			os.Remove(addr)
+		tlsConfig = &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+	}
+	writer, err := exporter.NewSyslogWriter(option.Config.ExportSyslogProtocol, option.Config.ExportSyslogAddress, tlsConfig)
+	if err != nil {
+		return err
+	}
+	syslogEncoder, err := encoder.NewSyslogEncoder(writer, format, option.Config.ExportSyslogFacility, node.GetNodeNameForExport())
+	if err != nil {
+		return err
+	}
+	var rateLimiter *ratelimit.RateLimiter
+	if option.Config.ExportRateLimit >= 0 {
+		rateLimiter = ratelimit.NewRateLimiter(ctx, 1*time.Minute, option.Config.ExportRateLimit, syslogEncoder)
+	}
+	req := tetragon.GetEventsRequest{AllowList: allowList, DenyList: denyList, FieldFilters: fieldFilters}
+	log.WithFields(logrus.Fields{
+		"address":  option.Config.ExportSyslogAddress,
+		"protocol": option.Config.ExportSyslogProtocol,
+		"format":   format,
+		"request":  &req,
+	}).Info("Starting syslog exporter")
+	exporter.NewExporter(ctx, &req, server, syslogEncoder, writer, rateLimiter).Start()
+	return nil
+}
+
+func startFluentExporter(ctx context.Context, server *server.Server) error {
+	allowList, denyList, err := getExportFilters()
+	if err != nil {
+		return err
+	}
+	fieldFilters, err := getFieldFilters()
+	if err != nil {
+		return err
+	}
+	var tlsConfig *tls.Config
+	if option.Config.ExportFluentTLS {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	timeFormat, timeLocation, err := getExportTime()
+	if err != nil {
+		return err
+	}
+	fluentExporter, err := fluent.NewExporter(fluent.Config{
+		Address:       option.Config.ExportFluentAddress,
+		Tag:           option.Config.ExportFluentTag,
+		TLS:           tlsConfig,
+		RequireAck:    option.Config.ExportFluentRequireAck,
+		QueueSize:     option.Config.ExportFluentQueueSize,
+		BatchSize:     option.Config.ExportFluentBatchSize,
+		FlushInterval: option.Config.ExportFluentFlushInterval,
+		TimeFormat:    timeFormat,
+		TimeLocation:  timeLocation,
+	})
+	if err != nil {
+		return err
+	}
+	var rateLimiter *ratelimit.RateLimiter
+	if option.Config.ExportRateLimit >= 0 {
+		rateLimiter = ratelimit.NewRateLimiter(ctx, 1*time.Minute, option.Config.ExportRateLimit, fluentExporter)
+	}
+	req := tetragon.GetEventsRequest{AllowList: allowList, DenyList: denyList, FieldFilters: fieldFilters}
+	log.WithFields(logrus.Fields{
+		"address": option.Config.ExportFluentAddress,
+		"tag":     option.Config.ExportFluentTag,
+		"request": &req,
+	}).Info("Starting fluent exporter")
+	exporter.NewExporter(ctx, &req, server, fluentExporter, fluentExporter, rateLimiter).Start()
+	return nil
+}
+
+// multiCloser closes all of its closers and returns the first error.
+type multiCloser []io.Closer
+
+func (mc multiCloser) Close() error {
+	var ret error
+	for _, c := range mc {
+		if err := c.Close(); err != nil && ret == nil {
+			ret = err
 		}
-	}(proto, addr)
+	}
+	return ret
+}
+
+// Serve serves the gRPC API on the server address and on the additional
+// listeners, each with its own server so that they can use different
+// credentials.
+func Serve(ctx context.Context, listenAddr string, listeners []string, srv *server.Server) error {
+	var configs []*server.ListenerConfig
+	c, err := server.ParseListener(listenAddr)
+	if err != nil {
+		return fmt.Errorf("failed to parse listen address %q: %w", listenAddr, err)
+	}
+	configs = append(configs, c)
+	for _, l := range listeners {
+		c, err := server.ParseAdditionalListener(l)
+		if err != nil {
+			return fmt.Errorf("failed to parse listen address %q: %w", l, err)
+		}
+		configs = append(configs, c)
+	}
+	for _, c := range configs {
+		opts, err := c.ServerOptions()
+		if err != nil {
+			return fmt.Errorf("failed to configure gRPC server %s: %w", c, err)
+		}
+		grpcServer := grpc.NewServer(opts...)
+		tetragon.RegisterFineGuidanceSensorsServer(grpcServer, srv)
+		go func(c *server.ListenerConfig) {
+			listener, err := c.Listen()
+			if err != nil {
+				log.WithError(err).WithField("protocol", c.Proto).WithField("address", c.Addr).Fatal("Failed to start gRPC server")
+			}
+			log.WithField("address", c.Addr).WithField("protocol", c.Proto).WithField("tls", c.TLSCert != "").Info("Starting gRPC server")
+			if err = grpcServer.Serve(listener); err != nil {
+				log.WithError(err).Error("Failed to close gRPC server")
+			}
+		}(c)
+		go func(c *server.ListenerConfig) {
+			<-ctx.Done()
+			grpcServer.Stop()
+			c.Cleanup()
+		}(c)
+	}
 	return nil
 }
 
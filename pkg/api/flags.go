@@ -91,4 +91,12 @@ const (
 	EventDataFilename = 0x800000
 	// EventDataArgs indicates args are received with data event
 	EventDataArgs = 0x1000000
+	// EventProcFSPoll indicates the event is generated by polling procfs,
+	// because the BPF programs of the exec sensor could not be loaded. It is
+	// always set together with EventProcFS.
+	EventProcFSPoll = 0x2000000
+	// EventProcConnector indicates the event is generated from the netlink
+	// proc connector, with the process information read from procfs. It is
+	// always set together with EventProcFS.
+	EventProcConnector = 0x4000000
 )
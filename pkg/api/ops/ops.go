@@ -26,6 +26,10 @@ const (
 
 	MSG_OP_LOADER = 26
 
+	MSG_OP_GENERIC_LSM = 27
+
+	MSG_OP_GENERIC_PERF_EVENT = 28
+
 	// just for testing
 	MSG_OP_TEST = 254
 )
@@ -77,6 +81,9 @@ func (op OpCode) String() string {
 		23:  "Clone",
 		24:  "Data",
 		25:  "Cgroup",
+		26:  "Loader",
+		27:  "GenericLsm",
+		28:  "GenericPerfEvent",
 		254: "Test",
 	}[op]
 }
@@ -35,8 +35,10 @@ const (
 	ExecveSetgid = 0x02
 
 	// flags of MsgCommon
-	MSG_COMMON_FLAG_RETURN     = 0x1
-	MSG_COMMON_FLAG_STACKTRACE = 0x2
+	MSG_COMMON_FLAG_RETURN          = 0x1
+	MSG_COMMON_FLAG_STACKTRACE      = 0x2
+	MSG_COMMON_FLAG_USER_STACKTRACE = 0x4
+	MSG_COMMON_FLAG_COMPAT          = 0x8
 )
 
 type MsgExec struct {
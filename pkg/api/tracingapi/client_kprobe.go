@@ -25,6 +25,8 @@ const (
 	ActionTrackSock    = 10
 	ActionUntrackSock  = 11
 	ActionNotifyKiller = 12
+	ActionCount        = 13
+	ActionSetTag       = 14
 )
 
 const (
@@ -33,12 +35,24 @@ const (
 	MODULE_NAME_LEN  = 64
 )
 
+const (
+	// LoaderFlagAllowlistViolation is set on loader events for libraries
+	// that are not in the loader allowlist.
+	LoaderFlagAllowlistViolation = 1
+	// LoaderFlagReportAll is set on loader events when a loader policy
+	// without an allowlist is loaded.
+	LoaderFlagReportAll = 2
+)
+
 type MsgLoader struct {
 	Common      processapi.MsgCommon
 	ProcessKey  processapi.MsgExecveKey
 	Pid         uint32
 	BuildIdSize uint32
 	PathSize    uint32
+	Flags       uint32
+	Violations  uint32
+	Unknown     uint32
 	BuildId     [20]byte
 	Path        [4096]byte
 }
@@ -54,6 +68,15 @@ type MsgGenericKprobe struct {
 	ActionArgId  uint32
 	Tid          uint32 // The recorded TID that triggered the event
 	StackID      int64
+	UserStackID  int64
+	// ActionKtime is the ktime at which the enforcement action was
+	// applied, zero if none
+	ActionKtime uint64
+	// AuditAction is the enforcement action that a policy in audit mode
+	// did not take, zero if none, and AuditSelector the index of the
+	// selector of the action.
+	AuditAction   uint32
+	AuditSelector uint32
 }
 
 type MsgGenericKprobeArgPath struct {
@@ -71,6 +94,36 @@ func (m MsgGenericKprobeArgPath) IsReturnArg() bool {
 	return (m.Index == ReturnArgIndex)
 }
 
+type MsgGenericKprobeArgLinuxBinprm struct {
+	Index uint64
+	Value string
+	Flags uint32
+	Label string
+}
+
+func (m MsgGenericKprobeArgLinuxBinprm) GetIndex() uint64 {
+	return m.Index
+}
+
+func (m MsgGenericKprobeArgLinuxBinprm) IsReturnArg() bool {
+	return (m.Index == ReturnArgIndex)
+}
+
+type MsgGenericKprobeArgStringArray struct {
+	Index     uint64
+	Values    []string
+	Truncated bool
+	Label     string
+}
+
+func (m MsgGenericKprobeArgStringArray) GetIndex() uint64 {
+	return m.Index
+}
+
+func (m MsgGenericKprobeArgStringArray) IsReturnArg() bool {
+	return (m.Index == ReturnArgIndex)
+}
+
 type MsgGenericKprobeArgFile struct {
 	Index uint64
 	Value string
@@ -200,6 +253,29 @@ func (m MsgGenericKprobeArgSock) IsReturnArg() bool {
 	return m.Index == ReturnArgIndex
 }
 
+type MsgGenericKprobeSockaddr struct {
+	SinFamily uint16
+	SinPort   uint16
+	Pad       uint32
+	SinAddr   [2]uint64
+}
+
+type MsgGenericKprobeArgSockaddr struct {
+	Index     uint64
+	SinFamily uint16
+	SinAddr   string
+	SinPort   uint32
+	Label     string
+}
+
+func (m MsgGenericKprobeArgSockaddr) GetIndex() uint64 {
+	return m.Index
+}
+
+func (m MsgGenericKprobeArgSockaddr) IsReturnArg() bool {
+	return m.Index == ReturnArgIndex
+}
+
 type MsgGenericKprobeSkb struct {
 	Tuple       MsgGenericKprobeTuple
 	Hash        uint32
@@ -208,6 +284,9 @@ type MsgGenericKprobeSkb struct {
 	Mark        uint32
 	SecPathLen  uint32
 	SecPathOLen uint32
+	IcmpType    uint8
+	IcmpCode    uint8
+	Pad         [6]uint8
 }
 
 type MsgGenericKprobeArgSkb struct {
@@ -224,6 +303,8 @@ type MsgGenericKprobeArgSkb struct {
 	Proto       uint32
 	SecPathLen  uint32
 	SecPathOLen uint32
+	IcmpType    uint32
+	IcmpCode    uint32
 	Label       string
 }
 
@@ -456,14 +537,15 @@ type KprobeArgs struct {
 const EventConfigMaxArgs = 5
 
 type EventConfig struct {
-	FuncId          uint32                     `align:"func_id"`
-	Arg             [EventConfigMaxArgs]int32  `align:"arg0"`
-	ArgM            [EventConfigMaxArgs]uint32 `align:"arg0m"`
-	ArgTpCtxOff     [EventConfigMaxArgs]uint32 `align:"t_arg0_ctx_off"`
-	Syscall         uint32                     `align:"syscall"`
-	ArgReturnCopy   int32                      `align:"argreturncopy"`
-	ArgReturn       int32                      `align:"argreturn"`
-	ArgReturnAction int32                      `align:"argreturnaction"`
-	PolicyID        uint32                     `align:"policy_id"`
-	Flags           uint32                     `align:"flags"`
+	FuncId               uint32                     `align:"func_id"`
+	Arg                  [EventConfigMaxArgs]int32  `align:"arg0"`
+	ArgM                 [EventConfigMaxArgs]uint32 `align:"arg0m"`
+	ArgTpCtxOff          [EventConfigMaxArgs]uint32 `align:"t_arg0_ctx_off"`
+	Syscall              uint32                     `align:"syscall"`
+	ArgReturnCopy        int32                      `align:"argreturncopy"`
+	ArgReturn            int32                      `align:"argreturn"`
+	ArgReturnAction      int32                      `align:"argreturnaction"`
+	PolicyID             uint32                     `align:"policy_id"`
+	Flags                uint32                     `align:"flags"`
+	ArgReturnCopyMaxSize uint32                     `align:"argreturncopy_max_size"`
 }
@@ -18,4 +18,13 @@ type MsgGenericTracepoint struct {
 	ActionArgId  uint32
 	Tid          uint32 // The recorded TID that triggered the event
 	StackID      int64
+	UserStackID  int64
+	// ActionKtime is the ktime at which the enforcement action was
+	// applied, zero if none
+	ActionKtime uint64
+	// AuditAction is the enforcement action that a policy in audit mode
+	// did not take, zero if none, and AuditSelector the index of the
+	// selector of the action.
+	AuditAction   uint32
+	AuditSelector uint32
 }
@@ -10,7 +10,12 @@ import (
 	"testing"
 )
 
-var supportedArchPrefix = map[string]string{"amd64": "__x64_", "arm64": "__arm64_"}
+var supportedArchPrefix = map[string]string{"amd64": "__x64_", "arm64": "__arm64_", "riscv64": "__riscv_"}
+
+// supportedArchCompatPrefix are the prefixes of the entry points of the compat
+// (32-bit) syscalls. On arm64 and riscv64, the syscalls that do not have a
+// compat implementation share their entry point with the 64-bit ones.
+var supportedArchCompatPrefix = map[string]string{"amd64": "__ia32_", "arm64": "__arm64_", "riscv64": "__riscv_"}
 
 func addSyscallPrefix(symbol string, arch string) (string, error) {
 	for prefix_arch, prefix := range supportedArchPrefix {
@@ -29,8 +34,8 @@ func addSyscallPrefix(symbol string, arch string) (string, error) {
 }
 
 // AddSyscallPrefix detects if the symbol is already prefixed with arch specific
-// prefix in the form of "__x64_" or "__arm64_", and if not, adds it based on
-// provided arch.
+// prefix in the form of "__x64_", "__arm64_" or "__riscv_", and if not, adds
+// it based on provided arch.
 //
 // Note that cilium/ebpf, retries to attach to an arch specific version of the
 // symbol after a failure thus making possible to attach to "sys_listen" which
@@ -60,10 +65,60 @@ func AddSyscallPrefixTestHelper(t *testing.T, symbol string) string {
 
 // CutSyscallPrefix removes a potential arch specific prefix from the symbol
 func CutSyscallPrefix(symbol string) string {
-	for _, prefix := range supportedArchPrefix {
-		if strings.HasPrefix(symbol, prefix) {
-			return symbol[len(prefix):]
+	for _, prefixes := range []map[string]string{supportedArchPrefix, supportedArchCompatPrefix} {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(symbol, prefix) {
+				return symbol[len(prefix):]
+			}
 		}
 	}
 	return symbol
 }
+
+// syscallName returns the function name of a syscall ("sys_lseek") from its
+// plain name, its function name, or its symbol on any supported arch.
+func syscallName(symbol string) string {
+	name := CutSyscallPrefix(symbol)
+	if strings.HasPrefix(name, "sys_") || strings.HasPrefix(name, "compat_sys_") {
+		return name
+	}
+	return "sys_" + name
+}
+
+func syscallSymbol(symbol string, arch string) (string, error) {
+	prefix, found := supportedArchPrefix[arch]
+	if !found {
+		return "", fmt.Errorf("unsupported architecture %s", arch)
+	}
+	return prefix + syscallName(symbol), nil
+}
+
+// SyscallSymbol returns the symbol of the entry point of a syscall on the
+// running arch. Unlike AddSyscallPrefix, the syscall can be given by its plain
+// name ("lseek"), its function name ("sys_lseek"), or its symbol on any
+// supported arch ("__x64_sys_lseek"), so that the same policy can be used on
+// all the supported archs.
+func SyscallSymbol(symbol string) (string, error) {
+	return syscallSymbol(symbol, runtime.GOARCH)
+}
+
+func compatSyscallSymbols(symbol string, arch string) ([]string, error) {
+	prefix, found := supportedArchCompatPrefix[arch]
+	if !found {
+		return nil, fmt.Errorf("unsupported architecture %s", arch)
+	}
+	name := syscallName(symbol)
+	if strings.HasPrefix(name, "compat_sys_") {
+		return []string{prefix + name}, nil
+	}
+	return []string{prefix + "compat_" + name, prefix + name}, nil
+}
+
+// CompatSyscallSymbols returns the candidate symbols of the compat (32-bit)
+// entry point of a syscall on the running arch, in order of preference: the
+// entry point of the compat implementation of the syscall, which only exists
+// for some syscalls, and then the entry point of its common implementation.
+// The syscall can be given in the same forms as for SyscallSymbol.
+func CompatSyscallSymbols(symbol string) ([]string, error) {
+	return compatSyscallSymbols(symbol, runtime.GOARCH)
+}
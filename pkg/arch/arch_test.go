@@ -35,3 +35,46 @@ func Test_addSyscallPrefix(t *testing.T) {
 	assert.Error(t, err)
 	assert.Empty(t, res)
 }
+
+func Test_syscallSymbol(t *testing.T) {
+	for _, symbol := range []string{"lseek", "sys_lseek", "__x64_sys_lseek", "__arm64_sys_lseek", "__riscv_sys_lseek"} {
+		res, err := syscallSymbol(symbol, "amd64")
+		assert.NoError(t, err)
+		assert.Equal(t, "__x64_sys_lseek", res)
+
+		res, err = syscallSymbol(symbol, "arm64")
+		assert.NoError(t, err)
+		assert.Equal(t, "__arm64_sys_lseek", res)
+
+		res, err = syscallSymbol(symbol, "riscv64")
+		assert.NoError(t, err)
+		assert.Equal(t, "__riscv_sys_lseek", res)
+	}
+
+	// not supported arch
+	res, err := syscallSymbol("lseek", "unsupported64")
+	assert.Error(t, err)
+	assert.Empty(t, res)
+}
+
+func Test_compatSyscallSymbols(t *testing.T) {
+	for _, symbol := range []string{"lseek", "sys_lseek", "__x64_sys_lseek", "__ia32_sys_lseek"} {
+		res, err := compatSyscallSymbols(symbol, "amd64")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"__ia32_compat_sys_lseek", "__ia32_sys_lseek"}, res)
+
+		res, err = compatSyscallSymbols(symbol, "arm64")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"__arm64_compat_sys_lseek", "__arm64_sys_lseek"}, res)
+	}
+
+	// compat implementation
+	res, err := compatSyscallSymbols("__ia32_compat_sys_lseek", "amd64")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"__ia32_compat_sys_lseek"}, res)
+
+	// not supported arch
+	res, err = compatSyscallSymbols("lseek", "unsupported64")
+	assert.Error(t, err)
+	assert.Empty(t, res)
+}
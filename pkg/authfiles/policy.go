@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package authfiles provides the built-in login and authentication files
+// policy. It reports the changes to the password and group shadow files, the
+// sudoers configuration, the SSH authorized keys and the PAM configuration,
+// and the reads of the shadow files, excluding the package managers, sssd and,
+// for the reads, the programs that authenticate users.
+package authfiles
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/cilium/tetragon/pkg/tracingpolicy"
+)
+
+// PolicyName is the name of the built-in authentication files policy.
+const PolicyName = "auth-file-access"
+
+var (
+	// authFiles are the files whose changes are reported.
+	authFiles = []string{
+		"/etc/shadow",
+		"/etc/gshadow",
+		"/etc/sudoers",
+		"/etc/pam.conf",
+	}
+	// authDirs are the directories whose files changes are reported.
+	authDirs = []string{
+		"/etc/sudoers.d/",
+		"/etc/pam.d/",
+		"/etc/security/",
+	}
+	// authorizedKeys are the SSH authorized keys files of the users.
+	authorizedKeys = []string{
+		"/.ssh/authorized_keys",
+		"/.ssh/authorized_keys2",
+	}
+	// credentialFiles are the files whose reads are reported.
+	credentialFiles = []string{
+		"/etc/shadow",
+		"/etc/gshadow",
+	}
+
+	// packageManagers install and update the configuration of the
+	// authentication stack.
+	packageManagers = []string{
+		"/usr/bin/dpkg",
+		"/usr/bin/apt",
+		"/usr/bin/apt-get",
+		"/usr/bin/rpm",
+		"/usr/bin/dnf",
+		"/usr/bin/dnf-3",
+		"/usr/bin/microdnf",
+		"/usr/bin/yum",
+		"/usr/bin/zypper",
+		"/usr/bin/pacman",
+		"/sbin/apk",
+	}
+	// sssd caches the credentials of the users of remote directories.
+	sssd = []string{
+		"/usr/sbin/sssd",
+		"/usr/libexec/sssd/sssd_be",
+		"/usr/libexec/sssd/sssd_nss",
+		"/usr/libexec/sssd/sssd_pam",
+	}
+	// authenticators read the shadow files to authenticate users.
+	authenticators = []string{
+		"/usr/sbin/unix_chkpwd",
+		"/sbin/unix_chkpwd",
+		"/usr/sbin/sshd",
+		"/usr/bin/sudo",
+		"/usr/bin/su",
+		"/bin/su",
+		"/usr/bin/login",
+		"/bin/login",
+		"/usr/bin/passwd",
+		"/usr/bin/chage",
+		"/usr/sbin/chpasswd",
+	}
+)
+
+// MAY_READ and MAY_WRITE of the mask of security_file_permission.
+const (
+	mayWrite = "2"
+	mayRead  = "4"
+)
+
+const selectorTemplate = `
+    - matchBinaries:
+      - operator: "NotIn"
+        values:%s
+      matchArgs:
+      - index: %d
+        operator: "%s"
+        values:%s%s
+      matchActions:
+      - action: Post
+        rateLimit: "1m"
+        rateLimitScope: "process"`
+
+const maskTemplate = `
+      - index: 1
+        operator: "Mask"
+        values:
+        - "%s"`
+
+// The files are changed either by writing to them, or by renaming a new file
+// over them, as most editors and the shadow utilities do.
+const policyTemplate = `apiVersion: cilium.io/v1alpha1
+kind: TracingPolicy
+metadata:
+  name: "` + PolicyName + `"
+spec:
+  kprobes:
+  - call: "security_file_permission"
+    syscall: false
+    args:
+    - index: 0
+      type: "file"
+    - index: 1
+      type: "int"
+    selectors:%s
+  - call: "security_inode_rename"
+    syscall: false
+    args:
+    - index: 1
+      type: "dentry"
+    - index: 3
+      type: "dentry"
+    selectors:%s
+`
+
+func yamlValues(values []string) string {
+	var sb strings.Builder
+	for _, v := range values {
+		fmt.Fprintf(&sb, "\n        - %q", v)
+	}
+	return sb.String()
+}
+
+// changeSelectors returns the selectors of the changes to the authentication
+// files, for the path argument index.
+func changeSelectors(index int, mask string, excluded []string) string {
+	var extra string
+	if mask != "" {
+		extra = fmt.Sprintf(maskTemplate, mask)
+	}
+	binaries := yamlValues(excluded)
+	return fmt.Sprintf(selectorTemplate, binaries, index, "Equal", yamlValues(authFiles), extra) +
+		fmt.Sprintf(selectorTemplate, binaries, index, "Prefix", yamlValues(authDirs), extra) +
+		fmt.Sprintf(selectorTemplate, binaries, index, "Postfix", yamlValues(authorizedKeys), extra)
+}
+
+func policyYAML(excludeBinaries []string) (string, error) {
+	for _, b := range excludeBinaries {
+		if !filepath.IsAbs(b) {
+			return "", fmt.Errorf("excluded binary %q is not an absolute path", b)
+		}
+	}
+	excluded := append(append(append([]string{}, packageManagers...), sssd...), excludeBinaries...)
+	readers := append(append([]string{}, excluded...), authenticators...)
+
+	permission := changeSelectors(0, mayWrite, excluded) +
+		fmt.Sprintf(selectorTemplate, yamlValues(readers), 0, "Equal", yamlValues(credentialFiles),
+			fmt.Sprintf(maskTemplate, mayRead))
+	rename := changeSelectors(3, "", excluded)
+	return fmt.Sprintf(policyTemplate, permission, rename), nil
+}
+
+// Policy returns the built-in authentication files policy. The changes and
+// reads of the excludeBinaries are not reported, in addition to the ones of
+// the package managers and sssd.
+func Policy(excludeBinaries []string) (tracingpolicy.TracingPolicy, error) {
+	policy, err := policyYAML(excludeBinaries)
+	if err != nil {
+		return nil, err
+	}
+	return tracingpolicy.FromYAML(policy)
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package authfiles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy(t *testing.T) {
+	tp, err := Policy([]string{"/opt/bin/configmgmt"})
+	require.NoError(t, err)
+	assert.Equal(t, PolicyName, tp.TpName())
+	spec := tp.TpSpec()
+	require.Len(t, spec.KProbes, 2)
+
+	perm := spec.KProbes[0]
+	assert.Equal(t, "security_file_permission", perm.Call)
+	require.Len(t, perm.Selectors, 4)
+	for _, sel := range perm.Selectors {
+		require.Len(t, sel.MatchBinaries, 1)
+		assert.Equal(t, "NotIn", sel.MatchBinaries[0].Operator)
+		assert.Contains(t, sel.MatchBinaries[0].Values, "/usr/bin/dpkg")
+		assert.Contains(t, sel.MatchBinaries[0].Values, "/usr/sbin/sssd")
+		assert.Contains(t, sel.MatchBinaries[0].Values, "/opt/bin/configmgmt")
+		require.Len(t, sel.MatchArgs, 2)
+		assert.Equal(t, "Mask", sel.MatchArgs[1].Operator)
+	}
+	assert.Equal(t, []string{"2"}, perm.Selectors[0].MatchArgs[1].Values)
+	assert.Equal(t, []string{"/etc/sudoers.d/", "/etc/pam.d/", "/etc/security/"}, perm.Selectors[1].MatchArgs[0].Values)
+	// only the reads of the shadow files exclude the authenticators
+	assert.NotContains(t, perm.Selectors[0].MatchBinaries[0].Values, "/usr/sbin/sshd")
+	assert.Contains(t, perm.Selectors[3].MatchBinaries[0].Values, "/usr/sbin/sshd")
+	assert.Equal(t, []string{"4"}, perm.Selectors[3].MatchArgs[1].Values)
+
+	rename := spec.KProbes[1]
+	assert.Equal(t, "security_inode_rename", rename.Call)
+	require.Len(t, rename.Selectors, 3)
+	assert.Equal(t, uint32(3), rename.Selectors[2].MatchArgs[0].Index)
+	assert.Equal(t, "Postfix", rename.Selectors[2].MatchArgs[0].Operator)
+
+	_, err = Policy(nil)
+	assert.NoError(t, err)
+	_, err = Policy([]string{"puppet"})
+	assert.Error(t, err)
+}
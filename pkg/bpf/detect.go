@@ -27,6 +27,8 @@ var (
 	kprobeMulti    Feature
 	buildid        Feature
 	modifyReturn   Feature
+	lsm            Feature
+	fentry         Feature
 )
 
 func detectOverrideHelper() bool {
@@ -145,7 +147,79 @@ func HasModifyReturn() bool {
 	return modifyReturn.detected
 }
 
+func detectLSM() bool {
+	prog, err := ebpf.NewProgram(&ebpf.ProgramSpec{
+		Name: "probe_lsm",
+		Type: ebpf.LSM,
+		Instructions: asm.Instructions{
+			asm.Mov.Imm(asm.R0, 0),
+			asm.Return(),
+		},
+		AttachType: ebpf.AttachLSMMac,
+		License:    "GPL",
+		AttachTo:   "file_open",
+	})
+	if err != nil {
+		return false
+	}
+	defer prog.Close()
+
+	// The program can be loaded even if bpf is not in the list of the
+	// active LSMs, in which case the attach fails.
+	link, err := link.AttachLSM(link.LSMOptions{
+		Program: prog,
+	})
+	if err != nil {
+		return false
+	}
+	link.Close()
+	return true
+}
+
+func HasLSMPrograms() bool {
+	lsm.init.Do(func() {
+		lsm.detected = detectLSM()
+	})
+	return lsm.detected
+}
+
+func detectFentry() bool {
+	prog, err := ebpf.NewProgram(&ebpf.ProgramSpec{
+		Name: "probe_fentry",
+		Type: ebpf.Tracing,
+		Instructions: asm.Instructions{
+			asm.Mov.Imm(asm.R0, 0),
+			asm.Return(),
+		},
+		AttachType: ebpf.AttachTraceFEntry,
+		License:    "GPL",
+		AttachTo:   "security_task_prctl",
+	})
+	if err != nil {
+		return false
+	}
+	defer prog.Close()
+
+	link, err := link.AttachTracing(link.TracingOptions{
+		Program: prog,
+	})
+	if err != nil {
+		return false
+	}
+	link.Close()
+	return true
+}
+
+// HasFentry returns true if fentry/fexit programs (BTF trampolines) are
+// supported.
+func HasFentry() bool {
+	fentry.init.Do(func() {
+		fentry.detected = detectFentry()
+	})
+	return fentry.detected
+}
+
 func LogFeatures() string {
-	return fmt.Sprintf("override_return: %t, buildid: %t, kprobe_multi: %t, fmodret: %t",
-		HasOverrideHelper(), HasBuildId(), HasKprobeMulti(), HasModifyReturn())
+	return fmt.Sprintf("override_return: %t, buildid: %t, kprobe_multi: %t, fmodret: %t, lsm: %t, fentry: %t",
+		HasOverrideHelper(), HasBuildId(), HasKprobeMulti(), HasModifyReturn(), HasLSMPrograms(), HasFentry())
 }
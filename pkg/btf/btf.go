@@ -9,6 +9,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/cilium/ebpf/btf"
@@ -155,3 +156,44 @@ func InitCachedBTF(lib, btf string) error {
 func GetCachedBTFFile() string {
 	return btfFile
 }
+
+// FuncParamsCount returns the number of parameters of the function call.
+func FuncParamsCount(spec *btf.Spec, call string) (int, error) {
+	var fn *btf.Func
+
+	if err := spec.TypeByName(call, &fn); err != nil {
+		return 0, fmt.Errorf("function %s not found in BTF: %w", call, err)
+	}
+	proto, ok := fn.Type.(*btf.FuncProto)
+	if !ok {
+		return 0, fmt.Errorf("proto for function %s not found", call)
+	}
+	return len(proto.Params), nil
+}
+
+// MatchFuncs returns the names of the functions of spec that match pattern,
+// a pattern of path.Match, sorted by name.
+func MatchFuncs(spec *btf.Spec, pattern string) ([]string, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+
+	seen := make(map[string]struct{})
+	var ret []string
+	iter := spec.Iterate()
+	for iter.Next() {
+		fn, ok := iter.Type.(*btf.Func)
+		if !ok {
+			continue
+		}
+		if _, ok := seen[fn.Name]; ok {
+			continue
+		}
+		if ok, _ := path.Match(pattern, fn.Name); ok {
+			seen[fn.Name] = struct{}{}
+			ret = append(ret, fn.Name)
+		}
+	}
+	sort.Strings(ret)
+	return ret, nil
+}
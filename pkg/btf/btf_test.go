@@ -4,11 +4,13 @@
 package btf
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/cilium/ebpf/btf"
 	"github.com/cilium/tetragon/pkg/defaults"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/sys/unix"
@@ -161,3 +163,53 @@ func TestInitCachedBTF(t *testing.T) {
 		assert.EqualValues(t, defaults.DefaultBTFFile, btffile, "GetCachedBTFFile()  -  want:'%s'  - got:'%s'", defaults.DefaultBTFFile, btffile)
 	}
 }
+
+func TestFuncParamsCount(t *testing.T) {
+	arg := &btf.Int{Name: "int", Size: 4, Encoding: btf.Signed}
+	proto := &btf.FuncProto{
+		Return: arg,
+		Params: []btf.FuncParam{{Name: "a", Type: arg}, {Name: "b", Type: arg}},
+	}
+	b, err := btf.NewBuilder([]btf.Type{&btf.Func{Name: "foo", Type: proto, Linkage: btf.GlobalFunc}})
+	assert.NoError(t, err)
+	raw, err := b.Marshal(nil, nil)
+	assert.NoError(t, err)
+	spec, err := btf.LoadSpecFromReader(bytes.NewReader(raw))
+	assert.NoError(t, err)
+
+	n, err := FuncParamsCount(spec, "foo")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	_, err = FuncParamsCount(spec, "bar")
+	assert.Error(t, err)
+}
+
+func TestMatchFuncs(t *testing.T) {
+	proto := &btf.FuncProto{Return: &btf.Int{Name: "int", Size: 4, Encoding: btf.Signed}}
+	var types []btf.Type
+	for _, name := range []string{"tcp_sendmsg", "tcp_close", "udp_sendmsg", "tcp_connect"} {
+		types = append(types, &btf.Func{Name: name, Type: proto, Linkage: btf.GlobalFunc})
+	}
+	b, err := btf.NewBuilder(types)
+	assert.NoError(t, err)
+	raw, err := b.Marshal(nil, nil)
+	assert.NoError(t, err)
+	spec, err := btf.LoadSpecFromReader(bytes.NewReader(raw))
+	assert.NoError(t, err)
+
+	funcs, err := MatchFuncs(spec, "tcp_*")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tcp_close", "tcp_connect", "tcp_sendmsg"}, funcs)
+
+	funcs, err = MatchFuncs(spec, "*_sendmsg")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tcp_sendmsg", "udp_sendmsg"}, funcs)
+
+	funcs, err = MatchFuncs(spec, "sctp_*")
+	assert.NoError(t, err)
+	assert.Empty(t, funcs)
+
+	_, err = MatchFuncs(spec, "tcp_[")
+	assert.Error(t, err)
+}
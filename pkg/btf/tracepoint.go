@@ -0,0 +1,212 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package btf
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cilium/ebpf/btf"
+)
+
+// TracepointField is a field of the entry of a tracepoint, i.e. the record
+// that tracepoint programs get as context. The fields have the names and the
+// layout of the fields of the format file of the tracepoint in tracefs.
+type TracepointField struct {
+	Name   string
+	Type   btf.Type
+	Offset uint32
+	Size   uint32
+}
+
+// TracepointFields returns the fields of the entry of a tracepoint, in the
+// order of the format file of the tracepoint in tracefs. The layout of the
+// entry is the trace_event_raw_<event> struct, or, for the tracepoints of
+// the syscalls subsystem, the syscall_trace_{enter,exit} structs.
+//
+// Events defined from an event class (DEFINE_EVENT) are not supported since
+// the name of their class cannot be found from BTF.
+func TracepointFields(spec *btf.Spec, subsys, event string) ([]TracepointField, error) {
+	if subsys == "syscalls" {
+		if name, ok := strings.CutPrefix(event, "sys_enter_"); ok {
+			return syscallEnterFields(spec, name)
+		}
+		if strings.HasPrefix(event, "sys_exit_") {
+			return syscallExitFields(spec)
+		}
+	}
+
+	var entry *btf.Struct
+	if err := spec.TypeByName("trace_event_raw_"+event, &entry); err != nil {
+		return nil, fmt.Errorf("tracepoint %s/%s not found in BTF: %w", subsys, event, err)
+	}
+
+	var ret []TracepointField
+	for _, m := range entry.Members {
+		switch m.Name {
+		case "ent":
+			fields, err := commonFields(m)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, fields...)
+		case "__data":
+			// dynamic data of the __data_loc fields
+		default:
+			field, err := tracepointField(m.Name, m.Type, m.Offset.Bytes())
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, field)
+		}
+	}
+	return ret, nil
+}
+
+// RawTracepointArgs returns the arguments of a raw tracepoint. The BTF type
+// of the raw tracepoint of event is the btf_trace_<event> typedef, a pointer
+// to a function whose first parameter is the private data of the tracepoint
+// and the next parameters are the arguments of the tracepoint.
+func RawTracepointArgs(spec *btf.Spec, event string) ([]btf.FuncParam, error) {
+	var typedef *btf.Typedef
+
+	name := "btf_trace_" + event
+	if err := spec.TypeByName(name, &typedef); err != nil {
+		return nil, fmt.Errorf("raw tracepoint %s not found in BTF: %w", event, err)
+	}
+	ptr, ok := typedef.Type.(*btf.Pointer)
+	if !ok {
+		return nil, fmt.Errorf("raw tracepoint %s: %s is not a pointer", event, name)
+	}
+	proto, ok := ptr.Target.(*btf.FuncProto)
+	if !ok || len(proto.Params) == 0 {
+		return nil, fmt.Errorf("raw tracepoint %s: %s is not a tracepoint prototype", event, name)
+	}
+	return proto.Params[1:], nil
+}
+
+func tracepointField(name string, ty btf.Type, off uint32) (TracepointField, error) {
+	size, err := btf.Sizeof(ty)
+	if err != nil {
+		return TracepointField{}, fmt.Errorf("field %s: %w", name, err)
+	}
+	return TracepointField{
+		Name:   name,
+		Type:   ty,
+		Offset: off,
+		Size:   uint32(size),
+	}, nil
+}
+
+// commonFields returns the fields of the trace_entry struct at the start of
+// every tracepoint entry. Their names are prefixed with common_, like in the
+// format files.
+func commonFields(ent btf.Member) ([]TracepointField, error) {
+	entry, ok := btf.UnderlyingType(ent.Type).(*btf.Struct)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type of tracepoint entry header: %s", ent.Type)
+	}
+
+	ret := make([]TracepointField, 0, len(entry.Members))
+	for _, m := range entry.Members {
+		field, err := tracepointField("common_"+m.Name, m.Type, ent.Offset.Bytes()+m.Offset.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, field)
+	}
+	return ret, nil
+}
+
+// syscallEntry returns the common fields and the __syscall_nr field of the
+// entry of the syscall tracepoints, and the entry struct.
+func syscallEntry(spec *btf.Spec, name string) ([]TracepointField, *btf.Struct, error) {
+	var entry *btf.Struct
+	if err := spec.TypeByName(name, &entry); err != nil {
+		return nil, nil, fmt.Errorf("syscall tracepoint entry %s not found in BTF: %w", name, err)
+	}
+
+	var ret []TracepointField
+	for _, m := range entry.Members {
+		switch m.Name {
+		case "ent":
+			fields, err := commonFields(m)
+			if err != nil {
+				return nil, nil, err
+			}
+			ret = append(ret, fields...)
+		case "nr":
+			field, err := tracepointField("__syscall_nr", m.Type, m.Offset.Bytes())
+			if err != nil {
+				return nil, nil, err
+			}
+			ret = append(ret, field)
+		}
+	}
+	return ret, entry, nil
+}
+
+// syscallEnterFields returns the fields of the entry of the sys_enter_<name>
+// tracepoint. The arguments of the syscall are stored as unsigned longs in
+// the args array of the entry, their names and types are the ones of the
+// parameters of the syscall function.
+func syscallEnterFields(spec *btf.Spec, name string) ([]TracepointField, error) {
+	ret, entry, err := syscallEntry(spec, "syscall_trace_enter")
+	if err != nil {
+		return nil, err
+	}
+
+	var args *btf.Member
+	for i := range entry.Members {
+		if entry.Members[i].Name == "args" {
+			args = &entry.Members[i]
+		}
+	}
+	if args == nil {
+		return nil, errors.New("syscall_trace_enter has no args field")
+	}
+
+	// __do_sys_<name> has the declared types of the parameters, but it
+	// is often inlined, __se_sys_<name> has the same parameters as longs.
+	var fn *btf.Func
+	if err := spec.TypeByName("__do_sys_"+name, &fn); err != nil {
+		if err := spec.TypeByName("__se_sys_"+name, &fn); err != nil {
+			return nil, fmt.Errorf("syscall %s not found in BTF: %w", name, err)
+		}
+	}
+	proto, ok := fn.Type.(*btf.FuncProto)
+	if !ok {
+		return nil, fmt.Errorf("proto for syscall %s not found", name)
+	}
+
+	for i, param := range proto.Params {
+		ret = append(ret, TracepointField{
+			Name:   param.Name,
+			Type:   param.Type,
+			Offset: args.Offset.Bytes() + uint32(i)*8,
+			Size:   8,
+		})
+	}
+	return ret, nil
+}
+
+// syscallExitFields returns the fields of the entry of the sys_exit_<name>
+// tracepoints.
+func syscallExitFields(spec *btf.Spec) ([]TracepointField, error) {
+	ret, entry, err := syscallEntry(spec, "syscall_trace_exit")
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range entry.Members {
+		if m.Name == "ret" {
+			field, err := tracepointField("ret", m.Type, m.Offset.Bytes())
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, field)
+		}
+	}
+	return ret, nil
+}
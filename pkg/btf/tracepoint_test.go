@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package btf
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cilium/ebpf/btf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracepointFieldsSyscalls(t *testing.T) {
+	u16 := &btf.Int{Name: "short unsigned int", Size: 2}
+	u8 := &btf.Int{Name: "unsigned char", Size: 1}
+	intTy := &btf.Int{Name: "int", Size: 4, Encoding: btf.Signed}
+	long := &btf.Int{Name: "long int", Size: 8, Encoding: btf.Signed}
+	ulong := &btf.Int{Name: "long unsigned int", Size: 8}
+	entry := &btf.Struct{Name: "trace_entry", Size: 8, Members: []btf.Member{
+		{Name: "type", Type: u16, Offset: 0},
+		{Name: "flags", Type: u8, Offset: 16},
+		{Name: "preempt_count", Type: u8, Offset: 24},
+		{Name: "pid", Type: intTy, Offset: 32},
+	}}
+	enter := &btf.Struct{Name: "syscall_trace_enter", Size: 16, Members: []btf.Member{
+		{Name: "ent", Type: entry, Offset: 0},
+		{Name: "nr", Type: intTy, Offset: 64},
+		{Name: "args", Type: &btf.Array{Index: intTy, Type: ulong, Nelems: 0}, Offset: 128},
+	}}
+	exit := &btf.Struct{Name: "syscall_trace_exit", Size: 24, Members: []btf.Member{
+		{Name: "ent", Type: entry, Offset: 0},
+		{Name: "nr", Type: intTy, Offset: 64},
+		{Name: "ret", Type: long, Offset: 128},
+	}}
+	lseek := &btf.Func{Name: "__se_sys_lseek", Linkage: btf.StaticFunc, Type: &btf.FuncProto{
+		Return: long,
+		Params: []btf.FuncParam{{Name: "fd", Type: long}, {Name: "offset", Type: long}, {Name: "whence", Type: long}},
+	}}
+	b, err := btf.NewBuilder([]btf.Type{enter, exit, lseek})
+	require.NoError(t, err)
+	raw, err := b.Marshal(nil, nil)
+	require.NoError(t, err)
+	spec, err := btf.LoadSpecFromReader(bytes.NewReader(raw))
+	require.NoError(t, err)
+
+	fields, err := TracepointFields(spec, "syscalls", "sys_enter_lseek")
+	require.NoError(t, err)
+	names := []string{}
+	offsets := []uint32{}
+	for _, f := range fields {
+		names = append(names, f.Name)
+		offsets = append(offsets, f.Offset)
+	}
+	assert.Equal(t, []string{"common_type", "common_flags", "common_preempt_count", "common_pid", "__syscall_nr", "fd", "offset", "whence"}, names)
+	assert.Equal(t, []uint32{0, 2, 3, 4, 8, 16, 24, 32}, offsets)
+
+	fields, err = TracepointFields(spec, "syscalls", "sys_exit_lseek")
+	require.NoError(t, err)
+	require.Len(t, fields, 6)
+	assert.Equal(t, "ret", fields[5].Name)
+	assert.Equal(t, uint32(16), fields[5].Offset)
+	assert.Equal(t, uint32(8), fields[5].Size)
+
+	_, err = TracepointFields(spec, "syscalls", "sys_enter_foo")
+	assert.Error(t, err)
+	_, err = TracepointFields(spec, "sched", "sched_switch")
+	assert.Error(t, err)
+}
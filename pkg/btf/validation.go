@@ -11,6 +11,7 @@ import (
 	"github.com/cilium/ebpf/btf"
 	"github.com/cilium/tetragon/pkg/arch"
 	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"github.com/cilium/tetragon/pkg/ksyms"
 	"github.com/cilium/tetragon/pkg/syscallinfo"
 )
 
@@ -35,17 +36,32 @@ func (e *ValidationFailed) Error() string {
 	return e.s
 }
 
+// maxSuggestions is the maximum number of alternative calls reported when a
+// call cannot be probed
+const maxSuggestions = 5
+
 // ValidateKprobeSpec validates a kprobe spec based on BTF information
 //
 // NB: turns out we need more than BTF information for the validation (see
 // syscalls). We still keep this code in the btf package for now, and we can
 // move it once we found a better home for it.
-func ValidateKprobeSpec(bspec *btf.Spec, call string, kspec *v1alpha1.KProbeSpec) error {
+//
+// If ks is not nil, the call is also checked against the kernel symbols. Calls
+// that are part of BTF but have no symbol (e.g., because they were inlined in
+// all their callers or were discarded after boot as __init functions) cannot
+// be probed. In both cases the error includes alternative calls, if any.
+func ValidateKprobeSpec(bspec *btf.Spec, call string, kspec *v1alpha1.KProbeSpec, ks *ksyms.Ksyms) error {
 	var fn *btf.Func
 
 	err := bspec.TypeByName(call, &fn)
 	if err != nil {
-		return &ValidationFailed{s: fmt.Sprintf("call %q not found", call)}
+		return &ValidationFailed{s: fmt.Sprintf("call %q not found%s", call,
+			formatSuggestions(suggestAlternatives(bspec, ks, call)))}
+	}
+
+	if ks != nil && !ks.IsFunction(call) {
+		return &ValidationFailed{s: fmt.Sprintf("call %q is not available for probing (inlined or __init function)%s", call,
+			formatSuggestions(suggestAlternatives(bspec, ks, call)))}
 	}
 
 	proto, ok := fn.Type.(*btf.FuncProto)
@@ -123,6 +139,54 @@ func ValidateKprobeSpec(bspec *btf.Spec, call string, kspec *v1alpha1.KProbeSpec
 	return nil
 }
 
+// suggestAlternatives returns calls that can be probed instead of call: the
+// compiler-generated clones of call (if ks is not nil), and common wrappers or
+// helpers of call (e.g., __call, do_call, call_common). The callers and
+// callees of call are not suggested, since neither BTF nor kallsyms describe
+// the call graph; the suggestions are derived from the names only.
+func suggestAlternatives(bspec *btf.Spec, ks *ksyms.Ksyms, call string) []string {
+	var ret []string
+
+	available := func(name string) bool {
+		if ks != nil {
+			return ks.IsFunction(name)
+		}
+		var fn *btf.Func
+		return bspec.TypeByName(name, &fn) == nil
+	}
+
+	if ks != nil {
+		ret = append(ret, ks.FunctionClones(call)...)
+	}
+
+	base := strings.TrimPrefix(strings.TrimLeft(call, "_"), "do_")
+	candidates := []string{
+		base,
+		"__" + base,
+		"do_" + base,
+		"__do_" + base,
+		base + "_common",
+	}
+	for _, c := range candidates {
+		if c == call || !available(c) {
+			continue
+		}
+		ret = append(ret, c)
+	}
+
+	if len(ret) > maxSuggestions {
+		ret = ret[:maxSuggestions]
+	}
+	return ret
+}
+
+func formatSuggestions(suggestions []string) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (possible alternatives: %s)", strings.Join(suggestions, ", "))
+}
+
 func getKernelType(arg btf.Type) string {
 	suffix := ""
 	ptr, ok := arg.(*btf.Pointer)
@@ -179,6 +243,16 @@ func typesCompatible(specTy string, kernelTy string) bool {
 		case "struct path *":
 			return true
 		}
+	case "dentry":
+		switch kernelTy {
+		case "struct dentry *":
+			return true
+		}
+	case "socket":
+		switch kernelTy {
+		case "struct socket *":
+			return true
+		}
 	case "bpf_attr":
 		switch kernelTy {
 		case "union bpf_attr *":
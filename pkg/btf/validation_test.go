@@ -4,14 +4,17 @@
 package btf
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/cilium/ebpf/btf"
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
 	"github.com/cilium/tetragon/pkg/tracingpolicy"
 )
 
@@ -72,7 +75,7 @@ func TestSpecs(t *testing.T) {
 			}
 			spec := tp.TpSpec()
 			for ki := range spec.KProbes {
-				err = ValidateKprobeSpec(btf, spec.KProbes[ki].Call, &spec.KProbes[ki])
+				err = ValidateKprobeSpec(btf, spec.KProbes[ki].Call, &spec.KProbes[ki], nil)
 				if checkErr := testFiles[fi].checkFn(t, err); checkErr != nil {
 					t.Fatal(checkErr)
 				}
@@ -80,3 +83,36 @@ func TestSpecs(t *testing.T) {
 		})
 	}
 }
+
+func TestSuggestAlternatives(t *testing.T) {
+	proto := &btf.FuncProto{Return: &btf.Void{}}
+	var types []btf.Type
+	for _, name := range []string{"do_foo", "foo_common", "bar"} {
+		types = append(types, &btf.Func{Name: name, Type: proto, Linkage: btf.GlobalFunc})
+	}
+	b, err := btf.NewBuilder(types)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := b.Marshal(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec, err := btf.LoadSpecFromReader(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	suggestions := suggestAlternatives(spec, nil, "__foo")
+	if len(suggestions) != 2 || suggestions[0] != "do_foo" || suggestions[1] != "foo_common" {
+		t.Errorf("unexpected suggestions: %v", suggestions)
+	}
+	if suggestions := suggestAlternatives(spec, nil, "baz"); len(suggestions) != 0 {
+		t.Errorf("unexpected suggestions: %v", suggestions)
+	}
+
+	err = ValidateKprobeSpec(spec, "__foo", &v1alpha1.KProbeSpec{Call: "__foo"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "possible alternatives: do_foo, foo_common") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
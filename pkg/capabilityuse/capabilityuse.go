@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package capabilityuse provides the built-in capability-use policy. The
+// capabilities of a process only tell what it is allowed to do, the policy
+// hooks cap_capable, that the kernel calls when a privileged operation checks
+// a capability, to report the capabilities that processes actually use. The
+// Recorder aggregates these events per workload into capability reports.
+package capabilityuse
+
+import (
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/tracingpolicy"
+)
+
+// PolicyName is the name of the built-in capability-use policy.
+const PolicyName = "capability-use"
+
+// The policy only reports the checks that granted the capability
+// (cap_capable returned 0), and each capability at most once a minute per
+// process and user namespace.
+const policy = `apiVersion: cilium.io/v1alpha1
+kind: TracingPolicy
+metadata:
+  name: "` + PolicyName + `"
+spec:
+  kprobes:
+  - call: "cap_capable"
+    syscall: false
+    return: true
+    args:
+    - index: 1
+      type: "user_namespace"
+    - index: 2
+      type: "capability"
+    returnArg:
+      index: 0
+      type: "int"
+    selectors:
+    - matchReturnArgs:
+      - index: 0
+        operator: "Equal"
+        values:
+        - "0"
+      matchActions:
+      - action: Post
+        rateLimit: "1m"
+        rateLimitScope: "process"
+`
+
+// Policy returns the built-in capability-use policy.
+func Policy() (tracingpolicy.TracingPolicy, error) {
+	return tracingpolicy.FromYAML(policy)
+}
+
+// Capability returns the capability that a process used, if the event was
+// generated by the capability-use policy.
+func Capability(ev *tetragon.ProcessKprobe) (tetragon.CapabilitiesType, bool) {
+	if ev.GetPolicyName() != PolicyName || ev.GetFunctionName() != "cap_capable" {
+		return 0, false
+	}
+	for _, arg := range ev.GetArgs() {
+		if c := arg.GetCapabilityArg(); c != nil && c.Value != nil {
+			v := c.Value.GetValue()
+			if _, ok := tetragon.CapabilitiesType_name[v]; !ok {
+				return 0, false
+			}
+			return tetragon.CapabilitiesType(v), true
+		}
+	}
+	return 0, false
+}
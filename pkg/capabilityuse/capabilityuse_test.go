@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package capabilityuse
+
+import (
+	"testing"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestPolicy(t *testing.T) {
+	tp, err := Policy()
+	require.NoError(t, err)
+	assert.Equal(t, PolicyName, tp.TpName())
+	spec := tp.TpSpec()
+	require.Len(t, spec.KProbes, 1)
+	assert.Equal(t, "cap_capable", spec.KProbes[0].Call)
+}
+
+func TestCapability(t *testing.T) {
+	capArg := func(v int32) *tetragon.KprobeArgument {
+		return &tetragon.KprobeArgument{Arg: &tetragon.KprobeArgument_CapabilityArg{
+			CapabilityArg: &tetragon.KprobeCapability{Value: wrapperspb.Int32(v)},
+		}}
+	}
+	ev := &tetragon.ProcessKprobe{
+		PolicyName:   PolicyName,
+		FunctionName: "cap_capable",
+		Args: []*tetragon.KprobeArgument{
+			{Arg: &tetragon.KprobeArgument_UserNsArg{UserNsArg: &tetragon.UserNamespace{}}},
+			capArg(int32(tetragon.CapabilitiesType_CAP_NET_RAW)),
+		},
+	}
+	c, ok := Capability(ev)
+	assert.True(t, ok)
+	assert.Equal(t, tetragon.CapabilitiesType_CAP_NET_RAW, c)
+
+	// events of other policies
+	ev.PolicyName = "other"
+	_, ok = Capability(ev)
+	assert.False(t, ok)
+
+	// unknown capabilities
+	ev.PolicyName = PolicyName
+	ev.Args[1] = capArg(100)
+	_, ok = Capability(ev)
+	assert.False(t, ok)
+}
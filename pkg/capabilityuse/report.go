@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package capabilityuse
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// workloadKey identifies a workload: a container of the pods of a Kubernetes
+// workload, or a binary for the processes that are not in pods.
+type workloadKey struct {
+	namespace    string
+	workload     string
+	workloadKind string
+	container    string
+	binary       string
+}
+
+func keyOf(proc *tetragon.Process) workloadKey {
+	pod := proc.GetPod()
+	if pod == nil {
+		return workloadKey{binary: proc.GetBinary()}
+	}
+	workload := pod.GetWorkload()
+	if workload == "" {
+		workload = pod.GetName()
+	}
+	return workloadKey{
+		namespace:    pod.GetNamespace(),
+		workload:     workload,
+		workloadKind: pod.GetWorkloadKind(),
+		container:    pod.GetContainer().GetName(),
+	}
+}
+
+// Recorder aggregates the events of the capability-use policy per workload,
+// to report the capabilities that each workload used during a window.
+type Recorder struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu sync.Mutex
+	// last time each workload used each capability
+	workloads map[workloadKey]map[tetragon.CapabilitiesType]time.Time
+}
+
+// NewRecorder returns a recorder that keeps the capabilities used during
+// window.
+func NewRecorder(window time.Duration) *Recorder {
+	return &Recorder{
+		window:    window,
+		now:       time.Now,
+		workloads: make(map[workloadKey]map[tetragon.CapabilitiesType]time.Time),
+	}
+}
+
+// Notify records the capability of the events of the capability-use policy.
+// It implements server.Listener.
+func (r *Recorder) Notify(res *tetragon.GetEventsResponse) {
+	kprobe := res.GetProcessKprobe()
+	if kprobe == nil {
+		return
+	}
+	c, ok := Capability(kprobe)
+	if !ok {
+		return
+	}
+	t := r.now()
+	if ts := res.GetTime(); ts != nil {
+		t = ts.AsTime()
+	}
+	k := keyOf(kprobe.GetProcess())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	caps, ok := r.workloads[k]
+	if !ok {
+		caps = make(map[tetragon.CapabilitiesType]time.Time)
+		r.workloads[k] = caps
+	}
+	if t.After(caps[c]) {
+		caps[c] = t
+	}
+}
+
+// Report returns the capabilities that the workloads of namespace, or all
+// workloads if namespace is empty, used during window. Windows that are
+// unset or longer than the window of the recorder are set to it.
+func (r *Recorder) Report(window time.Duration, namespace string) *tetragon.GetCapabilityReportResponse {
+	if window <= 0 || window > r.window {
+		window = r.window
+	}
+	now := r.now()
+	since := now.Add(-window)
+	expired := now.Add(-r.window)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res := &tetragon.GetCapabilityReportResponse{Window: durationpb.New(window)}
+	for k, caps := range r.workloads {
+		var report *tetragon.CapabilityReport
+		var lastUsed time.Time
+		for c, t := range caps {
+			if t.Before(expired) {
+				delete(caps, c)
+				continue
+			}
+			if t.Before(since) || (namespace != "" && k.namespace != namespace) {
+				continue
+			}
+			if report == nil {
+				report = &tetragon.CapabilityReport{
+					Namespace:    k.namespace,
+					Workload:     k.workload,
+					WorkloadKind: k.workloadKind,
+					Container:    k.container,
+					Binary:       k.binary,
+				}
+			}
+			report.Capabilities = append(report.Capabilities, c)
+			if t.After(lastUsed) {
+				lastUsed = t
+			}
+		}
+		if len(caps) == 0 {
+			delete(r.workloads, k)
+		}
+		if report == nil {
+			continue
+		}
+		sort.Slice(report.Capabilities, func(i, j int) bool {
+			return report.Capabilities[i] < report.Capabilities[j]
+		})
+		report.LastUsed = timestamppb.New(lastUsed)
+		res.Reports = append(res.Reports, report)
+	}
+	sort.Slice(res.Reports, func(i, j int) bool {
+		a, b := res.Reports[i], res.Reports[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Workload != b.Workload {
+			return a.Workload < b.Workload
+		}
+		if a.Container != b.Container {
+			return a.Container < b.Container
+		}
+		return a.Binary < b.Binary
+	})
+	return res
+}
+
+var recorder atomic.Pointer[Recorder]
+
+// SetRecorder sets the recorder that GetRecorder returns.
+func SetRecorder(r *Recorder) {
+	recorder.Store(r)
+}
+
+// GetRecorder returns the recorder of the agent, or nil if capability-use
+// reports are disabled.
+func GetRecorder() *Recorder {
+	return recorder.Load()
+}
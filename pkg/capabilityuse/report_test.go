@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package capabilityuse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func capabilityEvent(proc *tetragon.Process, c tetragon.CapabilitiesType, t time.Time) *tetragon.GetEventsResponse {
+	return &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessKprobe{ProcessKprobe: &tetragon.ProcessKprobe{
+			Process:      proc,
+			PolicyName:   PolicyName,
+			FunctionName: "cap_capable",
+			Args: []*tetragon.KprobeArgument{
+				{Arg: &tetragon.KprobeArgument_CapabilityArg{
+					CapabilityArg: &tetragon.KprobeCapability{Value: wrapperspb.Int32(int32(c))},
+				}},
+			},
+		}},
+		Time: timestamppb.New(t),
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	now := time.Unix(100000, 0)
+	r := NewRecorder(time.Hour)
+	r.now = func() time.Time { return now }
+
+	web := &tetragon.Process{Binary: "/usr/sbin/nginx", Pod: &tetragon.Pod{
+		Namespace:    "default",
+		Name:         "web-5d8f7-abcde",
+		Workload:     "web",
+		WorkloadKind: "Deployment",
+		Container:    &tetragon.Container{Name: "nginx"},
+	}}
+	host := &tetragon.Process{Binary: "/usr/bin/ping"}
+
+	r.Notify(capabilityEvent(web, tetragon.CapabilitiesType_CAP_SETUID, now.Add(-10*time.Minute)))
+	r.Notify(capabilityEvent(web, tetragon.CapabilitiesType_CAP_NET_BIND_SERVICE, now.Add(-30*time.Minute)))
+	r.Notify(capabilityEvent(web, tetragon.CapabilitiesType_CAP_SETUID, now.Add(-20*time.Minute)))
+	r.Notify(capabilityEvent(host, tetragon.CapabilitiesType_CAP_NET_RAW, now.Add(-2*time.Hour)))
+	// events of other policies are ignored
+	other := capabilityEvent(host, tetragon.CapabilitiesType_CAP_SYS_ADMIN, now)
+	other.GetProcessKprobe().PolicyName = "other"
+	r.Notify(other)
+
+	res := r.Report(0, "")
+	assert.Equal(t, time.Hour, res.Window.AsDuration())
+	require.Len(t, res.Reports, 1)
+	report := res.Reports[0]
+	assert.Equal(t, "default", report.Namespace)
+	assert.Equal(t, "web", report.Workload)
+	assert.Equal(t, "Deployment", report.WorkloadKind)
+	assert.Equal(t, "nginx", report.Container)
+	assert.Equal(t, []tetragon.CapabilitiesType{
+		tetragon.CapabilitiesType_CAP_SETUID,
+		tetragon.CapabilitiesType_CAP_NET_BIND_SERVICE,
+	}, report.Capabilities)
+	assert.True(t, now.Add(-10*time.Minute).Equal(report.LastUsed.AsTime()))
+
+	// the capabilities older than the recorder window were dropped
+	assert.Len(t, r.workloads, 1)
+
+	// shorter windows
+	res = r.Report(15*time.Minute, "")
+	require.Len(t, res.Reports, 1)
+	assert.Equal(t, []tetragon.CapabilitiesType{tetragon.CapabilitiesType_CAP_SETUID}, res.Reports[0].Capabilities)
+
+	// namespaces
+	assert.Empty(t, r.Report(0, "kube-system").Reports)
+	assert.Len(t, r.Report(0, "default").Reports, 1)
+}
+
+func TestRecorderHost(t *testing.T) {
+	now := time.Unix(100000, 0)
+	r := NewRecorder(time.Hour)
+	r.now = func() time.Time { return now }
+
+	host := &tetragon.Process{Binary: "/usr/bin/ping"}
+	r.Notify(capabilityEvent(host, tetragon.CapabilitiesType_CAP_NET_RAW, now))
+
+	res := r.Report(0, "")
+	require.Len(t, res.Reports, 1)
+	assert.Equal(t, "/usr/bin/ping", res.Reports[0].Binary)
+	assert.Empty(t, res.Reports[0].Namespace)
+}
@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package configsnapshot sends snapshots of the configuration of the agent:
+// its version, flags, the running kernel and the loaded tracing policies.
+// Snapshots are sent periodically and when the configuration changes, so that
+// the exported events can be interpreted against the configuration that was
+// active when they were observed.
+package configsnapshot
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/observer"
+	"github.com/cilium/tetragon/pkg/process"
+	"github.com/cilium/tetragon/pkg/reader/node"
+	"github.com/cilium/tetragon/pkg/reader/notify"
+	"github.com/cilium/tetragon/pkg/version"
+	"github.com/spf13/viper"
+	"golang.org/x/sys/unix"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxCheckInterval is the maximum interval at which the configuration is
+// checked for changes.
+const maxCheckInterval = 10 * time.Second
+
+// policyLister is implemented by the sensor manager.
+type policyLister interface {
+	ListTracingPolicies(ctx context.Context) (*tetragon.ListTracingPoliciesResponse, error)
+}
+
+// Snapshotter sends the snapshots of the configuration of the agent.
+type Snapshotter struct {
+	policies policyLister
+	interval time.Duration
+	// flags returns the flags of the agent
+	flags func() map[string]string
+	// push sends the snapshots to the listeners of the observer
+	push func(msg notify.Message)
+	// hash of the last snapshot sent, and when it was sent
+	lastHash string
+	lastSent time.Time
+}
+
+// NewSnapshotter returns a snapshotter that sends a snapshot every interval,
+// and when the configuration changes.
+func NewSnapshotter(policies policyLister, interval time.Duration) *Snapshotter {
+	return &Snapshotter{
+		policies: policies,
+		interval: interval,
+		flags:    agentFlags,
+		push:     observer.AllListeners,
+	}
+}
+
+// Run sends the snapshots until ctx is done. The first snapshot is sent
+// immediately.
+func (s *Snapshotter) Run(ctx context.Context) {
+	ticker := time.NewTicker(min(s.interval, maxCheckInterval))
+	defer ticker.Stop()
+
+	for {
+		s.check(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Snapshotter) check(ctx context.Context, now time.Time) {
+	snapshot, err := s.snapshot(ctx)
+	if err != nil {
+		logger.GetLogger().WithError(err).Warn("Failed to take a configuration snapshot")
+		return
+	}
+	if ev := s.update(snapshot, now); ev != nil {
+		s.push(&MsgConfigSnapshot{ConfigSnapshot: ev})
+	}
+}
+
+// update returns the snapshot to send, or nil if the configuration did not
+// change and the last snapshot was sent less than an interval ago.
+func (s *Snapshotter) update(snapshot *tetragon.ConfigSnapshot, now time.Time) *tetragon.ConfigSnapshot {
+	changed := snapshot.Hash != s.lastHash
+	if !changed && now.Sub(s.lastSent) < s.interval {
+		return nil
+	}
+	if changed && s.lastHash != "" {
+		logger.GetLogger().WithField("hash", snapshot.Hash).Info("Configuration changed")
+	}
+	snapshot.Changed = changed
+	s.lastHash = snapshot.Hash
+	s.lastSent = now
+	return snapshot
+}
+
+// snapshot returns the current configuration of the agent.
+func (s *Snapshotter) snapshot(ctx context.Context) (*tetragon.ConfigSnapshot, error) {
+	res, err := s.policies.ListTracingPolicies(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tracing policies: %w", err)
+	}
+
+	snapshot := &tetragon.ConfigSnapshot{
+		Version: version.Version,
+		Flags:   s.flags(),
+	}
+	var uts unix.Utsname
+	if err := unix.Uname(&uts); err == nil {
+		snapshot.KernelRelease = unix.ByteSliceToString(uts.Release[:])
+		snapshot.KernelVersion = unix.ByteSliceToString(uts.Version[:])
+		snapshot.Machine = unix.ByteSliceToString(uts.Machine[:])
+	}
+	for _, p := range res.GetPolicies() {
+		snapshot.Policies = append(snapshot.Policies, &tetragon.ConfigSnapshotPolicy{
+			Name:      p.GetName(),
+			Namespace: p.GetNamespace(),
+			Enabled:   p.GetEnabled(),
+			SpecYaml:  p.GetSpecYaml(),
+		})
+	}
+	sort.Slice(snapshot.Policies, func(i, j int) bool {
+		pi, pj := snapshot.Policies[i], snapshot.Policies[j]
+		if pi.Namespace != pj.Namespace {
+			return pi.Namespace < pj.Namespace
+		}
+		return pi.Name < pj.Name
+	})
+
+	hash, err := hashSnapshot(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Hash = hash
+	return snapshot, nil
+}
+
+// hashSnapshot returns the hash of the configuration of a snapshot.
+func hashSnapshot(snapshot *tetragon.ConfigSnapshot) (string, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(snapshot)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal configuration snapshot: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// agentFlags returns the flags of the agent, as read from the command line,
+// the environment and the configuration files.
+func agentFlags() map[string]string {
+	flags := make(map[string]string)
+	for _, k := range viper.AllKeys() {
+		flags[k] = fmt.Sprint(viper.Get(k))
+	}
+	return flags
+}
+
+// MsgConfigSnapshot is the message of a ConfigSnapshot event.
+type MsgConfigSnapshot struct {
+	ConfigSnapshot *tetragon.ConfigSnapshot
+}
+
+func (msg *MsgConfigSnapshot) Notify() bool {
+	return false
+}
+
+func (msg *MsgConfigSnapshot) RetryInternal(_ notify.Event, _ uint64) (*process.ProcessInternal, error) {
+	return nil, fmt.Errorf("Unsupported cache event MsgConfigSnapshot")
+}
+
+func (msg *MsgConfigSnapshot) Retry(_ *process.ProcessInternal, _ notify.Event) error {
+	return fmt.Errorf("Unsupported cache retry event MsgConfigSnapshot")
+}
+
+func (msg *MsgConfigSnapshot) HandleMessage() *tetragon.GetEventsResponse {
+	return &tetragon.GetEventsResponse{
+		Event:    &tetragon.GetEventsResponse_ConfigSnapshot{ConfigSnapshot: msg.ConfigSnapshot},
+		NodeName: node.GetNodeNameForExport(),
+		Time:     timestamppb.Now(),
+	}
+}
+
+func (msg *MsgConfigSnapshot) Cast(_ interface{}) notify.Message {
+	return &MsgConfigSnapshot{}
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package configsnapshot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/reader/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type dummyPolicyLister struct {
+	policies []*tetragon.TracingPolicyStatus
+}
+
+func (d *dummyPolicyLister) ListTracingPolicies(_ context.Context) (*tetragon.ListTracingPoliciesResponse, error) {
+	return &tetragon.ListTracingPoliciesResponse{Policies: d.policies}, nil
+}
+
+func TestSnapshotter(t *testing.T) {
+	ctx := context.Background()
+	policies := &dummyPolicyLister{
+		policies: []*tetragon.TracingPolicyStatus{
+			{Name: "b", Enabled: true, SpecYaml: "kprobes: []"},
+			{Name: "a", Namespace: "ns", SpecYaml: "tracepoints: []"},
+		},
+	}
+	var sent []*tetragon.ConfigSnapshot
+	s := NewSnapshotter(policies, time.Hour)
+	s.flags = func() map[string]string {
+		return map[string]string{"export-filename": "/var/log/tetragon/tetragon.log"}
+	}
+	s.push = func(msg notify.Message) {
+		sent = append(sent, msg.(*MsgConfigSnapshot).ConfigSnapshot)
+	}
+
+	now := time.Now()
+	s.check(ctx, now)
+	require.Len(t, sent, 1)
+	first := sent[0]
+	assert.True(t, first.Changed)
+	assert.NotEmpty(t, first.Hash)
+	assert.NotEmpty(t, first.KernelRelease)
+	assert.Equal(t, map[string]string{"export-filename": "/var/log/tetragon/tetragon.log"}, first.Flags)
+	require.Len(t, first.Policies, 2)
+	assert.Equal(t, "b", first.Policies[0].Name)
+	assert.True(t, first.Policies[0].Enabled)
+	assert.Equal(t, "a", first.Policies[1].Name)
+	assert.Equal(t, "ns", first.Policies[1].Namespace)
+
+	// unchanged configuration, sent once per interval
+	s.check(ctx, now.Add(time.Minute))
+	assert.Len(t, sent, 1)
+	s.check(ctx, now.Add(time.Hour))
+	require.Len(t, sent, 2)
+	assert.False(t, sent[1].Changed)
+	assert.Equal(t, first.Hash, sent[1].Hash)
+
+	// changed configuration, sent immediately
+	policies.policies[0].Enabled = false
+	s.check(ctx, now.Add(time.Hour+time.Minute))
+	require.Len(t, sent, 3)
+	assert.True(t, sent[2].Changed)
+	assert.NotEqual(t, first.Hash, sent[2].Hash)
+}
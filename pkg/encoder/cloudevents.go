@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package encoder
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/api/v1/tetragon/codegen/helpers"
+	"github.com/google/uuid"
+)
+
+const (
+	// CloudEventsSpecVersion is the version of the CloudEvents
+	// specification of the envelopes.
+	CloudEventsSpecVersion = "1.0"
+	// CloudEventsTypePrefix prefixes the event kinds in the types of the
+	// envelopes, e.g. io.cilium.tetragon.process_exec.
+	CloudEventsTypePrefix = "io.cilium.tetragon."
+)
+
+// CloudEventer wraps JSON encoded events in CloudEvents 1.0 envelopes, in the
+// structured content mode, so that event meshes like Knative or EventBridge
+// can route them by type, source and subject.
+type CloudEventer struct {
+	// Cluster is the name of the cluster in the sources of the events.
+	Cluster string
+
+	newID func() string
+}
+
+type cloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            string          `json:"time,omitempty"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// Wrap returns the envelope of event, whose JSON encoding is data. The type of
+// the envelope is the kind of the event, its source is the cluster and the
+// node of the event, and its subject is the namespace and the name of the pod
+// of the process of the event, if it has one.
+func (c *CloudEventer) Wrap(event *tetragon.GetEventsResponse, data []byte) ([]byte, error) {
+	id := c.newID
+	if id == nil {
+		id = uuid.NewString
+	}
+
+	ce := cloudEvent{
+		SpecVersion:     CloudEventsSpecVersion,
+		ID:              id(),
+		Source:          cloudEventSource(c.Cluster, event.GetNodeName()),
+		Type:            CloudEventsTypePrefix + eventKind(event),
+		DataContentType: "application/json",
+		Data:            data,
+	}
+	if pod := helpers.ResponseGetProcess(event).GetPod(); pod != nil {
+		ce.Subject = pod.GetNamespace() + "/" + pod.GetName()
+	}
+	if event.GetTime() != nil {
+		ce.Time = event.GetTime().AsTime().Format(time.RFC3339Nano)
+	}
+	return json.Marshal(&ce)
+}
+
+// cloudEventSource returns the source of the envelopes of the events of a
+// node, a URI reference.
+func cloudEventSource(cluster, node string) string {
+	if node == "" {
+		node = "unknown"
+	}
+	if cluster == "" {
+		return node
+	}
+	return cluster + "/" + node
+}
+
+// eventKind returns the name of the field of the event in GetEventsResponse,
+// e.g. process_exec.
+func eventKind(event *tetragon.GetEventsResponse) string {
+	msg := event.ProtoReflect()
+	field := msg.WhichOneof(msg.Descriptor().Oneofs().ByName("event"))
+	if field == nil {
+		return "unknown"
+	}
+	return string(field.Name())
+}
+
+// cloudEventPrefix starts the JSON encoding of the envelopes of CloudEventer.
+var cloudEventPrefix = []byte(`{"specversion":`)
+
+// UnwrapCloudEvent returns the event of line if it is a CloudEvents envelope
+// encoded by CloudEventer, or line otherwise.
+func UnwrapCloudEvent(line []byte) []byte {
+	if !bytes.HasPrefix(line, cloudEventPrefix) {
+		return line
+	}
+	var ce struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(line, &ce); err != nil || len(ce.Data) == 0 {
+		return line
+	}
+	return ce.Data
+}
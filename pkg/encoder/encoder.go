@@ -8,8 +8,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cilium/tetragon/api/v1/tetragon"
 	"github.com/cilium/tetragon/pkg/arch"
@@ -127,15 +130,39 @@ func (p *CompactEncoder) Encode(v interface{}) error {
 }
 
 type ProtojsonEncoder struct {
-	w io.Writer
+	w           io.Writer
+	time        timeFormatter
+	flatten     *Flattener
+	cloudEvents *CloudEventer
 }
 
 func NewProtojsonEncoder(w io.Writer) *ProtojsonEncoder {
+	return NewProtojsonEncoderWithTime(w, TimeFormatRFC3339, time.UTC)
+}
+
+// NewProtojsonEncoderWithTime returns a ProtojsonEncoder that encodes the
+// timestamps of events in the given format and location.
+func NewProtojsonEncoderWithTime(w io.Writer, format TimeFormat, location *time.Location) *ProtojsonEncoder {
 	return &ProtojsonEncoder{
-		w,
+		w:    w,
+		time: timeFormatter{format: format, location: location},
 	}
 }
 
+// WithFlattener sets the Flattener of the encoded events, nil keeps them
+// nested.
+func (p *ProtojsonEncoder) WithFlattener(f *Flattener) *ProtojsonEncoder {
+	p.flatten = f
+	return p
+}
+
+// WithCloudEvents sets the CloudEventer that wraps the encoded events in
+// CloudEvents envelopes, nil encodes the events alone.
+func (p *ProtojsonEncoder) WithCloudEvents(c *CloudEventer) *ProtojsonEncoder {
+	p.cloudEvents = c
+	return p
+}
+
 func (p *ProtojsonEncoder) Encode(v interface{}) error {
 	// TODO(WF): We may want to implement a streaming API here, similar to what they do in
 	// encoding/json. For now, I think this is probably fine though.
@@ -152,6 +179,24 @@ func (p *ProtojsonEncoder) Encode(v interface{}) error {
 	if err != nil {
 		return err
 	}
+	if !p.time.isDefault() {
+		out, err = p.time.rewrite(event, out)
+		if err != nil {
+			return err
+		}
+	}
+	if p.flatten != nil {
+		out, err = p.flatten.Flatten(out)
+		if err != nil {
+			return err
+		}
+	}
+	if p.cloudEvents != nil {
+		out, err = p.cloudEvents.Wrap(event, out)
+		if err != nil {
+			return err
+		}
+	}
 	fmt.Fprintln(p.w, string(out))
 	return nil
 }
@@ -209,6 +254,20 @@ func HumanStackTrace(response *tetragon.GetEventsResponse, colorer *Colorer) str
 				colorer.Yellow.Fprintf(out, "0x%x\n", st.Offset)
 			}
 		}
+		for _, st := range ev.ProcessKprobe.UserStackTrace {
+			colorer.Green.Fprintf(out, "   0x%x:", st.Address)
+			if st.Symbol != "" {
+				colorer.Blue.Fprintf(out, " %s", st.Symbol)
+			} else {
+				colorer.Blue.Fprintf(out, " %s", st.Module)
+			}
+			fmt.Fprintf(out, "+")
+			colorer.Yellow.Fprintf(out, "0x%x", st.Offset)
+			if st.Symbol != "" && st.Module != "" {
+				fmt.Fprintf(out, " (%s)", st.Module)
+			}
+			fmt.Fprintf(out, "\n")
+		}
 	}
 	return out.String()
 }
@@ -453,6 +512,41 @@ func (p *CompactEncoder) EventToString(response *tetragon.GetEventsResponse) (st
 			event := p.Colorer.Blue.Sprintf("❓ %-7s", "syscall")
 			return CapTrailorPrinter(fmt.Sprintf("%s %s %s", event, processInfo, kprobe.FunctionName), caps), nil
 		}
+	case *tetragon.GetEventsResponse_ProcessKprobeCount:
+		count := response.GetProcessKprobeCount()
+		if count.Process == nil {
+			return "", ErrMissingProcessInfo
+		}
+		event := p.Colorer.Blue.Sprintf("🔢 %-7s", "count")
+		processInfo, caps := p.Colorer.ProcessInfo(response.NodeName, count.Process)
+		calls := p.Colorer.Cyan.Sprint(count.Count, " calls")
+		return CapTrailorPrinter(fmt.Sprintf("%s %s %s %s", event, processInfo, count.FunctionName, calls), caps), nil
+	case *tetragon.GetEventsResponse_MiningSuspected:
+		mining := response.GetMiningSuspected()
+		if mining.Process == nil {
+			return "", ErrMissingProcessInfo
+		}
+		event := p.Colorer.Red.Sprintf("⛏️ %-7s", "mining")
+		processInfo, caps := p.Colorer.ProcessInfo(response.NodeName, mining.Process)
+		conf := p.Colorer.Cyan.Sprintf("confidence %.2f", mining.Confidence)
+		return CapTrailorPrinter(fmt.Sprintf("%s %s %s", event, processInfo, conf), caps), nil
+	case *tetragon.GetEventsResponse_SshConnection:
+		conn := response.GetSshConnection()
+		if conn.Process == nil {
+			return "", ErrMissingProcessInfo
+		}
+		event := p.Colorer.Magenta.Sprintf("🔑 %-7s", conn.Client)
+		processInfo, caps := p.Colorer.ProcessInfo(response.NodeName, conn.Process)
+		dest := conn.DestinationHost
+		if conn.User != "" {
+			dest = conn.User + "@" + dest
+		}
+		addr := net.JoinHostPort(conn.DestinationAddress, strconv.FormatUint(uint64(conn.DestinationPort), 10))
+		return CapTrailorPrinter(fmt.Sprintf("%s %s %s (%s)", event, processInfo, dest, addr), caps), nil
+	case *tetragon.GetEventsResponse_LostEvent:
+		lost := response.GetLostEvent()
+		event := p.Colorer.Red.Sprintf("⚠️ %-7s", "lost")
+		return fmt.Sprintf("%s %d events on CPU %d (%s)", event, lost.Count, lost.Cpu, lost.Transport), nil
 	case *tetragon.GetEventsResponse_ProcessTracepoint:
 		tp := response.GetProcessTracepoint()
 		if tp.Process == nil {
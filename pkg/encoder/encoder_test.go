@@ -8,10 +8,12 @@ import (
 	"encoding/json"
 	"os"
 	"testing"
+	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/cilium/tetragon/api/v1/tetragon"
 	"github.com/sryoya/protorand"
@@ -432,6 +434,38 @@ func TestCompactEncoder_KprobeBPFMapAllocEventToString(t *testing.T) {
 	assert.Equal(t, "🗺 bpf_map_create kube-system/tetragon /usr/bin/bpftool BPF_MAP_TYPE_HASH amazing-map key size 8 value size 8 max entries 1024", result)
 }
 
+func TestCompactEncoder_KprobeCountEventToString(t *testing.T) {
+	p := NewCompactEncoder(os.Stdout, Never, false, false)
+
+	// should fail without process field
+	_, err := p.EventToString(&tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessKprobeCount{
+			ProcessKprobeCount: &tetragon.ProcessKprobeCount{
+				FunctionName: "__kmalloc",
+			},
+		},
+	})
+	assert.Error(t, err)
+
+	result, err := p.EventToString(&tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessKprobeCount{
+			ProcessKprobeCount: &tetragon.ProcessKprobeCount{
+				Process: &tetragon.Process{
+					Binary: "/usr/bin/curl",
+					Pod: &tetragon.Pod{
+						Namespace: "kube-system",
+						Name:      "tetragon",
+					},
+				},
+				FunctionName: "__kmalloc",
+				Count:        1234,
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "🔢 count   kube-system/tetragon /usr/bin/curl __kmalloc 1234 calls", result)
+}
+
 func TestCompactEncoder_Encode(t *testing.T) {
 	var b bytes.Buffer
 	p := NewCompactEncoder(&b, Never, false, false)
@@ -535,3 +569,237 @@ func FuzzProtojsonCompatibility(f *testing.F) {
 		assert.True(t, proto.Equal(msg, msgProtojson))
 	})
 }
+
+func TestProtojsonEncoder_TimeFormat(t *testing.T) {
+	start := time.Date(2023, 10, 1, 12, 30, 0, 500000000, time.UTC)
+	ev := &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessExec{
+			ProcessExec: &tetragon.ProcessExec{
+				Process: &tetragon.Process{
+					Binary:    "/usr/bin/curl",
+					Arguments: "2023-10-01T12:30:01Z",
+					StartTime: timestamppb.New(start),
+				},
+			},
+		},
+		Time: timestamppb.New(start.Add(time.Second)),
+	}
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+
+	tests := []struct {
+		format    TimeFormat
+		location  *time.Location
+		startTime interface{}
+		time      interface{}
+	}{
+		{TimeFormatRFC3339, time.UTC, "2023-10-01T12:30:00.500Z", "2023-10-01T12:30:01.500Z"},
+		{TimeFormatRFC3339, paris, "2023-10-01T14:30:00.5+02:00", "2023-10-01T14:30:01.5+02:00"},
+		{TimeFormatRFC3339Nano, time.UTC, "2023-10-01T12:30:00.500000000Z", "2023-10-01T12:30:01.500000000Z"},
+		{TimeFormatUnixNano, time.UTC, "1696163400500000000", "1696163401500000000"},
+	}
+	for _, test := range tests {
+		var b bytes.Buffer
+		p := NewProtojsonEncoderWithTime(&b, test.format, test.location)
+		require.NoError(t, p.Encode(ev))
+
+		var out map[string]interface{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), &out), b.String())
+		assert.Equal(t, test.time, out["time"], test.format)
+		process := out["process_exec"].(map[string]interface{})["process"].(map[string]interface{})
+		assert.Equal(t, test.startTime, process["start_time"], test.format)
+		// other strings are left untouched
+		assert.Equal(t, "2023-10-01T12:30:01Z", process["arguments"], test.format)
+	}
+
+	_, err = ParseTimeFormat("rfc822")
+	assert.Error(t, err)
+}
+
+func TestProtojsonEncoder_Flatten(t *testing.T) {
+	ev := &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessKprobe{
+			ProcessKprobe: &tetragon.ProcessKprobe{
+				Process: &tetragon.Process{
+					Binary: "/usr/bin/cat",
+					Pid:    wrapperspb.UInt32(42),
+					Pod:    &tetragon.Pod{Namespace: "default", Name: "xwing"},
+				},
+				FunctionName: "fd_install",
+				Args: []*tetragon.KprobeArgument{
+					{Arg: &tetragon.KprobeArgument_IntArg{IntArg: 3}},
+					{Arg: &tetragon.KprobeArgument_FileArg{FileArg: &tetragon.KprobeFile{Path: "/etc/passwd"}}},
+				},
+			},
+		},
+		NodeName: "node",
+	}
+
+	encode := func(f *Flattener) map[string]interface{} {
+		var b bytes.Buffer
+		p := NewProtojsonEncoder(&b).WithFlattener(f)
+		require.NoError(t, p.Encode(ev))
+		var out map[string]interface{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), &out), b.String())
+		return out
+	}
+
+	out := encode(&Flattener{Arrays: ArrayModeKeep})
+	assert.Equal(t, "/usr/bin/cat", out["process_kprobe.process.binary"])
+	assert.Equal(t, float64(42), out["process_kprobe.process.pid"])
+	assert.Equal(t, "default", out["process_kprobe.process.pod.namespace"])
+	assert.Equal(t, "fd_install", out["process_kprobe.function_name"])
+	assert.Equal(t, "node", out["node_name"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"int_arg": float64(3)},
+		map[string]interface{}{"file_arg.path": "/etc/passwd"},
+	}, out["process_kprobe.args"])
+
+	out = encode(&Flattener{Arrays: ArrayModeIndex})
+	assert.Equal(t, float64(3), out["process_kprobe.args.0.int_arg"])
+	assert.Equal(t, "/etc/passwd", out["process_kprobe.args.1.file_arg.path"])
+	assert.NotContains(t, out, "process_kprobe.args")
+
+	out = encode(&Flattener{MaxDepth: 2, Arrays: ArrayModeIndex})
+	assert.Equal(t, "fd_install", out["process_kprobe.function_name"])
+	assert.Equal(t, map[string]interface{}{"namespace": "default", "name": "xwing"}, out["process_kprobe.process"].(map[string]interface{})["pod"])
+	assert.Len(t, out["process_kprobe.args"], 2)
+
+	_, err := ParseArrayMode("join")
+	assert.Error(t, err)
+}
+
+func TestCompactEncoder_MiningSuspectedEventToString(t *testing.T) {
+	p := NewCompactEncoder(os.Stdout, Never, false, false)
+
+	// should fail without process field
+	_, err := p.EventToString(&tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_MiningSuspected{
+			MiningSuspected: &tetragon.MiningSuspected{Confidence: 0.5},
+		},
+	})
+	assert.Error(t, err)
+
+	result, err := p.EventToString(&tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_MiningSuspected{
+			MiningSuspected: &tetragon.MiningSuspected{
+				Process: &tetragon.Process{
+					Binary: "/tmp/xmrig",
+					Pod: &tetragon.Pod{
+						Namespace: "default",
+						Name:      "web",
+					},
+				},
+				Confidence: 0.79,
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "⛏️ mining  default/web /tmp/xmrig confidence 0.79", result)
+}
+
+func TestCompactEncoder_SshConnectionEventToString(t *testing.T) {
+	p := NewCompactEncoder(os.Stdout, Never, false, false)
+
+	// should fail without process field
+	_, err := p.EventToString(&tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_SshConnection{
+			SshConnection: &tetragon.SshConnection{Client: "ssh"},
+		},
+	})
+	assert.Error(t, err)
+
+	result, err := p.EventToString(&tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_SshConnection{
+			SshConnection: &tetragon.SshConnection{
+				Process: &tetragon.Process{
+					Binary: "/usr/bin/ssh",
+					Pod: &tetragon.Pod{
+						Namespace: "default",
+						Name:      "web",
+					},
+				},
+				Client:             "scp",
+				DestinationHost:    "db.internal",
+				DestinationAddress: "10.0.0.5",
+				DestinationPort:    22,
+				User:               "admin",
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "🔑 scp     default/web /usr/bin/ssh admin@db.internal (10.0.0.5:22)", result)
+}
+
+func TestCompactEncoder_LostEventToString(t *testing.T) {
+	p := NewCompactEncoder(os.Stdout, Never, false, false)
+
+	result, err := p.EventToString(&tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_LostEvent{
+			LostEvent: &tetragon.LostEvent{
+				Count:     42,
+				Cpu:       3,
+				Transport: "ringbuf",
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "⚠️ lost    42 events on CPU 3 (ringbuf)", result)
+}
+
+func TestProtojsonEncoder_CloudEvents(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	event := &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessExec{
+			ProcessExec: &tetragon.ProcessExec{
+				Process: &tetragon.Process{
+					Binary: "/usr/bin/curl",
+					Pod:    &tetragon.Pod{Namespace: "default", Name: "xwing"},
+				},
+			},
+		},
+		NodeName: "node1",
+		Time:     timestamppb.New(ts),
+	}
+
+	var b bytes.Buffer
+	ce := &CloudEventer{Cluster: "prod", newID: func() string { return "id1" }}
+	require.NoError(t, NewProtojsonEncoder(&b).WithCloudEvents(ce).Encode(event))
+
+	var out map[string]interface{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), &out))
+	data := out["data"]
+	delete(out, "data")
+	assert.Equal(t, map[string]interface{}{
+		"specversion":     "1.0",
+		"id":              "id1",
+		"source":          "prod/node1",
+		"type":            "io.cilium.tetragon.process_exec",
+		"subject":         "default/xwing",
+		"time":            "2024-01-02T03:04:05Z",
+		"datacontenttype": "application/json",
+	}, out)
+	assert.Equal(t, "/usr/bin/curl", data.(map[string]interface{})["process_exec"].(map[string]interface{})["process"].(map[string]interface{})["binary"])
+
+	// the events of the envelopes can be decoded again
+	var res tetragon.GetEventsResponse
+	require.NoError(t, protojson.Unmarshal(UnwrapCloudEvent(bytes.TrimSpace(b.Bytes())), &res))
+	assert.True(t, proto.Equal(event, &res))
+
+	// events without a pod have no subject
+	b.Reset()
+	require.NoError(t, NewProtojsonEncoder(&b).WithCloudEvents(&CloudEventer{}).Encode(&tetragon.GetEventsResponse{
+		Event:    &tetragon.GetEventsResponse_RingBufferDrops{RingBufferDrops: &tetragon.RingBufferDrops{Lost: 1}},
+		NodeName: "node1",
+	}))
+	out = nil
+	require.NoError(t, json.Unmarshal(b.Bytes(), &out))
+	assert.Equal(t, "node1", out["source"])
+	assert.Equal(t, "io.cilium.tetragon.ring_buffer_drops", out["type"])
+	assert.NotContains(t, out, "subject")
+	assert.NotEmpty(t, out["id"])
+
+	// other lines are left alone
+	line := []byte(`{"process_exec":{}}`)
+	assert.Equal(t, line, UnwrapCloudEvent(line))
+}
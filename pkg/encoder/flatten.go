@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package encoder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// FlattenSeparator separates the names of the nested fields in the keys of
+// flattened events.
+const FlattenSeparator = "."
+
+// ArrayMode defines how the Flattener flattens arrays.
+type ArrayMode string
+
+const (
+	// ArrayModeKeep keeps arrays as JSON arrays, whose objects are
+	// flattened.
+	ArrayModeKeep ArrayMode = "keep"
+	// ArrayModeIndex flattens arrays like objects, with the indexes of the
+	// elements as names, e.g. process_kprobe.args.0.file_arg.path.
+	ArrayModeIndex ArrayMode = "index"
+)
+
+// ParseArrayMode returns the ArrayMode of the given name.
+func ParseArrayMode(s string) (ArrayMode, error) {
+	switch m := ArrayMode(s); m {
+	case ArrayModeKeep, ArrayModeIndex:
+		return m, nil
+	}
+	return "", fmt.Errorf("invalid array mode %q, should be one of %s or %s",
+		s, ArrayModeKeep, ArrayModeIndex)
+}
+
+// Flattener flattens the nested objects of JSON encoded events into a single
+// object, whose keys are the paths of the fields, e.g. process.pod.namespace.
+// Log systems like Splunk or Elasticsearch can then index the fields without
+// ingest pipelines.
+type Flattener struct {
+	// MaxDepth is the maximum number of names in a key, the values deeper
+	// than MaxDepth are kept nested. Zero means no limit.
+	MaxDepth int
+	// Arrays defines how arrays are flattened.
+	Arrays ArrayMode
+}
+
+// Flatten returns the flattened encoding of out, a JSON object.
+func (f *Flattener) Flatten(out []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(out))
+	// keep numbers as they are encoded
+	dec.UseNumber()
+	var obj map[string]interface{}
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+	ret := make(map[string]interface{}, len(obj))
+	f.flattenObject(ret, "", 0, obj)
+	return json.Marshal(ret)
+}
+
+func (f *Flattener) flattenObject(ret map[string]interface{}, prefix string, depth int, obj map[string]interface{}) {
+	for name, v := range obj {
+		f.flattenValue(ret, prefix+name, depth+1, v)
+	}
+}
+
+func (f *Flattener) flattenValue(ret map[string]interface{}, key string, depth int, v interface{}) {
+	if f.MaxDepth > 0 && depth >= f.MaxDepth {
+		ret[key] = v
+		return
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		// empty messages are kept, they can be meaningful
+		if len(v) == 0 {
+			ret[key] = v
+			return
+		}
+		f.flattenObject(ret, key+FlattenSeparator, depth, v)
+	case []interface{}:
+		if f.Arrays == ArrayModeIndex && len(v) > 0 {
+			for i, elem := range v {
+				f.flattenValue(ret, key+FlattenSeparator+strconv.Itoa(i), depth+1, elem)
+			}
+			return
+		}
+		for i, elem := range v {
+			if obj, ok := elem.(map[string]interface{}); ok && len(obj) > 0 {
+				flat := make(map[string]interface{}, len(obj))
+				f.flattenObject(flat, "", depth, obj)
+				v[i] = flat
+			}
+		}
+		ret[key] = v
+	default:
+		ret[key] = v
+	}
+}
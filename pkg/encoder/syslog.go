@@ -0,0 +1,319 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package encoder
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/api/v1/tetragon/codegen/helpers"
+	"github.com/cilium/tetragon/pkg/version"
+)
+
+// SyslogFormat is the format of the messages of a SyslogEncoder.
+type SyslogFormat string
+
+const (
+	// SyslogFormatRFC5424 renders the fields of the events as the
+	// structured data of RFC5424 syslog messages.
+	SyslogFormatRFC5424 SyslogFormat = "rfc5424"
+	// SyslogFormatCEF renders the fields of the events as the extension of
+	// ArcSight Common Event Format (CEF) messages, in RFC5424 syslog
+	// messages.
+	SyslogFormatCEF SyslogFormat = "cef"
+)
+
+// ParseSyslogFormat parses a syslog format name.
+func ParseSyslogFormat(s string) (SyslogFormat, error) {
+	switch f := SyslogFormat(s); f {
+	case SyslogFormatRFC5424, SyslogFormatCEF:
+		return f, nil
+	}
+	return "", fmt.Errorf("invalid syslog format '%s', expected %s or %s", s, SyslogFormatRFC5424, SyslogFormatCEF)
+}
+
+// Syslog severities of the events.
+const (
+	syslogWarning = 4
+	syslogNotice  = 5
+	syslogInfo    = 6
+)
+
+const (
+	syslogAppName = "tetragon"
+	// syslogSDID is the ID of the structured data element of the RFC5424
+	// messages, under the example enterprise number of RFC5612.
+	syslogSDID = "tetragon@32473"
+	// cefMaxCustomStrings is the number of custom string fields (cs1 to cs6)
+	// of CEF.
+	cefMaxCustomStrings = 6
+)
+
+// cefKeys maps the fields of the events to the CEF extension keys of the
+// same meaning. The other fields are custom string fields, labeled with
+// their names.
+var cefKeys = map[string]string{
+	"node":          "dvchost",
+	"binary":        "dproc",
+	"pid":           "dpid",
+	"uid":           "duid",
+	"parent_binary": "sproc",
+	"parent_pid":    "spid",
+	"path":          "filePath",
+}
+
+// syslogField is a field of an event, rendered as a structured data
+// parameter or a CEF extension.
+type syslogField struct {
+	name  string
+	value string
+}
+
+// syslogRecord is the rendering of an event by its template.
+type syslogRecord struct {
+	// kind is the kind of the event, the MSGID of the messages
+	kind string
+	// signature identifies the hook of the event, the Signature ID of CEF
+	signature string
+	// name describes the event
+	name     string
+	severity int
+	fields   []syslogField
+}
+
+// addAction adds the action of the hook of an event, e.g. sigkill, to r.
+func (r *syslogRecord) addAction(action tetragon.KprobeAction) {
+	if action != tetragon.KprobeAction_KPROBE_ACTION_UNKNOWN {
+		r.add("action", strings.ToLower(strings.TrimPrefix(action.String(), "KPROBE_ACTION_")))
+	}
+}
+
+func (r *syslogRecord) add(name, value string) {
+	if value != "" {
+		r.fields = append(r.fields, syslogField{name: name, value: value})
+	}
+}
+
+// SyslogEncoder encodes events as syslog messages, writing one message per
+// Write call to its writer.
+type SyslogEncoder struct {
+	w        io.Writer
+	format   SyslogFormat
+	facility int
+	hostname string
+}
+
+// NewSyslogEncoder returns a SyslogEncoder of the given format and facility,
+// between 0 and 23. The hostname of the messages is the node of the events,
+// or hostname if they have none.
+func NewSyslogEncoder(w io.Writer, format SyslogFormat, facility int, hostname string) (*SyslogEncoder, error) {
+	if facility < 0 || facility > 23 {
+		return nil, fmt.Errorf("invalid syslog facility %d, expected 0 to 23", facility)
+	}
+	return &SyslogEncoder{
+		w:        w,
+		format:   format,
+		facility: facility,
+		hostname: hostname,
+	}, nil
+}
+
+func (e *SyslogEncoder) Encode(v interface{}) error {
+	event, ok := v.(*tetragon.GetEventsResponse)
+	if !ok {
+		return ErrInvalidEvent
+	}
+	_, err := e.w.Write(e.message(event))
+	return err
+}
+
+// message returns the syslog message of event.
+func (e *SyslogEncoder) message(event *tetragon.GetEventsResponse) []byte {
+	rec := syslogTemplate(event)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<%d>1 ", e.facility*8+rec.severity)
+	if event.GetTime() != nil {
+		b.WriteString(event.GetTime().AsTime().UTC().Format(time.RFC3339Nano))
+	} else {
+		b.WriteString("-")
+	}
+	hostname := event.GetNodeName()
+	if hostname == "" {
+		hostname = e.hostname
+	}
+	procID := "-"
+	if pid := helpers.ResponseGetProcess(event).GetPid(); pid != nil {
+		procID = strconv.FormatUint(uint64(pid.GetValue()), 10)
+	}
+	fmt.Fprintf(&b, " %s %s %s %s ", syslogHeaderValue(hostname, 255), syslogAppName, procID, syslogHeaderValue(rec.kind, 32))
+
+	if e.format == SyslogFormatCEF {
+		b.WriteString("- ")
+		writeCEF(&b, &rec, event)
+		return []byte(b.String())
+	}
+
+	b.WriteString("[" + syslogSDID)
+	for _, f := range rec.fields {
+		fmt.Fprintf(&b, " %s=\"%s\"", f.name, sdParamEscaper.Replace(f.value))
+	}
+	b.WriteString("] ")
+	b.WriteString(rec.name)
+	return []byte(b.String())
+}
+
+// syslogHeaderValue returns s, or the nil value "-" if it is empty, without
+// spaces and truncated to max characters, for the header of the messages.
+func syslogHeaderValue(s string, max int) string {
+	if s == "" {
+		return "-"
+	}
+	s = strings.ReplaceAll(s, " ", "_")
+	if len(s) > max {
+		s = s[:max]
+	}
+	return s
+}
+
+var (
+	sdParamEscaper     = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+	cefHeaderEscaper   = strings.NewReplacer(`\`, `\\`, `|`, `\|`)
+	cefExtensionEscape = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`)
+)
+
+// cefSeverity maps the syslog severities to the CEF severities, from 0 to
+// 10.
+func cefSeverity(severity int) int {
+	switch severity {
+	case syslogWarning:
+		return 8
+	case syslogNotice:
+		return 5
+	}
+	return 3
+}
+
+// writeCEF writes the CEF message of rec to b.
+func writeCEF(b *strings.Builder, rec *syslogRecord, event *tetragon.GetEventsResponse) {
+	fmt.Fprintf(b, "CEF:0|Cilium|Tetragon|%s|%s|%s|%d|",
+		cefHeaderEscaper.Replace(version.Version),
+		cefHeaderEscaper.Replace(rec.signature),
+		cefHeaderEscaper.Replace(rec.name),
+		cefSeverity(rec.severity))
+
+	var ext []string
+	if event.GetTime() != nil {
+		ext = append(ext, "rt="+strconv.FormatInt(event.GetTime().AsTime().UnixMilli(), 10))
+	}
+	custom := 0
+	for _, f := range rec.fields {
+		value := cefExtensionEscape.Replace(f.value)
+		if key, ok := cefKeys[f.name]; ok {
+			ext = append(ext, key+"="+value)
+			continue
+		}
+		if custom == cefMaxCustomStrings {
+			continue
+		}
+		custom++
+		ext = append(ext, fmt.Sprintf("cs%dLabel=%s", custom, f.name), fmt.Sprintf("cs%d=%s", custom, value))
+	}
+	b.WriteString(strings.Join(ext, " "))
+}
+
+// actionSeverity returns the severity of the events of hooks whose action
+// is action.
+func actionSeverity(action tetragon.KprobeAction) int {
+	switch action {
+	case tetragon.KprobeAction_KPROBE_ACTION_SIGKILL,
+		tetragon.KprobeAction_KPROBE_ACTION_OVERRIDE,
+		tetragon.KprobeAction_KPROBE_ACTION_SIGNAL,
+		tetragon.KprobeAction_KPROBE_ACTION_NOTIFYKILLER:
+		return syslogWarning
+	}
+	return syslogNotice
+}
+
+// argsPath returns the path of the first file or path argument of args.
+func argsPath(args []*tetragon.KprobeArgument) string {
+	for _, arg := range args {
+		switch a := arg.GetArg().(type) {
+		case *tetragon.KprobeArgument_FileArg:
+			return a.FileArg.GetPath()
+		case *tetragon.KprobeArgument_PathArg:
+			return a.PathArg.GetPath()
+		}
+	}
+	return ""
+}
+
+// addProcess adds the fields of the process and the parent of an event to
+// r.
+func (r *syslogRecord) addProcess(node string, proc, parent *tetragon.Process) {
+	r.add("node", node)
+	r.add("binary", proc.GetBinary())
+	if proc.GetPid() != nil {
+		r.add("pid", strconv.FormatUint(uint64(proc.GetPid().GetValue()), 10))
+	}
+	if proc.GetUid() != nil {
+		r.add("uid", strconv.FormatUint(uint64(proc.GetUid().GetValue()), 10))
+	}
+	r.add("parent_binary", parent.GetBinary())
+	if parent.GetPid() != nil {
+		r.add("parent_pid", strconv.FormatUint(uint64(parent.GetPid().GetValue()), 10))
+	}
+	r.add("namespace", proc.GetPod().GetNamespace())
+	r.add("pod", proc.GetPod().GetName())
+	r.add("exec_id", proc.GetExecId())
+}
+
+// syslogTemplate returns the record of an event. The exec, exit, kprobe and
+// tracepoint events have their own templates, the other events are rendered
+// with the fields of their process.
+func syslogTemplate(event *tetragon.GetEventsResponse) syslogRecord {
+	kind := eventKind(event)
+	rec := syslogRecord{kind: kind, signature: kind, severity: syslogInfo}
+	node := event.GetNodeName()
+
+	switch ev := event.Event.(type) {
+	case *tetragon.GetEventsResponse_ProcessExec:
+		exec := ev.ProcessExec
+		rec.name = "Process executed"
+		rec.addProcess(node, exec.GetProcess(), exec.GetParent())
+		rec.add("arguments", exec.GetProcess().GetArguments())
+	case *tetragon.GetEventsResponse_ProcessExit:
+		exit := ev.ProcessExit
+		rec.name = "Process exited"
+		rec.addProcess(node, exit.GetProcess(), exit.GetParent())
+		rec.add("signal", exit.GetSignal())
+		rec.add("status", strconv.FormatUint(uint64(exit.GetStatus()), 10))
+	case *tetragon.GetEventsResponse_ProcessKprobe:
+		kprobe := ev.ProcessKprobe
+		rec.signature += ":" + kprobe.GetFunctionName()
+		rec.name = "Kprobe " + kprobe.GetFunctionName()
+		rec.severity = actionSeverity(kprobe.GetAction())
+		rec.addProcess(node, kprobe.GetProcess(), kprobe.GetParent())
+		rec.add("policy", kprobe.GetPolicyName())
+		rec.addAction(kprobe.GetAction())
+		rec.add("path", argsPath(kprobe.GetArgs()))
+	case *tetragon.GetEventsResponse_ProcessTracepoint:
+		tp := ev.ProcessTracepoint
+		rec.signature += ":" + tp.GetSubsys() + "/" + tp.GetEvent()
+		rec.name = "Tracepoint " + tp.GetSubsys() + "/" + tp.GetEvent()
+		rec.severity = actionSeverity(tp.GetAction())
+		rec.addProcess(node, tp.GetProcess(), tp.GetParent())
+		rec.add("policy", tp.GetPolicyName())
+		rec.addAction(tp.GetAction())
+		rec.add("path", argsPath(tp.GetArgs()))
+	default:
+		rec.name = rec.kind
+		rec.addProcess(node, helpers.ResponseGetProcess(event), nil)
+	}
+	return rec
+}
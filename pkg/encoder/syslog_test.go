@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package encoder
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// messageWriter records the messages of each Write call.
+type messageWriter struct {
+	messages []string
+}
+
+func (w *messageWriter) Write(p []byte) (int, error) {
+	w.messages = append(w.messages, string(p))
+	return len(p), nil
+}
+
+var syslogTime = timestamppb.New(time.Date(2023, 10, 6, 22, 3, 57, 700000000, time.UTC))
+
+func syslogProcess() *tetragon.Process {
+	return &tetragon.Process{
+		ExecId:    "Z2tlLWpvaG4tNjMy",
+		Pid:       wrapperspb.UInt32(1234),
+		Uid:       wrapperspb.UInt32(0),
+		Binary:    "/usr/bin/curl",
+		Arguments: `https://ebpf.io "a]b"`,
+		Pod:       &tetragon.Pod{Namespace: "default", Name: "xwing"},
+	}
+}
+
+func TestSyslogEncoderRFC5424(t *testing.T) {
+	w := &messageWriter{}
+	enc, err := NewSyslogEncoder(w, SyslogFormatRFC5424, 16, "localhost")
+	require.NoError(t, err)
+
+	err = enc.Encode(&tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessExec{ProcessExec: &tetragon.ProcessExec{
+			Process: syslogProcess(),
+			Parent:  &tetragon.Process{Binary: "/bin/bash", Pid: wrapperspb.UInt32(1000)},
+		}},
+		NodeName: "node-1",
+		Time:     syslogTime,
+	})
+	require.NoError(t, err)
+	err = enc.Encode(&tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessKprobe{ProcessKprobe: &tetragon.ProcessKprobe{
+			Process:      &tetragon.Process{Binary: "/usr/bin/cat", Pid: wrapperspb.UInt32(42)},
+			FunctionName: "security_file_permission",
+			Args: []*tetragon.KprobeArgument{
+				{Arg: &tetragon.KprobeArgument_FileArg{FileArg: &tetragon.KprobeFile{Path: "/etc/shadow"}}},
+				{Arg: &tetragon.KprobeArgument_IntArg{IntArg: 4}},
+			},
+			PolicyName: "file-monitoring",
+			Action:     tetragon.KprobeAction_KPROBE_ACTION_SIGKILL,
+		}},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, w.messages, 2)
+	assert.Equal(t, `<134>1 2023-10-06T22:03:57.7Z node-1 tetragon 1234 process_exec [tetragon@32473`+
+		` node="node-1" binary="/usr/bin/curl" pid="1234" uid="0" parent_binary="/bin/bash" parent_pid="1000"`+
+		` namespace="default" pod="xwing" exec_id="Z2tlLWpvaG4tNjMy" arguments="https://ebpf.io \"a\]b\""] Process executed`,
+		w.messages[0])
+	assert.Equal(t, `<132>1 - localhost tetragon 42 process_kprobe [tetragon@32473`+
+		` binary="/usr/bin/cat" pid="42" policy="file-monitoring" action="sigkill" path="/etc/shadow"]`+
+		` Kprobe security_file_permission`,
+		w.messages[1])
+}
+
+func TestSyslogEncoderCEF(t *testing.T) {
+	w := &messageWriter{}
+	enc, err := NewSyslogEncoder(w, SyslogFormatCEF, 1, "")
+	require.NoError(t, err)
+
+	err = enc.Encode(&tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessExit{ProcessExit: &tetragon.ProcessExit{
+			Process: syslogProcess(),
+			Signal:  "SIGKILL",
+		}},
+		NodeName: "node-1",
+		Time:     syslogTime,
+	})
+	require.NoError(t, err)
+	err = enc.Encode(&tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessTracepoint{ProcessTracepoint: &tetragon.ProcessTracepoint{
+			Process:    &tetragon.Process{Binary: "/usr/bin/x=y", Pid: wrapperspb.UInt32(7)},
+			Subsys:     "syscalls",
+			Event:      "sys_enter_ptrace",
+			PolicyName: "ptrace|audit",
+			Action:     tetragon.KprobeAction_KPROBE_ACTION_POST,
+		}},
+		NodeName: "node-1",
+		Time:     syslogTime,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, w.messages, 2)
+	assert.Equal(t, `<14>1 2023-10-06T22:03:57.7Z node-1 tetragon 1234 process_exit - `+
+		`CEF:0|Cilium|Tetragon|`+version.Version+`|process_exit|Process exited|3|`+
+		`rt=1696629837700 dvchost=node-1 dproc=/usr/bin/curl dpid=1234 duid=0`+
+		` cs1Label=namespace cs1=default cs2Label=pod cs2=xwing cs3Label=exec_id cs3=Z2tlLWpvaG4tNjMy`+
+		` cs4Label=signal cs4=SIGKILL cs5Label=status cs5=0`,
+		w.messages[0])
+	assert.Equal(t, `<13>1 2023-10-06T22:03:57.7Z node-1 tetragon 7 process_tracepoint - `+
+		`CEF:0|Cilium|Tetragon|`+version.Version+`|process_tracepoint:syscalls/sys_enter_ptrace|Tracepoint syscalls/sys_enter_ptrace|5|`+
+		`rt=1696629837700 dvchost=node-1 dproc=/usr/bin/x\=y dpid=7`+
+		` cs1Label=policy cs1=ptrace|audit cs2Label=action cs2=post`,
+		w.messages[1])
+}
+
+func TestSyslogEncoderOtherEvents(t *testing.T) {
+	var buf bytes.Buffer
+	enc, err := NewSyslogEncoder(&buf, SyslogFormatRFC5424, 16, "")
+	require.NoError(t, err)
+
+	err = enc.Encode(&tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessUprobe{ProcessUprobe: &tetragon.ProcessUprobe{
+			Process: &tetragon.Process{Binary: "/usr/bin/bash"},
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `<134>1 - - tetragon - process_uprobe [tetragon@32473 binary="/usr/bin/bash"] process_uprobe`, buf.String())
+
+	assert.ErrorIs(t, enc.Encode("event"), ErrInvalidEvent)
+}
+
+func TestSyslogEncoderOptions(t *testing.T) {
+	_, err := ParseSyslogFormat("cef")
+	require.NoError(t, err)
+	_, err = ParseSyslogFormat("leef")
+	require.Error(t, err)
+
+	_, err = NewSyslogEncoder(&bytes.Buffer{}, SyslogFormatCEF, 24, "")
+	require.Error(t, err)
+}
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package encoder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TimeFormat defines how the ProtojsonEncoder encodes timestamps.
+type TimeFormat string
+
+const (
+	// TimeFormatRFC3339 is the protojson encoding of timestamps: RFC3339
+	// with 0, 3, 6 or 9 fractional digits.
+	TimeFormatRFC3339 TimeFormat = "rfc3339"
+	// TimeFormatRFC3339Nano is RFC3339 with 9 fractional digits.
+	TimeFormatRFC3339Nano TimeFormat = "rfc3339nano"
+	// TimeFormatUnixNano is the number of nanoseconds since the Unix epoch,
+	// encoded as a string like other 64-bit integers in protojson.
+	TimeFormatUnixNano TimeFormat = "unix-nano"
+)
+
+// ParseTimeFormat returns the TimeFormat of the given name.
+func ParseTimeFormat(s string) (TimeFormat, error) {
+	switch f := TimeFormat(s); f {
+	case TimeFormatRFC3339, TimeFormatRFC3339Nano, TimeFormatUnixNano:
+		return f, nil
+	}
+	return "", fmt.Errorf("invalid time format %q, should be one of %s, %s or %s",
+		s, TimeFormatRFC3339, TimeFormatRFC3339Nano, TimeFormatUnixNano)
+}
+
+// timeFormatter rewrites the timestamps of protojson encoded events.
+type timeFormatter struct {
+	format   TimeFormat
+	location *time.Location
+}
+
+// isDefault returns true if the timestamps are kept in the protojson encoding.
+func (f *timeFormatter) isDefault() bool {
+	return f.format == TimeFormatRFC3339 && f.location == time.UTC
+}
+
+func (f *timeFormatter) formatTime(t time.Time) string {
+	switch f.format {
+	case TimeFormatUnixNano:
+		return strconv.Quote(strconv.FormatInt(t.UnixNano(), 10))
+	case TimeFormatRFC3339Nano:
+		return strconv.Quote(t.In(f.location).Format(rfc3339Nano))
+	default:
+		return strconv.Quote(t.In(f.location).Format(time.RFC3339Nano))
+	}
+}
+
+// rewrite replaces the timestamps of msg in out, its protojson encoding.
+//
+// protojson does not allow to customize the encoding of well-known types, so
+// the encoded timestamps are replaced in the output instead. This keeps the
+// order and the formatting of the other fields.
+func (f *timeFormatter) rewrite(msg proto.Message, out []byte) ([]byte, error) {
+	var replacements []string
+	seen := map[string]struct{}{}
+	var err error
+	walkTimestamps(msg.ProtoReflect(), func(ts *timestamppb.Timestamp) {
+		if err != nil {
+			return
+		}
+		old, mErr := protojson.Marshal(ts)
+		if mErr != nil {
+			err = mErr
+			return
+		}
+		if _, ok := seen[string(old)]; ok {
+			return
+		}
+		seen[string(old)] = struct{}{}
+		replacements = append(replacements, string(old), f.formatTime(ts.AsTime()))
+	})
+	if err != nil || len(replacements) == 0 {
+		return out, err
+	}
+	return []byte(strings.NewReplacer(replacements...).Replace(string(out))), nil
+}
+
+var timestampName = (&timestamppb.Timestamp{}).ProtoReflect().Descriptor().FullName()
+
+// walkTimestamps calls fn for each timestamp of m.
+func walkTimestamps(m protoreflect.Message, fn func(*timestamppb.Timestamp)) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Message() == nil {
+			return true
+		}
+		if fd.IsMap() {
+			if fd.MapValue().Message() == nil {
+				return true
+			}
+			v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+				walkTimestampValue(mv.Message(), fn)
+				return true
+			})
+			return true
+		}
+		if fd.IsList() {
+			l := v.List()
+			for i := 0; i < l.Len(); i++ {
+				walkTimestampValue(l.Get(i).Message(), fn)
+			}
+			return true
+		}
+		walkTimestampValue(v.Message(), fn)
+		return true
+	})
+}
+
+func walkTimestampValue(m protoreflect.Message, fn func(*timestamppb.Timestamp)) {
+	if m.Descriptor().FullName() == timestampName {
+		if ts, ok := m.Interface().(*timestamppb.Timestamp); ok {
+			fn(ts)
+		}
+		return
+	}
+	walkTimestamps(m, fn)
+}
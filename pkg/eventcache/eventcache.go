@@ -58,8 +58,16 @@ var (
 	ErrFailedToGetPodInfo     = errors.New("failed to get pod info from event cache")
 	ErrFailedToGetProcessInfo = errors.New("failed to get process info from event cache")
 	ErrFailedToGetParentInfo  = errors.New("failed to get parent info from event cache")
+	ErrExecPending            = errors.New("exec event of process pending in event cache")
 )
 
+// MissingProcessHandler is implemented by the messages that can send an
+// additional event when their process is still not known after the cache
+// retries, see MsgExitEventUnix.HandleMissingProcess().
+type MissingProcessHandler interface {
+	HandleMissingProcess(ev notify.Event) *tetragon.GetEventsResponse
+}
+
 // Generic internal lookup happens when events are received out of order and
 // this event was handled before an exec event so it wasn't able to populate
 // the process info yet.
@@ -143,6 +151,11 @@ func (ec *Cache) handleEvents() {
 			}
 			if errors.Is(err, ErrFailedToGetProcessInfo) {
 				eventcachemetrics.ProcessInfoError(notify.EventTypeString(event.event)).Inc()
+				if h, ok := event.msg.(MissingProcessHandler); ok {
+					if res := h.HandleMissingProcess(event.event); res != nil {
+						ec.server.NotifyListeners(event.msg, res)
+					}
+				}
 			} else if errors.Is(err, ErrFailedToGetPodInfo) {
 				eventcachemetrics.PodInfoError(notify.EventTypeString(event.event)).Inc()
 			}
@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package eventforward
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type chanNotifier struct {
+	events chan *tetragon.GetEventsResponse
+}
+
+func (n *chanNotifier) NotifyListeners(_ interface{}, processed *tetragon.GetEventsResponse) {
+	n.events <- processed
+}
+
+func TestForwardEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	option.Config.EnableK8s = true
+	defer func() { option.Config.EnableK8s = false }()
+
+	notifier := &chanNotifier{events: make(chan *tetragon.GetEventsResponse, 1)}
+	guest := Guest{Namespace: "default", Pod: "kata-pod"}
+	receiver := &Receiver{
+		notifier: notifier,
+		nodeName: "host",
+		guestOf: func(_ net.Conn) (Guest, error) {
+			return guest, nil
+		},
+		getPodInfo: func(cid, _, _ string, _ uint32) *tetragon.Pod {
+			pod := &tetragon.Pod{Namespace: "default", Name: "kata-pod", Container: &tetragon.Container{Id: cid}}
+			if cid == "other" {
+				pod.Name = "other-pod"
+			}
+			return pod
+		},
+	}
+
+	sock := filepath.Join(t.TempDir(), "forward.sock")
+	l, err := net.Listen("unix", sock)
+	require.NoError(t, err)
+	go receiver.Serve(ctx, l)
+
+	forwarder := newForwarder(func() (net.Conn, error) {
+		return net.Dial("unix", sock)
+	}, 16)
+	go forwarder.run()
+	defer forwarder.Close()
+
+	forward := func(container string) *tetragon.Process {
+		err = forwarder.Encode(&tetragon.GetEventsResponse{
+			Event: &tetragon.GetEventsResponse_ProcessExec{ProcessExec: &tetragon.ProcessExec{
+				Process: &tetragon.Process{
+					Binary: "/bin/sh",
+					Docker: container,
+					Pod:    &tetragon.Pod{Namespace: "kube-system", Name: "spoofed"},
+				},
+			}},
+			NodeName: "guest",
+		})
+		require.NoError(t, err)
+
+		select {
+		case ev := <-notifier.events:
+			assert.Equal(t, "host", ev.NodeName)
+			proc := ev.GetProcessExec().GetProcess()
+			assert.Equal(t, "/bin/sh", proc.Binary)
+			return proc
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for forwarded event")
+		}
+		return nil
+	}
+
+	proc := forward("abcd1234")
+	assert.Equal(t, "kata-pod", proc.GetPod().GetName())
+	assert.Equal(t, "abcd1234", proc.GetPod().GetContainer().GetId())
+
+	// the containers of other pods are not trusted
+	proc = forward("other")
+	assert.Equal(t, "default", proc.GetPod().GetNamespace())
+	assert.Equal(t, "kata-pod", proc.GetPod().GetName())
+	assert.Nil(t, proc.GetPod().GetContainer())
+}
+
+func TestReceiverRejectsUnknownGuests(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	receiver := NewReceiver(&chanNotifier{})
+	sock := filepath.Join(t.TempDir(), "forward.sock")
+	l, err := net.Listen("unix", sock)
+	require.NoError(t, err)
+	go receiver.Serve(ctx, l)
+
+	conn, err := net.Dial("unix", sock)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = conn.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestGuestResolver(t *testing.T) {
+	procfs := t.TempDir()
+	writeProc := func(pid, cmdline, cgroup string) {
+		dir := filepath.Join(procfs, pid)
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "cmdline"), []byte(cmdline), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "cgroup"), []byte(cgroup), 0644))
+	}
+	writeProc("100", "/usr/bin/qemu-system-x86_64\x00-device\x00vhost-vsock-pci,id=vsock-1,guest-cid=3,disable-modern=false\x00",
+		"0::/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod0f4c2a8e_6b1d_4e2f_9a3b_1c2d3e4f5a6b.slice/kata_abc.scope\n")
+	writeProc("200", "/usr/bin/qemu-system-x86_64\x00-device\x00vhost-vsock-pci,id=vsock-2,guest-cid=4\x00",
+		"0::/system.slice/qemu.service\n")
+	writeProc("self", "", "")
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "default",
+		Name:      "kata-pod",
+		UID:       "0f4c2a8e-6b1d-4e2f-9a3b-1c2d3e4f5a6b",
+	}}
+	resolver := &guestResolver{
+		procfs: procfs,
+		findPod: func(podID string) (*corev1.Pod, error) {
+			if podID != string(pod.UID) {
+				return nil, errors.New("pod not found")
+			}
+			return pod, nil
+		},
+	}
+
+	guest, err := resolver.resolve(3)
+	require.NoError(t, err)
+	assert.Equal(t, Guest{Namespace: "default", Pod: "kata-pod"}, guest)
+
+	// the hypervisor is not in the cgroup of a pod
+	_, err = resolver.resolve(4)
+	assert.Error(t, err)
+
+	// no hypervisor for the guest
+	_, err = resolver.resolve(30)
+	assert.Error(t, err)
+}
+
+func TestForwarderRetry(t *testing.T) {
+	dials := 0
+	forwarder := newForwarder(func() (net.Conn, error) {
+		dials++
+		return nil, errors.New("connection refused")
+	}, 16)
+	forwarder.retryInterval = time.Hour
+
+	ev := &tetragon.GetEventsResponse{}
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, forwarder.Encode(ev))
+	}
+	go forwarder.run()
+	require.NoError(t, forwarder.Close())
+	assert.ErrorIs(t, forwarder.Encode(ev), errClosed)
+
+	// no retry before retryInterval
+	assert.Equal(t, 1, dials)
+	assert.Equal(t, uint64(3), forwarder.Dropped())
+}
+
+func TestForwarderQueueFull(t *testing.T) {
+	forwarder := newForwarder(nil, 1)
+	ev := &tetragon.GetEventsResponse{}
+	assert.NoError(t, forwarder.Encode(ev))
+	assert.NoError(t, forwarder.Encode(ev))
+	assert.Equal(t, uint64(1), forwarder.Dropped())
+}
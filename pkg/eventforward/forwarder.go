@@ -0,0 +1,223 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package eventforward implements forwarding of events from a Tetragon agent
+// running inside a VM guest (e.g., a Kata containers sandbox) to the Tetragon
+// agent of the host, over vsock.
+//
+// The guest agent forwards all its events to the host, which merges them
+// into its own event stream and attributes them to the pod that owns the
+// guest based on the container ids of the forwarded processes.
+//
+// Events are sent as a stream of protobuf messages, each prefixed with its
+// size as a varint.
+package eventforward
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/metrics/exportmetrics"
+	"golang.org/x/sys/unix"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// maxEventSize is the maximum size of a forwarded event
+	maxEventSize = 16 * 1024 * 1024
+
+	defaultRetryInterval = 5 * time.Second
+	// defaultQueueSize is the number of events waiting to be forwarded
+	defaultQueueSize = 4096
+	// writeTimeout is the timeout of forwarding an event
+	writeTimeout = 5 * time.Second
+	// closeTimeout is the time given to forward the queued events on close
+	closeTimeout = 5 * time.Second
+)
+
+var (
+	errClosed       = errors.New("event forwarder is closed")
+	errNotConnected = errors.New("not connected to host agent")
+)
+
+// Forwarder is an exporter.ExportEncoder that forwards events to the host
+// agent. Events are queued and forwarded by a goroutine, which connects to the
+// host agent. Events are dropped when the queue is full or the host agent is
+// not reachable, and the connection is retried every retryInterval.
+type Forwarder struct {
+	dial          func() (net.Conn, error)
+	retryInterval time.Duration
+	dropped       atomic.Uint64
+
+	// conn and lastDial are only used by the run goroutine
+	conn     net.Conn
+	lastDial time.Time
+
+	mu     sync.RWMutex
+	closed bool
+	queue  chan []byte
+	done   chan struct{}
+}
+
+// NewVsockForwarder returns a Forwarder that forwards events to the given
+// vsock port of the host.
+func NewVsockForwarder(port uint32) *Forwarder {
+	f := newForwarder(func() (net.Conn, error) {
+		return dialVsock(unix.VMADDR_CID_HOST, port)
+	}, defaultQueueSize)
+	go f.run()
+	return f
+}
+
+func newForwarder(dial func() (net.Conn, error), queueSize int) *Forwarder {
+	return &Forwarder{
+		dial:          dial,
+		retryInterval: defaultRetryInterval,
+		queue:         make(chan []byte, queueSize),
+		done:          make(chan struct{}),
+	}
+}
+
+// Dropped returns the number of events that were dropped because the queue
+// was full or the host agent was not reachable.
+func (f *Forwarder) Dropped() uint64 {
+	return f.dropped.Load()
+}
+
+func (f *Forwarder) drop() {
+	f.dropped.Add(1)
+	exportmetrics.ForwardEvents.WithLabelValues(exportmetrics.ForwardDropped).Inc()
+}
+
+// Encode queues the event to be forwarded, or drops it if the queue is full.
+func (f *Forwarder) Encode(v interface{}) error {
+	event, ok := v.(*tetragon.GetEventsResponse)
+	if !ok {
+		return fmt.Errorf("cannot forward event of type %T", v)
+	}
+	buf, err := appendEvent(nil, event)
+	if err != nil {
+		return err
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if f.closed {
+		return errClosed
+	}
+	select {
+	case f.queue <- buf:
+	default:
+		f.drop()
+	}
+	return nil
+}
+
+// Close forwards the queued events, waiting for at most closeTimeout, and
+// closes the connection to the host agent.
+func (f *Forwarder) Close() error {
+	f.mu.Lock()
+	if f.closed {
+		f.mu.Unlock()
+		return nil
+	}
+	f.closed = true
+	close(f.queue)
+	f.mu.Unlock()
+
+	select {
+	case <-f.done:
+	case <-time.After(closeTimeout):
+		return errors.New("timeout forwarding the queued events")
+	}
+	return nil
+}
+
+func (f *Forwarder) run() {
+	defer close(f.done)
+	defer f.disconnect()
+	for buf := range f.queue {
+		if err := f.send(buf); err != nil {
+			f.drop()
+			continue
+		}
+		exportmetrics.ForwardEvents.WithLabelValues(exportmetrics.ForwardSent).Inc()
+	}
+}
+
+func (f *Forwarder) disconnect() {
+	if f.conn != nil {
+		f.conn.Close()
+		f.conn = nil
+	}
+}
+
+// send forwards an event, connecting to the host agent if needed, at most once
+// every retryInterval.
+func (f *Forwarder) send(buf []byte) error {
+	log := logger.GetLogger()
+	if f.conn == nil {
+		if !f.lastDial.IsZero() && time.Since(f.lastDial) < f.retryInterval {
+			return errNotConnected
+		}
+		f.lastDial = time.Now()
+		conn, err := f.dial()
+		if err != nil {
+			log.WithError(err).Warnf("Failed to connect to host agent, dropping events and retrying in %s", f.retryInterval)
+			return err
+		}
+		log.WithField("addr", conn.RemoteAddr()).Info("Forwarding events to host agent")
+		f.conn = conn
+	}
+
+	err := f.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if err == nil {
+		_, err = f.conn.Write(buf)
+	}
+	if err != nil {
+		log.WithError(err).Warnf("Failed to forward event to host agent, dropping events and reconnecting in %s", f.retryInterval)
+		f.disconnect()
+		return err
+	}
+	return nil
+}
+
+// appendEvent appends the size-prefixed encoding of event to buf.
+func appendEvent(buf []byte, event *tetragon.GetEventsResponse) ([]byte, error) {
+	data, err := proto.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event: %w", err)
+	}
+	buf = protowire.AppendVarint(buf, uint64(len(data)))
+	return append(buf, data...), nil
+}
+
+// readEvent reads a size-prefixed event from r.
+func readEvent(r *bufio.Reader) (*tetragon.GetEventsResponse, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if size > maxEventSize {
+		return nil, fmt.Errorf("event size %d exceeds maximum (%d)", size, maxEventSize)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	event := &tetragon.GetEventsResponse{}
+	if err := proto.Unmarshal(data, event); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+	return event, nil
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package eventforward
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+var (
+	// guestCIDRe matches the vsock device of the hypervisor of a guest,
+	// e.g. -device vhost-vsock-pci,id=vsock-1,guest-cid=3
+	guestCIDRe = regexp.MustCompile(`(?:^|,)guest-cid=([0-9]+)(?:,|$)`)
+	// podUIDRe matches the pod UID in the cgroup path of the hypervisor,
+	// which runs in the cgroup of the pod sandbox. The systemd cgroup
+	// driver replaces the dashes of the UID with underscores.
+	podUIDRe = regexp.MustCompile(`pod([0-9a-f]{8}[-_][0-9a-f]{4}[-_][0-9a-f]{4}[-_][0-9a-f]{4}[-_][0-9a-f]{12})`)
+)
+
+// guestResolver resolves the vsock context id (CID) of a guest to the pod of
+// its sandbox. The CID is found on the command line of the hypervisor
+// process, and the pod from the cgroup of this process.
+type guestResolver struct {
+	procfs  string
+	findPod func(podID string) (*corev1.Pod, error)
+}
+
+func (g *guestResolver) resolve(cid uint32) (Guest, error) {
+	pid, err := g.hypervisorPid(cid)
+	if err != nil {
+		return Guest{}, err
+	}
+
+	cgroup, err := os.ReadFile(filepath.Join(g.procfs, pid, "cgroup"))
+	if err != nil {
+		return Guest{}, fmt.Errorf("failed to read cgroup of hypervisor %s of guest %d: %w", pid, cid, err)
+	}
+	m := podUIDRe.FindSubmatch(cgroup)
+	if m == nil {
+		return Guest{}, fmt.Errorf("hypervisor %s of guest %d is not in the cgroup of a pod", pid, cid)
+	}
+	uid := strings.ReplaceAll(string(m[1]), "_", "-")
+
+	pod, err := g.findPod(uid)
+	if err != nil {
+		return Guest{}, fmt.Errorf("failed to find pod %s of guest %d: %w", uid, cid, err)
+	}
+	return Guest{Namespace: pod.Namespace, Pod: pod.Name}, nil
+}
+
+// hypervisorPid returns the pid of the hypervisor process of the guest with
+// the given CID.
+func (g *guestResolver) hypervisorPid(cid uint32) (string, error) {
+	entries, err := os.ReadDir(g.procfs)
+	if err != nil {
+		return "", err
+	}
+	want := strconv.FormatUint(uint64(cid), 10)
+	for _, e := range entries {
+		if _, err := strconv.ParseUint(e.Name(), 10, 32); err != nil {
+			continue
+		}
+		cmdline, err := os.ReadFile(filepath.Join(g.procfs, e.Name(), "cmdline"))
+		if err != nil {
+			continue
+		}
+		for _, arg := range bytes.Split(cmdline, []byte{0}) {
+			if m := guestCIDRe.FindSubmatch(arg); m != nil && string(m[1]) == want {
+				return e.Name(), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no hypervisor found for guest %d", cid)
+}
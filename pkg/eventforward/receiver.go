@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package eventforward
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/api/v1/tetragon/codegen/helpers"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/metrics/exportmetrics"
+	"github.com/cilium/tetragon/pkg/option"
+	"github.com/cilium/tetragon/pkg/process"
+	"github.com/cilium/tetragon/pkg/reader/node"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type notifier interface {
+	NotifyListeners(original interface{}, processed *tetragon.GetEventsResponse)
+}
+
+// Guest is the pod of a VM guest. The events forwarded by its agent are
+// attributed to this pod.
+type Guest struct {
+	Namespace string
+	Pod       string
+}
+
+// Receiver receives the events forwarded by guest agents and merges them into
+// the event stream of the host agent.
+type Receiver struct {
+	notifier notifier
+	nodeName string
+	// guestOf returns the guest of the agent of a connection, an error
+	// for the connections of unknown guests
+	guestOf func(conn net.Conn) (Guest, error)
+	// getPodInfo is used to attribute forwarded processes to pods
+	getPodInfo func(cid, bin, args string, nspid uint32) *tetragon.Pod
+}
+
+// NewReceiver returns a Receiver that receives the events of the guests. The
+// guests are identified by their vsock context ids (CIDs), which are resolved
+// to the pods of their sandboxes when they connect. The connections of guests
+// that are not the sandbox of a pod are rejected.
+func NewReceiver(notifier notifier) *Receiver {
+	resolver := &guestResolver{
+		procfs: option.Config.ProcFS,
+		findPod: func(podID string) (*corev1.Pod, error) {
+			return process.GetK8s().FindPod(podID)
+		},
+	}
+	return &Receiver{
+		notifier: notifier,
+		nodeName: node.GetNodeNameForExport(),
+		guestOf: func(conn net.Conn) (Guest, error) {
+			addr, ok := conn.RemoteAddr().(*vsockAddr)
+			if !ok {
+				return Guest{}, fmt.Errorf("not a vsock connection")
+			}
+			return resolver.resolve(addr.cid)
+		},
+		getPodInfo: process.GetPodInfo,
+	}
+}
+
+// ServeVsock receives forwarded events on the given vsock port until ctx is
+// done.
+func (r *Receiver) ServeVsock(ctx context.Context, port uint32) error {
+	l, err := listenVsock(port)
+	if err != nil {
+		return err
+	}
+	logger.GetLogger().WithField("addr", l.Addr()).Info("Receiving forwarded events from guest agents")
+	go r.Serve(ctx, l)
+	return nil
+}
+
+// Serve receives forwarded events from the connections of l until ctx is
+// done.
+func (r *Receiver) Serve(ctx context.Context, l net.Listener) {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	go func() {
+		<-ctx.Done()
+		l.Close()
+	}()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.GetLogger().WithError(err).Warn("Failed to accept guest agent connection")
+			}
+			return
+		}
+		guest, err := r.guestOf(conn)
+		if err != nil {
+			logger.GetLogger().WithField("addr", conn.RemoteAddr()).WithError(err).Warn("Rejected connection of unknown guest agent")
+			exportmetrics.ForwardGuestConnections.WithLabelValues(exportmetrics.ForwardRejected).Inc()
+			conn.Close()
+			continue
+		}
+		exportmetrics.ForwardGuestConnections.WithLabelValues(exportmetrics.ForwardAccepted).Inc()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.handleConn(ctx, conn, guest)
+		}()
+	}
+}
+
+func (r *Receiver) handleConn(ctx context.Context, conn net.Conn, guest Guest) {
+	log := logger.GetLogger().WithField("addr", conn.RemoteAddr()).
+		WithField("namespace", guest.Namespace).WithField("pod", guest.Pod)
+	log.Info("Guest agent connected")
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		conn.Close()
+	}()
+
+	rd := bufio.NewReader(conn)
+	for {
+		event, err := readEvent(rd)
+		if err != nil {
+			if errors.Is(err, io.EOF) || ctx.Err() != nil {
+				log.Info("Guest agent disconnected")
+			} else {
+				log.WithError(err).Warn("Failed to receive event from guest agent")
+			}
+			return
+		}
+		r.attribute(event, guest)
+		r.notifier.NotifyListeners(nil, event)
+	}
+}
+
+// attribute re-attributes an event forwarded by the agent of guest to the
+// host: the node name is set to the one of the host, and the processes are
+// attributed to the pod of the guest. The pod information of the guest agent
+// is not trusted: the container ids are only used to find the containers of
+// the pod of the guest, and processes of containers of other pods are
+// attributed to the pod of the guest without container.
+func (r *Receiver) attribute(event *tetragon.GetEventsResponse, guest Guest) {
+	event.NodeName = r.nodeName
+	status := exportmetrics.ForwardReceived
+	for _, proc := range []*tetragon.Process{helpers.ResponseGetProcess(event), helpers.ResponseGetParent(event)} {
+		if proc == nil {
+			continue
+		}
+		proc.Pod = &tetragon.Pod{Namespace: guest.Namespace, Name: guest.Pod}
+		if !option.Config.EnableK8s || proc.Docker == "" {
+			continue
+		}
+		pod := r.getPodInfo(proc.Docker, proc.Binary, proc.Arguments, 0)
+		if pod == nil {
+			continue
+		}
+		if pod.Namespace != guest.Namespace || pod.Name != guest.Pod {
+			logger.GetLogger().WithField("namespace", guest.Namespace).WithField("pod", guest.Pod).
+				WithField("container", proc.Docker).
+				Debug("Forwarded process of a container of another pod, attributing it to the pod of the guest")
+			status = exportmetrics.ForwardPodMismatch
+			continue
+		}
+		proc.Pod = pod
+	}
+	exportmetrics.ForwardReceivedEvents.WithLabelValues(status).Inc()
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package eventforward
+
+import (
+	"fmt"
+	"net"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// vsockAddr is the address of a vsock endpoint.
+type vsockAddr struct {
+	cid  uint32
+	port uint32
+}
+
+func (a *vsockAddr) Network() string {
+	return "vsock"
+}
+
+func (a *vsockAddr) String() string {
+	return fmt.Sprintf("vsock://%d:%d", a.cid, a.port)
+}
+
+func sockaddrToVsockAddr(sa unix.Sockaddr) *vsockAddr {
+	if vm, ok := sa.(*unix.SockaddrVM); ok {
+		return &vsockAddr{cid: vm.CID, port: vm.Port}
+	}
+	return &vsockAddr{}
+}
+
+// vsockConn is a net.Conn for a vsock stream socket. Reads, writes, and
+// deadlines are handled by the os.File of the (non-blocking) socket, so that
+// they go through the runtime poller.
+type vsockConn struct {
+	*os.File
+	local  *vsockAddr
+	remote *vsockAddr
+}
+
+func (c *vsockConn) LocalAddr() net.Addr {
+	return c.local
+}
+
+func (c *vsockConn) RemoteAddr() net.Addr {
+	return c.remote
+}
+
+// dialVsock connects to port on the vsock endpoint with the given cid.
+func dialVsock(cid, port uint32) (net.Conn, error) {
+	fd, err := unix.Socket(unix.AF_VSOCK, unix.SOCK_STREAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, fmt.Errorf("vsock socket failed: %w", err)
+	}
+	if err := unix.Connect(fd, &unix.SockaddrVM{CID: cid, Port: port}); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("vsock connect to %d:%d failed: %w", cid, port, err)
+	}
+	if err := unix.SetNonblock(fd, true); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("vsock set non-blocking failed: %w", err)
+	}
+
+	local := &vsockAddr{}
+	if sa, err := unix.Getsockname(fd); err == nil {
+		local = sockaddrToVsockAddr(sa)
+	}
+	return &vsockConn{
+		File:   os.NewFile(uintptr(fd), "vsock"),
+		local:  local,
+		remote: &vsockAddr{cid: cid, port: port},
+	}, nil
+}
+
+// vsockListener is a net.Listener for vsock stream sockets.
+type vsockListener struct {
+	f    *os.File
+	addr *vsockAddr
+}
+
+// listenVsock listens for connections on port from any vsock endpoint.
+func listenVsock(port uint32) (net.Listener, error) {
+	fd, err := unix.Socket(unix.AF_VSOCK, unix.SOCK_STREAM|unix.SOCK_NONBLOCK|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, fmt.Errorf("vsock socket failed: %w", err)
+	}
+	if err := unix.Bind(fd, &unix.SockaddrVM{CID: unix.VMADDR_CID_ANY, Port: port}); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("vsock bind to port %d failed: %w", port, err)
+	}
+	if err := unix.Listen(fd, unix.SOMAXCONN); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("vsock listen failed: %w", err)
+	}
+	return &vsockListener{
+		f:    os.NewFile(uintptr(fd), "vsock-listener"),
+		addr: &vsockAddr{cid: unix.VMADDR_CID_ANY, port: port},
+	}, nil
+}
+
+func (l *vsockListener) Accept() (net.Conn, error) {
+	rc, err := l.f.SyscallConn()
+	if err != nil {
+		return nil, err
+	}
+
+	var nfd int
+	var sa unix.Sockaddr
+	var acceptErr error
+	err = rc.Read(func(fd uintptr) bool {
+		nfd, sa, acceptErr = unix.Accept4(int(fd), unix.SOCK_NONBLOCK|unix.SOCK_CLOEXEC)
+		return acceptErr != unix.EAGAIN
+	})
+	if err != nil {
+		return nil, err
+	}
+	if acceptErr != nil {
+		return nil, fmt.Errorf("vsock accept failed: %w", acceptErr)
+	}
+
+	return &vsockConn{
+		File:   os.NewFile(uintptr(nfd), "vsock"),
+		local:  l.addr,
+		remote: sockaddrToVsockAddr(sa),
+	}, nil
+}
+
+func (l *vsockListener) Close() error {
+	return l.f.Close()
+}
+
+func (l *vsockListener) Addr() net.Addr {
+	return l.addr
+}
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package exporter
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/reader/node"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Sink is a named export encoder.
+type Sink struct {
+	Name    string
+	Encoder ExportEncoder
+}
+
+// FailoverEncoder is an ExportEncoder that writes events to a primary sink
+// and switches over to a failover sink when the primary sink fails (e.g.,
+// because the disk is full). While failing over, the primary sink is retried
+// every retryInterval and events switch back as soon as it recovers.
+//
+// On each switchover, an ExportSinkHealth event is written recording the time
+// range of the events that were delivered to the failover sink, from the time
+// fields of the events.
+type FailoverEncoder struct {
+	mu            sync.Mutex
+	primary       Sink
+	failover      Sink
+	retryInterval time.Duration
+	timeNow       func() time.Time
+
+	failing    bool
+	firstTime  time.Time
+	lastTime   time.Time
+	lastRetry  time.Time
+	switchover uint64
+}
+
+func NewFailoverEncoder(primary, failover Sink, retryInterval time.Duration) *FailoverEncoder {
+	return &FailoverEncoder{
+		primary:       primary,
+		failover:      failover,
+		retryInterval: retryInterval,
+		timeNow:       time.Now,
+	}
+}
+
+// Switchovers returns the number of times events switched over to the
+// failover sink.
+func (f *FailoverEncoder) Switchovers() uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.switchover
+}
+
+func (f *FailoverEncoder) Encode(v interface{}) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.failing {
+		err := f.primary.Encoder.Encode(v)
+		if err == nil {
+			return nil
+		}
+		f.failing = true
+		f.firstTime = f.eventTime(v)
+		f.lastRetry = f.timeNow()
+		f.switchover++
+		logger.GetLogger().WithError(err).WithFields(logrus.Fields{
+			"sink":           f.primary.Name,
+			"failoverSink":   f.failover.Name,
+			"firstEventTime": f.firstTime,
+		}).Warn("Export sink failed, switching over to failover sink")
+		f.encodeHealth(f.failover.Encoder, &tetragon.ExportSinkHealth{
+			Sink:           f.primary.Name,
+			FailoverSink:   f.failover.Name,
+			Healthy:        false,
+			FirstEventTime: timestamppb.New(f.firstTime),
+			Error:          err.Error(),
+		})
+		return f.encodeFailover(v)
+	}
+
+	if now := f.timeNow(); now.Sub(f.lastRetry) >= f.retryInterval {
+		f.lastRetry = now
+		if err := f.primary.Encoder.Encode(v); err == nil {
+			f.failing = false
+			health := &tetragon.ExportSinkHealth{
+				Sink:           f.primary.Name,
+				FailoverSink:   f.failover.Name,
+				Healthy:        true,
+				FirstEventTime: timestamppb.New(f.firstTime),
+				LastEventTime:  timestamppb.New(f.lastTime),
+			}
+			logger.GetLogger().WithFields(logrus.Fields{
+				"sink":           f.primary.Name,
+				"failoverSink":   f.failover.Name,
+				"firstEventTime": f.firstTime,
+				"lastEventTime":  f.lastTime,
+			}).Info("Export sink recovered, switching back from failover sink")
+			f.encodeHealth(f.failover.Encoder, health)
+			f.encodeHealth(f.primary.Encoder, health)
+			return nil
+		}
+	}
+	return f.encodeFailover(v)
+}
+
+// encodeFailover writes an event to the failover sink, and records its time
+// as the time of the last event delivered to it.
+func (f *FailoverEncoder) encodeFailover(v interface{}) error {
+	f.lastTime = f.eventTime(v)
+	return f.failover.Encoder.Encode(v)
+}
+
+// eventTime returns the time of an event, or the current time for events
+// without a time.
+func (f *FailoverEncoder) eventTime(v interface{}) time.Time {
+	if ev, ok := v.(*tetragon.GetEventsResponse); ok && ev.GetTime() != nil {
+		return ev.GetTime().AsTime()
+	}
+	return f.timeNow()
+}
+
+func (f *FailoverEncoder) encodeHealth(encoder ExportEncoder, health *tetragon.ExportSinkHealth) {
+	err := encoder.Encode(&tetragon.GetEventsResponse{
+		Event:    &tetragon.GetEventsResponse_ExportSinkHealth{ExportSinkHealth: health},
+		NodeName: node.GetNodeNameForExport(),
+		Time:     timestamppb.New(f.timeNow()),
+	})
+	if err != nil {
+		logger.GetLogger().WithError(err).Warn("Failed to encode export_sink_health event")
+	}
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package exporter
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type fakeSinkEncoder struct {
+	fail   bool
+	events []*tetragon.GetEventsResponse
+}
+
+func (e *fakeSinkEncoder) Encode(v interface{}) error {
+	if e.fail {
+		return errors.New("no space left on device")
+	}
+	e.events = append(e.events, v.(*tetragon.GetEventsResponse))
+	return nil
+}
+
+func newTestEvent(sec int64) *tetragon.GetEventsResponse {
+	return &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessExec{ProcessExec: &tetragon.ProcessExec{}},
+		Time:  timestamppb.New(time.Unix(sec, 0)),
+	}
+}
+
+func TestFailoverEncoder(t *testing.T) {
+	primary := &fakeSinkEncoder{}
+	failover := &fakeSinkEncoder{}
+	now := time.Unix(0, 0)
+	enc := NewFailoverEncoder(Sink{Name: "primary", Encoder: primary}, Sink{Name: "failover", Encoder: failover}, time.Minute)
+	enc.timeNow = func() time.Time { return now }
+
+	// event 1: primary works
+	require.NoError(t, enc.Encode(newTestEvent(1)))
+	assert.Len(t, primary.events, 1)
+	assert.Empty(t, failover.events)
+
+	// events 2,3: primary fails, events go to the failover sink
+	primary.fail = true
+	require.NoError(t, enc.Encode(newTestEvent(2)))
+	require.NoError(t, enc.Encode(newTestEvent(3)))
+	require.Len(t, failover.events, 3)
+	health := failover.events[0].GetExportSinkHealth()
+	require.NotNil(t, health)
+	assert.Equal(t, "primary", health.Sink)
+	assert.Equal(t, "failover", health.FailoverSink)
+	assert.False(t, health.Healthy)
+	assert.Equal(t, time.Unix(2, 0).UTC(), health.FirstEventTime.AsTime())
+	assert.Nil(t, health.LastEventTime)
+	assert.Equal(t, "no space left on device", health.Error)
+	assert.Equal(t, uint64(1), enc.Switchovers())
+
+	// event 4: primary recovered, but the retry interval did not elapse yet
+	primary.fail = false
+	require.NoError(t, enc.Encode(newTestEvent(4)))
+	assert.Len(t, primary.events, 1)
+	assert.Len(t, failover.events, 4)
+
+	// event 5: primary is retried and events switch back
+	now = now.Add(time.Minute)
+	require.NoError(t, enc.Encode(newTestEvent(5)))
+	require.Len(t, primary.events, 3)
+	require.Len(t, failover.events, 5)
+	for _, ev := range []*tetragon.GetEventsResponse{primary.events[2], failover.events[4]} {
+		health := ev.GetExportSinkHealth()
+		require.NotNil(t, health)
+		assert.True(t, health.Healthy)
+		assert.Equal(t, time.Unix(2, 0).UTC(), health.FirstEventTime.AsTime())
+		assert.Equal(t, time.Unix(4, 0).UTC(), health.LastEventTime.AsTime())
+	}
+}
@@ -0,0 +1,332 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package fluent exports events to fluentd or fluent-bit with the forward
+// protocol, as MessagePack records sent over TCP, see
+// https://github.com/fluent/fluentd/wiki/Forward-Protocol-Specification-v1
+package fluent
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/encoder"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/metrics/exportmetrics"
+	"github.com/tinylib/msgp/msgp"
+)
+
+const (
+	// exportTimeout is the timeout of sending a batch, and of its ack
+	exportTimeout = 10 * time.Second
+	// closeTimeout is the time given to export the queued events on close
+	closeTimeout = 10 * time.Second
+
+	defaultInitialBackoff = 1 * time.Second
+	maxBackoff            = 30 * time.Second
+	// maxRetryTime is the time after which the events of a batch that
+	// cannot be exported are dropped
+	maxRetryTime = 2 * time.Minute
+)
+
+var errClosed = errors.New("fluent exporter is closed")
+
+// Config configures an Exporter.
+type Config struct {
+	// Address is the host:port address of the forward input of the server.
+	Address string
+	// Tag is the tag of the events, used by the server to route them.
+	Tag string
+	// TLS is the TLS configuration of the connection, nil for plain TCP.
+	TLS *tls.Config
+	// RequireAck waits for the server to acknowledge each batch, which is
+	// otherwise sent again.
+	RequireAck bool
+	// QueueSize is the number of events waiting to be exported. New events
+	// are dropped when the queue is full.
+	QueueSize int
+	// BatchSize is the maximum number of events of a forward message.
+	BatchSize int
+	// FlushInterval is the maximum time events wait in the queue.
+	FlushInterval time.Duration
+	// TimeFormat and TimeLocation encode the timestamps of the records, as
+	// in the JSON export. They default to RFC3339 in UTC.
+	TimeFormat   encoder.TimeFormat
+	TimeLocation *time.Location
+}
+
+// recordEncoder returns the encoder of the records of the events.
+func (c *Config) recordEncoder(w io.Writer) *encoder.ProtojsonEncoder {
+	format, location := c.TimeFormat, c.TimeLocation
+	if format == "" {
+		format = encoder.TimeFormatRFC3339
+	}
+	if location == nil {
+		location = time.UTC
+	}
+	return encoder.NewProtojsonEncoderWithTime(w, format, location)
+}
+
+func (c *Config) validate() error {
+	if c.Address == "" {
+		return errors.New("fluent address is empty")
+	}
+	if c.Tag == "" {
+		return errors.New("fluent tag is empty")
+	}
+	if c.QueueSize <= 0 {
+		return fmt.Errorf("fluent queue size '%d' is not positive", c.QueueSize)
+	}
+	if c.BatchSize <= 0 {
+		return fmt.Errorf("fluent batch size '%d' is not positive", c.BatchSize)
+	}
+	if c.FlushInterval <= 0 {
+		return fmt.Errorf("fluent flush interval '%s' is not positive", c.FlushInterval)
+	}
+	return nil
+}
+
+// Exporter is an exporter.ExportEncoder that exports events to a fluentd or
+// fluent-bit server. Events are queued and sent in batches, as forward
+// messages, by a goroutine, which reconnects and retries the failed batches
+// with an exponential backoff.
+type Exporter struct {
+	cfg Config
+	// initialBackoff is the time before the first retry of a failed export
+	initialBackoff time.Duration
+
+	// conn and reader are only used by the run goroutine
+	conn   net.Conn
+	reader *msgp.Reader
+
+	mu     sync.RWMutex
+	closed bool
+	queue  chan *tetragon.GetEventsResponse
+	done   chan struct{}
+
+	// ctx is cancelled to abort the retries on close
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewExporter returns an Exporter exporting events to the server of cfg. The
+// connection to the server is established with the first batch.
+func NewExporter(cfg Config) (*Exporter, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	e := newExporter(cfg)
+	go e.run()
+	return e, nil
+}
+
+func newExporter(cfg Config) *Exporter {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Exporter{
+		cfg:            cfg,
+		initialBackoff: defaultInitialBackoff,
+		queue:          make(chan *tetragon.GetEventsResponse, cfg.QueueSize),
+		done:           make(chan struct{}),
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+}
+
+// Encode queues the event for export, or drops it if the queue is full.
+func (e *Exporter) Encode(v interface{}) error {
+	event, ok := v.(*tetragon.GetEventsResponse)
+	if !ok {
+		return fmt.Errorf("cannot export event of type %T", v)
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closed {
+		return errClosed
+	}
+	select {
+	case e.queue <- event:
+	default:
+		exportmetrics.FluentEvents.WithLabelValues(exportmetrics.FluentDropped).Inc()
+	}
+	return nil
+}
+
+// Close exports the queued events, waiting for at most closeTimeout, and
+// closes the connection to the server.
+func (e *Exporter) Close() error {
+	e.mu.Lock()
+	if e.closed {
+		e.mu.Unlock()
+		return nil
+	}
+	e.closed = true
+	close(e.queue)
+	e.mu.Unlock()
+
+	select {
+	case <-e.done:
+	case <-time.After(closeTimeout):
+		e.cancel()
+		<-e.done
+	}
+	e.cancel()
+	return nil
+}
+
+func (e *Exporter) run() {
+	defer close(e.done)
+	defer e.disconnect()
+	ticker := time.NewTicker(e.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*tetragon.GetEventsResponse, 0, e.cfg.BatchSize)
+	for {
+		select {
+		case event, ok := <-e.queue:
+			if !ok {
+				e.export(batch)
+				return
+			}
+			batch = append(batch, event)
+			if len(batch) < e.cfg.BatchSize {
+				continue
+			}
+		case <-ticker.C:
+			if len(batch) == 0 {
+				continue
+			}
+		}
+		e.export(batch)
+		batch = batch[:0]
+	}
+}
+
+func (e *Exporter) connect() error {
+	var dialer interface {
+		DialContext(ctx context.Context, network, address string) (net.Conn, error)
+	} = &net.Dialer{Timeout: exportTimeout}
+	if e.cfg.TLS != nil {
+		dialer = &tls.Dialer{NetDialer: &net.Dialer{Timeout: exportTimeout}, Config: e.cfg.TLS}
+	}
+	conn, err := dialer.DialContext(e.ctx, "tcp", e.cfg.Address)
+	if err != nil {
+		return err
+	}
+	e.conn = conn
+	e.reader = msgp.NewReader(conn)
+	return nil
+}
+
+func (e *Exporter) disconnect() {
+	if e.conn != nil {
+		e.conn.Close()
+		e.conn = nil
+		e.reader = nil
+	}
+}
+
+// send sends a forward message and, if chunk is not empty, waits for its
+// ack. The connection is closed on errors, to be established again by the
+// next send.
+func (e *Exporter) send(msg []byte, chunk string) error {
+	if e.conn == nil {
+		if err := e.connect(); err != nil {
+			return err
+		}
+	}
+	err := func() error {
+		if err := e.conn.SetDeadline(time.Now().Add(exportTimeout)); err != nil {
+			return err
+		}
+		if _, err := e.conn.Write(msg); err != nil {
+			return err
+		}
+		if chunk == "" {
+			return nil
+		}
+		ack, err := readAck(e.reader)
+		if err != nil {
+			return fmt.Errorf("failed to read ack: %w", err)
+		}
+		if ack != chunk {
+			return fmt.Errorf("unexpected ack '%s', expected '%s'", ack, chunk)
+		}
+		return nil
+	}()
+	if err != nil {
+		e.disconnect()
+	}
+	return err
+}
+
+// newChunk returns a random chunk ID, to match a forward message with its
+// ack.
+func newChunk() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(id), nil
+}
+
+// export sends a batch of events, retrying with an exponential backoff for
+// at most maxRetryTime.
+func (e *Exporter) export(batch []*tetragon.GetEventsResponse) {
+	if len(batch) == 0 {
+		return
+	}
+	events := float64(len(batch))
+	log := logger.GetLogger().WithField("address", e.cfg.Address)
+
+	var chunk string
+	if e.cfg.RequireAck {
+		var err error
+		if chunk, err = newChunk(); err != nil {
+			log.WithError(err).Warnf("Failed to generate a chunk ID, dropping %d events", len(batch))
+			exportmetrics.FluentEvents.WithLabelValues(exportmetrics.FluentDropped).Add(events)
+			return
+		}
+	}
+	msg, err := forwardMessage(&e.cfg, batch, chunk, time.Now())
+	if err != nil {
+		log.WithError(err).Warnf("Failed to encode %d events as a forward message, dropping them", len(batch))
+		exportmetrics.FluentEvents.WithLabelValues(exportmetrics.FluentDropped).Add(events)
+		return
+	}
+
+	deadline := time.Now().Add(maxRetryTime)
+	backoff := e.initialBackoff
+	for {
+		// a batch that is sent again keeps its chunk ID, so that the
+		// server can discard the duplicates
+		err := e.send(msg, chunk)
+		if err == nil {
+			exportmetrics.FluentEvents.WithLabelValues(exportmetrics.FluentSent).Add(events)
+			return
+		}
+
+		if time.Now().Add(backoff).After(deadline) || e.ctx.Err() != nil {
+			log.WithError(err).Warnf("Failed to export %d events to the fluent server, dropping them", len(batch))
+			exportmetrics.FluentEvents.WithLabelValues(exportmetrics.FluentDropped).Add(events)
+			return
+		}
+		select {
+		case <-e.ctx.Done():
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
@@ -0,0 +1,271 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package fluent
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/encoder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tinylib/msgp/msgp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type entry struct {
+	time   time.Time
+	record map[string]interface{}
+}
+
+type message struct {
+	tag     string
+	entries []entry
+	options map[string]interface{}
+}
+
+// readMessage reads a Forward mode message, as a fluent server does.
+func readMessage(r *msgp.Reader) (*message, error) {
+	if _, err := r.ReadArrayHeader(); err != nil {
+		return nil, err
+	}
+	var msg message
+	var err error
+	if msg.tag, err = r.ReadString(); err != nil {
+		return nil, err
+	}
+	n, err := r.ReadArrayHeader()
+	if err != nil {
+		return nil, err
+	}
+	for i := uint32(0); i < n; i++ {
+		if _, err := r.ReadArrayHeader(); err != nil {
+			return nil, err
+		}
+		var t eventTime
+		if err := r.ReadExtension(&t); err != nil {
+			return nil, err
+		}
+		record := map[string]interface{}{}
+		if err := r.ReadMapStrIntf(record); err != nil {
+			return nil, err
+		}
+		msg.entries = append(msg.entries, entry{time: t.Time, record: record})
+	}
+	msg.options = map[string]interface{}{}
+	if err := r.ReadMapStrIntf(msg.options); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+func writeAck(conn net.Conn, chunk string) error {
+	b := msgp.AppendMapHeader(nil, 1)
+	b = msgp.AppendString(b, "ack")
+	b = msgp.AppendString(b, chunk)
+	_, err := conn.Write(b)
+	return err
+}
+
+func execEvent(t time.Time, binary string) *tetragon.GetEventsResponse {
+	return &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessExec{ProcessExec: &tetragon.ProcessExec{
+			Process: &tetragon.Process{
+				Binary: binary,
+				Pid:    wrapperspb.UInt32(42),
+			},
+		}},
+		NodeName: "node-1",
+		Time:     timestamppb.New(t),
+	}
+}
+
+func testConfig(address string) Config {
+	return Config{
+		Address:       address,
+		Tag:           "tetragon",
+		RequireAck:    true,
+		QueueSize:     10,
+		BatchSize:     2,
+		FlushInterval: 10 * time.Millisecond,
+	}
+}
+
+func TestExporter(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	messages := make(chan *message, 10)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := msgp.NewReader(conn)
+		for {
+			msg, err := readMessage(r)
+			if err != nil {
+				return
+			}
+			if writeAck(conn, msg.options["chunk"].(string)) != nil {
+				return
+			}
+			messages <- msg
+		}
+	}()
+
+	e, err := NewExporter(testConfig(ln.Addr().String()))
+	require.NoError(t, err)
+
+	now := time.Unix(1700000000, 123456789)
+	for _, binary := range []string{"/a", "/b", "/c"} {
+		require.NoError(t, e.Encode(execEvent(now, binary)))
+	}
+	assert.Error(t, e.Encode("event"))
+
+	var entries []entry
+	for len(entries) < 3 {
+		select {
+		case msg := <-messages:
+			assert.Equal(t, "tetragon", msg.tag)
+			assert.EqualValues(t, len(msg.entries), msg.options["size"])
+			assert.NotEmpty(t, msg.options["chunk"])
+			entries = append(entries, msg.entries...)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for events, got %d", len(entries))
+		}
+	}
+	require.NoError(t, e.Close())
+
+	for i, binary := range []string{"/a", "/b", "/c"} {
+		assert.True(t, now.Equal(entries[i].time))
+		assert.Equal(t, "node-1", entries[i].record["node_name"])
+		exec := entries[i].record["process_exec"].(map[string]interface{})
+		process := exec["process"].(map[string]interface{})
+		assert.Equal(t, binary, process["binary"])
+		assert.EqualValues(t, 42, process["pid"])
+	}
+	assert.Error(t, e.Encode(execEvent(now, "/d")))
+}
+
+func TestForwardMessageTimeFormat(t *testing.T) {
+	now := time.Unix(1700000000, 123456789)
+	cfg := testConfig("")
+	cfg.TimeFormat = encoder.TimeFormatUnixNano
+	b, err := forwardMessage(&cfg, []*tetragon.GetEventsResponse{execEvent(now, "/a")}, "", now)
+	require.NoError(t, err)
+	msg, err := readMessage(msgp.NewReader(bytes.NewReader(b)))
+	require.NoError(t, err)
+	require.Len(t, msg.entries, 1)
+	assert.Equal(t, "1700000000123456789", msg.entries[0].record["time"])
+
+	// RFC3339 in UTC by default
+	cfg.TimeFormat = ""
+	b, err = forwardMessage(&cfg, []*tetragon.GetEventsResponse{execEvent(now, "/a")}, "", now)
+	require.NoError(t, err)
+	msg, err = readMessage(msgp.NewReader(bytes.NewReader(b)))
+	require.NoError(t, err)
+	assert.Equal(t, "2023-11-14T22:13:20.123456789Z", msg.entries[0].record["time"])
+}
+
+func TestExporterRetry(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	chunks := make(chan string, 10)
+	go func() {
+		for i := 0; ; i++ {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			msg, err := readMessage(msgp.NewReader(conn))
+			if err != nil {
+				conn.Close()
+				return
+			}
+			chunk := msg.options["chunk"].(string)
+			chunks <- chunk
+			// the first connection is closed without an ack
+			if i > 0 {
+				writeAck(conn, chunk)
+			}
+			conn.Close()
+		}
+	}()
+
+	e := newExporter(testConfig(ln.Addr().String()))
+	e.initialBackoff = 10 * time.Millisecond
+	go e.run()
+
+	require.NoError(t, e.Encode(execEvent(time.Now(), "/a")))
+	require.NoError(t, e.Close())
+
+	close(chunks)
+	var got []string
+	for chunk := range chunks {
+		got = append(got, chunk)
+	}
+	require.Len(t, got, 2)
+	// the batch is sent again with the same chunk
+	assert.Equal(t, got[0], got[1])
+}
+
+func TestExporterNoAck(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	messages := make(chan *message, 10)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		msg, err := readMessage(msgp.NewReader(conn))
+		if err != nil {
+			return
+		}
+		messages <- msg
+	}()
+
+	cfg := testConfig(ln.Addr().String())
+	cfg.RequireAck = false
+	e, err := NewExporter(cfg)
+	require.NoError(t, err)
+	require.NoError(t, e.Encode(execEvent(time.Now(), "/a")))
+
+	select {
+	case msg := <-messages:
+		assert.Len(t, msg.entries, 1)
+		assert.NotContains(t, msg.options, "chunk")
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for events")
+	}
+	require.NoError(t, e.Close())
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := testConfig("localhost:24224")
+	assert.NoError(t, cfg.validate())
+	for _, invalid := range []func(*Config){
+		func(c *Config) { c.Address = "" },
+		func(c *Config) { c.Tag = "" },
+		func(c *Config) { c.QueueSize = 0 },
+		func(c *Config) { c.BatchSize = 0 },
+		func(c *Config) { c.FlushInterval = 0 },
+	} {
+		c := cfg
+		invalid(&c)
+		assert.Error(t, c.validate())
+	}
+}
@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package fluent
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/tinylib/msgp/msgp"
+)
+
+// eventTime is the EventTime extension of the forward protocol, the time of
+// an entry with nanoseconds, see
+// https://github.com/fluent/fluentd/wiki/Forward-Protocol-Specification-v1#eventtime-ext-format
+type eventTime struct {
+	time.Time
+}
+
+func (t *eventTime) ExtensionType() int8 { return 0 }
+
+func (t *eventTime) Len() int { return 8 }
+
+func (t *eventTime) MarshalBinaryTo(b []byte) error {
+	binary.BigEndian.PutUint32(b, uint32(t.Unix()))
+	binary.BigEndian.PutUint32(b[4:], uint32(t.Nanosecond()))
+	return nil
+}
+
+func (t *eventTime) UnmarshalBinary(b []byte) error {
+	if len(b) != 8 {
+		return fmt.Errorf("invalid EventTime length %d", len(b))
+	}
+	t.Time = time.Unix(int64(binary.BigEndian.Uint32(b)), int64(binary.BigEndian.Uint32(b[4:])))
+	return nil
+}
+
+// appendValue appends a value decoded from JSON, with numbers as
+// json.Number, as MessagePack.
+func appendValue(b []byte, v interface{}) ([]byte, error) {
+	var err error
+	switch v := v.(type) {
+	case nil:
+		return msgp.AppendNil(b), nil
+	case bool:
+		return msgp.AppendBool(b, v), nil
+	case string:
+		return msgp.AppendString(b, v), nil
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return msgp.AppendInt64(b, i), nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return msgp.AppendFloat64(b, f), nil
+	case []interface{}:
+		b = msgp.AppendArrayHeader(b, uint32(len(v)))
+		for _, elem := range v {
+			if b, err = appendValue(b, elem); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case map[string]interface{}:
+		// sort the keys for a deterministic encoding
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		b = msgp.AppendMapHeader(b, uint32(len(keys)))
+		for _, key := range keys {
+			b = msgp.AppendString(b, key)
+			if b, err = appendValue(b, v[key]); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("cannot encode value of type %T", v)
+}
+
+// appendEntry appends the [time, record] entry of an event, whose record is
+// the JSON encoding of the event, as in the JSON export, with the time format
+// of cfg.
+func appendEntry(b []byte, event *tetragon.GetEventsResponse, cfg *Config, now time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := cfg.recordEncoder(&buf).Encode(event); err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(&buf)
+	dec.UseNumber()
+	var record map[string]interface{}
+	if err := dec.Decode(&record); err != nil {
+		return nil, err
+	}
+
+	var err error
+	t := eventTime{now}
+	if event.GetTime() != nil {
+		t.Time = event.GetTime().AsTime()
+	}
+	b = msgp.AppendArrayHeader(b, 2)
+	if b, err = msgp.AppendExtension(b, &t); err != nil {
+		return nil, err
+	}
+	return appendValue(b, record)
+}
+
+// forwardMessage returns the Forward mode message of a batch of events,
+// [tag, [entries...], option], with the tag of cfg. The chunk option, if not
+// empty, requests an ack from the server.
+func forwardMessage(cfg *Config, batch []*tetragon.GetEventsResponse, chunk string, now time.Time) ([]byte, error) {
+	var err error
+	b := msgp.AppendArrayHeader(nil, 3)
+	b = msgp.AppendString(b, cfg.Tag)
+	b = msgp.AppendArrayHeader(b, uint32(len(batch)))
+	for _, event := range batch {
+		if b, err = appendEntry(b, event, cfg, now); err != nil {
+			return nil, err
+		}
+	}
+	options := uint32(1)
+	if chunk != "" {
+		options++
+	}
+	b = msgp.AppendMapHeader(b, options)
+	b = msgp.AppendString(b, "size")
+	b = msgp.AppendInt(b, len(batch))
+	if chunk != "" {
+		b = msgp.AppendString(b, "chunk")
+		b = msgp.AppendString(b, chunk)
+	}
+	return b, nil
+}
+
+// readAck reads the response of the server, {"ack": chunk}, and returns its
+// chunk.
+func readAck(r *msgp.Reader) (string, error) {
+	n, err := r.ReadMapHeader()
+	if err != nil {
+		return "", err
+	}
+	var ack string
+	for i := uint32(0); i < n; i++ {
+		key, err := r.ReadString()
+		if err != nil {
+			return "", err
+		}
+		if key != "ack" {
+			if err := r.Skip(); err != nil {
+				return "", err
+			}
+			continue
+		}
+		if ack, err = r.ReadString(); err != nil {
+			return "", err
+		}
+	}
+	return ack, nil
+}
@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package otlp
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/api/v1/tetragon/codegen/helpers"
+	"github.com/cilium/tetragon/pkg/version"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+)
+
+const (
+	serviceName = "tetragon"
+	scopeName   = "github.com/cilium/tetragon"
+)
+
+// resourceKey identifies the resource of an event: the node and the
+// container of its process, if any.
+type resourceKey struct {
+	node, namespace, pod, container, containerID, image string
+}
+
+func eventResourceKey(event *tetragon.GetEventsResponse) resourceKey {
+	key := resourceKey{node: event.NodeName}
+	if pod := helpers.ResponseGetProcess(event).GetPod(); pod != nil {
+		key.namespace = pod.Namespace
+		key.pod = pod.Name
+		key.container = pod.GetContainer().GetName()
+		key.containerID = pod.GetContainer().GetId()
+		key.image = pod.GetContainer().GetImage().GetName()
+	}
+	return key
+}
+
+func stringAttr(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}}}
+}
+
+// appendStringAttr appends the attribute if its value is not empty
+func appendStringAttr(attrs []*commonpb.KeyValue, key, value string) []*commonpb.KeyValue {
+	if value == "" {
+		return attrs
+	}
+	return append(attrs, stringAttr(key, value))
+}
+
+// resource returns the resource of the log records of the events with the
+// given key, with the attributes of the Kubernetes and container semantic
+// conventions.
+func (k *resourceKey) resource(cluster string) *resourcepb.Resource {
+	attrs := []*commonpb.KeyValue{stringAttr("service.name", serviceName)}
+	attrs = appendStringAttr(attrs, "k8s.cluster.name", cluster)
+	attrs = appendStringAttr(attrs, "k8s.node.name", k.node)
+	attrs = appendStringAttr(attrs, "k8s.namespace.name", k.namespace)
+	attrs = appendStringAttr(attrs, "k8s.pod.name", k.pod)
+	attrs = appendStringAttr(attrs, "k8s.container.name", k.container)
+	attrs = appendStringAttr(attrs, "container.id", k.containerID)
+	attrs = appendStringAttr(attrs, "container.image.name", k.image)
+	return &resourcepb.Resource{Attributes: attrs}
+}
+
+// toResourceLogs converts events to log records, grouped by resource.
+func toResourceLogs(events []*tetragon.GetEventsResponse, cfg *Config, observed time.Time) []*logspb.ResourceLogs {
+	var ret []*logspb.ResourceLogs
+	index := make(map[resourceKey]*logspb.ScopeLogs)
+	for _, event := range events {
+		record, err := toLogRecord(event, cfg, observed)
+		if err != nil {
+			continue
+		}
+		key := eventResourceKey(event)
+		scopeLogs, ok := index[key]
+		if !ok {
+			scopeLogs = &logspb.ScopeLogs{
+				Scope: &commonpb.InstrumentationScope{Name: scopeName, Version: version.Version},
+			}
+			index[key] = scopeLogs
+			ret = append(ret, &logspb.ResourceLogs{
+				Resource:  key.resource(cfg.Cluster),
+				ScopeLogs: []*logspb.ScopeLogs{scopeLogs},
+			})
+		}
+		scopeLogs.LogRecords = append(scopeLogs.LogRecords, record)
+	}
+	return ret
+}
+
+// toLogRecord converts an event to a log record. The body of the record is
+// the event, in the same structure as the JSON export, and the attributes
+// identify the event and its process.
+func toLogRecord(event *tetragon.GetEventsResponse, cfg *Config, observed time.Time) (*logspb.LogRecord, error) {
+	body, err := eventBody(event, cfg)
+	if err != nil {
+		return nil, err
+	}
+	record := &logspb.LogRecord{
+		ObservedTimeUnixNano: uint64(observed.UnixNano()),
+		SeverityNumber:       logspb.SeverityNumber_SEVERITY_NUMBER_INFO,
+		SeverityText:         "INFO",
+		Body:                 body,
+	}
+	if event.Time != nil {
+		record.TimeUnixNano = uint64(event.Time.AsTime().UnixNano())
+	}
+	if eventType, err := helpers.ResponseTypeString(event); err == nil {
+		record.Attributes = append(record.Attributes, stringAttr("event.name", strings.ToLower(eventType)))
+	}
+	if process := helpers.ResponseGetProcess(event); process != nil {
+		if process.Pid != nil {
+			record.Attributes = append(record.Attributes, &commonpb.KeyValue{
+				Key:   "process.pid",
+				Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(process.Pid.Value)}},
+			})
+		}
+		record.Attributes = appendStringAttr(record.Attributes, "process.executable.path", process.Binary)
+		record.Attributes = appendStringAttr(record.Attributes, "tetragon.exec_id", process.ExecId)
+	}
+	return record, nil
+}
+
+func eventBody(event *tetragon.GetEventsResponse, cfg *Config) (*commonpb.AnyValue, error) {
+	var buf bytes.Buffer
+	if err := cfg.bodyEncoder(&buf).Encode(event); err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(&buf)
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return anyValue(v), nil
+}
+
+// anyValue converts a decoded JSON value to an OTLP value
+func anyValue(v interface{}) *commonpb.AnyValue {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		kvs := make([]*commonpb.KeyValue, 0, len(v))
+		for key, value := range v {
+			kvs = append(kvs, &commonpb.KeyValue{Key: key, Value: anyValue(value)})
+		}
+		// map iteration order is random
+		sort.Slice(kvs, func(i, j int) bool { return kvs[i].Key < kvs[j].Key })
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_KvlistValue{KvlistValue: &commonpb.KeyValueList{Values: kvs}}}
+	case []interface{}:
+		values := make([]*commonpb.AnyValue, 0, len(v))
+		for _, value := range v {
+			values = append(values, anyValue(value))
+		}
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: &commonpb.ArrayValue{Values: values}}}
+	case string:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v}}
+	case bool:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v}}
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: i}}
+		}
+		f, _ := v.Float64()
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: f}}
+	}
+	return &commonpb.AnyValue{}
+}
@@ -0,0 +1,283 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package otlp exports events to OpenTelemetry collectors, as OTLP log records
+// sent over gRPC.
+package otlp
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/encoder"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/metrics/exportmetrics"
+	"github.com/sirupsen/logrus"
+	collectorlogs "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// exportTimeout is the timeout of an export request
+	exportTimeout = 10 * time.Second
+	// closeTimeout is the time given to export the queued events on close
+	closeTimeout = 10 * time.Second
+
+	defaultInitialBackoff = 1 * time.Second
+	maxBackoff            = 30 * time.Second
+	// maxRetryTime is the time after which the events of a batch that
+	// cannot be exported are dropped
+	maxRetryTime = 2 * time.Minute
+)
+
+var errClosed = errors.New("OTLP exporter is closed")
+
+// Config configures an Exporter.
+type Config struct {
+	// Endpoint is the host:port address of the OTLP/gRPC endpoint of the
+	// collector.
+	Endpoint string
+	// Insecure disables TLS.
+	Insecure bool
+	// Headers are sent with the export requests, e.g. for authentication.
+	Headers map[string]string
+	// QueueSize is the number of events waiting to be exported. New events
+	// are dropped when the queue is full.
+	QueueSize int
+	// BatchSize is the maximum number of events of an export request.
+	BatchSize int
+	// FlushInterval is the maximum time events wait in the queue.
+	FlushInterval time.Duration
+	// Cluster is the name of the cluster, set in the resource attributes.
+	Cluster string
+	// TimeFormat and TimeLocation encode the timestamps of the bodies of
+	// the log records, as in the JSON export. They default to RFC3339 in
+	// UTC.
+	TimeFormat   encoder.TimeFormat
+	TimeLocation *time.Location
+}
+
+// bodyEncoder returns the encoder of the bodies of the log records.
+func (c *Config) bodyEncoder(w io.Writer) *encoder.ProtojsonEncoder {
+	format, location := c.TimeFormat, c.TimeLocation
+	if format == "" {
+		format = encoder.TimeFormatRFC3339
+	}
+	if location == nil {
+		location = time.UTC
+	}
+	return encoder.NewProtojsonEncoderWithTime(w, format, location)
+}
+
+func (c *Config) validate() error {
+	if c.Endpoint == "" {
+		return errors.New("OTLP endpoint is empty")
+	}
+	if c.QueueSize <= 0 {
+		return fmt.Errorf("OTLP queue size '%d' is not positive", c.QueueSize)
+	}
+	if c.BatchSize <= 0 {
+		return fmt.Errorf("OTLP batch size '%d' is not positive", c.BatchSize)
+	}
+	if c.FlushInterval <= 0 {
+		return fmt.Errorf("OTLP flush interval '%s' is not positive", c.FlushInterval)
+	}
+	return nil
+}
+
+// Exporter is an exporter.ExportEncoder that exports events to an
+// OpenTelemetry collector. Events are queued and exported in batches by a
+// goroutine, which retries the failed exports with an exponential backoff.
+type Exporter struct {
+	cfg    Config
+	conn   *grpc.ClientConn
+	client collectorlogs.LogsServiceClient
+	// initialBackoff is the time before the first retry of a failed export
+	initialBackoff time.Duration
+
+	mu     sync.RWMutex
+	closed bool
+	queue  chan *tetragon.GetEventsResponse
+	done   chan struct{}
+
+	// ctx is cancelled to abort the retries on close
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewExporter returns an Exporter exporting events to the collector of cfg.
+// The connection to the collector is established in the background.
+func NewExporter(cfg Config) (*Exporter, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	creds := insecure.NewCredentials()
+	if !cfg.Insecure {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	conn, err := grpc.Dial(cfg.Endpoint, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to OTLP endpoint %s: %w", cfg.Endpoint, err)
+	}
+	e := newExporter(cfg, collectorlogs.NewLogsServiceClient(conn))
+	e.conn = conn
+	go e.run()
+	return e, nil
+}
+
+func newExporter(cfg Config, client collectorlogs.LogsServiceClient) *Exporter {
+	ctx, cancel := context.WithCancel(context.Background())
+	if len(cfg.Headers) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(cfg.Headers))
+	}
+	e := &Exporter{
+		cfg:            cfg,
+		client:         client,
+		initialBackoff: defaultInitialBackoff,
+		queue:          make(chan *tetragon.GetEventsResponse, cfg.QueueSize),
+		done:           make(chan struct{}),
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+	return e
+}
+
+// Encode queues the event for export, or drops it if the queue is full.
+func (e *Exporter) Encode(v interface{}) error {
+	event, ok := v.(*tetragon.GetEventsResponse)
+	if !ok {
+		return fmt.Errorf("cannot export event of type %T", v)
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closed {
+		return errClosed
+	}
+	select {
+	case e.queue <- event:
+	default:
+		exportmetrics.OTLPLogRecords.WithLabelValues(exportmetrics.OTLPDropped).Inc()
+	}
+	return nil
+}
+
+// Close exports the queued events, waiting for at most closeTimeout, and
+// closes the connection to the collector.
+func (e *Exporter) Close() error {
+	e.mu.Lock()
+	if e.closed {
+		e.mu.Unlock()
+		return nil
+	}
+	e.closed = true
+	close(e.queue)
+	e.mu.Unlock()
+
+	select {
+	case <-e.done:
+	case <-time.After(closeTimeout):
+		e.cancel()
+		<-e.done
+	}
+	e.cancel()
+	if e.conn != nil {
+		return e.conn.Close()
+	}
+	return nil
+}
+
+func (e *Exporter) run() {
+	defer close(e.done)
+	ticker := time.NewTicker(e.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*tetragon.GetEventsResponse, 0, e.cfg.BatchSize)
+	for {
+		select {
+		case event, ok := <-e.queue:
+			if !ok {
+				e.export(batch)
+				return
+			}
+			batch = append(batch, event)
+			if len(batch) < e.cfg.BatchSize {
+				continue
+			}
+		case <-ticker.C:
+			if len(batch) == 0 {
+				continue
+			}
+		}
+		e.export(batch)
+		batch = batch[:0]
+	}
+}
+
+// retryable returns true if the export can be retried after the error, see
+// https://opentelemetry.io/docs/specs/otlp/#failures
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Canceled, codes.DeadlineExceeded, codes.ResourceExhausted,
+		codes.Aborted, codes.OutOfRange, codes.Unavailable, codes.DataLoss:
+		return true
+	}
+	return false
+}
+
+// export exports a batch of events, retrying with an exponential backoff for
+// at most maxRetryTime.
+func (e *Exporter) export(batch []*tetragon.GetEventsResponse) {
+	if len(batch) == 0 {
+		return
+	}
+	req := &collectorlogs.ExportLogsServiceRequest{
+		ResourceLogs: toResourceLogs(batch, &e.cfg, time.Now()),
+	}
+	records := float64(len(batch))
+	deadline := time.Now().Add(maxRetryTime)
+	backoff := e.initialBackoff
+	for {
+		ctx, cancel := context.WithTimeout(e.ctx, exportTimeout)
+		resp, err := e.client.Export(ctx, req)
+		cancel()
+		if err == nil {
+			rejected := float64(resp.GetPartialSuccess().GetRejectedLogRecords())
+			if rejected > 0 {
+				logger.GetLogger().WithFields(logrus.Fields{
+					"endpoint": e.cfg.Endpoint,
+					"rejected": rejected,
+				}).Warnf("OTLP collector rejected log records: %s", resp.GetPartialSuccess().GetErrorMessage())
+			}
+			exportmetrics.OTLPLogRecords.WithLabelValues(exportmetrics.OTLPSent).Add(records - rejected)
+			exportmetrics.OTLPLogRecords.WithLabelValues(exportmetrics.OTLPRejected).Add(rejected)
+			return
+		}
+
+		if !retryable(err) || time.Now().Add(backoff).After(deadline) || e.ctx.Err() != nil {
+			logger.GetLogger().WithField("endpoint", e.cfg.Endpoint).WithError(err).
+				Warnf("Failed to export %d events to the OTLP collector, dropping them", len(batch))
+			exportmetrics.OTLPLogRecords.WithLabelValues(exportmetrics.OTLPDropped).Add(records)
+			return
+		}
+		select {
+		case <-e.ctx.Done():
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
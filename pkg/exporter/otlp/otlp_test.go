@@ -0,0 +1,209 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package otlp
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/encoder"
+	"github.com/cilium/tetragon/pkg/metrics/exportmetrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collectorlogs "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// fakeClient records the export requests, and fails the first ones with
+// the errors of errs.
+type fakeClient struct {
+	mu       sync.Mutex
+	errs     []error
+	requests []*collectorlogs.ExportLogsServiceRequest
+	md       metadata.MD
+}
+
+func (c *fakeClient) Export(ctx context.Context, req *collectorlogs.ExportLogsServiceRequest, _ ...grpc.CallOption) (*collectorlogs.ExportLogsServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.md, _ = metadata.FromOutgoingContext(ctx)
+	if len(c.errs) > 0 {
+		err := c.errs[0]
+		c.errs = c.errs[1:]
+		return nil, err
+	}
+	c.requests = append(c.requests, req)
+	return &collectorlogs.ExportLogsServiceResponse{}, nil
+}
+
+func (c *fakeClient) records() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := 0
+	for _, req := range c.requests {
+		for _, rl := range req.ResourceLogs {
+			for _, sl := range rl.ScopeLogs {
+				n += len(sl.LogRecords)
+			}
+		}
+	}
+	return n
+}
+
+func execEvent(pod *tetragon.Pod) *tetragon.GetEventsResponse {
+	return &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessExec{
+			ProcessExec: &tetragon.ProcessExec{
+				Process: &tetragon.Process{
+					ExecId: "abcd",
+					Pid:    &wrapperspb.UInt32Value{Value: 42},
+					Binary: "/usr/bin/curl",
+					Pod:    pod,
+				},
+			},
+		},
+		NodeName: "node1",
+		Time:     timestamppb.New(time.Unix(1700000000, 0)),
+	}
+}
+
+func attrs(kvs []*commonpb.KeyValue) map[string]interface{} {
+	ret := make(map[string]interface{})
+	for _, kv := range kvs {
+		switch v := kv.Value.Value.(type) {
+		case *commonpb.AnyValue_StringValue:
+			ret[kv.Key] = v.StringValue
+		case *commonpb.AnyValue_IntValue:
+			ret[kv.Key] = v.IntValue
+		}
+	}
+	return ret
+}
+
+func TestToResourceLogs(t *testing.T) {
+	pod := &tetragon.Pod{
+		Namespace: "default",
+		Name:      "client",
+		Container: &tetragon.Container{Id: "containerd://1234", Name: "curl", Image: &tetragon.Image{Name: "curlimages/curl:8.4.0"}},
+	}
+	observed := time.Unix(1700000001, 0)
+	rls := toResourceLogs([]*tetragon.GetEventsResponse{execEvent(nil), execEvent(pod), execEvent(nil)}, &Config{Cluster: "cluster1"}, observed)
+	require.Len(t, rls, 2)
+
+	assert.Equal(t, map[string]interface{}{
+		"service.name":     "tetragon",
+		"k8s.cluster.name": "cluster1",
+		"k8s.node.name":    "node1",
+	}, attrs(rls[0].Resource.Attributes))
+	assert.Equal(t, map[string]interface{}{
+		"service.name":         "tetragon",
+		"k8s.cluster.name":     "cluster1",
+		"k8s.node.name":        "node1",
+		"k8s.namespace.name":   "default",
+		"k8s.pod.name":         "client",
+		"k8s.container.name":   "curl",
+		"container.id":         "containerd://1234",
+		"container.image.name": "curlimages/curl:8.4.0",
+	}, attrs(rls[1].Resource.Attributes))
+	require.Len(t, rls[0].ScopeLogs, 1)
+	assert.Len(t, rls[0].ScopeLogs[0].LogRecords, 2)
+	assert.Len(t, rls[1].ScopeLogs[0].LogRecords, 1)
+
+	record := rls[0].ScopeLogs[0].LogRecords[0]
+	assert.Equal(t, uint64(1700000000*time.Second), record.TimeUnixNano)
+	assert.Equal(t, uint64(1700000001*time.Second), record.ObservedTimeUnixNano)
+	assert.Equal(t, map[string]interface{}{
+		"event.name":              "process_exec",
+		"process.pid":             int64(42),
+		"process.executable.path": "/usr/bin/curl",
+		"tetragon.exec_id":        "abcd",
+	}, attrs(record.Attributes))
+
+	// the body has the structure of the JSON export
+	body := record.Body.GetKvlistValue().Values
+	require.Len(t, body, 3)
+	assert.Equal(t, "node_name", body[0].Key)
+	assert.Equal(t, "node1", body[0].Value.GetStringValue())
+	assert.Equal(t, "process_exec", body[1].Key)
+	process := body[1].Value.GetKvlistValue().Values[0]
+	assert.Equal(t, "process", process.Key)
+	assert.Equal(t, map[string]interface{}{
+		"exec_id": "abcd",
+		"pid":     int64(42),
+		"binary":  "/usr/bin/curl",
+	}, attrs(process.Value.GetKvlistValue().Values))
+	assert.Equal(t, "time", body[2].Key)
+	assert.Equal(t, "2023-11-14T22:13:20Z", body[2].Value.GetStringValue())
+
+	// the timestamps are encoded as in the JSON export
+	rls = toResourceLogs([]*tetragon.GetEventsResponse{execEvent(nil)}, &Config{TimeFormat: encoder.TimeFormatUnixNano}, observed)
+	body = rls[0].ScopeLogs[0].LogRecords[0].Body.GetKvlistValue().Values
+	assert.Equal(t, "time", body[2].Key)
+	assert.Equal(t, "1700000000000000000", body[2].Value.GetStringValue())
+}
+
+func TestExporter(t *testing.T) {
+	exportmetrics.OTLPLogRecords.Reset()
+	client := &fakeClient{
+		errs: []error{status.Error(codes.Unavailable, "unavailable")},
+	}
+	e := newExporter(Config{
+		Endpoint:      "collector:4317",
+		Headers:       map[string]string{"authorization": "token"},
+		QueueSize:     10,
+		BatchSize:     2,
+		FlushInterval: time.Hour,
+	}, client)
+	e.initialBackoff = time.Millisecond
+	go e.run()
+
+	// a full batch is exported, after a retry
+	require.NoError(t, e.Encode(execEvent(nil)))
+	require.NoError(t, e.Encode(execEvent(nil)))
+	require.Eventually(t, func() bool { return client.records() == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"token"}, client.md.Get("authorization"))
+
+	// the queued events are exported on close
+	require.NoError(t, e.Encode(execEvent(nil)))
+	require.NoError(t, e.Close())
+	assert.Equal(t, 3, client.records())
+	assert.Equal(t, float64(3), testutil.ToFloat64(exportmetrics.OTLPLogRecords.WithLabelValues(exportmetrics.OTLPSent)))
+	assert.Error(t, e.Encode(execEvent(nil)))
+
+	// non retryable errors drop the events
+	client = &fakeClient{
+		errs: []error{status.Error(codes.InvalidArgument, "invalid")},
+	}
+	e = newExporter(Config{Endpoint: "collector:4317", QueueSize: 10, BatchSize: 10, FlushInterval: time.Hour}, client)
+	go e.run()
+	require.NoError(t, e.Encode(execEvent(nil)))
+	require.NoError(t, e.Close())
+	assert.Equal(t, 0, client.records())
+	assert.Equal(t, float64(1), testutil.ToFloat64(exportmetrics.OTLPLogRecords.WithLabelValues(exportmetrics.OTLPDropped)))
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := Config{Endpoint: "collector:4317", QueueSize: 1, BatchSize: 1, FlushInterval: time.Second}
+	require.NoError(t, cfg.validate())
+	for _, mod := range []func(c *Config){
+		func(c *Config) { c.Endpoint = "" },
+		func(c *Config) { c.QueueSize = 0 },
+		func(c *Config) { c.BatchSize = -1 },
+		func(c *Config) { c.FlushInterval = 0 },
+	} {
+		c := cfg
+		mod(&c)
+		assert.Error(t, c.validate())
+	}
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package exporter
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/metrics/exportmetrics"
+)
+
+// Protocols of the SyslogWriter.
+const (
+	SyslogUDP = "udp"
+	SyslogTCP = "tcp"
+	SyslogTLS = "tls"
+)
+
+const (
+	syslogRetryInterval = 5 * time.Second
+	syslogDialTimeout   = 10 * time.Second
+	syslogWriteTimeout  = 10 * time.Second
+)
+
+// SyslogWriter sends each write as a message to a syslog server or a SIEM,
+// over UDP, TCP or TLS. Over TCP and TLS, the messages are framed with
+// octet counting (RFC6587). When the server is not reachable, messages are
+// dropped and the connection is retried every retryInterval.
+type SyslogWriter struct {
+	mu            sync.Mutex
+	address       string
+	framed        bool
+	dial          func() (net.Conn, error)
+	conn          net.Conn
+	retryInterval time.Duration
+	lastDial      time.Time
+	timeNow       func() time.Time
+}
+
+// NewSyslogWriter returns a SyslogWriter that sends messages to address over
+// protocol, one of udp, tcp or tls. tlsConfig configures the TLS
+// connections, nil uses the default configuration.
+func NewSyslogWriter(protocol, address string, tlsConfig *tls.Config) (*SyslogWriter, error) {
+	var dial func() (net.Conn, error)
+	switch protocol {
+	case SyslogUDP, SyslogTCP:
+		dial = func() (net.Conn, error) {
+			return net.DialTimeout(protocol, address, syslogDialTimeout)
+		}
+	case SyslogTLS:
+		dialer := &tls.Dialer{NetDialer: &net.Dialer{Timeout: syslogDialTimeout}, Config: tlsConfig}
+		dial = func() (net.Conn, error) {
+			return dialer.Dial("tcp", address)
+		}
+	default:
+		return nil, fmt.Errorf("invalid syslog protocol '%s', expected %s, %s or %s", protocol, SyslogUDP, SyslogTCP, SyslogTLS)
+	}
+	return newSyslogWriter(address, protocol != SyslogUDP, dial), nil
+}
+
+func newSyslogWriter(address string, framed bool, dial func() (net.Conn, error)) *SyslogWriter {
+	return &SyslogWriter{
+		address:       address,
+		framed:        framed,
+		dial:          dial,
+		retryInterval: syslogRetryInterval,
+		timeNow:       time.Now,
+	}
+}
+
+func (w *SyslogWriter) Write(p []byte) (int, error) {
+	msg := p
+	if w.framed {
+		msg = make([]byte, 0, len(p)+8)
+		msg = strconv.AppendInt(msg, int64(len(p)), 10)
+		msg = append(msg, ' ')
+		msg = append(msg, p...)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn == nil {
+		now := w.timeNow()
+		if !w.lastDial.IsZero() && now.Sub(w.lastDial) < w.retryInterval {
+			exportmetrics.SyslogMessages.WithLabelValues(exportmetrics.SyslogDropped).Inc()
+			return len(p), nil
+		}
+		w.lastDial = now
+		conn, err := w.dial()
+		if err != nil {
+			exportmetrics.SyslogMessages.WithLabelValues(exportmetrics.SyslogDropped).Inc()
+			return 0, fmt.Errorf("failed to connect to syslog server %s: %w", w.address, err)
+		}
+		logger.GetLogger().WithField("addr", conn.RemoteAddr()).Info("Exporting events to syslog server")
+		w.conn = conn
+	}
+
+	w.conn.SetWriteDeadline(time.Now().Add(syslogWriteTimeout))
+	if _, err := w.conn.Write(msg); err != nil {
+		w.conn.Close()
+		w.conn = nil
+		exportmetrics.SyslogMessages.WithLabelValues(exportmetrics.SyslogDropped).Inc()
+		return 0, fmt.Errorf("failed to send event to syslog server %s: %w", w.address, err)
+	}
+	exportmetrics.SyslogMessages.WithLabelValues(exportmetrics.SyslogSent).Inc()
+	return len(p), nil
+}
+
+func (w *SyslogWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package exporter
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyslogWriterTCP(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		var msgs string
+		for len(msgs) < len("5 hello6 world!") {
+			b, err := r.ReadByte()
+			if err != nil {
+				break
+			}
+			msgs += string(b)
+		}
+		received <- msgs
+	}()
+
+	w, err := NewSyslogWriter(SyslogTCP, l.Addr().String(), nil)
+	require.NoError(t, err)
+	defer w.Close()
+
+	_, err = w.Write([]byte("hello"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("world!"))
+	require.NoError(t, err)
+
+	select {
+	case msgs := <-received:
+		assert.Equal(t, "5 hello6 world!", msgs)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for syslog messages")
+	}
+}
+
+func TestSyslogWriterUDP(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer pc.Close()
+
+	w, err := NewSyslogWriter(SyslogUDP, pc.LocalAddr().String(), nil)
+	require.NoError(t, err)
+	defer w.Close()
+
+	_, err = w.Write([]byte("<134>1 - - tetragon - process_exec - hello"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 1024)
+	pc.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := pc.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "<134>1 - - tetragon - process_exec - hello", string(buf[:n]))
+}
+
+func TestSyslogWriterRetry(t *testing.T) {
+	dials := 0
+	w := newSyslogWriter("siem:6514", true, func() (net.Conn, error) {
+		dials++
+		if dials == 1 {
+			return nil, errors.New("connection refused")
+		}
+		// writes to the client of a pipe whose server is closed fail
+		client, server := net.Pipe()
+		server.Close()
+		return client, nil
+	})
+	now := time.Unix(0, 0)
+	w.timeNow = func() time.Time { return now }
+
+	_, err := w.Write([]byte("a"))
+	assert.Error(t, err)
+	assert.Equal(t, 1, dials)
+
+	// messages are dropped until the retry interval passes
+	_, err = w.Write([]byte("b"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, dials)
+
+	now = now.Add(syslogRetryInterval)
+	_, err = w.Write([]byte("c"))
+	assert.Error(t, err)
+	assert.Equal(t, 2, dials)
+	assert.Nil(t, w.conn)
+
+	_, err = NewSyslogWriter("sctp", "siem:514", nil)
+	assert.Error(t, err)
+}
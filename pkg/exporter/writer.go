@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package exporter
+
+import (
+	"io"
+
+	"github.com/cilium/tetragon/pkg/metrics/exportmetrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// MeteredWriter counts the bytes written to an export file in the
+// export_file_bytes_written_total metric.
+type MeteredWriter struct {
+	writer  io.Writer
+	written prometheus.Counter
+}
+
+// NewMeteredWriter returns a MeteredWriter writing to w, counting the
+// bytes under the name of the export file.
+func NewMeteredWriter(w io.Writer, filename string) *MeteredWriter {
+	return &MeteredWriter{
+		writer:  w,
+		written: exportmetrics.BytesWritten.WithLabelValues(filename),
+	}
+}
+
+func (mw *MeteredWriter) Write(p []byte) (int, error) {
+	n, err := mw.writer.Write(p)
+	if n > 0 {
+		mw.written.Add(float64(n))
+	}
+	return n, err
+}
@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package exporter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cilium/tetragon/pkg/metrics/exportmetrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeteredWriter(t *testing.T) {
+	exportmetrics.BytesWritten.Reset()
+
+	var buf bytes.Buffer
+	w := NewMeteredWriter(&buf, "/var/run/cilium/tetragon/tetragon.log")
+	n, err := w.Write([]byte("{\"foo\":1}\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 10, n)
+	_, err = w.Write([]byte("{\"bar\":2}\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "{\"foo\":1}\n{\"bar\":2}\n", buf.String())
+	assert.Equal(t, float64(20), testutil.ToFloat64(exportmetrics.BytesWritten.WithLabelValues("/var/run/cilium/tetragon/tetragon.log")))
+}
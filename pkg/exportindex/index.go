@@ -0,0 +1,183 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package exportindex implements an index of the export files, that maps the
+// time, the process exec IDs and the pods of the events to their offsets in
+// the files. The index is written by the exporter next to the export file,
+// and read to skip the parts of the files without matching events.
+//
+// The export files are divided into blocks of contiguous events, and the
+// index is a file of JSON records, one per block. Blocks refer to export files
+// by a key, the hash of the first line of the file, since the files are
+// renamed and compressed when rotated.
+package exportindex
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"sort"
+	"time"
+)
+
+// Ext is the extension of the index of an export file, appended to the name
+// of the export file.
+const Ext = ".idx"
+
+// Path returns the path of the index of an export file.
+func Path(exportFilename string) string {
+	return exportFilename + Ext
+}
+
+// Block is the index record of a block of events of an export file.
+type Block struct {
+	// File is the key of the export file.
+	File string `json:"file"`
+	// Offset and Size are the position of the block in the export file,
+	// uncompressed.
+	Offset int64 `json:"offset"`
+	Size   int64 `json:"size"`
+	// Start and End are the times of the earliest and latest events.
+	Start time.Time `json:"start,omitempty"`
+	End   time.Time `json:"end,omitempty"`
+	// ExecIDs and Pods are the exec IDs of the processes and the names of
+	// the pods of the events.
+	ExecIDs []string `json:"exec_ids,omitempty"`
+	Pods    []string `json:"pods,omitempty"`
+}
+
+// Filter is the conditions that the events of a block must satisfy for the
+// block to be scanned. Zero values match all the events.
+type Filter struct {
+	Since, Until time.Time
+	// ExecIDs and Pods must all appear in the block.
+	ExecIDs []string
+	Pods    []string
+}
+
+// Match returns true if the block can contain events matching the filter.
+func (b *Block) Match(f *Filter) bool {
+	if !f.Since.IsZero() && !b.End.IsZero() && b.End.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !b.Start.IsZero() && b.Start.After(f.Until) {
+		return false
+	}
+	return containsAll(b.ExecIDs, f.ExecIDs) && containsAll(b.Pods, f.Pods)
+}
+
+func containsAll(values, wanted []string) bool {
+	for _, w := range wanted {
+		found := false
+		for _, v := range values {
+			if v == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Range is a range of bytes of an export file, from Start included to End
+// excluded.
+type Range struct {
+	Start, End int64
+}
+
+// SkipRanges returns the ranges of the export file with key that only contain
+// events not matching the filter, sorted by offset. The parts of the file not
+// covered by the index are never skipped.
+func SkipRanges(blocks []Block, key string, f *Filter) []Range {
+	var ret []Range
+	for i := range blocks {
+		b := &blocks[i]
+		if b.File != key || b.Size <= 0 || b.Match(f) {
+			continue
+		}
+		ret = append(ret, Range{Start: b.Offset, End: b.Offset + b.Size})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Start < ret[j].Start
+	})
+
+	// merge the adjacent and overlapping ranges
+	merged := ret[:0]
+	for _, r := range ret {
+		if n := len(merged); n > 0 && r.Start <= merged[n-1].End {
+			if r.End > merged[n-1].End {
+				merged[n-1].End = r.End
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// Read returns the blocks of an index. Invalid records, e.g., the last record
+// of an index truncated by a crash, are ignored.
+func Read(path string) ([]Block, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var ret []Block
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(line) > 0 {
+			var b Block
+			if json.Unmarshal(line, &b) == nil {
+				ret = append(ret, b)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return ret, nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// FileKey returns the key of the export file read by r, the hash of its first
+// line.
+func FileKey(r io.Reader) (string, error) {
+	h := fnv.New64a()
+	br := bufio.NewReader(r)
+	size := 0
+	for {
+		// the line can be larger than the buffer
+		line, err := br.ReadSlice('\n')
+		size += len(line)
+		h.Write(bytes.TrimSuffix(line, []byte{'\n'}))
+		if err == nil {
+			break
+		} else if errors.Is(err, io.EOF) {
+			if size == 0 {
+				return "", errors.New("empty export file")
+			}
+			break
+		} else if !errors.Is(err, bufio.ErrBufferFull) {
+			return "", err
+		}
+	}
+	return fmt.Sprintf("%016x", h.Sum64()), nil
+}
+
+// lineKey returns the key of an export file whose first line is line.
+func lineKey(line []byte) string {
+	h := fnv.New64a()
+	h.Write(bytes.TrimSuffix(line, []byte{'\n'}))
+	return fmt.Sprintf("%016x", h.Sum64())
+}
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package exportindex
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cilium/lumberjack/v2"
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/encoder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var testTime = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func execEvent(execID, pod string, t time.Time) *tetragon.GetEventsResponse {
+	proc := &tetragon.Process{ExecId: execID, Binary: "/usr/bin/curl"}
+	if pod != "" {
+		proc.Pod = &tetragon.Pod{Namespace: "default", Name: pod}
+	}
+	return &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessExec{ProcessExec: &tetragon.ProcessExec{Process: proc}},
+		Time:  timestamppb.New(t),
+	}
+}
+
+func fileKey(t *testing.T, path string) string {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	key, err := FileKey(f)
+	require.NoError(t, err)
+	return key
+}
+
+func TestEncoder(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "tetragon.log")
+	// an existing export file is appended to
+	require.NoError(t, os.WriteFile(name, []byte("{\"node_name\":\"node1\"}\n"), 0600))
+
+	logger := &lumberjack.Logger{Filename: name}
+	writer := NewFileWriter(logger)
+	enc, err := NewEncoder(encoder.NewProtojsonEncoder(writer), writer, Path(name), 1024*1024)
+	require.NoError(t, err)
+
+	require.NoError(t, enc.Encode(execEvent("exec1", "xwing", testTime)))
+	require.NoError(t, enc.Encode(execEvent("exec2", "", testTime.Add(time.Minute))))
+	require.NoError(t, writer.Rotate())
+	require.NoError(t, enc.Encode(execEvent("exec3", "tie", testTime.Add(2*time.Minute))))
+	require.NoError(t, enc.Close())
+	require.NoError(t, writer.Close())
+
+	backups, err := filepath.Glob(filepath.Join(dir, "tetragon-*.log"))
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	backup, err := os.ReadFile(backups[0])
+	require.NoError(t, err)
+	lines := strings.SplitAfter(string(backup), "\n")
+	require.Len(t, lines, 4)
+
+	blocks, err := Read(Path(name))
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+
+	assert.Equal(t, fileKey(t, backups[0]), blocks[0].File)
+	assert.Equal(t, int64(len(lines[0])), blocks[0].Offset)
+	assert.Equal(t, int64(len(lines[1])+len(lines[2])), blocks[0].Size)
+	assert.True(t, testTime.Equal(blocks[0].Start))
+	assert.True(t, testTime.Add(time.Minute).Equal(blocks[0].End))
+	assert.ElementsMatch(t, []string{"exec1", "exec2"}, blocks[0].ExecIDs)
+	assert.Equal(t, []string{"xwing"}, blocks[0].Pods)
+
+	assert.Equal(t, fileKey(t, name), blocks[1].File)
+	assert.Equal(t, int64(0), blocks[1].Offset)
+	assert.Equal(t, []string{"exec3"}, blocks[1].ExecIDs)
+}
+
+func TestSkipRanges(t *testing.T) {
+	blocks := []Block{
+		{File: "a", Offset: 200, Size: 100, Start: testTime.Add(2 * time.Hour), End: testTime.Add(3 * time.Hour), ExecIDs: []string{"exec2"}},
+		{File: "a", Offset: 0, Size: 100, Start: testTime, End: testTime.Add(time.Hour), ExecIDs: []string{"exec1"}, Pods: []string{"xwing"}},
+		{File: "a", Offset: 100, Size: 100, Start: testTime.Add(time.Hour), End: testTime.Add(2 * time.Hour), ExecIDs: []string{"exec1", "exec2"}},
+		{File: "b", Offset: 0, Size: 100, Start: testTime, End: testTime.Add(time.Hour)},
+	}
+
+	assert.Empty(t, SkipRanges(blocks, "a", &Filter{}))
+	assert.Equal(t, []Range{{Start: 200, End: 300}}, SkipRanges(blocks, "a", &Filter{Until: testTime.Add(90 * time.Minute)}))
+	assert.Equal(t, []Range{{Start: 0, End: 100}}, SkipRanges(blocks, "a", &Filter{Since: testTime.Add(90 * time.Minute)}))
+	// adjacent ranges are merged
+	assert.Equal(t, []Range{{Start: 0, End: 200}}, SkipRanges(blocks, "a", &Filter{ExecIDs: []string{"exec2"}, Until: testTime.Add(150 * time.Minute), Since: testTime.Add(150 * time.Minute)}))
+	assert.Equal(t, []Range{{Start: 100, End: 300}}, SkipRanges(blocks, "a", &Filter{ExecIDs: []string{"exec1"}, Pods: []string{"xwing"}}))
+	assert.Empty(t, SkipRanges(blocks, "c", &Filter{ExecIDs: []string{"exec1"}}))
+}
+
+func TestIndexTruncate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tetragon.log.idx")
+	f, err := openIndexFile(path, 1000)
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		require.NoError(t, f.append(&Block{File: "a", Offset: int64(i * 100), Size: 100}))
+	}
+	require.NoError(t, f.close())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, info.Size(), int64(1000))
+
+	// the newest records are kept
+	blocks, err := Read(path)
+	require.NoError(t, err)
+	require.NotEmpty(t, blocks)
+	assert.Equal(t, int64(9900), blocks[len(blocks)-1].Offset)
+	for i := 1; i < len(blocks); i++ {
+		assert.Equal(t, blocks[i-1].Offset+100, blocks[i].Offset)
+	}
+
+	// truncated records are ignored
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data[:len(data)-10], 0600))
+	truncated, err := Read(path)
+	require.NoError(t, err)
+	assert.Len(t, truncated, len(blocks)-1)
+}
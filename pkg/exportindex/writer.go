@@ -0,0 +1,354 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package exportindex
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/cilium/lumberjack/v2"
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/api/v1/tetragon/codegen/helpers"
+	"github.com/cilium/tetragon/pkg/logger"
+)
+
+const (
+	// blockSize is the size of the events after which a block is indexed.
+	blockSize = 1024 * 1024
+	// blockMaxAge is the time after which a block is indexed, even if it
+	// is smaller than blockSize, so that the events are indexed regularly
+	// when the export rate is low.
+	blockMaxAge = time.Minute
+
+	megabyte = 1024 * 1024
+	// defaultMaxFileSize is the default maximum size of the export files of
+	// lumberjack.
+	defaultMaxFileSize = 100 * megabyte
+)
+
+// FileWriter writes the events to an export file rotated by a lumberjack
+// logger, and tracks the key of the current export file and the offsets of
+// the writes in it. It follows the rotation rules of the logger, so all the
+// writes and the rotations of the logger must go through it.
+type FileWriter struct {
+	mu      sync.Mutex
+	logger  *lumberjack.Logger
+	maxSize int64
+	// size of the current export file, -1 until the file is opened by the
+	// first write
+	size int64
+	key  string
+
+	// writes counts the writes, last is the position of the last one
+	writes uint64
+	last   Range
+}
+
+// NewFileWriter returns a writer to the export file of logger.
+func NewFileWriter(logger *lumberjack.Logger) *FileWriter {
+	maxSize := int64(logger.MaxSize) * megabyte
+	if maxSize == 0 {
+		maxSize = defaultMaxFileSize
+	}
+	return &FileWriter{
+		logger:  logger,
+		maxSize: maxSize,
+		size:    -1,
+	}
+}
+
+// Write implements io.Writer. The events are written one per write.
+func (w *FileWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	writeLen := int64(len(p))
+	if writeLen > w.maxSize {
+		// the write fails
+		return w.logger.Write(p)
+	}
+	if w.size < 0 {
+		w.openExisting(writeLen)
+	}
+	if w.size+writeLen > w.maxSize {
+		// the logger rotates the file before the write
+		w.size = 0
+	}
+	if w.size == 0 {
+		w.key = lineKey(p)
+	}
+
+	n, err := w.logger.Write(p)
+	if n > 0 {
+		w.writes++
+		w.last = Range{Start: w.size, End: w.size + int64(n)}
+		w.size += int64(n)
+	}
+	return n, err
+}
+
+// openExisting reads the size and the key of the export file that the logger
+// opens on its first write, like lumberjack does.
+func (w *FileWriter) openExisting(writeLen int64) {
+	w.size = 0
+	info, err := os.Stat(w.logger.Filename)
+	if err != nil || info.Size() == 0 || info.Size()+writeLen >= w.maxSize {
+		return
+	}
+	f, err := os.Open(w.logger.Filename)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	if key, err := FileKey(f); err == nil {
+		w.size = info.Size()
+		w.key = key
+	}
+}
+
+// Rotate rotates the export file.
+func (w *FileWriter) Rotate() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.size = 0
+	return w.logger.Rotate()
+}
+
+// Close closes the export file.
+func (w *FileWriter) Close() error {
+	return w.logger.Close()
+}
+
+// lastWrite returns the number of writes, and the key of the export file and
+// the position of the last write.
+func (w *FileWriter) lastWrite() (uint64, string, Range) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.writes, w.key, w.last
+}
+
+// EventEncoder encodes the events.
+type EventEncoder interface {
+	Encode(v interface{}) error
+}
+
+// Encoder is an event encoder writing to a FileWriter, that indexes the
+// encoded events.
+type Encoder struct {
+	mu      sync.Mutex
+	encoder EventEncoder
+	writer  *FileWriter
+	index   *indexFile
+
+	// current block, and when it was started
+	block        *Block
+	blockCreated time.Time
+	execIDs      map[string]struct{}
+	pods         map[string]struct{}
+}
+
+// NewEncoder returns an encoder indexing the events that encoder writes to
+// writer, in the index at path. When the index becomes larger than maxSize,
+// its oldest records are removed.
+func NewEncoder(encoder EventEncoder, writer *FileWriter, path string, maxSize int64) (*Encoder, error) {
+	index, err := openIndexFile(path, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Encoder{
+		encoder: encoder,
+		writer:  writer,
+		index:   index,
+	}, nil
+}
+
+// Encode implements EventEncoder.Encode.
+func (e *Encoder) Encode(v interface{}) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	writes, _, _ := e.writer.lastWrite()
+	if err := e.encoder.Encode(v); err != nil {
+		return err
+	}
+	n, key, pos := e.writer.lastWrite()
+	if n == writes {
+		// the event was not written
+		return nil
+	}
+	if b := e.block; b != nil && (b.File != key || b.Offset+b.Size != pos.Start) {
+		e.flush()
+	}
+	if e.block == nil {
+		e.block = &Block{File: key, Offset: pos.Start}
+		e.blockCreated = time.Now()
+		e.execIDs = make(map[string]struct{})
+		e.pods = make(map[string]struct{})
+	}
+	e.add(v, pos)
+	if e.block.Size >= blockSize || time.Since(e.blockCreated) >= blockMaxAge {
+		e.flush()
+	}
+	return nil
+}
+
+// add adds an event written at pos to the current block.
+func (e *Encoder) add(v interface{}, pos Range) {
+	b := e.block
+	b.Size = pos.End - b.Offset
+
+	ev, ok := v.(*tetragon.GetEventsResponse)
+	if !ok {
+		return
+	}
+	if ev.Time != nil {
+		t := ev.Time.AsTime()
+		if b.Start.IsZero() || t.Before(b.Start) {
+			b.Start = t
+		}
+		if b.End.IsZero() || t.After(b.End) {
+			b.End = t
+		}
+	}
+	if proc := helpers.ResponseGetProcess(ev); proc != nil {
+		if proc.ExecId != "" {
+			e.execIDs[proc.ExecId] = struct{}{}
+		}
+		if proc.Pod != nil && proc.Pod.Name != "" {
+			e.pods[proc.Pod.Name] = struct{}{}
+		}
+	}
+}
+
+// flush writes the record of the current block to the index.
+func (e *Encoder) flush() {
+	b := e.block
+	if b == nil {
+		return
+	}
+	e.block = nil
+	for id := range e.execIDs {
+		b.ExecIDs = append(b.ExecIDs, id)
+	}
+	for pod := range e.pods {
+		b.Pods = append(b.Pods, pod)
+	}
+	// the index is best effort, failures only make the lookups slower
+	if err := e.index.append(b); err != nil {
+		logger.GetLogger().WithError(err).WithField("index", e.index.path).Debug("Failed to write export index record")
+	}
+}
+
+// Close writes the current block to the index and closes it.
+func (e *Encoder) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.flush()
+	return e.index.close()
+}
+
+// indexFile is the file of an index, whose oldest records are removed when it
+// becomes larger than maxSize.
+type indexFile struct {
+	path    string
+	maxSize int64
+	file    *os.File
+	size    int64
+}
+
+func openIndexFile(path string, maxSize int64) (*indexFile, error) {
+	if maxSize <= 0 {
+		return nil, fmt.Errorf("invalid index maximum size %d", maxSize)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open index: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to open index: %w", err)
+	}
+	return &indexFile{
+		path:    path,
+		maxSize: maxSize,
+		file:    f,
+		size:    info.Size(),
+	}, nil
+}
+
+func (f *indexFile) append(b *Block) error {
+	if f.file == nil {
+		return errors.New("index is closed")
+	}
+	line, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+	if f.size+int64(len(line)) > f.maxSize {
+		if err := f.truncate(); err != nil {
+			return err
+		}
+	}
+	n, err := f.file.Write(line)
+	f.size += int64(n)
+	return err
+}
+
+// truncate removes the oldest records of the index, keeping the newest half.
+func (f *indexFile) truncate() error {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return err
+	}
+	for int64(len(data)) > f.maxSize/2 {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			data = nil
+			break
+		}
+		data = data[i+1:]
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		return err
+	}
+
+	f.file.Close()
+	f.file, err = os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		f.file = nil
+		return err
+	}
+	f.size = int64(len(data))
+	return nil
+}
+
+func (f *indexFile) close() error {
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package features reports the BPF features of the running kernel that
+// tracing policies depend on. The features are probed once, when they are
+// first checked, and policies that need a missing feature fail to load with an
+// error that names what the kernel lacks.
+package features
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/cilium/ebpf"
+	ebpffeatures "github.com/cilium/ebpf/features"
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/bpf"
+	"golang.org/x/sys/unix"
+)
+
+// Feature is a BPF feature of the kernel.
+type Feature struct {
+	// Name of the feature, e.g. "kprobe_multi"
+	Name string
+	// Description of what the feature is used for
+	Description string
+	// Requirement is what the kernel needs to support the feature
+	Requirement string
+
+	probe func() bool
+}
+
+// Supported returns true if the running kernel supports the feature.
+func (f *Feature) Supported() bool {
+	return f.probe()
+}
+
+// Require returns a MissingError if the running kernel does not support the
+// feature. user describes what needs the feature, e.g. "the Override action".
+func (f *Feature) Require(user string) error {
+	if f.Supported() {
+		return nil
+	}
+	return &MissingError{User: user, Feature: f}
+}
+
+// MissingError is returned when a feature needed by a policy is not supported
+// by the running kernel.
+type MissingError struct {
+	User    string
+	Feature *Feature
+}
+
+func (e *MissingError) Error() string {
+	return fmt.Sprintf("%s needs %s; kernel lacks %s", e.User, e.Feature.Name, e.Feature.Requirement)
+}
+
+// once returns a probe that is run once, the first time it is called.
+func once(probe func() bool) func() bool {
+	var (
+		probed    sync.Once
+		supported bool
+	)
+	return func() bool {
+		probed.Do(func() {
+			supported = probe()
+		})
+		return supported
+	}
+}
+
+var (
+	KprobeMulti = &Feature{
+		Name:        "kprobe_multi",
+		Description: "attaching a single program to many kprobes, which speeds up loading policies with many hooks",
+		Requirement: "CONFIG_FPROBE (kernel 5.18 or later)",
+		probe:       bpf.HasKprobeMulti,
+	}
+	Fentry = &Feature{
+		Name:        "fentry",
+		Description: "fentry and fexit programs",
+		Requirement: "BPF trampolines (CONFIG_DEBUG_INFO_BTF, kernel 5.5 or later)",
+		probe:       bpf.HasFentry,
+	}
+	ModifyReturn = &Feature{
+		Name:        "fmod_ret",
+		Description: "Override action through fmod_ret programs, for functions that bpf_override_return cannot override",
+		Requirement: "BPF trampolines with fmod_ret support (CONFIG_DEBUG_INFO_BTF and CONFIG_FUNCTION_ERROR_INJECTION)",
+		probe:       bpf.HasModifyReturn,
+	}
+	LSM = &Feature{
+		Name:        "bpf_lsm",
+		Description: "LSM hooks of policies",
+		Requirement: "CONFIG_BPF_LSM with bpf in the lsm= boot parameter",
+		probe:       bpf.HasLSMPrograms,
+	}
+	OverrideReturn = &Feature{
+		Name:        "bpf_override_return",
+		Description: "Override action of kprobes",
+		Requirement: "CONFIG_BPF_KPROBE_OVERRIDE and CONFIG_FUNCTION_ERROR_INJECTION",
+		probe:       bpf.HasOverrideHelper,
+	}
+	BuildID = &Feature{
+		Name:        "build_id",
+		Description: "build IDs of the binaries of user stack traces",
+		Requirement: "build ID support of perf events (kernel 5.12 or later)",
+		probe:       bpf.HasBuildId,
+	}
+	Ringbuf = &Feature{
+		Name:        "ringbuf",
+		Description: "BPF ring buffer maps",
+		Requirement: "BPF ring buffers (kernel 5.8 or later)",
+		probe: once(func() bool {
+			return ebpffeatures.HaveMapType(ebpf.RingBuf) == nil
+		}),
+	}
+	LargeProgs = &Feature{
+		Name:        "large_insn",
+		Description: "programs of up to one million instructions, needed by the larger selector programs",
+		Requirement: "support for large BPF programs (kernel 5.3 or later)",
+		probe: once(func() bool {
+			return ebpffeatures.HaveLargeInstructions() == nil
+		}),
+	}
+	KernelBTF = &Feature{
+		Name:        "btf",
+		Description: "BTF of the kernel, needed to resolve the types of hooks and arguments",
+		Requirement: "CONFIG_DEBUG_INFO_BTF, a BTF file can be provided with --btf instead",
+		probe: once(func() bool {
+			_, err := os.Stat("/sys/kernel/btf/vmlinux")
+			return err == nil
+		}),
+	}
+)
+
+// All returns the features, in the order they are reported.
+func All() []*Feature {
+	return []*Feature{
+		KernelBTF,
+		LargeProgs,
+		KprobeMulti,
+		Fentry,
+		ModifyReturn,
+		OverrideReturn,
+		LSM,
+		Ringbuf,
+		BuildID,
+	}
+}
+
+// Log returns the features and whether they are supported, for the logs.
+func Log() string {
+	var sb strings.Builder
+	for i, f := range All() {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "%s: %t", f.Name, f.Supported())
+	}
+	return sb.String()
+}
+
+// Report returns the features of the running kernel, as reported by the
+// GetKernelFeatures RPC.
+func Report() *tetragon.GetKernelFeaturesResponse {
+	res := &tetragon.GetKernelFeaturesResponse{}
+	var uts unix.Utsname
+	if err := unix.Uname(&uts); err == nil {
+		res.KernelRelease = unix.ByteSliceToString(uts.Release[:])
+	}
+	for _, f := range All() {
+		res.Features = append(res.Features, &tetragon.KernelFeature{
+			Name:        f.Name,
+			Supported:   f.Supported(),
+			Description: f.Description,
+			Requirement: f.Requirement,
+		})
+	}
+	return res
+}
@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package features
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequire(t *testing.T) {
+	supported := &Feature{Name: "supported", probe: func() bool { return true }}
+	require.NoError(t, supported.Require("this policy"))
+
+	missing := &Feature{
+		Name:        "bpf_override_return",
+		Requirement: "CONFIG_FUNCTION_ERROR_INJECTION",
+		probe:       func() bool { return false },
+	}
+	err := missing.Require("this policy")
+	require.Error(t, err)
+	assert.Equal(t, "this policy needs bpf_override_return; kernel lacks CONFIG_FUNCTION_ERROR_INJECTION", err.Error())
+
+	var missingErr *MissingError
+	require.True(t, errors.As(err, &missingErr))
+	assert.Equal(t, missing, missingErr.Feature)
+}
+
+func TestOnce(t *testing.T) {
+	calls := 0
+	probe := once(func() bool {
+		calls++
+		return true
+	})
+	assert.True(t, probe())
+	assert.True(t, probe())
+	assert.Equal(t, 1, calls)
+}
+
+func TestReport(t *testing.T) {
+	res := Report()
+	assert.NotEmpty(t, res.KernelRelease)
+	require.Len(t, res.Features, len(All()))
+	for i, f := range All() {
+		assert.Equal(t, f.Name, res.Features[i].Name)
+		assert.NotEmpty(t, res.Features[i].Requirement)
+	}
+}
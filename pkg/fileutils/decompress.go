@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package fileutils
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// CompressedExts are the extensions of the compressed files that
+// NewDecompressReader decompresses, e.g., the rotated export files.
+var CompressedExts = []string{".gz", ".zst"}
+
+// zstdCommand is the command decompressing zstd data from its stdin to its
+// stdout.
+var zstdCommand = []string{"zstd", "-d", "-c", "-q"}
+
+// OpenDecompress opens a file, decompressing it if it is compressed. The file
+// itself is returned when it is not compressed, so that it can be seeked.
+func OpenDecompress(path string) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	magic := make([]byte, len(zstdMagic))
+	n, err := io.ReadFull(f, magic)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		f.Close()
+		return nil, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if !isCompressed(magic[:n]) {
+		return f, nil
+	}
+	r, err := NewDecompressReader(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{r, closers{r, f}}, nil
+}
+
+// NewDecompressReader returns a reader of the data of r, decompressed if it
+// is gzip or zstd compressed, which is detected from its first bytes. gzip is
+// decompressed in process, and zstd by the zstd command that must be in PATH.
+// Closing the reader does not close r.
+func NewDecompressReader(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(len(zstdMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		return gzip.NewReader(br)
+	case bytes.HasPrefix(magic, zstdMagic):
+		return newCommandReader(br, zstdCommand)
+	}
+	return io.NopCloser(br), nil
+}
+
+func isCompressed(magic []byte) bool {
+	return bytes.HasPrefix(magic, gzipMagic) || bytes.HasPrefix(magic, zstdMagic)
+}
+
+// commandReader reads the output of a command decompressing its input.
+type commandReader struct {
+	cmd    *exec.Cmd
+	stdout io.ReadCloser
+	stderr bytes.Buffer
+	done   bool
+}
+
+func newCommandReader(r io.Reader, args []string) (*commandReader, error) {
+	c := &commandReader{cmd: exec.Command(args[0], args[1:]...)}
+	c.cmd.Stdin = r
+	c.cmd.Stderr = &c.stderr
+	stdout, err := c.cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := c.cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to decompress with %s: %w", args[0], err)
+	}
+	c.stdout = stdout
+	return c, nil
+}
+
+func (c *commandReader) Read(p []byte) (int, error) {
+	n, err := c.stdout.Read(p)
+	if errors.Is(err, io.EOF) && !c.done {
+		// report the decompression errors, e.g., of corrupted data
+		c.done = true
+		if werr := c.cmd.Wait(); werr != nil {
+			return n, fmt.Errorf("failed to decompress with %s: %w: %s",
+				c.cmd.Path, werr, strings.TrimSpace(c.stderr.String()))
+		}
+	}
+	return n, err
+}
+
+func (c *commandReader) Close() error {
+	if c.done {
+		return nil
+	}
+	c.done = true
+	// the data might not have been read entirely
+	c.cmd.Process.Kill()
+	c.cmd.Wait()
+	return nil
+}
+
+// closers closes all its closers, and returns the first error.
+type closers []io.Closer
+
+func (cs closers) Close() error {
+	var ret error
+	for _, c := range cs {
+		if err := c.Close(); err != nil && ret == nil {
+			ret = err
+		}
+	}
+	return ret
+}
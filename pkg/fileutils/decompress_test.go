@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package fileutils
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenDecompress(t *testing.T) {
+	dir := t.TempDir()
+	data := []byte("{\"process_exec\":{}}\n{\"process_exit\":{}}\n")
+
+	plain := filepath.Join(dir, "plain.log")
+	require.NoError(t, os.WriteFile(plain, data, 0600))
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	gzipped := filepath.Join(dir, "gzipped.log.gz")
+	require.NoError(t, os.WriteFile(gzipped, buf.Bytes(), 0600))
+
+	empty := filepath.Join(dir, "empty.log")
+	require.NoError(t, os.WriteFile(empty, nil, 0600))
+
+	for path, want := range map[string][]byte{plain: data, gzipped: data, empty: {}} {
+		r, err := OpenDecompress(path)
+		require.NoError(t, err, path)
+		got, err := io.ReadAll(r)
+		require.NoError(t, err, path)
+		assert.Equal(t, want, got, path)
+		require.NoError(t, r.Close(), path)
+	}
+
+	// uncompressed files can be seeked
+	r, err := OpenDecompress(plain)
+	require.NoError(t, err)
+	defer r.Close()
+	_, ok := r.(io.Seeker)
+	assert.True(t, ok)
+}
+
+func TestDecompressZstd(t *testing.T) {
+	if _, err := exec.LookPath(zstdCommand[0]); err != nil {
+		t.Skip("zstd command not found")
+	}
+	data := bytes.Repeat([]byte("{\"process_exec\":{}}\n"), 1000)
+	cmd := exec.Command("zstd", "-c", "-q")
+	cmd.Stdin = bytes.NewReader(data)
+	compressed, err := cmd.Output()
+	require.NoError(t, err)
+
+	r, err := NewDecompressReader(bytes.NewReader(compressed))
+	require.NoError(t, err)
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+	require.NoError(t, r.Close())
+
+	// corrupted data
+	r, err = NewDecompressReader(bytes.NewReader(compressed[:len(compressed)/2]))
+	require.NoError(t, err)
+	_, err = io.ReadAll(r)
+	assert.Error(t, err)
+	require.NoError(t, r.Close())
+
+	// closing before reading everything
+	r, err = NewDecompressReader(bytes.NewReader(compressed))
+	require.NoError(t, err)
+	_, err = r.Read(make([]byte, 10))
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+}
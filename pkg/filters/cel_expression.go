@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package filters
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/metrics/errormetrics"
+	v1 "github.com/cilium/tetragon/pkg/oldhubble/api/v1"
+	hubbleFilters "github.com/cilium/tetragon/pkg/oldhubble/filters"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/interpreter"
+)
+
+const (
+	// celCostLimit is the maximum cost of the evaluation of a CEL expression
+	// on an event. The expressions whose estimated cost exceeds it are
+	// rejected, and the evaluations exceeding it are aborted.
+	celCostLimit = 1000000
+	// celMaxSize is the size assumed for the lists and strings of the events
+	// when estimating the cost of the expressions.
+	celMaxSize = 4096
+)
+
+var (
+	celEnvOnce sync.Once
+	celEnv     *cel.Env
+	celEnvErr  error
+	// eventOneof is the oneof of the events of the GetEventsResponse
+	eventOneof = (&tetragon.GetEventsResponse{}).ProtoReflect().Descriptor().Oneofs().ByName("event")
+	// celUnknowns are the variables of the event types that are unknown for
+	// each event type, for the partial evaluation of the expressions.
+	celUnknowns map[string][]*interpreter.AttributePattern
+)
+
+// celSizeEstimator bounds the sizes of the lists and strings of the events to
+// estimate the cost of the CEL expressions.
+type celSizeEstimator struct{}
+
+func (celSizeEstimator) EstimateSize(_ checker.AstNode) *checker.SizeEstimate {
+	return &checker.SizeEstimate{Min: 0, Max: celMaxSize}
+}
+
+func (celSizeEstimator) EstimateCallCost(_, _ string, _ *checker.AstNode, _ []checker.AstNode) *checker.CallEstimate {
+	return nil
+}
+
+// getCELEnv returns the environment of the CEL expressions, which declares a
+// variable for each event type, named after the field of the event in
+// GetEventsResponse (process_exec, process_kprobe...).
+func getCELEnv() (*cel.Env, error) {
+	celEnvOnce.Do(func() {
+		opts := []cel.EnvOption{cel.Types(&tetragon.GetEventsResponse{})}
+		fields := eventOneof.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			field := fields.Get(i)
+			opts = append(opts, cel.Variable(string(field.Name()), cel.ObjectType(string(field.Message().FullName()))))
+		}
+		celUnknowns = make(map[string][]*interpreter.AttributePattern, fields.Len())
+		for i := 0; i < fields.Len(); i++ {
+			name := string(fields.Get(i).Name())
+			for j := 0; j < fields.Len(); j++ {
+				if j != i {
+					celUnknowns[name] = append(celUnknowns[name], cel.AttributePattern(string(fields.Get(j).Name())))
+				}
+			}
+		}
+		celEnv, celEnvErr = cel.NewEnv(opts...)
+	})
+	return celEnv, celEnvErr
+}
+
+func compileCELExpression(env *cel.Env, expr string) (cel.Program, error) {
+	ast, iss := env.Compile(expr)
+	if iss.Err() != nil {
+		return nil, fmt.Errorf("failed to compile CEL expression %q: %w", expr, iss.Err())
+	}
+	if ast.OutputType() != cel.BoolType {
+		return nil, fmt.Errorf("CEL expression %q must return a bool, not %s", expr, ast.OutputType())
+	}
+	cost, err := env.EstimateCost(ast, celSizeEstimator{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate the cost of CEL expression %q: %w", expr, err)
+	}
+	if cost.Max > celCostLimit {
+		return nil, fmt.Errorf("CEL expression %q is too expensive: estimated cost %d exceeds the limit of %d",
+			expr, cost.Max, celCostLimit)
+	}
+	prg, err := env.Program(ast, cel.CostLimit(celCostLimit), cel.EvalOptions(cel.OptPartialEval))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build CEL program %q: %w", expr, err)
+	}
+	return prg, nil
+}
+
+// celActivation returns the variables of the CEL expressions for an event:
+// only the variable of the type of the event is set, and the variables of the
+// other event types are unknown, so that the expressions depending on them
+// evaluate to an unknown value and do not match.
+func celActivation(ev *v1.Event) interpreter.PartialActivation {
+	response, ok := ev.Event.(*tetragon.GetEventsResponse)
+	if !ok {
+		return nil
+	}
+	msg := response.ProtoReflect()
+	field := msg.WhichOneof(eventOneof)
+	if field == nil {
+		return nil
+	}
+	name := string(field.Name())
+	activation, err := cel.PartialVars(map[string]any{
+		name: msg.Get(field).Message().Interface(),
+	}, celUnknowns[name]...)
+	if err != nil {
+		return nil
+	}
+	return activation
+}
+
+func filterByCELExpression(expressions []string) (hubbleFilters.FilterFunc, error) {
+	env, err := getCELEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+	var programs []cel.Program
+	for _, expr := range expressions {
+		prg, err := compileCELExpression(env, expr)
+		if err != nil {
+			return nil, err
+		}
+		programs = append(programs, prg)
+	}
+	return func(ev *v1.Event) bool {
+		activation := celActivation(ev)
+		if activation == nil {
+			return false
+		}
+		for _, prg := range programs {
+			out, _, err := prg.Eval(activation)
+			if err != nil {
+				// e.g. a missing field, or an evaluation exceeding the cost limit
+				errormetrics.ErrorTotalInc(errormetrics.FilterCELEvalFailed)
+				logger.GetLogger().WithError(err).Debug("Failed to evaluate CEL expression")
+				continue
+			}
+			if types.IsUnknown(out) {
+				continue
+			}
+			if match, ok := out.Value().(bool); ok && match {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+type CELExpressionFilter struct{}
+
+func (f *CELExpressionFilter) OnBuildFilter(_ context.Context, ff *tetragon.Filter) ([]hubbleFilters.FilterFunc, error) {
+	var fs []hubbleFilters.FilterFunc
+	if ff.CelExpression != nil {
+		celFilter, err := filterByCELExpression(ff.CelExpression)
+		if err != nil {
+			return nil, err
+		}
+		fs = append(fs, celFilter)
+	}
+	return fs, nil
+}
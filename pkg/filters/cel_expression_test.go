@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package filters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	v1 "github.com/cilium/tetragon/pkg/oldhubble/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCELExpression(t *testing.T) {
+	f := []*tetragon.Filter{{CelExpression: []string{
+		`process_kprobe.args[0].file_arg.path.startsWith("/etc")`,
+		`process_exec.process.binary == "/usr/bin/curl"`,
+	}}}
+	fl, err := BuildFilterList(context.Background(), f, []OnBuildFilter{&CELExpressionFilter{}})
+	require.NoError(t, err)
+
+	kprobe := func(path string) *v1.Event {
+		return &v1.Event{Event: &tetragon.GetEventsResponse{Event: &tetragon.GetEventsResponse_ProcessKprobe{
+			ProcessKprobe: &tetragon.ProcessKprobe{Args: []*tetragon.KprobeArgument{{
+				Arg: &tetragon.KprobeArgument_FileArg{FileArg: &tetragon.KprobeFile{Path: path}},
+			}}},
+		}}}
+	}
+	assert.True(t, fl.MatchOne(kprobe("/etc/passwd")))
+	assert.False(t, fl.MatchOne(kprobe("/tmp/passwd")))
+	// Expressions failing on an event do not match it.
+	ev := v1.Event{Event: &tetragon.GetEventsResponse{Event: &tetragon.GetEventsResponse_ProcessKprobe{
+		ProcessKprobe: &tetragon.ProcessKprobe{},
+	}}}
+	assert.False(t, fl.MatchOne(&ev))
+
+	ev = v1.Event{Event: &tetragon.GetEventsResponse{Event: &tetragon.GetEventsResponse_ProcessExec{
+		ProcessExec: &tetragon.ProcessExec{Process: &tetragon.Process{Binary: "/usr/bin/curl"}},
+	}}}
+	assert.True(t, fl.MatchOne(&ev))
+	ev = v1.Event{Event: &tetragon.GetEventsResponse{Event: &tetragon.GetEventsResponse_ProcessExit{
+		ProcessExit: &tetragon.ProcessExit{Process: &tetragon.Process{Binary: "/usr/bin/curl"}},
+	}}}
+	assert.False(t, fl.MatchOne(&ev))
+
+	// Expressions depending on another event type do not match, even when
+	// the event type is checked after.
+	f = []*tetragon.Filter{{CelExpression: []string{
+		`process_exit.process.binary == "/usr/bin/curl" || process_exec.process.binary == "/usr/bin/curl"`,
+		`process_exit.status == 1u && process_exec.process.binary == "/usr/bin/wget"`,
+	}}}
+	fl, err = BuildFilterList(context.Background(), f, []OnBuildFilter{&CELExpressionFilter{}})
+	require.NoError(t, err)
+	ev = v1.Event{Event: &tetragon.GetEventsResponse{Event: &tetragon.GetEventsResponse_ProcessExec{
+		ProcessExec: &tetragon.ProcessExec{Process: &tetragon.Process{Binary: "/usr/bin/curl"}},
+	}}}
+	assert.True(t, fl.MatchOne(&ev))
+	ev = v1.Event{Event: &tetragon.GetEventsResponse{Event: &tetragon.GetEventsResponse_ProcessExec{
+		ProcessExec: &tetragon.ProcessExec{Process: &tetragon.Process{Binary: "/usr/bin/wget"}},
+	}}}
+	assert.False(t, fl.MatchOne(&ev))
+}
+
+func TestCELExpressionInvalid(t *testing.T) {
+	for _, expr := range []string{
+		`process_exec.process.binary ==`,
+		`process_exec.process.binary`,
+		`process_exec.process.unknown == "foo"`,
+		// too expensive
+		`process_kprobe.args.exists(a, process_kprobe.args.exists(b, a.string_arg + b.string_arg == "foo"))`,
+	} {
+		f := []*tetragon.Filter{{CelExpression: []string{expr}}}
+		_, err := BuildFilterList(context.Background(), f, []OnBuildFilter{&CELExpressionFilter{}})
+		assert.Error(t, err, expr)
+	}
+}
@@ -31,7 +31,7 @@ func ParseFilterList(filters string, enablePidSetFilters bool) ([]*tetragon.Filt
 			}
 			return nil, err
 		}
-		if len(result.PidSet) != 0 && !enablePidSetFilters {
+		if hasPidSet(&result) && !enablePidSetFilters {
 			return nil, fmt.Errorf("pidSet filters use a best-effort approach for tracking PIDs and are intended for testing/development, not for production (pass the --enable-pid-set-filter to ignore)")
 		}
 		results = append(results, &result)
@@ -39,6 +39,28 @@ func ParseFilterList(filters string, enablePidSetFilters bool) ([]*tetragon.Filt
 	return results, nil
 }
 
+// hasPidSet returns true if the filter or one of the filters composing it
+// filters by pid_set.
+func hasPidSet(f *tetragon.Filter) bool {
+	if f == nil {
+		return false
+	}
+	if len(f.PidSet) != 0 || hasPidSet(f.Not) {
+		return true
+	}
+	for _, sub := range f.And {
+		if hasPidSet(sub) {
+			return true
+		}
+	}
+	for _, sub := range f.Or {
+		if hasPidSet(sub) {
+			return true
+		}
+	}
+	return false
+}
+
 // OnBuildFilter is invoked while building a flow filter
 type OnBuildFilter interface {
 	OnBuildFilter(context.Context, *tetragon.Filter) ([]hubbleFilters.FilterFunc, error)
@@ -63,6 +85,44 @@ func BuildFilter(ctx context.Context, ff *tetragon.Filter, filterFuncs []OnBuild
 			fs = append(fs, fl...)
 		}
 	}
+	fl, err := buildCompositionFilter(ctx, ff, filterFuncs)
+	if err != nil {
+		return nil, err
+	}
+	return append(fs, fl...), nil
+}
+
+// buildCompositionFilter builds the filters of the and, or and not fields of
+// a filter, composing the filters built from the other filters.
+func buildCompositionFilter(ctx context.Context, ff *tetragon.Filter, filterFuncs []OnBuildFilter) ([]hubbleFilters.FilterFunc, error) {
+	var fs []hubbleFilters.FilterFunc
+	for _, and := range ff.And {
+		tf, err := BuildFilter(ctx, and, filterFuncs)
+		if err != nil {
+			return nil, err
+		}
+		fs = append(fs, func(ev *v1.Event) bool {
+			return tf.MatchAll(ev)
+		})
+	}
+	if len(ff.Or) > 0 {
+		or, err := BuildFilterList(ctx, ff.Or, filterFuncs)
+		if err != nil {
+			return nil, err
+		}
+		fs = append(fs, func(ev *v1.Event) bool {
+			return or.MatchOne(ev)
+		})
+	}
+	if ff.Not != nil {
+		tf, err := BuildFilter(ctx, ff.Not, filterFuncs)
+		if err != nil {
+			return nil, err
+		}
+		fs = append(fs, func(ev *v1.Event) bool {
+			return !tf.MatchAll(ev)
+		})
+	}
 	return fs, nil
 }
 
@@ -92,6 +152,8 @@ var Filters = []OnBuildFilter{
 	&ArgumentsRegexFilter{},
 	&LabelsFilter{},
 	&PodRegexFilter{},
+	&PolicyNamesFilter{},
+	&CELExpressionFilter{},
 }
 
 func GetProcess(event *v1.Event) *tetragon.Process {
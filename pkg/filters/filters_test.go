@@ -88,3 +88,42 @@ func TestEventTypeFilterNoMatch(t *testing.T) {
 	}
 	assert.False(t, fl.MatchOne(&ev))
 }
+
+func TestCompositionFilter(t *testing.T) {
+	f, err := ParseFilterList(`{"namespace":["prod"],"not":{"binary_regex":["^/bin/sh$"]}}
+{"or":[{"binary_regex":["curl$"]},{"binary_regex":["wget$"]}],"and":[{"event_set":["PROCESS_EXEC"]}]}`, false)
+	assert.NoError(t, err)
+	fl, err := BuildFilterList(context.Background(), f, Filters)
+	assert.NoError(t, err)
+
+	exec := func(namespace, binary string) *v1.Event {
+		return &v1.Event{
+			Event: &tetragon.GetEventsResponse{
+				Event: &tetragon.GetEventsResponse_ProcessExec{
+					ProcessExec: &tetragon.ProcessExec{Process: &tetragon.Process{
+						Binary: binary,
+						Pod:    &tetragon.Pod{Namespace: namespace},
+					}},
+				},
+			},
+		}
+	}
+	exit := &v1.Event{
+		Event: &tetragon.GetEventsResponse{
+			Event: &tetragon.GetEventsResponse_ProcessExit{
+				ProcessExit: &tetragon.ProcessExit{Process: &tetragon.Process{Binary: "/usr/bin/curl"}},
+			},
+		},
+	}
+
+	assert.True(t, fl[0](exec("prod", "/bin/bash")))
+	assert.False(t, fl[0](exec("prod", "/bin/sh")))
+	assert.False(t, fl[0](exec("dev", "/bin/bash")))
+	assert.True(t, fl[1](exec("dev", "/usr/bin/curl")))
+	assert.True(t, fl[1](exec("dev", "/usr/bin/wget")))
+	assert.False(t, fl[1](exec("dev", "/bin/sh")))
+	assert.False(t, fl[1](exit))
+
+	_, err = ParseFilterList(`{"not":{"or":[{"pid_set":[1]}]}}`, false)
+	assert.Error(t, err)
+}
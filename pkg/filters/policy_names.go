@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package filters
+
+import (
+	"context"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	v1 "github.com/cilium/tetragon/pkg/oldhubble/api/v1"
+	hubbleFilters "github.com/cilium/tetragon/pkg/oldhubble/filters"
+)
+
+func getPolicyName(event *v1.Event) string {
+	response, ok := event.Event.(*tetragon.GetEventsResponse)
+	if !ok {
+		return ""
+	}
+	switch ev := response.Event.(type) {
+	case *tetragon.GetEventsResponse_ProcessKprobe:
+		return ev.ProcessKprobe.GetPolicyName()
+	case *tetragon.GetEventsResponse_ProcessTracepoint:
+		return ev.ProcessTracepoint.GetPolicyName()
+	case *tetragon.GetEventsResponse_ProcessUprobe:
+		return ev.ProcessUprobe.GetPolicyName()
+	case *tetragon.GetEventsResponse_ProcessLsm:
+		return ev.ProcessLsm.GetPolicyName()
+	case *tetragon.GetEventsResponse_ProcessPerfEvent:
+		return ev.ProcessPerfEvent.GetPolicyName()
+	}
+	return ""
+}
+
+func filterByPolicyNames(names []string) hubbleFilters.FilterFunc {
+	return func(ev *v1.Event) bool {
+		policyName := getPolicyName(ev)
+		if policyName == "" {
+			return false
+		}
+		for _, name := range names {
+			if name == policyName {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+type PolicyNamesFilter struct{}
+
+func (f *PolicyNamesFilter) OnBuildFilter(_ context.Context, ff *tetragon.Filter) ([]hubbleFilters.FilterFunc, error) {
+	var fs []hubbleFilters.FilterFunc
+	if ff.PolicyNames != nil {
+		fs = append(fs, filterByPolicyNames(ff.PolicyNames))
+	}
+	return fs, nil
+}
@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package filters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	v1 "github.com/cilium/tetragon/pkg/oldhubble/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyNames(t *testing.T) {
+	f := []*tetragon.Filter{{PolicyNames: []string{"file-monitoring", "sys-write"}}}
+	fl, err := BuildFilterList(context.Background(), f, []OnBuildFilter{&PolicyNamesFilter{}})
+	require.NoError(t, err)
+
+	ev := v1.Event{Event: &tetragon.GetEventsResponse{Event: &tetragon.GetEventsResponse_ProcessKprobe{
+		ProcessKprobe: &tetragon.ProcessKprobe{PolicyName: "file-monitoring"},
+	}}}
+	assert.True(t, fl.MatchOne(&ev))
+	ev = v1.Event{Event: &tetragon.GetEventsResponse{Event: &tetragon.GetEventsResponse_ProcessTracepoint{
+		ProcessTracepoint: &tetragon.ProcessTracepoint{PolicyName: "sys-write"},
+	}}}
+	assert.True(t, fl.MatchOne(&ev))
+	ev = v1.Event{Event: &tetragon.GetEventsResponse{Event: &tetragon.GetEventsResponse_ProcessUprobe{
+		ProcessUprobe: &tetragon.ProcessUprobe{PolicyName: "sys-write"},
+	}}}
+	assert.True(t, fl.MatchOne(&ev))
+	ev = v1.Event{Event: &tetragon.GetEventsResponse{Event: &tetragon.GetEventsResponse_ProcessLsm{
+		ProcessLsm: &tetragon.ProcessLsm{PolicyName: "network-monitoring"},
+	}}}
+	assert.False(t, fl.MatchOne(&ev))
+	ev = v1.Event{Event: &tetragon.GetEventsResponse{Event: &tetragon.GetEventsResponse_ProcessPerfEvent{
+		ProcessPerfEvent: &tetragon.ProcessPerfEvent{PolicyName: "sys-write"},
+	}}}
+	assert.True(t, fl.MatchOne(&ev))
+
+	// Events without a policy never match.
+	ev = v1.Event{Event: &tetragon.GetEventsResponse{Event: &tetragon.GetEventsResponse_ProcessExec{
+		ProcessExec: &tetragon.ProcessExec{Process: &tetragon.Process{}},
+	}}}
+	assert.False(t, fl.MatchOne(&ev))
+}
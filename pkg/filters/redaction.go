@@ -0,0 +1,290 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package filters
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// RedactionString replaces the redacted parts of the field values.
+const RedactionString = "*****"
+
+// ParseRedactionFilterList parses a list of redaction filters in JSON format
+// into protobuf messages.
+func ParseRedactionFilterList(filters string) ([]*tetragon.RedactionFilter, error) {
+	if filters == "" {
+		return nil, nil
+	}
+	dec := json.NewDecoder(strings.NewReader(filters))
+	var results []*tetragon.RedactionFilter
+	for dec.More() {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, err
+		}
+		// see ParseFieldFilterList for why the fields need to be fixed up
+		var result tetragon.RedactionFilter
+		if err := json.Unmarshal([]byte(fixupFieldFilterString(string(raw))), &result); err != nil {
+			return nil, err
+		}
+		results = append(results, &result)
+	}
+	return results, nil
+}
+
+type redactionFilter struct {
+	eventSet []tetragon.EventType
+	paths    [][]string
+	redact   []*regexp.Regexp
+}
+
+// Redactor redacts the values of the event fields matching a set of
+// redaction filters.
+type Redactor struct {
+	filters []*redactionFilter
+}
+
+// NewRedactor constructs a new Redactor from a list of redaction filters.
+func NewRedactor(filters []*tetragon.RedactionFilter) (*Redactor, error) {
+	r := &Redactor{}
+	for _, filter := range filters {
+		if filter == nil {
+			continue
+		}
+		if filter.Fields == nil || len(filter.Fields.Paths) == 0 {
+			return nil, fmt.Errorf("redaction filter has no fields")
+		}
+		f := &redactionFilter{eventSet: filter.EventSet}
+		for _, path := range filter.Fields.Paths {
+			p := strings.Split(path, ".")
+			if !pathResolves(f.eventSet, p) {
+				return nil, fmt.Errorf("redaction filter field '%s' is not a string or bytes field of the events", path)
+			}
+			f.paths = append(f.paths, p)
+		}
+		for _, expr := range filter.Redact {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compile redaction filter regex '%s': %w", expr, err)
+			}
+			f.redact = append(f.redact, re)
+		}
+		r.filters = append(r.filters, f)
+	}
+	return r, nil
+}
+
+// RedactorFromFilterList constructs a new Redactor from a list of redaction
+// filters in JSON format. It returns nil if there are no filters.
+func RedactorFromFilterList(filters string) (*Redactor, error) {
+	list, err := ParseRedactionFilterList(filters)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return NewRedactor(list)
+}
+
+// pathResolves returns true if the path resolves to a string or bytes field
+// in at least one of the event types of the event set.
+func pathResolves(eventSet []tetragon.EventType, path []string) bool {
+	events := (&tetragon.GetEventsResponse{}).ProtoReflect().Descriptor().Oneofs().ByName("event").Fields()
+	for i := 0; i < events.Len(); i++ {
+		event := events.Get(i)
+		if !inEventSet(eventSet, tetragon.EventType(event.Number())) {
+			continue
+		}
+		desc := event.Message()
+		for j, name := range path {
+			fd := desc.Fields().ByName(protoreflect.Name(name))
+			if fd == nil || fd.IsMap() {
+				break
+			}
+			if j == len(path)-1 {
+				if fd.Kind() == protoreflect.StringKind || fd.Kind() == protoreflect.BytesKind {
+					return true
+				}
+				break
+			}
+			if fd.Message() == nil {
+				break
+			}
+			desc = fd.Message()
+		}
+	}
+	return false
+}
+
+func inEventSet(eventSet []tetragon.EventType, t tetragon.EventType) bool {
+	if len(eventSet) == 0 {
+		return true
+	}
+	for _, e := range eventSet {
+		if e == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact returns the event with the values of the fields matching the
+// redaction filters redacted. Since the events share messages with the
+// process cache, the event is cloned before being redacted, and is returned
+// as is if there is nothing to redact.
+func (r *Redactor) Redact(event *tetragon.GetEventsResponse) *tetragon.GetEventsResponse {
+	if r == nil || len(r.filters) == 0 || event == nil {
+		return event
+	}
+	if !r.redact(event, false) {
+		return event
+	}
+	event = proto.Clone(event).(*tetragon.GetEventsResponse)
+	r.redact(event, true)
+	return event
+}
+
+// redact returns true if some field values of the event need to be redacted,
+// and redacts them if apply is set.
+func (r *Redactor) redact(event *tetragon.GetEventsResponse, apply bool) bool {
+	rft := event.ProtoReflect()
+	redacted := false
+	rft.Range(func(eventDesc protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if eventDesc.ContainingOneof() == nil {
+			return true
+		}
+		for _, f := range r.filters {
+			if !inEventSet(f.eventSet, tetragon.EventType(eventDesc.Number())) {
+				continue
+			}
+			for _, path := range f.paths {
+				if f.redactMessage(v.Message(), path, apply) {
+					redacted = true
+				}
+			}
+		}
+		return false
+	})
+	return redacted
+}
+
+func (f *redactionFilter) redactMessage(m protoreflect.Message, path []string, apply bool) bool {
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(path[0]))
+	if fd == nil || fd.IsMap() || !m.Has(fd) {
+		return false
+	}
+	redacted := false
+	if len(path) > 1 {
+		if fd.Message() == nil {
+			return false
+		}
+		if !fd.IsList() {
+			return f.redactMessage(m.Get(fd).Message(), path[1:], apply)
+		}
+		list := m.Get(fd).List()
+		for i := 0; i < list.Len(); i++ {
+			if f.redactMessage(list.Get(i).Message(), path[1:], apply) {
+				redacted = true
+			}
+		}
+		return redacted
+	}
+	if fd.Kind() != protoreflect.StringKind && fd.Kind() != protoreflect.BytesKind {
+		return false
+	}
+	if !fd.IsList() {
+		v, ok := f.redactValue(fd, m.Get(fd))
+		if ok && apply {
+			m.Set(fd, v)
+		}
+		return ok
+	}
+	list := m.Get(fd).List()
+	if apply {
+		list = m.Mutable(fd).List()
+	}
+	for i := 0; i < list.Len(); i++ {
+		v, ok := f.redactValue(fd, list.Get(i))
+		if ok {
+			redacted = true
+			if apply {
+				list.Set(i, v)
+			}
+		}
+	}
+	return redacted
+}
+
+// redactValue returns the redacted value and true if the value needs to be
+// redacted.
+func (f *redactionFilter) redactValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) (protoreflect.Value, bool) {
+	if fd.Kind() == protoreflect.BytesKind {
+		b := v.Bytes()
+		if len(b) == 0 {
+			return v, false
+		}
+		r, ok := f.redactString(string(b))
+		return protoreflect.ValueOfBytes([]byte(r)), ok
+	}
+	s := v.String()
+	if s == "" {
+		return v, false
+	}
+	r, ok := f.redactString(s)
+	return protoreflect.ValueOfString(r), ok
+}
+
+// redactString redacts the parts of s matching the capture groups of the
+// regular expressions, or the whole matches of the expressions without
+// capture groups, or the whole string if there are no expressions.
+func (f *redactionFilter) redactString(s string) (string, bool) {
+	if len(f.redact) == 0 {
+		return RedactionString, true
+	}
+	var ranges [][2]int
+	for _, re := range f.redact {
+		for _, match := range re.FindAllStringSubmatchIndex(s, -1) {
+			if re.NumSubexp() == 0 {
+				if match[0] < match[1] {
+					ranges = append(ranges, [2]int{match[0], match[1]})
+				}
+				continue
+			}
+			for i := 2; i < len(match); i += 2 {
+				if match[i] >= 0 && match[i] < match[i+1] {
+					ranges = append(ranges, [2]int{match[i], match[i+1]})
+				}
+			}
+		}
+	}
+	if len(ranges) == 0 {
+		return s, false
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+	var builder strings.Builder
+	end := 0
+	for _, r := range ranges {
+		if r[1] <= end {
+			continue
+		}
+		if r[0] > end {
+			builder.WriteString(s[end:r[0]])
+		}
+		if r[0] >= end {
+			builder.WriteString(RedactionString)
+		}
+		end = r[1]
+	}
+	builder.WriteString(s[end:])
+	return builder.String(), true
+}
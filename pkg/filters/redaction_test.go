@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package filters
+
+import (
+	"testing"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRedactionFilterList(t *testing.T) {
+	filters, err := ParseRedactionFilterList(`{"fields":"process.arguments,parent.arguments","redact":["--password=(\\S+)"]}
+{"event_set":["PROCESS_KPROBE"],"fields":"args.string_arg"}`)
+	require.NoError(t, err)
+	require.Len(t, filters, 2)
+	assert.Equal(t, []string{"process.arguments", "parent.arguments"}, filters[0].Fields.Paths)
+	assert.Equal(t, []string{`--password=(\S+)`}, filters[0].Redact)
+	assert.Equal(t, []tetragon.EventType{tetragon.EventType_PROCESS_KPROBE}, filters[1].EventSet)
+	assert.Equal(t, []string{"args.string_arg"}, filters[1].Fields.Paths)
+
+	_, err = RedactorFromFilterList(`{"fields":"process.pid"}`)
+	assert.Error(t, err)
+	_, err = RedactorFromFilterList(`{"fields":"process.foo"}`)
+	assert.Error(t, err)
+	_, err = RedactorFromFilterList(`{"event_set":["PROCESS_EXEC"],"fields":"args.string_arg"}`)
+	assert.Error(t, err)
+	_, err = RedactorFromFilterList(`{"fields":"process.arguments","redact":["("]}`)
+	assert.Error(t, err)
+
+	r, err := RedactorFromFilterList("")
+	require.NoError(t, err)
+	assert.Nil(t, r)
+}
+
+func TestRedactor(t *testing.T) {
+	r, err := RedactorFromFilterList(`{"fields":"process.arguments,parent.arguments","redact":["--password=(\\S+)","secret"]}
+{"event_set":["PROCESS_KPROBE"],"fields":"args.string_arg,args.bytes_arg"}`)
+	require.NoError(t, err)
+
+	process := &tetragon.Process{Binary: "/usr/bin/app", Arguments: "--user=foo --password=bar --token=secret"}
+	ev := &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessExec{
+			ProcessExec: &tetragon.ProcessExec{
+				Process: process,
+				Parent:  &tetragon.Process{Binary: "/bin/sh", Arguments: "-c app"},
+			},
+		},
+	}
+	redacted := r.Redact(ev)
+	assert.Equal(t, "--user=foo --password=***** --token=*****", redacted.GetProcessExec().Process.Arguments)
+	assert.Equal(t, "-c app", redacted.GetProcessExec().Parent.Arguments)
+	assert.Equal(t, "/usr/bin/app", redacted.GetProcessExec().Process.Binary)
+	// the original event is left untouched
+	assert.Equal(t, "--user=foo --password=bar --token=secret", process.Arguments)
+
+	// nothing to redact
+	ev = &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessExit{
+			ProcessExit: &tetragon.ProcessExit{
+				Process: &tetragon.Process{Arguments: "--user=foo"},
+			},
+		},
+	}
+	assert.Same(t, ev, r.Redact(ev))
+
+	ev = &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessKprobe{
+			ProcessKprobe: &tetragon.ProcessKprobe{
+				Process: process,
+				Args: []*tetragon.KprobeArgument{
+					{Arg: &tetragon.KprobeArgument_StringArg{StringArg: "foo"}},
+					{Arg: &tetragon.KprobeArgument_IntArg{IntArg: 1}},
+					{Arg: &tetragon.KprobeArgument_BytesArg{BytesArg: []byte("bar")}},
+				},
+			},
+		},
+	}
+	redacted = r.Redact(ev)
+	kprobe := redacted.GetProcessKprobe()
+	assert.Equal(t, "--user=foo --password=***** --token=*****", kprobe.Process.Arguments)
+	assert.Equal(t, RedactionString, kprobe.Args[0].GetStringArg())
+	assert.Equal(t, int32(1), kprobe.Args[1].GetIntArg())
+	assert.Equal(t, []byte(RedactionString), kprobe.Args[2].GetBytesArg())
+	assert.Equal(t, "foo", ev.GetProcessKprobe().Args[0].GetStringArg())
+
+	var nilRedactor *Redactor
+	assert.Same(t, ev, nilRedactor.Redact(ev))
+}
+
+func TestRedactString(t *testing.T) {
+	f := &redactionFilter{}
+	s, ok := f.redactString("foo")
+	assert.True(t, ok)
+	assert.Equal(t, RedactionString, s)
+
+	r, err := NewRedactor([]*tetragon.RedactionFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, r.filters)
+
+	r, err = RedactorFromFilterList(`{"fields":"process.arguments","redact":["pass(word)","(wor)d"]}`)
+	require.NoError(t, err)
+	s, ok = r.filters[0].redactString("password passwor")
+	assert.True(t, ok)
+	assert.Equal(t, "pass***** passwor", s)
+	s, ok = r.filters[0].redactString("foo")
+	assert.False(t, ok)
+	assert.Equal(t, "foo", s)
+}
@@ -5,6 +5,7 @@ package ftrace
 
 import (
 	"bufio"
+	"io"
 	"os"
 	"regexp"
 	"slices"
@@ -65,3 +66,31 @@ func ReadAvailFuncs(pattern string) ([]string, error) {
 
 	return final, nil
 }
+
+const errorInjectionList = "/sys/kernel/debug/error_injection/list"
+
+// ReadErrorInjectionFuncs returns the functions that allow error injection
+// (i.e., marked with ALLOW_ERROR_INJECTION), mapped to their error type (e.g.,
+// ERRNO).
+func ReadErrorInjectionFuncs() (map[string]string, error) {
+	file, err := os.Open(errorInjectionList)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return parseErrorInjectionList(file)
+}
+
+// parseErrorInjectionList parses lines in the form of "<func>[ [<module>]]\t<type>"
+func parseErrorInjectionList(r io.Reader) (map[string]string, error) {
+	funcs := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		funcs[fields[0]] = fields[len(fields)-1]
+	}
+	return funcs, scanner.Err()
+}
@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package ftrace
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseErrorInjectionList(t *testing.T) {
+	list := `__x64_sys_openat	ERRNO
+should_failslab	ERRNO
+btrfs_should_cancel_balance [btrfs]	TRUE
+open_ctree [btrfs]	ERRNO
+
+`
+	funcs, err := parseErrorInjectionList(strings.NewReader(list))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"__x64_sys_openat":            "ERRNO",
+		"should_failslab":             "ERRNO",
+		"btrfs_should_cancel_balance": "TRUE",
+		"open_ctree":                  "ERRNO",
+	}, funcs)
+}
@@ -37,6 +37,12 @@ const (
 	GenericLoadModule   = 26
 	GenericKernelModule = 27
 
+	GenericSocketType      = 28
+	GenericSockaddrType    = 29
+	GenericLinuxBinprmType = 30
+	GenericDentryType      = 31
+	GenericStringArrayType = 32
+
 	GenericNopType     = -1
 	GenericInvalidType = -2
 )
@@ -97,6 +103,16 @@ func GenericTypeFromString(arg string) int {
 		return GenericLoadModule
 	case "module":
 		return GenericKernelModule
+	case "socket":
+		return GenericSocketType
+	case "sockaddr":
+		return GenericSockaddrType
+	case "linux_binprm":
+		return GenericLinuxBinprmType
+	case "dentry":
+		return GenericDentryType
+	case "string_array":
+		return GenericStringArrayType
 	default:
 		return GenericInvalidType
 	}
@@ -70,6 +70,7 @@ func GetProcessExec(event *MsgExecveEventUnix, useCache bool) *tetragon.ProcessE
 	if useCache {
 		if ec := eventcache.Get(); ec != nil &&
 			(ec.Needed(tetragonEvent.Process) || (tetragonProcess.Pid.Value > 1 && ec.Needed(tetragonEvent.Parent))) {
+			proc.SetExecPending(true)
 			ec.Add(proc, tetragonEvent, event.Common.Ktime, event.Process.Ktime, event)
 			return nil
 		}
@@ -235,6 +236,7 @@ func (msg *MsgExecveEventUnix) Retry(internal *process.ProcessInternal, ev notif
 		go cleanupEvent.HandleMessage()
 	}
 
+	internal.SetExecPending(false)
 	return nil
 }
 
@@ -341,6 +343,11 @@ func GetProcessExit(event *MsgExitEventUnix) *tetragon.ProcessExit {
 			Pid:       &wrapperspb.UInt32Value{Value: event.ProcessKey.Pid},
 			StartTime: ktime.ToProto(event.ProcessKey.Ktime),
 		}
+		if option.Config.EnableShortLivedProcessTracking {
+			// allows to correlate the exit event with the exec
+			// event synthesized by HandleMissingProcess()
+			tetragonProcess.ExecId = process.GetProcessID(event.ProcessKey.Pid, event.ProcessKey.Ktime)
+		}
 	}
 	if parent != nil {
 		tetragonParent = parent.UnsafeGetProcess()
@@ -388,7 +395,8 @@ func GetProcessExit(event *MsgExitEventUnix) *tetragon.ProcessExit {
 	ec := eventcache.Get()
 	if ec != nil &&
 		(ec.Needed(tetragonProcess) ||
-			(tetragonProcess.Pid.Value > 1 && ec.Needed(tetragonParent))) {
+			(tetragonProcess.Pid.Value > 1 && ec.Needed(tetragonParent)) ||
+			execPending(proc)) {
 		ec.Add(nil, tetragonEvent, event.Common.Ktime, event.ProcessKey.Ktime, event)
 		return nil
 	}
@@ -401,6 +409,14 @@ func GetProcessExit(event *MsgExitEventUnix) *tetragon.ProcessExit {
 	return tetragonEvent
 }
 
+// execPending returns true if the exit event of the process must wait in the
+// event cache for the exec event of the process to be sent first. The exec
+// and exit events of short-lived processes often race, and the exec event may
+// still wait for its pod or parent while the exit event is ready.
+func execPending(proc *process.ProcessInternal) bool {
+	return option.Config.EnableShortLivedProcessTracking && proc != nil && proc.ExecPending()
+}
+
 type MsgExitEventUnix struct {
 	tetragonAPI.MsgExitEvent
 	RefCntDone [2]bool
@@ -444,9 +460,44 @@ func (msg *MsgExitEventUnix) RetryInternal(ev notify.Event, timestamp uint64) (*
 }
 
 func (msg *MsgExitEventUnix) Retry(internal *process.ProcessInternal, ev notify.Event) error {
+	if execPending(internal) {
+		return eventcache.ErrExecPending
+	}
 	return eventcache.HandleGenericEvent(internal, ev, nil)
 }
 
+// HandleMissingProcess synthesizes the exec event of a process whose exec
+// event was never received, so that its exit event is not orphaned. This
+// happens for processes that exit before their exec event could be read
+// from the ring buffer, or when the exec event was lost.
+func (msg *MsgExitEventUnix) HandleMissingProcess(ev notify.Event) *tetragon.GetEventsResponse {
+	if !option.Config.EnableShortLivedProcessTracking {
+		return nil
+	}
+
+	exit, ok := ev.(*tetragon.ProcessExit)
+	if !ok {
+		return nil
+	}
+	parent := exit.GetParent()
+	return &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessExec{
+			ProcessExec: &tetragon.ProcessExec{
+				Process: &tetragon.Process{
+					ExecId:       process.GetProcessID(msg.ProcessKey.Pid, msg.ProcessKey.Ktime),
+					Pid:          &wrapperspb.UInt32Value{Value: msg.ProcessKey.Pid},
+					StartTime:    ktime.ToProto(msg.ProcessKey.Ktime),
+					ParentExecId: parent.GetExecId(),
+					Flags:        "synthetic",
+				},
+				Parent: parent,
+			},
+		},
+		NodeName: nodeName,
+		Time:     ktime.ToProto(msg.ProcessKey.Ktime),
+	}
+}
+
 func (msg *MsgExitEventUnix) HandleMessage() *tetragon.GetEventsResponse {
 	var res *tetragon.GetEventsResponse
 
@@ -26,6 +26,10 @@ func TestGrpcMissingExec(t *testing.T) {
 	GrpcMissingExec[*MsgExecveEventUnix, *MsgExitEventUnix](t)
 }
 
+func TestGrpcMissingExecShortLived(t *testing.T) {
+	GrpcMissingExecShortLived[*MsgExecveEventUnix, *MsgExitEventUnix](t)
+}
+
 func TestGrpcExecParentOutOfOrder(t *testing.T) {
 	GrpcExecParentOutOfOrder[*MsgExecveEventUnix, *MsgExitEventUnix](t)
 }
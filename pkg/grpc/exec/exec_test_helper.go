@@ -511,6 +511,49 @@ func GrpcMissingExec[EXEC notify.Message, EXIT notify.Message](t *testing.T) {
 	assert.Equal(t, ev.GetProcessExit().Process.Pid, &wrapperspb.UInt32Value{Value: currentPid})
 }
 
+func GrpcMissingExecShortLived[EXEC notify.Message, EXIT notify.Message](t *testing.T) {
+	var cancelWg sync.WaitGroup
+
+	option.Config.EnableShortLivedProcessTracking = true
+	t.Cleanup(func() {
+		option.Config.EnableShortLivedProcessTracking = false
+	})
+
+	AllEvents = nil
+	watcher := watcher.NewFakeK8sWatcher(nil)
+	cancel := InitEnv[EXEC, EXIT](t, &cancelWg, watcher)
+	defer func() {
+		cancel()
+		cancelWg.Wait()
+	}()
+
+	parentPid := atomic.AddUint32(&BasePid, 1)
+	currentPid := atomic.AddUint32(&BasePid, 1)
+
+	_, _, _, exitMsg := CreateEvents[EXEC, EXIT](currentPid, 21034975089403, parentPid, 75200000000, "")
+
+	if e := (*exitMsg).HandleMessage(); e != nil {
+		AllEvents = append(AllEvents, e)
+	}
+
+	time.Sleep(time.Millisecond * ((eventcache.CacheStrikes + 4) * CacheTimerMs)) // wait for cache to do it's work
+
+	// the exec event is synthesized before the exit event
+	assert.Equal(t, len(AllEvents), 2)
+	execEv := AllEvents[0].GetProcessExec()
+	exitEv := AllEvents[1].GetProcessExit()
+	assert.NotNil(t, execEv)
+	assert.NotNil(t, exitEv)
+
+	assert.Equal(t, execEv.Process.Flags, "synthetic")
+	assert.Equal(t, execEv.Process.Pid, &wrapperspb.UInt32Value{Value: currentPid})
+	assert.Equal(t, execEv.Process.ExecId, process.GetProcessID(currentPid, 21034975089403))
+
+	// the exit event refers to the synthesized process
+	assert.Equal(t, exitEv.Process.ExecId, execEv.Process.ExecId)
+	assert.Equal(t, exitEv.Process.Pid, &wrapperspb.UInt32Value{Value: currentPid})
+}
+
 func GrpcExecParentOutOfOrder[EXEC notify.Message, EXIT notify.Message](t *testing.T) {
 	var cancelWg sync.WaitGroup
 
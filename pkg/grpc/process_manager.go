@@ -5,10 +5,12 @@ package grpc
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
 	"github.com/cilium/tetragon/api/v1/tetragon"
 	"github.com/cilium/tetragon/pkg/eventcache"
+	"github.com/cilium/tetragon/pkg/filters"
 	"github.com/cilium/tetragon/pkg/logger"
 	"github.com/cilium/tetragon/pkg/metrics/eventmetrics"
 	"github.com/cilium/tetragon/pkg/option"
@@ -27,6 +29,8 @@ type ProcessManager struct {
 	// synchronize access to the listeners map.
 	mux       sync.Mutex
 	listeners map[server.Listener]struct{}
+	// redactor redacts the event fields before notifying the listeners.
+	redactor *filters.Redactor
 }
 
 // NewProcessManager returns a pointer to an initialized ProcessManager struct.
@@ -36,9 +40,15 @@ func NewProcessManager(
 	manager *sensors.Manager,
 	hookRunner *rthooks.Runner,
 ) (*ProcessManager, error) {
+	redactor, err := filters.RedactorFromFilterList(option.Config.RedactionFilters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse redaction filters: %w", err)
+	}
+
 	pm := &ProcessManager{
 		nodeName:  node.GetNodeNameForExport(),
 		listeners: make(map[server.Listener]struct{}),
+		redactor:  redactor,
 	}
 
 	pm.Server = server.NewServer(ctx, wg, pm, manager, hookRunner)
@@ -83,6 +93,7 @@ func (pm *ProcessManager) RemoveListener(listener server.Listener) {
 }
 
 func (pm *ProcessManager) NotifyListener(original interface{}, processed *tetragon.GetEventsResponse) {
+	processed = pm.redactor.Redact(processed)
 	pm.mux.Lock()
 	defer pm.mux.Unlock()
 	for l := range pm.listeners {
@@ -241,3 +241,38 @@ func TestProcessManager_GetProcessID(t *testing.T) {
 	assert.Equal(t, "my-node:2:1", string(decoded))
 	assert.NoError(t, os.Unsetenv("NODE_NAME"))
 }
+
+func TestProcessManager_GetProcessIDClusterName(t *testing.T) {
+	assert.NoError(t, os.Setenv("NODE_NAME", "my-node"))
+	option.Config.ClusterName = "my-cluster"
+	defer func() { option.Config.ClusterName = "" }()
+
+	_, err := cilium.InitCiliumState(context.Background(), false)
+	assert.NoError(t, err)
+
+	err = process.InitCache(watcher.NewFakeK8sWatcher([]interface{}{}), 10)
+	assert.NoError(t, err)
+	defer process.FreeCache()
+	id := process.GetProcessID(1, 2)
+	decoded, err := base64.StdEncoding.DecodeString(id)
+	assert.NoError(t, err)
+	assert.Equal(t, "my-cluster:my-node:2:1", string(decoded))
+
+	parsed, err := process.ParseExecID(id)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.ExecID{ClusterName: "my-cluster", NodeName: "my-node", Ktime: 2, Pid: 1}, parsed)
+	assert.NoError(t, os.Unsetenv("NODE_NAME"))
+}
+
+func TestProcessManager_ParseExecID(t *testing.T) {
+	parsed, err := process.ParseExecID(base64.StdEncoding.EncodeToString([]byte("my-node:2:1")))
+	assert.NoError(t, err)
+	assert.Equal(t, &process.ExecID{NodeName: "my-node", Ktime: 2, Pid: 1}, parsed)
+
+	_, err = process.ParseExecID("not base64!")
+	assert.Error(t, err)
+	_, err = process.ParseExecID(base64.StdEncoding.EncodeToString([]byte("my-node:1")))
+	assert.Error(t, err)
+	_, err = process.ParseExecID(base64.StdEncoding.EncodeToString([]byte("my-node:abc:1")))
+	assert.Error(t, err)
+}
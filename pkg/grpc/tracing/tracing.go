@@ -4,6 +4,7 @@ package tracing
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/cilium/tetragon/pkg/reader/kernel"
 	"golang.org/x/sys/unix"
@@ -17,6 +18,7 @@ import (
 	"github.com/cilium/tetragon/pkg/ktime"
 	"github.com/cilium/tetragon/pkg/logger"
 	"github.com/cilium/tetragon/pkg/option"
+	"github.com/cilium/tetragon/pkg/pkgmanager"
 	"github.com/cilium/tetragon/pkg/process"
 	"github.com/cilium/tetragon/pkg/reader/bpf"
 	"github.com/cilium/tetragon/pkg/reader/caps"
@@ -25,6 +27,9 @@ import (
 	"github.com/cilium/tetragon/pkg/reader/notify"
 	"github.com/cilium/tetragon/pkg/reader/path"
 	"github.com/cilium/tetragon/pkg/tracingpolicy"
+	"github.com/cilium/tetragon/pkg/usyms"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
@@ -32,6 +37,18 @@ var (
 	nodeName = node.GetNodeNameForExport()
 )
 
+// auditAction returns the enforcement action that a policy in audit mode did
+// not take, nil if none.
+func auditAction(act, selector uint32) *tetragon.AuditAction {
+	if act == 0 {
+		return nil
+	}
+	return &tetragon.AuditAction{
+		Action:   kprobeAction(uint64(act)),
+		Selector: selector,
+	}
+}
+
 func kprobeAction(act uint64) tetragon.KprobeAction {
 	switch act {
 	case tracingapi.ActionPost:
@@ -60,11 +77,214 @@ func kprobeAction(act uint64) tetragon.KprobeAction {
 		return tetragon.KprobeAction_KPROBE_ACTION_UNTRACKSOCK
 	case tracingapi.ActionNotifyKiller:
 		return tetragon.KprobeAction_KPROBE_ACTION_NOTIFYKILLER
+	case tracingapi.ActionCount:
+		return tetragon.KprobeAction_KPROBE_ACTION_COUNT
+	case tracingapi.ActionSetTag:
+		return tetragon.KprobeAction_KPROBE_ACTION_SETTAG
 	default:
 		return tetragon.KprobeAction_KPROBE_ACTION_UNKNOWN
 	}
 }
 
+// getKprobeArgument converts a generic kprobe argument to its protobuf
+// representation.
+func getKprobeArgument(arg api.MsgGenericKprobeArg) *tetragon.KprobeArgument {
+	a := &tetragon.KprobeArgument{}
+	switch e := arg.(type) {
+	case api.MsgGenericKprobeArgInt:
+		a.Arg = &tetragon.KprobeArgument_IntArg{IntArg: e.Value}
+		a.Label = e.Label
+	case api.MsgGenericKprobeArgUInt:
+		a.Arg = &tetragon.KprobeArgument_UintArg{UintArg: e.Value}
+		a.Label = e.Label
+	case api.MsgGenericKprobeArgSize:
+		a.Arg = &tetragon.KprobeArgument_SizeArg{SizeArg: e.Value}
+		a.Label = e.Label
+	case api.MsgGenericKprobeArgString:
+		a.Arg = &tetragon.KprobeArgument_StringArg{StringArg: e.Value}
+		a.Label = e.Label
+	case api.MsgGenericKprobeArgSock:
+		sockArg := &tetragon.KprobeSock{
+			Cookie:   e.Sockaddr,
+			Family:   network.InetFamily(e.Family),
+			State:    network.TcpState(e.State),
+			Type:     network.InetType(e.Type),
+			Protocol: network.SockProtocol(e.Family, e.Protocol),
+			Mark:     e.Mark,
+			Priority: e.Priority,
+			Saddr:    e.Saddr,
+			Daddr:    e.Daddr,
+			Sport:    e.Sport,
+			Dport:    e.Dport,
+		}
+		a.Arg = &tetragon.KprobeArgument_SockArg{SockArg: sockArg}
+		a.Label = e.Label
+	case api.MsgGenericKprobeArgSockaddr:
+		sockaddrArg := &tetragon.KprobeSockaddr{
+			Family: network.InetFamily(e.SinFamily),
+			Addr:   e.SinAddr,
+			Port:   e.SinPort,
+		}
+		a.Arg = &tetragon.KprobeArgument_SockaddrArg{SockaddrArg: sockaddrArg}
+		a.Label = e.Label
+	case api.MsgGenericKprobeArgSkb:
+		skbArg := &tetragon.KprobeSkb{
+			Hash:        e.Hash,
+			Len:         e.Len,
+			Priority:    e.Priority,
+			Mark:        e.Mark,
+			Saddr:       e.Saddr,
+			Daddr:       e.Daddr,
+			Sport:       e.Sport,
+			Dport:       e.Dport,
+			Proto:       e.Proto,
+			Protocol:    network.InetProtocol(uint16(e.Proto)),
+			SecPathLen:  e.SecPathLen,
+			SecPathOlen: e.SecPathOLen,
+			Family:      network.InetFamily(e.Family),
+			IcmpType:    e.IcmpType,
+			IcmpCode:    e.IcmpCode,
+		}
+		a.Arg = &tetragon.KprobeArgument_SkbArg{SkbArg: skbArg}
+		a.Label = e.Label
+	case api.MsgGenericKprobeArgCred:
+		credArg := &tetragon.ProcessCredentials{
+			Uid:        &wrapperspb.UInt32Value{Value: e.Uid},
+			Gid:        &wrapperspb.UInt32Value{Value: e.Gid},
+			Euid:       &wrapperspb.UInt32Value{Value: e.Euid},
+			Egid:       &wrapperspb.UInt32Value{Value: e.Egid},
+			Suid:       &wrapperspb.UInt32Value{Value: e.Suid},
+			Sgid:       &wrapperspb.UInt32Value{Value: e.Sgid},
+			Fsuid:      &wrapperspb.UInt32Value{Value: e.FSuid},
+			Fsgid:      &wrapperspb.UInt32Value{Value: e.FSgid},
+			Securebits: caps.GetSecureBitsTypes(e.SecureBits),
+		}
+		credArg.Caps = &tetragon.Capabilities{
+			Permitted:   caps.GetCapabilitiesTypes(e.Cap.Permitted),
+			Effective:   caps.GetCapabilitiesTypes(e.Cap.Effective),
+			Inheritable: caps.GetCapabilitiesTypes(e.Cap.Inheritable),
+		}
+		credArg.UserNs = &tetragon.UserNamespace{
+			Level: &wrapperspb.Int32Value{Value: e.UserNs.Level},
+			Uid:   &wrapperspb.UInt32Value{Value: e.UserNs.Uid},
+			Gid:   &wrapperspb.UInt32Value{Value: e.UserNs.Gid},
+			Ns: &tetragon.Namespace{
+				Inum: e.UserNs.NsInum,
+			},
+		}
+		if e.UserNs.Level == 0 {
+			credArg.UserNs.Ns.IsHost = true
+		}
+		a.Arg = &tetragon.KprobeArgument_ProcessCredentialsArg{ProcessCredentialsArg: credArg}
+		a.Label = e.Label
+	case api.MsgGenericKprobeArgBytes:
+		if e.OrigSize > uint64(len(e.Value)) {
+			a.Arg = &tetragon.KprobeArgument_TruncatedBytesArg{
+				TruncatedBytesArg: &tetragon.KprobeTruncatedBytes{
+					OrigSize: e.OrigSize,
+					BytesArg: e.Value,
+				},
+			}
+		} else {
+			a.Arg = &tetragon.KprobeArgument_BytesArg{BytesArg: e.Value}
+		}
+		a.Label = e.Label
+	case api.MsgGenericKprobeArgFile:
+		fileArg := &tetragon.KprobeFile{
+			Path:  e.Value,
+			Flags: path.FilePathFlagsToStr(e.Flags),
+		}
+		a.Arg = &tetragon.KprobeArgument_FileArg{FileArg: fileArg}
+		a.Label = e.Label
+	case api.MsgGenericKprobeArgPath:
+		pathArg := &tetragon.KprobePath{
+			Path:  e.Value,
+			Flags: path.FilePathFlagsToStr(e.Flags),
+		}
+		a.Arg = &tetragon.KprobeArgument_PathArg{PathArg: pathArg}
+		a.Label = e.Label
+	case api.MsgGenericKprobeArgLinuxBinprm:
+		binprmArg := &tetragon.KprobeLinuxBinprm{
+			Path:  e.Value,
+			Flags: path.FilePathFlagsToStr(e.Flags),
+		}
+		a.Arg = &tetragon.KprobeArgument_LinuxBinprmArg{LinuxBinprmArg: binprmArg}
+		a.Label = e.Label
+	case api.MsgGenericKprobeArgStringArray:
+		a.Arg = &tetragon.KprobeArgument_StringArrayArg{StringArrayArg: &tetragon.KprobeStringArray{
+			Values:    e.Values,
+			Truncated: e.Truncated,
+		}}
+		a.Label = e.Label
+	case api.MsgGenericKprobeArgBpfAttr:
+		bpfAttrArg := &tetragon.KprobeBpfAttr{
+			ProgType: bpf.GetProgType(e.ProgType),
+			InsnCnt:  e.InsnCnt,
+			ProgName: e.ProgName,
+		}
+		a.Arg = &tetragon.KprobeArgument_BpfAttrArg{BpfAttrArg: bpfAttrArg}
+		a.Label = e.Label
+	case api.MsgGenericKprobeArgPerfEvent:
+		perfEventArg := &tetragon.KprobePerfEvent{
+			KprobeFunc:  e.KprobeFunc,
+			Type:        bpf.GetPerfEventType(e.Type),
+			Config:      e.Config,
+			ProbeOffset: e.ProbeOffset,
+		}
+		a.Arg = &tetragon.KprobeArgument_PerfEventArg{PerfEventArg: perfEventArg}
+		a.Label = e.Label
+	case api.MsgGenericKprobeArgBpfMap:
+		bpfMapArg := &tetragon.KprobeBpfMap{
+			MapType:    bpf.GetBpfMapType(e.MapType),
+			KeySize:    e.KeySize,
+			ValueSize:  e.ValueSize,
+			MaxEntries: e.MaxEntries,
+			MapName:    e.MapName,
+		}
+		a.Arg = &tetragon.KprobeArgument_BpfMapArg{BpfMapArg: bpfMapArg}
+		a.Label = e.Label
+	case api.MsgGenericKprobeArgUserNamespace:
+		nsArg := &tetragon.UserNamespace{
+			Level: &wrapperspb.Int32Value{Value: e.Level},
+			Uid:   &wrapperspb.UInt32Value{Value: e.Uid},
+			Gid:   &wrapperspb.UInt32Value{Value: e.Gid},
+			Ns: &tetragon.Namespace{
+				Inum: e.NsInum,
+			},
+		}
+		if e.Level == 0 {
+			nsArg.Ns.IsHost = true
+		}
+		a.Arg = &tetragon.KprobeArgument_UserNsArg{UserNsArg: nsArg}
+		a.Label = e.Label
+	case api.MsgGenericKprobeArgCapability:
+		cArg := &tetragon.KprobeCapability{
+			Value: &wrapperspb.Int32Value{Value: e.Value},
+		}
+		cArg.Name, _ = caps.GetCapability(e.Value)
+		a.Arg = &tetragon.KprobeArgument_CapabilityArg{CapabilityArg: cArg}
+		a.Label = e.Label
+	case api.MsgGenericKprobeArgLoadModule:
+		mArg := &tetragon.KernelModule{
+			Name:        e.Name,
+			SignatureOk: &wrapperspb.BoolValue{Value: e.SigOk != 0},
+			Tainted:     kernel.GetTaintedBitsTypes(e.Taints),
+		}
+		a.Arg = &tetragon.KprobeArgument_ModuleArg{ModuleArg: mArg}
+		a.Label = e.Label
+	case api.MsgGenericKprobeArgKernelModule:
+		mArg := &tetragon.KernelModule{
+			Name:    e.Name,
+			Tainted: kernel.GetTaintedBitsTypes(e.Taints),
+		}
+		a.Arg = &tetragon.KprobeArgument_ModuleArg{ModuleArg: mArg}
+		a.Label = e.Label
+	default:
+		logger.GetLogger().WithField("arg", e).Warnf("unexpected type: %T", e)
+	}
+	return a
+}
+
 func GetProcessKprobe(event *MsgGenericKprobeUnix) *tetragon.ProcessKprobe {
 	var tetragonParent, tetragonProcess *tetragon.Process
 	var tetragonArgs []*tetragon.KprobeArgument
@@ -87,176 +307,7 @@ func GetProcessKprobe(event *MsgGenericKprobeUnix) *tetragon.ProcessKprobe {
 	}
 
 	for _, arg := range event.Args {
-		a := &tetragon.KprobeArgument{}
-		switch e := arg.(type) {
-		case api.MsgGenericKprobeArgInt:
-			a.Arg = &tetragon.KprobeArgument_IntArg{IntArg: e.Value}
-			a.Label = e.Label
-		case api.MsgGenericKprobeArgUInt:
-			a.Arg = &tetragon.KprobeArgument_UintArg{UintArg: e.Value}
-			a.Label = e.Label
-		case api.MsgGenericKprobeArgSize:
-			a.Arg = &tetragon.KprobeArgument_SizeArg{SizeArg: e.Value}
-			a.Label = e.Label
-		case api.MsgGenericKprobeArgString:
-			a.Arg = &tetragon.KprobeArgument_StringArg{StringArg: e.Value}
-			a.Label = e.Label
-		case api.MsgGenericKprobeArgSock:
-			sockArg := &tetragon.KprobeSock{
-				Cookie:   e.Sockaddr,
-				Family:   network.InetFamily(e.Family),
-				State:    network.TcpState(e.State),
-				Type:     network.InetType(e.Type),
-				Protocol: network.InetProtocol(e.Protocol),
-				Mark:     e.Mark,
-				Priority: e.Priority,
-				Saddr:    e.Saddr,
-				Daddr:    e.Daddr,
-				Sport:    e.Sport,
-				Dport:    e.Dport,
-			}
-			a.Arg = &tetragon.KprobeArgument_SockArg{SockArg: sockArg}
-			a.Label = e.Label
-		case api.MsgGenericKprobeArgSkb:
-			skbArg := &tetragon.KprobeSkb{
-				Hash:        e.Hash,
-				Len:         e.Len,
-				Priority:    e.Priority,
-				Mark:        e.Mark,
-				Saddr:       e.Saddr,
-				Daddr:       e.Daddr,
-				Sport:       e.Sport,
-				Dport:       e.Dport,
-				Proto:       e.Proto,
-				Protocol:    network.InetProtocol(uint16(e.Proto)),
-				SecPathLen:  e.SecPathLen,
-				SecPathOlen: e.SecPathOLen,
-				Family:      network.InetFamily(e.Family),
-			}
-			a.Arg = &tetragon.KprobeArgument_SkbArg{SkbArg: skbArg}
-			a.Label = e.Label
-		case api.MsgGenericKprobeArgCred:
-			credArg := &tetragon.ProcessCredentials{
-				Uid:        &wrapperspb.UInt32Value{Value: e.Uid},
-				Gid:        &wrapperspb.UInt32Value{Value: e.Gid},
-				Euid:       &wrapperspb.UInt32Value{Value: e.Euid},
-				Egid:       &wrapperspb.UInt32Value{Value: e.Egid},
-				Suid:       &wrapperspb.UInt32Value{Value: e.Suid},
-				Sgid:       &wrapperspb.UInt32Value{Value: e.Sgid},
-				Fsuid:      &wrapperspb.UInt32Value{Value: e.FSuid},
-				Fsgid:      &wrapperspb.UInt32Value{Value: e.FSgid},
-				Securebits: caps.GetSecureBitsTypes(e.SecureBits),
-			}
-			credArg.Caps = &tetragon.Capabilities{
-				Permitted:   caps.GetCapabilitiesTypes(e.Cap.Permitted),
-				Effective:   caps.GetCapabilitiesTypes(e.Cap.Effective),
-				Inheritable: caps.GetCapabilitiesTypes(e.Cap.Inheritable),
-			}
-			credArg.UserNs = &tetragon.UserNamespace{
-				Level: &wrapperspb.Int32Value{Value: e.UserNs.Level},
-				Uid:   &wrapperspb.UInt32Value{Value: e.UserNs.Uid},
-				Gid:   &wrapperspb.UInt32Value{Value: e.UserNs.Gid},
-				Ns: &tetragon.Namespace{
-					Inum: e.UserNs.NsInum,
-				},
-			}
-			if e.UserNs.Level == 0 {
-				credArg.UserNs.Ns.IsHost = true
-			}
-			a.Arg = &tetragon.KprobeArgument_ProcessCredentialsArg{ProcessCredentialsArg: credArg}
-			a.Label = e.Label
-		case api.MsgGenericKprobeArgBytes:
-			if e.OrigSize > uint64(len(e.Value)) {
-				a.Arg = &tetragon.KprobeArgument_TruncatedBytesArg{
-					TruncatedBytesArg: &tetragon.KprobeTruncatedBytes{
-						OrigSize: e.OrigSize,
-						BytesArg: e.Value,
-					},
-				}
-			} else {
-				a.Arg = &tetragon.KprobeArgument_BytesArg{BytesArg: e.Value}
-			}
-			a.Label = e.Label
-		case api.MsgGenericKprobeArgFile:
-			fileArg := &tetragon.KprobeFile{
-				Path:  e.Value,
-				Flags: path.FilePathFlagsToStr(e.Flags),
-			}
-			a.Arg = &tetragon.KprobeArgument_FileArg{FileArg: fileArg}
-			a.Label = e.Label
-		case api.MsgGenericKprobeArgPath:
-			pathArg := &tetragon.KprobePath{
-				Path:  e.Value,
-				Flags: path.FilePathFlagsToStr(e.Flags),
-			}
-			a.Arg = &tetragon.KprobeArgument_PathArg{PathArg: pathArg}
-			a.Label = e.Label
-		case api.MsgGenericKprobeArgBpfAttr:
-			bpfAttrArg := &tetragon.KprobeBpfAttr{
-				ProgType: bpf.GetProgType(e.ProgType),
-				InsnCnt:  e.InsnCnt,
-				ProgName: e.ProgName,
-			}
-			a.Arg = &tetragon.KprobeArgument_BpfAttrArg{BpfAttrArg: bpfAttrArg}
-			a.Label = e.Label
-		case api.MsgGenericKprobeArgPerfEvent:
-			perfEventArg := &tetragon.KprobePerfEvent{
-				KprobeFunc:  e.KprobeFunc,
-				Type:        bpf.GetPerfEventType(e.Type),
-				Config:      e.Config,
-				ProbeOffset: e.ProbeOffset,
-			}
-			a.Arg = &tetragon.KprobeArgument_PerfEventArg{PerfEventArg: perfEventArg}
-			a.Label = e.Label
-		case api.MsgGenericKprobeArgBpfMap:
-			bpfMapArg := &tetragon.KprobeBpfMap{
-				MapType:    bpf.GetBpfMapType(e.MapType),
-				KeySize:    e.KeySize,
-				ValueSize:  e.ValueSize,
-				MaxEntries: e.MaxEntries,
-				MapName:    e.MapName,
-			}
-			a.Arg = &tetragon.KprobeArgument_BpfMapArg{BpfMapArg: bpfMapArg}
-			a.Label = e.Label
-		case api.MsgGenericKprobeArgUserNamespace:
-			nsArg := &tetragon.UserNamespace{
-				Level: &wrapperspb.Int32Value{Value: e.Level},
-				Uid:   &wrapperspb.UInt32Value{Value: e.Uid},
-				Gid:   &wrapperspb.UInt32Value{Value: e.Gid},
-				Ns: &tetragon.Namespace{
-					Inum: e.NsInum,
-				},
-			}
-			if e.Level == 0 {
-				nsArg.Ns.IsHost = true
-			}
-			a.Arg = &tetragon.KprobeArgument_UserNsArg{UserNsArg: nsArg}
-			a.Label = e.Label
-		case api.MsgGenericKprobeArgCapability:
-			cArg := &tetragon.KprobeCapability{
-				Value: &wrapperspb.Int32Value{Value: e.Value},
-			}
-			cArg.Name, _ = caps.GetCapability(e.Value)
-			a.Arg = &tetragon.KprobeArgument_CapabilityArg{CapabilityArg: cArg}
-			a.Label = e.Label
-		case api.MsgGenericKprobeArgLoadModule:
-			mArg := &tetragon.KernelModule{
-				Name:        e.Name,
-				SignatureOk: &wrapperspb.BoolValue{Value: e.SigOk != 0},
-				Tainted:     kernel.GetTaintedBitsTypes(e.Taints),
-			}
-			a.Arg = &tetragon.KprobeArgument_ModuleArg{ModuleArg: mArg}
-			a.Label = e.Label
-		case api.MsgGenericKprobeArgKernelModule:
-			mArg := &tetragon.KernelModule{
-				Name:    e.Name,
-				Tainted: kernel.GetTaintedBitsTypes(e.Taints),
-			}
-			a.Arg = &tetragon.KprobeArgument_ModuleArg{ModuleArg: mArg}
-			a.Label = e.Label
-		default:
-			logger.GetLogger().WithField("arg", e).Warnf("unexpected type: %T", e)
-		}
+		a := getKprobeArgument(arg)
 		if arg.IsReturnArg() {
 			tetragonReturnArg = a
 		} else {
@@ -293,15 +344,48 @@ func GetProcessKprobe(event *MsgGenericKprobeUnix) *tetragon.ProcessKprobe {
 		stackTrace = append(stackTrace, entry)
 	}
 
+	var userStackTrace []*tetragon.StackTraceEntry
+	var userAddrs []uint64
+	for _, addr := range event.UserStackTrace {
+		// see above, the fixed size array contains trailing zeros
+		if addr != 0 {
+			userAddrs = append(userAddrs, addr)
+		}
+	}
+	if len(userAddrs) > 0 {
+		fnOffsets, err := usyms.UserSymbols().GetFnOffsets(event.ProcessKey.Pid, userAddrs)
+		if err != nil {
+			logger.GetLogger().WithError(err).Warn("stacktrace: failed to read user memory mappings")
+		}
+		for i, addr := range userAddrs {
+			entry := &tetragon.StackTraceEntry{Address: addr}
+			if fnOffsets != nil && fnOffsets[i] != nil {
+				entry.Module = fnOffsets[i].Module
+				entry.Symbol = fnOffsets[i].SymName
+				entry.Offset = fnOffsets[i].Offset
+			}
+			userStackTrace = append(userStackTrace, entry)
+		}
+	}
+
 	tetragonEvent := &tetragon.ProcessKprobe{
-		Process:      tetragonProcess,
-		Parent:       tetragonParent,
-		FunctionName: event.FuncName,
-		Args:         tetragonArgs,
-		Return:       tetragonReturnArg,
-		Action:       kprobeAction(event.Action),
-		StackTrace:   stackTrace,
-		PolicyName:   event.PolicyName,
+		Process:        tetragonProcess,
+		Parent:         tetragonParent,
+		FunctionName:   event.FuncName,
+		Args:           tetragonArgs,
+		Return:         tetragonReturnArg,
+		Action:         kprobeAction(event.Action),
+		StackTrace:     stackTrace,
+		PolicyName:     event.PolicyName,
+		UserStackTrace: userStackTrace,
+		Compat:         event.Common.Flags&processapi.MSG_COMMON_FLAG_COMPAT != 0,
+		AuditAction:    auditAction(event.AuditAction, event.AuditSelector),
+	}
+	if event.Latency != 0 {
+		tetragonEvent.Latency = durationpb.New(time.Duration(event.Latency))
+	}
+	if event.ActionLatency != 0 {
+		tetragonEvent.ActionLatency = durationpb.New(time.Duration(event.ActionLatency))
 	}
 
 	if ec := eventcache.Get(); ec != nil &&
@@ -321,6 +405,7 @@ func GetProcessKprobe(event *MsgGenericKprobeUnix) *tetragon.ProcessKprobe {
 		// order to safely modify them, to not corrupt gRPC streams.
 		tetragonEvent.Process = proc.GetProcessCopy()
 		process.UpdateEventProcessTid(tetragonEvent.Process, &event.Tid)
+		tetragonEvent.PackageManager = pkgmanager.Attribute(tetragonEvent.Process, tetragonEvent.Args)
 	}
 	if parent != nil {
 		tetragonEvent.Parent = tetragonParent
@@ -339,6 +424,18 @@ type MsgGenericTracepointUnix struct {
 	Args       []tracingapi.MsgGenericTracepointArg
 	PolicyName string
 	Action     uint64
+	// Return is the return value of the syscall, for tracepoints with
+	// return.
+	Return tracingapi.MsgGenericTracepointArg
+	// ActionLatency is the time between the hit of the hook and the
+	// completion of the enforcement action in nanoseconds, zero if there was
+	// none.
+	ActionLatency uint64
+	// AuditAction is the enforcement action that the policy, in audit
+	// mode, did not take, zero if none, and AuditSelector the index of the
+	// selector of the action.
+	AuditAction   uint32
+	AuditSelector uint32
 }
 
 func (msg *MsgGenericTracepointUnix) Notify() bool {
@@ -371,46 +468,27 @@ func (msg *MsgGenericTracepointUnix) HandleMessage() *tetragon.GetEventsResponse
 
 	var tetragonArgs []*tetragon.KprobeArgument
 	for _, arg := range msg.Args {
-		switch v := arg.(type) {
-		case uint64:
-			tetragonArgs = append(tetragonArgs, &tetragon.KprobeArgument{Arg: &tetragon.KprobeArgument_SizeArg{
-				SizeArg: v,
-			}})
-		case int64:
-			tetragonArgs = append(tetragonArgs, &tetragon.KprobeArgument{Arg: &tetragon.KprobeArgument_LongArg{
-				LongArg: v,
-			}})
-		case uint32:
-			tetragonArgs = append(tetragonArgs, &tetragon.KprobeArgument{Arg: &tetragon.KprobeArgument_UintArg{
-				UintArg: v,
-			}})
-		case int32:
-			tetragonArgs = append(tetragonArgs, &tetragon.KprobeArgument{Arg: &tetragon.KprobeArgument_IntArg{
-				IntArg: v,
-			}})
-		case string:
-			tetragonArgs = append(tetragonArgs, &tetragon.KprobeArgument{Arg: &tetragon.KprobeArgument_StringArg{
-				StringArg: v,
-			}})
-
-		case []byte:
-			tetragonArgs = append(tetragonArgs, &tetragon.KprobeArgument{Arg: &tetragon.KprobeArgument_BytesArg{
-				BytesArg: v,
-			}})
-
-		default:
-			logger.GetLogger().Warnf("handleGenericTracepointMessage: unhandled value: %+v (%T)", arg, arg)
+		if a := tracepointArgToProto(arg); a != nil {
+			tetragonArgs = append(tetragonArgs, a)
 		}
 	}
 
 	tetragonEvent := &tetragon.ProcessTracepoint{
-		Process:    tetragonProcess,
-		Parent:     tetragonParent,
-		Subsys:     msg.Subsys,
-		Event:      msg.Event,
-		Args:       tetragonArgs,
-		PolicyName: msg.PolicyName,
-		Action:     kprobeAction(msg.Action),
+		Process:     tetragonProcess,
+		Parent:      tetragonParent,
+		Subsys:      msg.Subsys,
+		Event:       msg.Event,
+		Args:        tetragonArgs,
+		PolicyName:  msg.PolicyName,
+		Action:      kprobeAction(msg.Action),
+		Compat:      msg.Common.Flags&processapi.MSG_COMMON_FLAG_COMPAT != 0,
+		AuditAction: auditAction(msg.AuditAction, msg.AuditSelector),
+	}
+	if msg.Return != nil {
+		tetragonEvent.Return = tracepointArgToProto(msg.Return)
+	}
+	if msg.ActionLatency != 0 {
+		tetragonEvent.ActionLatency = durationpb.New(time.Duration(msg.ActionLatency))
 	}
 
 	if ec := eventcache.Get(); ec != nil &&
@@ -439,6 +517,40 @@ func (msg *MsgGenericTracepointUnix) HandleMessage() *tetragon.GetEventsResponse
 	}
 }
 
+// tracepointArgToProto returns the protobuf argument of a tracepoint
+// argument, or nil if its type is not handled.
+func tracepointArgToProto(arg tracingapi.MsgGenericTracepointArg) *tetragon.KprobeArgument {
+	switch v := arg.(type) {
+	case uint64:
+		return &tetragon.KprobeArgument{Arg: &tetragon.KprobeArgument_SizeArg{
+			SizeArg: v,
+		}}
+	case int64:
+		return &tetragon.KprobeArgument{Arg: &tetragon.KprobeArgument_LongArg{
+			LongArg: v,
+		}}
+	case uint32:
+		return &tetragon.KprobeArgument{Arg: &tetragon.KprobeArgument_UintArg{
+			UintArg: v,
+		}}
+	case int32:
+		return &tetragon.KprobeArgument{Arg: &tetragon.KprobeArgument_IntArg{
+			IntArg: v,
+		}}
+	case string:
+		return &tetragon.KprobeArgument{Arg: &tetragon.KprobeArgument_StringArg{
+			StringArg: v,
+		}}
+	case []byte:
+		return &tetragon.KprobeArgument{Arg: &tetragon.KprobeArgument_BytesArg{
+			BytesArg: v,
+		}}
+	default:
+		logger.GetLogger().Warnf("handleGenericTracepointMessage: unhandled value: %+v (%T)", arg, arg)
+		return nil
+	}
+}
+
 func (msg *MsgGenericTracepointUnix) Cast(o interface{}) notify.Message {
 	t := o.(MsgGenericTracepointUnix)
 	return &t
@@ -452,17 +564,30 @@ func (msg *MsgGenericTracepointUnix) PolicyInfo() tracingpolicy.PolicyInfo {
 }
 
 type MsgGenericKprobeUnix struct {
-	Common       processapi.MsgCommon
-	ProcessKey   processapi.MsgExecveKey
-	Namespaces   processapi.MsgNamespaces
-	Capabilities processapi.MsgCapabilities
-	Id           uint64
-	Action       uint64
-	Tid          uint32
-	FuncName     string
-	Args         []tracingapi.MsgGenericKprobeArg
-	PolicyName   string
-	StackTrace   [unix.PERF_MAX_STACK_DEPTH]uint64
+	Common         processapi.MsgCommon
+	ProcessKey     processapi.MsgExecveKey
+	Namespaces     processapi.MsgNamespaces
+	Capabilities   processapi.MsgCapabilities
+	Id             uint64
+	Action         uint64
+	Tid            uint32
+	FuncName       string
+	Args           []tracingapi.MsgGenericKprobeArg
+	PolicyName     string
+	StackTrace     [unix.PERF_MAX_STACK_DEPTH]uint64
+	UserStackTrace [unix.PERF_MAX_STACK_DEPTH]uint64
+	// Latency is the time between the entry and the return of the call in
+	// nanoseconds, zero if it was not measured.
+	Latency uint64
+	// ActionLatency is the time between the hit of the hook and the
+	// completion of the enforcement action in nanoseconds, zero if there was
+	// none.
+	ActionLatency uint64
+	// AuditAction is the enforcement action that the policy, in audit
+	// mode, did not take, zero if none, and AuditSelector the index of the
+	// selector of the action.
+	AuditAction   uint32
+	AuditSelector uint32
 }
 
 func (msg *MsgGenericKprobeUnix) Notify() bool {
@@ -474,7 +599,13 @@ func (msg *MsgGenericKprobeUnix) RetryInternal(ev notify.Event, timestamp uint64
 }
 
 func (msg *MsgGenericKprobeUnix) Retry(internal *process.ProcessInternal, ev notify.Event) error {
-	return eventcache.HandleGenericEvent(internal, ev, &msg.Tid)
+	if err := eventcache.HandleGenericEvent(internal, ev, &msg.Tid); err != nil {
+		return err
+	}
+	if k, ok := ev.(*tetragon.ProcessKprobe); ok {
+		k.PackageManager = pkgmanager.Attribute(k.Process, k.Args)
+	}
+	return nil
 }
 
 func (msg *MsgGenericKprobeUnix) HandleMessage() *tetragon.GetEventsResponse {
@@ -501,11 +632,78 @@ func (msg *MsgGenericKprobeUnix) PolicyInfo() tracingpolicy.PolicyInfo {
 	}
 }
 
+// MsgProcessKprobeCount is the message of a ProcessKprobeCount event, which
+// reports the calls of a kprobe that a process made during a report window,
+// counted in the kernel by the Count action.
+type MsgProcessKprobeCount struct {
+	ProcessKey processapi.MsgExecveKey
+	FuncName   string
+	PolicyName string
+	Count      uint64
+	Window     time.Duration
+}
+
+func GetProcessKprobeCount(event *MsgProcessKprobeCount) *tetragon.ProcessKprobeCount {
+	var tetragonParent, tetragonProcess *tetragon.Process
+
+	proc, parent := process.GetParentProcessInternal(event.ProcessKey.Pid, event.ProcessKey.Ktime)
+	if proc == nil {
+		tetragonProcess = &tetragon.Process{
+			Pid:       &wrapperspb.UInt32Value{Value: event.ProcessKey.Pid},
+			StartTime: ktime.ToProto(event.ProcessKey.Ktime),
+		}
+	} else {
+		tetragonProcess = proc.UnsafeGetProcess()
+	}
+	if parent != nil {
+		tetragonParent = parent.UnsafeGetProcess()
+	}
+
+	return &tetragon.ProcessKprobeCount{
+		Process:      tetragonProcess,
+		Parent:       tetragonParent,
+		FunctionName: event.FuncName,
+		PolicyName:   event.PolicyName,
+		Count:        event.Count,
+		Window:       durationpb.New(event.Window),
+	}
+}
+
+func (msg *MsgProcessKprobeCount) Notify() bool {
+	return false
+}
+
+func (msg *MsgProcessKprobeCount) RetryInternal(_ notify.Event, _ uint64) (*process.ProcessInternal, error) {
+	return nil, fmt.Errorf("Unsupported cache event MsgProcessKprobeCount")
+}
+
+func (msg *MsgProcessKprobeCount) Retry(_ *process.ProcessInternal, _ notify.Event) error {
+	return fmt.Errorf("Unsupported cache retry event MsgProcessKprobeCount")
+}
+
+func (msg *MsgProcessKprobeCount) HandleMessage() *tetragon.GetEventsResponse {
+	return &tetragon.GetEventsResponse{
+		Event:    &tetragon.GetEventsResponse_ProcessKprobeCount{ProcessKprobeCount: GetProcessKprobeCount(msg)},
+		NodeName: nodeName,
+		Time:     timestamppb.Now(),
+	}
+}
+
+func (msg *MsgProcessKprobeCount) Cast(o interface{}) notify.Message {
+	t := o.(MsgProcessKprobeCount)
+	return &t
+}
+
 type MsgProcessLoaderUnix struct {
 	ProcessKey processapi.MsgExecveKey
 	Path       string
 	Ktime      uint64
 	Buildid    []byte
+	// AllowlistViolation is set for libraries outside of the loader allowlist
+	AllowlistViolation bool
+	// AllowlistPolicies are the policies whose allowlist the library is
+	// outside of
+	AllowlistPolicies []string
 }
 
 type ProcessLoaderNotify struct {
@@ -538,14 +736,18 @@ func GetProcessLoader(msg *MsgProcessLoaderUnix) *tetragon.ProcessLoader {
 		tetragonEvent.Process = tetragonProcess
 		tetragonEvent.Path = msg.Path
 		tetragonEvent.Buildid = msg.Buildid
+		tetragonEvent.AllowlistViolation = msg.AllowlistViolation
+		tetragonEvent.AllowlistPolicies = msg.AllowlistPolicies
 		ec.Add(nil, tetragonEvent, msg.Ktime, msg.ProcessKey.Ktime, msg)
 		return nil
 	}
 
 	tetragonEvent := &tetragon.ProcessLoader{
-		Process: tetragonProcess,
-		Path:    msg.Path,
-		Buildid: msg.Buildid,
+		Process:            tetragonProcess,
+		Path:               msg.Path,
+		Buildid:            msg.Buildid,
+		AllowlistViolation: msg.AllowlistViolation,
+		AllowlistPolicies:  msg.AllowlistPolicies,
 	}
 
 	return tetragonEvent
@@ -588,7 +790,14 @@ type MsgGenericUprobeUnix struct {
 	Tid        uint32
 	Path       string
 	Symbol     string
+	Offset     uint64
 	PolicyName string
+	Args       []api.MsgGenericKprobeArg
+	// AuditAction is the enforcement action that the policy, in audit
+	// mode, did not take, zero if none, and AuditSelector the index of the
+	// selector of the action.
+	AuditAction   uint32
+	AuditSelector uint32
 }
 
 func (msg *MsgGenericUprobeUnix) Notify() bool {
@@ -598,7 +807,20 @@ func (msg *MsgGenericUprobeUnix) Notify() bool {
 func (msg *MsgGenericUprobeUnix) PolicyInfo() tracingpolicy.PolicyInfo {
 	return tracingpolicy.PolicyInfo{
 		Name: msg.PolicyName,
-		Hook: fmt.Sprintf("uprobe:%s/%s", msg.Path, msg.Symbol),
+		Hook: fmt.Sprintf("uprobe:%s/%s", msg.Path, msg.location()),
+	}
+}
+
+// location returns the probed location: the symbol, the symbol and the
+// offset, or the offset in the binary.
+func (msg *MsgGenericUprobeUnix) location() string {
+	switch {
+	case msg.Offset == 0:
+		return msg.Symbol
+	case msg.Symbol == "":
+		return fmt.Sprintf("0x%x", msg.Offset)
+	default:
+		return fmt.Sprintf("%s+0x%x", msg.Symbol, msg.Offset)
 	}
 }
 
@@ -632,11 +854,17 @@ func GetProcessUprobe(event *MsgGenericUprobeUnix) *tetragon.ProcessUprobe {
 	}
 
 	tetragonEvent := &tetragon.ProcessUprobe{
-		Process:    tetragonProcess,
-		Parent:     tetragonParent,
-		Path:       event.Path,
-		Symbol:     event.Symbol,
-		PolicyName: event.PolicyName,
+		Process:     tetragonProcess,
+		Parent:      tetragonParent,
+		Path:        event.Path,
+		Symbol:      event.Symbol,
+		Offset:      event.Offset,
+		PolicyName:  event.PolicyName,
+		AuditAction: auditAction(event.AuditAction, event.AuditSelector),
+	}
+
+	for _, arg := range event.Args {
+		tetragonEvent.Args = append(tetragonEvent.Args, getKprobeArgument(arg))
 	}
 
 	if ec := eventcache.Get(); ec != nil &&
@@ -676,3 +904,225 @@ func (msg *MsgGenericUprobeUnix) Cast(o interface{}) notify.Message {
 	t := o.(MsgGenericUprobeUnix)
 	return &t
 }
+
+type MsgGenericLsmUnix struct {
+	Common     processapi.MsgCommon
+	ProcessKey processapi.MsgExecveKey
+	Id         uint64
+	Action     uint64
+	Tid        uint32
+	Hook       string
+	Args       []tracingapi.MsgGenericKprobeArg
+	PolicyName string
+	// ActionLatency is the time between the hit of the hook and the
+	// completion of the enforcement action in nanoseconds, zero if there was
+	// none.
+	ActionLatency uint64
+	// AuditAction is the enforcement action that the policy, in audit
+	// mode, did not take, zero if none, and AuditSelector the index of the
+	// selector of the action.
+	AuditAction   uint32
+	AuditSelector uint32
+}
+
+func (msg *MsgGenericLsmUnix) Notify() bool {
+	return true
+}
+
+func (msg *MsgGenericLsmUnix) PolicyInfo() tracingpolicy.PolicyInfo {
+	return tracingpolicy.PolicyInfo{
+		Name: msg.PolicyName,
+		Hook: fmt.Sprintf("lsm:%s", msg.Hook),
+	}
+}
+
+func (msg *MsgGenericLsmUnix) RetryInternal(ev notify.Event, timestamp uint64) (*process.ProcessInternal, error) {
+	return eventcache.HandleGenericInternal(ev, msg.ProcessKey.Pid, &msg.Tid, timestamp)
+}
+
+func (msg *MsgGenericLsmUnix) Retry(internal *process.ProcessInternal, ev notify.Event) error {
+	if err := eventcache.HandleGenericEvent(internal, ev, &msg.Tid); err != nil {
+		return err
+	}
+	if l, ok := ev.(*tetragon.ProcessLsm); ok {
+		l.PackageManager = pkgmanager.Attribute(l.Process, l.Args)
+	}
+	return nil
+}
+
+func GetProcessLsm(event *MsgGenericLsmUnix) *tetragon.ProcessLsm {
+	var tetragonParent, tetragonProcess *tetragon.Process
+
+	proc, parent := process.GetParentProcessInternal(event.ProcessKey.Pid, event.ProcessKey.Ktime)
+	if proc == nil {
+		tetragonProcess = &tetragon.Process{
+			Pid:       &wrapperspb.UInt32Value{Value: event.ProcessKey.Pid},
+			StartTime: ktime.ToProto(event.ProcessKey.Ktime),
+		}
+	} else {
+		tetragonProcess = proc.UnsafeGetProcess()
+		if err := proc.AnnotateProcess(option.Config.EnableProcessCred, option.Config.EnableProcessNs); err != nil {
+			logger.GetLogger().WithError(err).WithField("processId", tetragonProcess.Pid).
+				Debugf("Failed to annotate process with capabilities and namespaces info")
+		}
+	}
+
+	if parent != nil {
+		tetragonParent = parent.UnsafeGetProcess()
+	}
+
+	tetragonEvent := &tetragon.ProcessLsm{
+		Process:      tetragonProcess,
+		Parent:       tetragonParent,
+		FunctionName: event.Hook,
+		Action:       kprobeAction(event.Action),
+		PolicyName:   event.PolicyName,
+		AuditAction:  auditAction(event.AuditAction, event.AuditSelector),
+	}
+	if event.ActionLatency != 0 {
+		tetragonEvent.ActionLatency = durationpb.New(time.Duration(event.ActionLatency))
+	}
+
+	for _, arg := range event.Args {
+		tetragonEvent.Args = append(tetragonEvent.Args, getKprobeArgument(arg))
+	}
+
+	if ec := eventcache.Get(); ec != nil &&
+		(ec.Needed(tetragonProcess) ||
+			(tetragonProcess.Pid.Value > 1 && ec.Needed(tetragonParent))) {
+		ec.Add(nil, tetragonEvent, event.Common.Ktime, event.ProcessKey.Ktime, event)
+		return nil
+	}
+
+	if proc != nil {
+		// At LSM hooks we report the per thread fields, so take a copy
+		// of the thread leader from the cache then update the corresponding
+		// per thread fields.
+		tetragonEvent.Process = proc.GetProcessCopy()
+		process.UpdateEventProcessTid(tetragonEvent.Process, &event.Tid)
+		tetragonEvent.PackageManager = pkgmanager.Attribute(tetragonEvent.Process, tetragonEvent.Args)
+	}
+	return tetragonEvent
+}
+
+func (msg *MsgGenericLsmUnix) HandleMessage() *tetragon.GetEventsResponse {
+	k := GetProcessLsm(msg)
+	if k == nil {
+		return nil
+	}
+	return &tetragon.GetEventsResponse{
+		Event:    &tetragon.GetEventsResponse_ProcessLsm{ProcessLsm: k},
+		NodeName: nodeName,
+		Time:     ktime.ToProto(msg.Common.Ktime),
+	}
+}
+
+func (msg *MsgGenericLsmUnix) Cast(o interface{}) notify.Message {
+	t := o.(MsgGenericLsmUnix)
+	return &t
+}
+
+type MsgGenericPerfEventUnix struct {
+	Common     processapi.MsgCommon
+	ProcessKey processapi.MsgExecveKey
+	Id         uint64
+	Action     uint64
+	Tid        uint32
+	// Type and Event are the type and name of the perf event
+	Type         string
+	Event        string
+	Samples      uint64
+	SamplePeriod uint64
+	PolicyName   string
+	// AuditAction is the enforcement action that the policy, in audit
+	// mode, did not take, zero if none, and AuditSelector the index of the
+	// selector of the action.
+	AuditAction   uint32
+	AuditSelector uint32
+}
+
+func (msg *MsgGenericPerfEventUnix) Notify() bool {
+	return true
+}
+
+func (msg *MsgGenericPerfEventUnix) PolicyInfo() tracingpolicy.PolicyInfo {
+	return tracingpolicy.PolicyInfo{
+		Name: msg.PolicyName,
+		Hook: fmt.Sprintf("perf_event:%s/%s", msg.Type, msg.Event),
+	}
+}
+
+func (msg *MsgGenericPerfEventUnix) RetryInternal(ev notify.Event, timestamp uint64) (*process.ProcessInternal, error) {
+	return eventcache.HandleGenericInternal(ev, msg.ProcessKey.Pid, &msg.Tid, timestamp)
+}
+
+func (msg *MsgGenericPerfEventUnix) Retry(internal *process.ProcessInternal, ev notify.Event) error {
+	return eventcache.HandleGenericEvent(internal, ev, &msg.Tid)
+}
+
+func GetProcessPerfEvent(event *MsgGenericPerfEventUnix) *tetragon.ProcessPerfEvent {
+	var tetragonParent, tetragonProcess *tetragon.Process
+
+	proc, parent := process.GetParentProcessInternal(event.ProcessKey.Pid, event.ProcessKey.Ktime)
+	if proc == nil {
+		tetragonProcess = &tetragon.Process{
+			Pid:       &wrapperspb.UInt32Value{Value: event.ProcessKey.Pid},
+			StartTime: ktime.ToProto(event.ProcessKey.Ktime),
+		}
+	} else {
+		tetragonProcess = proc.UnsafeGetProcess()
+		if err := proc.AnnotateProcess(option.Config.EnableProcessCred, option.Config.EnableProcessNs); err != nil {
+			logger.GetLogger().WithError(err).WithField("processId", tetragonProcess.Pid).
+				Debugf("Failed to annotate process with capabilities and namespaces info")
+		}
+	}
+
+	if parent != nil {
+		tetragonParent = parent.UnsafeGetProcess()
+	}
+
+	tetragonEvent := &tetragon.ProcessPerfEvent{
+		Process:      tetragonProcess,
+		Parent:       tetragonParent,
+		Type:         event.Type,
+		Event:        event.Event,
+		Samples:      event.Samples,
+		SamplePeriod: event.SamplePeriod,
+		Action:       kprobeAction(event.Action),
+		PolicyName:   event.PolicyName,
+		AuditAction:  auditAction(event.AuditAction, event.AuditSelector),
+	}
+
+	if ec := eventcache.Get(); ec != nil &&
+		(ec.Needed(tetragonProcess) ||
+			(tetragonProcess.Pid.Value > 1 && ec.Needed(tetragonParent))) {
+		ec.Add(nil, tetragonEvent, event.Common.Ktime, event.ProcessKey.Ktime, event)
+		return nil
+	}
+
+	if proc != nil {
+		// Perf events report the per thread fields of the sampled thread,
+		// so take a copy of the thread leader from the cache then update
+		// the corresponding per thread fields.
+		tetragonEvent.Process = proc.GetProcessCopy()
+		process.UpdateEventProcessTid(tetragonEvent.Process, &event.Tid)
+	}
+	return tetragonEvent
+}
+
+func (msg *MsgGenericPerfEventUnix) HandleMessage() *tetragon.GetEventsResponse {
+	k := GetProcessPerfEvent(msg)
+	if k == nil {
+		return nil
+	}
+	return &tetragon.GetEventsResponse{
+		Event:    &tetragon.GetEventsResponse_ProcessPerfEvent{ProcessPerfEvent: k},
+		NodeName: nodeName,
+		Time:     ktime.ToProto(msg.Common.Ktime),
+	}
+}
+
+func (msg *MsgGenericPerfEventUnix) Cast(o interface{}) notify.Message {
+	t := o.(MsgGenericPerfEventUnix)
+	return &t
+}
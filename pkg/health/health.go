@@ -4,13 +4,32 @@
 package health
 
 import (
+	"sort"
+	"sync"
+
 	"github.com/cilium/tetragon/api/v1/tetragon"
 )
 
 var (
 	grpcHealth = tetragon.HealthStatusResult_HEALTH_STATUS_RUNNING
+
+	// statuses reported by the components of the agent, by type
+	statusesMu sync.Mutex
+	statuses   = make(map[tetragon.HealthStatusType]*tetragon.HealthStatus)
 )
 
+// SetStatus sets the health status of the given type, which is reported
+// after the status of the agent.
+func SetStatus(typ tetragon.HealthStatusType, status tetragon.HealthStatusResult, details string) {
+	statusesMu.Lock()
+	defer statusesMu.Unlock()
+	statuses[typ] = &tetragon.HealthStatus{
+		Event:   typ,
+		Status:  status,
+		Details: details,
+	}
+}
+
 func GetHealth() (*tetragon.GetHealthStatusResponse, error) {
 	resp := &tetragon.GetHealthStatusResponse{}
 	hs := &tetragon.HealthStatus{
@@ -19,5 +38,18 @@ func GetHealth() (*tetragon.GetHealthStatusResponse, error) {
 		Details: "running",
 	}
 	resp.HealthStatus = append(resp.HealthStatus, hs)
+
+	statusesMu.Lock()
+	defer statusesMu.Unlock()
+	for _, s := range statuses {
+		resp.HealthStatus = append(resp.HealthStatus, &tetragon.HealthStatus{
+			Event:   s.Event,
+			Status:  s.Status,
+			Details: s.Details,
+		})
+	}
+	sort.Slice(resp.HealthStatus[1:], func(i, j int) bool {
+		return resp.HealthStatus[i+1].Event < resp.HealthStatus[j+1].Event
+	})
 	return resp, nil
 }
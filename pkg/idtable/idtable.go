@@ -127,3 +127,16 @@ func (t *Table) Len() int {
 	}
 	return count
 }
+
+// Entries returns the valid entries of the table
+func (t *Table) Entries() []Entry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var ret []Entry
+	for i := range t.arr {
+		if _, invalid := t.arr[i].(invalidEntry); !invalid {
+			ret = append(ret, t.arr[i])
+		}
+	}
+	return ret
+}
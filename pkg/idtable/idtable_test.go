@@ -71,4 +71,8 @@ func TestOps(t *testing.T) {
 	checkLen(2)
 	checkGetVal(e1.eid, "e1")
 	checkGetVal(e2.eid, "e2")
+
+	if entries := idt.Entries(); len(entries) != 2 || entries[0] != &e2 || entries[1] != &e1 {
+		t.Fatalf("Entries failed: unexpected entries: %v", entries)
+	}
 }
@@ -83,13 +83,38 @@ type TracingPolicySpec struct {
 	// Enable loader events
 	Loader bool `json:"loader,omitempty"`
 	// +kubebuilder:validation:Optional
+	// Library allowlist for loader events.
+	LoaderAllowlist *LoaderAllowlistSpec `json:"loaderAllowlist,omitempty"`
+	// +kubebuilder:validation:Optional
 	// A list of uprobe specs.
 	UProbes []UProbeSpec `json:"uprobes,omitempty"`
+	// +kubebuilder:validation:Optional
+	// A list of LSM hook specs.
+	LsmHooks []LsmHookSpec `json:"lsmhooks,omitempty"`
+	// +kubebuilder:validation:Optional
+	// A list of perf event specs.
+	PerfEvents []PerfEventSpec `json:"perfEvents,omitempty"`
 
 	// +kubebuilder:validation:Optional
 	// PodSelector selects pods that this policy applies to
 	PodSelector *slimv1.LabelSelector `json:"podSelector,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// ContainerSelector selects the containers of the selected pods that
+	// this policy applies to. The selector matches a set of labels built
+	// from the fields of the container: currently only "name", the name of
+	// the container.
+	ContainerSelector *slimv1.LabelSelector `json:"containerSelector,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// NodeSelector selects the nodes that this policy is loaded on. The
+	// selector matches the labels of the Kubernetes node of the agent, and
+	// the labels that the agent sets: "kubernetes.io/arch",
+	// "kubernetes.io/os", "kubernetes.io/hostname" and
+	// "tetragon.io/kernel-release". On the other nodes, the policy is added
+	// but not loaded.
+	NodeSelector *slimv1.LabelSelector `json:"nodeSelector,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// A list of list specs.
 	Lists []ListSpec `json:"lists,omitempty"`
@@ -97,8 +122,46 @@ type TracingPolicySpec struct {
 	// +kubebuilder:validation:Optional
 	// A killer spec.
 	Killers []KillerSpec `json:"killers,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Load the policy even if some of its hooks fail to load or attach,
+	// e.g. because a function does not exist in the running kernel. The
+	// hooks that fail are skipped and reported in the status of the policy.
+	PartialLoad bool `json:"partialLoad,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=enforce;audit
+	// Mode of the policy, enforce by default. In audit mode, the
+	// enforcement actions of the selectors (Sigkill, Signal, Override and
+	// NotifyKiller) are not taken: the events report the action that would
+	// have been taken and the selector that matched instead.
+	Mode string `json:"mode,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// A list of tests of the policy. Tests are run by the test harness
+	// (tetra tracingpolicy test), they are ignored by the agent.
+	Tests []PolicyTestSpec `json:"tests,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// A list of parameters of the policy. References to a parameter, e.g.
+	// ${name}, in the string fields of the policy are replaced by its
+	// value when the policy is loaded.
+	Values []ValueSpec `json:"values,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// A list of options of the policy, e.g. to size its BPF maps.
+	Options []OptionSpec `json:"options,omitempty"`
 }
 
+const (
+	// PolicyModeEnforce is the default mode of the policies, which take
+	// their enforcement actions.
+	PolicyModeEnforce = "enforce"
+	// PolicyModeAudit is the mode of the policies which report their
+	// enforcement actions in the events instead of taking them.
+	PolicyModeAudit = "audit"
+)
+
 func (tp *TracingPolicy) TpName() string {
 	return tp.ObjectMeta.Name
 }
@@ -5,6 +5,7 @@ package v1alpha1
 
 import (
 	ciliumio "github.com/cilium/tetragon/pkg/k8s/apis/cilium.io"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -22,17 +23,50 @@ const (
 )
 
 type KProbeSpec struct {
-	// Name of the function to apply the kprobe spec to.
-	Call string `json:"call"`
+	// +kubebuilder:validation:Optional
+	// Name of the function to apply the kprobe spec to. It can be a
+	// wildcard pattern (e.g., tcp_*) that matches the kernel functions, or,
+	// with syscall, the syscalls. Either call or calls must be set.
+	Call string `json:"call,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Names of the functions to apply the kprobe spec to, as an
+	// alternative to call. Each name can be a wildcard pattern. All the
+	// functions share the selectors of the spec, and are attached with a
+	// single multi kprobe when available.
+	Calls []string `json:"calls,omitempty"`
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=false
 	// Indicates whether to collect return value of the traced function.
 	Return bool `json:"return"`
 	// +kubebuilder:validation:Optional
+	// Indicates whether to measure the time between the entry and the
+	// return of the traced function, reported in the latency field of the
+	// events. Requires return.
+	Latency bool `json:"latency,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Indicates whether to count the latencies of the traced function in an
+	// in-kernel histogram, exported in the
+	// tetragon_generic_kprobe_latency_seconds metric. The histogram counts
+	// the calls that match the selectors. Requires return.
+	LatencyHistogram bool `json:"latencyHistogram,omitempty"`
+	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=true
-	// Indicates whether the traced function is a syscall.
+	// Indicates whether the traced function is a syscall. The call of a
+	// syscall can be its plain name (e.g., lseek), its function name (e.g.,
+	// sys_lseek), or its symbol on any supported architecture (e.g.,
+	// __x64_sys_lseek), it is resolved to the symbol of the running
+	// architecture.
 	Syscall bool `json:"syscall"`
 	// +kubebuilder:validation:Optional
+	// Indicates whether to trace the compat (32-bit) entry point of the
+	// syscall instead of the native one. Requires syscall.
+	Compat bool `json:"compat,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Indicates whether to also trace the compat (32-bit) entry point of the
+	// syscall, in addition to the native one, so that the syscall cannot be
+	// called without being traced by 32-bit binaries. Requires syscall.
+	IncludeCompat bool `json:"includeCompat,omitempty"`
+	// +kubebuilder:validation:Optional
 	// A list of function arguments to include in the trace output.
 	Args []KProbeArg `json:"args,omitempty"`
 	// +kubebuilder:validation:Optional
@@ -45,13 +79,26 @@ type KProbeSpec struct {
 	// +kubebuilder:validation:Optional
 	// Selectors to apply before producing trace output. Selectors are ORed.
 	Selectors []KProbeSelector `json:"selectors,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=kprobe;fentry
+	// How to attach to the function. With fentry, the function is attached
+	// with fentry/fexit programs, which have a lower overhead than kprobes,
+	// when the kernel supports BTF trampolines. Tetragon falls back to
+	// kprobes when fentry cannot be used. The default is kprobe.
+	AttachMode string `json:"attachMode,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Defer the attachment of the kprobe until its function is available,
+	// e.g. when the function belongs to a kernel module that is not loaded
+	// yet, instead of failing to load the policy. Deferred kprobes cannot
+	// be attached with fentry nor use the Override action.
+	Deferred bool `json:"deferred,omitempty"`
 }
 
 type KProbeArg struct {
 	// +kubebuilder:validation:Minimum=0
 	// Position of the argument.
 	Index uint32 `json:"index"`
-	// +kubebuilder:validation:Enum=auto;int;uint32;int32;uint64;int64;char_buf;char_iovec;size_t;skb;sock;string;fd;file;filename;path;nop;bpf_attr;perf_event;bpf_map;user_namespace;capability;kiocb;iov_iter;cred;load_info;module;
+	// +kubebuilder:validation:Enum=auto;int;uint32;int32;uint64;int64;char_buf;char_iovec;size_t;skb;sock;socket;string;fd;file;filename;path;nop;bpf_attr;perf_event;bpf_map;user_namespace;capability;kiocb;iov_iter;cred;load_info;module;sockaddr;linux_binprm;dentry;string_array;
 	// +kubebuilder:default=auto
 	// Argument type.
 	Type string `json:"type"`
@@ -76,12 +123,20 @@ type KProbeArg struct {
 	// supports fetching up to 327360 bytes if this flag is turned on
 	MaxData bool `json:"maxData"`
 	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=16777215
+	// Maximum number of bytes to copy. This field is only used for char_buf,
+	// char_iovec and iov_iter data. Larger buffers are truncated to this size
+	// and reported as truncated bytes along with their original size. When
+	// this value is 0 (default), the limit is the one of maxData.
+	MaxDataSize uint32 `json:"maxDataSize"`
+	// +kubebuilder:validation:Optional
 	// Label to output in the JSON
 	Label string `json:"label"`
 }
 
 type BinarySelector struct {
-	// +kubebuilder:validation:Enum=In;NotIn
+	// +kubebuilder:validation:Enum=In;NotIn;Prefix;NotPrefix
 	// Filter operation.
 	Operator string `json:"operator"`
 	// Value to compare the argument against.
@@ -118,6 +173,13 @@ type KProbeSelector struct {
 	// +kubebuilder:validation:Optional
 	// IDs for capabilities changes
 	MatchCapabilityChanges []CapabilitiesSelector `json:"matchCapabilityChanges,omitempty"`
+	// +kubebuilder:validation:Optional
+	// A list of credential (uid/gid) filters. MatchCredentials are ANDed.
+	MatchCredentials []CredentialsSelector `json:"matchCredentials,omitempty"`
+	// +kubebuilder:validation:Optional
+	// A list of filters on the tags set on the processes by the SetTag
+	// action. MatchTags are ANDed.
+	MatchTags []TagsSelector `json:"matchTags,omitempty"`
 }
 
 type NamespaceChangesSelector struct {
@@ -156,6 +218,26 @@ type CapabilitiesSelector struct {
 	Values []string `json:"values"`
 }
 
+type CredentialsSelector struct {
+	// +kubebuilder:validation:Enum=UID;EUID;GID;EGID;FSUID
+	// Type of credentials
+	Type string `json:"type"`
+	// +kubebuilder:validation:Enum=In;NotIn
+	// Credentials selector operator.
+	Operator string `json:"operator"`
+	// User or group IDs to match.
+	Values []uint32 `json:"values"`
+}
+
+type TagsSelector struct {
+	// +kubebuilder:validation:Enum=In;NotIn
+	// Tags selector operator. In matches processes with any of the tags,
+	// NotIn processes with none of them.
+	Operator string `json:"operator"`
+	// Names of the tags to match.
+	Values []string `json:"values"`
+}
+
 type PIDSelector struct {
 	// +kubebuilder:validation:Enum=In;NotIn
 	// PID selector operator.
@@ -176,7 +258,7 @@ type ArgSelector struct {
 	// +kubebuilder:validation:Minimum=0
 	// Position of the argument to apply fhe filter to.
 	Index uint32 `json:"index"`
-	// +kubebuilder:validation:Enum=Equal;NotEqual;Prefix;NotPrefix;Postfix;NotPostfix;GreaterThan;LessThan;GT;LT;Mask;SPort;NotSPort;SPortPriv;NotSportPriv;DPort;NotDPort;DPortPriv;NotDPortPriv;SAddr;NotSAddr;DAddr;NotDAddr;Protocol;Family;State;InMap;NotInMap
+	// +kubebuilder:validation:Enum=Equal;NotEqual;Prefix;NotPrefix;Postfix;NotPostfix;GreaterThan;LessThan;GT;LT;Mask;SPort;NotSPort;SPortPriv;NotSportPriv;DPort;NotDPort;DPortPriv;NotDPortPriv;SAddr;NotSAddr;DAddr;NotDAddr;Protocol;Family;State;SockType;InMap;NotInMap
 	// Filter operation.
 	Operator string `json:"operator"`
 	// Value to compare the argument against.
@@ -184,7 +266,7 @@ type ArgSelector struct {
 }
 
 type ActionSelector struct {
-	// +kubebuilder:validation:Enum=Post;FollowFD;UnfollowFD;Sigkill;CopyFD;Override;GetUrl;DnsLookup;NoPost;Signal;TrackSock;UntrackSock;NotifyKiller
+	// +kubebuilder:validation:Enum=Post;FollowFD;UnfollowFD;Sigkill;CopyFD;Override;GetUrl;DnsLookup;NoPost;Signal;TrackSock;UntrackSock;NotifyKiller;Count;SetTag
 	// Action to execute.
 	Action string `json:"action"`
 	// +kubebuilder:validation:Optional
@@ -209,13 +291,33 @@ type ActionSelector struct {
 	// An arg index for the sock for trackSock and untrackSock actions
 	ArgSock uint32 `json:"argSock"`
 	// +kubebuilder:validation:Optional
+	// A tag name for the setTag action
+	ArgTag string `json:"argTag,omitempty"`
+	// +kubebuilder:validation:Optional
 	// A time period within which repeated messages will not be posted. Can be
 	// specified in seconds (default or with 's' suffix), minutes ('m' suffix)
-	// or hours ('h' suffix). Only valid with the post action.
+	// or hours ('h' suffix). A maximum number of messages per time period can
+	// be specified with the COUNT/PERIOD format (e.g., 10/s or 100/5m). Only
+	// valid with the post action.
 	RateLimit string `json:"rateLimit"`
 	// +kubebuilder:validation:Optional
-	// Enable stack trace export. Only valid with the post action.
+	// +kubebuilder:validation:Enum=thread;process;global
+	// The scope of the rate limiting: repeated messages are counted per
+	// thread (default), per process, or globally. Only valid with rateLimit.
+	RateLimitScope string `json:"rateLimitScope,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Post only one of every sample matches of the selector, e.g., 1000 to
+	// post one event of every thousand. The matches are counted per hook and
+	// selector, so that the selectors of a hook can sample at different
+	// rates. Only valid with the post action.
+	Sample uint32 `json:"sample,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Enable kernel stack trace export. Only valid with the post action.
 	StackTrace bool `json:"stackTrace"`
+	// +kubebuilder:validation:Optional
+	// Enable user space stack trace export. Only valid with the post action.
+	UserStackTrace bool `json:"userStackTrace"`
 }
 
 type TracepointSpec struct {
@@ -224,6 +326,28 @@ type TracepointSpec struct {
 	// Tracepoint event
 	Event string `json:"event"`
 	// +kubebuilder:validation:Optional
+	// Attach the tracepoint as a raw tracepoint. The arguments of raw
+	// tracepoints are the arguments of the tracepoint in the kernel, typed
+	// with BTF, instead of the fields of the tracepoint format in tracefs.
+	Raw bool `json:"raw,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Indicates whether to report the events of compat (32-bit) syscalls.
+	// Only valid for raw_syscalls tracepoints, whose events of compat
+	// syscalls are dropped otherwise, since their syscall IDs are the ones
+	// of the compat syscall table.
+	IncludeCompat bool `json:"includeCompat,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Indicates whether to collect the return value of the syscall. Only
+	// valid for the sys_enter tracepoints of the syscalls and raw_syscalls
+	// subsystems, the return value is read from the matching sys_exit
+	// tracepoint and reported in the event of the sys_enter tracepoint.
+	Return bool `json:"return,omitempty"`
+	// +kubebuilder:validation:Optional
+	// The type of the return value to include in the trace output, an
+	// integer type. Defaults to int64, the type of the ret field of the
+	// sys_exit tracepoints.
+	ReturnArg *KProbeArg `json:"returnArg,omitempty"`
+	// +kubebuilder:validation:Optional
 	// A list of function arguments to include in the trace output.
 	Args []KProbeArg `json:"args,omitempty"`
 	// +kubebuilder:validation:Optional
@@ -234,13 +358,85 @@ type TracepointSpec struct {
 type UProbeSpec struct {
 	// Name of the traced binary
 	Path string `json:"path"`
+	// +kubebuilder:validation:Optional
 	// Name of the traced symbol
-	Symbol string `json:"symbol"`
+	Symbol string `json:"symbol,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Offset of the probe. If a symbol is specified, the offset is relative
+	// to the symbol address, otherwise it is the offset in the binary file.
+	Offset *uint64 `json:"offset,omitempty"`
+	// +kubebuilder:validation:Optional
+	// USDT probe to attach to. Symbol and offset must not be specified
+	// for USDT probes.
+	Usdt *UsdtSpec `json:"usdt,omitempty"`
+	// +kubebuilder:validation:Optional
+	// A list of function arguments to include in the trace output. For USDT
+	// probes, the index is the index of the probe argument.
+	Args []KProbeArg `json:"args,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Selectors to apply before producing trace output. Selectors are ORed.
+	Selectors []KProbeSelector `json:"selectors,omitempty"`
+}
+
+type UsdtSpec struct {
+	// Provider of the USDT probe
+	Provider string `json:"provider"`
+	// Name of the USDT probe
+	Name string `json:"name"`
+}
+
+type LsmHookSpec struct {
+	// Name of the LSM hook, e.g. file_open. The security_ prefix of the
+	// kernel function may be omitted.
+	Hook string `json:"hook"`
+	// +kubebuilder:validation:Optional
+	// A list of hook arguments to include in the trace output.
+	Args []KProbeArg `json:"args,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Selectors to apply before producing trace output. Selectors are ORed.
+	// An Override action denies the operation with the given error.
+	Selectors []KProbeSelector `json:"selectors,omitempty"`
+}
+
+type PerfEventSpec struct {
+	// +kubebuilder:validation:Enum=software;hardware
+	// Type of the perf event.
+	Type string `json:"type"`
+	// Name of the perf event. Software events: cpu-clock, task-clock,
+	// page-faults, minor-faults, major-faults, context-switches,
+	// cpu-migrations, alignment-faults and emulation-faults. Hardware
+	// events: cpu-cycles, instructions, cache-references, cache-misses,
+	// branch-instructions, branch-misses, bus-cycles,
+	// stalled-cycles-frontend, stalled-cycles-backend and ref-cpu-cycles.
+	Event string `json:"event"`
+	// +kubebuilder:validation:Optional
+	// Number of occurrences of the event between two samples. Exclusive
+	// with sampleFrequency. Defaults to a sample frequency of 100 if
+	// neither is set.
+	SamplePeriod uint64 `json:"samplePeriod,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Number of samples per second, the kernel adjusts the sample period
+	// to reach it. Exclusive with samplePeriod.
+	SampleFrequency uint64 `json:"sampleFrequency,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Report a process only when the number of its samples in a time
+	// window reaches a threshold. Every sample is reported otherwise.
+	Threshold *PerfEventThresholdSpec `json:"threshold,omitempty"`
 	// +kubebuilder:validation:Optional
 	// Selectors to apply before producing trace output. Selectors are ORed.
 	Selectors []KProbeSelector `json:"selectors,omitempty"`
 }
 
+type PerfEventThresholdSpec struct {
+	// +kubebuilder:validation:Minimum=1
+	// Number of samples of a process in the window to report it. A
+	// process is reported at most once per window.
+	Samples uint64 `json:"samples"`
+	// +kubebuilder:validation:Optional
+	// Duration of the window, e.g. 500ms or 10s. Defaults to 1s.
+	Window string `json:"window,omitempty"`
+}
+
 type ListSpec struct {
 	// Name of the list
 	Name string `json:"name"`
@@ -259,6 +455,29 @@ type ListSpec struct {
 	Validated bool `json:"validated"`
 }
 
+// ValueSpec is a parameter of a tracing policy. Its value replaces the
+// ${name} references in the string fields of the policy when it is loaded.
+type ValueSpec struct {
+	// Name of the parameter
+	Name string `json:"name"`
+	// +kubebuilder:validation:Optional
+	// Value of a scalar parameter.
+	Value string `json:"value,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Values of a list parameter. A list element that is exactly a
+	// reference to a list parameter is replaced by all its values.
+	Values []string `json:"values,omitempty"`
+}
+
+// OptionSpec is an option of a tracing policy.
+type OptionSpec struct {
+	// Name of the option.
+	Name string `json:"name"`
+	// +kubebuilder:validation:Optional
+	// Value of the option.
+	Value string `json:"value,omitempty"`
+}
+
 type PodInfoSpec struct {
 	// Host networking requested for this pod. Use the host's network namespace.
 	// If this option is set, the ports that will be used must be specified.
@@ -324,3 +543,43 @@ type KillerSpec struct {
 	// syscalls where killer is executed in
 	Syscalls []string `json:"syscalls"`
 }
+
+type LoaderAllowlistSpec struct {
+	// Build IDs (hex encoded) of the libraries that are allowed to be
+	// loaded, e.g. generated from an SBOM.
+	BuildIDs []string `json:"buildIDs"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=8
+	// Binaries whose loaded libraries are checked against the allowlist.
+	// If empty, libraries loaded by any process are checked.
+	Binaries []string `json:"binaries,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Post;Sigkill
+	// +kubebuilder:default=Post
+	// Action taken when a library outside the allowlist is loaded. Post
+	// flags the loader event, Sigkill additionally kills the process
+	// before it returns from the mapping of the library. Processes
+	// started before the policy was loaded are not killed, their loader
+	// events are flagged instead.
+	Action string `json:"action,omitempty"`
+}
+
+type PolicyTestSpec struct {
+	// Name of the test.
+	Name string `json:"name"`
+	// +kubebuilder:validation:MinItems=1
+	// Command, and its arguments, to run to trigger the events of the test.
+	Trigger []string `json:"trigger"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=10
+	// Time, in seconds, to wait for the expected events after the trigger
+	// command has been started.
+	Timeout uint32 `json:"timeout"`
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// Events expected to be generated by the trigger command, in the event
+	// checker format: an "ordered" boolean and a list of "checks".
+	Expect apiextensionsv1.JSON `json:"expect"`
+}
@@ -92,6 +92,27 @@ func (in *CapabilitiesSelector) DeepCopy() *CapabilitiesSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialsSelector) DeepCopyInto(out *CredentialsSelector) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]uint32, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsSelector.
+func (in *CredentialsSelector) DeepCopy() *CredentialsSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialsSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KProbeArg) DeepCopyInto(out *KProbeArg) {
 	*out = *in
@@ -172,6 +193,20 @@ func (in *KProbeSelector) DeepCopyInto(out *KProbeSelector) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MatchCredentials != nil {
+		in, out := &in.MatchCredentials, &out.MatchCredentials
+		*out = make([]CredentialsSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MatchTags != nil {
+		in, out := &in.MatchTags, &out.MatchTags
+		*out = make([]TagsSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -188,6 +223,11 @@ func (in *KProbeSelector) DeepCopy() *KProbeSelector {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KProbeSpec) DeepCopyInto(out *KProbeSpec) {
 	*out = *in
+	if in.Calls != nil {
+		in, out := &in.Calls, &out.Calls
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Args != nil {
 		in, out := &in.Args, &out.Args
 		*out = make([]KProbeArg, len(*in))
@@ -265,6 +305,60 @@ func (in *ListSpec) DeepCopy() *ListSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoaderAllowlistSpec) DeepCopyInto(out *LoaderAllowlistSpec) {
+	*out = *in
+	if in.BuildIDs != nil {
+		in, out := &in.BuildIDs, &out.BuildIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Binaries != nil {
+		in, out := &in.Binaries, &out.Binaries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoaderAllowlistSpec.
+func (in *LoaderAllowlistSpec) DeepCopy() *LoaderAllowlistSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoaderAllowlistSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LsmHookSpec) DeepCopyInto(out *LsmHookSpec) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]KProbeArg, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selectors != nil {
+		in, out := &in.Selectors, &out.Selectors
+		*out = make([]KProbeSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LsmHookSpec.
+func (in *LsmHookSpec) DeepCopy() *LsmHookSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LsmHookSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespaceChangesSelector) DeepCopyInto(out *NamespaceChangesSelector) {
 	*out = *in
@@ -307,6 +401,22 @@ func (in *NamespaceSelector) DeepCopy() *NamespaceSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OptionSpec) DeepCopyInto(out *OptionSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OptionSpec.
+func (in *OptionSpec) DeepCopy() *OptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PIDSelector) DeepCopyInto(out *PIDSelector) {
 	*out = *in
@@ -328,6 +438,50 @@ func (in *PIDSelector) DeepCopy() *PIDSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfEventSpec) DeepCopyInto(out *PerfEventSpec) {
+	*out = *in
+	if in.Threshold != nil {
+		in, out := &in.Threshold, &out.Threshold
+		*out = new(PerfEventThresholdSpec)
+		**out = **in
+	}
+	if in.Selectors != nil {
+		in, out := &in.Selectors, &out.Selectors
+		*out = make([]KProbeSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfEventSpec.
+func (in *PerfEventSpec) DeepCopy() *PerfEventSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfEventSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfEventThresholdSpec) DeepCopyInto(out *PerfEventThresholdSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfEventThresholdSpec.
+func (in *PerfEventThresholdSpec) DeepCopy() *PerfEventThresholdSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfEventThresholdSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodIP) DeepCopyInto(out *PodIP) {
 	*out = *in
@@ -444,9 +598,57 @@ func (in *PodInfoStatus) DeepCopy() *PodInfoStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyTestSpec) DeepCopyInto(out *PolicyTestSpec) {
+	*out = *in
+	if in.Trigger != nil {
+		in, out := &in.Trigger, &out.Trigger
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Expect.DeepCopyInto(&out.Expect)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyTestSpec.
+func (in *PolicyTestSpec) DeepCopy() *PolicyTestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyTestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TagsSelector) DeepCopyInto(out *TagsSelector) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TagsSelector.
+func (in *TagsSelector) DeepCopy() *TagsSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(TagsSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TracepointSpec) DeepCopyInto(out *TracepointSpec) {
 	*out = *in
+	if in.ReturnArg != nil {
+		in, out := &in.ReturnArg, &out.ReturnArg
+		*out = new(KProbeArg)
+		**out = **in
+	}
 	if in.Args != nil {
 		in, out := &in.Args, &out.Args
 		*out = make([]KProbeArg, len(*in))
@@ -609,6 +811,11 @@ func (in *TracingPolicySpec) DeepCopyInto(out *TracingPolicySpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LoaderAllowlist != nil {
+		in, out := &in.LoaderAllowlist, &out.LoaderAllowlist
+		*out = new(LoaderAllowlistSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.UProbes != nil {
 		in, out := &in.UProbes, &out.UProbes
 		*out = make([]UProbeSpec, len(*in))
@@ -616,11 +823,35 @@ func (in *TracingPolicySpec) DeepCopyInto(out *TracingPolicySpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LsmHooks != nil {
+		in, out := &in.LsmHooks, &out.LsmHooks
+		*out = make([]LsmHookSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PerfEvents != nil {
+		in, out := &in.PerfEvents, &out.PerfEvents
+		*out = make([]PerfEventSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.PodSelector != nil {
 		in, out := &in.PodSelector, &out.PodSelector
 		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ContainerSelector != nil {
+		in, out := &in.ContainerSelector, &out.ContainerSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Lists != nil {
 		in, out := &in.Lists, &out.Lists
 		*out = make([]ListSpec, len(*in))
@@ -635,6 +866,25 @@ func (in *TracingPolicySpec) DeepCopyInto(out *TracingPolicySpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Tests != nil {
+		in, out := &in.Tests, &out.Tests
+		*out = make([]PolicyTestSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]ValueSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]OptionSpec, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -651,6 +901,21 @@ func (in *TracingPolicySpec) DeepCopy() *TracingPolicySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UProbeSpec) DeepCopyInto(out *UProbeSpec) {
 	*out = *in
+	if in.Offset != nil {
+		in, out := &in.Offset, &out.Offset
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.Usdt != nil {
+		in, out := &in.Usdt, &out.Usdt
+		*out = new(UsdtSpec)
+		**out = **in
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]KProbeArg, len(*in))
+		copy(*out, *in)
+	}
 	if in.Selectors != nil {
 		in, out := &in.Selectors, &out.Selectors
 		*out = make([]KProbeSelector, len(*in))
@@ -671,6 +936,22 @@ func (in *UProbeSpec) DeepCopy() *UProbeSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsdtSpec) DeepCopyInto(out *UsdtSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsdtSpec.
+func (in *UsdtSpec) DeepCopy() *UsdtSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UsdtSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadObjectMeta) DeepCopyInto(out *WorkloadObjectMeta) {
 	*out = *in
@@ -686,3 +967,24 @@ func (in *WorkloadObjectMeta) DeepCopy() *WorkloadObjectMeta {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValueSpec) DeepCopyInto(out *ValueSpec) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValueSpec.
+func (in *ValueSpec) DeepCopy() *ValueSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ValueSpec)
+	in.DeepCopyInto(out)
+	return out
+}
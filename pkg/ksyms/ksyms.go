@@ -40,7 +40,9 @@ type ksym struct {
 
 // Ksyms is a structure for kernel symbols
 type Ksyms struct {
-	table   []ksym
+	table []ksym
+	// funcs are the sorted names of the function symbols
+	funcs   []string
 	fnCache *lru.Cache[uint64, fnOffsetVal]
 }
 
@@ -128,6 +130,7 @@ func NewKsyms(procfs string) (*Ksyms, error) {
 	if needsSort {
 		sort.Slice(ksyms.table[:], func(i1, i2 int) bool { return ksyms.table[i1].addr < ksyms.table[i2].addr })
 	}
+	ksyms.indexFunctions()
 
 	fc, err := lru.New[uint64, fnOffsetVal](1024)
 	if err == nil {
@@ -193,3 +196,57 @@ func (k *Ksyms) getFnOffset(addr uint64) (*FnOffset, error) {
 		Offset:  addr - sym.addr,
 	}, nil
 }
+
+// indexFunctions builds the index of the function names used by IsFunction
+// and FunctionClones.
+func (k *Ksyms) indexFunctions() {
+	k.funcs = k.funcs[:0]
+	for i := range k.table {
+		if k.table[i].isFunction() {
+			k.funcs = append(k.funcs, k.table[i].name)
+		}
+	}
+	sort.Strings(k.funcs)
+}
+
+// IsFunction returns true if name is a function symbol
+func (k *Ksyms) IsFunction(name string) bool {
+	i := sort.SearchStrings(k.funcs, name)
+	return i < len(k.funcs) && k.funcs[i] == name
+}
+
+// FunctionClones returns the compiler-generated clones of a function (e.g.,
+// name.isra.0, name.constprop.0, or name.part.0). When name itself was
+// inlined or optimized, its clones are still available to be probed.
+func (k *Ksyms) FunctionClones(name string) []string {
+	var ret []string
+	prefix := name + "."
+	for i := sort.SearchStrings(k.funcs, prefix); i < len(k.funcs) && strings.HasPrefix(k.funcs[i], prefix); i++ {
+		ret = append(ret, k.funcs[i])
+	}
+	return ret
+}
+
+// IsFunctionAvailable returns true if name is a function symbol of the
+// kernel. Unlike KernelSymbols, it reads procfs/kallsyms on every call, so
+// it sees the symbols of the modules loaded in the meantime.
+func IsFunctionAvailable(procfs string, name string) (bool, error) {
+	file, err := os.Open(fmt.Sprintf("%s/kallsyms", procfs))
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	s := bufio.NewScanner(file)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) < 3 || fields[2] != name {
+			continue
+		}
+		sym := ksym{name: fields[2], ty: fields[1]}
+		if sym.isFunction() {
+			return true, nil
+		}
+	}
+	return false, s.Err()
+}
@@ -3,6 +3,8 @@
 package ksyms
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -86,3 +88,56 @@ func TestGetFnOffset(t *testing.T) {
 		})
 	}
 }
+
+func TestFunctionClones(t *testing.T) {
+	ksyms := &Ksyms{
+		table: []ksym{
+			{addr: 0x100, name: "foo", ty: "t"},
+			{addr: 0x200, name: "bar.isra.0", ty: "t"},
+			{addr: 0x300, name: "bar.constprop.0", ty: "t"},
+			{addr: 0x400, name: "barbaz", ty: "t"},
+			{addr: 0x500, name: "bar.data", ty: "d"},
+		},
+	}
+	ksyms.indexFunctions()
+
+	if !ksyms.IsFunction("foo") {
+		t.Errorf("expected foo to be a function")
+	}
+	if ksyms.IsFunction("bar") {
+		t.Errorf("expected bar not to be a function")
+	}
+
+	clones := ksyms.FunctionClones("bar")
+	if len(clones) != 2 || clones[0] != "bar.constprop.0" || clones[1] != "bar.isra.0" {
+		t.Errorf("unexpected clones: %v", clones)
+	}
+}
+
+func TestIsFunctionAvailable(t *testing.T) {
+	procfs := t.TempDir()
+	kallsyms := "ffffffff81000000 T _stext\n" +
+		"ffffffffc0a01000 t foo_init\t[foo]\n" +
+		"ffffffffc0a02000 d foo_data\t[foo]\n"
+	if err := os.WriteFile(filepath.Join(procfs, "kallsyms"), []byte(kallsyms), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		want bool
+	}{
+		{"_stext", true},
+		{"foo_init", true},
+		{"foo_data", false},
+		{"bar", false},
+	} {
+		got, err := IsFunctionAvailable(procfs, tc.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.want {
+			t.Errorf("IsFunctionAvailable(%q) = %t, want %t", tc.name, got, tc.want)
+		}
+	}
+}
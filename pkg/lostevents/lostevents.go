@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package lostevents reports the events lost because their transport was
+// full, for every CPU that lost events, so that the consumers of the events
+// know that their view is incomplete.
+package lostevents
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/observer"
+	"github.com/cilium/tetragon/pkg/process"
+	"github.com/cilium/tetragon/pkg/reader/node"
+	"github.com/cilium/tetragon/pkg/reader/notify"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// reportInterval is the interval at which lost events are reported.
+const reportInterval = time.Second
+
+// Reporter sends a LostEvent event for every CPU that lost events during a
+// report window.
+type Reporter struct {
+	obs       *observer.Observer
+	transport string
+	// lost events at the start of the current report window
+	prevLost  map[int]uint64
+	prevStart time.Time
+}
+
+// NewReporter returns a reporter for the events lost by obs, read from the
+// transport of the events.
+func NewReporter(obs *observer.Observer, transport string) *Reporter {
+	return &Reporter{
+		obs:       obs,
+		transport: transport,
+	}
+}
+
+// Run reports the lost events until ctx is done.
+func (r *Reporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(reportInterval)
+	defer ticker.Stop()
+
+	r.prevLost = r.obs.ReadLostEventsPerCPU()
+	r.prevStart = time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			r.report(now)
+		}
+	}
+}
+
+func (r *Reporter) report(now time.Time) {
+	lost := r.obs.ReadLostEventsPerCPU()
+	events := lostEvents(r.prevLost, lost, r.prevStart, now, r.transport)
+	r.prevLost, r.prevStart = lost, now
+	for _, ev := range events {
+		observer.AllListeners(&MsgLostEvent{Lost: ev})
+	}
+}
+
+// lostEvents returns the events of the CPUs that lost events between the prev
+// and cur counts, in increasing order of CPUs.
+func lostEvents(prev, cur map[int]uint64, start, end time.Time, transport string) []*tetragon.LostEvent {
+	var events []*tetragon.LostEvent
+	for cpu, c := range cur {
+		if c <= prev[cpu] {
+			continue
+		}
+		events = append(events, &tetragon.LostEvent{
+			Count:       c - prev[cpu],
+			Cpu:         uint32(cpu),
+			WindowStart: timestamppb.New(start),
+			WindowEnd:   timestamppb.New(end),
+			Transport:   transport,
+		})
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Cpu < events[j].Cpu
+	})
+	return events
+}
+
+// MsgLostEvent is the message of a LostEvent event.
+type MsgLostEvent struct {
+	Lost *tetragon.LostEvent
+}
+
+func (msg *MsgLostEvent) Notify() bool {
+	return false
+}
+
+func (msg *MsgLostEvent) RetryInternal(_ notify.Event, _ uint64) (*process.ProcessInternal, error) {
+	return nil, fmt.Errorf("Unsupported cache event MsgLostEvent")
+}
+
+func (msg *MsgLostEvent) Retry(_ *process.ProcessInternal, _ notify.Event) error {
+	return fmt.Errorf("Unsupported cache retry event MsgLostEvent")
+}
+
+func (msg *MsgLostEvent) HandleMessage() *tetragon.GetEventsResponse {
+	return &tetragon.GetEventsResponse{
+		Event:    &tetragon.GetEventsResponse_LostEvent{LostEvent: msg.Lost},
+		NodeName: node.GetNodeNameForExport(),
+		Time:     msg.Lost.WindowEnd,
+	}
+}
+
+func (msg *MsgLostEvent) Cast(_ interface{}) notify.Message {
+	return &MsgLostEvent{}
+}
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package lostevents
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestLostEvents(t *testing.T) {
+	start := time.Unix(1000, 0)
+	end := start.Add(reportInterval)
+	prev := map[int]uint64{0: 10, 1: 5}
+	cur := map[int]uint64{
+		// 3 new lost events
+		0: 13,
+		// no new lost events
+		1: 5,
+		// first lost events
+		3: 7,
+	}
+
+	assert.Equal(t, []*tetragon.LostEvent{
+		{Count: 3, Cpu: 0, WindowStart: timestamppb.New(start), WindowEnd: timestamppb.New(end), Transport: "perf"},
+		{Count: 7, Cpu: 3, WindowStart: timestamppb.New(start), WindowEnd: timestamppb.New(end), Transport: "perf"},
+	}, lostEvents(prev, cur, start, end, "perf"))
+
+	assert.Empty(t, lostEvents(cur, cur, start, end, "perf"))
+}
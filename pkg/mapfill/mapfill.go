@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package mapfill observes the fill levels of the BPF maps of tracing
+// policies. Some of these maps, once full, silently change the behavior of
+// their policies, e.g., file descriptors are no longer tracked by fdinstall_map
+// or stack traces are no longer stored, so a MapFill event is emitted when the
+// fill level of a map crosses a threshold.
+package mapfill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/metrics/mapmetrics"
+	"github.com/cilium/tetragon/pkg/observer"
+	"github.com/cilium/tetragon/pkg/process"
+	"github.com/cilium/tetragon/pkg/reader/node"
+	"github.com/cilium/tetragon/pkg/reader/notify"
+	"github.com/cilium/tetragon/pkg/sensors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxResizeEntries is the maximum number of entries to which a map is
+// automatically resized.
+const maxResizeEntries = 1 << 20
+
+// policyMaps is implemented by the sensor manager.
+type policyMaps interface {
+	PolicyMaps(ctx context.Context) ([]sensors.PolicyMap, error)
+	ResizePolicyMap(ctx context.Context, policy, pinName string, maxEntries uint32) error
+}
+
+type mapKey struct {
+	policy  string
+	pinName string
+}
+
+// Monitor checks the fill levels of the maps of the tracing policies.
+type Monitor struct {
+	maps       policyMaps
+	interval   time.Duration
+	threshold  uint64
+	autoResize bool
+	// maps whose fill levels are above the threshold
+	above map[mapKey]struct{}
+}
+
+// NewMonitor returns a monitor that checks the maps of the tracing policies
+// every interval, and reports the maps whose number of entries is above
+// threshold percent of their maximum entries. If autoResize is set, these
+// maps are resized to twice their maximum entries the next time their
+// policies are enabled.
+func NewMonitor(maps policyMaps, interval time.Duration, threshold int, autoResize bool) *Monitor {
+	if threshold <= 0 || threshold > 100 {
+		threshold = 100
+	}
+	return &Monitor{
+		maps:       maps,
+		interval:   interval,
+		threshold:  uint64(threshold),
+		autoResize: autoResize,
+		above:      make(map[mapKey]struct{}),
+	}
+}
+
+// Run checks the maps until ctx is done.
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.check(ctx)
+		}
+	}
+}
+
+func (m *Monitor) check(ctx context.Context) {
+	pms, err := m.maps.PolicyMaps(ctx)
+	if err != nil {
+		logger.GetLogger().WithError(err).Warn("Failed to get the maps of tracing policies")
+		return
+	}
+
+	mapmetrics.PolicyMapFill.Reset()
+	seen := make(map[mapKey]struct{}, len(pms))
+	for _, pm := range pms {
+		entries, err := countEntries(pm.Map)
+		maxEntries := pm.Map.MaxEntries()
+		pm.Map.Close()
+		if err != nil {
+			logger.GetLogger().WithError(err).WithField("map", pm.PinName).Debug("Failed to count map entries")
+			continue
+		}
+
+		seen[mapKey{pm.Policy, pm.PinName}] = struct{}{}
+		if maxEntries > 0 {
+			mapmetrics.PolicyMapFill.WithLabelValues(pm.Policy, pm.Name).Set(float64(entries) / float64(maxEntries))
+		}
+		if ev := m.update(ctx, pm, entries, maxEntries); ev != nil {
+			observer.AllListeners(&MsgMapFill{MapFill: ev})
+		}
+	}
+
+	// forget the maps of the policies that were disabled or deleted
+	for k := range m.above {
+		if _, ok := seen[k]; !ok {
+			delete(m.above, k)
+		}
+	}
+}
+
+// update returns the event to report for the number of entries of a map, or
+// nil if its fill level did not cross the threshold since the last check.
+func (m *Monitor) update(ctx context.Context, pm sensors.PolicyMap, entries, maxEntries uint32) *tetragon.MapFill {
+	k := mapKey{pm.Policy, pm.PinName}
+	_, wasAbove := m.above[k]
+	isAbove := uint64(entries)*100 >= uint64(maxEntries)*m.threshold
+	if isAbove == wasAbove {
+		return nil
+	}
+
+	ev := &tetragon.MapFill{
+		Policy:         pm.Policy,
+		Map:            pm.Name,
+		Pin:            pm.PinName,
+		Entries:        entries,
+		MaxEntries:     maxEntries,
+		AboveThreshold: isAbove,
+	}
+	log := logger.GetLogger().WithFields(logrus.Fields{
+		"policy":     pm.Policy,
+		"map":        pm.PinName,
+		"entries":    entries,
+		"maxEntries": maxEntries,
+	})
+	if !isAbove {
+		delete(m.above, k)
+		log.Info("BPF map of tracing policy is back below the fill threshold")
+		return ev
+	}
+
+	m.above[k] = struct{}{}
+	log.Warn("BPF map of tracing policy is above the fill threshold, the policy may stop matching once it is full")
+	if next := nextMaxEntries(maxEntries); m.autoResize && next != 0 {
+		if err := m.maps.ResizePolicyMap(ctx, pm.Policy, pm.PinName, next); err != nil {
+			log.WithError(err).Warn("Failed to resize BPF map of tracing policy")
+		} else {
+			log.WithField("nextMaxEntries", next).Info("BPF map of tracing policy will be resized the next time the policy is enabled")
+			ev.NextMaxEntries = next
+		}
+	}
+	return ev
+}
+
+// nextMaxEntries returns the maximum entries to which a map with maxEntries
+// is resized, or 0 if it cannot be.
+func nextMaxEntries(maxEntries uint32) uint32 {
+	if maxEntries == 0 || maxEntries >= maxResizeEntries {
+		return 0
+	}
+	return min(2*maxEntries, maxResizeEntries)
+}
+
+// countEntries returns the number of entries of a map, by iterating over its
+// keys. The count is capped to the maximum entries of the map, since the
+// iteration restarts from the first key if the current one gets deleted.
+func countEntries(m *ebpf.Map) (uint32, error) {
+	maxEntries := m.MaxEntries()
+	key := make([]byte, m.KeySize())
+	next := make([]byte, m.KeySize())
+
+	var count uint32
+	var prev interface{}
+	for count < maxEntries {
+		err := m.NextKey(prev, next)
+		if errors.Is(err, ebpf.ErrKeyNotExist) {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		count++
+		copy(key, next)
+		prev = key
+	}
+	return count, nil
+}
+
+// MsgMapFill is the message of a MapFill event.
+type MsgMapFill struct {
+	MapFill *tetragon.MapFill
+}
+
+func (msg *MsgMapFill) Notify() bool {
+	return false
+}
+
+func (msg *MsgMapFill) RetryInternal(_ notify.Event, _ uint64) (*process.ProcessInternal, error) {
+	return nil, fmt.Errorf("Unsupported cache event MsgMapFill")
+}
+
+func (msg *MsgMapFill) Retry(_ *process.ProcessInternal, _ notify.Event) error {
+	return fmt.Errorf("Unsupported cache retry event MsgMapFill")
+}
+
+func (msg *MsgMapFill) HandleMessage() *tetragon.GetEventsResponse {
+	return &tetragon.GetEventsResponse{
+		Event:    &tetragon.GetEventsResponse_MapFill{MapFill: msg.MapFill},
+		NodeName: node.GetNodeNameForExport(),
+		Time:     timestamppb.Now(),
+	}
+}
+
+func (msg *MsgMapFill) Cast(_ interface{}) notify.Message {
+	return &MsgMapFill{}
+}
@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package mapfill
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cilium/tetragon/pkg/sensors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type dummyPolicyMaps struct {
+	resized map[string]uint32
+}
+
+func (d *dummyPolicyMaps) PolicyMaps(_ context.Context) ([]sensors.PolicyMap, error) {
+	return nil, nil
+}
+
+func (d *dummyPolicyMaps) ResizePolicyMap(_ context.Context, policy, pinName string, maxEntries uint32) error {
+	d.resized[policy+"/"+pinName] = maxEntries
+	return nil
+}
+
+func TestUpdate(t *testing.T) {
+	ctx := context.Background()
+	maps := &dummyPolicyMaps{resized: make(map[string]uint32)}
+	m := NewMonitor(maps, 0, 90, true)
+	pm := sensors.PolicyMap{Policy: "pol", Name: "fdinstall_map", PinName: "gkp-sensor-1-fdinstall_map"}
+
+	// below the threshold
+	assert.Nil(t, m.update(ctx, pm, 10, 100))
+
+	// crosses the threshold
+	ev := m.update(ctx, pm, 90, 100)
+	require.NotNil(t, ev)
+	assert.True(t, ev.AboveThreshold)
+	assert.Equal(t, "pol", ev.Policy)
+	assert.Equal(t, "fdinstall_map", ev.Map)
+	assert.Equal(t, uint32(90), ev.Entries)
+	assert.Equal(t, uint32(200), ev.NextMaxEntries)
+	assert.Equal(t, map[string]uint32{"pol/gkp-sensor-1-fdinstall_map": 200}, maps.resized)
+
+	// still above the threshold, reported once
+	assert.Nil(t, m.update(ctx, pm, 100, 100))
+
+	// back below the threshold
+	ev = m.update(ctx, pm, 50, 100)
+	require.NotNil(t, ev)
+	assert.False(t, ev.AboveThreshold)
+	assert.Zero(t, ev.NextMaxEntries)
+
+	// the maps of other policies are tracked independently
+	other := sensors.PolicyMap{Policy: "other", Name: "fdinstall_map", PinName: "gkp-sensor-2-fdinstall_map"}
+	assert.NotNil(t, m.update(ctx, other, 95, 100))
+	assert.Nil(t, m.update(ctx, pm, 50, 100))
+}
+
+func TestUpdateNoResize(t *testing.T) {
+	ctx := context.Background()
+	maps := &dummyPolicyMaps{resized: make(map[string]uint32)}
+	m := NewMonitor(maps, 0, 90, false)
+	pm := sensors.PolicyMap{Policy: "pol", Name: "stack_trace_map", PinName: "gkp-sensor-1-stack_trace_map"}
+
+	ev := m.update(ctx, pm, 100, 100)
+	require.NotNil(t, ev)
+	assert.Zero(t, ev.NextMaxEntries)
+	assert.Empty(t, maps.resized)
+}
+
+func TestNextMaxEntries(t *testing.T) {
+	assert.Equal(t, uint32(0), nextMaxEntries(0))
+	assert.Equal(t, uint32(2), nextMaxEntries(1))
+	assert.Equal(t, uint32(65536), nextMaxEntries(32768))
+	assert.Equal(t, uint32(maxResizeEntries), nextMaxEntries(maxResizeEntries-1))
+	assert.Equal(t, uint32(0), nextMaxEntries(maxResizeEntries))
+}
@@ -0,0 +1,359 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package memlimit watches the memory usage of the agent against the limit of
+// its memory cgroup, and shrinks the process cache and the events queues as
+// the usage approaches the limit, so that the agent is not OOM-killed.
+package memlimit
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/health"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/metrics/memlimitmetrics"
+	"github.com/cilium/tetragon/pkg/process"
+	"github.com/cilium/tetragon/pkg/server"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// checkInterval is the interval at which the memory usage is checked.
+	checkInterval = 5 * time.Second
+	// freeOSMemoryInterval is the minimum interval between two forced
+	// garbage collections, which stop the world for a while.
+	freeOSMemoryInterval = time.Minute
+	// hysteresis is the number of percents the usage must fall under the
+	// threshold of a level to leave it.
+	hysteresis = 5
+
+	// cgroup v1 reports a page aligned math.MaxInt64 when there is no limit
+	unlimitedV1 = math.MaxInt64 &^ 4095
+)
+
+// Level is the level at which the agent is throttled.
+type Level int
+
+const (
+	// LevelNormal is used while the usage is under the threshold.
+	LevelNormal Level = iota
+	// LevelThrottled is used once the usage reaches the threshold.
+	LevelThrottled
+	// LevelCritical is used once the usage reaches half way between the
+	// threshold and the limit.
+	LevelCritical
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelNormal:
+		return "normal"
+	case LevelThrottled:
+		return "throttled"
+	case LevelCritical:
+		return "critical"
+	}
+	return fmt.Sprintf("unknown(%d)", int(l))
+}
+
+// shares are the percentages of the process cache size and of the events
+// queues capacity used at each level. Only exited processes are removed to
+// fit the process cache share, running processes are never evicted.
+var shares = map[Level]struct {
+	processCache int
+	eventQueue   int
+}{
+	LevelNormal:    {processCache: 100, eventQueue: 100},
+	LevelThrottled: {processCache: 50, eventQueue: 50},
+	LevelCritical:  {processCache: 25, eventQueue: 10},
+}
+
+// cgroupMemory are the files of the memory cgroup of the agent.
+type cgroupMemory struct {
+	limitFile string
+	usageFile string
+	statFile  string
+	// inactiveFileKey is the key of the inactive file cache in statFile
+	inactiveFileKey string
+}
+
+// findCgroupMemory returns the memory cgroup files of the process from the
+// given cgroup file, under the cgroup filesystem mounted at cgroupRoot.
+func findCgroupMemory(procCgroup, cgroupRoot string) (*cgroupMemory, error) {
+	f, err := os.Open(procCgroup)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// hierarchy-ID:controller-list:cgroup-path
+		fields := strings.SplitN(scanner.Text(), ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		if fields[0] == "0" && fields[1] == "" {
+			dir := cgroupDir(cgroupRoot, fields[2], "memory.max")
+			if dir == "" {
+				continue
+			}
+			return &cgroupMemory{
+				limitFile:       filepath.Join(dir, "memory.max"),
+				usageFile:       filepath.Join(dir, "memory.current"),
+				statFile:        filepath.Join(dir, "memory.stat"),
+				inactiveFileKey: "inactive_file",
+			}, nil
+		}
+		for _, c := range strings.Split(fields[1], ",") {
+			if c != "memory" {
+				continue
+			}
+			dir := cgroupDir(filepath.Join(cgroupRoot, "memory"), fields[2], "memory.limit_in_bytes")
+			if dir == "" {
+				break
+			}
+			return &cgroupMemory{
+				limitFile:       filepath.Join(dir, "memory.limit_in_bytes"),
+				usageFile:       filepath.Join(dir, "memory.usage_in_bytes"),
+				statFile:        filepath.Join(dir, "memory.stat"),
+				inactiveFileKey: "total_inactive_file",
+			}, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, errors.New("memory cgroup not found")
+}
+
+// cgroupDir returns the directory of the cgroup path under root, or root
+// itself if the cgroup is not visible, e.g. when the agent runs in its own
+// cgroup namespace without it, as long as it contains file.
+func cgroupDir(root, path, file string) string {
+	for _, dir := range []string{filepath.Join(root, path), root} {
+		if _, err := os.Stat(filepath.Join(dir, file)); err == nil {
+			return dir
+		}
+	}
+	return ""
+}
+
+// limit returns the memory limit of the cgroup, or 0 if there is none.
+func (c *cgroupMemory) limit() (uint64, error) {
+	data, err := os.ReadFile(c.limitFile)
+	if err != nil {
+		return 0, err
+	}
+	s := strings.TrimSpace(string(data))
+	if s == "max" {
+		return 0, nil
+	}
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", c.limitFile, err)
+	}
+	if v >= unlimitedV1 {
+		return 0, nil
+	}
+	return v, nil
+}
+
+// workingSet returns the memory usage of the cgroup without the inactive
+// file cache, which the kernel reclaims before invoking the OOM killer.
+func (c *cgroupMemory) workingSet() (uint64, error) {
+	data, err := os.ReadFile(c.usageFile)
+	if err != nil {
+		return 0, err
+	}
+	usage, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", c.usageFile, err)
+	}
+
+	stat, err := os.ReadFile(c.statFile)
+	if err != nil {
+		return usage, nil
+	}
+	for _, line := range strings.Split(string(stat), "\n") {
+		key, value, ok := strings.Cut(line, " ")
+		if !ok || key != c.inactiveFileKey {
+			continue
+		}
+		inactive, err := strconv.ParseUint(value, 10, 64)
+		if err == nil && inactive < usage {
+			usage -= inactive
+		}
+		break
+	}
+	return usage, nil
+}
+
+// nextLevel returns the level to use for the given usage, in percents of the
+// limit, when the current level is cur.
+func nextLevel(cur Level, percent, threshold int) Level {
+	critical := threshold + (100-threshold)/2
+	var next Level
+	switch {
+	case percent >= critical:
+		next = LevelCritical
+	case percent >= threshold:
+		next = LevelThrottled
+	default:
+		next = LevelNormal
+	}
+	if next >= cur {
+		return next
+	}
+	// leave the current level only once the usage fell sufficiently
+	// under its threshold, so that we do not flap around it
+	switch {
+	case cur == LevelCritical && percent >= critical-hysteresis:
+		return LevelCritical
+	case cur >= LevelThrottled && percent >= threshold-hysteresis:
+		return LevelThrottled
+	}
+	return next
+}
+
+// Monitor checks the memory usage of the agent and throttles it.
+type Monitor struct {
+	mem       *cgroupMemory
+	limit     uint64
+	threshold int
+	level     Level
+	// lastFreeOSMemory is the time of the last forced garbage collection
+	lastFreeOSMemory time.Time
+}
+
+// NewMonitor returns a monitor that throttles the agent once its memory usage
+// reaches threshold percents of the limit of its memory cgroup. It returns
+// nil if the memory cgroup of the agent has no limit.
+func NewMonitor(threshold int) (*Monitor, error) {
+	if threshold <= 0 || threshold >= 100 {
+		return nil, fmt.Errorf("memory throttle threshold %d must be between 1 and 99", threshold)
+	}
+	mem, err := findCgroupMemory("/proc/self/cgroup", "/sys/fs/cgroup")
+	if err != nil {
+		return nil, err
+	}
+	limit, err := mem.limit()
+	if err != nil {
+		return nil, err
+	}
+	if limit == 0 {
+		return nil, nil
+	}
+	memlimitmetrics.MemoryLimit.Set(float64(limit))
+	return &Monitor{
+		mem:       mem,
+		limit:     limit,
+		threshold: threshold,
+	}, nil
+}
+
+// Run checks the memory usage until ctx is done.
+func (m *Monitor) Run(ctx context.Context) {
+	logger.GetLogger().WithFields(logrus.Fields{
+		"limit":     m.limit,
+		"threshold": m.threshold,
+	}).Info("Memory cgroup limit detected, throttling enabled")
+	m.setHealth(0)
+
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.check()
+		}
+	}
+}
+
+func (m *Monitor) check() {
+	usage, err := m.mem.workingSet()
+	if err != nil {
+		logger.GetLogger().WithError(err).Warn("Failed to read memory cgroup usage")
+		return
+	}
+	memlimitmetrics.MemoryUsage.Set(float64(usage))
+
+	percent := int(usage * 100 / m.limit)
+	next := nextLevel(m.level, percent, m.threshold)
+	if next == m.level {
+		if next != LevelNormal {
+			// processes keep exiting while throttled
+			m.shrinkProcessCache()
+			m.freeOSMemory()
+		}
+		m.setHealth(usage)
+		return
+	}
+
+	log := logger.GetLogger().WithFields(logrus.Fields{
+		"usage":          usage,
+		"limit":          m.limit,
+		"previous-level": m.level,
+		"level":          next,
+	})
+	if next > m.level {
+		log.Warn("Memory usage is approaching the memory cgroup limit, shrinking caches and queues")
+	} else {
+		log.Info("Memory usage decreased, growing caches and queues back")
+	}
+	m.level = next
+	m.apply()
+	memlimitmetrics.ThrottleLevel.Set(float64(m.level))
+	memlimitmetrics.ThrottleTransitions.Inc()
+	m.setHealth(usage)
+}
+
+// apply resizes the caches and queues for the current level.
+func (m *Monitor) apply() {
+	server.SetEventQueueLimit(shares[m.level].eventQueue)
+	if m.level != LevelNormal {
+		m.shrinkProcessCache()
+		m.freeOSMemory()
+	}
+}
+
+// shrinkProcessCache removes exited processes from the process cache down to
+// its share of the current level.
+func (m *Monitor) shrinkProcessCache() {
+	removed := process.ShrinkCache(process.CacheSize() * shares[m.level].processCache / 100)
+	if removed > 0 {
+		logger.GetLogger().WithField("removed", removed).Info("Removed exited processes from the process cache")
+	}
+}
+
+// freeOSMemory gives back to the OS what the shrinking freed, at most once
+// per freeOSMemoryInterval.
+func (m *Monitor) freeOSMemory() {
+	if time.Since(m.lastFreeOSMemory) < freeOSMemoryInterval {
+		return
+	}
+	m.lastFreeOSMemory = time.Now()
+	debug.FreeOSMemory()
+}
+
+func (m *Monitor) setHealth(usage uint64) {
+	status := tetragon.HealthStatusResult_HEALTH_STATUS_RUNNING
+	if m.level != LevelNormal {
+		status = tetragon.HealthStatusResult_HEALTH_STATUS_THROTTLED
+	}
+	health.SetStatus(tetragon.HealthStatusType_HEALTH_STATUS_TYPE_MEMORY, status,
+		fmt.Sprintf("%s: using %d of %d bytes", m.level, usage, m.limit))
+}
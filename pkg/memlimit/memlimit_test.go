@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package memlimit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestCgroupMemoryV2(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"cgroup":                                   "0::/kubepods/pod1/tetragon\n",
+		"fs/kubepods/pod1/tetragon/memory.max":     "1048576\n",
+		"fs/kubepods/pod1/tetragon/memory.current": "524288\n",
+		"fs/kubepods/pod1/tetragon/memory.stat":    "anon 400000\ninactive_file 100000\nactive_file 24288\n",
+	})
+
+	mem, err := findCgroupMemory(filepath.Join(dir, "cgroup"), filepath.Join(dir, "fs"))
+	require.NoError(t, err)
+	limit, err := mem.limit()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1048576), limit)
+	usage, err := mem.workingSet()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(424288), usage)
+
+	writeFiles(t, dir, map[string]string{
+		"fs/kubepods/pod1/tetragon/memory.max": "max\n",
+	})
+	limit, err = mem.limit()
+	require.NoError(t, err)
+	assert.Zero(t, limit)
+}
+
+func TestCgroupMemoryV1(t *testing.T) {
+	dir := t.TempDir()
+	// the cgroup of the agent is not visible from its cgroup namespace
+	writeFiles(t, dir, map[string]string{
+		"cgroup":                          "12:pids:/docker/abc\n11:cpu,cpuacct:/docker/abc\n4:memory:/docker/abc\n",
+		"fs/memory/memory.limit_in_bytes": "9223372036854771712\n",
+		"fs/memory/memory.usage_in_bytes": "2048\n",
+	})
+
+	mem, err := findCgroupMemory(filepath.Join(dir, "cgroup"), filepath.Join(dir, "fs"))
+	require.NoError(t, err)
+	limit, err := mem.limit()
+	require.NoError(t, err)
+	assert.Zero(t, limit)
+	usage, err := mem.workingSet()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2048), usage)
+
+	_, err = findCgroupMemory(filepath.Join(dir, "cgroup"), filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
+
+func TestNextLevel(t *testing.T) {
+	// threshold 80, critical 90, hysteresis 5
+	tests := []struct {
+		cur     Level
+		percent int
+		next    Level
+	}{
+		{LevelNormal, 50, LevelNormal},
+		{LevelNormal, 80, LevelThrottled},
+		{LevelNormal, 95, LevelCritical},
+		{LevelThrottled, 92, LevelCritical},
+		{LevelThrottled, 77, LevelThrottled},
+		{LevelThrottled, 74, LevelNormal},
+		{LevelCritical, 86, LevelCritical},
+		{LevelCritical, 84, LevelThrottled},
+		{LevelCritical, 60, LevelNormal},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.next, nextLevel(tc.cur, tc.percent, 80), "level %s at %d%%", tc.cur, tc.percent)
+	}
+}
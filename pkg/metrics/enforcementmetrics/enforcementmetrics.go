@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package enforcementmetrics
+
+import (
+	"time"
+
+	"github.com/cilium/tetragon/pkg/metrics/consts"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	Latency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace:   consts.MetricsNamespace,
+		Name:        "enforcement_latency_seconds",
+		Help:        "The time between the hit of a hook and the completion of its enforcement action (sigkill, signal or override), measured in the kernel.",
+		Buckets:     []float64{0.000001, 0.000005, 0.00001, 0.00005, 0.0001, 0.0005, 0.001, 0.01}, // 1us, 5us, 10us, 50us, 100us, 500us, 1ms, 10ms
+		ConstLabels: nil,
+	}, []string{"policy", "action"})
+	AuditActions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   consts.MetricsNamespace,
+		Name:        "enforcement_audit_actions_total",
+		Help:        "The number of enforcement actions that policies in audit mode did not take.",
+		ConstLabels: nil,
+	}, []string{"policy", "action"})
+)
+
+func InitMetrics(registry *prometheus.Registry) {
+	registry.MustRegister(Latency)
+	registry.MustRegister(AuditActions)
+}
+
+// Observe records the latency of an enforcement action of a policy.
+func Observe(policy, action string, latency time.Duration) {
+	Latency.WithLabelValues(policy, action).Observe(latency.Seconds())
+}
+
+// ObserveAudit records an enforcement action that a policy in audit mode did
+// not take.
+func ObserveAudit(policy, action string) {
+	AuditActions.WithLabelValues(policy, action).Inc()
+}
@@ -35,6 +35,8 @@ var (
 	HandlerError ErrorType = "handler_error"
 	// An event finalizer on Process failed
 	EventFinalizeProcessInfoFailed ErrorType = "event_finalize_process_info_failed"
+	// The evaluation of a CEL expression of an event filter failed.
+	FilterCELEvalFailed ErrorType = "filter_cel_eval_failed"
 )
 
 var (
@@ -38,6 +38,12 @@ var (
 		Help:        "The total number of events dropped because listener buffer was full",
 		ConstLabels: nil,
 	})
+	ListenerDroppedEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   consts.MetricsNamespace,
+		Name:        "listener_dropped_events_total",
+		Help:        "The total number of events dropped because the buffer of a gRPC client or exporter was full, by listener",
+		ConstLabels: nil,
+	}, []string{"listener"})
 
 	policyStats = metrics.MustNewGranularCounter(prometheus.CounterOpts{
 		Namespace:   consts.MetricsNamespace,
@@ -51,6 +57,7 @@ func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(EventsProcessed.ToProm())
 	registry.MustRegister(FlagCount)
 	registry.MustRegister(NotifyOverflowedEvents)
+	registry.MustRegister(ListenerDroppedEvents)
 	registry.MustRegister(policyStats.ToProm())
 }
 
@@ -95,6 +102,7 @@ func handleProcessedEvent(pInfo *tracingpolicy.PolicyInfo, processedEvent interf
 	}
 	EventsProcessed.WithLabelValues(eventType, namespace, workload, pod, binary).Inc()
 	if pInfo != nil && pInfo.Name != "" {
+		policyEventsInc(pInfo.Name)
 		policyStats.
 			WithLabelValues(pInfo.Name, pInfo.Hook, namespace, workload, pod, binary).
 			Inc()
@@ -10,6 +10,7 @@ import (
 	"github.com/cilium/tetragon/api/v1/tetragon"
 	"github.com/cilium/tetragon/pkg/api"
 	"github.com/cilium/tetragon/pkg/api/processapi"
+	"github.com/cilium/tetragon/pkg/tracingpolicy"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 )
@@ -98,3 +99,16 @@ tetragon_flags_total{type="execve"} 1
 `)
 	assert.NoError(t, testutil.CollectAndCompare(FlagCount, expected))
 }
+
+func TestPolicyEvents(t *testing.T) {
+	ev := &tetragon.GetEventsResponse{Event: &tetragon.GetEventsResponse_ProcessKprobe{ProcessKprobe: &tetragon.ProcessKprobe{}}}
+	handleProcessedEvent(&tracingpolicy.PolicyInfo{Name: "policy-a", Hook: "kprobe:fd_install"}, ev)
+	handleProcessedEvent(&tracingpolicy.PolicyInfo{Name: "policy-a", Hook: "kprobe:fd_install"}, ev)
+	handleProcessedEvent(&tracingpolicy.PolicyInfo{Name: "policy-b", Hook: "kprobe:fd_install"}, ev)
+	assert.Equal(t, uint64(2), PolicyEvents("policy-a"))
+	assert.Equal(t, uint64(1), PolicyEvents("policy-b"))
+	assert.Equal(t, uint64(0), PolicyEvents("policy-c"))
+
+	ResetPolicyEvents("policy-a")
+	assert.Equal(t, uint64(0), PolicyEvents("policy-a"))
+}
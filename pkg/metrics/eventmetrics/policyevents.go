@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package eventmetrics
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// policyEvents counts the events of the tracing policies by policy name, for
+// the status of the policies. Unlike the policy_events_total metric, these
+// counters do not depend on the metrics configuration, and are reset when a
+// policy is added.
+var policyEvents sync.Map // string -> *atomic.Uint64
+
+func policyEventsInc(name string) {
+	v, ok := policyEvents.Load(name)
+	if !ok {
+		v, _ = policyEvents.LoadOrStore(name, new(atomic.Uint64))
+	}
+	v.(*atomic.Uint64).Add(1)
+}
+
+// PolicyEvents returns the number of events of a tracing policy.
+func PolicyEvents(name string) uint64 {
+	if v, ok := policyEvents.Load(name); ok {
+		return v.(*atomic.Uint64).Load()
+	}
+	return 0
+}
+
+// ResetPolicyEvents resets the number of events of a tracing policy.
+func ResetPolicyEvents(name string) {
+	policyEvents.Delete(name)
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package exportmetrics
+
+import (
+	"github.com/cilium/tetragon/pkg/metrics/consts"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	BytesWritten = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   consts.MetricsNamespace,
+		Name:        "export_file_bytes_written_total",
+		Help:        "The total number of bytes written to the JSON export files, by file.",
+		ConstLabels: nil,
+	}, []string{"file"})
+	OTLPLogRecords = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   consts.MetricsNamespace,
+		Name:        "export_otlp_log_records_total",
+		Help:        "The total number of events exported to the OTLP collector, by status: sent, rejected by the collector, or dropped because the queue was full or the export failed.",
+		ConstLabels: nil,
+	}, []string{"status"})
+	SyslogMessages = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   consts.MetricsNamespace,
+		Name:        "export_syslog_messages_total",
+		Help:        "The total number of events exported to the syslog server, by status: sent, or dropped because the server was not reachable.",
+		ConstLabels: nil,
+	}, []string{"status"})
+	FluentEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   consts.MetricsNamespace,
+		Name:        "export_fluent_events_total",
+		Help:        "The total number of events exported to the fluent server, by status: sent, or dropped because the queue was full or the export failed.",
+		ConstLabels: nil,
+	}, []string{"status"})
+	ForwardEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   consts.MetricsNamespace,
+		Name:        "export_forward_events_total",
+		Help:        "The total number of events forwarded to the host agent, by status: sent, or dropped because the queue was full or the host agent was not reachable.",
+		ConstLabels: nil,
+	}, []string{"status"})
+	ForwardReceivedEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   consts.MetricsNamespace,
+		Name:        "export_forward_received_events_total",
+		Help:        "The total number of events received from guest agents, by status: received, or pod_mismatch if processes of the event belong to containers of another pod than the one of the guest.",
+		ConstLabels: nil,
+	}, []string{"status"})
+	ForwardGuestConnections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   consts.MetricsNamespace,
+		Name:        "export_forward_guest_connections_total",
+		Help:        "The total number of connections of guest agents, by status: accepted, or rejected because the guest is unknown.",
+		ConstLabels: nil,
+	}, []string{"status"})
+)
+
+// Status labels of the OTLPLogRecords metric
+const (
+	OTLPSent     = "sent"
+	OTLPRejected = "rejected"
+	OTLPDropped  = "dropped"
+)
+
+// Status labels of the SyslogMessages metric
+const (
+	SyslogSent    = "sent"
+	SyslogDropped = "dropped"
+)
+
+// Status labels of the FluentEvents metric
+const (
+	FluentSent    = "sent"
+	FluentDropped = "dropped"
+)
+
+// Status labels of the ForwardEvents metric
+const (
+	ForwardSent    = "sent"
+	ForwardDropped = "dropped"
+)
+
+// Status labels of the ForwardReceivedEvents metric
+const (
+	ForwardReceived    = "received"
+	ForwardPodMismatch = "pod_mismatch"
+)
+
+// Status labels of the ForwardGuestConnections metric
+const (
+	ForwardAccepted = "accepted"
+	ForwardRejected = "rejected"
+)
+
+func InitMetrics(registry *prometheus.Registry) {
+	registry.MustRegister(BytesWritten)
+	registry.MustRegister(OTLPLogRecords)
+	registry.MustRegister(SyslogMessages)
+	registry.MustRegister(FluentEvents)
+	registry.MustRegister(ForwardEvents)
+	registry.MustRegister(ForwardReceivedEvents)
+	registry.MustRegister(ForwardGuestConnections)
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package kprobemetrics
+
+import (
+	"math"
+	"sync"
+
+	"github.com/cilium/tetragon/pkg/metrics/consts"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// latencyMinSlot and latencyMaxSlot bound the log2 slots exported as
+	// buckets, from 1µs (2^10ns) to 68s (2^36ns). Lower slots are counted in
+	// the first bucket, and higher ones only in the +Inf bucket.
+	latencyMinSlot = 9
+	latencyMaxSlot = 35
+)
+
+var (
+	LatencySeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(consts.MetricsNamespace, "", "generic_kprobe_latency_seconds"),
+		"The latency of the kprobe calls, from the entry to the return of the function. Only set for kprobes with latencyHistogram.",
+		[]string{"policy", "function"}, nil,
+	)
+
+	latencyMu     sync.Mutex
+	latencySource func() []LatencyHistogram
+)
+
+// LatencyHistogram is the in-kernel latency histogram of a kprobe.
+type LatencyHistogram struct {
+	Policy   string
+	Function string
+	// Counts[i] is the number of calls whose latency in nanoseconds has a
+	// log2 of i, i.e., is in [2^i, 2^(i+1)).
+	Counts []uint64
+	// Sum is the sum of the latencies in nanoseconds.
+	Sum uint64
+}
+
+// SetLatencySource sets the function that reads the latency histograms of the
+// loaded kprobes. It is set by the generic kprobe sensor.
+func SetLatencySource(fn func() []LatencyHistogram) {
+	latencyMu.Lock()
+	defer latencyMu.Unlock()
+	latencySource = fn
+}
+
+// bpfCollector implements prometheus.Collector. It collects the latency
+// histograms from BPF maps.
+type bpfCollector struct{}
+
+func NewBPFCollector() prometheus.Collector {
+	return &bpfCollector{}
+}
+
+func (c *bpfCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- LatencySeconds
+}
+
+func (c *bpfCollector) Collect(ch chan<- prometheus.Metric) {
+	latencyMu.Lock()
+	fn := latencySource
+	latencyMu.Unlock()
+	if fn == nil {
+		return
+	}
+
+	for _, h := range fn() {
+		count, buckets := latencyBuckets(h.Counts)
+		ch <- prometheus.MustNewConstHistogram(
+			LatencySeconds,
+			count, float64(h.Sum)/1e9, buckets,
+			h.Policy, h.Function,
+		)
+	}
+}
+
+// latencyBuckets returns the total count and the cumulative buckets, in
+// seconds, of the log2 slots of a latency histogram.
+func latencyBuckets(counts []uint64) (uint64, map[float64]uint64) {
+	buckets := make(map[float64]uint64, latencyMaxSlot-latencyMinSlot+1)
+	var count uint64
+	for slot, n := range counts {
+		count += n
+		if slot >= latencyMinSlot && slot <= latencyMaxSlot {
+			// upper bound of the slot
+			buckets[math.Ldexp(1, slot+1)/1e9] = count
+		}
+	}
+	// buckets of slots that are not in counts
+	for slot := max(len(counts), latencyMinSlot); slot <= latencyMaxSlot; slot++ {
+		buckets[math.Ldexp(1, slot+1)/1e9] = count
+	}
+	return count, buckets
+}
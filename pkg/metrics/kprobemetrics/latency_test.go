@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package kprobemetrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatencyBuckets(t *testing.T) {
+	counts := make([]uint64, 64)
+	// below 1µs
+	counts[3] = 1
+	counts[9] = 2
+	// [2^10, 2^11) ns
+	counts[10] = 3
+	// [2^20, 2^21) ns
+	counts[20] = 4
+	// above the highest bucket
+	counts[40] = 5
+
+	count, buckets := latencyBuckets(counts)
+	assert.Equal(t, uint64(15), count)
+	assert.Len(t, buckets, latencyMaxSlot-latencyMinSlot+1)
+	assert.Equal(t, uint64(3), buckets[1024e-9])
+	assert.Equal(t, uint64(6), buckets[2048e-9])
+	assert.Equal(t, uint64(6), buckets[1048576e-9])
+	assert.Equal(t, uint64(10), buckets[2097152e-9])
+	assert.Equal(t, uint64(10), buckets[68719476736e-9])
+
+	// missing slots are empty
+	count, buckets = latencyBuckets(counts[:11])
+	assert.Equal(t, uint64(6), count)
+	assert.Len(t, buckets, latencyMaxSlot-latencyMinSlot+1)
+	assert.Equal(t, uint64(6), buckets[68719476736e-9])
+}
@@ -16,6 +16,12 @@ var (
 		Help:        "The total number of entries dropped per LRU map.",
 		ConstLabels: nil,
 	}, []string{"map"})
+	PolicyMapFill = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace:   consts.MetricsNamespace,
+		Name:        "policy_map_fill_ratio",
+		Help:        "The ratio of in-use entries to maximum entries per BPF map of tracing policies.",
+		ConstLabels: nil,
+	}, []string{"policy", "map"})
 	MapSize = metrics.NewBPFGauge(prometheus.NewDesc(
 		prometheus.BuildFQName(consts.MetricsNamespace, "", "map_in_use_gauge"),
 		"The total number of in-use entries per map.",
@@ -30,6 +36,7 @@ var (
 
 func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(MapDrops)
+	registry.MustRegister(PolicyMapFill)
 	// custom collectors are registered independently
 }
 
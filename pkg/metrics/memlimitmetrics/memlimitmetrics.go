@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package memlimitmetrics
+
+import (
+	"github.com/cilium/tetragon/pkg/metrics/consts"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	MemoryLimit = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace:   consts.MetricsNamespace,
+		Name:        "memory_cgroup_limit_bytes",
+		Help:        "The memory limit of the memory cgroup of Tetragon.",
+		ConstLabels: nil,
+	})
+	MemoryUsage = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace:   consts.MetricsNamespace,
+		Name:        "memory_cgroup_working_set_bytes",
+		Help:        "The memory usage of the memory cgroup of Tetragon, without the inactive file cache.",
+		ConstLabels: nil,
+	})
+	ThrottleLevel = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace:   consts.MetricsNamespace,
+		Name:        "memory_throttle_level",
+		Help:        "The level at which Tetragon shrinks its caches and queues to stay under its memory limit. 0 means not throttled.",
+		ConstLabels: nil,
+	})
+	ThrottleTransitions = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace:   consts.MetricsNamespace,
+		Name:        "memory_throttle_transitions_total",
+		Help:        "The total number of changes of the memory throttle level.",
+		ConstLabels: nil,
+	})
+)
+
+func InitMetrics(registry *prometheus.Registry) {
+	registry.MustRegister(MemoryLimit)
+	registry.MustRegister(MemoryUsage)
+	registry.MustRegister(ThrottleLevel)
+	registry.MustRegister(ThrottleTransitions)
+}
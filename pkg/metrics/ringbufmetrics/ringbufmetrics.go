@@ -21,6 +21,12 @@ var (
 		Help:        "The total number of Tetragon ringbuf perf events lost.",
 		ConstLabels: nil,
 	})
+	LostEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   consts.MetricsNamespace,
+		Name:        "lost_events_total",
+		Help:        "The total number of events lost because their transport was full, by CPU.",
+		ConstLabels: nil,
+	}, []string{"cpu"})
 	PerfEventErrors = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace:   consts.MetricsNamespace,
 		Name:        "ringbuf_perf_event_errors_total",
@@ -32,5 +38,6 @@ var (
 func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(PerfEventReceived)
 	registry.MustRegister(PerfEventLost)
+	registry.MustRegister(LostEvents)
 	registry.MustRegister(PerfEventErrors)
 }
@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package selectormetrics
+
+import (
+	"github.com/cilium/tetragon/pkg/metrics/consts"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	RuntimeValues = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace:   consts.MetricsNamespace,
+		Name:        "policy_selector_runtime_values",
+		Help:        "The number of values added at runtime to the selectors of tracing policies.",
+		ConstLabels: nil,
+	}, []string{"policy"})
+	ExpiredValues = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   consts.MetricsNamespace,
+		Name:        "policy_selector_expired_values_total",
+		Help:        "The total number of values added at runtime to the selectors of tracing policies that were removed once their TTL expired.",
+		ConstLabels: nil,
+	}, []string{"policy"})
+)
+
+func InitMetrics(registry *prometheus.Registry) {
+	registry.MustRegister(RuntimeValues)
+	registry.MustRegister(ExpiredValues)
+}
@@ -0,0 +1,272 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package miningdetect
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/observer"
+	"github.com/cilium/tetragon/pkg/option"
+	"github.com/cilium/tetragon/pkg/process"
+	"github.com/cilium/tetragon/pkg/reader/node"
+	"github.com/cilium/tetragon/pkg/reader/notify"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// checkInterval is the interval at which the CPU usage of the suspects
+	// is sampled, and the suspects are reported.
+	checkInterval = 10 * time.Second
+	// cpuThreshold is the share of a CPU above which a process is busy.
+	cpuThreshold = 0.8
+	// cpuSamples is the number of consecutive busy samples after which the
+	// CPU usage of a process is a signal.
+	cpuSamples = 18
+	// maxSuspects bounds the number of processes with signals that are
+	// tracked.
+	maxSuspects = 4096
+)
+
+// signalConfidence is the confidence that a process is mining when only the
+// signal is observed.
+var signalConfidence = map[tetragon.MiningSignalType]float64{
+	tetragon.MiningSignalType_MINING_SIGNAL_POOL_PORT:         0.3,
+	tetragon.MiningSignalType_MINING_SIGNAL_STRATUM_URL:       0.6,
+	tetragon.MiningSignalType_MINING_SIGNAL_STRATUM_HANDSHAKE: 0.7,
+	tetragon.MiningSignalType_MINING_SIGNAL_MSR_ACCESS:        0.3,
+	tetragon.MiningSignalType_MINING_SIGNAL_HUGE_PAGES:        0.1,
+	tetragon.MiningSignalType_MINING_SIGNAL_SUSTAINED_CPU:     0.3,
+}
+
+// confidence combines the confidence of independent signals: the process is
+// not mining only if none of the signals is right.
+func confidence(signals []*tetragon.MiningSignal) float64 {
+	notMining := 1.0
+	for _, s := range signals {
+		notMining *= 1 - signalConfidence[s.Type]
+	}
+	return 1 - notMining
+}
+
+// suspect is a process with at least one signal.
+type suspect struct {
+	process *tetragon.Process
+	parent  *tetragon.Process
+	// signals, at most one per type
+	signals []*tetragon.MiningSignal
+	// number of signals when the process was last reported
+	reported int
+
+	// CPU time of the process at the last check, and the number of
+	// consecutive checks during which the process was busy
+	lastRuntime time.Duration
+	lastCheck   time.Time
+	busy        int
+}
+
+func (s *suspect) hasSignal(ty tetragon.MiningSignalType) bool {
+	for _, sig := range s.signals {
+		if sig.Type == ty {
+			return true
+		}
+	}
+	return false
+}
+
+// Detector combines the signals of processes, and reports the processes whose
+// confidence is above the threshold.
+type Detector struct {
+	threshold float64
+	now       func() time.Time
+	// runtime returns the CPU time of a process
+	runtime func(pid uint32) (time.Duration, error)
+	// push sends the events to the listeners of the observer
+	push func(msg notify.Message)
+
+	mu sync.Mutex
+	// suspects by exec ID
+	suspects map[string]*suspect
+}
+
+// NewDetector returns a detector that reports the processes whose confidence
+// is at least threshold.
+func NewDetector(threshold float64) *Detector {
+	return &Detector{
+		threshold: threshold,
+		now:       time.Now,
+		runtime:   schedRuntime,
+		push:      observer.AllListeners,
+		suspects:  make(map[string]*suspect),
+	}
+}
+
+// Notify collects the signals of the events. It implements server.Listener.
+func (d *Detector) Notify(res *tetragon.GetEventsResponse) {
+	switch ev := res.Event.(type) {
+	case *tetragon.GetEventsResponse_ProcessExec:
+		if sig := execSignal(ev.ProcessExec, res.GetTime()); sig != nil {
+			d.addSignal(ev.ProcessExec.GetProcess(), ev.ProcessExec.GetParent(), sig)
+		}
+	case *tetragon.GetEventsResponse_ProcessKprobe:
+		if sig := kprobeSignal(ev.ProcessKprobe, res.GetTime()); sig != nil {
+			d.addSignal(ev.ProcessKprobe.GetProcess(), ev.ProcessKprobe.GetParent(), sig)
+		}
+	case *tetragon.GetEventsResponse_ProcessExit:
+		d.mu.Lock()
+		delete(d.suspects, ev.ProcessExit.GetProcess().GetExecId())
+		d.mu.Unlock()
+	}
+}
+
+func (d *Detector) addSignal(proc, parent *tetragon.Process, sig *tetragon.MiningSignal) {
+	id := proc.GetExecId()
+	if id == "" {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	s, ok := d.suspects[id]
+	if !ok {
+		if len(d.suspects) >= maxSuspects {
+			return
+		}
+		s = &suspect{process: proc, parent: parent}
+		d.suspects[id] = s
+	}
+	if !s.hasSignal(sig.Type) {
+		s.signals = append(s.signals, sig)
+	}
+}
+
+// Run checks the suspects until ctx is done.
+func (d *Detector) Run(ctx context.Context) {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, ev := range d.check() {
+				d.push(&MsgMiningSuspected{MiningSuspected: ev})
+			}
+		}
+	}
+}
+
+// check samples the CPU usage of the suspects, and returns the events of the
+// suspects whose confidence is above the threshold and that have new signals
+// since they were last reported.
+func (d *Detector) check() []*tetragon.MiningSuspected {
+	now := d.now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var events []*tetragon.MiningSuspected
+	for id, s := range d.suspects {
+		if err := d.checkCPU(s, now); err != nil {
+			// the process is gone
+			delete(d.suspects, id)
+			continue
+		}
+		conf := confidence(s.signals)
+		if conf < d.threshold || len(s.signals) == s.reported {
+			continue
+		}
+		s.reported = len(s.signals)
+		events = append(events, &tetragon.MiningSuspected{
+			Process:    s.process,
+			Parent:     s.parent,
+			Confidence: conf,
+			Signals:    append([]*tetragon.MiningSignal(nil), s.signals...),
+		})
+		logger.GetLogger().WithField("process", s.process.GetBinary()).
+			WithField("pid", s.process.GetPid().GetValue()).
+			WithField("confidence", conf).
+			Info("Process suspected of crypto-mining")
+	}
+	return events
+}
+
+// checkCPU samples the CPU usage of a suspect, and adds the sustained CPU
+// signal after cpuSamples consecutive busy samples.
+func (d *Detector) checkCPU(s *suspect, now time.Time) error {
+	runtime, err := d.runtime(s.process.GetPid().GetValue())
+	if err != nil {
+		return err
+	}
+	if !s.lastCheck.IsZero() {
+		usage := float64(runtime-s.lastRuntime) / float64(now.Sub(s.lastCheck))
+		if usage >= cpuThreshold {
+			s.busy++
+		} else {
+			s.busy = 0
+		}
+		if s.busy >= cpuSamples && !s.hasSignal(tetragon.MiningSignalType_MINING_SIGNAL_SUSTAINED_CPU) {
+			detail := fmt.Sprintf("%.0f%% of a CPU for %s", usage*100, time.Duration(s.busy)*checkInterval)
+			s.signals = append(s.signals, newSignal(tetragon.MiningSignalType_MINING_SIGNAL_SUSTAINED_CPU, detail, timestamppb.New(now)))
+		}
+	}
+	s.lastRuntime = runtime
+	s.lastCheck = now
+	return nil
+}
+
+// schedRuntime returns the time that a process spent on a CPU, as reported
+// by the scheduler statistics of procfs.
+func schedRuntime(pid uint32) (time.Duration, error) {
+	data, err := os.ReadFile(filepath.Join(option.Config.ProcFS, fmt.Sprint(pid), "schedstat"))
+	if err != nil {
+		return 0, err
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("invalid schedstat of process %d", pid)
+	}
+	ns, err := strconv.ParseUint(fields[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid schedstat of process %d: %w", pid, err)
+	}
+	return time.Duration(ns), nil
+}
+
+// MsgMiningSuspected is the message of a MiningSuspected event.
+type MsgMiningSuspected struct {
+	MiningSuspected *tetragon.MiningSuspected
+}
+
+func (msg *MsgMiningSuspected) Notify() bool {
+	return false
+}
+
+func (msg *MsgMiningSuspected) RetryInternal(_ notify.Event, _ uint64) (*process.ProcessInternal, error) {
+	return nil, fmt.Errorf("Unsupported cache event MsgMiningSuspected")
+}
+
+func (msg *MsgMiningSuspected) Retry(_ *process.ProcessInternal, _ notify.Event) error {
+	return fmt.Errorf("Unsupported cache retry event MsgMiningSuspected")
+}
+
+func (msg *MsgMiningSuspected) HandleMessage() *tetragon.GetEventsResponse {
+	return &tetragon.GetEventsResponse{
+		Event:    &tetragon.GetEventsResponse_MiningSuspected{MiningSuspected: msg.MiningSuspected},
+		NodeName: node.GetNodeNameForExport(),
+		Time:     timestamppb.Now(),
+	}
+}
+
+func (msg *MsgMiningSuspected) Cast(_ interface{}) notify.Message {
+	return &MsgMiningSuspected{}
+}
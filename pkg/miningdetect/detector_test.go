@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package miningdetect
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestPolicy(t *testing.T) {
+	tp, err := Policy([]int{3333, 14444})
+	require.NoError(t, err)
+	assert.Equal(t, PolicyName, tp.TpName())
+	spec := tp.TpSpec()
+	require.Len(t, spec.KProbes, 4)
+	assert.Equal(t, []string{"3333", "14444"}, spec.KProbes[0].Selectors[0].MatchArgs[0].Values)
+	assert.Equal(t, "sys_write", spec.KProbes[1].Call)
+	assert.Equal(t, stratumRequests, spec.KProbes[1].Selectors[0].MatchArgs[0].Values)
+
+	_, err = Policy([]int{70000})
+	assert.Error(t, err)
+	_, err = Policy(nil)
+	assert.Error(t, err)
+}
+
+func kprobe(fn string, arg *tetragon.KprobeArgument) *tetragon.GetEventsResponse {
+	return &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessKprobe{
+			ProcessKprobe: &tetragon.ProcessKprobe{
+				Process:      &tetragon.Process{ExecId: "miner", Pid: wrapperspb.UInt32(42), Binary: "/tmp/xmrig"},
+				PolicyName:   PolicyName,
+				FunctionName: fn,
+				Args:         []*tetragon.KprobeArgument{arg},
+			},
+		},
+	}
+}
+
+func TestDetector(t *testing.T) {
+	now := time.Now()
+	runtime := time.Duration(0)
+	d := NewDetector(0.5)
+	d.now = func() time.Time { return now }
+	d.runtime = func(pid uint32) (time.Duration, error) {
+		if pid != 42 {
+			return 0, fmt.Errorf("no process %d", pid)
+		}
+		return runtime, nil
+	}
+	tick := func(busy bool) []*tetragon.MiningSuspected {
+		now = now.Add(checkInterval)
+		if busy {
+			runtime += checkInterval
+		}
+		return d.check()
+	}
+
+	// a connection to a pool port is not enough
+	d.Notify(kprobe("tcp_connect", &tetragon.KprobeArgument{
+		Arg: &tetragon.KprobeArgument_SockArg{SockArg: &tetragon.KprobeSock{Daddr: "10.0.0.1", Dport: 3333}},
+	}))
+	assert.Empty(t, tick(true))
+
+	// with sustained CPU usage, the process is reported
+	var events []*tetragon.MiningSuspected
+	for i := 0; i < cpuSamples && len(events) == 0; i++ {
+		events = tick(true)
+	}
+	require.Len(t, events, 1)
+	assert.InDelta(t, 0.51, events[0].Confidence, 0.001)
+	require.Len(t, events[0].Signals, 2)
+	assert.Equal(t, tetragon.MiningSignalType_MINING_SIGNAL_POOL_PORT, events[0].Signals[0].Type)
+	assert.Equal(t, "10.0.0.1:3333", events[0].Signals[0].Detail)
+	assert.Equal(t, tetragon.MiningSignalType_MINING_SIGNAL_SUSTAINED_CPU, events[0].Signals[1].Type)
+
+	// reported again only with new signals
+	assert.Empty(t, tick(true))
+	d.Notify(kprobe("__x64_sys_write", &tetragon.KprobeArgument{
+		Arg: &tetragon.KprobeArgument_BytesArg{BytesArg: []byte(`{"id":1,"jsonrpc":"2.0","method":"login","params":{}}`)},
+	}))
+	events = tick(true)
+	require.Len(t, events, 1)
+	require.Len(t, events[0].Signals, 3)
+	assert.Equal(t, tetragon.MiningSignalType_MINING_SIGNAL_STRATUM_HANDSHAKE, events[0].Signals[2].Type)
+	assert.Equal(t, "login", events[0].Signals[2].Detail)
+
+	// exited processes are forgotten
+	d.Notify(&tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessExit{
+			ProcessExit: &tetragon.ProcessExit{Process: &tetragon.Process{ExecId: "miner"}},
+		},
+	})
+	assert.Empty(t, d.suspects)
+}
+
+func TestExecSignal(t *testing.T) {
+	d := NewDetector(0.5)
+	d.runtime = func(_ uint32) (time.Duration, error) { return 0, nil }
+	d.Notify(&tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessExec{
+			ProcessExec: &tetragon.ProcessExec{
+				Process: &tetragon.Process{
+					ExecId:    "miner",
+					Pid:       wrapperspb.UInt32(42),
+					Arguments: "-o stratum+tcp://pool.example.com:3333 -u wallet",
+				},
+			},
+		},
+	})
+	events := d.check()
+	require.Len(t, events, 1)
+	assert.InDelta(t, 0.6, events[0].Confidence, 0.001)
+	assert.Equal(t, "stratum+tcp://pool.example.com:3333", events[0].Signals[0].Detail)
+
+	// events of other policies are ignored
+	ev := kprobe("tcp_connect", &tetragon.KprobeArgument{
+		Arg: &tetragon.KprobeArgument_SockArg{SockArg: &tetragon.KprobeSock{Dport: 3333}},
+	})
+	ev.GetProcessKprobe().PolicyName = "other"
+	assert.Nil(t, kprobeSignal(ev.GetProcessKprobe(), nil))
+}
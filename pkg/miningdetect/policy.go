@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package miningdetect provides the crypto-mining detector. The built-in
+// crypto-mining policy reports the connections to the ports of mining pools,
+// the stratum requests that miners send to log in to pools, and the accesses
+// to the CPU registers and huge pages settings that miners tune. The Detector
+// combines these signals with the stratum URLs of the arguments of processes
+// and their CPU usage, and reports the processes suspected of mining in
+// MiningSuspected events.
+package miningdetect
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/tracingpolicy"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// PolicyName is the name of the built-in crypto-mining policy.
+const PolicyName = "crypto-mining"
+
+// stratumRequests are the prefixes of the first requests that miners send to
+// pools: the login of the Monero pools and the subscribe of the stratum v1
+// pools, as sent by the common miners.
+var stratumRequests = []string{
+	`{"id":1,"jsonrpc":"2.0","method":"login"`,
+	`{"id":1,"method":"login"`,
+	`{"method":"login"`,
+	`{"id":1,"method":"mining.subscribe"`,
+	`{"id": 1, "method": "mining.subscribe"`,
+	`{"id":0,"method":"mining.subscribe"`,
+}
+
+// The signals of a process are only needed once, so the events are rate
+// limited per process.
+const sendHookTemplate = `
+  - call: "%s"
+    syscall: true
+    args:
+    - index: 0
+      type: "int"
+    - index: 1
+      type: "char_buf"
+      sizeArgIndex: 3
+      maxDataSize: 128
+    - index: 2
+      type: "size_t"
+    selectors:
+    - matchArgs:
+      - index: 1
+        operator: "Prefix"
+        values:%s
+      matchActions:
+      - action: Post
+        rateLimit: "1m"
+        rateLimitScope: "process"`
+
+const policyTemplate = `apiVersion: cilium.io/v1alpha1
+kind: TracingPolicy
+metadata:
+  name: "` + PolicyName + `"
+spec:
+  kprobes:
+  - call: "tcp_connect"
+    syscall: false
+    args:
+    - index: 0
+      type: "sock"
+    selectors:
+    - matchArgs:
+      - index: 0
+        operator: "DPort"
+        values:%s
+      matchActions:
+      - action: Post
+        rateLimit: "1m"
+        rateLimitScope: "process"%s%s
+  - call: "security_file_open"
+    syscall: false
+    args:
+    - index: 0
+      type: "file"
+    selectors:
+    - matchArgs:
+      - index: 0
+        operator: "Prefix"
+        values:
+        - "/dev/cpu/"
+      matchActions:
+      - action: Post
+        rateLimit: "1m"
+        rateLimitScope: "process"
+    - matchArgs:
+      - index: 0
+        operator: "Equal"
+        values:
+        - "/proc/sys/vm/nr_hugepages"
+      matchActions:
+      - action: Post
+        rateLimit: "1m"
+        rateLimitScope: "process"
+`
+
+func yamlValues(values []string) string {
+	var sb strings.Builder
+	for _, v := range values {
+		fmt.Fprintf(&sb, "\n        - %q", v)
+	}
+	return sb.String()
+}
+
+func policyYAML(poolPorts []int) (string, error) {
+	if len(poolPorts) == 0 {
+		return "", fmt.Errorf("no mining pool ports")
+	}
+	ports := make([]string, 0, len(poolPorts))
+	for _, p := range poolPorts {
+		if p <= 0 || p > 65535 {
+			return "", fmt.Errorf("invalid mining pool port %d", p)
+		}
+		ports = append(ports, fmt.Sprint(p))
+	}
+	requests := yamlValues(stratumRequests)
+	return fmt.Sprintf(policyTemplate, yamlValues(ports),
+		fmt.Sprintf(sendHookTemplate, "sys_write", requests),
+		fmt.Sprintf(sendHookTemplate, "sys_sendto", requests)), nil
+}
+
+// Policy returns the built-in crypto-mining policy, reporting the connections
+// to poolPorts.
+func Policy(poolPorts []int) (tracingpolicy.TracingPolicy, error) {
+	policy, err := policyYAML(poolPorts)
+	if err != nil {
+		return nil, err
+	}
+	return tracingpolicy.FromYAML(policy)
+}
+
+var (
+	stratumURL    = regexp.MustCompile(`stratum[0-9]?\+(tcp|ssl|tls)://[^\s"']+`)
+	stratumMethod = regexp.MustCompile(`"method"\s*:\s*"([^"]+)"`)
+)
+
+// newSignal returns a signal observed at ts, or now if ts is unset.
+func newSignal(ty tetragon.MiningSignalType, detail string, ts *timestamppb.Timestamp) *tetragon.MiningSignal {
+	if ts == nil {
+		ts = timestamppb.New(time.Now())
+	}
+	return &tetragon.MiningSignal{Type: ty, Detail: detail, Time: ts}
+}
+
+// execSignal returns the signal of an exec event, if the arguments of the
+// process contain a stratum URL.
+func execSignal(ev *tetragon.ProcessExec, ts *timestamppb.Timestamp) *tetragon.MiningSignal {
+	url := stratumURL.FindString(ev.GetProcess().GetArguments())
+	if url == "" {
+		return nil
+	}
+	return newSignal(tetragon.MiningSignalType_MINING_SIGNAL_STRATUM_URL, url, ts)
+}
+
+// kprobeSignal returns the signal of an event of the crypto-mining policy.
+func kprobeSignal(ev *tetragon.ProcessKprobe, ts *timestamppb.Timestamp) *tetragon.MiningSignal {
+	if ev.GetPolicyName() != PolicyName {
+		return nil
+	}
+	fn := ev.GetFunctionName()
+	switch {
+	case fn == "tcp_connect":
+		for _, arg := range ev.GetArgs() {
+			if sock := arg.GetSockArg(); sock != nil {
+				detail := fmt.Sprintf("%s:%d", sock.Daddr, sock.Dport)
+				return newSignal(tetragon.MiningSignalType_MINING_SIGNAL_POOL_PORT, detail, ts)
+			}
+		}
+	case strings.HasSuffix(fn, "sys_write") || strings.HasSuffix(fn, "sys_sendto"):
+		for _, arg := range ev.GetArgs() {
+			if data := arg.GetBytesArg(); data != nil {
+				detail := ""
+				if m := stratumMethod.FindSubmatch(data); m != nil {
+					detail = string(m[1])
+				}
+				return newSignal(tetragon.MiningSignalType_MINING_SIGNAL_STRATUM_HANDSHAKE, detail, ts)
+			}
+		}
+	case fn == "security_file_open":
+		for _, arg := range ev.GetArgs() {
+			if file := arg.GetFileArg(); file != nil {
+				ty := tetragon.MiningSignalType_MINING_SIGNAL_MSR_ACCESS
+				if !strings.HasPrefix(file.Path, "/dev/cpu/") {
+					ty = tetragon.MiningSignalType_MINING_SIGNAL_HUGE_PAGES
+				}
+				return newSignal(ty, file.Path, ts)
+			}
+		}
+	}
+	return nil
+}
@@ -12,37 +12,64 @@ import (
 	"github.com/cilium/tetragon/pkg/api/dataapi"
 	"github.com/cilium/tetragon/pkg/api/ops"
 	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/option"
 	lru "github.com/hashicorp/golang-lru/v2"
 )
 
+// Data events are the side-channel of the data that does not fit in an event
+// (the arguments of exec events, and char_buf arguments with maxData). The
+// data is sent in chunks, referenced by the id of a dataapi.DataEventDesc in
+// the event, and reassembled here until the event handler gets it with
+// DataGet. Stack traces do not use data events, they are referenced by their
+// id in the stack trace map.
+
 func init() {
 	RegisterEventHandlerAtInit(ops.MSG_OP_DATA, HandleData)
 }
 
+// dataEvent is the data reassembled from data event chunks. The data is
+// capped to option.Config.DataEventMaxSize, size counts all received bytes.
+type dataEvent struct {
+	data []byte
+	size int
+}
+
 var (
-	dataMap *lru.Cache[dataapi.DataEventId, []byte]
+	dataMap *lru.Cache[dataapi.DataEventId, *dataEvent]
 )
 
 func InitDataCache(size int) error {
 	var err error
 
-	dataMap, err = lru.New[dataapi.DataEventId, []byte](size)
+	dataMap, err = lru.New[dataapi.DataEventId, *dataEvent](size)
 	return err
 }
 
+// appendCapped appends msgData to data, up to max bytes (0 means no limit)
+func appendCapped(data, msgData []byte, max int) []byte {
+	if max > 0 && len(data)+len(msgData) > max {
+		if len(data) >= max {
+			return data
+		}
+		msgData = msgData[:max-len(data)]
+	}
+	return append(data, msgData...)
+}
+
 func DataAdd(id dataapi.DataEventId, msgData []byte) error {
 	size := len(msgData)
-	data, ok := dataMap.Get(id)
+	ev, ok := dataMap.Get(id)
 	if !ok {
-		dataMap.Add(id, msgData)
+		ev = &dataEvent{}
+		dataMap.Add(id, ev)
 		DataEventMetricInc(DataEventAdded)
 	} else {
-		data = append(data, msgData...)
-		dataMap.Add(id, data)
 		DataEventMetricInc(DataEventAppended)
 	}
+	ev.data = appendCapped(ev.data, msgData, option.Config.DataEventMaxSize)
+	ev.size += size
 
-	logger.GetLogger().WithFields(nil).Tracef("Data message received id %v, size %v, total %v", id, size, len(data))
+	logger.GetLogger().WithFields(nil).Tracef("Data message received id %v, size %v, total %v", id, size, ev.size)
 	return nil
 }
 
@@ -59,8 +86,10 @@ func add(r *bytes.Reader, m *dataapi.MsgData) error {
 	return DataAdd(m.Id, msgData)
 }
 
+// DataGet returns the data of the given data event. The data might be
+// truncated if it is larger than option.Config.DataEventMaxSize.
 func DataGet(desc dataapi.DataEventDesc) ([]byte, error) {
-	data, ok := dataMap.Get(desc.Id)
+	ev, ok := dataMap.Get(desc.Id)
 	if !ok {
 		DataEventMetricInc(DataEventNotMatched)
 		return nil, fmt.Errorf("failed to find data for id: %v", desc.Id)
@@ -69,17 +98,20 @@ func DataGet(desc dataapi.DataEventDesc) ([]byte, error) {
 	dataMap.Remove(desc.Id)
 
 	// make sure we did not loose anything on the way through ring buffer
-	if len(data) != int(desc.Size-desc.Leftover) {
+	if ev.size != int(desc.Size-desc.Leftover) {
 		DataEventMetricInc(DataEventBad)
 		DataEventMetricSizeBad(desc.Size)
 		return nil, fmt.Errorf("failed to get correct data for id: %v", desc.Id)
 	}
 
 	DataEventMetricSizeOk(desc.Size)
+	if len(ev.data) < ev.size {
+		DataEventMetricInc(DataEventTruncated)
+	}
 
-	logger.GetLogger().WithFields(nil).Tracef("Data message used id %v, data len %v", desc.Id, len(data))
+	logger.GetLogger().WithFields(nil).Tracef("Data message used id %v, data len %v", desc.Id, len(ev.data))
 	DataEventMetricInc(DataEventMatched)
-	return data, nil
+	return ev.data, nil
 }
 
 func HandleData(r *bytes.Reader) ([]Event, error) {
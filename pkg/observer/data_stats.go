@@ -40,6 +40,7 @@ const (
 	DataEventMatched
 	DataEventNotMatched
 	DataEventBad
+	DataEventTruncated
 )
 
 var DataEventTypeStrings = map[DataEventType]string{
@@ -49,6 +50,7 @@ var DataEventTypeStrings = map[DataEventType]string{
 	DataEventMatched:    "Matched",
 	DataEventNotMatched: "NotMatched",
 	DataEventBad:        "Bad",
+	DataEventTruncated:  "Truncated",
 }
 
 // Increment a data event metric for an event type and location
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package observer
+
+import (
+	"testing"
+
+	"github.com/cilium/tetragon/pkg/api/dataapi"
+	"github.com/cilium/tetragon/pkg/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataGet(t *testing.T) {
+	require.NoError(t, InitDataCache(16))
+	defer DataPurge()
+
+	id := dataapi.DataEventId{Pid: 1, Time: 1}
+	require.NoError(t, DataAdd(id, []byte("hello ")))
+	require.NoError(t, DataAdd(id, []byte("world")))
+
+	data, err := DataGet(dataapi.DataEventDesc{Id: id, Size: 11})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hello world"), data)
+
+	// the data is removed once used
+	_, err = DataGet(dataapi.DataEventDesc{Id: id, Size: 11})
+	assert.Error(t, err)
+
+	// lost chunks are detected
+	require.NoError(t, DataAdd(id, []byte("hello ")))
+	_, err = DataGet(dataapi.DataEventDesc{Id: id, Size: 11})
+	assert.Error(t, err)
+}
+
+func TestDataGetMaxSize(t *testing.T) {
+	require.NoError(t, InitDataCache(16))
+	defer DataPurge()
+
+	oldMaxSize := option.Config.DataEventMaxSize
+	option.Config.DataEventMaxSize = 8
+	defer func() { option.Config.DataEventMaxSize = oldMaxSize }()
+
+	id := dataapi.DataEventId{Pid: 1, Time: 1}
+	require.NoError(t, DataAdd(id, []byte("hello ")))
+	require.NoError(t, DataAdd(id, []byte("world")))
+	require.NoError(t, DataAdd(id, []byte("!!")))
+
+	data, err := DataGet(dataapi.DataEventDesc{Id: id, Size: 15, Leftover: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hello wo"), data)
+}
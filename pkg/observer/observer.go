@@ -6,17 +6,18 @@ package observer
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"os"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/cilium/ebpf"
-	"github.com/cilium/ebpf/perf"
 	"github.com/cilium/tetragon/pkg/api/readyapi"
 	"github.com/cilium/tetragon/pkg/bpf"
 	"github.com/cilium/tetragon/pkg/logger"
@@ -49,12 +50,18 @@ func RegisterEventHandlerAtInit(ev uint8, handler func(r *bytes.Reader) ([]Event
 }
 
 func (k *Observer) observerListeners(msg notify.Message) {
+	var failed []Listener
+	k.listenersMu.RLock()
 	for listener := range k.listeners {
 		if err := listener.Notify(msg); err != nil {
-			k.log.Debug("Write failure removing Listener")
-			k.RemoveListener(listener)
+			failed = append(failed, listener)
 		}
 	}
+	k.listenersMu.RUnlock()
+	for _, listener := range failed {
+		k.log.Debug("Write failure removing Listener")
+		k.RemoveListener(listener)
+	}
 }
 
 func AllListeners(msg notify.Message) {
@@ -65,12 +72,16 @@ func AllListeners(msg notify.Message) {
 
 func (k *Observer) AddListener(listener Listener) {
 	k.log.WithField("listener", listener).Debug("Add listener")
+	k.listenersMu.Lock()
+	defer k.listenersMu.Unlock()
 	k.listeners[listener] = struct{}{}
 }
 
 func (k *Observer) RemoveListener(listener Listener) {
 	k.log.WithField("listener", listener).Debug("Delete listener")
+	k.listenersMu.Lock()
 	delete(k.listeners, listener)
+	k.listenersMu.Unlock()
 	if err := listener.Close(); err != nil {
 		k.log.WithError(err).Warn("failed to close listener")
 	}
@@ -207,84 +218,118 @@ func (k *Observer) getRBQueueSize() int {
 	return size
 }
 
-func (k *Observer) RunEvents(stopCtx context.Context, ready func()) error {
-	pinOpts := ebpf.LoadPinOptions{}
-	perfMap, err := ebpf.LoadPinnedMap(k.PerfConfig.MapName, &pinOpts)
+func (k *Observer) getRBWatermark() int {
+	watermark := option.Config.RBWatermark
+	if watermark != 0 {
+		k.log.WithField("watermark", sizeWithSuffix(watermark)).
+			Info("Perf ring buffer wakeup watermark (bytes)")
+	}
+	return watermark
+}
+
+// readEvents reads the records of reader into the events queue, until stopCtx
+// is done or the reader is closed.
+func (k *Observer) readEvents(stopCtx context.Context, reader EventReader) {
+	for stopCtx.Err() == nil {
+		record, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, os.ErrClosed) {
+				return
+			}
+			// NOTE(JM and Djalal): count and log errors while excluding the stopping context
+			if stopCtx.Err() == nil {
+				errorCnt := atomic.AddUint64(&k.errorCntr, 1)
+				ringbufmetrics.PerfEventErrors.Inc()
+				k.log.WithField("errors", errorCnt).WithError(err).Warn("Reading bpf events failed")
+			}
+		} else {
+			if len(record.RawSample) > 0 {
+				select {
+				case k.eventsQueue(&record) <- &record:
+				default:
+					// the events queue is full, drop the event
+					ringbufqueuemetrics.Lost.Inc()
+				}
+				atomic.AddUint64(&k.recvCntr, 1)
+				ringbufmetrics.PerfEventReceived.Inc()
+			}
+
+			if record.LostSamples > 0 {
+				atomic.AddUint64(&k.lostCntr, uint64(record.LostSamples))
+				ringbufmetrics.PerfEventLost.Add(float64(record.LostSamples))
+				k.addLostEvents(record.CPU, record.LostSamples)
+			}
+		}
+	}
+}
+
+// openEventReader opens the reader of the events of the transport set by
+// --rb-transport.
+func (k *Observer) openEventReader() (EventReader, error) {
+	transport, err := EventTransport()
 	if err != nil {
-		return fmt.Errorf("opening pinned map '%s' failed: %w", k.PerfConfig.MapName, err)
+		return nil, err
 	}
-	defer perfMap.Close()
 
-	rbSize := k.getRBSize(int(perfMap.MaxEntries()))
-	perfReader, err := perf.NewReader(perfMap, rbSize)
+	if transport == TransportRingBuf {
+		k.log.WithField("size", sizeWithSuffix(RingBufSize())).
+			Info("Reading events of BPF ring buffer")
+		// the perf ring buffers only get the events of the
+		// programs that do not use the BPF ring buffer
+		return OpenEventReader(k.PerfConfig.MapName, transport, perCPUBufferBytes, 0)
+	}
 
+	perfMap, err := ebpf.LoadPinnedMap(k.PerfConfig.MapName, nil)
 	if err != nil {
-		return fmt.Errorf("creating perf array reader failed: %w", err)
+		return nil, fmt.Errorf("opening pinned map '%s' failed: %w", k.PerfConfig.MapName, err)
+	}
+	cpus := int(perfMap.MaxEntries())
+	perfMap.Close()
+	return OpenEventReader(k.PerfConfig.MapName, transport, k.getRBSize(cpus), k.getRBWatermark())
+}
+
+func (k *Observer) RunEvents(stopCtx context.Context, ready func()) error {
+	reader, err := k.openEventReader()
+	if err != nil {
+		return err
 	}
 
 	// Inform caller that we're about to start processing events.
 	k.observerListeners(&readyapi.MsgTetragonReady{})
 	ready()
 
-	// We spawn go routine to read and process perf events,
-	// connected with main app through eventsQueue channel.
-	eventsQueue := make(chan *perf.Record, k.getRBQueueSize())
-
-	// Listeners are ready and about to start reading from perf reader, tell
-	// user everything is ready.
+	// Listeners are ready and about to start reading from the event reader,
+	// tell user everything is ready.
 	k.log.Info("Listening for events...")
 
-	// Start reading records from the perf array. Reads until the reader is closed.
+	// Start reading records from the transports of the events. Reads until
+	// the reader is closed.
 	var wg sync.WaitGroup
 	wg.Add(1)
 	defer wg.Wait()
 	go func() {
 		defer wg.Done()
-		for stopCtx.Err() == nil {
-			record, err := perfReader.Read()
-			if err != nil {
-				// NOTE(JM and Djalal): count and log errors while excluding the stopping context
-				if stopCtx.Err() == nil {
-					errorCnt := atomic.AddUint64(&k.errorCntr, 1)
-					ringbufmetrics.PerfEventErrors.Inc()
-					k.log.WithField("errors", errorCnt).WithError(err).Warn("Reading bpf events failed")
-				}
-			} else {
-				if len(record.RawSample) > 0 {
-					select {
-					case eventsQueue <- &record:
-					default:
-						// eventsQueue channel is full, drop the event
-						ringbufqueuemetrics.Lost.Inc()
-					}
-					k.recvCntr++
-					ringbufmetrics.PerfEventReceived.Inc()
-				}
-
-				if record.LostSamples > 0 {
-					atomic.AddUint64(&k.lostCntr, uint64(record.LostSamples))
-					ringbufmetrics.PerfEventLost.Add(float64(record.LostSamples))
-				}
-			}
-		}
+		k.readEvents(stopCtx, reader)
 	}()
 
-	// Start processing records from perf.
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		for {
-			select {
-			case event := <-eventsQueue:
-				k.receiveEvent(event.RawSample)
-				ringbufqueuemetrics.Received.Inc()
-			case <-stopCtx.Done():
-				k.log.WithError(stopCtx.Err()).Infof("Listening for events completed.")
-				k.log.Debugf("Unprocessed events in RB queue: %d", len(eventsQueue))
-				return
+	// Start processing records from perf, one goroutine per queue.
+	for i, queue := range k.eventsQueues {
+		wg.Add(1)
+		go func(i int, queue chan *EventRecord) {
+			defer wg.Done()
+			for {
+				select {
+				case event := <-queue:
+					k.receiveEvent(event.RawSample)
+					ringbufqueuemetrics.Received.Inc()
+				case <-stopCtx.Done():
+					k.log.WithError(stopCtx.Err()).WithField("worker", i).Infof("Listening for events completed.")
+					k.log.Debugf("Unprocessed events in RB queue %d: %d", i, len(queue))
+					return
+				}
 			}
-		}
-	}()
+		}(i, queue)
+	}
 
 	// Loading default program consumes some memory lets kick GC to give
 	// this back to the OS (K8s).
@@ -294,7 +339,7 @@ func (k *Observer) RunEvents(stopCtx context.Context, ready func()) error {
 
 	// Wait for context to be cancelled and then stop.
 	<-stopCtx.Done()
-	return perfReader.Close()
+	return reader.Close()
 }
 
 // Observer represents the link between the BPF perf ring and the listeners. It
@@ -303,10 +348,16 @@ func (k *Observer) RunEvents(stopCtx context.Context, ready func()) error {
 // notified of their corresponding events.
 type Observer struct {
 	/* Configuration */
-	listeners  map[Listener]struct{}
-	PerfConfig *bpf.PerfEventConfig
+	listenersMu sync.RWMutex
+	listeners   map[Listener]struct{}
+	PerfConfig  *bpf.PerfEventConfig
+	// eventsQueues connect the goroutines reading the transports of the
+	// events to the ones processing their records
+	eventsQueues []chan *EventRecord
 	/* Statistics */
 	lostCntr   uint64 // atomic
+	lostMu     sync.Mutex
+	lostPerCPU map[int]uint64
 	errorCntr  uint64 // atomic
 	recvCntr   uint64 // atomic
 	filterPass uint64
@@ -367,6 +418,7 @@ func NewObserver(configFile string) *Observer {
 		log:        logger.GetLogger(),
 		configFile: configFile,
 	}
+	o.eventsQueues = newEventsQueues(option.Config.RBQueueWorkers, o.getRBQueueSize())
 	observerList = append(observerList, o)
 	return o
 }
@@ -384,6 +436,29 @@ func (k *Observer) ReadLostEvents() uint64 {
 	return atomic.LoadUint64(&k.lostCntr)
 }
 
+func (k *Observer) addLostEvents(cpu int, lost uint64) {
+	ringbufmetrics.LostEvents.WithLabelValues(strconv.Itoa(cpu)).Add(float64(lost))
+
+	k.lostMu.Lock()
+	defer k.lostMu.Unlock()
+	if k.lostPerCPU == nil {
+		k.lostPerCPU = make(map[int]uint64)
+	}
+	k.lostPerCPU[cpu] += lost
+}
+
+// ReadLostEventsPerCPU returns the number of events lost by each CPU that lost
+// events since the observer started.
+func (k *Observer) ReadLostEventsPerCPU() map[int]uint64 {
+	k.lostMu.Lock()
+	defer k.lostMu.Unlock()
+	ret := make(map[int]uint64, len(k.lostPerCPU))
+	for cpu, lost := range k.lostPerCPU {
+		ret[cpu] = lost
+	}
+	return ret
+}
+
 func (k *Observer) ReadErrorEvents() uint64 {
 	return atomic.LoadUint64(&k.errorCntr)
 }
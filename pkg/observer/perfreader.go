@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package observer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cilium/ebpf"
+)
+
+// PerfReader reads the events of a sensor that has its own perf ring buffer.
+type PerfReader struct {
+	reader EventReader
+	wg     sync.WaitGroup
+}
+
+// StartPerfReader starts reading the events of the perf event array pinned
+// at pinPath, with a per CPU buffer of size bytes and a wakeup watermark of
+// watermark bytes. The events are processed with the ones of the main perf
+// ring buffer, until the reader is closed.
+func StartPerfReader(pinPath string, size, watermark int) (*PerfReader, error) {
+	if len(observerList) == 0 {
+		return nil, errors.New("no observer to process the events")
+	}
+	k := observerList[0]
+
+	perfMap, err := ebpf.LoadPinnedMap(pinPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("opening pinned map '%s' failed: %w", pinPath, err)
+	}
+	defer perfMap.Close()
+
+	reader, err := NewPerfEventReader(perfMap, size, watermark)
+	if err != nil {
+		return nil, err
+	}
+
+	cpuSize := perfBufferSize(size)
+	k.log.WithField("map", pinPath).
+		WithField("percpu", sizeWithSuffix(cpuSize)).
+		WithField("total", sizeWithSuffix(cpuSize*int(perfMap.MaxEntries()))).
+		WithField("watermark", sizeWithSuffix(watermark)).
+		Info("Reading events of perf ring buffer")
+
+	pr := &PerfReader{reader: reader}
+	pr.wg.Add(1)
+	go func() {
+		defer pr.wg.Done()
+		k.readEvents(context.Background(), reader)
+	}()
+	return pr, nil
+}
+
+// Close stops the reader.
+func (pr *PerfReader) Close() error {
+	err := pr.reader.Close()
+	pr.wg.Wait()
+	return err
+}
@@ -0,0 +1,353 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package observer
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/perf"
+	"github.com/cilium/ebpf/ringbuf"
+	"github.com/cilium/tetragon/pkg/bpf"
+	"github.com/cilium/tetragon/pkg/features"
+	"github.com/cilium/tetragon/pkg/kernels"
+	"github.com/cilium/tetragon/pkg/option"
+)
+
+const (
+	// TransportPerf is the transport of the events through the per CPU
+	// perf ring buffers of tcpmon_map.
+	TransportPerf = "perf"
+	// TransportRingBuf is the transport of the events through the BPF ring
+	// buffer tcpmon_ringbuf, shared by all CPUs.
+	TransportRingBuf = "ringbuf"
+
+	ringBufMapName        = "tcpmon_ringbuf"
+	eventTransportMapName = "event_transport_map"
+	ringBufLostMapName    = "ringbuf_lost_map"
+
+	// ringBufLostInterval is the interval at which the number of events
+	// lost by the BPF ring buffer is read.
+	ringBufLostInterval = time.Second
+)
+
+// EventTransport returns the transport of the events set by --rb-transport.
+// Only the BPF programs of kernels >= 6.1 can write to the BPF ring buffer, so
+// the transport falls back to perf ring buffers on older kernels.
+func EventTransport() (string, error) {
+	switch option.Config.RBTransport {
+	case "", TransportPerf:
+		return TransportPerf, nil
+	case TransportRingBuf:
+		if !kernels.EnableV61Progs() || !features.Ringbuf.Supported() {
+			return TransportPerf, nil
+		}
+		return TransportRingBuf, nil
+	}
+	return "", fmt.Errorf("invalid value for --%s: %q, expected one of %s, %s",
+		option.KeyRBTransport, option.Config.RBTransport, TransportPerf, TransportRingBuf)
+}
+
+// RingBufSize returns the size of the BPF ring buffer of the events: the
+// total size of the perf ring buffers set by --rb-size-total or --rb-size,
+// rounded up to a power of two number of pages as the kernel requires.
+func RingBufSize() int {
+	cpus := bpf.GetNumPossibleCPUs()
+	if cpus == 0 {
+		cpus = 1
+	}
+
+	var size int
+	if option.Config.RBSizeTotal != 0 {
+		size = option.Config.RBSizeTotal
+	} else if option.Config.RBSize != 0 {
+		size = option.Config.RBSize * cpus
+	} else {
+		size = perCPUBufferBytes * cpus
+	}
+	return ringBufSize(size)
+}
+
+func ringBufSize(size int) int {
+	pageSize := os.Getpagesize()
+	nPages := (size + pageSize - 1) / pageSize
+	if nPages < 1 {
+		nPages = 1
+	}
+	nPages = int(math.Pow(2, math.Ceil(math.Log2(float64(nPages)))))
+	return nPages * pageSize
+}
+
+// EventRecord is a record read from a transport of the events.
+type EventRecord struct {
+	// RawSample is the event, empty if the record only reports losses
+	RawSample []byte
+	// LostSamples is the number of events lost since the previous record
+	LostSamples uint64
+	// CPU is the CPU that produced or lost the events, zero for the BPF
+	// ring buffer samples
+	CPU int
+}
+
+// EventReader reads the events of the BPF programs from their transports.
+// Read returns an error wrapping os.ErrClosed once the reader is closed.
+type EventReader interface {
+	Read() (EventRecord, error)
+	Close() error
+}
+
+type perfEventReader struct {
+	reader *perf.Reader
+}
+
+// NewPerfEventReader returns a reader of the perf event array m, with a per
+// CPU buffer of perCPUBuffer bytes and a wakeup watermark of watermark bytes.
+func NewPerfEventReader(m *ebpf.Map, perCPUBuffer, watermark int) (EventReader, error) {
+	reader, err := perf.NewReaderWithOptions(m, perCPUBuffer, perf.ReaderOptions{
+		Watermark: watermark,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating perf array reader failed: %w", err)
+	}
+	return &perfEventReader{reader: reader}, nil
+}
+
+func (r *perfEventReader) Read() (EventRecord, error) {
+	record, err := r.reader.Read()
+	if err != nil {
+		return EventRecord{}, err
+	}
+	return EventRecord{RawSample: record.RawSample, LostSamples: record.LostSamples, CPU: record.CPU}, nil
+}
+
+func (r *perfEventReader) Close() error {
+	return r.reader.Close()
+}
+
+type ringBufEventReader struct {
+	reader *ringbuf.Reader
+	// lost holds the per CPU number of events that the BPF programs could
+	// not write to the ring buffer
+	lost     *ebpf.Map
+	prevLost []uint64
+	lastLost time.Time
+	// pendingLost holds the records of the CPUs that lost events, not
+	// returned yet
+	pendingLost []EventRecord
+}
+
+// NewRingBufEventReader returns a reader of the BPF ring buffer rb. The
+// number of lost events is read from the per CPU counter of lost.
+func NewRingBufEventReader(rb, lost *ebpf.Map) (EventReader, error) {
+	reader, err := ringbuf.NewReader(rb)
+	if err != nil {
+		return nil, fmt.Errorf("creating ring buffer reader failed: %w", err)
+	}
+	lost, err = lost.Clone()
+	if err != nil {
+		reader.Close()
+		return nil, err
+	}
+	return &ringBufEventReader{reader: reader, lost: lost}, nil
+}
+
+func (r *ringBufEventReader) Read() (EventRecord, error) {
+	for {
+		if len(r.pendingLost) > 0 {
+			record := r.pendingLost[0]
+			r.pendingLost = r.pendingLost[1:]
+			return record, nil
+		}
+		r.reader.SetDeadline(time.Now().Add(ringBufLostInterval))
+		record, err := r.reader.Read()
+		if err != nil && !errors.Is(err, os.ErrDeadlineExceeded) {
+			return EventRecord{}, err
+		}
+		if time.Since(r.lastLost) >= ringBufLostInterval {
+			r.pendingLost = r.readLost()
+		}
+		if err == nil {
+			return EventRecord{RawSample: record.RawSample}, nil
+		}
+	}
+}
+
+// readLost returns a record for every CPU that lost events since it was last
+// called.
+func (r *ringBufEventReader) readLost() []EventRecord {
+	r.lastLost = time.Now()
+	var counts []uint64
+	if err := r.lost.Lookup(uint32(0), &counts); err != nil {
+		return nil
+	}
+	records := lostRecords(r.prevLost, counts)
+	r.prevLost = counts
+	return records
+}
+
+// lostRecords returns a record for every CPU whose count of lost events
+// increased from prev to cur.
+func lostRecords(prev, cur []uint64) []EventRecord {
+	var records []EventRecord
+	for cpu, c := range cur {
+		var p uint64
+		if cpu < len(prev) {
+			p = prev[cpu]
+		}
+		if c > p {
+			records = append(records, EventRecord{LostSamples: c - p, CPU: cpu})
+		}
+	}
+	return records
+}
+
+func (r *ringBufEventReader) Close() error {
+	err := r.reader.Close()
+	r.lost.Close()
+	return err
+}
+
+type eventResult struct {
+	record EventRecord
+	err    error
+}
+
+// mergedEventReader reads the events of several readers.
+type mergedEventReader struct {
+	readers []EventReader
+	results chan eventResult
+	done    chan struct{}
+	close   sync.Once
+	wg      sync.WaitGroup
+}
+
+// MergeEventReaders returns a reader of the events of all the readers. The
+// order of the events of each reader is preserved.
+func MergeEventReaders(readers ...EventReader) EventReader {
+	r := &mergedEventReader{
+		readers: readers,
+		results: make(chan eventResult),
+		done:    make(chan struct{}),
+	}
+	for _, reader := range readers {
+		r.wg.Add(1)
+		go func(reader EventReader) {
+			defer r.wg.Done()
+			for {
+				record, err := reader.Read()
+				if errors.Is(err, os.ErrClosed) {
+					return
+				}
+				select {
+				case r.results <- eventResult{record: record, err: err}:
+				case <-r.done:
+					return
+				}
+			}
+		}(reader)
+	}
+	return r
+}
+
+func (r *mergedEventReader) Read() (EventRecord, error) {
+	select {
+	case res := <-r.results:
+		return res.record, res.err
+	case <-r.done:
+		return EventRecord{}, fmt.Errorf("event reader: %w", os.ErrClosed)
+	}
+}
+
+func (r *mergedEventReader) Close() error {
+	var errs []error
+	r.close.Do(func() {
+		close(r.done)
+		for _, reader := range r.readers {
+			errs = append(errs, reader.Close())
+		}
+		r.wg.Wait()
+	})
+	return errors.Join(errs...)
+}
+
+// setEventTransport selects the transport that the BPF programs write their
+// events to. The transport map only exists with the programs of kernels >=
+// 6.1, and the other programs always use perf ring buffers.
+func setEventTransport(mapDir, transport string) error {
+	m, err := ebpf.LoadPinnedMap(filepath.Join(mapDir, eventTransportMapName), nil)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && transport == TransportPerf {
+			return nil
+		}
+		return fmt.Errorf("opening pinned map '%s' failed: %w", eventTransportMapName, err)
+	}
+	defer m.Close()
+
+	var val uint32
+	if transport == TransportRingBuf {
+		val = 1
+	}
+	return m.Update(uint32(0), val, ebpf.UpdateAny)
+}
+
+// openRingBufEventReader returns a reader of the BPF ring buffer of the
+// events pinned in mapDir.
+func openRingBufEventReader(mapDir string) (EventReader, error) {
+	rb, err := ebpf.LoadPinnedMap(filepath.Join(mapDir, ringBufMapName), nil)
+	if err != nil {
+		return nil, fmt.Errorf("opening pinned map '%s' failed: %w", ringBufMapName, err)
+	}
+	defer rb.Close()
+	lost, err := ebpf.LoadPinnedMap(filepath.Join(mapDir, ringBufLostMapName), nil)
+	if err != nil {
+		return nil, fmt.Errorf("opening pinned map '%s' failed: %w", ringBufLostMapName, err)
+	}
+	defer lost.Close()
+	return NewRingBufEventReader(rb, lost)
+}
+
+// OpenEventReader returns a reader of the events of the transport, with the
+// perf event array pinned at perfMapPath, and the other maps of the events
+// pinned in the same directory. With the BPF ring buffer transport, the events
+// of the programs that only use perf ring buffers are read as well, from per
+// CPU buffers of perCPUBuffer bytes with a wakeup watermark of watermark
+// bytes.
+func OpenEventReader(perfMapPath, transport string, perCPUBuffer, watermark int) (EventReader, error) {
+	mapDir := filepath.Dir(perfMapPath)
+	perfMap, err := ebpf.LoadPinnedMap(perfMapPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("opening pinned map '%s' failed: %w", perfMapPath, err)
+	}
+	defer perfMap.Close()
+
+	perfReader, err := NewPerfEventReader(perfMap, perCPUBuffer, watermark)
+	if err != nil {
+		return nil, err
+	}
+	if transport != TransportRingBuf {
+		if err := setEventTransport(mapDir, TransportPerf); err != nil {
+			perfReader.Close()
+			return nil, err
+		}
+		return perfReader, nil
+	}
+
+	ringBufReader, err := openRingBufEventReader(mapDir)
+	if err != nil {
+		perfReader.Close()
+		return nil, err
+	}
+	reader := MergeEventReaders(ringBufReader, perfReader)
+	if err := setEventTransport(mapDir, TransportRingBuf); err != nil {
+		reader.Close()
+		return nil, err
+	}
+	return reader, nil
+}
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package observer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/cilium/tetragon/pkg/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRingBufSize(t *testing.T) {
+	pageSize := os.Getpagesize()
+	assert.Equal(t, pageSize, ringBufSize(0))
+	assert.Equal(t, pageSize, ringBufSize(1))
+	assert.Equal(t, 2*pageSize, ringBufSize(pageSize+1))
+	assert.Equal(t, 4*pageSize, ringBufSize(3*pageSize))
+	assert.Equal(t, 4*pageSize, ringBufSize(4*pageSize))
+}
+
+func TestEventTransport(t *testing.T) {
+	prev := option.Config.RBTransport
+	t.Cleanup(func() { option.Config.RBTransport = prev })
+
+	option.Config.RBTransport = TransportPerf
+	transport, err := EventTransport()
+	require.NoError(t, err)
+	assert.Equal(t, TransportPerf, transport)
+
+	// the BPF ring buffer falls back to perf ring buffers on older kernels
+	option.Config.RBTransport = TransportRingBuf
+	transport, err = EventTransport()
+	require.NoError(t, err)
+	assert.Contains(t, []string{TransportPerf, TransportRingBuf}, transport)
+
+	option.Config.RBTransport = "pipe"
+	_, err = EventTransport()
+	assert.Error(t, err)
+}
+
+// fakeEventReader returns its records, and then blocks until it is closed.
+type fakeEventReader struct {
+	records chan EventRecord
+	closed  chan struct{}
+}
+
+func newFakeEventReader(samples ...string) *fakeEventReader {
+	r := &fakeEventReader{
+		records: make(chan EventRecord, len(samples)),
+		closed:  make(chan struct{}),
+	}
+	for _, s := range samples {
+		r.records <- EventRecord{RawSample: []byte(s)}
+	}
+	return r
+}
+
+func (r *fakeEventReader) Read() (EventRecord, error) {
+	select {
+	case rec := <-r.records:
+		return rec, nil
+	case <-r.closed:
+		return EventRecord{}, fmt.Errorf("fake: %w", os.ErrClosed)
+	}
+}
+
+func (r *fakeEventReader) Close() error {
+	close(r.closed)
+	return nil
+}
+
+func TestMergeEventReaders(t *testing.T) {
+	reader := MergeEventReaders(newFakeEventReader("a1", "a2", "a3"), newFakeEventReader("b1", "b2"))
+
+	var a, b []string
+	for i := 0; i < 5; i++ {
+		rec, err := reader.Read()
+		require.NoError(t, err)
+		switch s := string(rec.RawSample); s[0] {
+		case 'a':
+			a = append(a, s)
+		case 'b':
+			b = append(b, s)
+		}
+	}
+	// the order of the records of each reader is preserved
+	assert.Equal(t, []string{"a1", "a2", "a3"}, a)
+	assert.Equal(t, []string{"b1", "b2"}, b)
+
+	require.NoError(t, reader.Close())
+	_, err := reader.Read()
+	assert.True(t, errors.Is(err, os.ErrClosed))
+	// closing twice is fine
+	assert.NoError(t, reader.Close())
+}
+
+func TestLostRecords(t *testing.T) {
+	assert.Equal(t, []EventRecord{
+		{LostSamples: 2, CPU: 0},
+		{LostSamples: 5, CPU: 2},
+	}, lostRecords(nil, []uint64{2, 0, 5}))
+
+	assert.Equal(t, []EventRecord{
+		{LostSamples: 1, CPU: 1},
+	}, lostRecords([]uint64{2, 0, 5}, []uint64{2, 1, 5}))
+
+	assert.Empty(t, lostRecords([]uint64{2, 1, 5}, []uint64{2, 1, 5}))
+}
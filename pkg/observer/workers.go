@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package observer
+
+import (
+	"encoding/binary"
+
+	"github.com/cilium/tetragon/pkg/api/ops"
+	"github.com/cilium/tetragon/pkg/api/processapi"
+)
+
+// The records of the events are processed by several workers when
+// --rb-queue-workers is set. Each worker has its own queue, and the records of
+// the events of a process are always sent to the queue of the same worker,
+// so that they are decoded, enriched and exported in order: for example, the
+// data events of the arguments of a kprobe are processed before the kprobe
+// event, and the exit of a process after its kprobes events. The records of
+// the events that are not related to a process are sent to the queue of the
+// CPU that produced them, when the transport reports it.
+
+var (
+	// offset of the pid of the process, after the common header of the
+	// events that start with the key of their process
+	processKeyPidOffset = binary.Size(processapi.MsgCommon{})
+	// offset of the pid of the new process of an exec event
+	execPidOffset = binary.Size(processapi.MsgExecveEvent{}) + 4
+	// offset of the pid of the new process of a clone event
+	clonePidOffset = binary.Size(processapi.MsgCommon{}) + binary.Size(processapi.MsgExecveKey{})
+	// offset of the pid of the process of a cgroup event
+	cgroupPidOffset = clonePidOffset + 4
+)
+
+// eventPid returns the pid of the process of the raw sample of an event, if
+// the event is related to a process.
+func eventPid(data []byte) (uint32, bool) {
+	var off int
+	switch data[0] {
+	case ops.MSG_OP_EXIT, ops.MSG_OP_GENERIC_KPROBE, ops.MSG_OP_GENERIC_TRACEPOINT,
+		ops.MSG_OP_GENERIC_UPROBE, ops.MSG_OP_GENERIC_LSM, ops.MSG_OP_LOADER:
+		off = processKeyPidOffset
+	case ops.MSG_OP_DATA:
+		// the id of the data events is the pid and tid of their
+		// process, with the pid in the upper bits
+		if len(data) < processKeyPidOffset+8 {
+			return 0, false
+		}
+		return uint32(binary.LittleEndian.Uint64(data[processKeyPidOffset:]) >> 32), true
+	case ops.MSG_OP_EXECVE:
+		off = execPidOffset
+	case ops.MSG_OP_CLONE:
+		off = clonePidOffset
+	case ops.MSG_OP_CGROUP:
+		off = cgroupPidOffset
+	default:
+		return 0, false
+	}
+	if len(data) < off+4 {
+		return 0, false
+	}
+	return binary.LittleEndian.Uint32(data[off:]), true
+}
+
+// newEventsQueues returns the queues of the workers processing the records of
+// the events, which share the size of the queue.
+func newEventsQueues(workers, size int) []chan *EventRecord {
+	if workers < 1 {
+		workers = 1
+	}
+	size = (size + workers - 1) / workers
+	queues := make([]chan *EventRecord, workers)
+	for i := range queues {
+		queues[i] = make(chan *EventRecord, size)
+	}
+	return queues
+}
+
+// eventsQueue returns the queue of the worker processing a record.
+func (k *Observer) eventsQueue(record *EventRecord) chan *EventRecord {
+	if len(k.eventsQueues) == 1 {
+		return k.eventsQueues[0]
+	}
+	key := uint32(record.CPU)
+	if pid, ok := eventPid(record.RawSample); ok {
+		key = pid
+	}
+	return k.eventsQueues[key%uint32(len(k.eventsQueues))]
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package observer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/cilium/tetragon/pkg/api/dataapi"
+	"github.com/cilium/tetragon/pkg/api/ops"
+	"github.com/cilium/tetragon/pkg/api/processapi"
+	"github.com/cilium/tetragon/pkg/api/tracingapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rawSample(t *testing.T, msgs ...interface{}) []byte {
+	var buf bytes.Buffer
+	for _, msg := range msgs {
+		require.NoError(t, binary.Write(&buf, binary.LittleEndian, msg))
+	}
+	return buf.Bytes()
+}
+
+func TestEventPid(t *testing.T) {
+	kprobe := rawSample(t, &tracingapi.MsgGenericKprobe{
+		Common:     processapi.MsgCommon{Op: ops.MSG_OP_GENERIC_KPROBE},
+		ProcessKey: processapi.MsgExecveKey{Pid: 42},
+	})
+	data := rawSample(t, &dataapi.MsgData{
+		Common: processapi.MsgCommon{Op: ops.MSG_OP_DATA},
+		Id:     dataapi.DataEventId{Pid: 42<<32 | 43},
+	})
+	exec := rawSample(t, &processapi.MsgExecveEvent{
+		Common: processapi.MsgCommon{Op: ops.MSG_OP_EXECVE},
+		Parent: processapi.MsgExecveKey{Pid: 1},
+	}, &processapi.MsgExec{PID: 42})
+	clone := rawSample(t, &processapi.MsgCloneEvent{
+		Common: processapi.MsgCommon{Op: ops.MSG_OP_CLONE},
+		Parent: processapi.MsgExecveKey{Pid: 1},
+		PID:    42,
+	})
+	cgroup := rawSample(t, &processapi.MsgCgroupEvent{
+		Common: processapi.MsgCommon{Op: ops.MSG_OP_CGROUP},
+		Parent: processapi.MsgExecveKey{Pid: 1},
+		PID:    42,
+	})
+
+	for name, sample := range map[string][]byte{
+		"kprobe": kprobe,
+		"data":   data,
+		"exec":   exec,
+		"clone":  clone,
+		"cgroup": cgroup,
+	} {
+		pid, ok := eventPid(sample)
+		assert.True(t, ok, name)
+		assert.Equal(t, uint32(42), pid, name)
+	}
+
+	_, ok := eventPid([]byte{ops.MSG_OP_TEST, 0, 0, 0})
+	assert.False(t, ok)
+	_, ok = eventPid(kprobe[:processKeyPidOffset])
+	assert.False(t, ok)
+}
+
+func TestEventsQueue(t *testing.T) {
+	kprobe := func(pid uint32) []byte {
+		return rawSample(t, &tracingapi.MsgGenericKprobe{
+			Common:     processapi.MsgCommon{Op: ops.MSG_OP_GENERIC_KPROBE},
+			ProcessKey: processapi.MsgExecveKey{Pid: pid},
+		})
+	}
+
+	k := &Observer{eventsQueues: newEventsQueues(4, 10)}
+	require.Len(t, k.eventsQueues, 4)
+	assert.Equal(t, 3, cap(k.eventsQueues[0]))
+
+	// the events of a process are processed by the same worker, whatever
+	// their CPU
+	assert.Equal(t, k.eventsQueues[1], k.eventsQueue(&EventRecord{RawSample: kprobe(5), CPU: 0}))
+	assert.Equal(t, k.eventsQueues[1], k.eventsQueue(&EventRecord{RawSample: kprobe(5), CPU: 2}))
+	assert.Equal(t, k.eventsQueues[2], k.eventsQueue(&EventRecord{RawSample: kprobe(6), CPU: 0}))
+	// the other events by the worker of their CPU
+	assert.Equal(t, k.eventsQueues[3], k.eventsQueue(&EventRecord{RawSample: []byte{ops.MSG_OP_TEST}, CPU: 7}))
+
+	k = &Observer{eventsQueues: newEventsQueues(0, 10)}
+	require.Len(t, k.eventsQueues, 1)
+	assert.Equal(t, k.eventsQueues[0], k.eventsQueue(&EventRecord{RawSample: kprobe(5)}))
+}
@@ -15,6 +15,14 @@ import (
 	"github.com/spf13/viper"
 )
 
+// Behaviors of the event queues of the gRPC clients and exporters when they
+// are full, set by --event-queue-overflow.
+const (
+	EventQueueOverflowDropNewest = "drop-newest"
+	EventQueueOverflowDropOldest = "drop-oldest"
+	EventQueueOverflowBlock      = "block"
+)
+
 type config struct {
 	Debug           bool
 	ProcFS          string
@@ -25,11 +33,14 @@ type config struct {
 	ForceSmallProgs bool
 	ForceLargeProgs bool
 
-	EnableCilium      bool
-	EnableProcessNs   bool
-	EnableProcessCred bool
-	EnableK8s         bool
-	K8sKubeConfigPath string
+	EnableCilium           bool
+	EnableProcessNs        bool
+	EnableProcessCred      bool
+	EnableProcessUsernames bool
+	EnableJVMPerfMaps      bool
+	EnableGoBuildInfo      bool
+	EnableK8s              bool
+	K8sKubeConfigPath      string
 
 	DisableKprobeMulti bool
 
@@ -40,26 +51,68 @@ type config struct {
 
 	LogOpts map[string]string
 
-	RBSize      int
-	RBSizeTotal int
-	RBQueueSize int
+	RBSize         int
+	RBSizeTotal    int
+	RBQueueSize    int
+	RBQueueWorkers int
+	RBWatermark    int
+	RBTransport    string
 
 	ProcessCacheSize int
 	DataCacheSize    int
+	DataEventMaxSize int
 
-	MetricsServer      string
-	MetricsLabelFilter map[string]interface{}
-	ServerAddress      string
-	TracingPolicy      string
-	TracingPolicyDir   string
+	EnableShortLivedProcessTracking bool
+
+	MetricsServer         string
+	MetricsLabelFilter    map[string]interface{}
+	ServerAddress         string
+	ServerListeners       []string
+	TracingPolicy         string
+	TracingPolicyDir      string
+	TracingPolicyDryRun   bool
+	TracingPolicyDirWatch bool
 
 	ExportFilename             string
 	ExportFileMaxSizeMB        int
 	ExportFileRotationInterval time.Duration
 	ExportFileMaxBackups       int
+	ExportFileMaxAgeDays       int
 	ExportFileCompress         bool
 	ExportRateLimit            int
 	ExportFilePerm             string
+	ExportTimeFormat           string
+	ExportTimeZone             string
+	ExportFlatten              bool
+	ExportFlattenDepth         int
+	ExportFlattenArrays        string
+	ExportCloudEvents          bool
+	ExportIndex                bool
+	ExportIndexMaxSizeMB       int
+
+	ExportFailoverFilename      string
+	ExportFailoverRetryInterval time.Duration
+
+	ExportOTLPEndpoint      string
+	ExportOTLPInsecure      bool
+	ExportOTLPHeaders       map[string]string
+	ExportOTLPQueueSize     int
+	ExportOTLPBatchSize     int
+	ExportOTLPFlushInterval time.Duration
+
+	ExportSyslogAddress   string
+	ExportSyslogProtocol  string
+	ExportSyslogFormat    string
+	ExportSyslogFacility  int
+	ExportSyslogTLSCAFile string
+
+	ExportFluentAddress       string
+	ExportFluentTag           string
+	ExportFluentTLS           bool
+	ExportFluentRequireAck    bool
+	ExportFluentQueueSize     int
+	ExportFluentBatchSize     int
+	ExportFluentFlushInterval time.Duration
 
 	// Export aggregation options
 	EnableExportAggregation     bool
@@ -70,15 +123,21 @@ type config struct {
 	MemProfile string
 	PprofAddr  string
 
-	EventQueueSize uint
+	EventQueueSize     uint
+	EventQueueOverflow string
+
+	MemoryThrottleThreshold int
 
-	ReleasePinned bool
+	ReleasePinned  bool
+	StalePinnedBPF string
 
 	EnablePolicyFilter      bool
 	EnablePolicyFilterDebug bool
 
 	EnablePidSetFilter bool
 
+	RedactionFilters string
+
 	EnableMsgHandlingLatency bool
 
 	KMods []string
@@ -86,6 +145,39 @@ type config struct {
 	EnablePodInfo bool
 
 	ExposeKernelAddresses bool
+	StackTraceMapSize     int
+
+	MapFillCheckInterval time.Duration
+	MapFillThreshold     int
+	MapFillAutoResize    bool
+
+	ConfigSnapshotInterval time.Duration
+
+	EnableCapabilityUse       bool
+	CapabilityUseReportWindow time.Duration
+
+	EnableMiningDetection    bool
+	MiningDetectionThreshold float64
+	MiningDetectionPoolPorts []int
+
+	EnableSshDetection   bool
+	SshDetectionBinaries []string
+
+	EnableAuthFilePolicy          bool
+	AuthFilePolicyExcludeBinaries []string
+
+	EnablePackageManagerAttribution bool
+	PackageManagerPaths             []string
+
+	ProcessEventsSource    string
+	ProcFSFallbackInterval time.Duration
+
+	ClusterName string
+
+	EventAnnotationTokenFile string
+
+	EventForwardVsockPort uint32
+	EventReceiveVsockPort uint32
 }
 
 var (
@@ -25,6 +25,7 @@ const (
 	KeyVerbosity        = "verbose"
 	KeyProcessCacheSize = "process-cache-size"
 	KeyDataCacheSize    = "data-cache-size"
+	KeyDataEventMaxSize = "data-event-max-size"
 	KeyForceSmallProgs  = "force-small-progs"
 	KeyForceLargeProgs  = "force-large-progs"
 
@@ -35,26 +36,67 @@ const (
 	KeyK8sKubeConfigPath      = "k8s-kubeconfig-path"
 	KeyEnableProcessAncestors = "enable-process-ancestors"
 
-	KeyMetricsServer      = "metrics-server"
-	KeyMetricsLabelFilter = "metrics-label-filter"
-	KeyServerAddress      = "server-address"
-	KeyGopsAddr           = "gops-address"
-	KeyEnableProcessCred  = "enable-process-cred"
-	KeyEnableProcessNs    = "enable-process-ns"
-	KeyTracingPolicy      = "tracing-policy"
-	KeyTracingPolicyDir   = "tracing-policy-dir"
+	KeyMetricsServer          = "metrics-server"
+	KeyMetricsLabelFilter     = "metrics-label-filter"
+	KeyServerAddress          = "server-address"
+	KeyServerListeners        = "server-listeners"
+	KeyGopsAddr               = "gops-address"
+	KeyEnableProcessCred      = "enable-process-cred"
+	KeyEnableProcessNs        = "enable-process-ns"
+	KeyEnableProcessUsernames = "enable-process-usernames"
+	KeyEnableGoBuildInfo      = "enable-process-go-build-info"
+	KeyEnableJVMPerfMaps      = "enable-jvm-perf-maps"
+	KeyTracingPolicy          = "tracing-policy"
+	KeyTracingPolicyDir       = "tracing-policy-dir"
+	KeyTracingPolicyDryRun    = "tracing-policy-dry-run"
+	KeyTracingPolicyDirWatch  = "tracing-policy-dir-watch"
 
 	KeyCpuProfile = "cpuprofile"
 	KeyMemProfile = "memprofile"
 	KeyPprofAddr  = "pprof-addr"
 
+	KeyEnableShortLivedProcessTracking = "enable-short-lived-process-tracking"
+
 	KeyExportFilename             = "export-filename"
 	KeyExportFileMaxSizeMB        = "export-file-max-size-mb"
 	KeyExportFileRotationInterval = "export-file-rotation-interval"
 	KeyExportFileMaxBackups       = "export-file-max-backups"
+	KeyExportFileMaxAgeDays       = "export-file-max-age-days"
 	KeyExportFileCompress         = "export-file-compress"
 	KeyExportRateLimit            = "export-rate-limit"
 	KeyExportFilePerm             = "export-file-perm"
+	KeyExportTimeFormat           = "export-time-format"
+	KeyExportTimeZone             = "export-time-zone"
+	KeyExportFlatten              = "export-flatten"
+	KeyExportFlattenDepth         = "export-flatten-depth"
+	KeyExportFlattenArrays        = "export-flatten-arrays"
+	KeyExportCloudEvents          = "export-cloudevents"
+	KeyExportIndex                = "export-index"
+	KeyExportIndexMaxSizeMB       = "export-index-max-size-mb"
+
+	KeyExportFailoverFilename      = "export-failover-filename"
+	KeyExportFailoverRetryInterval = "export-failover-retry-interval"
+
+	KeyExportOTLPEndpoint      = "export-otlp-endpoint"
+	KeyExportOTLPInsecure      = "export-otlp-insecure"
+	KeyExportOTLPHeaders       = "export-otlp-headers"
+	KeyExportOTLPQueueSize     = "export-otlp-queue-size"
+	KeyExportOTLPBatchSize     = "export-otlp-batch-size"
+	KeyExportOTLPFlushInterval = "export-otlp-flush-interval"
+
+	KeyExportSyslogAddress   = "export-syslog-address"
+	KeyExportSyslogProtocol  = "export-syslog-protocol"
+	KeyExportSyslogFormat    = "export-syslog-format"
+	KeyExportSyslogFacility  = "export-syslog-facility"
+	KeyExportSyslogTLSCAFile = "export-syslog-tls-ca-file"
+
+	KeyExportFluentAddress       = "export-fluent-address"
+	KeyExportFluentTag           = "export-fluent-tag"
+	KeyExportFluentTLS           = "export-fluent-tls"
+	KeyExportFluentRequireAck    = "export-fluent-require-ack"
+	KeyExportFluentQueueSize     = "export-fluent-queue-size"
+	KeyExportFluentBatchSize     = "export-fluent-batch-size"
+	KeyExportFluentFlushInterval = "export-fluent-flush-interval"
 
 	KeyEnableExportAggregation     = "enable-export-aggregation"
 	KeyExportAggregationWindowSize = "export-aggregation-window-size"
@@ -63,19 +105,27 @@ const (
 	KeyExportAllowlist = "export-allowlist"
 	KeyExportDenylist  = "export-denylist"
 
-	KeyFieldFilters = "field-filters"
+	KeyFieldFilters     = "field-filters"
+	KeyRedactionFilters = "redaction-filters"
 
 	KeyNetnsDir = "netns-dir"
 
 	KeyDisableKprobeMulti = "disable-kprobe-multi"
 
-	KeyRBSize      = "rb-size"
-	KeyRBSizeTotal = "rb-size-total"
-	KeyRBQueueSize = "rb-queue-size"
+	KeyRBSize         = "rb-size"
+	KeyRBSizeTotal    = "rb-size-total"
+	KeyRBQueueSize    = "rb-queue-size"
+	KeyRBQueueWorkers = "rb-queue-workers"
+	KeyRBWatermark    = "rb-watermark"
+	KeyRBTransport    = "rb-transport"
+
+	KeyEventQueueSize     = "event-queue-size"
+	KeyEventQueueOverflow = "event-queue-overflow"
 
-	KeyEventQueueSize = "event-queue-size"
+	KeyMemoryThrottleThreshold = "memory-throttle-threshold"
 
 	KeyReleasePinnedBPF = "release-pinned-bpf"
+	KeyStalePinnedBPF   = "stale-pinned-bpf"
 
 	KeyEnablePolicyFilter      = "enable-policy-filter"
 	KeyEnablePolicyFilterDebug = "enable-policy-filter-debug"
@@ -89,6 +139,39 @@ const (
 	KeyEnablePodInfo = "enable-pod-info"
 
 	KeyExposeKernelAddresses = "expose-kernel-addresses"
+	KeyStackTraceMapSize     = "stack-trace-map-size"
+
+	KeyMapFillCheckInterval = "map-fill-check-interval"
+	KeyMapFillThreshold     = "map-fill-threshold"
+	KeyMapFillAutoResize    = "map-fill-auto-resize"
+
+	KeyConfigSnapshotInterval = "config-snapshot-interval"
+
+	KeyEnableCapabilityUse       = "enable-capability-use"
+	KeyCapabilityUseReportWindow = "capability-use-report-window"
+
+	KeyEnableMiningDetection    = "enable-mining-detection"
+	KeyMiningDetectionThreshold = "mining-detection-threshold"
+	KeyMiningDetectionPoolPorts = "mining-detection-pool-ports"
+
+	KeyEnableSshDetection   = "enable-ssh-detection"
+	KeySshDetectionBinaries = "ssh-detection-binaries"
+
+	KeyEnableAuthFilePolicy          = "enable-auth-file-policy"
+	KeyAuthFilePolicyExcludeBinaries = "auth-file-policy-exclude-binaries"
+
+	KeyEnablePackageManagerAttribution = "enable-package-manager-attribution"
+	KeyPackageManagerPaths             = "package-manager-paths"
+
+	KeyProcessEventsSource    = "process-events-source"
+	KeyProcFSFallbackInterval = "procfs-fallback-interval"
+
+	KeyClusterName = "cluster-name"
+
+	KeyEventAnnotationTokenFile = "event-annotation-token-file"
+
+	KeyEventForwardVsockPort = "event-forward-vsock-port"
+	KeyEventReceiveVsockPort = "event-receive-vsock-port"
 )
 
 func ReadAndSetFlags() error {
@@ -103,6 +186,10 @@ func ReadAndSetFlags() error {
 
 	Config.EnableProcessCred = viper.GetBool(KeyEnableProcessCred)
 	Config.EnableProcessNs = viper.GetBool(KeyEnableProcessNs)
+	Config.EnableProcessUsernames = viper.GetBool(KeyEnableProcessUsernames)
+	Config.EnableGoBuildInfo = viper.GetBool(KeyEnableGoBuildInfo)
+	Config.EnableJVMPerfMaps = viper.GetBool(KeyEnableJVMPerfMaps)
+	Config.EnableShortLivedProcessTracking = viper.GetBool(KeyEnableShortLivedProcessTracking)
 	Config.EnableK8s = viper.GetBool(KeyEnableK8sAPI)
 	Config.K8sKubeConfigPath = viper.GetString(KeyK8sKubeConfigPath)
 
@@ -119,6 +206,14 @@ func ReadAndSetFlags() error {
 	if Config.RBQueueSize, err = strutils.ParseSize(viper.GetString(KeyRBQueueSize)); err != nil {
 		return fmt.Errorf("failed to parse rb-queue-size value: %s", err)
 	}
+	Config.RBQueueWorkers = viper.GetInt(KeyRBQueueWorkers)
+	if Config.RBQueueWorkers < 1 {
+		return fmt.Errorf("invalid rb-queue-workers value: %d, must be at least 1", Config.RBQueueWorkers)
+	}
+	if Config.RBWatermark, err = strutils.ParseSize(viper.GetString(KeyRBWatermark)); err != nil {
+		return fmt.Errorf("failed to parse rb-watermark value: %s", err)
+	}
+	Config.RBTransport = viper.GetString(KeyRBTransport)
 
 	Config.GopsAddr = viper.GetString(KeyGopsAddr)
 
@@ -128,18 +223,60 @@ func ReadAndSetFlags() error {
 
 	Config.ProcessCacheSize = viper.GetInt(KeyProcessCacheSize)
 	Config.DataCacheSize = viper.GetInt(KeyDataCacheSize)
+	Config.DataEventMaxSize = viper.GetInt(KeyDataEventMaxSize)
 
 	Config.MetricsServer = viper.GetString(KeyMetricsServer)
 	Config.MetricsLabelFilter = ParseMetricsLabelFilter(viper.GetString(KeyMetricsLabelFilter))
 	Config.ServerAddress = viper.GetString(KeyServerAddress)
+	Config.ServerListeners = viper.GetStringSlice(KeyServerListeners)
 
 	Config.ExportFilename = viper.GetString(KeyExportFilename)
 	Config.ExportFileMaxSizeMB = viper.GetInt(KeyExportFileMaxSizeMB)
 	Config.ExportFileRotationInterval = viper.GetDuration(KeyExportFileRotationInterval)
 	Config.ExportFileMaxBackups = viper.GetInt(KeyExportFileMaxBackups)
+	Config.ExportFileMaxAgeDays = viper.GetInt(KeyExportFileMaxAgeDays)
 	Config.ExportFileCompress = viper.GetBool(KeyExportFileCompress)
 	Config.ExportRateLimit = viper.GetInt(KeyExportRateLimit)
 	Config.ExportFilePerm = viper.GetString(KeyExportFilePerm)
+	Config.ExportTimeFormat = viper.GetString(KeyExportTimeFormat)
+	Config.ExportTimeZone = viper.GetString(KeyExportTimeZone)
+	Config.ExportFlatten = viper.GetBool(KeyExportFlatten)
+	Config.ExportFlattenDepth = viper.GetInt(KeyExportFlattenDepth)
+	Config.ExportFlattenArrays = viper.GetString(KeyExportFlattenArrays)
+	Config.ExportCloudEvents = viper.GetBool(KeyExportCloudEvents)
+	Config.ExportIndex = viper.GetBool(KeyExportIndex)
+	Config.ExportIndexMaxSizeMB = viper.GetInt(KeyExportIndexMaxSizeMB)
+
+	Config.ExportFailoverFilename = viper.GetString(KeyExportFailoverFilename)
+	Config.ExportFailoverRetryInterval = viper.GetDuration(KeyExportFailoverRetryInterval)
+
+	Config.ExportOTLPEndpoint = viper.GetString(KeyExportOTLPEndpoint)
+	Config.ExportOTLPInsecure = viper.GetBool(KeyExportOTLPInsecure)
+	Config.ExportOTLPHeaders = make(map[string]string)
+	for _, header := range viper.GetStringSlice(KeyExportOTLPHeaders) {
+		key, value, found := strings.Cut(header, "=")
+		if !found || key == "" {
+			return fmt.Errorf("invalid OTLP export header '%s', expected key=value", header)
+		}
+		Config.ExportOTLPHeaders[key] = value
+	}
+	Config.ExportOTLPQueueSize = viper.GetInt(KeyExportOTLPQueueSize)
+	Config.ExportOTLPBatchSize = viper.GetInt(KeyExportOTLPBatchSize)
+	Config.ExportOTLPFlushInterval = viper.GetDuration(KeyExportOTLPFlushInterval)
+
+	Config.ExportSyslogAddress = viper.GetString(KeyExportSyslogAddress)
+	Config.ExportSyslogProtocol = viper.GetString(KeyExportSyslogProtocol)
+	Config.ExportSyslogFormat = viper.GetString(KeyExportSyslogFormat)
+	Config.ExportSyslogFacility = viper.GetInt(KeyExportSyslogFacility)
+	Config.ExportSyslogTLSCAFile = viper.GetString(KeyExportSyslogTLSCAFile)
+
+	Config.ExportFluentAddress = viper.GetString(KeyExportFluentAddress)
+	Config.ExportFluentTag = viper.GetString(KeyExportFluentTag)
+	Config.ExportFluentTLS = viper.GetBool(KeyExportFluentTLS)
+	Config.ExportFluentRequireAck = viper.GetBool(KeyExportFluentRequireAck)
+	Config.ExportFluentQueueSize = viper.GetInt(KeyExportFluentQueueSize)
+	Config.ExportFluentBatchSize = viper.GetInt(KeyExportFluentBatchSize)
+	Config.ExportFluentFlushInterval = viper.GetDuration(KeyExportFluentFlushInterval)
 
 	Config.EnableExportAggregation = viper.GetBool(KeyEnableExportAggregation)
 	Config.ExportAggregationWindowSize = viper.GetDuration(KeyExportAggregationWindowSize)
@@ -150,23 +287,77 @@ func ReadAndSetFlags() error {
 	Config.PprofAddr = viper.GetString(KeyPprofAddr)
 
 	Config.EventQueueSize = viper.GetUint(KeyEventQueueSize)
+	Config.EventQueueOverflow = viper.GetString(KeyEventQueueOverflow)
+	switch Config.EventQueueOverflow {
+	case "", EventQueueOverflowDropNewest, EventQueueOverflowDropOldest, EventQueueOverflowBlock:
+	default:
+		return fmt.Errorf("invalid value for --%s: %q, expected one of %s, %s, %s", KeyEventQueueOverflow,
+			Config.EventQueueOverflow, EventQueueOverflowDropNewest, EventQueueOverflowDropOldest, EventQueueOverflowBlock)
+	}
+	Config.MemoryThrottleThreshold = viper.GetInt(KeyMemoryThrottleThreshold)
 
 	Config.ReleasePinned = viper.GetBool(KeyReleasePinnedBPF)
+	Config.StalePinnedBPF = viper.GetString(KeyStalePinnedBPF)
 	Config.EnablePolicyFilter = viper.GetBool(KeyEnablePolicyFilter)
 	Config.EnablePolicyFilterDebug = viper.GetBool(KeyEnablePolicyFilterDebug)
 	Config.EnableMsgHandlingLatency = viper.GetBool(KeyEnableMsgHandlingLatency)
 
 	Config.EnablePidSetFilter = viper.GetBool(KeyEnablePidSetFilter)
 
+	Config.RedactionFilters = viper.GetString(KeyRedactionFilters)
+
 	Config.TracingPolicyDir = viper.GetString(KeyTracingPolicyDir)
+	Config.TracingPolicyDirWatch = viper.GetBool(KeyTracingPolicyDirWatch)
 
 	Config.KMods = viper.GetStringSlice(KeyKmods)
 
 	Config.EnablePodInfo = viper.GetBool(KeyEnablePodInfo)
 
 	Config.TracingPolicy = viper.GetString(KeyTracingPolicy)
+	Config.TracingPolicyDryRun = viper.GetBool(KeyTracingPolicyDryRun)
 
 	Config.ExposeKernelAddresses = viper.GetBool(KeyExposeKernelAddresses)
+	Config.StackTraceMapSize = viper.GetInt(KeyStackTraceMapSize)
+
+	Config.MapFillCheckInterval = viper.GetDuration(KeyMapFillCheckInterval)
+	Config.MapFillThreshold = viper.GetInt(KeyMapFillThreshold)
+	Config.MapFillAutoResize = viper.GetBool(KeyMapFillAutoResize)
+
+	Config.ConfigSnapshotInterval = viper.GetDuration(KeyConfigSnapshotInterval)
+
+	Config.EnableCapabilityUse = viper.GetBool(KeyEnableCapabilityUse)
+	Config.CapabilityUseReportWindow = viper.GetDuration(KeyCapabilityUseReportWindow)
+
+	Config.EnableMiningDetection = viper.GetBool(KeyEnableMiningDetection)
+	Config.MiningDetectionThreshold = viper.GetFloat64(KeyMiningDetectionThreshold)
+	Config.MiningDetectionPoolPorts = viper.GetIntSlice(KeyMiningDetectionPoolPorts)
+
+	Config.EnableSshDetection = viper.GetBool(KeyEnableSshDetection)
+	Config.SshDetectionBinaries = viper.GetStringSlice(KeySshDetectionBinaries)
+
+	Config.EnableAuthFilePolicy = viper.GetBool(KeyEnableAuthFilePolicy)
+	Config.AuthFilePolicyExcludeBinaries = viper.GetStringSlice(KeyAuthFilePolicyExcludeBinaries)
+
+	Config.EnablePackageManagerAttribution = viper.GetBool(KeyEnablePackageManagerAttribution)
+	Config.PackageManagerPaths = viper.GetStringSlice(KeyPackageManagerPaths)
+
+	Config.ProcessEventsSource = viper.GetString(KeyProcessEventsSource)
+	Config.ProcFSFallbackInterval = viper.GetDuration(KeyProcFSFallbackInterval)
+
+	Config.ClusterName = viper.GetString(KeyClusterName)
+	// exec_ids are split on ':', see process.ParseExecID
+	if strings.Contains(Config.ClusterName, ":") {
+		return fmt.Errorf("invalid --%s value %q: must not contain ':'", KeyClusterName, Config.ClusterName)
+	}
+
+	Config.EventAnnotationTokenFile = viper.GetString(KeyEventAnnotationTokenFile)
+
+	Config.EventForwardVsockPort = viper.GetUint32(KeyEventForwardVsockPort)
+	Config.EventReceiveVsockPort = viper.GetUint32(KeyEventReceiveVsockPort)
+	// guests are resolved to the pods of their sandboxes
+	if Config.EventReceiveVsockPort != 0 && !Config.EnableK8s {
+		return fmt.Errorf("--%s requires --%s", KeyEventReceiveVsockPort, KeyEnableK8sAPI)
+	}
 
 	return nil
 }
@@ -190,15 +381,45 @@ func AddFlags(flags *pflag.FlagSet) {
 	flags.Int(KeyVerbosity, 0, "set verbosity level for eBPF verifier dumps. Pass 0 for silent, 1 for truncated logs, 2 for a full dump")
 	flags.Int(KeyProcessCacheSize, 65536, "Size of the process cache")
 	flags.Int(KeyDataCacheSize, 1024, "Size of the data events cache")
+	flags.Int(KeyDataEventMaxSize, 0, "Maximum size in bytes of the data reassembled from data events, larger data is truncated (0 for no limit)")
 	flags.Bool(KeyForceSmallProgs, false, "Force loading small programs, even in kernels with >= 5.3 versions")
 	flags.Bool(KeyForceLargeProgs, false, "Force loading large programs, even in kernels with < 5.3 versions")
 	flags.String(KeyExportFilename, "", "Filename for JSON export. Disabled by default")
 	flags.Int(KeyExportFileMaxSizeMB, 10, "Size in MB for rotating JSON export files")
 	flags.Duration(KeyExportFileRotationInterval, 0, "Interval at which to rotate JSON export files in addition to rotating them by size")
 	flags.Int(KeyExportFileMaxBackups, 5, "Number of rotated JSON export files to retain")
+	flags.Int(KeyExportFileMaxAgeDays, 0, "Number of days to retain rotated JSON export files. 0 to retain them regardless of their age")
 	flags.Bool(KeyExportFileCompress, false, "Compress rotated JSON export files")
 	flags.String(KeyExportFilePerm, defaults.DefaultLogsPermission, "Access permissions on JSON export files")
 	flags.Int(KeyExportRateLimit, -1, "Rate limit (per minute) for event export. Set to -1 to disable")
+	flags.String(KeyExportTimeFormat, "rfc3339", "Format of the timestamps of exported events: rfc3339, rfc3339nano (9 fractional digits) or unix-nano (nanoseconds since the epoch)")
+	flags.String(KeyExportTimeZone, "UTC", "Time zone of the timestamps of exported events in the rfc3339 formats (IANA name, or Local)")
+	flags.Bool(KeyExportFlatten, false, "Flatten the nested fields of exported events into dotted keys, e.g. process.pod.namespace")
+	flags.Int(KeyExportFlattenDepth, 0, "Maximum number of fields in the flattened keys of exported events, deeper fields are kept nested. Set to 0 for no limit")
+	flags.String(KeyExportFlattenArrays, "keep", "Flattening of the arrays of exported events: keep (JSON arrays of flattened objects) or index (dotted keys with the indexes of the elements)")
+	flags.Bool(KeyExportCloudEvents, false, "Wrap each exported event in a CloudEvents 1.0 envelope, whose type is the kind of the event, source the cluster and the node, and subject the pod")
+	flags.Bool(KeyExportIndex, false, "Maintain an index of the events of the JSON export files, in <export-filename>.idx, to speed up their lookups with tetra query")
+	flags.Int(KeyExportIndexMaxSizeMB, 16, "Size in MB of the index of the JSON export files, above which its oldest entries are removed")
+	flags.String(KeyExportFailoverFilename, "", "Filename for JSON export when writing to the export file fails. Disabled by default")
+	flags.Duration(KeyExportFailoverRetryInterval, 30*time.Second, "Interval at which to retry the export file while exporting to the failover file")
+	flags.String(KeyExportOTLPEndpoint, "", "OTLP/gRPC endpoint (host:port) of an OpenTelemetry collector to export events to as log records. Disabled by default")
+	flags.Bool(KeyExportOTLPInsecure, false, "Connect to the OTLP endpoint without TLS")
+	flags.StringSlice(KeyExportOTLPHeaders, nil, "Headers of the OTLP export requests, as key=value pairs (e.g. 'authorization=Bearer <token>')")
+	flags.Int(KeyExportOTLPQueueSize, 10000, "Number of events waiting to be exported to the OTLP endpoint, new events are dropped when the queue is full")
+	flags.Int(KeyExportOTLPBatchSize, 512, "Maximum number of events of an OTLP export request")
+	flags.Duration(KeyExportOTLPFlushInterval, time.Second, "Maximum time events wait before being exported to the OTLP endpoint")
+	flags.String(KeyExportSyslogAddress, "", "Address (host:port) of a syslog server or SIEM to export events to as syslog messages. Disabled by default")
+	flags.String(KeyExportSyslogProtocol, "udp", "Protocol of the syslog export: udp, tcp or tls")
+	flags.String(KeyExportSyslogFormat, "rfc5424", "Format of the syslog export: rfc5424 (fields in the structured data) or cef (ArcSight Common Event Format)")
+	flags.Int(KeyExportSyslogFacility, 16, "Facility of the exported syslog messages, from 0 to 23. Defaults to local0")
+	flags.String(KeyExportSyslogTLSCAFile, "", "CA certificates file to verify the syslog server with the tls protocol, instead of the system certificates")
+	flags.String(KeyExportFluentAddress, "", "Address (host:port) of the forward input of a fluentd or fluent-bit server to export events to. Disabled by default")
+	flags.String(KeyExportFluentTag, "tetragon", "Tag of the events exported to the fluent server")
+	flags.Bool(KeyExportFluentTLS, false, "Connect to the fluent server with TLS, verified with the system certificates")
+	flags.Bool(KeyExportFluentRequireAck, true, "Wait for the fluent server to acknowledge each batch of events, which is sent again otherwise")
+	flags.Int(KeyExportFluentQueueSize, 10000, "Number of events waiting to be exported to the fluent server, new events are dropped when the queue is full")
+	flags.Int(KeyExportFluentBatchSize, 512, "Maximum number of events of a forward message to the fluent server")
+	flags.Duration(KeyExportFluentFlushInterval, time.Second, "Maximum time events wait before being exported to the fluent server")
 	flags.String(KeyLogLevel, "info", "Set log level")
 	flags.String(KeyLogFormat, "text", "Set log format")
 	flags.Bool(KeyEnableK8sAPI, false, "Access Kubernetes API to associate Tetragon events with Kubernetes pods")
@@ -206,16 +427,27 @@ func AddFlags(flags *pflag.FlagSet) {
 	flags.Bool(KeyEnableProcessAncestors, true, "Include ancestors in process exec events")
 	flags.String(KeyMetricsServer, "", "Metrics server address (e.g. ':2112'). Disabled by default")
 	flags.String(KeyServerAddress, "localhost:54321", "gRPC server address (e.g. 'localhost:54321' or 'unix:///var/run/tetragon/tetragon.sock'")
+	flags.StringSlice(KeyServerListeners, nil, "Additional gRPC server addresses, with TLS settings required by TCP and abstract unix sockets (e.g. 'unix:///var/run/tetragon/exporter.sock' or '0.0.0.0:54322?tls-cert=/etc/tls/tls.crt&tls-key=/etc/tls/tls.key&tls-client-ca=/etc/tls/ca.crt')")
 	flags.String(KeyGopsAddr, "", "gops server address (e.g. 'localhost:8118'). Disabled by default")
 	flags.Bool(KeyEnableProcessCred, false, "Enable process_cred events")
 	flags.Bool(KeyEnableProcessNs, false, "Enable namespace information in process_exec and process_kprobe events")
+	flags.Bool(KeyEnableProcessUsernames, false, "Resolve the user and group names of processes from the /etc/passwd and /etc/group files of their mount namespace")
+	flags.Bool(KeyEnableGoBuildInfo, false, "Read the build information of the Go binaries executed by processes (Go version, main module and version control revision)")
+	flags.Bool(KeyEnableJVMPerfMaps, false, "Resolve the JIT compiled frames of the user stack traces of JVM processes from their perf maps (/tmp/perf-<pid>.map in their mount namespace)")
+	flags.Bool(KeyEnableShortLivedProcessTracking, false, "Guarantee the exec and exit events of short-lived processes: exit events wait for the exec events of their processes, exited processes stay longer in the process cache, and an exec event is synthesized for the exit events of unknown processes")
 	flags.Uint(KeyEventQueueSize, 10000, "Set the size of the internal event queue.")
+	flags.String(KeyEventQueueOverflow, EventQueueOverflowDropNewest, "What to do with the events of a gRPC client or exporter whose event queue is full: 'drop-newest' drops the new events, 'drop-oldest' drops the oldest events of the queue, and 'block' waits for the queue, stalling all the listeners")
+	flags.Int(KeyMemoryThrottleThreshold, 80, "Percentage of the memory cgroup limit from which the process cache and the event queues are shrunk to avoid being OOM-killed. Set to 0 to disable")
 
 	// Tracing policy file
 	flags.String(KeyTracingPolicy, "", "Tracing policy file to load at startup")
 
 	flags.String(KeyTracingPolicyDir, defaults.DefaultTpDir, "Directory from where to load Tracing Policies")
 
+	flags.Bool(KeyTracingPolicyDirWatch, false, "Watch the directory of --tracing-policy-dir, to load the policies of new files, reload the policies of modified files and unload the policies of removed files")
+
+	flags.Bool(KeyTracingPolicyDryRun, false, "Validate the tracing policies of --tracing-policy and --tracing-policy-dir without loading them, print the result as JSON and exit")
+
 	// Options for debugging/development, not visible to users
 	flags.String(KeyCpuProfile, "", "Store CPU profile into provided file")
 	flags.MarkHidden(KeyCpuProfile)
@@ -238,6 +470,9 @@ func AddFlags(flags *pflag.FlagSet) {
 	// Field filters options for export
 	flags.String(KeyFieldFilters, "", "Field filters for event exports")
 
+	// Redaction filters options for export and gRPC
+	flags.String(KeyRedactionFilters, "", "Redaction filters for event fields, applied to the exported events and the events sent to gRPC clients")
+
 	// Network namespace options
 	flags.String(KeyNetnsDir, "/var/run/docker/netns/", "Network namespace dir")
 
@@ -247,11 +482,14 @@ func AddFlags(flags *pflag.FlagSet) {
 	// Allow to specify perf ring buffer size
 	flags.String(KeyRBSizeTotal, "0", "Set perf ring buffer size in total for all cpus (default 65k per cpu, allows K/M/G suffix)")
 	flags.String(KeyRBSize, "0", "Set perf ring buffer size for single cpu (default 65k, allows K/M/G suffix)")
+	flags.String(KeyRBWatermark, "0", "Set perf ring buffer wakeup watermark, the number of bytes written in the buffer of a cpu before its events are read (default 0, read every event, allows K/M/G suffix)")
+	flags.String(KeyRBTransport, "perf", "Transport of the events of the BPF programs: 'perf' for per cpu perf ring buffers, or 'ringbuf' for a BPF ring buffer shared by all cpus, sized like the perf ring buffers in total, that falls back to 'perf' on kernels older than 6.1")
 
 	// Provide option to remove existing pinned BPF programs and maps in Tetragon's
 	// observer dir on startup. Useful for doing upgrades/downgrades. Set to false to
 	// disable.
 	flags.Bool(KeyReleasePinnedBPF, true, "Release all pinned BPF programs and maps in Tetragon BPF directory. Enabled by default. Set to false to disable")
+	flags.String(KeyStalePinnedBPF, "keep", "What to do with the BPF programs and maps pinned by previous runs when release-pinned-bpf is disabled: 'keep' keeps them all, 'adopt' reuses the ones of the base sensor and removes the others, and 'remove' removes them all")
 
 	// Provide option to enable policy filtering. Because the code is new,
 	// this is set to false by default.
@@ -266,8 +504,42 @@ func AddFlags(flags *pflag.FlagSet) {
 	flags.StringSlice(KeyKmods, []string{}, "List of kernel modules to load symbols from")
 
 	flags.String(KeyRBQueueSize, "65535", "Set size of channel between ring buffer and sensor go routines (default 65k, allows K/M/G suffix)")
+	flags.Int(KeyRBQueueWorkers, 1, "Number of goroutines processing the events of the ring buffer. The events of a process are always processed in order by the same goroutine, and the queue size is split between the goroutines")
 
 	flags.Bool(KeyEnablePodInfo, false, "Enable PodInfo custom resource")
 
 	flags.Bool(KeyExposeKernelAddresses, false, "Expose real kernel addresses in events stack traces")
+	flags.Int(KeyStackTraceMapSize, 32768, "Maximum number of distinct stack traces stored per kprobe sensor")
+
+	flags.Duration(KeyMapFillCheckInterval, time.Minute, "Interval at which to check the fill levels of the BPF maps of tracing policies. Set to 0 to disable")
+	flags.Int(KeyMapFillThreshold, 90, "Percentage of the maximum entries of a BPF map of a tracing policy above which a MapFill event is emitted")
+	flags.Bool(KeyMapFillAutoResize, false, "Double the maximum entries of the BPF maps of tracing policies that go above the fill threshold, the next time their policies are enabled")
+
+	flags.Duration(KeyConfigSnapshotInterval, time.Hour, "Interval at which to send a ConfigSnapshot event with the loaded tracing policies, the agent flags and the kernel info. Snapshots are also sent when the configuration changes. Set to 0 to disable")
+
+	flags.Bool(KeyEnableCapabilityUse, false, "Load the built-in capability-use policy, that reports the capabilities that processes use")
+	flags.Duration(KeyCapabilityUseReportWindow, 24*time.Hour, "Window of the capabilities used by workloads that are kept for capability reports, with --enable-capability-use")
+
+	flags.Bool(KeyEnableMiningDetection, false, "Load the built-in crypto-mining policy, and report the processes suspected of mining in MiningSuspected events")
+	flags.Float64(KeyMiningDetectionThreshold, 0.5, "Confidence, between 0 and 1, above which processes are reported as suspected of mining, with --enable-mining-detection")
+	flags.IntSlice(KeyMiningDetectionPoolPorts, []int{3333, 4444, 5555, 7777, 14433, 14444, 45560, 45700}, "Ports of mining pools whose connections are a mining signal, with --enable-mining-detection")
+
+	flags.Bool(KeyEnableSshDetection, false, "Load the built-in SSH policy, and report the connections of the SSH clients in SshConnection events")
+	flags.StringSlice(KeySshDetectionBinaries, []string{"/usr/bin/ssh", "/bin/ssh", "/usr/local/bin/ssh"}, "Absolute paths of the SSH client binaries whose connections are reported, with --enable-ssh-detection")
+
+	flags.Bool(KeyEnableAuthFilePolicy, false, "Load the built-in auth-file-access policy, that reports the changes to the shadow files, the sudoers and PAM configurations and the SSH authorized keys, and the reads of the shadow files")
+	flags.StringSlice(KeyAuthFilePolicyExcludeBinaries, nil, "Absolute paths of binaries whose accesses are not reported by the auth-file-access policy, in addition to the package managers and sssd, with --enable-auth-file-policy")
+
+	flags.Bool(KeyEnablePackageManagerAttribution, false, "Set the package manager, dpkg, rpm or apk, of the kprobe and LSM events on files under the package manager paths when the process or one of its ancestors is a package manager, to mark the package-managed changes")
+	flags.StringSlice(KeyPackageManagerPaths, []string{"/bin", "/boot", "/etc", "/lib", "/lib32", "/lib64", "/opt", "/sbin", "/usr"}, "System paths whose changes are attributed to the package managers, with --enable-package-manager-attribution")
+
+	flags.String(KeyProcessEventsSource, "auto", "Source of the process exec and exit events: bpf, proc-connector (netlink proc connector, requires CAP_NET_ADMIN), procfs (polling procfs), or auto (bpf, falling back to proc-connector and then to procfs if the BPF programs of the exec sensor cannot be loaded)")
+	flags.Duration(KeyProcFSFallbackInterval, time.Second, "Interval at which to poll procfs for process exec and exit events, when procfs is the source of process events. Set to 0 to disable the fallback to procfs")
+
+	flags.String(KeyClusterName, "", "Name of the cluster where Tetragon is running. If set, it is included in process exec_ids to make them unique across clusters. Must not contain ':'")
+
+	flags.String(KeyEventAnnotationTokenFile, "", "File containing the bearer token that clients must present to annotate events. Event annotations are disabled if not set")
+
+	flags.Uint32(KeyEventForwardVsockPort, 0, "Forward all events to the host agent on this vsock port, when running inside a VM guest (e.g., Kata containers). Disabled if 0")
+	flags.Uint32(KeyEventReceiveVsockPort, 0, "Receive the events forwarded by agents running inside VM guests on this vsock port, and attribute them to the pods of the guest sandboxes, resolved from the hypervisor processes. Requires the Kubernetes API. Disabled if 0")
 }
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package pkgmanager attributes the file changes under the system paths to
+// the package managers. A change is package-managed when the process that
+// made it, or one of its ancestors, is dpkg, rpm or apk, or a front-end that
+// runs them in process, like dnf or yum for rpm.
+package pkgmanager
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/option"
+	"github.com/cilium/tetragon/pkg/process"
+)
+
+// maxAncestors bounds the number of ancestors that are walked to find a
+// package manager.
+const maxAncestors = 32
+
+// managers maps the base names of the binaries of the package managers to
+// the package manager whose database they update.
+var managers = map[string]string{
+	"dpkg":     "dpkg",
+	"rpm":      "rpm",
+	"dnf":      "rpm",
+	"dnf-3":    "rpm",
+	"microdnf": "rpm",
+	"yum":      "rpm",
+	"zypper":   "rpm",
+	"apk":      "apk",
+}
+
+// manager returns the package manager of a binary, or "" if it is not one.
+func manager(binary string) string {
+	return managers[filepath.Base(binary)]
+}
+
+// underPaths returns true if path is one of paths or is in one of their
+// directories.
+func underPaths(path string, paths []string) bool {
+	for _, p := range paths {
+		p = strings.TrimSuffix(p, "/")
+		if path == p || strings.HasPrefix(path, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// changesPaths returns true if one of the file or path arguments of an event
+// is under paths.
+func changesPaths(args []*tetragon.KprobeArgument, paths []string) bool {
+	for _, arg := range args {
+		var path string
+		switch a := arg.GetArg().(type) {
+		case *tetragon.KprobeArgument_FileArg:
+			path = a.FileArg.GetPath()
+		case *tetragon.KprobeArgument_PathArg:
+			path = a.PathArg.GetPath()
+		default:
+			continue
+		}
+		if underPaths(path, paths) {
+			return true
+		}
+	}
+	return false
+}
+
+// fromAncestry returns the package manager among proc and its ancestors,
+// which are looked up with parent, or "" if there is none.
+func fromAncestry(proc *tetragon.Process, parent func(execID string) *tetragon.Process) string {
+	for i := 0; proc != nil && i <= maxAncestors; i++ {
+		if m := manager(proc.GetBinary()); m != "" {
+			return m
+		}
+		if proc.GetParentExecId() == "" {
+			break
+		}
+		proc = parent(proc.GetParentExecId())
+	}
+	return ""
+}
+
+func cachedProcess(execID string) *tetragon.Process {
+	proc, err := process.Get(execID)
+	if err != nil {
+		return nil
+	}
+	return proc.UnsafeGetProcess()
+}
+
+// Attribute returns the package manager that made the change of a file under
+// the system paths of an event with args, by proc, or "" if the change is not
+// package-managed or the attribution is disabled.
+func Attribute(proc *tetragon.Process, args []*tetragon.KprobeArgument) string {
+	if !option.Config.EnablePackageManagerAttribution || proc == nil {
+		return ""
+	}
+	if !changesPaths(args, option.Config.PackageManagerPaths) {
+		return ""
+	}
+	return fromAncestry(proc, cachedProcess)
+}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package pkgmanager
+
+import (
+	"testing"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/stretchr/testify/assert"
+)
+
+var systemPaths = []string{"/bin", "/etc", "/lib", "/sbin", "/usr"}
+
+func fileArg(path string) *tetragon.KprobeArgument {
+	return &tetragon.KprobeArgument{Arg: &tetragon.KprobeArgument_FileArg{FileArg: &tetragon.KprobeFile{Path: path}}}
+}
+
+func TestChangesPaths(t *testing.T) {
+	assert.True(t, changesPaths([]*tetragon.KprobeArgument{fileArg("/usr/bin/curl")}, systemPaths))
+	assert.True(t, changesPaths([]*tetragon.KprobeArgument{
+		{Arg: &tetragon.KprobeArgument_IntArg{IntArg: 2}},
+		{Arg: &tetragon.KprobeArgument_PathArg{PathArg: &tetragon.KprobePath{Path: "/etc/passwd"}}},
+	}, systemPaths))
+	assert.True(t, changesPaths([]*tetragon.KprobeArgument{fileArg("/etc")}, []string{"/etc/"}))
+	assert.False(t, changesPaths([]*tetragon.KprobeArgument{fileArg("/etcetera/file")}, systemPaths))
+	assert.False(t, changesPaths([]*tetragon.KprobeArgument{fileArg("/home/user/.bashrc")}, systemPaths))
+	assert.False(t, changesPaths(nil, systemPaths))
+}
+
+func TestFromAncestry(t *testing.T) {
+	procs := map[string]*tetragon.Process{
+		"apt":      {ExecId: "apt", Binary: "/usr/bin/apt-get"},
+		"dpkg":     {ExecId: "dpkg", Binary: "/usr/bin/dpkg", ParentExecId: "apt"},
+		"postinst": {ExecId: "postinst", Binary: "/bin/sh", ParentExecId: "dpkg"},
+		"dnf":      {ExecId: "dnf", Binary: "/usr/bin/dnf-3"},
+		"rpm-cp":   {ExecId: "rpm-cp", Binary: "/usr/bin/cp", ParentExecId: "dnf"},
+		"bash":     {ExecId: "bash", Binary: "/bin/bash", ParentExecId: "missing"},
+		"cp":       {ExecId: "cp", Binary: "/usr/bin/cp", ParentExecId: "bash"},
+	}
+	parent := func(execID string) *tetragon.Process {
+		return procs[execID]
+	}
+
+	assert.Equal(t, "dpkg", fromAncestry(procs["dpkg"], parent))
+	assert.Equal(t, "dpkg", fromAncestry(&tetragon.Process{Binary: "/usr/bin/install", ParentExecId: "postinst"}, parent))
+	assert.Equal(t, "rpm", fromAncestry(procs["rpm-cp"], parent))
+	assert.Equal(t, "apk", fromAncestry(&tetragon.Process{Binary: "/sbin/apk"}, parent))
+	assert.Equal(t, "", fromAncestry(procs["cp"], parent))
+	assert.Equal(t, "", fromAncestry(procs["apt"], parent))
+}
+
+func TestFromAncestryLoop(t *testing.T) {
+	loop := &tetragon.Process{ExecId: "loop", Binary: "/bin/sh", ParentExecId: "loop"}
+	parent := func(string) *tetragon.Process {
+		return loop
+	}
+	assert.Equal(t, "", fromAncestry(loop, parent))
+}
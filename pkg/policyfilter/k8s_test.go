@@ -279,6 +279,25 @@ func (ts *testState) findCgroupID(podID PodID, containerID string) (CgroupID, er
 	return CgroupID(0), fmt.Errorf("unknown container id: %s", containerID)
 }
 
+func (ts *testState) containersCgroupIDs(t *testing.T, podName string, containerNames ...string) []uint64 {
+	var ret []uint64
+	testPod := ts.findPod(t, podName)
+	for _, contName := range containerNames {
+		found := false
+		for _, cont := range testPod.containers {
+			if cont.name == contName {
+				ret = append(ret, uint64(cont.cgID))
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("unknown container name: %s", contName)
+		}
+	}
+	return ret
+}
+
 func (ts *testState) podsCgroupIDs(t *testing.T, podNames ...string) []uint64 {
 	var ret []uint64
 	for _, podName := range podNames {
@@ -303,9 +322,9 @@ func (ts *testState) podsCgroupIDs(t *testing.T, podNames ...string) []uint64 {
 }
 
 func testNamespacePods(t *testing.T, st *state, ts *testState) {
-	err := st.AddPolicy(PolicyID(1), "ns1", nil)
+	err := st.AddPolicy(PolicyID(1), "ns1", nil, nil)
 	require.NoError(t, err)
-	err = st.AddPolicy(PolicyID(2), "ns2", nil)
+	err = st.AddPolicy(PolicyID(2), "ns2", nil, nil)
 	require.NoError(t, err)
 
 	emptyLabels := labels.Labels{}
@@ -366,7 +385,7 @@ func testPodLabelFilters(t *testing.T, st *state, ts *testState) {
 	matchesAllID := uint32(1)
 	matchesWebID := uint32(2)
 	matchesAppsID := uint32(3)
-	err := st.AddPolicy(PolicyID(matchesAllID), "", nil)
+	err := st.AddPolicy(PolicyID(matchesAllID), "", nil, nil)
 	require.NoError(t, err)
 	err = st.AddPolicy(PolicyID(matchesWebID), "", &slimv1.LabelSelector{
 		MatchExpressions: []slimv1.LabelSelectorRequirement{{
@@ -374,14 +393,14 @@ func testPodLabelFilters(t *testing.T, st *state, ts *testState) {
 			Operator: slimv1.LabelSelectorOpIn,
 			Values:   []string{"web"},
 		}},
-	})
+	}, nil)
 	require.NoError(t, err)
 	err = st.AddPolicy(PolicyID(matchesAppsID), "", &slimv1.LabelSelector{
 		MatchExpressions: []slimv1.LabelSelectorRequirement{{
 			Key:      "app",
 			Operator: slimv1.LabelSelectorOpExists,
 		}},
-	})
+	}, nil)
 	require.NoError(t, err)
 
 	// create pods
@@ -465,7 +484,7 @@ func testPreExistingPods(t *testing.T, st *state, ts *testState) {
 			Operator: slimv1.LabelSelectorOpIn,
 			Values:   []string{"web"},
 		}},
-	})
+	}, nil)
 	require.NoError(t, err)
 
 	requirePfmEqualTo(t, st.pfMap,
@@ -491,7 +510,7 @@ func testContainersChange(t *testing.T, st *state, ts *testState) {
 
 	// create policy
 	policyID := uint32(2)
-	err := st.AddPolicy(PolicyID(policyID), "", &slimv1.LabelSelector{})
+	err := st.AddPolicy(PolicyID(policyID), "", &slimv1.LabelSelector{}, nil)
 	require.NoError(t, err)
 
 	require.Equal(t, len(ts.podsCgroupIDs(t, "web")), 2)
@@ -523,6 +542,82 @@ func testContainersChange(t *testing.T, st *state, ts *testState) {
 	)
 }
 
+func testContainerFilters(t *testing.T, st *state, ts *testState) {
+	ts.createPod(t, "web", "default", labels.Labels{"app": "web"}, "web-c1", "web-c2")
+	ts.createPod(t, "db", "default", labels.Labels{"app": "db"}, "db-c1")
+	ts.waitForCallbacks(t)
+
+	// create policies
+	matchesC1ID := uint32(1)
+	matchesWebNotC1ID := uint32(2)
+	err := st.AddPolicy(PolicyID(matchesC1ID), "", nil, &slimv1.LabelSelector{
+		MatchLabels: map[string]slimv1.MatchLabelsValue{"name": "web-c1"},
+	})
+	require.NoError(t, err)
+	err = st.AddPolicy(PolicyID(matchesWebNotC1ID), "", &slimv1.LabelSelector{
+		MatchLabels: map[string]slimv1.MatchLabelsValue{"app": "web"},
+	}, &slimv1.LabelSelector{
+		MatchExpressions: []slimv1.LabelSelectorRequirement{{
+			Key:      "name",
+			Operator: slimv1.LabelSelectorOpNotIn,
+			Values:   []string{"web-c1"},
+		}},
+	})
+	require.NoError(t, err)
+
+	requirePfmEqualTo(t, st.pfMap,
+		map[uint64][]uint64{
+			uint64(matchesC1ID):       ts.containersCgroupIDs(t, "web", "web-c1"),
+			uint64(matchesWebNotC1ID): ts.containersCgroupIDs(t, "web", "web-c2"),
+		},
+	)
+
+	// new containers are added to the policies whose container selector matches them
+	ts.updatePodContainers(t, "web", "web-c1", "web-c3")
+	ts.updatePodContainers(t, "db", "db-c1", "web-c1")
+	ts.waitForCallbacks(t)
+	requirePfmEqualTo(t, st.pfMap,
+		map[uint64][]uint64{
+			uint64(matchesC1ID): append(
+				ts.containersCgroupIDs(t, "web", "web-c1"),
+				ts.containersCgroupIDs(t, "db", "web-c1")...),
+			uint64(matchesWebNotC1ID): ts.containersCgroupIDs(t, "web", "web-c3"),
+		},
+	)
+
+	// label changes only add the containers that match the container selector
+	ts.updatePodLabels(t, "db", labels.Labels{"app": "web"})
+	ts.waitForCallbacks(t)
+	requirePfmEqualTo(t, st.pfMap,
+		map[uint64][]uint64{
+			uint64(matchesC1ID): append(
+				ts.containersCgroupIDs(t, "web", "web-c1"),
+				ts.containersCgroupIDs(t, "db", "web-c1")...),
+			uint64(matchesWebNotC1ID): append(
+				ts.containersCgroupIDs(t, "web", "web-c3"),
+				ts.containersCgroupIDs(t, "db", "db-c1")...),
+		},
+	)
+
+	ts.deletePod(t, "web")
+	ts.deletePod(t, "db")
+	ts.waitForCallbacks(t)
+	requirePfmEqualTo(t, st.pfMap,
+		map[uint64][]uint64{
+			uint64(matchesC1ID):       {},
+			uint64(matchesWebNotC1ID): {},
+		},
+	)
+
+	err = st.DelPolicy(PolicyID(matchesC1ID))
+	require.NoError(t, err)
+	err = st.DelPolicy(PolicyID(matchesWebNotC1ID))
+	require.NoError(t, err)
+	requirePfmEqualTo(t, st.pfMap,
+		map[uint64][]uint64{},
+	)
+}
+
 // example taken from https://github.com/kubernetes/client-go/blob/04ef61f72b7bc5ae6efef4e4dc0001746637fdb3/examples/fake-client/main_test.go
 func TestK8s(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -604,4 +699,8 @@ func TestK8s(t *testing.T) {
 	t.Run("containers change", func(t *testing.T) {
 		testContainersChange(t, st, ts)
 	})
+
+	t.Run("container filters", func(t *testing.T) {
+		testContainerFilters(t, st, ts)
+	})
 }
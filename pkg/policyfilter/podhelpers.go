@@ -32,11 +32,14 @@ func containerIDFromContainerStatus(c *v1.ContainerStatus) string {
 	return ret
 }
 
-func podContainersIDs(pod *v1.Pod) []string {
-	ret := make([]string, 0)
+// podContainersIDs returns the ids of the running containers of a pod, and their names
+func podContainersIDs(pod *v1.Pod) ([]string, []string) {
+	ids := make([]string, 0)
+	names := make([]string, 0)
 	podForAllContainers(pod, func(c *v1.ContainerStatus) {
 		id := containerIDFromContainerStatus(c)
-		ret = append(ret, id)
+		ids = append(ids, id)
+		names = append(names, c.Name)
 	})
-	return ret
+	return ids, names
 }
@@ -31,6 +31,19 @@ const (
 	uidStringLen = len("00000000-0000-0000-0000-000000000000")
 )
 
+// containerNameAnnotations are the annotations that container runtimes use for the container name
+// (containerd and cri-o, respectively)
+var containerNameAnnotations = []string{"io.kubernetes.cri.container-name", "io.kubernetes.container.name"}
+
+func containerNameFromAnnotations(annotations map[string]string) string {
+	for _, key := range containerNameAnnotations {
+		if name, ok := annotations[key]; ok {
+			return name
+		}
+	}
+	return ""
+}
+
 func createContainerHook(_ context.Context, arg *rthooks.CreateContainerArg) error {
 	var err error
 
@@ -93,14 +106,16 @@ func createContainerHook(_ context.Context, arg *rthooks.CreateContainerArg) err
 	}
 
 	namespace := pod.ObjectMeta.Namespace
+	containerName := containerNameFromAnnotations(arg.Req.Annotations)
 	log.WithFields(logrus.Fields{
-		"pod-id":       podID,
-		"namespace":    namespace,
-		"container-id": containerID,
-		"cgroup-id":    cgID,
+		"pod-id":         podID,
+		"namespace":      namespace,
+		"container-id":   containerID,
+		"container-name": containerName,
+		"cgroup-id":      cgID,
 	}).Trace("policyfilter: add pod container")
 	cgid := policyfilter.CgroupID(cgID)
-	if err := pfState.AddPodContainer(policyfilter.PodID(podID), namespace, pod.Labels, containerID, cgid); err != nil {
+	if err := pfState.AddPodContainer(policyfilter.PodID(podID), namespace, pod.Labels, containerID, cgid, containerName); err != nil {
 		log.WithError(err).Warn("failed to update policy filter, aborting hook.")
 	}
 	policyfiltermetrics.OpInc("rthooks", "add-container", err)
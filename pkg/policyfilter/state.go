@@ -91,6 +91,7 @@ func (i PodID) String() string {
 type containerInfo struct {
 	id   string   // container id
 	cgID CgroupID // cgroup id
+	name string   // container name, "" if unknown
 }
 
 // podInfo contains the necessary information for each pod
@@ -104,14 +105,6 @@ type podInfo struct {
 	matchedPolicies []PolicyID
 }
 
-func (pod *podInfo) cgroupIDs() []CgroupID {
-	ret := make([]CgroupID, 0, len(pod.containers))
-	for i := range pod.containers {
-		ret = append(ret, pod.containers[i].cgID)
-	}
-	return ret
-}
-
 // delete containers from a pod based on their id, and return them
 // NB: in most cases there will be a single container, but we do not reject users adding a container
 // with the same id and different cgroup, so we return a list to cover all cases.
@@ -196,6 +189,9 @@ type policy struct {
 
 	podSelector labels.Selector
 
+	// containerSelector selects the containers of the matched pods
+	containerSelector labels.Selector
+
 	// polMap is the (inner) policy map for this policy
 	polMap polMap
 }
@@ -212,6 +208,26 @@ func (pol *policy) podInfoMatches(pod *podInfo) bool {
 	return pol.podMatches(pod.namespace, pod.labels)
 }
 
+// containerMatches checks whether the container selector of the policy matches a container. The
+// selector is matched against the container fields as labels (currently, only "name").
+func (pol *policy) containerMatches(container *containerInfo) bool {
+	return pol.containerSelector.Match(labels.Labels{
+		"name": container.name,
+	})
+}
+
+// matchingCgroupIDs returns the cgroup ids of the containers that match the container selector of
+// the policy
+func (pol *policy) matchingCgroupIDs(containers []containerInfo) []CgroupID {
+	ret := make([]CgroupID, 0, len(containers))
+	for i := range containers {
+		if pol.containerMatches(&containers[i]) {
+			ret = append(ret, containers[i].cgID)
+		}
+	}
+	return ret
+}
+
 // State holds the necessary state for policyfilter
 type state struct {
 	log logrus.FieldLogger
@@ -260,7 +276,7 @@ func newState(
 //revive:enable:unexported-return
 
 func (m *state) updatePodHandler(pod *v1.Pod) error {
-	containerIDs := podContainersIDs(pod)
+	containerIDs, containerNames := podContainersIDs(pod)
 	podID, err := uuid.Parse(string(pod.UID))
 	if err != nil {
 		m.log.WithError(err).WithField("pod-id", pod.UID).Warn("policyfilter, pod handler: failed to parse pod id")
@@ -268,7 +284,7 @@ func (m *state) updatePodHandler(pod *v1.Pod) error {
 	}
 
 	namespace := pod.Namespace
-	err = m.UpdatePod(PodID(podID), namespace, pod.Labels, containerIDs)
+	err = m.UpdatePod(PodID(podID), namespace, pod.Labels, containerIDs, containerNames)
 	if err != nil {
 		m.log.WithError(err).WithFields(logrus.Fields{
 			"pod-id":        podID,
@@ -377,7 +393,8 @@ func (m *state) delPod(id PodID) *podInfo {
 }
 
 // AddPolicy adds a policy
-func (m *state) AddPolicy(polID PolicyID, namespace string, podLabelSelector *slimv1.LabelSelector) error {
+func (m *state) AddPolicy(polID PolicyID, namespace string, podLabelSelector *slimv1.LabelSelector,
+	containerFieldSelector *slimv1.LabelSelector) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -389,10 +406,15 @@ func (m *state) AddPolicy(polID PolicyID, namespace string, podLabelSelector *sl
 	if err != nil {
 		return err
 	}
+	containerSelector, err := labels.SelectorFromLabelSelector(containerFieldSelector)
+	if err != nil {
+		return err
+	}
 	policy := policy{
-		id:          polID,
-		namespace:   namespace,
-		podSelector: podSelector,
+		id:                polID,
+		namespace:         namespace,
+		podSelector:       podSelector,
+		containerSelector: containerSelector,
 	}
 
 	cgroupIDs := make([]CgroupID, 0)
@@ -403,9 +425,7 @@ func (m *state) AddPolicy(polID PolicyID, namespace string, podLabelSelector *sl
 		if !policy.podInfoMatches(pod) {
 			continue
 		}
-		for cIdx := range pod.containers {
-			cgroupIDs = append(cgroupIDs, pod.containers[cIdx].cgID)
-		}
+		cgroupIDs = append(cgroupIDs, policy.matchingCgroupIDs(pod.containers)...)
 		matchedPods = append(matchedPods, pod)
 		pod.addCachedPolicy(policy.id)
 	}
@@ -463,12 +483,13 @@ func cgIDPointerStr(p *CgroupID) string {
 // It will update the state for all containers that do not exist.
 // It takes an optional argument of a list of cgroup ids (one per container). If this list is empty,
 // the function will try to figure out the cgroup id on its own.
-// Finally, it will scan over all the matching policies for the pod and update the policy maps.
-func (m *state) addPodContainers(pod *podInfo, containerIDs []string, cgroupIDs []CgroupID) {
+// It also takes an optional argument of a list of container names (one per container).
+// Finally, it will scan over all the matching policies for the pod and update the policy maps with
+// the containers that match their container selector.
+func (m *state) addPodContainers(pod *podInfo, containerIDs []string, cgroupIDs []CgroupID, containerNames []string) {
 	// Find the containers that do not exist in our state, and for those find the cgroup id if
 	// one does not exist.
 	cinfo := make([]containerInfo, 0, len(containerIDs))
-	cgIDs := make([]CgroupID, 0, len(containerIDs))
 	for i, contID := range containerIDs {
 		var cgIDptr *CgroupID
 		if len(cgroupIDs) > i {
@@ -498,8 +519,12 @@ func (m *state) addPodContainers(pod *podInfo, containerIDs []string, cgroupIDs
 			cgIDptr = &cgid
 		}
 
-		cinfo = append(cinfo, containerInfo{contID, *cgIDptr})
-		cgIDs = append(cgIDs, *cgIDptr)
+		var name string
+		if len(containerNames) > i {
+			name = containerNames[i]
+		}
+
+		cinfo = append(cinfo, containerInfo{contID, *cgIDptr, name})
 	}
 
 	if len(cinfo) == 0 {
@@ -531,11 +556,16 @@ func (m *state) addPodContainers(pod *podInfo, containerIDs []string, cgroupIDs
 			continue
 		}
 
+		cgIDs := pol.matchingCgroupIDs(cinfo)
+		if len(cgIDs) == 0 {
+			continue
+		}
+
 		if err := pol.polMap.addCgroupIDs(cgIDs); err != nil {
 			m.log.WithError(err).WithFields(logrus.Fields{
 				"policy-id":  pol.id,
 				"pod-id":     pod.id,
-				"cgroup-ids": cgroupIDs,
+				"cgroup-ids": cgIDs,
 			}).Warn("failed to update policy map")
 		}
 	}
@@ -560,9 +590,11 @@ func (m *state) addNewPod(podID PodID, namespace string, podLabels labels.Labels
 
 // AddPodContainer informs policyfilter about a new container in a pod.
 // if the cgroup id of the container is known, cgID is not nil and it contains its value.
+// containerName is the name of the container, or "" if it is not known.
 //
 // The pod might or might not have been encountered before.
-func (m *state) AddPodContainer(podID PodID, namespace string, podLabels labels.Labels, containerID string, cgID CgroupID) error {
+func (m *state) AddPodContainer(podID PodID, namespace string, podLabels labels.Labels,
+	containerID string, cgID CgroupID, containerName string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -580,7 +612,7 @@ func (m *state) AddPodContainer(podID PodID, namespace string, podLabels labels.
 		return fmt.Errorf("conflicting namespaces for pod with id %s: old='%s' vs new='%s'", podID, pod.namespace, namespace)
 	}
 
-	m.addPodContainers(pod, []string{containerID}, []CgroupID{cgID})
+	m.addPodContainers(pod, []string{containerID}, []CgroupID{cgID}, []string{containerName})
 	return nil
 }
 
@@ -592,23 +624,22 @@ func (m *state) delPodCgroupIDsFromPolicyMaps(pod *podInfo, containers []contain
 		return
 	}
 
-	cgroupIDs := make([]CgroupID, 0, len(containers))
-	for i := range containers {
-		cgroupIDs = append(cgroupIDs, containers[i].cgID)
-	}
-
 	// check what policies match the pod, and delete the cgroup ids
 	for _, policyID := range pod.matchedPolicies {
 		pol := m.findPolicy(policyID)
 		if pol == nil {
 			m.log.WithFields(logrus.Fields{
-				"policy-id":  policyID,
-				"pod-id":     pod.id,
-				"cgroup-ids": cgroupIDs,
+				"policy-id": policyID,
+				"pod-id":    pod.id,
 			}).Warn("delPodCgroupIDsFromPolicyMaps: unknown policy id found in pod. This should not happen, ignoring.")
 			continue
 		}
 
+		cgroupIDs := pol.matchingCgroupIDs(containers)
+		if len(cgroupIDs) == 0 {
+			continue
+		}
+
 		if err := pol.polMap.delCgroupIDs(cgroupIDs); err != nil {
 			// NB: depending on the error, we might want to schedule some retries here
 			m.log.WithError(err).WithFields(logrus.Fields{
@@ -701,8 +732,11 @@ func (m *state) applyPodPolicyDiff(pod *podInfo, polDiff *policiesDiffRes) {
 		return
 	}
 
-	cgroupIDs := pod.cgroupIDs()
 	for _, addPol := range polDiff.addedPolicies {
+		cgroupIDs := addPol.matchingCgroupIDs(pod.containers)
+		if len(cgroupIDs) == 0 {
+			continue
+		}
 		if err := addPol.polMap.addCgroupIDs(cgroupIDs); err != nil {
 			m.log.WithError(err).WithFields(logrus.Fields{
 				"policy-id":  addPol.id,
@@ -714,6 +748,10 @@ func (m *state) applyPodPolicyDiff(pod *podInfo, polDiff *policiesDiffRes) {
 	}
 
 	for _, delPol := range polDiff.deletedPolicies {
+		cgroupIDs := delPol.matchingCgroupIDs(pod.containers)
+		if len(cgroupIDs) == 0 {
+			continue
+		}
 		if err := delPol.polMap.delCgroupIDs(cgroupIDs); err != nil {
 			m.log.WithError(err).WithFields(logrus.Fields{
 				"policy-id":  delPol.id,
@@ -756,13 +794,15 @@ func (pod *podInfo) containerDiff(newContainerIDs []string) ([]string, []string)
 }
 
 // UpdatePod updates the pod state for a pod
-// containerIDs contains all the running container ids for the given pod.
+// containerIDs contains all the running container ids for the given pod, and containerNames their
+// names.
 // This function will:
 //   - remove the containers that are not part of the containerIDs list
 //   - add the ones that do not exist in the current state
 //
 // It is intended to be used from k8s watchers (where no cgroup information is available)
-func (m *state) UpdatePod(podID PodID, namespace string, podLabels labels.Labels, containerIDs []string) error {
+func (m *state) UpdatePod(podID PodID, namespace string, podLabels labels.Labels,
+	containerIDs []string, containerNames []string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -808,6 +848,17 @@ func (m *state) UpdatePod(podID PodID, namespace string, podLabels labels.Labels
 		m.delPodCgroupIDsFromPolicyMaps(pod, containers)
 	}
 
-	m.addPodContainers(pod, addIDs, nil)
+	addNames := make([]string, 0, len(addIDs))
+	for _, cid := range addIDs {
+		var name string
+		for i := range containerIDs {
+			if containerIDs[i] == cid && len(containerNames) > i {
+				name = containerNames[i]
+				break
+			}
+		}
+		addNames = append(addNames, name)
+	}
+	m.addPodContainers(pod, addIDs, nil, addNames)
 	return nil
 }
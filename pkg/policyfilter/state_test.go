@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"testing"
 
+	slimv1 "github.com/cilium/cilium/pkg/k8s/slim/k8s/apis/meta/v1"
+	"github.com/cilium/tetragon/pkg/labels"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
@@ -18,36 +20,36 @@ func TestState(t *testing.T) {
 	}
 	defer s.Close()
 
-	err = s.AddPolicy(PolicyID(1), "ns1", nil)
+	err = s.AddPolicy(PolicyID(1), "ns1", nil, nil)
 	require.NoError(t, err)
-	err = s.AddPolicy(PolicyID(2), "ns2", nil)
+	err = s.AddPolicy(PolicyID(2), "ns2", nil, nil)
 	require.NoError(t, err)
-	err = s.AddPolicy(PolicyID(3), "ns3", nil)
+	err = s.AddPolicy(PolicyID(3), "ns3", nil, nil)
 	require.NoError(t, err)
 
 	pod1 := PodID(uuid.New())
 	cgidi1 := CgroupID(2001)
-	err = s.AddPodContainer(pod1, "ns2", nil, "cont1", cgidi1)
+	err = s.AddPodContainer(pod1, "ns2", nil, "cont1", cgidi1, "")
 	require.NoError(t, err)
 	cgidi2 := CgroupID(2002)
-	err = s.AddPodContainer(pod1, "ns2", nil, "cont2", cgidi2)
+	err = s.AddPodContainer(pod1, "ns2", nil, "cont2", cgidi2, "")
 	require.NoError(t, err)
 
 	pod2 := PodID(uuid.New())
 	cgidi3 := CgroupID(1001)
-	err = s.AddPodContainer(pod2, "ns1", nil, "cont3", cgidi3)
+	err = s.AddPodContainer(pod2, "ns1", nil, "cont3", cgidi3, "")
 	require.NoError(t, err)
 
 	cgidi4 := CgroupID(3001)
 	pod3 := PodID(uuid.New())
-	err = s.AddPodContainer(pod3, "ns3", nil, "cont4", cgidi4)
+	err = s.AddPodContainer(pod3, "ns3", nil, "cont4", cgidi4, "")
 	require.NoError(t, err)
 	pod4 := PodID(uuid.New())
 	cgidi5 := CgroupID(3002)
-	err = s.AddPodContainer(pod4, "ns3", nil, "cont5", cgidi5)
+	err = s.AddPodContainer(pod4, "ns3", nil, "cont5", cgidi5, "")
 	require.NoError(t, err)
 	cgidi6 := CgroupID(3003)
-	err = s.AddPodContainer(pod4, "ns3", nil, "cont6", cgidi6)
+	err = s.AddPodContainer(pod4, "ns3", nil, "cont6", cgidi6, "")
 	require.NoError(t, err)
 
 	requirePfmEqualTo(t, s.pfMap, map[uint64][]uint64{
@@ -101,3 +103,34 @@ func TestState(t *testing.T) {
 	require.Len(t, s.policies, 0)
 	require.Len(t, s.pods, 0)
 }
+
+func TestPolicyContainerMatches(t *testing.T) {
+	containers := []containerInfo{
+		{id: "cont1", cgID: 1, name: "web"},
+		{id: "cont2", cgID: 2, name: "sidecar"},
+		{id: "cont3", cgID: 3},
+	}
+
+	sel, err := labels.SelectorFromLabelSelector(nil)
+	require.NoError(t, err)
+	pol := policy{containerSelector: sel}
+	require.Equal(t, []CgroupID{1, 2, 3}, pol.matchingCgroupIDs(containers))
+
+	sel, err = labels.SelectorFromLabelSelector(&slimv1.LabelSelector{
+		MatchLabels: map[string]slimv1.MatchLabelsValue{"name": "web"},
+	})
+	require.NoError(t, err)
+	pol = policy{containerSelector: sel}
+	require.Equal(t, []CgroupID{1}, pol.matchingCgroupIDs(containers))
+
+	sel, err = labels.SelectorFromLabelSelector(&slimv1.LabelSelector{
+		MatchExpressions: []slimv1.LabelSelectorRequirement{{
+			Key:      "name",
+			Operator: slimv1.LabelSelectorOpNotIn,
+			Values:   []string{"sidecar"},
+		}},
+	})
+	require.NoError(t, err)
+	pol = policy{containerSelector: sel}
+	require.Equal(t, []CgroupID{1, 3}, pol.matchingCgroupIDs(containers))
+}
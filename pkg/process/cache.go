@@ -12,6 +12,7 @@ import (
 	"github.com/cilium/tetragon/pkg/logger"
 	"github.com/cilium/tetragon/pkg/metrics/errormetrics"
 	"github.com/cilium/tetragon/pkg/metrics/mapmetrics"
+	"github.com/cilium/tetragon/pkg/option"
 	lru "github.com/hashicorp/golang-lru/v2"
 )
 
@@ -19,15 +20,28 @@ type Cache struct {
 	cache      *lru.Cache[string, *ProcessInternal]
 	size       int
 	deleteChan chan *ProcessInternal
+	shrinkChan chan shrinkRequest
 	stopChan   chan bool
 }
 
+// shrinkRequest asks the garbage collector to remove exited processes until
+// the cache holds at most size processes. The number of removed processes is
+// sent on done.
+type shrinkRequest struct {
+	size int
+	done chan int
+}
+
 // garbage collection states
 const (
 	inUse = iota
 	deletePending
 	deleteReady
 	deleted
+	// deleteDeferred is an additional state between deletePending and
+	// deleteReady when short-lived process tracking is enabled, so that
+	// exited processes stay in the cache for one more pass.
+	deleteDeferred
 )
 
 // garbage collection run interval
@@ -38,6 +52,7 @@ const (
 func (pc *Cache) cacheGarbageCollector() {
 	ticker := time.NewTicker(intervalGC)
 	pc.deleteChan = make(chan *ProcessInternal)
+	pc.shrinkChan = make(chan shrinkRequest)
 	pc.stopChan = make(chan bool)
 
 	go func() {
@@ -73,15 +88,40 @@ func (pc *Cache) cacheGarbageCollector() {
 					if ref != 0 {
 						continue
 					}
-					if p.color == deleteReady {
+					switch {
+					case p.color == deleteReady:
 						p.color = deleted
 						pc.remove(p.process)
-					} else {
+					case p.color == deletePending && option.Config.EnableShortLivedProcessTracking:
+						// Events of short-lived processes, e.g. the
+						// exec events of their children, may still
+						// be retried by the event cache.
+						newQueue = append(newQueue, p)
+						p.color = deleteDeferred
+					default:
 						newQueue = append(newQueue, p)
 						p.color = deleteReady
 					}
 				}
 				deleteQueue = newQueue
+			case req := <-pc.shrinkChan:
+				// Only the exited processes of the delete queue,
+				// oldest first, are removed early: processes still
+				// referenced may have events in flight.
+				removed := 0
+				newQueue = newQueue[:0]
+				for _, p := range deleteQueue {
+					if pc.cache.Len() > req.size && atomic.LoadUint32(&p.refcnt) == 0 {
+						p.color = deleted
+						if pc.remove(p.process) {
+							removed++
+						}
+						continue
+					}
+					newQueue = append(newQueue, p)
+				}
+				deleteQueue = newQueue
+				req.done <- removed
 			case p := <-pc.deleteChan:
 				// duplicate deletes can happen, if they do reset
 				// color to pending and move along. This will cause
@@ -174,6 +214,16 @@ func (pc *Cache) remove(process *tetragon.Process) bool {
 	return present
 }
 
+// shrink removes exited processes, which are otherwise removed by the
+// garbage collector after a few passes, until the cache holds at most size
+// processes. Processes that did not exit are never removed. It returns the
+// number of removed processes.
+func (pc *Cache) shrink(size int) int {
+	done := make(chan int)
+	pc.shrinkChan <- shrinkRequest{size: size, done: done}
+	return <-done
+}
+
 func (pc *Cache) len() int {
 	return pc.cache.Len()
 }
@@ -4,6 +4,7 @@
 package process
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/cilium/tetragon/api/v1/tetragon"
@@ -44,3 +45,38 @@ func TestProcessCache(t *testing.T) {
 	_, err = cache.get(proc.process.ExecId)
 	assert.Error(t, err)
 }
+
+func TestProcessCacheShrink(t *testing.T) {
+	cache, err := NewCache(10)
+	require.NoError(t, err)
+	defer cache.Purge()
+
+	var procs []*ProcessInternal
+	for i := 0; i < 4; i++ {
+		proc := &ProcessInternal{
+			process: &tetragon.Process{ExecId: fmt.Sprintf("process%d", i)},
+			refcnt:  1,
+		}
+		cache.add(proc)
+		procs = append(procs, proc)
+	}
+	// the first two processes exited
+	cache.refDec(procs[0])
+	cache.refDec(procs[1])
+
+	// running processes are never removed
+	assert.Equal(t, 2, cache.shrink(0))
+	assert.Equal(t, 2, cache.len())
+	_, err = cache.get("process0")
+	assert.Error(t, err)
+	_, err = cache.get("process2")
+	assert.NoError(t, err)
+
+	cache.refDec(procs[2])
+	cache.refDec(procs[3])
+	assert.Equal(t, 1, cache.shrink(1))
+	assert.Equal(t, 1, cache.len())
+	// the oldest exited process is removed first
+	_, err = cache.get("process3")
+	assert.NoError(t, err)
+}
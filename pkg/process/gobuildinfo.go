@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package process
+
+import (
+	"debug/buildinfo"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"syscall"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/option"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+// goBuildInfoCacheSize is the number of executables whose Go build
+// information is kept in the cache.
+const goBuildInfoCacheSize = 1024
+
+// goBuildInfoKey identifies an executable file, so that the file does not
+// need to be read again for each process.
+type goBuildInfoKey struct {
+	dev   uint64
+	ino   uint64
+	mtime int64
+}
+
+// goBuildInfoQueueSize is the number of executables that can wait to be
+// read. The executables of the exec events that do not fit in the queue are
+// not read.
+const goBuildInfoQueueSize = 256
+
+// goBuildInfoRead is an executable waiting to be read, and the processes
+// that run it.
+type goBuildInfoRead struct {
+	key   goBuildInfoKey
+	exe   *os.File
+	procs []*ProcessInternal
+}
+
+// goBuildInfoReader reads the Go build information of the executables in the
+// background, so that the exec events are not delayed by reading large
+// binaries. Once read, the build information is added to the processes
+// that run the executable.
+type goBuildInfoReader struct {
+	cache *lru.Cache[goBuildInfoKey, *tetragon.GoBuildInfo]
+	queue chan *goBuildInfoRead
+
+	mu sync.Mutex
+	// pending holds the executables in the queue or being read
+	pending map[goBuildInfoKey]*goBuildInfoRead
+}
+
+// goBuildInfos reads the Go build information of the executables, nil if
+// disabled.
+var goBuildInfos *goBuildInfoReader
+
+func newGoBuildInfoReader() (*goBuildInfoReader, error) {
+	cache, err := lru.New[goBuildInfoKey, *tetragon.GoBuildInfo](goBuildInfoCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	r := &goBuildInfoReader{
+		cache:   cache,
+		queue:   make(chan *goBuildInfoRead, goBuildInfoQueueSize),
+		pending: make(map[goBuildInfoKey]*goBuildInfoRead),
+	}
+	go r.run()
+	return r, nil
+}
+
+// stop stops the reader once the queued executables were read.
+func (r *goBuildInfoReader) stop() {
+	close(r.queue)
+}
+
+func (r *goBuildInfoReader) run() {
+	for req := range r.queue {
+		var info *tetragon.GoBuildInfo
+		if bi, err := buildinfo.Read(req.exe); err == nil {
+			info = goBuildInfoFromBuildInfo(bi)
+		}
+		req.exe.Close()
+
+		r.mu.Lock()
+		r.cache.Add(req.key, info)
+		delete(r.pending, req.key)
+		procs := req.procs
+		r.mu.Unlock()
+
+		if info == nil {
+			continue
+		}
+		for _, pi := range procs {
+			// the events of the processes must not share the message
+			pi.addGoBuildInfo(proto.Clone(info).(*tetragon.GoBuildInfo))
+		}
+	}
+}
+
+func goBuildInfoFromBuildInfo(bi *debug.BuildInfo) *tetragon.GoBuildInfo {
+	ret := &tetragon.GoBuildInfo{
+		GoVersion:     bi.GoVersion,
+		Path:          bi.Path,
+		ModulePath:    bi.Main.Path,
+		ModuleVersion: bi.Main.Version,
+		ModuleSum:     bi.Main.Sum,
+	}
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			ret.VcsRevision = s.Value
+		case "vcs.time":
+			ret.VcsTime = s.Value
+		case "vcs.modified":
+			ret.VcsModified = s.Value == "true"
+		}
+	}
+	return ret
+}
+
+// addGoBuildInfo adds the Go build information of the executable to process
+// pi, through procfs since the executable might be in another mount
+// namespace. The executables are read once, and the result is cached by their
+// device, inode and modification time. The build information of a cached
+// executable is added immediately, otherwise the executable is read in the
+// background and the build information is added to the process afterwards,
+// so the exec event of the first process running an executable usually does
+// not have it. Nothing is added if the executable is not a Go binary or
+// cannot be read.
+func (r *goBuildInfoReader) addGoBuildInfo(pi *ProcessInternal, pid uint32) {
+	exe := filepath.Join(option.Config.ProcFS, strconv.FormatUint(uint64(pid), 10), "exe")
+	f, err := os.Open(exe)
+	if err != nil {
+		// the process may have exited already
+		logger.GetLogger().WithFields(logrus.Fields{
+			"pid": pid,
+		}).WithError(err).Debug("Failed to open executable of process")
+		return
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return
+	}
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		f.Close()
+		return
+	}
+	key := goBuildInfoKey{dev: uint64(st.Dev), ino: st.Ino, mtime: fi.ModTime().UnixNano()}
+
+	r.mu.Lock()
+	if info, ok := r.cache.Get(key); ok {
+		r.mu.Unlock()
+		f.Close()
+		if info != nil {
+			pi.addGoBuildInfo(proto.Clone(info).(*tetragon.GoBuildInfo))
+		}
+		return
+	}
+	if req, ok := r.pending[key]; ok {
+		req.procs = append(req.procs, pi)
+		r.mu.Unlock()
+		f.Close()
+		return
+	}
+	// the open file keeps the executable readable even if the process
+	// exits before it is read
+	req := &goBuildInfoRead{key: key, exe: f, procs: []*ProcessInternal{pi}}
+	select {
+	case r.queue <- req:
+		r.pending[key] = req
+	default:
+		f.Close()
+		logger.GetLogger().WithFields(logrus.Fields{
+			"pid": pid,
+		}).Debug("Go build info queue full, executable of process not read")
+	}
+	r.mu.Unlock()
+}
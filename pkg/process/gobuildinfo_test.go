@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package process
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoBuildInfoFromBuildInfo(t *testing.T) {
+	bi := &debug.BuildInfo{
+		GoVersion: "go1.22.1",
+		Path:      "github.com/cilium/tetragon/cmd/tetragon",
+		Main:      debug.Module{Path: "github.com/cilium/tetragon", Version: "(devel)"},
+		Settings: []debug.BuildSetting{
+			{Key: "-ldflags", Value: "-s -w"},
+			{Key: "vcs", Value: "git"},
+			{Key: "vcs.revision", Value: "0123456789abcdef"},
+			{Key: "vcs.time", Value: "2024-03-01T10:00:00Z"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	}
+	assert.Equal(t, &tetragon.GoBuildInfo{
+		GoVersion:     "go1.22.1",
+		Path:          "github.com/cilium/tetragon/cmd/tetragon",
+		ModulePath:    "github.com/cilium/tetragon",
+		ModuleVersion: "(devel)",
+		VcsRevision:   "0123456789abcdef",
+		VcsTime:       "2024-03-01T10:00:00Z",
+		VcsModified:   true,
+	}, goBuildInfoFromBuildInfo(bi))
+}
+
+func TestGoBuildInfoReader(t *testing.T) {
+	procfs := t.TempDir()
+	oldProcFS := option.Config.ProcFS
+	option.Config.ProcFS = procfs
+	t.Cleanup(func() {
+		option.Config.ProcFS = oldProcFS
+	})
+
+	r, err := newGoBuildInfoReader()
+	require.NoError(t, err)
+	t.Cleanup(r.stop)
+
+	// the test binary is a Go binary
+	self, err := os.Executable()
+	require.NoError(t, err)
+	require.NoError(t, os.Mkdir(filepath.Join(procfs, "100"), 0755))
+	require.NoError(t, os.Symlink(self, filepath.Join(procfs, "100", "exe")))
+	notGo := filepath.Join(t.TempDir(), "script.sh")
+	require.NoError(t, os.WriteFile(notGo, []byte("#!/bin/sh\n"), 0755))
+	require.NoError(t, os.Mkdir(filepath.Join(procfs, "200"), 0755))
+	require.NoError(t, os.Symlink(notGo, filepath.Join(procfs, "200", "exe")))
+
+	newProcess := func() *ProcessInternal {
+		return &ProcessInternal{process: &tetragon.Process{}}
+	}
+	goBuildInfo := func(pi *ProcessInternal) *tetragon.GoBuildInfo {
+		return pi.GetProcessCopy().GoBuildInfo
+	}
+
+	// read in the background
+	first := newProcess()
+	r.addGoBuildInfo(first, 100)
+	script := newProcess()
+	r.addGoBuildInfo(script, 200)
+	// exited process
+	exited := newProcess()
+	r.addGoBuildInfo(exited, 300)
+	require.Eventually(t, func() bool {
+		return r.cache.Len() == 2
+	}, 10*time.Second, 10*time.Millisecond)
+
+	info := goBuildInfo(first)
+	require.NotNil(t, info)
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+	assert.Equal(t, "github.com/cilium/tetragon", info.ModulePath)
+	assert.Nil(t, goBuildInfo(script))
+	assert.Nil(t, goBuildInfo(exited))
+
+	// cached, added immediately but not shared between processes
+	second := newProcess()
+	r.addGoBuildInfo(second, 100)
+	cached := second.process.GoBuildInfo
+	require.NotNil(t, cached)
+	assert.Equal(t, info.GoVersion, cached.GoVersion)
+	assert.NotSame(t, first.process.GoBuildInfo, cached)
+}
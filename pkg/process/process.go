@@ -7,6 +7,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -29,6 +30,7 @@ import (
 	"github.com/cilium/tetragon/pkg/reader/path"
 	"github.com/cilium/tetragon/pkg/reader/proc"
 	"github.com/cilium/tetragon/pkg/watcher"
+	lru "github.com/hashicorp/golang-lru/v2"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
@@ -52,10 +54,14 @@ type ProcessInternal struct {
 	// garbage collector metadata
 	color  int // Writes should happen only inside gc select channel
 	refcnt uint32
+	// execPending is set while the exec event of the process waits in the
+	// event cache, see SetExecPending().
+	execPending uint32
 }
 
 var (
 	nodeName    string
+	clusterName string
 	procCache   *Cache
 	ciliumState *hubble.State
 	k8s         watcher.K8sResourceWatcher
@@ -73,6 +79,7 @@ func InitCache(w watcher.K8sResourceWatcher, size int) error {
 	}
 
 	nodeName = node.GetNodeNameForExport()
+	clusterName = option.Config.ClusterName
 	ciliumState = cilium.GetCiliumState()
 	if ciliumState == nil {
 		return fmt.Errorf("ciliumState must be initialized before process cache")
@@ -81,6 +88,16 @@ func InitCache(w watcher.K8sResourceWatcher, size int) error {
 	procCache, err = NewCache(size)
 	if err != nil {
 		k8s = nil
+		return err
+	}
+	if option.Config.EnableProcessUsernames {
+		userDBs, err = lru.New[string, *userDB](userDBCacheSize)
+		if err != nil {
+			return err
+		}
+	}
+	if option.Config.EnableGoBuildInfo {
+		goBuildInfos, err = newGoBuildInfoReader()
 	}
 	return err
 }
@@ -88,6 +105,29 @@ func InitCache(w watcher.K8sResourceWatcher, size int) error {
 func FreeCache() {
 	procCache.Purge()
 	procCache = nil
+	userDBs = nil
+	if goBuildInfos != nil {
+		goBuildInfos.stop()
+		goBuildInfos = nil
+	}
+}
+
+// ShrinkCache removes exited processes from the process cache until it holds
+// at most size processes, without evicting the processes that are still
+// running. It returns the number of removed processes.
+func ShrinkCache(size int) int {
+	if procCache == nil {
+		return 0
+	}
+	return procCache.shrink(size)
+}
+
+// CacheSize returns the size the process cache was initialized with.
+func CacheSize() int {
+	if procCache == nil {
+		return 0
+	}
+	return procCache.size
 }
 
 // GetProcessCopy() duplicates tetragon.Process and returns it
@@ -123,6 +163,12 @@ func (pi *ProcessInternal) AddPodInfo(pod *tetragon.Pod) {
 	pi.mu.Unlock()
 }
 
+func (pi *ProcessInternal) addGoBuildInfo(info *tetragon.GoBuildInfo) {
+	pi.mu.Lock()
+	pi.process.GoBuildInfo = info
+	pi.mu.Unlock()
+}
+
 func (pi *ProcessInternal) getProcess() *tetragon.Process {
 	pi.mu.Lock()
 	return pi.process
@@ -136,6 +182,23 @@ func (pi *ProcessInternal) UnsafeGetProcess() *tetragon.Process {
 	return pi.process
 }
 
+// SetExecPending marks the exec event of the process as waiting in the event
+// cache. With --enable-short-lived-process-tracking, the exit event of the
+// process is held back until its exec event was sent.
+func (pi *ProcessInternal) SetExecPending(pending bool) {
+	var v uint32
+	if pending {
+		v = 1
+	}
+	atomic.StoreUint32(&pi.execPending, v)
+}
+
+// ExecPending returns true if the exec event of the process waits in the
+// event cache.
+func (pi *ProcessInternal) ExecPending() bool {
+	return atomic.LoadUint32(&pi.execPending) != 0
+}
+
 // UpdateExecOutsideCache() checks if we must augment the ProcessExec.Process
 // with more fields without propagating again those fields into the process
 // cache. This means that those added fields will only show up for the
@@ -240,10 +303,57 @@ func UpdateEventProcessTid(process *tetragon.Process, tid *uint32) {
 	}
 }
 
+// GetProcessID returns the exec_id of a process. The exec_id is the base64
+// encoding of "node:ktime:pid", or "cluster:node:ktime:pid" if a cluster
+// name is configured. Use ParseExecID to decode it.
 func GetProcessID(pid uint32, ktime uint64) string {
+	if clusterName != "" {
+		return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s:%d:%d", clusterName, nodeName, ktime, pid)))
+	}
 	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%d:%d", nodeName, ktime, pid)))
 }
 
+// ExecID holds the decoded fields of an exec_id.
+type ExecID struct {
+	// ClusterName is empty if the exec_id was generated without a cluster name.
+	ClusterName string
+	NodeName    string
+	Ktime       uint64
+	Pid         uint32
+}
+
+// ParseExecID decodes an exec_id generated by GetProcessID.
+func ParseExecID(execID string) (*ExecID, error) {
+	decoded, err := base64.StdEncoding.DecodeString(execID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode exec_id %q: %w", execID, err)
+	}
+
+	fields := strings.Split(string(decoded), ":")
+	ret := &ExecID{}
+	switch len(fields) {
+	case 3:
+		ret.NodeName = fields[0]
+	case 4:
+		ret.ClusterName = fields[0]
+		ret.NodeName = fields[1]
+	default:
+		return nil, fmt.Errorf("invalid exec_id %q: unexpected number of fields %d", execID, len(fields))
+	}
+
+	ktime, err := strconv.ParseUint(fields[len(fields)-2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exec_id %q: failed to parse ktime: %w", execID, err)
+	}
+	pid, err := strconv.ParseUint(fields[len(fields)-1], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exec_id %q: failed to parse pid: %w", execID, err)
+	}
+	ret.Ktime = ktime
+	ret.Pid = uint32(pid)
+	return ret, nil
+}
+
 func GetExecID(proc *tetragonAPI.MsgProcess) string {
 	return GetProcessID(proc.PID, proc.Ktime)
 }
@@ -317,7 +427,7 @@ func initProcessInternalExec(
 		process.TID = process.PID
 		errormetrics.ErrorTotalInc(errormetrics.ProcessPidTidMismatch)
 	}
-	return &ProcessInternal{
+	pi := &ProcessInternal{
 		process: &tetragon.Process{
 			Pid:          &wrapperspb.UInt32Value{Value: process.PID},
 			Tid:          &wrapperspb.UInt32Value{Value: process.TID},
@@ -333,6 +443,7 @@ func initProcessInternalExec(
 			Docker:       containerID,
 			ParentExecId: parentExecID,
 			Refcnt:       0,
+			User:         getUserRecord(process.PID, process.UID, creds.Gid, protoPod, containerID),
 		},
 		capabilities:  apiCaps,
 		apiCreds:      apiCreds,
@@ -340,6 +451,10 @@ func initProcessInternalExec(
 		namespaces:    apiNs,
 		refcnt:        1,
 	}
+	if goBuildInfos != nil {
+		goBuildInfos.addGoBuildInfo(pi, process.PID)
+	}
+	return pi
 }
 
 // initProcessInternalClone() initialize and returns ProcessInternal from
@@ -457,6 +572,9 @@ func AddCloneEvent(event *tetragonAPI.MsgCloneEvent) error {
 }
 
 func Get(execId string) (*ProcessInternal, error) {
+	if procCache == nil {
+		return nil, fmt.Errorf("process cache not initialized")
+	}
 	return procCache.get(execId)
 }
 
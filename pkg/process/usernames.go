@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package process
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/option"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// userDBCacheSize is the number of user databases (i.e., container images)
+// kept in the cache.
+const userDBCacheSize = 256
+
+// userDB holds the user and group names of the passwd and group files of a
+// container image, or of the host.
+type userDB struct {
+	users  map[uint32]string
+	groups map[uint32]string
+}
+
+var userDBs *lru.Cache[string, *userDB]
+
+// parseNameDB parses a passwd or group file, both of them have the name in
+// the first field and the ID in the third one.
+func parseNameDB(r io.Reader) (map[uint32]string, error) {
+	ret := make(map[uint32]string)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, ":")
+		if len(fields) < 3 {
+			continue
+		}
+		id, err := strconv.ParseUint(fields[2], 10, 32)
+		if err != nil {
+			continue
+		}
+		// keep the first entry, like getpwuid(3)
+		if _, ok := ret[uint32(id)]; !ok {
+			ret[uint32(id)] = fields[0]
+		}
+	}
+	return ret, scanner.Err()
+}
+
+func readNameDB(fname string) (map[uint32]string, error) {
+	f, err := os.Open(fname)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseNameDB(f)
+}
+
+// readUserDB reads the passwd and group files of the mount namespace of the
+// process pid. Missing files result in empty databases.
+func readUserDB(pid uint32) (*userDB, error) {
+	root := filepath.Join(option.Config.ProcFS, strconv.FormatUint(uint64(pid), 10), "root")
+	// fails if the process does not exist anymore, which must not be
+	// mistaken for missing files
+	if _, err := os.Stat(root); err != nil {
+		return nil, err
+	}
+
+	users, err := readNameDB(filepath.Join(root, "etc", "passwd"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	groups, err := readNameDB(filepath.Join(root, "etc", "group"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	return &userDB{users: users, groups: groups}, nil
+}
+
+// userDBKey returns the key of the user database of a process: the image of
+// its container, or its container ID if the pod of the container is not known
+// yet, or the host.
+func userDBKey(pod *tetragon.Pod, containerID string) string {
+	if image := pod.GetContainer().GetImage().GetId(); image != "" {
+		return "image:" + image
+	}
+	if containerID != "" {
+		return "container:" + containerID
+	}
+	return "host"
+}
+
+// getUserRecord resolves the user and group names of a process from the
+// passwd and group files of its own mount namespace, since the files of the
+// host do not apply to containers. The files are read once per container
+// image. It returns nil if the names cannot be resolved.
+func getUserRecord(pid, uid, gid uint32, pod *tetragon.Pod, containerID string) *tetragon.UserRecord {
+	if userDBs == nil {
+		return nil
+	}
+
+	key := userDBKey(pod, containerID)
+	db, ok := userDBs.Get(key)
+	if !ok {
+		var err error
+		db, err = readUserDB(pid)
+		if err != nil {
+			// the process may have exited already, do not cache the
+			// failure so that the next process of the image retries
+			logger.GetLogger().WithFields(logrus.Fields{
+				"pid": pid,
+				"key": key,
+			}).WithError(err).Debug("Failed to read user database of process")
+			return nil
+		}
+		userDBs.Add(key, db)
+	}
+
+	user, userOk := db.users[uid]
+	group, groupOk := db.groups[gid]
+	if !userOk && !groupOk {
+		return nil
+	}
+	return &tetragon.UserRecord{
+		Name:  user,
+		Group: group,
+	}
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package process
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/option"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeEtcFiles(t *testing.T, procfs string, pid string, passwd, group string) {
+	etc := filepath.Join(procfs, pid, "root", "etc")
+	require.NoError(t, os.MkdirAll(etc, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(etc, "passwd"), []byte(passwd), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(etc, "group"), []byte(group), 0644))
+}
+
+func TestGetUserRecord(t *testing.T) {
+	procfs := t.TempDir()
+	oldProcFS := option.Config.ProcFS
+	option.Config.ProcFS = procfs
+	t.Cleanup(func() {
+		option.Config.ProcFS = oldProcFS
+		userDBs = nil
+	})
+
+	var err error
+	userDBs, err = lru.New[string, *userDB](userDBCacheSize)
+	require.NoError(t, err)
+
+	writeEtcFiles(t, procfs, "100",
+		"root:x:0:0:root:/root:/bin/bash\n# comment\nalice:x:1000:1000::/home/alice:/bin/sh\n",
+		"root:x:0:\nalice:x:1000:\n")
+	writeEtcFiles(t, procfs, "200",
+		"root:x:0:0:root:/root:/bin/sh\nnginx:x:1000:101::/nonexistent:/bin/false\n",
+		"root:x:0:\nnginx:x:101:\n")
+
+	pod := &tetragon.Pod{
+		Container: &tetragon.Container{
+			Image: &tetragon.Image{Id: "nginx@sha256:1234"},
+		},
+	}
+
+	// the same uid resolves to different names on the host and in the
+	// container
+	assert.Equal(t, &tetragon.UserRecord{Name: "alice", Group: "alice"}, getUserRecord(100, 1000, 1000, nil, ""))
+	assert.Equal(t, &tetragon.UserRecord{Name: "nginx", Group: "nginx"}, getUserRecord(200, 1000, 101, pod, "abcd"))
+	assert.Equal(t, &tetragon.UserRecord{Name: "nginx"}, getUserRecord(200, 1000, 1000, pod, "abcd"))
+	assert.Nil(t, getUserRecord(200, 2000, 2000, pod, "abcd"))
+
+	// the files are read once per image
+	assert.Equal(t, &tetragon.UserRecord{Name: "nginx", Group: "nginx"}, getUserRecord(300, 1000, 101, pod, "efgh"))
+
+	// processes that do not exist are not resolved
+	assert.Nil(t, getUserRecord(400, 0, 0, nil, "ijkl"))
+	assert.Equal(t, 2, userDBs.Len())
+}
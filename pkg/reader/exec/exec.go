@@ -21,6 +21,12 @@ func DecodeCommonFlags(flags uint32) []string {
 	if (flags & api.EventProcFS) != 0 {
 		s = append(s, "procFS")
 	}
+	if (flags & api.EventProcFSPoll) != 0 {
+		s = append(s, "procFSPoll")
+	}
+	if (flags & api.EventProcConnector) != 0 {
+		s = append(s, "procConnector")
+	}
 	if (flags & api.EventTruncFilename) != 0 {
 		s = append(s, "truncFilename")
 	}
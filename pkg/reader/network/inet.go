@@ -4,6 +4,7 @@ package network
 
 import (
 	"fmt"
+	"net"
 
 	"golang.org/x/sys/unix"
 )
@@ -90,6 +91,15 @@ func InetType(ty uint16) string {
 	return fmt.Sprintf("%d", ty)
 }
 
+func InetTypeNumber(ty string) (uint16, error) {
+	for tynum, tystr := range inetType {
+		if ty == tystr {
+			return tynum, nil
+		}
+	}
+	return 0, fmt.Errorf("socket type string not known")
+}
+
 var inetProtocol = map[uint16]string{
 	0:   "IPPROTO_IP",
 	1:   "IPPROTO_ICMP",
@@ -107,6 +117,7 @@ var inetProtocol = map[uint16]string{
 	47:  "IPPROTO_GRE",      /* Cisco GRE tunnels (rfc 1701,1702)    */
 	50:  "IPPROTO_ESP",      /* Encapsulation Security Payload protocol */
 	51:  "IPPROTO_AH",       /* Authentication Header protocol       */
+	58:  "IPPROTO_ICMPV6",   /* ICMPv6                               */
 	92:  "IPPROTO_MTP",      /* Multicast Transport Protocol         */
 	94:  "IPPROTO_BEETPH",   /* IP option pseudo header for BEET     */
 	98:  "IPPROTO_ENCAP",    /* Encapsulation Header                 */
@@ -136,6 +147,73 @@ func InetProtocolNumber(proto string) (uint16, error) {
 	return 0, fmt.Errorf("protocol string not known")
 }
 
+var netlinkProtocol = map[uint16]string{
+	unix.NETLINK_ROUTE:          "NETLINK_ROUTE",
+	unix.NETLINK_UNUSED:         "NETLINK_UNUSED",
+	unix.NETLINK_USERSOCK:       "NETLINK_USERSOCK",
+	unix.NETLINK_FIREWALL:       "NETLINK_FIREWALL",
+	unix.NETLINK_SOCK_DIAG:      "NETLINK_SOCK_DIAG",
+	unix.NETLINK_NFLOG:          "NETLINK_NFLOG",
+	unix.NETLINK_XFRM:           "NETLINK_XFRM",
+	unix.NETLINK_SELINUX:        "NETLINK_SELINUX",
+	unix.NETLINK_ISCSI:          "NETLINK_ISCSI",
+	unix.NETLINK_AUDIT:          "NETLINK_AUDIT",
+	unix.NETLINK_FIB_LOOKUP:     "NETLINK_FIB_LOOKUP",
+	unix.NETLINK_CONNECTOR:      "NETLINK_CONNECTOR",
+	unix.NETLINK_NETFILTER:      "NETLINK_NETFILTER",
+	unix.NETLINK_IP6_FW:         "NETLINK_IP6_FW",
+	unix.NETLINK_DNRTMSG:        "NETLINK_DNRTMSG",
+	unix.NETLINK_KOBJECT_UEVENT: "NETLINK_KOBJECT_UEVENT",
+	unix.NETLINK_GENERIC:        "NETLINK_GENERIC",
+	unix.NETLINK_SCSITRANSPORT:  "NETLINK_SCSITRANSPORT",
+	unix.NETLINK_ECRYPTFS:       "NETLINK_ECRYPTFS",
+	unix.NETLINK_RDMA:           "NETLINK_RDMA",
+	unix.NETLINK_CRYPTO:         "NETLINK_CRYPTO",
+	unix.NETLINK_SMC:            "NETLINK_SMC",
+}
+
+func NetlinkProtocol(proto uint16) string {
+	if p, ok := netlinkProtocol[proto]; ok {
+		return p
+	}
+	return fmt.Sprintf("%d", proto)
+}
+
+func NetlinkProtocolNumber(proto string) (uint16, error) {
+	for protonum, protostr := range netlinkProtocol {
+		if proto == protostr {
+			return protonum, nil
+		}
+	}
+	return 0, fmt.Errorf("netlink protocol string not known")
+}
+
+// packetProtocol maps the protocols of AF_PACKET sockets, which are Ethernet
+// protocol ids in network byte order, to their names.
+var packetProtocol = map[uint16]string{
+	unix.ETH_P_ALL:   "ETH_P_ALL",
+	unix.ETH_P_IP:    "ETH_P_IP",
+	unix.ETH_P_ARP:   "ETH_P_ARP",
+	unix.ETH_P_IPV6:  "ETH_P_IPV6",
+	unix.ETH_P_8021Q: "ETH_P_8021Q",
+}
+
+// SockProtocol returns the name of the protocol of a socket, which depends on
+// the socket family.
+func SockProtocol(family uint16, proto uint16) string {
+	switch family {
+	case unix.AF_NETLINK:
+		return NetlinkProtocol(proto)
+	case unix.AF_PACKET:
+		ethProto := proto>>8 | proto<<8
+		if p, ok := packetProtocol[ethProto]; ok {
+			return p
+		}
+		return fmt.Sprintf("%d", ethProto)
+	}
+	return InetProtocol(proto)
+}
+
 var tcpState = map[uint8]string{
 	1:  "TCP_ESTABLISHED",
 	2:  "TCP_SYN_SENT",
@@ -166,3 +244,17 @@ func TcpStateNumber(state string) (uint8, error) {
 	}
 	return 0, fmt.Errorf("state string not known")
 }
+
+// ServicePortNumber returns the port of a well-known TCP or UDP service (e.g.,
+// https), as listed in /etc/services.
+func ServicePortNumber(service string) (uint16, error) {
+	if service == "" {
+		return 0, fmt.Errorf("service string empty")
+	}
+	for _, network := range []string{"tcp", "udp"} {
+		if port, err := net.LookupPort(network, service); err == nil {
+			return uint16(port), nil
+		}
+	}
+	return 0, fmt.Errorf("service string not known")
+}
@@ -19,6 +19,9 @@ func GetIPv4(i uint32) net.IP {
 	return ip
 }
 
+// GetIP returns the address of a sock, skb or sockaddr tuple. IPv4-mapped
+// IPv6 addresses, used for IPv4 traffic on dual-stack sockets, are returned
+// in their 4 bytes IPv4 form.
 func GetIP(i [2]uint64, family uint16) net.IP {
 	switch family {
 	case unix.AF_INET:
@@ -29,7 +32,10 @@ func GetIP(i [2]uint64, family uint16) net.IP {
 
 		binary.LittleEndian.PutUint64(a, i[0])
 		binary.LittleEndian.PutUint64(b, i[1])
-		ip := append(a, b...)
+		ip := net.IP(append(a, b...))
+		if ip4 := ip.To4(); ip4 != nil {
+			return ip4
+		}
 		return ip
 	}
 	return nil
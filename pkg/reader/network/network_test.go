@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package network
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+)
+
+// tupleAddr returns the address as stored in the tuple of sock and skb events
+func tupleAddr(ip net.IP) [2]uint64 {
+	var addr [16]byte
+	copy(addr[:], ip)
+	return [2]uint64{binary.LittleEndian.Uint64(addr[:8]), binary.LittleEndian.Uint64(addr[8:])}
+}
+
+func TestGetIP(t *testing.T) {
+	tests := []struct {
+		addr     net.IP
+		family   uint16
+		expected string
+	}{
+		{net.ParseIP("10.1.2.3").To4(), unix.AF_INET, "10.1.2.3"},
+		{net.ParseIP("2a1:56::1"), unix.AF_INET6, "2a1:56::1"},
+		{net.ParseIP("::1"), unix.AF_INET6, "::1"},
+		// IPv4 traffic on dual-stack sockets is reported as the IPv4 address
+		{net.ParseIP("::ffff:10.1.2.3"), unix.AF_INET6, "10.1.2.3"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, GetIP(tupleAddr(test.addr), test.family).String())
+	}
+	assert.Nil(t, GetIP(tupleAddr(net.ParseIP("10.1.2.3").To4()), unix.AF_UNIX))
+
+	// IPv4-mapped addresses are normalized to the IPv4 form, so that
+	// they compare equal to the addresses of AF_INET sockets
+	ip := GetIP(tupleAddr(net.ParseIP("::ffff:10.1.2.3")), unix.AF_INET6)
+	assert.Len(t, ip, net.IPv4len)
+	assert.True(t, ip.Equal(GetIP(tupleAddr(net.ParseIP("10.1.2.3").To4()), unix.AF_INET)))
+	assert.Len(t, GetIP(tupleAddr(net.ParseIP("::")), unix.AF_INET6), net.IPv6len)
+}
+
+func TestInetFamilyNumber(t *testing.T) {
+	family, err := InetFamilyNumber("AF_INET6")
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(unix.AF_INET6), family)
+	assert.Equal(t, "AF_INET6", InetFamily(unix.AF_INET6))
+	_, err = InetFamilyNumber("AF_FOO")
+	assert.Error(t, err)
+}
+
+func TestInetProtocolNumber(t *testing.T) {
+	for _, proto := range []uint16{unix.IPPROTO_TCP, unix.IPPROTO_UDP, unix.IPPROTO_ICMP, unix.IPPROTO_ICMPV6} {
+		num, err := InetProtocolNumber(InetProtocol(proto))
+		assert.NoError(t, err)
+		assert.Equal(t, proto, num)
+	}
+}
+
+func TestSockProtocol(t *testing.T) {
+	assert.Equal(t, "IPPROTO_TCP", SockProtocol(unix.AF_INET, unix.IPPROTO_TCP))
+	assert.Equal(t, "NETLINK_AUDIT", SockProtocol(unix.AF_NETLINK, unix.NETLINK_AUDIT))
+	assert.Equal(t, "NETLINK_ROUTE", SockProtocol(unix.AF_NETLINK, unix.NETLINK_ROUTE))
+	// AF_PACKET protocols are in network byte order
+	assert.Equal(t, "ETH_P_ALL", SockProtocol(unix.AF_PACKET, 0x0300))
+	assert.Equal(t, "ETH_P_IP", SockProtocol(unix.AF_PACKET, 0x0008))
+	assert.Equal(t, "4660", SockProtocol(unix.AF_PACKET, 0x3412))
+
+	num, err := NetlinkProtocolNumber("NETLINK_KOBJECT_UEVENT")
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(unix.NETLINK_KOBJECT_UEVENT), num)
+
+	ty, err := InetTypeNumber("SOCK_RAW")
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(unix.SOCK_RAW), ty)
+	_, err = InetTypeNumber("SOCK_FOO")
+	assert.Error(t, err)
+}
+
+func TestServicePortNumber(t *testing.T) {
+	port, err := ServicePortNumber("https")
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(443), port)
+	_, err = ServicePortNumber("no-such-service")
+	assert.Error(t, err)
+	_, err = ServicePortNumber("")
+	assert.Error(t, err)
+}
@@ -2,7 +2,13 @@
 // Copyright Authors of Tetragon
 package node
 
-import "os"
+import (
+	"os"
+	"runtime"
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
 
 // getNodeNameForExport returns node name string for JSON export. It uses NODE_NAME
 // env variable by default, which is also used by k8s watcher to watch for local pods:
@@ -17,3 +23,40 @@ func GetNodeNameForExport() string {
 	}
 	return os.Getenv("NODE_NAME")
 }
+
+var (
+	labelsMu sync.RWMutex
+	// labels of the Kubernetes node of the agent
+	k8sLabels map[string]string
+)
+
+// SetNodeLabels sets the labels of the Kubernetes node of the agent.
+func SetNodeLabels(labels map[string]string) {
+	labelsMu.Lock()
+	defer labelsMu.Unlock()
+	k8sLabels = labels
+}
+
+// GetNodeLabels returns the labels of the node of the agent that the node
+// selectors of the tracing policies match: the labels of its Kubernetes node,
+// if any, and the labels that the agent sets, which do not override them.
+func GetNodeLabels() map[string]string {
+	ret := map[string]string{
+		"kubernetes.io/arch": runtime.GOARCH,
+		"kubernetes.io/os":   runtime.GOOS,
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		ret["kubernetes.io/hostname"] = hostname
+	}
+	var uts unix.Utsname
+	if err := unix.Uname(&uts); err == nil {
+		ret["tetragon.io/kernel-release"] = unix.ByteSliceToString(uts.Release[:])
+	}
+
+	labelsMu.RLock()
+	defer labelsMu.RUnlock()
+	for k, v := range k8sLabels {
+		ret[k] = v
+	}
+	return ret
+}
@@ -0,0 +1,235 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package ringdrops reports the events lost because the BPF ring buffer was
+// full, and attributes the events written to the ring buffer during the same
+// window to the cgroups of the tasks that wrote them.
+package ringdrops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/cgroups"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/observer"
+	"github.com/cilium/tetragon/pkg/process"
+	"github.com/cilium/tetragon/pkg/reader/node"
+	"github.com/cilium/tetragon/pkg/reader/notify"
+	"github.com/cilium/tetragon/pkg/sensors/exec/procevents"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// StatsMapName is the name of the map where the BPF programs count the
+	// events that the tasks of each cgroup write to the ring buffer.
+	StatsMapName = "event_cgroup_stats_map"
+
+	// reportInterval is the interval at which lost events are reported.
+	reportInterval = 10 * time.Second
+	// topCgroupsCount is the number of cgroups attributed in a report.
+	topCgroupsCount = 5
+)
+
+// cgroupStats mirrors struct event_cgroup_stats of bpf/lib/bpf_event.h.
+type cgroupStats struct {
+	Events uint64
+	Bytes  uint64
+}
+
+// Reporter sends a RingBufferDrops event for every report window where
+// events were lost.
+type Reporter struct {
+	obs      *observer.Observer
+	statsMap *ebpf.Map
+	// statistics at the start of the current report window
+	prevStats map[uint64]cgroupStats
+	prevLost  uint64
+}
+
+// NewReporter returns a reporter for the events lost by obs. The statistics
+// map is pinned by the base sensor, so it must be loaded first.
+func NewReporter(obs *observer.Observer, mapDir string) (*Reporter, error) {
+	m, err := ebpf.LoadPinnedMap(filepath.Join(mapDir, StatsMapName), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cgroup statistics map: %w", err)
+	}
+	return &Reporter{
+		obs:      obs,
+		statsMap: m,
+	}, nil
+}
+
+// Run reports the lost events until ctx is done.
+func (r *Reporter) Run(ctx context.Context) {
+	defer r.statsMap.Close()
+
+	ticker := time.NewTicker(reportInterval)
+	defer ticker.Stop()
+
+	r.prevLost = r.obs.ReadLostEvents()
+	r.prevStats, _ = readCgroupStats(r.statsMap)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.report()
+		}
+	}
+}
+
+func (r *Reporter) report() {
+	stats, err := readCgroupStats(r.statsMap)
+	if err != nil {
+		logger.GetLogger().WithError(err).Warn("Failed to read cgroup event statistics")
+	}
+	lost := r.obs.ReadLostEvents()
+	prevStats, prevLost := r.prevStats, r.prevLost
+	r.prevStats, r.prevLost = stats, lost
+	if lost == prevLost {
+		return
+	}
+
+	rates := topCgroups(prevStats, stats, topCgroupsCount)
+	resolveCgroups(rates)
+	observer.AllListeners(&MsgRingBufferDrops{
+		Drops: &tetragon.RingBufferDrops{
+			Lost:    lost - prevLost,
+			Window:  durationpb.New(reportInterval),
+			Cgroups: rates,
+		},
+	})
+}
+
+// readCgroupStats returns the statistics of the cgroups, summed over all
+// CPUs.
+func readCgroupStats(m *ebpf.Map) (map[uint64]cgroupStats, error) {
+	ret := make(map[uint64]cgroupStats)
+
+	var key uint64
+	var values []cgroupStats
+	iter := m.Iterate()
+	for iter.Next(&key, &values) {
+		var sum cgroupStats
+		for _, v := range values {
+			sum.Events += v.Events
+			sum.Bytes += v.Bytes
+		}
+		ret[key] = sum
+	}
+	return ret, iter.Err()
+}
+
+// topCgroups returns the n cgroups that wrote the most events to the ring
+// buffer between the prev and cur statistics, in decreasing order of events.
+func topCgroups(prev, cur map[uint64]cgroupStats, n int) []*tetragon.CgroupEventRate {
+	rates := make([]*tetragon.CgroupEventRate, 0, len(cur))
+	for id, c := range cur {
+		p := prev[id]
+		if c.Events < p.Events {
+			// the entry was evicted from the LRU map and added
+			// again since the previous statistics
+			p = cgroupStats{}
+		}
+		if c.Events == p.Events {
+			continue
+		}
+		rates = append(rates, &tetragon.CgroupEventRate{
+			CgroupId: id,
+			Events:   c.Events - p.Events,
+			Bytes:    c.Bytes - p.Bytes,
+		})
+	}
+
+	sort.Slice(rates, func(i, j int) bool {
+		if rates[i].Events != rates[j].Events {
+			return rates[i].Events > rates[j].Events
+		}
+		return rates[i].CgroupId < rates[j].CgroupId
+	})
+	if len(rates) > n {
+		rates = rates[:n]
+	}
+	return rates
+}
+
+// resolveCgroups sets the paths and the pods of the cgroups, by walking the
+// cgroup filesystem to find the cgroups of their IDs. The IDs are the ones of
+// the cgroup v2 hierarchy, so the cgroups are only resolved in unified mode.
+func resolveCgroups(rates []*tetragon.CgroupEventRate) {
+	root := cgroups.GetCgroupFSPath()
+	if len(rates) == 0 || root == "" || cgroups.GetCgroupMode() != cgroups.CGROUP_UNIFIED {
+		return
+	}
+
+	pending := make(map[uint64]*tetragon.CgroupEventRate, len(rates))
+	for _, rate := range rates {
+		pending[rate.CgroupId] = rate
+	}
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		id, err := cgroups.GetCgroupIdFromPath(path)
+		if err != nil {
+			return nil
+		}
+		rate, ok := pending[id]
+		if !ok {
+			return nil
+		}
+		if rel, err := filepath.Rel(root, path); err == nil {
+			rate.CgroupPath = filepath.Join("/", rel)
+		}
+		if containerID, _ := procevents.LookupContainerId(path, false, true); containerID != "" {
+			rate.Pod = process.GetPodInfo(containerID, "", "", 0)
+		}
+		delete(pending, id)
+		if len(pending) == 0 {
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, filepath.SkipAll) {
+		logger.GetLogger().WithError(err).Debug("Failed to resolve cgroups of event statistics")
+	}
+}
+
+// MsgRingBufferDrops is the message of a RingBufferDrops event.
+type MsgRingBufferDrops struct {
+	Drops *tetragon.RingBufferDrops
+}
+
+func (msg *MsgRingBufferDrops) Notify() bool {
+	return false
+}
+
+func (msg *MsgRingBufferDrops) RetryInternal(_ notify.Event, _ uint64) (*process.ProcessInternal, error) {
+	return nil, fmt.Errorf("Unsupported cache event MsgRingBufferDrops")
+}
+
+func (msg *MsgRingBufferDrops) Retry(_ *process.ProcessInternal, _ notify.Event) error {
+	return fmt.Errorf("Unsupported cache retry event MsgRingBufferDrops")
+}
+
+func (msg *MsgRingBufferDrops) HandleMessage() *tetragon.GetEventsResponse {
+	return &tetragon.GetEventsResponse{
+		Event:    &tetragon.GetEventsResponse_RingBufferDrops{RingBufferDrops: msg.Drops},
+		NodeName: node.GetNodeNameForExport(),
+		Time:     timestamppb.Now(),
+	}
+}
+
+func (msg *MsgRingBufferDrops) Cast(_ interface{}) notify.Message {
+	return &MsgRingBufferDrops{}
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package ringdrops
+
+import (
+	"testing"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopCgroups(t *testing.T) {
+	prev := map[uint64]cgroupStats{
+		1: {Events: 100, Bytes: 1000},
+		2: {Events: 10, Bytes: 100},
+		3: {Events: 500, Bytes: 5000},
+		4: {Events: 7, Bytes: 70},
+	}
+	cur := map[uint64]cgroupStats{
+		// 50 new events
+		1: {Events: 150, Bytes: 1500},
+		// 1000 new events
+		2: {Events: 1010, Bytes: 10100},
+		// evicted and added again, 20 new events
+		3: {Events: 20, Bytes: 200},
+		// no new events
+		4: {Events: 7, Bytes: 70},
+		// new cgroup, 50 new events
+		5: {Events: 50, Bytes: 800},
+	}
+
+	assert.Equal(t, []*tetragon.CgroupEventRate{
+		{CgroupId: 2, Events: 1000, Bytes: 10000},
+		{CgroupId: 1, Events: 50, Bytes: 500},
+		{CgroupId: 5, Events: 50, Bytes: 800},
+		{CgroupId: 3, Events: 20, Bytes: 200},
+	}, topCgroups(prev, cur, 10))
+
+	assert.Equal(t, []*tetragon.CgroupEventRate{
+		{CgroupId: 2, Events: 1000, Bytes: 10000},
+		{CgroupId: 1, Events: 50, Bytes: 500},
+	}, topCgroups(prev, cur, 2))
+
+	assert.Empty(t, topCgroups(cur, cur, 10))
+}
+
+func TestMsgRingBufferDrops(t *testing.T) {
+	drops := &tetragon.RingBufferDrops{
+		Lost:    42,
+		Cgroups: []*tetragon.CgroupEventRate{{CgroupId: 2, Events: 1000, Bytes: 10000}},
+	}
+	msg := &MsgRingBufferDrops{Drops: drops}
+	res := msg.HandleMessage()
+	assert.Equal(t, drops, res.GetRingBufferDrops())
+	assert.NotNil(t, res.GetTime())
+}
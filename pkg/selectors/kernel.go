@@ -33,6 +33,8 @@ const (
 	ActionTypeTrackSock    = 10
 	ActionTypeUntrackSock  = 11
 	ActionTypeNotifyKiller = 12
+	ActionTypeCount        = 13
+	ActionTypeSetTag       = 14
 )
 
 var actionTypeTable = map[string]uint32{
@@ -49,6 +51,8 @@ var actionTypeTable = map[string]uint32{
 	"tracksock":    ActionTypeTrackSock,
 	"untracksock":  ActionTypeUntrackSock,
 	"notifykiller": ActionTypeNotifyKiller,
+	"count":        ActionTypeCount,
+	"settag":       ActionTypeSetTag,
 }
 
 var actionTypeStringTable = map[uint32]string{
@@ -65,6 +69,8 @@ var actionTypeStringTable = map[uint32]string{
 	ActionTypeTrackSock:    "tracksock",
 	ActionTypeUntrackSock:  "untracksock",
 	ActionTypeNotifyKiller: "notifykiller",
+	ActionTypeCount:        "count",
+	ActionTypeSetTag:       "settag",
 }
 
 // Action argument table entry (for URL and FQDN arguments)
@@ -81,6 +87,48 @@ func (g *ActionArgEntry) GetArg() string {
 	return g.arg
 }
 
+// addActionArg adds an action argument to the table, or reuses the entry of
+// the same argument, so that updating the selectors of a probe does not grow
+// its table. It returns the id of the entry.
+func addActionArg(actionArgTable *idtable.Table, arg string) idtable.EntryID {
+	for _, e := range actionArgTable.Entries() {
+		if entry, ok := e.(*ActionArgEntry); ok && entry.arg == arg {
+			return entry.tableId
+		}
+	}
+	entry := &ActionArgEntry{
+		arg:     arg,
+		tableId: idtable.UninitializedEntryID,
+	}
+	actionArgTable.AddEntry(entry)
+	return entry.tableId
+}
+
+// PruneActionArgs removes the entries of the action argument table that are
+// not used by any of the given selector states, e.g. by the current and the
+// previous selectors of a probe after an update of its selectors, whose events
+// may still be in flight.
+func PruneActionArgs(actionArgTable *idtable.Table, states ...*KernelSelectorState) {
+	for _, e := range actionArgTable.Entries() {
+		entry, ok := e.(*ActionArgEntry)
+		if !ok {
+			continue
+		}
+		used := false
+		for _, k := range states {
+			if k == nil {
+				continue
+			}
+			if _, used = k.actionArgs[entry.tableId]; used {
+				break
+			}
+		}
+		if !used {
+			actionArgTable.RemoveEntry(entry.tableId)
+		}
+	}
+}
+
 func MatchActionSigKill(spec interface{}) bool {
 	var sels []v1alpha1.KProbeSelector
 	switch s := spec.(type) {
@@ -140,6 +188,22 @@ var capabilitiesTypeTable = map[string]uint32{
 	"permitted":   capsPermitted,
 }
 
+const (
+	credTypeUid   = 0
+	credTypeEuid  = 1
+	credTypeGid   = 2
+	credTypeEgid  = 3
+	credTypeFsuid = 4
+)
+
+var credentialsTypeTable = map[string]uint32{
+	"uid":   credTypeUid,
+	"euid":  credTypeEuid,
+	"gid":   credTypeGid,
+	"egid":  credTypeEgid,
+	"fsuid": credTypeFsuid,
+}
+
 const (
 	argTypeInt       = 1
 	argTypeCharBuf   = 2
@@ -160,44 +224,64 @@ const (
 
 	argTypeUrl  = 18
 	argTypeFqdn = 19
+
+	argTypeCapability = 23
+
+	argTypeSocket      = 28
+	argTypeSockaddr    = 29
+	argTypeLinuxBinprm = 30
+	argTypeDentry      = 31
+	argTypeStringArray = 32
 )
 
 var argTypeTable = map[string]uint32{
-	"int":        argTypeInt,
-	"uint32":     argTypeU32,
-	"int32":      argTypeS32,
-	"uint64":     argTypeU64,
-	"int64":      argTypeS64,
-	"char_buf":   argTypeCharBuf,
-	"char_iovec": argTypeCharIovec,
-	"sizet":      argTypeSizet,
-	"skb":        argTypeSkb,
-	"string":     argTypeString,
-	"fd":         argTypeFd,
-	"path":       argTypePath,
-	"file":       argTypeFile,
-	"sock":       argTypeSock,
-	"url":        argTypeUrl,
-	"fqdn":       argTypeFqdn,
+	"int":          argTypeInt,
+	"uint32":       argTypeU32,
+	"int32":        argTypeS32,
+	"uint64":       argTypeU64,
+	"int64":        argTypeS64,
+	"char_buf":     argTypeCharBuf,
+	"char_iovec":   argTypeCharIovec,
+	"sizet":        argTypeSizet,
+	"skb":          argTypeSkb,
+	"string":       argTypeString,
+	"fd":           argTypeFd,
+	"path":         argTypePath,
+	"file":         argTypeFile,
+	"sock":         argTypeSock,
+	"socket":       argTypeSocket,
+	"sockaddr":     argTypeSockaddr,
+	"linux_binprm": argTypeLinuxBinprm,
+	"dentry":       argTypeDentry,
+	"string_array": argTypeStringArray,
+	"url":          argTypeUrl,
+	"fqdn":         argTypeFqdn,
+	"capability":   argTypeCapability,
 }
 
 var argTypeStringTable = map[uint32]string{
-	argTypeInt:       "int",
-	argTypeU32:       "uint32",
-	argTypeS32:       "int32",
-	argTypeU64:       "uint64",
-	argTypeS64:       "int64",
-	argTypeCharBuf:   "char_buf",
-	argTypeCharIovec: "char_iovec",
-	argTypeSizet:     "sizet",
-	argTypeSkb:       "skb",
-	argTypeString:    "string",
-	argTypeFd:        "fd",
-	argTypeFile:      "file",
-	argTypePath:      "path",
-	argTypeSock:      "sock",
-	argTypeUrl:       "url",
-	argTypeFqdn:      "fqdn",
+	argTypeInt:         "int",
+	argTypeU32:         "uint32",
+	argTypeS32:         "int32",
+	argTypeU64:         "uint64",
+	argTypeS64:         "int64",
+	argTypeCharBuf:     "char_buf",
+	argTypeCharIovec:   "char_iovec",
+	argTypeSizet:       "sizet",
+	argTypeSkb:         "skb",
+	argTypeString:      "string",
+	argTypeFd:          "fd",
+	argTypeFile:        "file",
+	argTypePath:        "path",
+	argTypeSock:        "sock",
+	argTypeSocket:      "socket",
+	argTypeSockaddr:    "sockaddr",
+	argTypeLinuxBinprm: "linux_binprm",
+	argTypeDentry:      "dentry",
+	argTypeStringArray: "string_array",
+	argTypeUrl:         "url",
+	argTypeFqdn:        "fqdn",
+	argTypeCapability:  "capability",
 }
 
 const (
@@ -235,13 +319,14 @@ const (
 	SelectorOpNotPrefix  = 26
 	SelectorOpNotPostfix = 27
 	// more socket ops
-	SelectorOpFamily = 28
-	SelectorOpState  = 29
+	SelectorOpFamily   = 28
+	SelectorOpState    = 29
+	SelectorOpSockType = 30
 )
 
 var selectorOpStringTable = map[uint32]string{
-	SelectorOpGT:           "gt",
-	SelectorOpLT:           "lt",
+	SelectorOpGT:           "GT",
+	SelectorOpLT:           "LT",
 	SelectorOpEQ:           "Equal",
 	SelectorOpNEQ:          "NotEqual",
 	SelectorOpIn:           "In",
@@ -268,13 +353,14 @@ var selectorOpStringTable = map[uint32]string{
 	SelectorOpNotPostfix:   "NotPostfix",
 	SelectorOpFamily:       "Family",
 	SelectorOpState:        "State",
+	SelectorOpSockType:     "SockType",
 }
 
 func SelectorOp(op string) (uint32, error) {
 	switch op {
-	case "gt":
+	case "gt", "GT", "GreaterThan":
 		return SelectorOpGT, nil
-	case "lt":
+	case "lt", "LT", "LessThan":
 		return SelectorOpLT, nil
 	case "eq", "Equal":
 		return SelectorOpEQ, nil
@@ -328,6 +414,8 @@ func SelectorOp(op string) (uint32, error) {
 		return SelectorOpFamily, nil
 	case "state", "State":
 		return SelectorOpState, nil
+	case "socktype", "SockType":
+		return SelectorOpSockType, nil
 	}
 
 	return 0, fmt.Errorf("Unknown op '%s'", op)
@@ -395,6 +483,11 @@ func ArgTypeToString(t uint32) string {
 	return argTypeStringTable[t]
 }
 
+// ActionTypeString returns the name of an action type.
+func ActionTypeString(act uint32) string {
+	return actionTypeStringTable[act]
+}
+
 func ActionTypeFromString(action string) int32 {
 	act, ok := actionTypeTable[strings.ToLower(action)]
 	if !ok {
@@ -436,6 +529,9 @@ func writeRangeInMap(v string, ty uint32, op uint32, m *ValueMap) error {
 		switch op {
 		case SelectorOpProtocol:
 			protocol, err := network.InetProtocolNumber(v)
+			if err != nil {
+				protocol, err = network.NetlinkProtocolNumber(v)
+			}
 			if err == nil {
 				protocolStr := fmt.Sprintf("%d", protocol)
 				rangeStr = []string{protocolStr, protocolStr}
@@ -452,6 +548,12 @@ func writeRangeInMap(v string, ty uint32, op uint32, m *ValueMap) error {
 				stateStr := fmt.Sprintf("%d", state)
 				rangeStr = []string{stateStr, stateStr}
 			}
+		case SelectorOpSockType:
+			sockType, err := network.InetTypeNumber(v)
+			if err == nil {
+				sockTypeStr := fmt.Sprintf("%d", sockType)
+				rangeStr = []string{sockTypeStr, sockTypeStr}
+			}
 		}
 	}
 	for idx := 0; idx < 2; idx++ {
@@ -567,6 +669,12 @@ func writeMatchValuesInMap(k *KernelSelectorState, values []string, ty uint32, o
 				return fmt.Errorf("MatchArgs value %s invalid: %w", v, err)
 			}
 			binary.LittleEndian.PutUint64(val[:], uint64(i))
+		case argTypeCapability:
+			c, err := parseCapability(v)
+			if err != nil {
+				return fmt.Errorf("MatchArgs value %s invalid: %w", v, err)
+			}
+			binary.LittleEndian.PutUint64(val[:], uint64(c))
 		default:
 			return fmt.Errorf("Unknown type: %d", ty)
 		}
@@ -588,6 +696,11 @@ func writeMatchAddrsInMap(k *KernelSelectorState, values []string) error {
 		if len(addr) == 4 {
 			val := KernelLPMTrie4{prefixLen: maskLen, addr: binary.LittleEndian.Uint32(addr)}
 			m4[val] = struct{}{}
+			// IPv4 traffic on dual-stack (AF_INET6) sockets uses
+			// IPv4-mapped addresses, so match those as well.
+			val6 := KernelLPMTrie6{prefixLen: v4MappedPrefixLen + maskLen}
+			copy(val6.addr[:], net.IP(addr).To16())
+			m6[val6] = struct{}{}
 		} else if len(addr) == 16 {
 			val := KernelLPMTrie6{prefixLen: maskLen}
 			copy(val.addr[:], addr)
@@ -612,6 +725,79 @@ func writeMatchAddrsInMap(k *KernelSelectorState, values []string) error {
 	return nil
 }
 
+// parsePort parses a port number or the name of a well-known service.
+func parsePort(v string) (uint16, error) {
+	if port, err := strconv.ParseUint(v, 10, 16); err == nil {
+		return uint16(port), nil
+	}
+	return network.ServicePortNumber(v)
+}
+
+// parsePortRange parses a value of the port operators, which can be a port, or
+// a range of ports specified as 'min:max' or 'min-max'. Ports can be given as
+// numbers or as names of well-known services.
+func parsePortRange(v string) (uint16, uint16, error) {
+	// service names can contain a '-', so check them first
+	if port, err := parsePort(v); err == nil {
+		return port, port, nil
+	}
+	sep := ":"
+	if !strings.Contains(v, sep) {
+		sep = "-"
+	}
+	minStr, maxStr, found := strings.Cut(v, sep)
+	if !found {
+		return 0, 0, fmt.Errorf("MatchArgs value %s invalid: not a port number or a known service", v)
+	}
+	minPort, err := parsePort(minStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("MatchArgs value %s invalid: %s: %w", v, minStr, err)
+	}
+	maxPort, err := parsePort(maxStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("MatchArgs value %s invalid: %s: %w", v, maxStr, err)
+	}
+	if minPort > maxPort {
+		minPort, maxPort = maxPort, minPort
+	}
+	return minPort, maxPort, nil
+}
+
+// portLPMTrie4 returns the LPM trie key for the ports that share their first
+// prefixLen bits with port. Ports are stored as 32-bit values in network byte
+// order, so that they can use the same LPM tries as IPv4 addresses.
+func portLPMTrie4(port uint32, prefixLen uint32) KernelLPMTrie4 {
+	var addr [4]byte
+	binary.BigEndian.PutUint32(addr[:], port)
+	return KernelLPMTrie4{prefixLen: 16 + prefixLen, addr: binary.LittleEndian.Uint32(addr[:])}
+}
+
+// writeMatchPortsInMap writes the ports and ranges of ports of values in an
+// LPM trie map. Each range is stored as the minimal set of prefixes covering
+// it, instead of one entry per port.
+func writeMatchPortsInMap(k *KernelSelectorState, values []string) error {
+	m := k.createAddr4Map()
+	for _, v := range values {
+		minPort, maxPort, err := parsePortRange(v)
+		if err != nil {
+			return err
+		}
+		for port := uint32(minPort); port <= uint32(maxPort); {
+			// find the largest aligned block starting at port that fits in the range
+			bits := uint32(0)
+			for bits < 16 && port&(1<<(bits+1)-1) == 0 && port+(1<<(bits+1))-1 <= uint32(maxPort) {
+				bits++
+			}
+			m[portLPMTrie4(port, 16-bits)] = struct{}{}
+			port += 1 << bits
+		}
+	}
+	// write the map id into the selector
+	mid := k.insertAddr4Map(m)
+	WriteSelectorUint32(k, mid)
+	return nil
+}
+
 func getBase(v string) int {
 	if strings.HasPrefix(v, "0x") {
 		return 16
@@ -622,6 +808,13 @@ func getBase(v string) int {
 	return 10
 }
 
+// v4MappedPrefixLen is the length of the ::ffff:0:0/96 prefix of IPv4-mapped
+// IPv6 addresses.
+const v4MappedPrefixLen = 96
+
+// parseAddr parses an IPv4/6 address or CIDR and returns the address and the
+// prefix length. IPv4 and IPv4-mapped IPv6 addresses are returned as 4 bytes,
+// other IPv6 addresses as 16 bytes.
 func parseAddr(v string) ([]byte, uint32, error) {
 	ipaddr := net.ParseIP(v)
 	if ipaddr != nil {
@@ -648,6 +841,17 @@ func parseAddr(v string) ([]byte, uint32, error) {
 		return nil, 0, fmt.Errorf("IP CIDR is not valid: mask part does not parse")
 	}
 	ipaddr4 := ipaddr.To4()
+	if ipaddr4 != nil && strings.Contains(vParts[0], ":") {
+		// IPv4-mapped IPv6 CIDR (e.g. ::ffff:10.0.0.0/104). If it only
+		// covers IPv4-mapped addresses, use the equivalent IPv4 CIDR.
+		if maskLen > 128 {
+			return nil, 0, fmt.Errorf("IP CIDR is not valid: IPv6 mask len must be <= 128")
+		}
+		if maskLen >= v4MappedPrefixLen {
+			return ipaddr4, uint32(maskLen - v4MappedPrefixLen), nil
+		}
+		return ipaddr.To16(), uint32(maskLen), nil
+	}
 	if ipaddr4 != nil {
 		if maskLen <= 32 {
 			return ipaddr4, uint32(maskLen), nil
@@ -692,8 +896,14 @@ func writeMatchValues(k *KernelSelectorState, values []string, ty, op uint32) er
 				return fmt.Errorf("MatchArgs value %s invalid: %w", v, err)
 			}
 			WriteSelectorUint64(k, uint64(i))
-		case argTypeSock, argTypeSkb:
-			return fmt.Errorf("MatchArgs type sock and skb do not support operator %s", selectorOpStringTable[op])
+		case argTypeCapability:
+			c, err := parseCapability(v)
+			if err != nil {
+				return fmt.Errorf("MatchArgs value %s invalid: %w", v, err)
+			}
+			WriteSelectorUint32(k, c)
+		case argTypeSock, argTypeSocket, argTypeSkb, argTypeSockaddr:
+			return fmt.Errorf("MatchArgs type sock, socket, skb and sockaddr do not support operator %s", selectorOpStringTable[op])
 		case argTypeCharIovec:
 			return fmt.Errorf("MatchArgs values %s unsupported", v)
 		}
@@ -701,6 +911,41 @@ func writeMatchValues(k *KernelSelectorState, values []string, ty, op uint32) er
 	return nil
 }
 
+// parseCapability parses a capability value of matchArgs, either a name like
+// CAP_SYS_ADMIN or its number.
+func parseCapability(v string) (uint32, error) {
+	if c, ok := tetragon.CapabilitiesType_value[strings.ToUpper(v)]; ok {
+		return uint32(c), nil
+	}
+	i, err := strconv.ParseUint(v, 0, 32)
+	if err != nil {
+		return 0, fmt.Errorf("unknown capability")
+	}
+	if _, ok := tetragon.CapabilitiesType_name[int32(i)]; !ok {
+		return 0, fmt.Errorf("unknown capability")
+	}
+	return uint32(i), nil
+}
+
+// isSockArgType returns true if the type supports the sock/skb operators
+func isSockArgType(ty uint32) bool {
+	return ty == argTypeSock || ty == argTypeSocket || ty == argTypeSkb || ty == argTypeSockaddr
+}
+
+// isSockaddrOp returns true if the sock/skb operator applies to sockaddr
+// arguments, that only have a family, an address and a port. The source and
+// destination operators both match the address and the port of the sockaddr.
+func isSockaddrOp(op uint32) bool {
+	switch op {
+	case SelectorOpFamily, SelectorOpSaddr, SelectorOpNotSaddr,
+		SelectorOpSport, SelectorOpNotSport, SelectorOpSportPriv, SelectorOpNotSportPriv,
+		SelectorOpDaddr, SelectorOpNotDaddr,
+		SelectorOpDport, SelectorOpNotDport, SelectorOpDportPriv, SelectorOpNotDportPriv:
+		return true
+	}
+	return false
+}
+
 func writeMatchStrings(k *KernelSelectorState, values []string, ty uint32) error {
 	maps := k.createStringMaps()
 
@@ -768,6 +1013,27 @@ func writePostfixStrings(k *KernelSelectorState, values []string, ty uint32) err
 	return nil
 }
 
+// isStringArgType returns true for the argument types that the generic
+// kprobe/tracepoint programs compare as strings (Equal, Prefix and Postfix).
+func isStringArgType(ty uint32) bool {
+	switch ty {
+	case argTypeFd, argTypeFile, argTypePath, argTypeString, argTypeCharBuf,
+		argTypeLinuxBinprm, argTypeDentry:
+		return true
+	}
+	return false
+}
+
+// isStringOp returns true if the operator is supported by string types.
+func isStringOp(op uint32) bool {
+	switch op {
+	case SelectorOpEQ, SelectorOpNEQ, SelectorOpPrefix, SelectorOpNotPrefix,
+		SelectorOpPostfix, SelectorOpNotPostfix:
+		return true
+	}
+	return false
+}
+
 func ParseMatchArg(k *KernelSelectorState, arg *v1alpha1.ArgSelector, sig []v1alpha1.KProbeArg) error {
 	WriteSelectorUint32(k, arg.Index)
 
@@ -782,6 +1048,15 @@ func ParseMatchArg(k *KernelSelectorState, arg *v1alpha1.ArgSelector, sig []v1al
 		return fmt.Errorf("argSelector error: %w", err)
 	}
 	WriteSelectorUint32(k, ty)
+	if ty == argTypeStringArray {
+		return fmt.Errorf("matchArgs not supported for %s type", argTypeStringTable[ty])
+	}
+	if ty == argTypeSockaddr && !isSockaddrOp(op) {
+		return fmt.Errorf("operator %s specified for sockaddr type", selectorOpStringTable[op])
+	}
+	if isStringArgType(ty) && !isStringOp(op) {
+		return fmt.Errorf("operator %s not supported for type %s", selectorOpStringTable[op], argTypeStringTable[ty])
+	}
 	switch op {
 	case SelectorInMap, SelectorNotInMap:
 		err := writeMatchValuesInMap(k, arg.Values, ty, op)
@@ -789,38 +1064,54 @@ func ParseMatchArg(k *KernelSelectorState, arg *v1alpha1.ArgSelector, sig []v1al
 			return fmt.Errorf("writeMatchRangesInMap error: %w", err)
 		}
 	case SelectorOpEQ, SelectorOpNEQ:
-		switch ty {
-		case argTypeFd, argTypeFile, argTypePath, argTypeString, argTypeCharBuf:
+		if isStringArgType(ty) {
 			err := writeMatchStrings(k, arg.Values, ty)
 			if err != nil {
 				return fmt.Errorf("writeMatchStrings error: %w", err)
 			}
-		default:
+		} else {
 			err = writeMatchValues(k, arg.Values, ty, op)
 			if err != nil {
 				return fmt.Errorf("writeMatchValues error: %w", err)
 			}
 		}
 	case SelectorOpPrefix, SelectorOpNotPrefix:
+		if !isStringArgType(ty) {
+			return fmt.Errorf("prefix operators specified for non-string type %s", argTypeStringTable[ty])
+		}
 		err := writePrefixStrings(k, arg.Values)
 		if err != nil {
 			return fmt.Errorf("writePrefixStrings error: %w", err)
 		}
 	case SelectorOpPostfix, SelectorOpNotPostfix:
+		if !isStringArgType(ty) {
+			return fmt.Errorf("postfix operators specified for non-string type %s", argTypeStringTable[ty])
+		}
 		err := writePostfixStrings(k, arg.Values, ty)
 		if err != nil {
 			return fmt.Errorf("writePostfixStrings error: %w", err)
 		}
-	case SelectorOpSport, SelectorOpDport, SelectorOpNotSport, SelectorOpNotDport, SelectorOpProtocol, SelectorOpFamily, SelectorOpState:
-		if ty != argTypeSock && ty != argTypeSkb {
+	case SelectorOpSport, SelectorOpDport, SelectorOpNotSport, SelectorOpNotDport:
+		if !isSockArgType(ty) {
 			return fmt.Errorf("sock/skb operators specified for non-sock/skb type")
 		}
-		err := writeMatchRangesInMap(k, arg.Values, argTypeU64, op) // force type for ports and protocols as ty is sock/skb
+		err := writeMatchPortsInMap(k, arg.Values)
+		if err != nil {
+			return fmt.Errorf("writeMatchPortsInMap error: %w", err)
+		}
+	case SelectorOpProtocol, SelectorOpFamily, SelectorOpState, SelectorOpSockType:
+		if !isSockArgType(ty) {
+			return fmt.Errorf("sock/skb operators specified for non-sock/skb type")
+		}
+		if op == SelectorOpSockType && ty == argTypeSkb {
+			return fmt.Errorf("SockType operator specified for skb type")
+		}
+		err := writeMatchRangesInMap(k, arg.Values, argTypeU64, op) // force type for protocols as ty is sock/skb
 		if err != nil {
 			return fmt.Errorf("writeMatchRangesInMap error: %w", err)
 		}
 	case SelectorOpSaddr, SelectorOpDaddr, SelectorOpNotSaddr, SelectorOpNotDaddr:
-		if ty != argTypeSock && ty != argTypeSkb {
+		if !isSockArgType(ty) {
 			return fmt.Errorf("sock/skb operators specified for non-sock/skb type")
 		}
 		err := writeMatchAddrsInMap(k, arg.Values)
@@ -829,7 +1120,7 @@ func ParseMatchArg(k *KernelSelectorState, arg *v1alpha1.ArgSelector, sig []v1al
 		}
 	case SelectorOpSportPriv, SelectorOpDportPriv, SelectorOpNotSportPriv, SelectorOpNotDportPriv:
 		// These selectors do not take any values, but we do check that they are only used for sock/skb.
-		if ty != argTypeSock && ty != argTypeSkb {
+		if !isSockArgType(ty) {
 			return fmt.Errorf("sock/skb operators specified for non-sock/skb type")
 		}
 	default:
@@ -900,6 +1191,46 @@ func parseRateLimit(str string) (uint32, error) {
 	return uint32(rateLimit), nil
 }
 
+const (
+	rateLimitScopeThread  = 0
+	rateLimitScopeProcess = 1
+	rateLimitScopeGlobal  = 2
+)
+
+var rateLimitScopeTable = map[string]uint32{
+	"":        rateLimitScopeThread,
+	"thread":  rateLimitScopeThread,
+	"process": rateLimitScopeProcess,
+	"global":  rateLimitScopeGlobal,
+}
+
+// parseRateLimitCount parses a rateLimit in the COUNT/PERIOD format (e.g., 10/s
+// or 100/5m), or in the PERIOD format which is equivalent to 1/PERIOD. It
+// returns the period in milliseconds and the count.
+func parseRateLimitCount(str string) (uint32, uint32, error) {
+	countStr, period, found := strings.Cut(str, "/")
+	if !found {
+		interval, err := parseRateLimit(str)
+		return interval, 1, err
+	}
+	count, err := strconv.ParseUint(countStr, 10, 32)
+	if err != nil || count == 0 {
+		return 0, 0, fmt.Errorf("parseRateLimit: rateLimit count %s is invalid", countStr)
+	}
+	// allow a unit without a value, e.g. 10/s
+	switch period {
+	case "s", "S", "m", "M", "h", "H":
+		period = "1" + period
+	case "":
+		return 0, 0, fmt.Errorf("parseRateLimit: rateLimit value %s is invalid", str)
+	}
+	interval, err := parseRateLimit(period)
+	if err != nil {
+		return 0, 0, err
+	}
+	return interval, uint32(count), nil
+}
+
 func ParseMatchAction(k *KernelSelectorState, action *v1alpha1.ActionSelector, actionArgTable *idtable.Table) error {
 	act, ok := actionTypeTable[strings.ToLower(action.Action)]
 	if !ok {
@@ -908,16 +1239,32 @@ func ParseMatchAction(k *KernelSelectorState, action *v1alpha1.ActionSelector, a
 	WriteSelectorUint32(k, act)
 
 	rateLimit := uint32(0)
+	rateLimitCount := uint32(0)
 	if action.RateLimit != "" {
 		if act != ActionTypePost {
 			return fmt.Errorf("rate limiting can only applied to post action (was applied to '%s')", action.Action)
 		}
 		var err error
-		rateLimit, err = parseRateLimit(action.RateLimit)
+		rateLimit, rateLimitCount, err = parseRateLimitCount(action.RateLimit)
 		if err != nil {
 			return err
 		}
 	}
+	rateLimitScope, ok := rateLimitScopeTable[action.RateLimitScope]
+	if !ok {
+		return fmt.Errorf("rateLimitScope '%s' is invalid, should be one of thread, process or global", action.RateLimitScope)
+	}
+	if action.RateLimitScope != "" && action.RateLimit == "" {
+		return fmt.Errorf("rateLimitScope can only be used with rateLimit")
+	}
+	if action.Sample != 0 {
+		if act != ActionTypePost {
+			return fmt.Errorf("sampling can only be applied to post action (was applied to '%s')", action.Action)
+		}
+		if !kernels.EnableLargeProgs() {
+			return fmt.Errorf("sampling is only supported in kernels >= 5.3")
+		}
+	}
 
 	switch act {
 	case ActionTypeFollowFd, ActionTypeCopyFd:
@@ -929,28 +1276,35 @@ func ParseMatchAction(k *KernelSelectorState, action *v1alpha1.ActionSelector, a
 	case ActionTypeOverride:
 		WriteSelectorInt32(k, action.ArgError)
 	case ActionTypeGetUrl, ActionTypeDnsLookup:
-		actionArg := ActionArgEntry{
-			tableId: idtable.UninitializedEntryID,
+		arg := action.ArgUrl
+		if act == ActionTypeDnsLookup {
+			arg = action.ArgFqdn
 		}
-		switch act {
-		case ActionTypeGetUrl:
-			actionArg.arg = action.ArgUrl
-		case ActionTypeDnsLookup:
-			actionArg.arg = action.ArgFqdn
+		id := addActionArg(actionArgTable, arg)
+		if k.actionArgs == nil {
+			k.actionArgs = make(map[idtable.EntryID]struct{})
 		}
-		actionArgTable.AddEntry(&actionArg)
-		WriteSelectorUint32(k, uint32(actionArg.tableId.ID))
+		k.actionArgs[id] = struct{}{}
+		WriteSelectorUint32(k, uint32(id.ID))
 	case ActionTypeSignal:
 		WriteSelectorUint32(k, action.ArgSig)
 	case ActionTypeTrackSock, ActionTypeUntrackSock:
 		WriteSelectorUint32(k, action.ArgSock)
 	case ActionTypePost:
 		WriteSelectorUint32(k, rateLimit)
+		WriteSelectorUint32(k, rateLimitCount)
+		WriteSelectorUint32(k, rateLimitScope)
 		stackTrace := uint32(0)
 		if action.StackTrace {
 			stackTrace = 1
 		}
 		WriteSelectorUint32(k, stackTrace)
+		userStackTrace := uint32(0)
+		if action.UserStackTrace {
+			userStackTrace = 1
+		}
+		WriteSelectorUint32(k, userStackTrace)
+		WriteSelectorUint32(k, action.Sample)
 	case ActionTypeNoPost:
 		// no arguments
 	case ActionTypeSigKill:
@@ -959,6 +1313,14 @@ func ParseMatchAction(k *KernelSelectorState, action *v1alpha1.ActionSelector, a
 	case ActionTypeNotifyKiller:
 		WriteSelectorInt32(k, action.ArgError)
 		WriteSelectorUint32(k, action.ArgSig)
+	case ActionTypeCount:
+		// no arguments
+	case ActionTypeSetTag:
+		id, err := tagID(action.ArgTag)
+		if err != nil {
+			return fmt.Errorf("setTag action error: %w", err)
+		}
+		WriteSelectorUint32(k, id)
 	default:
 		return fmt.Errorf("ParseMatchAction: act %d (%s) is missing a handler", act, actionTypeStringTable[act])
 	}
@@ -1064,6 +1426,10 @@ func ParseMatchNamespaceChange(k *KernelSelectorState, action *v1alpha1.Namespac
 	}
 	WriteSelectorUint32(k, op)
 
+	if len(action.Values) == 0 {
+		return fmt.Errorf("matchNamespaceChanges requires at least one namespace value")
+	}
+
 	// process and write values
 	nsval := uint32(0)
 	for _, v := range action.Values {
@@ -1155,6 +1521,9 @@ func ParseMatchCapabilities(k *KernelSelectorState, actions []v1alpha1.Capabilit
 }
 
 func ParseMatchCapabilityChanges(k *KernelSelectorState, actions []v1alpha1.CapabilitiesSelector) error {
+	if (len(actions) > 0) && (kernels.EnableLargeProgs() == false) {
+		return fmt.Errorf("matchCapabilityChanges is only supported in kernels >= 5.3")
+	}
 	loff := AdvanceSelectorLength(k)
 	for _, a := range actions {
 		if err := ParseMatchCaps(k, &a); err != nil {
@@ -1165,20 +1534,131 @@ func ParseMatchCapabilityChanges(k *KernelSelectorState, actions []v1alpha1.Capa
 	return nil
 }
 
+func ParseMatchCredential(k *KernelSelectorState, cred *v1alpha1.CredentialsSelector) error {
+	// type
+	ty, ok := credentialsTypeTable[strings.ToLower(cred.Type)]
+	if !ok {
+		return fmt.Errorf("parseMatchCredential: type %s unknown", cred.Type)
+	}
+	WriteSelectorUint32(k, ty)
+
+	// operator
+	op, err := SelectorOp(cred.Operator)
+	if err != nil {
+		return fmt.Errorf("matchCredentials error: %w", err)
+	}
+	if (op != SelectorOpIn) && (op != SelectorOpNotIn) {
+		return fmt.Errorf("matchCredentials supports only In and NotIn operators")
+	}
+	WriteSelectorUint32(k, op)
+
+	// values
+	if len(cred.Values) == 0 {
+		return fmt.Errorf("matchCredentials requires at least one value")
+	}
+	if len(cred.Values) > 4 { // 4 should match the number of iterations in selector_match() in pfilter.h
+		return fmt.Errorf("matchCredentials supports up to 4 values per filter (current number of values is %d)", len(cred.Values))
+	}
+	WriteSelectorUint32(k, uint32(len(cred.Values)))
+	for _, v := range cred.Values {
+		WriteSelectorUint32(k, v)
+	}
+	return nil
+}
+
+func ParseMatchCredentials(k *KernelSelectorState, creds []v1alpha1.CredentialsSelector) error {
+	if (len(creds) > 0) && (kernels.EnableLargeProgs() == false) {
+		return fmt.Errorf("matchCredentials is only supported in kernels >= 5.3")
+	}
+	if len(creds) > len(credentialsTypeTable) { // should match NUM_CRED_FILTERS in pfilter.h
+		return fmt.Errorf("matchCredentials supports up to %d filters (current number of filters is %d)", len(credentialsTypeTable), len(creds))
+	}
+	loff := AdvanceSelectorLength(k)
+	for _, c := range creds {
+		if err := ParseMatchCredential(k, &c); err != nil {
+			return err
+		}
+	}
+	WriteSelectorLength(k, loff)
+	return nil
+}
+
+func ParseMatchTag(k *KernelSelectorState, tags *v1alpha1.TagsSelector) error {
+	// operator
+	op, err := SelectorOp(tags.Operator)
+	if err != nil {
+		return fmt.Errorf("matchTags error: %w", err)
+	}
+	if (op != SelectorOpIn) && (op != SelectorOpNotIn) {
+		return fmt.Errorf("matchTags supports only In and NotIn operators")
+	}
+	WriteSelectorUint32(k, op)
+
+	// values, as a mask of the tag IDs
+	if len(tags.Values) == 0 {
+		return fmt.Errorf("matchTags requires at least one value")
+	}
+	mask, err := tagsMask(tags.Values)
+	if err != nil {
+		return fmt.Errorf("matchTags error: %w", err)
+	}
+	WriteSelectorUint32(k, uint32(mask))
+	WriteSelectorUint32(k, uint32(mask>>32))
+	return nil
+}
+
+func ParseMatchTags(k *KernelSelectorState, tags []v1alpha1.TagsSelector) error {
+	if (len(tags) > 0) && (kernels.EnableLargeProgs() == false) {
+		return fmt.Errorf("matchTags is only supported in kernels >= 5.3")
+	}
+	if len(tags) > 2 { // should match NUM_TAG_FILTERS in pfilter.h
+		return fmt.Errorf("matchTags supports up to %d filters (current number of filters is %d)", 2, len(tags))
+	}
+	loff := AdvanceSelectorLength(k)
+	for _, t := range tags {
+		if err := ParseMatchTag(k, &t); err != nil {
+			return err
+		}
+	}
+	WriteSelectorLength(k, loff)
+	return nil
+}
+
 func ParseMatchBinary(k *KernelSelectorState, b *v1alpha1.BinarySelector, selIdx int) error {
 	op, err := SelectorOp(b.Operator)
 	if err != nil {
 		return fmt.Errorf("matchBinary error: %w", err)
 	}
-	if op != SelectorOpIn && op != SelectorOpNotIn {
-		return fmt.Errorf("matchBinary error: Only In and NotIn operators are supported")
-	}
-	k.SetBinaryOp(selIdx, op)
-	for _, s := range b.Values {
-		if len(s) > 255 {
-			return fmt.Errorf("matchBinary error: Binary names > 255 chars do not supported")
+	switch op {
+	case SelectorOpIn, SelectorOpNotIn:
+		k.SetBinaryOp(selIdx, op)
+		for _, s := range b.Values {
+			if len(s) > 255 {
+				return fmt.Errorf("matchBinary error: Binary names > 255 chars do not supported")
+			}
+			k.AddBinaryName(selIdx, s)
 		}
-		k.AddBinaryName(selIdx, s)
+	case SelectorOpPrefix, SelectorOpNotPrefix:
+		// the kernel resolves prefixes to ids at exec time, so from
+		// the selector point of view these are In/NotIn operations
+		if op == SelectorOpPrefix {
+			k.SetBinaryOp(selIdx, SelectorOpIn)
+		} else {
+			k.SetBinaryOp(selIdx, SelectorOpNotIn)
+		}
+		for _, s := range b.Values {
+			if len(s) == 0 {
+				return fmt.Errorf("matchBinary error: empty prefix is not supported")
+			}
+			if len(s) > 255 {
+				return fmt.Errorf("matchBinary error: Binary prefixes > 255 chars do not supported")
+			}
+			if err := k.AddBinaryPrefix(selIdx, s); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("matchBinary error: Only In, NotIn, Prefix and NotPrefix operators are supported")
 	}
 	return nil
 }
@@ -1216,6 +1696,12 @@ func parseSelector(
 	if err := ParseMatchCapabilityChanges(k, selectors.MatchCapabilityChanges); err != nil {
 		return fmt.Errorf("parseMatchCapabilityChanges error: %w", err)
 	}
+	if err := ParseMatchCredentials(k, selectors.MatchCredentials); err != nil {
+		return fmt.Errorf("parseMatchCredentials error: %w", err)
+	}
+	if err := ParseMatchTags(k, selectors.MatchTags); err != nil {
+		return fmt.Errorf("parseMatchTags error: %w", err)
+	}
 	if err := ParseMatchBinaries(k, selectors.MatchBinaries, selIdx); err != nil {
 		return fmt.Errorf("parseMatchBinaries error: %w", err)
 	}
@@ -1245,6 +1731,8 @@ func parseSelector(
 //	[matchCapabilities]
 //	[matchNamespaceChanges]
 //	[matchCapabilityChanges]
+//	[matchCredentials]
+//	[matchTags]
 //	[matchArgs]
 //	[matchActions]
 //
@@ -1253,12 +1741,16 @@ func parseSelector(
 // matchCapabilities := [length][CAx][CAy]...[CAn]
 // matchNamespaceChanges := [length][NCx][NCy]...[NCn]
 // matchCapabilityChanges := [length][CAx][CAy]...[CAn]
+// matchCredentials := [length][CRx][CRy]...[CRn]
+// matchTags := [length][TAx][TAy]...[TAn]
 // matchArgs := [length][ARGx][ARGy]...[ARGn]
 // PIDn := [op][flags][nValues][v1]...[vn]
 // Argn := [index][op][valueGen]
 // NSn := [namespace][op][valueInt]
 // NCn := [op][valueInt]
 // CAn := [type][op][namespacecap][valueInt]
+// CRn := [type][op][nValues][v1]...[vn]
+// TAn := [op][mask_lo][mask_hi]
 // valueGen := [type][len][v]
 // valueInt := [len][v]
 //
@@ -1268,6 +1760,8 @@ func InitKernelSelectors(selectors []v1alpha1.KProbeSelector, args []v1alpha1.KP
 	if err != nil {
 		return [4096]byte{}, err
 	}
+	// only the encoding is returned, so the prefixes are not used
+	kernelSelectors.ReleaseBinaryPrefixes()
 	return kernelSelectors.e, nil
 }
 
@@ -1284,6 +1778,7 @@ func InitKernelSelectorState(selectors []v1alpha1.KProbeSelector, args []v1alpha
 		WriteSelectorLength(kernelSelectors, soff[i])
 		loff := AdvanceSelectorLength(kernelSelectors)
 		if err := parseSelector(kernelSelectors, &s, i, args, actionArgTable); err != nil {
+			kernelSelectors.ReleaseBinaryPrefixes()
 			return nil, err
 		}
 		WriteSelectorLength(kernelSelectors, loff)
@@ -1303,6 +1798,19 @@ func HasOverride(spec *v1alpha1.KProbeSpec) bool {
 	return false
 }
 
+// HasCount returns true if one of the selectors has a Count action.
+func HasCount(selectors []v1alpha1.KProbeSelector) bool {
+	for _, s := range selectors {
+		for _, action := range s.MatchActions {
+			act, _ := actionTypeTable[strings.ToLower(action.Action)]
+			if act == ActionTypeCount {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func HasEarlyBinaryFilter(selectors []v1alpha1.KProbeSelector) bool {
 	if len(selectors) == 0 {
 		return false
@@ -1313,6 +1821,8 @@ func HasEarlyBinaryFilter(selectors []v1alpha1.KProbeSelector) bool {
 			len(s.MatchCapabilities) > 0 ||
 			len(s.MatchNamespaceChanges) > 0 ||
 			len(s.MatchCapabilityChanges) > 0 ||
+			len(s.MatchCredentials) > 0 ||
+			len(s.MatchTags) > 0 ||
 			len(s.MatchArgs) > 0 {
 			return false
 		}
@@ -1323,9 +1833,10 @@ func HasEarlyBinaryFilter(selectors []v1alpha1.KProbeSelector) bool {
 	return false
 }
 
-func HasSigkillAction(kspec *v1alpha1.KProbeSpec) bool {
-	for i := range kspec.Selectors {
-		s := &kspec.Selectors[i]
+// HasSigkillAction returns true if any of the selectors has a Sigkill action
+func HasSigkillAction(selectors []v1alpha1.KProbeSelector) bool {
+	for i := range selectors {
+		s := &selectors[i]
 		for j := range s.MatchActions {
 			act := strings.ToLower(s.MatchActions[j].Action)
 			if act == "sigkill" {
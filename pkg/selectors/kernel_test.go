@@ -9,6 +9,8 @@ package selectors
 import (
 	"bytes"
 	"encoding/binary"
+	"fmt"
+	"net"
 	"strings"
 	"testing"
 
@@ -102,6 +104,12 @@ func TestSelectorOp(t *testing.T) {
 	if op, err := SelectorOp("lt"); op != SelectorOpLT || err != nil {
 		t.Errorf("selectorOp: expected %d actual %d %v\n", SelectorOpLT, op, err)
 	}
+	if op, err := SelectorOp("GreaterThan"); op != SelectorOpGT || err != nil {
+		t.Errorf("selectorOp: expected %d actual %d %v\n", SelectorOpGT, op, err)
+	}
+	if op, err := SelectorOp("LT"); op != SelectorOpLT || err != nil {
+		t.Errorf("selectorOp: expected %d actual %d %v\n", SelectorOpLT, op, err)
+	}
 	if op, err := SelectorOp("eq"); op != SelectorOpEQ || err != nil {
 		t.Errorf("selectorOp: expected %d actual %d %v\n", SelectorOpEQ, op, err)
 	}
@@ -260,7 +268,7 @@ func TestParseMatchArg(t *testing.T) {
 		16, 0x00, 0x00, 0x00, // length == 16
 		0x07, 0x00, 0x00, 0x00, // value type == sock
 		0x00, 0x00, 0x00, 0x00, // Addr4LPM mapid = 0
-		0xff, 0xff, 0xff, 0xff, // Addr6LPM no map
+		0x00, 0x00, 0x00, 0x00, // Addr6LPM mapid = 0 (IPv4-mapped)
 	}
 	if err := ParseMatchArg(k, arg3, sig); err != nil || bytes.Equal(expected3, k.e[nextArg:k.off]) == false {
 		t.Errorf("parseMatchArg: error %v expected %v bytes %v parsing %v\n", err, expected3, k.e[nextArg:k.off], arg3)
@@ -273,7 +281,7 @@ func TestParseMatchArg(t *testing.T) {
 		15, 0x00, 0x00, 0x00, // operator == sport
 		12, 0x00, 0x00, 0x00, // length == 12
 		0x05, 0x00, 0x00, 0x00, // value type == skb
-		0x00, 0x00, 0x00, 0x00, // argfilter mapid = 0
+		0x01, 0x00, 0x00, 0x00, // Addr4LPM mapid = 1
 	}
 	if err := ParseMatchArg(k, arg4, sig); err != nil || bytes.Equal(expected4, k.e[nextArg:k.off]) == false {
 		t.Errorf("parseMatchArg: error %v expected %v bytes %v parsing %v\n", err, expected4, k.e[nextArg:k.off], arg4)
@@ -286,7 +294,7 @@ func TestParseMatchArg(t *testing.T) {
 		17, 0x00, 0x00, 0x00, // operator == protocol
 		12, 0x00, 0x00, 0x00, // length == 12
 		0x05, 0x00, 0x00, 0x00, // value type == skb
-		1, 0x00, 0x00, 0x00, // argfilter mapid = 1
+		0x00, 0x00, 0x00, 0x00, // argfilter mapid = 0
 	}
 	if err := ParseMatchArg(k, arg5, sig); err != nil || bytes.Equal(expected5, k.e[nextArg:k.off]) == false {
 		t.Errorf("parseMatchArg: error %v expected %v bytes %v parsing %v\n", err, expected5, k.e[nextArg:k.off], arg5)
@@ -299,13 +307,22 @@ func TestParseMatchArg(t *testing.T) {
 		13, 0x00, 0x00, 0x00, // operator == saddr
 		16, 0x00, 0x00, 0x00, // length == 16
 		0x07, 0x00, 0x00, 0x00, // value type == sock
-		1, 0x00, 0x00, 0x00, // Addr4LPM mapid = 1
-		0x00, 0x00, 0x00, 0x00, // Addr6LPM mapid = 0
+		2, 0x00, 0x00, 0x00, // Addr4LPM mapid = 2
+		1, 0x00, 0x00, 0x00, // Addr6LPM mapid = 1
 	}
 	if err := ParseMatchArg(k, arg6, sig); err != nil || bytes.Equal(expected6, k.e[nextArg:k.off]) == false {
 		t.Errorf("parseMatchArg: error %v expected %v bytes %v parsing %v\n", err, expected6, k.e[nextArg:k.off], arg6)
 	}
 
+	arg7 := &v1alpha1.ArgSelector{Index: 2, Operator: "Prefix", Values: []string{"1"}}
+	if err := ParseMatchArg(k, arg7, sig); err == nil {
+		t.Errorf("parseMatchArg: expected error for Prefix on int type, parsing %v\n", arg7)
+	}
+	arg8 := &v1alpha1.ArgSelector{Index: 5, Operator: "Postfix", Values: []string{"foo"}}
+	if err := ParseMatchArg(k, arg8, sig); err == nil {
+		t.Errorf("parseMatchArg: expected error for Postfix on sock type, parsing %v\n", arg8)
+	}
+
 	if kernels.EnableLargeProgs() { // multiple match args are supported only in kernels >= 5.4
 		length := []byte{
 			88, 0x00, 0x00, 0x00,
@@ -325,6 +342,246 @@ func TestParseMatchArg(t *testing.T) {
 	}
 }
 
+func TestParseAddr(t *testing.T) {
+	tests := []struct {
+		value   string
+		addr    net.IP
+		maskLen uint32
+	}{
+		{"10.1.2.3", net.ParseIP("10.1.2.3").To4(), 32},
+		{"10.1.2.0/24", net.ParseIP("10.1.2.0").To4(), 24},
+		{"2a1:56::1", net.ParseIP("2a1:56::1"), 128},
+		{"2a1:56::/32", net.ParseIP("2a1:56::"), 32},
+		{"::ffff:10.1.2.3", net.ParseIP("10.1.2.3").To4(), 32},
+		{"::ffff:10.1.2.0/120", net.ParseIP("10.1.2.0").To4(), 24},
+		{"::ffff:0.0.0.0/96", net.ParseIP("0.0.0.0").To4(), 0},
+		{"::ffff:0.0.0.0/80", net.ParseIP("::ffff:0.0.0.0").To16(), 80},
+	}
+	for _, test := range tests {
+		addr, maskLen, err := parseAddr(test.value)
+		if err != nil {
+			t.Errorf("parseAddr(%s): unexpected error: %v", test.value, err)
+			continue
+		}
+		if !bytes.Equal(addr, test.addr) || maskLen != test.maskLen {
+			t.Errorf("parseAddr(%s): expected %v/%d got %v/%d", test.value, test.addr, test.maskLen, addr, maskLen)
+		}
+	}
+
+	for _, value := range []string{"10.1.2.0/33", "::1/129", "::ffff:10.1.2.0/129", "10.1.2", "10.1.2.0/24/8"} {
+		if _, _, err := parseAddr(value); err == nil {
+			t.Errorf("parseAddr(%s): expected error", value)
+		}
+	}
+}
+
+func TestWriteMatchAddrsInMap(t *testing.T) {
+	k := NewKernelSelectorState(nil, nil)
+	if err := writeMatchAddrsInMap(k, []string{"10.1.2.0/24", "::ffff:192.168.0.1", "2a1:56::/32"}); err != nil {
+		t.Fatalf("writeMatchAddrsInMap: unexpected error: %v", err)
+	}
+
+	m4 := k.Addr4Maps()
+	if len(m4) != 1 || len(m4[0]) != 2 {
+		t.Fatalf("expected one IPv4 map with 2 entries, got %v", m4)
+	}
+	for _, v := range []struct {
+		addr      string
+		prefixLen uint32
+	}{{"10.1.2.0", 24}, {"192.168.0.1", 32}} {
+		val := KernelLPMTrie4{prefixLen: v.prefixLen, addr: binary.LittleEndian.Uint32(net.ParseIP(v.addr).To4())}
+		if _, ok := m4[0][val]; !ok {
+			t.Errorf("IPv4 map: missing %s/%d", v.addr, v.prefixLen)
+		}
+	}
+
+	// IPv4 CIDRs are also added as IPv4-mapped IPv6 CIDRs
+	m6 := k.Addr6Maps()
+	if len(m6) != 1 || len(m6[0]) != 3 {
+		t.Fatalf("expected one IPv6 map with 3 entries, got %v", m6)
+	}
+	for _, v := range []struct {
+		addr      string
+		prefixLen uint32
+	}{{"::ffff:10.1.2.0", 120}, {"::ffff:192.168.0.1", 128}, {"2a1:56::", 32}} {
+		val := KernelLPMTrie6{prefixLen: v.prefixLen}
+		copy(val.addr[:], net.ParseIP(v.addr).To16())
+		if _, ok := m6[0][val]; !ok {
+			t.Errorf("IPv6 map: missing %s/%d", v.addr, v.prefixLen)
+		}
+	}
+}
+
+func TestParsePortRange(t *testing.T) {
+	tests := []struct {
+		str      string
+		min, max uint16
+	}{
+		{"80", 80, 80},
+		{"https", 443, 443},
+		{"1024:65535", 1024, 65535},
+		{"1024-65535", 1024, 65535},
+		{"8081-8080", 8080, 8081},
+		{"http-https", 80, 443},
+	}
+	for _, test := range tests {
+		min, max, err := parsePortRange(test.str)
+		if err != nil || min != test.min || max != test.max {
+			t.Errorf("parsePortRange(%s): error %v expected %d-%d got %d-%d", test.str, err, test.min, test.max, min, max)
+		}
+	}
+	for _, str := range []string{"65536", "1-2-3", "1024-", "no-such-service"} {
+		if _, _, err := parsePortRange(str); err == nil {
+			t.Errorf("parsePortRange(%s): expected error", str)
+		}
+	}
+}
+
+func TestWriteMatchPortsInMap(t *testing.T) {
+	k := NewKernelSelectorState(nil, nil)
+	if err := writeMatchPortsInMap(k, []string{"1024-65535", "https", "8080:8081"}); err != nil {
+		t.Fatalf("writeMatchPortsInMap: unexpected error: %v", err)
+	}
+
+	m := k.Addr4Maps()
+	if len(m) != 1 || len(m[0]) != 8 {
+		t.Fatalf("expected one map with 8 entries, got %v", m)
+	}
+	for _, v := range []struct {
+		port      uint32
+		prefixLen uint32
+	}{
+		// 1024-65535
+		{1024, 6}, {2048, 5}, {4096, 4}, {8192, 3}, {16384, 2}, {32768, 1},
+		{443, 16},
+		{8080, 15},
+	} {
+		if _, ok := m[0][portLPMTrie4(v.port, v.prefixLen)]; !ok {
+			t.Errorf("ports map: missing %d/%d", v.port, v.prefixLen)
+		}
+	}
+}
+
+func TestParseMatchArgSocket(t *testing.T) {
+	sig := []v1alpha1.KProbeArg{
+		{Index: 0, Type: "socket"},
+		{Index: 1, Type: "skb"},
+	}
+	valueKey := func(v uint64) [8]byte {
+		var key [8]byte
+		binary.LittleEndian.PutUint64(key[:], v)
+		return key
+	}
+
+	k := NewKernelSelectorState(nil, nil)
+	arg := &v1alpha1.ArgSelector{Index: 0, Operator: "SockType", Values: []string{"SOCK_RAW", "10"}}
+	if err := ParseMatchArg(k, arg, sig); err != nil {
+		t.Fatalf("parseMatchArg: unexpected error %v parsing %v", err, arg)
+	}
+	arg = &v1alpha1.ArgSelector{Index: 0, Operator: "Protocol", Values: []string{"NETLINK_AUDIT", "IPPROTO_ICMP"}}
+	if err := ParseMatchArg(k, arg, sig); err != nil {
+		t.Fatalf("parseMatchArg: unexpected error %v parsing %v", err, arg)
+	}
+	arg = &v1alpha1.ArgSelector{Index: 0, Operator: "Family", Values: []string{"AF_NETLINK", "AF_PACKET"}}
+	if err := ParseMatchArg(k, arg, sig); err != nil {
+		t.Fatalf("parseMatchArg: unexpected error %v parsing %v", err, arg)
+	}
+
+	expected := [][]uint64{{3, 10}, {9, 1}, {16, 17}}
+	maps := k.ValueMaps()
+	if len(maps) != len(expected) {
+		t.Fatalf("expected %d value maps, got %d", len(expected), len(maps))
+	}
+	for i, values := range expected {
+		if len(maps[i].Data) != len(values) {
+			t.Errorf("value map %d: expected %v got %v", i, values, maps[i].Data)
+		}
+		for _, v := range values {
+			if _, ok := maps[i].Data[valueKey(v)]; !ok {
+				t.Errorf("value map %d: missing %d", i, v)
+			}
+		}
+	}
+
+	arg = &v1alpha1.ArgSelector{Index: 1, Operator: "SockType", Values: []string{"SOCK_RAW"}}
+	if err := ParseMatchArg(k, arg, sig); err == nil {
+		t.Errorf("parseMatchArg: expected error for SockType on skb type, parsing %v", arg)
+	}
+}
+
+func TestParseMatchArgSockaddr(t *testing.T) {
+	sig := []v1alpha1.KProbeArg{
+		{Index: 0, Type: "sockaddr"},
+	}
+
+	k := NewKernelSelectorState(nil, nil)
+	for _, arg := range []*v1alpha1.ArgSelector{
+		{Index: 0, Operator: "Family", Values: []string{"AF_INET"}},
+		{Index: 0, Operator: "SAddr", Values: []string{"10.0.0.0/8"}},
+		{Index: 0, Operator: "NotSPort", Values: []string{"53"}},
+		{Index: 0, Operator: "SPortPriv"},
+		// the destination operators match the same address and port
+		{Index: 0, Operator: "DAddr", Values: []string{"10.0.0.0/8"}},
+		{Index: 0, Operator: "NotDAddr", Values: []string{"fd00::/8"}},
+		{Index: 0, Operator: "DPort", Values: []string{"443", "8000-8999"}},
+		{Index: 0, Operator: "NotDPortPriv"},
+	} {
+		if err := ParseMatchArg(k, arg, sig); err != nil {
+			t.Errorf("parseMatchArg: unexpected error %v parsing %v", err, arg)
+		}
+	}
+
+	// a sockaddr has no protocol, state or type
+	for _, arg := range []*v1alpha1.ArgSelector{
+		{Index: 0, Operator: "Protocol", Values: []string{"IPPROTO_TCP"}},
+		{Index: 0, Operator: "State", Values: []string{"TCP_SYN_SENT"}},
+		{Index: 0, Operator: "SockType", Values: []string{"SOCK_STREAM"}},
+		{Index: 0, Operator: "Equal", Values: []string{"1"}},
+	} {
+		if err := ParseMatchArg(k, arg, sig); err == nil {
+			t.Errorf("parseMatchArg: expected error for %s on sockaddr type, parsing %v", arg.Operator, arg)
+		}
+	}
+}
+
+func TestParseMatchArgFilePaths(t *testing.T) {
+	sig := []v1alpha1.KProbeArg{
+		{Index: 0, Type: "linux_binprm"},
+		{Index: 1, Type: "dentry"},
+	}
+
+	k := NewKernelSelectorState(nil, nil)
+	for _, arg := range []*v1alpha1.ArgSelector{
+		{Index: 0, Operator: "Equal", Values: []string{"/usr/bin/curl"}},
+		{Index: 0, Operator: "Prefix", Values: []string{"/tmp/"}},
+		{Index: 1, Operator: "Postfix", Values: []string{".sh"}},
+		{Index: 1, Operator: "NotPrefix", Values: []string{"/etc/"}},
+	} {
+		if err := ParseMatchArg(k, arg, sig); err != nil {
+			t.Errorf("parseMatchArg: unexpected error %v parsing %v", err, arg)
+		}
+	}
+
+	for _, arg := range []*v1alpha1.ArgSelector{
+		{Index: 0, Operator: "SAddr", Values: []string{"10.0.0.0/8"}},
+		{Index: 1, Operator: "DPort", Values: []string{"53"}},
+	} {
+		if err := ParseMatchArg(k, arg, sig); err == nil {
+			t.Errorf("parseMatchArg: expected error for %s on path type, parsing %v", arg.Operator, arg)
+		}
+	}
+
+	gt := &v1alpha1.ArgSelector{Index: 0, Operator: "GT", Values: []string{"/tmp/"}}
+	if err := ParseMatchArg(k, gt, sig); err == nil || err.Error() != "operator GT not supported for type linux_binprm" {
+		t.Errorf("parseMatchArg: expected error for gt on path type, got %v parsing %v", err, gt)
+	}
+
+	arr := &v1alpha1.ArgSelector{Index: 0, Operator: "Equal", Values: []string{"-l"}}
+	if err := ParseMatchArg(k, arr, []v1alpha1.KProbeArg{{Index: 0, Type: "string_array"}}); err == nil {
+		t.Errorf("parseMatchArg: expected error for string_array type, parsing %v", arr)
+	}
+}
+
 func TestParseMatchPid(t *testing.T) {
 	pid1 := &v1alpha1.PIDSelector{Operator: "In", Values: []uint32{1, 2, 3}, IsNamespacePID: true, FollowForks: true}
 	k := &KernelSelectorState{off: 0}
@@ -415,6 +672,15 @@ func TestParseMatchNamespaceChanges(t *testing.T) {
 	if err := ParseMatchNamespaceChange(k, ns1); err != nil || bytes.Equal(expected1, k.e[0:k.off]) == false {
 		t.Errorf("parseMatchNamespaceChange: error %v expected %v bytes %v parsing %v\n", err, expected1, k.e[0:k.off], ns1)
 	}
+
+	ns2 := &v1alpha1.NamespaceChangesSelector{Operator: "In", Values: []string{}}
+	if err := ParseMatchNamespaceChange(k, ns2); err == nil {
+		t.Errorf("parseMatchNamespaceChange: expected error for empty values")
+	}
+	ns3 := &v1alpha1.NamespaceChangesSelector{Operator: "In", Values: []string{"Foo"}}
+	if err := ParseMatchNamespaceChange(k, ns3); err == nil {
+		t.Errorf("parseMatchNamespaceChange: expected error for unknown namespace")
+	}
 }
 
 func TestParseMatchCapabilities(t *testing.T) {
@@ -452,6 +718,174 @@ func TestParseMatchCapabilities(t *testing.T) {
 	}
 }
 
+func TestParseMatchArgCapability(t *testing.T) {
+	sig := []v1alpha1.KProbeArg{{Index: 2, Type: "capability"}}
+
+	k := NewKernelSelectorState(nil, nil)
+	arg := &v1alpha1.ArgSelector{Index: 2, Operator: "Equal", Values: []string{"CAP_SYS_ADMIN", "cap_net_raw", "12"}}
+	expected := []byte{
+		0x02, 0x00, 0x00, 0x00, // Index == 2
+		0x03, 0x00, 0x00, 0x00, // operator == equal
+		20, 0x00, 0x00, 0x00, // length == 20
+		23, 0x00, 0x00, 0x00, // value type == capability
+		21, 0x00, 0x00, 0x00, // CAP_SYS_ADMIN
+		13, 0x00, 0x00, 0x00, // CAP_NET_RAW
+		12, 0x00, 0x00, 0x00, // CAP_NET_ADMIN
+	}
+	if err := ParseMatchArg(k, arg, sig); err != nil || bytes.Equal(expected, k.e[0:k.off]) == false {
+		t.Errorf("parseMatchArg: error %v expected:\n%v\nbytes:\n%v\nparsing %v\n", err, expected, k.e[0:k.off], arg)
+	}
+
+	k = NewKernelSelectorState(nil, nil)
+	arg = &v1alpha1.ArgSelector{Index: 2, Operator: "InMap", Values: []string{"CAP_SYS_ADMIN", "CAP_BPF"}}
+	if err := ParseMatchArg(k, arg, sig); err != nil {
+		t.Errorf("parseMatchArg: error %v parsing %v\n", err, arg)
+	}
+	if maps := k.ValueMaps(); len(maps) != 1 || len(maps[0].Data) != 2 {
+		t.Errorf("parseMatchArg: expected one value map with 2 values, got %v\n", maps)
+	}
+
+	for _, v := range []string{"CAP_FOO", "64", "-1"} {
+		k = NewKernelSelectorState(nil, nil)
+		arg = &v1alpha1.ArgSelector{Index: 2, Operator: "Equal", Values: []string{v}}
+		if err := ParseMatchArg(k, arg, sig); err == nil {
+			t.Errorf("parseMatchArg: expected error for value %s\n", v)
+		}
+	}
+}
+
+func TestParseMatchBinariesPrefix(t *testing.T) {
+	k := NewKernelSelectorState(nil, nil)
+	bin0 := []v1alpha1.BinarySelector{{Operator: "Prefix", Values: []string{"/usr/"}}}
+	bin1 := []v1alpha1.BinarySelector{{Operator: "NotPrefix", Values: []string{"/usr/local/bin/"}}}
+	if err := ParseMatchBinaries(k, bin0, 0); err != nil {
+		t.Fatalf("ParseMatchBinaries: %v", err)
+	}
+	if err := ParseMatchBinaries(k, bin1, 1); err != nil {
+		t.Fatalf("ParseMatchBinaries: %v", err)
+	}
+
+	if op := k.GetBinaryOp(0); op != SelectorOpIn {
+		t.Errorf("expected op In for Prefix, got %d", op)
+	}
+	if op := k.GetBinaryOp(1); op != SelectorOpNotIn {
+		t.Errorf("expected op NotIn for NotPrefix, got %d", op)
+	}
+
+	prefixes := map[string]uint32{}
+	for idx, p := range k.GetNewBinaryPrefixMappings() {
+		prefixes[p] = idx
+	}
+	usrIdx, ok0 := prefixes["/usr/"]
+	localIdx, ok1 := prefixes["/usr/local/bin/"]
+	if !ok0 || !ok1 {
+		t.Fatalf("missing prefix mappings: %v", prefixes)
+	}
+	if len(k.GetNewBinaryMappings()) != 0 {
+		t.Errorf("unexpected names_map entries: %v", k.GetNewBinaryMappings())
+	}
+
+	// the selectors only contain their own prefixes, the kernel walks the
+	// parents of the longest matching prefix
+	sel0 := k.GetBinSelNamesMap()[0].GetBinSelNamesMap()
+	if _, ok := sel0[usrIdx]; !ok {
+		t.Errorf("selector 0 does not contain /usr/")
+	}
+	if _, ok := sel0[localIdx]; ok {
+		t.Errorf("selector 0 unexpectedly contains /usr/local/bin/")
+	}
+	sel1 := k.GetBinSelNamesMap()[1].GetBinSelNamesMap()
+	if _, ok := sel1[usrIdx]; ok {
+		t.Errorf("selector 1 unexpectedly contains /usr/")
+	}
+	_, parents := BinaryPrefixMaps()
+	if parent, ok := parents[localIdx]; !ok || parent != usrIdx {
+		t.Errorf("expected /usr/ as the parent of /usr/local/bin/, got %v", parents)
+	}
+	k.ReleaseBinaryPrefixes()
+
+	bad := []v1alpha1.BinarySelector{{Operator: "Postfix", Values: []string{"cat"}}}
+	if err := ParseMatchBinaries(k, bad, 2); err == nil {
+		t.Errorf("expected error for Postfix matchBinaries operator")
+	}
+}
+
+func TestBinaryPrefixMaps(t *testing.T) {
+	parsePrefixes := func(prefixes ...string) *KernelSelectorState {
+		k := NewKernelSelectorState(nil, nil)
+		bin := []v1alpha1.BinarySelector{{Operator: "Prefix", Values: prefixes}}
+		if err := ParseMatchBinaries(k, bin, 0); err != nil {
+			t.Fatalf("ParseMatchBinaries: %v", err)
+		}
+		return k
+	}
+	ids := func(k *KernelSelectorState) map[string]uint32 {
+		ret := map[string]uint32{}
+		for id, p := range k.GetNewBinaryPrefixMappings() {
+			ret[p] = id
+		}
+		return ret
+	}
+
+	// a longer prefix of another policy does not change the selector of
+	// the first one, its parent is the prefix of the first policy
+	k0 := parsePrefixes("/opt/")
+	k1 := parsePrefixes("/opt/bin/")
+	optID, optBinID := ids(k0)["/opt/"], ids(k1)["/opt/bin/"]
+	prefixes, parents := BinaryPrefixMaps()
+	if prefixes[optID] != "/opt/" || prefixes[optBinID] != "/opt/bin/" {
+		t.Errorf("unexpected names_prefix_map entries: %v", prefixes)
+	}
+	if parents[optBinID] != optID {
+		t.Errorf("expected /opt/ as the parent of /opt/bin/, got %v", parents)
+	}
+	if sel := k0.GetBinSelNamesMap()[0].GetBinSelNamesMap(); len(sel) != 1 {
+		t.Errorf("unexpected sel_names_map entries: %v", sel)
+	}
+
+	// the prefix of a removed policy is removed from the names_prefix_map,
+	// and the processes that matched it keep matching the shorter prefixes
+	k2 := parsePrefixes("/opt/bin/")
+	if id := ids(k2)["/opt/bin/"]; id != optBinID {
+		t.Errorf("expected id %d for /opt/bin/, got %d", optBinID, id)
+	}
+	if !k1.ReleaseBinaryPrefixes() || k1.ReleaseBinaryPrefixes() {
+		t.Errorf("expected the prefixes to be released once")
+	}
+	if prefixes, _ = BinaryPrefixMaps(); prefixes[optBinID] == "" {
+		t.Errorf("/opt/bin/ removed while in use: %v", prefixes)
+	}
+	k2.ReleaseBinaryPrefixes()
+	prefixes, parents = BinaryPrefixMaps()
+	if _, ok := prefixes[optBinID]; ok {
+		t.Errorf("/opt/bin/ not removed: %v", prefixes)
+	}
+	if parents[optBinID] != optID {
+		t.Errorf("expected /opt/ as the parent of the removed /opt/bin/, got %v", parents)
+	}
+	k0.ReleaseBinaryPrefixes()
+	prefixes, parents = BinaryPrefixMaps()
+	if _, ok := prefixes[optID]; ok {
+		t.Errorf("/opt/ not removed: %v", prefixes)
+	}
+	if _, ok := parents[optBinID]; ok {
+		t.Errorf("unexpected parent of /opt/bin/: %v", parents)
+	}
+
+	// nested prefixes are limited by the number of parents that the kernel
+	// walks
+	nested := make([]string, 0, MaxBinaryPrefixDepth+1)
+	for i := 1; i <= MaxBinaryPrefixDepth+1; i++ {
+		nested = append(nested, "/srv/"+strings.Repeat("a", i))
+	}
+	k := NewKernelSelectorState(nil, nil)
+	bin := []v1alpha1.BinarySelector{{Operator: "Prefix", Values: nested}}
+	if err := ParseMatchBinaries(k, bin, 0); err == nil {
+		t.Errorf("expected error for %d nested prefixes", len(nested))
+	}
+	k.ReleaseBinaryPrefixes()
+}
+
 func TestParseMatchAction(t *testing.T) {
 	// Create URL and FQDN tables to store URLs and FQDNs for this kprobe
 	var actionArgTable idtable.Table
@@ -462,7 +896,11 @@ func TestParseMatchAction(t *testing.T) {
 	expected1 := []byte{
 		0x00, 0x00, 0x00, 0x00, // Action = "post"
 		0x00, 0x00, 0x00, 0x00, // DontRepeatFor = 0
+		0x00, 0x00, 0x00, 0x00, // RateLimitCount = 0
+		0x00, 0x00, 0x00, 0x00, // RateLimitScope = thread
 		0x00, 0x00, 0x00, 0x00, // StackTrace = 0
+		0x00, 0x00, 0x00, 0x00, // UserStackTrace = 0
+		0x00, 0x00, 0x00, 0x00, // Sample = 0
 	}
 	if err := ParseMatchAction(k, act1, &actionArgTable); err != nil || bytes.Equal(expected1, k.e[0:k.off]) == false {
 		t.Errorf("parseMatchAction: error %v expected %v bytes %v parsing %v\n", err, expected1, k.e[0:k.off], act1)
@@ -473,9 +911,13 @@ func TestParseMatchAction(t *testing.T) {
 	expected2 := []byte{
 		0x00, 0x00, 0x00, 0x00, // Action = "post"
 		0x00, 0x00, 0x00, 0x00, // DontRepeatFor = 0
+		0x00, 0x00, 0x00, 0x00, // RateLimitCount = 0
+		0x00, 0x00, 0x00, 0x00, // RateLimitScope = thread
 		0x00, 0x00, 0x00, 0x00, // StackTrace = 0
+		0x00, 0x00, 0x00, 0x00, // UserStackTrace = 0
+		0x00, 0x00, 0x00, 0x00, // Sample = 0
 	}
-	length := []byte{28, 0x00, 0x00, 0x00}
+	length := []byte{60, 0x00, 0x00, 0x00}
 	expected := append(length, expected1[:]...)
 	expected = append(expected, expected2[:]...)
 
@@ -504,6 +946,108 @@ func TestParseMatchActionMax(t *testing.T) {
 	}
 }
 
+func TestParseMatchActionCount(t *testing.T) {
+	var actionArgTable idtable.Table
+
+	act := &v1alpha1.ActionSelector{Action: "Count"}
+	k := &KernelSelectorState{off: 0}
+	expected := []byte{
+		0x0d, 0x00, 0x00, 0x00, // Action = "count"
+	}
+	if err := ParseMatchAction(k, act, &actionArgTable); err != nil || bytes.Equal(expected, k.e[0:k.off]) == false {
+		t.Errorf("parseMatchAction: error %v expected %v bytes %v parsing %v\n", err, expected, k.e[0:k.off], act)
+	}
+
+	sels := []v1alpha1.KProbeSelector{
+		{MatchActions: []v1alpha1.ActionSelector{{Action: "Post"}}},
+		{MatchActions: []v1alpha1.ActionSelector{{Action: "Count"}}},
+	}
+	if !HasCount(sels) || HasCount(sels[:1]) {
+		t.Errorf("HasCount: unexpected result for %v", sels)
+	}
+}
+
+func TestParseMatchActionRateLimit(t *testing.T) {
+	var actionArgTable idtable.Table
+
+	act := &v1alpha1.ActionSelector{Action: "post", RateLimit: "10/m", RateLimitScope: "process"}
+	k := &KernelSelectorState{off: 0}
+	expected := []byte{
+		0x00, 0x00, 0x00, 0x00, // Action = "post"
+		0x60, 0xea, 0x00, 0x00, // DontRepeatFor = 60000
+		0x0a, 0x00, 0x00, 0x00, // RateLimitCount = 10
+		0x01, 0x00, 0x00, 0x00, // RateLimitScope = process
+		0x00, 0x00, 0x00, 0x00, // StackTrace = 0
+		0x00, 0x00, 0x00, 0x00, // UserStackTrace = 0
+		0x00, 0x00, 0x00, 0x00, // Sample = 0
+	}
+	if err := ParseMatchAction(k, act, &actionArgTable); err != nil || bytes.Equal(expected, k.e[0:k.off]) == false {
+		t.Errorf("parseMatchAction: error %v expected %v bytes %v parsing %v\n", err, expected, k.e[0:k.off], act)
+	}
+
+	invalid := []v1alpha1.ActionSelector{
+		{Action: "post", RateLimit: "1m", RateLimitScope: "cpu"},
+		{Action: "post", RateLimitScope: "global"},
+		{Action: "post", RateLimit: "0/s"},
+		{Action: "post", RateLimit: "10/"},
+		{Action: "sigkill", RateLimit: "10/s"},
+	}
+	for i := range invalid {
+		k := &KernelSelectorState{off: 0}
+		if err := ParseMatchAction(k, &invalid[i], &actionArgTable); err == nil {
+			t.Errorf("parseMatchAction: expected error parsing %v\n", invalid[i])
+		}
+	}
+}
+
+func TestParseMatchActionSample(t *testing.T) {
+	if !kernels.EnableLargeProgs() {
+		t.Skip("sampling requires large programs")
+	}
+	var actionArgTable idtable.Table
+
+	act := &v1alpha1.ActionSelector{Action: "post", Sample: 1000}
+	k := &KernelSelectorState{off: 0}
+	expected := []byte{
+		0x00, 0x00, 0x00, 0x00, // Action = "post"
+		0x00, 0x00, 0x00, 0x00, // DontRepeatFor = 0
+		0x00, 0x00, 0x00, 0x00, // RateLimitCount = 0
+		0x00, 0x00, 0x00, 0x00, // RateLimitScope = thread
+		0x00, 0x00, 0x00, 0x00, // StackTrace = 0
+		0x00, 0x00, 0x00, 0x00, // UserStackTrace = 0
+		0xe8, 0x03, 0x00, 0x00, // Sample = 1000
+	}
+	if err := ParseMatchAction(k, act, &actionArgTable); err != nil || bytes.Equal(expected, k.e[0:k.off]) == false {
+		t.Errorf("parseMatchAction: error %v expected %v bytes %v parsing %v\n", err, expected, k.e[0:k.off], act)
+	}
+
+	invalid := &v1alpha1.ActionSelector{Action: "sigkill", Sample: 10}
+	if err := ParseMatchAction(k, invalid, &actionArgTable); err == nil {
+		t.Errorf("parseMatchAction: expected error parsing %v\n", invalid)
+	}
+}
+
+func TestParseRateLimitCount(t *testing.T) {
+	tests := []struct {
+		str      string
+		interval uint32
+		count    uint32
+	}{
+		{"5", 5000, 1},
+		{"5m", 300000, 1},
+		{"10/s", 1000, 10},
+		{"100/5m", 300000, 100},
+		{"2/1h", 3600000, 2},
+	}
+	for _, test := range tests {
+		interval, count, err := parseRateLimitCount(test.str)
+		if err != nil || interval != test.interval || count != test.count {
+			t.Errorf("parseRateLimitCount(%s): error %v expected %d/%dms got %d/%dms\n",
+				test.str, err, test.count, test.interval, count, interval)
+		}
+	}
+}
+
 // NB(kkourt):
 func TestMultipleSelectorsExample(t *testing.T) {
 	// Create URL and FQDN tables to store URLs and FQDNs for this kprobe
@@ -535,8 +1079,8 @@ func TestMultipleSelectorsExample(t *testing.T) {
 	// value               absolute offset    explanation
 	expU32Push(2)               // off: 0       number of selectors
 	expU32Push(8)               // off: 4       relative ofset of 1st selector (4 + 8 = 12)
-	expU32Push(100)             // off: 8       relative ofset of 2nd selector (8 + 124 = 132)
-	expU32Push(96)              // off: 12      selector1: length (76 + 12 = 96)
+	expU32Push(108)             // off: 8       relative ofset of 2nd selector (8 + 108 = 116)
+	expU32Push(104)             // off: 12      selector1: length (92 + 12 = 104)
 	expU32Push(24)              // off: 16      selector1: MatchPIDs: len
 	expU32Push(SelectorOpNotIn) // off: 20      selector1: MatchPIDs[0]: op
 	expU32Push(0)               // off: 24      selector1: MatchPIDs[0]: flags
@@ -547,20 +1091,22 @@ func TestMultipleSelectorsExample(t *testing.T) {
 	expU32Push(4)               // off: 44      selector1: MatchCapabilities: len
 	expU32Push(4)               // off: 48      selector1: MatchNamespaceChanges: len
 	expU32Push(4)               // off: 52      selector1: MatchCapabilityChanges: len
-	expU32Push(48)              // off: 80      selector1: matchArgs: len
-	expU32Push(24)              // off: 84      selector1: matchArgs[0]: offset
-	expU32Push(0)               // off: 88      selector1: matchArgs[1]: offset
-	expU32Push(0)               // off: 92      selector1: matchArgs[2]: offset
-	expU32Push(0)               // off: 96      selector1: matchArgs[3]: offset
-	expU32Push(0)               // off: 100     selector1: matchArgs[4]: offset
-	expU32Push(1)               // off: 104     selector1: matchArgs: arg0: index
-	expU32Push(SelectorOpEQ)    // off: 108     selector1: matchArgs: arg0: operator
-	expU32Push(16)              // off: 112     selector1: matchArgs: arg0: len of vals
-	expU32Push(argTypeInt)      // off: 116     selector1: matchArgs: arg0: type
-	expU32Push(10)              // off: 120     selector1: matchArgs: arg0: val0: 10
-	expU32Push(20)              // off: 124     selector1: matchArgs: arg0: val1: 20
-	expU32Push(4)               // off: 128     selector1: matchActions: length
-	expU32Push(96)              // off: 132     selector2: length
+	expU32Push(4)               // off: 56      selector1: MatchCredentials: len
+	expU32Push(4)               // off: 60      selector1: MatchTags: len
+	expU32Push(48)              // off: 64      selector1: matchArgs: len
+	expU32Push(24)              // off: 68      selector1: matchArgs[0]: offset
+	expU32Push(0)               // off: 72      selector1: matchArgs[1]: offset
+	expU32Push(0)               // off: 76      selector1: matchArgs[2]: offset
+	expU32Push(0)               // off: 80      selector1: matchArgs[3]: offset
+	expU32Push(0)               // off: 84      selector1: matchArgs[4]: offset
+	expU32Push(1)               // off: 88      selector1: matchArgs: arg0: index
+	expU32Push(SelectorOpEQ)    // off: 92      selector1: matchArgs: arg0: operator
+	expU32Push(16)              // off: 96      selector1: matchArgs: arg0: len of vals
+	expU32Push(argTypeInt)      // off: 100     selector1: matchArgs: arg0: type
+	expU32Push(10)              // off: 104     selector1: matchArgs: arg0: val0: 10
+	expU32Push(20)              // off: 108     selector1: matchArgs: arg0: val1: 20
+	expU32Push(4)               // off: 112     selector1: matchActions: length
+	expU32Push(104)             // off: 116     selector2: length
 	// ... everything else should be the same as selector1 ...
 
 	if bytes.Equal(expected[:expectedLen], b[:expectedLen]) == false {
@@ -577,11 +1123,11 @@ func TestInitKernelSelectors(t *testing.T) {
 	}
 
 	expected_selsize_small := []byte{
-		0xf8, 0x00, 0x00, 0x00, // size = pids + args + actions + namespaces + capabilities  + 4
+		0x10, 0x01, 0x00, 0x00, // size = pids + args + actions + namespaces + capabilities  + 4
 	}
 
 	expected_selsize_large := []byte{
-		0x2c, 0x01, 0x00, 0x00, // size = pids + args + actions + namespaces + namespacesChanges + capabilities + capabilityChanges + 4
+		0x58, 0x01, 0x00, 0x00, // size = pids + args + actions + namespaces + namespacesChanges + capabilities + capabilityChanges + credentials + tags + 4
 	}
 
 	expected_filters := []byte{
@@ -644,6 +1190,12 @@ func TestInitKernelSelectors(t *testing.T) {
 
 		// capability changes header
 		0x04, 0x00, 0x00, 0x00,
+
+		// credentials header
+		0x04, 0x00, 0x00, 0x00,
+
+		// tags header
+		0x04, 0x00, 0x00, 0x00,
 	}
 
 	expected_changes := []byte{
@@ -662,6 +1214,19 @@ func TestInitKernelSelectors(t *testing.T) {
 		0x05, 0x00, 0x00, 0x00, // op == In
 		0x00, 0x00, 0x00, 0x00, // IsNamespaceCapability = false
 		0x00, 0x20, 0x20, 0x00, 0x00, 0x00, 0x00, 0x00, // Values (uint64)
+
+		// credentials header
+		24, 0x00, 0x00, 0x00, // size = sizeof(cred1) + 4
+
+		// cred1 size = 20
+		0x00, 0x00, 0x00, 0x00, // Type == UID
+		0x05, 0x00, 0x00, 0x00, // op == In
+		0x02, 0x00, 0x00, 0x00, // length == 0x2
+		0x00, 0x00, 0x00, 0x00, // Values[0] == 0
+		0xe8, 0x03, 0x00, 0x00, // Values[1] == 1000
+
+		// tags header
+		0x04, 0x00, 0x00, 0x00,
 	}
 
 	expected_last_large := []byte{
@@ -694,10 +1259,14 @@ func TestInitKernelSelectors(t *testing.T) {
 		0x02, 0x00, 0x00, 0x00, // value 2
 
 		// actions header
-		28, 0x00, 0x00, 0x00, // size = (3 * sizeof(uint32) * number of actions) + args
+		44, 0x00, 0x00, 0x00, // size = post (7 * sizeof(uint32)) + fdinstall (3 * sizeof(uint32)) + 4
 		0x00, 0x00, 0x00, 0x00, // post to userspace
 		0x00, 0x00, 0x00, 0x00, // DontRepeatFor = 0
+		0x00, 0x00, 0x00, 0x00, // RateLimitCount = 0
+		0x00, 0x00, 0x00, 0x00, // RateLimitScope = thread
 		0x00, 0x00, 0x00, 0x00, // StackTrace = 0
+		0x00, 0x00, 0x00, 0x00, // UserStackTrace = 0
+		0x00, 0x00, 0x00, 0x00, // Sample = 0
 		0x01, 0x00, 0x00, 0x00, // fdinstall
 		0x00, 0x00, 0x00, 0x00, // arg index of fd
 		0x01, 0x00, 0x00, 0x00, // arg index of string filename
@@ -725,10 +1294,14 @@ func TestInitKernelSelectors(t *testing.T) {
 		0xff, 0xff, 0xff, 0xff, // map ID for strings 121-144
 
 		// actions header
-		28, 0x00, 0x00, 0x00, // size = (3 * sizeof(uint32) * number of actions) + args + 4
+		44, 0x00, 0x00, 0x00, // size = post (7 * sizeof(uint32)) + fdinstall (3 * sizeof(uint32)) + 4
 		0x00, 0x00, 0x00, 0x00, // post to userspace
 		0x00, 0x00, 0x00, 0x00, // DontRepeatFor = 0
+		0x00, 0x00, 0x00, 0x00, // RateLimitCount = 0
+		0x00, 0x00, 0x00, 0x00, // RateLimitScope = thread
 		0x00, 0x00, 0x00, 0x00, // StackTrace = 0
+		0x00, 0x00, 0x00, 0x00, // UserStackTrace = 0
+		0x00, 0x00, 0x00, 0x00, // Sample = 0
 		0x01, 0x00, 0x00, 0x00, // fdinstall
 		0x00, 0x00, 0x00, 0x00, // arg index of fd
 		0x01, 0x00, 0x00, 0x00, // arg index of string filename
@@ -766,6 +1339,11 @@ func TestInitKernelSelectors(t *testing.T) {
 		cc := &v1alpha1.CapabilitiesSelector{Type: "Effective", Operator: "In", IsNamespaceCapability: false, Values: []string{"CAP_SYS_ADMIN", "CAP_NET_RAW"}}
 		matchCapabilityChanges = append(matchCapabilityChanges, *cc)
 	}
+	matchCredentials := []v1alpha1.CredentialsSelector{}
+	if kernels.EnableLargeProgs() {
+		cr := &v1alpha1.CredentialsSelector{Type: "UID", Operator: "In", Values: []uint32{0, 1000}}
+		matchCredentials = append(matchCredentials, *cr)
+	}
 	var matchArgs []v1alpha1.ArgSelector
 	if kernels.EnableLargeProgs() {
 		arg1 := &v1alpha1.ArgSelector{Index: 1, Operator: "Equal", Values: []string{"foobar"}}
@@ -788,6 +1366,7 @@ func TestInitKernelSelectors(t *testing.T) {
 			MatchCapabilities:      matchCapabilities,
 			MatchNamespaceChanges:  matchNamespaceChanges,
 			MatchCapabilityChanges: matchCapabilityChanges,
+			MatchCredentials:       matchCredentials,
 			MatchArgs:              matchArgs,
 			MatchActions:           matchActions,
 		},
@@ -807,3 +1386,143 @@ func TestInitKernelSelectors(t *testing.T) {
 		t.Errorf("InitKernelSelectors:\nexpected %v\nbytes    %v\n", expected, b[0:len(expected)])
 	}
 }
+
+func TestParseMatchTags(t *testing.T) {
+	tagMu.Lock()
+	tagIDs = map[string]uint32{"curl": 0}
+	tagMu.Unlock()
+
+	tags := &v1alpha1.TagsSelector{Operator: "NotIn", Values: []string{"curl", "cron"}}
+	k := &KernelSelectorState{off: 0}
+	expected := []byte{
+		0x06, 0x00, 0x00, 0x00, // op == NotIn
+		0x03, 0x00, 0x00, 0x00, // mask_lo == curl | cron
+		0x00, 0x00, 0x00, 0x00, // mask_hi
+	}
+	if err := ParseMatchTag(k, tags); err != nil || bytes.Equal(expected, k.e[0:k.off]) == false {
+		t.Errorf("parseMatchTag: error %v expected %v bytes %v parsing %v\n", err, expected, k.e[0:k.off], tags)
+	}
+
+	// the setTag action uses the same tag IDs
+	k = &KernelSelectorState{off: 0}
+	act := &v1alpha1.ActionSelector{Action: "SetTag", ArgTag: "cron"}
+	expected = []byte{
+		0x0e, 0x00, 0x00, 0x00, // action == SetTag
+		0x01, 0x00, 0x00, 0x00, // tag == cron
+	}
+	if err := ParseMatchAction(k, act, nil); err != nil || bytes.Equal(expected, k.e[0:k.off]) == false {
+		t.Errorf("parseMatchAction: error %v expected %v bytes %v parsing %v\n", err, expected, k.e[0:k.off], act)
+	}
+
+	invalid := []v1alpha1.TagsSelector{
+		{Operator: "Equal", Values: []string{"curl"}},
+		{Operator: "In"},
+		{Operator: "In", Values: []string{""}},
+	}
+	for _, tags := range invalid {
+		k := &KernelSelectorState{off: 0}
+		if err := ParseMatchTag(k, &tags); err == nil {
+			t.Errorf("parseMatchTag: expected error parsing %v\n", tags)
+		}
+	}
+
+	// tags are allocated up to maxTags
+	for i := 0; i < maxTags-2; i++ {
+		if _, err := tagID(fmt.Sprintf("tag%d", i)); err != nil {
+			t.Fatalf("tagID: unexpected error %v", err)
+		}
+	}
+	if _, err := tagID("one-too-many"); err == nil {
+		t.Errorf("tagID: expected error for more than %d tags", maxTags)
+	}
+
+	tagMu.Lock()
+	tagIDs = make(map[string]uint32)
+	tagMu.Unlock()
+}
+
+func TestParseMatchCredentials(t *testing.T) {
+	cred := &v1alpha1.CredentialsSelector{Type: "EUID", Operator: "NotIn", Values: []uint32{1000}}
+	k := &KernelSelectorState{off: 0}
+	expected := []byte{
+		0x01, 0x00, 0x00, 0x00, // Type == EUID
+		0x06, 0x00, 0x00, 0x00, // op == NotIn
+		0x01, 0x00, 0x00, 0x00, // length == 0x1
+		0xe8, 0x03, 0x00, 0x00, // Values[0] == 1000
+	}
+	if err := ParseMatchCredential(k, cred); err != nil || bytes.Equal(expected, k.e[0:k.off]) == false {
+		t.Errorf("parseMatchCredential: error %v expected %v bytes %v parsing %v\n", err, expected, k.e[0:k.off], cred)
+	}
+
+	invalid := []v1alpha1.CredentialsSelector{
+		{Type: "SUID", Operator: "In", Values: []uint32{0}},
+		{Type: "UID", Operator: "Equal", Values: []uint32{0}},
+		{Type: "UID", Operator: "In"},
+		{Type: "UID", Operator: "In", Values: []uint32{0, 1, 2, 3, 4}},
+	}
+	for _, cred := range invalid {
+		k := &KernelSelectorState{off: 0}
+		if err := ParseMatchCredential(k, &cred); err == nil {
+			t.Errorf("parseMatchCredential: expected error parsing %v\n", cred)
+		}
+	}
+}
+
+func TestHasSigkillAction(t *testing.T) {
+	sels := []v1alpha1.KProbeSelector{
+		{MatchActions: []v1alpha1.ActionSelector{{Action: "Post"}}},
+	}
+	if HasSigkillAction(sels) {
+		t.Errorf("HasSigkillAction: expected false for %v", sels)
+	}
+
+	sels = append(sels, v1alpha1.KProbeSelector{
+		MatchActions: []v1alpha1.ActionSelector{{Action: "Sigkill"}},
+	})
+	if !HasSigkillAction(sels) {
+		t.Errorf("HasSigkillAction: expected true for %v", sels)
+	}
+}
+
+func TestActionArgs(t *testing.T) {
+	var actionArgTable idtable.Table
+	getURL := func(urls ...string) []v1alpha1.KProbeSelector {
+		var sels []v1alpha1.KProbeSelector
+		for _, url := range urls {
+			sels = append(sels, v1alpha1.KProbeSelector{
+				MatchActions: []v1alpha1.ActionSelector{{Action: "GetUrl", ArgUrl: url}},
+			})
+		}
+		return sels
+	}
+
+	first, err := InitKernelSelectorState(getURL("http://a", "http://b", "http://a"), nil, &actionArgTable, nil, nil)
+	if err != nil {
+		t.Fatalf("InitKernelSelectorState: %v", err)
+	}
+	if n := actionArgTable.Len(); n != 2 {
+		t.Errorf("expected 2 action arguments, got %d", n)
+	}
+
+	// updated selectors reuse the entries of the same arguments
+	second, err := InitKernelSelectorState(getURL("http://b", "http://c"), nil, &actionArgTable, nil, nil)
+	if err != nil {
+		t.Fatalf("InitKernelSelectorState: %v", err)
+	}
+	if n := actionArgTable.Len(); n != 3 {
+		t.Errorf("expected 3 action arguments, got %d", n)
+	}
+
+	PruneActionArgs(&actionArgTable, first, second)
+	if n := actionArgTable.Len(); n != 3 {
+		t.Errorf("expected 3 action arguments, got %d", n)
+	}
+	PruneActionArgs(&actionArgTable, second)
+	var args []string
+	for _, e := range actionArgTable.Entries() {
+		args = append(args, e.(*ActionArgEntry).GetArg())
+	}
+	if strings.Join(args, ",") != "http://b,http://c" {
+		t.Errorf("expected the arguments of the second selectors, got %v", args)
+	}
+}
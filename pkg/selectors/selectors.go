@@ -6,7 +6,10 @@ package selectors
 import (
 	"encoding/binary"
 	"fmt"
+	"strings"
 	"sync"
+
+	"github.com/cilium/tetragon/pkg/idtable"
 )
 
 // as we use a single names_map for all kprobes, so we have to use
@@ -16,11 +19,80 @@ var (
 	binIdx uint32 = 1
 	// contains all entries for the names_map
 	binVals = make(map[string]uint32)
+	// contains all the prefixes of the names_prefix_map, and the ones
+	// that were removed from it. Ids are allocated from binIdx as well, so
+	// that they do not collide with binVals.
+	binPrefixVals = make(map[string]*binPrefix)
+)
+
+const (
+	// maxBinaryPrefixes is the number of entries of the names_prefix_map
+	maxBinaryPrefixes = 256
+	// maxBinaryPrefixParents is the number of entries of the
+	// names_prefix_parent_map
+	maxBinaryPrefixParents = 1024
+	// MaxBinaryPrefixDepth is the maximum number of nested prefixes, the
+	// number of prefix ids that matchBinaries looks up
+	// (NAMES_PREFIX_MAX_DEPTH in generic.h).
+	MaxBinaryPrefixDepth = 8
+)
+
+// binPrefix is a prefix of the names_prefix_map. refs counts the selector
+// states using it, the prefix is removed from the names_prefix_map when it
+// drops to zero. Its id is kept, since processes keep the id of the prefix
+// that they matched at exec time.
+type binPrefix struct {
+	id   uint32
+	refs int
+}
+
+// maxTags is the number of tags, the bits of the tags of the processes in the
+// execve_map (MAX_TAGS in process.h).
+const maxTags = 64
+
+// tags are set on processes and matched by the selectors of all the policies,
+// so we use a global variable to assign bits to tag names
+var (
+	tagMu  sync.Mutex
+	tagIDs = make(map[string]uint32)
 )
 
+// tagID returns the bit of a tag, allocated on its first use. Bits are never
+// released, since processes can keep the tags of unloaded policies.
+func tagID(name string) (uint32, error) {
+	if name == "" {
+		return 0, fmt.Errorf("empty tag name")
+	}
+	tagMu.Lock()
+	defer tagMu.Unlock()
+	if id, ok := tagIDs[name]; ok {
+		return id, nil
+	}
+	if len(tagIDs) >= maxTags {
+		return 0, fmt.Errorf("cannot add tag %q: up to %d tags are supported", name, maxTags)
+	}
+	id := uint32(len(tagIDs))
+	tagIDs[name] = id
+	return id, nil
+}
+
+// tagsMask returns the mask of the bits of the tags.
+func tagsMask(names []string) (uint64, error) {
+	var mask uint64
+	for _, name := range names {
+		id, err := tagID(name)
+		if err != nil {
+			return 0, err
+		}
+		mask |= 1 << id
+	}
+	return mask, nil
+}
+
 type MatchBinariesMappings struct {
 	op          uint32
 	selNamesMap map[uint32]uint32 // these will be used for the sel_names_map
+	prefixes    map[string]uint32 // prefixes of this selector (Prefix/NotPrefix operators)
 }
 
 func (k *MatchBinariesMappings) GetBinSelNamesMap() map[uint32]uint32 {
@@ -97,8 +169,13 @@ type KernelSelectorState struct {
 	// addr6Maps are used to populate IPv6 address LpmTrie maps for sock and skb operators
 	addr6Maps []map[KernelLPMTrie6]struct{}
 
-	matchBinaries map[int]*MatchBinariesMappings // matchBinaries mappings (one per selector)
-	newBinVals    map[uint32]string              // these should be added in the names_map
+	matchBinaries    map[int]*MatchBinariesMappings // matchBinaries mappings (one per selector)
+	newBinVals       map[uint32]string              // these should be added in the names_map
+	newBinPrefixVals map[uint32]string              // these should be added in the names_prefix_map
+
+	// actionArgs are the ids of the action argument table entries used by
+	// the selectors
+	actionArgs map[idtable.EntryID]struct{}
 
 	listReader ValueReader
 
@@ -110,10 +187,11 @@ func NewKernelSelectorState(listReader ValueReader, maps *KernelSelectorMaps) *K
 		maps = &KernelSelectorMaps{}
 	}
 	return &KernelSelectorState{
-		matchBinaries: make(map[int]*MatchBinariesMappings),
-		newBinVals:    make(map[uint32]string),
-		listReader:    listReader,
-		maps:          maps,
+		matchBinaries:    make(map[int]*MatchBinariesMappings),
+		newBinVals:       make(map[uint32]string),
+		newBinPrefixVals: make(map[uint32]string),
+		listReader:       listReader,
+		maps:             maps,
 	}
 }
 
@@ -122,6 +200,7 @@ func (k *KernelSelectorState) SetBinaryOp(selIdx int, op uint32) {
 	if _, ok := k.matchBinaries[selIdx]; !ok {
 		k.matchBinaries[selIdx] = &MatchBinariesMappings{
 			selNamesMap: make(map[uint32]uint32),
+			prefixes:    make(map[string]uint32),
 		}
 	}
 	k.matchBinaries[selIdx].op = op
@@ -148,10 +227,144 @@ func (k *KernelSelectorState) AddBinaryName(selIdx int, binary string) {
 	k.matchBinaries[selIdx].selNamesMap[idx] = 1 // value in the per-selector names_map (we ignore the value)
 }
 
+// BinaryID returns the names_map id of a binary, allocated on its first use.
+// The caller adds the binary to the names_map.
+func BinaryID(binary string) uint32 {
+	binMu.Lock()
+	defer binMu.Unlock()
+	if idx, ok := binVals[binary]; ok {
+		return idx
+	}
+	idx := binIdx
+	binIdx++
+	binVals[binary] = idx
+	return idx
+}
+
+// AddBinaryPrefix adds a binary path prefix to the selector selIdx.
+//
+// At exec time, the kernel stores the id of the longest prefix that matches
+// the binary path, and matchBinaries walks the names_prefix_parent_map from
+// this id to the shorter prefixes. Hence, the selector only contains the id
+// of its own prefix, which stays valid when other policies add or remove
+// prefixes.
+func (k *KernelSelectorState) AddBinaryPrefix(selIdx int, prefix string) error {
+	binMu.Lock()
+	defer binMu.Unlock()
+	p, ok := binPrefixVals[prefix]
+	if !ok {
+		p = &binPrefix{id: binIdx}
+		binIdx++
+		binPrefixVals[prefix] = p
+	}
+	if _, ok := k.newBinPrefixVals[p.id]; !ok {
+		if p.refs == 0 {
+			if err := checkBinaryPrefix(prefix); err != nil {
+				return err
+			}
+		}
+		p.refs++
+		k.newBinPrefixVals[p.id] = prefix
+	}
+	k.matchBinaries[selIdx].selNamesMap[p.id] = 1
+	return nil
+}
+
+// checkBinaryPrefix checks that prefix can be added to the names_prefix_map.
+// It is called with binMu held.
+func checkBinaryPrefix(prefix string) error {
+	live := []string{prefix}
+	for p, bp := range binPrefixVals {
+		if bp.refs > 0 {
+			live = append(live, p)
+		}
+	}
+	if len(live) > maxBinaryPrefixes {
+		return fmt.Errorf("matchBinary error: cannot add prefix %q: up to %d binary prefixes are supported", prefix, maxBinaryPrefixes)
+	}
+	for _, p := range live {
+		depth := 0
+		for _, q := range live {
+			if strings.HasPrefix(p, q) {
+				depth++
+			}
+		}
+		if depth > MaxBinaryPrefixDepth {
+			return fmt.Errorf("matchBinary error: cannot add prefix %q: up to %d nested binary prefixes are supported", prefix, MaxBinaryPrefixDepth)
+		}
+	}
+	return nil
+}
+
+// ReleaseBinaryPrefixes releases the prefixes of the selectors, to be called
+// when the selectors are not used anymore. The names_prefix_map needs to be
+// updated afterwards, see BinaryPrefixMaps. It returns whether prefixes were
+// released.
+func (k *KernelSelectorState) ReleaseBinaryPrefixes() bool {
+	binMu.Lock()
+	defer binMu.Unlock()
+	released := len(k.newBinPrefixVals) > 0
+	for id, prefix := range k.newBinPrefixVals {
+		if p := binPrefixVals[prefix]; p != nil && p.refs > 0 {
+			p.refs--
+		}
+		delete(k.newBinPrefixVals, id)
+	}
+	return released
+}
+
+// BinaryPrefixMaps returns the entries of the names_prefix_map, the prefixes
+// of the selectors in use, and of the names_prefix_parent_map, the longest
+// of these prefixes covering each known prefix. The parents of the removed
+// prefixes are kept for the processes that matched them.
+func BinaryPrefixMaps() (prefixes map[uint32]string, parents map[uint32]uint32) {
+	binMu.Lock()
+	defer binMu.Unlock()
+	prefixes = make(map[uint32]string)
+	for p, bp := range binPrefixVals {
+		if bp.refs > 0 {
+			prefixes[bp.id] = p
+		}
+	}
+
+	parentOf := func(prefix string) (uint32, bool) {
+		var parent string
+		for _, p := range prefixes {
+			if len(p) < len(prefix) && len(p) > len(parent) && strings.HasPrefix(prefix, p) {
+				parent = p
+			}
+		}
+		if parent == "" {
+			return 0, false
+		}
+		return binPrefixVals[parent].id, true
+	}
+
+	parents = make(map[uint32]uint32)
+	for id, p := range prefixes {
+		if parent, ok := parentOf(p); ok {
+			parents[id] = parent
+		}
+	}
+	for p, bp := range binPrefixVals {
+		if bp.refs > 0 || len(parents) >= maxBinaryPrefixParents {
+			continue
+		}
+		if parent, ok := parentOf(p); ok {
+			parents[bp.id] = parent
+		}
+	}
+	return prefixes, parents
+}
+
 func (k *KernelSelectorState) GetNewBinaryMappings() map[uint32]string {
 	return k.newBinVals
 }
 
+func (k *KernelSelectorState) GetNewBinaryPrefixMappings() map[uint32]string {
+	return k.newBinPrefixVals
+}
+
 func (k *KernelSelectorState) GetBinSelNamesMap() map[int]*MatchBinariesMappings {
 	return k.matchBinaries
 }
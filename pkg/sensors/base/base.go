@@ -27,7 +27,7 @@ var (
 	)
 
 	Exit = program.Builder(
-		"bpf_exit.o",
+		ExitObj(),
 		"acct_process",
 		"kprobe/acct_process",
 		"event_exit",
@@ -35,7 +35,7 @@ var (
 	)
 
 	Fork = program.Builder(
-		"bpf_fork.o",
+		ForkObj(),
 		"wake_up_new_task",
 		"kprobe/wake_up_new_task",
 		"kprobe_pid_clear",
@@ -44,6 +44,12 @@ var (
 
 	/* Event Ring map */
 	TCPMonMap = program.MapBuilder("tcpmon_map", Execve)
+	/* Events written to the ring per cgroup */
+	EventCgroupStatsMap = program.MapBuilder("event_cgroup_stats_map", Execve)
+	/* BPF ring buffer of the events, and its transport and loss maps */
+	TCPMonRingBuf     = program.MapBuilder("tcpmon_ringbuf", Execve)
+	EventTransportMap = program.MapBuilder("event_transport_map", Execve)
+	RingBufLostMap    = program.MapBuilder("ringbuf_lost_map", Execve)
 	/* Networking and Process Monitoring maps */
 	ExecveMap          = program.MapBuilder("execve_map", Execve)
 	ExecveTailCallsMap = program.MapBuilderPin("execve_calls", "execve_calls", Execve)
@@ -51,7 +57,9 @@ var (
 	ExecveJoinMap = program.MapBuilder("tg_execve_joined_info_map", ExecveBprmCommit)
 
 	/* Policy maps populated from base programs */
-	NamesMap = program.MapBuilder("names_map", Execve)
+	NamesMap             = program.MapBuilder("names_map", Execve)
+	NamesPrefixMap       = program.MapBuilder("names_prefix_map", Execve)
+	NamesPrefixParentMap = program.MapBuilder("names_prefix_parent_map", Execve)
 
 	/* Tetragon runtime configuration */
 	TetragonConfMap = program.MapBuilder("tg_conf_map", Execve)
@@ -97,18 +105,47 @@ func GetDefaultMaps() []*program.Map {
 		ExecveJoinMapStats,
 		ExecveTailCallsMap,
 		NamesMap,
+		NamesPrefixMap,
+		NamesPrefixParentMap,
 		TCPMonMap,
+		EventCgroupStatsMap,
 		TetragonConfMap,
 	}
+	// the programs of older kernels only use the perf ring buffer
+	if kernels.EnableV61Progs() {
+		maps = append(maps, TCPMonRingBuf, EventTransportMap, RingBufLostMap)
+	}
 	return maps
 
 }
 
+// SetRingBufSize sets the size in bytes of the BPF ring buffer of the events.
+func SetRingBufSize(size int) {
+	TCPMonRingBuf.SetMaxEntries(size)
+}
+
 // GetInitialSensor returns the base sensor
 func GetInitialSensor() *sensors.Sensor {
 	return &sensor
 }
 
+// IsPin returns true if name is the name of a pin of the base sensor in the
+// observer directory. The pins of the base sensor can be adopted by a new run
+// of the agent.
+func IsPin(name string) bool {
+	for _, m := range GetDefaultMaps() {
+		if m.PinName == name {
+			return true
+		}
+	}
+	for _, p := range GetDefaultPrograms() {
+		if p.PinPath == name {
+			return true
+		}
+	}
+	return false
+}
+
 // ExecObj returns the exec object based on the kernel version
 func ExecObj() string {
 	if kernels.EnableV61Progs() {
@@ -118,3 +155,19 @@ func ExecObj() string {
 	}
 	return "bpf_execve_event.o"
 }
+
+// ForkObj returns the fork object based on the kernel version
+func ForkObj() string {
+	if kernels.EnableV61Progs() {
+		return "bpf_fork_v61.o"
+	}
+	return "bpf_fork.o"
+}
+
+// ExitObj returns the exit object based on the kernel version
+func ExitObj() string {
+	if kernels.EnableV61Progs() {
+		return "bpf_exit_v61.o"
+	}
+	return "bpf_exit.o"
+}
@@ -5,6 +5,7 @@ package sensors
 import (
 	"fmt"
 
+	"github.com/cilium/tetragon/api/v1/tetragon"
 	"github.com/cilium/tetragon/pkg/tracingpolicy"
 	"go.uber.org/multierr"
 )
@@ -23,6 +24,11 @@ type collection struct {
 	policyfilterID uint64
 	// indicates if the collection is enabled or disabled
 	enabled bool
+	// values added at runtime to the selectors of the tracing policy
+	selectorValues []selectorValue
+	// indicates that the node selector of the tracing policy does not
+	// match the node, so its sensors are not loaded
+	nodeNotSelected bool
 }
 
 func (c *collection) info() string {
@@ -32,6 +38,19 @@ func (c *collection) info() string {
 	return c.name
 }
 
+// state returns the state of the tracing policy of the collection.
+func (c *collection) state() tetragon.TracingPolicyState {
+	switch {
+	case c.err != nil:
+		return tetragon.TracingPolicyState_TP_STATE_ERROR
+	case c.nodeNotSelected:
+		return tetragon.TracingPolicyState_TP_STATE_NODE_NOT_SELECTED
+	case c.enabled:
+		return tetragon.TracingPolicyState_TP_STATE_ENABLED
+	}
+	return tetragon.TracingPolicyState_TP_STATE_DISABLED
+}
+
 // load will attempt to load a collection of sensors. If loading one of the sensors fails, it
 // will attempt to unload the already loaded sensors.
 func (c *collection) load(bpfDir, mapDir string) error {
@@ -166,10 +166,9 @@ func execParse(reader *bytes.Reader) (processapi.MsgProcess, bool, error) {
 		if err != nil {
 			return proc, false, err
 		}
-		// cut the zero byte
+		// cut the zero byte, it might be missing if the data was truncated
 		if len(data) > 0 {
-			n := len(data) - 1
-			cmdArgs = bytes.Split(data[:n], []byte{0x00})
+			cmdArgs = bytes.Split(bytes.TrimSuffix(data, []byte{0x00}), []byte{0x00})
 		}
 
 		cwd := args[unsafe.Sizeof(desc):]
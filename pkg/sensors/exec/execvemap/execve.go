@@ -17,7 +17,8 @@ type ExecveValue struct {
 	Flags        uint32                     `align:"flags"`
 	Nspid        uint32                     `align:"nspid"`
 	Binary       uint32                     `align:"binary"`
-	Pad          uint32                     `align:"pad"`
+	BinaryPrefix uint32                     `align:"binary_prefix"`
 	Namespaces   processapi.MsgNamespaces   `align:"ns"`
 	Capabilities processapi.MsgCapabilities `align:"caps"`
+	Tags         uint64                     `align:"tags"`
 }
@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package procevents
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/cilium/tetragon/pkg/api"
+	"github.com/cilium/tetragon/pkg/api/ops"
+	"github.com/cilium/tetragon/pkg/api/processapi"
+	"github.com/cilium/tetragon/pkg/grpc/exec"
+	"github.com/cilium/tetragon/pkg/kernels"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/observer"
+	"github.com/cilium/tetragon/pkg/option"
+	"github.com/cilium/tetragon/pkg/reader/notify"
+	"github.com/cilium/tetragon/pkg/reader/proc"
+	"golang.org/x/sys/unix"
+)
+
+// Poller generates the exec and exit events of processes by polling procfs.
+// It is the fallback of the exec sensor when its BPF programs cannot be
+// loaded, e.g., on very old or locked-down kernels.
+//
+// Polling is best effort: processes that start and exit between two polls
+// are missed, exit codes are unknown, and a process that execs without
+// forking is not detected. All the events it generates are flagged with
+// EventProcFS and EventProcFSPoll.
+type Poller struct {
+	procPath  string
+	interval  time.Duration
+	hasTimeNs bool
+	// push sends the events to the listeners of the observer
+	push func(msg notify.Message)
+	// start time (ktime) of the known processes, by pid. It is nil until the
+	// first poll.
+	known map[uint32]uint64
+}
+
+// NewPoller returns a poller of procfs that polls every interval.
+func NewPoller(interval time.Duration) *Poller {
+	procPath := option.Config.ProcFS
+	kernelVer, _, _ := kernels.GetKernelVersion(option.Config.KernelVersion, procPath)
+	return &Poller{
+		procPath: procPath,
+		interval: interval,
+		// time and time_for_children namespaces introduced in kernel 5.6
+		hasTimeNs: (int64(kernelVer) >= kernels.KernelStringToNumeric("5.6.0")),
+		push:      observer.AllListeners,
+	}
+}
+
+// Run polls procfs until ctx is done.
+func (p *Poller) Run(ctx context.Context) {
+	logger.GetLogger().WithField("interval", p.interval).
+		Warn("Exec sensor unavailable, falling back to polling procfs for process events")
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		p.poll()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll generates the exec events of the processes that started, and the exit
+// events of the processes that exited, since the previous poll.
+func (p *Poller) poll() {
+	entries, err := os.ReadDir(p.procPath)
+	if err != nil {
+		logger.GetLogger().WithError(err).WithField("procfs", p.procPath).Warn("Failed to poll procfs")
+		return
+	}
+
+	first := p.known == nil
+	running := make(map[uint32]uint64, len(entries))
+	var started []procs
+	for _, d := range entries {
+		if !d.IsDir() {
+			continue
+		}
+		pid, err := proc.GetProcPid(d.Name())
+		if err != nil {
+			continue
+		}
+		stats, err := proc.GetProcStatStrings(filepath.Join(p.procPath, d.Name()))
+		if err != nil {
+			// the process exited
+			continue
+		}
+		ktime, err := proc.GetStatsKtime(stats)
+		if err != nil {
+			continue
+		}
+		if known, ok := p.known[uint32(pid)]; ok && known == ktime {
+			running[uint32(pid)] = ktime
+			continue
+		}
+		ps, ok := readProc(p.procPath, d.Name(), p.hasTimeNs)
+		if !ok {
+			continue
+		}
+		ps.flags |= api.EventProcFSPoll
+		ps.pflags |= api.EventProcFSPoll
+		running[ps.pid] = ps.ktime
+		started = append(started, ps)
+	}
+
+	// processes that exited, or whose pid was reused
+	now := monotonicKtime()
+	for pid, ktime := range p.known {
+		if running[pid] == ktime {
+			continue
+		}
+		// exit codes are unknown
+		p.push(exitMsg(pid, ktime, 0, now))
+	}
+
+	if first {
+		kernel := procKernel()
+		kernel.flags |= api.EventProcFSPoll
+		kernel.pflags |= api.EventProcFSPoll
+		started = append(started, kernel)
+	}
+	for _, ps := range sortByParent(started) {
+		p.push(execveMsg(ps))
+	}
+	p.known = running
+}
+
+// sortByParent sorts processes by parent pid, as the initial read of procfs
+// does, so that the parents are usually reported first. The kernel, if
+// present, is kept last.
+func sortByParent(procs []procs) []procs {
+	sort.SliceStable(procs, func(i, j int) bool {
+		if procs[i].pid == kernelPid || procs[j].pid == kernelPid {
+			return procs[j].pid == kernelPid && procs[i].pid != kernelPid
+		}
+		return procs[i].ppid < procs[j].ppid
+	})
+	return procs
+}
+
+// exitMsg returns the exit event of the process identified by pid and ktime.
+// code is the exit code in the wait status format, and now the time of the
+// exit relative to the monotonic clock.
+func exitMsg(pid uint32, ktime uint64, code uint32, now uint64) *exec.MsgExitEventUnix {
+	m := &exec.MsgExitEventUnix{}
+	m.Common.Op = ops.MSG_OP_EXIT
+	m.Common.Ktime = now
+	m.ProcessKey = processapi.MsgExecveKey{Pid: pid, Ktime: ktime}
+	m.Info.Code = code
+	m.Info.Tid = pid
+	return m
+}
+
+func monotonicKtime() uint64 {
+	ts := unix.Timespec{}
+	if err := unix.ClockGettime(unix.CLOCK_MONOTONIC, &ts); err != nil {
+		return 0
+	}
+	return uint64(ts.Nano())
+}
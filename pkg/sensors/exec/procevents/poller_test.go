@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package procevents
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/cilium/tetragon/pkg/api"
+	grpcexec "github.com/cilium/tetragon/pkg/grpc/exec"
+	"github.com/cilium/tetragon/pkg/reader/notify"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoller(t *testing.T) {
+	var msgs []notify.Message
+	p := &Poller{
+		procPath: "/proc",
+		push: func(msg notify.Message) {
+			msgs = append(msgs, msg)
+		},
+	}
+
+	execs := func(pid uint32) []*grpcexec.MsgExecveEventUnix {
+		var ret []*grpcexec.MsgExecveEventUnix
+		for _, msg := range msgs {
+			if m, ok := msg.(*grpcexec.MsgExecveEventUnix); ok && m.Process.PID == pid {
+				ret = append(ret, m)
+			}
+		}
+		return ret
+	}
+	exits := func(pid uint32) []*grpcexec.MsgExitEventUnix {
+		var ret []*grpcexec.MsgExitEventUnix
+		for _, msg := range msgs {
+			if m, ok := msg.(*grpcexec.MsgExitEventUnix); ok && m.ProcessKey.Pid == pid {
+				ret = append(ret, m)
+			}
+		}
+		return ret
+	}
+
+	// the first poll reports the running processes and the kernel
+	p.poll()
+	require.NotEmpty(t, msgs)
+	require.Len(t, execs(kernelPid), 1)
+	for _, msg := range msgs {
+		m, ok := msg.(*grpcexec.MsgExecveEventUnix)
+		require.True(t, ok)
+		require.NotZero(t, m.Process.Flags&api.EventProcFS)
+		require.NotZero(t, m.Process.Flags&api.EventProcFSPoll)
+	}
+
+	cmd := exec.Command("sleep", "60")
+	require.NoError(t, cmd.Start())
+	pid := uint32(cmd.Process.Pid)
+
+	msgs = nil
+	p.poll()
+	started := execs(pid)
+	require.Len(t, started, 1)
+	require.NotZero(t, started[0].Process.Flags&api.EventProcFSPoll)
+	require.Contains(t, started[0].Process.Filename, "sleep")
+	require.Empty(t, execs(kernelPid))
+
+	// known processes are not reported again
+	msgs = nil
+	p.poll()
+	require.Empty(t, execs(pid))
+
+	require.NoError(t, cmd.Process.Kill())
+	cmd.Wait()
+
+	msgs = nil
+	p.poll()
+	exited := exits(pid)
+	require.Len(t, exited, 1)
+	require.Equal(t, started[0].Process.Ktime, exited[0].ProcessKey.Ktime)
+	require.Equal(t, pid, exited[0].Info.Tid)
+}
@@ -0,0 +1,281 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package procevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/cilium/tetragon/pkg/api"
+	"github.com/cilium/tetragon/pkg/kernels"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/observer"
+	"github.com/cilium/tetragon/pkg/option"
+	"github.com/cilium/tetragon/pkg/reader/notify"
+	"golang.org/x/sys/unix"
+)
+
+// Constants of the proc connector, see include/uapi/linux/connector.h and
+// include/uapi/linux/cn_proc.h.
+const (
+	cnIdxProc = 0x1
+	cnValProc = 0x1
+
+	procCnMcastListen = 1
+	procCnMcastIgnore = 2
+
+	procEventExec = 0x00000002
+	procEventExit = 0x80000000
+
+	// sizeof(struct cn_msg)
+	sizeofCnMsg = 20
+	// offset of event_data in struct proc_event
+	procEventDataOffset = 16
+
+	recvTimeout = time.Second
+)
+
+// procEvent is the part of struct proc_event that the connector uses.
+type procEvent struct {
+	what      uint32
+	timestamp uint64
+	// pid and tgid of the process, for exec and exit events
+	pid  uint32
+	tgid uint32
+	// exit code of the process, in the wait status format, for exit events
+	exitCode uint32
+}
+
+// parseProcEvents parses the proc events of a netlink message buffer.
+func parseProcEvents(b []byte) ([]procEvent, error) {
+	msgs, err := syscall.ParseNetlinkMessage(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []procEvent
+	for _, msg := range msgs {
+		if msg.Header.Type != unix.NLMSG_DONE {
+			continue
+		}
+		data := msg.Data
+		if len(data) < sizeofCnMsg+procEventDataOffset {
+			return nil, fmt.Errorf("proc connector message too short: %d bytes", len(data))
+		}
+		idx := binary.LittleEndian.Uint32(data[0:4])
+		val := binary.LittleEndian.Uint32(data[4:8])
+		if idx != cnIdxProc || val != cnValProc {
+			continue
+		}
+		ev := data[sizeofCnMsg:]
+		pe := procEvent{
+			what:      binary.LittleEndian.Uint32(ev[0:4]),
+			timestamp: binary.LittleEndian.Uint64(ev[8:16]),
+		}
+		evData := ev[procEventDataOffset:]
+		switch pe.what {
+		case procEventExec:
+			if len(evData) < 8 {
+				return nil, fmt.Errorf("proc connector exec event too short: %d bytes", len(evData))
+			}
+		case procEventExit:
+			if len(evData) < 12 {
+				return nil, fmt.Errorf("proc connector exit event too short: %d bytes", len(evData))
+			}
+			pe.exitCode = binary.LittleEndian.Uint32(evData[8:12])
+		default:
+			continue
+		}
+		pe.pid = binary.LittleEndian.Uint32(evData[0:4])
+		pe.tgid = binary.LittleEndian.Uint32(evData[4:8])
+		ret = append(ret, pe)
+	}
+	return ret, nil
+}
+
+// ProcConnector generates the exec and exit events of processes from the
+// netlink proc connector. It is a source of process events for environments
+// that forbid loading the BPF programs of the exec sensor, but allow
+// CAP_NET_ADMIN.
+//
+// The connector only reports the pids of the processes, the other information
+// is read from procfs when the events are received. Processes that exit
+// before procfs is read are missed. All the events it generates are flagged
+// with EventProcFS and EventProcConnector.
+type ProcConnector struct {
+	fd        int
+	procPath  string
+	hasTimeNs bool
+	// push sends the events to the listeners of the observer
+	push func(msg notify.Message)
+	// start time (ktime) of the known processes, by pid
+	known map[uint32]uint64
+}
+
+// NewProcConnector subscribes to the proc connector. It fails if the kernel
+// does not support it or the agent lacks CAP_NET_ADMIN.
+func NewProcConnector() (*ProcConnector, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, unix.NETLINK_CONNECTOR)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create proc connector socket: %w", err)
+	}
+	c := &ProcConnector{
+		fd:       fd,
+		procPath: option.Config.ProcFS,
+		push:     observer.AllListeners,
+		known:    make(map[uint32]uint64),
+	}
+	if err := unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK, Groups: cnIdxProc}); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("failed to bind proc connector socket: %w", err)
+	}
+	if err := c.listen(procCnMcastListen); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("failed to subscribe to proc connector: %w", err)
+	}
+
+	kernelVer, _, _ := kernels.GetKernelVersion(option.Config.KernelVersion, c.procPath)
+	// time and time_for_children namespaces introduced in kernel 5.6
+	c.hasTimeNs = (int64(kernelVer) >= kernels.KernelStringToNumeric("5.6.0"))
+	return c, nil
+}
+
+// listen sends a multicast operation (listen or ignore) to the connector.
+func (c *ProcConnector) listen(op uint32) error {
+	var buf bytes.Buffer
+	hdr := unix.NlMsghdr{
+		Len:  unix.SizeofNlMsghdr + sizeofCnMsg + 4,
+		Type: unix.NLMSG_DONE,
+		Pid:  uint32(os.Getpid()),
+	}
+	binary.Write(&buf, binary.LittleEndian, hdr)
+	// struct cn_msg: id.idx, id.val, seq, ack, len, flags
+	binary.Write(&buf, binary.LittleEndian, [4]uint32{cnIdxProc, cnValProc, 0, 0})
+	binary.Write(&buf, binary.LittleEndian, [2]uint16{4, 0})
+	binary.Write(&buf, binary.LittleEndian, op)
+	return unix.Sendto(c.fd, buf.Bytes(), 0, &unix.SockaddrNetlink{Family: unix.AF_NETLINK})
+}
+
+// Close unsubscribes from the proc connector.
+func (c *ProcConnector) Close() error {
+	c.listen(procCnMcastIgnore)
+	return unix.Close(c.fd)
+}
+
+// Run reports the running processes, and then the processes that exec and
+// exit until ctx is done. It closes the connector when it returns.
+func (c *ProcConnector) Run(ctx context.Context) error {
+	defer c.Close()
+
+	logger.GetLogger().Info("Using the proc connector for process events")
+
+	// wake up regularly to check ctx
+	tv := unix.NsecToTimeval(recvTimeout.Nanoseconds())
+	if err := unix.SetsockoptTimeval(c.fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv); err != nil {
+		return fmt.Errorf("failed to set proc connector socket timeout: %w", err)
+	}
+
+	// subscribe before reading procfs, so that no process is missed
+	c.pushRunning()
+
+	buf := make([]byte, os.Getpagesize())
+	for {
+		n, _, err := unix.Recvfrom(c.fd, buf, 0)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if errors.Is(err, unix.ENOBUFS) {
+			logger.GetLogger().Warn("Proc connector events lost, the agent did not keep up")
+			continue
+		}
+		if errors.Is(err, unix.EINTR) || errors.Is(err, unix.EAGAIN) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read proc connector: %w", err)
+		}
+		if n == 0 {
+			return nil
+		}
+		events, err := parseProcEvents(buf[:n])
+		if err != nil {
+			logger.GetLogger().WithError(err).Warn("Failed to parse proc connector events")
+			continue
+		}
+		for i := range events {
+			c.handle(&events[i])
+		}
+	}
+}
+
+// pushRunning reports the running processes.
+func (c *ProcConnector) pushRunning() {
+	procs, err := listRunningProcs(c.procPath)
+	if err != nil {
+		logger.GetLogger().WithError(err).Warnf("Failed to list running processes from '%s'", c.procPath)
+	}
+	procs = append(procs, procKernel())
+	for _, p := range sortByParent(procs) {
+		p.flags |= api.EventProcConnector
+		p.pflags |= api.EventProcConnector
+		if p.pid != kernelPid {
+			c.known[p.pid] = p.ktime
+		}
+		c.push(execveMsg(p))
+	}
+}
+
+func (c *ProcConnector) handle(ev *procEvent) {
+	switch ev.what {
+	case procEventExec:
+		p, ok := readProc(c.procPath, strconv.FormatUint(uint64(ev.tgid), 10), c.hasTimeNs)
+		if !ok {
+			// the process already exited
+			return
+		}
+		// the start time of the process in procfs is the time of its
+		// fork, so use the time of the exec to identify it. Like the start
+		// times of procfs, it is relative to boot time.
+		p.ktime = ev.timestamp + bootTimeOffset()
+		if pktime, ok := c.known[p.ppid]; ok {
+			p.pktime = pktime
+		}
+		p.flags |= api.EventProcConnector
+		p.pflags |= api.EventProcConnector
+		c.known[p.pid] = p.ktime
+		c.push(execveMsg(p))
+	case procEventExit:
+		// only the exit of the thread group leader is the exit of the
+		// process
+		if ev.pid != ev.tgid {
+			return
+		}
+		ktime, ok := c.known[ev.tgid]
+		if !ok {
+			return
+		}
+		delete(c.known, ev.tgid)
+		c.push(exitMsg(ev.tgid, ktime, ev.exitCode, ev.timestamp))
+	}
+}
+
+// bootTimeOffset returns the offset of the boot time clock from the monotonic
+// clock, i.e., the time that the system was suspended.
+func bootTimeOffset() uint64 {
+	var boot, mono unix.Timespec
+	if err := unix.ClockGettime(unix.CLOCK_BOOTTIME, &boot); err != nil {
+		return 0
+	}
+	if err := unix.ClockGettime(unix.CLOCK_MONOTONIC, &mono); err != nil {
+		return 0
+	}
+	return uint64(boot.Nano() - mono.Nano())
+}
@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package procevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"os/exec"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/pkg/api"
+	grpcexec "github.com/cilium/tetragon/pkg/grpc/exec"
+	"github.com/cilium/tetragon/pkg/reader/notify"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+// procEventMsg returns a netlink message of the proc connector
+func procEventMsg(what uint32, ts uint64, data ...uint32) []byte {
+	var ev bytes.Buffer
+	// struct proc_event: what, cpu, timestamp_ns, event_data
+	binary.Write(&ev, binary.LittleEndian, [2]uint32{what, 0})
+	binary.Write(&ev, binary.LittleEndian, ts)
+	binary.Write(&ev, binary.LittleEndian, data)
+
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, unix.NlMsghdr{
+		Len:  uint32(unix.SizeofNlMsghdr + sizeofCnMsg + ev.Len()),
+		Type: unix.NLMSG_DONE,
+	})
+	binary.Write(&buf, binary.LittleEndian, [4]uint32{cnIdxProc, cnValProc, 0, 0})
+	binary.Write(&buf, binary.LittleEndian, [2]uint16{uint16(ev.Len()), 0})
+	buf.Write(ev.Bytes())
+	return buf.Bytes()
+}
+
+func TestParseProcEvents(t *testing.T) {
+	var b []byte
+	b = append(b, procEventMsg(procEventExec, 100, 42, 42)...)
+	// fork events are ignored
+	b = append(b, procEventMsg(0x1, 200, 42, 42, 43, 43)...)
+	b = append(b, procEventMsg(procEventExit, 300, 43, 42, 9, 17)...)
+
+	events, err := parseProcEvents(b)
+	require.NoError(t, err)
+	require.Equal(t, []procEvent{
+		{what: procEventExec, timestamp: 100, pid: 42, tgid: 42},
+		{what: procEventExit, timestamp: 300, pid: 43, tgid: 42, exitCode: 9},
+	}, events)
+
+	_, err = parseProcEvents(procEventMsg(procEventExit, 300, 43))
+	require.Error(t, err)
+}
+
+func TestProcConnector(t *testing.T) {
+	c, err := NewProcConnector()
+	if err != nil {
+		t.Skipf("proc connector unavailable: %s", err)
+	}
+	c.procPath = "/proc"
+
+	var mu sync.Mutex
+	var msgs []notify.Message
+	c.push = func(msg notify.Message) {
+		mu.Lock()
+		defer mu.Unlock()
+		msgs = append(msgs, msg)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- c.Run(ctx)
+	}()
+
+	// the running processes are reported first, and the connector is
+	// subscribed before that
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(msgs) > 0
+	}, 10*time.Second, 10*time.Millisecond)
+
+	cmd := exec.Command("sleep", "0.5")
+	require.NoError(t, cmd.Start())
+	pid := uint32(cmd.Process.Pid)
+	require.NoError(t, cmd.Wait())
+
+	var execMsg *grpcexec.MsgExecveEventUnix
+	var exitMsg *grpcexec.MsgExitEventUnix
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, msg := range msgs {
+			switch m := msg.(type) {
+			case *grpcexec.MsgExecveEventUnix:
+				if m.Process.PID == pid {
+					execMsg = m
+				}
+			case *grpcexec.MsgExitEventUnix:
+				if m.ProcessKey.Pid == pid {
+					exitMsg = m
+				}
+			}
+		}
+		return execMsg != nil && exitMsg != nil
+	}, 10*time.Second, 10*time.Millisecond)
+
+	require.NotZero(t, execMsg.Process.Flags&api.EventProcFS)
+	require.NotZero(t, execMsg.Process.Flags&api.EventProcConnector)
+	require.Contains(t, execMsg.Process.Filename, "sleep")
+	require.Equal(t, execMsg.Process.Ktime, exitMsg.ProcessKey.Ktime)
+	require.Equal(t, uint32(0), exitMsg.Info.Code)
+
+	cancel()
+	require.NoError(t, <-done)
+}
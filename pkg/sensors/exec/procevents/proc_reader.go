@@ -128,6 +128,11 @@ func getCWD(pid uint32) (string, uint32) {
 }
 
 func pushExecveEvents(p procs) {
+	observer.AllListeners(execveMsg(p))
+}
+
+// execveMsg returns the exec event of a process read from procfs
+func execveMsg(p procs) *exec.MsgExecveEventUnix {
 	var err error
 
 	args, filename := procsFilename(p.args)
@@ -185,7 +190,7 @@ func pushExecveEvents(p procs) {
 	m.Process.Filename = filename
 	m.Process.Args = args
 
-	observer.AllListeners(&m)
+	return &m
 }
 
 func updateExecveMapStats(procs int64) {
@@ -289,222 +294,229 @@ func listRunningProcs(procPath string) ([]procs, error) {
 	hasTimeNs := (int64(kernelVer) >= kernels.KernelStringToNumeric("5.6.0"))
 
 	for _, d := range procFS {
-		var pcmdline []byte
-		var pstats []string
-		var pktime uint64
-		var pexecPath string
-		var pnspid uint32
-
 		if d.IsDir() == false {
 			continue
 		}
+		if p, ok := readProc(procPath, d.Name(), hasTimeNs); ok {
+			processes = append(processes, p)
+		}
+	}
+
+	logger.GetLogger().Infof("Read ProcFS %s appended %d/%d entries", option.Config.ProcFS, len(processes), len(procFS))
+
+	return processes, nil
+}
+
+// readProc reads the process of the /proc entry name. It returns false if the entry is not a
+// process or it could not be read, e.g., because the process exited.
+func readProc(procPath string, name string, hasTimeNs bool) (procs, bool) {
+	var pcmdline []byte
+	var pstats []string
+	var pktime uint64
+	var pexecPath string
+	var pnspid uint32
+
+	pathName := filepath.Join(procPath, name)
+
+	cmdline, err := os.ReadFile(filepath.Join(pathName, "cmdline"))
+	if err != nil {
+		return procs{}, false
+	}
+	if string(cmdline) == "" {
+		return procs{}, false
+	}
+
+	pid, err := proc.GetProcPid(name)
+	if err != nil {
+		logger.GetLogger().WithError(err).Warnf("pid read error")
+		return procs{}, false
+	}
 
-		pathName := filepath.Join(procPath, d.Name())
+	stats, err := proc.GetProcStatStrings(pathName)
+	if err != nil {
+		logger.GetLogger().WithError(err).Warnf("stats read error")
+		return procs{}, false
+	}
 
-		cmdline, err := os.ReadFile(filepath.Join(pathName, "cmdline"))
+	ppid := stats[3]
+	_ppid, err := strconv.ParseUint(ppid, 10, 32)
+	if err != nil {
+		_ppid = 0 // 0 pid indicates no known parent
+	}
+
+	ktime, err := proc.GetStatsKtime(stats)
+	if err != nil {
+		logger.GetLogger().WithError(err).Warnf("ktime read error")
+	}
+
+	// Initialize with invalid uid
+	uids := []uint32{proc.InvalidUid, proc.InvalidUid, proc.InvalidUid, proc.InvalidUid}
+	gids := []uint32{proc.InvalidUid, proc.InvalidUid, proc.InvalidUid, proc.InvalidUid}
+	auid := proc.InvalidUid
+	// Get process status
+	status, err := proc.GetStatus(pathName)
+	if err != nil {
+		logger.GetLogger().WithError(err).Warnf("Reading process status error")
+	} else {
+		uids, err = status.GetUids()
 		if err != nil {
-			continue
-		}
-		if string(cmdline) == "" {
-			continue
+			logger.GetLogger().WithError(err).Warnf("Reading Uids of %s failed, falling back to uid: %d", pathName, uint32(proc.InvalidUid))
 		}
 
-		pid, err := proc.GetProcPid(d.Name())
+		gids, err = status.GetGids()
 		if err != nil {
-			logger.GetLogger().WithError(err).Warnf("pid read error")
-			continue
+			logger.GetLogger().WithError(err).Warnf("Reading Uids of %s failed, falling back to gid: %d", pathName, uint32(proc.InvalidUid))
 		}
 
-		stats, err := proc.GetProcStatStrings(pathName)
+		auid, err = status.GetLoginUid()
 		if err != nil {
-			logger.GetLogger().WithError(err).Warnf("stats read error")
-			continue
+			logger.GetLogger().WithError(err).Warnf("Reading Loginuid of %s failed, falling back to loginuid: %d", pathName, uint32(auid))
 		}
+	}
 
-		ppid := stats[3]
-		_ppid, err := strconv.ParseUint(ppid, 10, 32)
+	nspid, permitted, effective, inheritable := caps.GetPIDCaps(filepath.Join(procPath, name, "status"))
+
+	uts_ns := namespace.GetPidNsInode(uint32(pid), "uts")
+	ipc_ns := namespace.GetPidNsInode(uint32(pid), "ipc")
+	mnt_ns := namespace.GetPidNsInode(uint32(pid), "mnt")
+	pid_ns := namespace.GetPidNsInode(uint32(pid), "pid")
+	pid_for_children_ns := namespace.GetPidNsInode(uint32(pid), "pid_for_children")
+	net_ns := namespace.GetPidNsInode(uint32(pid), "net")
+	time_ns := uint32(0)
+	time_for_children_ns := uint32(0)
+	if hasTimeNs {
+		time_ns = namespace.GetPidNsInode(uint32(pid), "time")
+		time_for_children_ns = namespace.GetPidNsInode(uint32(pid), "time_for_children")
+	}
+	cgroup_ns := namespace.GetPidNsInode(uint32(pid), "cgroup")
+	user_ns := namespace.GetPidNsInode(uint32(pid), "user")
+
+	// On error procsDockerId zeros dockerId so we can ignore any errors.
+	dockerId, _ := procsDockerId(uint32(pid))
+	if dockerId == "" {
+		// If we do not have a container ID, then set nspid to zero.
+		// This field is used to construct the pod information to
+		// identify pids inside the container.
+		nspid = 0
+	}
+
+	if _ppid != 0 {
+		var err error
+		parentPath := filepath.Join(procPath, ppid)
+
+		pcmdline, err = os.ReadFile(filepath.Join(parentPath, "cmdline"))
 		if err != nil {
-			_ppid = 0 // 0 pid indicates no known parent
+			logger.GetLogger().WithError(err).WithField("path", parentPath).Warn("parent cmdline error")
+			return procs{}, false
 		}
 
-		ktime, err := proc.GetStatsKtime(stats)
+		pstats, err = proc.GetProcStatStrings(string(parentPath))
 		if err != nil {
-			logger.GetLogger().WithError(err).Warnf("ktime read error")
+			logger.GetLogger().WithError(err).Warnf("parent stats read error")
+			return procs{}, false
 		}
 
-		// Initialize with invalid uid
-		uids := []uint32{proc.InvalidUid, proc.InvalidUid, proc.InvalidUid, proc.InvalidUid}
-		gids := []uint32{proc.InvalidUid, proc.InvalidUid, proc.InvalidUid, proc.InvalidUid}
-		auid := proc.InvalidUid
-		// Get process status
-		status, err := proc.GetStatus(pathName)
+		pktime, err = proc.GetStatsKtime(pstats)
 		if err != nil {
-			logger.GetLogger().WithError(err).Warnf("Reading process status error")
-		} else {
-			uids, err = status.GetUids()
-			if err != nil {
-				logger.GetLogger().WithError(err).Warnf("Reading Uids of %s failed, falling back to uid: %d", pathName, uint32(proc.InvalidUid))
-			}
-
-			gids, err = status.GetGids()
-			if err != nil {
-				logger.GetLogger().WithError(err).Warnf("Reading Uids of %s failed, falling back to gid: %d", pathName, uint32(proc.InvalidUid))
-			}
-
-			auid, err = status.GetLoginUid()
-			if err != nil {
-				logger.GetLogger().WithError(err).Warnf("Reading Loginuid of %s failed, falling back to loginuid: %d", pathName, uint32(auid))
-			}
+			logger.GetLogger().WithError(err).Warnf("parent ktime read error")
 		}
 
-		nspid, permitted, effective, inheritable := caps.GetPIDCaps(filepath.Join(procPath, d.Name(), "status"))
-
-		uts_ns := namespace.GetPidNsInode(uint32(pid), "uts")
-		ipc_ns := namespace.GetPidNsInode(uint32(pid), "ipc")
-		mnt_ns := namespace.GetPidNsInode(uint32(pid), "mnt")
-		pid_ns := namespace.GetPidNsInode(uint32(pid), "pid")
-		pid_for_children_ns := namespace.GetPidNsInode(uint32(pid), "pid_for_children")
-		net_ns := namespace.GetPidNsInode(uint32(pid), "net")
-		time_ns := uint32(0)
-		time_for_children_ns := uint32(0)
-		if hasTimeNs {
-			time_ns = namespace.GetPidNsInode(uint32(pid), "time")
-			time_for_children_ns = namespace.GetPidNsInode(uint32(pid), "time_for_children")
+		if dockerId != "" {
+			// We have a container ID so let's get the nspid inside.
+			pnspid, _, _, _ = caps.GetPIDCaps(filepath.Join(procPath, ppid, "status"))
 		}
-		cgroup_ns := namespace.GetPidNsInode(uint32(pid), "cgroup")
-		user_ns := namespace.GetPidNsInode(uint32(pid), "user")
-
-		// On error procsDockerId zeros dockerId so we can ignore any errors.
-		dockerId, _ := procsDockerId(uint32(pid))
-		if dockerId == "" {
-			// If we do not have a container ID, then set nspid to zero.
-			// This field is used to construct the pod information to
-			// identify pids inside the container.
-			nspid = 0
-		}
-
-		if _ppid != 0 {
-			var err error
-			parentPath := filepath.Join(procPath, ppid)
-
-			pcmdline, err = os.ReadFile(filepath.Join(parentPath, "cmdline"))
-			if err != nil {
-				logger.GetLogger().WithError(err).WithField("path", parentPath).Warn("parent cmdline error")
-				continue
-			}
-
-			pstats, err = proc.GetProcStatStrings(string(parentPath))
-			if err != nil {
-				logger.GetLogger().WithError(err).Warnf("parent stats read error")
-				continue
-			}
-
-			pktime, err = proc.GetStatsKtime(pstats)
-			if err != nil {
-				logger.GetLogger().WithError(err).Warnf("parent ktime read error")
-			}
+	} else {
+		pcmdline = nil
+		pstats = nil
+		pktime = 0
+		pnspid = 0
+	}
 
-			if dockerId != "" {
-				// We have a container ID so let's get the nspid inside.
-				pnspid, _, _, _ = caps.GetPIDCaps(filepath.Join(procPath, ppid, "status"))
-			}
-		} else {
-			pcmdline = nil
-			pstats = nil
-			pktime = 0
-			pnspid = 0
-		}
+	execPath, err := os.Readlink(filepath.Join(procPath, name, "exe"))
+	if err == nil {
+		cmdline = proc.PrependPath(execPath, cmdline)
+	}
 
-		execPath, err := os.Readlink(filepath.Join(procPath, d.Name(), "exe"))
+	if _ppid != 0 {
+		pexecPath, err = os.Readlink(filepath.Join(procPath, ppid, "exe"))
 		if err == nil {
-			cmdline = proc.PrependPath(execPath, cmdline)
-		}
-
-		if _ppid != 0 {
-			pexecPath, err = os.Readlink(filepath.Join(procPath, ppid, "exe"))
-			if err == nil {
-				pcmdline = proc.PrependPath(pexecPath, pcmdline)
-			}
-		} else {
-			pexecPath = ""
+			pcmdline = proc.PrependPath(pexecPath, pcmdline)
 		}
+	} else {
+		pexecPath = ""
+	}
 
-		pcmdsUTF := stringToUTF8(pcmdline)
-		cmdsUTF := stringToUTF8(cmdline)
-
-		p := procs{
-			ppid: uint32(_ppid), pnspid: pnspid, pargs: pcmdsUTF,
-			pflags:               api.EventProcFS | api.EventNeedsCWD | api.EventNeedsAUID,
-			pktime:               pktime,
-			uids:                 uids,
-			gids:                 gids,
-			auid:                 auid,
-			pid:                  uint32(pid),
-			tid:                  uint32(pid), // Read dir does not return threads and we only track tgid
-			nspid:                nspid,
-			args:                 cmdsUTF,
-			flags:                api.EventProcFS | api.EventNeedsCWD | api.EventNeedsAUID,
-			ktime:                ktime,
-			permitted:            permitted,
-			effective:            effective,
-			inheritable:          inheritable,
-			uts_ns:               uts_ns,
-			ipc_ns:               ipc_ns,
-			mnt_ns:               mnt_ns,
-			pid_ns:               pid_ns,
-			pid_for_children_ns:  pid_for_children_ns,
-			net_ns:               net_ns,
-			time_ns:              time_ns,
-			time_for_children_ns: time_for_children_ns,
-			cgroup_ns:            cgroup_ns,
-			user_ns:              user_ns,
-		}
+	pcmdsUTF := stringToUTF8(pcmdline)
+	cmdsUTF := stringToUTF8(cmdline)
+
+	p := procs{
+		ppid: uint32(_ppid), pnspid: pnspid, pargs: pcmdsUTF,
+		pflags:               api.EventProcFS | api.EventNeedsCWD | api.EventNeedsAUID,
+		pktime:               pktime,
+		uids:                 uids,
+		gids:                 gids,
+		auid:                 auid,
+		pid:                  uint32(pid),
+		tid:                  uint32(pid), // Read dir does not return threads and we only track tgid
+		nspid:                nspid,
+		args:                 cmdsUTF,
+		flags:                api.EventProcFS | api.EventNeedsCWD | api.EventNeedsAUID,
+		ktime:                ktime,
+		permitted:            permitted,
+		effective:            effective,
+		inheritable:          inheritable,
+		uts_ns:               uts_ns,
+		ipc_ns:               ipc_ns,
+		mnt_ns:               mnt_ns,
+		pid_ns:               pid_ns,
+		pid_for_children_ns:  pid_for_children_ns,
+		net_ns:               net_ns,
+		time_ns:              time_ns,
+		time_for_children_ns: time_for_children_ns,
+		cgroup_ns:            cgroup_ns,
+		user_ns:              user_ns,
+	}
 
-		p.size = uint32(processapi.MSG_SIZEOF_EXECVE + len(p.args) + processapi.MSG_SIZEOF_CWD)
-		p.psize = uint32(processapi.MSG_SIZEOF_EXECVE + len(p.pargs) + processapi.MSG_SIZEOF_CWD)
-		/* If we can't fit this in the buffer lets trim some parts and
-		 * make it fit.
-		 */
-		if p.size+p.psize > processapi.MSG_SIZEOF_BUFFER {
-			var deduct uint32
-			var need int32
-
-			need = int32((p.size + p.psize) - processapi.MSG_SIZEOF_BUFFER)
-			// First consume CWD space from parent because this speculative extra space
-			// next try to consume CWD space from child and finally start truncating args
-			// if necessary.
+	p.size = uint32(processapi.MSG_SIZEOF_EXECVE + len(p.args) + processapi.MSG_SIZEOF_CWD)
+	p.psize = uint32(processapi.MSG_SIZEOF_EXECVE + len(p.pargs) + processapi.MSG_SIZEOF_CWD)
+	/* If we can't fit this in the buffer lets trim some parts and
+	 * make it fit.
+	 */
+	if p.size+p.psize > processapi.MSG_SIZEOF_BUFFER {
+		var deduct uint32
+		var need int32
+
+		need = int32((p.size + p.psize) - processapi.MSG_SIZEOF_BUFFER)
+		// First consume CWD space from parent because this speculative extra space
+		// next try to consume CWD space from child and finally start truncating args
+		// if necessary.
+		deduct = processapi.MSG_SIZEOF_CWD
+		p.pflags = p.pflags & ^uint32(api.EventNeedsCWD)
+		p.pflags = p.pflags | api.EventNoCWDSupport
+		p.psize -= deduct
+		need -= int32(deduct)
+		if need > 0 {
 			deduct = processapi.MSG_SIZEOF_CWD
-			p.pflags = p.pflags & ^uint32(api.EventNeedsCWD)
-			p.pflags = p.pflags | api.EventNoCWDSupport
-			p.psize -= deduct
+			p.size -= deduct
+			p.flags = p.flags & ^uint32(api.EventNeedsCWD)
+			p.flags = p.flags | api.EventNoCWDSupport
 			need -= int32(deduct)
-			if need > 0 {
-				deduct = processapi.MSG_SIZEOF_CWD
-				p.size -= deduct
-				p.flags = p.flags & ^uint32(api.EventNeedsCWD)
-				p.flags = p.flags | api.EventNoCWDSupport
-				need -= int32(deduct)
-			}
+		}
 
-			for i := int32(0); i < need; i++ {
-				if len(p.pargs) > len(p.args) {
-					p.pflags |= api.EventTruncArgs
-					p.pargs = p.pargs[:len(p.pargs)-1]
-					p.psize--
-				} else {
-					p.flags |= api.EventTruncArgs
-					p.args = p.args[:len(p.args)-1]
-					p.size--
-				}
+		for i := int32(0); i < need; i++ {
+			if len(p.pargs) > len(p.args) {
+				p.pflags |= api.EventTruncArgs
+				p.pargs = p.pargs[:len(p.pargs)-1]
+				p.psize--
+			} else {
+				p.flags |= api.EventTruncArgs
+				p.args = p.args[:len(p.args)-1]
+				p.size--
 			}
 		}
-
-		processes = append(processes, p)
 	}
 
-	logger.GetLogger().Infof("Read ProcFS %s appended %d/%d entries", option.Config.ProcFS, len(processes), len(procFS))
-
-	return processes, nil
+	return p, true
 }
 
 func GetRunningProcs() error {
@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package procevents
+
+// Sources of the process exec and exit events.
+const (
+	// SourceAuto uses the BPF programs of the exec sensor, and falls back
+	// to the proc connector and then to polling procfs if they cannot be
+	// loaded.
+	SourceAuto = "auto"
+	// SourceBPF uses the BPF programs of the exec sensor.
+	SourceBPF = "bpf"
+	// SourceProcConnector uses the netlink proc connector.
+	SourceProcConnector = "proc-connector"
+	// SourceProcFS polls procfs.
+	SourceProcFS = "procfs"
+)
+
+// Sources are the valid sources of process events.
+var Sources = []string{SourceAuto, SourceBPF, SourceProcConnector, SourceProcFS}
+
+// ValidSource returns true if source is a valid source of process events.
+func ValidSource(source string) bool {
+	for _, s := range Sources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
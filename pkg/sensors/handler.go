@@ -6,11 +6,41 @@ package sensors
 import (
 	"errors"
 	"fmt"
+	"reflect"
 
 	slimv1 "github.com/cilium/cilium/pkg/k8s/slim/k8s/apis/meta/v1"
+	"github.com/cilium/ebpf"
 	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"github.com/cilium/tetragon/pkg/labels"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/metrics/eventmetrics"
 	"github.com/cilium/tetragon/pkg/policyfilter"
+	"github.com/cilium/tetragon/pkg/reader/node"
 	"github.com/cilium/tetragon/pkg/tracingpolicy"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	// ErrTracingPolicyNotFound is returned by the operations on a tracing
+	// policy that is not loaded.
+	ErrTracingPolicyNotFound = errors.New("tracing policy not found")
+	// ErrTracingPolicyExists is returned when adding a tracing policy with
+	// the name of a loaded one.
+	ErrTracingPolicyExists = errors.New("tracing policy already exists")
+	// ErrTracingPolicyEnabled and ErrTracingPolicyDisabled are returned when
+	// enabling or disabling a tracing policy that already is.
+	ErrTracingPolicyEnabled  = errors.New("tracing policy already enabled")
+	ErrTracingPolicyDisabled = errors.New("tracing policy already disabled")
+	// ErrTracingPolicyNotUpdatable is returned when updating the selectors
+	// of a tracing policy requires to reload it.
+	ErrTracingPolicyNotUpdatable = errors.New("tracing policy cannot be updated in place")
+	// ErrInvalidSelectorValues is returned when adding selector values to
+	// a filter that the tracing policy does not have.
+	ErrInvalidSelectorValues = errors.New("invalid selector values")
+	// ErrTracingPolicyNodeNotSelected is returned when enabling a tracing
+	// policy whose node selector does not match the node.
+	ErrTracingPolicyNodeNotSelected = errors.New("tracing policy node selector does not match the node")
 )
 
 type handler struct {
@@ -53,7 +83,7 @@ func SensorsFromPolicy(tp tracingpolicy.TracingPolicy, filterID policyfilter.Pol
 // revive:enable:exported
 
 // updatePolicyFilter will update the policyfilter state so that filtering for
-// i) namespaced policies and ii) pod label filters happens.
+// i) namespaced policies, ii) pod label filters and iii) container filters happens.
 //
 // It returns:
 //
@@ -66,10 +96,17 @@ func (h *handler) updatePolicyFilter(tp tracingpolicy.TracingPolicy, tpID uint64
 		namespace = tpNs.TpNamespace()
 	}
 
-	var selector *slimv1.LabelSelector
+	var podSelector *slimv1.LabelSelector
 	if ps := tp.TpSpec().PodSelector; ps != nil {
 		if len(ps.MatchLabels)+len(ps.MatchExpressions) > 0 {
-			selector = ps
+			podSelector = ps
+		}
+	}
+
+	var containerSelector *slimv1.LabelSelector
+	if cs := tp.TpSpec().ContainerSelector; cs != nil {
+		if len(cs.MatchLabels)+len(cs.MatchExpressions) > 0 {
+			containerSelector = cs
 		}
 	}
 
@@ -77,23 +114,58 @@ func (h *handler) updatePolicyFilter(tp tracingpolicy.TracingPolicy, tpID uint64
 	// means that if policyfilter is disabled
 	// (option.Config.EnablePolicyFilter is false) then loading the policy
 	// will only fail if filtering is required.
-	if namespace == "" && selector == nil {
+	if namespace == "" && podSelector == nil && containerSelector == nil {
 		return policyfilter.NoFilterID, nil
 	}
 
 	filterID := policyfilter.PolicyID(tpID)
-	if err := h.pfState.AddPolicy(filterID, namespace, selector); err != nil {
+	if err := h.pfState.AddPolicy(filterID, namespace, podSelector, containerSelector); err != nil {
 		return policyfilter.NoFilterID, err
 	}
 	return filterID, nil
 }
 
+// nodeSelected returns whether the node selector of a tracing policy matches
+// the labels of the node.
+func nodeSelected(spec *v1alpha1.TracingPolicySpec) (bool, error) {
+	ns := spec.NodeSelector
+	if ns == nil || len(ns.MatchLabels)+len(ns.MatchExpressions) == 0 {
+		return true, nil
+	}
+	selector, err := labels.SelectorFromLabelSelector(ns)
+	if err != nil {
+		return false, fmt.Errorf("invalid node selector: %w", err)
+	}
+	return selector.Match(node.GetNodeLabels()), nil
+}
+
 func (h *handler) addTracingPolicy(op *tracingPolicyAdd) error {
 	if _, exists := h.collections[op.name]; exists {
-		return fmt.Errorf("failed to add tracing policy %s, a sensor collection with the name already exists", op.name)
+		return fmt.Errorf("failed to add tracing policy %s: %w", op.name, ErrTracingPolicyExists)
 	}
+
+	selected, err := nodeSelected(op.tp.TpSpec())
+	if err != nil {
+		return fmt.Errorf("failed to add tracing policy %s: %w", op.name, err)
+	}
+
 	tpID := h.allocPolicyID()
 
+	// the policy is kept, so that it is listed, but its sensors are not
+	// loaded on the nodes that its node selector does not match
+	if !selected {
+		logger.GetLogger().WithField("policy", op.name).
+			Info("Tracing policy node selector does not match the node, not loading it")
+		h.collections[op.name] = collection{
+			name:            op.name,
+			tracingpolicy:   op.tp,
+			tracingpolicyID: tpID,
+			nodeNotSelected: true,
+		}
+		eventmetrics.ResetPolicyEvents(op.name)
+		return nil
+	}
+
 	// update policy filter state before loading the sensors of the policy.
 	//
 	// The filterID is set to a non-zero value only if we need to apply
@@ -121,6 +193,9 @@ func (h *handler) addTracingPolicy(op *tracingPolicyAdd) error {
 		policyfilterID:  uint64(filterID),
 	}
 	if err := col.load(h.bpfDir, h.mapDir); err != nil {
+		// the policy is not kept, so the resources of its sensors
+		// are released
+		col.destroy()
 		return err
 	}
 	col.enabled = true
@@ -128,17 +203,19 @@ func (h *handler) addTracingPolicy(op *tracingPolicyAdd) error {
 	// NB: in some cases it might make sense to keep the policy registered if there was an
 	// error. For now, however, we only keep it if it was successfully loaded
 	h.collections[op.name] = col
+	eventmetrics.ResetPolicyEvents(op.name)
 	return nil
 }
 
 func (h *handler) deleteTracingPolicy(op *tracingPolicyDelete) error {
 	col, exists := h.collections[op.name]
 	if !exists {
-		return fmt.Errorf("tracing policy %s does not exist", op.name)
+		return fmt.Errorf("%w: %s", ErrTracingPolicyNotFound, op.name)
 	}
 	defer delete(h.collections, op.name)
 
 	col.destroy()
+	col.clearSelectorValues()
 
 	filterID := policyfilter.PolicyID(col.policyfilterID)
 	err := h.pfState.DelPolicy(filterID)
@@ -163,6 +240,8 @@ func (h *handler) listTracingPolicies(op *tracingPolicyList) error {
 			Enabled:  col.enabled,
 			FilterId: col.policyfilterID,
 			Error:    fmt.Sprint(col.err),
+			State:    col.state(),
+			Events:   eventmetrics.PolicyEvents(name),
 		}
 
 		pol.Namespace = ""
@@ -172,6 +251,27 @@ func (h *handler) listTracingPolicies(op *tracingPolicyList) error {
 
 		for _, sens := range col.sensors {
 			pol.Sensors = append(pol.Sensors, sens.Name)
+			pol.SensorStatus = append(pol.SensorStatus, &tetragon.SensorStatus{
+				Name:          sens.Name,
+				Enabled:       sens.Loaded,
+				Collection:    col.info(),
+				AttachModes:   sens.AttachModes,
+				DeferredHooks: sens.deferredHooks(),
+				Programs:      sens.programPins(),
+				Maps:          sens.mapPins(),
+			})
+			for hook, err := range sens.FailedHooks {
+				if pol.FailedHooks == nil {
+					pol.FailedHooks = make(map[string]string)
+				}
+				pol.FailedHooks[hook] = err
+			}
+		}
+
+		if spec, err := yaml.Marshal(col.tracingpolicy.TpSpec()); err == nil {
+			pol.SpecYaml = string(spec)
+		} else {
+			logger.GetLogger().WithError(err).WithField("policy", name).Warn("failed to marshal tracing policy spec")
 		}
 
 		ret.Policies = append(ret.Policies, &pol)
@@ -181,14 +281,135 @@ func (h *handler) listTracingPolicies(op *tracingPolicyList) error {
 	return nil
 }
 
+// observableMapTypes are the types of the maps whose fill levels are
+// reported by tracingPolicyMaps.
+var observableMapTypes = map[ebpf.MapType]struct{}{
+	ebpf.Hash:       {},
+	ebpf.LRUHash:    {},
+	ebpf.PerCPUHash: {},
+	ebpf.LRUCPUHash: {},
+	ebpf.StackTrace: {},
+}
+
+func (h *handler) tracingPolicyMaps(op *tracingPolicyMaps) error {
+	for name, col := range h.collections {
+		if col.tracingpolicy == nil || !col.enabled {
+			continue
+		}
+		seen := make(map[string]struct{})
+		for _, sens := range col.sensors {
+			for _, m := range sens.Maps {
+				if m.MapHandle == nil {
+					continue
+				}
+				if _, ok := observableMapTypes[m.MapHandle.Type()]; !ok {
+					continue
+				}
+				if _, ok := seen[m.PinName]; ok {
+					continue
+				}
+				seen[m.PinName] = struct{}{}
+				clone, err := m.MapHandle.Clone()
+				if err != nil {
+					logger.GetLogger().WithError(err).WithField("map", m.PinName).Warn("failed to clone map handle")
+					continue
+				}
+				op.result = append(op.result, PolicyMap{
+					Policy:  name,
+					Name:    m.Name,
+					PinName: m.PinName,
+					Map:     clone,
+				})
+			}
+		}
+	}
+	return nil
+}
+
+func (h *handler) resizeTracingPolicyMap(op *tracingPolicyMapResize) error {
+	col, exists := h.collections[op.name]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrTracingPolicyNotFound, op.name)
+	}
+
+	found := false
+	for _, sens := range col.sensors {
+		for _, m := range sens.Maps {
+			if m.PinName == op.pinName {
+				m.SetMaxEntries(int(op.maxEntries))
+				found = true
+			}
+		}
+	}
+	if !found {
+		return fmt.Errorf("map %s not found in tracing policy %s", op.pinName, op.name)
+	}
+	return nil
+}
+
+// specWithoutSelectors returns a copy of spec without the selectors of its
+// kprobes and tracepoints.
+func specWithoutSelectors(spec *v1alpha1.TracingPolicySpec) *v1alpha1.TracingPolicySpec {
+	ret := spec.DeepCopy()
+	for i := range ret.KProbes {
+		ret.KProbes[i].Selectors = nil
+	}
+	for i := range ret.Tracepoints {
+		ret.Tracepoints[i].Selectors = nil
+	}
+	return ret
+}
+
+func tpNamespace(tp tracingpolicy.TracingPolicy) string {
+	if tpNs, ok := tp.(tracingpolicy.TracingPolicyNamespaced); ok {
+		return tpNs.TpNamespace()
+	}
+	return ""
+}
+
+func (h *handler) updateTracingPolicySelectors(op *tracingPolicyUpdateSelectors) error {
+	col, exists := h.collections[op.name]
+	if !exists || col.tracingpolicy == nil {
+		return fmt.Errorf("%w: %s", ErrTracingPolicyNotFound, op.name)
+	}
+
+	if !col.enabled {
+		return fmt.Errorf("%w: %s", ErrTracingPolicyDisabled, op.name)
+	}
+
+	if tpNamespace(col.tracingpolicy) != tpNamespace(op.tp) {
+		return fmt.Errorf("%w: %s: the namespace of the policy changed", ErrTracingPolicyNotUpdatable, op.name)
+	}
+
+	spec := op.tp.TpSpec()
+	if !reflect.DeepEqual(specWithoutSelectors(col.tracingpolicy.TpSpec()), specWithoutSelectors(spec)) {
+		return fmt.Errorf("%w: %s: only the selectors of kprobes and tracepoints can be updated", ErrTracingPolicyNotUpdatable, op.name)
+	}
+
+	// the values added at runtime are kept, if their filters still exist
+	var values []selectorValue
+	for _, v := range col.selectorValues {
+		if _, err := selectorArgFilter(spec, v.tracepoint, v.hook, v.selector, v.arg); err == nil {
+			values = append(values, v)
+		}
+	}
+	col.tracingpolicy = op.tp
+	if err := col.setSelectorValues(values); err != nil {
+		return err
+	}
+
+	h.collections[op.name] = col
+	return nil
+}
+
 func (h *handler) disableTracingPolicy(op *tracingPolicyDisable) error {
 	col, exists := h.collections[op.name]
 	if !exists {
-		return fmt.Errorf("tracing policy %s does not exist", op.name)
+		return fmt.Errorf("%w: %s", ErrTracingPolicyNotFound, op.name)
 	}
 
 	if !col.enabled {
-		return fmt.Errorf("tracing policy %s is already disabled", op.name)
+		return fmt.Errorf("%w: %s", ErrTracingPolicyDisabled, op.name)
 	}
 
 	err := col.unload()
@@ -197,6 +418,7 @@ func (h *handler) disableTracingPolicy(op *tracingPolicyDisable) error {
 		return err
 	}
 
+	col.clearSelectorValues()
 	col.enabled = false
 	h.collections[op.name] = col
 	return nil
@@ -205,11 +427,15 @@ func (h *handler) disableTracingPolicy(op *tracingPolicyDisable) error {
 func (h *handler) enableTracingPolicy(op *tracingPolicyEnable) error {
 	col, exists := h.collections[op.name]
 	if !exists {
-		return fmt.Errorf("tracing policy %s does not exist", op.name)
+		return fmt.Errorf("%w: %s", ErrTracingPolicyNotFound, op.name)
 	}
 
 	if col.enabled {
-		return fmt.Errorf("tracing policy %s is already enabled", op.name)
+		return fmt.Errorf("%w: %s", ErrTracingPolicyEnabled, op.name)
+	}
+
+	if col.nodeNotSelected {
+		return fmt.Errorf("%w: %s", ErrTracingPolicyNodeNotSelected, op.name)
 	}
 
 	if err := col.load(h.bpfDir, h.mapDir); err != nil {
@@ -285,9 +511,13 @@ func (h *handler) listSensors(op *sensorList) error {
 		colInfo := col.info()
 		for _, s := range col.sensors {
 			ret = append(ret, SensorStatus{
-				Name:       s.Name,
-				Enabled:    s.Loaded,
-				Collection: colInfo,
+				Name:          s.Name,
+				Enabled:       s.Loaded,
+				Collection:    colInfo,
+				AttachModes:   s.AttachModes,
+				DeferredHooks: s.deferredHooks(),
+				Programs:      s.programPins(),
+				Maps:          s.mapPins(),
 			})
 		}
 	}
@@ -296,16 +526,25 @@ func (h *handler) listSensors(op *sensorList) error {
 }
 
 func sensorsFromPolicyHandlers(tp tracingpolicy.TracingPolicy, filterID policyfilter.PolicyID) ([]*Sensor, error) {
+	if len(tp.TpSpec().Values) > 0 {
+		return nil, fmt.Errorf("values of policy '%s' are not resolved", tp.TpName())
+	}
+
 	var sensors []*Sensor
 	for n, s := range registeredPolicyHandlers {
 		var sensor *Sensor
 		sensor, err := s.PolicyHandler(tp, filterID)
 		if err != nil {
+			// release the resources of the sensors created so far
+			for _, sensor := range sensors {
+				sensor.Destroy()
+			}
 			return nil, fmt.Errorf("policy handler '%s' failed loading policy '%s': %w", n, tp.TpName(), err)
 		}
 		if sensor == nil {
 			continue
 		}
+		sensor.PartialLoad = tp.TpSpec().PartialLoad
 		sensors = append(sensors, sensor)
 	}
 
@@ -102,6 +102,8 @@ func (s *Sensor) Load(bpfDir, mapDir string) error {
 		return fmt.Errorf("tetragon, aborting could not load sensor BPF maps: %w", err)
 	}
 
+	s.FailedHooks = nil
+	failed := 0
 	for _, p := range s.Progs {
 		if p.LoadState.IsLoaded() {
 			l.WithField("prog", p.Name).Info("BPF prog is already loaded, incrementing reference count")
@@ -110,13 +112,32 @@ func (s *Sensor) Load(bpfDir, mapDir string) error {
 		}
 
 		if err := observerLoadInstance(bpfDir, mapDir, p); err != nil {
-			return err
+			if !s.PartialLoad {
+				return err
+			}
+			l.WithField("prog", p.Name).WithField("hook", p.Attach).WithError(err).Warn("BPF prog failed to load, skipping it")
+			if s.FailedHooks == nil {
+				s.FailedHooks = make(map[string]string)
+			}
+			s.FailedHooks[p.Attach] = err.Error()
+			failed++
+			continue
 		}
 		p.LoadState.RefInc()
 		l.WithField("prog", p.Name).WithField("label", p.Label).Debugf("BPF prog was loaded")
 	}
+	if failed > 0 && failed == len(s.Progs) {
+		return fmt.Errorf("loading sensor %s failed: all its programs failed to load", s.Name)
+	}
 	l.WithField("sensor", s.Name).Infof("Loaded BPF maps and events for sensor successfully")
 	s.Loaded = true
+
+	// NB: the sensor is loaded, so the caller unloads it if the hook fails
+	if s.PostLoadHook != nil {
+		if err := s.PostLoadHook(); err != nil {
+			return fmt.Errorf("post load hook of sensor %s failed: %w", s.Name, err)
+		}
+	}
 	return nil
 }
 
@@ -242,6 +263,10 @@ func (s *Sensor) loadMaps(mapDir string) error {
 			}
 		}
 
+		if size, ok := m.Prog.ValueSizeMap[mapSpec.Name]; ok {
+			mapSpec.ValueSize = size
+		}
+
 		if err := m.LoadOrCreatePinnedMap(pinPath, mapSpec); err != nil {
 			return fmt.Errorf("failed to load map '%s' for sensor '%s': %w", m.Name, s.Name, err)
 		}
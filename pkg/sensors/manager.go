@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/cilium/ebpf"
 	"github.com/cilium/tetragon/api/v1/tetragon"
 	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
 	"github.com/cilium/tetragon/pkg/logger"
@@ -16,9 +17,27 @@ import (
 )
 
 type SensorStatus struct {
-	Name       string
-	Enabled    bool
-	Collection string
+	Name        string
+	Enabled     bool
+	Collection  string
+	AttachModes map[string]string
+	// DeferredHooks maps the deferred kprobes of the sensor to their
+	// state, see program.DeferredState
+	DeferredHooks map[string]string
+	// Programs and Maps are the pin paths of the BPF programs and the pin
+	// names of the BPF maps of the sensor
+	Programs []string
+	Maps     []string
+}
+
+// PolicyMap is a BPF map of a loaded tracing policy.
+type PolicyMap struct {
+	Policy string
+	Name   string
+	// PinName is the pin name of the map, which identifies it
+	PinName string
+	// Map is a clone of the handle of the map, to be closed by the caller
+	Map *ebpf.Map
 }
 
 // StartSensorManager initializes the sensorCtlHandle by spawning a sensor
@@ -77,6 +96,16 @@ func startSensorManager(
 				err = handler.deleteTracingPolicy(op)
 			case *tracingPolicyList:
 				err = handler.listTracingPolicies(op)
+			case *tracingPolicyUpdateSelectors:
+				err = handler.updateTracingPolicySelectors(op)
+			case *tracingPolicyAddSelectorValues:
+				err = handler.addTracingPolicySelectorValues(op)
+			case *tracingPolicyExpireSelectorValues:
+				err = handler.expireTracingPolicySelectorValues(op)
+			case *tracingPolicyMaps:
+				err = handler.tracingPolicyMaps(op)
+			case *tracingPolicyMapResize:
+				err = handler.resizeTracingPolicyMap(op)
 			case *tracingPolicyEnable:
 				err = handler.enableTracingPolicy(op)
 			case *tracingPolicyDisable:
@@ -197,6 +226,25 @@ func (h *Manager) AddTracingPolicy(ctx context.Context, tp tracingpolicy.Tracing
 	return err
 }
 
+// UpdateTracingPolicySelectors updates the selectors of an enabled tracing
+// policy to the ones of tp, without reloading its BPF programs, so no event is
+// lost during the update. The spec of tp must only differ from the one of the
+// loaded policy by the selectors of its kprobes and tracepoints.
+func (h *Manager) UpdateTracingPolicySelectors(ctx context.Context, tp tracingpolicy.TracingPolicy) error {
+	retc := make(chan error)
+	op := &tracingPolicyUpdateSelectors{
+		ctx:     ctx,
+		name:    tp.TpName(),
+		tp:      tp,
+		retChan: retc,
+	}
+
+	h.sensorCtl <- op
+	err := <-retc
+
+	return err
+}
+
 // DeleteTracingPolicy deletes a new sensor based on a tracing policy
 func (h *Manager) DeleteTracingPolicy(ctx context.Context, name string) error {
 	retc := make(chan error)
@@ -253,6 +301,39 @@ func (h *Manager) ListTracingPolicies(ctx context.Context) (*tetragon.ListTracin
 	return op.result, err
 }
 
+// PolicyMaps returns the hash and stack trace maps of the enabled tracing
+// policies, whose fill levels can be observed. Maps of maps are not returned
+// since their inner maps are sized for the values of their selectors.
+func (h *Manager) PolicyMaps(ctx context.Context) ([]PolicyMap, error) {
+	retc := make(chan error)
+	op := &tracingPolicyMaps{
+		ctx:     ctx,
+		retChan: retc,
+	}
+
+	h.sensorCtl <- op
+	err := <-retc
+	return op.result, err
+}
+
+// ResizePolicyMap sets the maximum number of entries of the map of a tracing
+// policy with the given pin name. Loaded maps cannot be resized, so the new
+// size is applied the next time the policy is enabled.
+func (h *Manager) ResizePolicyMap(ctx context.Context, policy, pinName string, maxEntries uint32) error {
+	retc := make(chan error)
+	op := &tracingPolicyMapResize{
+		ctx:        ctx,
+		name:       policy,
+		pinName:    pinName,
+		maxEntries: maxEntries,
+		retChan:    retc,
+	}
+
+	h.sensorCtl <- op
+	err := <-retc
+	return err
+}
+
 func (h *Manager) RemoveSensor(ctx context.Context, sensorName string) error {
 	retc := make(chan error)
 	op := &sensorRemove{
@@ -353,6 +434,27 @@ type tracingPolicyList struct {
 	retChan chan error
 }
 
+type tracingPolicyUpdateSelectors struct {
+	ctx     context.Context
+	name    string
+	tp      tracingpolicy.TracingPolicy
+	retChan chan error
+}
+
+type tracingPolicyMaps struct {
+	ctx     context.Context
+	result  []PolicyMap
+	retChan chan error
+}
+
+type tracingPolicyMapResize struct {
+	ctx        context.Context
+	name       string
+	pinName    string
+	maxEntries uint32
+	retChan    chan error
+}
+
 type tracingPolicyDisable struct {
 	ctx     context.Context
 	name    string
@@ -418,16 +520,19 @@ type LoadArg struct{}
 type UnloadArg = LoadArg
 
 // trivial sensorOpDone implementations for commands
-func (s *tracingPolicyAdd) sensorOpDone(e error)     { s.retChan <- e }
-func (s *tracingPolicyDelete) sensorOpDone(e error)  { s.retChan <- e }
-func (s *tracingPolicyList) sensorOpDone(e error)    { s.retChan <- e }
-func (s *tracingPolicyEnable) sensorOpDone(e error)  { s.retChan <- e }
-func (s *tracingPolicyDisable) sensorOpDone(e error) { s.retChan <- e }
-func (s *sensorAdd) sensorOpDone(e error)            { s.retChan <- e }
-func (s *sensorRemove) sensorOpDone(e error)         { s.retChan <- e }
-func (s *sensorEnable) sensorOpDone(e error)         { s.retChan <- e }
-func (s *sensorDisable) sensorOpDone(e error)        { s.retChan <- e }
-func (s *sensorList) sensorOpDone(e error)           { s.retChan <- e }
-func (s *sensorCtlStop) sensorOpDone(e error)        { s.retChan <- e }
+func (s *tracingPolicyAdd) sensorOpDone(e error)             { s.retChan <- e }
+func (s *tracingPolicyDelete) sensorOpDone(e error)          { s.retChan <- e }
+func (s *tracingPolicyList) sensorOpDone(e error)            { s.retChan <- e }
+func (s *tracingPolicyUpdateSelectors) sensorOpDone(e error) { s.retChan <- e }
+func (s *tracingPolicyMaps) sensorOpDone(e error)            { s.retChan <- e }
+func (s *tracingPolicyMapResize) sensorOpDone(e error)       { s.retChan <- e }
+func (s *tracingPolicyEnable) sensorOpDone(e error)          { s.retChan <- e }
+func (s *tracingPolicyDisable) sensorOpDone(e error)         { s.retChan <- e }
+func (s *sensorAdd) sensorOpDone(e error)                    { s.retChan <- e }
+func (s *sensorRemove) sensorOpDone(e error)                 { s.retChan <- e }
+func (s *sensorEnable) sensorOpDone(e error)                 { s.retChan <- e }
+func (s *sensorDisable) sensorOpDone(e error)                { s.retChan <- e }
+func (s *sensorList) sensorOpDone(e error)                   { s.retChan <- e }
+func (s *sensorCtlStop) sensorOpDone(e error)                { s.retChan <- e }
 
 type sensorCtlHandle = chan<- sensorOp
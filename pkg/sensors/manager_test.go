@@ -7,11 +7,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 	"time"
 
+	"github.com/cilium/tetragon/api/v1/tetragon"
 	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
 	"github.com/cilium/tetragon/pkg/policyfilter"
+	"github.com/cilium/tetragon/pkg/reader/node"
 	"github.com/cilium/tetragon/pkg/sensors/program"
 	"github.com/cilium/tetragon/pkg/tracingpolicy"
 
@@ -26,7 +31,12 @@ type dummyHandler struct {
 }
 
 func (d *dummyHandler) PolicyHandler(_ tracingpolicy.TracingPolicy, _ policyfilter.PolicyID) (*Sensor, error) {
-	return d.s, d.e
+	if d.s == nil {
+		return nil, d.e
+	}
+	// policy handlers create a new sensor for each policy
+	s := *d.s
+	return &s, d.e
 }
 
 // TestAddPolicy tests the addition of a policy with a dummy sensor
@@ -120,10 +130,16 @@ func TestAddPolicyLoadError(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	RegisterPolicyHandlerAtInit("dummy", &dummyHandler{s: &Sensor{Name: "dummy-sensor"}})
+	destroyed := 0
+	destroyHook := func() error {
+		destroyed++
+		return nil
+	}
+	RegisterPolicyHandlerAtInit("dummy", &dummyHandler{s: &Sensor{Name: "dummy-sensor", DestroyHook: destroyHook}})
 	RegisterPolicyHandlerAtInit("load-fail", &dummyHandler{s: &Sensor{
-		Name:  "dummy-sensor",
-		Progs: []*program.Program{{Name: "bpf-program-that-does-not-exist"}},
+		Name:        "dummy-sensor",
+		Progs:       []*program.Program{{Name: "bpf-program-that-does-not-exist"}},
+		DestroyHook: destroyHook,
 	}})
 	t.Cleanup(func() {
 		delete(registeredPolicyHandlers, "dummy")
@@ -142,11 +158,61 @@ func TestAddPolicyLoadError(t *testing.T) {
 	err = mgr.AddTracingPolicy(ctx, &policy)
 	assert.NotNil(t, err)
 	t.Logf("got error (as expected): %s", err)
+	// the sensors of the policy are destroyed
+	assert.Equal(t, 2, destroyed)
 	l, err := mgr.ListSensors(ctx)
 	assert.NoError(t, err)
 	assert.Equal(t, []SensorStatus{}, *l)
 }
 
+// TestAddPolicyPartialLoad tests that the programs that fail to load are
+// skipped and reported when the policy is partially loaded
+func TestAddPolicyPartialLoad(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// the program is not a valid BPF object, so it fails to load
+	invalidProg := filepath.Join(t.TempDir(), "invalid.o")
+	require.NoError(t, os.WriteFile(invalidProg, []byte("invalid"), 0644))
+	// the program is marked as loaded, so it is not loaded again
+	loadedProg := &program.Program{Name: invalidProg, Attach: "loaded_func"}
+	loadedProg.LoadState.RefInc()
+
+	RegisterPolicyHandlerAtInit("partial-load", &dummyHandler{s: &Sensor{
+		Name: "dummy-sensor",
+		Progs: []*program.Program{
+			loadedProg,
+			{Name: invalidProg, Attach: "invalid_func", ErrorFatal: true},
+		},
+	}})
+	t.Cleanup(func() {
+		delete(registeredPolicyHandlers, "partial-load")
+	})
+
+	mgr, err := StartSensorManager("", "", nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := mgr.StopSensorManager(ctx); err != nil {
+			panic("failed to stop sensor manager")
+		}
+	})
+
+	policy := v1alpha1.TracingPolicy{}
+	policy.ObjectMeta.Name = "test-policy"
+	err = mgr.AddTracingPolicy(ctx, &policy)
+	require.Error(t, err, "policy without partialLoad")
+
+	policy.Spec.PartialLoad = true
+	err = mgr.AddTracingPolicy(ctx, &policy)
+	require.NoError(t, err)
+	res, err := mgr.ListTracingPolicies(ctx)
+	require.NoError(t, err)
+	require.Len(t, res.Policies, 1)
+	failed := res.Policies[0].FailedHooks
+	require.Len(t, failed, 1)
+	assert.Contains(t, failed, "invalid_func")
+}
+
 func TestPolicyFilterDisabled(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -189,3 +255,301 @@ func TestPolicyFilterDisabled(t *testing.T) {
 	err = mgr.AddTracingPolicy(ctx, &policy)
 	require.Error(t, err)
 }
+
+// TestListTracingPoliciesStatus tests the state and the sensors reported in
+// the status of the policies
+func TestListTracingPoliciesStatus(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	progFile := filepath.Join(t.TempDir(), "prog.o")
+	require.NoError(t, os.WriteFile(progFile, []byte("prog"), 0644))
+	prog := &program.Program{Name: progFile, Attach: "func", PinPath: "policy/prog"}
+	// the program is marked as loaded, so it is not loaded
+	prog.LoadState.RefInc()
+	RegisterPolicyHandlerAtInit("status", &dummyHandler{s: &Sensor{
+		Name:        "dummy-sensor",
+		Progs:       []*program.Program{prog},
+		AttachModes: map[string]string{"func": "kprobe"},
+	}})
+	t.Cleanup(func() {
+		delete(registeredPolicyHandlers, "status")
+	})
+
+	mgr, err := StartSensorManager("", "", nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := mgr.StopSensorManager(ctx); err != nil {
+			panic("failed to stop sensor manager")
+		}
+	})
+
+	policy := v1alpha1.TracingPolicy{}
+	policy.ObjectMeta.Name = "test-policy"
+	require.NoError(t, mgr.AddTracingPolicy(ctx, &policy))
+	res, err := mgr.ListTracingPolicies(ctx)
+	require.NoError(t, err)
+	require.Len(t, res.Policies, 1)
+	pol := res.Policies[0]
+	assert.Equal(t, tetragon.TracingPolicyState_TP_STATE_ENABLED, pol.State)
+	require.Len(t, pol.SensorStatus, 1)
+	assert.Equal(t, "dummy-sensor", pol.SensorStatus[0].Name)
+	assert.Equal(t, []string{"policy/prog"}, pol.SensorStatus[0].Programs)
+	assert.Equal(t, map[string]string{"func": "kprobe"}, pol.SensorStatus[0].AttachModes)
+
+	require.NoError(t, mgr.DisableTracingPolicy(ctx, "test-policy"))
+	res, err = mgr.ListTracingPolicies(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, tetragon.TracingPolicyState_TP_STATE_DISABLED, res.Policies[0].State)
+
+	err = mgr.DisableTracingPolicy(ctx, "test-policy")
+	assert.ErrorIs(t, err, ErrTracingPolicyDisabled)
+	err = mgr.DeleteTracingPolicy(ctx, "unknown-policy")
+	assert.ErrorIs(t, err, ErrTracingPolicyNotFound)
+}
+
+func TestSensorMapPins(t *testing.T) {
+	prog := &program.Program{PinMap: map[string]string{}}
+	s := &Sensor{Maps: []*program.Map{
+		program.MapBuilder("map1", prog),
+		program.MapBuilderPin("map2", "policy/map2", prog),
+		program.MapBuilder("map1", prog),
+	}}
+	assert.Equal(t, []string{"map1", "policy/map2"}, s.mapPins())
+	assert.Nil(t, (&Sensor{}).mapPins())
+}
+
+func TestUpdateTracingPolicySelectors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var updated []*v1alpha1.TracingPolicySpec
+	RegisterPolicyHandlerAtInit("selectors", &dummyHandler{s: &Sensor{
+		Name: "dummy-sensor",
+		SelectorsHook: func(spec *v1alpha1.TracingPolicySpec) error {
+			updated = append(updated, spec)
+			return nil
+		},
+	}})
+	t.Cleanup(func() {
+		delete(registeredPolicyHandlers, "selectors")
+	})
+
+	mgr, err := StartSensorManager("", "", nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := mgr.StopSensorManager(ctx); err != nil {
+			panic("failed to stop sensor manager")
+		}
+	})
+
+	newPolicy := func(call string, values ...string) *v1alpha1.TracingPolicy {
+		policy := &v1alpha1.TracingPolicy{}
+		policy.ObjectMeta.Name = "test-policy"
+		policy.Spec.KProbes = []v1alpha1.KProbeSpec{{
+			Call: call,
+			Args: []v1alpha1.KProbeArg{{Index: 0, Type: "string"}},
+			Selectors: []v1alpha1.KProbeSelector{{
+				MatchArgs: []v1alpha1.ArgSelector{{Index: 0, Operator: "Equal", Values: values}},
+			}},
+		}}
+		return policy
+	}
+
+	require.NoError(t, mgr.AddTracingPolicy(ctx, newPolicy("do_sys_open", "/etc/passwd")))
+
+	// only the values of the selectors changed
+	update := newPolicy("do_sys_open", "/etc/passwd", "/etc/shadow")
+	require.NoError(t, mgr.UpdateTracingPolicySelectors(ctx, update))
+	require.Len(t, updated, 1)
+	assert.Equal(t, update.TpSpec(), updated[0])
+	res, err := mgr.ListTracingPolicies(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, res.Policies[0].SpecYaml, "/etc/shadow")
+
+	// the hooked function changed
+	err = mgr.UpdateTracingPolicySelectors(ctx, newPolicy("do_sys_openat2", "/etc/passwd"))
+	assert.ErrorIs(t, err, ErrTracingPolicyNotUpdatable)
+	assert.Len(t, updated, 1)
+
+	require.NoError(t, mgr.DisableTracingPolicy(ctx, "test-policy"))
+	err = mgr.UpdateTracingPolicySelectors(ctx, update)
+	assert.ErrorIs(t, err, ErrTracingPolicyDisabled)
+
+	unknown := newPolicy("do_sys_open")
+	unknown.ObjectMeta.Name = "unknown-policy"
+	err = mgr.UpdateTracingPolicySelectors(ctx, unknown)
+	assert.ErrorIs(t, err, ErrTracingPolicyNotFound)
+}
+
+func TestAddTracingPolicySelectorValues(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var updated []*v1alpha1.TracingPolicySpec
+	RegisterPolicyHandlerAtInit("selector-values", &dummyHandler{s: &Sensor{
+		Name: "dummy-sensor",
+		SelectorsHook: func(spec *v1alpha1.TracingPolicySpec) error {
+			updated = append(updated, spec)
+			return nil
+		},
+	}})
+	t.Cleanup(func() {
+		delete(registeredPolicyHandlers, "selector-values")
+	})
+
+	mgr, err := StartSensorManager("", "", nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := mgr.StopSensorManager(ctx); err != nil {
+			panic("failed to stop sensor manager")
+		}
+	})
+
+	newPolicy := func(values ...string) *v1alpha1.TracingPolicy {
+		policy := &v1alpha1.TracingPolicy{}
+		policy.ObjectMeta.Name = "test-policy"
+		policy.Spec.KProbes = []v1alpha1.KProbeSpec{{
+			Call: "do_sys_open",
+			Args: []v1alpha1.KProbeArg{{Index: 0, Type: "string"}},
+			Selectors: []v1alpha1.KProbeSelector{{
+				MatchArgs: []v1alpha1.ArgSelector{{Index: 0, Operator: "Equal", Values: values}},
+			}},
+		}}
+		policy.Spec.Tracepoints = []v1alpha1.TracepointSpec{{
+			Subsystem: "syscalls",
+			Event:     "sys_enter_openat",
+			Args:      []v1alpha1.KProbeArg{{Index: 5, Type: "string"}},
+			Selectors: []v1alpha1.KProbeSelector{{
+				MatchArgs: []v1alpha1.ArgSelector{{Index: 5, Operator: "Equal", Values: []string{"/etc/hosts"}}},
+			}},
+		}}
+		return policy
+	}
+	lastValues := func() []string {
+		require.NotEmpty(t, updated)
+		return updated[len(updated)-1].KProbes[0].Selectors[0].MatchArgs[0].Values
+	}
+	lastTracepointValues := func() []string {
+		require.NotEmpty(t, updated)
+		return updated[len(updated)-1].Tracepoints[0].Selectors[0].MatchArgs[0].Values
+	}
+
+	require.NoError(t, mgr.AddTracingPolicy(ctx, newPolicy("/etc/passwd")))
+
+	require.NoError(t, mgr.AddTracingPolicySelectorValues(ctx, "test-policy", &SelectorValues{
+		Values: []string{"/etc/shadow"},
+		TTL:    time.Hour,
+	}))
+	require.NoError(t, mgr.AddTracingPolicySelectorValues(ctx, "test-policy", &SelectorValues{
+		Values: []string{"/etc/sudoers"},
+	}))
+	assert.Equal(t, []string{"/etc/passwd", "/etc/shadow", "/etc/sudoers"}, lastValues())
+
+	// adding a value again only resets its TTL, without updating the sensors
+	require.NoError(t, mgr.AddTracingPolicySelectorValues(ctx, "test-policy", &SelectorValues{
+		Values: []string{"/etc/shadow"},
+		TTL:    time.Hour,
+	}))
+	assert.Len(t, updated, 2)
+
+	require.NoError(t, mgr.AddTracingPolicySelectorValues(ctx, "test-policy", &SelectorValues{
+		Tracepoint: true,
+		Arg:        5,
+		Values:     []string{"/etc/resolv.conf"},
+	}))
+	assert.Equal(t, []string{"/etc/hosts", "/etc/resolv.conf"}, lastTracepointValues())
+	assert.Equal(t, []string{"/etc/passwd", "/etc/shadow", "/etc/sudoers"}, lastValues())
+
+	// the values are kept when the selectors are updated
+	require.NoError(t, mgr.UpdateTracingPolicySelectors(ctx, newPolicy("/etc/passwd", "/etc/group")))
+	assert.Equal(t, []string{"/etc/passwd", "/etc/group", "/etc/shadow", "/etc/sudoers"}, lastValues())
+
+	// only the values with a TTL expire
+	require.NoError(t, mgr.ExpireTracingPolicySelectorValues(ctx, time.Now().Add(2*time.Hour)))
+	assert.Equal(t, []string{"/etc/passwd", "/etc/group", "/etc/sudoers"}, lastValues())
+
+	err = mgr.AddTracingPolicySelectorValues(ctx, "test-policy", &SelectorValues{
+		Selector: 1,
+		Values:   []string{"/etc/shadow"},
+	})
+	assert.ErrorIs(t, err, ErrInvalidSelectorValues)
+	err = mgr.AddTracingPolicySelectorValues(ctx, "test-policy", &SelectorValues{
+		Arg:    1,
+		Values: []string{"/etc/shadow"},
+	})
+	assert.ErrorIs(t, err, ErrInvalidSelectorValues)
+	err = mgr.AddTracingPolicySelectorValues(ctx, "test-policy", &SelectorValues{
+		Tracepoint: true,
+		Hook:       1,
+		Arg:        5,
+		Values:     []string{"/etc/shadow"},
+	})
+	assert.ErrorIs(t, err, ErrInvalidSelectorValues)
+
+	require.NoError(t, mgr.DisableTracingPolicy(ctx, "test-policy"))
+	err = mgr.AddTracingPolicySelectorValues(ctx, "test-policy", &SelectorValues{Values: []string{"/etc/shadow"}})
+	assert.ErrorIs(t, err, ErrTracingPolicyDisabled)
+
+	err = mgr.AddTracingPolicySelectorValues(ctx, "unknown-policy", &SelectorValues{Values: []string{"/etc/shadow"}})
+	assert.ErrorIs(t, err, ErrTracingPolicyNotFound)
+}
+
+func TestAddPolicyNodeSelector(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	RegisterPolicyHandlerAtInit("node-selector", &dummyHandler{s: &Sensor{Name: "dummy-sensor"}})
+	t.Cleanup(func() {
+		delete(registeredPolicyHandlers, "node-selector")
+	})
+	node.SetNodeLabels(map[string]string{"gpu": "true"})
+	t.Cleanup(func() {
+		node.SetNodeLabels(nil)
+	})
+
+	mgr, err := StartSensorManager("", "", nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := mgr.StopSensorManager(ctx); err != nil {
+			panic("failed to stop sensor manager")
+		}
+	})
+
+	newPolicy := func(name string, ns *slimv1.LabelSelector) *v1alpha1.TracingPolicy {
+		policy := &v1alpha1.TracingPolicy{}
+		policy.ObjectMeta.Name = name
+		policy.Spec.NodeSelector = ns
+		return policy
+	}
+
+	require.NoError(t, mgr.AddTracingPolicy(ctx, newPolicy("gpu-policy", &slimv1.LabelSelector{
+		MatchLabels: map[string]string{"gpu": "true"},
+	})))
+	require.NoError(t, mgr.AddTracingPolicy(ctx, newPolicy("arch-policy", &slimv1.LabelSelector{
+		MatchExpressions: []slimv1.LabelSelectorRequirement{{
+			Key:      "kubernetes.io/arch",
+			Operator: slimv1.LabelSelectorOpNotIn,
+			Values:   []string{runtime.GOARCH},
+		}},
+	})))
+	err = mgr.AddTracingPolicy(ctx, newPolicy("invalid-policy", &slimv1.LabelSelector{
+		MatchExpressions: []slimv1.LabelSelectorRequirement{{Key: "gpu", Operator: "Equal"}},
+	}))
+	assert.Error(t, err)
+
+	states := make(map[string]tetragon.TracingPolicyState)
+	res, err := mgr.ListTracingPolicies(ctx)
+	require.NoError(t, err)
+	for _, pol := range res.Policies {
+		states[pol.Name] = pol.State
+	}
+	assert.Equal(t, map[string]tetragon.TracingPolicyState{
+		"gpu-policy":  tetragon.TracingPolicyState_TP_STATE_ENABLED,
+		"arch-policy": tetragon.TracingPolicyState_TP_STATE_NODE_NOT_SELECTED,
+	}, states)
+
+	err = mgr.EnableTracingPolicy(ctx, "arch-policy")
+	assert.ErrorIs(t, err, ErrTracingPolicyNodeNotSelected)
+	require.NoError(t, mgr.DeleteTracingPolicy(ctx, "arch-policy"))
+}
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package program
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cilium/tetragon/pkg/ksyms"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/option"
+	"github.com/cilium/tetragon/pkg/sensors/unloader"
+)
+
+const (
+	// DeferredPending is the state of a deferred program waiting for its
+	// symbol to be available.
+	DeferredPending = "pending"
+	// DeferredAttached is the state of a deferred program attached to its
+	// symbol.
+	DeferredAttached = "attached"
+)
+
+// DeferredPollInterval is the interval at which the kernel symbols are read
+// to find the symbols of the pending deferred programs.
+var DeferredPollInterval = 5 * time.Second
+
+// deferredUnloader is the unloader of a program whose symbol was not
+// available when the program was loaded, e.g. because it belongs to a kernel
+// module that is not loaded yet. It polls the kernel symbols, and attaches
+// the program once its symbol is available.
+type deferredUnloader struct {
+	mu     sync.Mutex
+	relink *unloader.RelinkUnloader
+	symbol string
+	// disabled is true if the program was unlinked, the program is not
+	// attached when its symbol appears.
+	disabled bool
+	stop     chan struct{}
+	stopped  bool
+}
+
+func newDeferredUnloader(relink *unloader.RelinkUnloader, symbol string) *deferredUnloader {
+	u := &deferredUnloader{
+		relink: relink,
+		symbol: symbol,
+		stop:   make(chan struct{}),
+	}
+	go u.watch()
+	return u
+}
+
+func symbolAvailable(symbol string) bool {
+	ok, err := ksyms.IsFunctionAvailable(option.Config.ProcFS, symbol)
+	if err != nil {
+		logger.GetLogger().WithError(err).WithField("symbol", symbol).Warn("Failed to read kernel symbols")
+	}
+	return ok
+}
+
+func (u *deferredUnloader) watch() {
+	ticker := time.NewTicker(DeferredPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-u.stop:
+			return
+		case <-ticker.C:
+		}
+
+		if !symbolAvailable(u.symbol) {
+			continue
+		}
+
+		u.mu.Lock()
+		if !u.stopped && !u.disabled && !u.relink.IsLinked {
+			if err := u.relink.Relink(); err != nil {
+				logger.GetLogger().WithError(err).WithField("symbol", u.symbol).Warn("Failed to attach deferred program")
+			} else {
+				logger.GetLogger().WithField("symbol", u.symbol).Info("Attached deferred program")
+			}
+		}
+		u.mu.Unlock()
+	}
+}
+
+// State returns the state of the program, DeferredPending or
+// DeferredAttached.
+func (u *deferredUnloader) State() string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.relink.IsLinked {
+		return DeferredAttached
+	}
+	return DeferredPending
+}
+
+func (u *deferredUnloader) Unload() error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if !u.stopped {
+		u.stopped = true
+		close(u.stop)
+	}
+	return u.relink.Unload()
+}
+
+// Unlink detaches the program if it is attached, and prevents it from being
+// attached while it is pending.
+func (u *deferredUnloader) Unlink() error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.disabled = true
+	if !u.relink.IsLinked {
+		return nil
+	}
+	return u.relink.Unlink()
+}
+
+// Relink attaches the program if its symbol is available, otherwise the
+// program stays pending.
+func (u *deferredUnloader) Relink() error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.disabled = false
+	if u.relink.IsLinked || !symbolAvailable(u.symbol) {
+		return nil
+	}
+	return u.relink.Relink()
+}
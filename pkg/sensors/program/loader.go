@@ -9,10 +9,12 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"unsafe"
 
 	"github.com/cilium/ebpf"
 	"github.com/cilium/ebpf/btf"
 	"github.com/cilium/ebpf/link"
+	"github.com/cilium/tetragon/pkg/bpf"
 	cachedbtf "github.com/cilium/tetragon/pkg/btf"
 	"github.com/cilium/tetragon/pkg/logger"
 	"github.com/cilium/tetragon/pkg/option"
@@ -133,6 +135,9 @@ func disableProg(coll *ebpf.CollectionSpec, name string) {
 
 func KprobeOpen(load *Program) OpenFunc {
 	return func(coll *ebpf.CollectionSpec) error {
+		if load.Fentry {
+			fentryOpen(load, coll)
+		}
 		// The generic_kprobe_override program is part of bpf_generic_kprobe.o object,
 		// so let's disable it if the override is not configured. Otherwise it gets
 		// loaded and bpftool will show it.
@@ -155,15 +160,62 @@ func KprobeOpen(load *Program) OpenFunc {
 	}
 }
 
+// fentryOpen turns the kprobe programs of the object into fentry (fexit for
+// retprobes) programs. The sections of the programs do not name the
+// function, attach all of them (including the tail calls) to the kprobe
+// function. The kprobe override program cannot be used with fentry, the
+// caller must use fmodret for the override.
+func fentryOpen(load *Program, coll *ebpf.CollectionSpec) {
+	for _, prog := range coll.Programs {
+		if prog.Type != ebpf.Kprobe {
+			continue
+		}
+		prog.Type = ebpf.Tracing
+		prog.AttachType = ebpf.AttachTraceFEntry
+		if load.RetProbe {
+			prog.AttachType = ebpf.AttachTraceFExit
+		}
+		prog.AttachTo = load.Attach
+	}
+	disableProg(coll, "generic_kprobe_override")
+}
+
+// rawTracepointOpen turns the tracepoint programs of the object into raw
+// tracepoint programs. The programs read their arguments at the context
+// offsets of their configuration, so the same object is used for both.
+func rawTracepointOpen(coll *ebpf.CollectionSpec) error {
+	for _, prog := range coll.Programs {
+		if prog.Type == ebpf.TracePoint {
+			prog.Type = ebpf.RawTracepoint
+		}
+	}
+	return nil
+}
+
 func kprobeAttach(load *Program, prog *ebpf.Program, spec *ebpf.ProgramSpec, symbol string) (unloader.Unloader, error) {
 	var linkFn func() (link.Link, error)
 
-	if load.RetProbe {
+	if load.Fentry {
+		// the function is set in the program by fentryOpen
+		linkFn = func() (link.Link, error) {
+			return link.AttachTracing(link.TracingOptions{
+				Program: prog,
+			})
+		}
+	} else if load.RetProbe {
 		linkFn = func() (link.Link, error) { return link.Kretprobe(symbol, prog, nil) }
 	} else {
 		linkFn = func() (link.Link, error) { return link.Kprobe(symbol, prog, nil) }
 	}
 
+	if load.Deferred && !symbolAvailable(symbol) {
+		logger.GetLogger().WithField("symbol", symbol).Info("Symbol not available, deferring the attachment of the program")
+		return newDeferredUnloader(&unloader.RelinkUnloader{
+			UnloadProg: unloader.PinUnloader{Prog: prog}.Unload,
+			RelinkFn:   linkFn,
+		}, symbol), nil
+	}
+
 	lnk, err := linkFn()
 	if err != nil {
 		return nil, fmt.Errorf("attaching '%s' failed: %w", spec.Name, err)
@@ -287,7 +339,11 @@ func UprobeAttach(load *Program) AttachFunc {
 			if err != nil {
 				return nil, err
 			}
-			return exec.Uprobe(data.Symbol, prog, nil)
+			opts := &link.UprobeOptions{Offset: data.Offset, RefCtrOffset: data.RefCtrOffset}
+			if data.Symbol == "" {
+				opts = &link.UprobeOptions{Address: data.Offset, RefCtrOffset: data.RefCtrOffset}
+			}
+			return exec.Uprobe(data.Symbol, prog, opts)
 		}
 
 		lnk, err := linkFn()
@@ -335,7 +391,119 @@ func TracingAttach() AttachFunc {
 	}
 }
 
-func LSMAttach() AttachFunc {
+func PerfEventAttach(load *Program) AttachFunc {
+	return func(coll *ebpf.Collection, collSpec *ebpf.CollectionSpec,
+		prog *ebpf.Program, spec *ebpf.ProgramSpec) (unloader.Unloader, error) {
+
+		data, ok := load.AttachData.(*PerfEventAttachData)
+		if !ok {
+			return nil, fmt.Errorf("attaching '%s' failed: wrong attach data", spec.Name)
+		}
+
+		attr := unix.PerfEventAttr{
+			Type:   data.Type,
+			Config: data.Config,
+			Size:   uint32(unsafe.Sizeof(unix.PerfEventAttr{})),
+			Sample: data.Sample,
+		}
+		if data.Freq {
+			attr.Bits |= unix.PerfBitFreq
+		}
+
+		// The perf events are opened on every CPU for all the processes,
+		// the program is attached to each of them.
+		perfUnloader := unloader.PerfEventUnloader{}
+		for cpu := 0; cpu < bpf.GetNumPossibleCPUs(); cpu++ {
+			fd, err := unix.PerfEventOpen(&attr, -1, cpu, -1, unix.PERF_FLAG_FD_CLOEXEC)
+			if errors.Is(err, unix.ENODEV) {
+				// offline CPU
+				continue
+			}
+			if err != nil {
+				perfUnloader.Unload()
+				return nil, fmt.Errorf("opening perf event on cpu %d failed: %w", cpu, err)
+			}
+			perfUnloader.FDs = append(perfUnloader.FDs, fd)
+			if err := unix.IoctlSetInt(fd, unix.PERF_EVENT_IOC_SET_BPF, prog.FD()); err != nil {
+				perfUnloader.Unload()
+				return nil, fmt.Errorf("attaching '%s' on cpu %d failed: %w", spec.Name, cpu, err)
+			}
+			if err := unix.IoctlSetInt(fd, unix.PERF_EVENT_IOC_ENABLE, 0); err != nil {
+				perfUnloader.Unload()
+				return nil, fmt.Errorf("enabling perf event on cpu %d failed: %w", cpu, err)
+			}
+		}
+		return unloader.ChainUnloader{
+			unloader.PinUnloader{
+				Prog: prog,
+			},
+			perfUnloader,
+		}, nil
+	}
+}
+
+func LSMOpen(load *Program) OpenFunc {
+	return func(coll *ebpf.CollectionSpec) error {
+		// The sections of the generic LSM programs do not name the hook,
+		// attach all of them (including the tail calls) to the policy hook.
+		for _, prog := range coll.Programs {
+			if prog.Type == ebpf.LSM {
+				prog.AttachTo = load.Attach
+			}
+		}
+		if !load.Override {
+			disableProg(coll, "generic_lsm_override")
+		}
+		return nil
+	}
+}
+
+func lsmAttachOverride(load *Program, bpfDir string,
+	coll *ebpf.Collection, collSpec *ebpf.CollectionSpec) error {
+
+	spec, ok := collSpec.Programs["generic_lsm_override"]
+	if !ok {
+		return fmt.Errorf("spec for generic_lsm_override program not found")
+	}
+
+	prog, ok := coll.Programs["generic_lsm_override"]
+	if !ok {
+		return fmt.Errorf("program generic_lsm_override not found")
+	}
+
+	prog, err := prog.Clone()
+	if err != nil {
+		return fmt.Errorf("failed to clone generic_lsm_override program: %w", err)
+	}
+
+	pinPath := filepath.Join(bpfDir, fmt.Sprint(load.PinPath, "-override"))
+
+	if err := prog.Pin(pinPath); err != nil {
+		return fmt.Errorf("pinning '%s' to '%s' failed: %w", load.Label, pinPath, err)
+	}
+
+	linkFn := func() (link.Link, error) {
+		return link.AttachLSM(link.LSMOptions{
+			Program: prog,
+		})
+	}
+
+	lnk, err := linkFn()
+	if err != nil {
+		return fmt.Errorf("attaching '%s' failed: %w", spec.Name, err)
+	}
+
+	load.unloaderOverride = &unloader.RelinkUnloader{
+		UnloadProg: unloader.PinUnloader{Prog: prog}.Unload,
+		IsLinked:   true,
+		Link:       lnk,
+		RelinkFn:   linkFn,
+	}
+
+	return nil
+}
+
+func LSMAttach(load *Program, bpfDir string) AttachFunc {
 	return func(coll *ebpf.Collection, collSpec *ebpf.CollectionSpec,
 		prog *ebpf.Program, spec *ebpf.ProgramSpec) (unloader.Unloader, error) {
 		linkFn := func() (link.Link, error) {
@@ -347,6 +515,14 @@ func LSMAttach() AttachFunc {
 		if err != nil {
 			return nil, fmt.Errorf("attaching '%s' failed: %w", spec.Name, err)
 		}
+		// The override program is attached after the main program, so it
+		// runs once the selectors have set the override error.
+		if load.Override {
+			if err := lsmAttachOverride(load, bpfDir, coll, collSpec); err != nil {
+				lnk.Close()
+				return nil, err
+			}
+		}
 		return &unloader.RelinkUnloader{
 			UnloadProg: unloader.PinUnloader{Prog: prog}.Unload,
 			IsLinked:   true,
@@ -442,6 +618,10 @@ func LoadTracepointProgram(bpfDir, mapDir string, load *Program, verbose int) er
 		attach: TracepointAttach(load),
 		ci:     ci,
 	}
+	if load.RawTracepoint {
+		opts.attach = RawTracepointAttach(load)
+		opts.open = rawTracepointOpen
+	}
 	return loadProgram(bpfDir, []string{mapDir}, load, opts, verbose)
 }
 
@@ -529,8 +709,32 @@ func LoadTracingProgram(bpfDir, mapDir string, load *Program, verbose int) error
 }
 
 func LoadLSMProgram(bpfDir, mapDir string, load *Program, verbose int) error {
+	var ci *customInstall
+	for mName, mPath := range load.PinMap {
+		if mName == "lsm_calls" {
+			ci = &customInstall{mPath, "lsm"}
+			break
+		}
+	}
 	opts := &loadOpts{
-		attach: LSMAttach(),
+		attach: LSMAttach(load, bpfDir),
+		open:   LSMOpen(load),
+		ci:     ci,
+	}
+	return loadProgram(bpfDir, []string{mapDir}, load, opts, verbose)
+}
+
+func LoadPerfEventProgram(bpfDir, mapDir string, load *Program, verbose int) error {
+	var ci *customInstall
+	for mName, mPath := range load.PinMap {
+		if mName == "perf_event_calls" {
+			ci = &customInstall{mPath, "perf_event"}
+			break
+		}
+	}
+	opts := &loadOpts{
+		attach: PerfEventAttach(load),
+		ci:     ci,
 	}
 	return loadProgram(bpfDir, []string{mapDir}, load, opts, verbose)
 }
@@ -662,6 +866,10 @@ func doLoadProgram(
 			}
 			ms.InnerMap.MaxEntries = innerMax
 		}
+
+		if size, ok := load.ValueSizeMap[ms.Name]; ok {
+			ms.ValueSize = size
+		}
 	}
 
 	// Find all the maps referenced by the program, so we'll rewrite only
@@ -178,3 +178,7 @@ func (m *Map) SetMaxEntries(max int) {
 func (m *Map) SetInnerMaxEntries(max int) {
 	m.Prog.MaxEntriesInnerMap[m.Name] = uint32(max)
 }
+
+func (m *Map) SetValueSize(size int) {
+	m.Prog.ValueSizeMap[m.Name] = uint32(size)
+}
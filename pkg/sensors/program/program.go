@@ -29,6 +29,7 @@ func Builder(
 		PinMap:             make(map[string]string),
 		MaxEntriesMap:      make(map[string]uint32),
 		MaxEntriesInnerMap: make(map[string]uint32),
+		ValueSizeMap:       make(map[string]uint32),
 	}
 }
 
@@ -51,6 +52,22 @@ type MultiKprobeAttachData struct {
 type UprobeAttachData struct {
 	Path   string
 	Symbol string
+	// Offset is relative to Symbol, or to the start of the file if Symbol
+	// is empty.
+	Offset uint64
+	// RefCtrOffset is the file offset of the reference counter (USDT
+	// semaphore) incremented while the uprobe is attached.
+	RefCtrOffset uint64
+}
+
+type PerfEventAttachData struct {
+	// Type and Config are the type and config of the perf event
+	// attributes, e.g. PERF_TYPE_SOFTWARE and PERF_COUNT_SW_PAGE_FAULTS.
+	Type   uint32
+	Config uint64
+	// Sample is the sample period, or the sample frequency if Freq is set.
+	Sample uint64
+	Freq   bool
 }
 
 // Program reprents a BPF program.
@@ -77,6 +94,19 @@ type Program struct {
 	Override        bool
 	OverrideFmodRet bool
 
+	// Fentry indicates whether a kprobe is attached with fentry (fexit
+	// for retprobes) instead of kprobe.
+	Fentry bool
+
+	// RawTracepoint indicates whether a tracepoint is attached as a raw
+	// tracepoint instead of a tracepoint.
+	RawTracepoint bool
+
+	// Deferred indicates whether the attachment of a kprobe is deferred
+	// until its symbol is available, if the symbol is not available when
+	// the program is loaded.
+	Deferred bool
+
 	// Type is the type of BPF program. For example, tc, skb, tracepoint,
 	// etc.
 	Type      string
@@ -101,6 +131,7 @@ type Program struct {
 
 	MaxEntriesMap      map[string]uint32
 	MaxEntriesInnerMap map[string]uint32
+	ValueSizeMap       map[string]uint32
 }
 
 func (p *Program) SetRetProbe(ret bool) *Program {
@@ -108,6 +139,21 @@ func (p *Program) SetRetProbe(ret bool) *Program {
 	return p
 }
 
+func (p *Program) SetFentry(fentry bool) *Program {
+	p.Fentry = fentry
+	return p
+}
+
+func (p *Program) SetRawTracepoint(raw bool) *Program {
+	p.RawTracepoint = raw
+	return p
+}
+
+func (p *Program) SetDeferred(deferred bool) *Program {
+	p.Deferred = deferred
+	return p
+}
+
 func (p *Program) SetLoaderData(d interface{}) *Program {
 	p.LoaderData = d
 	return p
@@ -143,6 +189,20 @@ func (p *Program) Unlink() error {
 	return ul.Unlink()
 }
 
+// DeferredState returns the state of a deferred program: DeferredPending or
+// DeferredAttached. It returns an empty string if the program is not deferred
+// or not loaded.
+func (p *Program) DeferredState() string {
+	if !p.Deferred || p.unloader == nil {
+		return ""
+	}
+	if du, ok := p.unloader.(*deferredUnloader); ok {
+		return du.State()
+	}
+	// the symbol was available when the program was loaded
+	return DeferredAttached
+}
+
 func (p *Program) Relink() error {
 	rl, ok := p.unloader.(interface{ Relink() error })
 	if !ok {
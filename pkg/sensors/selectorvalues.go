@@ -0,0 +1,276 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package sensors
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/metrics/selectormetrics"
+)
+
+// selectorValuesGCInterval is the interval at which the expired selector
+// values are removed. The values that expire during an interval are removed
+// together, with a single update of the selectors of their policy.
+const selectorValuesGCInterval = 5 * time.Second
+
+// SelectorValues are values added at runtime to a matchArgs filter of a
+// kprobe or of a tracepoint of a tracing policy.
+type SelectorValues struct {
+	// Tracepoint is true if the values are added to a tracepoint of the
+	// policy, and false if they are added to a kprobe.
+	Tracepoint bool
+	// Hook is the index of the kprobe in the kprobes of the policy, or of
+	// the tracepoint in its tracepoints.
+	Hook int
+	// Selector is the index of the selector in the selectors of the kprobe.
+	Selector int
+	// Arg is the argument index of the matchArgs filter.
+	Arg uint32
+	// Values are the values to add to the filter.
+	Values []string
+	// TTL is the time after which the values are removed, or zero to keep
+	// them until the policy is disabled.
+	TTL time.Duration
+}
+
+// selectorValue is a value added at runtime to a matchArgs filter.
+type selectorValue struct {
+	tracepoint bool
+	hook       int
+	selector   int
+	arg        uint32
+	value      string
+	// expires is the time at which the value is removed, zero if never
+	expires time.Time
+}
+
+func (v *selectorValue) sameFilterValue(o *selectorValue) bool {
+	return v.tracepoint == o.tracepoint && v.hook == o.hook && v.selector == o.selector && v.arg == o.arg && v.value == o.value
+}
+
+// selectorArgFilter returns the matchArgs filter of the arg argument index of
+// a selector of a kprobe, or of a tracepoint, of spec.
+func selectorArgFilter(spec *v1alpha1.TracingPolicySpec, tracepoint bool, hook, selector int, arg uint32) (*v1alpha1.ArgSelector, error) {
+	var name string
+	var sels []v1alpha1.KProbeSelector
+	if tracepoint {
+		if hook < 0 || hook >= len(spec.Tracepoints) {
+			return nil, fmt.Errorf("%w: no tracepoint %d", ErrInvalidSelectorValues, hook)
+		}
+		tp := &spec.Tracepoints[hook]
+		name, sels = "tracepoint "+tp.Subsystem+"/"+tp.Event, tp.Selectors
+	} else {
+		if hook < 0 || hook >= len(spec.KProbes) {
+			return nil, fmt.Errorf("%w: no kprobe %d", ErrInvalidSelectorValues, hook)
+		}
+		kp := &spec.KProbes[hook]
+		name, sels = "kprobe "+kp.Call, kp.Selectors
+	}
+	if selector < 0 || selector >= len(sels) {
+		return nil, fmt.Errorf("%w: no selector %d in %s", ErrInvalidSelectorValues, selector, name)
+	}
+	sel := &sels[selector]
+	for i := range sel.MatchArgs {
+		if sel.MatchArgs[i].Index == arg {
+			return &sel.MatchArgs[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: no matchArgs filter of argument %d in selector %d of %s",
+		ErrInvalidSelectorValues, arg, selector, name)
+}
+
+// specWithSelectorValues returns a copy of spec with the values added to their
+// matchArgs filters. The values whose filters do not exist are skipped.
+func specWithSelectorValues(spec *v1alpha1.TracingPolicySpec, values []selectorValue) *v1alpha1.TracingPolicySpec {
+	ret := spec.DeepCopy()
+	for i := range values {
+		v := &values[i]
+		filter, err := selectorArgFilter(ret, v.tracepoint, v.hook, v.selector, v.arg)
+		if err != nil {
+			continue
+		}
+		filter.Values = append(filter.Values, v.value)
+	}
+	return ret
+}
+
+// setSelectorValues updates the selectors of the sensors of the collection to
+// the ones of its tracing policy with the values. The sensors only write the
+// maps of the filters that changed.
+func (col *collection) setSelectorValues(values []selectorValue) error {
+	spec := specWithSelectorValues(col.tracingpolicy.TpSpec(), values)
+	for _, sens := range col.sensors {
+		if sens.SelectorsHook == nil {
+			continue
+		}
+		if err := sens.SelectorsHook(spec); err != nil {
+			return fmt.Errorf("failed to update the selectors of sensor %s: %w", sens.Name, err)
+		}
+	}
+	col.selectorValues = values
+	selectormetrics.RuntimeValues.WithLabelValues(col.name).Set(float64(len(values)))
+	return nil
+}
+
+func (h *handler) addTracingPolicySelectorValues(op *tracingPolicyAddSelectorValues) error {
+	col, exists := h.collections[op.name]
+	if !exists || col.tracingpolicy == nil {
+		return fmt.Errorf("%w: %s", ErrTracingPolicyNotFound, op.name)
+	}
+
+	if !col.enabled {
+		return fmt.Errorf("%w: %s", ErrTracingPolicyDisabled, op.name)
+	}
+
+	if len(op.values.Values) == 0 {
+		return fmt.Errorf("%w: no values", ErrInvalidSelectorValues)
+	}
+	if _, err := selectorArgFilter(col.tracingpolicy.TpSpec(), op.values.Tracepoint, op.values.Hook, op.values.Selector, op.values.Arg); err != nil {
+		return err
+	}
+
+	var expires time.Time
+	if op.values.TTL > 0 {
+		expires = op.now.Add(op.values.TTL)
+	}
+	values := append([]selectorValue{}, col.selectorValues...)
+	added := false
+	for _, val := range op.values.Values {
+		v := selectorValue{
+			tracepoint: op.values.Tracepoint,
+			hook:       op.values.Hook,
+			selector:   op.values.Selector,
+			arg:        op.values.Arg,
+			value:      val,
+			expires:    expires,
+		}
+		found := false
+		for i := range values {
+			if values[i].sameFilterValue(&v) {
+				// adding a value again resets its TTL
+				values[i].expires = expires
+				found = true
+				break
+			}
+		}
+		if !found {
+			values = append(values, v)
+			added = true
+		}
+	}
+
+	if !added {
+		// the filters do not change, only the TTLs of the values
+		col.selectorValues = values
+	} else if err := col.setSelectorValues(values); err != nil {
+		return err
+	}
+	h.collections[op.name] = col
+	return nil
+}
+
+func (h *handler) expireTracingPolicySelectorValues(op *tracingPolicyExpireSelectorValues) error {
+	for name, col := range h.collections {
+		if !col.enabled || len(col.selectorValues) == 0 {
+			continue
+		}
+		values := make([]selectorValue, 0, len(col.selectorValues))
+		for _, v := range col.selectorValues {
+			if v.expires.IsZero() || op.now.Before(v.expires) {
+				values = append(values, v)
+			}
+		}
+		expired := len(col.selectorValues) - len(values)
+		if expired == 0 {
+			continue
+		}
+		if err := col.setSelectorValues(values); err != nil {
+			logger.GetLogger().WithError(err).WithField("policy", name).
+				Warn("Failed to remove expired selector values")
+			continue
+		}
+		selectormetrics.ExpiredValues.WithLabelValues(name).Add(float64(expired))
+		h.collections[name] = col
+	}
+	return nil
+}
+
+// clearSelectorValues forgets the selector values of the collection, once its
+// sensors are unloaded.
+func (col *collection) clearSelectorValues() {
+	col.selectorValues = nil
+	selectormetrics.RuntimeValues.DeleteLabelValues(col.name)
+}
+
+// AddTracingPolicySelectorValues adds values to a matchArgs filter of an
+// enabled tracing policy in place, without reloading its BPF programs. The
+// values are removed once their TTL expires, or when the policy is disabled.
+func (h *Manager) AddTracingPolicySelectorValues(ctx context.Context, name string, values *SelectorValues) error {
+	retc := make(chan error)
+	op := &tracingPolicyAddSelectorValues{
+		ctx:     ctx,
+		name:    name,
+		values:  values,
+		now:     time.Now(),
+		retChan: retc,
+	}
+
+	h.sensorCtl <- op
+	err := <-retc
+
+	return err
+}
+
+// ExpireTracingPolicySelectorValues removes the selector values whose TTL
+// expired at now.
+func (h *Manager) ExpireTracingPolicySelectorValues(ctx context.Context, now time.Time) error {
+	retc := make(chan error)
+	op := &tracingPolicyExpireSelectorValues{
+		ctx:     ctx,
+		now:     now,
+		retChan: retc,
+	}
+
+	h.sensorCtl <- op
+	err := <-retc
+
+	return err
+}
+
+// RunSelectorValuesGC removes the expired selector values until ctx is done.
+func (h *Manager) RunSelectorValuesGC(ctx context.Context) {
+	ticker := time.NewTicker(selectorValuesGCInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := h.ExpireTracingPolicySelectorValues(ctx, now); err != nil {
+				logger.GetLogger().WithError(err).Warn("Failed to remove expired selector values")
+			}
+		}
+	}
+}
+
+type tracingPolicyAddSelectorValues struct {
+	ctx     context.Context
+	name    string
+	values  *SelectorValues
+	now     time.Time
+	retChan chan error
+}
+
+type tracingPolicyExpireSelectorValues struct {
+	ctx     context.Context
+	now     time.Time
+	retChan chan error
+}
+
+func (s *tracingPolicyAddSelectorValues) sensorOpDone(e error)    { s.retChan <- e }
+func (s *tracingPolicyExpireSelectorValues) sensorOpDone(e error) { s.retChan <- e }
@@ -6,6 +6,7 @@ package sensors
 import (
 	"fmt"
 
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
 	"github.com/cilium/tetragon/pkg/logger"
 	"github.com/cilium/tetragon/pkg/policyfilter"
 	"github.com/cilium/tetragon/pkg/sensors/program"
@@ -43,6 +44,10 @@ type Sensor struct {
 	Loaded bool
 	// Destroyed indicates whether the sensor had been destroyed.
 	Destroyed bool
+	// PostLoadHook can optionally contain a pointer to a function to be
+	// called during sensor loading, after the programs and maps being
+	// loaded.
+	PostLoadHook SensorHook
 	// PreUnloadHook can optionally contain a pointer to a function to be
 	// called during sensor unloading, prior to the programs and maps being
 	// unloaded.
@@ -55,12 +60,73 @@ type Sensor struct {
 	// when removing the sensor, sensor cannot be loaded again after this hook
 	// being triggered and must be recreated.
 	DestroyHook SensorHook
+	// AttachModes optionally maps the functions hooked by the sensor to how
+	// they are attached (e.g., kprobe or fentry).
+	AttachModes map[string]string
+	// PartialLoad indicates whether the programs of the sensor that fail
+	// to load are skipped instead of failing the load of the sensor.
+	PartialLoad bool
+	// FailedHooks maps the hooks of the programs skipped when loading the
+	// sensor with PartialLoad to their error.
+	FailedHooks map[string]string
+	// SelectorsHook can optionally contain a pointer to a function that
+	// updates the selectors of the loaded sensor to the ones of the given
+	// tracing policy spec, without reloading its programs.
+	SelectorsHook SelectorsHook
+}
+
+// deferredHooks returns the state of the deferred programs of the sensor,
+// by hook. A hook is pending if one of its programs is pending.
+func (s *Sensor) deferredHooks() map[string]string {
+	var ret map[string]string
+	for _, p := range s.Progs {
+		state := p.DeferredState()
+		if state == "" {
+			continue
+		}
+		if ret == nil {
+			ret = make(map[string]string)
+		}
+		if ret[p.Attach] != program.DeferredPending {
+			ret[p.Attach] = state
+		}
+	}
+	return ret
+}
+
+// programPins returns the pin paths of the programs of the sensor.
+func (s *Sensor) programPins() []string {
+	var ret []string
+	for _, p := range s.Progs {
+		ret = append(ret, p.PinPath)
+	}
+	return ret
+}
+
+// mapPins returns the pin names of the maps of the sensor. Maps shared by
+// several programs are reported once.
+func (s *Sensor) mapPins() []string {
+	var ret []string
+	seen := make(map[string]struct{}, len(s.Maps))
+	for _, m := range s.Maps {
+		if _, ok := seen[m.PinName]; ok {
+			continue
+		}
+		seen[m.PinName] = struct{}{}
+		ret = append(ret, m.PinName)
+	}
+	return ret
 }
 
 // SensorHook is the function signature for an optional function
 // that can be called during sensor unloading and removing.
 type SensorHook func() error
 
+// SelectorsHook is the function signature of the function that updates the
+// selectors of a loaded sensor. The spec only differs from the one of the
+// sensor by its selectors.
+type SelectorsHook func(spec *v1alpha1.TracingPolicySpec) error
+
 func SensorCombine(name string, sensors ...*Sensor) *Sensor {
 	progs := []*program.Program{}
 	maps := []*program.Map{}
@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package tracing
+
+import (
+	"time"
+
+	"github.com/cilium/tetragon/pkg/metrics/enforcementmetrics"
+	"github.com/cilium/tetragon/pkg/selectors"
+)
+
+// actionLatency returns the time in nanoseconds between the hit of a hook at
+// hookKtime and the completion of its enforcement action at actionKtime, zero
+// if there was none, and records it in the enforcement latency metric.
+func actionLatency(policy string, action, hookKtime, actionKtime uint64) uint64 {
+	if actionKtime == 0 || actionKtime < hookKtime {
+		return 0
+	}
+	latency := actionKtime - hookKtime
+	enforcementmetrics.Observe(policy, selectors.ActionTypeString(uint32(action)), time.Duration(latency))
+	return latency
+}
+
+// auditAction returns the enforcement action that a hook of a policy in audit
+// mode did not take, zero if none, and records it in the audit metric.
+func auditAction(policy string, action uint32) uint32 {
+	if action != 0 {
+		enforcementmetrics.ObserveAudit(policy, selectors.ActionTypeString(action))
+	}
+	return action
+}
@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package tracing
+
+import (
+	"testing"
+
+	"github.com/cilium/tetragon/pkg/metrics/enforcementmetrics"
+	"github.com/cilium/tetragon/pkg/selectors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActionLatency(t *testing.T) {
+	enforcementmetrics.Latency.Reset()
+
+	// no enforcement action
+	assert.Zero(t, actionLatency("policy", selectors.ActionTypePost, 1000, 0))
+	assert.Zero(t, actionLatency("policy", selectors.ActionTypeSigKill, 1000, 500))
+	assert.Zero(t, testutil.CollectAndCount(enforcementmetrics.Latency))
+
+	assert.Equal(t, uint64(2500), actionLatency("policy", selectors.ActionTypeSigKill, 1000, 3500))
+	assert.Equal(t, uint64(100), actionLatency("policy", selectors.ActionTypeOverride, 1000, 1100))
+	assert.Equal(t, 2, testutil.CollectAndCount(enforcementmetrics.Latency))
+}
+
+func TestAuditAction(t *testing.T) {
+	enforcementmetrics.AuditActions.Reset()
+
+	assert.Zero(t, auditAction("policy", 0))
+	assert.Zero(t, testutil.CollectAndCount(enforcementmetrics.AuditActions))
+
+	assert.Equal(t, uint32(selectors.ActionTypeSigKill), auditAction("policy", selectors.ActionTypeSigKill))
+	assert.Equal(t, uint32(selectors.ActionTypeSigKill), auditAction("policy", selectors.ActionTypeSigKill))
+	assert.Equal(t, float64(2), testutil.ToFloat64(enforcementmetrics.AuditActions.WithLabelValues("policy", "sigkill")))
+}
@@ -3,7 +3,15 @@
 package tracing
 
 import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+
 	"github.com/cilium/ebpf"
+	"github.com/cilium/tetragon/pkg/bpf"
+	"github.com/cilium/tetragon/pkg/selectors"
+	"github.com/cilium/tetragon/pkg/sensors/base"
 )
 
 type BinaryMapKey struct {
@@ -14,6 +22,11 @@ type BinaryMapValue struct {
 	Id uint32
 }
 
+type BinaryPrefixMapKey struct {
+	PrefixLen uint32
+	PathName  [256]byte
+}
+
 func writeBinaryMap(m *ebpf.Map, id uint32, path string) error {
 	p := [256]byte{0}
 	copy(p[:], path)
@@ -26,3 +39,118 @@ func writeBinaryMap(m *ebpf.Map, id uint32, path string) error {
 	}
 	return m.Update(k, v, ebpf.UpdateAny)
 }
+
+func binaryPrefixMapKey(prefix string) BinaryPrefixMapKey {
+	k := BinaryPrefixMapKey{
+		PrefixLen: uint32(len(prefix) * 8), // prefix is in bits
+	}
+	copy(k.PathName[:], prefix)
+	return k
+}
+
+// binaryPrefixMu serializes the updates of the names_prefix_map, so that the
+// last update writes the latest prefixes.
+var binaryPrefixMu sync.Mutex
+
+// writeBinaryPrefixMaps updates the names_prefix_map and the
+// names_prefix_parent_map of the base sensor if the selectors have
+// matchBinaries prefixes.
+func writeBinaryPrefixMaps(mapDir string, k *selectors.KernelSelectorState) error {
+	if len(k.GetNewBinaryPrefixMappings()) == 0 {
+		return nil
+	}
+	return syncBinaryPrefixMaps(mapDir)
+}
+
+// releaseBinaryPrefixes releases the matchBinaries prefixes of selectors that
+// are not used anymore, and removes them from the names_prefix_map.
+func releaseBinaryPrefixes(k *selectors.KernelSelectorState) error {
+	if k == nil || !k.ReleaseBinaryPrefixes() {
+		return nil
+	}
+	return syncBinaryPrefixMaps(bpf.MapPrefixPath())
+}
+
+// releaseSelectorsBinaryPrefixes releases the matchBinaries prefixes of states.
+func releaseSelectorsBinaryPrefixes(states []*selectors.KernelSelectorState) error {
+	var errs error
+	for _, k := range states {
+		errs = errors.Join(errs, releaseBinaryPrefixes(k))
+	}
+	return errs
+}
+
+// syncBinaryPrefixMaps writes the prefixes of the selectors in use to the
+// names_prefix_map, and their parents to the names_prefix_parent_map, and
+// removes the stale entries. The parents are written first, so that the
+// processes matching a new prefix also match the shorter ones.
+func syncBinaryPrefixMaps(mapDir string) error {
+	binaryPrefixMu.Lock()
+	defer binaryPrefixMu.Unlock()
+
+	prefixes, parents := selectors.BinaryPrefixMaps()
+
+	trie, err := ebpf.LoadPinnedMap(filepath.Join(mapDir, base.NamesPrefixMap.Name), nil)
+	if err != nil {
+		return err
+	}
+	defer trie.Close()
+	parentMap, err := ebpf.LoadPinnedMap(filepath.Join(mapDir, base.NamesPrefixParentMap.Name), nil)
+	if err != nil {
+		return err
+	}
+	defer parentMap.Close()
+
+	for id, parent := range parents {
+		if err := parentMap.Update(id, parent, ebpf.UpdateAny); err != nil {
+			return fmt.Errorf("failed to update %s: %w", base.NamesPrefixParentMap.Name, err)
+		}
+	}
+
+	ids := make(map[BinaryPrefixMapKey]uint32, len(prefixes))
+	for id, prefix := range prefixes {
+		ids[binaryPrefixMapKey(prefix)] = id
+	}
+	var (
+		key   BinaryPrefixMapKey
+		id    uint32
+		stale []BinaryPrefixMapKey
+	)
+	iter := trie.Iterate()
+	for iter.Next(&key, &id) {
+		if wantID, ok := ids[key]; !ok || wantID != id {
+			stale = append(stale, key)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("failed to iterate %s: %w", base.NamesPrefixMap.Name, err)
+	}
+	for i := range stale {
+		if err := trie.Delete(&stale[i]); err != nil && !errors.Is(err, ebpf.ErrKeyNotExist) {
+			return fmt.Errorf("failed to delete from %s: %w", base.NamesPrefixMap.Name, err)
+		}
+	}
+	for key, id := range ids {
+		if err := trie.Update(&key, &BinaryMapValue{Id: id}, ebpf.UpdateAny); err != nil {
+			return fmt.Errorf("failed to update %s: %w", base.NamesPrefixMap.Name, err)
+		}
+	}
+
+	var staleIDs []uint32
+	var parent uint32
+	iter = parentMap.Iterate()
+	for iter.Next(&id, &parent) {
+		if _, ok := parents[id]; !ok {
+			staleIDs = append(staleIDs, id)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("failed to iterate %s: %w", base.NamesPrefixParentMap.Name, err)
+	}
+	for _, id := range staleIDs {
+		if err := parentMap.Delete(id); err != nil && !errors.Is(err, ebpf.ErrKeyNotExist) {
+			return fmt.Errorf("failed to delete from %s: %w", base.NamesPrefixParentMap.Name, err)
+		}
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ import (
 	"strings"
 
 	"github.com/cilium/ebpf"
+	ebtf "github.com/cilium/ebpf/btf"
 	"github.com/cilium/tetragon/pkg/api/dataapi"
 	"github.com/cilium/tetragon/pkg/api/ops"
 	"github.com/cilium/tetragon/pkg/api/processapi"
@@ -27,10 +28,13 @@ import (
 	"github.com/cilium/tetragon/pkg/btf"
 	cachedbtf "github.com/cilium/tetragon/pkg/btf"
 	"github.com/cilium/tetragon/pkg/eventhandler"
+	"github.com/cilium/tetragon/pkg/features"
+	"github.com/cilium/tetragon/pkg/ftrace"
 	"github.com/cilium/tetragon/pkg/grpc/tracing"
 	"github.com/cilium/tetragon/pkg/idtable"
 	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
 	"github.com/cilium/tetragon/pkg/kernels"
+	"github.com/cilium/tetragon/pkg/ksyms"
 	"github.com/cilium/tetragon/pkg/logger"
 	"github.com/cilium/tetragon/pkg/metrics/kprobemetrics"
 	"github.com/cilium/tetragon/pkg/observer"
@@ -44,6 +48,7 @@ import (
 	"github.com/cilium/tetragon/pkg/strutils"
 	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
 
 	gt "github.com/cilium/tetragon/pkg/generictypes"
 )
@@ -58,6 +63,7 @@ func init() {
 	}
 	sensors.RegisterProbeType("generic_kprobe", kprobe)
 	observer.RegisterEventHandlerAtInit(ops.MSG_OP_GENERIC_KPROBE, handleGenericKprobe)
+	kprobemetrics.SetLatencySource(kprobeLatencyHistograms)
 }
 
 const (
@@ -129,10 +135,25 @@ type genericKprobe struct {
 	// is there override defined for the kprobe
 	hasOverride bool
 
+	// is there a count action defined for the kprobe
+	hasCount bool
+
+	// attachMode is how the kprobe is attached: kprobe, kprobe_multi or
+	// fentry
+	attachMode string
+
 	// reference to a stack trace map, must be closed when unloading the kprobe,
 	// this is done in the sensor PostUnloadHook
 	stackTraceMapRef *ebpf.Map
 
+	// reference to the latency histogram map, closed like the stack trace
+	// map
+	latencyHistMapRef *ebpf.Map
+
+	// reference to the map of the calls counted by the count action, closed
+	// like the stack trace map
+	countMapRef *ebpf.Map
+
 	customHandler eventhandler.Handler
 }
 
@@ -173,6 +194,9 @@ var (
 const (
 	argReturnCopyBit = 1 << 4
 	argMaxDataBit    = 1 << 5
+
+	argMaxDataSizeShift = 8
+	argMaxDataSizeMax   = 1<<(32-argMaxDataSizeShift) - 1
 )
 
 func argReturnCopy(meta int) bool {
@@ -185,6 +209,7 @@ func argReturnCopy(meta int) bool {
 //	0-3 : SizeArgIndex
 //	  4 : ReturnCopy
 //	  5 : MaxData
+//	8-31 : MaxDataSize
 func getMetaValue(arg *v1alpha1.KProbeArg) (int, error) {
 	var meta int
 
@@ -200,14 +225,30 @@ func getMetaValue(arg *v1alpha1.KProbeArg) (int, error) {
 	if arg.MaxData {
 		meta = meta | argMaxDataBit
 	}
+	if arg.MaxDataSize > 0 {
+		if arg.MaxDataSize > argMaxDataSizeMax {
+			return 0, fmt.Errorf("invalid MaxDataSize value (>%d): %v", argMaxDataSizeMax, arg.MaxDataSize)
+		}
+		meta = meta | int(arg.MaxDataSize)<<argMaxDataSizeShift
+	}
 	return meta, nil
 }
 
+// isBufferType returns true if the arguments of type ty are user buffers,
+// which can be truncated with maxDataSize.
+func isBufferType(ty int) bool {
+	switch ty {
+	case gt.GenericCharBuffer, gt.GenericCharIovec, gt.GenericIovIter:
+		return true
+	}
+	return false
+}
+
 func multiKprobePinPath(sensorPath string) string {
 	return sensors.PathJoin(sensorPath, "multi_kprobe")
 }
 
-func createMultiKprobeSensor(sensorPath string, multiIDs, multiRetIDs []idtable.EntryID) ([]*program.Program, []*program.Map) {
+func createMultiKprobeSensor(sensorPath string, multiIDs, multiRetIDs []idtable.EntryID, options *kprobeOptions) ([]*program.Program, []*program.Map) {
 	var progs []*program.Program
 	var maps []*program.Map
 
@@ -285,8 +326,17 @@ func createMultiKprobeSensor(sensorPath string, multiIDs, multiRetIDs []idtable.
 	maps = append(maps, selNamesMap)
 
 	stackTraceMap := program.MapBuilderPin("stack_trace_map", sensors.PathJoin(pinPath, "stack_trace_map"), load)
+	if option.Config.StackTraceMapSize > 0 {
+		stackTraceMap.SetMaxEntries(option.Config.StackTraceMapSize)
+	}
 	maps = append(maps, stackTraceMap)
 
+	countMap := program.MapBuilderPin("count_map", sensors.PathJoin(pinPath, "count_map"), load)
+	if !kprobesHaveCount(multiIDs) {
+		countMap.SetMaxEntries(1)
+	}
+	maps = append(maps, countMap)
+
 	if kernels.EnableLargeProgs() {
 		socktrack := program.MapBuilderPin("socktrack_map", sensors.PathJoin(sensorPath, "socktrack_map"), load)
 		maps = append(maps, socktrack)
@@ -321,17 +371,152 @@ func createMultiKprobeSensor(sensorPath string, multiIDs, multiRetIDs []idtable.
 		socktrack := program.MapBuilderPin("socktrack_map", sensors.PathJoin(sensorPath, "socktrack_map"), loadret)
 		maps = append(maps, socktrack)
 
+		latencyHist := program.MapBuilderPin("latency_hist_map", sensors.PathJoin(pinPath, "latency_hist_map"), loadret)
+		maps = append(maps, latencyHist)
+
 		retConfigMap.SetMaxEntries(len(multiRetIDs))
+		latencyHist.SetMaxEntries(latencyHistEntries * len(multiRetIDs))
 	}
 
+	options.sizeMaps(maps...)
 	return progs, maps
 }
 
-// preValidateKprobes pre-validates the semantics and BTF information of a Kprobe spec
-//
-// Pre validate the kprobe semantics and BTF information in order to separate
-// the kprobe errors from BPF related ones.
-func preValidateKprobes(name string, kprobes []v1alpha1.KProbeSpec, lists []v1alpha1.ListSpec) error {
+// validateFdActions checks that the argFd and argName of the FollowFD,
+// UnfollowFD and CopyFD actions refer to arguments of the call that the BPF
+// side can use: file descriptors need to be integers (or the fd type, which
+// starts with the file descriptor), and the FollowFD names need to be files or
+// paths.
+func validateFdActions(f *v1alpha1.KProbeSpec) error {
+	argType := func(idx uint32) (int, bool) {
+		for _, a := range f.Args {
+			if a.Index == idx {
+				return gt.GenericTypeFromString(a.Type), true
+			}
+		}
+		return gt.GenericInvalidType, false
+	}
+	checkFd := func(action, field string, idx uint32) error {
+		ty, ok := argType(idx)
+		if !ok {
+			return fmt.Errorf("%s action: %s %d does not refer to an argument of '%s'", action, field, idx, f.Call)
+		}
+		switch ty {
+		case gt.GenericIntType, gt.GenericS32Type, gt.GenericU32Type,
+			gt.GenericS64Type, gt.GenericU64Type, gt.GenericFdType:
+			return nil
+		}
+		return fmt.Errorf("%s action: %s %d must be an integer or fd argument", action, field, idx)
+	}
+
+	for _, sel := range f.Selectors {
+		for _, act := range sel.MatchActions {
+			switch selectors.ActionTypeFromString(act.Action) {
+			case selectors.ActionTypeFollowFd:
+				if err := checkFd(act.Action, "argFd", act.ArgFd); err != nil {
+					return err
+				}
+				ty, ok := argType(act.ArgName)
+				if !ok {
+					return fmt.Errorf("%s action: argName %d does not refer to an argument of '%s'", act.Action, act.ArgName, f.Call)
+				}
+				if ty != gt.GenericFileType && ty != gt.GenericPathType {
+					return fmt.Errorf("%s action: argName %d must be a file or path argument", act.Action, act.ArgName)
+				}
+			case selectors.ActionTypeUnfollowFd:
+				if err := checkFd(act.Action, "argFd", act.ArgFd); err != nil {
+					return err
+				}
+			case selectors.ActionTypeCopyFd:
+				if err := checkFd(act.Action, "argFd", act.ArgFd); err != nil {
+					return err
+				}
+				if err := checkFd(act.Action, "argName", act.ArgName); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateErrorInjection checks that the syscalls allow error injection, which
+// is required by bpf_override_return. If the list of the functions that allow
+// error injection is not available (e.g., debugfs is not mounted), the check
+// is skipped.
+func validateErrorInjection(calls []string) error {
+	funcs, err := ftrace.ReadErrorInjectionFuncs()
+	if err != nil {
+		logger.GetLogger().WithError(err).Warn("Failed to read error injection list, skipping override validation")
+		return nil
+	}
+	for _, call := range calls {
+		if _, ok := funcs[call]; ok {
+			continue
+		}
+		if prefixed, err := arch.AddSyscallPrefix(call); err == nil {
+			if _, ok := funcs[prefixed]; ok {
+				continue
+			}
+		}
+		return fmt.Errorf("Error override action not supported for '%s': function does not allow error injection", call)
+	}
+	return nil
+}
+
+// withCompatKprobes returns the kprobe specs with, for each spec with
+// includeCompat, an additional spec for the compat entry point of its syscall.
+func withCompatKprobes(kprobes []v1alpha1.KProbeSpec) []v1alpha1.KProbeSpec {
+	var compats []v1alpha1.KProbeSpec
+	for i := range kprobes {
+		f := &kprobes[i]
+		if !f.IncludeCompat || !f.Syscall || f.Compat || strings.HasPrefix(f.Call, "list:") {
+			continue
+		}
+		compat := f.DeepCopy()
+		compat.Compat = true
+		compat.IncludeCompat = false
+		compats = append(compats, *compat)
+	}
+	if len(compats) == 0 {
+		return kprobes
+	}
+	ret := make([]v1alpha1.KProbeSpec, 0, len(kprobes)+len(compats))
+	ret = append(ret, kprobes...)
+	return append(ret, compats...)
+}
+
+// compatSyscallSymbol returns the symbol of the compat entry point of a
+// syscall, the first of the candidate symbols of the arch that is in BTF.
+func compatSyscallSymbol(spec *ebtf.Spec, call string) (string, error) {
+	symbols, err := arch.CompatSyscallSymbols(call)
+	if err != nil {
+		return "", err
+	}
+	for _, symbol := range symbols {
+		var fn *ebtf.Func
+		if err := spec.TypeByName(symbol, &fn); err == nil {
+			return symbol, nil
+		}
+	}
+	return "", fmt.Errorf("compat entry point of syscall %s not found, tried %s", call, strings.Join(symbols, ", "))
+}
+
+func hasDeferredKprobes(kprobes []v1alpha1.KProbeSpec) bool {
+	for i := range kprobes {
+		if kprobes[i].Deferred {
+			return true
+		}
+	}
+	return false
+}
+
+// preValidateKprobes pre-validates the semantics and BTF information of the
+// kprobes before creating their sensor, in order to separate the kprobe errors
+// from BPF related ones. With partialLoad, the kprobes whose function cannot
+// be found are not an error, they are left to fail when loading the sensor,
+// where they are skipped.
+func preValidateKprobes(name string, kprobes []v1alpha1.KProbeSpec, lists []v1alpha1.ListSpec, partialLoad bool) error {
 	btfobj, err := btf.NewBTF()
 	if err != nil {
 		return err
@@ -364,24 +549,53 @@ func preValidateKprobes(name string, kprobes []v1alpha1.KProbeSpec, lists []v1al
 			if list == nil {
 				return fmt.Errorf("Error list '%s' not found", listName)
 			}
+		} else if f.Syscall && f.Compat {
+			// modifying f.Call directly since BTF validation
+			// later will use v1alpha1.KProbeSpec object
+			symbol, err := compatSyscallSymbol(btfobj, f.Call)
+			if err != nil {
+				return fmt.Errorf("kprobes[%d]: %w", i, err)
+			}
+			f.Call = symbol
 		} else if f.Syscall {
 			// modifying f.Call directly since BTF validation
 			// later will use v1alpha1.KProbeSpec object
-			prefixedName, err := arch.AddSyscallPrefix(f.Call)
+			symbol, err := arch.SyscallSymbol(f.Call)
 			if err != nil {
 				logger.GetLogger().WithFields(logrus.Fields{
 					"sensor": name,
 				}).WithError(err).Warn("Kprobe spec pre-validation of syscall prefix failed")
 			} else {
-				f.Call = prefixedName
+				f.Call = symbol
 			}
 		}
+		if f.Compat && (!f.Syscall || list != nil) {
+			return fmt.Errorf("kprobes[%d]: compat can only be used with a syscall call", i)
+		}
+		if f.IncludeCompat && (!f.Syscall || list != nil || f.Compat) {
+			return fmt.Errorf("kprobes[%d]: includeCompat can only be used with a syscall call, without compat", i)
+		}
+		if (f.Latency || f.LatencyHistogram) && !f.Return {
+			return fmt.Errorf("kprobes[%d]: latency and latencyHistogram can only be used with return", i)
+		}
 
 		for sid, selector := range f.Selectors {
 			for mid, matchAction := range selector.MatchActions {
 				if matchAction.StackTrace && matchAction.Action != "Post" {
 					return fmt.Errorf("stackTrace can only be used along Post action: got stackTrace enabled in kprobes[%d].selectors[%d].matchActions[%d] with action '%s'", i, sid, mid, matchAction.Action)
 				}
+				if matchAction.UserStackTrace && matchAction.Action != "Post" {
+					return fmt.Errorf("userStackTrace can only be used along Post action: got userStackTrace enabled in kprobes[%d].selectors[%d].matchActions[%d] with action '%s'", i, sid, mid, matchAction.Action)
+				}
+			}
+		}
+
+		if f.Deferred {
+			if f.AttachMode == attachModeFentry {
+				return fmt.Errorf("kprobes[%d]: deferred kprobes cannot be attached with fentry", i)
+			}
+			if selectors.HasOverride(f) {
+				return fmt.Errorf("kprobes[%d]: deferred kprobes cannot use the Override action", i)
 			}
 		}
 
@@ -394,8 +608,8 @@ func preValidateKprobes(name string, kprobes []v1alpha1.KProbeSpec, lists []v1al
 		}()
 
 		if selectors.HasOverride(f) {
-			if !bpf.HasOverrideHelper() {
-				return fmt.Errorf("Error override action not supported, bpf_override_return helper not available")
+			if err := features.OverrideReturn.Require("the Override action"); err != nil {
+				return err
 			}
 			if !f.Syscall {
 				for idx := range calls {
@@ -403,22 +617,46 @@ func preValidateKprobes(name string, kprobes []v1alpha1.KProbeSpec, lists []v1al
 						return fmt.Errorf("Error override action can be used only with syscalls and security_ hooks")
 					}
 				}
+			} else if err := validateErrorInjection(calls); err != nil {
+				return err
 			}
 		}
 
-		if selectors.HasSigkillAction(f) && !kernels.EnableLargeProgs() {
+		if selectors.HasSigkillAction(f.Selectors) && !kernels.EnableLargeProgs() {
 			return fmt.Errorf("sigkill action requires kernel >= 5.3.0")
 		}
 
+		if err := validateFdActions(f); err != nil {
+			return err
+		}
+
+		// kernel symbols are optional for the validation
+		ks, err := ksyms.KernelSymbols()
+		if err != nil {
+			ks = nil
+		}
+
 		for idx := range calls {
 			// Now go over BTF validation
-			if err := btf.ValidateKprobeSpec(btfobj, calls[idx], f); err != nil {
+			if err := btf.ValidateKprobeSpec(btfobj, calls[idx], f, ks); err != nil {
 				if warn, ok := err.(*btf.ValidationWarn); ok {
 					logger.GetLogger().WithFields(logrus.Fields{
 						"sensor": name,
 					}).WithError(warn).Warn("Kprobe spec pre-validation failed, but will continue with loading")
-				} else if e, ok := err.(*btf.ValidationFailed); ok {
-					return fmt.Errorf("kprobe spec pre-validation failed: %w", e)
+				} else if e, ok := err.(*btf.ValidationFailed); ok && f.Deferred {
+					// the function may belong to a module that is not
+					// loaded yet
+					logger.GetLogger().WithFields(logrus.Fields{
+						"sensor": name,
+					}).WithError(e).Info("Kprobe spec pre-validation failed, the kprobe is deferred until its function is available")
+				} else if ok {
+					if partialLoad {
+						logger.GetLogger().WithFields(logrus.Fields{
+							"sensor": name,
+						}).WithError(e).Warn("Kprobe spec pre-validation failed, the kprobe will be skipped if it fails to load")
+					} else {
+						return fmt.Errorf("kprobe spec pre-validation failed: %w", e)
+					}
 				} else {
 					err = fmt.Errorf("invalid or old kprobe spec: %s", err)
 					logger.GetLogger().WithFields(logrus.Fields{
@@ -444,15 +682,46 @@ func preValidateKprobes(name string, kprobes []v1alpha1.KProbeSpec, lists []v1al
 
 const (
 	flagsEarlyFilter = 1 << 0
+	flagsUsdt        = 1 << 1
+	flagsSkipCompat  = 1 << 2
+	flagsLatency     = 1 << 3
+	flagsLatencyHist = 1 << 4
+	flagsAudit       = 1 << 5
+)
+
+// values of the syscall field of the kprobe config, see the bpf generic_calls.h
+const (
+	syscallNative = 1
+	// the arguments of the syscalls are read from the compat (32-bit)
+	// registers
+	syscallCompat = 2
 )
 
 func flagsString(flags uint32) string {
-	s := "none"
+	var s []string
 
 	if flags&flagsEarlyFilter != 0 {
-		s = "early_filter"
+		s = append(s, "early_filter")
+	}
+	if flags&flagsUsdt != 0 {
+		s = append(s, "usdt")
+	}
+	if flags&flagsSkipCompat != 0 {
+		s = append(s, "skip_compat")
+	}
+	if flags&flagsLatency != 0 {
+		s = append(s, "latency")
+	}
+	if flags&flagsLatencyHist != 0 {
+		s = append(s, "latency_hist")
 	}
-	return s
+	if flags&flagsAudit != 0 {
+		s = append(s, "audit")
+	}
+	if len(s) == 0 {
+		return "none"
+	}
+	return strings.Join(s, ",")
 }
 
 func isGTOperator(op string) bool {
@@ -465,10 +734,16 @@ func isLTOperator(op string) bool {
 
 type addKprobeIn struct {
 	useMulti      bool
+	options       *kprobeOptions
 	sensorPath    string
 	policyName    string
 	policyID      policyfilter.PolicyID
+	audit         bool // the policy is in audit mode
 	customHandler eventhandler.Handler
+	// fentrySpec is the BTF spec of the kprobes attached with fentry, nil
+	// if fentry is not used, in which case fentryErr is the reason why
+	fentrySpec *ebtf.Spec
+	fentryErr  error
 }
 
 type addKprobeOut struct {
@@ -478,6 +753,79 @@ type addKprobeOut struct {
 	maps        []*program.Map
 	// identifier returned when the kprobe was added to the genericKprobeTable
 	tableEntryIndex int
+	// how the kprobe is attached
+	attachMode string
+}
+
+// Attach modes of the kprobes, see KProbeSpec.AttachMode
+const (
+	attachModeKprobe      = "kprobe"
+	attachModeKprobeMulti = "kprobe_multi"
+	attachModeFentry      = "fentry"
+)
+
+// Maximum number of arguments of the functions attached with fentry, x86
+// trampolines support up to 6 arguments on older kernels.
+const fentryMaxArgs = 6
+
+func fentryKprobeObjs() (string, string) {
+	if kernels.EnableV61Progs() {
+		return "bpf_fentry_kprobe_v61.o", "bpf_fentry_retkprobe_v61.o"
+	}
+	return "bpf_fentry_kprobe_v53.o", "bpf_fentry_retkprobe_v53.o"
+}
+
+// fentryBTFSpec returns the BTF spec needed to attach the kprobes with
+// fentry, or nil if none of the kprobes requests the fentry attach mode. The
+// error is the reason why fentry cannot be used.
+func fentryBTFSpec(kprobes []v1alpha1.KProbeSpec) (*ebtf.Spec, error) {
+	requested := false
+	for i := range kprobes {
+		if kprobes[i].AttachMode == attachModeFentry {
+			requested = true
+			break
+		}
+	}
+	if !requested {
+		return nil, nil
+	}
+
+	if !bpf.HasFentry() || !kernels.EnableLargeProgs() {
+		return nil, fmt.Errorf("fentry is not supported by the kernel")
+	}
+
+	spec, err := btf.NewBTF()
+	if err != nil {
+		return nil, err
+	}
+	if len(option.Config.KMods) > 0 {
+		spec, err = cachedbtf.AddModulesToSpec(spec, option.Config.KMods)
+		if err != nil {
+			return nil, fmt.Errorf("adding modules to spec failed: %w", err)
+		}
+	}
+	return spec, nil
+}
+
+// fentryArgsCount returns the number of arguments of the function, or an
+// error if the function cannot be attached with fentry.
+func fentryArgsCount(funcName string, f *v1alpha1.KProbeSpec, in *addKprobeIn) (int, error) {
+	if in.fentrySpec == nil {
+		return 0, in.fentryErr
+	}
+	// the override of fentry programs is done with fmodret, which is only
+	// allowed on security_ functions
+	if selectors.HasOverride(f) && (!strings.HasPrefix(funcName, "security_") || !bpf.HasModifyReturn()) {
+		return 0, fmt.Errorf("override action requires kprobe")
+	}
+	n, err := cachedbtf.FuncParamsCount(in.fentrySpec, funcName)
+	if err != nil {
+		return 0, err
+	}
+	if n > fentryMaxArgs {
+		return 0, fmt.Errorf("function has %d arguments, fentry supports up to %d", n, fentryMaxArgs)
+	}
+	return n, nil
 }
 
 func getKprobeSymbols(symbol string, syscall bool, lists []v1alpha1.ListSpec) ([]string, bool, error) {
@@ -500,6 +848,9 @@ func createGenericKprobeSensor(
 	policyID policyfilter.PolicyID,
 	policyName string,
 	lists []v1alpha1.ListSpec,
+	opts []v1alpha1.OptionSpec,
+	partialLoad bool,
+	audit bool,
 	customHandler eventhandler.Handler,
 ) (*sensors.Sensor, error) {
 	var progs []*program.Program
@@ -508,19 +859,36 @@ func createGenericKprobeSensor(
 	var useMulti bool
 	var selMaps *selectors.KernelSelectorMaps
 
+	options, err := getKprobeOptions(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kprobe options: %w", err)
+	}
+
+	fentrySpec, fentryErr := fentryBTFSpec(kprobes)
+
 	// use multi kprobe only if:
-	// - it's not disabled by user
+	// - it's not disabled by user, or by the options of the policy
 	// - there's support detected
-	useMulti = !option.Config.DisableKprobeMulti &&
-		bpf.HasKprobeMulti()
+	// - none of the kprobes is attached with fentry
+	// - the policy is not partially loaded, and none of the kprobes is
+	//   deferred, since a multi kprobe program attaches all the kprobes at
+	//   once
+	useMulti = !option.Config.DisableKprobeMulti && !options.DisableKprobeMulti &&
+		bpf.HasKprobeMulti() && fentrySpec == nil && !partialLoad &&
+		!hasDeferredKprobes(kprobes)
 
 	in := addKprobeIn{
 		useMulti:      useMulti,
+		options:       options,
 		sensorPath:    name,
 		policyID:      policyID,
 		policyName:    policyName,
+		audit:         audit,
 		customHandler: customHandler,
+		fentrySpec:    fentrySpec,
+		fentryErr:     fentryErr,
 	}
+	attachModes := make(map[string]string)
 
 	addedKprobeIndices := []int{}
 	if useMulti {
@@ -529,6 +897,7 @@ func createGenericKprobeSensor(
 	for i := range kprobes {
 		syms, syscall, err := getKprobeSymbols(kprobes[i].Call, kprobes[i].Syscall, lists)
 		if err != nil {
+			releaseKprobesBinaryPrefixes(addedKprobeIndices)
 			return nil, err
 		}
 
@@ -538,9 +907,11 @@ func createGenericKprobeSensor(
 		for idx := range syms {
 			out, err := addKprobe(syms[idx], &kprobes[i], &in, selMaps)
 			if err != nil {
+				releaseKprobesBinaryPrefixes(addedKprobeIndices)
 				return nil, err
 			}
 			addedKprobeIndices = append(addedKprobeIndices, out.tableEntryIndex)
+			attachModes[syms[idx]] = out.attachMode
 
 			if useMulti {
 				multiRetIDs = append(multiRetIDs, out.multiRetIDs...)
@@ -553,13 +924,25 @@ func createGenericKprobeSensor(
 	}
 
 	if useMulti {
-		progs, maps = createMultiKprobeSensor(in.sensorPath, multiIDs, multiRetIDs)
+		progs, maps = createMultiKprobeSensor(in.sensorPath, multiIDs, multiRetIDs, options)
 	}
 
+	if options.PerfBufferSize > 0 {
+		// the programs write the events of the policy to its own perf
+		// ring buffer instead of the one of the agent
+		for _, prog := range progs {
+			maps = append(maps, program.MapBuilderPin("tcpmon_map", sensors.PathJoin(name, "tcpmon_map"), prog))
+		}
+	}
+	postLoadHook, preUnloadHook := perfBufferHooks(name, options)
+
 	return &sensors.Sensor{
-		Name:  name,
-		Progs: progs,
-		Maps:  maps,
+		Name:          name,
+		Progs:         progs,
+		Maps:          maps,
+		AttachModes:   attachModes,
+		PostLoadHook:  postLoadHook,
+		PreUnloadHook: preUnloadHook,
 		PostUnloadHook: func() error {
 			var errs error
 			for _, idx := range addedKprobeIndices {
@@ -568,7 +951,8 @@ func createGenericKprobeSensor(
 					errs = errors.Join(errs, err)
 				}
 
-				// close the eventual reference to the stack trace map
+				// close the eventual references to the stack trace,
+				// latency histogram and count maps
 				gk, ok := entry.(*genericKprobe)
 				if !ok {
 					errs = errors.Join(errs, fmt.Errorf("entry from genericKprobeTable with invalid type: %T (%v)", entry, entry))
@@ -580,12 +964,26 @@ func createGenericKprobeSensor(
 						}
 						gk.stackTraceMapRef = nil
 					}
+					if gk.latencyHistMapRef != nil {
+						err = gk.latencyHistMapRef.Close()
+						if err != nil {
+							errs = errors.Join(errs, fmt.Errorf("failed to close map: %v", gk.latencyHistMapRef))
+						}
+						gk.latencyHistMapRef = nil
+					}
+					if gk.countMapRef != nil {
+						err = gk.countMapRef.Close()
+						if err != nil {
+							errs = errors.Join(errs, fmt.Errorf("failed to close map: %v", gk.countMapRef))
+						}
+						gk.countMapRef = nil
+					}
 				}
 			}
 			return errs
 		},
 		DestroyHook: func() error {
-			var errs error
+			errs := releaseKprobesBinaryPrefixes(addedKprobeIndices)
 			for _, idx := range addedKprobeIndices {
 				_, err := genericKprobeTable.RemoveEntry(idtable.EntryID{ID: idx})
 				if err != nil {
@@ -594,9 +992,182 @@ func createGenericKprobeSensor(
 			}
 			return errs
 		},
+		SelectorsHook: func(spec *v1alpha1.TracingPolicySpec) error {
+			updated, err := updateKprobeSelectors(spec, kprobes, addedKprobeIndices, useMulti)
+			if err != nil {
+				return err
+			}
+			kprobes = updated
+			return nil
+		},
 	}, nil
 }
 
+// updateKprobeSelectors updates the selectors of the loaded kprobes, added
+// from the kprobes specs with the table entries of ids, to the selectors of
+// the kprobes of spec. It returns the kprobes specs of spec.
+func updateKprobeSelectors(
+	spec *v1alpha1.TracingPolicySpec,
+	kprobes []v1alpha1.KProbeSpec,
+	ids []int,
+	useMulti bool,
+) ([]v1alpha1.KProbeSpec, error) {
+	// the inner maps of the values of the selectors must keep their sizes
+	// on older kernels
+	if !kernels.MinKernelVersion("5.9") {
+		return nil, fmt.Errorf("%w: updating selectors requires kernel >= 5.9", sensors.ErrTracingPolicyNotUpdatable)
+	}
+
+	newKprobes, lists, err := expandKprobeCalls(spec.KProbes, spec.Lists)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	newKprobes = withCompatKprobes(newKprobes)
+	if len(newKprobes) != len(kprobes) {
+		return nil, fmt.Errorf("%w: the kprobes changed", sensors.ErrTracingPolicyNotUpdatable)
+	}
+	if err := preValidateKprobes("", newKprobes, lists, spec.PartialLoad); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	type kprobeUpdate struct {
+		gk                *genericKprobe
+		selectors         *selectors.KernelSelectorState
+		userReturnFilters []v1alpha1.ArgSelector
+	}
+	updates := make([]*kprobeUpdate, 0, len(ids))
+	// the selectors of the updates are swapped with the ones of the kprobes
+	// that are updated, so that the selectors that are not used anymore are
+	// released
+	defer func() {
+		for _, u := range updates {
+			if err := releaseBinaryPrefixes(u.selectors); err != nil {
+				logger.GetLogger().WithError(err).Warn("Failed to release the matchBinaries prefixes")
+			}
+		}
+	}()
+
+	var selMaps *selectors.KernelSelectorMaps
+	if useMulti {
+		selMaps = &selectors.KernelSelectorMaps{}
+	}
+	for i := range newKprobes {
+		f, old := &newKprobes[i], &kprobes[i]
+		// these change the programs or the sizes of the maps of the
+		// kprobe
+		if selectors.HasOverride(f) != selectors.HasOverride(old) ||
+			selectors.HasCount(f.Selectors) != selectors.HasCount(old.Selectors) ||
+			selectors.HasEarlyBinaryFilter(f.Selectors) != selectors.HasEarlyBinaryFilter(old.Selectors) {
+			return nil, fmt.Errorf("%w: kprobes[%d]: adding or removing Override or Count actions, or early binary filters",
+				sensors.ErrTracingPolicyNotUpdatable, i)
+		}
+
+		syms, syscall, err := getKprobeSymbols(f.Call, f.Syscall, lists)
+		if err != nil {
+			return nil, err
+		}
+		f.Syscall = syscall
+
+		for range syms {
+			if len(updates) == len(ids) {
+				return nil, fmt.Errorf("%w: the kprobes changed", sensors.ErrTracingPolicyNotUpdatable)
+			}
+			gk, err := genericKprobeTableGet(idtable.EntryID{ID: ids[len(updates)]})
+			if err != nil {
+				return nil, err
+			}
+			u := &kprobeUpdate{gk: gk}
+			u.userReturnFilters, err = kprobeUserReturnFilters(f)
+			if err != nil {
+				return nil, err
+			}
+			// the arguments of the actions are added to the ones of
+			// the current selectors, which stay valid until the
+			// selector maps are updated
+			u.selectors, err = selectors.InitKernelSelectorState(f.Selectors, f.Args, &gk.actionArgs, nil, selMaps)
+			if err != nil {
+				return nil, err
+			}
+			updates = append(updates, u)
+		}
+	}
+	if len(updates) != len(ids) {
+		return nil, fmt.Errorf("%w: the kprobes changed", sensors.ErrTracingPolicyNotUpdatable)
+	}
+
+	index := func(idx int) uint32 {
+		if useMulti {
+			return uint32(idx)
+		}
+		return 0
+	}
+	for idx, u := range updates {
+		if err := updateSelectorMaps(u.selectors, u.gk.loadArgs.selectors, u.gk.pinPathPrefix, index(idx)); err != nil {
+			// restore the selectors of the kprobes updated so far,
+			// so that the policy is not left half updated. The maps
+			// of the kprobe that failed are all written again.
+			for j := 0; j <= idx; j++ {
+				old := updates[j].gk
+				cur := updates[j].selectors
+				if j == idx {
+					cur = nil
+				}
+				if rerr := updateSelectorMaps(old.loadArgs.selectors, cur, old.pinPathPrefix, index(j)); rerr != nil {
+					err = errors.Join(err, fmt.Errorf("failed to restore the selectors of %s: %w", old.funcName, rerr))
+				}
+			}
+			return nil, err
+		}
+	}
+	for _, u := range updates {
+		u.gk.loadArgs.selectors, u.selectors = u.selectors, u.gk.loadArgs.selectors
+		u.gk.userReturnFilters = u.userReturnFilters
+		// the events of the previous selectors may still be in flight
+		selectors.PruneActionArgs(&u.gk.actionArgs, u.gk.loadArgs.selectors, u.selectors)
+	}
+	return newKprobes, nil
+}
+
+// releaseKprobesBinaryPrefixes releases the matchBinaries prefixes of the
+// selectors of the kprobes of the genericKprobeTable entries ids.
+func releaseKprobesBinaryPrefixes(ids []int) error {
+	var errs error
+	for _, idx := range ids {
+		gk, err := genericKprobeTableGet(idtable.EntryID{ID: idx})
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		errs = errors.Join(errs, releaseBinaryPrefixes(gk.loadArgs.selectors))
+	}
+	return errs
+}
+
+// kprobeUserReturnFilters returns the return arg filters of the selectors of a
+// kprobe, which are implemented in userspace.
+func kprobeUserReturnFilters(f *v1alpha1.KProbeSpec) ([]v1alpha1.ArgSelector, error) {
+	var userReturnFilters []v1alpha1.ArgSelector
+	for _, s := range f.Selectors {
+		for _, returnArg := range s.MatchReturnArgs {
+			// we allow integer values so far
+			for _, v := range returnArg.Values {
+				if _, err := strconv.Atoi(v); err != nil {
+					return nil, fmt.Errorf("ReturnArg value supports only integer values, got %s", v)
+				}
+			}
+			// only single value for GT,LT operators
+			if isGTOperator(returnArg.Operator) || isLTOperator(returnArg.Operator) {
+				if len(returnArg.Values) > 1 {
+					return nil, fmt.Errorf("ReturnArg operater '%s' supports only single value, got %d",
+						returnArg.Operator, len(returnArg.Values))
+				}
+			}
+			userReturnFilters = append(userReturnFilters, returnArg)
+		}
+	}
+	return userReturnFilters, nil
+}
+
 // addKprobe will, amongst other things, create a generic kprobe entry and add
 // it to the genericKprobeTable. The caller should make sure that this entry is
 // properly removed on kprobe removal.
@@ -630,9 +1201,28 @@ func addKprobe(funcName string, f *v1alpha1.KProbeSpec, in *addKprobeIn, selMaps
 			return nil, fmt.Errorf("Error: can't override '%s' function with kprobe_multi, use --disable-kprobe-multi option",
 				funcName)
 		}
-		if isSecurityFunc && !bpf.HasModifyReturn() {
-			return nil, fmt.Errorf("Error: can't override '%s' function without fmodret support",
-				funcName)
+		if isSecurityFunc {
+			if err := features.ModifyReturn.Require(fmt.Sprintf("the Override action on '%s'", funcName)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	out.attachMode = attachModeKprobe
+	if in.useMulti {
+		out.attachMode = attachModeKprobeMulti
+	}
+	if f.AttachMode == attachModeFentry {
+		nargs, err := fentryArgsCount(funcName, f, in)
+		if err != nil {
+			logger.GetLogger().WithField("function", funcName).WithError(err).
+				Warnf("Cannot attach with fentry, falling back to %s", out.attachMode)
+		} else {
+			out.attachMode = attachModeFentry
+			loadProgName, loadProgRetName = fentryKprobeObjs()
+			// the fexit program finds the return value after the
+			// arguments of the function
+			config.ArgTpCtxOff[0] = uint32(nargs)
 		}
 	}
 
@@ -652,6 +1242,9 @@ func addKprobe(funcName string, f *v1alpha1.KProbeSpec, in *addKprobeIn, selMaps
 				logger.GetLogger().Warnf("maxData flag is ignored (supported from large programs)")
 			}
 		}
+		if a.MaxDataSize > 0 && !isBufferType(argType) {
+			logger.GetLogger().Warnf("maxDataSize is ignored (supported for char_buf, char_iovec and iov_iter types)")
+		}
 		argMValue, err := getMetaValue(&a)
 		if err != nil {
 			return nil, err
@@ -706,6 +1299,7 @@ func addKprobe(funcName string, f *v1alpha1.KProbeSpec, in *addKprobeIn, selMaps
 
 		argType := gt.GenericTypeFromString(argRetprobe.Type)
 		config.ArgReturnCopy = int32(argType)
+		config.ArgReturnCopyMaxSize = argRetprobe.MaxDataSize
 
 		argP := argPrinters{index: int(argRetprobe.Index), ty: argType, label: argRetprobe.Label}
 		argReturnPrinters = append(argReturnPrinters, argP)
@@ -725,24 +1319,9 @@ func addKprobe(funcName string, f *v1alpha1.KProbeSpec, in *addKprobeIn, selMaps
 	}
 
 	// Copy over userspace return filters
-	var userReturnFilters []v1alpha1.ArgSelector
-	for _, s := range f.Selectors {
-		for _, returnArg := range s.MatchReturnArgs {
-			// we allow integer values so far
-			for _, v := range returnArg.Values {
-				if _, err := strconv.Atoi(v); err != nil {
-					return nil, fmt.Errorf("ReturnArg value supports only integer values, got %s", v)
-				}
-			}
-			// only single value for GT,LT operators
-			if isGTOperator(returnArg.Operator) || isLTOperator(returnArg.Operator) {
-				if len(returnArg.Values) > 1 {
-					return nil, fmt.Errorf("ReturnArg operater '%s' supports only single value, got %d",
-						returnArg.Operator, len(returnArg.Values))
-				}
-			}
-			userReturnFilters = append(userReturnFilters, returnArg)
-		}
+	userReturnFilters, err := kprobeUserReturnFilters(f)
+	if err != nil {
+		return nil, err
 	}
 
 	// Write attributes into BTF ptr for use with load
@@ -750,15 +1329,27 @@ func addKprobe(funcName string, f *v1alpha1.KProbeSpec, in *addKprobeIn, selMaps
 		setRetprobe = f.Return
 	}
 
-	if f.Syscall {
-		config.Syscall = 1
-	} else {
+	switch {
+	case f.Syscall && f.Compat:
+		config.Syscall = syscallCompat
+	case f.Syscall:
+		config.Syscall = syscallNative
+	default:
 		config.Syscall = 0
 	}
 
 	if selectors.HasEarlyBinaryFilter(f.Selectors) {
 		config.Flags |= flagsEarlyFilter
 	}
+	if f.Latency {
+		config.Flags |= flagsLatency
+	}
+	if f.LatencyHistogram {
+		config.Flags |= flagsLatencyHist
+	}
+	if in.audit {
+		config.Flags |= flagsAudit
+	}
 
 	// create a new entry on the table, and pass its id to BPF-side
 	// so that we can do the matching at event-generation time
@@ -776,6 +1367,8 @@ func addKprobe(funcName string, f *v1alpha1.KProbeSpec, in *addKprobeIn, selMaps
 		tableId:           idtable.UninitializedEntryID,
 		policyName:        in.policyName,
 		hasOverride:       selectors.HasOverride(f),
+		hasCount:          selectors.HasCount(f.Selectors),
+		attachMode:        out.attachMode,
 		customHandler:     in.customHandler,
 	}
 
@@ -787,6 +1380,7 @@ func addKprobe(funcName string, f *v1alpha1.KProbeSpec, in *addKprobeIn, selMaps
 
 	kprobeEntry.pendingEvents, err = lru.New[pendingEventKey, pendingEvent](4096)
 	if err != nil {
+		releaseBinaryPrefixes(kprobeEntry.loadArgs.selectors)
 		return nil, err
 	}
 
@@ -819,6 +1413,8 @@ func addKprobe(funcName string, f *v1alpha1.KProbeSpec, in *addKprobeIn, selMaps
 		"kprobe/generic_kprobe",
 		pinProg,
 		"generic_kprobe").
+		SetFentry(kprobeEntry.attachMode == attachModeFentry).
+		SetDeferred(f.Deferred).
 		SetLoaderData(kprobeEntry.tableId)
 	load.Override = kprobeEntry.hasOverride
 	if load.Override {
@@ -906,8 +1502,17 @@ func addKprobe(funcName string, f *v1alpha1.KProbeSpec, in *addKprobeIn, selMaps
 	out.maps = append(out.maps, selNamesMap)
 
 	stackTraceMap := program.MapBuilderPin("stack_trace_map", sensors.PathJoin(pinPath, "stack_trace_map"), load)
+	if option.Config.StackTraceMapSize > 0 {
+		stackTraceMap.SetMaxEntries(option.Config.StackTraceMapSize)
+	}
 	out.maps = append(out.maps, stackTraceMap)
 
+	countMap := program.MapBuilderPin("count_map", sensors.PathJoin(pinPath, "count_map"), load)
+	if !kprobeEntry.hasCount {
+		countMap.SetMaxEntries(1)
+	}
+	out.maps = append(out.maps, countMap)
+
 	if kernels.EnableLargeProgs() {
 		socktrack := program.MapBuilderPin("socktrack_map", sensors.PathJoin(in.sensorPath, "socktrack_map"), load)
 		out.maps = append(out.maps, socktrack)
@@ -922,6 +1527,8 @@ func addKprobe(funcName string, f *v1alpha1.KProbeSpec, in *addKprobeIn, selMaps
 			pinRetProg,
 			"generic_kprobe").
 			SetRetProbe(true).
+			SetFentry(kprobeEntry.attachMode == attachModeFentry).
+			SetDeferred(f.Deferred).
 			SetLoaderData(kprobeEntry.tableId)
 		out.progs = append(out.progs, loadret)
 
@@ -931,16 +1538,23 @@ func addKprobe(funcName string, f *v1alpha1.KProbeSpec, in *addKprobeIn, selMaps
 		retConfigMap := program.MapBuilderPin("config_map", sensors.PathJoin(pinPath, "retprobe_config_map"), loadret)
 		out.maps = append(out.maps, retConfigMap)
 
+		latencyHist := program.MapBuilderPin("latency_hist_map", sensors.PathJoin(pinPath, "latency_hist_map"), loadret)
+		out.maps = append(out.maps, latencyHist)
+
 		// add maps with non-default paths (pins) to the retprobe
 		program.MapBuilderPin("process_call_heap", sensors.PathJoin(pinPath, "process_call_heap"), loadret)
-		program.MapBuilderPin("fdinstall_map", sensors.PathJoin(in.sensorPath, "fdinstall_map"), loadret)
+		retFdinstall := program.MapBuilderPin("fdinstall_map", sensors.PathJoin(in.sensorPath, "fdinstall_map"), loadret)
+		in.options.sizeMaps(retFdinstall)
 		if kernels.EnableLargeProgs() {
 			program.MapBuilderPin("socktrack_map", sensors.PathJoin(in.sensorPath, "socktrack_map"), loadret)
 		}
 	}
 
+	in.options.sizeMaps(out.maps...)
+
 	logger.GetLogger().WithField("flags", flagsString(config.Flags)).
 		WithField("override", kprobeEntry.hasOverride).
+		WithField("attach", kprobeEntry.attachMode).
 		Infof("Added generic kprobe sensor: %s -> %s", load.Name, load.Attach)
 	return out, nil
 }
@@ -982,7 +1596,7 @@ func loadSingleKprobeSensor(id idtable.EntryID, bpfDir, mapDir string, load *pro
 		writeBinaryMap(m, i, path)
 	}
 
-	return err
+	return writeBinaryPrefixMaps(mapDir, gk.loadArgs.selectors)
 }
 
 func loadMultiKprobeSensor(ids []idtable.EntryID, bpfDir, mapDir string, load *program.Program, verbose int) error {
@@ -1039,6 +1653,9 @@ func loadMultiKprobeSensor(ids []idtable.EntryID, bpfDir, mapDir string, load *p
 			for i, path := range gk.loadArgs.selectors.GetNewBinaryMappings() {
 				writeBinaryMap(m, i, path)
 			}
+			if err := writeBinaryPrefixMaps(mapDir, gk.loadArgs.selectors); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -1095,6 +1712,41 @@ func parseString(r io.Reader) (string, error) {
 	return strutils.UTF8FromBPFBytes(stringBuffer), nil
 }
 
+const (
+	// maxStringArrayEntries is MAX_STRING_ARRAY_ENTRIES of the large BPF programs
+	maxStringArrayEntries = 16
+	stringArrayTruncated  = 1
+)
+
+// parseStringArray parses arrays of strings encoded from BPF copy_string_array
+// in the form:
+// *---------*---------*---------------*-----
+// | 4 bytes | 4 bytes |    string     | ...
+// |  count  |  flags  | (parseString) | ...
+// *---------*---------*---------------*-----
+// It returns the strings and whether the array has more entries than these.
+func parseStringArray(r io.Reader) ([]string, bool, error) {
+	var hdr struct {
+		Count uint32
+		Flags uint32
+	}
+	if err := binary.Read(r, binary.LittleEndian, &hdr); err != nil {
+		return nil, false, fmt.Errorf("failed to read string array header: %w", err)
+	}
+	if hdr.Count > maxStringArrayEntries {
+		return nil, false, fmt.Errorf("string array count too large: %d, max count is %d", hdr.Count, maxStringArrayEntries)
+	}
+	values := make([]string, 0, hdr.Count)
+	for i := uint32(0); i < hdr.Count; i++ {
+		v, err := parseString(r)
+		if err != nil {
+			return values, true, fmt.Errorf("failed to read string array entry %d: %w", i, err)
+		}
+		values = append(values, v)
+	}
+	return values, hdr.Flags&stringArrayTruncated != 0, nil
+}
+
 func ReadArgBytes(r *bytes.Reader, index int, hasMaxData bool) (*api.MsgGenericKprobeArgBytes, error) {
 	var bytes, bytes_rd, hasDataEvents int32
 	var arg api.MsgGenericKprobeArgBytes
@@ -1115,7 +1767,7 @@ func ReadArgBytes(r *bytes.Reader, index int, hasMaxData bool) (*api.MsgGenericK
 				return nil, err
 			}
 			arg.Index = uint64(index)
-			arg.OrigSize = uint64(len(data) + int(desc.Leftover))
+			arg.OrigSize = uint64(desc.Size)
 			arg.Value = data
 			return &arg, nil
 		}
@@ -1192,83 +1844,46 @@ func handleGenericKprobe(r *bytes.Reader) ([]observer.Event, error) {
 	return ret, err
 }
 
-func handleMsgGenericKprobe(m *api.MsgGenericKprobe, gk *genericKprobe, r *bytes.Reader) ([]observer.Event, error) {
-	var err error
-
-	switch m.ActionId {
-	case selectors.ActionTypeGetUrl, selectors.ActionTypeDnsLookup:
-		actionArgEntry, err := gk.actionArgs.GetEntry(idtable.EntryID{ID: int(m.ActionArgId)})
+// lookupStackTrace reads the stack trace with the given id from the stack
+// trace map into stackTrace.
+func (gk *genericKprobe) lookupStackTrace(stackID int64, stackTrace *[unix.PERF_MAX_STACK_DEPTH]uint64) {
+	if stackID < 0 {
+		logger.GetLogger().Warnf("failed to retrieve stacktrace: id equal to errno %d", stackID)
+		return
+	}
+	// remove the error part
+	id := uint32(stackID)
+
+	// lazy load the map reference if needed
+	if gk.stackTraceMapRef == nil {
+		var err error
+		gk.stackTraceMapRef, err = ebpf.LoadPinnedMap(path.Join(bpf.MapPrefixPath(), gk.pinPathPrefix)+"-stack_trace_map", &ebpf.LoadPinOptions{
+			ReadOnly: true,
+		})
 		if err != nil {
-			logger.GetLogger().WithError(err).Warnf("Failed to find argument for id:%d", m.ActionArgId)
-			return nil, fmt.Errorf("Failed to find argument for id")
-		}
-		actionArg := actionArgEntry.(*selectors.ActionArgEntry).GetArg()
-		switch m.ActionId {
-		case selectors.ActionTypeGetUrl:
-			logger.GetLogger().WithField("URL", actionArg).Trace("Get URL Action")
-			getUrl(actionArg)
-		case selectors.ActionTypeDnsLookup:
-			logger.GetLogger().WithField("FQDN", actionArg).Trace("DNS lookup")
-			dnsLookup(actionArg)
+			logger.GetLogger().WithError(err).Warn("failed to load the stacktrace map")
 		}
+		// close this in cleanup postHook defer stackTraceMap.Close()
 	}
 
-	unix := &tracing.MsgGenericKprobeUnix{}
-	unix.Common = m.Common
-	unix.ProcessKey = m.ProcessKey
-	unix.Id = m.FuncId
-	unix.Action = m.ActionId
-	unix.Tid = m.Tid
-	unix.FuncName = gk.funcName
-	unix.Namespaces = m.Namespaces
-	unix.Capabilities = m.Capabilities
-	unix.PolicyName = gk.policyName
-
-	returnEvent := m.Common.Flags&processapi.MSG_COMMON_FLAG_RETURN != 0
-
-	var ktimeEnter uint64
-	var printers []argPrinters
-	if returnEvent {
-		// if this a return event, also read the ktime of the enter event
-		err := binary.Read(r, binary.LittleEndian, &ktimeEnter)
+	// this can't be an else statement in the previous block since it
+	// must execute as well when the reference is first initialized
+	if gk.stackTraceMapRef != nil {
+		// the depth of the stack traces might be reduced by the
+		// stack-trace-depth option of the policy
+		frames := make([]uint64, gk.stackTraceMapRef.ValueSize()/8)
+		err := gk.stackTraceMapRef.Lookup(id, frames)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read ktimeEnter")
+			logger.GetLogger().WithError(err).Warn("failed to lookup the stacktrace map")
 		}
-		printers = gk.argReturnPrinters
-	} else {
-		ktimeEnter = m.Common.Ktime
-		printers = gk.argSigPrinters
+		copy(stackTrace[:], frames)
 	}
+}
 
-	if m.Common.Flags&processapi.MSG_COMMON_FLAG_STACKTRACE != 0 {
-		if m.StackID < 0 {
-			logger.GetLogger().Warnf("failed to retrieve stacktrace: id equal to errno %d", m.StackID)
-		} else {
-			// remove the error part
-			id := uint32(m.StackID)
-
-			// lazy load the map reference if needed
-			if gk.stackTraceMapRef == nil {
-				bpf.MapPrefixPath()
-				gk.stackTraceMapRef, err = ebpf.LoadPinnedMap(path.Join(bpf.MapPrefixPath(), gk.pinPathPrefix)+"-stack_trace_map", &ebpf.LoadPinOptions{
-					ReadOnly: true,
-				})
-				if err != nil {
-					logger.GetLogger().WithError(err).Warn("failed to load the stacktrace map")
-				}
-				// close this in cleanup postHook defer stackTraceMap.Close()
-			}
-
-			// this can't be an else statement in the previous block since it
-			// must execute as well when the reference is first initialized
-			if gk.stackTraceMapRef != nil {
-				err = gk.stackTraceMapRef.Lookup(id, &unix.StackTrace)
-				if err != nil {
-					logger.GetLogger().WithError(err).Warn("failed to lookup the stacktrace map")
-				}
-			}
-		}
-	}
+// getArgs reads the arguments described by printers from r.
+func getArgs(r *bytes.Reader, printers []argPrinters) ([]api.MsgGenericKprobeArg, error) {
+	var args []api.MsgGenericKprobeArg
+	var err error
 
 	for _, a := range printers {
 		switch a.ty {
@@ -1284,7 +1899,7 @@ func handleMsgGenericKprobe(m *api.MsgGenericKprobe, gk *genericKprobe, r *bytes
 			arg.Index = uint64(a.index)
 			arg.Value = output
 			arg.Label = a.label
-			unix.Args = append(unix.Args, arg)
+			args = append(args, arg)
 		case gt.GenericFileType, gt.GenericFdType, gt.GenericKiocb:
 			var arg api.MsgGenericKprobeArgFile
 			var flags uint32
@@ -1318,8 +1933,8 @@ func handleMsgGenericKprobe(m *api.MsgGenericKprobe, gk *genericKprobe, r *bytes
 
 			arg.Flags = flags
 			arg.Label = a.label
-			unix.Args = append(unix.Args, arg)
-		case gt.GenericPathType:
+			args = append(args, arg)
+		case gt.GenericPathType, gt.GenericDentryType:
 			var arg api.MsgGenericKprobeArgPath
 			var flags uint32
 
@@ -1341,7 +1956,41 @@ func handleMsgGenericKprobe(m *api.MsgGenericKprobe, gk *genericKprobe, r *bytes
 
 			arg.Flags = flags
 			arg.Label = a.label
-			unix.Args = append(unix.Args, arg)
+			args = append(args, arg)
+		case gt.GenericLinuxBinprmType:
+			var arg api.MsgGenericKprobeArgLinuxBinprm
+			var flags uint32
+
+			arg.Index = uint64(a.index)
+			arg.Value, err = parseString(r)
+			if err != nil {
+				if errors.Is(err, errParseStringSize) {
+					arg.Value = "/"
+				} else {
+					logger.GetLogger().WithError(err).Warn("error parsing arg type linux_binprm")
+				}
+			}
+
+			// read the first byte that keeps the flags
+			err := binary.Read(r, binary.LittleEndian, &flags)
+			if err != nil {
+				flags = 0
+			}
+
+			arg.Flags = flags
+			arg.Label = a.label
+			args = append(args, arg)
+		case gt.GenericStringArrayType:
+			var arg api.MsgGenericKprobeArgStringArray
+
+			arg.Index = uint64(a.index)
+			arg.Values, arg.Truncated, err = parseStringArray(r)
+			if err != nil {
+				logger.GetLogger().WithError(err).Warn("error parsing arg type string_array")
+			}
+
+			arg.Label = a.label
+			args = append(args, arg)
 		case gt.GenericFilenameType, gt.GenericStringType:
 			var arg api.MsgGenericKprobeArgString
 
@@ -1352,7 +2001,7 @@ func handleMsgGenericKprobe(m *api.MsgGenericKprobe, gk *genericKprobe, r *bytes
 			}
 
 			arg.Label = a.label
-			unix.Args = append(unix.Args, arg)
+			args = append(args, arg)
 		case gt.GenericCredType:
 			var cred api.MsgGenericCred
 			var arg api.MsgGenericKprobeArgCred
@@ -1380,11 +2029,11 @@ func handleMsgGenericKprobe(m *api.MsgGenericKprobe, gk *genericKprobe, r *bytes
 			arg.UserNs.Gid = cred.UserNs.Gid
 			arg.UserNs.NsInum = cred.UserNs.NsInum
 			arg.Label = a.label
-			unix.Args = append(unix.Args, arg)
+			args = append(args, arg)
 		case gt.GenericCharBuffer, gt.GenericCharIovec, gt.GenericIovIter:
 			if arg, err := ReadArgBytes(r, a.index, a.maxData); err == nil {
 				arg.Label = a.label
-				unix.Args = append(unix.Args, *arg)
+				args = append(args, *arg)
 			} else {
 				logger.GetLogger().WithError(err).Warnf("failed to read bytes argument")
 			}
@@ -1410,9 +2059,11 @@ func handleMsgGenericKprobe(m *api.MsgGenericKprobe, gk *genericKprobe, r *bytes
 			arg.Proto = uint32(skb.Tuple.Protocol)
 			arg.SecPathLen = skb.SecPathLen
 			arg.SecPathOLen = skb.SecPathOLen
+			arg.IcmpType = uint32(skb.IcmpType)
+			arg.IcmpCode = uint32(skb.IcmpCode)
 			arg.Label = a.label
-			unix.Args = append(unix.Args, arg)
-		case gt.GenericSockType:
+			args = append(args, arg)
+		case gt.GenericSockType, gt.GenericSocketType:
 			var sock api.MsgGenericKprobeSock
 			var arg api.MsgGenericKprobeArgSock
 
@@ -1434,20 +2085,38 @@ func handleMsgGenericKprobe(m *api.MsgGenericKprobe, gk *genericKprobe, r *bytes
 			arg.Dport = uint32(sock.Tuple.Dport)
 			arg.Sockaddr = sock.Sockaddr
 			arg.Label = a.label
-			unix.Args = append(unix.Args, arg)
+			args = append(args, arg)
+		case gt.GenericSockaddrType:
+			var address api.MsgGenericKprobeSockaddr
+			var arg api.MsgGenericKprobeArgSockaddr
+
+			err := binary.Read(r, binary.LittleEndian, &address)
+			if err != nil {
+				logger.GetLogger().WithError(err).Warnf("sockaddr type err")
+			}
+
+			arg.Index = uint64(a.index)
+			arg.SinFamily = address.SinFamily
+			// the address is only decoded for IPv4 and IPv6
+			if ip := network.GetIP(address.SinAddr, address.SinFamily); ip != nil {
+				arg.SinAddr = ip.String()
+			}
+			arg.SinPort = uint32(address.SinPort)
+			arg.Label = a.label
+			args = append(args, arg)
 		case gt.GenericSizeType, gt.GenericU64Type:
 			var output uint64
 			var arg api.MsgGenericKprobeArgSize
 
 			err := binary.Read(r, binary.LittleEndian, &output)
 			if err != nil {
-				logger.GetLogger().WithError(err).Warnf("Size type error sizeof %d", m.Common.Size)
+				logger.GetLogger().WithError(err).Warnf("Size type error")
 			}
 
 			arg.Index = uint64(a.index)
 			arg.Value = output
 			arg.Label = a.label
-			unix.Args = append(unix.Args, arg)
+			args = append(args, arg)
 		case gt.GenericNopType:
 			// do nothing
 		case gt.GenericBpfAttr:
@@ -1463,7 +2132,7 @@ func handleMsgGenericKprobe(m *api.MsgGenericKprobe, gk *genericKprobe, r *bytes
 			length := bytes.IndexByte(output.ProgName[:], 0) // trim tailing null bytes
 			arg.ProgName = string(output.ProgName[:length])
 			arg.Label = a.label
-			unix.Args = append(unix.Args, arg)
+			args = append(args, arg)
 		case gt.GenericPerfEvent:
 			var output api.MsgGenericKprobePerfEvent
 			var arg api.MsgGenericKprobeArgPerfEvent
@@ -1478,7 +2147,7 @@ func handleMsgGenericKprobe(m *api.MsgGenericKprobe, gk *genericKprobe, r *bytes
 			arg.Config = output.Config
 			arg.ProbeOffset = output.ProbeOffset
 			arg.Label = a.label
-			unix.Args = append(unix.Args, arg)
+			args = append(args, arg)
 		case gt.GenericBpfMap:
 			var output api.MsgGenericKprobeBpfMap
 			var arg api.MsgGenericKprobeArgBpfMap
@@ -1495,7 +2164,7 @@ func handleMsgGenericKprobe(m *api.MsgGenericKprobe, gk *genericKprobe, r *bytes
 			length := bytes.IndexByte(output.MapName[:], 0) // trim tailing null bytes
 			arg.MapName = string(output.MapName[:length])
 			arg.Label = a.label
-			unix.Args = append(unix.Args, arg)
+			args = append(args, arg)
 		case gt.GenericU32Type:
 			var output uint32
 			var arg api.MsgGenericKprobeArgUInt
@@ -1508,7 +2177,7 @@ func handleMsgGenericKprobe(m *api.MsgGenericKprobe, gk *genericKprobe, r *bytes
 			arg.Index = uint64(a.index)
 			arg.Value = output
 			arg.Label = a.label
-			unix.Args = append(unix.Args, arg)
+			args = append(args, arg)
 		case gt.GenericUserNamespace:
 			var output api.MsgGenericUserNamespace
 			var arg api.MsgGenericKprobeArgUserNamespace
@@ -1522,7 +2191,7 @@ func handleMsgGenericKprobe(m *api.MsgGenericKprobe, gk *genericKprobe, r *bytes
 			arg.Gid = output.Gid
 			arg.NsInum = output.NsInum
 			arg.Label = a.label
-			unix.Args = append(unix.Args, arg)
+			args = append(args, arg)
 		case gt.GenericCapability:
 			var output api.MsgGenericKprobeCapability
 			var arg api.MsgGenericKprobeArgCapability
@@ -1533,7 +2202,7 @@ func handleMsgGenericKprobe(m *api.MsgGenericKprobe, gk *genericKprobe, r *bytes
 			}
 			arg.Value = output.Value
 			arg.Label = a.label
-			unix.Args = append(unix.Args, arg)
+			args = append(args, arg)
 		case gt.GenericLoadModule:
 			var output api.MsgGenericLoadModule
 			var arg api.MsgGenericKprobeArgLoadModule
@@ -1551,7 +2220,7 @@ func handleMsgGenericKprobe(m *api.MsgGenericKprobe, gk *genericKprobe, r *bytes
 				arg.Taints = output.Taints
 			}
 			arg.Label = a.label
-			unix.Args = append(unix.Args, arg)
+			args = append(args, arg)
 		case gt.GenericKernelModule:
 			var output api.MsgGenericLoadModule
 			var arg api.MsgGenericKprobeArgKernelModule
@@ -1568,11 +2237,79 @@ func handleMsgGenericKprobe(m *api.MsgGenericKprobe, gk *genericKprobe, r *bytes
 				arg.Taints = output.Taints
 			}
 			arg.Label = a.label
-			unix.Args = append(unix.Args, arg)
+			args = append(args, arg)
 		default:
 			logger.GetLogger().WithError(err).WithField("event-type", a.ty).Warnf("Unknown event type")
 		}
 	}
+	return args, err
+}
+
+func handleMsgGenericKprobe(m *api.MsgGenericKprobe, gk *genericKprobe, r *bytes.Reader) ([]observer.Event, error) {
+	var err error
+
+	switch m.ActionId {
+	case selectors.ActionTypeGetUrl, selectors.ActionTypeDnsLookup:
+		actionArgEntry, err := gk.actionArgs.GetEntry(idtable.EntryID{ID: int(m.ActionArgId)})
+		if err != nil {
+			logger.GetLogger().WithError(err).Warnf("Failed to find argument for id:%d", m.ActionArgId)
+			return nil, fmt.Errorf("Failed to find argument for id")
+		}
+		actionArg := actionArgEntry.(*selectors.ActionArgEntry).GetArg()
+		switch m.ActionId {
+		case selectors.ActionTypeGetUrl:
+			logger.GetLogger().WithField("URL", actionArg).Trace("Get URL Action")
+			getUrl(actionArg)
+		case selectors.ActionTypeDnsLookup:
+			logger.GetLogger().WithField("FQDN", actionArg).Trace("DNS lookup")
+			dnsLookup(actionArg)
+		}
+	}
+
+	unix := &tracing.MsgGenericKprobeUnix{}
+	unix.Common = m.Common
+	unix.ProcessKey = m.ProcessKey
+	unix.Id = m.FuncId
+	unix.Action = m.ActionId
+	unix.Tid = m.Tid
+	unix.FuncName = gk.funcName
+	unix.Namespaces = m.Namespaces
+	unix.Capabilities = m.Capabilities
+	unix.PolicyName = gk.policyName
+	unix.ActionLatency = actionLatency(gk.policyName, m.ActionId, m.Common.Ktime, m.ActionKtime)
+	unix.AuditAction = auditAction(gk.policyName, m.AuditAction)
+	unix.AuditSelector = m.AuditSelector
+
+	returnEvent := m.Common.Flags&processapi.MSG_COMMON_FLAG_RETURN != 0
+
+	var ktimeEnter uint64
+	var printers []argPrinters
+	if returnEvent {
+		// if this a return event, also read the ktime of the enter event
+		err := binary.Read(r, binary.LittleEndian, &ktimeEnter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ktimeEnter")
+		}
+		if gk.loadArgs.config.Flags&flagsLatency != 0 {
+			err = binary.Read(r, binary.LittleEndian, &unix.Latency)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read latency")
+			}
+		}
+		printers = gk.argReturnPrinters
+	} else {
+		ktimeEnter = m.Common.Ktime
+		printers = gk.argSigPrinters
+	}
+
+	if m.Common.Flags&processapi.MSG_COMMON_FLAG_STACKTRACE != 0 {
+		gk.lookupStackTrace(m.StackID, &unix.StackTrace)
+	}
+	if m.Common.Flags&processapi.MSG_COMMON_FLAG_USER_STACKTRACE != 0 {
+		gk.lookupStackTrace(m.UserStackID, &unix.UserStackTrace)
+	}
+
+	unix.Args, err = getArgs(r, printers)
 
 	// Cache return value on merge and run return filters below before
 	// passing up to notify hooks.
@@ -1734,6 +2471,7 @@ func retprobeMerge(prev pendingEvent, curr pendingEvent) (*tracing.MsgGenericKpr
 	}
 
 	kprobemetrics.MergeOkTotalInc()
+	enterEv.Latency = retEv.Latency
 
 	for _, retArg := range retEv.Args {
 		index := retArg.GetIndex()
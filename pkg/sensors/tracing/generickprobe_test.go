@@ -6,16 +6,21 @@ package tracing
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
+	"sort"
 	"testing"
 	"time"
 
+	"github.com/cilium/tetragon/pkg/api/processapi"
 	"github.com/cilium/tetragon/pkg/bpf"
+	"github.com/cilium/tetragon/pkg/grpc/tracing"
 	"github.com/cilium/tetragon/pkg/idtable"
 	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
 	"github.com/cilium/tetragon/pkg/sensors"
 	"github.com/cilium/tetragon/pkg/sensors/base"
 	tus "github.com/cilium/tetragon/pkg/testutils/sensors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -77,7 +82,7 @@ func Test_SensorDestroyHook(t *testing.T) {
 			Call:    "test_symbol",
 			Syscall: false,
 		},
-	}, 0, "test_policy", nil, nil)
+	}, 0, "test_policy", nil, nil, false, false, nil)
 	if err != nil {
 		t.Errorf("createGenericKprobeSensor err expected: nil, got: %s", err)
 	}
@@ -110,6 +115,175 @@ func Test_SensorDestroyHook(t *testing.T) {
 	}
 }
 
+// Test_SensorAttachModeFallback tests that a kprobe that cannot be attached
+// with fentry falls back to kprobes.
+func Test_SensorAttachModeFallback(t *testing.T) {
+	// test_symbol is not in the kernel BTF, so it cannot be attached with
+	// fentry whatever the kernel features are
+	sensor, err := createGenericKprobeSensor("test_sensor", []v1alpha1.KProbeSpec{
+		{
+			Call:       "test_symbol",
+			Syscall:    false,
+			AttachMode: attachModeFentry,
+		},
+	}, 0, "test_policy", nil, nil, false, false, nil)
+	if err != nil {
+		t.Fatalf("createGenericKprobeSensor err expected: nil, got: %s", err)
+	}
+	sensor.Loaded = true
+	defer sensor.Destroy()
+
+	assert.Contains(t, []string{attachModeKprobe, attachModeKprobeMulti}, sensor.AttachModes["test_symbol"])
+	for _, prog := range sensor.Progs {
+		assert.False(t, prog.Fentry)
+	}
+}
+
+func Test_withCompatKprobes(t *testing.T) {
+	kprobes := []v1alpha1.KProbeSpec{
+		{Call: "sys_lseek", Syscall: true, IncludeCompat: true},
+		{Call: "fd_install", Syscall: false},
+		{Call: "sys_read", Syscall: true},
+	}
+
+	ret := withCompatKprobes(kprobes)
+	assert.Len(t, kprobes, 3)
+	assert.Equal(t, kprobes, ret[:3])
+	assert.Equal(t, []v1alpha1.KProbeSpec{
+		{Call: "sys_lseek", Syscall: true, Compat: true},
+	}, ret[3:])
+
+	// no includeCompat
+	ret = withCompatKprobes(kprobes[1:])
+	assert.Equal(t, kprobes[1:], ret)
+}
+
+func Test_expandKprobeCalls(t *testing.T) {
+	kprobes := []v1alpha1.KProbeSpec{
+		{Call: "fd_install"},
+		{Calls: []string{"security_file_open", "security_file_permission", "security_file_open"}},
+		{Calls: []string{"lseek", "read"}, Syscall: true},
+		{Call: "list:files"},
+	}
+	lists := []v1alpha1.ListSpec{{Name: "files", Values: []string{"vfs_read", "vfs_write"}}}
+
+	retKprobes, retLists, err := expandKprobeCalls(kprobes, lists)
+	assert.NoError(t, err)
+	assert.Equal(t, []v1alpha1.KProbeSpec{
+		{Call: "fd_install"},
+		{Call: "list:kprobes[1].calls"},
+		{Call: "list:kprobes[2].calls", Syscall: true},
+		{Call: "list:files"},
+	}, retKprobes)
+	assert.Equal(t, []v1alpha1.ListSpec{
+		{Name: "files", Values: []string{"vfs_read", "vfs_write"}},
+		{Name: "kprobes[1].calls", Values: []string{"security_file_open", "security_file_permission"}},
+		{Name: "kprobes[2].calls", Type: "syscalls", Values: []string{"lseek", "read"}},
+	}, retLists)
+	// the policy is not modified
+	assert.Equal(t, []string{"lseek", "read"}, kprobes[2].Calls)
+	assert.Len(t, lists, 1)
+
+	// nothing to expand
+	retKprobes, retLists, err = expandKprobeCalls(kprobes[:1], lists)
+	assert.NoError(t, err)
+	assert.Equal(t, kprobes[:1], retKprobes)
+	assert.Equal(t, lists, retLists)
+
+	_, _, err = expandKprobeCalls([]v1alpha1.KProbeSpec{{Call: "fd_install", Calls: []string{"fd_install"}}}, nil)
+	assert.Error(t, err)
+	_, _, err = expandKprobeCalls([]v1alpha1.KProbeSpec{{}}, nil)
+	assert.Error(t, err)
+	_, _, err = expandKprobeCalls([]v1alpha1.KProbeSpec{{Calls: []string{"list:files"}}}, lists)
+	assert.Error(t, err)
+}
+
+func Test_retprobeMergeLatency(t *testing.T) {
+	enter := pendingEvent{ev: &tracing.MsgGenericKprobeUnix{FuncName: "vfs_read"}}
+	ret := pendingEvent{ev: &tracing.MsgGenericKprobeUnix{FuncName: "vfs_read", Latency: 1234}, returnEvent: true}
+
+	ev, _ := retprobeMerge(enter, ret)
+	assert.Equal(t, uint64(1234), ev.Latency)
+
+	enter = pendingEvent{ev: &tracing.MsgGenericKprobeUnix{FuncName: "vfs_read"}}
+	ret = pendingEvent{ev: &tracing.MsgGenericKprobeUnix{FuncName: "vfs_read", Latency: 5678}, returnEvent: true}
+	ev, _ = retprobeMerge(ret, enter)
+	assert.Equal(t, uint64(5678), ev.Latency)
+}
+
+func Test_getMetaValue(t *testing.T) {
+	meta, err := getMetaValue(&v1alpha1.KProbeArg{SizeArgIndex: 3, ReturnCopy: true, MaxDataSize: 256})
+	require.NoError(t, err)
+	assert.Equal(t, 3|argReturnCopyBit|256<<argMaxDataSizeShift, meta)
+
+	meta, err = getMetaValue(&v1alpha1.KProbeArg{MaxData: true, MaxDataSize: argMaxDataSizeMax})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0xffffff00|argMaxDataBit), uint32(meta))
+
+	_, err = getMetaValue(&v1alpha1.KProbeArg{MaxDataSize: argMaxDataSizeMax + 1})
+	assert.Error(t, err)
+}
+
+func Test_parseStringArray(t *testing.T) {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, []uint32{2, stringArrayTruncated})
+	for _, s := range []string{"/bin/ls", "-l"} {
+		binary.Write(&buf, binary.LittleEndian, uint32(len(s)))
+		buf.WriteString(s)
+	}
+	values, truncated, err := parseStringArray(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/bin/ls", "-l"}, values)
+	assert.True(t, truncated)
+
+	buf.Reset()
+	binary.Write(&buf, binary.LittleEndian, []uint32{0, 0})
+	values, truncated, err = parseStringArray(&buf)
+	require.NoError(t, err)
+	assert.Empty(t, values)
+	assert.False(t, truncated)
+
+	buf.Reset()
+	binary.Write(&buf, binary.LittleEndian, []uint32{maxStringArrayEntries + 1, 0})
+	_, _, err = parseStringArray(&buf)
+	assert.Error(t, err)
+}
+
+func Test_countMessages(t *testing.T) {
+	gk := &genericKprobe{funcName: "__kmalloc", policyName: "count", hasCount: true}
+	genericKprobeTable.AddEntry(gk)
+	defer genericKprobeTable.RemoveEntry(gk.tableId)
+
+	id := uint32(gk.tableId.ID)
+	key1 := countKey{FuncId: id, Pid: 1, Ktime: 100}
+	key2 := countKey{FuncId: id, Pid: 2, Ktime: 200}
+	key3 := countKey{FuncId: id, Pid: 3, Ktime: 300}
+	// unknown kprobe
+	key4 := countKey{FuncId: id + 1, Pid: 4, Ktime: 400}
+
+	prev := map[string]map[countKey]uint64{
+		"prefix": {key1: 10, key2: 5, key3: 50},
+	}
+	cur := map[string]map[countKey]uint64{
+		// key2 did not change, key3 was evicted and added again
+		"prefix": {key1: 15, key2: 5, key3: 7, key4: 1},
+	}
+	msgs := countMessages(prev, cur)
+	sort.Slice(msgs, func(i, j int) bool {
+		return msgs[i].ProcessKey.Pid < msgs[j].ProcessKey.Pid
+	})
+	require.Len(t, msgs, 2)
+	assert.Equal(t, &tracing.MsgProcessKprobeCount{
+		ProcessKey: processapi.MsgExecveKey{Pid: 1, Ktime: 100},
+		FuncName:   "__kmalloc",
+		PolicyName: "count",
+		Count:      5,
+		Window:     countReportInterval,
+	}, msgs[0])
+	assert.Equal(t, uint32(3), msgs[1].ProcessKey.Pid)
+	assert.Equal(t, uint64(7), msgs[1].Count)
+}
+
 // Test_Kprobe_DisableEnablePolicy tests that disabling and enabling a tracing
 // policy containing a kprobe works. This is following a regression:
 // https://github.com/cilium/tetragon/issues/1489
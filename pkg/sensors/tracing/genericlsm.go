@@ -0,0 +1,374 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/tetragon/pkg/api/ops"
+	api "github.com/cilium/tetragon/pkg/api/tracingapi"
+	"github.com/cilium/tetragon/pkg/features"
+	gt "github.com/cilium/tetragon/pkg/generictypes"
+	"github.com/cilium/tetragon/pkg/grpc/tracing"
+	"github.com/cilium/tetragon/pkg/idtable"
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"github.com/cilium/tetragon/pkg/kernels"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/observer"
+	"github.com/cilium/tetragon/pkg/option"
+	"github.com/cilium/tetragon/pkg/policyfilter"
+	"github.com/cilium/tetragon/pkg/selectors"
+	"github.com/cilium/tetragon/pkg/sensors"
+	"github.com/cilium/tetragon/pkg/sensors/base"
+	"github.com/cilium/tetragon/pkg/sensors/program"
+	"github.com/cilium/tetragon/pkg/tracingpolicy"
+)
+
+type observerLsmSensor struct {
+	name string
+}
+
+var (
+	lsmTable idtable.Table
+)
+
+type genericLsm struct {
+	tableId       idtable.EntryID
+	pinPathPrefix string
+	config        *api.EventConfig
+	hook          string
+	selectors     *selectors.KernelSelectorState
+	// argPrinters describe the arguments read by the LSM hook
+	argPrinters []argPrinters
+	// policyName is the name of the policy that this LSM hook belongs to
+	policyName string
+}
+
+func (g *genericLsm) SetID(id idtable.EntryID) {
+	g.tableId = id
+}
+
+func init() {
+	lsm := &observerLsmSensor{
+		name: "lsm sensor",
+	}
+	sensors.RegisterProbeType("generic_lsm", lsm)
+	sensors.RegisterPolicyHandlerAtInit(lsm.name, lsm)
+	observer.RegisterEventHandlerAtInit(ops.MSG_OP_GENERIC_LSM, handleGenericLsm)
+}
+
+func genericLsmTableGet(id idtable.EntryID) (*genericLsm, error) {
+	entry, err := lsmTable.GetEntry(id)
+	if err != nil {
+		return nil, fmt.Errorf("getting entry from lsmTable failed with: %w", err)
+	}
+	val, ok := entry.(*genericLsm)
+	if !ok {
+		return nil, fmt.Errorf("getting entry from lsmTable failed with: got invalid type: %T (%v)", entry, entry)
+	}
+	return val, nil
+}
+
+func handleGenericLsm(r *bytes.Reader) ([]observer.Event, error) {
+	m := api.MsgGenericKprobe{}
+	err := binary.Read(r, binary.LittleEndian, &m)
+	if err != nil {
+		logger.GetLogger().WithError(err).Warnf("Failed to read process call msg")
+		return nil, fmt.Errorf("Failed to read process call msg")
+	}
+
+	lsmEntry, err := genericLsmTableGet(idtable.EntryID{ID: int(m.FuncId)})
+	if err != nil {
+		logger.GetLogger().WithError(err).Warnf("Failed to match id:%d", m.FuncId)
+		return nil, fmt.Errorf("Failed to match id")
+	}
+
+	unix := &tracing.MsgGenericLsmUnix{}
+	unix.Common = m.Common
+	unix.ProcessKey = m.ProcessKey
+	unix.Id = m.FuncId
+	unix.Action = m.ActionId
+	unix.Tid = m.Tid
+	unix.Hook = lsmEntry.hook
+	unix.PolicyName = lsmEntry.policyName
+	unix.ActionLatency = actionLatency(lsmEntry.policyName, m.ActionId, m.Common.Ktime, m.ActionKtime)
+	unix.AuditAction = auditAction(lsmEntry.policyName, m.AuditAction)
+	unix.AuditSelector = m.AuditSelector
+	unix.Args, err = getArgs(r, lsmEntry.argPrinters)
+
+	return []observer.Event{unix}, err
+}
+
+func (k *observerLsmSensor) LoadProbe(args sensors.LoadProbeArgs) error {
+	load := args.Load
+
+	lsmEntry, ok := load.LoaderData.(*genericLsm)
+	if !ok {
+		return fmt.Errorf("invalid loadData type: expecting idtable.EntryID and got: %T (%v)", load.LoaderData, load.LoaderData)
+	}
+
+	// config_map data
+	var configData bytes.Buffer
+	binary.Write(&configData, binary.LittleEndian, lsmEntry.config)
+
+	// filter_map data
+	selBuff := lsmEntry.selectors.Buffer()
+
+	mapLoad := []*program.MapLoad{
+		{
+			Index: 0,
+			Name:  "config_map",
+			Load: func(m *ebpf.Map, index uint32) error {
+				return m.Update(index, configData.Bytes()[:], ebpf.UpdateAny)
+			},
+		},
+		{
+			Index: 0,
+			Name:  "filter_map",
+			Load: func(m *ebpf.Map, index uint32) error {
+				return m.Update(index, selBuff[:], ebpf.UpdateAny)
+			},
+		},
+		{
+			Index: 0,
+			Name:  "sel_names_map",
+			Load: func(outerMap *ebpf.Map, index uint32) error {
+				return populateBinariesMaps(lsmEntry.selectors, nil, lsmEntry.pinPathPrefix, outerMap)
+			},
+		},
+	}
+
+	load.MapLoad = append(load.MapLoad, mapLoad...)
+
+	sensors.AllPrograms = append(sensors.AllPrograms, load)
+
+	if err := program.LoadLSMProgram(args.BPFDir, args.MapDir, args.Load, args.Verbose); err != nil {
+		return err
+	}
+
+	m, err := ebpf.LoadPinnedMap(filepath.Join(args.MapDir, base.NamesMap.Name), nil)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	for i, path := range lsmEntry.selectors.GetNewBinaryMappings() {
+		writeBinaryMap(m, i, path)
+	}
+
+	if err := writeBinaryPrefixMaps(args.MapDir, lsmEntry.selectors); err != nil {
+		return err
+	}
+
+	logger.GetLogger().WithField("flags", flagsString(lsmEntry.config.Flags)).
+		Infof("Loaded generic LSM program: %s -> %s", args.Load.Name, lsmEntry.hook)
+	return nil
+}
+
+// lsmHookName returns the name of the LSM hook of spec, without the
+// security_ prefix of the kernel function.
+func lsmHookName(spec *v1alpha1.LsmHookSpec) string {
+	return strings.TrimPrefix(spec.Hook, "security_")
+}
+
+func isValidLsmSelectors(sels []v1alpha1.KProbeSelector) error {
+	for _, s := range sels {
+		if len(s.MatchReturnArgs) > 0 {
+			return fmt.Errorf("matchReturnArgs selectors are not supported for LSM hooks")
+		}
+	}
+	if selectors.HasCount(sels) {
+		return fmt.Errorf("count action is not supported for LSM hooks")
+	}
+	return nil
+}
+
+// addLsmArgs sets the argument types of config and returns the printers of
+// the arguments.
+func addLsmArgs(spec *v1alpha1.LsmHookSpec, config *api.EventConfig) ([]argPrinters, error) {
+	var printers []argPrinters
+	var argsSet [api.EventConfigMaxArgs]bool
+
+	for j, a := range spec.Args {
+		argType := gt.GenericTypeFromString(a.Type)
+		if argType == gt.GenericInvalidType {
+			return nil, fmt.Errorf("Arg(%d) type '%s' unsupported", j, a.Type)
+		}
+		argMValue, err := getMetaValue(&a)
+		if err != nil {
+			return nil, err
+		}
+		if argReturnCopy(argMValue) {
+			return nil, fmt.Errorf("Arg(%d): returnCopy is not supported for LSM hooks", j)
+		}
+		if a.Index > 4 {
+			return nil, fmt.Errorf("Error add arg: ArgType %s Index %d out of bounds",
+				a.Type, int(a.Index))
+		}
+		config.Arg[a.Index] = int32(argType)
+		config.ArgM[a.Index] = uint32(argMValue)
+		argsSet[a.Index] = true
+		printers = append(printers, argPrinters{index: j, ty: argType, maxData: a.MaxData, label: a.Label})
+	}
+
+	// Mark remaining arguments as 'nops' the kernel side will skip
+	// copying 'nop' args.
+	for j, set := range argsSet {
+		if !set {
+			config.Arg[j] = gt.GenericNopType
+			config.ArgM[j] = 0
+		}
+	}
+	return printers, nil
+}
+
+func createGenericLsmSensor(
+	name string,
+	hooks []v1alpha1.LsmHookSpec,
+	policyName string,
+	audit bool,
+) (_ *sensors.Sensor, err error) {
+	var progs []*program.Program
+	var maps []*program.Map
+
+	// the matchBinaries prefixes of the selectors are released with the
+	// sensor
+	var states []*selectors.KernelSelectorState
+	defer func() {
+		if err != nil {
+			releaseSelectorsBinaryPrefixes(states)
+		}
+	}()
+
+	sensorPath := name
+
+	loadProgName := "bpf_generic_lsm_v53.o"
+	if kernels.EnableV61Progs() {
+		loadProgName = "bpf_generic_lsm_v61.o"
+	}
+
+	for i := range hooks {
+		spec := &hooks[i]
+		config := &api.EventConfig{}
+		hook := lsmHookName(spec)
+
+		if hook == "" {
+			return nil, fmt.Errorf("lsmhooks[%d]: hook name is empty", i)
+		}
+
+		if err := isValidLsmSelectors(spec.Selectors); err != nil {
+			return nil, fmt.Errorf("lsmhooks[%d]: %w", i, err)
+		}
+
+		printers, err := addLsmArgs(spec, config)
+		if err != nil {
+			return nil, err
+		}
+
+		// Parse Filters into kernel filter logic
+		lsmSelectorState, err := selectors.InitKernelSelectorState(spec.Selectors, spec.Args, nil, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, lsmSelectorState)
+
+		if err := features.LSM.Require(fmt.Sprintf("LSM hook '%s'", hook)); err != nil {
+			return nil, err
+		}
+
+		lsmEntry := &genericLsm{
+			tableId:     idtable.UninitializedEntryID,
+			config:      config,
+			hook:        hook,
+			selectors:   lsmSelectorState,
+			argPrinters: printers,
+			policyName:  policyName,
+		}
+
+		lsmTable.AddEntry(lsmEntry)
+		id := lsmEntry.tableId.ID
+
+		lsmEntry.pinPathPrefix = sensors.PathJoin(sensorPath, fmt.Sprintf("%d", id))
+		config.FuncId = uint32(id)
+
+		if selectors.HasEarlyBinaryFilter(spec.Selectors) {
+			config.Flags |= flagsEarlyFilter
+		}
+		if audit {
+			config.Flags |= flagsAudit
+		}
+
+		pinPath := lsmEntry.pinPathPrefix
+		pinProg := sensors.PathJoin(pinPath, "prog")
+
+		load := program.Builder(
+			path.Join(option.Config.HubbleLib, loadProgName),
+			hook,
+			"lsm/generic_lsm_core",
+			pinProg,
+			"generic_lsm").
+			SetLoaderData(lsmEntry)
+
+		// The override program denies the operation with the error set
+		// by the Override action of the selectors.
+		load.Override = hasLsmOverride(spec)
+
+		progs = append(progs, load)
+
+		configMap := program.MapBuilderPin("config_map", sensors.PathJoin(pinPath, "config_map"), load)
+		tailCalls := program.MapBuilderPin("lsm_calls", sensors.PathJoin(pinPath, "lsm_calls"), load)
+		filterMap := program.MapBuilderPin("filter_map", sensors.PathJoin(pinPath, "filter_map"), load)
+		selNamesMap := program.MapBuilderPin("sel_names_map", sensors.PathJoin(pinPath, "sel_names_map"), load)
+		overrideTasks := program.MapBuilderPin("override_tasks", sensors.PathJoin(pinPath, "override_tasks"), load)
+		maps = append(maps, configMap, tailCalls, filterMap, selNamesMap, overrideTasks)
+	}
+
+	return &sensors.Sensor{
+		Name:  name,
+		Progs: progs,
+		Maps:  maps,
+		DestroyHook: func() error {
+			return releaseSelectorsBinaryPrefixes(states)
+		},
+	}, nil
+}
+
+// hasLsmOverride returns true if one of the selectors of spec has an
+// Override action.
+func hasLsmOverride(spec *v1alpha1.LsmHookSpec) bool {
+	for _, s := range spec.Selectors {
+		for _, action := range s.MatchActions {
+			if selectors.ActionTypeFromString(action.Action) == selectors.ActionTypeOverride {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (k *observerLsmSensor) PolicyHandler(
+	p tracingpolicy.TracingPolicy,
+	fid policyfilter.PolicyID,
+) (*sensors.Sensor, error) {
+	spec := p.TpSpec()
+
+	if len(spec.LsmHooks) == 0 {
+		return nil, nil
+	}
+
+	if fid != policyfilter.NoFilterID {
+		return nil, fmt.Errorf("lsm sensor does not implement policy filtering")
+	}
+
+	name := fmt.Sprintf("glsm-sensor-%d", atomic.AddUint64(&sensorCounter, 1))
+	policyName := p.TpName()
+	return createGenericLsmSensor(name, spec.LsmHooks, policyName, isAudit(spec))
+}
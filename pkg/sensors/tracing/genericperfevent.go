@@ -0,0 +1,447 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/tetragon/pkg/api/ops"
+	api "github.com/cilium/tetragon/pkg/api/tracingapi"
+	gt "github.com/cilium/tetragon/pkg/generictypes"
+	"github.com/cilium/tetragon/pkg/grpc/tracing"
+	"github.com/cilium/tetragon/pkg/idtable"
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"github.com/cilium/tetragon/pkg/kernels"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/observer"
+	"github.com/cilium/tetragon/pkg/option"
+	"github.com/cilium/tetragon/pkg/policyfilter"
+	"github.com/cilium/tetragon/pkg/selectors"
+	"github.com/cilium/tetragon/pkg/sensors"
+	"github.com/cilium/tetragon/pkg/sensors/base"
+	"github.com/cilium/tetragon/pkg/sensors/program"
+	"github.com/cilium/tetragon/pkg/tracingpolicy"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	perfEventTypeSoftware = "software"
+	perfEventTypeHardware = "hardware"
+
+	// perfEventDefaultFrequency is the sample frequency of the perf events
+	// without sample period or frequency
+	perfEventDefaultFrequency = 100
+	// perfEventDefaultWindow is the default window of the thresholds
+	perfEventDefaultWindow = time.Second
+)
+
+var (
+	perfEventTable idtable.Table
+
+	perfEventSoftwareConfigs = map[string]uint64{
+		"cpu-clock":        unix.PERF_COUNT_SW_CPU_CLOCK,
+		"task-clock":       unix.PERF_COUNT_SW_TASK_CLOCK,
+		"page-faults":      unix.PERF_COUNT_SW_PAGE_FAULTS,
+		"context-switches": unix.PERF_COUNT_SW_CONTEXT_SWITCHES,
+		"cpu-migrations":   unix.PERF_COUNT_SW_CPU_MIGRATIONS,
+		"minor-faults":     unix.PERF_COUNT_SW_PAGE_FAULTS_MIN,
+		"major-faults":     unix.PERF_COUNT_SW_PAGE_FAULTS_MAJ,
+		"alignment-faults": unix.PERF_COUNT_SW_ALIGNMENT_FAULTS,
+		"emulation-faults": unix.PERF_COUNT_SW_EMULATION_FAULTS,
+	}
+
+	perfEventHardwareConfigs = map[string]uint64{
+		"cpu-cycles":              unix.PERF_COUNT_HW_CPU_CYCLES,
+		"instructions":            unix.PERF_COUNT_HW_INSTRUCTIONS,
+		"cache-references":        unix.PERF_COUNT_HW_CACHE_REFERENCES,
+		"cache-misses":            unix.PERF_COUNT_HW_CACHE_MISSES,
+		"branch-instructions":     unix.PERF_COUNT_HW_BRANCH_INSTRUCTIONS,
+		"branch-misses":           unix.PERF_COUNT_HW_BRANCH_MISSES,
+		"bus-cycles":              unix.PERF_COUNT_HW_BUS_CYCLES,
+		"stalled-cycles-frontend": unix.PERF_COUNT_HW_STALLED_CYCLES_FRONTEND,
+		"stalled-cycles-backend":  unix.PERF_COUNT_HW_STALLED_CYCLES_BACKEND,
+		"ref-cpu-cycles":          unix.PERF_COUNT_HW_REF_CPU_CYCLES,
+	}
+)
+
+type observerPerfEventSensor struct {
+	name string
+}
+
+// perfEventThreshold is the value of the perf_event_threshold_map, see
+// struct perf_event_threshold in bpf_generic_perf_event.c
+type perfEventThreshold struct {
+	Samples uint64
+	Window  uint64
+}
+
+type genericPerfEvent struct {
+	tableId       idtable.EntryID
+	pinPathPrefix string
+	config        *api.EventConfig
+	threshold     perfEventThreshold
+	// eventType and event are the type and name of the perf event
+	eventType string
+	event     string
+	selectors *selectors.KernelSelectorState
+	// policyName is the name of the policy that this perf event belongs to
+	policyName string
+}
+
+func (g *genericPerfEvent) SetID(id idtable.EntryID) {
+	g.tableId = id
+}
+
+func init() {
+	perfEvent := &observerPerfEventSensor{
+		name: "perf event sensor",
+	}
+	sensors.RegisterProbeType("generic_perf_event", perfEvent)
+	sensors.RegisterPolicyHandlerAtInit(perfEvent.name, perfEvent)
+	observer.RegisterEventHandlerAtInit(ops.MSG_OP_GENERIC_PERF_EVENT, handleGenericPerfEvent)
+}
+
+func genericPerfEventTableGet(id idtable.EntryID) (*genericPerfEvent, error) {
+	entry, err := perfEventTable.GetEntry(id)
+	if err != nil {
+		return nil, fmt.Errorf("getting entry from perfEventTable failed with: %w", err)
+	}
+	val, ok := entry.(*genericPerfEvent)
+	if !ok {
+		return nil, fmt.Errorf("getting entry from perfEventTable failed with: got invalid type: %T (%v)", entry, entry)
+	}
+	return val, nil
+}
+
+func handleGenericPerfEvent(r *bytes.Reader) ([]observer.Event, error) {
+	m := api.MsgGenericKprobe{}
+	err := binary.Read(r, binary.LittleEndian, &m)
+	if err != nil {
+		logger.GetLogger().WithError(err).Warnf("Failed to read process call msg")
+		return nil, fmt.Errorf("Failed to read process call msg")
+	}
+
+	perfEntry, err := genericPerfEventTableGet(idtable.EntryID{ID: int(m.FuncId)})
+	if err != nil {
+		logger.GetLogger().WithError(err).Warnf("Failed to match id:%d", m.FuncId)
+		return nil, fmt.Errorf("Failed to match id")
+	}
+
+	msg := &tracing.MsgGenericPerfEventUnix{}
+	msg.Common = m.Common
+	msg.ProcessKey = m.ProcessKey
+	msg.Id = m.FuncId
+	msg.Action = m.ActionId
+	msg.Tid = m.Tid
+	msg.Type = perfEntry.eventType
+	msg.Event = perfEntry.event
+	msg.PolicyName = perfEntry.policyName
+	msg.AuditAction = auditAction(perfEntry.policyName, m.AuditAction)
+	msg.AuditSelector = m.AuditSelector
+
+	// The number of samples and the sample period are the two u64
+	// arguments of the event, see generic_perf_event.
+	if err := binary.Read(r, binary.LittleEndian, &msg.Samples); err != nil {
+		return nil, fmt.Errorf("failed to read perf event samples: %w", err)
+	}
+	if err := binary.Read(r, binary.LittleEndian, &msg.SamplePeriod); err != nil {
+		return nil, fmt.Errorf("failed to read perf event sample period: %w", err)
+	}
+
+	return []observer.Event{msg}, nil
+}
+
+func (k *observerPerfEventSensor) LoadProbe(args sensors.LoadProbeArgs) error {
+	load := args.Load
+
+	perfEntry, ok := load.LoaderData.(*genericPerfEvent)
+	if !ok {
+		return fmt.Errorf("invalid loadData type: expecting idtable.EntryID and got: %T (%v)", load.LoaderData, load.LoaderData)
+	}
+
+	// config_map data
+	var configData bytes.Buffer
+	binary.Write(&configData, binary.LittleEndian, perfEntry.config)
+
+	// filter_map data
+	selBuff := perfEntry.selectors.Buffer()
+
+	mapLoad := []*program.MapLoad{
+		{
+			Index: 0,
+			Name:  "config_map",
+			Load: func(m *ebpf.Map, index uint32) error {
+				return m.Update(index, configData.Bytes()[:], ebpf.UpdateAny)
+			},
+		},
+		{
+			Index: 0,
+			Name:  "filter_map",
+			Load: func(m *ebpf.Map, index uint32) error {
+				return m.Update(index, selBuff[:], ebpf.UpdateAny)
+			},
+		},
+		{
+			Index: 0,
+			Name:  "perf_event_threshold_map",
+			Load: func(m *ebpf.Map, index uint32) error {
+				return m.Update(index, perfEntry.threshold, ebpf.UpdateAny)
+			},
+		},
+		{
+			Index: 0,
+			Name:  "sel_names_map",
+			Load: func(outerMap *ebpf.Map, index uint32) error {
+				return populateBinariesMaps(perfEntry.selectors, nil, perfEntry.pinPathPrefix, outerMap)
+			},
+		},
+	}
+
+	load.MapLoad = append(load.MapLoad, mapLoad...)
+
+	sensors.AllPrograms = append(sensors.AllPrograms, load)
+
+	if err := program.LoadPerfEventProgram(args.BPFDir, args.MapDir, args.Load, args.Verbose); err != nil {
+		return err
+	}
+
+	m, err := ebpf.LoadPinnedMap(filepath.Join(args.MapDir, base.NamesMap.Name), nil)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	for i, path := range perfEntry.selectors.GetNewBinaryMappings() {
+		writeBinaryMap(m, i, path)
+	}
+
+	if err := writeBinaryPrefixMaps(args.MapDir, perfEntry.selectors); err != nil {
+		return err
+	}
+
+	logger.GetLogger().WithField("flags", flagsString(perfEntry.config.Flags)).
+		Infof("Loaded generic perf event program: %s -> %s/%s", args.Load.Name, perfEntry.eventType, perfEntry.event)
+	return nil
+}
+
+// perfEventAttachData returns the attributes of the perf event of spec.
+func perfEventAttachData(spec *v1alpha1.PerfEventSpec) (*program.PerfEventAttachData, error) {
+	data := &program.PerfEventAttachData{}
+
+	var configs map[string]uint64
+	switch spec.Type {
+	case perfEventTypeSoftware:
+		data.Type = unix.PERF_TYPE_SOFTWARE
+		configs = perfEventSoftwareConfigs
+	case perfEventTypeHardware:
+		data.Type = unix.PERF_TYPE_HARDWARE
+		configs = perfEventHardwareConfigs
+	default:
+		return nil, fmt.Errorf("invalid perf event type '%s', expecting %s or %s",
+			spec.Type, perfEventTypeSoftware, perfEventTypeHardware)
+	}
+
+	config, ok := configs[spec.Event]
+	if !ok {
+		return nil, fmt.Errorf("unknown %s perf event '%s'", spec.Type, spec.Event)
+	}
+	data.Config = config
+
+	switch {
+	case spec.SamplePeriod != 0 && spec.SampleFrequency != 0:
+		return nil, fmt.Errorf("samplePeriod and sampleFrequency are exclusive")
+	case spec.SamplePeriod != 0:
+		data.Sample = spec.SamplePeriod
+	case spec.SampleFrequency != 0:
+		data.Sample = spec.SampleFrequency
+		data.Freq = true
+	default:
+		data.Sample = perfEventDefaultFrequency
+		data.Freq = true
+	}
+	return data, nil
+}
+
+// perfEventThresholdFromSpec returns the threshold of the perf event of
+// spec, zero if every sample is reported.
+func perfEventThresholdFromSpec(spec *v1alpha1.PerfEventSpec) (perfEventThreshold, error) {
+	if spec.Threshold == nil {
+		return perfEventThreshold{}, nil
+	}
+	if spec.Threshold.Samples == 0 {
+		return perfEventThreshold{}, fmt.Errorf("threshold samples must be at least 1")
+	}
+	window := perfEventDefaultWindow
+	if spec.Threshold.Window != "" {
+		var err error
+		window, err = time.ParseDuration(spec.Threshold.Window)
+		if err != nil {
+			return perfEventThreshold{}, fmt.Errorf("invalid threshold window '%s': %w", spec.Threshold.Window, err)
+		}
+		if window <= 0 {
+			return perfEventThreshold{}, fmt.Errorf("threshold window must be positive")
+		}
+	}
+	return perfEventThreshold{
+		Samples: spec.Threshold.Samples,
+		Window:  uint64(window.Nanoseconds()),
+	}, nil
+}
+
+func isValidPerfEventSelectors(sels []v1alpha1.KProbeSelector) error {
+	for _, s := range sels {
+		if len(s.MatchArgs) > 0 || len(s.MatchReturnArgs) > 0 {
+			return fmt.Errorf("matchArgs and matchReturnArgs selectors are not supported for perf events")
+		}
+		for _, a := range s.MatchActions {
+			switch selectors.ActionTypeFromString(a.Action) {
+			case selectors.ActionTypePost, selectors.ActionTypeNoPost,
+				selectors.ActionTypeSigKill, selectors.ActionTypeSignal:
+			default:
+				return fmt.Errorf("action '%s' is not supported for perf events", a.Action)
+			}
+		}
+	}
+	return nil
+}
+
+func createGenericPerfEventSensor(
+	name string,
+	perfEvents []v1alpha1.PerfEventSpec,
+	policyName string,
+	audit bool,
+) (_ *sensors.Sensor, err error) {
+	var progs []*program.Program
+	var maps []*program.Map
+
+	// the matchBinaries prefixes of the selectors are released with the
+	// sensor
+	var states []*selectors.KernelSelectorState
+	defer func() {
+		if err != nil {
+			releaseSelectorsBinaryPrefixes(states)
+		}
+	}()
+
+	sensorPath := name
+
+	loadProgName := "bpf_generic_perf_event_v53.o"
+	if kernels.EnableV61Progs() {
+		loadProgName = "bpf_generic_perf_event_v61.o"
+	}
+
+	for i := range perfEvents {
+		spec := &perfEvents[i]
+		config := &api.EventConfig{}
+
+		attachData, err := perfEventAttachData(spec)
+		if err != nil {
+			return nil, fmt.Errorf("perfEvents[%d]: %w", i, err)
+		}
+
+		threshold, err := perfEventThresholdFromSpec(spec)
+		if err != nil {
+			return nil, fmt.Errorf("perfEvents[%d]: %w", i, err)
+		}
+
+		if err := isValidPerfEventSelectors(spec.Selectors); err != nil {
+			return nil, fmt.Errorf("perfEvents[%d]: %w", i, err)
+		}
+
+		// The number of samples and the sample period are reported as the
+		// first two arguments, the others are skipped.
+		config.Arg[0] = gt.GenericU64Type
+		config.Arg[1] = gt.GenericU64Type
+		for j := 2; j < api.EventConfigMaxArgs; j++ {
+			config.Arg[j] = gt.GenericNopType
+		}
+
+		// Parse Filters into kernel filter logic
+		perfSelectorState, err := selectors.InitKernelSelectorState(spec.Selectors, nil, nil, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, perfSelectorState)
+
+		perfEntry := &genericPerfEvent{
+			tableId:    idtable.UninitializedEntryID,
+			config:     config,
+			threshold:  threshold,
+			eventType:  spec.Type,
+			event:      spec.Event,
+			selectors:  perfSelectorState,
+			policyName: policyName,
+		}
+
+		perfEventTable.AddEntry(perfEntry)
+		id := perfEntry.tableId.ID
+
+		perfEntry.pinPathPrefix = sensors.PathJoin(sensorPath, fmt.Sprintf("%d", id))
+		config.FuncId = uint32(id)
+
+		if selectors.HasEarlyBinaryFilter(spec.Selectors) {
+			config.Flags |= flagsEarlyFilter
+		}
+		if audit {
+			config.Flags |= flagsAudit
+		}
+
+		pinPath := perfEntry.pinPathPrefix
+		pinProg := sensors.PathJoin(pinPath, "prog")
+
+		load := program.Builder(
+			path.Join(option.Config.HubbleLib, loadProgName),
+			fmt.Sprintf("%s/%s", spec.Type, spec.Event),
+			"perf_event/generic_perf_event",
+			pinProg,
+			"generic_perf_event").
+			SetLoaderData(perfEntry).
+			SetAttachData(attachData)
+
+		progs = append(progs, load)
+
+		configMap := program.MapBuilderPin("config_map", sensors.PathJoin(pinPath, "config_map"), load)
+		tailCalls := program.MapBuilderPin("perf_event_calls", sensors.PathJoin(pinPath, "perf_event_calls"), load)
+		filterMap := program.MapBuilderPin("filter_map", sensors.PathJoin(pinPath, "filter_map"), load)
+		selNamesMap := program.MapBuilderPin("sel_names_map", sensors.PathJoin(pinPath, "sel_names_map"), load)
+		thresholdMap := program.MapBuilderPin("perf_event_threshold_map", sensors.PathJoin(pinPath, "perf_event_threshold_map"), load)
+		countsMap := program.MapBuilderPin("perf_event_counts", sensors.PathJoin(pinPath, "perf_event_counts"), load)
+		maps = append(maps, configMap, tailCalls, filterMap, selNamesMap, thresholdMap, countsMap)
+	}
+
+	return &sensors.Sensor{
+		Name:  name,
+		Progs: progs,
+		Maps:  maps,
+		DestroyHook: func() error {
+			return releaseSelectorsBinaryPrefixes(states)
+		},
+	}, nil
+}
+
+func (k *observerPerfEventSensor) PolicyHandler(
+	p tracingpolicy.TracingPolicy,
+	fid policyfilter.PolicyID,
+) (*sensors.Sensor, error) {
+	spec := p.TpSpec()
+
+	if len(spec.PerfEvents) == 0 {
+		return nil, nil
+	}
+
+	if fid != policyfilter.NoFilterID {
+		return nil, fmt.Errorf("perf event sensor does not implement policy filtering")
+	}
+
+	name := fmt.Sprintf("gperf-sensor-%d", atomic.AddUint64(&sensorCounter, 1))
+	policyName := p.TpName()
+	return createGenericPerfEventSensor(name, spec.PerfEvents, policyName, isAudit(spec))
+}
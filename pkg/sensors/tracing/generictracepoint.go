@@ -10,12 +10,16 @@ import (
 	"fmt"
 	"path"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/cilium/ebpf"
+	ebtf "github.com/cilium/ebpf/btf"
 	"github.com/cilium/tetragon/pkg/api/ops"
+	"github.com/cilium/tetragon/pkg/api/processapi"
 	"github.com/cilium/tetragon/pkg/api/tracingapi"
 	api "github.com/cilium/tetragon/pkg/api/tracingapi"
+	"github.com/cilium/tetragon/pkg/btf"
 	"github.com/cilium/tetragon/pkg/eventhandler"
 	"github.com/cilium/tetragon/pkg/grpc/tracing"
 	"github.com/cilium/tetragon/pkg/idtable"
@@ -30,6 +34,7 @@ import (
 	"github.com/cilium/tetragon/pkg/sensors/base"
 	"github.com/cilium/tetragon/pkg/sensors/program"
 	"github.com/cilium/tetragon/pkg/tracepoint"
+	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/sirupsen/logrus"
 
 	gt "github.com/cilium/tetragon/pkg/generictypes"
@@ -82,12 +87,35 @@ type genericTracepoint struct {
 
 	// policyName is the name of the policy that this tracepoint belongs to
 	policyName string
+	// audit is set if the policy is in audit mode
+	audit bool
 
 	// parsed kernel selector state
 	selectors *selectors.KernelSelectorState
 
 	// custom event handler
 	customHandler eventhandler.Handler
+
+	// for tracepoints with return, the sys_exit tracepoint whose ret field
+	// holds the return value, the offset of the field, and the generic
+	// type of the return value
+	retInfo      *tracepoint.Tracepoint
+	retCtxOffset int
+	retTypeId    int
+
+	// for tracepoints with return, we maintain the enter events until the
+	// return events are received to merge them, like the enter events of
+	// kprobes with a retprobe.
+	pendingEvents *lru.Cache[pendingEventKey, pendingTracepointEvent]
+}
+
+// pendingTracepointEvent is an event of a tracepoint with return waiting to
+// be merged with another event: the enter event, or the return value from the
+// return event.
+type pendingTracepointEvent struct {
+	ev          *tracing.MsgGenericTracepointUnix
+	ret         tracingapi.MsgGenericTracepointArg
+	returnEvent bool
 }
 
 // genericTracepointArg is the internal representation of an output value of a
@@ -321,15 +349,32 @@ func createGenericTracepoint(
 	conf *GenericTracepointConf,
 	policyID policyfilter.PolicyID,
 	policyName string,
+	audit bool,
 	customHandler eventhandler.Handler,
+	btfSpec func() (*ebtf.Spec, error),
 ) (*genericTracepoint, error) {
 	tp := tracepoint.Tracepoint{
 		Subsys: conf.Subsystem,
 		Event:  conf.Event,
 	}
 
-	if err := tp.LoadFormat(); err != nil {
-		return nil, fmt.Errorf("tracepoint %s/%s not supported: %w", tp.Subsys, tp.Event, err)
+	// sigkill is implemented with bpf_send_signal, which is only available
+	// in the large programs
+	if selectors.HasSigkillAction(conf.Selectors) && !kernels.EnableLargeProgs() {
+		return nil, fmt.Errorf("tracepoint %s/%s: sigkill action requires kernel >= 5.3.0", tp.Subsys, tp.Event)
+	}
+
+	// the calls are counted per kprobe, in a map of the kprobe sensors
+	if selectors.HasCount(conf.Selectors) {
+		return nil, fmt.Errorf("tracepoint %s/%s: count action is only supported for kprobes", tp.Subsys, tp.Event)
+	}
+
+	if conf.IncludeCompat && tp.Subsys != "raw_syscalls" {
+		return nil, fmt.Errorf("tracepoint %s/%s: includeCompat is only supported for raw_syscalls tracepoints", tp.Subsys, tp.Event)
+	}
+
+	if err := loadTracepointFormat(&tp, conf.Raw, btfSpec); err != nil {
+		return nil, err
 	}
 
 	tpArgs, err := buildGenericTracepointArgs(&tp, conf.Args)
@@ -343,14 +388,93 @@ func createGenericTracepoint(
 		args:          tpArgs,
 		policyID:      policyID,
 		policyName:    policyName,
+		audit:         audit,
 		customHandler: customHandler,
 	}
 
+	if conf.Return {
+		if err := ret.initReturn(btfSpec); err != nil {
+			return nil, err
+		}
+	}
+
 	genericTracepointTable.addTracepoint(ret)
 	ret.pinPathPrefix = sensors.PathJoin(sensorName, fmt.Sprintf("gtp-%d", ret.tableIdx))
 	return ret, nil
 }
 
+// tracepointExitEvent returns the sys_exit tracepoint matching a sys_enter
+// tracepoint.
+func tracepointExitEvent(subsys, event string) (string, error) {
+	switch {
+	case subsys == "syscalls" && strings.HasPrefix(event, "sys_enter_"):
+		return "sys_exit_" + strings.TrimPrefix(event, "sys_enter_"), nil
+	case subsys == "raw_syscalls" && event == "sys_enter":
+		return "sys_exit", nil
+	}
+	return "", fmt.Errorf("tracepoint %s/%s: return is only supported for the sys_enter tracepoints of the syscalls and raw_syscalls subsystems", subsys, event)
+}
+
+// tracepointReturnType returns the generic type of the return value of a
+// tracepoint, an integer type.
+func tracepointReturnType(arg *v1alpha1.KProbeArg) (int, error) {
+	if arg == nil {
+		return gt.GenericS64Type, nil
+	}
+	switch ty := gt.GenericTypeFromString(arg.Type); ty {
+	case gt.GenericIntType, gt.GenericS32Type, gt.GenericU32Type,
+		gt.GenericS64Type, gt.GenericU64Type, gt.GenericSizeType:
+		return ty, nil
+	}
+	return gt.GenericInvalidType, fmt.Errorf("return type '%s' is not an integer type", arg.Type)
+}
+
+// initReturn sets up the sys_exit tracepoint that provides the return value
+// of the syscall of tp.
+func (tp *genericTracepoint) initReturn(btfSpec func() (*ebtf.Spec, error)) error {
+	if tp.Spec.Raw {
+		return fmt.Errorf("tracepoint %s/%s: return is not supported for raw tracepoints", tp.Info.Subsys, tp.Info.Event)
+	}
+	exit, err := tracepointExitEvent(tp.Info.Subsys, tp.Info.Event)
+	if err != nil {
+		return err
+	}
+	retTypeId, err := tracepointReturnType(tp.Spec.ReturnArg)
+	if err != nil {
+		return fmt.Errorf("tracepoint %s/%s: %w", tp.Info.Subsys, tp.Info.Event, err)
+	}
+
+	retInfo := &tracepoint.Tracepoint{Subsys: tp.Info.Subsys, Event: exit}
+	if err := loadTracepointFormat(retInfo, false, btfSpec); err != nil {
+		return err
+	}
+	retCtxOffset := -1
+	for i := range retInfo.Format.Fields {
+		field := &retInfo.Format.Fields[i]
+		if field.Field == nil && field.ParseField() != nil {
+			continue
+		}
+		if field.Field.Name == "ret" {
+			retCtxOffset = int(field.Offset)
+			break
+		}
+	}
+	if retCtxOffset < 0 {
+		return fmt.Errorf("tracepoint %s/%s: ret field not found", retInfo.Subsys, retInfo.Event)
+	}
+
+	pendingEvents, err := lru.New[pendingEventKey, pendingTracepointEvent](4096)
+	if err != nil {
+		return err
+	}
+
+	tp.retInfo = retInfo
+	tp.retCtxOffset = retCtxOffset
+	tp.retTypeId = retTypeId
+	tp.pendingEvents = pendingEvents
+	return nil
+}
+
 // createGenericTracepointSensor will create a sensor that can be loaded based on a generic tracepoint configuration
 func createGenericTracepointSensor(
 	name string,
@@ -358,12 +482,16 @@ func createGenericTracepointSensor(
 	policyID policyfilter.PolicyID,
 	policyName string,
 	lists []v1alpha1.ListSpec,
+	audit bool,
 	customHandler eventhandler.Handler,
 ) (*sensors.Sensor, error) {
 
+	// the BTF spec is only loaded if a tracepoint needs it
+	btfSpec := sync.OnceValues(btf.NewBTF)
+
 	tracepoints := make([]*genericTracepoint, 0, len(confs))
 	for i := range confs {
-		tp, err := createGenericTracepoint(name, &confs[i], policyID, policyName, customHandler)
+		tp, err := createGenericTracepoint(name, &confs[i], policyID, policyName, audit, customHandler, btfSpec)
 		if err != nil {
 			return nil, err
 		}
@@ -371,10 +499,13 @@ func createGenericTracepointSensor(
 	}
 
 	progName := "bpf_generic_tracepoint.o"
+	retProgName := "bpf_generic_rettracepoint.o"
 	if kernels.EnableV61Progs() {
 		progName = "bpf_generic_tracepoint_v61.o"
+		retProgName = "bpf_generic_rettracepoint_v61.o"
 	} else if kernels.EnableLargeProgs() {
 		progName = "bpf_generic_tracepoint_v53.o"
+		retProgName = "bpf_generic_rettracepoint_v53.o"
 	}
 
 	maps := []*program.Map{}
@@ -389,10 +520,11 @@ func createGenericTracepointSensor(
 			"tracepoint/generic_tracepoint",
 			pinProg,
 			"generic_tracepoint",
-		)
+		).SetRawTracepoint(tp.Spec.Raw)
 
 		err := tp.InitKernelSelectors(lists)
 		if err != nil {
+			releaseTracepointsBinaryPrefixes(tracepoints)
 			return nil, fmt.Errorf("failed to initialize tracepoint kernel selectors: %w", err)
 		}
 
@@ -470,25 +602,184 @@ func createGenericTracepointSensor(
 
 		killerDataMap := program.MapBuilderPin("killer_data", "killer_data", prog0)
 		maps = append(maps, killerDataMap)
+
+		if tp.Spec.Return {
+			retProbe := program.MapBuilderPin("retprobe_map", sensors.PathJoin(pinPath, "retprobe_map"), prog0)
+			maps = append(maps, retProbe)
+
+			pinRetProg := sensors.PathJoin(pinPath, fmt.Sprintf("%s:%s_ret_prog", tp.retInfo.Subsys, tp.retInfo.Event))
+			loadret := program.Builder(
+				path.Join(option.Config.HubbleLib, retProgName),
+				fmt.Sprintf("%s/%s", tp.retInfo.Subsys, tp.retInfo.Event),
+				"tracepoint/generic_rettracepoint",
+				pinRetProg,
+				"generic_tracepoint").
+				SetRetProbe(true).
+				SetLoaderData(tp.tableIdx)
+			progs = append(progs, loadret)
+
+			retProbe = program.MapBuilderPin("retprobe_map", sensors.PathJoin(pinPath, "retprobe_map"), loadret)
+			maps = append(maps, retProbe)
+
+			retConfigMap := program.MapBuilderPin("config_map", sensors.PathJoin(pinPath, "retprobe_config_map"), loadret)
+			maps = append(maps, retConfigMap)
+
+			// add maps with non-default paths (pins) to the return program
+			program.MapBuilderPin("fdinstall_map", sensors.PathJoin(pinPath, "fdinstall_map"), loadret)
+		}
 	}
 
 	return &sensors.Sensor{
 		Name:  name,
 		Progs: progs,
 		Maps:  maps,
+		DestroyHook: func() error {
+			return releaseTracepointsBinaryPrefixes(tracepoints)
+		},
+		SelectorsHook: func(spec *v1alpha1.TracingPolicySpec) error {
+			return updateTracepointSelectors(spec, tracepoints)
+		},
 	}, nil
 }
 
+// releaseTracepointsBinaryPrefixes releases the matchBinaries prefixes of the
+// selectors of the tracepoints.
+func releaseTracepointsBinaryPrefixes(tracepoints []*genericTracepoint) error {
+	var errs error
+	for _, tp := range tracepoints {
+		errs = errors.Join(errs, releaseBinaryPrefixes(tp.selectors))
+	}
+	return errs
+}
+
+// updateTracepointSelectors updates the selectors of the loaded tracepoints,
+// added from the tracepoints specs of a policy, to the selectors of the
+// tracepoints of spec.
+func updateTracepointSelectors(spec *v1alpha1.TracingPolicySpec, tracepoints []*genericTracepoint) error {
+	// the inner maps of the values of the selectors must keep their sizes
+	// on older kernels
+	if !kernels.MinKernelVersion("5.9") {
+		return fmt.Errorf("%w: updating selectors requires kernel >= 5.9", sensors.ErrTracingPolicyNotUpdatable)
+	}
+	if len(spec.Tracepoints) != len(tracepoints) {
+		return fmt.Errorf("%w: the tracepoints changed", sensors.ErrTracingPolicyNotUpdatable)
+	}
+
+	states := make([]*selectors.KernelSelectorState, 0, len(tracepoints))
+	// the states are swapped with the selectors of the tracepoints that are
+	// updated, so that the selectors that are not used anymore are released
+	defer func() {
+		for _, state := range states {
+			if err := releaseBinaryPrefixes(state); err != nil {
+				logger.GetLogger().WithError(err).Warn("Failed to release the matchBinaries prefixes")
+			}
+		}
+	}()
+	for i, tp := range tracepoints {
+		conf := &spec.Tracepoints[i]
+		if selectors.HasSigkillAction(conf.Selectors) && !kernels.EnableLargeProgs() {
+			return fmt.Errorf("tracepoint %s/%s: sigkill action requires kernel >= 5.3.0", tp.Info.Subsys, tp.Info.Event)
+		}
+		if selectors.HasCount(conf.Selectors) {
+			return fmt.Errorf("tracepoint %s/%s: count action is only supported for kprobes", tp.Info.Subsys, tp.Info.Event)
+		}
+		// the binary filter is enabled in the config of the program
+		if selectors.HasEarlyBinaryFilter(conf.Selectors) != selectors.HasEarlyBinaryFilter(tp.Spec.Selectors) {
+			return fmt.Errorf("%w: tracepoints[%d]: adding or removing early binary filters", sensors.ErrTracingPolicyNotUpdatable, i)
+		}
+
+		// the arguments of the actions are added to the ones of the
+		// current selectors, which stay valid until the selector maps
+		// are updated
+		state, err := tp.kernelSelectorState(conf.Selectors, spec.Lists)
+		if err != nil {
+			return err
+		}
+		states = append(states, state)
+	}
+
+	for i, tp := range tracepoints {
+		if err := updateSelectorMaps(states[i], tp.selectors, tp.pinPathPrefix, 0); err != nil {
+			// restore the selectors of the tracepoints updated so
+			// far, so that the policy is not left half updated. The
+			// maps of the tracepoint that failed are all written
+			// again.
+			for j, old := range tracepoints[:i+1] {
+				cur := states[j]
+				if j == i {
+					cur = nil
+				}
+				if rerr := updateSelectorMaps(old.selectors, cur, old.pinPathPrefix, 0); rerr != nil {
+					err = errors.Join(err, fmt.Errorf("failed to restore the selectors of tracepoint %s/%s: %w",
+						old.Info.Subsys, old.Info.Event, rerr))
+				}
+			}
+			return err
+		}
+	}
+	for i, tp := range tracepoints {
+		tp.selectors, states[i] = states[i], tp.selectors
+		tp.Spec = &spec.Tracepoints[i]
+		// the events of the previous selectors may still be in flight
+		selectors.PruneActionArgs(&tp.actionArgs, tp.selectors, states[i])
+	}
+	return nil
+}
+
+// loadTracepointFormat loads the format of a tracepoint. Raw tracepoints use
+// the BTF types of their arguments. The format of the other tracepoints is
+// read from tracefs, or from BTF if tracefs is not available.
+func loadTracepointFormat(tp *tracepoint.Tracepoint, raw bool, btfSpec func() (*ebtf.Spec, error)) error {
+	if raw {
+		spec, err := btfSpec()
+		if err == nil {
+			err = tp.LoadRawFormat(spec)
+		}
+		if err != nil {
+			return fmt.Errorf("raw tracepoint %s/%s not supported: %w", tp.Subsys, tp.Event, err)
+		}
+		return nil
+	}
+
+	err := tp.LoadFormat()
+	if err == nil {
+		return nil
+	}
+	spec, btfErr := btfSpec()
+	if btfErr == nil {
+		btfErr = tp.LoadBTFFormat(spec)
+	}
+	if btfErr != nil {
+		return fmt.Errorf("tracepoint %s/%s not supported: %w", tp.Subsys, tp.Event, errors.Join(err, btfErr))
+	}
+	logger.GetLogger().WithError(err).Infof("tracepoint %s/%s: format loaded from BTF", tp.Subsys, tp.Event)
+	return nil
+}
+
 func (tp *genericTracepoint) InitKernelSelectors(lists []v1alpha1.ListSpec) error {
 	if tp.selectors != nil {
 		return fmt.Errorf("InitKernelSelectors: selectors already initialized")
 	}
 
+	selectors, err := tp.kernelSelectorState(tp.Spec.Selectors, lists)
+	if err != nil {
+		return err
+	}
+	tp.selectors = selectors
+	return nil
+}
+
+// kernelSelectorState returns the kernel selector state of the selectors of
+// the tracepoint, whose arguments are the ones of the tracepoint spec.
+func (tp *genericTracepoint) kernelSelectorState(
+	specSelectors []v1alpha1.KProbeSelector,
+	lists []v1alpha1.ListSpec,
+) (*selectors.KernelSelectorState, error) {
 	// rewrite arg index
 	selArgs := make([]v1alpha1.KProbeArg, 0, len(tp.args))
-	selSelectors := make([]v1alpha1.KProbeSelector, 0, len(tp.Spec.Selectors))
-	for i := range tp.Spec.Selectors {
-		origSel := &tp.Spec.Selectors[i]
+	selSelectors := make([]v1alpha1.KProbeSelector, 0, len(specSelectors))
+	for i := range specSelectors {
+		origSel := &specSelectors[i]
 		selSelectors = append(selSelectors, *origSel.DeepCopy())
 	}
 
@@ -496,7 +787,7 @@ func (tp *genericTracepoint) InitKernelSelectors(lists []v1alpha1.ListSpec) erro
 		tpArg := &tp.args[i]
 		ty, err := tpArg.setGenericTypeId()
 		if err != nil {
-			return fmt.Errorf("output argument %v unsupported: %w", tpArg, err)
+			return nil, fmt.Errorf("output argument %v unsupported: %w", tpArg, err)
 		}
 		selType := selectors.ArgTypeToString(uint32(ty))
 
@@ -518,12 +809,7 @@ func (tp *genericTracepoint) InitKernelSelectors(lists []v1alpha1.ListSpec) erro
 		}
 	}
 
-	selectors, err := selectors.InitKernelSelectorState(selSelectors, selArgs, &tp.actionArgs, &listReader{lists}, nil)
-	if err != nil {
-		return err
-	}
-	tp.selectors = selectors
-	return nil
+	return selectors.InitKernelSelectorState(selSelectors, selArgs, &tp.actionArgs, &listReader{lists}, nil)
 }
 
 func (tp *genericTracepoint) EventConfig() (api.EventConfig, error) {
@@ -560,10 +846,30 @@ func (tp *genericTracepoint) EventConfig() (api.EventConfig, error) {
 	if selectors.HasEarlyBinaryFilter(tp.Spec.Selectors) {
 		config.Flags |= flagsEarlyFilter
 	}
+	if tp.audit {
+		config.Flags |= flagsAudit
+	}
+	if tp.Info.Subsys == "raw_syscalls" && !tp.Spec.IncludeCompat {
+		config.Flags |= flagsSkipCompat
+	}
+	if tp.Spec.Return {
+		config.ArgReturn = int32(tp.retTypeId)
+	}
 
 	return config, nil
 }
 
+// retEventConfig returns the config of the program of the sys_exit
+// tracepoint, which reads the return value at the offset of the ret field.
+func (tp *genericTracepoint) retEventConfig() (api.EventConfig, error) {
+	config, err := tp.EventConfig()
+	if err != nil {
+		return config, err
+	}
+	config.ArgTpCtxOff[0] = uint32(tp.retCtxOffset)
+	return config, nil
+}
+
 func LoadGenericTracepointSensor(bpfDir, mapDir string, load *program.Program, verbose int) error {
 
 	tracepointLog = logger.GetLogger()
@@ -578,9 +884,13 @@ func LoadGenericTracepointSensor(bpfDir, mapDir string, load *program.Program, v
 		return fmt.Errorf("Could not find generic tracepoint information for %s: %w", load.Attach, err)
 	}
 
-	load.MapLoad = append(load.MapLoad, selectorsMaploads(tp.selectors, tp.pinPathPrefix, 0)...)
-
-	config, err := tp.EventConfig()
+	var config api.EventConfig
+	if load.RetProbe {
+		config, err = tp.retEventConfig()
+	} else {
+		load.MapLoad = append(load.MapLoad, selectorsMaploads(tp.selectors, tp.pinPathPrefix, 0)...)
+		config, err = tp.EventConfig()
+	}
 	if err != nil {
 		return fmt.Errorf("failed to generate config data for generic tracepoint: %w", err)
 	}
@@ -601,6 +911,9 @@ func LoadGenericTracepointSensor(bpfDir, mapDir string, load *program.Program, v
 	} else {
 		return err
 	}
+	if load.RetProbe {
+		return nil
+	}
 
 	m, err := ebpf.LoadPinnedMap(filepath.Join(mapDir, base.NamesMap.Name), nil)
 	if err != nil {
@@ -612,7 +925,7 @@ func LoadGenericTracepointSensor(bpfDir, mapDir string, load *program.Program, v
 		writeBinaryMap(m, i, path)
 	}
 
-	return err
+	return writeBinaryPrefixMaps(mapDir, tp.selectors)
 }
 
 func handleGenericTracepoint(r *bytes.Reader) ([]observer.Event, error) {
@@ -638,7 +951,12 @@ func handleGenericTracepoint(r *bytes.Reader) ([]observer.Event, error) {
 		return []observer.Event{unix}, nil
 	}
 
-	ret, err := handleMsgGenericTracepoint(&m, unix, tp, r)
+	var ret []observer.Event
+	if m.Common.Flags&processapi.MSG_COMMON_FLAG_RETURN != 0 {
+		ret, err = handleMsgGenericTracepointReturn(&m, tp, r)
+	} else {
+		ret, err = handleMsgGenericTracepoint(&m, unix, tp, r)
+	}
 	if tp.customHandler != nil {
 		ret, err = tp.customHandler(ret, err)
 	}
@@ -673,6 +991,9 @@ func handleMsgGenericTracepoint(
 	unix.Subsys = tp.Info.Subsys
 	unix.Event = tp.Info.Event
 	unix.PolicyName = tp.policyName
+	unix.ActionLatency = actionLatency(tp.policyName, m.ActionId, m.Common.Ktime, m.ActionKtime)
+	unix.AuditAction = auditAction(tp.policyName, m.AuditAction)
+	unix.AuditSelector = m.AuditSelector
 
 	for idx, out := range tp.args {
 
@@ -767,9 +1088,92 @@ func handleMsgGenericTracepoint(
 			logger.GetLogger().Warnf("handleGenericTracepoint: ignoring:  %+v", out)
 		}
 	}
+
+	if tp.pendingEvents != nil {
+		// the event is sent once the return value is received
+		key := pendingEventKey{eventId: m.RetProbeId, ktimeEnter: m.Common.Ktime}
+		unix = tp.mergeReturn(key, pendingTracepointEvent{ev: unix})
+		if unix == nil {
+			return []observer.Event{}, nil
+		}
+	}
+	return []observer.Event{unix}, nil
+}
+
+// handleMsgGenericTracepointReturn handles the return event of a tracepoint
+// with return, sent by the program of the sys_exit tracepoint.
+func handleMsgGenericTracepointReturn(
+	m *tracingapi.MsgGenericTracepoint,
+	tp *genericTracepoint,
+	r *bytes.Reader,
+) ([]observer.Event, error) {
+	if tp.pendingEvents == nil {
+		return nil, fmt.Errorf("tracepoint %s/%s: unexpected return event", tp.Info.Subsys, tp.Info.Event)
+	}
+
+	var ktimeEnter uint64
+	if err := binary.Read(r, binary.LittleEndian, &ktimeEnter); err != nil {
+		return nil, fmt.Errorf("failed to read ktimeEnter")
+	}
+
+	var ret tracingapi.MsgGenericTracepointArg
+	var err error
+	switch tp.retTypeId {
+	case gt.GenericS64Type:
+		var val int64
+		err = binary.Read(r, binary.LittleEndian, &val)
+		ret = val
+	case gt.GenericU64Type, gt.GenericSizeType:
+		var val uint64
+		err = binary.Read(r, binary.LittleEndian, &val)
+		ret = val
+	case gt.GenericU32Type:
+		var val uint32
+		err = binary.Read(r, binary.LittleEndian, &val)
+		ret = val
+	default:
+		var val int32
+		err = binary.Read(r, binary.LittleEndian, &val)
+		ret = val
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read return value: %w", err)
+	}
+
+	key := pendingEventKey{eventId: m.RetProbeId, ktimeEnter: ktimeEnter}
+	unix := tp.mergeReturn(key, pendingTracepointEvent{ret: ret, returnEvent: true})
+	if unix == nil {
+		return []observer.Event{}, nil
+	}
 	return []observer.Event{unix}, nil
 }
 
+// mergeReturn merges the enter and the return events of a tracepoint with
+// return. It returns the enter event with the return value once both events
+// are received, nil otherwise.
+func (tp *genericTracepoint) mergeReturn(key pendingEventKey, curr pendingTracepointEvent) *tracing.MsgGenericTracepointUnix {
+	prev, exists := tp.pendingEvents.Get(key)
+	if !exists {
+		tp.pendingEvents.Add(key, curr)
+		return nil
+	}
+	tp.pendingEvents.Remove(key)
+
+	enter, ret := prev, curr
+	if prev.returnEvent {
+		enter, ret = curr, prev
+	}
+	if enter.returnEvent || !ret.returnEvent {
+		logger.GetLogger().WithFields(logrus.Fields{
+			"subsys": tp.Info.Subsys,
+			"event":  tp.Info.Event,
+		}).Debug("failed to merge tracepoint events")
+		return nil
+	}
+	enter.ev.Return = ret.ret
+	return enter.ev
+}
+
 func (t *observerTracepointSensor) LoadProbe(args sensors.LoadProbeArgs) error {
 	return LoadGenericTracepointSensor(args.BPFDir, args.MapDir, args.Load, args.Verbose)
 }
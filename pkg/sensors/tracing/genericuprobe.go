@@ -14,6 +14,7 @@ import (
 	"github.com/cilium/ebpf"
 	"github.com/cilium/tetragon/pkg/api/ops"
 	api "github.com/cilium/tetragon/pkg/api/tracingapi"
+	gt "github.com/cilium/tetragon/pkg/generictypes"
 	"github.com/cilium/tetragon/pkg/grpc/tracing"
 	"github.com/cilium/tetragon/pkg/idtable"
 	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
@@ -27,6 +28,7 @@ import (
 	"github.com/cilium/tetragon/pkg/sensors/base"
 	"github.com/cilium/tetragon/pkg/sensors/program"
 	"github.com/cilium/tetragon/pkg/tracingpolicy"
+	"github.com/cilium/tetragon/pkg/usdt"
 )
 
 type observerUprobeSensor struct {
@@ -43,7 +45,10 @@ type genericUprobe struct {
 	config        *api.EventConfig
 	path          string
 	symbol        string
+	offset        uint64
 	selectors     *selectors.KernelSelectorState
+	// argPrinters describe the arguments read by the uprobe
+	argPrinters []argPrinters
 	// policyName is the name of the policy that this uprobe belongs to
 	policyName string
 }
@@ -93,7 +98,11 @@ func handleGenericUprobe(r *bytes.Reader) ([]observer.Event, error) {
 	unix.Tid = m.Tid
 	unix.Path = uprobeEntry.path
 	unix.Symbol = uprobeEntry.symbol
+	unix.Offset = uprobeEntry.offset
 	unix.PolicyName = uprobeEntry.policyName
+	unix.AuditAction = auditAction(uprobeEntry.policyName, m.AuditAction)
+	unix.AuditSelector = m.AuditSelector
+	unix.Args, err = getArgs(r, uprobeEntry.argPrinters)
 
 	return []observer.Event{unix}, err
 }
@@ -132,7 +141,7 @@ func (k *observerUprobeSensor) LoadProbe(args sensors.LoadProbeArgs) error {
 			Index: 0,
 			Name:  "sel_names_map",
 			Load: func(outerMap *ebpf.Map, index uint32) error {
-				return populateBinariesMaps(uprobeEntry.selectors, uprobeEntry.pinPathPrefix, outerMap)
+				return populateBinariesMaps(uprobeEntry.selectors, nil, uprobeEntry.pinPathPrefix, outerMap)
 			},
 		},
 	}
@@ -155,6 +164,10 @@ func (k *observerUprobeSensor) LoadProbe(args sensors.LoadProbeArgs) error {
 		writeBinaryMap(m, i, path)
 	}
 
+	if err := writeBinaryPrefixMaps(args.MapDir, uprobeEntry.selectors); err != nil {
+		return err
+	}
+
 	logger.GetLogger().WithField("flags", flagsString(uprobeEntry.config.Flags)).
 		Infof("Loaded generic uprobe program: %s -> %s [%s]", args.Load.Name, uprobeEntry.path, uprobeEntry.symbol)
 	return nil
@@ -162,27 +175,109 @@ func (k *observerUprobeSensor) LoadProbe(args sensors.LoadProbeArgs) error {
 
 func isValidUprobeSelectors(selectors []v1alpha1.KProbeSelector) error {
 	for _, s := range selectors {
-		if len(s.MatchArgs) > 0 ||
-			len(s.MatchActions) > 0 ||
+		if len(s.MatchActions) > 0 ||
 			len(s.MatchReturnArgs) > 0 ||
 			len(s.MatchNamespaces) > 0 ||
 			len(s.MatchNamespaceChanges) > 0 ||
 			len(s.MatchCapabilities) > 0 ||
-			len(s.MatchCapabilityChanges) > 0 {
-			return fmt.Errorf("Only matchPIDs selector is supported")
+			len(s.MatchCapabilityChanges) > 0 ||
+			len(s.MatchCredentials) > 0 ||
+			len(s.MatchTags) > 0 {
+			return fmt.Errorf("Only matchPIDs, matchArgs and matchBinaries selectors are supported")
 		}
 	}
 	return nil
 }
 
+// isValidUprobeArgType returns true if the argument type can be read at
+// uprobes. Only types that do not depend on kernel structures are supported.
+func isValidUprobeArgType(ty int) bool {
+	switch ty {
+	case gt.GenericIntType, gt.GenericS32Type, gt.GenericU32Type,
+		gt.GenericU64Type, gt.GenericSizeType, gt.GenericStringType,
+		gt.GenericStringArrayType:
+		return true
+	}
+	return false
+}
+
+// addUprobeArgs sets the argument types of config and returns the printers
+// of the arguments.
+func addUprobeArgs(spec *v1alpha1.UProbeSpec, config *api.EventConfig) ([]argPrinters, error) {
+	var printers []argPrinters
+	var argsSet [api.EventConfigMaxArgs]bool
+
+	for j, a := range spec.Args {
+		argType := gt.GenericTypeFromString(a.Type)
+		if argType == gt.GenericInvalidType {
+			return nil, fmt.Errorf("Arg(%d) type '%s' unsupported", j, a.Type)
+		}
+		if !isValidUprobeArgType(argType) {
+			return nil, fmt.Errorf("Arg(%d) type '%s' unsupported for uprobes", j, a.Type)
+		}
+		if a.Index > 4 {
+			return nil, fmt.Errorf("Error add arg: ArgType %s Index %d out of bounds",
+				a.Type, int(a.Index))
+		}
+		config.Arg[a.Index] = int32(argType)
+		config.ArgM[a.Index] = 0
+		argsSet[a.Index] = true
+		printers = append(printers, argPrinters{index: j, ty: argType, label: a.Label})
+	}
+
+	// Mark remaining arguments as 'nops' the kernel side will skip
+	// copying 'nop' args.
+	for j, set := range argsSet {
+		if !set {
+			config.Arg[j] = gt.GenericNopType
+			config.ArgM[j] = 0
+		}
+	}
+	return printers, nil
+}
+
+// addUsdtArgs looks up the USDT probe of spec and sets the locations of its
+// arguments in config.
+func addUsdtArgs(spec *v1alpha1.UProbeSpec, config *api.EventConfig) (*usdt.Probe, error) {
+	probe, err := usdt.FindProbe(spec.Path, spec.Usdt.Provider, spec.Usdt.Name)
+	if err != nil {
+		return nil, err
+	}
+	if len(spec.Args) == 0 {
+		return probe, nil
+	}
+	usdtArgs, err := probe.ParseArgs()
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range spec.Args {
+		if int(a.Index) >= len(usdtArgs) {
+			return nil, fmt.Errorf("USDT probe %s:%s has %d arguments, argument index %d is out of bounds",
+				probe.Provider, probe.Name, len(usdtArgs), a.Index)
+		}
+		config.ArgTpCtxOff[a.Index] = usdtArgs[a.Index].Location()
+	}
+	return probe, nil
+}
+
 func createGenericUprobeSensor(
 	name string,
 	uprobes []v1alpha1.UProbeSpec,
 	policyName string,
-) (*sensors.Sensor, error) {
+	audit bool,
+) (_ *sensors.Sensor, err error) {
 	var progs []*program.Program
 	var maps []*program.Map
 
+	// the matchBinaries prefixes of the selectors are released with the
+	// sensor
+	var states []*selectors.KernelSelectorState
+	defer func() {
+		if err != nil {
+			releaseSelectorsBinaryPrefixes(states)
+		}
+	}()
+
 	sensorPath := name
 
 	loadProgName := "bpf_generic_uprobe.o"
@@ -196,25 +291,61 @@ func createGenericUprobeSensor(
 		spec := &uprobes[i]
 		config := &api.EventConfig{}
 
-		var args []v1alpha1.KProbeArg
+		if spec.Usdt == nil && spec.Symbol == "" && spec.Offset == nil {
+			return nil, fmt.Errorf("uprobe on '%s' needs a symbol or an offset", spec.Path)
+		}
+		if spec.Usdt != nil && (spec.Symbol != "" || spec.Offset != nil) {
+			return nil, fmt.Errorf("uprobe on '%s': symbol and offset cannot be used with usdt", spec.Path)
+		}
 
 		if err := isValidUprobeSelectors(spec.Selectors); err != nil {
 			return nil, err
 		}
 
+		printers, err := addUprobeArgs(spec, config)
+		if err != nil {
+			return nil, err
+		}
+
 		// Parse Filters into kernel filter logic
-		uprobeSelectorState, err := selectors.InitKernelSelectorState(spec.Selectors, args, nil, nil, nil)
+		uprobeSelectorState, err := selectors.InitKernelSelectorState(spec.Selectors, spec.Args, nil, nil, nil)
 		if err != nil {
 			return nil, err
 		}
+		states = append(states, uprobeSelectorState)
+
+		var offset uint64
+		if spec.Offset != nil {
+			offset = *spec.Offset
+		}
+
+		symbol := spec.Symbol
+		attachData := &program.UprobeAttachData{
+			Path:   spec.Path,
+			Symbol: spec.Symbol,
+			Offset: offset,
+		}
+
+		if spec.Usdt != nil {
+			probe, err := addUsdtArgs(spec, config)
+			if err != nil {
+				return nil, err
+			}
+			symbol = probe.Provider + ":" + probe.Name
+			attachData.Offset = probe.Offset
+			attachData.RefCtrOffset = probe.SemaphoreOffset
+			config.Flags |= flagsUsdt
+		}
 
 		uprobeEntry := &genericUprobe{
-			tableId:    idtable.UninitializedEntryID,
-			config:     config,
-			path:       spec.Path,
-			symbol:     spec.Symbol,
-			selectors:  uprobeSelectorState,
-			policyName: policyName,
+			tableId:     idtable.UninitializedEntryID,
+			config:      config,
+			path:        spec.Path,
+			symbol:      symbol,
+			offset:      offset,
+			selectors:   uprobeSelectorState,
+			argPrinters: printers,
+			policyName:  policyName,
 		}
 
 		uprobeTable.AddEntry(uprobeEntry)
@@ -226,15 +357,13 @@ func createGenericUprobeSensor(
 		if selectors.HasEarlyBinaryFilter(spec.Selectors) {
 			config.Flags |= flagsEarlyFilter
 		}
+		if audit {
+			config.Flags |= flagsAudit
+		}
 
 		pinPath := uprobeEntry.pinPathPrefix
 		pinProg := sensors.PathJoin(pinPath, "prog")
 
-		attachData := &program.UprobeAttachData{
-			Path:   spec.Path,
-			Symbol: spec.Symbol,
-		}
-
 		load := program.Builder(
 			path.Join(option.Config.HubbleLib, loadProgName),
 			"",
@@ -257,6 +386,9 @@ func createGenericUprobeSensor(
 		Name:  name,
 		Progs: progs,
 		Maps:  maps,
+		DestroyHook: func() error {
+			return releaseSelectorsBinaryPrefixes(states)
+		},
 	}, nil
 }
 
@@ -276,5 +408,5 @@ func (k *observerUprobeSensor) PolicyHandler(
 
 	name := fmt.Sprintf("gup-sensor-%d", atomic.AddUint64(&sensorCounter, 1))
 	policyName := p.TpName()
-	return createGenericUprobeSensor(name, spec.UProbes, policyName)
+	return createGenericUprobeSensor(name, spec.UProbes, policyName, isAudit(spec))
 }
@@ -15,13 +15,27 @@ package tracing
 
 // #cgo LDFLAGS: '-Wl,--export-dynamic'
 /*
+#include <stdlib.h>
+
 int uprobe_test_func(void)
 {
 	return 0;
 }
+
+int uprobe_test_func_args(int num, const char *str)
+{
+	return num + (str ? 1 : 0);
+}
 */
 import "C"
+import "unsafe"
 
 func UprobeTestFunc() {
 	C.uprobe_test_func()
 }
+
+func UprobeTestFuncArgs(num int, str string) {
+	cstr := C.CString(str)
+	defer C.free(unsafe.Pointer(cstr))
+	C.uprobe_test_func_args(C.int(num), cstr)
+}
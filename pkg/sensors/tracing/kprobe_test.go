@@ -29,6 +29,7 @@ import (
 	"github.com/cilium/tetragon/pkg/jsonchecker"
 	"github.com/cilium/tetragon/pkg/kernels"
 	bc "github.com/cilium/tetragon/pkg/matchers/bytesmatcher"
+	"github.com/cilium/tetragon/pkg/matchers/durationmatcher"
 	lc "github.com/cilium/tetragon/pkg/matchers/listmatcher"
 	sm "github.com/cilium/tetragon/pkg/matchers/stringmatcher"
 	"github.com/cilium/tetragon/pkg/observer/observertesthelper"
@@ -43,6 +44,7 @@ import (
 	_ "github.com/cilium/tetragon/pkg/sensors/exec"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/sys/unix"
 )
 
@@ -569,6 +571,131 @@ spec:
 	runKprobeObjectRead(t, readHook, checker, fd, fd2)
 }
 
+func TestKprobeObjectReadReturnLatency(t *testing.T) {
+	fd, fd2, fdString := createTestFile(t)
+	pidStr := strconv.Itoa(int(observertesthelper.GetMyPid()))
+	readHook := `
+apiVersion: cilium.io/v1alpha1
+kind: TracingPolicy
+metadata:
+  name: "sys-read"
+spec:
+  kprobes:
+  - call: "sys_read"
+    syscall: true
+    return: true
+    latency: true
+    latencyHistogram: true
+    args:
+    - index: 0
+      type: "int"
+    returnArg:
+      type: "size_t"
+    selectors:
+    - matchPIDs:
+      - operator: In
+        followForks: true
+        values:
+        - ` + pidStr + `
+      matchArgs:
+      - index: 0
+        operator: "Equal"
+        values:
+        - ` + fdString
+
+	kpChecker := ec.NewProcessKprobeChecker("").
+		WithFunctionName(sm.Full(arch.AddSyscallPrefixTestHelper(t, "sys_read"))).
+		WithArgs(ec.NewKprobeArgumentListMatcher().
+			WithOperator(lc.Ordered).
+			WithValues(
+				ec.NewKprobeArgumentChecker().WithIntArg(int32(fd2)),
+			)).
+		WithReturn(ec.NewKprobeArgumentChecker().WithSizeArg(11)).
+		WithLatency(durationmatcher.Greater(&durationmatcher.Duration{Duration: 0}))
+	checker := ec.NewUnorderedEventChecker(kpChecker)
+
+	runKprobeObjectRead(t, readHook, checker, fd, fd2)
+
+	histograms := kprobeLatencyHistograms()
+	if !assert.Len(t, histograms, 1) {
+		return
+	}
+	var count uint64
+	for _, n := range histograms[0].Counts {
+		count += n
+	}
+	assert.Equal(t, uint64(1), count)
+	assert.NotZero(t, histograms[0].Sum)
+}
+
+func TestKprobeObjectReadCount(t *testing.T) {
+	var doneWG, readyWG sync.WaitGroup
+	defer doneWG.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), tus.Conf().CmdWaitTime)
+	defer cancel()
+
+	fd, fd2, fdString := createTestFile(t)
+	pidStr := strconv.Itoa(int(observertesthelper.GetMyPid()))
+	readHook := `
+apiVersion: cilium.io/v1alpha1
+kind: TracingPolicy
+metadata:
+  name: "sys-read-count"
+spec:
+  kprobes:
+  - call: "sys_read"
+    syscall: true
+    args:
+    - index: 0
+      type: "int"
+    selectors:
+    - matchPIDs:
+      - operator: In
+        followForks: true
+        values:
+        - ` + pidStr + `
+      matchArgs:
+      - index: 0
+        operator: "Equal"
+        values:
+        - ` + fdString + `
+      matchActions:
+      - action: Count`
+
+	createCrdFile(t, readHook)
+
+	obs, err := observertesthelper.GetDefaultObserverWithFile(t, ctx, testConfigFile, tus.Conf().TetragonLib, observertesthelper.WithMyPid())
+	if err != nil {
+		t.Fatalf("GetDefaultObserverWithFile error: %s", err)
+	}
+	observertesthelper.LoopEvents(ctx, t, &doneWG, &readyWG, obs)
+	readyWG.Wait()
+
+	hello := []byte("hello world")
+	_, err = syscall.Write(fd, hello)
+	require.NoError(t, err)
+	syscall.Fsync(fd)
+	var readBytes = make([]byte, 4)
+	for i := 0; i < 3; i++ {
+		_, err = syscall.Read(fd2, readBytes)
+		require.NoError(t, err)
+	}
+
+	// the reads are not posted, they are reported by the count reporter
+	(&countReporter{}).report()
+
+	countChecker := ec.NewProcessKprobeCountChecker("").
+		WithProcess(ec.NewProcessChecker().WithPid(observertesthelper.GetMyPid())).
+		WithFunctionName(sm.Full(arch.AddSyscallPrefixTestHelper(t, "sys_read"))).
+		WithPolicyName(sm.Full("sys-read-count")).
+		WithCount(3)
+	checker := ec.NewUnorderedEventChecker(countChecker)
+
+	err = jsonchecker.JsonTestCheck(t, checker)
+	assert.NoError(t, err)
+}
+
 // sys_openat trace
 func getOpenatChecker(t *testing.T, dir string) ec.MultiEventChecker {
 	kpChecker := ec.NewProcessKprobeChecker("").
@@ -3804,6 +3931,7 @@ func TestLoadKprobeSensor(t *testing.T) {
 
 		// generic_kprobe_process_event*,generic_kprobe_output,generic_retkprobe_event
 		sensorMaps = append(sensorMaps, tus.SensorMap{Name: "tcpmon_map", Progs: []uint{1, 2, 3, 4, 5, 13, 14}})
+		sensorMaps = append(sensorMaps, tus.SensorMap{Name: "event_cgroup_stats_map", Progs: []uint{1, 2, 3, 4, 5, 13, 14}})
 
 		// generic_kprobe_process_event*,generic_kprobe_actions,retkprobe
 		sensorMaps = append(sensorMaps, tus.SensorMap{Name: "socktrack_map", Progs: []uint{1, 2, 3, 4, 5, 12, 14}})
@@ -3813,6 +3941,7 @@ func TestLoadKprobeSensor(t *testing.T) {
 
 		// generic_kprobe_output,generic_retkprobe_event
 		sensorMaps = append(sensorMaps, tus.SensorMap{Name: "tcpmon_map", Progs: []uint{13, 14}})
+		sensorMaps = append(sensorMaps, tus.SensorMap{Name: "event_cgroup_stats_map", Progs: []uint{13, 14}})
 	}
 
 	readHook := `
@@ -5304,6 +5433,65 @@ spec:
 	assert.NoError(t, err)
 }
 
+func TestKprobeSockaddr(t *testing.T) {
+	var doneWG, readyWG sync.WaitGroup
+	defer doneWG.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), tus.Conf().CmdWaitTime)
+	defer cancel()
+
+	hook := `apiVersion: cilium.io/v1alpha1
+kind: TracingPolicy
+metadata:
+  name: "connect"
+spec:
+  kprobes:
+  - call: "security_socket_connect"
+    syscall: false
+    args:
+    - index: 1
+      type: "sockaddr"
+      label: "address"
+    selectors:
+    - matchArgs:
+      - index: 1
+        operator: "SPort"
+        values:
+        - "9919"
+`
+	createCrdFile(t, hook)
+
+	obs, err := observertesthelper.GetDefaultObserverWithFile(t, ctx, testConfigFile, tus.Conf().TetragonLib)
+	if err != nil {
+		t.Fatalf("GetDefaultObserverWithFile error: %s", err)
+	}
+	observertesthelper.LoopEvents(ctx, t, &doneWG, &readyWG, obs)
+	readyWG.Wait()
+
+	// the connection is refused, but the address is checked before
+	if conn, err := net.Dial("tcp", "127.0.0.1:9919"); err == nil {
+		conn.Close()
+	}
+
+	kpChecker := ec.NewProcessKprobeChecker("connect-checker").
+		WithFunctionName(sm.Full("security_socket_connect")).
+		WithArgs(ec.NewKprobeArgumentListMatcher().
+			WithOperator(lc.Ordered).
+			WithValues(
+				ec.NewKprobeArgumentChecker().WithLabel(sm.Full("address")).
+					WithSockaddrArg(ec.NewKprobeSockaddrChecker().
+						WithFamily(sm.Full("AF_INET")).
+						WithAddr(sm.Full("127.0.0.1")).
+						WithPort(9919),
+					),
+			))
+
+	checker := ec.NewUnorderedEventChecker(kpChecker)
+
+	err = jsonchecker.JsonTestCheck(t, checker)
+	assert.NoError(t, err)
+}
+
 func TestKprobeListSyscallDupsRange(t *testing.T) {
 	if !kernels.MinKernelVersion("5.3.0") {
 		t.Skip("TestCopyFd requires at least 5.3.0 version")
@@ -6,6 +6,7 @@ package tracing
 import (
 	"testing"
 
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
 	"github.com/cilium/tetragon/pkg/sensors"
 	"github.com/cilium/tetragon/pkg/tracingpolicy"
 	"github.com/stretchr/testify/assert"
@@ -250,3 +251,32 @@ spec:
 	err := checkCrd(t, crd)
 	assert.Error(t, err)
 }
+
+func TestKprobeValidationFdActions(t *testing.T) {
+	spec := func(args []v1alpha1.KProbeArg, action v1alpha1.ActionSelector) *v1alpha1.KProbeSpec {
+		return &v1alpha1.KProbeSpec{
+			Call: "fd_install",
+			Args: args,
+			Selectors: []v1alpha1.KProbeSelector{
+				{MatchActions: []v1alpha1.ActionSelector{action}},
+			},
+		}
+	}
+	fdInstallArgs := []v1alpha1.KProbeArg{{Index: 0, Type: "int"}, {Index: 1, Type: "file"}}
+	dupArgs := []v1alpha1.KProbeArg{{Index: 0, Type: "fd"}, {Index: 1, Type: "int"}}
+
+	// valid
+	assert.NoError(t, validateFdActions(spec(fdInstallArgs, v1alpha1.ActionSelector{Action: "FollowFD", ArgFd: 0, ArgName: 1})))
+	assert.NoError(t, validateFdActions(spec(dupArgs, v1alpha1.ActionSelector{Action: "CopyFD", ArgFd: 0, ArgName: 1})))
+	assert.NoError(t, validateFdActions(spec(dupArgs[1:], v1alpha1.ActionSelector{Action: "UnfollowFD", ArgFd: 1})))
+
+	// argument not defined
+	assert.Error(t, validateFdActions(spec(fdInstallArgs, v1alpha1.ActionSelector{Action: "FollowFD", ArgFd: 2, ArgName: 1})))
+	assert.Error(t, validateFdActions(spec(fdInstallArgs[:1], v1alpha1.ActionSelector{Action: "FollowFD", ArgFd: 0, ArgName: 1})))
+	assert.Error(t, validateFdActions(spec(dupArgs[1:], v1alpha1.ActionSelector{Action: "UnfollowFD", ArgFd: 0})))
+
+	// wrong argument types
+	assert.Error(t, validateFdActions(spec(fdInstallArgs, v1alpha1.ActionSelector{Action: "FollowFD", ArgFd: 1, ArgName: 0})))
+	assert.Error(t, validateFdActions(spec(dupArgs, v1alpha1.ActionSelector{Action: "FollowFD", ArgFd: 1, ArgName: 0})))
+	assert.Error(t, validateFdActions(spec(fdInstallArgs, v1alpha1.ActionSelector{Action: "CopyFD", ArgFd: 0, ArgName: 1})))
+}
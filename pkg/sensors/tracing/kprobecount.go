@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package tracing
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/tetragon/pkg/api/processapi"
+	"github.com/cilium/tetragon/pkg/bpf"
+	"github.com/cilium/tetragon/pkg/grpc/tracing"
+	"github.com/cilium/tetragon/pkg/idtable"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/observer"
+)
+
+// countReportInterval is the interval at which the calls counted by the
+// Count action are reported.
+const countReportInterval = 10 * time.Second
+
+// countKey mirrors struct count_key of bpf/process/types/basic.h.
+type countKey struct {
+	FuncId uint32
+	Pid    uint32
+	Ktime  uint64
+}
+
+// countMap returns the map where the calls of the kprobe are counted. The map
+// of multi kprobes is shared by all the kprobes of the sensor.
+func (gk *genericKprobe) countMap() (*ebpf.Map, error) {
+	// lazy load the map reference if needed
+	if gk.countMapRef == nil {
+		var err error
+		gk.countMapRef, err = ebpf.LoadPinnedMap(path.Join(bpf.MapPrefixPath(), gk.pinPathPrefix)+"-count_map", &ebpf.LoadPinOptions{
+			ReadOnly: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		// close this in cleanup postHook
+	}
+	return gk.countMapRef, nil
+}
+
+// kprobesHaveCount returns true if one of the kprobes has a Count action.
+func kprobesHaveCount(ids []idtable.EntryID) bool {
+	for _, id := range ids {
+		gk, err := genericKprobeTableGet(id)
+		if err == nil && gk.hasCount {
+			return true
+		}
+	}
+	return false
+}
+
+// readCounts returns the counts of a count map, summed over all CPUs.
+func readCounts(m *ebpf.Map) (map[countKey]uint64, error) {
+	ret := make(map[countKey]uint64)
+
+	var key countKey
+	var values []uint64
+	iter := m.Iterate()
+	for iter.Next(&key, &values) {
+		var sum uint64
+		for _, v := range values {
+			sum += v
+		}
+		ret[key] = sum
+	}
+	return ret, iter.Err()
+}
+
+// countReporter sends a ProcessKprobeCount event for every kprobe and process
+// whose calls were counted during a report window. The counts are never reset
+// in the kernel, so the reported counts are the differences with the counts at
+// the start of the window.
+type countReporter struct {
+	// counts at the start of the current report window, per count map
+	prevCounts map[string]map[countKey]uint64
+}
+
+// RunCountReporter reports the calls counted by the Count action of the
+// loaded kprobes until ctx is done.
+func RunCountReporter(ctx context.Context) {
+	r := &countReporter{}
+	ticker := time.NewTicker(countReportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.report()
+		}
+	}
+}
+
+func (r *countReporter) report() {
+	counts := make(map[string]map[countKey]uint64)
+	for _, entry := range genericKprobeTable.Entries() {
+		gk, ok := entry.(*genericKprobe)
+		if !ok || !gk.hasCount {
+			continue
+		}
+		if _, ok := counts[gk.pinPathPrefix]; ok {
+			continue
+		}
+		m, err := gk.countMap()
+		if err == nil {
+			counts[gk.pinPathPrefix], err = readCounts(m)
+		}
+		if err != nil {
+			logger.GetLogger().WithError(err).WithField("function", gk.funcName).
+				Warn("Failed to read the counts of the count action")
+		}
+	}
+
+	prevCounts := r.prevCounts
+	r.prevCounts = counts
+	for _, msg := range countMessages(prevCounts, counts) {
+		observer.AllListeners(msg)
+	}
+}
+
+// countMessages returns the messages of the calls counted between the prev and
+// cur counts.
+func countMessages(prev, cur map[string]map[countKey]uint64) []*tracing.MsgProcessKprobeCount {
+	var ret []*tracing.MsgProcessKprobeCount
+	for prefix, counts := range cur {
+		for key, c := range counts {
+			p := prev[prefix][key]
+			if c < p {
+				// the entry was evicted from the LRU map and
+				// added again since the previous counts
+				p = 0
+			}
+			if c == p {
+				continue
+			}
+			gk, err := genericKprobeTableGet(idtable.EntryID{ID: int(key.FuncId)})
+			if err != nil {
+				continue
+			}
+			ret = append(ret, &tracing.MsgProcessKprobeCount{
+				ProcessKey: processapi.MsgExecveKey{Pid: key.Pid, Ktime: key.Ktime},
+				FuncName:   gk.funcName,
+				PolicyName: gk.policyName,
+				Count:      c - p,
+				Window:     countReportInterval,
+			})
+		}
+	}
+	return ret
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package tracing
+
+import (
+	"path"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/tetragon/pkg/bpf"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/metrics/kprobemetrics"
+)
+
+const (
+	// latencyHistSlots is the number of log2 slots of the latency
+	// histograms, see bpf_generic_retkprobe.c
+	latencyHistSlots = 64
+	// latencyHistSum is the slot holding the sum of the latencies
+	latencyHistSum = latencyHistSlots
+	// latencyHistEntries is the number of entries of a kprobe in the
+	// latency_hist_map
+	latencyHistEntries = latencyHistSlots + 1
+)
+
+// latencyHistKey mirrors struct latency_hist_key of bpf_generic_retkprobe.c.
+type latencyHistKey struct {
+	FuncId uint32
+	Slot   uint32
+}
+
+// latencyHistogram reads the latency histogram of the kprobe from the
+// latency_hist_map, summing the values of all CPUs.
+func (gk *genericKprobe) latencyHistogram() (*kprobemetrics.LatencyHistogram, error) {
+	// lazy load the map reference if needed
+	if gk.latencyHistMapRef == nil {
+		var err error
+		gk.latencyHistMapRef, err = ebpf.LoadPinnedMap(path.Join(bpf.MapPrefixPath(), gk.pinPathPrefix)+"-latency_hist_map", &ebpf.LoadPinOptions{
+			ReadOnly: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		// close this in cleanup postHook
+	}
+
+	h := &kprobemetrics.LatencyHistogram{
+		Policy:   gk.policyName,
+		Function: gk.funcName,
+		Counts:   make([]uint64, latencyHistSlots),
+	}
+	key := latencyHistKey{FuncId: gk.loadArgs.config.FuncId}
+	var values []uint64
+	for slot := uint32(0); slot < latencyHistEntries; slot++ {
+		key.Slot = slot
+		if err := gk.latencyHistMapRef.Lookup(&key, &values); err != nil {
+			// the slot is not set until a latency falls in it
+			continue
+		}
+		var sum uint64
+		for _, v := range values {
+			sum += v
+		}
+		if slot == latencyHistSum {
+			h.Sum = sum
+		} else {
+			h.Counts[slot] = sum
+		}
+	}
+	return h, nil
+}
+
+// kprobeLatencyHistograms returns the latency histograms of the loaded kprobes
+// with latencyHistogram.
+func kprobeLatencyHistograms() []kprobemetrics.LatencyHistogram {
+	var ret []kprobemetrics.LatencyHistogram
+	for _, entry := range genericKprobeTable.Entries() {
+		gk, ok := entry.(*genericKprobe)
+		if !ok || gk.loadArgs.config.Flags&flagsLatencyHist == 0 {
+			continue
+		}
+		h, err := gk.latencyHistogram()
+		if err != nil {
+			logger.GetLogger().WithError(err).WithField("function", gk.funcName).
+				Debug("failed to read the latency histogram")
+			continue
+		}
+		ret = append(ret, *h)
+	}
+	return ret
+}
@@ -5,13 +5,18 @@ package tracing
 
 import (
 	"fmt"
+	"path"
 	"strings"
 
 	"github.com/cilium/tetragon/pkg/arch"
 	"github.com/cilium/tetragon/pkg/btf"
 	"github.com/cilium/tetragon/pkg/ftrace"
 	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"github.com/cilium/tetragon/pkg/ksyms"
+	"github.com/cilium/tetragon/pkg/option"
 	"github.com/cilium/tetragon/pkg/syscallinfo"
+
+	ebtf "github.com/cilium/ebpf/btf"
 )
 
 func getList(name string, lists []v1alpha1.ListSpec) *v1alpha1.ListSpec {
@@ -60,7 +65,7 @@ func validateList(list *v1alpha1.ListSpec) (err error) {
 	// Add prefix to syscalls list
 	if listTypeFromString(list.Type) == ListTypeSyscalls {
 		for idx := range list.Values {
-			symbol, err := arch.AddSyscallPrefix(list.Values[idx])
+			symbol, err := arch.SyscallSymbol(list.Values[idx])
 			if err != nil {
 				return err
 			}
@@ -113,6 +118,163 @@ func preValidateLists(lists []v1alpha1.ListSpec) (err error) {
 	return nil
 }
 
+func isCallPattern(call string) bool {
+	return strings.ContainsAny(call, "*?[")
+}
+
+// needsCallsExpansion returns true if the kprobe spec hooks several functions
+// with calls or a wildcard call, instead of a list or a single function.
+func needsCallsExpansion(f *v1alpha1.KProbeSpec) bool {
+	if len(f.Calls) > 0 {
+		return true
+	}
+	return !strings.HasPrefix(f.Call, "list:") && isCallPattern(f.Call)
+}
+
+// matchCalls returns the functions, or the syscalls if syscall is set, that
+// match a wildcard pattern. Functions need to be in BTF, and in the kernel
+// symbols if they are available, to be hooked.
+func matchCalls(spec *ebtf.Spec, pattern string, syscall bool) ([]string, error) {
+	if syscall {
+		var ret []string
+		for _, name := range syscallinfo.SyscallsNames() {
+			if ok, err := path.Match(pattern, name); err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+			} else if !ok {
+				continue
+			}
+			symbol, err := arch.SyscallSymbol(name)
+			if err != nil {
+				continue
+			}
+			var fn *ebtf.Func
+			if err := spec.TypeByName(symbol, &fn); err == nil {
+				ret = append(ret, name)
+			}
+		}
+		return ret, nil
+	}
+
+	funcs, err := btf.MatchFuncs(spec, pattern)
+	if err != nil {
+		return nil, err
+	}
+	// kernel symbols are optional, inlined functions are filtered out
+	// when they are available
+	ks, err := ksyms.KernelSymbols()
+	if err != nil {
+		return funcs, nil
+	}
+	ret := funcs[:0]
+	for _, fn := range funcs {
+		if ks.IsFunction(fn) {
+			ret = append(ret, fn)
+		}
+	}
+	return ret, nil
+}
+
+// expandKprobeCalls returns the kprobe specs and the lists of a policy where
+// each spec that hooks several functions, with calls or a wildcard call, uses
+// a list of the functions instead. The functions of such a spec then share its
+// selectors and are attached with kprobe_multi when it is available, like the
+// functions of a list. The specs and lists of the policy are not modified.
+func expandKprobeCalls(kprobes []v1alpha1.KProbeSpec, lists []v1alpha1.ListSpec) ([]v1alpha1.KProbeSpec, []v1alpha1.ListSpec, error) {
+	var btfobj *ebtf.Spec
+	loadBTF := func() (*ebtf.Spec, error) {
+		if btfobj != nil {
+			return btfobj, nil
+		}
+		spec, err := btf.NewBTF()
+		if err != nil {
+			return nil, err
+		}
+		if len(option.Config.KMods) > 0 {
+			spec, err = btf.AddModulesToSpec(spec, option.Config.KMods)
+			if err != nil {
+				return nil, fmt.Errorf("adding modules to spec failed: %w", err)
+			}
+		}
+		btfobj = spec
+		return btfobj, nil
+	}
+
+	var retKprobes []v1alpha1.KProbeSpec
+	var retLists []v1alpha1.ListSpec
+	for i := range kprobes {
+		f := &kprobes[i]
+		if f.Call == "" && len(f.Calls) == 0 {
+			return nil, nil, fmt.Errorf("kprobes[%d]: either call or calls must be set", i)
+		}
+		if f.Call != "" && len(f.Calls) > 0 {
+			return nil, nil, fmt.Errorf("kprobes[%d]: call and calls cannot be both set", i)
+		}
+		if !needsCallsExpansion(f) {
+			continue
+		}
+		if retKprobes == nil {
+			retKprobes = append([]v1alpha1.KProbeSpec{}, kprobes...)
+			// validation modifies the values of the lists
+			retLists = make([]v1alpha1.ListSpec, 0, len(lists)+1)
+			for j := range lists {
+				retLists = append(retLists, *lists[j].DeepCopy())
+			}
+		}
+
+		patterns := f.Calls
+		if len(patterns) == 0 {
+			patterns = []string{f.Call}
+		}
+		var values []string
+		seen := make(map[string]struct{})
+		for _, pattern := range patterns {
+			if strings.HasPrefix(pattern, "list:") {
+				return nil, nil, fmt.Errorf("kprobes[%d]: calls cannot refer to lists: '%s'", i, pattern)
+			}
+			matches := []string{pattern}
+			if isCallPattern(pattern) {
+				spec, err := loadBTF()
+				if err != nil {
+					return nil, nil, err
+				}
+				matches, err = matchCalls(spec, pattern, f.Syscall)
+				if err != nil {
+					return nil, nil, fmt.Errorf("kprobes[%d]: %w", i, err)
+				}
+				if len(matches) == 0 {
+					return nil, nil, fmt.Errorf("kprobes[%d]: no function matches '%s'", i, pattern)
+				}
+			}
+			for _, m := range matches {
+				if _, ok := seen[m]; !ok {
+					seen[m] = struct{}{}
+					values = append(values, m)
+				}
+			}
+		}
+
+		name := fmt.Sprintf("kprobes[%d].calls", i)
+		typ := ""
+		if f.Syscall {
+			typ = "syscalls"
+		}
+		retLists = append(retLists, v1alpha1.ListSpec{
+			Name:   name,
+			Type:   typ,
+			Values: values,
+		})
+		spec := f.DeepCopy()
+		spec.Call = "list:" + name
+		spec.Calls = nil
+		retKprobes[i] = *spec
+	}
+
+	if retKprobes == nil {
+		return kprobes, lists, nil
+	}
+	return retKprobes, retLists, nil
+}
+
 type listReader struct {
 	lists []v1alpha1.ListSpec
 }
@@ -22,25 +22,45 @@
 // - checks the perf event id matches the one from bpf map
 // - reads needed data from perf event and sends LOADER event
 //   to user space
+//
+// Policies can also specify an allowlist of library build ids (e.g. generated
+// from an SBOM). Each such policy gets a slot in the loader_policies map, and
+// its build ids are loaded into the loader_allowlist map keyed by the slot.
+// The bpf side then sends LOADER events for libraries outside of the allowlist
+// of a policy, flagged as allowlist violations, and all the LOADER events if a
+// loader policy without an allowlist is loaded. The binaries of an allowlist
+// are matched by their names_map ids, and the Sigkill action is enforced by
+// the bpf side.
 
 package tracing
 
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
 	"syscall"
 	"unsafe"
 
+	"github.com/cilium/ebpf"
 	"github.com/cilium/tetragon/pkg/api/ops"
+	"github.com/cilium/tetragon/pkg/api/processapi"
 	"github.com/cilium/tetragon/pkg/api/tracingapi"
 	"github.com/cilium/tetragon/pkg/bpf"
 	"github.com/cilium/tetragon/pkg/grpc/tracing"
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
 	"github.com/cilium/tetragon/pkg/kernels"
 	"github.com/cilium/tetragon/pkg/logger"
 	"github.com/cilium/tetragon/pkg/observer"
 	"github.com/cilium/tetragon/pkg/policyfilter"
+	"github.com/cilium/tetragon/pkg/process"
+	"github.com/cilium/tetragon/pkg/selectors"
 	"github.com/cilium/tetragon/pkg/sensors"
+	"github.com/cilium/tetragon/pkg/sensors/base"
 	"github.com/cilium/tetragon/pkg/sensors/program"
 	"github.com/cilium/tetragon/pkg/strutils"
 	"github.com/cilium/tetragon/pkg/tracingpolicy"
@@ -56,11 +76,156 @@ var (
 		"loader",
 	)
 
-	idsMap = program.MapBuilder("ids_map", loader)
+	idsMap             = program.MapBuilder("ids_map", loader)
+	loaderConfigMap    = program.MapBuilder("loader_config_map", loader)
+	loaderPoliciesMap  = program.MapBuilder("loader_policies", loader)
+	loaderAllowlistMap = program.MapBuilder("loader_allowlist", loader)
 
 	loaderEnabled bool
+
+	// loaderPolicies holds the allowlists of the loaded policies, by
+	// their slot in the loader_policies map, and the number of loaded
+	// policies without an allowlist
+	loaderPolicies struct {
+		mu        sync.Mutex
+		slots     [loaderMaxPolicies]*loaderAllowlistState
+		reportAll uint32
+	}
+)
+
+const (
+	// loaderMaxPolicies is the number of policies with an allowlist
+	// (LOADER_MAX_POLICIES in bpf_loader.c)
+	loaderMaxPolicies = 8
+	// loaderMaxBinaries is the number of binaries of an allowlist
+	// (LOADER_MAX_BINARIES in bpf_loader.c)
+	loaderMaxBinaries = 8
+
+	loaderPolicyFlagSigkill = 1
 )
 
+// loaderPolicy is the value of the loader_policies map.
+type loaderPolicy struct {
+	Active   uint32
+	Flags    uint32
+	Binaries [loaderMaxBinaries]uint32
+}
+
+// loaderAllowlistKey is the key of the loader_allowlist map.
+type loaderAllowlistKey struct {
+	Policy  uint32
+	BuildID [20]byte
+}
+
+// loaderAllowlistState is the parsed LoaderAllowlistSpec of a policy.
+type loaderAllowlistState struct {
+	policy   string
+	slot     uint32
+	buildIDs [][20]byte
+	binaries map[string]struct{}
+	sigkill  bool
+}
+
+func newLoaderAllowlist(policy string, spec *v1alpha1.LoaderAllowlistSpec) (*loaderAllowlistState, error) {
+	a := &loaderAllowlistState{
+		policy:   policy,
+		binaries: make(map[string]struct{}, len(spec.Binaries)),
+	}
+
+	for _, s := range spec.BuildIDs {
+		id, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid loader allowlist build id '%s': %w", s, err)
+		}
+		if len(id) == 0 || len(id) > 20 {
+			return nil, fmt.Errorf("invalid loader allowlist build id '%s': size must be between 1 and 20 bytes", s)
+		}
+		var key [20]byte
+		copy(key[:], id)
+		a.buildIDs = append(a.buildIDs, key)
+	}
+
+	if len(spec.Binaries) > loaderMaxBinaries {
+		return nil, fmt.Errorf("loader allowlist supports up to %d binaries", loaderMaxBinaries)
+	}
+	for _, b := range spec.Binaries {
+		if len(b) >= 256 {
+			return nil, fmt.Errorf("invalid loader allowlist binary '%s': path must be shorter than 256 bytes", b)
+		}
+		a.binaries[b] = struct{}{}
+	}
+
+	switch strings.ToLower(spec.Action) {
+	case "", "post":
+	case "sigkill":
+		a.sigkill = true
+	default:
+		return nil, fmt.Errorf("invalid loader allowlist action '%s'", spec.Action)
+	}
+	return a, nil
+}
+
+// addLoaderAllowlist assigns a slot of the loader_policies map to the
+// allowlist.
+func addLoaderAllowlist(a *loaderAllowlistState) error {
+	loaderPolicies.mu.Lock()
+	defer loaderPolicies.mu.Unlock()
+	for i, slot := range loaderPolicies.slots {
+		if slot == nil {
+			a.slot = uint32(i)
+			loaderPolicies.slots[i] = a
+			return nil
+		}
+	}
+	return fmt.Errorf("loader allowlist supports up to %d policies", loaderMaxPolicies)
+}
+
+func removeLoaderAllowlist(a *loaderAllowlistState) {
+	loaderPolicies.mu.Lock()
+	defer loaderPolicies.mu.Unlock()
+	if loaderPolicies.slots[a.slot] == a {
+		loaderPolicies.slots[a.slot] = nil
+	}
+}
+
+// matchesProcess returns true if the allowlist applies to the process. It is
+// used for processes that the bpf side could not match, since they started
+// before the binaries of the allowlist were added to the names_map. Processes
+// missing from the process cache are matched, so that their violations are
+// reported.
+func (a *loaderAllowlistState) matchesProcess(proc *process.ProcessInternal) bool {
+	if len(a.binaries) == 0 || proc == nil {
+		return true
+	}
+	_, ok := a.binaries[proc.UnsafeGetProcess().GetBinary()]
+	return ok
+}
+
+// loaderViolations returns the policies whose allowlist the library of a
+// LOADER event is outside of. violations are the slots of the allowlists that
+// the bpf side matched, unknown the ones it could not match the process to.
+func loaderViolations(key processapi.MsgExecveKey, violations, unknown uint32) []string {
+	var proc *process.ProcessInternal
+	if unknown != 0 {
+		proc, _ = process.Get(process.GetProcessID(key.Pid, key.Ktime))
+	}
+
+	loaderPolicies.mu.Lock()
+	defer loaderPolicies.mu.Unlock()
+
+	var policies []string
+	for i, a := range loaderPolicies.slots {
+		if a == nil {
+			continue
+		}
+		bit := uint32(1) << i
+		if violations&bit != 0 || (unknown&bit != 0 && a.matchesProcess(proc)) {
+			policies = append(policies, a.policy)
+		}
+	}
+	return policies
+}
+
 type loaderSensor struct {
 	name string
 }
@@ -79,7 +244,7 @@ func GetLoaderSensor() *sensors.Sensor {
 	return &sensors.Sensor{
 		Name:  "__loader__",
 		Progs: []*program.Program{loader},
-		Maps:  []*program.Map{idsMap},
+		Maps:  []*program.Map{idsMap, loaderConfigMap, loaderPoliciesMap, loaderAllowlistMap},
 	}
 }
 
@@ -101,8 +266,42 @@ func (k *loaderSensor) PolicyHandler(p tracingpolicy.TracingPolicy, fid policyfi
 	if !hasLoaderEvents() {
 		return nil, fmt.Errorf("Loader event are not supported on running kernel")
 	}
+
+	sensor := GetLoaderSensor()
+	if spec.LoaderAllowlist == nil {
+		// the loader program is shared by the policies, so the bpf
+		// side sends all the LOADER events while a policy without
+		// an allowlist is loaded
+		sensor.PostLoadHook = func() error {
+			return updateLoaderReportAll(1)
+		}
+		sensor.PreUnloadHook = func() error {
+			return updateLoaderReportAll(-1)
+		}
+	} else {
+		allowlist, err := newLoaderAllowlist(p.TpName(), spec.LoaderAllowlist)
+		if err != nil {
+			return nil, err
+		}
+		if err := addLoaderAllowlist(allowlist); err != nil {
+			return nil, err
+		}
+		// the allowlist is written to the maps of the loader program
+		// when the sensor of the policy is loaded, and cleared when
+		// it is unloaded
+		sensor.PostLoadHook = func() error {
+			return writeLoaderAllowlist(allowlist)
+		}
+		sensor.PreUnloadHook = func() error {
+			return clearLoaderAllowlist(allowlist)
+		}
+		sensor.DestroyHook = func() error {
+			removeLoaderAllowlist(allowlist)
+			return nil
+		}
+	}
 	loaderEnabled = true
-	return GetLoaderSensor(), nil
+	return sensor, nil
 }
 
 func createLoaderEvents() error {
@@ -146,6 +345,72 @@ func createLoaderEvents() error {
 	return nil
 }
 
+func updateLoaderReportAll(delta int32) error {
+	loaderPolicies.mu.Lock()
+	defer loaderPolicies.mu.Unlock()
+
+	loaderPolicies.reportAll = uint32(int32(loaderPolicies.reportAll) + delta)
+	key := uint32(0)
+	if err := loaderConfigMap.MapHandle.Put(key, loaderPolicies.reportAll); err != nil {
+		return fmt.Errorf("failed to update loader_config_map: %w", err)
+	}
+	return nil
+}
+
+func writeLoaderAllowlist(a *loaderAllowlistState) error {
+	policy := loaderPolicy{Active: 1}
+	if a.sigkill {
+		policy.Flags |= loaderPolicyFlagSigkill
+	}
+
+	if len(a.binaries) > 0 {
+		names, err := ebpf.LoadPinnedMap(filepath.Join(bpf.MapPrefixPath(), base.NamesMap.Name), nil)
+		if err != nil {
+			return fmt.Errorf("failed to open names_map: %w", err)
+		}
+		defer names.Close()
+
+		i := 0
+		for b := range a.binaries {
+			id := selectors.BinaryID(b)
+			if err := writeBinaryMap(names, id, b); err != nil {
+				return fmt.Errorf("failed to update names_map: %w", err)
+			}
+			policy.Binaries[i] = id
+			i++
+		}
+	}
+
+	for _, id := range a.buildIDs {
+		key := loaderAllowlistKey{Policy: a.slot, BuildID: id}
+		if err := loaderAllowlistMap.MapHandle.Put(key, uint8(1)); err != nil {
+			return fmt.Errorf("failed to update loader_allowlist: %w", err)
+		}
+	}
+
+	if err := loaderPoliciesMap.MapHandle.Put(a.slot, policy); err != nil {
+		return fmt.Errorf("failed to update loader_policies: %w", err)
+	}
+	return nil
+}
+
+// clearLoaderAllowlist removes the allowlist from the maps of the loader
+// program. The names_map entries of its binaries are kept, since processes
+// keep the ids they matched at exec time.
+func clearLoaderAllowlist(a *loaderAllowlistState) error {
+	var errs error
+	if err := loaderPoliciesMap.MapHandle.Put(a.slot, loaderPolicy{}); err != nil {
+		errs = errors.Join(errs, fmt.Errorf("failed to update loader_policies: %w", err))
+	}
+	for _, id := range a.buildIDs {
+		key := loaderAllowlistKey{Policy: a.slot, BuildID: id}
+		if err := loaderAllowlistMap.MapHandle.Delete(key); err != nil && !errors.Is(err, ebpf.ErrKeyNotExist) {
+			errs = errors.Join(errs, fmt.Errorf("failed to delete from loader_allowlist: %w", err))
+		}
+	}
+	return errs
+}
+
 func (k *loaderSensor) LoadProbe(args sensors.LoadProbeArgs) error {
 	if loaderEnabled {
 		if err := createLoaderEvents(); err != nil {
@@ -172,5 +437,13 @@ func handleLoader(r *bytes.Reader) ([]observer.Event, error) {
 		Path:       strutils.UTF8FromBPFBytes(path),
 		Buildid:    m.BuildId[:m.BuildIdSize],
 	}
+
+	if m.Violations != 0 || m.Unknown != 0 {
+		msg.AllowlistPolicies = loaderViolations(m.ProcessKey, m.Violations, m.Unknown)
+		msg.AllowlistViolation = len(msg.AllowlistPolicies) > 0
+	}
+	if !msg.AllowlistViolation && m.Flags&tracingapi.LoaderFlagReportAll == 0 {
+		return nil, nil
+	}
 	return []observer.Event{msg}, nil
 }
@@ -15,7 +15,9 @@ import (
 	"testing"
 
 	ec "github.com/cilium/tetragon/api/v1/tetragon/codegen/eventchecker"
+	"github.com/cilium/tetragon/pkg/api/processapi"
 	"github.com/cilium/tetragon/pkg/jsonchecker"
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
 	"github.com/cilium/tetragon/pkg/matchers/bytesmatcher"
 	sm "github.com/cilium/tetragon/pkg/matchers/stringmatcher"
 	"github.com/cilium/tetragon/pkg/observer/observertesthelper"
@@ -140,3 +142,59 @@ spec:
 	err = jsonchecker.JsonTestCheck(t, checker)
 	assert.NoError(t, err)
 }
+
+func TestLoaderAllowlistParse(t *testing.T) {
+	a, err := newLoaderAllowlist("policy", &v1alpha1.LoaderAllowlistSpec{
+		BuildIDs: []string{"0102030405060708090a0b0c0d0e0f1011121314", "abcd"},
+		Binaries: []string{"/usr/bin/curl"},
+		Action:   "Sigkill",
+	})
+	assert.NoError(t, err)
+	assert.True(t, a.sigkill)
+	assert.Len(t, a.buildIDs, 2)
+	assert.Equal(t, [20]byte{0xab, 0xcd}, a.buildIDs[1])
+	assert.Contains(t, a.binaries, "/usr/bin/curl")
+
+	a, err = newLoaderAllowlist("policy", &v1alpha1.LoaderAllowlistSpec{BuildIDs: []string{"abcd"}})
+	assert.NoError(t, err)
+	assert.False(t, a.sigkill)
+
+	for _, spec := range []v1alpha1.LoaderAllowlistSpec{
+		{BuildIDs: []string{"xyz"}},
+		{BuildIDs: []string{""}},
+		{BuildIDs: []string{"0102030405060708090a0b0c0d0e0f101112131415"}},
+		{BuildIDs: []string{"abcd"}, Action: "Override"},
+		{BuildIDs: []string{"abcd"}, Binaries: []string{"/1", "/2", "/3", "/4", "/5", "/6", "/7", "/8", "/9"}},
+	} {
+		_, err := newLoaderAllowlist("policy", &spec)
+		assert.Error(t, err, "spec %+v", spec)
+	}
+}
+
+func TestLoaderAllowlistViolations(t *testing.T) {
+	a, err := newLoaderAllowlist("curl", &v1alpha1.LoaderAllowlistSpec{
+		BuildIDs: []string{"abcd"},
+		Binaries: []string{"/usr/bin/curl"},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, addLoaderAllowlist(a))
+	defer removeLoaderAllowlist(a)
+
+	b, err := newLoaderAllowlist("any", &v1alpha1.LoaderAllowlistSpec{BuildIDs: []string{"abcd"}})
+	assert.NoError(t, err)
+	assert.NoError(t, addLoaderAllowlist(b))
+	defer removeLoaderAllowlist(b)
+	assert.NotEqual(t, a.slot, b.slot)
+
+	// the allowlists are reported separately
+	key := processapi.MsgExecveKey{Pid: 1, Ktime: 1}
+	assert.Equal(t, []string{"any"}, loaderViolations(key, 1<<b.slot, 0))
+	assert.Empty(t, loaderViolations(key, 0, 0))
+
+	// processes that the bpf side could not match and that are missing
+	// from the process cache are reported
+	assert.Equal(t, []string{"curl"}, loaderViolations(key, 0, 1<<a.slot))
+
+	assert.True(t, a.matchesProcess(nil))
+	assert.True(t, b.matchesProcess(nil))
+}
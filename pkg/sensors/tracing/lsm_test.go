@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package tracing
+
+import (
+	"testing"
+
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLsmHookName(t *testing.T) {
+	assert.Equal(t, "file_open", lsmHookName(&v1alpha1.LsmHookSpec{Hook: "file_open"}))
+	assert.Equal(t, "file_open", lsmHookName(&v1alpha1.LsmHookSpec{Hook: "security_file_open"}))
+}
+
+func TestLsmHasOverride(t *testing.T) {
+	spec := &v1alpha1.LsmHookSpec{
+		Hook: "file_open",
+		Selectors: []v1alpha1.KProbeSelector{
+			{MatchActions: []v1alpha1.ActionSelector{{Action: "Post"}}},
+		},
+	}
+	assert.False(t, hasLsmOverride(spec))
+
+	spec.Selectors = append(spec.Selectors, v1alpha1.KProbeSelector{
+		MatchActions: []v1alpha1.ActionSelector{{Action: "Override", ArgError: -1}},
+	})
+	assert.True(t, hasLsmOverride(spec))
+}
+
+func TestLsmSpecErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		spec v1alpha1.LsmHookSpec
+	}{
+		{
+			name: "no hook",
+			spec: v1alpha1.LsmHookSpec{Hook: "security_"},
+		},
+		{
+			name: "invalid arg type",
+			spec: v1alpha1.LsmHookSpec{
+				Hook: "file_open",
+				Args: []v1alpha1.KProbeArg{{Index: 0, Type: "foo"}},
+			},
+		},
+		{
+			name: "arg index out of bounds",
+			spec: v1alpha1.LsmHookSpec{
+				Hook: "file_open",
+				Args: []v1alpha1.KProbeArg{{Index: 5, Type: "file"}},
+			},
+		},
+		{
+			name: "returnCopy arg",
+			spec: v1alpha1.LsmHookSpec{
+				Hook: "file_open",
+				Args: []v1alpha1.KProbeArg{{Index: 0, Type: "char_buf", ReturnCopy: true}},
+			},
+		},
+		{
+			name: "matchReturnArgs selector",
+			spec: v1alpha1.LsmHookSpec{
+				Hook: "file_open",
+				Selectors: []v1alpha1.KProbeSelector{{
+					MatchReturnArgs: []v1alpha1.ArgSelector{{Operator: "Equal", Values: []string{"0"}}},
+				}},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := createGenericLsmSensor("test", []v1alpha1.LsmHookSpec{tc.spec}, "policy", false)
+			assert.Error(t, err)
+		})
+	}
+}
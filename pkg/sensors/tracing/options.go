@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package tracing
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/cilium/tetragon/pkg/bpf"
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/observer"
+	"github.com/cilium/tetragon/pkg/selectors"
+	"github.com/cilium/tetragon/pkg/sensors"
+	"github.com/cilium/tetragon/pkg/sensors/program"
+	"github.com/cilium/tetragon/pkg/strutils"
+	"golang.org/x/sys/unix"
+)
+
+// kprobeOptions are the options of the kprobes of a policy. The map sizes
+// replace the ones of the BPF programs when they are not zero.
+type kprobeOptions struct {
+	DisableKprobeMulti bool
+	// maximum number of value lists of the argument, address and string
+	// prefix and postfix selectors
+	SelectorMapsMaxEntries int
+	// maximum number of value lists of each of the string_maps_* maps
+	StringMapsMaxEntries int
+	// maximum number of file descriptors followed by FollowFD
+	FdInstallMapMaxEntries int
+	// maximum number of frames of the stack traces
+	StackTraceDepth int
+	// size of the per CPU buffer and wakeup watermark, in bytes, of the
+	// perf ring buffer of the policy, which uses the one of the agent if
+	// the size is zero
+	PerfBufferSize int
+	PerfWatermark  int
+}
+
+type kprobeOption struct {
+	name string
+	set  func(str string, options *kprobeOptions) error
+}
+
+func parseMaxEntries(str string) (int, error) {
+	val, err := strconv.ParseUint(str, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	if val == 0 {
+		return 0, fmt.Errorf("max entries must be positive")
+	}
+	return int(val), nil
+}
+
+var kprobeOpts = map[string]kprobeOption{
+	"disable-kprobe-multi": {
+		name: "disable-kprobe-multi",
+		set: func(str string, options *kprobeOptions) (err error) {
+			options.DisableKprobeMulti, err = strconv.ParseBool(str)
+			return err
+		},
+	},
+	"selector-maps-max-entries": {
+		name: "selector-maps-max-entries",
+		set: func(str string, options *kprobeOptions) (err error) {
+			options.SelectorMapsMaxEntries, err = parseMaxEntries(str)
+			return err
+		},
+	},
+	"string-maps-max-entries": {
+		name: "string-maps-max-entries",
+		set: func(str string, options *kprobeOptions) (err error) {
+			options.StringMapsMaxEntries, err = parseMaxEntries(str)
+			return err
+		},
+	},
+	"fdinstall-map-max-entries": {
+		name: "fdinstall-map-max-entries",
+		set: func(str string, options *kprobeOptions) (err error) {
+			options.FdInstallMapMaxEntries, err = parseMaxEntries(str)
+			return err
+		},
+	},
+	"stack-trace-depth": {
+		name: "stack-trace-depth",
+		set: func(str string, options *kprobeOptions) error {
+			depth, err := strconv.ParseUint(str, 10, 32)
+			if err != nil {
+				return err
+			}
+			if depth == 0 || depth > unix.PERF_MAX_STACK_DEPTH {
+				return fmt.Errorf("stack trace depth must be between 1 and %d", unix.PERF_MAX_STACK_DEPTH)
+			}
+			options.StackTraceDepth = int(depth)
+			return nil
+		},
+	},
+	"perf-buffer-size": {
+		name: "perf-buffer-size",
+		set: func(str string, options *kprobeOptions) (err error) {
+			options.PerfBufferSize, err = strutils.ParseSize(str)
+			if err == nil && options.PerfBufferSize <= 0 {
+				err = fmt.Errorf("perf buffer size must be positive")
+			}
+			return err
+		},
+	},
+	"perf-watermark": {
+		name: "perf-watermark",
+		set: func(str string, options *kprobeOptions) (err error) {
+			options.PerfWatermark, err = strutils.ParseSize(str)
+			return err
+		},
+	},
+}
+
+// getKprobeOptions parses the options of a policy that apply to its kprobes.
+func getKprobeOptions(specs []v1alpha1.OptionSpec) (*kprobeOptions, error) {
+	options := &kprobeOptions{}
+
+	for _, spec := range specs {
+		opt, ok := kprobeOpts[spec.Name]
+		if !ok {
+			return nil, fmt.Errorf("unknown option %q", spec.Name)
+		}
+		if err := opt.set(spec.Value, options); err != nil {
+			return nil, fmt.Errorf("failed to set option %s: %w", opt.name, err)
+		}
+		logger.GetLogger().Infof("Set option %s = %s", spec.Name, spec.Value)
+	}
+
+	if options.PerfWatermark != 0 {
+		if options.PerfBufferSize == 0 {
+			return nil, fmt.Errorf("option perf-watermark requires option perf-buffer-size")
+		}
+		if options.PerfWatermark < 0 || options.PerfWatermark >= options.PerfBufferSize {
+			return nil, fmt.Errorf("option perf-watermark must be smaller than perf-buffer-size")
+		}
+	}
+
+	return options, nil
+}
+
+// sizeMaps applies the map sizes of the options to maps.
+func (o *kprobeOptions) sizeMaps(maps ...*program.Map) {
+	for _, m := range maps {
+		switch m.Name {
+		case "argfilter_maps", "addr4lpm_maps", "addr6lpm_maps", "string_prefix_maps", "string_postfix_maps":
+			if o.SelectorMapsMaxEntries > 0 {
+				m.SetMaxEntries(o.SelectorMapsMaxEntries)
+			}
+		case "fdinstall_map":
+			if o.FdInstallMapMaxEntries > 0 {
+				m.SetMaxEntries(o.FdInstallMapMaxEntries)
+			}
+		case "stack_trace_map":
+			if o.StackTraceDepth > 0 {
+				m.SetValueSize(o.StackTraceDepth * 8)
+			}
+		default:
+			for i := 0; i < selectors.StringMapsNumSubMaps; i++ {
+				if m.Name == fmt.Sprintf("string_maps_%d", i) && o.StringMapsMaxEntries > 0 {
+					m.SetMaxEntries(o.StringMapsMaxEntries)
+				}
+			}
+		}
+	}
+}
+
+// perfBufferHooks returns the hooks of a sensor that start and stop the
+// reader of the perf ring buffer of the policy, if it has one.
+func perfBufferHooks(sensorPath string, options *kprobeOptions) (postLoad, preUnload sensors.SensorHook) {
+	if options.PerfBufferSize == 0 {
+		return nil, nil
+	}
+
+	var reader *observer.PerfReader
+	postLoad = func() error {
+		var err error
+		pinPath := filepath.Join(bpf.MapPrefixPath(), sensors.PathJoin(sensorPath, "tcpmon_map"))
+		reader, err = observer.StartPerfReader(pinPath, options.PerfBufferSize, options.PerfWatermark)
+		return err
+	}
+	preUnload = func() error {
+		if reader == nil {
+			return nil
+		}
+		err := reader.Close()
+		reader = nil
+		return err
+	}
+	return postLoad, preUnload
+}
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package tracing
+
+import (
+	"testing"
+
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"github.com/cilium/tetragon/pkg/sensors/program"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetKprobeOptions(t *testing.T) {
+	options, err := getKprobeOptions([]v1alpha1.OptionSpec{
+		{Name: "disable-kprobe-multi", Value: "true"},
+		{Name: "selector-maps-max-entries", Value: "16"},
+		{Name: "string-maps-max-entries", Value: "32"},
+		{Name: "fdinstall-map-max-entries", Value: "1024"},
+		{Name: "stack-trace-depth", Value: "32"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &kprobeOptions{
+		DisableKprobeMulti:     true,
+		SelectorMapsMaxEntries: 16,
+		StringMapsMaxEntries:   32,
+		FdInstallMapMaxEntries: 1024,
+		StackTraceDepth:        32,
+	}, options)
+
+	for _, spec := range []v1alpha1.OptionSpec{
+		{Name: "unknown", Value: "1"},
+		{Name: "disable-kprobe-multi", Value: "maybe"},
+		{Name: "fdinstall-map-max-entries", Value: "0"},
+		{Name: "string-maps-max-entries", Value: "-1"},
+		{Name: "stack-trace-depth", Value: "128"},
+		{Name: "perf-buffer-size", Value: "0"},
+		{Name: "perf-watermark", Value: "4K"},
+	} {
+		_, err := getKprobeOptions([]v1alpha1.OptionSpec{spec})
+		assert.Error(t, err, "option %s=%s", spec.Name, spec.Value)
+	}
+}
+
+func TestGetKprobeOptionsPerfBuffer(t *testing.T) {
+	options, err := getKprobeOptions([]v1alpha1.OptionSpec{
+		{Name: "perf-buffer-size", Value: "1M"},
+		{Name: "perf-watermark", Value: "64K"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1024*1024, options.PerfBufferSize)
+	assert.Equal(t, 64*1024, options.PerfWatermark)
+	postLoad, preUnload := perfBufferHooks("sensor", options)
+	assert.NotNil(t, postLoad)
+	assert.NotNil(t, preUnload)
+
+	_, err = getKprobeOptions([]v1alpha1.OptionSpec{
+		{Name: "perf-buffer-size", Value: "64K"},
+		{Name: "perf-watermark", Value: "64K"},
+	})
+	assert.Error(t, err)
+
+	// the policy uses the perf ring buffer of the agent by default
+	postLoad, preUnload = perfBufferHooks("sensor", &kprobeOptions{})
+	assert.Nil(t, postLoad)
+	assert.Nil(t, preUnload)
+}
+
+func TestKprobeOptionsSizeMaps(t *testing.T) {
+	load := program.Builder("", "", "", "", "")
+	fdinstall := program.MapBuilder("fdinstall_map", load)
+	argFilter := program.MapBuilder("argfilter_maps", load)
+	stringMap := program.MapBuilder("string_maps_3", load)
+	stackTrace := program.MapBuilder("stack_trace_map", load)
+	config := program.MapBuilder("config_map", load)
+
+	options := &kprobeOptions{
+		SelectorMapsMaxEntries: 16,
+		StringMapsMaxEntries:   32,
+		FdInstallMapMaxEntries: 1024,
+		StackTraceDepth:        32,
+	}
+	options.sizeMaps(fdinstall, argFilter, stringMap, stackTrace, config)
+	assert.Equal(t, map[string]uint32{
+		"fdinstall_map":  1024,
+		"argfilter_maps": 16,
+		"string_maps_3":  32,
+	}, load.MaxEntriesMap)
+	assert.Equal(t, map[string]uint32{"stack_trace_map": 256}, load.ValueSizeMap)
+
+	// maps are left alone without options
+	load = program.Builder("", "", "", "", "")
+	(&kprobeOptions{}).sizeMaps(program.MapBuilder("fdinstall_map", load))
+	assert.Empty(t, load.MaxEntriesMap)
+}
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package tracing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func TestPerfEventAttachData(t *testing.T) {
+	data, err := perfEventAttachData(&v1alpha1.PerfEventSpec{Type: "software", Event: "page-faults"})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(unix.PERF_TYPE_SOFTWARE), data.Type)
+	assert.Equal(t, uint64(unix.PERF_COUNT_SW_PAGE_FAULTS), data.Config)
+	assert.Equal(t, uint64(perfEventDefaultFrequency), data.Sample)
+	assert.True(t, data.Freq)
+
+	data, err = perfEventAttachData(&v1alpha1.PerfEventSpec{Type: "hardware", Event: "cpu-cycles", SamplePeriod: 1000000})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(unix.PERF_TYPE_HARDWARE), data.Type)
+	assert.Equal(t, uint64(unix.PERF_COUNT_HW_CPU_CYCLES), data.Config)
+	assert.Equal(t, uint64(1000000), data.Sample)
+	assert.False(t, data.Freq)
+
+	data, err = perfEventAttachData(&v1alpha1.PerfEventSpec{Type: "software", Event: "cpu-clock", SampleFrequency: 49})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(49), data.Sample)
+	assert.True(t, data.Freq)
+}
+
+func TestPerfEventThreshold(t *testing.T) {
+	threshold, err := perfEventThresholdFromSpec(&v1alpha1.PerfEventSpec{})
+	require.NoError(t, err)
+	assert.Zero(t, threshold.Samples)
+
+	threshold, err = perfEventThresholdFromSpec(&v1alpha1.PerfEventSpec{
+		Threshold: &v1alpha1.PerfEventThresholdSpec{Samples: 100},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), threshold.Samples)
+	assert.Equal(t, uint64(time.Second), threshold.Window)
+
+	threshold, err = perfEventThresholdFromSpec(&v1alpha1.PerfEventSpec{
+		Threshold: &v1alpha1.PerfEventThresholdSpec{Samples: 10, Window: "500ms"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(500*time.Millisecond), threshold.Window)
+}
+
+func TestPerfEventSpecErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		spec v1alpha1.PerfEventSpec
+	}{
+		{
+			name: "invalid type",
+			spec: v1alpha1.PerfEventSpec{Type: "tracepoint", Event: "page-faults"},
+		},
+		{
+			name: "unknown event",
+			spec: v1alpha1.PerfEventSpec{Type: "software", Event: "cpu-cycles"},
+		},
+		{
+			name: "period and frequency",
+			spec: v1alpha1.PerfEventSpec{Type: "software", Event: "page-faults", SamplePeriod: 10, SampleFrequency: 10},
+		},
+		{
+			name: "zero threshold",
+			spec: v1alpha1.PerfEventSpec{
+				Type:      "software",
+				Event:     "page-faults",
+				Threshold: &v1alpha1.PerfEventThresholdSpec{},
+			},
+		},
+		{
+			name: "invalid window",
+			spec: v1alpha1.PerfEventSpec{
+				Type:      "software",
+				Event:     "page-faults",
+				Threshold: &v1alpha1.PerfEventThresholdSpec{Samples: 10, Window: "-1s"},
+			},
+		},
+		{
+			name: "matchArgs selector",
+			spec: v1alpha1.PerfEventSpec{
+				Type:  "software",
+				Event: "page-faults",
+				Selectors: []v1alpha1.KProbeSelector{{
+					MatchArgs: []v1alpha1.ArgSelector{{Index: 0, Operator: "Equal", Values: []string{"1"}}},
+				}},
+			},
+		},
+		{
+			name: "override action",
+			spec: v1alpha1.PerfEventSpec{
+				Type:  "software",
+				Event: "page-faults",
+				Selectors: []v1alpha1.KProbeSelector{{
+					MatchActions: []v1alpha1.ActionSelector{{Action: "Override", ArgError: -1}},
+				}},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := createGenericPerfEventSensor("test", []v1alpha1.PerfEventSpec{tc.spec}, "policy", false)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"sync/atomic"
 
 	"github.com/cilium/tetragon/pkg/eventhandler"
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
 	"github.com/cilium/tetragon/pkg/policyfilter"
 	"github.com/cilium/tetragon/pkg/sensors"
 	"github.com/cilium/tetragon/pkg/tracingpolicy"
@@ -34,15 +35,26 @@ func (h policyHandler) PolicyHandler(
 	handler := eventhandler.GetCustomEventhandler(policy)
 	if len(spec.KProbes) > 0 {
 		name := fmt.Sprintf("gkp-sensor-%d", atomic.AddUint64(&sensorCounter, 1))
-		err := preValidateKprobes(name, spec.KProbes, spec.Lists)
+		kprobes, lists, err := expandKprobeCalls(spec.KProbes, spec.Lists)
 		if err != nil {
 			return nil, fmt.Errorf("validation failed: %w", err)
 		}
-		return createGenericKprobeSensor(name, spec.KProbes, policyID, policyName, spec.Lists, handler)
+		kprobes = withCompatKprobes(kprobes)
+		err = preValidateKprobes(name, kprobes, lists, spec.PartialLoad)
+		if err != nil {
+			return nil, fmt.Errorf("validation failed: %w", err)
+		}
+		return createGenericKprobeSensor(name, kprobes, policyID, policyName, lists, spec.Options, spec.PartialLoad, isAudit(spec), handler)
 	}
 	if len(spec.Tracepoints) > 0 {
 		name := fmt.Sprintf("gtp-sensor-%d", atomic.AddUint64(&sensorCounter, 1))
-		return createGenericTracepointSensor(name, spec.Tracepoints, policyID, policyName, spec.Lists, handler)
+		return createGenericTracepointSensor(name, spec.Tracepoints, policyID, policyName, spec.Lists, isAudit(spec), handler)
 	}
 	return nil, nil
 }
+
+// isAudit returns true if the policy is in audit mode, in which the hooks
+// report their enforcement actions in the events instead of taking them.
+func isAudit(spec *v1alpha1.TracingPolicySpec) bool {
+	return spec.Mode == v1alpha1.PolicyModeAudit
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package tracing
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/cilium/tetragon/pkg/jsonchecker"
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"github.com/cilium/tetragon/pkg/observer/observertesthelper"
+	"github.com/cilium/tetragon/pkg/testutils"
+	tus "github.com/cilium/tetragon/pkg/testutils/sensors"
+	"github.com/cilium/tetragon/pkg/tracingpolicy"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExamplePolicyTests runs the inline tests of the example policies, the
+// same way as "tetra tracingpolicy test" does against an agent.
+func TestExamplePolicyTests(t *testing.T) {
+	files, err := filepath.Glob(testutils.RepoRootPath("examples/tracingpolicy/*.yaml"))
+	require.NoError(t, err)
+
+	for _, file := range files {
+		tp, err := tracingpolicy.FromFile(file)
+		if err != nil || len(tp.TpSpec().Tests) == 0 {
+			continue
+		}
+		for i := range tp.TpSpec().Tests {
+			test := &tp.TpSpec().Tests[i]
+			t.Run(tp.TpName()+"/"+test.Name, func(t *testing.T) {
+				runExamplePolicyTest(t, file, test)
+			})
+		}
+	}
+}
+
+func runExamplePolicyTest(t *testing.T, file string, test *v1alpha1.PolicyTestSpec) {
+	var doneWG, readyWG sync.WaitGroup
+	defer doneWG.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), tus.Conf().CmdWaitTime)
+	defer cancel()
+
+	checker, err := tracingpolicy.PolicyTestChecker(test)
+	require.NoError(t, err)
+
+	// only the events of the trigger, a child of the test process, are
+	// checked
+	obs, err := observertesthelper.GetDefaultObserverWithFile(t, ctx, file, tus.Conf().TetragonLib, observertesthelper.WithMyPid())
+	require.NoError(t, err)
+	observertesthelper.LoopEvents(ctx, t, &doneWG, &readyWG, obs)
+	readyWG.Wait()
+
+	// the exit status of the trigger is ignored, the policy may for
+	// example kill it
+	exec.Command(test.Trigger[0], test.Trigger[1:]...).Run()
+
+	require.NoError(t, jsonchecker.JsonTestCheck(t, checker))
+}
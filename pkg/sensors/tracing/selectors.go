@@ -4,108 +4,126 @@
 package tracing
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
 
 	"github.com/cilium/ebpf"
 	"github.com/cilium/tetragon/pkg/bpf"
 	"github.com/cilium/tetragon/pkg/kernels"
 	"github.com/cilium/tetragon/pkg/selectors"
 	"github.com/cilium/tetragon/pkg/sensors"
+	"github.com/cilium/tetragon/pkg/sensors/base"
 	"github.com/cilium/tetragon/pkg/sensors/program"
 )
 
 func selectorsMaploads(ks *selectors.KernelSelectorState, pinPathPrefix string, index uint32) []*program.MapLoad {
+	return selectorsMapUpdates(ks, nil, pinPathPrefix, index)
+}
+
+// selectorsMapUpdates returns the loads of the selector maps of ks. If cur,
+// the selectors the maps currently hold, is not nil, only the inner maps and
+// the filter_map entry that differ from the ones of cur are written.
+func selectorsMapUpdates(ks, cur *selectors.KernelSelectorState, pinPathPrefix string, index uint32) []*program.MapLoad {
 	selBuff := ks.Buffer()
 	return []*program.MapLoad{
 		{
 			Index: index,
 			Name:  "filter_map",
 			Load: func(m *ebpf.Map, index uint32) error {
+				if cur != nil && cur.Buffer() == selBuff {
+					return nil
+				}
 				return m.Update(index, selBuff[:], ebpf.UpdateAny)
 			},
 		}, {
 			Index: 0,
 			Name:  "argfilter_maps",
 			Load: func(outerMap *ebpf.Map, index uint32) error {
-				return populateArgFilterMaps(ks, pinPathPrefix, outerMap)
+				return populateArgFilterMaps(ks, cur, pinPathPrefix, outerMap)
 			},
 		}, {
 			Index: 0,
 			Name:  "addr4lpm_maps",
 			Load: func(outerMap *ebpf.Map, index uint32) error {
-				return populateAddr4FilterMaps(ks, pinPathPrefix, outerMap)
+				return populateAddr4FilterMaps(ks, cur, pinPathPrefix, outerMap)
 			},
 		}, {
 			Index: 0,
 			Name:  "addr6lpm_maps",
 			Load: func(outerMap *ebpf.Map, index uint32) error {
-				return populateAddr6FilterMaps(ks, pinPathPrefix, outerMap)
+				return populateAddr6FilterMaps(ks, cur, pinPathPrefix, outerMap)
 			},
 		}, {
 			Index: 0,
 			Name:  "sel_names_map",
 			Load: func(outerMap *ebpf.Map, index uint32) error {
-				return populateBinariesMaps(ks, pinPathPrefix, outerMap)
+				return populateBinariesMaps(ks, cur, pinPathPrefix, outerMap)
 			},
 		}, {
 			Index: 0,
 			Name:  "string_maps_0",
 			Load: func(outerMap *ebpf.Map, index uint32) error {
-				return populateStringFilterMaps(ks, pinPathPrefix, outerMap, 0)
+				return populateStringFilterMaps(ks, cur, pinPathPrefix, outerMap, 0)
 			},
 		}, {
 			Index: 0,
 			Name:  "string_maps_1",
 			Load: func(outerMap *ebpf.Map, index uint32) error {
-				return populateStringFilterMaps(ks, pinPathPrefix, outerMap, 1)
+				return populateStringFilterMaps(ks, cur, pinPathPrefix, outerMap, 1)
 			},
 		}, {
 			Index: 0,
 			Name:  "string_maps_2",
 			Load: func(outerMap *ebpf.Map, index uint32) error {
-				return populateStringFilterMaps(ks, pinPathPrefix, outerMap, 2)
+				return populateStringFilterMaps(ks, cur, pinPathPrefix, outerMap, 2)
 			},
 		}, {
 			Index: 0,
 			Name:  "string_maps_3",
 			Load: func(outerMap *ebpf.Map, index uint32) error {
-				return populateStringFilterMaps(ks, pinPathPrefix, outerMap, 3)
+				return populateStringFilterMaps(ks, cur, pinPathPrefix, outerMap, 3)
 			},
 		}, {
 			Index: 0,
 			Name:  "string_maps_4",
 			Load: func(outerMap *ebpf.Map, index uint32) error {
-				return populateStringFilterMaps(ks, pinPathPrefix, outerMap, 4)
+				return populateStringFilterMaps(ks, cur, pinPathPrefix, outerMap, 4)
 			},
 		}, {
 			Index: 0,
 			Name:  "string_maps_5",
 			Load: func(outerMap *ebpf.Map, index uint32) error {
-				return populateStringFilterMaps(ks, pinPathPrefix, outerMap, 5)
+				return populateStringFilterMaps(ks, cur, pinPathPrefix, outerMap, 5)
 			},
 		}, {
 			Index: 0,
 			Name:  "string_prefix_maps",
 			Load: func(outerMap *ebpf.Map, index uint32) error {
-				return populateStringPrefixFilterMaps(ks, pinPathPrefix, outerMap)
+				return populateStringPrefixFilterMaps(ks, cur, pinPathPrefix, outerMap)
 			},
 		}, {
 			Index: 0,
 			Name:  "string_postfix_maps",
 			Load: func(outerMap *ebpf.Map, index uint32) error {
-				return populateStringPostfixFilterMaps(ks, pinPathPrefix, outerMap)
+				return populateStringPostfixFilterMaps(ks, cur, pinPathPrefix, outerMap)
 			},
 		},
 	}
 }
 
 func populateArgFilterMaps(
-	k *selectors.KernelSelectorState,
+	k, cur *selectors.KernelSelectorState,
 	pinPathPrefix string,
 	outerMap *ebpf.Map,
 ) error {
 	maxEntries := k.ValueMapsMaxEntries()
 	for i, vm := range k.ValueMaps() {
+		if cur != nil && i < len(cur.ValueMaps()) && maps.Equal(cur.ValueMaps()[i].Data, vm.Data) {
+			continue
+		}
 		nrEntries := uint32(len(vm.Data))
 		// Versions before 5.9 do not allow inner maps to have different sizes.
 		// See: https://lore.kernel.org/bpf/20200828011800.1970018-1-kafai@fb.com/
@@ -159,12 +177,15 @@ func populateArgFilterMap(
 }
 
 func populateAddr4FilterMaps(
-	k *selectors.KernelSelectorState,
+	k, cur *selectors.KernelSelectorState,
 	pinPathPrefix string,
 	outerMap *ebpf.Map,
 ) error {
 	maxEntries := k.Addr4MapsMaxEntries()
 	for i, am := range k.Addr4Maps() {
+		if cur != nil && i < len(cur.Addr4Maps()) && maps.Equal(cur.Addr4Maps()[i], am) {
+			continue
+		}
 		nrEntries := uint32(len(am))
 		// Versions before 5.9 do not allow inner maps to have different sizes.
 		// See: https://lore.kernel.org/bpf/20200828011800.1970018-1-kafai@fb.com/
@@ -219,12 +240,15 @@ func populateAddr4FilterMap(
 }
 
 func populateAddr6FilterMaps(
-	k *selectors.KernelSelectorState,
+	k, cur *selectors.KernelSelectorState,
 	pinPathPrefix string,
 	outerMap *ebpf.Map,
 ) error {
 	maxEntries := k.Addr6MapsMaxEntries()
 	for i, am := range k.Addr6Maps() {
+		if cur != nil && i < len(cur.Addr6Maps()) && maps.Equal(cur.Addr6Maps()[i], am) {
+			continue
+		}
 		nrEntries := uint32(len(am))
 		// Versions before 5.9 do not allow inner maps to have different sizes.
 		// See: https://lore.kernel.org/bpf/20200828011800.1970018-1-kafai@fb.com/
@@ -279,13 +303,16 @@ func populateAddr6FilterMap(
 }
 
 func populateStringFilterMaps(
-	k *selectors.KernelSelectorState,
+	k, cur *selectors.KernelSelectorState,
 	pinPathPrefix string,
 	outerMap *ebpf.Map,
 	subMap int,
 ) error {
 	maxEntries := k.StringMapsMaxEntries(subMap)
 	for i, am := range k.StringMaps(subMap) {
+		if cur != nil && i < len(cur.StringMaps(subMap)) && maps.Equal(cur.StringMaps(subMap)[i], am) {
+			continue
+		}
 		nrEntries := uint32(len(am))
 		// Versions before 5.9 do not allow inner maps to have different sizes.
 		// See: https://lore.kernel.org/bpf/20200828011800.1970018-1-kafai@fb.com/
@@ -341,11 +368,17 @@ func populateStringFilterMap(
 }
 
 func populateBinariesMaps(
-	ks *selectors.KernelSelectorState,
+	ks, cur *selectors.KernelSelectorState,
 	pinPathPrefix string,
 	outerMap *ebpf.Map,
 ) error {
 	for innerID, sel := range ks.GetBinSelNamesMap() {
+		if cur != nil {
+			if csel, ok := cur.GetBinSelNamesMap()[innerID]; ok && cur.GetBinaryOp(innerID) == ks.GetBinaryOp(innerID) &&
+				maps.Equal(csel.GetBinSelNamesMap(), sel.GetBinSelNamesMap()) {
+				continue
+			}
+		}
 		innerName := fmt.Sprintf("sel_names_map_%d", innerID)
 		innerSpec := &ebpf.MapSpec{
 			Name:       innerName,
@@ -381,12 +414,15 @@ func populateBinariesMaps(
 }
 
 func populateStringPrefixFilterMaps(
-	k *selectors.KernelSelectorState,
+	k, cur *selectors.KernelSelectorState,
 	pinPathPrefix string,
 	outerMap *ebpf.Map,
 ) error {
 	maxEntries := k.StringPrefixMapsMaxEntries()
 	for i, am := range k.StringPrefixMaps() {
+		if cur != nil && i < len(cur.StringPrefixMaps()) && maps.Equal(cur.StringPrefixMaps()[i], am) {
+			continue
+		}
 		nrEntries := uint32(len(am))
 		// Versions before 5.9 do not allow inner maps to have different sizes.
 		// See: https://lore.kernel.org/bpf/20200828011800.1970018-1-kafai@fb.com/
@@ -441,12 +477,15 @@ func populateStringPrefixFilterMap(
 }
 
 func populateStringPostfixFilterMaps(
-	k *selectors.KernelSelectorState,
+	k, cur *selectors.KernelSelectorState,
 	pinPathPrefix string,
 	outerMap *ebpf.Map,
 ) error {
 	maxEntries := k.StringPostfixMapsMaxEntries()
 	for i, am := range k.StringPostfixMaps() {
+		if cur != nil && i < len(cur.StringPostfixMaps()) && maps.Equal(cur.StringPostfixMaps()[i], am) {
+			continue
+		}
 		nrEntries := uint32(len(am))
 		// Versions before 5.9 do not allow inner maps to have different sizes.
 		// See: https://lore.kernel.org/bpf/20200828011800.1970018-1-kafai@fb.com/
@@ -499,3 +538,56 @@ func populateStringPostfixFilterMap(
 
 	return nil
 }
+
+// updateSelectorMaps updates the selector maps of a loaded program from the
+// selectors of cur to the ones of ks, e.g., when the values of the selectors of
+// its policy are updated. Only the values maps that changed are replaced, so
+// that adding a value to a filter only rewrites the map of the filter, and
+// they are replaced before the filter_map entry of the program, so that the
+// new selectors only refer to populated maps. If cur is nil, all the maps are
+// written. The maps of programs that were not loaded, e.g., skipped by a
+// partial load, are not updated.
+func updateSelectorMaps(ks, cur *selectors.KernelSelectorState, pinPathPrefix string, index uint32) error {
+	mapDir := bpf.MapPrefixPath()
+	update := func(ml *program.MapLoad) error {
+		pin := filepath.Join(mapDir, sensors.PathJoin(pinPathPrefix, ml.Name))
+		m, err := ebpf.LoadPinnedMap(pin, nil)
+		if err != nil {
+			return fmt.Errorf("failed to open map %s: %w", pin, err)
+		}
+		defer m.Close()
+		if err := ml.Load(m, ml.Index); err != nil {
+			return fmt.Errorf("failed to update map %s: %w", pin, err)
+		}
+		return nil
+	}
+
+	if _, err := os.Stat(filepath.Join(mapDir, sensors.PathJoin(pinPathPrefix, "filter_map"))); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	var filterMap *program.MapLoad
+	for _, ml := range selectorsMapUpdates(ks, cur, pinPathPrefix, index) {
+		if ml.Name == "filter_map" {
+			filterMap = ml
+			continue
+		}
+		if err := update(ml); err != nil {
+			return err
+		}
+	}
+
+	names, err := ebpf.LoadPinnedMap(filepath.Join(mapDir, base.NamesMap.Name), nil)
+	if err != nil {
+		return err
+	}
+	defer names.Close()
+	for i, path := range ks.GetNewBinaryMappings() {
+		writeBinaryMap(names, i, path)
+	}
+	if err := writeBinaryPrefixMaps(mapDir, ks); err != nil {
+		return err
+	}
+
+	return update(filterMap)
+}
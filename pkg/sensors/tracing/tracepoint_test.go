@@ -19,6 +19,7 @@ import (
 	"github.com/cilium/tetragon/api/v1/tetragon"
 	"github.com/cilium/tetragon/api/v1/tetragon/codegen/eventchecker"
 	ec "github.com/cilium/tetragon/api/v1/tetragon/codegen/eventchecker"
+	gt "github.com/cilium/tetragon/pkg/generictypes"
 	"github.com/cilium/tetragon/pkg/grpc/tracing"
 	"github.com/cilium/tetragon/pkg/jsonchecker"
 	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
@@ -34,6 +35,8 @@ import (
 	"github.com/cilium/tetragon/pkg/testutils"
 	"github.com/cilium/tetragon/pkg/testutils/perfring"
 	tus "github.com/cilium/tetragon/pkg/testutils/sensors"
+	"github.com/cilium/tetragon/pkg/tracepoint"
+	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -78,7 +81,7 @@ func TestGenericTracepointSimple(t *testing.T) {
 	sm := tus.GetTestSensorManager(ctx, t)
 	// create and add sensor
 	sensor, err := createGenericTracepointSensor("GtpLseekTest", []GenericTracepointConf{lseekConf}, policyfilter.NoFilterID,
-		"policyName", []v1alpha1.ListSpec{}, nil)
+		"policyName", []v1alpha1.ListSpec{}, false, nil)
 	if err != nil {
 		t.Fatalf("failed to create generic tracepoint sensor: %s", err)
 	}
@@ -138,7 +141,7 @@ func doTestGenericTracepointPidFilter(t *testing.T, conf GenericTracepointConf,
 	sm := tus.GetTestSensorManager(ctx, t)
 	// create and add sensor
 	sensor, err := createGenericTracepointSensor("GtpLseekTest", []GenericTracepointConf{conf}, policyfilter.NoFilterID,
-		"policyName", []v1alpha1.ListSpec{}, nil)
+		"policyName", []v1alpha1.ListSpec{}, false, nil)
 	if err != nil {
 		t.Fatalf("failed to create generic tracepoint sensor: %s", err)
 	}
@@ -273,6 +276,96 @@ func TestGenericTracepointArgFilterLseek(t *testing.T) {
 	doTestGenericTracepointPidFilter(t, tracepointConf, op, check)
 }
 
+func TestGenericTracepointReturnLseek(t *testing.T) {
+	tracepointConf := GenericTracepointConf{
+		Subsystem: "syscalls",
+		Event:     "sys_enter_lseek",
+		Return:    true,
+		Args: []v1alpha1.KProbeArg{
+			{
+				Index: 7, /* whence */
+			},
+		},
+		Selectors: []v1alpha1.KProbeSelector{
+			{
+				MatchArgs: []v1alpha1.ArgSelector{
+					{
+						Index:    7,
+						Operator: "Equal",
+						Values:   []string{fmt.Sprintf("%d", whenceBogusValue)},
+					},
+				},
+			},
+		},
+	}
+
+	op := func() {
+		t.Logf("Calling lseek...\n")
+		unix.Seek(-1, 0, whenceBogusValue)
+	}
+
+	check := func(event *tetragon.ProcessTracepoint) error {
+		ret, ok := event.Return.GetArg().(*tetragon.KprobeArgument_LongArg)
+		if !ok {
+			return fmt.Errorf("unexpected return value: %s", event.Return)
+		}
+		if ret.LongArg != -int64(unix.EBADF) {
+			return fmt.Errorf("unexpected return value. got:%d expecting:%d", ret.LongArg, -int64(unix.EBADF))
+		}
+		return nil
+	}
+
+	doTestGenericTracepointPidFilter(t, tracepointConf, op, check)
+}
+
+func TestTracepointExitEvent(t *testing.T) {
+	exit, err := tracepointExitEvent("syscalls", "sys_enter_lseek")
+	require.NoError(t, err)
+	assert.Equal(t, "sys_exit_lseek", exit)
+	exit, err = tracepointExitEvent("raw_syscalls", "sys_enter")
+	require.NoError(t, err)
+	assert.Equal(t, "sys_exit", exit)
+	_, err = tracepointExitEvent("syscalls", "sys_exit_lseek")
+	assert.Error(t, err)
+	_, err = tracepointExitEvent("sched", "sched_process_exec")
+	assert.Error(t, err)
+
+	ty, err := tracepointReturnType(nil)
+	require.NoError(t, err)
+	assert.Equal(t, gt.GenericS64Type, ty)
+	ty, err = tracepointReturnType(&v1alpha1.KProbeArg{Type: "int"})
+	require.NoError(t, err)
+	assert.Equal(t, gt.GenericIntType, ty)
+	_, err = tracepointReturnType(&v1alpha1.KProbeArg{Type: "string"})
+	assert.Error(t, err)
+}
+
+func TestTracepointMergeReturn(t *testing.T) {
+	pendingEvents, err := lru.New[pendingEventKey, pendingTracepointEvent](16)
+	require.NoError(t, err)
+	tp := &genericTracepoint{
+		Info:          &tracepoint.Tracepoint{Subsys: "syscalls", Event: "sys_enter_lseek"},
+		pendingEvents: pendingEvents,
+	}
+
+	// the enter event is received first
+	key := pendingEventKey{eventId: 1, ktimeEnter: 100}
+	enter := &tracing.MsgGenericTracepointUnix{Event: "sys_enter_lseek"}
+	assert.Nil(t, tp.mergeReturn(key, pendingTracepointEvent{ev: enter}))
+	ev := tp.mergeReturn(key, pendingTracepointEvent{ret: int64(-9), returnEvent: true})
+	require.NotNil(t, ev)
+	assert.Equal(t, int64(-9), ev.Return)
+
+	// the return event is received first
+	key = pendingEventKey{eventId: 1, ktimeEnter: 200}
+	enter = &tracing.MsgGenericTracepointUnix{Event: "sys_enter_lseek"}
+	assert.Nil(t, tp.mergeReturn(key, pendingTracepointEvent{ret: int64(0), returnEvent: true}))
+	ev = tp.mergeReturn(key, pendingTracepointEvent{ev: enter})
+	require.NotNil(t, ev)
+	assert.Equal(t, int64(0), ev.Return)
+	assert.Equal(t, 0, pendingEvents.Len())
+}
+
 func TestGenericTracepointMeta(t *testing.T) {
 	// We want to write to a file so we can filter by non-stdout fd and thus avoid
 	// catching all the writes to test logs
@@ -416,6 +509,47 @@ func TestGenericTracepointRawSyscall(t *testing.T) {
 	doTestGenericTracepointPidFilter(t, tracepointConf, op, check)
 }
 
+func TestGenericTracepointRawMode(t *testing.T) {
+	tracepointConf := GenericTracepointConf{
+		Subsystem: "raw_syscalls",
+		Event:     "sys_enter",
+		Raw:       true,
+		Args: []v1alpha1.KProbeArg{
+			v1alpha1.KProbeArg{
+				Index: 1, /* id */
+			},
+		},
+		Selectors: []v1alpha1.KProbeSelector{
+			{
+				MatchArgs: []v1alpha1.ArgSelector{
+					{
+						Index:    1,
+						Operator: "Equal",
+						Values:   []string{fmt.Sprintf("%d", unix.SYS_LSEEK)},
+					},
+				},
+			},
+		},
+	}
+	op := func() {
+		t.Logf("Calling lseek...\n")
+		unix.Seek(-1, 0, whenceBogusValue)
+	}
+
+	check := func(event *tetragon.ProcessTracepoint) error {
+		arg0, ok := event.Args[0].GetArg().(*tetragon.KprobeArgument_LongArg)
+		if !ok {
+			return fmt.Errorf("unexpected type of system call id: %T", event.Args[0].GetArg())
+		}
+		if sysID := arg0.LongArg; sysID != unix.SYS_LSEEK {
+			return jsonchecker.NewDebugError(fmt.Errorf("unexpected arg val: got:%d expecting:%d", sysID, unix.SYS_LSEEK))
+		}
+		return nil
+	}
+
+	doTestGenericTracepointPidFilter(t, tracepointConf, op, check)
+}
+
 func TestLoadTracepointSensor(t *testing.T) {
 	var sensorProgs = []tus.SensorProg{
 		0:  tus.SensorProg{Name: "generic_tracepoint_event", Type: ebpf.TracePoint},
@@ -449,6 +583,7 @@ func TestLoadTracepointSensor(t *testing.T) {
 
 		// generic_tracepoint_output
 		tus.SensorMap{Name: "tcpmon_map", Progs: []uint{13}},
+		tus.SensorMap{Name: "event_cgroup_stats_map", Progs: []uint{13}},
 
 		// all kprobe but generic_tracepoint_filter
 		tus.SensorMap{Name: "config_map", Progs: []uint{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
@@ -534,7 +669,7 @@ func TestTracepointCloneThreads(t *testing.T) {
 	sm := tus.GetTestSensorManager(ctx, t)
 	// create and add sensor
 	sensor, err := createGenericTracepointSensor("GtpLseekTest", []GenericTracepointConf{lseekConf}, policyfilter.NoFilterID,
-		"policyName", []v1alpha1.ListSpec{}, nil)
+		"policyName", []v1alpha1.ListSpec{}, false, nil)
 	if err != nil {
 		t.Fatalf("failed to create generic tracepoint sensor: %s", err)
 	}
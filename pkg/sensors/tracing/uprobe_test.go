@@ -14,8 +14,10 @@ import (
 	"github.com/cilium/ebpf"
 	ec "github.com/cilium/tetragon/api/v1/tetragon/codegen/eventchecker"
 	"github.com/cilium/tetragon/pkg/jsonchecker"
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
 	"github.com/cilium/tetragon/pkg/kernels"
 	"github.com/cilium/tetragon/pkg/logger"
+	lc "github.com/cilium/tetragon/pkg/matchers/listmatcher"
 	sm "github.com/cilium/tetragon/pkg/matchers/stringmatcher"
 	"github.com/cilium/tetragon/pkg/observer/observertesthelper"
 	"github.com/cilium/tetragon/pkg/sensors"
@@ -56,6 +58,7 @@ func TestUprobeLoad(t *testing.T) {
 
 		// generic_uprobe_output
 		tus.SensorMap{Name: "tcpmon_map", Progs: []uint{13}},
+		tus.SensorMap{Name: "event_cgroup_stats_map", Progs: []uint{13}},
 	}
 
 	if kernels.EnableLargeProgs() {
@@ -141,6 +144,121 @@ spec:
 	assert.NoError(t, err)
 }
 
+func TestUprobeArgs(t *testing.T) {
+	path, err := os.Executable()
+	assert.NoError(t, err)
+
+	argsHook := `
+apiVersion: cilium.io/v1alpha1
+kind: TracingPolicy
+metadata:
+  name: "uprobe"
+spec:
+  uprobes:
+  - path: "` + path + `"
+    symbol: "uprobe_test_func_args"
+    args:
+    - index: 0
+      type: "int"
+    - index: 1
+      type: "string"
+    selectors:
+    - matchArgs:
+      - index: 0
+        operator: "Equal"
+        values:
+        - "42"
+`
+
+	err = os.WriteFile(testConfigFile, []byte(argsHook), 0644)
+	if err != nil {
+		t.Fatalf("writeFile(%s): err %s", testConfigFile, err)
+	}
+
+	upChecker := ec.NewProcessUprobeChecker("UPROBE_ARGS").
+		WithProcess(ec.NewProcessChecker().
+			WithBinary(sm.Full(path))).
+		WithSymbol(sm.Full("uprobe_test_func_args")).
+		WithArgs(ec.NewKprobeArgumentListMatcher().
+			WithOperator(lc.Ordered).
+			WithValues(
+				ec.NewKprobeArgumentChecker().WithIntArg(42),
+				ec.NewKprobeArgumentChecker().WithStringArg(sm.Full("hello uprobe")),
+			))
+	checker := ec.NewUnorderedEventChecker(upChecker)
+
+	var doneWG, readyWG sync.WaitGroup
+	defer doneWG.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), tus.Conf().CmdWaitTime)
+	defer cancel()
+
+	obs, err := observertesthelper.GetDefaultObserverWithFile(t, ctx, testConfigFile, tus.Conf().TetragonLib, observertesthelper.WithMyPid())
+	if err != nil {
+		t.Fatalf("GetDefaultObserverWithFile error: %s", err)
+	}
+	observertesthelper.LoopEvents(ctx, t, &doneWG, &readyWG, obs)
+	readyWG.Wait()
+
+	UprobeTestFuncArgs(1, "filtered out")
+	UprobeTestFuncArgs(42, "hello uprobe")
+
+	err = jsonchecker.JsonTestCheck(t, checker)
+	assert.NoError(t, err)
+}
+
+func TestUprobeSpecErrors(t *testing.T) {
+	offset := uint64(0x10)
+	for _, tc := range []struct {
+		name string
+		spec v1alpha1.UProbeSpec
+	}{
+		{
+			name: "no symbol and no offset",
+			spec: v1alpha1.UProbeSpec{Path: "/bin/true"},
+		},
+		{
+			name: "kernel arg type",
+			spec: v1alpha1.UProbeSpec{
+				Path:   "/bin/true",
+				Symbol: "main",
+				Args:   []v1alpha1.KProbeArg{{Index: 0, Type: "file"}},
+			},
+		},
+		{
+			name: "arg index out of bounds",
+			spec: v1alpha1.UProbeSpec{
+				Path:   "/bin/true",
+				Offset: &offset,
+				Args:   []v1alpha1.KProbeArg{{Index: 5, Type: "int"}},
+			},
+		},
+		{
+			name: "usdt with symbol",
+			spec: v1alpha1.UProbeSpec{
+				Path:   "/bin/true",
+				Symbol: "main",
+				Usdt:   &v1alpha1.UsdtSpec{Provider: "provider", Name: "probe"},
+			},
+		},
+		{
+			name: "unsupported selector",
+			spec: v1alpha1.UProbeSpec{
+				Path:   "/bin/true",
+				Symbol: "main",
+				Selectors: []v1alpha1.KProbeSelector{{
+					MatchActions: []v1alpha1.ActionSelector{{Action: "Sigkill"}},
+				}},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := createGenericUprobeSensor("test", []v1alpha1.UProbeSpec{tc.spec}, "policy", false)
+			assert.Error(t, err)
+		})
+	}
+}
+
 func uprobePidMatch(t *testing.T, pid uint32) error {
 	path, err := os.Executable()
 	assert.NoError(t, err)
@@ -147,6 +147,23 @@ func detachTC(linkName string, ingress bool) error {
 
 }
 
+// PerfEventUnloader disables and closes the perf events a program is
+// attached to.
+type PerfEventUnloader struct {
+	FDs []int
+}
+
+func (pu PerfEventUnloader) Unload() error {
+	var ret error
+	for _, fd := range pu.FDs {
+		unix.IoctlSetInt(fd, unix.PERF_EVENT_IOC_DISABLE, 0)
+		if err := unix.Close(fd); err != nil {
+			ret = multierr.Append(ret, fmt.Errorf("failed to close perf event: %w", err))
+		}
+	}
+	return ret
+}
+
 // RelinkUnloader is an unloader that allows unlinking/relinking as well.
 type RelinkUnloader struct {
 	// UnloadProg unloads the program
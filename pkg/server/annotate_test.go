@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/api/processapi"
+	"github.com/cilium/tetragon/pkg/cilium"
+	"github.com/cilium/tetragon/pkg/option"
+	"github.com/cilium/tetragon/pkg/process"
+	"github.com/cilium/tetragon/pkg/watcher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type recordingNotifier struct {
+	events []*tetragon.GetEventsResponse
+}
+
+func (n *recordingNotifier) AddListener(Listener)    {}
+func (n *recordingNotifier) RemoveListener(Listener) {}
+func (n *recordingNotifier) NotifyListener(_ interface{}, processed *tetragon.GetEventsResponse) {
+	n.events = append(n.events, processed)
+}
+
+func TestAnnotateEvent(t *testing.T) {
+	notifier := &recordingNotifier{}
+	s := NewServer(context.Background(), nil, notifier, &FakeObserver{}, nil)
+	_, err := cilium.InitCiliumState(context.Background(), false)
+	require.NoError(t, err)
+	require.NoError(t, process.InitCache(watcher.NewFakeK8sWatcher(nil), 10))
+	defer process.FreeCache()
+	addExec(42, 1000, "/usr/bin/curl", processapi.MsgExecveKey{Pid: 0})
+
+	execID := process.GetProcessID(42, 1000)
+	eventTime := timestamppb.New(time.Now().Add(-time.Minute))
+	req := &tetragon.AnnotateEventRequest{
+		Annotation: &tetragon.EventAnnotation{
+			ExecId:  execID,
+			Time:    eventTime,
+			Verdict: tetragon.EventVerdict_EVENT_VERDICT_MALICIOUS,
+			Author:  "soar",
+		},
+	}
+
+	// disabled by default
+	_, err = s.AnnotateEvent(context.Background(), req)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("s3cr3t\n"), 0600))
+	option.Config.EventAnnotationTokenFile = tokenFile
+	defer func() { option.Config.EventAnnotationTokenFile = "" }()
+
+	// missing and invalid tokens
+	_, err = s.AnnotateEvent(context.Background(), req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer wrong"))
+	_, err = s.AnnotateEvent(ctx, req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Empty(t, notifier.events)
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer s3cr3t"))
+	for _, annotation := range []*tetragon.EventAnnotation{
+		// no event reference
+		{},
+		// invalid exec_id
+		{ExecId: "event-1", Time: eventTime},
+		// exec_id of another node
+		{ExecId: base64.StdEncoding.EncodeToString([]byte("other-node:1000:42")), Time: eventTime},
+		// no time
+		{ExecId: execID},
+		// time in the future
+		{ExecId: execID, Time: timestamppb.New(time.Now().Add(time.Hour))},
+		// time before the start of the process
+		{ExecId: execID, Time: timestamppb.New(time.Unix(0, 0))},
+	} {
+		_, err = s.AnnotateEvent(ctx, &tetragon.AnnotateEventRequest{Annotation: annotation})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), annotation)
+	}
+
+	_, err = s.AnnotateEvent(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, notifier.events, 1)
+	assert.Equal(t, execID, notifier.events[0].GetEventAnnotation().GetExecId())
+	assert.Equal(t, eventTime.AsTime(), notifier.events[0].GetEventAnnotation().GetTime().AsTime())
+	assert.Equal(t, tetragon.EventVerdict_EVENT_VERDICT_MALICIOUS, notifier.events[0].GetEventAnnotation().GetVerdict())
+}
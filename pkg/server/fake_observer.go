@@ -46,6 +46,14 @@ func (f *FakeObserver) DisableTracingPolicy(ctx context.Context, sensorName stri
 	return nil
 }
 
+func (f *FakeObserver) UpdateTracingPolicySelectors(ctx context.Context, tp tracingpolicy.TracingPolicy) error {
+	return nil
+}
+
+func (f *FakeObserver) AddTracingPolicySelectorValues(ctx context.Context, name string, values *sensors.SelectorValues) error {
+	return nil
+}
+
 func (f *FakeObserver) RemoveSensor(ctx context.Context, sensorName string) error {
 	return nil
 }
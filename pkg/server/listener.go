@@ -0,0 +1,245 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/unixlisten"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	listenerTLSCert     = "tls-cert"
+	listenerTLSKey      = "tls-key"
+	listenerTLSClientCA = "tls-client-ca"
+)
+
+// ListenerConfig is the configuration of a gRPC server listener.
+type ListenerConfig struct {
+	// Proto and Addr are the arguments of net.Listen. Unix sockets in the
+	// abstract namespace have an address starting with '@'.
+	Proto string
+	Addr  string
+	// TLSCert and TLSKey are the files of the certificate and of the key of
+	// the server, to serve TLS.
+	TLSCert string
+	TLSKey  string
+	// TLSClientCA is the file of the certificate authorities verifying the
+	// certificates of the clients, which are then required (mutual TLS).
+	TLSClientCA string
+}
+
+// ParseListener parses the configuration of a listener, an address as
+// accepted by SplitListenAddr or unix://@name for a unix socket in the
+// abstract namespace, optionally followed by settings in the URL query format:
+//
+//	0.0.0.0:54322?tls-cert=/etc/tls/tls.crt&tls-key=/etc/tls/tls.key&tls-client-ca=/etc/tls/ca.crt
+//
+// Unix sockets in the abstract namespace have no permissions, any process of
+// the network namespace can connect to them, so they require mutual TLS.
+func ParseListener(arg string) (*ListenerConfig, error) {
+	addr, query, _ := strings.Cut(arg, "?")
+	var proto string
+	if name, ok := strings.CutPrefix(addr, "unix://@"); ok && name != "" {
+		proto, addr = "unix", "@"+name
+	} else {
+		var err error
+		if proto, addr, err = SplitListenAddr(addr); err != nil {
+			return nil, err
+		}
+	}
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid settings %q: %w", query, err)
+	}
+
+	c := &ListenerConfig{Proto: proto, Addr: addr}
+	for key, v := range values {
+		if len(v) != 1 {
+			return nil, fmt.Errorf("setting %s specified %d times", key, len(v))
+		}
+		switch key {
+		case listenerTLSCert:
+			c.TLSCert = v[0]
+		case listenerTLSKey:
+			c.TLSKey = v[0]
+		case listenerTLSClientCA:
+			c.TLSClientCA = v[0]
+		default:
+			return nil, fmt.Errorf("unknown setting %s", key)
+		}
+	}
+	if (c.TLSCert == "") != (c.TLSKey == "") {
+		return nil, fmt.Errorf("settings %s and %s must be specified together", listenerTLSCert, listenerTLSKey)
+	}
+	if c.TLSClientCA != "" && c.TLSCert == "" {
+		return nil, fmt.Errorf("setting %s requires %s and %s", listenerTLSClientCA, listenerTLSCert, listenerTLSKey)
+	}
+	if c.Proto == "unix" && !c.isUnixPath() {
+		if err := c.requireMutualTLS(); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// ParseAdditionalListener parses the configuration of an additional listener
+// like ParseListener. Unlike the server address, whose TCP default is kept
+// for compatibility, additional TCP listeners also require mutual TLS.
+func ParseAdditionalListener(arg string) (*ListenerConfig, error) {
+	c, err := ParseListener(arg)
+	if err != nil {
+		return nil, err
+	}
+	if !c.isUnixPath() {
+		if err := c.requireMutualTLS(); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (c *ListenerConfig) requireMutualTLS() error {
+	if c.TLSClientCA == "" {
+		return fmt.Errorf("listener %s can be reached by any process of its network namespace and requires the settings %s, %s and %s",
+			c, listenerTLSCert, listenerTLSKey, listenerTLSClientCA)
+	}
+	return nil
+}
+
+// String returns the address of the listener, for logging.
+func (c *ListenerConfig) String() string {
+	return c.Proto + "://" + c.Addr
+}
+
+// isUnixPath returns true if the listener is a unix socket with a path in
+// the file system.
+func (c *ListenerConfig) isUnixPath() bool {
+	return c.Proto == "unix" && !strings.HasPrefix(c.Addr, "@")
+}
+
+// ServerOptions returns the options of the gRPC server of the listener. The
+// TLS files are loaded again when they change, so that rotated certificates
+// are used by the next connections.
+func (c *ListenerConfig) ServerOptions() ([]grpc.ServerOption, error) {
+	if c.TLSCert == "" {
+		return nil, nil
+	}
+	r := &tlsReloader{listener: c}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		GetConfigForClient: r.getConfigForClient,
+	}
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(tlsConfig))}, nil
+}
+
+// tlsConfig loads the TLS files of the listener.
+func (c *ListenerConfig) tlsConfig() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(c.TLSCert, c.TLSKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+		NextProtos:   []string{"h2"},
+	}
+	if c.TLSClientCA != "" {
+		pem, err := os.ReadFile(c.TLSClientCA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read TLS client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("failed to parse TLS client CA: no certificate found")
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
+
+// tlsReloader serves the TLS configuration of a listener, loading its files
+// again when their modification times change, e.g. when the certificates of a
+// Kubernetes secret are rotated.
+type tlsReloader struct {
+	listener *ListenerConfig
+
+	mu       sync.Mutex
+	modTimes [3]time.Time
+	config   *tls.Config
+}
+
+func (r *tlsReloader) files() [3]string {
+	return [3]string{r.listener.TLSCert, r.listener.TLSKey, r.listener.TLSClientCA}
+}
+
+// reload loads the TLS files if they changed since they were last loaded.
+// Callers must hold r.mu, except for the initial load.
+func (r *tlsReloader) reload() error {
+	var modTimes [3]time.Time
+	for i, file := range r.files() {
+		if file == "" {
+			continue
+		}
+		if fi, err := os.Stat(file); err == nil {
+			modTimes[i] = fi.ModTime()
+		}
+	}
+	if r.config != nil && modTimes == r.modTimes {
+		return nil
+	}
+	config, err := r.listener.tlsConfig()
+	// on errors, wait for the files to change again before retrying
+	r.modTimes = modTimes
+	if err != nil {
+		return err
+	}
+	r.config = config
+	return nil
+}
+
+func (r *tlsReloader) getConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.reload(); err != nil {
+		// the files may be in the middle of being updated, keep the
+		// previous configuration until they are complete
+		logger.GetLogger().WithError(err).WithField("listener", r.listener).Warn("Failed to reload TLS files, using the previous ones")
+	}
+	return r.config, nil
+}
+
+// Listen creates the listener. Unix sockets in the file system are only
+// accessible by their owner and group.
+func (c *ListenerConfig) Listen() (net.Listener, error) {
+	if c.isUnixPath() {
+		return unixlisten.ListenWithRename(c.Addr, 0660)
+	}
+	return net.Listen(c.Proto, c.Addr)
+}
+
+// Cleanup removes the unix socket file of the listener, if any.
+func (c *ListenerConfig) Cleanup() {
+	// ListenWithRename() creates the socket then renames it, so it is not
+	// removed when the listener is closed
+	if c.isUnixPath() {
+		os.Remove(c.Addr)
+	}
+}
@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseListener(t *testing.T) {
+	c, err := ParseListener("unix:///var/run/tetragon/tetragon.sock")
+	require.NoError(t, err)
+	assert.Equal(t, &ListenerConfig{Proto: "unix", Addr: "/var/run/tetragon/tetragon.sock"}, c)
+	assert.True(t, c.isUnixPath())
+
+	c, err = ParseListener("unix://@tetragon?tls-cert=/tls/tls.crt&tls-key=/tls/tls.key&tls-client-ca=/tls/ca.crt")
+	require.NoError(t, err)
+	assert.Equal(t, &ListenerConfig{
+		Proto:       "unix",
+		Addr:        "@tetragon",
+		TLSCert:     "/tls/tls.crt",
+		TLSKey:      "/tls/tls.key",
+		TLSClientCA: "/tls/ca.crt",
+	}, c)
+	assert.False(t, c.isUnixPath())
+
+	// the server address keeps its TCP default
+	c, err = ParseListener("localhost:54321")
+	require.NoError(t, err)
+	assert.Equal(t, &ListenerConfig{Proto: "tcp", Addr: "localhost:54321"}, c)
+
+	c, err = ParseListener("0.0.0.0:54322?tls-cert=/tls/tls.crt&tls-key=/tls/tls.key&tls-client-ca=/tls/ca.crt")
+	require.NoError(t, err)
+	assert.Equal(t, &ListenerConfig{
+		Proto:       "tcp",
+		Addr:        "0.0.0.0:54322",
+		TLSCert:     "/tls/tls.crt",
+		TLSKey:      "/tls/tls.key",
+		TLSClientCA: "/tls/ca.crt",
+	}, c)
+
+	for _, arg := range []string{
+		"unix://relative.sock",
+		"unix://@",
+		"unix://@tetragon",
+		"unix://@tetragon?tls-cert=/tls/tls.crt&tls-key=/tls/tls.key",
+		"localhost:54322?tls-cert=/tls/tls.crt",
+		"localhost:54322?tls-client-ca=/tls/ca.crt",
+		"localhost:54322?tls-cert=/a&tls-cert=/b&tls-key=/c",
+		"localhost:54322?unknown=1",
+	} {
+		_, err := ParseListener(arg)
+		assert.Error(t, err, arg)
+	}
+}
+
+func TestParseAdditionalListener(t *testing.T) {
+	c, err := ParseAdditionalListener("unix:///var/run/tetragon/tetragon.sock")
+	require.NoError(t, err)
+	assert.Equal(t, &ListenerConfig{Proto: "unix", Addr: "/var/run/tetragon/tetragon.sock"}, c)
+
+	_, err = ParseAdditionalListener("0.0.0.0:54322?tls-cert=/tls/tls.crt&tls-key=/tls/tls.key&tls-client-ca=/tls/ca.crt")
+	assert.NoError(t, err)
+
+	for _, arg := range []string{
+		"localhost:54322",
+		"0.0.0.0:54322?tls-cert=/tls/tls.crt&tls-key=/tls/tls.key",
+		"unix://@tetragon",
+	} {
+		_, err := ParseAdditionalListener(arg)
+		assert.Error(t, err, arg)
+	}
+}
+
+func writeTestCert(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "tetragon"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	return certFile, keyFile
+}
+
+func TestListenerServerOptions(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCert(t, dir)
+
+	c := &ListenerConfig{Proto: "tcp", Addr: "localhost:0"}
+	opts, err := c.ServerOptions()
+	require.NoError(t, err)
+	assert.Empty(t, opts)
+
+	c.TLSCert, c.TLSKey, c.TLSClientCA = certFile, keyFile, certFile
+	opts, err = c.ServerOptions()
+	require.NoError(t, err)
+	assert.Len(t, opts, 1)
+
+	// the key is not a CA
+	c.TLSClientCA = keyFile
+	_, err = c.ServerOptions()
+	assert.Error(t, err)
+
+	c.TLSCert, c.TLSClientCA = filepath.Join(dir, "missing.crt"), ""
+	_, err = c.ServerOptions()
+	assert.Error(t, err)
+}
+
+func TestListenerTLSReload(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCert(t, dir)
+	r := &tlsReloader{listener: &ListenerConfig{Proto: "tcp", Addr: "localhost:0", TLSCert: certFile, TLSKey: keyFile}}
+	require.NoError(t, r.reload())
+
+	config, err := r.getConfigForClient(nil)
+	require.NoError(t, err)
+	first := config.Certificates[0].Certificate[0]
+
+	// unchanged files are not loaded again
+	config, err = r.getConfigForClient(nil)
+	require.NoError(t, err)
+	assert.Equal(t, first, config.Certificates[0].Certificate[0])
+
+	// rotated certificates are used by the next connections
+	writeTestCert(t, dir)
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, future, future))
+	config, err = r.getConfigForClient(nil)
+	require.NoError(t, err)
+	rotated := config.Certificates[0].Certificate[0]
+	assert.NotEqual(t, first, rotated)
+
+	// invalid files keep the previous certificates
+	require.NoError(t, os.WriteFile(keyFile, []byte("invalid"), 0600))
+	require.NoError(t, os.Chtimes(keyFile, future.Add(time.Minute), future.Add(time.Minute)))
+	config, err = r.getConfigForClient(nil)
+	require.NoError(t, err)
+	assert.Equal(t, rotated, config.Certificates[0].Certificate[0])
+}
+
+func TestListenerUnix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tetragon.sock")
+	for _, c := range []*ListenerConfig{
+		{Proto: "unix", Addr: path},
+		{Proto: "unix", Addr: fmt.Sprintf("@tetragon-test-%d", os.Getpid())},
+	} {
+		l, err := c.Listen()
+		require.NoError(t, err, c)
+		l.Close()
+		c.Cleanup()
+	}
+	_, err := os.Stat(path)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
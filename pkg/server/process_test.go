@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/api/processapi"
+	"github.com/cilium/tetragon/pkg/cilium"
+	"github.com/cilium/tetragon/pkg/process"
+	"github.com/cilium/tetragon/pkg/watcher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func addExec(pid uint32, ktime uint64, binary string, parent processapi.MsgExecveKey) {
+	process.AddExecEvent(&processapi.MsgExecveEventUnix{
+		Parent: parent,
+		Process: processapi.MsgProcess{
+			PID:      pid,
+			TID:      pid,
+			Ktime:    ktime,
+			Filename: binary,
+		},
+	})
+}
+
+func TestGetProcess(t *testing.T) {
+	_, err := cilium.InitCiliumState(context.Background(), false)
+	require.NoError(t, err)
+	require.NoError(t, process.InitCache(watcher.NewFakeK8sWatcher(nil), 10))
+	defer process.FreeCache()
+
+	// the parent of bash is not in the cache
+	addExec(1, 10, "/bin/bash", processapi.MsgExecveKey{Pid: 0})
+	addExec(2, 20, "/usr/bin/curl", processapi.MsgExecveKey{Pid: 1, Ktime: 10})
+
+	s := NewServer(context.Background(), nil, &recordingNotifier{}, &FakeObserver{}, nil)
+
+	resp, err := s.GetProcess(context.Background(), &tetragon.GetProcessRequest{Pid: 2, StartTime: 20})
+	require.NoError(t, err)
+	assert.Equal(t, process.GetProcessID(2, 20), resp.GetProcess().GetExecId())
+	assert.Equal(t, "/usr/bin/curl", resp.GetProcess().GetBinary())
+	require.NotNil(t, resp.GetParent())
+	assert.Equal(t, process.GetProcessID(1, 10), resp.GetParent().GetExecId())
+	assert.Equal(t, "/bin/bash", resp.GetParent().GetBinary())
+
+	resp, err = s.GetProcessByExecId(context.Background(), &tetragon.GetProcessByExecIdRequest{ExecId: process.GetProcessID(1, 10)})
+	require.NoError(t, err)
+	assert.Equal(t, "/bin/bash", resp.GetProcess().GetBinary())
+	assert.Nil(t, resp.GetParent())
+
+	_, err = s.GetProcess(context.Background(), &tetragon.GetProcessRequest{Pid: 2, StartTime: 21})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = s.GetProcessByExecId(context.Background(), &tetragon.GetProcessByExecIdRequest{ExecId: process.GetProcessID(3, 30)})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = s.GetProcessByExecId(context.Background(), &tetragon.GetProcessByExecIdRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -5,12 +5,20 @@ package server
 
 import (
 	"context"
+	"crypto/subtle"
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/cilium/tetragon/api/v1/tetragon"
 	"github.com/cilium/tetragon/pkg/aggregator"
+	"github.com/cilium/tetragon/pkg/capabilityuse"
+	"github.com/cilium/tetragon/pkg/features"
 	"github.com/cilium/tetragon/pkg/filters"
 	"github.com/cilium/tetragon/pkg/health"
 	"github.com/cilium/tetragon/pkg/logger"
@@ -18,11 +26,19 @@ import (
 	v1 "github.com/cilium/tetragon/pkg/oldhubble/api/v1"
 	hubbleFilters "github.com/cilium/tetragon/pkg/oldhubble/filters"
 	"github.com/cilium/tetragon/pkg/option"
+	"github.com/cilium/tetragon/pkg/process"
+	"github.com/cilium/tetragon/pkg/reader/node"
 	"github.com/cilium/tetragon/pkg/sensors"
 	"github.com/cilium/tetragon/pkg/tracingpolicy"
 	"github.com/cilium/tetragon/pkg/version"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type Listener interface {
@@ -45,6 +61,12 @@ type observer interface {
 	ListTracingPolicies(ctx context.Context) (*tetragon.ListTracingPoliciesResponse, error)
 	DisableTracingPolicy(ctx context.Context, name string) error
 	EnableTracingPolicy(ctx context.Context, name string) error
+	// UpdateTracingPolicySelectors updates the selectors of an enabled
+	// tracing policy to the ones of policy.
+	UpdateTracingPolicySelectors(ctx context.Context, policy tracingpolicy.TracingPolicy) error
+	// AddTracingPolicySelectorValues adds values to a matchArgs filter of
+	// an enabled tracing policy.
+	AddTracingPolicySelectorValues(ctx context.Context, name string, values *sensors.SelectorValues) error
 	// ListTracingPolicies lists active traing policies
 	// ListTracingPolicies lists active traing policies
 
@@ -68,6 +90,33 @@ type Server struct {
 
 type getEventsListener struct {
 	events chan *tetragon.GetEventsResponse
+	// name identifies the listener in the metrics and in the logs
+	name string
+	// overflow is the behavior of the listener when its events queue is
+	// full, see --event-queue-overflow
+	overflow string
+	dropped  prometheus.Counter
+}
+
+// listenerID numbers the listeners in their names.
+var listenerID atomic.Uint64
+
+// eventQueueLimit is the percentage of the capacity of the events queues of
+// the listeners that can be used, see SetEventQueueLimit().
+var eventQueueLimit atomic.Int32
+
+func init() {
+	eventQueueLimit.Store(100)
+}
+
+// SetEventQueueLimit limits the events queues of the listeners to the given
+// percentage of their capacity. Events that do not fit are dropped, like when
+// a queue is full.
+func SetEventQueueLimit(percent int) {
+	if percent <= 0 || percent > 100 {
+		percent = 100
+	}
+	eventQueueLimit.Store(int32(percent))
 }
 
 func NewServer(ctx context.Context, cleanupWg *sync.WaitGroup, notifier notifier, observer observer, hookRunner hookRunner) *Server {
@@ -80,25 +129,68 @@ func NewServer(ctx context.Context, cleanupWg *sync.WaitGroup, notifier notifier
 	}
 }
 
-func newListener() *getEventsListener {
+// listenerName returns the name of the listener of a GetEvents request
+// received in ctx: grpc-<n> for the gRPC clients, and export-<n> for the
+// exporters.
+func listenerName(ctx context.Context) string {
+	kind := "export"
+	if _, ok := peer.FromContext(ctx); ok {
+		kind = "grpc"
+	}
+	return fmt.Sprintf("%s-%d", kind, listenerID.Add(1))
+}
+
+func newListener(name string) *getEventsListener {
 	var chanSize uint = 10000
 	if option.Config.EventQueueSize > 0 {
 		chanSize = option.Config.EventQueueSize
 	}
 	return &getEventsListener{
-		events: make(chan *tetragon.GetEventsResponse, chanSize),
+		events:   make(chan *tetragon.GetEventsResponse, chanSize),
+		name:     name,
+		overflow: option.Config.EventQueueOverflow,
+		dropped:  eventmetrics.ListenerDroppedEvents.WithLabelValues(name),
 	}
 }
 
 func (l *getEventsListener) Notify(res *tetragon.GetEventsResponse) {
+	if limit := eventQueueLimit.Load(); limit < 100 && len(l.events) >= cap(l.events)*int(limit)/100 {
+		l.drop()
+		return
+	}
+	switch l.overflow {
+	case option.EventQueueOverflowBlock:
+		l.events <- res
+		return
+	case option.EventQueueOverflowDropOldest:
+		for {
+			select {
+			case l.events <- res:
+				return
+			default:
+			}
+			// events channel is full: drop the oldest event to make
+			// room for the new one
+			select {
+			case <-l.events:
+				l.drop()
+			default:
+			}
+		}
+	}
 	select {
 	case l.events <- res:
 	default:
 		// events channel is full: drop the event so that we do not block everything
-		eventmetrics.NotifyOverflowedEvents.Inc()
+		l.drop()
 	}
 }
 
+func (l *getEventsListener) drop() {
+	eventmetrics.NotifyOverflowedEvents.Inc()
+	l.dropped.Inc()
+}
+
 func (s *Server) NotifyListeners(original interface{}, processed *tetragon.GetEventsResponse) {
 	s.notifier.NotifyListener(original, processed)
 }
@@ -117,6 +209,7 @@ func (s *Server) removeNotifierAndDrain(l *getEventsListener) {
 		select {
 		case <-l.events:
 		case <-done:
+			eventmetrics.ListenerDroppedEvents.DeleteLabelValues(l.name)
 			return
 		}
 	}
@@ -126,7 +219,9 @@ func (s *Server) GetEvents(request *tetragon.GetEventsRequest, server tetragon.F
 }
 
 func (s *Server) GetEventsWG(request *tetragon.GetEventsRequest, server tetragon.FineGuidanceSensors_GetEventsServer, closer io.Closer, readyWG *sync.WaitGroup) error {
+	name := listenerName(server.Context())
 	logger.GetLogger().WithFields(logrus.Fields{
+		"listener":                   name,
 		"events.allow_list":          request.GetAllowList(),
 		"events.deny_list":           request.GetDenyList(),
 		"events.field_filters":       request.GetFieldFilters(),
@@ -148,7 +243,7 @@ func (s *Server) GetEventsWG(request *tetragon.GetEventsRequest, server tetragon
 		go aggregator.Start()
 	}
 
-	l := newListener()
+	l := newListener(name)
 	s.notifier.AddListener(l)
 	defer s.removeNotifierAndDrain(l)
 	if readyWG != nil {
@@ -225,20 +320,46 @@ func (s *Server) ListSensors(ctx context.Context, _ *tetragon.ListSensorsRequest
 	sensors := make([]*tetragon.SensorStatus, 0, len(*list))
 	for _, s := range *list {
 		sensors = append(sensors, &tetragon.SensorStatus{
-			Name:       s.Name,
-			Enabled:    s.Enabled,
-			Collection: s.Collection,
+			Name:          s.Name,
+			Enabled:       s.Enabled,
+			Collection:    s.Collection,
+			AttachModes:   s.AttachModes,
+			DeferredHooks: s.DeferredHooks,
+			Programs:      s.Programs,
+			Maps:          s.Maps,
 		})
 	}
 
 	return &tetragon.ListSensorsResponse{Sensors: sensors}, nil
 }
 
+// tracingPolicyError returns the gRPC status of an error of an operation on a
+// tracing policy.
+func tracingPolicyError(err error) error {
+	switch {
+	case errors.Is(err, sensors.ErrTracingPolicyNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, sensors.ErrTracingPolicyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, sensors.ErrTracingPolicyEnabled), errors.Is(err, sensors.ErrTracingPolicyDisabled),
+		errors.Is(err, sensors.ErrTracingPolicyNotUpdatable), errors.Is(err, sensors.ErrTracingPolicyNodeNotSelected):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, sensors.ErrInvalidSelectorValues):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+// AddTracingPolicy loads a tracing policy in the YAML or JSON format, like the
+// tracing policies of the files and of the Kubernetes resources.
 func (s *Server) AddTracingPolicy(ctx context.Context, req *tetragon.AddTracingPolicyRequest) (*tetragon.AddTracingPolicyResponse, error) {
+	if req.GetYaml() == "" {
+		return nil, status.Error(codes.InvalidArgument, "yaml is required")
+	}
 	tp, err := tracingpolicy.FromYAML(req.GetYaml())
 	if err != nil {
 		logger.GetLogger().WithError(err).Warn("Server AddTracingPolicy request failed")
-		return nil, err
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tracing policy: %s", err)
 	}
 	namespace := ""
 	if tpNs, ok := tp.(tracingpolicy.TracingPolicyNamespaced); ok {
@@ -255,7 +376,7 @@ func (s *Server) AddTracingPolicy(ctx context.Context, req *tetragon.AddTracingP
 			"metadata.namespace": namespace,
 			"metadata.name":      tp.TpName(),
 		}).WithError(err).Warn("Server AddTracingPolicy request failed")
-		return nil, err
+		return nil, tracingPolicyError(err)
 	}
 	return &tetragon.AddTracingPolicyResponse{}, nil
 }
@@ -269,7 +390,7 @@ func (s *Server) DeleteTracingPolicy(ctx context.Context, req *tetragon.DeleteTr
 		logger.GetLogger().WithFields(logrus.Fields{
 			"name": req.GetName(),
 		}).WithError(err).Warn("Server DeleteTracingPolicy request failed")
-		return nil, err
+		return nil, tracingPolicyError(err)
 	}
 	return &tetragon.DeleteTracingPolicyResponse{}, nil
 }
@@ -283,7 +404,7 @@ func (s *Server) EnableTracingPolicy(ctx context.Context, req *tetragon.EnableTr
 		logger.GetLogger().WithFields(logrus.Fields{
 			"name": req.GetName(),
 		}).WithError(err).Warn("Server EnableTracingPolicy request failed")
-		return nil, err
+		return nil, tracingPolicyError(err)
 	}
 	return &tetragon.EnableTracingPolicyResponse{}, nil
 }
@@ -297,11 +418,76 @@ func (s *Server) DisableTracingPolicy(ctx context.Context, req *tetragon.Disable
 		logger.GetLogger().WithFields(logrus.Fields{
 			"name": req.GetName(),
 		}).WithError(err).Warn("Server DisableTracingPolicy request failed")
-		return nil, err
+		return nil, tracingPolicyError(err)
 	}
 	return &tetragon.DisableTracingPolicyResponse{}, nil
 }
 
+// UpdateTracingPolicySelectors updates the selectors of an enabled tracing
+// policy, without reloading its BPF programs.
+func (s *Server) UpdateTracingPolicySelectors(ctx context.Context, req *tetragon.UpdateTracingPolicySelectorsRequest) (*tetragon.UpdateTracingPolicySelectorsResponse, error) {
+	if req.GetYaml() == "" {
+		return nil, status.Error(codes.InvalidArgument, "yaml is required")
+	}
+	tp, err := tracingpolicy.FromYAML(req.GetYaml())
+	if err != nil {
+		logger.GetLogger().WithError(err).Warn("Server UpdateTracingPolicySelectors request failed")
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tracing policy: %s", err)
+	}
+
+	logger.GetLogger().WithFields(logrus.Fields{
+		"metadata.name": tp.TpName(),
+	}).Debug("Received an UpdateTracingPolicySelectors request")
+
+	if err := s.observer.UpdateTracingPolicySelectors(ctx, tp); err != nil {
+		logger.GetLogger().WithFields(logrus.Fields{
+			"metadata.name": tp.TpName(),
+		}).WithError(err).Warn("Server UpdateTracingPolicySelectors request failed")
+		return nil, tracingPolicyError(err)
+	}
+	return &tetragon.UpdateTracingPolicySelectorsResponse{}, nil
+}
+
+// AddTracingPolicySelectorValues adds values to a matchArgs filter of an
+// enabled tracing policy, optionally for a limited time.
+func (s *Server) AddTracingPolicySelectorValues(ctx context.Context, req *tetragon.AddTracingPolicySelectorValuesRequest) (*tetragon.AddTracingPolicySelectorValuesResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if len(req.GetValues()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "values are required")
+	}
+	if req.GetTtl() != nil && req.GetTtl().AsDuration() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "ttl must be positive")
+	}
+
+	logger.GetLogger().WithFields(logrus.Fields{
+		"name":       req.GetName(),
+		"kprobe":     req.GetKprobe(),
+		"tracepoint": req.GetTracepoint(),
+		"selector":   req.GetSelector(),
+		"arg":        req.GetArg(),
+		"values":     req.GetValues(),
+		"ttl":        req.GetTtl().AsDuration(),
+	}).Debug("Received an AddTracingPolicySelectorValues request")
+
+	values := &sensors.SelectorValues{
+		Tracepoint: req.GetTracepoint(),
+		Hook:       int(req.GetKprobe()),
+		Selector:   int(req.GetSelector()),
+		Arg:        req.GetArg(),
+		Values:     req.GetValues(),
+		TTL:        req.GetTtl().AsDuration(),
+	}
+	if err := s.observer.AddTracingPolicySelectorValues(ctx, req.GetName(), values); err != nil {
+		logger.GetLogger().WithFields(logrus.Fields{
+			"name": req.GetName(),
+		}).WithError(err).Warn("Server AddTracingPolicySelectorValues request failed")
+		return nil, tracingPolicyError(err)
+	}
+	return &tetragon.AddTracingPolicySelectorValuesResponse{}, nil
+}
+
 func (s *Server) ListTracingPolicies(ctx context.Context, req *tetragon.ListTracingPoliciesRequest) (*tetragon.ListTracingPoliciesResponse, error) {
 	logger.GetLogger().WithField("request", req).Debug("Received a ListTracingPolicies request")
 	ret, err := s.observer.ListTracingPolicies(ctx)
@@ -367,3 +553,129 @@ func (s *Server) RuntimeHook(ctx context.Context, req *tetragon.RuntimeHookReque
 	}
 	return &tetragon.RuntimeHookResponse{}, nil
 }
+
+func (s *Server) GetProcess(ctx context.Context, req *tetragon.GetProcessRequest) (*tetragon.GetProcessResponse, error) {
+	logger.GetLogger().WithField("request", req).Debug("Received a GetProcess request")
+	return s.getProcess(process.GetProcessID(req.GetPid(), req.GetStartTime()))
+}
+
+func (s *Server) GetProcessByExecId(ctx context.Context, req *tetragon.GetProcessByExecIdRequest) (*tetragon.GetProcessResponse, error) {
+	logger.GetLogger().WithField("request", req).Debug("Received a GetProcessByExecId request")
+	if req.GetExecId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "exec_id is required")
+	}
+	return s.getProcess(req.GetExecId())
+}
+
+// getProcess looks up the process (and its parent, if available) in the
+// process cache. Processes that already exited remain available until they
+// are removed by the cache garbage collector.
+func (s *Server) getProcess(execID string) (*tetragon.GetProcessResponse, error) {
+	proc, err := process.Get(execID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "process %s not found in process cache", execID)
+	}
+	resp := &tetragon.GetProcessResponse{
+		Process: proc.GetProcessCopy(),
+	}
+	if parentID := resp.Process.GetParentExecId(); parentID != "" {
+		if parent, err := process.Get(parentID); err == nil {
+			resp.Parent = parent.GetProcessCopy()
+		}
+	}
+	return resp, nil
+}
+
+func (s *Server) AnnotateEvent(ctx context.Context, req *tetragon.AnnotateEventRequest) (*tetragon.AnnotateEventResponse, error) {
+	logger.GetLogger().WithField("request", req).Debug("Received an AnnotateEvent request")
+	if err := authorizeAnnotation(ctx); err != nil {
+		logger.GetLogger().WithError(err).Warn("Server AnnotateEvent request denied")
+		return nil, err
+	}
+	annotation := req.GetAnnotation()
+	if err := validateAnnotatedEvent(annotation, time.Now()); err != nil {
+		return nil, err
+	}
+
+	s.notifier.NotifyListener(req, &tetragon.GetEventsResponse{
+		Event:    &tetragon.GetEventsResponse_EventAnnotation{EventAnnotation: annotation},
+		NodeName: node.GetNodeNameForExport(),
+		Time:     timestamppb.Now(),
+	})
+	return &tetragon.AnnotateEventResponse{}, nil
+}
+
+func (s *Server) GetCapabilityReport(_ context.Context, req *tetragon.GetCapabilityReportRequest) (*tetragon.GetCapabilityReportResponse, error) {
+	logger.GetLogger().WithField("request", req).Debug("Received a GetCapabilityReport request")
+	r := capabilityuse.GetRecorder()
+	if r == nil {
+		return nil, status.Error(codes.FailedPrecondition, "capability-use reports are disabled")
+	}
+	return r.Report(req.GetWindow().AsDuration(), req.GetNamespace()), nil
+}
+
+func (s *Server) GetKernelFeatures(_ context.Context, _ *tetragon.GetKernelFeaturesRequest) (*tetragon.GetKernelFeaturesResponse, error) {
+	return features.Report(), nil
+}
+
+// annotationClockSkew is how far in the future the time of an annotated
+// event may be, to tolerate the clock skew between the agent and its clients.
+const annotationClockSkew = time.Minute
+
+// validateAnnotatedEvent checks that the exec_id and the time of an annotation
+// refer to an event of this node: the exec_id must be one of this node, and
+// the time must not be in the future, nor before the start of the process if
+// it is still in the process cache.
+func validateAnnotatedEvent(annotation *tetragon.EventAnnotation, now time.Time) error {
+	execID := annotation.GetExecId()
+	if execID == "" {
+		return status.Error(codes.InvalidArgument, "annotation.exec_id is required")
+	}
+	id, err := process.ParseExecID(execID)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid annotation.exec_id: %s", err)
+	}
+	if nodeName := node.GetNodeNameForExport(); id.NodeName != nodeName {
+		return status.Errorf(codes.InvalidArgument, "annotation.exec_id %s is of node %q, not of node %q", execID, id.NodeName, nodeName)
+	}
+	if annotation.GetTime() == nil {
+		return status.Error(codes.InvalidArgument, "annotation.time is required")
+	}
+	t := annotation.GetTime().AsTime()
+	if t.After(now.Add(annotationClockSkew)) {
+		return status.Errorf(codes.InvalidArgument, "annotation.time %s is in the future", t)
+	}
+	if proc, err := process.Get(execID); err == nil {
+		if start := proc.UnsafeGetProcess().GetStartTime(); start != nil && t.Before(start.AsTime()) {
+			return status.Errorf(codes.InvalidArgument, "annotation.time %s is before the start of process %s", t, execID)
+		}
+	}
+	return nil
+}
+
+// authorizeAnnotation checks that the client presented the bearer token
+// configured in option.Config.EventAnnotationTokenFile in the authorization
+// metadata of the request.
+func authorizeAnnotation(ctx context.Context) error {
+	if option.Config.EventAnnotationTokenFile == "" {
+		return status.Error(codes.PermissionDenied, "event annotations are disabled")
+	}
+	token, err := os.ReadFile(option.Config.EventAnnotationTokenFile)
+	if err != nil {
+		logger.GetLogger().WithError(err).Warn("Failed to read event annotation token file")
+		return status.Error(codes.Internal, "failed to read event annotation token")
+	}
+	expected := strings.TrimSpace(string(token))
+	if expected == "" {
+		return status.Error(codes.Internal, "event annotation token is empty")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get("authorization") {
+		presented := strings.TrimPrefix(v, "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(presented), []byte(expected)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "invalid event annotation token")
+}
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/option"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/peer"
+)
+
+func testListener(t *testing.T, overflow string) *getEventsListener {
+	prevSize, prevOverflow := option.Config.EventQueueSize, option.Config.EventQueueOverflow
+	t.Cleanup(func() {
+		option.Config.EventQueueSize, option.Config.EventQueueOverflow = prevSize, prevOverflow
+	})
+	option.Config.EventQueueSize = 2
+	option.Config.EventQueueOverflow = overflow
+	return newListener(t.Name())
+}
+
+func testEvent(node string) *tetragon.GetEventsResponse {
+	return &tetragon.GetEventsResponse{NodeName: node}
+}
+
+func queuedNodes(l *getEventsListener) []string {
+	var nodes []string
+	for len(l.events) > 0 {
+		nodes = append(nodes, (<-l.events).NodeName)
+	}
+	return nodes
+}
+
+func TestListenerOverflow(t *testing.T) {
+	t.Run("drop-newest", func(t *testing.T) {
+		l := testListener(t, option.EventQueueOverflowDropNewest)
+		for _, n := range []string{"a", "b", "c", "d"} {
+			l.Notify(testEvent(n))
+		}
+		assert.Equal(t, []string{"a", "b"}, queuedNodes(l))
+		assert.Equal(t, 2.0, testutil.ToFloat64(l.dropped))
+	})
+
+	t.Run("drop-oldest", func(t *testing.T) {
+		l := testListener(t, option.EventQueueOverflowDropOldest)
+		for _, n := range []string{"a", "b", "c", "d"} {
+			l.Notify(testEvent(n))
+		}
+		assert.Equal(t, []string{"c", "d"}, queuedNodes(l))
+		assert.Equal(t, 2.0, testutil.ToFloat64(l.dropped))
+	})
+
+	t.Run("block", func(t *testing.T) {
+		l := testListener(t, option.EventQueueOverflowBlock)
+		l.Notify(testEvent("a"))
+		l.Notify(testEvent("b"))
+		done := make(chan struct{})
+		go func() {
+			l.Notify(testEvent("c"))
+			close(done)
+		}()
+		select {
+		case <-done:
+			t.Fatal("Notify did not block on a full queue")
+		case <-time.After(50 * time.Millisecond):
+		}
+		assert.Equal(t, "a", (<-l.events).NodeName)
+		<-done
+		assert.Equal(t, []string{"b", "c"}, queuedNodes(l))
+		assert.Equal(t, 0.0, testutil.ToFloat64(l.dropped))
+	})
+}
+
+func TestListenerName(t *testing.T) {
+	assert.Regexp(t, `^export-\d+$`, listenerName(context.Background()))
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.UnixAddr{Name: "@", Net: "unix"}})
+	assert.Regexp(t, `^grpc-\d+$`, listenerName(ctx))
+	assert.NotEqual(t, listenerName(ctx), listenerName(ctx))
+}
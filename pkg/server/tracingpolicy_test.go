@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/sensors"
+	"github.com/cilium/tetragon/pkg/tracingpolicy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type policyObserver struct {
+	FakeObserver
+	policies map[string]bool
+}
+
+func (o *policyObserver) AddTracingPolicy(_ context.Context, tp tracingpolicy.TracingPolicy) error {
+	if _, ok := o.policies[tp.TpName()]; ok {
+		return fmt.Errorf("failed to add tracing policy %s: %w", tp.TpName(), sensors.ErrTracingPolicyExists)
+	}
+	o.policies[tp.TpName()] = true
+	return nil
+}
+
+func (o *policyObserver) DeleteTracingPolicy(_ context.Context, name string) error {
+	if _, ok := o.policies[name]; !ok {
+		return fmt.Errorf("%w: %s", sensors.ErrTracingPolicyNotFound, name)
+	}
+	delete(o.policies, name)
+	return nil
+}
+
+func (o *policyObserver) EnableTracingPolicy(_ context.Context, name string) error {
+	enabled, ok := o.policies[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", sensors.ErrTracingPolicyNotFound, name)
+	}
+	if enabled {
+		return fmt.Errorf("%w: %s", sensors.ErrTracingPolicyEnabled, name)
+	}
+	o.policies[name] = true
+	return nil
+}
+
+func (o *policyObserver) UpdateTracingPolicySelectors(_ context.Context, tp tracingpolicy.TracingPolicy) error {
+	if _, ok := o.policies[tp.TpName()]; !ok {
+		return fmt.Errorf("%w: %s", sensors.ErrTracingPolicyNotFound, tp.TpName())
+	}
+	return fmt.Errorf("%w: %s", sensors.ErrTracingPolicyNotUpdatable, tp.TpName())
+}
+
+func (o *policyObserver) AddTracingPolicySelectorValues(_ context.Context, name string, values *sensors.SelectorValues) error {
+	if _, ok := o.policies[name]; !ok {
+		return fmt.Errorf("%w: %s", sensors.ErrTracingPolicyNotFound, name)
+	}
+	if values.Tracepoint || values.Hook != 0 {
+		return fmt.Errorf("%w: no hook %d", sensors.ErrInvalidSelectorValues, values.Hook)
+	}
+	return nil
+}
+
+func TestTracingPolicyRPCs(t *testing.T) {
+	ctx := context.Background()
+	obs := &policyObserver{policies: map[string]bool{}}
+	s := NewServer(ctx, nil, nil, obs, nil)
+
+	policy := `{"apiVersion":"cilium.io/v1alpha1","kind":"TracingPolicy","metadata":{"name":"json-policy"},` +
+		`"spec":{"kprobes":[{"call":"fd_install","syscall":false,"args":[{"index":0,"type":"int"}]}]}}`
+	_, err := s.AddTracingPolicy(ctx, &tetragon.AddTracingPolicyRequest{Yaml: policy})
+	require.NoError(t, err)
+	assert.Contains(t, obs.policies, "json-policy")
+
+	_, err = s.AddTracingPolicy(ctx, &tetragon.AddTracingPolicyRequest{Yaml: policy})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	_, err = s.AddTracingPolicy(ctx, &tetragon.AddTracingPolicyRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = s.AddTracingPolicy(ctx, &tetragon.AddTracingPolicyRequest{Yaml: "kind: Unknown"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.EnableTracingPolicy(ctx, &tetragon.EnableTracingPolicyRequest{Name: "json-policy"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = s.UpdateTracingPolicySelectors(ctx, &tetragon.UpdateTracingPolicySelectorsRequest{Yaml: policy})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = s.UpdateTracingPolicySelectors(ctx, &tetragon.UpdateTracingPolicySelectorsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.AddTracingPolicySelectorValues(ctx, &tetragon.AddTracingPolicySelectorValuesRequest{
+		Name: "json-policy", Values: []string{"10.0.0.1"}, Ttl: durationpb.New(time.Hour),
+	})
+	require.NoError(t, err)
+	_, err = s.AddTracingPolicySelectorValues(ctx, &tetragon.AddTracingPolicySelectorValuesRequest{
+		Name: "json-policy", Kprobe: 1, Values: []string{"10.0.0.1"},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = s.AddTracingPolicySelectorValues(ctx, &tetragon.AddTracingPolicySelectorValuesRequest{
+		Name: "json-policy", Tracepoint: true, Values: []string{"10.0.0.1"},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = s.AddTracingPolicySelectorValues(ctx, &tetragon.AddTracingPolicySelectorValuesRequest{Name: "json-policy"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = s.AddTracingPolicySelectorValues(ctx, &tetragon.AddTracingPolicySelectorValuesRequest{
+		Name: "json-policy", Values: []string{"10.0.0.1"}, Ttl: durationpb.New(-time.Hour),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = s.AddTracingPolicySelectorValues(ctx, &tetragon.AddTracingPolicySelectorValuesRequest{
+		Name: "unknown-policy", Values: []string{"10.0.0.1"},
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = s.DeleteTracingPolicy(ctx, &tetragon.DeleteTracingPolicyRequest{Name: "json-policy"})
+	require.NoError(t, err)
+	_, err = s.DeleteTracingPolicy(ctx, &tetragon.DeleteTracingPolicyRequest{Name: "json-policy"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
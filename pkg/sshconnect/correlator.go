@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package sshconnect
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/observer"
+	"github.com/cilium/tetragon/pkg/process"
+	"github.com/cilium/tetragon/pkg/reader/node"
+	"github.com/cilium/tetragon/pkg/reader/notify"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxClients bounds the number of SSH client processes whose reported
+	// destinations are tracked.
+	maxClients = 4096
+	// eventsQueueSize is the number of events waiting to be pushed, above
+	// which events are dropped.
+	eventsQueueSize = 1024
+)
+
+// Correlator consolidates the exec of the SSH clients, with their arguments,
+// and their connections reported by the built-in SSH policy in SshConnection
+// events. Each destination of a client process is reported once.
+type Correlator struct {
+	// push sends the events to the listeners of the observer
+	push   func(msg notify.Message)
+	events chan *tetragon.SshConnection
+
+	mu sync.Mutex
+	// reported destinations of the clients, by exec ID
+	reported map[string]map[string]struct{}
+}
+
+// NewCorrelator returns a new correlator.
+func NewCorrelator() *Correlator {
+	return &Correlator{
+		push:     observer.AllListeners,
+		events:   make(chan *tetragon.SshConnection, eventsQueueSize),
+		reported: make(map[string]map[string]struct{}),
+	}
+}
+
+// Notify correlates the connections of the events. It implements
+// server.Listener. The events are pushed by Run, since Notify is called with
+// the lock of the process manager held.
+func (c *Correlator) Notify(res *tetragon.GetEventsResponse) {
+	switch ev := res.Event.(type) {
+	case *tetragon.GetEventsResponse_ProcessKprobe:
+		if ev.ProcessKprobe.GetPolicyName() != PolicyName {
+			return
+		}
+		conn := c.connection(ev.ProcessKprobe)
+		if conn == nil {
+			return
+		}
+		select {
+		case c.events <- conn:
+		default:
+			logger.GetLogger().WithField("process", conn.Process.GetBinary()).
+				Warn("SSH connections queue is full, dropping event")
+		}
+	case *tetragon.GetEventsResponse_ProcessExit:
+		c.mu.Lock()
+		delete(c.reported, ev.ProcessExit.GetProcess().GetExecId())
+		c.mu.Unlock()
+	}
+}
+
+// connection returns the event of the connection of a kprobe event, or nil if
+// its destination was already reported.
+func (c *Correlator) connection(kprobe *tetragon.ProcessKprobe) *tetragon.SshConnection {
+	proc := kprobe.GetProcess()
+	id := proc.GetExecId()
+	if id == "" {
+		return nil
+	}
+	var sock *tetragon.KprobeSock
+	for _, arg := range kprobe.GetArgs() {
+		if s := arg.GetSockArg(); s != nil {
+			sock = s
+			break
+		}
+	}
+	if sock == nil {
+		return nil
+	}
+
+	dest := net.JoinHostPort(sock.Daddr, strconv.FormatUint(uint64(sock.Dport), 10))
+	c.mu.Lock()
+	dests, ok := c.reported[id]
+	if !ok {
+		if len(c.reported) >= maxClients {
+			c.mu.Unlock()
+			return nil
+		}
+		dests = make(map[string]struct{})
+		c.reported[id] = dests
+	}
+	_, seen := dests[dest]
+	dests[dest] = struct{}{}
+	c.mu.Unlock()
+	if seen {
+		return nil
+	}
+
+	client := "ssh"
+	switch base := filepath.Base(kprobe.GetParent().GetBinary()); base {
+	case "scp", "sftp":
+		client = base
+	}
+	host, user := parseDestination(proc.GetArguments())
+	return &tetragon.SshConnection{
+		Process:            proc,
+		Parent:             kprobe.GetParent(),
+		Client:             client,
+		DestinationHost:    host,
+		DestinationAddress: sock.Daddr,
+		DestinationPort:    sock.Dport,
+		User:               user,
+	}
+}
+
+// Run pushes the events of the connections until ctx is done.
+func (c *Correlator) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev := <-c.events:
+			c.push(&MsgSshConnection{SshConnection: ev})
+		}
+	}
+}
+
+// MsgSshConnection is the message of an SshConnection event.
+type MsgSshConnection struct {
+	SshConnection *tetragon.SshConnection
+}
+
+func (msg *MsgSshConnection) Notify() bool {
+	return false
+}
+
+func (msg *MsgSshConnection) RetryInternal(_ notify.Event, _ uint64) (*process.ProcessInternal, error) {
+	return nil, fmt.Errorf("Unsupported cache event MsgSshConnection")
+}
+
+func (msg *MsgSshConnection) Retry(_ *process.ProcessInternal, _ notify.Event) error {
+	return fmt.Errorf("Unsupported cache retry event MsgSshConnection")
+}
+
+func (msg *MsgSshConnection) HandleMessage() *tetragon.GetEventsResponse {
+	return &tetragon.GetEventsResponse{
+		Event:    &tetragon.GetEventsResponse_SshConnection{SshConnection: msg.SshConnection},
+		NodeName: node.GetNodeNameForExport(),
+		Time:     timestamppb.Now(),
+	}
+}
+
+func (msg *MsgSshConnection) Cast(_ interface{}) notify.Message {
+	return &MsgSshConnection{}
+}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package sshconnect
+
+import (
+	"testing"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/reader/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy(t *testing.T) {
+	tp, err := Policy([]string{"/usr/bin/ssh", "/opt/ssh"})
+	require.NoError(t, err)
+	assert.Equal(t, PolicyName, tp.TpName())
+	spec := tp.TpSpec()
+	require.Len(t, spec.KProbes, 1)
+	assert.Equal(t, "tcp_connect", spec.KProbes[0].Call)
+	assert.Equal(t, []string{"/usr/bin/ssh", "/opt/ssh"}, spec.KProbes[0].Selectors[0].MatchBinaries[0].Values)
+
+	_, err = Policy([]string{"ssh"})
+	assert.Error(t, err)
+	_, err = Policy(nil)
+	assert.Error(t, err)
+}
+
+func TestParseDestination(t *testing.T) {
+	for _, tc := range []struct {
+		args, host, user string
+	}{
+		{"db", "db", ""},
+		{"admin@db", "db", "admin"},
+		{"-p 2222 -i /root/.ssh/id admin@db uptime", "db", "admin"},
+		{"-p2222 -vA db", "db", ""},
+		{"-l root db", "db", "root"},
+		{"-l root admin@db", "db", "root"},
+		{"-o User=ops -o \"ProxyCommand nc %h %p\" db", "db", "ops"},
+		{"ssh://admin@db:2222", "db", "admin"},
+		{"ssh://[fd00::1]:2222", "fd00::1", ""},
+		{"-x -oForwardAgent=no -- admin@db scp -t /tmp", "db", "admin"},
+		{"-v", "", ""},
+	} {
+		host, user := parseDestination(tc.args)
+		assert.Equal(t, tc.host, host, tc.args)
+		assert.Equal(t, tc.user, user, tc.args)
+	}
+}
+
+func connect(id, args, parent, daddr string) *tetragon.GetEventsResponse {
+	return &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessKprobe{
+			ProcessKprobe: &tetragon.ProcessKprobe{
+				Process:      &tetragon.Process{ExecId: id, Binary: "/usr/bin/ssh", Arguments: args},
+				Parent:       &tetragon.Process{Binary: parent},
+				PolicyName:   PolicyName,
+				FunctionName: "tcp_connect",
+				Args: []*tetragon.KprobeArgument{{
+					Arg: &tetragon.KprobeArgument_SockArg{
+						SockArg: &tetragon.KprobeSock{Daddr: daddr, Dport: 22},
+					},
+				}},
+			},
+		},
+	}
+}
+
+func TestCorrelator(t *testing.T) {
+	c := NewCorrelator()
+	var events []*tetragon.SshConnection
+	c.push = func(msg notify.Message) {
+		events = append(events, msg.(*MsgSshConnection).SshConnection)
+	}
+
+	c.Notify(connect("ssh1", "admin@db uptime", "/bin/bash", "10.0.0.5"))
+	// the same destination is reported once per process
+	c.Notify(connect("ssh1", "admin@db uptime", "/bin/bash", "10.0.0.5"))
+	c.Notify(connect("ssh2", "-x -oForwardAgent=no -- ops@web scp -t /tmp", "/usr/bin/scp", "10.0.0.6"))
+	// other policies are ignored
+	other := connect("ssh3", "db", "/bin/bash", "10.0.0.7")
+	other.GetProcessKprobe().PolicyName = "other"
+	c.Notify(other)
+
+	for len(c.events) > 0 {
+		c.push(&MsgSshConnection{SshConnection: <-c.events})
+	}
+
+	require.Len(t, events, 2)
+	assert.Equal(t, "ssh", events[0].Client)
+	assert.Equal(t, "db", events[0].DestinationHost)
+	assert.Equal(t, "admin", events[0].User)
+	assert.Equal(t, "10.0.0.5", events[0].DestinationAddress)
+	assert.Equal(t, uint32(22), events[0].DestinationPort)
+	assert.Equal(t, "scp", events[1].Client)
+	assert.Equal(t, "web", events[1].DestinationHost)
+	assert.Equal(t, "ops", events[1].User)
+
+	// the destinations of a process are forgotten when it exits
+	c.Notify(&tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessExit{
+			ProcessExit: &tetragon.ProcessExit{Process: &tetragon.Process{ExecId: "ssh1"}},
+		},
+	})
+	assert.NotContains(t, c.reported, "ssh1")
+}
@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package sshconnect reports the connections of SSH clients. The built-in
+// SSH policy reports the connect() calls of the SSH client binaries, and the
+// Correlator consolidates them with the exec of the clients in SshConnection
+// events, with the destination host and user from the arguments of the
+// clients.
+package sshconnect
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/cilium/tetragon/pkg/tracingpolicy"
+)
+
+// PolicyName is the name of the built-in SSH policy.
+const PolicyName = "ssh-lateral-movement"
+
+const policyTemplate = `apiVersion: cilium.io/v1alpha1
+kind: TracingPolicy
+metadata:
+  name: "` + PolicyName + `"
+spec:
+  kprobes:
+  - call: "tcp_connect"
+    syscall: false
+    args:
+    - index: 0
+      type: "sock"
+    selectors:
+    - matchBinaries:
+      - operator: "In"
+        values:%s
+      matchActions:
+      - action: Post
+`
+
+func policyYAML(binaries []string) (string, error) {
+	if len(binaries) == 0 {
+		return "", fmt.Errorf("no SSH client binaries")
+	}
+	var sb strings.Builder
+	for _, b := range binaries {
+		if !filepath.IsAbs(b) {
+			return "", fmt.Errorf("SSH client binary %q is not an absolute path", b)
+		}
+		fmt.Fprintf(&sb, "\n        - %q", b)
+	}
+	return fmt.Sprintf(policyTemplate, sb.String()), nil
+}
+
+// Policy returns the built-in SSH policy, reporting the connections of the
+// SSH client binaries.
+func Policy(binaries []string) (tracingpolicy.TracingPolicy, error) {
+	policy, err := policyYAML(binaries)
+	if err != nil {
+		return nil, err
+	}
+	return tracingpolicy.FromYAML(policy)
+}
+
+// sshOptionsWithArg are the options of the OpenSSH client that take an
+// argument.
+const sshOptionsWithArg = "BbcDEeFIiJLlmOoPpQRSWw"
+
+// splitArgs splits the arguments of a process, as formatted by Tetragon:
+// separated by spaces, and quoted when they contain spaces.
+func splitArgs(args string) []string {
+	var ret []string
+	for args != "" {
+		args = strings.TrimLeft(args, " ")
+		if args == "" {
+			break
+		}
+		if args[0] == '"' {
+			if end := strings.IndexByte(args[1:], '"'); end >= 0 {
+				ret = append(ret, args[1:end+1])
+				args = args[end+2:]
+				continue
+			}
+		}
+		end := strings.IndexByte(args, ' ')
+		if end < 0 {
+			end = len(args)
+		}
+		ret = append(ret, args[:end])
+		args = args[end:]
+	}
+	return ret
+}
+
+// parseDestination returns the destination host and the remote user of the
+// arguments of an SSH client. The user is the one of the -l option or of the
+// User option, or else the one of the destination.
+func parseDestination(args string) (host, user string) {
+	var destUser string
+	argv := splitArgs(args)
+	for i := 0; i < len(argv); i++ {
+		arg := argv[i]
+		if arg == "--" {
+			if i+1 < len(argv) && host == "" {
+				host = argv[i+1]
+			}
+			break
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			if host == "" {
+				host = arg
+				// the arguments after the destination are the
+				// command, unless they are options
+				continue
+			}
+			break
+		}
+		// options can be grouped, the first one that takes an argument
+		// ends the group, with the rest of the group or the next
+		// argument as its argument
+		for j := 1; j < len(arg); j++ {
+			if !strings.ContainsRune(sshOptionsWithArg, rune(arg[j])) {
+				continue
+			}
+			val := arg[j+1:]
+			if val == "" && i+1 < len(argv) {
+				i++
+				val = argv[i]
+			}
+			switch arg[j] {
+			case 'l':
+				user = val
+			case 'o':
+				if k, v, ok := strings.Cut(val, "="); ok && strings.EqualFold(strings.TrimSpace(k), "user") {
+					user = strings.TrimSpace(v)
+				}
+			}
+			break
+		}
+	}
+
+	host = strings.TrimPrefix(host, "ssh://")
+	if at := strings.LastIndexByte(host, '@'); at >= 0 {
+		destUser, host = host[:at], host[at+1:]
+	}
+	if strings.HasPrefix(host, "[") {
+		// ssh://[ipv6]:port
+		if end := strings.IndexByte(host, ']'); end > 0 {
+			host = host[1:end]
+		}
+	} else if h, _, ok := strings.Cut(host, ":"); ok && strings.Count(host, ":") == 1 {
+		host = h
+	}
+	if user == "" {
+		user = destUser
+	}
+	return host, user
+}
@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package stalepins cleans up the BPF programs and maps pinned in the Tetragon
+// BPF directory by the previous runs of the agent.
+//
+// Every run of the agent marks the BPF directory with a generation marker, a
+// directory named after the generation of the run, which is removed when the
+// agent exits cleanly. When the agent starts, all the pins of the directory
+// were left by previous runs, and a marker means that the previous run did not
+// exit cleanly, e.g., it crashed. The pins are then removed, or adopted when
+// they are reused by the new run, so that crash loops do not accumulate
+// kernel memory.
+package stalepins
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Mode is what to do with the pins left by the previous runs.
+type Mode string
+
+const (
+	// ModeAdopt keeps the adoptable pins, reused by the new run, and
+	// removes the others.
+	ModeAdopt Mode = "adopt"
+	// ModeRemove removes all the pins.
+	ModeRemove Mode = "remove"
+	// ModeKeep keeps all the pins.
+	ModeKeep Mode = "keep"
+)
+
+// ParseMode parses a mode.
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(s); m {
+	case ModeAdopt, ModeRemove, ModeKeep:
+		return m, nil
+	}
+	return "", fmt.Errorf("invalid stale pins mode '%s', expected one of %s, %s or %s", s, ModeAdopt, ModeRemove, ModeKeep)
+}
+
+// generationPrefix is the prefix of the name of the generation markers.
+const generationPrefix = "generation-"
+
+func generationMarker(gen uint64) string {
+	return generationPrefix + strconv.FormatUint(gen, 10)
+}
+
+func parseGenerationMarker(name string) (uint64, bool) {
+	s, ok := strings.CutPrefix(name, generationPrefix)
+	if !ok {
+		return 0, false
+	}
+	gen, err := strconv.ParseUint(s, 10, 64)
+	return gen, err == nil
+}
+
+// Report is what Clean found in the BPF directory and did with it.
+type Report struct {
+	// Generation is the generation of the new run. It is 1 after a clean
+	// exit, and increases with every run that does not exit cleanly, so it
+	// counts the restarts of a crash loop.
+	Generation uint64
+	// PrevGeneration is the generation of the previous run if it left a
+	// marker, i.e., did not exit cleanly, zero otherwise.
+	PrevGeneration uint64
+	// Adopted, Removed and Kept are the names of the pins in the BPF
+	// directory, and Failed the names of the pins that failed to be
+	// removed.
+	Adopted []string
+	Removed []string
+	Kept    []string
+	Failed  []string
+}
+
+// Found returns the number of pins found.
+func (r *Report) Found() int {
+	return len(r.Adopted) + len(r.Removed) + len(r.Kept) + len(r.Failed)
+}
+
+// Crashed returns true if the previous run did not exit cleanly.
+func (r *Report) Crashed() bool {
+	return r.PrevGeneration != 0
+}
+
+// Clean cleans up the pins left in dir by the previous runs, according to
+// mode, and marks dir with the generation of the new run. adoptable returns
+// true for the names of the pins that the new run reuses. It must be called
+// before the new run pins anything.
+func Clean(dir string, mode Mode, adoptable func(name string) bool) (*Report, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Report{}
+	var markers []string
+	for _, e := range entries {
+		name := e.Name()
+		if gen, ok := parseGenerationMarker(name); ok && e.IsDir() {
+			if gen > r.PrevGeneration {
+				r.PrevGeneration = gen
+			}
+			markers = append(markers, name)
+			continue
+		}
+
+		switch {
+		case mode == ModeKeep:
+			r.Kept = append(r.Kept, name)
+		case mode == ModeAdopt && adoptable != nil && adoptable(name):
+			r.Adopted = append(r.Adopted, name)
+		default:
+			// directories are created for pins with subdirectories
+			if err := os.RemoveAll(filepath.Join(dir, name)); err != nil {
+				r.Failed = append(r.Failed, name)
+			} else {
+				r.Removed = append(r.Removed, name)
+			}
+		}
+	}
+
+	for _, m := range markers {
+		if err := os.Remove(filepath.Join(dir, m)); err != nil {
+			return r, fmt.Errorf("failed to remove generation marker: %w", err)
+		}
+	}
+	r.Generation = r.PrevGeneration + 1
+	if err := os.Mkdir(filepath.Join(dir, generationMarker(r.Generation)), 0755); err != nil {
+		return r, fmt.Errorf("failed to create generation marker: %w", err)
+	}
+
+	sort.Strings(r.Adopted)
+	sort.Strings(r.Removed)
+	sort.Strings(r.Kept)
+	sort.Strings(r.Failed)
+	return r, nil
+}
+
+// Release removes the generation markers of dir, when the agent exits cleanly.
+func Release(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	for _, e := range entries {
+		if _, ok := parseGenerationMarker(e.Name()); ok && e.IsDir() {
+			if err := os.Remove(filepath.Join(dir, e.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package stalepins
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pin(t *testing.T, dir string, names ...string) {
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, nil, 0600))
+	}
+}
+
+func entries(t *testing.T, dir string) []string {
+	des, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var ret []string
+	for _, de := range des {
+		ret = append(ret, de.Name())
+	}
+	return ret
+}
+
+func isBase(name string) bool {
+	return name == "execve_map" || name == "event_execve"
+}
+
+func TestClean(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "tetragon")
+
+	// first run
+	r, err := Clean(dir, ModeAdopt, isBase)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), r.Generation)
+	assert.False(t, r.Crashed())
+	assert.Zero(t, r.Found())
+	pin(t, dir, "execve_map", "event_execve", "gkp-sensor-1-multi_kprobe-config_map", "gkp-sensor-1/fdinstall_map")
+
+	// the first run crashes, the pins of the policies are removed
+	r, err = Clean(dir, ModeAdopt, isBase)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), r.Generation)
+	assert.Equal(t, uint64(1), r.PrevGeneration)
+	assert.True(t, r.Crashed())
+	assert.Equal(t, []string{"event_execve", "execve_map"}, r.Adopted)
+	assert.Equal(t, []string{"gkp-sensor-1", "gkp-sensor-1-multi_kprobe-config_map"}, r.Removed)
+	assert.Empty(t, r.Kept)
+	assert.Empty(t, r.Failed)
+	assert.ElementsMatch(t, []string{"event_execve", "execve_map", "generation-2"}, entries(t, dir))
+
+	// the second run crashes too
+	pin(t, dir, "gtp-sensor-1-config_map")
+	r, err = Clean(dir, ModeKeep, isBase)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), r.Generation)
+	assert.Equal(t, []string{"event_execve", "execve_map", "gtp-sensor-1-config_map"}, r.Kept)
+	assert.Equal(t, 3, r.Found())
+
+	// the third run exits cleanly
+	require.NoError(t, Release(dir))
+	r, err = Clean(dir, ModeRemove, isBase)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), r.Generation)
+	assert.False(t, r.Crashed())
+	assert.Equal(t, []string{"event_execve", "execve_map", "gtp-sensor-1-config_map"}, r.Removed)
+	assert.Equal(t, []string{"generation-1"}, entries(t, dir))
+
+	require.NoError(t, Release(dir))
+	assert.Empty(t, entries(t, dir))
+	require.NoError(t, Release(filepath.Join(dir, "missing")))
+}
+
+func TestParseMode(t *testing.T) {
+	for _, s := range []string{"adopt", "remove", "keep"} {
+		m, err := ParseMode(s)
+		require.NoError(t, err)
+		assert.Equal(t, Mode(s), m)
+	}
+	_, err := ParseMode("release")
+	assert.Error(t, err)
+}
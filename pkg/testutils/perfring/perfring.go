@@ -14,8 +14,6 @@ import (
 	"sync"
 	"testing"
 
-	"github.com/cilium/ebpf"
-	"github.com/cilium/ebpf/perf"
 	"github.com/cilium/tetragon/pkg/bpf"
 	testapi "github.com/cilium/tetragon/pkg/grpc/test"
 	"github.com/cilium/tetragon/pkg/logger"
@@ -45,7 +43,8 @@ func loopEvents(events []observer.Event, eventFn EventFn, complChecker *testsens
 	return nil
 }
 
-// ProcessEvents will open the perf ringbuffer and process events.
+// ProcessEvents will open the transport of the events (the perf ringbuffer by
+// default) and process events.
 //
 // It will complete, whenever it sees a number of MsgTestEventUnix on _all_
 // cpus or when the ctx is done.  Hence, callers need to load the test sensor
@@ -60,18 +59,15 @@ func loopEvents(events []observer.Event, eventFn EventFn, complChecker *testsens
 // If the test sensor is loaded, users can use TestCheckerMarkEnd to generate
 // the appropriate MsgTestEventUnix.
 func ProcessEvents(t *testing.T, ctx context.Context, eventFn EventFn, wgStarted *sync.WaitGroup) {
-	pinOpts := ebpf.LoadPinOptions{}
 	config := bpf.DefaultPerfEventConfig()
 
-	perfMap, err := ebpf.LoadPinnedMap(config.MapName, &pinOpts)
+	transport, err := observer.EventTransport()
 	if err != nil {
-		t.Fatalf("opening pinned map '%s' failed: %v", config.MapName, err)
+		t.Fatal(err)
 	}
-	defer perfMap.Close()
-
-	perfReader, err := perf.NewReader(perfMap, 65535)
+	eventReader, err := observer.OpenEventReader(config.MapName, transport, 65535, 0)
 	if err != nil {
-		t.Fatalf("creating perf array reader failed: %v", err)
+		t.Fatal(err)
 	}
 
 	wgStarted.Done()
@@ -96,7 +92,7 @@ func ProcessEvents(t *testing.T, ctx context.Context, eventFn EventFn, wgStarted
 				break
 			}
 
-			record, err := perfReader.Read()
+			record, err := eventReader.Read()
 			if err != nil {
 				if ctx.Err() == nil {
 					errChan <- fmt.Errorf("error reading perfring data: %v", err)
@@ -104,6 +100,11 @@ func ProcessEvents(t *testing.T, ctx context.Context, eventFn EventFn, wgStarted
 				break
 			}
 
+			if len(record.RawSample) == 0 {
+				// the record only reports lost events
+				continue
+			}
+
 			_, events, err := observer.HandlePerfData(record.RawSample)
 			if err != nil {
 				errChan <- fmt.Errorf("error handling perfring data: %v", err)
@@ -127,11 +128,11 @@ func ProcessEvents(t *testing.T, ctx context.Context, eventFn EventFn, wgStarted
 		case err := <-errChan:
 			t.Fatal(err)
 		case <-complChan:
-			perfReader.Close()
+			eventReader.Close()
 			return
 		case <-ctx.Done():
 			// Wait for context cancel.
-			perfReader.Close()
+			eventReader.Close()
 			return
 		}
 	}
@@ -125,12 +125,14 @@ func mergeInBaseSensorMaps(t *testing.T, sensorMaps []SensorMap, sensorProgs []S
 		// all programs
 		SensorMap{Name: "execve_map", Progs: []uint{0, 1, 2, 3, 4}},
 		SensorMap{Name: "tcpmon_map", Progs: []uint{0, 1, 2, 3}},
+		SensorMap{Name: "event_cgroup_stats_map", Progs: []uint{0, 1, 2, 3}},
 
 		// all but event_execve
 		SensorMap{Name: "execve_map_stats", Progs: []uint{1, 2}},
 
 		// event_execve
 		SensorMap{Name: "names_map", Progs: []uint{0}},
+		SensorMap{Name: "names_prefix_map", Progs: []uint{0}},
 		SensorMap{Name: "tg_conf_map", Progs: []uint{0}},
 
 		// event_wake_up_new_task
@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package tracepoint
+
+import (
+	"strings"
+
+	"github.com/cilium/ebpf/btf"
+	tbtf "github.com/cilium/tetragon/pkg/btf"
+)
+
+// rawArgSize is the size of an argument of a raw tracepoint. The context of
+// raw tracepoints is an array of u64 values, one for each argument of the
+// tracepoint in the kernel (TP_PROTO).
+const rawArgSize = 8
+
+// LoadBTFFormat loads the format of a tracepoint from BTF. The resulting
+// format is the one of the format file of the tracepoint in tracefs, so it
+// can be used when tracefs is not available. See btf.TracepointFields for
+// the tracepoints that are supported.
+//
+// Types that do not have an equivalent in the field types of this package
+// (e.g., structs) are kept as their BTF type.
+func (gt *Tracepoint) LoadBTFFormat(spec *btf.Spec) error {
+	fields, err := tbtf.TracepointFields(spec, gt.Subsys, gt.Event)
+	if err != nil {
+		return err
+	}
+
+	format := &Format{
+		Name:   gt.Event,
+		ID:     -1,
+		Fields: make([]FieldFormat, 0, len(fields)),
+	}
+	for _, field := range fields {
+		ty, _, signed := btfFieldType(field.Type)
+		format.Fields = append(format.Fields, FieldFormat{
+			FieldStr: field.Name,
+			Field:    &Field{Name: field.Name, Type: ty},
+			Offset:   uint(field.Offset),
+			Size:     uint(field.Size),
+			IsSigned: signed,
+		})
+	}
+	gt.Format = format
+	return nil
+}
+
+// LoadRawFormat loads the format of a tracepoint from the BTF type of its
+// raw tracepoint. Unlike LoadFormat, it does not depend on tracefs, and the
+// fields of the format are the untranslated arguments of the tracepoint: the
+// offset of the field at index i is the offset of the i-th argument in the
+// context of the raw tracepoint.
+//
+// Types that do not have an equivalent in the field types of this package
+// (e.g., structs) are kept as their BTF type.
+func (gt *Tracepoint) LoadRawFormat(spec *btf.Spec) error {
+	params, err := tbtf.RawTracepointArgs(spec, gt.Event)
+	if err != nil {
+		return err
+	}
+
+	format := &Format{
+		Name:   gt.Event,
+		ID:     -1,
+		Fields: make([]FieldFormat, 0, len(params)),
+	}
+	for i, param := range params {
+		ty, size, signed := btfFieldType(param.Type)
+		format.Fields = append(format.Fields, FieldFormat{
+			FieldStr: param.Name,
+			Field:    &Field{Name: param.Name, Type: ty},
+			Offset:   uint(i * rawArgSize),
+			Size:     size,
+			IsSigned: signed,
+		})
+	}
+	gt.Format = format
+	return nil
+}
+
+// btfIntBase returns the base of an integer type from its BTF name, using
+// the size of the type for the names that are not C integer types.
+func btfIntBase(t *btf.Int) IntTyBase {
+	switch {
+	case t.Encoding&btf.Char != 0 || strings.Contains(t.Name, "char"):
+		return IntTyChar
+	case strings.Contains(t.Name, "long long"):
+		return IntTyLongLong
+	case strings.Contains(t.Name, "long"):
+		return IntTyLong
+	case strings.Contains(t.Name, "short"):
+		return IntTyShort
+	case strings.Contains(t.Name, "int"):
+		return IntTyInt
+	}
+	switch t.Size {
+	case 1:
+		return IntTyInt8
+	case 2:
+		return IntTyInt16
+	case 4:
+		return IntTyInt32
+	}
+	return IntTyInt64
+}
+
+// btfFieldType returns the field type, the size, and the signedness of a BTF
+// type.
+func btfFieldType(ty btf.Type) (interface{}, uint, bool) {
+	switch t := ty.(type) {
+	case *btf.Typedef:
+		switch t.Name {
+		case "size_t":
+			return SizeTy{}, rawArgSize, false
+		case "pid_t":
+			return PidTy{}, 4, true
+		case "bool":
+			return BoolTy{}, 1, false
+		case "dma_addr_t":
+			return DmaAddrTy{}, rawArgSize, false
+		case "u8":
+			return IntTy{Base: IntTyInt8, Unsigned: true}, 1, false
+		case "u16":
+			return IntTy{Base: IntTyInt16, Unsigned: true}, 2, false
+		case "u32":
+			return IntTy{Base: IntTyInt32, Unsigned: true}, 4, false
+		case "u64":
+			return IntTy{Base: IntTyInt64, Unsigned: true}, 8, false
+		}
+		return btfFieldType(t.Type)
+	case *btf.Const:
+		return btfFieldType(t.Type)
+	case *btf.Volatile:
+		return btfFieldType(t.Type)
+	case *btf.Restrict:
+		return btfFieldType(t.Type)
+	case *btf.Int:
+		if t.Encoding&btf.Bool != 0 {
+			return BoolTy{}, uint(t.Size), false
+		}
+		signed := t.Encoding&btf.Signed != 0
+		return IntTy{Base: btfIntBase(t), Unsigned: !signed}, uint(t.Size), signed
+	case *btf.Enum:
+		base := IntTyInt
+		if t.Size == 8 {
+			base = IntTyLong
+		}
+		return IntTy{Base: base, Unsigned: !t.Signed}, uint(t.Size), t.Signed
+	case *btf.Pointer:
+		_, isConst := t.Target.(*btf.Const)
+		target, _, _ := btfFieldType(t.Target)
+		return PointerTy{Ty: target, Const: isConst}, rawArgSize, false
+	case *btf.Array:
+		elem, size, signed := btfFieldType(t.Type)
+		return ArrayTy{Ty: elem, Size: uint(t.Nelems)}, size * uint(t.Nelems), signed
+	case *btf.Void:
+		return VoidTy{}, 0, false
+	}
+	return ty, uint(rawArgSize), false
+}
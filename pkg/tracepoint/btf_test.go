@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package tracepoint
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cilium/ebpf/btf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracepointLoadRawFormat(t *testing.T) {
+	voidPtr := &btf.Pointer{Target: &btf.Void{}}
+	intTy := &btf.Int{Name: "int", Size: 4, Encoding: btf.Signed}
+	charPtr := &btf.Pointer{Target: &btf.Const{Type: &btf.Int{Name: "char", Size: 1, Encoding: btf.Signed}}}
+	sizeTy := &btf.Typedef{Name: "size_t", Type: &btf.Int{Name: "long unsigned int", Size: 8}}
+	taskPtr := &btf.Pointer{Target: &btf.Struct{Name: "task_struct"}}
+	proto := &btf.FuncProto{
+		Return: &btf.Void{},
+		Params: []btf.FuncParam{
+			{Name: "__data", Type: voidPtr},
+			{Name: "task", Type: taskPtr},
+			{Name: "err", Type: intTy},
+			{Name: "name", Type: charPtr},
+			{Name: "len", Type: sizeTy},
+		},
+	}
+	b, err := btf.NewBuilder([]btf.Type{
+		&btf.Typedef{Name: "btf_trace_foo", Type: &btf.Pointer{Target: proto}},
+	})
+	require.NoError(t, err)
+	raw, err := b.Marshal(nil, nil)
+	require.NoError(t, err)
+	spec, err := btf.LoadSpecFromReader(bytes.NewReader(raw))
+	require.NoError(t, err)
+
+	tp := Tracepoint{Subsys: "bar", Event: "foo"}
+	require.NoError(t, tp.LoadRawFormat(spec))
+	fields := tp.Format.Fields
+	require.Len(t, fields, 4)
+
+	assert.Equal(t, "task", fields[0].Field.Name)
+	assert.Equal(t, uint(0), fields[0].Offset)
+	ptr, ok := fields[0].Field.Type.(PointerTy)
+	require.True(t, ok)
+	assert.IsType(t, &btf.Struct{}, ptr.Ty)
+
+	assert.Equal(t, uint(8), fields[1].Offset)
+	assert.Equal(t, IntTy{Base: IntTyInt}, fields[1].Field.Type)
+	assert.Equal(t, uint(4), fields[1].Size)
+	assert.True(t, fields[1].IsSigned)
+
+	assert.Equal(t, uint(16), fields[2].Offset)
+	assert.Equal(t, PointerTy{Ty: IntTy{Base: IntTyChar}, Const: true}, fields[2].Field.Type)
+
+	assert.Equal(t, uint(24), fields[3].Offset)
+	assert.Equal(t, SizeTy{}, fields[3].Field.Type)
+
+	tp = Tracepoint{Subsys: "bar", Event: "baz"}
+	assert.Error(t, tp.LoadRawFormat(spec))
+}
+
+func TestTracepointLoadBTFFormat(t *testing.T) {
+	u16 := &btf.Int{Name: "short unsigned int", Size: 2}
+	u8 := &btf.Int{Name: "unsigned char", Size: 1}
+	intTy := &btf.Int{Name: "int", Size: 4, Encoding: btf.Signed}
+	char := &btf.Int{Name: "char", Size: 1, Encoding: btf.Signed}
+	entry := &btf.Struct{Name: "trace_entry", Size: 8, Members: []btf.Member{
+		{Name: "type", Type: u16, Offset: 0},
+		{Name: "flags", Type: u8, Offset: 16},
+		{Name: "preempt_count", Type: u8, Offset: 24},
+		{Name: "pid", Type: intTy, Offset: 32},
+	}}
+	raw := &btf.Struct{Name: "trace_event_raw_foo", Size: 32, Members: []btf.Member{
+		{Name: "ent", Type: entry, Offset: 0},
+		{Name: "comm", Type: &btf.Array{Index: intTy, Type: char, Nelems: 16}, Offset: 64},
+		{Name: "prio", Type: intTy, Offset: 192},
+		{Name: "__data", Type: &btf.Array{Index: intTy, Type: char, Nelems: 0}, Offset: 224},
+	}}
+	b, err := btf.NewBuilder([]btf.Type{raw})
+	require.NoError(t, err)
+	buf, err := b.Marshal(nil, nil)
+	require.NoError(t, err)
+	spec, err := btf.LoadSpecFromReader(bytes.NewReader(buf))
+	require.NoError(t, err)
+
+	tp := Tracepoint{Subsys: "bar", Event: "foo"}
+	require.NoError(t, tp.LoadBTFFormat(spec))
+	fields := tp.Format.Fields
+	require.Len(t, fields, 6)
+
+	assert.Equal(t, "common_type", fields[0].Field.Name)
+	assert.Equal(t, IntTy{Base: IntTyShort, Unsigned: true}, fields[0].Field.Type)
+	assert.Equal(t, "common_pid", fields[3].Field.Name)
+	assert.Equal(t, uint(4), fields[3].Offset)
+	assert.True(t, fields[3].IsSigned)
+
+	assert.Equal(t, "comm", fields[4].Field.Name)
+	assert.Equal(t, ArrayTy{Ty: IntTy{Base: IntTyChar}, Size: 16}, fields[4].Field.Type)
+	assert.Equal(t, uint(8), fields[4].Offset)
+	assert.Equal(t, uint(16), fields[4].Size)
+
+	assert.Equal(t, "prio", fields[5].Field.Name)
+	assert.Equal(t, uint(24), fields[5].Offset)
+	assert.Equal(t, uint(4), fields[5].Size)
+}
@@ -24,9 +24,9 @@ func TestTracepointLoadFormat(t *testing.T) {
 	}
 
 	// the standard does not specify if char is signed or not
-	// historically, it's signed on amd64 and unsigned on arm64
+	// historically, it's signed on amd64 and unsigned on arm64 and riscv64
 	isCharSigned := true
-	if runtime.GOARCH == "arm64" {
+	if runtime.GOARCH == "arm64" || runtime.GOARCH == "riscv64" {
 		isCharSigned = false
 	}
 
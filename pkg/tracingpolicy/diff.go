@@ -0,0 +1,271 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package tracingpolicy
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+)
+
+// ChangeType is the type of a change between two tracing policy specs.
+type ChangeType int
+
+const (
+	ChangeAdded ChangeType = iota
+	ChangeRemoved
+	ChangeModified
+)
+
+func (t ChangeType) String() string {
+	switch t {
+	case ChangeAdded:
+		return "+"
+	case ChangeRemoved:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+// Change is a semantic difference between two tracing policy specs.
+type Change struct {
+	Type ChangeType
+	// Path identifies the changed element, e.g. "kprobe sys_write" for a
+	// hook or "kprobe sys_write: selectors[0].matchActions" for a field of
+	// a hook.
+	Path string
+	// Old and New are the compact JSON encoding of the element before and
+	// after the change. Old is empty for added elements, New is empty for
+	// removed elements.
+	Old string
+	New string
+}
+
+func (c Change) String() string {
+	switch c.Type {
+	case ChangeAdded, ChangeRemoved:
+		return fmt.Sprintf("%s %s", c.Type, c.Path)
+	default:
+		return fmt.Sprintf("%s %s\n    - %s\n    + %s", c.Type, c.Path, c.Old, c.New)
+	}
+}
+
+// DiffSpecs returns the semantic differences between the old and new specs:
+// the hooks added or removed and the changes of the fields (arguments,
+// selectors, actions...) of the hooks of both specs. Hooks are identified by
+// their attachment point, e.g. the call of kprobes.
+func DiffSpecs(old, new *v1alpha1.TracingPolicySpec) []Change {
+	var changes []Change
+	diffStruct("", reflect.ValueOf(old).Elem(), reflect.ValueOf(new).Elem(), &changes)
+	return changes
+}
+
+// hookKey returns the key identifying a hook of a policy, or false if v is
+// not a hook.
+func hookKey(v interface{}) (string, bool) {
+	switch h := v.(type) {
+	case v1alpha1.KProbeSpec:
+		if len(h.Calls) > 0 {
+			return "kprobe " + strings.Join(h.Calls, ","), true
+		}
+		return "kprobe " + h.Call, true
+	case v1alpha1.TracepointSpec:
+		return fmt.Sprintf("tracepoint %s/%s", h.Subsystem, h.Event), true
+	case v1alpha1.UProbeSpec:
+		key := "uprobe " + h.Path
+		if h.Usdt != nil {
+			key += fmt.Sprintf(" usdt:%s:%s", h.Usdt.Provider, h.Usdt.Name)
+		}
+		if h.Symbol != "" {
+			key += " " + h.Symbol
+		}
+		if h.Offset != nil {
+			key += fmt.Sprintf("+0x%x", *h.Offset)
+		}
+		return key, true
+	case v1alpha1.LsmHookSpec:
+		return "lsmhook " + h.Hook, true
+	case v1alpha1.PerfEventSpec:
+		return fmt.Sprintf("perfevent %s/%s", h.Type, h.Event), true
+	case v1alpha1.ListSpec:
+		return "list " + h.Name, true
+	case v1alpha1.KillerSpec:
+		return "killer " + strings.Join(h.Syscalls, ","), true
+	}
+	return "", false
+}
+
+// jsonName returns the JSON name of a struct field.
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+func joinPath(path, name string) string {
+	switch {
+	case path == "":
+		return name
+	case strings.HasSuffix(path, "]"), strings.Contains(path, ": "):
+		return path + "." + name
+	default:
+		return path + ": " + name
+	}
+}
+
+// diffStruct compares the fields of the old and new structs.
+func diffStruct(path string, old, new reflect.Value, changes *[]Change) {
+	for i := 0; i < old.NumField(); i++ {
+		f := old.Type().Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := joinPath(path, jsonName(f))
+		oldField, newField := old.Field(i), new.Field(i)
+
+		if f.Type.Kind() == reflect.Slice {
+			if _, ok := hookKey(reflect.Zero(f.Type.Elem()).Interface()); ok {
+				diffHooks(oldField, newField, changes)
+				continue
+			}
+			if f.Type.Elem() == reflect.TypeOf(v1alpha1.KProbeSelector{}) {
+				diffSelectors(name, oldField, newField, changes)
+				continue
+			}
+		}
+
+		oldStr, newStr := compactJSON(oldField.Interface()), compactJSON(newField.Interface())
+		if oldStr != newStr {
+			*changes = append(*changes, Change{Type: ChangeModified, Path: name, Old: oldStr, New: newStr})
+		}
+	}
+}
+
+// hookKeys returns the keys of the hooks of a slice. If several hooks share
+// the same attachment point, their keys are suffixed by their occurrence.
+func hookKeys(hooks reflect.Value) ([]string, map[string]reflect.Value) {
+	keys := make([]string, 0, hooks.Len())
+	values := make(map[string]reflect.Value, hooks.Len())
+	for i := 0; i < hooks.Len(); i++ {
+		key, _ := hookKey(hooks.Index(i).Interface())
+		if _, exists := values[key]; exists {
+			for n := 2; ; n++ {
+				k := fmt.Sprintf("%s#%d", key, n)
+				if _, exists := values[k]; !exists {
+					key = k
+					break
+				}
+			}
+		}
+		keys = append(keys, key)
+		values[key] = hooks.Index(i)
+	}
+	return keys, values
+}
+
+// diffHooks matches the old and new hooks by their key and compares them.
+func diffHooks(old, new reflect.Value, changes *[]Change) {
+	oldKeys, oldHooks := hookKeys(old)
+	newKeys, newHooks := hookKeys(new)
+
+	for _, key := range oldKeys {
+		newHook, ok := newHooks[key]
+		if !ok {
+			*changes = append(*changes, Change{Type: ChangeRemoved, Path: key,
+				Old: compactJSON(oldHooks[key].Interface())})
+			continue
+		}
+		diffStruct(key, oldHooks[key], newHook, changes)
+	}
+	for _, key := range newKeys {
+		if _, ok := oldHooks[key]; !ok {
+			*changes = append(*changes, Change{Type: ChangeAdded, Path: key,
+				New: compactJSON(newHooks[key].Interface())})
+		}
+	}
+}
+
+// diffSelectors compares the selectors by their index, so that each filter
+// and action change is reported separately.
+func diffSelectors(path string, old, new reflect.Value, changes *[]Change) {
+	for i := 0; i < old.Len() || i < new.Len(); i++ {
+		name := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= new.Len():
+			*changes = append(*changes, Change{Type: ChangeRemoved, Path: name,
+				Old: compactJSON(old.Index(i).Interface())})
+		case i >= old.Len():
+			*changes = append(*changes, Change{Type: ChangeAdded, Path: name,
+				New: compactJSON(new.Index(i).Interface())})
+		default:
+			diffStruct(name, old.Index(i), new.Index(i), changes)
+		}
+	}
+}
+
+// compactJSON returns the JSON encoding of v without its zero values, so
+// that unset and empty fields compare equal and are not displayed.
+func compactJSON(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return string(b)
+	}
+	generic = dropZero(generic)
+	if generic == nil {
+		return "null"
+	}
+	b, err = json.Marshal(generic)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+func dropZero(v interface{}) interface{} {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		for k, val := range x {
+			if val = dropZero(val); val == nil {
+				delete(x, k)
+			} else {
+				x[k] = val
+			}
+		}
+		if len(x) == 0 {
+			return nil
+		}
+		return x
+	case []interface{}:
+		if len(x) == 0 {
+			return nil
+		}
+		for i := range x {
+			x[i] = dropZero(x[i])
+		}
+		return x
+	case string:
+		if x == "" {
+			return nil
+		}
+	case bool:
+		if !x {
+			return nil
+		}
+	case float64:
+		if x == 0 {
+			return nil
+		}
+	}
+	return v
+}
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package tracingpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var diffOld = `
+apiVersion: cilium.io/v1alpha1
+kind: TracingPolicy
+metadata:
+  name: "diff"
+spec:
+  kprobes:
+  - call: "sys_write"
+    syscall: true
+    args:
+    - index: 0
+      type: "int"
+    selectors:
+    - matchArgs:
+      - index: 0
+        operator: "Equal"
+        values:
+        - "1"
+      matchActions:
+      - action: Sigkill
+  - call: "security_file_open"
+    args:
+    - index: 0
+      type: "file"
+  tracepoints:
+  - subsystem: "syscalls"
+    event: "sys_enter_openat"
+`
+
+var diffNew = `
+apiVersion: cilium.io/v1alpha1
+kind: TracingPolicy
+metadata:
+  name: "diff"
+spec:
+  kprobes:
+  - call: "sys_write"
+    syscall: true
+    args:
+    - index: 0
+      type: "int"
+    selectors:
+    - matchArgs:
+      - index: 0
+        operator: "Equal"
+        values:
+        - "1"
+      matchActions:
+      - action: Post
+    - matchBinaries:
+      - operator: "In"
+        values:
+        - "/usr/bin/cat"
+  - call: "security_file_open"
+    args:
+    - index: 0
+      type: "file"
+  - call: "security_bprm_check"
+    args:
+    - index: 0
+      type: "path"
+`
+
+func TestDiffSpecs(t *testing.T) {
+	oldTp, err := FromYAML(diffOld)
+	require.NoError(t, err)
+	newTp, err := FromYAML(diffNew)
+	require.NoError(t, err)
+
+	assert.Empty(t, DiffSpecs(oldTp.TpSpec(), oldTp.TpSpec()))
+
+	changes := DiffSpecs(oldTp.TpSpec(), newTp.TpSpec())
+	require.Len(t, changes, 4)
+
+	assert.Equal(t, ChangeModified, changes[0].Type)
+	assert.Equal(t, "kprobe sys_write: selectors[0].matchActions", changes[0].Path)
+	assert.Equal(t, `[{"action":"Sigkill"}]`, changes[0].Old)
+	assert.Equal(t, `[{"action":"Post"}]`, changes[0].New)
+
+	assert.Equal(t, ChangeAdded, changes[1].Type)
+	assert.Equal(t, "kprobe sys_write: selectors[1]", changes[1].Path)
+
+	assert.Equal(t, ChangeAdded, changes[2].Type)
+	assert.Equal(t, "kprobe security_bprm_check", changes[2].Path)
+
+	assert.Equal(t, ChangeRemoved, changes[3].Type)
+	assert.Equal(t, "tracepoint syscalls/sys_enter_openat", changes[3].Path)
+}
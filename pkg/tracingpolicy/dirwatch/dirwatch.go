@@ -0,0 +1,320 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package dirwatch loads the tracing policies of a directory and keeps them in
+// sync with the files of the directory: new files are loaded, modified files
+// are reloaded and removed files are unloaded.
+package dirwatch
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/sensors"
+	"github.com/cilium/tetragon/pkg/tracingpolicy"
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+)
+
+// maxDepth is the depth of the subdirectories whose files are loaded.
+const maxDepth = 1
+
+// settleDelay is the time to wait for the changes of the directory to settle
+// before syncing the policies, since editors usually generate several events
+// for a single change.
+const settleDelay = 500 * time.Millisecond
+
+// Files returns the tracing policy files of a directory and of its
+// subdirectories, up to maxDepth.
+func Files(dir string) ([]string, error) {
+	var files []string
+	tpFS := os.DirFS(dir)
+
+	err := fs.WalkDir(tpFS, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if strings.Count(path, string(os.PathSeparator)) >= maxDepth {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		file := filepath.Join(dir, path)
+		st, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+
+		if st.Mode().IsRegular() == false {
+			return nil
+		}
+
+		files = append(files, file)
+		return nil
+	})
+
+	return files, err
+}
+
+// policyManager is implemented by the sensor manager.
+type policyManager interface {
+	AddTracingPolicy(ctx context.Context, tp tracingpolicy.TracingPolicy) error
+	DeleteTracingPolicy(ctx context.Context, name string) error
+	UpdateTracingPolicySelectors(ctx context.Context, tp tracingpolicy.TracingPolicy) error
+}
+
+// policyFile is the state of a policy file.
+type policyFile struct {
+	hash [sha256.Size]byte
+	// policy loaded from the file, nil if the file is not a valid policy or
+	// the policy failed to load
+	tp tracingpolicy.TracingPolicy
+}
+
+// Watcher keeps the tracing policies of a directory in sync with its files.
+type Watcher struct {
+	dir     string
+	mgr     policyManager
+	files   map[string]*policyFile
+	watcher *fsnotify.Watcher
+}
+
+// New returns a watcher of the tracing policies of dir.
+func New(dir string, mgr policyManager) *Watcher {
+	return &Watcher{
+		dir:   dir,
+		mgr:   mgr,
+		files: make(map[string]*policyFile),
+	}
+}
+
+// Watch starts watching the directory. It is called before Load, so that the
+// changes made while the policies are loaded are synced by Run.
+func (w *Watcher) Watch() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	w.watcher = watcher
+	w.watchDirs()
+	return nil
+}
+
+// Load loads the policies of files, the files of the directory. Unlike the
+// later changes of the directory, that are only logged, it fails on the first
+// policy that fails.
+func (w *Watcher) Load(ctx context.Context, files []string) error {
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		tp, err := tracingpolicy.FromYAML(string(data))
+		if err != nil {
+			return err
+		}
+		if err := w.mgr.AddTracingPolicy(ctx, tp); err != nil {
+			return err
+		}
+		w.files[file] = &policyFile{hash: sha256.Sum256(data), tp: tp}
+		logPolicy(file, tp).Info("Added TracingPolicy with success")
+	}
+	return nil
+}
+
+// Run syncs the policies with the changes of the directory until ctx is done.
+// It starts watching the directory if Watch was not called.
+func (w *Watcher) Run(ctx context.Context) error {
+	if w.watcher == nil {
+		if err := w.Watch(); err != nil {
+			return err
+		}
+	}
+	watcher := w.watcher
+	defer watcher.Close()
+
+	// the timer is armed by the events of the directory
+	timer := time.NewTimer(settleDelay)
+	if !timer.Stop() {
+		<-timer.C
+	}
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			logger.GetLogger().WithField("event", ev).Debug("TracingPolicy directory changed")
+			timer.Reset(settleDelay)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			logger.GetLogger().WithError(err).Warn("Failed to watch TracingPolicy directory")
+			// events may have been lost, sync the directory
+			timer.Reset(settleDelay)
+		case <-timer.C:
+			// subdirectories may have been added or recreated
+			w.watchDirs()
+			w.sync(ctx)
+		}
+	}
+}
+
+// watchDirs adds the directory and its subdirectories to the watcher. Adding
+// a directory that is already watched is a no-op.
+func (w *Watcher) watchDirs() {
+	dirs := []string{w.dir}
+	if entries, err := os.ReadDir(w.dir); err == nil {
+		for _, e := range entries {
+			if e.IsDir() {
+				dirs = append(dirs, filepath.Join(w.dir, e.Name()))
+			}
+		}
+	}
+	for _, dir := range dirs {
+		if err := w.watcher.Add(dir); err != nil {
+			logger.GetLogger().WithError(err).WithField("dir", dir).Warn("Failed to watch TracingPolicy directory")
+		}
+	}
+}
+
+// sync loads the policies of the new and modified files of the directory, and
+// unloads the policies of the removed files.
+func (w *Watcher) sync(ctx context.Context) {
+	files, err := Files(w.dir)
+	if err != nil {
+		logger.GetLogger().WithError(err).WithField("dir", w.dir).Warn("Failed to list TracingPolicy directory")
+		return
+	}
+
+	present := make(map[string]struct{}, len(files))
+	for _, file := range files {
+		present[file] = struct{}{}
+	}
+	for file, pf := range w.files {
+		if _, ok := present[file]; ok {
+			continue
+		}
+		// the removal is retried by the next sync
+		if err := w.unload(ctx, file, pf); err != nil {
+			continue
+		}
+		delete(w.files, file)
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			logger.GetLogger().WithError(err).WithField("TracingPolicy", file).Warn("Failed to read TracingPolicy")
+			continue
+		}
+		hash := sha256.Sum256(data)
+		pf, ok := w.files[file]
+		if ok && bytes.Equal(pf.hash[:], hash[:]) {
+			continue
+		}
+		if !ok {
+			pf = &policyFile{}
+			w.files[file] = pf
+		}
+		w.reload(ctx, file, pf, data, hash)
+	}
+}
+
+// unload deletes the policy of a file. A policy that is not loaded anymore,
+// e.g. deleted with tetra, is considered unloaded.
+func (w *Watcher) unload(ctx context.Context, file string, pf *policyFile) error {
+	if pf.tp == nil {
+		return nil
+	}
+	log := logPolicy(file, pf.tp)
+	err := w.mgr.DeleteTracingPolicy(ctx, pf.tp.TpName())
+	if err != nil && !errors.Is(err, sensors.ErrTracingPolicyNotFound) {
+		log.WithError(err).Warn("Failed to delete TracingPolicy")
+		return err
+	}
+	pf.tp = nil
+	log.Info("Deleted TracingPolicy with success")
+	return nil
+}
+
+// reload replaces the policy of a file with the policy of its new data, of
+// the given hash. The selectors of the previous policy are updated in place
+// when only they changed, so that no event is lost. Otherwise, the previous
+// policy is deleted and the new one added. The previous policy is kept if the
+// new policy is invalid or if the previous one fails to be deleted, and
+// restored if the new one fails to load.
+func (w *Watcher) reload(ctx context.Context, file string, pf *policyFile, data []byte, hash [sha256.Size]byte) {
+	tp, err := tracingpolicy.FromYAML(string(data))
+	if err != nil {
+		pf.hash = hash
+		logger.GetLogger().WithError(err).WithField("TracingPolicy", file).Warn("Invalid TracingPolicy, keeping the previous version of the file")
+		return
+	}
+
+	log := logPolicy(file, tp)
+	old := pf.tp
+	if old != nil && old.TpName() == tp.TpName() {
+		err := w.mgr.UpdateTracingPolicySelectors(ctx, tp)
+		if err == nil {
+			pf.hash, pf.tp = hash, tp
+			log.Info("Updated TracingPolicy selectors with success")
+			return
+		}
+		if !errors.Is(err, sensors.ErrTracingPolicyNotUpdatable) && !errors.Is(err, sensors.ErrTracingPolicyNotFound) {
+			pf.hash = hash
+			log.WithError(err).Warn("Failed to update TracingPolicy, keeping the previous version")
+			return
+		}
+		log.WithError(err).Debug("TracingPolicy cannot be updated in place, deleting and adding it")
+	}
+
+	// the reload is retried by the next sync, keep the previous hash
+	if err := w.unload(ctx, file, pf); err != nil {
+		return
+	}
+	pf.hash = hash
+
+	if err := w.mgr.AddTracingPolicy(ctx, tp); err != nil {
+		log.WithError(err).Warn("Failed to add TracingPolicy")
+		if old == nil {
+			return
+		}
+		if err := w.mgr.AddTracingPolicy(ctx, old); err != nil {
+			logPolicy(file, old).WithError(err).Warn("Failed to restore the previous version of TracingPolicy")
+			return
+		}
+		pf.tp = old
+		return
+	}
+	pf.tp = tp
+	log.Info("Added TracingPolicy with success")
+}
+
+func logPolicy(file string, tp tracingpolicy.TracingPolicy) logrus.FieldLogger {
+	namespace := ""
+	if tpNs, ok := tp.(tracingpolicy.TracingPolicyNamespaced); ok {
+		namespace = tpNs.TpNamespace()
+	}
+	return logger.GetLogger().WithFields(logrus.Fields{
+		"TracingPolicy":      file,
+		"metadata.namespace": namespace,
+		"metadata.name":      tp.TpName(),
+	})
+}
@@ -0,0 +1,249 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package dirwatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/pkg/sensors"
+	"github.com/cilium/tetragon/pkg/tracingpolicy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type dummyManager struct {
+	mu sync.Mutex
+	// loaded policies and the calls of their kprobes
+	policies map[string]string
+	// names of the policies that fail to load
+	failing map[string]struct{}
+	// names of the policies that fail to be deleted
+	failingDelete map[string]struct{}
+	// number of the in place updates
+	updates int
+}
+
+func newDummyManager() *dummyManager {
+	return &dummyManager{
+		policies:      make(map[string]string),
+		failing:       make(map[string]struct{}),
+		failingDelete: make(map[string]struct{}),
+	}
+}
+
+func (m *dummyManager) AddTracingPolicy(_ context.Context, tp tracingpolicy.TracingPolicy) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	name := tp.TpName()
+	if _, ok := m.policies[name]; ok {
+		return fmt.Errorf("policy %s already exists", name)
+	}
+	call := tp.TpSpec().KProbes[0].Call
+	if _, ok := m.failing[call]; ok {
+		return errors.New("failed to load policy")
+	}
+	m.policies[name] = call
+	return nil
+}
+
+func (m *dummyManager) DeleteTracingPolicy(_ context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.policies[name]; !ok {
+		return fmt.Errorf("%w: %s", sensors.ErrTracingPolicyNotFound, name)
+	}
+	if _, ok := m.failingDelete[name]; ok {
+		return errors.New("failed to delete policy")
+	}
+	delete(m.policies, name)
+	return nil
+}
+
+// UpdateTracingPolicySelectors updates the policies whose call did not change,
+// as only the selectors can be updated in place.
+func (m *dummyManager) UpdateTracingPolicySelectors(_ context.Context, tp tracingpolicy.TracingPolicy) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	name := tp.TpName()
+	call, ok := m.policies[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", sensors.ErrTracingPolicyNotFound, name)
+	}
+	if call != tp.TpSpec().KProbes[0].Call {
+		return fmt.Errorf("%w: %s", sensors.ErrTracingPolicyNotUpdatable, name)
+	}
+	m.updates++
+	return nil
+}
+
+func writePolicy(t *testing.T, file, name, call string, pids ...string) {
+	data := fmt.Sprintf(`apiVersion: cilium.io/v1alpha1
+kind: TracingPolicy
+metadata:
+  name: "%s"
+spec:
+  kprobes:
+  - call: "%s"
+    syscall: false
+`, name, call)
+	if len(pids) > 0 {
+		data += fmt.Sprintf(`    selectors:
+    - matchPIDs:
+      - operator: In
+        values: [%s]
+`, strings.Join(pids, ", "))
+	}
+	require.NoError(t, os.WriteFile(file, []byte(data), 0644))
+}
+
+func TestWatcherSync(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0755))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sub", "subsub"), 0755))
+
+	writePolicy(t, filepath.Join(dir, "a.yaml"), "a", "fd_install")
+	writePolicy(t, filepath.Join(dir, "sub", "b.yaml"), "b", "security_file_open")
+	// too deep
+	writePolicy(t, filepath.Join(dir, "sub", "subsub", "c.yaml"), "c", "security_file_open")
+
+	files, err := Files(dir)
+	require.NoError(t, err)
+	sort.Strings(files)
+	assert.Equal(t, []string{filepath.Join(dir, "a.yaml"), filepath.Join(dir, "sub", "b.yaml")}, files)
+
+	mgr := newDummyManager()
+	w := New(dir, mgr)
+	require.NoError(t, w.Load(ctx, files))
+	assert.Equal(t, map[string]string{"a": "fd_install", "b": "security_file_open"}, mgr.policies)
+
+	// new, modified and removed files
+	writePolicy(t, filepath.Join(dir, "d.yaml"), "d", "tcp_connect")
+	writePolicy(t, filepath.Join(dir, "a.yaml"), "a", "tcp_close")
+	require.NoError(t, os.Remove(filepath.Join(dir, "sub", "b.yaml")))
+	w.sync(ctx)
+	assert.Equal(t, map[string]string{"a": "tcp_close", "d": "tcp_connect"}, mgr.policies)
+
+	// invalid policies keep the previous version
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"), []byte("invalid"), 0644))
+	w.sync(ctx)
+	assert.Equal(t, map[string]string{"a": "tcp_close", "d": "tcp_connect"}, mgr.policies)
+
+	// policies that fail to load are restored
+	mgr.failing["sys_bpf"] = struct{}{}
+	writePolicy(t, filepath.Join(dir, "d.yaml"), "d", "sys_bpf")
+	w.sync(ctx)
+	assert.Equal(t, map[string]string{"a": "tcp_close", "d": "tcp_connect"}, mgr.policies)
+
+	// renamed policies
+	writePolicy(t, filepath.Join(dir, "d.yaml"), "e", "tcp_connect")
+	w.sync(ctx)
+	assert.Equal(t, map[string]string{"a": "tcp_close", "e": "tcp_connect"}, mgr.policies)
+
+	// policies whose selectors changed are updated in place
+	writePolicy(t, filepath.Join(dir, "d.yaml"), "e", "tcp_connect", "1")
+	w.sync(ctx)
+	assert.Equal(t, 1, mgr.updates)
+	assert.Equal(t, map[string]string{"a": "tcp_close", "e": "tcp_connect"}, mgr.policies)
+
+	// policies that fail to be deleted are kept, and reloaded by the next
+	// sync
+	mgr.failingDelete["e"] = struct{}{}
+	writePolicy(t, filepath.Join(dir, "d.yaml"), "e", "tcp_sendmsg")
+	w.sync(ctx)
+	assert.Equal(t, map[string]string{"a": "tcp_close", "e": "tcp_connect"}, mgr.policies)
+	delete(mgr.failingDelete, "e")
+	w.sync(ctx)
+	assert.Equal(t, map[string]string{"a": "tcp_close", "e": "tcp_sendmsg"}, mgr.policies)
+
+	// unchanged files are not reloaded
+	delete(mgr.policies, "e")
+	w.sync(ctx)
+	assert.Equal(t, map[string]string{"a": "tcp_close"}, mgr.policies)
+
+	// policies deleted meanwhile are added again
+	writePolicy(t, filepath.Join(dir, "d.yaml"), "e", "tcp_sendmsg", "2")
+	w.sync(ctx)
+	assert.Equal(t, map[string]string{"a": "tcp_close", "e": "tcp_sendmsg"}, mgr.policies)
+}
+
+func TestWatcherWatchBeforeLoad(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dir := t.TempDir()
+
+	mgr := newDummyManager()
+	w := New(dir, mgr)
+	require.NoError(t, w.Watch())
+	files, err := Files(dir)
+	require.NoError(t, err)
+	require.NoError(t, w.Load(ctx, files))
+
+	// the file is added after the policies were listed, before Run
+	writePolicy(t, filepath.Join(dir, "a.yaml"), "a", "fd_install")
+	done := make(chan error)
+	go func() {
+		done <- w.Run(ctx)
+	}()
+	require.Eventually(t, func() bool {
+		mgr.mu.Lock()
+		defer mgr.mu.Unlock()
+		_, ok := mgr.policies["a"]
+		return ok
+	}, 10*time.Second, 100*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestWatcherRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dir := t.TempDir()
+
+	mgr := newDummyManager()
+	w := New(dir, mgr)
+	done := make(chan error)
+	go func() {
+		done <- w.Run(ctx)
+	}()
+
+	loaded := func(name string) func() bool {
+		return func() bool {
+			mgr.mu.Lock()
+			defer mgr.mu.Unlock()
+			_, ok := mgr.policies[name]
+			return ok
+		}
+	}
+
+	// the watcher may not be watching yet, rewrite the file until it is
+	// loaded
+	require.Eventually(t, func() bool {
+		writePolicy(t, filepath.Join(dir, "a.yaml"), "a", "fd_install")
+		return loaded("a")()
+	}, 10*time.Second, time.Second)
+
+	// new subdirectories are watched
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0755))
+	require.Eventually(t, func() bool {
+		writePolicy(t, filepath.Join(dir, "sub", "b.yaml"), "b", "fd_install")
+		return loaded("b")()
+	}, 10*time.Second, time.Second)
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "a.yaml")))
+	require.Eventually(t, func() bool { return !loaded("a")() }, 10*time.Second, 100*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
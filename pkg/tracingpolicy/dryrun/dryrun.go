@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package dryrun compiles tracing policies against the running kernel without
+// loading them. On top of the validation of the policy specs, the sensors of
+// the policies are built, which compiles their selectors and checks their
+// argument types, BTF and kernel features, and their hooks are resolved
+// against the kernel symbols.
+package dryrun
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cilium/tetragon/pkg/arch"
+	"github.com/cilium/tetragon/pkg/btf"
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"github.com/cilium/tetragon/pkg/ksyms"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/policyfilter"
+	"github.com/cilium/tetragon/pkg/sensors"
+	"github.com/cilium/tetragon/pkg/tracepoint"
+	"github.com/cilium/tetragon/pkg/tracingpolicy"
+
+	// register the policy handlers of the tracing sensors
+	_ "github.com/cilium/tetragon/pkg/sensors/tracing"
+)
+
+// Compiler compiles tracing policies against the running kernel.
+type Compiler struct {
+	// kernel symbols, nil if they are not available
+	ks *ksyms.Ksyms
+}
+
+// NewCompiler returns a compiler of tracing policies. lib is the directory of
+// the BPF objects and BTF files of Tetragon, and btfPath the BTF of the
+// kernel, discovered if empty.
+func NewCompiler(lib, btfPath string) (*Compiler, error) {
+	if err := btf.InitCachedBTF(lib, btfPath); err != nil {
+		return nil, err
+	}
+
+	// kernel symbols are only used to resolve hooks, the resolution is
+	// skipped if they are not available.
+	ks, err := ksyms.KernelSymbols()
+	if err != nil {
+		logger.GetLogger().WithError(err).Warn("Failed to read kernel symbols, hooks will not be resolved")
+		ks = nil
+	}
+	return &Compiler{ks: ks}, nil
+}
+
+// ValidateFile validates the tracing policy of a file, as
+// tracingpolicy.ValidateFile does, and compiles it if it is valid.
+func (c *Compiler) ValidateFile(path string) (tracingpolicy.TracingPolicy, *tracingpolicy.ValidationReport) {
+	return c.compileValid(tracingpolicy.ValidateFile(path))
+}
+
+// Validate validates a tracing policy, as tracingpolicy.Validate does, and
+// compiles it if it is valid. The returned policy is nil if the policy is
+// invalid.
+func (c *Compiler) Validate(file string, data []byte) (tracingpolicy.TracingPolicy, *tracingpolicy.ValidationReport) {
+	return c.compileValid(tracingpolicy.Validate(file, data))
+}
+
+func (c *Compiler) compileValid(tp tracingpolicy.TracingPolicy, report *tracingpolicy.ValidationReport) (tracingpolicy.TracingPolicy, *tracingpolicy.ValidationReport) {
+	if tp == nil {
+		return nil, report
+	}
+	c.Compile(tp, report)
+	if !report.Valid {
+		return nil, report
+	}
+	return tp, report
+}
+
+// Compile builds the sensors of a policy without loading them, and resolves
+// its hooks. The errors and warnings are added to report, whose hooks must be
+// the ones of the policy, as set by tracingpolicy.Validate.
+func (c *Compiler) Compile(tp tracingpolicy.TracingPolicy, report *tracingpolicy.ValidationReport) {
+	sens, err := sensors.SensorsFromPolicy(tp, policyfilter.NoFilterID)
+	if err != nil {
+		report.AddError("", err)
+	}
+	for _, s := range sens {
+		report.Cost.Programs += len(s.Progs)
+		report.Cost.Maps += len(s.Maps)
+	}
+	if c.ks != nil {
+		c.resolveHooks(tp.TpSpec(), report)
+	}
+}
+
+// resolveHooks sets the targets of the hooks of the report. The hooks of the
+// report are in the order of the spec: kprobes, tracepoints, uprobes, then
+// LSM hooks. Hooks that are not found are errors, unless the policy can be
+// partially loaded.
+func (c *Compiler) resolveHooks(spec *v1alpha1.TracingPolicySpec, report *tracingpolicy.ValidationReport) {
+	i := 0
+	next := func() *tracingpolicy.HookReport {
+		hr := &report.Hooks[i]
+		i++
+		return hr
+	}
+	notFound := func(hr *tracingpolicy.HookReport, what string) {
+		msg := fmt.Sprintf("%s %s not found on this kernel", what, hr.Target)
+		if spec.PartialLoad {
+			report.AddWarning(hr.Hook, msg+", the hook will be skipped")
+		} else {
+			report.AddError(hr.Hook, errors.New(msg))
+		}
+	}
+
+	for _, kp := range spec.KProbes {
+		hr := next()
+		// lists, calls and patterns are resolved when loading the policy
+		if strings.HasPrefix(kp.Call, "list:") || len(kp.Calls) > 0 || strings.ContainsAny(kp.Call, "*?[") {
+			continue
+		}
+		hr.Target = kp.Call
+		if kp.Syscall {
+			if call, err := arch.AddSyscallPrefix(kp.Call); err == nil {
+				hr.Target = call
+			}
+		}
+		hr.Resolved = c.ks.IsFunction(hr.Target)
+		if !hr.Resolved {
+			notFound(hr, "function")
+		}
+	}
+	for _, t := range spec.Tracepoints {
+		hr := next()
+		hr.Target = t.Subsystem + "/" + t.Event
+		tp := tracepoint.Tracepoint{Subsys: t.Subsystem, Event: t.Event}
+		if t.Raw {
+			btfSpec, err := btf.NewBTF()
+			hr.Resolved = err == nil && tp.LoadRawFormat(btfSpec) == nil
+		} else if hr.Resolved = tp.LoadFormat() == nil; !hr.Resolved {
+			// tracefs may not be available, the agent falls back to BTF
+			btfSpec, err := btf.NewBTF()
+			hr.Resolved = err == nil && tp.LoadBTFFormat(btfSpec) == nil
+		}
+		if !hr.Resolved {
+			notFound(hr, "tracepoint")
+		}
+	}
+	for range spec.UProbes {
+		next()
+	}
+	for _, lsm := range spec.LsmHooks {
+		hr := next()
+		hr.Target = "bpf_lsm_" + strings.TrimPrefix(lsm.Hook, "security_")
+		hr.Resolved = c.ks.IsFunction(hr.Target)
+		if !hr.Resolved {
+			notFound(hr, "LSM hook")
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package dryrun
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cilium/tetragon/pkg/option"
+	"github.com/cilium/tetragon/pkg/tracingpolicy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func policy(call, operator string, partialLoad bool) []byte {
+	return []byte(fmt.Sprintf(`apiVersion: cilium.io/v1alpha1
+kind: TracingPolicy
+metadata:
+  name: "dryrun"
+spec:
+  partialLoad: %t
+  kprobes:
+  - call: "%s"
+    syscall: false
+    args:
+    - index: 0
+      type: "file"
+    selectors:
+    - matchArgs:
+      - index: 0
+        operator: "%s"
+        values:
+        - "/etc/"
+`, partialLoad, call, operator))
+}
+
+func TestCompile(t *testing.T) {
+	option.Config.ProcFS = "/proc/"
+	compiler, err := NewCompiler("", "")
+	if err != nil {
+		t.Skipf("kernel BTF not available: %s", err)
+	}
+	if compiler.ks == nil || !compiler.ks.IsFunction("security_file_open") {
+		t.Skip("kernel symbols not available")
+	}
+
+	tp, report := compiler.Validate("", policy("security_file_open", "Prefix", false))
+	require.NotNil(t, tp, "errors: %v", report.Errors)
+	assert.True(t, report.Hooks[0].Resolved)
+	assert.Positive(t, report.Cost.Programs)
+
+	tp, report = compiler.Validate("", policy("security_file_open", "GT", false))
+	assert.Nil(t, tp)
+	require.NotEmpty(t, report.Errors)
+	assert.Contains(t, report.Errors[0].Message, "operator GT not supported for type file")
+
+	tp, report = compiler.Validate("", policy("no_such_function", "Prefix", false))
+	assert.Nil(t, tp)
+	assert.Contains(t, report.Errors, tracingpolicy.ValidationMessage{
+		Hook:    "kprobe no_such_function",
+		Message: "function no_such_function not found on this kernel",
+	})
+	assert.False(t, report.Hooks[0].Resolved)
+
+	// hooks that are not found are skipped by partially loaded policies
+	_, report = compiler.Validate("", policy("no_such_function", "Prefix", true))
+	assert.NotContains(t, report.Errors, tracingpolicy.ValidationMessage{
+		Hook:    "kprobe no_such_function",
+		Message: "function no_such_function not found on this kernel",
+	})
+	assert.Contains(t, report.Warnings, tracingpolicy.ValidationMessage{
+		Hook:    "kprobe no_such_function",
+		Message: "function no_such_function not found on this kernel, the hook will be skipped",
+	})
+}
@@ -279,6 +279,10 @@ func FromYAML(data string) (TracingPolicy, error) {
 		return nil, fmt.Errorf("validation failed: %w", validationResult.AsError())
 	}
 
+	if err := ResolveValues(policy.TpSpec()); err != nil {
+		return nil, fmt.Errorf("failed to resolve values: %w", err)
+	}
+
 	return policy, nil
 }
 
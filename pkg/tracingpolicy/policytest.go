@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package tracingpolicy
+
+import (
+	"errors"
+	"fmt"
+
+	ec "github.com/cilium/tetragon/api/v1/tetragon/codegen/eventchecker"
+	ecYaml "github.com/cilium/tetragon/api/v1/tetragon/codegen/eventchecker/yaml"
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"sigs.k8s.io/yaml"
+)
+
+// PolicyTestChecker returns the checker of the events expected by a test of
+// a policy. The expectations of the test use the format of the spec of the
+// event checker YAML files, see examples/eventchecker.
+func PolicyTestChecker(test *v1alpha1.PolicyTestSpec) (ec.MultiEventChecker, error) {
+	if len(test.Trigger) == 0 {
+		return nil, errors.New("trigger command is empty")
+	}
+
+	var spec ecYaml.MultiEventCheckerSpec
+	if err := yaml.UnmarshalStrict(test.Expect.Raw, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse expected events: %w", err)
+	}
+	if len(spec.Checks) == 0 {
+		return nil, errors.New("no expected events")
+	}
+	return spec.IntoMultiEventChecker()
+}
+
+// validatePolicyTests adds the errors of the tests of a policy to the report.
+func validatePolicyTests(report *ValidationReport, spec *v1alpha1.TracingPolicySpec) {
+	names := make(map[string]struct{}, len(spec.Tests))
+	for i := range spec.Tests {
+		test := &spec.Tests[i]
+		if _, ok := names[test.Name]; ok {
+			report.AddError("", fmt.Errorf("tests[%d]: duplicate test name %q", i, test.Name))
+		}
+		names[test.Name] = struct{}{}
+		if _, err := PolicyTestChecker(test); err != nil {
+			report.AddError("", fmt.Errorf("tests[%d] %q: %w", i, test.Name, err))
+		}
+	}
+}
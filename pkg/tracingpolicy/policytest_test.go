@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package tracingpolicy
+
+import (
+	"testing"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyTests(t *testing.T) {
+	tp, err := FromYAML(`
+apiVersion: cilium.io/v1alpha1
+kind: TracingPolicy
+metadata:
+  name: "tests"
+spec:
+  kprobes:
+  - call: "fd_install"
+    syscall: false
+  tests:
+  - name: "cat"
+    trigger: ["cat", "/etc/passwd"]
+    expect:
+      ordered: false
+      checks:
+      - kprobe:
+          functionName: "fd_install"
+          process:
+            binary: "/usr/bin/cat"
+`)
+	require.NoError(t, err)
+	tests := tp.TpSpec().Tests
+	require.Len(t, tests, 1)
+	assert.Equal(t, []string{"cat", "/etc/passwd"}, tests[0].Trigger)
+	assert.Equal(t, uint32(10), tests[0].Timeout, "default timeout")
+
+	checker, err := PolicyTestChecker(&tests[0])
+	require.NoError(t, err)
+
+	log := logrus.New()
+	done, err := checker.NextEventCheck(&tetragon.ProcessKprobe{
+		Process:      &tetragon.Process{Binary: "/usr/bin/ls"},
+		FunctionName: "fd_install",
+	}, log)
+	assert.False(t, done)
+	assert.Error(t, err)
+	done, err = checker.NextEventCheck(&tetragon.ProcessKprobe{
+		Process:      &tetragon.Process{Binary: "/usr/bin/cat"},
+		FunctionName: "fd_install",
+	}, log)
+	assert.True(t, done)
+	assert.NoError(t, err)
+}
+
+func TestValidatePolicyTests(t *testing.T) {
+	tp, report := Validate("policy.yaml", []byte(`
+apiVersion: cilium.io/v1alpha1
+kind: TracingPolicy
+metadata:
+  name: "tests"
+spec:
+  kprobes:
+  - call: "fd_install"
+    syscall: false
+  tests:
+  - name: "no-checks"
+    trigger: ["true"]
+    expect:
+      ordered: true
+  - name: "no-checks"
+    trigger: ["true"]
+    expect:
+      checks:
+      - unknown: {}
+`))
+	assert.Nil(t, tp)
+	assert.False(t, report.Valid)
+	require.Len(t, report.Errors, 3)
+	assert.Contains(t, report.Errors[0].Message, "no expected events")
+	assert.Contains(t, report.Errors[1].Message, "duplicate test name")
+	assert.Contains(t, report.Errors[2].Message, "failed to parse expected events")
+}
@@ -0,0 +1,253 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package tracingpolicy
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"sigs.k8s.io/yaml"
+)
+
+// ValidationResultVersion is the version of the JSON encoding of
+// ValidationResult. It is bumped on every incompatible change of the format,
+// fields may be added without bumping it.
+const ValidationResultVersion = 1
+
+// ValidationResult is the result of the validation of a set of tracing
+// policies. Its JSON encoding is a stable document meant to be consumed by
+// automation, e.g. to gate the merge of policy changes.
+type ValidationResult struct {
+	Version int `json:"version"`
+	// Valid is true if all the policies are valid
+	Valid    bool                `json:"valid"`
+	Policies []*ValidationReport `json:"policies"`
+}
+
+// NewValidationResult returns an empty, valid, ValidationResult.
+func NewValidationResult() *ValidationResult {
+	return &ValidationResult{
+		Version:  ValidationResultVersion,
+		Valid:    true,
+		Policies: []*ValidationReport{},
+	}
+}
+
+// Add adds the report of a policy to the result.
+func (r *ValidationResult) Add(report *ValidationReport) {
+	r.Policies = append(r.Policies, report)
+	r.Valid = r.Valid && report.Valid
+}
+
+// ValidationReport is the result of the validation of a tracing policy.
+type ValidationReport struct {
+	// File is the file the policy was read from, if any
+	File      string `json:"file,omitempty"`
+	Policy    string `json:"policy"`
+	Namespace string `json:"namespace,omitempty"`
+	// Valid is true if the policy has no errors
+	Valid    bool                `json:"valid"`
+	Errors   []ValidationMessage `json:"errors"`
+	Warnings []ValidationMessage `json:"warnings"`
+	Hooks    []HookReport        `json:"hooks"`
+	Cost     PolicyCost          `json:"cost"`
+}
+
+// ValidationMessage is an error or a warning of a policy validation.
+type ValidationMessage struct {
+	// Hook is the hook the message refers to, empty if the message refers
+	// to the whole policy.
+	Hook    string `json:"hook,omitempty"`
+	Message string `json:"message"`
+}
+
+// HookReport describes a hook of a policy.
+type HookReport struct {
+	// Hook identifies the hook, e.g. "kprobe sys_write"
+	Hook string `json:"hook"`
+	// Type of the hook: kprobe, tracepoint, uprobe, lsmhook or perfevent
+	Type string `json:"type"`
+	// Target is the attachment point of the hook resolved against the
+	// running kernel. It is only set when the policy is validated by the
+	// agent.
+	Target string `json:"target,omitempty"`
+	// Resolved is true if the target was found in the running kernel
+	Resolved  bool     `json:"resolved"`
+	Args      int      `json:"args"`
+	Selectors int      `json:"selectors"`
+	Actions   []string `json:"actions"`
+	// Enforcing is true if the hook has actions that act on the process
+	// or the operation (Sigkill, Signal, Override...).
+	Enforcing bool `json:"enforcing"`
+}
+
+// PolicyCost is an estimate of the cost of a policy.
+type PolicyCost struct {
+	Hooks     int `json:"hooks"`
+	Selectors int `json:"selectors"`
+	// Programs and Maps are the number of BPF programs and maps of the
+	// sensors of the policy. They are only set when the policy is
+	// validated by the agent.
+	Programs int `json:"programs"`
+	Maps     int `json:"maps"`
+}
+
+func newValidationReport(file string) *ValidationReport {
+	return &ValidationReport{
+		File:     file,
+		Valid:    true,
+		Errors:   []ValidationMessage{},
+		Warnings: []ValidationMessage{},
+		Hooks:    []HookReport{},
+	}
+}
+
+// AddError adds an error to the report and marks the policy as invalid.
+func (r *ValidationReport) AddError(hook string, err error) {
+	r.Errors = append(r.Errors, ValidationMessage{Hook: hook, Message: err.Error()})
+	r.Valid = false
+}
+
+// AddWarning adds a warning to the report.
+func (r *ValidationReport) AddWarning(hook string, msg string) {
+	r.Warnings = append(r.Warnings, ValidationMessage{Hook: hook, Message: msg})
+}
+
+// Validate validates a tracing policy without loading it. Unlike FromYAML,
+// it reports all the errors of the policy and warnings, and describes the
+// hooks of the policy. The returned policy is nil if the policy is invalid.
+func Validate(file string, data []byte) (TracingPolicy, *ValidationReport) {
+	report := newValidationReport(file)
+
+	rawPolicy, namespaced, err := ApplyCRDDefault(data)
+	if err != nil {
+		report.AddError("", fmt.Errorf("error applying CRD defaults: %w", err))
+		return nil, report
+	}
+
+	var policy K8sTracingPolicyObject
+	if namespaced {
+		policy = &GenericTracingPolicyNamespaced{}
+	} else {
+		policy = &GenericTracingPolicy{}
+	}
+
+	if err := yaml.UnmarshalStrict(rawPolicy, &policy); err != nil {
+		report.AddError("", fmt.Errorf("failed to unmarshal object with defaults: %w", err))
+		return nil, report
+	}
+	report.Policy = policy.TpName()
+	if namespaced {
+		report.Namespace = policy.GetMetadata().Namespace
+	}
+
+	validationResult, err := ValidateCRD(policy)
+	if err != nil {
+		report.AddError("", err)
+		return nil, report
+	}
+	for _, err := range validationResult.Errors {
+		report.AddError("", err)
+	}
+	for _, warn := range validationResult.Warnings {
+		report.AddWarning("", warn.Error())
+	}
+
+	if err := ResolveValues(policy.TpSpec()); err != nil {
+		report.AddError("", fmt.Errorf("failed to resolve values: %w", err))
+		return nil, report
+	}
+
+	addHookReports(report, policy.TpSpec())
+	validatePolicyTests(report, policy.TpSpec())
+
+	if !report.Valid {
+		return nil, report
+	}
+	return policy, report
+}
+
+// ValidateFile validates the tracing policy of a file, see Validate.
+func ValidateFile(path string) (TracingPolicy, *ValidationReport) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		report := newValidationReport(path)
+		report.AddError("", err)
+		return nil, report
+	}
+	return Validate(path, data)
+}
+
+// enforcingActions are the actions acting on the process or the operation,
+// in lower case.
+var enforcingActions = map[string]struct{}{
+	"sigkill":      {},
+	"signal":       {},
+	"override":     {},
+	"notifykiller": {},
+}
+
+// hasFilter returns true if the selector filters the events, i.e. has other
+// fields than its actions.
+func hasFilter(sel *v1alpha1.KProbeSelector) bool {
+	filters := *sel
+	filters.MatchActions = nil
+	return compactJSON(filters) != "null"
+}
+
+func addHookReports(report *ValidationReport, spec *v1alpha1.TracingPolicySpec) {
+	add := func(typ string, hook interface{}, args int, sels []v1alpha1.KProbeSelector) {
+		key, _ := hookKey(hook)
+		hr := HookReport{
+			Hook:      key,
+			Type:      typ,
+			Args:      args,
+			Selectors: len(sels),
+			Actions:   []string{},
+		}
+		for i := range sels {
+			sel := &sels[i]
+			var enforcing bool
+			for _, act := range sel.MatchActions {
+				hr.Actions = append(hr.Actions, act.Action)
+				if _, ok := enforcingActions[strings.ToLower(act.Action)]; ok {
+					enforcing = true
+				}
+			}
+			if enforcing {
+				hr.Enforcing = true
+				if !hasFilter(sel) {
+					report.AddWarning(key, fmt.Sprintf("selectors[%d] has enforcing actions but no filters, they apply to every call of the hook", i))
+				}
+			}
+		}
+		for _, h := range report.Hooks {
+			if h.Hook == key {
+				report.AddWarning(key, "hook is defined more than once")
+				break
+			}
+		}
+		report.Hooks = append(report.Hooks, hr)
+		report.Cost.Hooks++
+		report.Cost.Selectors += len(sels)
+	}
+
+	for _, h := range spec.KProbes {
+		add("kprobe", h, len(h.Args), h.Selectors)
+	}
+	for _, h := range spec.Tracepoints {
+		add("tracepoint", h, len(h.Args), h.Selectors)
+	}
+	for _, h := range spec.UProbes {
+		add("uprobe", h, len(h.Args), h.Selectors)
+	}
+	for _, h := range spec.LsmHooks {
+		add("lsmhook", h, len(h.Args), h.Selectors)
+	}
+	for _, h := range spec.PerfEvents {
+		add("perfevent", h, 0, h.Selectors)
+	}
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package tracingpolicy
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	tp, report := Validate("policy.yaml", []byte(`
+apiVersion: cilium.io/v1alpha1
+kind: TracingPolicy
+metadata:
+  name: "validate"
+spec:
+  kprobes:
+  - call: "sys_openat"
+    syscall: true
+    args:
+    - index: 1
+      type: "string"
+    selectors:
+    - matchActions:
+      - action: Sigkill
+  - call: "sys_openat"
+    syscall: true
+  tracepoints:
+  - subsystem: "syscalls"
+    event: "sys_enter_write"
+`))
+	require.NotNil(t, tp)
+	assert.True(t, report.Valid)
+	assert.Equal(t, "validate", report.Policy)
+	assert.Empty(t, report.Errors)
+	require.Len(t, report.Warnings, 2)
+	assert.Equal(t, "kprobe sys_openat", report.Warnings[0].Hook)
+	assert.Contains(t, report.Warnings[0].Message, "no filters")
+	assert.Contains(t, report.Warnings[1].Message, "more than once")
+
+	require.Len(t, report.Hooks, 3)
+	assert.Equal(t, "kprobe", report.Hooks[0].Type)
+	assert.True(t, report.Hooks[0].Enforcing)
+	assert.Equal(t, []string{"Sigkill"}, report.Hooks[0].Actions)
+	assert.False(t, report.Hooks[1].Enforcing)
+	assert.Equal(t, "tracepoint syscalls/sys_enter_write", report.Hooks[2].Hook)
+	assert.Equal(t, PolicyCost{Hooks: 3, Selectors: 1}, report.Cost)
+}
+
+func TestValidateErrors(t *testing.T) {
+	tp, report := Validate("policy.yaml", []byte(`
+apiVersion: cilium.io/v1alpha1
+kind: TracingPolicy
+metadata:
+  name: "validate"
+spec:
+  kprobes:
+  - call: "sys_openat"
+    args:
+    - index: 1
+      type: "foo"
+    - index: 2
+      type: "bar"
+`))
+	assert.Nil(t, tp)
+	assert.False(t, report.Valid)
+	assert.Len(t, report.Errors, 2)
+
+	result := NewValidationResult()
+	result.Add(report)
+	assert.False(t, result.Valid)
+
+	// empty lists are encoded as such, so that the document is stable
+	b, err := json.Marshal(result)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"warnings":[]`)
+}
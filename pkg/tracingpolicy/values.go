@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package tracingpolicy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+)
+
+var (
+	valueNameRe = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	valueRefRe  = regexp.MustCompile(`\$\{([^}]*)\}`)
+)
+
+// ResolveValues replaces the references to the values of the policy, e.g.
+// ${name}, in the string fields of spec by the values, and removes the values
+// from spec.
+//
+// A reference to a scalar value may appear anywhere in a string. A list
+// element that is exactly a reference to a list value is replaced by all the
+// elements of the list, list values cannot be used in other places. It is an
+// error to reference a value that is not defined.
+func ResolveValues(spec *v1alpha1.TracingPolicySpec) error {
+	if len(spec.Values) == 0 {
+		return nil
+	}
+
+	values := make(map[string]*v1alpha1.ValueSpec, len(spec.Values))
+	for i := range spec.Values {
+		v := &spec.Values[i]
+		if !valueNameRe.MatchString(v.Name) {
+			return fmt.Errorf("invalid value name '%s'", v.Name)
+		}
+		if _, ok := values[v.Name]; ok {
+			return fmt.Errorf("value '%s' defined multiple times", v.Name)
+		}
+		if v.Value != "" && len(v.Values) > 0 {
+			return fmt.Errorf("value '%s' has both a value and a list of values", v.Name)
+		}
+		values[v.Name] = v
+	}
+
+	resolved := spec.DeepCopy()
+	resolved.Values = nil
+	data, err := json.Marshal(resolved)
+	if err != nil {
+		return err
+	}
+	var tree interface{}
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return err
+	}
+	tree, err = resolveNode(tree, values)
+	if err != nil {
+		return err
+	}
+	data, err = json.Marshal(tree)
+	if err != nil {
+		return err
+	}
+
+	*resolved = v1alpha1.TracingPolicySpec{}
+	if err := json.Unmarshal(data, resolved); err != nil {
+		return fmt.Errorf("failed to unmarshal policy with resolved values: %w", err)
+	}
+	*spec = *resolved
+	return nil
+}
+
+func resolveNode(node interface{}, values map[string]*v1alpha1.ValueSpec) (interface{}, error) {
+	switch n := node.(type) {
+	case string:
+		return resolveString(n, values)
+	case []interface{}:
+		ret := make([]interface{}, 0, len(n))
+		for _, elem := range n {
+			if s, ok := elem.(string); ok {
+				if v := listRef(s, values); v != nil {
+					for _, val := range v.Values {
+						ret = append(ret, val)
+					}
+					continue
+				}
+			}
+			r, err := resolveNode(elem, values)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, r)
+		}
+		return ret, nil
+	case map[string]interface{}:
+		for k, elem := range n {
+			r, err := resolveNode(elem, values)
+			if err != nil {
+				return nil, err
+			}
+			n[k] = r
+		}
+		return n, nil
+	default:
+		return node, nil
+	}
+}
+
+// listRef returns the list value that s references, if s is exactly a
+// reference to a list value.
+func listRef(s string, values map[string]*v1alpha1.ValueSpec) *v1alpha1.ValueSpec {
+	m := valueRefRe.FindStringSubmatchIndex(s)
+	if m == nil || m[0] != 0 || m[1] != len(s) {
+		return nil
+	}
+	v, ok := values[s[m[2]:m[3]]]
+	if !ok || len(v.Values) == 0 {
+		return nil
+	}
+	return v
+}
+
+func resolveString(s string, values map[string]*v1alpha1.ValueSpec) (string, error) {
+	var errs error
+	ret := valueRefRe.ReplaceAllStringFunc(s, func(ref string) string {
+		name := ref[2 : len(ref)-1]
+		v, ok := values[name]
+		if !ok {
+			errs = errors.Join(errs, fmt.Errorf("value '%s' is not defined", name))
+			return ref
+		}
+		if len(v.Values) > 0 {
+			errs = errors.Join(errs, fmt.Errorf("list value '%s' must be a whole list element", name))
+			return ref
+		}
+		return v.Value
+	})
+	return ret, errs
+}
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package tracingpolicy
+
+import (
+	"testing"
+
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fileOpenTemplate = `
+apiVersion: cilium.io/v1alpha1
+kind: TracingPolicy
+metadata:
+  name: "file-open"
+spec:
+  values:
+  - name: hook
+    value: "security_file_open"
+  - name: dir
+    value: "/etc"
+  - name: binaries
+    values:
+    - "/usr/bin/cat"
+    - "/usr/bin/less"
+  kprobes:
+  - call: "${hook}"
+    syscall: false
+    args:
+    - index: 0
+      type: "file"
+    selectors:
+    - matchArgs:
+      - index: 0
+        operator: "Prefix"
+        values:
+        - "${dir}/shadow"
+        - "${dir}/passwd"
+      matchBinaries:
+      - operator: "In"
+        values:
+        - "/usr/bin/vi"
+        - "${binaries}"
+`
+
+func TestResolveValues(t *testing.T) {
+	tp, err := FromYAML(fileOpenTemplate)
+	require.NoError(t, err)
+
+	spec := tp.TpSpec()
+	assert.Empty(t, spec.Values)
+	require.Len(t, spec.KProbes, 1)
+	kprobe := spec.KProbes[0]
+	assert.Equal(t, "security_file_open", kprobe.Call)
+	assert.Equal(t, uint32(0), kprobe.Args[0].Index)
+	require.Len(t, kprobe.Selectors, 1)
+	assert.Equal(t, []string{"/etc/shadow", "/etc/passwd"}, kprobe.Selectors[0].MatchArgs[0].Values)
+	assert.Equal(t, []string{"/usr/bin/vi", "/usr/bin/cat", "/usr/bin/less"}, kprobe.Selectors[0].MatchBinaries[0].Values)
+}
+
+func TestResolveValuesErrors(t *testing.T) {
+	spec := func(values []v1alpha1.ValueSpec, call string) *v1alpha1.TracingPolicySpec {
+		return &v1alpha1.TracingPolicySpec{
+			Values:  values,
+			KProbes: []v1alpha1.KProbeSpec{{Call: call}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		spec   *v1alpha1.TracingPolicySpec
+		errMsg string
+	}{
+		{
+			name:   "undefined",
+			spec:   spec([]v1alpha1.ValueSpec{{Name: "a", Value: "x"}}, "${b}"),
+			errMsg: "value 'b' is not defined",
+		},
+		{
+			name:   "invalid name",
+			spec:   spec([]v1alpha1.ValueSpec{{Name: "a-b", Value: "x"}}, "fd_install"),
+			errMsg: "invalid value name 'a-b'",
+		},
+		{
+			name:   "duplicate",
+			spec:   spec([]v1alpha1.ValueSpec{{Name: "a", Value: "x"}, {Name: "a", Value: "y"}}, "fd_install"),
+			errMsg: "value 'a' defined multiple times",
+		},
+		{
+			name:   "value and values",
+			spec:   spec([]v1alpha1.ValueSpec{{Name: "a", Value: "x", Values: []string{"y"}}}, "fd_install"),
+			errMsg: "value 'a' has both a value and a list of values",
+		},
+		{
+			name:   "list in string",
+			spec:   spec([]v1alpha1.ValueSpec{{Name: "a", Values: []string{"x", "y"}}}, "sys_${a}"),
+			errMsg: "list value 'a' must be a whole list element",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ResolveValues(test.spec)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.errMsg)
+		})
+	}
+
+	// policies without values are not modified
+	noValues := spec(nil, "${a}")
+	require.NoError(t, ResolveValues(noValues))
+	assert.Equal(t, "${a}", noValues.KProbes[0].Call)
+}
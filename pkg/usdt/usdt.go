@@ -0,0 +1,422 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package usdt reads the USDT (dtrace-style statically defined tracing)
+// probes of ELF binaries from their .note.stapsdt section.
+package usdt
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const (
+	noteSection   = ".note.stapsdt"
+	baseSection   = ".stapsdt.base"
+	noteName      = "stapsdt"
+	noteType      = 3
+	noteAlignment = 4
+)
+
+// ArgType is the location type of a USDT probe argument.
+type ArgType int
+
+const (
+	// ArgRegister arguments are stored in a register
+	ArgRegister ArgType = iota
+	// ArgMemory arguments are stored in memory, at an offset from the
+	// address stored in a register
+	ArgMemory
+	// ArgConstant arguments are constants
+	ArgConstant
+)
+
+// The location of an argument is encoded for the BPF programs as the offset
+// of the register in struct pt_regs in the low bits, and for memory and
+// constant arguments a signed 16 bit offset or value. See read_usdt_arg in
+// bpf/process/generic_calls.h.
+const (
+	locValShift = 12
+	locMemory   = 1 << 29
+	locConstant = 1 << 30
+)
+
+// Arg is a USDT probe argument.
+type Arg struct {
+	// Size of the argument in bytes
+	Size int
+	// Signed is true if the argument is a signed integer
+	Signed bool
+	// Type is the location type of the argument
+	Type ArgType
+	// Reg is the register storing the argument, or its address for memory
+	// arguments
+	Reg string
+	// RegOffset is the offset of the register in struct pt_regs
+	RegOffset uint32
+	// Offset is the offset of the argument from the address stored in the
+	// register for memory arguments
+	Offset int16
+	// Value is the value of constant arguments
+	Value int16
+}
+
+// Location returns the encoded location of the argument for the BPF programs.
+func (a *Arg) Location() uint32 {
+	switch a.Type {
+	case ArgMemory:
+		return locMemory | uint32(uint16(a.Offset))<<locValShift | a.RegOffset
+	case ArgConstant:
+		return locConstant | uint32(uint16(a.Value))<<locValShift
+	}
+	return a.RegOffset
+}
+
+// Probe is a USDT probe of a binary.
+type Probe struct {
+	Provider string
+	Name     string
+	// Offset is the file offset of the probe
+	Offset uint64
+	// SemaphoreOffset is the file offset of the probe semaphore, or 0 if
+	// the probe has no semaphore.
+	SemaphoreOffset uint64
+	// ArgsSpec is the specification of the probe arguments, see ParseArgs
+	ArgsSpec string
+}
+
+// ParseArgs returns the arguments of the probe
+func (p *Probe) ParseArgs() ([]Arg, error) {
+	args, err := parseArgs(p.ArgsSpec, runtime.GOARCH)
+	if err != nil {
+		return nil, fmt.Errorf("probe %s:%s: %w", p.Provider, p.Name, err)
+	}
+	return args, nil
+}
+
+// ptRegsOffsets are the offsets of the registers in struct pt_regs, per
+// architecture. The sub-registers share the offset of their full register as
+// the arguments are read in little endian.
+var ptRegsOffsets = map[string]map[string]uint32{
+	"amd64":   amd64PtRegsOffsets(),
+	"arm64":   arm64PtRegsOffsets(),
+	"riscv64": riscv64PtRegsOffsets(),
+}
+
+func amd64PtRegsOffsets() map[string]uint32 {
+	ret := map[string]uint32{}
+	for name, off := range map[string]uint32{"bp": 32, "bx": 40, "ax": 80, "cx": 88, "dx": 96, "si": 104, "di": 112, "sp": 152} {
+		ret["r"+name] = off
+		ret["e"+name] = off
+		ret[name] = off
+	}
+	for name, off := range map[string]uint32{"bpl": 32, "bl": 40, "al": 80, "cl": 88, "dl": 96, "sil": 104, "dil": 112, "ip": 128, "rip": 128} {
+		ret[name] = off
+	}
+	// r8 to r15
+	for i, off := range []uint32{72, 64, 56, 48, 24, 16, 8, 0} {
+		r := "r" + strconv.Itoa(i+8)
+		for _, suffix := range []string{"", "d", "w", "b"} {
+			ret[r+suffix] = off
+		}
+	}
+	return ret
+}
+
+func arm64PtRegsOffsets() map[string]uint32 {
+	ret := map[string]uint32{"sp": 31 * 8, "pc": 32 * 8}
+	for i := uint32(0); i < 31; i++ {
+		ret["x"+strconv.Itoa(int(i))] = i * 8
+		ret["w"+strconv.Itoa(int(i))] = i * 8
+	}
+	return ret
+}
+
+func riscv64PtRegsOffsets() map[string]uint32 {
+	ret := map[string]uint32{}
+	regs := []string{"pc", "ra", "sp", "gp", "tp", "t0", "t1", "t2", "s0", "s1"}
+	for i := 0; i < 8; i++ {
+		regs = append(regs, "a"+strconv.Itoa(i))
+	}
+	for i := 2; i < 12; i++ {
+		regs = append(regs, "s"+strconv.Itoa(i))
+	}
+	for i := 3; i < 7; i++ {
+		regs = append(regs, "t"+strconv.Itoa(i))
+	}
+	for i, name := range regs {
+		ret[name] = uint32(i) * 8
+	}
+	ret["fp"] = ret["s0"]
+	return ret
+}
+
+// parseArg parses a probe argument in the SIZE@LOCATION format. The location
+// is a register (e.g., -4@%edi or 8@x1), a memory operand addressed by a
+// register and an optional offset (e.g., -4@-20(%rbp), 8@[sp, 16] or
+// 8@16(sp)), or a constant (e.g., 4@$5 or 4@5).
+func parseArg(s, arch string) (Arg, error) {
+	var arg Arg
+
+	sizeStr, loc, found := strings.Cut(s, "@")
+	if !found {
+		return arg, fmt.Errorf("invalid argument '%s'", s)
+	}
+	size, err := strconv.Atoi(sizeStr)
+	if err != nil {
+		return arg, fmt.Errorf("invalid argument '%s': %w", s, err)
+	}
+	if size < 0 {
+		arg.Signed = true
+		size = -size
+	}
+	switch size {
+	case 1, 2, 4, 8:
+		arg.Size = size
+	default:
+		return arg, fmt.Errorf("invalid argument '%s': invalid size %d", s, size)
+	}
+
+	offsets, ok := ptRegsOffsets[arch]
+	if !ok {
+		return arg, fmt.Errorf("unsupported architecture %s", arch)
+	}
+
+	reg := loc
+	switch {
+	case arch == "amd64" && strings.HasPrefix(loc, "$"):
+		arg.Type = ArgConstant
+		if arg.Value, err = parseInt16(loc[1:]); err != nil {
+			return arg, fmt.Errorf("argument '%s' not supported: %w", s, err)
+		}
+		return arg, nil
+	case arch != "amd64" && len(loc) > 0 && (loc[0] == '-' || (loc[0] >= '0' && loc[0] <= '9')) && !strings.HasSuffix(loc, ")"):
+		arg.Type = ArgConstant
+		if arg.Value, err = parseInt16(loc); err != nil {
+			return arg, fmt.Errorf("argument '%s' not supported: %w", s, err)
+		}
+		return arg, nil
+	case strings.HasPrefix(loc, "[") && strings.HasSuffix(loc, "]"):
+		// arm64 memory operand: [REG] or [REG, OFFSET]
+		arg.Type = ArgMemory
+		var off string
+		reg, off, found = strings.Cut(loc[1:len(loc)-1], ",")
+		reg = strings.TrimSpace(reg)
+		if found {
+			if arg.Offset, err = parseInt16(strings.TrimPrefix(strings.TrimSpace(off), "#")); err != nil {
+				return arg, fmt.Errorf("argument '%s' not supported: %w", s, err)
+			}
+		}
+	case strings.HasSuffix(loc, ")"):
+		// memory operand: OFFSET(REG) or (REG)
+		arg.Type = ArgMemory
+		off, r, found := strings.Cut(loc[:len(loc)-1], "(")
+		if !found {
+			return arg, fmt.Errorf("invalid argument '%s'", s)
+		}
+		reg = r
+		if off != "" {
+			if arg.Offset, err = parseInt16(off); err != nil {
+				return arg, fmt.Errorf("argument '%s' not supported: %w", s, err)
+			}
+		}
+	}
+
+	reg = strings.TrimPrefix(reg, "%")
+	off, ok := offsets[reg]
+	// the memory arguments relative to the instruction pointer (globals)
+	// are not supported
+	if !ok || (arg.Type == ArgMemory && (reg == "ip" || reg == "rip" || reg == "pc")) {
+		return arg, fmt.Errorf("argument '%s' not supported: unsupported location '%s'", s, loc)
+	}
+	arg.Reg = reg
+	arg.RegOffset = off
+	return arg, nil
+}
+
+// parseInt16 parses the offsets of memory arguments and the values of
+// constant arguments, which are limited to 16 bits by their encoding.
+func parseInt16(s string) (int16, error) {
+	v, err := strconv.ParseInt(s, 0, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid or out of range value '%s'", s)
+	}
+	return int16(v), nil
+}
+
+func parseArgs(s, arch string) ([]Arg, error) {
+	var ret []Arg
+	for _, f := range splitArgs(s) {
+		arg, err := parseArg(f, arch)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, arg)
+	}
+	return ret, nil
+}
+
+// splitArgs splits the arguments specification on spaces, except in the
+// brackets of arm64 memory operands (e.g., 8@[sp, 16]).
+func splitArgs(s string) []string {
+	var ret []string
+	start, depth := -1, 0
+	for i, c := range s {
+		switch {
+		case c == '[':
+			depth++
+		case c == ']' && depth > 0:
+			depth--
+		case c == ' ' && depth == 0:
+			if start >= 0 {
+				ret = append(ret, s[start:i])
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		ret = append(ret, s[start:])
+	}
+	return ret
+}
+
+// note is a raw stapsdt note
+type note struct {
+	pc, base, semaphore uint64
+	provider, name      string
+	args                string
+}
+
+func align(n uint32) uint32 {
+	return (n + noteAlignment - 1) &^ (noteAlignment - 1)
+}
+
+// parseNotes parses the content of a .note.stapsdt section
+func parseNotes(data []byte, order binary.ByteOrder, addrSize int) ([]note, error) {
+	var ret []note
+	for len(data) > 0 {
+		if len(data) < 12 {
+			return nil, errors.New("truncated note header")
+		}
+		nameSz := order.Uint32(data[0:4])
+		descSz := order.Uint32(data[4:8])
+		typ := order.Uint32(data[8:12])
+		data = data[12:]
+		if uint64(len(data)) < uint64(align(nameSz))+uint64(align(descSz)) {
+			return nil, errors.New("truncated note")
+		}
+		name := string(bytes.TrimRight(data[:nameSz], "\x00"))
+		desc := data[align(nameSz) : align(nameSz)+descSz]
+		data = data[align(nameSz)+align(descSz):]
+		if name != noteName || typ != noteType {
+			continue
+		}
+
+		if len(desc) < 3*addrSize {
+			return nil, errors.New("truncated stapsdt note")
+		}
+		var n note
+		addr := func(b []byte) uint64 {
+			if addrSize == 4 {
+				return uint64(order.Uint32(b))
+			}
+			return order.Uint64(b)
+		}
+		n.pc = addr(desc[0:])
+		n.base = addr(desc[addrSize:])
+		n.semaphore = addr(desc[2*addrSize:])
+		strs := strings.SplitN(string(desc[3*addrSize:]), "\x00", 4)
+		if len(strs) < 3 {
+			return nil, errors.New("invalid stapsdt note strings")
+		}
+		n.provider, n.name, n.args = strs[0], strs[1], strs[2]
+		ret = append(ret, n)
+	}
+	return ret, nil
+}
+
+// addrToOffset translates a virtual address to a file offset
+func addrToOffset(f *elf.File, addr uint64) (uint64, error) {
+	for _, p := range f.Progs {
+		if p.Type == elf.PT_LOAD && p.Vaddr <= addr && addr < p.Vaddr+p.Memsz {
+			return addr - p.Vaddr + p.Off, nil
+		}
+	}
+	return 0, fmt.Errorf("address 0x%x is not in a loadable segment", addr)
+}
+
+// ReadProbes returns the USDT probes of the ELF file at path
+func ReadProbes(path string) ([]Probe, error) {
+	f, err := elf.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sec := f.Section(noteSection)
+	if sec == nil {
+		return nil, fmt.Errorf("%s: no %s section", path, noteSection)
+	}
+	data, err := sec.Data()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to read %s: %w", path, noteSection, err)
+	}
+	addrSize := 8
+	if f.Class == elf.ELFCLASS32 {
+		addrSize = 4
+	}
+	notes, err := parseNotes(data, f.ByteOrder, addrSize)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	// the probe addresses are adjusted if the binary was prelinked
+	var baseAddr uint64
+	if base := f.Section(baseSection); base != nil {
+		baseAddr = base.Addr
+	}
+
+	ret := make([]Probe, 0, len(notes))
+	for _, n := range notes {
+		p := Probe{Provider: n.provider, Name: n.name, ArgsSpec: n.args}
+		pc := n.pc
+		if baseAddr != 0 {
+			pc += baseAddr - n.base
+		}
+		if p.Offset, err = addrToOffset(f, pc); err != nil {
+			return nil, fmt.Errorf("%s: probe %s:%s: %w", path, n.provider, n.name, err)
+		}
+		if n.semaphore != 0 {
+			if p.SemaphoreOffset, err = addrToOffset(f, n.semaphore); err != nil {
+				return nil, fmt.Errorf("%s: probe %s:%s semaphore: %w", path, n.provider, n.name, err)
+			}
+		}
+		ret = append(ret, p)
+	}
+	return ret, nil
+}
+
+// FindProbe returns the USDT probe of the ELF file at path with the given
+// provider and name.
+func FindProbe(path, provider, name string) (*Probe, error) {
+	probes, err := ReadProbes(path)
+	if err != nil {
+		return nil, err
+	}
+	for i := range probes {
+		if probes[i].Provider == provider && probes[i].Name == name {
+			return &probes[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%s: USDT probe %s:%s not found", path, provider, name)
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package usdt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseArgs(t *testing.T) {
+	args, err := parseArgs("-4@%edi 8@%rsi 1@%r9b", "amd64")
+	require.NoError(t, err)
+	assert.Equal(t, []Arg{
+		{Size: 4, Signed: true, Reg: "edi", RegOffset: 112},
+		{Size: 8, Reg: "rsi", RegOffset: 104},
+		{Size: 1, Reg: "r9b", RegOffset: 64},
+	}, args)
+
+	args, err = parseArgs("8@x1 -4@w30", "arm64")
+	require.NoError(t, err)
+	assert.Equal(t, []Arg{
+		{Size: 8, Reg: "x1", RegOffset: 8},
+		{Size: 4, Signed: true, Reg: "w30", RegOffset: 240},
+	}, args)
+
+	args, err = parseArgs("8@a0 -4@s1 8@fp", "riscv64")
+	require.NoError(t, err)
+	assert.Equal(t, []Arg{
+		{Size: 8, Reg: "a0", RegOffset: 80},
+		{Size: 4, Signed: true, Reg: "s1", RegOffset: 72},
+		{Size: 8, Reg: "fp", RegOffset: 64},
+	}, args)
+
+	args, err = parseArgs("", "amd64")
+	require.NoError(t, err)
+	assert.Empty(t, args)
+
+	args, err = parseArgs("-4@-20(%rbp) 8@(%rax) 4@$5 -4@$-1 8@$0x10", "amd64")
+	require.NoError(t, err)
+	assert.Equal(t, []Arg{
+		{Size: 4, Signed: true, Type: ArgMemory, Reg: "rbp", RegOffset: 32, Offset: -20},
+		{Size: 8, Type: ArgMemory, Reg: "rax", RegOffset: 80},
+		{Size: 4, Type: ArgConstant, Value: 5},
+		{Size: 4, Signed: true, Type: ArgConstant, Value: -1},
+		{Size: 8, Type: ArgConstant, Value: 16},
+	}, args)
+
+	args, err = parseArgs("8@[sp, 16] -4@[x1] 4@5", "arm64")
+	require.NoError(t, err)
+	assert.Equal(t, []Arg{
+		{Size: 8, Type: ArgMemory, Reg: "sp", RegOffset: 248, Offset: 16},
+		{Size: 4, Signed: true, Type: ArgMemory, Reg: "x1", RegOffset: 8},
+		{Size: 4, Type: ArgConstant, Value: 5},
+	}, args)
+
+	args, err = parseArgs("-4@-20(s0) 8@-1", "riscv64")
+	require.NoError(t, err)
+	assert.Equal(t, []Arg{
+		{Size: 4, Signed: true, Type: ArgMemory, Reg: "s0", RegOffset: 64, Offset: -20},
+		{Size: 8, Type: ArgConstant, Value: -1},
+	}, args)
+
+	for _, s := range []string{"4@var(%rip)", "4@8(%rip)", "4@(%rax,%rbx,8)", "4@$100000", "4@65536(%rbp)", "3@%edi", "%edi", "4@%xyz"} {
+		_, err = parseArgs(s, "amd64")
+		assert.Error(t, err, s)
+	}
+	_, err = parseArgs("4@%edi", "mips")
+	assert.Error(t, err)
+}
+
+func TestArgLocation(t *testing.T) {
+	arg := Arg{Type: ArgRegister, RegOffset: 112}
+	assert.Equal(t, uint32(112), arg.Location())
+	arg = Arg{Type: ArgMemory, RegOffset: 32, Offset: -20}
+	assert.Equal(t, uint32(locMemory|0xffec<<locValShift|32), arg.Location())
+	arg = Arg{Type: ArgConstant, Value: 5}
+	assert.Equal(t, uint32(locConstant|5<<locValShift), arg.Location())
+}
+
+func writeNote(buf *bytes.Buffer, name string, typ uint32, desc []byte) {
+	nameb := append([]byte(name), 0)
+	binary.Write(buf, binary.LittleEndian, uint32(len(nameb)))
+	binary.Write(buf, binary.LittleEndian, uint32(len(desc)))
+	binary.Write(buf, binary.LittleEndian, typ)
+	buf.Write(nameb)
+	buf.Write(make([]byte, align(uint32(len(nameb)))-uint32(len(nameb))))
+	buf.Write(desc)
+	buf.Write(make([]byte, align(uint32(len(desc)))-uint32(len(desc))))
+}
+
+func TestParseNotes(t *testing.T) {
+	var desc bytes.Buffer
+	binary.Write(&desc, binary.LittleEndian, []uint64{0x1130, 0x2000, 0x4010})
+	desc.WriteString("provider\x00probe\x00-4@%edi 8@%rsi\x00")
+
+	var buf bytes.Buffer
+	writeNote(&buf, "GNU", 1, []byte{1, 2, 3})
+	writeNote(&buf, noteName, noteType, desc.Bytes())
+
+	notes, err := parseNotes(buf.Bytes(), binary.LittleEndian, 8)
+	require.NoError(t, err)
+	assert.Equal(t, []note{{
+		pc:        0x1130,
+		base:      0x2000,
+		semaphore: 0x4010,
+		provider:  "provider",
+		name:      "probe",
+		args:      "-4@%edi 8@%rsi",
+	}}, notes)
+
+	_, err = parseNotes(buf.Bytes()[:buf.Len()-8], binary.LittleEndian, 8)
+	assert.Error(t, err)
+}
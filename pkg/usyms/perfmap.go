@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package usyms
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// jvmLibrary is the library of the HotSpot and OpenJ9 JVMs, mapped by
+	// all the JVM processes.
+	jvmLibrary = "libjvm.so"
+	// maxPerfMapSize is the maximum size of the perf maps that are read.
+	maxPerfMapSize = 64 << 20
+)
+
+// perfMapSymbols are the symbols of the JIT compiled code of a process, from
+// its perf map.
+type perfMapSymbols struct {
+	// path is the path of the perf map in the mount namespace of the
+	// process, /tmp/perf-<pid>.map
+	path  string
+	table []usym
+}
+
+// isJVM returns true if the mappings of a process include the JVM library
+func isJVM(mappings []mapping) bool {
+	for i := range mappings {
+		if filepath.Base(mappings[i].path) == jvmLibrary {
+			return true
+		}
+	}
+	return false
+}
+
+// nsPid returns the pid of a process in its own pid namespace, from the NSpid
+// field of a procfs/<pid>/status file.
+func nsPid(r io.Reader) (uint64, error) {
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		value, found := strings.CutPrefix(s.Text(), "NSpid:")
+		if !found {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			break
+		}
+		return strconv.ParseUint(fields[len(fields)-1], 10, 32)
+	}
+	if err := s.Err(); err != nil {
+		return 0, err
+	}
+	return 0, errors.New("no NSpid field")
+}
+
+// parsePerfMap parses a perf map, with a line per JIT compiled function in
+// the START SIZE NAME format, where START and SIZE are hexadecimal. The
+// returned table is sorted by address.
+func parsePerfMap(r io.Reader) ([]usym, error) {
+	var ret []usym
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		fields := strings.SplitN(strings.TrimSpace(s.Text()), " ", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		addr, err := strconv.ParseUint(strings.TrimPrefix(fields[0], "0x"), 16, 64)
+		if err != nil {
+			continue
+		}
+		size, err := strconv.ParseUint(strings.TrimPrefix(fields[1], "0x"), 16, 64)
+		if err != nil {
+			continue
+		}
+		ret = append(ret, usym{addr: addr, size: size, name: fields[2]})
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	// stable, as the code of the functions recompiled at the same address
+	// is appended to the map
+	sort.SliceStable(ret, func(i1, i2 int) bool { return ret[i1].addr < ret[i2].addr })
+	return ret, nil
+}
+
+// openPerfMap opens the perf map at path in the root of a process. The perf
+// map is written by the process, so its path is resolved in the root of the
+// process without following the symlinks to the files of the host or a last
+// symlink, and it must be a regular file that is not too large.
+func openPerfMap(procDir, path string) (*os.File, error) {
+	hostPath := filepath.Join(procDir, "root", path)
+	flags := unix.O_RDONLY | unix.O_NOFOLLOW | unix.O_NONBLOCK | unix.O_CLOEXEC
+	root, err := unix.Open(filepath.Join(procDir, "root"), unix.O_PATH|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, err
+	}
+	fd, err := unix.Openat2(root, strings.TrimPrefix(path, "/"), &unix.OpenHow{
+		Flags:   uint64(flags),
+		Resolve: unix.RESOLVE_IN_ROOT | unix.RESOLVE_NO_MAGICLINKS,
+	})
+	unix.Close(root)
+	if errors.Is(err, unix.ENOSYS) {
+		// openat2 needs Linux 5.6
+		fd, err = unix.Open(hostPath, flags, 0)
+	}
+	if err != nil {
+		return nil, err
+	}
+	f := os.NewFile(uintptr(fd), hostPath)
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if !fi.Mode().IsRegular() {
+		f.Close()
+		return nil, fmt.Errorf("%s is not a regular file", hostPath)
+	}
+	if fi.Size() > maxPerfMapSize {
+		f.Close()
+		return nil, fmt.Errorf("%s is larger than %d bytes", hostPath, maxPerfMapSize)
+	}
+	return f, nil
+}
+
+// getPerfMap returns the perf map of process pid, or nil if it has none. The
+// perf map is written by the JVM in the mount namespace of the process, and
+// named after the pid of the process in its pid namespace, e.g. with
+// -XX:+UnlockDiagnosticVMOptions -XX:+DumpPerfMapAtExit, jcmd <pid>
+// Compiler.perfmap, or a perf map agent.
+func (u *Usyms) getPerfMap(pid uint32) *perfMapSymbols {
+	procDir := filepath.Join(u.procfs, strconv.FormatUint(uint64(pid), 10))
+	status, err := os.Open(filepath.Join(procDir, "status"))
+	if err != nil {
+		return nil
+	}
+	defer status.Close()
+	nspid, err := nsPid(status)
+	if err != nil {
+		return nil
+	}
+
+	path := fmt.Sprintf("/tmp/perf-%d.map", nspid)
+	f, err := openPerfMap(procDir, path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	hostPath := f.Name()
+	fi, err := f.Stat()
+	if err != nil {
+		return nil
+	}
+
+	if u.perfMapCache != nil {
+		if val, ok := u.perfMapCache.Get(hostPath); ok && val.size == fi.Size() && val.modTime.Equal(fi.ModTime()) {
+			return &perfMapSymbols{path: path, table: val.table}
+		}
+	}
+	// the perf map may grow while it is read
+	table, err := parsePerfMap(io.LimitReader(f, maxPerfMapSize))
+	if err != nil {
+		return nil
+	}
+	if u.perfMapCache != nil {
+		u.perfMapCache.Add(hostPath, perfMapVal{size: fi.Size(), modTime: fi.ModTime(), table: table})
+	}
+	return &perfMapSymbols{path: path, table: table}
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package usyms
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func TestParsePerfMap(t *testing.T) {
+	table, err := parsePerfMap(strings.NewReader(`7f3b5c000200 40 Interpreter
+7f3b5c0a0000 1a0 Ljava/lang/String;::hashCode
+invalid line
+7f3b5c000100 20 StubRoutines (1)
+7f3b5c0a0000 80 Ljava/lang/String;::equals
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []usym{
+		{addr: 0x7f3b5c000100, size: 0x20, name: "StubRoutines (1)"},
+		{addr: 0x7f3b5c000200, size: 0x40, name: "Interpreter"},
+		{addr: 0x7f3b5c0a0000, size: 0x1a0, name: "Ljava/lang/String;::hashCode"},
+		{addr: 0x7f3b5c0a0000, size: 0x80, name: "Ljava/lang/String;::equals"},
+	}, table)
+
+	// the last compiled code at an address wins
+	sym, ok := lookupSym(table, 0x7f3b5c0a0010)
+	assert.True(t, ok)
+	assert.Equal(t, "Ljava/lang/String;::equals", sym.name)
+	_, ok = lookupSym(table, 0x7f3b5c000240)
+	assert.False(t, ok)
+}
+
+func TestNsPid(t *testing.T) {
+	pid, err := nsPid(strings.NewReader("Name:\tjava\nPid:\t4242\nNSpid:\t4242\t7\n"))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), pid)
+
+	_, err = nsPid(strings.NewReader("Name:\tjava\nPid:\t4242\n"))
+	assert.Error(t, err)
+}
+
+func TestGetFnOffsetsPerfMap(t *testing.T) {
+	procfs := t.TempDir()
+	procDir := filepath.Join(procfs, "4242")
+	require.NoError(t, os.MkdirAll(filepath.Join(procDir, "root", "tmp"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(procDir, "status"), []byte("Name:\tjava\nNSpid:\t4242\t7\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(procDir, "root", "tmp", "perf-7.map"),
+		[]byte("7f3b5c0a0000 1a0 Ljava/lang/String;::hashCode\n"), 0644))
+	maps := `7f3b5c000000-7f3b5c270000 rwxp 00000000 00:00 0
+7f3b6a000000-7f3b6b000000 r-xp 00000000 fd:01 1234 /usr/lib/jvm/java-17-openjdk/lib/server/libjvm.so
+`
+	require.NoError(t, os.WriteFile(filepath.Join(procDir, "maps"), []byte(maps), 0644))
+
+	u := NewUsyms(procfs)
+	fnOffsets, err := u.GetFnOffsets(4242, []uint64{0x7f3b5c0a0010})
+	require.NoError(t, err)
+	assert.Nil(t, fnOffsets[0])
+
+	u.perfMaps = true
+	fnOffsets, err = u.GetFnOffsets(4242, []uint64{0x7f3b5c0a0010, 0x7f3b5c0b0000})
+	require.NoError(t, err)
+	assert.Equal(t, &FnOffset{Module: "/tmp/perf-7.map", SymName: "Ljava/lang/String;::hashCode", Offset: 0x10}, fnOffsets[0])
+	assert.Nil(t, fnOffsets[1])
+
+	// perf maps that are not regular files, or symlinks, are not read
+	perfMap := filepath.Join(procDir, "root", "tmp", "perf-7.map")
+	require.NoError(t, os.Remove(perfMap))
+	require.NoError(t, unix.Mkfifo(perfMap, 0644))
+	fnOffsets, err = u.GetFnOffsets(4242, []uint64{0x7f3b5c0a0010})
+	require.NoError(t, err)
+	assert.Nil(t, fnOffsets[0])
+	require.NoError(t, os.Remove(perfMap))
+	require.NoError(t, os.WriteFile(filepath.Join(procDir, "root", "perf.map"),
+		[]byte("7f3b5c0a0000 1a0 Ljava/lang/String;::hashCode\n"), 0644))
+	require.NoError(t, os.Symlink("/perf.map", perfMap))
+	fnOffsets, err = u.GetFnOffsets(4242, []uint64{0x7f3b5c0a0010})
+	require.NoError(t, err)
+	assert.Nil(t, fnOffsets[0])
+
+	// not a JVM
+	require.NoError(t, os.WriteFile(filepath.Join(procDir, "maps"), []byte("7f3b5c000000-7f3b5c270000 rwxp 00000000 00:00 0\n"), 0644))
+	fnOffsets, err = u.GetFnOffsets(4242, []uint64{0x7f3b5c0a0010})
+	require.NoError(t, err)
+	assert.Nil(t, fnOffsets[0])
+}
@@ -0,0 +1,333 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package usyms resolves the addresses of user space stack traces to the
+// modules (executables and shared libraries) and the functions they belong
+// to.
+package usyms
+
+import (
+	"bufio"
+	"debug/elf"
+	"debug/gosym"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/option"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+var (
+	userSymbols    *Usyms
+	setUserSymbols sync.Once
+)
+
+func UserSymbols() *Usyms {
+	setUserSymbols.Do(func() {
+		userSymbols = NewUsyms(option.Config.ProcFS)
+		userSymbols.perfMaps = option.Config.EnableJVMPerfMaps
+	})
+	return userSymbols
+}
+
+// FnOffset is a function location in a module (function name + offset). When
+// the function is not known, SymName is empty and Offset is the offset into
+// the module.
+type FnOffset struct {
+	Module  string
+	SymName string
+	Offset  uint64
+}
+
+// ToString returns a string representation of FnOffset
+func (fo *FnOffset) ToString() string {
+	if fo.SymName == "" {
+		return fmt.Sprintf("%s+0x%x", fo.Module, fo.Offset)
+	}
+	return fmt.Sprintf("%s()+0x%x (%s)", fo.SymName, fo.Offset, fo.Module)
+}
+
+// mapping is an executable memory mapping of a file, from procfs/<pid>/maps
+type mapping struct {
+	start  uint64
+	end    uint64
+	offset uint64
+	// id identifies the mapped file (device and inode)
+	id   string
+	path string
+}
+
+type usym struct {
+	addr uint64
+	size uint64
+	name string
+}
+
+// moduleSymbols are the function symbols of a module
+type moduleSymbols struct {
+	progs []elf.ProgHeader
+	table []usym
+}
+
+// modSymsVal is used as a value in the module symbols cache.
+type modSymsVal struct {
+	syms *moduleSymbols
+	err  error
+}
+
+// perfMapVal is used as a value in the perf maps cache. Since the JVM appends
+// to the perf maps as it compiles code, the entries are only valid for a given
+// size and modification time of the file.
+type perfMapVal struct {
+	size    int64
+	modTime time.Time
+	table   []usym
+}
+
+// Usyms resolves user space addresses using the memory mappings of processes
+// and the symbol tables of the mapped files.
+type Usyms struct {
+	procfs   string
+	modCache *lru.Cache[string, modSymsVal]
+	// perfMaps enables the resolution of the JIT compiled code of JVM
+	// processes from their perf maps.
+	perfMaps     bool
+	perfMapCache *lru.Cache[string, perfMapVal]
+}
+
+// NewUsyms creates a new Usyms structure
+func NewUsyms(procfs string) *Usyms {
+	u := &Usyms{procfs: procfs}
+	mc, err := lru.New[string, modSymsVal](64)
+	if err == nil {
+		u.modCache = mc
+	} else {
+		logger.GetLogger().Infof("failed to initialize cache: %s", err)
+	}
+	pc, err := lru.New[string, perfMapVal](16)
+	if err == nil {
+		u.perfMapCache = pc
+	} else {
+		logger.GetLogger().Infof("failed to initialize cache: %s", err)
+	}
+	return u
+}
+
+// GetFnOffsets returns the FnOffset for the given addresses of process pid.
+// Addresses that cannot be resolved are nil in the result.
+func (u *Usyms) GetFnOffsets(pid uint32, addrs []uint64) ([]*FnOffset, error) {
+	mapsFname := filepath.Join(u.procfs, strconv.FormatUint(uint64(pid), 10), "maps")
+	file, err := os.Open(mapsFname)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	mappings, err := parseMaps(file)
+	if err != nil {
+		return nil, err
+	}
+
+	// files are opened through the root of the process, as they might be
+	// in another mount namespace.
+	root := filepath.Join(u.procfs, strconv.FormatUint(uint64(pid), 10), "root")
+	ret := make([]*FnOffset, len(addrs))
+	// the perf map is only read for the JIT compiled frames of JVMs
+	var perfMap *perfMapSymbols
+	perfMapRead := !u.perfMaps || !isJVM(mappings)
+	for i, addr := range addrs {
+		m := findMapping(mappings, addr)
+		if m == nil {
+			if !perfMapRead {
+				perfMap = u.getPerfMap(pid)
+				perfMapRead = true
+			}
+			if perfMap != nil {
+				if sym, ok := lookupSym(perfMap.table, addr); ok {
+					ret[i] = &FnOffset{Module: perfMap.path, SymName: sym.name, Offset: addr - sym.addr}
+				}
+			}
+			continue
+		}
+		fileOffset := addr - m.start + m.offset
+		ret[i] = &FnOffset{Module: m.path, Offset: fileOffset}
+
+		syms, err := u.getModuleSymbols(m.id, filepath.Join(root, m.path))
+		if err != nil {
+			continue
+		}
+		if name, off, ok := syms.fnOffset(fileOffset); ok {
+			ret[i].SymName = name
+			ret[i].Offset = off
+		}
+	}
+	return ret, nil
+}
+
+func (u *Usyms) getModuleSymbols(id, path string) (*moduleSymbols, error) {
+	// no cache
+	if u.modCache == nil {
+		return readModuleSymbols(path)
+	}
+
+	// cache hit
+	if ret, ok := u.modCache.Get(id); ok {
+		return ret.syms, ret.err
+	}
+
+	// cache miss
+	syms, err := readModuleSymbols(path)
+	u.modCache.Add(id, modSymsVal{syms: syms, err: err})
+	return syms, err
+}
+
+// parseMaps returns the executable file mappings from the content of a
+// procfs/<pid>/maps file.
+func parseMaps(r io.Reader) ([]mapping, error) {
+	var ret []mapping
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		// address perms offset dev inode pathname
+		fields := strings.Fields(s.Text())
+		if len(fields) < 6 || !strings.Contains(fields[1], "x") || !strings.HasPrefix(fields[5], "/") {
+			continue
+		}
+		start, end, found := strings.Cut(fields[0], "-")
+		if !found {
+			continue
+		}
+		var m mapping
+		var err error
+		if m.start, err = strconv.ParseUint(start, 16, 64); err != nil {
+			continue
+		}
+		if m.end, err = strconv.ParseUint(end, 16, 64); err != nil {
+			continue
+		}
+		if m.offset, err = strconv.ParseUint(fields[2], 16, 64); err != nil {
+			continue
+		}
+		m.id = fields[3] + ":" + fields[4]
+		m.path = strings.Join(fields[5:], " ")
+		ret = append(ret, m)
+	}
+	return ret, s.Err()
+}
+
+func findMapping(mappings []mapping, addr uint64) *mapping {
+	for i := range mappings {
+		if mappings[i].start <= addr && addr < mappings[i].end {
+			return &mappings[i]
+		}
+	}
+	return nil
+}
+
+// readModuleSymbols reads the function symbols of the ELF file at path
+func readModuleSymbols(path string) (*moduleSymbols, error) {
+	f, err := elf.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var ret moduleSymbols
+	for _, p := range f.Progs {
+		if p.Type == elf.PT_LOAD && p.Flags&elf.PF_X != 0 {
+			ret.progs = append(ret.progs, p.ProgHeader)
+		}
+	}
+
+	syms, _ := f.Symbols()
+	dynSyms, _ := f.DynamicSymbols()
+	for _, sym := range append(syms, dynSyms...) {
+		if elf.ST_TYPE(sym.Info) != elf.STT_FUNC || sym.Value == 0 {
+			continue
+		}
+		ret.table = append(ret.table, usym{addr: sym.Value, size: sym.Size, name: sym.Name})
+	}
+	if len(ret.table) == 0 {
+		// stripped Go binaries keep the symbols of their functions for
+		// the runtime
+		ret.table = readGoSymbols(f)
+	}
+	if len(ret.table) == 0 {
+		return nil, errors.New("no symbols found")
+	}
+	sort.Slice(ret.table, func(i1, i2 int) bool { return ret.table[i1].addr < ret.table[i2].addr })
+	return &ret, nil
+}
+
+// readGoSymbols reads the function symbols of a Go binary from its
+// .gopclntab section.
+func readGoSymbols(f *elf.File) []usym {
+	pclntab := f.Section(".gopclntab")
+	text := f.Section(".text")
+	if pclntab == nil || text == nil {
+		return nil
+	}
+	data, err := pclntab.Data()
+	if err != nil {
+		return nil
+	}
+	var symtab []byte
+	if sec := f.Section(".gosymtab"); sec != nil {
+		symtab, _ = sec.Data()
+	}
+	table, err := gosym.NewTable(symtab, gosym.NewLineTable(data, text.Addr))
+	if err != nil {
+		return nil
+	}
+	ret := make([]usym, 0, len(table.Funcs))
+	for _, fn := range table.Funcs {
+		ret = append(ret, usym{addr: fn.Entry, size: fn.End - fn.Entry, name: fn.Name})
+	}
+	return ret
+}
+
+// fnOffset returns the function containing the given file offset, and the
+// offset into that function.
+func (m *moduleSymbols) fnOffset(fileOffset uint64) (string, uint64, bool) {
+	// symbol values are virtual addresses, translate the file offset
+	var addr uint64
+	found := false
+	for _, p := range m.progs {
+		if p.Off <= fileOffset && fileOffset < p.Off+p.Filesz {
+			addr = fileOffset - p.Off + p.Vaddr
+			found = true
+			break
+		}
+	}
+	if !found {
+		return "", 0, false
+	}
+
+	sym, ok := lookupSym(m.table, addr)
+	if !ok {
+		return "", 0, false
+	}
+	return sym.name, addr - sym.addr, true
+}
+
+// lookupSym returns the symbol of the sorted table containing addr
+func lookupSym(table []usym, addr uint64) (usym, bool) {
+	// last symbol starting at or before addr
+	i := sort.Search(len(table), func(i int) bool { return table[i].addr > addr }) - 1
+	if i < 0 {
+		return usym{}, false
+	}
+	sym := table[i]
+	if sym.size != 0 && addr >= sym.addr+sym.size {
+		return usym{}, false
+	}
+	return sym, true
+}
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package usyms
+
+import (
+	"bytes"
+	"debug/elf"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMaps(t *testing.T) {
+	maps := `55d3c5a00000-55d3c5a28000 r--p 00000000 fd:01 1835090                    /usr/bin/bash
+55d3c5a28000-55d3c5ad9000 r-xp 00028000 fd:01 1835090                    /usr/bin/bash
+7f0e1c228000-7f0e1c3bd000 r-xp 00028000 fd:01 1837571                    /usr/lib/x86_64-linux-gnu/libc.so.6
+7ffd6b1f3000-7ffd6b1f5000 r-xp 00000000 00:00 0                          [vdso]
+7ffd6b1f6000-7ffd6b1f7000 r-xp 00000000 00:00 0
+`
+	mappings, err := parseMaps(strings.NewReader(maps))
+	require.NoError(t, err)
+	require.Len(t, mappings, 2)
+	assert.Equal(t, mapping{start: 0x55d3c5a28000, end: 0x55d3c5ad9000, offset: 0x28000, id: "fd:01:1835090", path: "/usr/bin/bash"}, mappings[0])
+	assert.Equal(t, "/usr/lib/x86_64-linux-gnu/libc.so.6", mappings[1].path)
+
+	assert.Equal(t, &mappings[1], findMapping(mappings, 0x7f0e1c228010))
+	assert.Nil(t, findMapping(mappings, 0x7ffd6b1f3010))
+}
+
+func TestFnOffset(t *testing.T) {
+	syms := &moduleSymbols{
+		progs: []elf.ProgHeader{{Off: 0x1000, Vaddr: 0x401000, Filesz: 0x1000}},
+		table: []usym{
+			{addr: 0x401000, size: 0x100, name: "fn1"},
+			{addr: 0x401200, size: 0x100, name: "fn2"},
+		},
+	}
+
+	name, off, ok := syms.fnOffset(0x1010)
+	assert.True(t, ok)
+	assert.Equal(t, "fn1", name)
+	assert.Equal(t, uint64(0x10), off)
+
+	name, off, ok = syms.fnOffset(0x12ff)
+	assert.True(t, ok)
+	assert.Equal(t, "fn2", name)
+	assert.Equal(t, uint64(0xff), off)
+
+	// between fn1 and fn2
+	_, _, ok = syms.fnOffset(0x1100)
+	assert.False(t, ok)
+	// outside of the executable segment
+	_, _, ok = syms.fnOffset(0x2010)
+	assert.False(t, ok)
+}
+
+func TestGetFnOffsets(t *testing.T) {
+	// use the libc of the test, mapped at a fake address of a fake process
+	self, err := os.ReadFile("/proc/self/maps")
+	require.NoError(t, err)
+	mappings, err := parseMaps(bytes.NewReader(self))
+	require.NoError(t, err)
+	libc := ""
+	for _, m := range mappings {
+		if strings.Contains(filepath.Base(m.path), "libc.so") {
+			libc = m.path
+		}
+	}
+	if libc == "" {
+		t.Skip("test requires a dynamically linked libc")
+	}
+
+	f, err := elf.Open(libc)
+	require.NoError(t, err)
+	defer f.Close()
+	var prog *elf.Prog
+	for _, p := range f.Progs {
+		if p.Type == elf.PT_LOAD && p.Flags&elf.PF_X != 0 {
+			prog = p
+		}
+	}
+	require.NotNil(t, prog)
+	dynSyms, err := f.DynamicSymbols()
+	require.NoError(t, err)
+	var malloc elf.Symbol
+	for _, sym := range dynSyms {
+		if sym.Name == "malloc" {
+			malloc = sym
+		}
+	}
+	require.NotZero(t, malloc.Value)
+
+	procfs := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(procfs, "1"), 0755))
+	require.NoError(t, os.Symlink("/", filepath.Join(procfs, "1", "root")))
+	base := uint64(0x7f0000000000)
+	maps := fmt.Sprintf("%x-%x r-xp %08x fd:01 1234 %s\n", base, base+prog.Filesz, prog.Off, libc)
+	require.NoError(t, os.WriteFile(filepath.Join(procfs, "1", "maps"), []byte(maps), 0644))
+
+	addr := base + malloc.Value - prog.Vaddr + 0x10
+	u := NewUsyms(procfs)
+	fnOffsets, err := u.GetFnOffsets(1, []uint64{addr, 0x1000})
+	require.NoError(t, err)
+	require.Len(t, fnOffsets, 2)
+	require.NotNil(t, fnOffsets[0])
+	assert.Equal(t, libc, fnOffsets[0].Module)
+	// malloc might have aliases
+	assert.NotEmpty(t, fnOffsets[0].SymName)
+	assert.Equal(t, uint64(0x10), fnOffsets[0].Offset)
+	assert.Nil(t, fnOffsets[1])
+
+	_, err = u.GetFnOffsets(2, []uint64{addr})
+	assert.Error(t, err)
+}
+
+func TestReadGoSymbols(t *testing.T) {
+	// the test binary is a Go binary
+	self, err := os.Executable()
+	require.NoError(t, err)
+	f, err := elf.Open(self)
+	require.NoError(t, err)
+	defer f.Close()
+
+	table := readGoSymbols(f)
+	require.NotEmpty(t, table)
+	found := false
+	for _, sym := range table {
+		if sym.name == "github.com/cilium/tetragon/pkg/usyms.TestReadGoSymbols" {
+			found = true
+			assert.NotZero(t, sym.size)
+		}
+	}
+	assert.True(t, found)
+}
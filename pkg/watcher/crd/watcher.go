@@ -56,14 +56,20 @@ func addTracingPolicy(ctx context.Context, log logrus.FieldLogger, s *sensors.Ma
 			"name": tp.TpName(),
 			"info": tp.TpInfo(),
 		}).Info("adding tracing policy")
-		err = s.AddTracingPolicy(ctx, tp)
+		tp = tp.DeepCopy()
+		if err = tracingpolicy.ResolveValues(&tp.Spec); err == nil {
+			err = s.AddTracingPolicy(ctx, tp)
+		}
 	case *v1alpha1.TracingPolicyNamespaced:
 		log.WithFields(logrus.Fields{
 			"name":      tp.TpName(),
 			"info":      tp.TpInfo(),
 			"namespace": tp.TpNamespace(),
 		}).Info("adding namespaced tracing policy")
-		err = s.AddTracingPolicy(ctx, tp)
+		tp = tp.DeepCopy()
+		if err = tracingpolicy.ResolveValues(&tp.Spec); err == nil {
+			err = s.AddTracingPolicy(ctx, tp)
+		}
 	default:
 		log.WithFields(logrus.Fields{
 			"obj":      obj,
@@ -111,6 +117,12 @@ func updateTracingPolicy(ctx context.Context, log logrus.FieldLogger, s *sensors
 	oldObj interface{}, newObj interface{}) {
 
 	update := func(oldTp, newTp tracingpolicy.TracingPolicy) {
+		if err := tracingpolicy.ResolveValues(newTp.TpSpec()); err != nil {
+			log.WithError(err).WithField(
+				"new-name", newTp.TpName(),
+			).Warnf("updateTracingPolicy: failed to resolve values of new policy")
+			return
+		}
 		if err := s.DeleteTracingPolicy(ctx, oldTp.TpName()); err != nil {
 			log.WithError(err).WithField(
 				"old-name", oldTp.TpName(),
@@ -142,7 +154,7 @@ func updateTracingPolicy(ctx context.Context, log logrus.FieldLogger, s *sensors
 			"old": oldTp.TpName(),
 			"new": newTp.TpName(),
 		}).Info("updating tracing policy")
-		update(oldTp, newTp)
+		update(oldTp, newTp.DeepCopy())
 
 	case *v1alpha1.TracingPolicyNamespaced:
 		newTp, ok := newObj.(*v1alpha1.TracingPolicyNamespaced)
@@ -160,7 +172,7 @@ func updateTracingPolicy(ctx context.Context, log logrus.FieldLogger, s *sensors
 			"old": oldTp.TpName(),
 			"new": newTp.TpName(),
 		}).Info("updating namespaced tracing policy")
-		update(oldTp, newTp)
+		update(oldTp, newTp.DeepCopy())
 	}
 
 	log.WithFields(logrus.Fields{